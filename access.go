@@ -0,0 +1,262 @@
+package iso9001
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ErrPermissionDenied is returned by AccessPolicy.Authorize when none of an identity's
+// roles grants the requested permission
+var ErrPermissionDenied = errors.New("permission denied")
+
+// Permission is an operation on the QMS that access control can grant or withhold
+type Permission string
+
+const (
+	PermissionView            Permission = "qms:view"          // read and validate QMS data
+	PermissionEdit            Permission = "qms:edit"          // create and update organizations, processes, risks and objectives
+	PermissionManageDocuments Permission = "document:manage"   // create and revise documents
+	PermissionApproveDocument Permission = "document:approve"  // approve documents for release
+	PermissionManageAudits    Permission = "audit:manage"      // plan audits and record findings
+	PermissionCloseFinding    Permission = "finding:close"     // verify and close audit findings
+	PermissionAdminister      Permission = "access:administer" // assign roles to identities
+)
+
+// QMSRole is a set of permissions assigned to identities
+type QMSRole string
+
+const (
+	RoleQualityManager QMSRole = "quality_manager"
+	RoleAuditor        QMSRole = "auditor"
+	RoleViewer         QMSRole = "viewer"
+)
+
+// DefaultRolePermissions lists the permissions granted by each built-in role. Quality
+// managers hold every permission; auditors plan audits and record and close findings.
+var DefaultRolePermissions = map[QMSRole][]Permission{
+	RoleQualityManager: {PermissionView, PermissionEdit, PermissionManageDocuments, PermissionApproveDocument,
+		PermissionManageAudits, PermissionCloseFinding, PermissionAdminister},
+	RoleAuditor: {PermissionView, PermissionManageAudits, PermissionCloseFinding},
+	RoleViewer:  {PermissionView},
+}
+
+// AccessPolicy assigns QMS roles to identities and decides which operations they may
+// perform. Roles not in the policy's own table fall back to DefaultRolePermissions.
+type AccessPolicy struct {
+	Roles       map[QMSRole][]Permission `json:"roles,omitempty" yaml:"roles,omitempty"`
+	Assignments map[string][]QMSRole     `json:"assignments" yaml:"assignments"`
+	// Tenants lists the tenants each identity may access when multi-tenancy is enabled;
+	// AllTenants grants access to every tenant
+	Tenants map[string][]string `json:"tenants,omitempty" yaml:"tenants,omitempty"`
+}
+
+// AllTenants in a tenant assignment grants access to every tenant
+const AllTenants = "*"
+
+// NewAccessPolicy creates an empty policy using the default roles
+func NewAccessPolicy() *AccessPolicy {
+	return &AccessPolicy{
+		Roles:       make(map[QMSRole][]Permission),
+		Assignments: make(map[string][]QMSRole),
+		Tenants:     make(map[string][]string),
+	}
+}
+
+// LoadAccessPolicyJSON parses a policy of the form
+// {"roles": {...}, "assignments": {"jane": ["quality_manager"]}, "tenants": {"jane": ["ACME"]}}
+func LoadAccessPolicyJSON(data []byte) (*AccessPolicy, error) {
+	policy := NewAccessPolicy()
+	if err := json.Unmarshal(data, policy); err != nil {
+		return nil, fmt.Errorf("failed to parse access policy: %w", err)
+	}
+	if policy.Roles == nil {
+		policy.Roles = make(map[QMSRole][]Permission)
+	}
+	if policy.Assignments == nil {
+		policy.Assignments = make(map[string][]QMSRole)
+	}
+	if policy.Tenants == nil {
+		policy.Tenants = make(map[string][]string)
+	}
+	for identity, roles := range policy.Assignments {
+		for _, role := range roles {
+			if _, ok := policy.rolePermissions(role); !ok {
+				return nil, fmt.Errorf("access policy assigns unknown role %q to %s", role, identity)
+			}
+		}
+	}
+	return policy, nil
+}
+
+// Assign grants roles to an identity, keeping roles it already holds
+func (p *AccessPolicy) Assign(identity string, roles ...QMSRole) {
+	for _, role := range roles {
+		if !p.HasRole(identity, role) {
+			p.Assignments[identity] = append(p.Assignments[identity], role)
+		}
+	}
+}
+
+// Revoke removes a role from an identity
+func (p *AccessPolicy) Revoke(identity string, role QMSRole) {
+	held := p.Assignments[identity]
+	for i, r := range held {
+		if r == role {
+			p.Assignments[identity] = append(held[:i], held[i+1:]...)
+			return
+		}
+	}
+}
+
+// HasRole reports whether an identity is assigned the role
+func (p *AccessPolicy) HasRole(identity string, role QMSRole) bool {
+	for _, r := range p.Assignments[identity] {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// Permissions returns the sorted permissions an identity holds through its roles
+func (p *AccessPolicy) Permissions(identity string) []Permission {
+	granted := make(map[Permission]bool)
+	for _, role := range p.Assignments[identity] {
+		permissions, _ := p.rolePermissions(role)
+		for _, permission := range permissions {
+			granted[permission] = true
+		}
+	}
+	list := make([]Permission, 0, len(granted))
+	for permission := range granted {
+		list = append(list, permission)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i] < list[j] })
+	return list
+}
+
+// Can reports whether an identity holds a permission
+func (p *AccessPolicy) Can(identity string, permission Permission) bool {
+	for _, role := range p.Assignments[identity] {
+		permissions, _ := p.rolePermissions(role)
+		for _, granted := range permissions {
+			if granted == permission {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Authorize returns an error wrapping ErrPermissionDenied unless the identity holds
+// every listed permission
+func (p *AccessPolicy) Authorize(identity string, permissions ...Permission) error {
+	if identity == "" {
+		return fmt.Errorf("%w: no identity supplied", ErrPermissionDenied)
+	}
+	var missing []string
+	for _, permission := range permissions {
+		if !p.Can(identity, permission) {
+			missing = append(missing, string(permission))
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: %s lacks %s", ErrPermissionDenied, identity, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// AssignTenant lets an identity access a tenant's entities
+func (p *AccessPolicy) AssignTenant(identity, tenantID string) {
+	if !p.CanAccessTenant(identity, tenantID) {
+		p.Tenants[identity] = append(p.Tenants[identity], tenantID)
+	}
+}
+
+// CanAccessTenant reports whether an identity is assigned the tenant or all tenants
+func (p *AccessPolicy) CanAccessTenant(identity, tenantID string) bool {
+	for _, assigned := range p.Tenants[identity] {
+		if assigned == tenantID || assigned == AllTenants {
+			return true
+		}
+	}
+	return false
+}
+
+// AuthorizeTenant returns an error wrapping ErrPermissionDenied unless the identity may
+// access the tenant
+func (p *AccessPolicy) AuthorizeTenant(identity, tenantID string) error {
+	if identity == "" {
+		return fmt.Errorf("%w: no identity supplied", ErrPermissionDenied)
+	}
+	if !p.CanAccessTenant(identity, tenantID) {
+		return fmt.Errorf("%w: %s has no access to tenant %s", ErrPermissionDenied, identity, tenantID)
+	}
+	return nil
+}
+
+// rolePermissions looks a role up in the policy, then among the default roles
+func (p *AccessPolicy) rolePermissions(role QMSRole) ([]Permission, bool) {
+	if permissions, ok := p.Roles[role]; ok {
+		return permissions, true
+	}
+	permissions, ok := DefaultRolePermissions[role]
+	return permissions, ok
+}
+
+// AllowsRead reports whether the read list admits an identity holding the given roles.
+// Only documents classified restricted are limited to their read list; entries name
+// an identity or a QMS role and are matched case-insensitively.
+func (a DocumentAccess) AllowsRead(identity string, roles ...QMSRole) bool {
+	if !strings.EqualFold(a.Classification, ClassificationRestricted) {
+		return true
+	}
+	for _, entry := range a.ReadAccess {
+		if identity != "" && strings.EqualFold(entry, identity) {
+			return true
+		}
+		for _, role := range roles {
+			if strings.EqualFold(entry, string(role)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// CanReadDocument reports whether an identity may read a document in full, by identity
+// or through the roles the policy assigns it. A nil policy assigns no roles.
+func (p *AccessPolicy) CanReadDocument(identity string, doc *DocumentedInformation) bool {
+	var roles []QMSRole
+	if p != nil {
+		roles = p.Assignments[identity]
+	}
+	return doc.Access.AllowsRead(identity, roles...)
+}
+
+// RedactedTitle replaces the title of a redacted document
+const RedactedTitle = "[redacted]"
+
+// RedactDocument returns a copy of a document for readers not on its read list. It
+// keeps what identifies the document and its state: ID, type, category,
+// classification, status, current version and dates. Title, content, metadata,
+// approvals and history are withheld.
+func RedactDocument(doc *DocumentedInformation) *DocumentedInformation {
+	redacted := &DocumentedInformation{
+		ID:       doc.ID,
+		Title:    RedactedTitle,
+		Type:     doc.Type,
+		Category: doc.Category,
+		Access:   DocumentAccess{Classification: doc.Access.Classification},
+		Status:   doc.Status,
+		Created:  doc.Created,
+		Modified: doc.Modified,
+	}
+	if version := doc.currentVersion(); version != "" {
+		redacted.Versions = []DocumentVersion{{VersionNumber: version}}
+	}
+	return redacted
+}
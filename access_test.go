@@ -0,0 +1,107 @@
+package iso9001
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAccessPolicyAuthorize(t *testing.T) {
+	policy, err := LoadAccessPolicyJSON([]byte(`{
+		"roles": {"document_controller": ["qms:view", "document:manage"]},
+		"assignments": {
+			"jane": ["quality_manager"],
+			"sam": ["auditor"],
+			"val": ["viewer", "document_controller"]
+		}
+	}`))
+	if err != nil {
+		t.Fatalf("Failed to load policy: %v", err)
+	}
+
+	if err := policy.Authorize("jane", PermissionApproveDocument, PermissionCloseFinding); err != nil {
+		t.Errorf("Quality manager should be authorized: %v", err)
+	}
+	if err := policy.Authorize("sam", PermissionCloseFinding); err != nil {
+		t.Errorf("Auditor should close findings: %v", err)
+	}
+	if err := policy.Authorize("sam", PermissionApproveDocument); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("Auditor should not approve documents, got %v", err)
+	}
+	if err := policy.Authorize("val", PermissionManageDocuments); err != nil {
+		t.Errorf("Custom role should grant document:manage: %v", err)
+	}
+	if err := policy.Authorize("", PermissionView); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("Anonymous identity should be denied, got %v", err)
+	}
+	if err := policy.Authorize("mallory", PermissionView); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("Unassigned identity should be denied, got %v", err)
+	}
+
+	policy.Assign("sam", RoleQualityManager)
+	if !policy.Can("sam", PermissionApproveDocument) {
+		t.Error("Expected assigned role to grant permission")
+	}
+	policy.Revoke("sam", RoleQualityManager)
+	if policy.Can("sam", PermissionApproveDocument) {
+		t.Error("Expected revoked role to withdraw permission")
+	}
+
+	if got := policy.Permissions("val"); len(got) != 2 || got[0] != PermissionManageDocuments || got[1] != PermissionView {
+		t.Errorf("Unexpected permissions %v", got)
+	}
+
+	if _, err := LoadAccessPolicyJSON([]byte(`{"assignments": {"x": ["superuser"]}}`)); err == nil {
+		t.Error("Expected error for unknown role")
+	}
+
+	policy.AssignTenant("sam", "ACME")
+	policy.AssignTenant("jane", AllTenants)
+	if err := policy.AuthorizeTenant("sam", "ACME"); err != nil {
+		t.Errorf("Expected access to assigned tenant: %v", err)
+	}
+	if err := policy.AuthorizeTenant("sam", "GLOBEX"); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("Expected tenant isolation, got %v", err)
+	}
+	if !policy.CanAccessTenant("jane", "GLOBEX") || policy.CanAccessTenant("val", "ACME") {
+		t.Error("Unexpected tenant access result")
+	}
+}
+
+func TestCanReadRestrictedDocument(t *testing.T) {
+	policy := NewAccessPolicy()
+	policy.Assign("alice", RoleAuditor)
+	policy.Assign("bob", RoleViewer)
+
+	doc := &DocumentedInformation{
+		ID:       "DOC-007",
+		Title:    "Supplier pricing",
+		Content:  "Secret terms",
+		Access:   DocumentAccess{Classification: ClassificationRestricted, ReadAccess: []string{"carol", "auditor"}},
+		Status:   DocumentStatusApproved,
+		Versions: []DocumentVersion{{VersionNumber: "1.0"}},
+	}
+	if !policy.CanReadDocument("carol", doc) || !policy.CanReadDocument("alice", doc) {
+		t.Error("Expected identities on the read list, directly or by role, to read the document")
+	}
+	if policy.CanReadDocument("bob", doc) {
+		t.Error("Expected bob to be denied")
+	}
+	var none *AccessPolicy
+	if none.CanReadDocument("alice", doc) {
+		t.Error("Expected no roles without a policy")
+	}
+	if !policy.CanReadDocument("bob", &DocumentedInformation{Access: DocumentAccess{Classification: ClassificationConfidential}}) {
+		t.Error("Expected only restricted documents to be limited to the read list")
+	}
+
+	redacted := RedactDocument(doc)
+	if redacted.Content != "" || redacted.Title != RedactedTitle || len(redacted.Access.ReadAccess) != 0 {
+		t.Errorf("Expected content, title and read list to be withheld, got %+v", redacted)
+	}
+	if redacted.ID != doc.ID || redacted.Status != doc.Status || redacted.currentVersion() != "1.0" {
+		t.Errorf("Expected identifying metadata to be kept, got %+v", redacted)
+	}
+	if doc.Content != "Secret terms" {
+		t.Error("RedactDocument must not modify the document")
+	}
+}
@@ -0,0 +1,174 @@
+package iso9001
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ActivityEntry attributes one write operation to the actor who performed it
+type ActivityEntry struct {
+	Sequence   uint64          `json:"sequence" yaml:"sequence"`
+	Timestamp  time.Time       `json:"timestamp" yaml:"timestamp"`
+	Actor      string          `json:"actor" yaml:"actor"`
+	Action     string          `json:"action" yaml:"action"` // tool or operation that made the change
+	EntityType string          `json:"entity_type" yaml:"entity_type"`
+	EntityID   string          `json:"entity_id" yaml:"entity_id"`
+	Operation  ChangeOperation `json:"operation" yaml:"operation"`
+	Before     string          `json:"before,omitempty" yaml:"before,omitempty"` // summary of the entity before the change
+	After      string          `json:"after,omitempty" yaml:"after,omitempty"`   // summary of the entity after the change
+}
+
+// ActivityQuery selects entries from an activity log; empty fields match everything
+type ActivityQuery struct {
+	Actor      string    `json:"actor,omitempty" yaml:"actor,omitempty"`
+	Action     string    `json:"action,omitempty" yaml:"action,omitempty"`
+	EntityType string    `json:"entity_type,omitempty" yaml:"entity_type,omitempty"`
+	EntityID   string    `json:"entity_id,omitempty" yaml:"entity_id,omitempty"`
+	Since      time.Time `json:"since,omitempty" yaml:"since,omitempty"`
+	Until      time.Time `json:"until,omitempty" yaml:"until,omitempty"`
+	Limit      int       `json:"limit,omitempty" yaml:"limit,omitempty"` // most recent entries to return; zero returns all
+}
+
+// ActivityLog is an append-only record of who changed what and when, providing the
+// traceability expected of electronic quality records. Unlike a ChangeLog it keeps
+// every entry, not only the latest state of each entity.
+type ActivityLog struct {
+	mu       sync.RWMutex
+	entries  []ActivityEntry
+	sequence uint64
+}
+
+// NewActivityLog creates an empty activity log
+func NewActivityLog() *ActivityLog {
+	return &ActivityLog{}
+}
+
+// Record appends an entry, assigning its sequence number and, when unset, its timestamp
+func (l *ActivityLog) Record(entry ActivityEntry) ActivityEntry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sequence++
+	entry.Sequence = l.sequence
+	if entry.Timestamp.IsZero() {
+		entry.Timestamp = time.Now()
+	}
+	l.entries = append(l.entries, entry)
+	return entry
+}
+
+// Len returns the number of entries recorded
+func (l *ActivityLog) Len() int {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return len(l.entries)
+}
+
+// Query returns the entries matching the query in the order they were recorded
+func (l *ActivityLog) Query(query ActivityQuery) []ActivityEntry {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	matches := []ActivityEntry{}
+	for _, entry := range l.entries {
+		if query.Actor != "" && entry.Actor != query.Actor {
+			continue
+		}
+		if query.Action != "" && entry.Action != query.Action {
+			continue
+		}
+		if query.EntityType != "" && entry.EntityType != query.EntityType {
+			continue
+		}
+		if query.EntityID != "" && entry.EntityID != query.EntityID {
+			continue
+		}
+		if !query.Since.IsZero() && entry.Timestamp.Before(query.Since) {
+			continue
+		}
+		if !query.Until.IsZero() && entry.Timestamp.After(query.Until) {
+			continue
+		}
+		matches = append(matches, entry)
+	}
+	if query.Limit > 0 && len(matches) > query.Limit {
+		matches = matches[len(matches)-query.Limit:]
+	}
+	return matches
+}
+
+// maxSummaryLength bounds summaries of entities without a dedicated summary
+const maxSummaryLength = 200
+
+// SummarizeEntity describes an entity in one line for the activity log
+func SummarizeEntity(entity interface{}) string {
+	switch e := entity.(type) {
+	case nil:
+		return ""
+	case *Organization:
+		return summarizeOrganization(e)
+	case *Process:
+		return summarizeProcess(*e)
+	case Process:
+		return summarizeProcess(e)
+	case *Risk:
+		return summarizeRisk(*e)
+	case Risk:
+		return summarizeRisk(e)
+	case *QualityObjective:
+		return fmt.Sprintf("objective %s (%s, %d targets, responsible %s)", e.Name, e.Status, len(e.Targets), e.Responsible)
+	case *Audit:
+		return fmt.Sprintf("audit %s (%s, %s, %d findings)", e.Title, e.Type, e.Status, len(e.Findings))
+	case AuditFinding:
+		return summarizeFinding(e)
+	case *AuditFinding:
+		return summarizeFinding(*e)
+	case *DocumentedInformation:
+		return fmt.Sprintf("document %s (%s, %s, version %s)", e.Title, e.Type, e.Status, e.currentVersion())
+	case Approval:
+		return fmt.Sprintf("approval by %s as %s", e.ApproverID, e.Role)
+	}
+
+	data, err := json.Marshal(entity)
+	if err != nil {
+		return fmt.Sprintf("%T", entity)
+	}
+	if len(data) > maxSummaryLength {
+		return string(data[:maxSummaryLength]) + "..."
+	}
+	return string(data)
+}
+
+func summarizeOrganization(org *Organization) string {
+	var external, internal, parties, processes, risks, objectives int
+	if org.Context != nil {
+		external = len(org.Context.ExternalIssues)
+		internal = len(org.Context.InternalIssues)
+		parties = len(org.Context.InterestedParties)
+	}
+	if org.QMS != nil {
+		processes = len(org.QMS.Processes)
+		risks = len(org.QMS.Risks)
+		objectives = len(org.QMS.Objectives)
+	}
+	policy := "no quality policy"
+	if org.Leadership != nil && org.Leadership.QualityPolicy != nil {
+		policy = "quality policy " + org.Leadership.QualityPolicy.ID
+	}
+	return fmt.Sprintf("organization %s: %d external and %d internal issues, %d interested parties, %d processes, %d risks, %d objectives, %s",
+		org.Name, external, internal, parties, processes, risks, objectives, policy)
+}
+
+func summarizeProcess(process Process) string {
+	return fmt.Sprintf("process %s (%s, %d inputs, %d outputs)", process.Name, process.Status, len(process.Inputs), len(process.Outputs))
+}
+
+func summarizeRisk(risk Risk) string {
+	return fmt.Sprintf("risk %s (likelihood %s, impact %s, %s, %d mitigation actions)", risk.Description, risk.Likelihood, risk.Impact, risk.Status, len(risk.Mitigation))
+}
+
+func summarizeFinding(finding AuditFinding) string {
+	return fmt.Sprintf("finding %s against clause %s (%s, %s)", finding.Description, finding.Clause, finding.Severity, finding.Status)
+}
@@ -0,0 +1,45 @@
+package iso9001
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestActivityLogQuery(t *testing.T) {
+	log := NewActivityLog()
+	start := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+
+	log.Record(ActivityEntry{Timestamp: start, Actor: "jane", Action: "qms_create_document", EntityType: EntityTypeDocument, EntityID: "DOC-1", Operation: ChangeOperationCreated})
+	log.Record(ActivityEntry{Timestamp: start.Add(time.Hour), Actor: "sam", Action: "qms_add_audit_finding", EntityType: EntityTypeFinding, EntityID: "F-1", Operation: ChangeOperationCreated})
+	last := log.Record(ActivityEntry{Timestamp: start.Add(2 * time.Hour), Actor: "jane", Action: "qms_approve_document", EntityType: EntityTypeDocument, EntityID: "DOC-1", Operation: ChangeOperationUpdated})
+
+	if last.Sequence != 3 || log.Len() != 3 {
+		t.Fatalf("Expected 3 entries, got sequence %d and length %d", last.Sequence, log.Len())
+	}
+	if got := log.Query(ActivityQuery{Actor: "jane"}); len(got) != 2 {
+		t.Errorf("Expected 2 entries by jane, got %d", len(got))
+	}
+	if got := log.Query(ActivityQuery{EntityID: "DOC-1", Limit: 1}); len(got) != 1 || got[0].Action != "qms_approve_document" {
+		t.Errorf("Expected latest DOC-1 entry, got %+v", got)
+	}
+	if got := log.Query(ActivityQuery{Since: start.Add(30 * time.Minute), Until: start.Add(90 * time.Minute)}); len(got) != 1 || got[0].Actor != "sam" {
+		t.Errorf("Expected sam's entry in time window, got %+v", got)
+	}
+	if got := log.Query(ActivityQuery{Action: "qms_delete_everything"}); got == nil || len(got) != 0 {
+		t.Errorf("Expected empty result, got %v", got)
+	}
+}
+
+func TestSummarizeEntity(t *testing.T) {
+	org := CreateExampleOrganization()
+	if summary := SummarizeEntity(org); !strings.HasPrefix(summary, "organization "+org.Name+":") {
+		t.Errorf("Unexpected organization summary %q", summary)
+	}
+	if summary := SummarizeEntity(AuditFinding{Description: "Calibration overdue", Clause: "7.1.5", Severity: SeverityMajor, Status: FindingStatusOpen}); !strings.Contains(summary, "clause 7.1.5") {
+		t.Errorf("Unexpected finding summary %q", summary)
+	}
+	if summary := SummarizeEntity(map[string]string{"content": strings.Repeat("x", 500)}); len(summary) != maxSummaryLength+3 {
+		t.Errorf("Expected truncated summary, got %d characters", len(summary))
+	}
+}
@@ -0,0 +1,373 @@
+package iso9001
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Errors returned by APIKeyManager
+var (
+	ErrAPIKeyNotFound     = errors.New("API key not found")
+	ErrInvalidAPIKey      = errors.New("invalid API key")
+	ErrAPIKeyRevoked      = errors.New("API key revoked")
+	ErrAPIKeyExpired      = errors.New("API key expired")
+	ErrInsufficientScope  = errors.New("API key scope insufficient")
+	ErrUnknownAPIKeyScope = errors.New("unknown API key scope")
+)
+
+// APIKeySecretPrefix starts every API key secret, so leaked keys are easy to recognize
+const APIKeySecretPrefix = "qms_"
+
+// APIKeyScope limits what a key may do. Scopes are ordered: write includes read and
+// admin includes write.
+type APIKeyScope string
+
+const (
+	APIKeyScopeRead  APIKeyScope = "read"
+	APIKeyScopeWrite APIKeyScope = "write"
+	APIKeyScopeAdmin APIKeyScope = "admin"
+)
+
+// ParseAPIKeyScope validates a scope name
+func ParseAPIKeyScope(scope string) (APIKeyScope, error) {
+	switch s := APIKeyScope(strings.ToLower(scope)); s {
+	case APIKeyScopeRead, APIKeyScopeWrite, APIKeyScopeAdmin:
+		return s, nil
+	}
+	return "", fmt.Errorf("%w: %q (expected read, write or admin)", ErrUnknownAPIKeyScope, scope)
+}
+
+// Allows reports whether a key of this scope may perform operations requiring required
+func (s APIKeyScope) Allows(required APIKeyScope) bool {
+	return scopeRank(s) >= scopeRank(required) && scopeRank(required) > 0
+}
+
+func scopeRank(scope APIKeyScope) int {
+	switch scope {
+	case APIKeyScopeRead:
+		return 1
+	case APIKeyScopeWrite:
+		return 2
+	case APIKeyScopeAdmin:
+		return 3
+	}
+	return 0
+}
+
+// APIKey describes an API key. Only a hash of the secret is kept; the secret itself is
+// shown once, when the key is created or rotated.
+type APIKey struct {
+	ID         string      `json:"id" yaml:"id"`
+	Name       string      `json:"name" yaml:"name"`
+	Scope      APIKeyScope `json:"scope" yaml:"scope"`
+	Hint       string      `json:"hint" yaml:"hint"` // first characters of the secret, for recognizing it
+	SecretHash string      `json:"secret_hash" yaml:"secret_hash"`
+	Created    time.Time   `json:"created" yaml:"created"`
+	Rotated    *time.Time  `json:"rotated,omitempty" yaml:"rotated,omitempty"`
+	Expires    *time.Time  `json:"expires,omitempty" yaml:"expires,omitempty"`
+	Revoked    *time.Time  `json:"revoked,omitempty" yaml:"revoked,omitempty"`
+	LastUsed   *time.Time  `json:"last_used,omitempty" yaml:"last_used,omitempty"`
+	RateLimit  *RateLimit  `json:"rate_limit,omitempty" yaml:"rate_limit,omitempty"` // overrides the server's default limit
+}
+
+// Active reports whether the key is neither revoked nor expired at the given time
+func (k *APIKey) Active(at time.Time) bool {
+	return k.Revoked == nil && (k.Expires == nil || at.Before(*k.Expires))
+}
+
+// APIKeyManager creates, rotates, revokes and checks API keys for network transports.
+// Keys are persisted to a JSON file after every change when a path is set. The file
+// is read again whenever it changed, so keys created, rotated or revoked by another
+// process, such as iso9001ctl apikey, take effect on a running server.
+type APIKeyManager struct {
+	mu     sync.Mutex
+	path   string
+	file   os.FileInfo // the key file as last read or written
+	keys   map[string]*APIKey
+	byHash map[string]*APIKey
+	now    func() time.Time
+}
+
+// APIKeyFile returns the location of the API key file within a tenant store directory.
+// It lives in a subdirectory so the file backend does not list it as a tenant.
+func APIKeyFile(storeDir string) string {
+	return filepath.Join(storeDir, "keys", "api-keys.json")
+}
+
+// NewAPIKeyManager creates a manager persisting keys to path, loading the keys already
+// stored there. An empty path keeps keys in memory only.
+func NewAPIKeyManager(path string) (*APIKeyManager, error) {
+	m := &APIKeyManager{
+		path:   path,
+		keys:   make(map[string]*APIKey),
+		byHash: make(map[string]*APIKey),
+		now:    time.Now,
+	}
+	if err := m.reloadLocked(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// reloadLocked reads the key file again if it changed since it was last read or
+// written. LastUsed times, which are only saved with other changes, are kept.
+func (m *APIKeyManager) reloadLocked() error {
+	if m.path == "" {
+		return nil
+	}
+	info, err := os.Stat(m.path)
+	if errors.Is(err, os.ErrNotExist) {
+		if m.file != nil {
+			m.keys = make(map[string]*APIKey)
+			m.byHash = make(map[string]*APIKey)
+			m.file = nil
+		}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if m.file != nil && os.SameFile(m.file, info) && m.file.ModTime().Equal(info.ModTime()) && m.file.Size() == info.Size() {
+		return nil
+	}
+
+	data, err := os.ReadFile(m.path)
+	if err != nil {
+		return err
+	}
+	var keys []*APIKey
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return fmt.Errorf("failed to decode API keys: %w", err)
+	}
+	byID := make(map[string]*APIKey, len(keys))
+	byHash := make(map[string]*APIKey, len(keys))
+	for _, key := range keys {
+		if previous, ok := m.keys[key.ID]; ok && key.LastUsed == nil {
+			key.LastUsed = previous.LastUsed
+		}
+		byID[key.ID] = key
+		byHash[key.SecretHash] = key
+	}
+	m.keys, m.byHash, m.file = byID, byHash, info
+	return nil
+}
+
+// Create issues a new key and returns its secret, which cannot be retrieved later.
+// A zero ttl creates a key that does not expire.
+func (m *APIKeyManager) Create(name string, scope APIKeyScope, ttl time.Duration) (string, *APIKey, error) {
+	if scopeRank(scope) == 0 {
+		return "", nil, fmt.Errorf("%w: %q", ErrUnknownAPIKeyScope, scope)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.reloadLocked(); err != nil {
+		return "", nil, err
+	}
+
+	now := m.now()
+	key := &APIKey{ID: m.nextID(), Name: name, Scope: scope, Created: now}
+	if ttl > 0 {
+		expires := now.Add(ttl)
+		key.Expires = &expires
+	}
+	secret, err := m.issueSecret(key)
+	if err != nil {
+		return "", nil, err
+	}
+	m.keys[key.ID] = key
+	if err := m.saveLocked(); err != nil {
+		return "", nil, err
+	}
+	copied := *key
+	return secret, &copied, nil
+}
+
+// Rotate replaces the secret of a key, keeping its ID, name, scope and expiry. The old
+// secret stops working immediately.
+func (m *APIKeyManager) Rotate(id string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.reloadLocked(); err != nil {
+		return "", err
+	}
+
+	key, ok := m.keys[id]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrAPIKeyNotFound, id)
+	}
+	if key.Revoked != nil {
+		return "", fmt.Errorf("%w: %s", ErrAPIKeyRevoked, id)
+	}
+
+	delete(m.byHash, key.SecretHash)
+	secret, err := m.issueSecret(key)
+	if err != nil {
+		return "", err
+	}
+	now := m.now()
+	key.Rotated = &now
+	return secret, m.saveLocked()
+}
+
+// Revoke disables a key permanently; it stays listed for traceability
+func (m *APIKeyManager) Revoke(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.reloadLocked(); err != nil {
+		return err
+	}
+
+	key, ok := m.keys[id]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrAPIKeyNotFound, id)
+	}
+	if key.Revoked != nil {
+		return nil
+	}
+	now := m.now()
+	key.Revoked = &now
+	return m.saveLocked()
+}
+
+// SetRateLimit sets the rate limit and daily quota of a key; nil restores the server
+// default
+func (m *APIKeyManager) SetRateLimit(id string, limit *RateLimit) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.reloadLocked(); err != nil {
+		return err
+	}
+
+	key, ok := m.keys[id]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrAPIKeyNotFound, id)
+	}
+	key.RateLimit = limit
+	return m.saveLocked()
+}
+
+// List returns copies of all keys, revoked ones included, ordered by ID. A key file
+// that changed but can no longer be read leaves the keys as they were.
+func (m *APIKeyManager) List() []APIKey {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.reloadLocked()
+
+	list := make([]APIKey, 0, len(m.keys))
+	for _, key := range m.keys {
+		list = append(list, *key)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list
+}
+
+// HasActiveKeys reports whether any key can currently authenticate
+func (m *APIKeyManager) HasActiveKeys() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.reloadLocked()
+
+	now := m.now()
+	for _, key := range m.keys {
+		if key.Active(now) {
+			return true
+		}
+	}
+	return false
+}
+
+// Authenticate checks a presented secret and that its key grants the required scope,
+// against the key file as it is now. Use of a key is recorded in LastUsed, which is
+// kept in memory until the next change is saved.
+func (m *APIKeyManager) Authenticate(secret string, required APIKeyScope) (*APIKey, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.reloadLocked(); err != nil {
+		return nil, fmt.Errorf("failed to read API keys: %w", err)
+	}
+
+	key, ok := m.byHash[hashAPIKeySecret(secret)]
+	if !ok {
+		return nil, ErrInvalidAPIKey
+	}
+	now := m.now()
+	if key.Revoked != nil {
+		return nil, fmt.Errorf("%w: %s", ErrAPIKeyRevoked, key.ID)
+	}
+	if key.Expires != nil && !now.Before(*key.Expires) {
+		return nil, fmt.Errorf("%w: %s", ErrAPIKeyExpired, key.ID)
+	}
+	if !key.Scope.Allows(required) {
+		return nil, fmt.Errorf("%w: %s has scope %s, %s required", ErrInsufficientScope, key.ID, key.Scope, required)
+	}
+	key.LastUsed = &now
+	copied := *key
+	return &copied, nil
+}
+
+// issueSecret generates a secret for the key and indexes its hash
+func (m *APIKeyManager) issueSecret(key *APIKey) (string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("failed to generate API key: %w", err)
+	}
+	secret := APIKeySecretPrefix + base64.RawURLEncoding.EncodeToString(raw)
+	key.SecretHash = hashAPIKeySecret(secret)
+	key.Hint = secret[:len(APIKeySecretPrefix)+4]
+	m.byHash[key.SecretHash] = key
+	return secret, nil
+}
+
+func (m *APIKeyManager) nextID() string {
+	for n := len(m.keys) + 1; ; n++ {
+		if id := fmt.Sprintf("KEY-%03d", n); m.keys[id] == nil {
+			return id
+		}
+	}
+}
+
+// saveLocked writes all keys to the key file, readable by the owner only
+func (m *APIKeyManager) saveLocked() error {
+	if m.path == "" {
+		return nil
+	}
+	keys := make([]*APIKey, 0, len(m.keys))
+	for _, key := range m.keys {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].ID < keys[j].ID })
+
+	data, err := json.MarshalIndent(keys, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(m.path), 0o700); err != nil {
+		return err
+	}
+	if err := writeFileAtomic(m.path, data, 0o600); err != nil {
+		return err
+	}
+	info, err := os.Stat(m.path)
+	if err != nil {
+		return err
+	}
+	m.file = info
+	return nil
+}
+
+func hashAPIKeySecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,113 @@
+package iso9001
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestAPIKeyLifecycle(t *testing.T) {
+	path := APIKeyFile(t.TempDir())
+	keys, err := NewAPIKeyManager(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	secret, key, err := keys.Create("dashboard", APIKeyScopeRead, 0)
+	if err != nil {
+		t.Fatalf("Failed to create key: %v", err)
+	}
+	if key.ID != "KEY-001" || key.Hint != secret[:8] {
+		t.Errorf("Unexpected key %+v", key)
+	}
+	if _, err := keys.Authenticate(secret, APIKeyScopeRead); err != nil {
+		t.Errorf("Expected read access: %v", err)
+	}
+	if _, err := keys.Authenticate(secret, APIKeyScopeWrite); !errors.Is(err, ErrInsufficientScope) {
+		t.Errorf("Expected insufficient scope, got %v", err)
+	}
+
+	rotated, err := keys.Rotate(key.ID)
+	if err != nil {
+		t.Fatalf("Failed to rotate: %v", err)
+	}
+	if _, err := keys.Authenticate(secret, APIKeyScopeRead); !errors.Is(err, ErrInvalidAPIKey) {
+		t.Errorf("Old secret should stop working, got %v", err)
+	}
+
+	// Keys are persisted and reloaded
+	reloaded, err := NewAPIKeyManager(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := reloaded.Authenticate(rotated, APIKeyScopeRead); err != nil {
+		t.Errorf("Rotated secret should survive reload: %v", err)
+	}
+	if err := reloaded.Revoke(key.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := reloaded.Authenticate(rotated, APIKeyScopeRead); !errors.Is(err, ErrAPIKeyRevoked) {
+		t.Errorf("Expected revoked key, got %v", err)
+	}
+	if reloaded.HasActiveKeys() || len(reloaded.List()) != 1 {
+		t.Error("Revoked key should remain listed but inactive")
+	}
+
+	admin, _, err := reloaded.Create("ops", APIKeyScopeAdmin, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := reloaded.Authenticate(admin, APIKeyScopeWrite); err != nil {
+		t.Errorf("Admin scope should include write: %v", err)
+	}
+	reloaded.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	if _, err := reloaded.Authenticate(admin, APIKeyScopeRead); !errors.Is(err, ErrAPIKeyExpired) {
+		t.Errorf("Expected expired key, got %v", err)
+	}
+
+	if err := reloaded.SetRateLimit("KEY-002", &RateLimit{Rate: 5, DailyQuota: 1000}); err != nil {
+		t.Fatal(err)
+	}
+	if list := reloaded.List(); list[1].RateLimit == nil || list[1].RateLimit.DailyQuota != 1000 {
+		t.Errorf("Expected rate limit on KEY-002, got %+v", list[1].RateLimit)
+	}
+
+	if _, err := ParseAPIKeyScope("root"); !errors.Is(err, ErrUnknownAPIKeyScope) {
+		t.Errorf("Expected unknown scope error, got %v", err)
+	}
+}
+
+func TestAPIKeyFileChanges(t *testing.T) {
+	path := APIKeyFile(t.TempDir())
+	server, err := NewAPIKeyManager(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cli, err := NewAPIKeyManager(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	secret, key, err := cli.Create("dashboard", APIKeyScopeRead, 0)
+	if err != nil {
+		t.Fatalf("Failed to create key: %v", err)
+	}
+	if _, err := server.Authenticate(secret, APIKeyScopeRead); err != nil {
+		t.Fatalf("Expected a key created by another manager to work: %v", err)
+	}
+
+	if err := cli.Revoke(key.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := server.Authenticate(secret, APIKeyScopeRead); !errors.Is(err, ErrAPIKeyRevoked) {
+		t.Errorf("Expected a key revoked by another manager to stop working, got %v", err)
+	}
+
+	// the server's own changes keep those made by the other manager
+	if _, _, err := server.Create("ops", APIKeyScopeWrite, 0); err != nil {
+		t.Fatal(err)
+	}
+	if list := cli.List(); len(list) != 2 || list[0].Revoked == nil {
+		t.Errorf("Expected both keys with the revocation, got %+v", list)
+	}
+}
@@ -0,0 +1,324 @@
+package iso9001
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// defaultApprovalStage names the single stage of documents that only list
+// RequiredApprovers
+const defaultApprovalStage = "approval"
+
+// ApprovalStage is one step of a document's approval workflow. Stages are completed in
+// order. The groups of a stage approve in parallel, and the stage is complete once
+// every group has approved.
+type ApprovalStage struct {
+	Name   string          `json:"name" yaml:"name"`
+	Groups []ApproverGroup `json:"groups" yaml:"groups"`
+	// Due is the time allowed once the stage starts; zero means no deadline
+	Due time.Duration `json:"due,omitempty" yaml:"due,omitempty"`
+	// EscalateTo lists approvers or roles who may approve for any open group once the
+	// stage is overdue and has been escalated
+	EscalateTo []string   `json:"escalate_to,omitempty" yaml:"escalate_to,omitempty"`
+	Started    *time.Time `json:"started,omitempty" yaml:"started,omitempty"`
+	Escalated  *time.Time `json:"escalated,omitempty" yaml:"escalated,omitempty"`
+}
+
+// ApproverGroup is a set of alternative approvers, by ID or role, of which
+// MinApprovals must approve; one when zero. A role counts once for each person
+// holding it who approves.
+type ApproverGroup struct {
+	Name         string   `json:"name" yaml:"name"`
+	Approvers    []string `json:"approvers" yaml:"approvers"`
+	MinApprovals int      `json:"min_approvals,omitempty" yaml:"min_approvals,omitempty"`
+}
+
+// ApprovalRejection records an approver returning a document to draft. Approvals
+// given before it no longer count.
+type ApprovalRejection struct {
+	ApproverID string    `json:"approver_id" yaml:"approver_id"`
+	Role       string    `json:"role,omitempty" yaml:"role,omitempty"`
+	Stage      string    `json:"stage" yaml:"stage"`
+	Version    string    `json:"version" yaml:"version"`
+	Comments   string    `json:"comments" yaml:"comments"`
+	Timestamp  time.Time `json:"timestamp" yaml:"timestamp"`
+}
+
+// ApprovalEscalation reports a stage escalated because it was not approved in time
+type ApprovalEscalation struct {
+	DocumentID string    `json:"document_id" yaml:"document_id"`
+	Stage      string    `json:"stage" yaml:"stage"`
+	Due        time.Time `json:"due" yaml:"due"`
+	Pending    []string  `json:"pending" yaml:"pending"` // approvers of the open groups
+	EscalateTo []string  `json:"escalate_to" yaml:"escalate_to"`
+}
+
+// required returns the number of approvals the group needs
+func (g ApproverGroup) required() int {
+	if g.MinApprovals < 1 {
+		return 1
+	}
+	return g.MinApprovals
+}
+
+// DueDate returns when the stage has to be approved, or zero when it has no deadline
+// or has not started
+func (s ApprovalStage) DueDate() time.Time {
+	if s.Due <= 0 || s.Started == nil {
+		return time.Time{}
+	}
+	return s.Started.Add(s.Due)
+}
+
+// workflow returns the approval stages of the document. Documents that only list
+// RequiredApprovers have one stage in which each of them must approve.
+func (a *DocumentApproval) workflow() []ApprovalStage {
+	if a == nil {
+		return nil
+	}
+	if len(a.Stages) > 0 {
+		return a.Stages
+	}
+	if len(a.RequiredApprovers) == 0 {
+		return nil
+	}
+	stage := ApprovalStage{Name: defaultApprovalStage}
+	for _, required := range a.RequiredApprovers {
+		stage.Groups = append(stage.Groups, ApproverGroup{Name: required, Approvers: []string{required}})
+	}
+	return []ApprovalStage{stage}
+}
+
+// validApprovals returns the approvals of the current version given since the last
+// rejection
+func (doc *DocumentedInformation) validApprovals() []Approval {
+	if doc.Approval == nil {
+		return nil
+	}
+	version := doc.currentVersion()
+	var since time.Time
+	if n := len(doc.Approval.Rejections); n > 0 {
+		since = doc.Approval.Rejections[n-1].Timestamp
+	}
+
+	var valid []Approval
+	for _, approval := range doc.Approval.ActualApprovers {
+		if approval.Version != "" && approval.Version != version {
+			continue
+		}
+		if !since.IsZero() && !approval.Timestamp.After(since) {
+			continue
+		}
+		valid = append(valid, approval)
+	}
+	return valid
+}
+
+// groupApprovals counts the approvals a group of a stage has received. Approvals
+// recorded before workflows existed name no group and count for every group listing
+// the approver or role.
+func groupApprovals(stage ApprovalStage, group ApproverGroup, approvals []Approval) int {
+	count := 0
+	for _, approval := range approvals {
+		if approval.Group != "" {
+			if approval.Stage == stage.Name && approval.Group == group.Name {
+				count++
+			}
+			continue
+		}
+		for _, approver := range group.Approvers {
+			if approver == approval.ApproverID || (approval.Role != "" && strings.EqualFold(approver, approval.Role)) {
+				count++
+				break
+			}
+		}
+	}
+	return count
+}
+
+// openGroups returns the groups of a stage still waiting for approvals
+func openGroups(stage ApprovalStage, approvals []Approval) []ApproverGroup {
+	var open []ApproverGroup
+	for _, group := range stage.Groups {
+		if groupApprovals(stage, group, approvals) < group.required() {
+			open = append(open, group)
+		}
+	}
+	return open
+}
+
+// currentStage returns the index of the first stage not yet approved, or the number of
+// stages once all are approved
+func (doc *DocumentedInformation) currentStage() int {
+	stages := doc.Approval.workflow()
+	approvals := doc.validApprovals()
+	for i, stage := range stages {
+		if len(openGroups(stage, approvals)) > 0 {
+			return i
+		}
+	}
+	return len(stages)
+}
+
+// CurrentApprovalStage returns the stage the document is waiting on, if any
+func (doc *DocumentedInformation) CurrentApprovalStage() (ApprovalStage, bool) {
+	stages := doc.Approval.workflow()
+	if i := doc.currentStage(); i < len(stages) {
+		return stages[i], true
+	}
+	return ApprovalStage{}, false
+}
+
+// PendingApprovers lists the approvers and roles of the open groups of the current
+// stage
+func (doc *DocumentedInformation) PendingApprovers() []string {
+	stage, ok := doc.CurrentApprovalStage()
+	if !ok {
+		return nil
+	}
+	var pending []string
+	for _, group := range openGroups(stage, doc.validApprovals()) {
+		pending = append(pending, group.Approvers...)
+	}
+	return pending
+}
+
+// startCurrentStage starts the clock of the stage the document is waiting on
+func (doc *DocumentedInformation) startCurrentStage(now time.Time) {
+	if doc.Approval == nil || len(doc.Approval.Stages) == 0 {
+		return
+	}
+	if i := doc.currentStage(); i < len(doc.Approval.Stages) && doc.Approval.Stages[i].Started == nil {
+		started := now
+		doc.Approval.Stages[i].Started = &started
+	}
+}
+
+// SetApprovalWorkflow replaces the approval workflow of a document that is not yet
+// released. Groups without a name are named after their position in the stage.
+// Approvals already given no longer count unless they were given in a stage and group
+// of the same names.
+func (dm *DocumentationManager) SetApprovalWorkflow(docID string, stages []ApprovalStage) error {
+	doc, exists := dm.Documents[docID]
+	if !exists {
+		return fmt.Errorf("document with ID %s not found", docID)
+	}
+	if doc.isReleased() {
+		return fmt.Errorf("%w: document %s is %s", ErrDocumentReleased, docID, doc.Status)
+	}
+
+	stages = append([]ApprovalStage(nil), stages...)
+	names := make(map[string]bool)
+	for i := range stages {
+		stage := &stages[i]
+		if stage.Name == "" || names[stage.Name] {
+			return fmt.Errorf("approval stage %d of document %s needs a unique name", i+1, docID)
+		}
+		names[stage.Name] = true
+		if len(stage.Groups) == 0 {
+			return fmt.Errorf("approval stage %s of document %s has no approvers", stage.Name, docID)
+		}
+		stage.Groups = append([]ApproverGroup(nil), stage.Groups...)
+		for j := range stage.Groups {
+			group := &stage.Groups[j]
+			if len(group.Approvers) == 0 || group.MinApprovals < 0 {
+				return fmt.Errorf("approver group %d of stage %s needs approvers and a non-negative minimum", j+1, stage.Name)
+			}
+			if group.Name == "" {
+				group.Name = fmt.Sprintf("group-%d", j+1)
+			}
+		}
+		stage.Started, stage.Escalated = nil, nil
+	}
+
+	if doc.Approval == nil {
+		doc.Approval = &DocumentApproval{}
+	}
+	doc.Approval.Stages = stages
+	doc.Approval.Status = ApprovalStatusPending
+	if doc.Status == DocumentStatusReview {
+		doc.startCurrentStage(time.Now())
+	}
+	doc.Modified = time.Now()
+
+	return dm.save(doc, fmt.Sprintf("Set approval workflow of %s", docID))
+}
+
+// RejectDocument returns a document to draft with the approver's comments. Only an
+// approver of the current stage may reject, and the workflow restarts from the first
+// stage when the document is approved again.
+func (dm *DocumentationManager) RejectDocument(docID string, rejection ApprovalRejection) error {
+	doc, exists := dm.Documents[docID]
+	if !exists {
+		return fmt.Errorf("document with ID %s not found", docID)
+	}
+	if doc.Status != DocumentStatusDraft && doc.Status != DocumentStatusReview {
+		return fmt.Errorf("only documents in draft or review can be rejected, document %s is %s", docID, doc.Status)
+	}
+	if strings.TrimSpace(rejection.Comments) == "" {
+		return fmt.Errorf("rejection of document %s must explain what to change", docID)
+	}
+	if rejection.Timestamp.IsZero() {
+		rejection.Timestamp = time.Now()
+	}
+
+	match, err := dm.checkApprover(doc, Approval{ApproverID: rejection.ApproverID, Role: rejection.Role, Timestamp: rejection.Timestamp})
+	if err != nil {
+		return err
+	}
+	rejection.Stage = match.stage
+	rejection.Version = doc.currentVersion()
+
+	if doc.Approval == nil {
+		doc.Approval = &DocumentApproval{}
+	}
+	doc.Approval.Rejections = append(doc.Approval.Rejections, rejection)
+	doc.Approval.Status = ApprovalStatusRejected
+	for i := range doc.Approval.Stages {
+		doc.Approval.Stages[i].Started, doc.Approval.Stages[i].Escalated = nil, nil
+	}
+	doc.Status = DocumentStatusDraft
+	doc.Modified = rejection.Timestamp
+
+	dm.updateIndex(doc)
+	return dm.save(doc, fmt.Sprintf("Reject %s version %s by %s", docID, rejection.Version, rejection.ApproverID))
+}
+
+// EscalateOverdueApprovals escalates every current approval stage past its due date,
+// letting the stage's escalation approvers approve for it. Each stage is escalated
+// once; the escalations are returned for notification.
+func (dm *DocumentationManager) EscalateOverdueApprovals(now time.Time) ([]ApprovalEscalation, error) {
+	var escalations []ApprovalEscalation
+	var errs []error
+	for _, doc := range dm.Documents {
+		if doc.Approval == nil || len(doc.Approval.Stages) == 0 || (doc.Status != DocumentStatusDraft && doc.Status != DocumentStatusReview) {
+			continue
+		}
+		i := doc.currentStage()
+		if i == len(doc.Approval.Stages) {
+			continue
+		}
+		stage := &doc.Approval.Stages[i]
+		due := stage.DueDate()
+		if stage.Escalated != nil || due.IsZero() || !due.Before(now) {
+			continue
+		}
+
+		escalated := now
+		stage.Escalated = &escalated
+		doc.Modified = now
+		escalations = append(escalations, ApprovalEscalation{
+			DocumentID: doc.ID,
+			Stage:      stage.Name,
+			Due:        due,
+			Pending:    doc.PendingApprovers(),
+			EscalateTo: append([]string(nil), stage.EscalateTo...),
+		})
+		if err := dm.save(doc, fmt.Sprintf("Escalate approval stage %s of %s", stage.Name, doc.ID)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return escalations, errors.Join(errs...)
+}
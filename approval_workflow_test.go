@@ -0,0 +1,103 @@
+package iso9001
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestApprovalWorkflow(t *testing.T) {
+	org := &Organization{
+		Leadership: &Leadership{
+			TopManagement: []Person{{ID: "P-001", Name: "Ada", Role: "CEO"}},
+			Roles: []OrganizationalRole{
+				{ID: "ROLE-QM", Name: "Quality Manager", AssignedTo: "P-002"},
+				{ID: "ROLE-ENG1", Name: "Engineer", AssignedTo: "P-003"},
+				{ID: "ROLE-ENG2", Name: "Engineer", AssignedTo: "P-004"},
+				{ID: "ROLE-SAFE", Name: "Safety Officer", AssignedTo: "P-005"},
+			},
+		},
+	}
+	dm := NewDocumentationManager()
+	dm.Approvers = NewOrganizationDirectory(org)
+	dm.AddDocument(&DocumentedInformation{ID: "DOC-001", Title: "Welding procedure", Content: "Draft"})
+
+	err := dm.SetApprovalWorkflow("DOC-001", []ApprovalStage{
+		{Name: "technical", Due: 48 * time.Hour, EscalateTo: []string{"Quality Manager"}, Groups: []ApproverGroup{
+			{Name: "engineering", Approvers: []string{"Engineer"}, MinApprovals: 2},
+			{Name: "safety", Approvers: []string{"Safety Officer"}},
+		}},
+		{Name: "release", Groups: []ApproverGroup{{Approvers: []string{"CEO"}}}},
+	})
+	if err != nil {
+		t.Fatalf("Failed to set workflow: %v", err)
+	}
+	if err := dm.SetApprovalWorkflow("DOC-001", []ApprovalStage{{Name: "x", Groups: []ApproverGroup{{Approvers: []string{"CEO"}, MinApprovals: -1}}}}); err == nil {
+		t.Error("Expected group with a negative minimum to be rejected")
+	}
+	dm.SetDocumentStatus("DOC-001", DocumentStatusReview)
+	doc, _ := dm.GetDocument("DOC-001")
+	if stage, _ := doc.CurrentApprovalStage(); stage.Name != "technical" || stage.Started == nil {
+		t.Fatalf("Expected technical stage to start on submission, got %+v", stage)
+	}
+	deadlines := CollectDeadlines(DeadlineSources{Documents: dm})
+	if len(deadlines) != 1 || deadlines[0].Kind != DeadlineDocumentApproval || deadlines[0].EntityID != "technical" || deadlines[0].Responsible != "Engineer, Safety Officer" {
+		t.Errorf("Expected a deadline for the technical stage, got %+v", deadlines)
+	}
+
+	// The release stage waits for the technical stage
+	if err := dm.ApproveDocument("DOC-001", Approval{ApproverID: "P-001"}); !errors.Is(err, ErrApproverNotAuthorized) {
+		t.Errorf("Expected CEO approval to wait for the technical stage, got %v", err)
+	}
+	for _, id := range []string{"P-003", "P-005"} {
+		if err := dm.ApproveDocument("DOC-001", Approval{ApproverID: id}); err != nil {
+			t.Fatalf("Approval by %s failed: %v", id, err)
+		}
+	}
+	if pending := doc.PendingApprovers(); len(pending) != 1 || pending[0] != "Engineer" {
+		t.Errorf("Expected a second engineer to be pending, got %v", pending)
+	}
+
+	// Rejection returns the document to draft and voids the approvals so far
+	if err := dm.RejectDocument("DOC-001", ApprovalRejection{ApproverID: "P-004"}); err == nil {
+		t.Error("Expected rejection without comments to fail")
+	}
+	if err := dm.RejectDocument("DOC-001", ApprovalRejection{ApproverID: "P-004", Comments: "Preheat temperature missing"}); err != nil {
+		t.Fatal(err)
+	}
+	if doc.Status != DocumentStatusDraft || doc.Approval.Status != ApprovalStatusRejected || len(doc.validApprovals()) != 0 {
+		t.Fatalf("Expected rejected draft without valid approvals, got %s %s", doc.Status, doc.Approval.Status)
+	}
+	if rejection := doc.Approval.Rejections[0]; rejection.Stage != "technical" || rejection.Version != "1.0" {
+		t.Errorf("Unexpected rejection %+v", rejection)
+	}
+
+	// Resubmitted, the stage runs late and is escalated to the quality manager
+	dm.UpdateDocument("DOC-001", &DocumentedInformation{Title: "Welding procedure", Content: "Preheat to 150C"}, VersionChange{Summary: "Add preheat"})
+	doc, _ = dm.GetDocument("DOC-001")
+	dm.SetDocumentStatus("DOC-001", DocumentStatusReview)
+	dm.ApproveDocument("DOC-001", Approval{ApproverID: "P-003"})
+	dm.ApproveDocument("DOC-001", Approval{ApproverID: "P-004"})
+	if err := dm.ApproveDocument("DOC-001", Approval{ApproverID: "P-002"}); !errors.Is(err, ErrApproverNotAuthorized) {
+		t.Errorf("Expected escalation approver to wait for the escalation, got %v", err)
+	}
+	escalations, err := dm.EscalateOverdueApprovals(time.Now().Add(72 * time.Hour))
+	if err != nil || len(escalations) != 1 || escalations[0].Pending[0] != "Safety Officer" {
+		t.Fatalf("Expected the technical stage to be escalated, got %+v %v", escalations, err)
+	}
+	if again, _ := dm.EscalateOverdueApprovals(time.Now().Add(96 * time.Hour)); len(again) != 0 {
+		t.Error("Expected a stage to be escalated once")
+	}
+	if err := dm.ApproveDocument("DOC-001", Approval{ApproverID: "P-002"}); err != nil {
+		t.Fatalf("Expected escalation approver to approve, got %v", err)
+	}
+	if stage, _ := doc.CurrentApprovalStage(); stage.Name != "release" {
+		t.Fatalf("Expected release stage, got %s", stage.Name)
+	}
+	if err := dm.ApproveDocument("DOC-001", Approval{ApproverID: "P-001"}); err != nil {
+		t.Fatal(err)
+	}
+	if doc.Status != DocumentStatusApproved || doc.Approval.Status != ApprovalStatusApproved {
+		t.Errorf("Expected approved document, got %s %s", doc.Status, doc.Approval.Status)
+	}
+}
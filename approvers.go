@@ -0,0 +1,363 @@
+package iso9001
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Errors returned by ApproveDocument when an approval is rejected
+var (
+	ErrUnknownApprover       = errors.New("approver not found")
+	ErrApproverNotAuthorized = errors.New("approver does not hold a required role")
+	ErrAlreadyApproved       = errors.New("approver has already approved this version")
+)
+
+// ErrInvalidDelegation is returned when a delegation cannot be recorded
+var ErrInvalidDelegation = errors.New("invalid delegation")
+
+// Delegation lets a delegate approve on behalf of a delegator for a limited time, e.g.
+// during leave. Roles limits the delegation to some of the delegator's roles and
+// Categories to some document categories; empty lists delegate all of them.
+type Delegation struct {
+	ID         string             `json:"id" yaml:"id"`
+	Delegator  string             `json:"delegator" yaml:"delegator"`
+	Delegate   string             `json:"delegate" yaml:"delegate"`
+	Roles      []string           `json:"roles,omitempty" yaml:"roles,omitempty"`
+	Categories []DocumentCategory `json:"categories,omitempty" yaml:"categories,omitempty"`
+	From       time.Time          `json:"from" yaml:"from"`
+	Until      time.Time          `json:"until" yaml:"until"`
+	Reason     string             `json:"reason,omitempty" yaml:"reason,omitempty"`
+	Revoked    *time.Time         `json:"revoked,omitempty" yaml:"revoked,omitempty"`
+}
+
+// ActiveAt reports whether the delegation is in force at the given time
+func (d *Delegation) ActiveAt(at time.Time) bool {
+	if d.Revoked != nil && !at.Before(*d.Revoked) {
+		return false
+	}
+	return !at.Before(d.From) && at.Before(d.Until)
+}
+
+// coversCategory reports whether documents of the category are within scope
+func (d *Delegation) coversCategory(category DocumentCategory) bool {
+	if len(d.Categories) == 0 {
+		return true
+	}
+	for _, c := range d.Categories {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
+
+// DelegationDirectory is implemented by approver directories that support time-bound
+// delegation. ApproveDocument consults it when an approver does not hold a role
+// directly.
+type DelegationDirectory interface {
+	// DelegationFor returns the delegation in force at the given time that allows the
+	// delegate to approve the document for a role or on behalf of a named approver
+	DelegationFor(delegateID, role string, doc *DocumentedInformation, at time.Time) (*Delegation, bool)
+}
+
+// ApproverDirectory resolves approvers and the roles they may approve for
+type ApproverDirectory interface {
+	// FindPerson returns the person with the given ID
+	FindPerson(personID string) (Person, bool)
+	// HoldsRole reports whether the person holds the role, directly or by delegation
+	HoldsRole(personID, role string) bool
+}
+
+// OrganizationDirectory is an ApproverDirectory built from an organization's top
+// management and role assignments. Time-bound delegations are kept in the
+// organization's Leadership.Delegations, so they are saved with it.
+type OrganizationDirectory struct {
+	people      map[string]Person
+	roles       map[string][]string
+	delegations map[string][]string
+	leadership  *Leadership
+}
+
+// NewOrganizationDirectory indexes the people and role assignments of an organization.
+// People are taken from top management; role assignees that are not listed there are
+// known by ID only.
+func NewOrganizationDirectory(org *Organization) *OrganizationDirectory {
+	dir := &OrganizationDirectory{
+		people:      make(map[string]Person),
+		roles:       make(map[string][]string),
+		delegations: make(map[string][]string),
+		leadership:  org.Leadership,
+	}
+	if org.Leadership == nil {
+		return dir
+	}
+
+	for _, person := range org.Leadership.TopManagement {
+		dir.people[person.ID] = person
+		if person.Role != "" {
+			dir.roles[person.ID] = append(dir.roles[person.ID], person.Role)
+		}
+	}
+	for _, role := range org.Leadership.Roles {
+		if role.AssignedTo == "" {
+			continue
+		}
+		if _, known := dir.people[role.AssignedTo]; !known {
+			dir.people[role.AssignedTo] = Person{ID: role.AssignedTo, Role: role.Name}
+		}
+		dir.roles[role.AssignedTo] = append(dir.roles[role.AssignedTo], role.ID, role.Name)
+	}
+
+	return dir
+}
+
+// Delegate grants a person the authority to approve on behalf of a role
+func (d *OrganizationDirectory) Delegate(role, personID string) {
+	d.delegations[personID] = append(d.delegations[personID], role)
+}
+
+// AddDelegation records a time-bound delegation in the organization. The delegator
+// must be known and hold the delegated roles, and the delegation must end after it
+// starts. An ID of the form DEL-001 is assigned when none is given.
+func (d *OrganizationDirectory) AddDelegation(delegation Delegation) (Delegation, error) {
+	if _, ok := d.people[delegation.Delegator]; !ok || d.leadership == nil {
+		return Delegation{}, fmt.Errorf("%w: unknown delegator %s", ErrInvalidDelegation, delegation.Delegator)
+	}
+	return addDelegation(d.leadership, delegation, d.holdsDirectly)
+}
+
+// RevokeDelegation ends a delegation early; approvals already given remain valid
+func (d *OrganizationDirectory) RevokeDelegation(id string, at time.Time) error {
+	return revokeDelegation(d.leadership, id, at)
+}
+
+// Delegations returns the recorded time-bound delegations, revoked ones included
+func (d *OrganizationDirectory) Delegations() []Delegation {
+	if d.leadership == nil {
+		return nil
+	}
+	return append([]Delegation(nil), d.leadership.Delegations...)
+}
+
+// DelegationFor returns the delegation in force at the given time that allows the
+// delegate to approve the document for the role. A role is covered when the
+// delegation lists it, or lists no roles and the delegator holds it; a required
+// approver naming the delegator by ID is covered by delegations without role limits.
+func (d *OrganizationDirectory) DelegationFor(delegateID, role string, doc *DocumentedInformation, at time.Time) (*Delegation, bool) {
+	return delegationFor(d.leadership, d.holdsDirectly, delegateID, role, doc, at)
+}
+
+// addDelegation validates a delegation whose delegator is known and appends it to the
+// delegations of leadership. holds reports the roles people hold directly.
+func addDelegation(leadership *Leadership, delegation Delegation, holds func(personID, role string) bool) (Delegation, error) {
+	if delegation.Delegate == "" || delegation.Delegate == delegation.Delegator {
+		return Delegation{}, fmt.Errorf("%w: delegate must be another person", ErrInvalidDelegation)
+	}
+	if !delegation.Until.After(delegation.From) {
+		return Delegation{}, fmt.Errorf("%w: period must end after %s", ErrInvalidDelegation, delegation.From.Format("2006-01-02"))
+	}
+	for _, role := range delegation.Roles {
+		if !holds(delegation.Delegator, role) {
+			return Delegation{}, fmt.Errorf("%w: %s does not hold role %s", ErrInvalidDelegation, delegation.Delegator, role)
+		}
+	}
+	if delegation.ID == "" {
+		delegation.ID = fmt.Sprintf("DEL-%03d", len(leadership.Delegations)+1)
+	}
+	for _, existing := range leadership.Delegations {
+		if existing.ID == delegation.ID {
+			return Delegation{}, fmt.Errorf("%w: delegation %s already exists", ErrInvalidDelegation, delegation.ID)
+		}
+	}
+	leadership.Delegations = append(leadership.Delegations, delegation)
+	return delegation, nil
+}
+
+// revokeDelegation ends a delegation of leadership at the given time
+func revokeDelegation(leadership *Leadership, id string, at time.Time) error {
+	if leadership != nil {
+		for i := range leadership.Delegations {
+			if leadership.Delegations[i].ID == id {
+				leadership.Delegations[i].Revoked = &at
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("%w: delegation %s not found", ErrInvalidDelegation, id)
+}
+
+// delegationFor finds the delegation of leadership in force at the given time that
+// allows the delegate to approve the document for the role; see
+// OrganizationDirectory.DelegationFor
+func delegationFor(leadership *Leadership, holds func(personID, role string) bool, delegateID, role string, doc *DocumentedInformation, at time.Time) (*Delegation, bool) {
+	if leadership == nil {
+		return nil, false
+	}
+	for i := range leadership.Delegations {
+		delegation := &leadership.Delegations[i]
+		if delegation.Delegate != delegateID || !delegation.ActiveAt(at) {
+			continue
+		}
+		if doc != nil && !delegation.coversCategory(doc.Category) {
+			continue
+		}
+		if len(delegation.Roles) == 0 {
+			if role == delegation.Delegator || holds(delegation.Delegator, role) {
+				return delegation, true
+			}
+			continue
+		}
+		for _, delegated := range delegation.Roles {
+			if strings.EqualFold(delegated, role) {
+				return delegation, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// FindPerson returns the person with the given ID
+func (d *OrganizationDirectory) FindPerson(personID string) (Person, bool) {
+	person, ok := d.people[personID]
+	return person, ok
+}
+
+// HoldsRole reports whether the person is assigned the role, matched by role ID or
+// case-insensitive name, or has been delegated authority for it without time limit
+func (d *OrganizationDirectory) HoldsRole(personID, role string) bool {
+	if d.holdsDirectly(personID, role) {
+		return true
+	}
+	for _, delegated := range d.delegations[personID] {
+		if strings.EqualFold(delegated, role) {
+			return true
+		}
+	}
+	return false
+}
+
+// holdsDirectly reports whether the person is assigned the role, ignoring delegations
+func (d *OrganizationDirectory) holdsDirectly(personID, role string) bool {
+	for _, held := range d.roles[personID] {
+		if strings.EqualFold(held, role) {
+			return true
+		}
+	}
+	return false
+}
+
+// directory returns the directory approvers are verified against, or nil when they are
+// matched by ID and role only, as in a tenant without users
+func (dm *DocumentationManager) directory() ApproverDirectory {
+	if users, ok := dm.Approvers.(tenantDirectory); ok && !users.verifies() {
+		return nil
+	}
+	return dm.Approvers
+}
+
+// approverMatch is the place in the approval workflow an approver acts for
+type approverMatch struct {
+	stage      string      // stage name; empty for documents without a workflow
+	group      string      // group name within the stage
+	entry      string      // approver ID or role of the group the approver satisfies
+	delegation *Delegation // delegation the approver acts under, if any
+}
+
+// checkApprover verifies an approval for the current version of a document and
+// returns the open group of the current approval stage it satisfies, directly, under
+// a delegation or, once the stage is escalated, as an escalation approver. Without a
+// directory approvers are matched by ID and role only.
+func (dm *DocumentationManager) checkApprover(doc *DocumentedInformation, approver Approval) (approverMatch, error) {
+	stages := doc.Approval.workflow()
+	current := doc.currentStage()
+	version := doc.currentVersion()
+	if len(stages) > 0 && current == len(stages) {
+		return approverMatch{}, fmt.Errorf("%w: version %s of document %s has completed its approval workflow", ErrAlreadyApproved, version, doc.ID)
+	}
+	var stage ApprovalStage
+	if current < len(stages) {
+		stage = stages[current]
+	}
+	for _, existing := range doc.validApprovals() {
+		if existing.ApproverID == approver.ApproverID && (existing.Stage == "" || existing.Stage == stage.Name) {
+			return approverMatch{}, fmt.Errorf("%w: %s approved version %s of document %s", ErrAlreadyApproved, approver.ApproverID, version, doc.ID)
+		}
+	}
+
+	approvers := dm.directory()
+	if approvers != nil {
+		if _, ok := approvers.FindPerson(approver.ApproverID); !ok {
+			return approverMatch{}, fmt.Errorf("%w: %s", ErrUnknownApprover, approver.ApproverID)
+		}
+	}
+
+	at := approver.Timestamp
+	if at.IsZero() {
+		at = time.Now()
+	}
+	// authorized reports whether the approver may act for an approver ID or role,
+	// directly or under a delegation in force at the time of approval
+	authorized := func(entry string) (bool, *Delegation) {
+		if entry == approver.ApproverID {
+			return true, nil
+		}
+		if approvers == nil {
+			return approver.Role != "" && strings.EqualFold(entry, approver.Role), nil
+		}
+		if approvers.HoldsRole(approver.ApproverID, entry) {
+			return true, nil
+		}
+		if delegations, ok := approvers.(DelegationDirectory); ok {
+			if delegation, ok := delegations.DelegationFor(approver.ApproverID, entry, doc, at); ok {
+				return true, delegation
+			}
+		}
+		return false, nil
+	}
+
+	var delegation *Delegation
+	if approver.Role != "" && approvers != nil {
+		ok, via := authorized(approver.Role)
+		if !ok {
+			return approverMatch{}, fmt.Errorf("%w: %s does not hold role %s", ErrApproverNotAuthorized, approver.ApproverID, approver.Role)
+		}
+		delegation = via
+	}
+	if len(stages) == 0 {
+		return approverMatch{delegation: delegation}, nil
+	}
+
+	open := openGroups(stage, doc.validApprovals())
+	var pending []string
+	for _, group := range open {
+		for _, entry := range group.Approvers {
+			pending = append(pending, entry)
+			if approver.Role != "" && entry != approver.ApproverID && !strings.EqualFold(entry, approver.Role) {
+				continue
+			}
+			if ok, via := authorized(entry); ok {
+				return approverMatch{stage: stage.Name, group: group.Name, entry: entry, delegation: via}, nil
+			}
+		}
+	}
+	if stage.Escalated != nil {
+		for _, entry := range stage.EscalateTo {
+			if ok, via := authorized(entry); ok {
+				return approverMatch{stage: stage.Name, group: open[0].Name, entry: entry, delegation: via}, nil
+			}
+		}
+	}
+
+	return approverMatch{}, fmt.Errorf("%w: %s cannot approve document %s (requires %s)", ErrApproverNotAuthorized,
+		approver.ApproverID, doc.ID, strings.Join(pending, ", "))
+}
+
+// currentVersion returns the latest version number of the document
+func (doc *DocumentedInformation) currentVersion() string {
+	if len(doc.Versions) == 0 {
+		return ""
+	}
+	return doc.Versions[len(doc.Versions)-1].VersionNumber
+}
@@ -0,0 +1,364 @@
+package iso9001
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Errors returned when attachments cannot be stored or read
+var (
+	ErrNoAttachmentStore  = errors.New("documentation manager has no attachment store")
+	ErrAttachmentNotFound = errors.New("attachment not found")
+	ErrAttachmentCorrupt  = errors.New("attachment content does not match its checksum")
+)
+
+// Attachment is a file carried by a document, such as a PDF, a drawing or a
+// spreadsheet. The content is kept in the manager's AttachmentStore under
+// StorageRef; the document only holds its description.
+type Attachment struct {
+	ID         string    `json:"id" yaml:"id"`
+	FileName   string    `json:"file_name" yaml:"file_name"`
+	MIMEType   string    `json:"mime_type" yaml:"mime_type"`
+	Size       int64     `json:"size" yaml:"size"`
+	Checksum   string    `json:"checksum" yaml:"checksum"` // "sha256:" followed by the hex digest
+	StorageRef string    `json:"storage_ref" yaml:"storage_ref"`
+	UploadedBy string    `json:"uploaded_by,omitempty" yaml:"uploaded_by,omitempty"`
+	Uploaded   time.Time `json:"uploaded" yaml:"uploaded"`
+}
+
+// AttachmentStore keeps attachment content by storage reference. Implement it to keep
+// attachments in object storage or a database.
+type AttachmentStore interface {
+	// Create returns a writer for the content stored under ref; the content is only
+	// stored once the writer is closed
+	Create(ref string) (io.WriteCloser, error)
+	// Open returns the content stored under ref, or an error wrapping
+	// ErrAttachmentNotFound
+	Open(ref string) (io.ReadCloser, error)
+	// Delete removes the content stored under ref
+	Delete(ref string) error
+}
+
+// AddAttachment streams a file into the attachment store and attaches it to a draft
+// document. The MIME type is taken from the file extension, or detected from the
+// content, when empty.
+func (dm *DocumentationManager) AddAttachment(docID string, attachment Attachment, content io.Reader) (*Attachment, error) {
+	return dm.AddAttachmentContext(context.Background(), docID, attachment, content)
+}
+
+// AddAttachmentContext adds an attachment like AddAttachment, stopping the upload once
+// ctx is done. Content stored so far is removed and the document is left unchanged.
+func (dm *DocumentationManager) AddAttachmentContext(ctx context.Context, docID string, attachment Attachment, content io.Reader) (*Attachment, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	content = contextReader{ctx: ctx, r: content}
+	doc, exists := dm.Documents[docID]
+	if !exists {
+		return nil, fmt.Errorf("document with ID %s not found", docID)
+	}
+	if doc.isReleased() {
+		return nil, fmt.Errorf("%w: document %s is %s", ErrDocumentReleased, docID, doc.Status)
+	}
+	if dm.Attachments == nil {
+		return nil, ErrNoAttachmentStore
+	}
+	name := attachment.FileName
+	if name == "" || name != filepath.Base(name) || name != path.Base(name) || name == "." || name == ".." {
+		return nil, fmt.Errorf("invalid attachment file name %q", name)
+	}
+
+	attachment.ID = nextAttachmentID(doc)
+	attachment.StorageRef = docID + "/" + attachment.ID + "-" + name
+	if attachment.MIMEType == "" {
+		attachment.MIMEType = mime.TypeByExtension(filepath.Ext(name))
+	}
+	if attachment.MIMEType == "" {
+		buffered := bufio.NewReader(content)
+		head, _ := buffered.Peek(512)
+		attachment.MIMEType = http.DetectContentType(head)
+		content = buffered
+	}
+
+	w, err := dm.Attachments.Create(attachment.StorageRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to store attachment %s: %w", name, err)
+	}
+	digest := sha256.New()
+	size, err := io.Copy(io.MultiWriter(w, digest), content)
+	if closeErr := w.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		dm.Attachments.Delete(attachment.StorageRef)
+		return nil, fmt.Errorf("failed to store attachment %s: %w", name, err)
+	}
+
+	attachment.Size = size
+	attachment.Checksum = "sha256:" + hex.EncodeToString(digest.Sum(nil))
+	attachment.Uploaded = time.Now()
+	doc.Attachments = append(doc.Attachments, attachment)
+	doc.Modified = attachment.Uploaded
+
+	if err := dm.save(doc, fmt.Sprintf("Attach %s to %s", name, docID)); err != nil {
+		return nil, err
+	}
+	return &doc.Attachments[len(doc.Attachments)-1], nil
+}
+
+// nextAttachmentID returns the first free attachment ID of the form ATT-001
+func nextAttachmentID(doc *DocumentedInformation) string {
+	for n := len(doc.Attachments) + 1; ; n++ {
+		id := fmt.Sprintf("ATT-%03d", n)
+		if _, err := doc.Attachment(id); err != nil {
+			return id
+		}
+	}
+}
+
+// Attachment returns an attachment of the document by ID
+func (doc *DocumentedInformation) Attachment(attachmentID string) (*Attachment, error) {
+	for i := range doc.Attachments {
+		if doc.Attachments[i].ID == attachmentID {
+			return &doc.Attachments[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s in document %s", ErrAttachmentNotFound, attachmentID, doc.ID)
+}
+
+// OpenAttachment streams the content of an attachment. The reader verifies the size
+// and checksum as it reaches the end of the content and then fails with
+// ErrAttachmentCorrupt if they do not match.
+func (dm *DocumentationManager) OpenAttachment(docID, attachmentID string) (io.ReadCloser, *Attachment, error) {
+	doc, exists := dm.Documents[docID]
+	if !exists {
+		return nil, nil, fmt.Errorf("document with ID %s not found", docID)
+	}
+	attachment, err := doc.Attachment(attachmentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if dm.Attachments == nil {
+		return nil, nil, ErrNoAttachmentStore
+	}
+
+	r, err := dm.Attachments.Open(attachment.StorageRef)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open attachment %s: %w", attachment.FileName, err)
+	}
+	return &verifyingReader{ReadCloser: r, digest: sha256.New(), attachment: *attachment}, attachment, nil
+}
+
+// RemoveAttachment detaches a file from a draft document. The content is deleted
+// from the store unless another document, such as a revision, still refers to it.
+func (dm *DocumentationManager) RemoveAttachment(docID, attachmentID string) error {
+	doc, exists := dm.Documents[docID]
+	if !exists {
+		return fmt.Errorf("document with ID %s not found", docID)
+	}
+	if doc.isReleased() {
+		return fmt.Errorf("%w: document %s is %s", ErrDocumentReleased, docID, doc.Status)
+	}
+	attachment, err := doc.Attachment(attachmentID)
+	if err != nil {
+		return err
+	}
+	removed := *attachment
+
+	for i := range doc.Attachments {
+		if doc.Attachments[i].ID == attachmentID {
+			doc.Attachments = append(doc.Attachments[:i], doc.Attachments[i+1:]...)
+			break
+		}
+	}
+	doc.Modified = time.Now()
+
+	if dm.Attachments != nil && !dm.attachmentReferenced(removed.StorageRef) {
+		if err := dm.Attachments.Delete(removed.StorageRef); err != nil && !errors.Is(err, ErrAttachmentNotFound) {
+			return fmt.Errorf("failed to delete attachment %s: %w", removed.FileName, err)
+		}
+	}
+	return dm.save(doc, fmt.Sprintf("Remove attachment %s from %s", removed.FileName, docID))
+}
+
+// attachmentReferenced reports whether any document still refers to stored content
+func (dm *DocumentationManager) attachmentReferenced(ref string) bool {
+	for _, doc := range dm.Documents {
+		for _, attachment := range doc.Attachments {
+			if attachment.StorageRef == ref {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// verifyingReader checks the size and checksum of attachment content once it has
+// been read to the end
+type verifyingReader struct {
+	io.ReadCloser
+	digest     hash.Hash
+	size       int64
+	attachment Attachment
+}
+
+func (r *verifyingReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.digest.Write(p[:n])
+	r.size += int64(n)
+	if err == io.EOF {
+		checksum := "sha256:" + hex.EncodeToString(r.digest.Sum(nil))
+		if r.size != r.attachment.Size || checksum != r.attachment.Checksum {
+			return n, fmt.Errorf("%w: %s", ErrAttachmentCorrupt, r.attachment.FileName)
+		}
+	}
+	return n, err
+}
+
+// MemoryAttachmentStore keeps attachment content in memory
+type MemoryAttachmentStore struct {
+	mu      sync.RWMutex
+	content map[string][]byte
+}
+
+// NewMemoryAttachmentStore creates an empty in-memory attachment store
+func NewMemoryAttachmentStore() *MemoryAttachmentStore {
+	return &MemoryAttachmentStore{content: make(map[string][]byte)}
+}
+
+// Create returns a writer that stores the content when closed
+func (s *MemoryAttachmentStore) Create(ref string) (io.WriteCloser, error) {
+	return &memoryAttachmentWriter{store: s, ref: ref}, nil
+}
+
+// Open returns the stored content
+func (s *MemoryAttachmentStore) Open(ref string) (io.ReadCloser, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	content, exists := s.content[ref]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrAttachmentNotFound, ref)
+	}
+	return io.NopCloser(bytes.NewReader(content)), nil
+}
+
+// Delete removes the stored content
+func (s *MemoryAttachmentStore) Delete(ref string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.content, ref)
+	return nil
+}
+
+type memoryAttachmentWriter struct {
+	bytes.Buffer
+	store *MemoryAttachmentStore
+	ref   string
+}
+
+func (w *memoryAttachmentWriter) Close() error {
+	w.store.mu.Lock()
+	defer w.store.mu.Unlock()
+
+	w.store.content[w.ref] = w.Bytes()
+	return nil
+}
+
+// DirAttachmentStore keeps attachment content as files below a directory, one file
+// per storage reference
+type DirAttachmentStore struct {
+	Dir string
+}
+
+// NewDirAttachmentStore creates a directory attachment store, creating the directory
+// if needed
+func NewDirAttachmentStore(dir string) (*DirAttachmentStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create attachment directory: %w", err)
+	}
+	return &DirAttachmentStore{Dir: dir}, nil
+}
+
+func (s *DirAttachmentStore) path(ref string) (string, error) {
+	clean := path.Clean(ref)
+	if ref == "" || clean != ref || path.IsAbs(ref) || clean == ".." || strings.HasPrefix(clean, "../") || strings.Contains(ref, `\`) {
+		return "", fmt.Errorf("invalid attachment reference %q", ref)
+	}
+	return filepath.Join(s.Dir, filepath.FromSlash(clean)), nil
+}
+
+// Create writes the content to a temporary file that replaces the stored file when
+// closed, so readers never see partial content
+func (s *DirAttachmentStore) Create(ref string) (io.WriteCloser, error) {
+	target, err := s.path(ref)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return nil, err
+	}
+	f, err := os.CreateTemp(filepath.Dir(target), ".upload-*")
+	if err != nil {
+		return nil, err
+	}
+	return &dirAttachmentWriter{File: f, target: target}, nil
+}
+
+// Open opens the stored file
+func (s *DirAttachmentStore) Open(ref string) (io.ReadCloser, error) {
+	target, err := s.path(ref)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(target)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrAttachmentNotFound, ref)
+	}
+	return f, err
+}
+
+// Delete removes the stored file
+func (s *DirAttachmentStore) Delete(ref string) error {
+	target, err := s.path(ref)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(target); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+type dirAttachmentWriter struct {
+	*os.File
+	target string
+}
+
+func (w *dirAttachmentWriter) Close() error {
+	if err := w.File.Close(); err != nil {
+		os.Remove(w.Name())
+		return err
+	}
+	if err := os.Rename(w.Name(), w.target); err != nil {
+		os.Remove(w.Name())
+		return err
+	}
+	return nil
+}
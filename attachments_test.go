@@ -0,0 +1,117 @@
+package iso9001
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestDocumentAttachments(t *testing.T) {
+	dm := NewDocumentationManager()
+	doc := &DocumentedInformation{ID: "DOC-001", Title: "Calibration procedure", Content: "See the attached drawing"}
+	if err := dm.AddDocument(doc); err != nil {
+		t.Fatal(err)
+	}
+
+	drawing := []byte("%PDF-1.7 drawing")
+	attachment, err := dm.AddAttachment("DOC-001", Attachment{FileName: "drawing.pdf", UploadedBy: "jane"}, bytes.NewReader(drawing))
+	if err != nil {
+		t.Fatalf("Failed to add attachment: %v", err)
+	}
+	if attachment.ID != "ATT-001" || attachment.MIMEType != "application/pdf" || attachment.Size != int64(len(drawing)) || !strings.HasPrefix(attachment.Checksum, "sha256:") {
+		t.Errorf("Unexpected attachment %+v", attachment)
+	}
+	sheet, err := dm.AddAttachment("DOC-001", Attachment{FileName: "limits"}, strings.NewReader("gauge,limit\nG-1,0.02\n"))
+	if err != nil || !strings.HasPrefix(sheet.MIMEType, "text/plain") {
+		t.Errorf("Expected MIME type to be detected from content, got %+v %v", sheet, err)
+	}
+	if _, err := dm.AddAttachment("DOC-001", Attachment{FileName: "../escape.pdf"}, strings.NewReader("x")); err == nil {
+		t.Error("Expected file name with a path to be rejected")
+	}
+
+	r, _, err := dm.OpenAttachment("DOC-001", "ATT-001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	read, err := io.ReadAll(r)
+	r.Close()
+	if err != nil || !bytes.Equal(read, drawing) {
+		t.Errorf("Expected attachment content back, got %q %v", read, err)
+	}
+
+	// Content changed in the store is detected when read
+	w, _ := dm.Attachments.Create(attachment.StorageRef)
+	w.Write([]byte("%PDF-1.7 tampered"))
+	w.Close()
+	r, _, _ = dm.OpenAttachment("DOC-001", "ATT-001")
+	if _, err := io.ReadAll(r); !errors.Is(err, ErrAttachmentCorrupt) {
+		t.Errorf("Expected corrupted content to be reported, got %v", err)
+	}
+
+	// Attachments survive updates, are shared with revisions and are frozen on release
+	if err := dm.UpdateDocument("DOC-001", &DocumentedInformation{Title: "Calibration procedure", Content: "Rev B"}, VersionChange{Summary: "Tighten limits"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(dm.Documents["DOC-001"].Attachments) != 2 {
+		t.Fatal("Expected update to keep the attachments")
+	}
+	if err := dm.RemoveAttachment("DOC-001", "ATT-002"); err != nil {
+		t.Fatal(err)
+	}
+	dm.SetDocumentStatus("DOC-001", DocumentStatusApproved)
+	dm.SetDocumentStatus("DOC-001", DocumentStatusPublished)
+	if _, err := dm.AddAttachment("DOC-001", Attachment{FileName: "late.pdf"}, strings.NewReader("x")); !errors.Is(err, ErrDocumentReleased) {
+		t.Errorf("Expected released document to refuse attachments, got %v", err)
+	}
+
+	revision, err := dm.CreateRevision("DOC-001", "DOC-001-B", "jane", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := dm.RemoveAttachment(revision.ID, "ATT-001"); err != nil {
+		t.Fatal(err)
+	}
+	if r, _, err := dm.OpenAttachment("DOC-001", "ATT-001"); err != nil {
+		t.Errorf("Expected content still referenced by the published document to be kept, got %v", err)
+	} else {
+		r.Close()
+	}
+}
+
+func TestDirAttachmentStore(t *testing.T) {
+	store, err := NewDirAttachmentStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Create("../outside"); err == nil {
+		t.Error("Expected reference outside the directory to be rejected")
+	}
+
+	w, err := store.Create("DOC-001/ATT-001-drawing.pdf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	io.WriteString(w, "drawing")
+	if _, err := store.Open("DOC-001/ATT-001-drawing.pdf"); !errors.Is(err, ErrAttachmentNotFound) {
+		t.Errorf("Expected content to be stored only when closed, got %v", err)
+	}
+	w.Close()
+
+	r, err := store.Open("DOC-001/ATT-001-drawing.pdf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, _ := io.ReadAll(r)
+	r.Close()
+	if string(content) != "drawing" {
+		t.Errorf("Expected stored content, got %q", content)
+	}
+	if err := store.Delete("DOC-001/ATT-001-drawing.pdf"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Open("DOC-001/ATT-001-drawing.pdf"); !errors.Is(err, ErrAttachmentNotFound) {
+		t.Errorf("Expected deleted content to be gone, got %v", err)
+	}
+}
@@ -2,6 +2,7 @@ package iso9001
 
 import (
 	"fmt"
+	"sort"
 	"time"
 )
 
@@ -22,6 +23,7 @@ type Audit struct {
 	Report            *AuditReport      `json:"report,omitempty" yaml:"report,omitempty"`
 	Status            AuditStatus       `json:"status" yaml:"status"`
 	RiskAssessment    AuditRisk         `json:"risk_assessment" yaml:"risk_assessment"`
+	DueDatePolicy     *DueDatePolicy    `json:"due_date_policy,omitempty" yaml:"due_date_policy,omitempty"` // overrides the manager's policy
 	Created           time.Time         `json:"created" yaml:"created"`
 	Modified          time.Time         `json:"modified" yaml:"modified"`
 }
@@ -66,6 +68,7 @@ type AuditFinding struct {
 	Severity       FindingSeverity    `json:"severity" yaml:"severity"`
 	Category       FindingCategory    `json:"category" yaml:"category"`
 	RootCause      string             `json:"root_cause" yaml:"root_cause"`
+	RootCauseAnalysis *RootCauseAnalysis `json:"root_cause_analysis,omitempty" yaml:"root_cause_analysis,omitempty"`
 	Process        string             `json:"process" yaml:"process"`
 	Responsible    string             `json:"responsible" yaml:"responsible"`
 	DueDate        time.Time          `json:"due_date" yaml:"due_date"`
@@ -190,6 +193,7 @@ type ManagementReviewInputs struct {
 
 // ManagementReviewOutputs represents outputs from management review (clause 9.3.3)
 type ManagementReviewOutputs struct {
+	Decisions                []string                 `json:"decisions,omitempty" yaml:"decisions,omitempty"` // decisions recorded in the minutes
 	ImprovementOpportunities []ImprovementOpportunity `json:"improvement_opportunities" yaml:"improvement_opportunities"`
 	QMSChanges               []QMSChange              `json:"qms_changes" yaml:"qms_changes"`
 	ResourceNeeds            []ResourceNeed           `json:"resource_needs" yaml:"resource_needs"`
@@ -213,8 +217,11 @@ type QMSPerformanceReport struct {
 
 type CustomerSatisfactionReport struct {
 	OverallSatisfaction float64            `json:"overall_satisfaction" yaml:"overall_satisfaction"`
+	NetPromoterScore    *float64            `json:"net_promoter_score,omitempty" yaml:"net_promoter_score,omitempty"` // -100 to 100, when surveys ask for it
+	Responses           int                 `json:"responses,omitempty" yaml:"responses,omitempty"`
 	SurveyResults       []SurveyResult      `json:"survey_results" yaml:"survey_results"`
 	Complaints          []CustomerComplaint `json:"complaints" yaml:"complaints"`
+	ComplaintStatistics *ComplaintStatistics `json:"complaint_statistics,omitempty" yaml:"complaint_statistics,omitempty"`
 	Trends              []Trend             `json:"trends" yaml:"trends"`
 }
 
@@ -236,6 +243,10 @@ type NonconformanceReport struct {
 	Description string             `json:"description" yaml:"description"`
 	Status      NonconformanceStatus `json:"status" yaml:"status"`
 	RootCause   string             `json:"root_cause" yaml:"root_cause"`
+	RootCauseAnalysis *RootCauseAnalysis `json:"root_cause_analysis,omitempty" yaml:"root_cause_analysis,omitempty"`
+	Process     string             `json:"process,omitempty" yaml:"process,omitempty"`
+	Date        time.Time          `json:"date,omitempty" yaml:"date,omitempty"`
+	Source      string             `json:"source,omitempty" yaml:"source,omitempty"` // system or person that reported it
 }
 
 type CorrectiveActionReport struct {
@@ -251,6 +262,11 @@ type MeasurementResult struct {
 	Value    float64   `json:"value" yaml:"value"`
 	Target   float64   `json:"target" yaml:"target"`
 	Date     time.Time `json:"date" yaml:"date"`
+	Unit        string `json:"unit,omitempty" yaml:"unit,omitempty"`
+	ProcessID   string `json:"process_id,omitempty" yaml:"process_id,omitempty"`     // process whose criteria the result measures
+	CriteriaID  string `json:"criteria_id,omitempty" yaml:"criteria_id,omitempty"`   // monitoring criteria of the process
+	ObjectiveID string `json:"objective_id,omitempty" yaml:"objective_id,omitempty"` // quality objective the result counts towards
+	Source      string `json:"source,omitempty" yaml:"source,omitempty"`             // system or person that reported the result
 }
 
 type AuditResultSummary struct {
@@ -337,6 +353,16 @@ type CustomerComplaint struct {
 	Date        time.Time `json:"date" yaml:"date"`
 	Status      string    `json:"status" yaml:"status"`
 	Resolution  string    `json:"resolution" yaml:"resolution"`
+	Customer    string    `json:"customer,omitempty" yaml:"customer,omitempty"`
+	Source      string    `json:"source,omitempty" yaml:"source,omitempty"` // system or person that reported it
+	Product     string    `json:"product,omitempty" yaml:"product,omitempty"`
+	Priority    Priority  `json:"priority,omitempty" yaml:"priority,omitempty"`
+	Responsible string    `json:"responsible,omitempty" yaml:"responsible,omitempty"`
+	// AcknowledgeBy and DueDate are the SLA deadlines for acknowledging and resolving it
+	AcknowledgeBy time.Time  `json:"acknowledge_by,omitempty" yaml:"acknowledge_by,omitempty"`
+	DueDate       time.Time  `json:"due_date,omitempty" yaml:"due_date,omitempty"`
+	Acknowledged  *time.Time `json:"acknowledged,omitempty" yaml:"acknowledged,omitempty"`
+	Resolved      *time.Time `json:"resolved,omitempty" yaml:"resolved,omitempty"`
 }
 
 type CorrectiveAction struct {
@@ -379,10 +405,44 @@ const (
 	ChangeTypeOrganizational ChangeType = "organizational"
 )
 
+// DueDatePolicy sets how many days are allowed to address a finding of each severity
+type DueDatePolicy struct {
+	Days        map[FindingSeverity]int `json:"days" yaml:"days"`
+	DefaultDays int                     `json:"default_days" yaml:"default_days"` // used for severities missing from Days
+}
+
+// DefaultDueDatePolicy returns the policy used when none is configured
+func DefaultDueDatePolicy() DueDatePolicy {
+	return DueDatePolicy{
+		Days: map[FindingSeverity]int{
+			SeverityCritical:    7,
+			SeverityMajor:       30,
+			SeverityMinor:       60,
+			SeverityObservation: 90,
+		},
+		DefaultDays: 30,
+	}
+}
+
+// DaysFor returns the number of days allowed for a finding of the given severity
+func (p DueDatePolicy) DaysFor(severity FindingSeverity) int {
+	if days, ok := p.Days[severity]; ok {
+		return days
+	}
+	return p.DefaultDays
+}
+
 // AuditManager manages audits and management reviews
 type AuditManager struct {
 	Audits           map[string]*Audit           `json:"audits" yaml:"audits"`
 	ManagementReviews map[string]*ManagementReview `json:"management_reviews" yaml:"management_reviews"`
+	DueDatePolicy    *DueDatePolicy              `json:"due_date_policy,omitempty" yaml:"due_date_policy,omitempty"`
+	Programs         map[int]*AuditProgram       `json:"programs,omitempty" yaml:"programs,omitempty"` // audit programmes by year
+
+	// IDs, when set, is shared with the organization's other managers
+	IDs *IDRegistry `json:"-" yaml:"-"`
+	// DueDates, when set, evaluates finding deadlines in the organization's time zone
+	DueDates *DueDateCalculator `json:"-" yaml:"-"`
 }
 
 // NewAuditManager creates a new audit manager
@@ -390,11 +450,30 @@ func NewAuditManager() *AuditManager {
 	return &AuditManager{
 		Audits:            make(map[string]*Audit),
 		ManagementReviews: make(map[string]*ManagementReview),
+		Programs:          make(map[int]*AuditProgram),
 	}
 }
 
 // CreateAudit creates a new audit
 func (am *AuditManager) CreateAudit(audit *Audit) error {
+	if err := checkNewAudit(audit); err != nil {
+		return err
+	}
+	_, exists := am.Audits[audit.ID]
+	if err := claimID(am.IDs, audit.ID, EntityTypeAudit, exists); err != nil {
+		return err
+	}
+
+	audit.Created = time.Now()
+	audit.Modified = time.Now()
+	audit.Status = AuditStatusPlanned
+
+	am.Audits[audit.ID] = audit
+	return nil
+}
+
+// checkNewAudit verifies the fields required to create an audit
+func checkNewAudit(audit *Audit) error {
 	if audit.ID == "" {
 		return fmt.Errorf("audit must have an ID")
 	}
@@ -404,12 +483,6 @@ func (am *AuditManager) CreateAudit(audit *Audit) error {
 	if audit.Scope.Description == "" {
 		return fmt.Errorf("audit must have a defined scope")
 	}
-
-	audit.Created = time.Now()
-	audit.Modified = time.Now()
-	audit.Status = AuditStatusPlanned
-
-	am.Audits[audit.ID] = audit
 	return nil
 }
 
@@ -420,8 +493,8 @@ func (am *AuditManager) StartAudit(auditID string, startDate time.Time) error {
 		return fmt.Errorf("audit with ID %s not found", auditID)
 	}
 
-	if audit.Status != AuditStatusPlanned {
-		return fmt.Errorf("audit is not in planned status")
+	if err := auditTransitions.check(EntityTypeAudit, auditID, audit.Status, AuditStatusInProgress); err != nil {
+		return err
 	}
 
 	audit.ActualStartDate = &startDate
@@ -437,20 +510,72 @@ func (am *AuditManager) AddFinding(auditID string, finding AuditFinding) error {
 	if !exists {
 		return fmt.Errorf("audit with ID %s not found", auditID)
 	}
+	if finding.ID != "" {
+		if err := claimID(am.IDs, finding.ID, EntityTypeFinding, am.hasFinding(finding.ID)); err != nil {
+			return err
+		}
+	}
 
 	finding.Created = time.Now()
+	if finding.DueDate.IsZero() {
+		finding.DueDate = am.findingDueDate(audit, finding.Severity, finding.Created)
+	}
 	audit.Findings = append(audit.Findings, finding)
 	audit.Modified = time.Now()
 
 	return nil
 }
 
-// CompleteAudit completes an audit
+// FindingDueDate returns the deadline for a finding of the given severity raised in
+// an audit today, applying the audit's policy override if it has one
+func (am *AuditManager) FindingDueDate(auditID string, severity FindingSeverity) (time.Time, error) {
+	audit, exists := am.Audits[auditID]
+	if !exists {
+		return time.Time{}, fmt.Errorf("audit with ID %s not found", auditID)
+	}
+	return am.findingDueDate(audit, severity, time.Now()), nil
+}
+
+func (am *AuditManager) findingDueDate(audit *Audit, severity FindingSeverity, from time.Time) time.Time {
+	policy := DefaultDueDatePolicy()
+	if audit.DueDatePolicy != nil {
+		policy = *audit.DueDatePolicy
+	} else if am.DueDatePolicy != nil {
+		policy = *am.DueDatePolicy
+	}
+
+	days := policy.DaysFor(severity)
+	if am.DueDates != nil {
+		return am.DueDates.DueDate(from, days)
+	}
+	return from.AddDate(0, 0, days)
+}
+
+// hasFinding reports whether any audit already has a finding with the given ID
+func (am *AuditManager) hasFinding(findingID string) bool {
+	for _, audit := range am.Audits {
+		for _, finding := range audit.Findings {
+			if finding.ID == findingID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// CompleteAudit completes an audit. It fails with ErrCriticalFindingsOpen while a
+// critical finding is neither closed nor accepted.
 func (am *AuditManager) CompleteAudit(auditID string, endDate time.Time, report *AuditReport) error {
 	audit, exists := am.Audits[auditID]
 	if !exists {
 		return fmt.Errorf("audit with ID %s not found", auditID)
 	}
+	if err := auditTransitions.check(EntityTypeAudit, auditID, audit.Status, AuditStatusCompleted); err != nil {
+		return err
+	}
+	if err := checkCriticalFindings(audit); err != nil {
+		return err
+	}
 
 	audit.ActualEndDate = &endDate
 	audit.Report = report
@@ -460,13 +585,53 @@ func (am *AuditManager) CompleteAudit(auditID string, endDate time.Time, report
 	return nil
 }
 
+// SetAuditStatus moves an audit to a new status, e.g. to reported or closed once it
+// has been completed
+func (am *AuditManager) SetAuditStatus(auditID string, status AuditStatus) error {
+	audit, exists := am.Audits[auditID]
+	if !exists {
+		return fmt.Errorf("audit with ID %s not found", auditID)
+	}
+	if err := auditTransitions.check(EntityTypeAudit, auditID, audit.Status, status); err != nil {
+		return err
+	}
+
+	audit.Status = status
+	audit.Modified = time.Now()
+	return nil
+}
+
+// UpdateFindingStatus moves a finding of an audit to a new status. A nonconformity
+// can only be closed once its corrective actions have been verified as effective, and
+// an open finding only without corrective actions.
+func (am *AuditManager) UpdateFindingStatus(auditID, findingID string, status FindingStatus) error {
+	audit, finding, err := am.finding(auditID, findingID)
+	if err != nil {
+		return err
+	}
+	closesDirectly := finding.Status == FindingStatusOpen && status == FindingStatusClosed && len(finding.CorrectiveActions) == 0
+	if err := findingTransitions.check(EntityTypeFinding, findingID, finding.Status, status); err != nil && !closesDirectly {
+		return err
+	}
+	if status == FindingStatusClosed {
+		if err := checkFindingClosure(finding); err != nil {
+			return err
+		}
+	}
+
+	finding.Status = status
+	audit.Modified = time.Now()
+	return nil
+}
+
 // CreateManagementReview creates a new management review
 func (am *AuditManager) CreateManagementReview(review *ManagementReview) error {
-	if review.ID == "" {
-		return fmt.Errorf("management review must have an ID")
+	if err := checkNewManagementReview(review); err != nil {
+		return err
 	}
-	if review.Title == "" {
-		return fmt.Errorf("management review must have a title")
+	_, exists := am.ManagementReviews[review.ID]
+	if err := claimID(am.IDs, review.ID, EntityTypeManagementReview, exists); err != nil {
+		return err
 	}
 
 	review.Created = time.Now()
@@ -476,6 +641,17 @@ func (am *AuditManager) CreateManagementReview(review *ManagementReview) error {
 	return nil
 }
 
+// checkNewManagementReview verifies the fields required to create a management review
+func checkNewManagementReview(review *ManagementReview) error {
+	if review.ID == "" {
+		return fmt.Errorf("management review must have an ID")
+	}
+	if review.Title == "" {
+		return fmt.Errorf("management review must have a title")
+	}
+	return nil
+}
+
 // CompleteManagementReview completes a management review
 func (am *AuditManager) CompleteManagementReview(reviewID string, outputs ManagementReviewOutputs) error {
 	review, exists := am.ManagementReviews[reviewID]
@@ -503,6 +679,25 @@ func (am *AuditManager) GetAuditsDue() []*Audit {
 	return due
 }
 
+// GetUpcomingAudits returns planned audits starting within the given duration,
+// ordered by planned start date
+func (am *AuditManager) GetUpcomingAudits(within time.Duration) []*Audit {
+	var upcoming []*Audit
+	now := time.Now()
+	horizon := now.Add(within)
+
+	for _, audit := range am.Audits {
+		if audit.Status == AuditStatusPlanned && !audit.PlannedStartDate.Before(now) && !audit.PlannedStartDate.After(horizon) {
+			upcoming = append(upcoming, audit)
+		}
+	}
+	sort.Slice(upcoming, func(i, j int) bool {
+		return upcoming[i].PlannedStartDate.Before(upcoming[j].PlannedStartDate)
+	})
+
+	return upcoming
+}
+
 // GetOverdueFindings returns audit findings that are overdue
 func (am *AuditManager) GetOverdueFindings() []AuditFinding {
 	var overdue []AuditFinding
@@ -510,7 +705,7 @@ func (am *AuditManager) GetOverdueFindings() []AuditFinding {
 
 	for _, audit := range am.Audits {
 		for _, finding := range audit.Findings {
-			if finding.Status != FindingStatusClosed && finding.DueDate.Before(now) {
+			if finding.Status != FindingStatusClosed && am.DueDates.IsOverdue(finding.DueDate, now) {
 				overdue = append(overdue, finding)
 			}
 		}
@@ -0,0 +1,267 @@
+package iso9001
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ChecklistResult is the auditor's conclusion on a checklist question
+type ChecklistResult string
+
+const (
+	ChecklistConforming    ChecklistResult = "conforming"
+	ChecklistNonconforming ChecklistResult = "nonconforming"
+	ChecklistObservation   ChecklistResult = "observation"
+	ChecklistNotApplicable ChecklistResult = "not_applicable"
+)
+
+// AuditChecklist is the working paper of an audit: the questions to ask for each
+// clause and process in scope, with room for the auditor's results and notes
+type AuditChecklist struct {
+	Scope AuditScope      `json:"scope" yaml:"scope"`
+	Items []ChecklistItem `json:"items" yaml:"items"`
+}
+
+// ChecklistItem is one question of an audit checklist
+type ChecklistItem struct {
+	ID          string   `json:"id" yaml:"id"`
+	Clause      string   `json:"clause" yaml:"clause"`
+	ClauseTitle string   `json:"clause_title,omitempty" yaml:"clause_title,omitempty"`
+	ProcessID   string   `json:"process_id,omitempty" yaml:"process_id,omitempty"`
+	Question    string   `json:"question" yaml:"question"`
+	Evidence    []string `json:"evidence,omitempty" yaml:"evidence,omitempty"` // what the auditor should look at
+	Records     []string `json:"records,omitempty" yaml:"records,omitempty"`   // documented information that must be retained
+
+	Result ChecklistResult `json:"result,omitempty" yaml:"result,omitempty"`
+	Notes  string          `json:"notes,omitempty" yaml:"notes,omitempty"`
+}
+
+// GenerateAuditChecklist derives the checklist questions of an audit scope. Each
+// clause in scope, with the clauses nested below it, gets a question from the clause
+// library; without clauses the whole library is used, and excluded clauses are left
+// out. Each process in scope, looked up in the organization, gets questions on its
+// inputs and outputs, responsibilities, resources, monitoring criteria and risks.
+func GenerateAuditChecklist(org *Organization, scope AuditScope) (*AuditChecklist, error) {
+	checklist := &AuditChecklist{Scope: scope}
+	add := func(item ChecklistItem) {
+		item.ID = fmt.Sprintf("Q-%03d", len(checklist.Items)+1)
+		checklist.Items = append(checklist.Items, item)
+	}
+
+	excluded := func(number string) bool {
+		for _, exclusion := range scope.Exclusions {
+			exclusion = normalizeClauseReference(exclusion)
+			if number == exclusion || strings.HasPrefix(number, exclusion+".") {
+				return true
+			}
+		}
+		return false
+	}
+
+	var clauses []Clause
+	if len(scope.Clauses) == 0 {
+		clauses = Clauses()
+	}
+	seen := make(map[string]bool)
+	for _, reference := range scope.Clauses {
+		if _, ok := exclusionClause(reference); !ok {
+			return nil, fmt.Errorf("audit scope names unknown clause %q", reference)
+		}
+		number := normalizeClauseReference(reference)
+		clause, ok := LookupClause(number)
+		if !ok {
+			// Clauses 4 to 6 are not in the clause library
+			clause = Clause{Number: number}
+		}
+		for _, clause := range append([]Clause{clause}, SubClauses(number)...) {
+			if !seen[clause.Number] {
+				seen[clause.Number] = true
+				clauses = append(clauses, clause)
+			}
+		}
+	}
+	for _, clause := range clauses {
+		if excluded(clause.Number) {
+			continue
+		}
+		add(ChecklistItem{
+			Clause:      clause.Number,
+			ClauseTitle: clause.Title,
+			Question:    clauseQuestion(clause),
+			Evidence:    clause.TypicalEvidence,
+			Records:     clause.RequiredRecords,
+		})
+	}
+
+	for _, processID := range scope.Processes {
+		process := findProcess(org, processID)
+		if process == nil {
+			return nil, fmt.Errorf("process with ID %s not found", processID)
+		}
+		for _, item := range processQuestions(process) {
+			if !excluded(item.Clause) {
+				item.ProcessID = process.ID
+				add(item)
+			}
+		}
+	}
+	return checklist, nil
+}
+
+// clauseQuestion turns the requirement of a clause into a question
+func clauseQuestion(clause Clause) string {
+	requirement := strings.TrimSuffix(strings.TrimSpace(clause.Description), ".")
+	if requirement == "" {
+		return fmt.Sprintf("How does the organization meet the requirements of clause %s?", clause.Number)
+	}
+	first, size := utf8.DecodeRuneInString(requirement)
+	return "How does the organization " + string(unicode.ToLower(first)) + requirement[size:] + "?"
+}
+
+// processQuestions returns the questions on a process; their clause tells which
+// requirement they test
+func processQuestions(process *Process) []ChecklistItem {
+	name := process.Name
+	if name == "" {
+		name = process.ID
+	}
+	var items []ChecklistItem
+
+	var inputs, outputs []string
+	for _, input := range process.Inputs {
+		inputs = append(inputs, input.Name)
+	}
+	for _, output := range process.Outputs {
+		outputs = append(outputs, output.Name)
+	}
+	question := fmt.Sprintf("Are the inputs and expected outputs of the %s process determined", name)
+	var evidence []string
+	if len(inputs) > 0 {
+		evidence = append(evidence, "Inputs: "+strings.Join(inputs, ", "))
+	}
+	if len(outputs) > 0 {
+		evidence = append(evidence, "Outputs: "+strings.Join(outputs, ", "))
+	}
+	if len(evidence) > 0 {
+		question += ", and are they as documented"
+	}
+	items = append(items, ChecklistItem{Clause: "4.4", ClauseTitle: "Quality management system and its processes", Question: question + "?", Evidence: evidence})
+
+	responsibilities := "Responsibilities and authorities"
+	if len(process.Responsibilities) > 0 {
+		responsibilities += ": " + strings.Join(process.Responsibilities, ", ")
+	}
+	items = append(items, ChecklistItem{
+		Clause:      "5.3",
+		ClauseTitle: "Organizational roles, responsibilities and authorities",
+		Question:    fmt.Sprintf("Are responsibilities and authorities for the %s process assigned, communicated and understood?", name),
+		Evidence:    []string{responsibilities, "Interviews with process personnel"},
+	})
+
+	for _, risk := range process.Risks {
+		items = append(items, ChecklistItem{
+			Clause:      "6.1",
+			ClauseTitle: "Actions to address risks and opportunities",
+			Question:    fmt.Sprintf("Have the actions to address risk %s (%s) of the %s process been implemented, and is their effectiveness evaluated?", risk.ID, risk.Description, name),
+			Evidence:    []string{"Risk register entry", "Records of the mitigation actions"},
+		})
+	}
+
+	if len(process.Resources) > 0 {
+		var resources []string
+		for _, resource := range process.Resources {
+			resources = append(resources, resource.Name)
+		}
+		items = append(items, ChecklistItem{
+			Clause:      "7.1",
+			ClauseTitle: "Resources",
+			Question:    fmt.Sprintf("Are the resources needed by the %s process available and maintained?", name),
+			Evidence:    []string{"Resources: " + strings.Join(resources, ", ")},
+		})
+	}
+	for _, resource := range process.Resources {
+		if resource.CalibrationDue == nil {
+			continue
+		}
+		items = append(items, ChecklistItem{
+			Clause:      "7.1.5.2",
+			ClauseTitle: "Measurement traceability",
+			Question:    fmt.Sprintf("Is %s calibrated, identified with its calibration status and due for calibration on %s?", resource.Name, resource.CalibrationDue.Format("2006-01-02")),
+			Records:     []string{"Calibration or verification records"},
+		})
+	}
+
+	for _, criteria := range process.Criteria {
+		target := criteria.Target
+		if criteria.Metric != "" {
+			target = strings.TrimSpace(criteria.Metric + " " + target)
+		}
+		question := fmt.Sprintf("Is %q of the %s process monitored, and do recent results meet the target", criteria.Name, name)
+		if target != "" {
+			question += " (" + target + ")"
+		}
+		items = append(items, ChecklistItem{
+			Clause:      "9.1.1",
+			ClauseTitle: "General",
+			Question:    question + "?",
+			Evidence:    []string{"Measurement results", "Trend analysis"},
+			Records:     []string{"Evidence of the results of monitoring and measurement"},
+		})
+	}
+	return items
+}
+
+// findProcess returns the organization's process with the ID, or nil
+func findProcess(org *Organization, id string) *Process {
+	if org == nil || org.QMS == nil {
+		return nil
+	}
+	for i := range org.QMS.Processes {
+		if org.QMS.Processes[i].ID == id {
+			return &org.QMS.Processes[i]
+		}
+	}
+	return nil
+}
+
+// WriteMarkdown writes the checklist as a Markdown working paper, with a table per
+// clause of the standard and per process and empty result and notes columns to fill in
+func (c *AuditChecklist) WriteMarkdown(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("# Audit checklist\n")
+	if c.Scope.Description != "" {
+		fmt.Fprintf(&b, "\nScope: %s\n", c.Scope.Description)
+	}
+
+	section := ""
+	for _, item := range c.Items {
+		heading := "Process " + item.ProcessID
+		if item.ProcessID == "" {
+			number := item.Clause
+			if parts := strings.SplitN(number, ".", 3); len(parts) == 3 {
+				number = parts[0] + "." + parts[1]
+			}
+			heading = "Clause " + number
+			if clause, ok := LookupClause(number); ok {
+				heading += " " + clause.Title
+			}
+		}
+		if heading != section {
+			section = heading
+			fmt.Fprintf(&b, "\n## %s\n\n| # | Clause | Question | Evidence | Result | Notes |\n|---|---|---|---|---|---|\n", heading)
+		}
+		evidence := append(append([]string(nil), item.Evidence...), item.Records...)
+		fmt.Fprintf(&b, "| %s | %s | %s | %s | %s | %s |\n", item.ID, item.Clause, markdownCell(item.Question),
+			markdownCell(strings.Join(evidence, "; ")), item.Result, markdownCell(item.Notes))
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// markdownCell escapes text for a Markdown table cell
+func markdownCell(s string) string {
+	return strings.NewReplacer("|", "\\|", "\n", " ").Replace(s)
+}
@@ -0,0 +1,89 @@
+package iso9001
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestGenerateAuditChecklist(t *testing.T) {
+	org := CreateExampleOrganization()
+	process := &org.QMS.Processes[0]
+	process.Risks = []Risk{{ID: "RISK-001", Description: "Late design changes"}}
+
+	checklist, err := GenerateAuditChecklist(org, AuditScope{
+		Description: "Design and development",
+		Clauses:     []string{"Clause 7.1", "9.3"},
+		Exclusions:  []string{"7.1.5"},
+		Processes:   []string{process.ID},
+	})
+	if err != nil {
+		t.Fatalf("Failed to generate checklist: %v", err)
+	}
+
+	clauses := make(map[string]ChecklistItem)
+	var processItems []ChecklistItem
+	if checklist.Items[0].ID != "Q-001" || checklist.Items[1].ID != "Q-002" {
+		t.Errorf("Expected numbered questions, got %s and %s", checklist.Items[0].ID, checklist.Items[1].ID)
+	}
+	for _, item := range checklist.Items {
+		if item.ProcessID != "" {
+			processItems = append(processItems, item)
+		} else {
+			clauses[item.Clause] = item
+		}
+	}
+	if _, ok := clauses["7.1.5.1"]; ok {
+		t.Error("Expected clauses below the exclusion to be left out")
+	}
+	if item, ok := clauses["7.1.6"]; !ok || len(item.Evidence) == 0 {
+		t.Errorf("Expected sub-clause 7.1.6 with evidence, got %+v", item)
+	}
+	if item := clauses["9.3.1"]; item.Question != "How does the organization have top management review the QMS at planned intervals?" {
+		t.Errorf("Unexpected question %q", item.Question)
+	}
+
+	var risk, criteria bool
+	for _, item := range processItems {
+		risk = risk || item.Clause == "6.1" && strings.Contains(item.Question, "RISK-001")
+		criteria = criteria || item.Clause == "9.1.1"
+	}
+	if len(processItems) == 0 || processItems[0].Clause != "4.4" || !risk || !criteria {
+		t.Errorf("Expected process questions on inputs, risks and criteria, got %+v", processItems)
+	}
+
+	var out bytes.Buffer
+	if err := checklist.WriteMarkdown(&out); err != nil {
+		t.Fatalf("Failed to write checklist: %v", err)
+	}
+	for _, want := range []string{"Scope: Design and development", "## Clause 9.3 Management review", "## Process " + process.ID, "| Result | Notes |"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Expected working paper to contain %q", want)
+		}
+	}
+}
+
+func TestGenerateAuditChecklistWholeStandard(t *testing.T) {
+	checklist, err := GenerateAuditChecklist(nil, AuditScope{Exclusions: []string{"8.3"}})
+	if err != nil {
+		t.Fatalf("Failed to generate checklist: %v", err)
+	}
+	if len(checklist.Items) == 0 {
+		t.Fatal("Expected questions for the whole clause library")
+	}
+	for _, item := range checklist.Items {
+		if strings.HasPrefix(item.Clause, "8.3") {
+			t.Errorf("Expected excluded clause to be left out, got %s", item.Clause)
+		}
+	}
+
+	if _, err := GenerateAuditChecklist(nil, AuditScope{Clauses: []string{"11.2"}}); err == nil {
+		t.Error("Expected error for unknown clause")
+	}
+	if _, err := GenerateAuditChecklist(nil, AuditScope{Clauses: []string{"4.1"}, Processes: []string{"PROC-404"}}); err == nil {
+		t.Error("Expected error for unknown process")
+	}
+	if checklist, _ := GenerateAuditChecklist(nil, AuditScope{Clauses: []string{"4.1"}}); len(checklist.Items) != 1 || checklist.Items[0].Question == "" {
+		t.Errorf("Expected a generic question for clause 4.1, got %+v", checklist.Items)
+	}
+}
@@ -0,0 +1,374 @@
+package iso9001
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// AuditProgram plans the internal audits of a year (clause 9.2.2). It lists the
+// processes to audit with their risk, which together with the results of previous
+// audits sets how often each is audited, and the clauses the cycle must cover.
+// Audits belong to the programme by the year they take place in.
+type AuditProgram struct {
+	Year      int                   `json:"year" yaml:"year"`
+	Processes []ProgramProcess      `json:"processes" yaml:"processes"`
+	Clauses   []string              `json:"clauses,omitempty" yaml:"clauses,omitempty"`     // clauses to cover; empty for every clause of the standard
+	Frequency *AuditFrequencyPolicy `json:"frequency,omitempty" yaml:"frequency,omitempty"` // overrides the default policy
+	Created   time.Time             `json:"created" yaml:"created"`
+	Modified  time.Time             `json:"modified" yaml:"modified"`
+
+	// audits is the manager the programme belongs to
+	audits *AuditManager
+}
+
+// ProgramProcess is a process in an audit programme with the risk it is audited for
+type ProgramProcess struct {
+	ProcessID string    `json:"process_id" yaml:"process_id"`
+	Risk      RiskLevel `json:"risk" yaml:"risk"`
+}
+
+// AuditFrequencyPolicy sets how many times a year a process is audited
+type AuditFrequencyPolicy struct {
+	PerYear        map[RiskLevel]int `json:"per_year" yaml:"per_year"`
+	DefaultPerYear int               `json:"default_per_year" yaml:"default_per_year"` // used for risk levels missing from PerYear
+
+	// ExtraAfterNonconformity is added when the last audit of the process raised a
+	// critical or major finding
+	ExtraAfterNonconformity int `json:"extra_after_nonconformity" yaml:"extra_after_nonconformity"`
+	// CleanAuditsToReduce consecutive audits without nonconformities lower the
+	// frequency by one, to no less than once a year; zero never reduces it
+	CleanAuditsToReduce int `json:"clean_audits_to_reduce" yaml:"clean_audits_to_reduce"`
+}
+
+// DefaultAuditFrequencyPolicy returns the policy used when none is configured
+func DefaultAuditFrequencyPolicy() AuditFrequencyPolicy {
+	return AuditFrequencyPolicy{
+		PerYear: map[RiskLevel]int{
+			RiskLevelVeryHigh: 4,
+			RiskLevelHigh:     2,
+			RiskLevelMedium:   1,
+			RiskLevelLow:      1,
+			RiskLevelVeryLow:  1,
+		},
+		DefaultPerYear:          1,
+		ExtraAfterNonconformity: 1,
+		CleanAuditsToReduce:     3,
+	}
+}
+
+// ProgramCoverage is how often a process or clause is audited in a programme's year
+type ProgramCoverage struct {
+	Kind      string `json:"kind" yaml:"kind"` // "process" or "clause"
+	Reference string `json:"reference" yaml:"reference"`
+	Required  int    `json:"required" yaml:"required"`
+	Audited   int    `json:"audited" yaml:"audited"` // completed audits
+	Planned   int    `json:"planned" yaml:"planned"` // audits planned or in progress
+}
+
+// PlanAuditProgram drafts the programme of a year with every process of the
+// organization, rated by the highest priority among its risks. Processes without
+// assessed risks are rated medium.
+func PlanAuditProgram(org *Organization, year int) *AuditProgram {
+	program := &AuditProgram{Year: year}
+	if org == nil || org.QMS == nil {
+		return program
+	}
+	for _, process := range org.QMS.Processes {
+		program.Processes = append(program.Processes, ProgramProcess{ProcessID: process.ID, Risk: processRisk(process)})
+	}
+	return program
+}
+
+// processRisk rates a process by the highest priority among its risks
+func processRisk(process Process) RiskLevel {
+	levels := map[Priority]RiskLevel{
+		PriorityCritical: RiskLevelVeryHigh,
+		PriorityHigh:     RiskLevelHigh,
+		PriorityMedium:   RiskLevelMedium,
+		PriorityLow:      RiskLevelLow,
+	}
+	rating := RiskLevel("")
+	for _, risk := range process.Risks {
+		if level, ok := levels[risk.Priority]; ok && riskLevelRank[level] > riskLevelRank[rating] {
+			rating = level
+		}
+	}
+	if rating == "" {
+		return RiskLevelMedium
+	}
+	return rating
+}
+
+// CreateAuditProgram adds the audit programme of a year
+func (am *AuditManager) CreateAuditProgram(program *AuditProgram) error {
+	if program.Year <= 0 {
+		return fmt.Errorf("audit programme must have a year")
+	}
+	if _, exists := am.Programs[program.Year]; exists {
+		return fmt.Errorf("audit programme for %d already exists", program.Year)
+	}
+	seen := make(map[string]bool)
+	for _, process := range program.Processes {
+		if process.ProcessID == "" {
+			return fmt.Errorf("audit programme for %d lists a process without an ID", program.Year)
+		}
+		if seen[process.ProcessID] {
+			return fmt.Errorf("audit programme for %d lists process %s twice", program.Year, process.ProcessID)
+		}
+		seen[process.ProcessID] = true
+	}
+	for _, clause := range program.Clauses {
+		if _, ok := exclusionClause(clause); !ok {
+			return fmt.Errorf("audit programme for %d names unknown clause %q", program.Year, clause)
+		}
+	}
+
+	if am.Programs == nil {
+		am.Programs = make(map[int]*AuditProgram)
+	}
+	program.Created = time.Now()
+	program.Modified = time.Now()
+	program.audits = am
+	am.Programs[program.Year] = program
+	return nil
+}
+
+// GetAuditProgram returns the audit programme of a year
+func (am *AuditManager) GetAuditProgram(year int) (*AuditProgram, error) {
+	program, exists := am.Programs[year]
+	if !exists {
+		return nil, fmt.Errorf("audit programme for %d not found", year)
+	}
+	program.audits = am
+	return program, nil
+}
+
+// Audits returns the internal, process and system audits of the programme's year,
+// ordered by date. Supplier, external and certification audits are not part of it.
+func (p *AuditProgram) Audits() []*Audit {
+	var audits []*Audit
+	if p.audits == nil {
+		return audits
+	}
+	for _, audit := range p.audits.Audits {
+		if programAudit(audit) && auditDate(audit).Year() == p.Year {
+			audits = append(audits, audit)
+		}
+	}
+	sortAudits(audits)
+	return audits
+}
+
+// RequiredAudits returns how many times a process of the programme is to be audited
+// in the year: the frequency for its risk, raised when its last audit raised a
+// critical or major finding and lowered after a run of audits without
+// nonconformities
+func (p *AuditProgram) RequiredAudits(processID string) int {
+	policy := DefaultAuditFrequencyPolicy()
+	if p.Frequency != nil {
+		policy = *p.Frequency
+	}
+	required := policy.DefaultPerYear
+	for _, process := range p.Processes {
+		if process.ProcessID == processID {
+			if perYear, ok := policy.PerYear[process.Risk]; ok {
+				required = perYear
+			}
+		}
+	}
+
+	// Previous results, oldest first
+	var previous []*Audit
+	if p.audits != nil {
+		for _, audit := range p.audits.Audits {
+			if programAudit(audit) && auditFinished(audit) && auditCoversProcess(audit, processID) && auditDate(audit).Year() <= p.Year {
+				previous = append(previous, audit)
+			}
+		}
+	}
+	sortAudits(previous)
+	if len(previous) == 0 {
+		return required
+	}
+	last := previous[len(previous)-1]
+	if hasFindingOf(last, SeverityCritical, SeverityMajor) {
+		return required + policy.ExtraAfterNonconformity
+	}
+	if policy.CleanAuditsToReduce > 0 && len(previous) >= policy.CleanAuditsToReduce && required > 1 {
+		for _, audit := range previous[len(previous)-policy.CleanAuditsToReduce:] {
+			if hasFindingOf(audit, SeverityCritical, SeverityMajor, SeverityMinor) {
+				return required
+			}
+		}
+		return required - 1
+	}
+	return required
+}
+
+// Coverage returns how often each process and clause of the programme is audited in
+// its year. An audit covers a clause when its scope names the clause or a clause
+// above it, or names neither clauses nor processes, and does not exclude it.
+func (p *AuditProgram) Coverage() []ProgramCoverage {
+	audits := p.Audits()
+	count := func(entry *ProgramCoverage, covers func(*Audit) bool) {
+		for _, audit := range audits {
+			if !covers(audit) {
+				continue
+			}
+			if auditFinished(audit) {
+				entry.Audited++
+			} else if audit.Status == AuditStatusPlanned || audit.Status == AuditStatusInProgress {
+				entry.Planned++
+			}
+		}
+	}
+
+	var coverage []ProgramCoverage
+	for _, process := range p.Processes {
+		entry := ProgramCoverage{Kind: "process", Reference: process.ProcessID, Required: p.RequiredAudits(process.ProcessID)}
+		count(&entry, func(audit *Audit) bool { return auditCoversProcess(audit, process.ProcessID) })
+		coverage = append(coverage, entry)
+	}
+	for _, clause := range p.clauses() {
+		entry := ProgramCoverage{Kind: "clause", Reference: clause, Required: 1}
+		count(&entry, func(audit *Audit) bool { return auditCoversClause(audit, clause) })
+		coverage = append(coverage, entry)
+	}
+	return coverage
+}
+
+// GetCoverageGaps returns the processes and clauses audited fewer times in the
+// programme's year than required, processes first. Planned tells whether audits to
+// close the gap are already scheduled.
+func (p *AuditProgram) GetCoverageGaps() []ProgramCoverage {
+	var gaps []ProgramCoverage
+	for _, entry := range p.Coverage() {
+		if entry.Audited < entry.Required {
+			gaps = append(gaps, entry)
+		}
+	}
+	return gaps
+}
+
+// ProposeAudits drafts the audits still needed to cover the programme's processes,
+// spread evenly over the year. They are not added to the manager; create the ones to
+// keep with CreateAudit.
+func (p *AuditProgram) ProposeAudits() []*Audit {
+	var proposed []*Audit
+	for _, entry := range p.Coverage() {
+		if entry.Kind != "process" {
+			continue
+		}
+		missing := entry.Required - entry.Audited - entry.Planned
+		for i := 0; i < missing; i++ {
+			month := time.Month(1 + (entry.Audited+entry.Planned+i)*12/entry.Required)
+			start := time.Date(p.Year, month, 1, 0, 0, 0, 0, time.UTC)
+			proposed = append(proposed, &Audit{
+				ID:               fmt.Sprintf("AUDIT-%d-%s-%d", p.Year, entry.Reference, entry.Audited+entry.Planned+i+1),
+				Title:            fmt.Sprintf("Internal audit of %s", entry.Reference),
+				Type:             AuditTypeProcess,
+				Scope:            AuditScope{Description: fmt.Sprintf("Process %s", entry.Reference), Processes: []string{entry.Reference}},
+				PlannedStartDate: start,
+				PlannedEndDate:   start.AddDate(0, 0, 1),
+			})
+		}
+	}
+	return proposed
+}
+
+// clauses returns the clauses the programme covers
+func (p *AuditProgram) clauses() []string {
+	if len(p.Clauses) > 0 {
+		clauses := make([]string, len(p.Clauses))
+		for i, clause := range p.Clauses {
+			clauses[i] = normalizeClauseReference(clause)
+		}
+		return clauses
+	}
+	clauses := make([]string, len(manualSections))
+	for i, section := range manualSections {
+		clauses[i] = section.clause
+	}
+	return clauses
+}
+
+// programAudit reports whether an audit is part of the internal audit programme
+func programAudit(audit *Audit) bool {
+	switch audit.Type {
+	case AuditTypeSupplier, AuditTypeExternal, AuditTypeCertification:
+		return false
+	}
+	return true
+}
+
+// auditFinished reports whether the fieldwork of an audit is done
+func auditFinished(audit *Audit) bool {
+	switch audit.Status {
+	case AuditStatusCompleted, AuditStatusReported, AuditStatusClosed:
+		return true
+	}
+	return false
+}
+
+// auditDate is when an audit took place, or is planned to
+func auditDate(audit *Audit) time.Time {
+	if audit.ActualStartDate != nil {
+		return *audit.ActualStartDate
+	}
+	return audit.PlannedStartDate
+}
+
+// sortAudits orders audits by date
+func sortAudits(audits []*Audit) {
+	sort.Slice(audits, func(i, j int) bool {
+		if di, dj := auditDate(audits[i]), auditDate(audits[j]); !di.Equal(dj) {
+			return di.Before(dj)
+		}
+		return audits[i].ID < audits[j].ID
+	})
+}
+
+func auditCoversProcess(audit *Audit, processID string) bool {
+	for _, id := range audit.Scope.Processes {
+		if id == processID {
+			return true
+		}
+	}
+	return false
+}
+
+func auditCoversClause(audit *Audit, clause string) bool {
+	within := func(reference string) bool {
+		reference = normalizeClauseReference(reference)
+		return clause == reference || strings.HasPrefix(clause, reference+".")
+	}
+	for _, exclusion := range audit.Scope.Exclusions {
+		if within(exclusion) {
+			return false
+		}
+	}
+	if len(audit.Scope.Clauses) == 0 {
+		// A scope of neither clauses nor processes is the whole system
+		return len(audit.Scope.Processes) == 0
+	}
+	for _, reference := range audit.Scope.Clauses {
+		if within(reference) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasFindingOf reports whether an audit raised a finding of any of the severities
+func hasFindingOf(audit *Audit, severities ...FindingSeverity) bool {
+	for _, finding := range audit.Findings {
+		for _, severity := range severities {
+			if finding.Severity == severity {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,107 @@
+package iso9001
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAuditProgramCoverage(t *testing.T) {
+	am := NewAuditManager()
+	program := &AuditProgram{
+		Year: 2026,
+		Processes: []ProgramProcess{
+			{ProcessID: "PROC-001", Risk: RiskLevelHigh},
+			{ProcessID: "PROC-002", Risk: RiskLevelLow},
+		},
+		Clauses: []string{"7.5", "Clause 8.4"},
+	}
+	if err := am.CreateAuditProgram(program); err != nil {
+		t.Fatalf("Failed to create audit programme: %v", err)
+	}
+	if err := am.CreateAuditProgram(&AuditProgram{Year: 2026}); err == nil {
+		t.Error("Expected error for a second programme in the same year")
+	}
+
+	audit := func(id string, date time.Time, scope AuditScope, status AuditStatus, findings ...FindingSeverity) {
+		scope.Description = id
+		if err := am.CreateAudit(&Audit{ID: id, Title: id, Type: AuditTypeInternal, Scope: scope, PlannedStartDate: date}); err != nil {
+			t.Fatalf("Failed to create audit: %v", err)
+		}
+		for _, severity := range findings {
+			am.AddFinding(id, AuditFinding{Description: "Finding", Severity: severity})
+		}
+		am.Audits[id].Status = status
+	}
+	audit("AUD-2025", time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC), AuditScope{Processes: []string{"PROC-002"}}, AuditStatusClosed, SeverityMajor)
+	audit("AUD-1", time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), AuditScope{Processes: []string{"PROC-001"}, Clauses: []string{"7"}, Exclusions: []string{"7.1"}}, AuditStatusCompleted)
+	audit("AUD-2", time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC), AuditScope{Processes: []string{"PROC-001"}}, AuditStatusPlanned)
+	audit("AUD-SUP", time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), AuditScope{}, AuditStatusCompleted)
+	am.Audits["AUD-SUP"].Type = AuditTypeSupplier
+
+	if got := program.RequiredAudits("PROC-002"); got != 2 {
+		t.Errorf("Expected a major finding last time to add an audit, got %d", got)
+	}
+	if audits := program.Audits(); len(audits) != 2 || audits[0].ID != "AUD-1" {
+		t.Errorf("Expected the internal audits of 2026, got %d", len(audits))
+	}
+
+	gaps := program.GetCoverageGaps()
+	byReference := make(map[string]ProgramCoverage)
+	for _, gap := range gaps {
+		byReference[gap.Reference] = gap
+	}
+	if gap, ok := byReference["PROC-001"]; !ok || gap.Required != 2 || gap.Audited != 1 || gap.Planned != 1 {
+		t.Errorf("Expected PROC-001 to be audited once of twice with one planned, got %+v", gap)
+	}
+	if gap, ok := byReference["PROC-002"]; !ok || gap.Audited != 0 {
+		t.Errorf("Expected PROC-002 to be a gap, got %+v", gap)
+	}
+	if _, ok := byReference["7.5"]; ok {
+		t.Error("Expected clause 7.5 to be covered by the audit of clause 7")
+	}
+	if gap, ok := byReference["8.4"]; !ok || gap.Kind != "clause" {
+		t.Errorf("Expected clause 8.4 to be a gap, got %+v", gaps)
+	}
+
+	proposed := program.ProposeAudits()
+	if len(proposed) != 2 {
+		t.Fatalf("Expected two proposed audits for PROC-002, got %d", len(proposed))
+	}
+	if proposed[0].Scope.Processes[0] != "PROC-002" || proposed[1].PlannedStartDate.Month() != time.July {
+		t.Errorf("Expected audits of PROC-002 spread over the year, got %+v", proposed[1])
+	}
+	for _, audit := range proposed {
+		if err := am.CreateAudit(audit); err != nil {
+			t.Errorf("Expected proposed audit to be valid: %v", err)
+		}
+	}
+}
+
+func TestAuditProgramFrequency(t *testing.T) {
+	org := CreateExampleOrganization()
+	org.QMS.Processes[0].Risks = []Risk{{ID: "RISK-001", Priority: PriorityCritical}, {ID: "RISK-002", Priority: PriorityLow}}
+	program := PlanAuditProgram(org, 2026)
+	if len(program.Processes) != len(org.QMS.Processes) || program.Processes[0].Risk != RiskLevelVeryHigh {
+		t.Fatalf("Expected processes rated by their highest risk, got %+v", program.Processes)
+	}
+
+	am := NewAuditManager()
+	am.CreateAuditProgram(program)
+	processID := program.Processes[0].ProcessID
+	for i := 0; i < 3; i++ {
+		id := string(rune('A' + i))
+		am.CreateAudit(&Audit{ID: id, Title: id, Scope: AuditScope{Description: id, Processes: []string{processID}}, PlannedStartDate: time.Date(2025, time.Month(1+i), 1, 0, 0, 0, 0, time.UTC)})
+		am.Audits[id].Status = AuditStatusClosed
+	}
+	if got := program.RequiredAudits(processID); got != 3 {
+		t.Errorf("Expected three clean audits to lower the frequency from 4 to 3, got %d", got)
+	}
+
+	program.Frequency = &AuditFrequencyPolicy{DefaultPerYear: 1}
+	if got := program.RequiredAudits(processID); got != 1 {
+		t.Errorf("Expected the programme's policy to apply, got %d", got)
+	}
+	if _, err := am.GetAuditProgram(2027); err == nil {
+		t.Error("Expected error for a year without programme")
+	}
+}
@@ -0,0 +1,335 @@
+package iso9001
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// ErrTrailTampered is returned when an audit trail entry no longer matches its hash
+var ErrTrailTampered = errors.New("audit trail tampered")
+
+// TrailEntry records one change to an entity. Its hash covers the entry and the hash
+// of the entry before it, so entries cannot be altered, removed or reordered without
+// breaking the chain.
+type TrailEntry struct {
+	Sequence   uint64          `json:"sequence" yaml:"sequence"`
+	Timestamp  time.Time       `json:"timestamp" yaml:"timestamp"`
+	Actor      string          `json:"actor" yaml:"actor"`
+	Action     string          `json:"action,omitempty" yaml:"action,omitempty"` // operation that made the change
+	EntityType string          `json:"entity_type" yaml:"entity_type"`
+	EntityID   string          `json:"entity_id" yaml:"entity_id"`
+	Operation  ChangeOperation `json:"operation" yaml:"operation"`
+	Before     string          `json:"before,omitempty" yaml:"before,omitempty"` // summary of the entity before the change
+	After      string          `json:"after,omitempty" yaml:"after,omitempty"`   // summary of the entity after the change
+	Hash       string          `json:"hash" yaml:"hash"`
+}
+
+// AuditTrail is the hash-chained record of every change to the entities of a tenant:
+// who made it, what changed and when. It is saved with the tenant, so it documents
+// the QMS records themselves for certification audits. Entries are only appended;
+// Verify detects any later edit. Once keyed with SetKey, the chain is an HMAC, so
+// only holders of the key can rewrite the trail and recompute a valid chain.
+type AuditTrail struct {
+	Entries []TrailEntry `json:"entries" yaml:"entries"`
+
+	key []byte
+}
+
+// NewAuditTrail creates an empty audit trail
+func NewAuditTrail() *AuditTrail {
+	return &AuditTrail{Entries: []TrailEntry{}}
+}
+
+// Record appends an entry, assigning its sequence number, its hash and, when unset,
+// its timestamp
+func (t *AuditTrail) Record(entry TrailEntry) TrailEntry {
+	previous := ""
+	entry.Sequence = 1
+	if n := len(t.Entries); n > 0 {
+		previous = t.Entries[n-1].Hash
+		entry.Sequence = t.Entries[n-1].Sequence + 1
+	}
+	if entry.Timestamp.IsZero() {
+		entry.Timestamp = time.Now()
+	}
+	entry.Timestamp = entry.Timestamp.UTC()
+	entry.Hash = trailHash(t.key, previous, entry)
+	t.Entries = append(t.Entries, entry)
+	return entry
+}
+
+// Query returns the entries matching the query in the order they were recorded
+func (t *AuditTrail) Query(query ActivityQuery) []TrailEntry {
+	matches := []TrailEntry{}
+	for _, entry := range t.Entries {
+		if query.Actor != "" && entry.Actor != query.Actor {
+			continue
+		}
+		if query.Action != "" && entry.Action != query.Action {
+			continue
+		}
+		if query.EntityType != "" && entry.EntityType != query.EntityType {
+			continue
+		}
+		if query.EntityID != "" && entry.EntityID != query.EntityID {
+			continue
+		}
+		if !query.Since.IsZero() && entry.Timestamp.Before(query.Since) {
+			continue
+		}
+		if !query.Until.IsZero() && entry.Timestamp.After(query.Until) {
+			continue
+		}
+		matches = append(matches, entry)
+	}
+	if query.Limit > 0 && len(matches) > query.Limit {
+		matches = matches[len(matches)-query.Limit:]
+	}
+	return matches
+}
+
+// SetKey sets the secret key the chain is computed with, e.g. one read with
+// LoadTrailKey. Entries already in the trail must have been hashed with the same key;
+// Seal rehashes a trail kept with another key or none.
+func (t *AuditTrail) SetKey(key []byte) {
+	t.key = key
+}
+
+// Verify recomputes the hash chain and returns ErrTrailTampered at the first entry
+// that was altered, removed or reordered
+func (t *AuditTrail) Verify() error {
+	previous := ""
+	for i, entry := range t.Entries {
+		if entry.Sequence != uint64(i+1) {
+			return fmt.Errorf("%w: entry %d has sequence %d", ErrTrailTampered, i+1, entry.Sequence)
+		}
+		if trailHash(t.key, previous, entry) != entry.Hash {
+			return fmt.Errorf("%w: entry %d does not match its hash", ErrTrailTampered, entry.Sequence)
+		}
+		previous = entry.Hash
+	}
+	return nil
+}
+
+// Seal verifies the trail with its current key, then rehashes every entry with key and
+// keeps key for later entries. It migrates a trail recorded without a key, or rotates
+// the key; a tampered trail is left unchanged.
+func (t *AuditTrail) Seal(key []byte) error {
+	if err := t.Verify(); err != nil {
+		return err
+	}
+	t.rehash(key)
+	return nil
+}
+
+// rehash recomputes the chain with key, whether or not the entries match their hashes
+func (t *AuditTrail) rehash(key []byte) {
+	previous := ""
+	for i := range t.Entries {
+		t.Entries[i].Hash = trailHash(key, previous, t.Entries[i])
+		previous = t.Entries[i].Hash
+	}
+	t.key = key
+}
+
+// trailHash hashes an entry, without its own hash, chained to the previous hash. With
+// a key the hash is an HMAC-SHA256, otherwise a plain SHA-256.
+func trailHash(key []byte, previous string, entry TrailEntry) string {
+	entry.Hash = ""
+	data, _ := json.Marshal(entry) // a struct of strings, numbers and a time always encodes
+	if key == nil {
+		sum := sha256.Sum256(append([]byte(previous), data...))
+		return hex.EncodeToString(sum[:])
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(previous))
+	mac.Write(data)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// TrailKeyFile returns the location of the audit trail key within a tenant store
+// directory, next to the API key file
+func TrailKeyFile(storeDir string) string {
+	return filepath.Join(storeDir, "keys", "trail.key")
+}
+
+// LoadTrailKey reads the audit trail key at path, creating a random one readable
+// only by the owner when the file does not exist yet
+func LoadTrailKey(path string) ([]byte, error) {
+	key, err := os.ReadFile(path)
+	if err == nil {
+		if len(key) == 0 {
+			return nil, fmt.Errorf("audit trail key %s is empty", path)
+		}
+		return key, nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read audit trail key: %w", err)
+	}
+
+	key = make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("failed to generate audit trail key: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("failed to create key directory: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		if errors.Is(err, os.ErrExist) {
+			// another process created it first
+			return LoadTrailKey(path)
+		}
+		return nil, fmt.Errorf("failed to write audit trail key: %w", err)
+	}
+	if _, err := file.Write(key); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to write audit trail key: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write audit trail key: %w", err)
+	}
+	return key, nil
+}
+
+// Change runs fn on the tenant and records in its audit trail each organization,
+// document, risk, opportunity, objective, audit, finding and management review fn
+// created, updated or deleted, attributed to actor and action. When fn fails, or
+// leaves the organization with an invalid time zone or calendar, the changes it made
+// are rolled back and nothing is recorded. Otherwise the tenant is marked as modified,
+// so a TenantStore saves it on the next flush.
+func (t *Tenant) Change(actor, action string, fn func(tenant *Tenant) error) error {
+	saved, err := t.copy()
+	if err != nil {
+		return fmt.Errorf("failed to snapshot tenant %s: %w", t.ID, err)
+	}
+	before := t.trailSnapshot()
+	err = fn(t)
+	if err == nil {
+		err = t.ConfigureDueDates()
+	}
+	if err != nil {
+		t.Replace(saved)
+		return err
+	}
+	t.dirty = true
+	after := t.trailSnapshot()
+
+	if t.Trail == nil {
+		t.Trail = NewAuditTrail()
+	}
+	now := time.Now()
+	for _, key := range trailKeys(before, after) {
+		old, existed := before[key]
+		current, exists := after[key]
+		entry := TrailEntry{Timestamp: now, Actor: actor, Action: action, EntityType: key.entityType, EntityID: key.id}
+		switch {
+		case !existed:
+			entry.Operation = ChangeOperationCreated
+			entry.After = current.summary
+		case !exists:
+			entry.Operation = ChangeOperationDeleted
+			entry.Before = old.summary
+		case old.digest != current.digest:
+			entry.Operation = ChangeOperationUpdated
+			entry.Before = old.summary
+			entry.After = current.summary
+		default:
+			continue
+		}
+		t.Trail.Record(entry)
+	}
+	return nil
+}
+
+// trailKey identifies an entity in a trail snapshot
+type trailKey struct {
+	entityType, id string
+}
+
+// trailState is what a snapshot keeps of an entity
+type trailState struct {
+	summary string
+	data    []byte // the entity as JSON
+	digest  [sha256.Size]byte
+}
+
+// trailSnapshot captures the state of the tenant's entities
+func (t *Tenant) trailSnapshot() map[trailKey]trailState {
+	snapshot := make(map[trailKey]trailState)
+	t.eachEntity(func(entityType, id string, entity interface{}) {
+		data, err := json.Marshal(entity)
+		if err != nil {
+			data = []byte(fmt.Sprintf("%p", entity))
+		}
+		snapshot[trailKey{entityType, id}] = trailState{summary: SummarizeEntity(entity), data: data, digest: sha256.Sum256(data)}
+	})
+	return snapshot
+}
+
+// eachEntity calls fn for each entity of the tenant the audit trail follows
+func (t *Tenant) eachEntity(fn func(entityType, id string, entity interface{})) {
+	if t.Organization != nil {
+		fn(EntityTypeOrganization, t.Organization.ID, t.Organization)
+	}
+	if t.Documents != nil {
+		for id, doc := range t.Documents.Documents {
+			fn(EntityTypeDocument, id, doc)
+		}
+	}
+	if t.Risks != nil {
+		for id, risk := range t.Risks.Risks {
+			fn(EntityTypeRisk, id, risk)
+		}
+		for id, opportunity := range t.Risks.Opportunities {
+			fn(EntityTypeOpportunity, id, opportunity)
+		}
+	}
+	if t.Objectives != nil {
+		for id, objective := range t.Objectives.Objectives {
+			fn(EntityTypeObjective, id, objective)
+		}
+	}
+	if t.Audits != nil {
+		for id, audit := range t.Audits.Audits {
+			fn(EntityTypeAudit, id, audit)
+			for _, finding := range audit.Findings {
+				if finding.ID != "" {
+					fn(EntityTypeFinding, finding.ID, finding)
+				}
+			}
+		}
+		for id, review := range t.Audits.ManagementReviews {
+			fn(EntityTypeManagementReview, id, review)
+		}
+	}
+}
+
+// trailKeys returns the keys of both snapshots in a stable order
+func trailKeys(before, after map[trailKey]trailState) []trailKey {
+	var keys []trailKey
+	for key := range before {
+		keys = append(keys, key)
+	}
+	for key := range after {
+		if _, ok := before[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].entityType != keys[j].entityType {
+			return keys[i].entityType < keys[j].entityType
+		}
+		return keys[i].id < keys[j].id
+	})
+	return keys
+}
@@ -0,0 +1,188 @@
+package iso9001
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTenantChangeRecordsAuditTrail(t *testing.T) {
+	tenant := NewTenant("acme")
+	start := time.Now().Add(-time.Second)
+
+	err := tenant.Change("jane", "identify", func(tenant *Tenant) error {
+		tenant.Organization.Name = "Acme"
+		return tenant.Risks.IdentifyRisk(&Risk{ID: "RISK-001", Description: "Supplier fails"})
+	})
+	if err != nil {
+		t.Fatalf("Change failed: %v", err)
+	}
+	tenant.Change("carl", "audit", func(tenant *Tenant) error {
+		tenant.Risks.AssessRisk("RISK-001", RiskLevelHigh, RiskLevelMedium)
+		tenant.Audits.CreateAudit(&Audit{ID: "AUDIT-001", Title: "Purchasing", Scope: AuditScope{Description: "Purchasing"}})
+		return tenant.Audits.AddFinding("AUDIT-001", AuditFinding{ID: "F-001", Description: "No evaluation", Severity: SeverityMinor})
+	})
+	failure := errors.New("rejected")
+	if err := tenant.Change("carl", "remove", func(tenant *Tenant) error {
+		delete(tenant.Risks.Risks, "RISK-001")
+		return failure
+	}); err != failure {
+		t.Errorf("Expected the error of fn, got %v", err)
+	}
+	tenant.Change("carl", "read", func(tenant *Tenant) error { return nil })
+
+	if _, ok := tenant.Risks.Risks["RISK-001"]; !ok {
+		t.Error("Expected failed change to be rolled back")
+	}
+
+	entries := tenant.Trail.Query(ActivityQuery{EntityID: "RISK-001"})
+	if len(entries) != 2 {
+		t.Fatalf("Expected risk to be created and updated only, got %+v", entries)
+	}
+	if entries[0].Operation != ChangeOperationCreated || entries[0].Actor != "jane" || entries[0].Action != "identify" {
+		t.Errorf("Unexpected creation entry %+v", entries[0])
+	}
+	if entries[1].Operation != ChangeOperationUpdated || entries[1].Before == entries[1].After {
+		t.Errorf("Expected update with summaries before and after, got %+v", entries[1])
+	}
+	if got := tenant.Trail.Query(ActivityQuery{EntityType: EntityTypeFinding}); len(got) != 1 || got[0].EntityID != "F-001" {
+		t.Errorf("Expected finding creation to be recorded, got %+v", got)
+	}
+	if got := tenant.Trail.Query(ActivityQuery{EntityType: EntityTypeOrganization, Since: start, Until: time.Now()}); len(got) != 1 {
+		t.Errorf("Expected organization edit in time range, got %+v", got)
+	}
+	if got := tenant.Trail.Query(ActivityQuery{Since: time.Now().Add(time.Hour)}); len(got) != 0 {
+		t.Errorf("Expected no entries after now, got %d", len(got))
+	}
+	if got := tenant.Trail.Query(ActivityQuery{Action: "read"}); len(got) != 0 {
+		t.Errorf("Expected no entries for a change without effect, got %+v", got)
+	}
+}
+
+func TestAuditTrailVerify(t *testing.T) {
+	tenant := NewTenant("acme")
+	for _, id := range []string{"DOC-001", "DOC-002", "DOC-003"} {
+		tenant.Change("jane", "add", func(tenant *Tenant) error {
+			return tenant.Documents.AddDocument(&DocumentedInformation{ID: id, Title: id})
+		})
+	}
+	if err := tenant.Trail.Verify(); err != nil {
+		t.Fatalf("Expected intact trail, got %v", err)
+	}
+
+	data, err := json.Marshal(tenant)
+	if err != nil {
+		t.Fatalf("Failed to encode tenant: %v", err)
+	}
+	loaded, err := LoadTenantJSON(data)
+	if err != nil {
+		t.Fatalf("Failed to load tenant: %v", err)
+	}
+	if err := loaded.Trail.Verify(); err != nil || len(loaded.Trail.Entries) != 3 {
+		t.Errorf("Expected trail to survive JSON, got %d entries and %v", len(loaded.Trail.Entries), err)
+	}
+	var yamlData bytes.Buffer
+	if err := SaveTenantToYAML(&yamlData, tenant); err != nil {
+		t.Fatalf("Failed to save YAML: %v", err)
+	}
+	if loaded, err = LoadTenantFromYAML(yamlData.Bytes()); err != nil {
+		t.Fatalf("Failed to load YAML: %v", err)
+	}
+	if err := loaded.Trail.Verify(); err != nil {
+		t.Errorf("Expected trail to survive YAML, got %v", err)
+	}
+
+	loaded.Trail.Entries[1].Actor = "mallory"
+	if err := loaded.Trail.Verify(); !errors.Is(err, ErrTrailTampered) {
+		t.Errorf("Expected edited entry to be detected, got %v", err)
+	}
+	tenant.Trail.Entries = append(tenant.Trail.Entries[:1], tenant.Trail.Entries[2:]...)
+	if err := tenant.Trail.Verify(); !errors.Is(err, ErrTrailTampered) {
+		t.Errorf("Expected removed entry to be detected, got %v", err)
+	}
+}
+
+func TestAuditTrailKey(t *testing.T) {
+	tenant := NewTenant("acme")
+	tenant.Change("jane", "add", func(tenant *Tenant) error {
+		return tenant.Documents.AddDocument(&DocumentedInformation{ID: "DOC-001", Title: "Manual"})
+	})
+
+	key := []byte("secret")
+	if err := tenant.Trail.Seal(key); err != nil {
+		t.Fatalf("Failed to seal trail: %v", err)
+	}
+	tenant.Change("jane", "add", func(tenant *Tenant) error {
+		return tenant.Documents.AddDocument(&DocumentedInformation{ID: "DOC-002", Title: "Procedure"})
+	})
+	if err := tenant.Trail.Verify(); err != nil {
+		t.Fatalf("Expected sealed trail to verify, got %v", err)
+	}
+
+	// rewriting an entry and recomputing the chain without the key is detected
+	forged := &AuditTrail{}
+	for _, entry := range tenant.Trail.Entries {
+		entry.Actor = "mallory"
+		forged.Record(entry)
+	}
+	forged.SetKey(key)
+	if err := forged.Verify(); !errors.Is(err, ErrTrailTampered) {
+		t.Errorf("Expected chain forged without the key to be detected, got %v", err)
+	}
+
+	tenant.Trail.SetKey([]byte("other"))
+	if err := tenant.Trail.Seal(key); !errors.Is(err, ErrTrailTampered) {
+		t.Errorf("Expected seal with the wrong current key to fail, got %v", err)
+	}
+}
+
+func TestLoadTrailKey(t *testing.T) {
+	path := TrailKeyFile(t.TempDir())
+	key, err := LoadTrailKey(path)
+	if err != nil || len(key) != 32 {
+		t.Fatalf("Expected new 32-byte key, got %d bytes and %v", len(key), err)
+	}
+	again, err := LoadTrailKey(path)
+	if err != nil || !bytes.Equal(key, again) {
+		t.Errorf("Expected the same key on reload, got %v", err)
+	}
+}
+
+func TestTenantReplaceKeepsTrail(t *testing.T) {
+	tenant := NewTenant("acme")
+	tenant.Change("jane", "add", func(tenant *Tenant) error {
+		return tenant.Documents.AddDocument(&DocumentedInformation{ID: "DOC-001", Title: "Manual"})
+	})
+	trail := tenant.Trail
+
+	imported := NewTenant("other")
+	imported.Risks.IdentifyRisk(&Risk{ID: "RISK-001", Description: "Supplier fails"})
+	imported.Trail.Record(TrailEntry{Actor: "mallory", EntityType: EntityTypeRisk, EntityID: "RISK-001", Operation: ChangeOperationCreated})
+
+	if err := tenant.Change("carl", "import", func(tenant *Tenant) error {
+		tenant.Replace(imported)
+		return nil
+	}); err != nil {
+		t.Fatalf("Change failed: %v", err)
+	}
+	if tenant.Trail != trail || tenant.Organization.ID != "acme" {
+		t.Errorf("Expected tenant to keep its ID and trail")
+	}
+	if _, ok := tenant.Risks.Risks["RISK-001"]; !ok {
+		t.Error("Expected imported risk")
+	}
+	if got := tenant.Trail.Query(ActivityQuery{Actor: "mallory"}); len(got) != 0 {
+		t.Errorf("Expected imported trail to be ignored, got %+v", got)
+	}
+	if got := tenant.Trail.Query(ActivityQuery{Action: "import", EntityID: "DOC-001"}); len(got) != 1 || got[0].Operation != ChangeOperationDeleted {
+		t.Errorf("Expected import to record the removed document, got %+v", got)
+	}
+	if got := tenant.Trail.Query(ActivityQuery{Action: "import", EntityID: "RISK-001"}); len(got) != 1 || got[0].Operation != ChangeOperationCreated {
+		t.Errorf("Expected import to record the added risk, got %+v", got)
+	}
+	if err := tenant.Trail.Verify(); err != nil {
+		t.Errorf("Expected intact trail, got %v", err)
+	}
+}
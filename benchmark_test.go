@@ -0,0 +1,230 @@
+package iso9001
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+)
+
+var (
+	fixtureSize     = flag.Int("fixture.size", 500, "number of processes, risks and documents in generated benchmark fixtures")
+	checkBudgets    = flag.Bool("perf.budgets", false, "run benchmarks and fail when they regress beyond the stored baselines")
+	updateBaselines = flag.Bool("perf.update", false, "run benchmarks and overwrite the stored baselines")
+	budgetTolerance = flag.Float64("perf.tolerance", 2.0, "allowed slowdown factor relative to the stored baselines")
+)
+
+const baselinesFile = "testdata/benchmark_baselines.json"
+
+// benchmarkBaseline stores the reference timing of a benchmark
+type benchmarkBaseline struct {
+	NsPerOp     int64 `json:"ns_per_op"`
+	FixtureSize int   `json:"fixture_size"`
+}
+
+// generateBenchmarkOrganization builds an organization with the given number of
+// processes, risks, opportunities and objectives
+func generateBenchmarkOrganization(size int) *Organization {
+	org := CreateExampleOrganization()
+	org.QMS.Processes = make([]Process, 0, size)
+	org.QMS.Risks = make([]Risk, 0, size)
+	org.QMS.Opportunities = make([]Opportunity, 0, size)
+	org.QMS.Objectives = make([]QualityObjective, 0, size)
+
+	for i := 0; i < size; i++ {
+		org.QMS.Processes = append(org.QMS.Processes, Process{
+			ID:               fmt.Sprintf("PROC-%05d", i),
+			Name:             fmt.Sprintf("Process %d", i),
+			Inputs:           []ProcessInput{{Name: "Input"}},
+			Outputs:          []ProcessOutput{{Name: "Output"}},
+			Responsibilities: []string{"Owner"},
+			Criteria:         []ProcessCriteria{{Name: "Criterion", Metric: "metric", Target: "100%"}},
+		})
+		org.QMS.Risks = append(org.QMS.Risks, Risk{
+			ID:          fmt.Sprintf("RISK-%05d", i),
+			Description: fmt.Sprintf("Risk %d", i),
+		})
+		org.QMS.Opportunities = append(org.QMS.Opportunities, Opportunity{
+			ID:          fmt.Sprintf("OPP-%05d", i),
+			Description: fmt.Sprintf("Opportunity %d", i),
+		})
+		org.QMS.Objectives = append(org.QMS.Objectives, QualityObjective{
+			ID:          fmt.Sprintf("OBJ-%05d", i),
+			Name:        fmt.Sprintf("Objective %d", i),
+			Measurable:  true,
+			Targets:     []ObjectiveTarget{{Metric: "metric", Value: "100"}},
+			Responsible: "Owner",
+			Timeline:    ObjectiveTimeline{TargetDate: time.Now().AddDate(0, 6, 0)},
+		})
+	}
+
+	return org
+}
+
+// generateBenchmarkDocuments builds a documentation manager holding size documents
+func generateBenchmarkDocuments(size int) *DocumentationManager {
+	dm := NewDocumentationManager()
+	types := []DocumentType{DocumentTypePolicy, DocumentTypeProcedure, DocumentTypeWorkInstruction, DocumentTypeRecord}
+
+	for i := 0; i < size; i++ {
+		dm.AddDocument(&DocumentedInformation{
+			ID:       fmt.Sprintf("DOC-%05d", i),
+			Title:    fmt.Sprintf("Document %d", i),
+			Type:     types[i%len(types)],
+			Category: CategoryQualityManagement,
+			Metadata: DocumentMetadata{
+				Author:         "Author",
+				Owner:          "Owner",
+				Keywords:       []string{"quality", fmt.Sprintf("kw%d", i%20)},
+				RelatedClauses: []string{"7.5"},
+			},
+		})
+	}
+
+	return dm
+}
+
+func BenchmarkOrganizationValidation(b *testing.B) {
+	org := CreateExampleOrganization()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ValidateOrganization(org)
+	}
+}
+
+func BenchmarkComplianceScore(b *testing.B) {
+	org := CreateExampleOrganization()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		GetComplianceScore(org)
+	}
+}
+
+func BenchmarkLargeOrganizationValidation(b *testing.B) {
+	org := generateBenchmarkOrganization(*fixtureSize)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ValidateOrganization(org)
+	}
+}
+
+func BenchmarkLargeOrganizationValidationParallel(b *testing.B) {
+	org := generateBenchmarkOrganization(*fixtureSize)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ValidateOrganizationWithOptions(org, ValidationOptions{Workers: 4})
+	}
+}
+
+func BenchmarkGenerateComplianceReport(b *testing.B) {
+	org := generateBenchmarkOrganization(*fixtureSize)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		GenerateComplianceReport(org)
+	}
+}
+
+func BenchmarkDocumentSearch(b *testing.B) {
+	dm := generateBenchmarkDocuments(*fixtureSize)
+	docType := DocumentTypeProcedure
+	keyword := "kw7"
+	criteria := DocumentSearchCriteria{Type: &docType, Keyword: &keyword}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		dm.SearchDocuments(criteria)
+	}
+}
+
+func BenchmarkRiskRegisterUpdate(b *testing.B) {
+	rm := NewRiskManager()
+	for i := 0; i < *fixtureSize; i++ {
+		rm.IdentifyRisk(&Risk{ID: fmt.Sprintf("RISK-%05d", i), Description: "Benchmark risk"})
+	}
+	levels := []RiskLevel{RiskLevelLow, RiskLevelMedium, RiskLevelHigh, RiskLevelVeryHigh}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		id := fmt.Sprintf("RISK-%05d", i%*fixtureSize)
+		rm.AssessRisk(id, levels[i%len(levels)], levels[(i/3)%len(levels)])
+	}
+}
+
+// budgetedBenchmarks are the benchmarks checked against the stored baselines
+var budgetedBenchmarks = map[string]func(b *testing.B){
+	"OrganizationValidation":      BenchmarkOrganizationValidation,
+	"ComplianceScore":             BenchmarkComplianceScore,
+	"LargeOrganizationValidation": BenchmarkLargeOrganizationValidation,
+	"GenerateComplianceReport":    BenchmarkGenerateComplianceReport,
+	"DocumentSearch":              BenchmarkDocumentSearch,
+	"RiskRegisterUpdate":          BenchmarkRiskRegisterUpdate,
+}
+
+// TestPerformanceBudgets runs the benchmark suite and compares it against the stored
+// baselines. It only runs when -perf.budgets or -perf.update is set.
+func TestPerformanceBudgets(t *testing.T) {
+	if !*checkBudgets && !*updateBaselines {
+		t.Skip("performance budgets are only checked with -perf.budgets or -perf.update")
+	}
+
+	baselines := map[string]benchmarkBaseline{}
+	if data, err := os.ReadFile(baselinesFile); err == nil {
+		if err := json.Unmarshal(data, &baselines); err != nil {
+			t.Fatalf("Failed to parse %s: %v", baselinesFile, err)
+		}
+	} else if !*updateBaselines {
+		t.Fatalf("Failed to read %s: %v", baselinesFile, err)
+	}
+
+	names := make([]string, 0, len(budgetedBenchmarks))
+	for name := range budgetedBenchmarks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		result := testing.Benchmark(budgetedBenchmarks[name])
+		nsPerOp := result.NsPerOp()
+		t.Logf("%s: %d ns/op", name, nsPerOp)
+
+		if *updateBaselines {
+			baselines[name] = benchmarkBaseline{NsPerOp: nsPerOp, FixtureSize: *fixtureSize}
+			continue
+		}
+
+		baseline, ok := baselines[name]
+		if !ok {
+			t.Errorf("No baseline stored for %s", name)
+			continue
+		}
+		if baseline.FixtureSize != *fixtureSize {
+			t.Logf("Skipping %s: baseline recorded with fixture size %d", name, baseline.FixtureSize)
+			continue
+		}
+		if limit := float64(baseline.NsPerOp) * *budgetTolerance; float64(nsPerOp) > limit {
+			t.Errorf("%s regressed: %d ns/op exceeds budget of %.0f ns/op", name, nsPerOp, limit)
+		}
+	}
+
+	if *updateBaselines {
+		data, err := json.MarshalIndent(baselines, "", "  ")
+		if err != nil {
+			t.Fatalf("Failed to marshal baselines: %v", err)
+		}
+		if err := os.MkdirAll(filepath.Dir(baselinesFile), 0o755); err != nil {
+			t.Fatalf("Failed to create testdata directory: %v", err)
+		}
+		if err := os.WriteFile(baselinesFile, append(data, '\n'), 0o644); err != nil {
+			t.Fatalf("Failed to write %s: %v", baselinesFile, err)
+		}
+	}
+}
@@ -0,0 +1,276 @@
+package iso9001
+
+import (
+	"fmt"
+	"time"
+)
+
+// DefaultBulkBatchSize is the batch size used when BulkOptions.BatchSize is not set
+const DefaultBulkBatchSize = 100
+
+// BulkOptions configures bulk import operations
+type BulkOptions struct {
+	// BatchSize is the number of items written per batch
+	BatchSize int
+	// OnBatch is called with the IDs of each batch after it has been written to the
+	// manager, e.g. to flush the batch to a storage backend. Returning an error rolls
+	// the batch back and reports every item in it as failed.
+	OnBatch func(ids []string) error
+}
+
+// BulkItemError describes why a single item of a bulk import was rejected
+type BulkItemError struct {
+	Index int    `json:"index" yaml:"index"`
+	ID    string `json:"id" yaml:"id"`
+	Err   error  `json:"-" yaml:"-"`
+}
+
+func (e BulkItemError) Error() string {
+	return fmt.Sprintf("item %d (%s): %v", e.Index, e.ID, e.Err)
+}
+
+func (e BulkItemError) Unwrap() error {
+	return e.Err
+}
+
+// BulkResult summarizes the outcome of a bulk import
+type BulkResult struct {
+	Added  int             `json:"added" yaml:"added"`
+	Errors []BulkItemError `json:"errors" yaml:"errors"`
+}
+
+// HasErrors reports whether any item was rejected
+func (r BulkResult) HasErrors() bool {
+	return len(r.Errors) > 0
+}
+
+// bulkOps describes how a manager validates and stores items of one type
+type bulkOps[T any] struct {
+	id       func(T) string
+	claim    func(id string) error
+	validate func(T) error
+	write    func(batch []T)
+	rollback func(batch []T)
+}
+
+// bulkAdd validates all items up front, rejecting invalid items and duplicate IDs
+// within the input or against the manager, then writes the remaining items in batches
+func bulkAdd[T any](items []T, opts BulkOptions, ops bulkOps[T]) BulkResult {
+	result := BulkResult{}
+
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultBulkBatchSize
+	}
+
+	var valid []T
+	var validIndex []int
+	seen := make(map[string]int)
+
+	for i, item := range items {
+		id := ops.id(item)
+		if err := ops.validate(item); err != nil {
+			result.Errors = append(result.Errors, BulkItemError{Index: i, ID: id, Err: err})
+			continue
+		}
+		if first, dup := seen[id]; dup {
+			result.Errors = append(result.Errors, BulkItemError{Index: i, ID: id, Err: fmt.Errorf("duplicate ID %s (first seen at item %d)", id, first)})
+			continue
+		}
+		if err := ops.claim(id); err != nil {
+			result.Errors = append(result.Errors, BulkItemError{Index: i, ID: id, Err: err})
+			continue
+		}
+		seen[id] = i
+		valid = append(valid, item)
+		validIndex = append(validIndex, i)
+	}
+
+	for start := 0; start < len(valid); start += batchSize {
+		end := start + batchSize
+		if end > len(valid) {
+			end = len(valid)
+		}
+		batch := valid[start:end]
+
+		ops.write(batch)
+
+		if opts.OnBatch != nil {
+			ids := make([]string, len(batch))
+			for i, item := range batch {
+				ids[i] = ops.id(item)
+			}
+			if err := opts.OnBatch(ids); err != nil {
+				ops.rollback(batch)
+				for i := range batch {
+					result.Errors = append(result.Errors, BulkItemError{Index: validIndex[start+i], ID: ids[i], Err: fmt.Errorf("batch write failed: %w", err)})
+				}
+				continue
+			}
+		}
+
+		result.Added += len(batch)
+	}
+
+	return result
+}
+
+// BulkAddDocuments adds documents in batches, returning per-item errors
+func (dm *DocumentationManager) BulkAddDocuments(docs []*DocumentedInformation, opts BulkOptions) BulkResult {
+	if dm.Repository != nil {
+		// Save each batch before the caller's OnBatch so a failure rolls the batch back
+		onBatch := opts.OnBatch
+		opts.OnBatch = func(ids []string) error {
+			for _, id := range ids {
+				doc := dm.Documents[id]
+				if err := dm.save(doc, fmt.Sprintf("Add %s: %s", doc.ID, doc.Title)); err != nil {
+					return err
+				}
+			}
+			if onBatch != nil {
+				return onBatch(ids)
+			}
+			return nil
+		}
+	}
+	return bulkAdd(docs, opts, bulkOps[*DocumentedInformation]{
+		id:       func(doc *DocumentedInformation) string { return doc.ID },
+		claim:    func(id string) error { _, ok := dm.Documents[id]; return claimID(dm.IDs, id, EntityTypeDocument, ok) },
+		validate: checkNewDocument,
+		write: func(batch []*DocumentedInformation) {
+			for _, doc := range batch {
+				dm.insertDocument(doc)
+			}
+		},
+		rollback: func(batch []*DocumentedInformation) {
+			for _, doc := range batch {
+				delete(dm.Documents, doc.ID)
+				releaseID(dm.IDs, doc.ID)
+				dm.removeFromIndex(doc.ID)
+			}
+		},
+	})
+}
+
+// BulkAddRisks identifies risks in batches, rebuilding the risk register once per batch
+func (rm *RiskManager) BulkAddRisks(risks []*Risk, opts BulkOptions) BulkResult {
+	return bulkAdd(risks, opts, bulkOps[*Risk]{
+		id:       func(risk *Risk) string { return risk.ID },
+		claim:    func(id string) error { _, ok := rm.Risks[id]; return claimID(rm.IDs, id, EntityTypeRisk, ok) },
+		validate: checkNewRisk,
+		write: func(batch []*Risk) {
+			for _, risk := range batch {
+				risk.Created = time.Now()
+				risk.Status = RiskStatusIdentified
+				rm.Risks[risk.ID] = risk
+			}
+			rm.RebuildRegister()
+		},
+		rollback: func(batch []*Risk) {
+			for _, risk := range batch {
+				delete(rm.Risks, risk.ID)
+				releaseID(rm.IDs, risk.ID)
+			}
+			rm.RebuildRegister()
+		},
+	})
+}
+
+// BulkAddOpportunities identifies opportunities in batches
+func (rm *RiskManager) BulkAddOpportunities(opportunities []*Opportunity, opts BulkOptions) BulkResult {
+	return bulkAdd(opportunities, opts, bulkOps[*Opportunity]{
+		id: func(opportunity *Opportunity) string { return opportunity.ID },
+		claim: func(id string) error {
+			_, ok := rm.Opportunities[id]
+			return claimID(rm.IDs, id, EntityTypeOpportunity, ok)
+		},
+		validate: checkNewOpportunity,
+		write: func(batch []*Opportunity) {
+			for _, opportunity := range batch {
+				opportunity.Created = time.Now()
+				opportunity.Status = OpportunityStatusIdentified
+				rm.Opportunities[opportunity.ID] = opportunity
+			}
+			rm.Register.LastUpdated = time.Now()
+		},
+		rollback: func(batch []*Opportunity) {
+			for _, opportunity := range batch {
+				delete(rm.Opportunities, opportunity.ID)
+				releaseID(rm.IDs, opportunity.ID)
+			}
+		},
+	})
+}
+
+// BulkAddObjectives creates quality objectives in batches
+func (qom *QualityObjectivesManager) BulkAddObjectives(objectives []*QualityObjective, opts BulkOptions) BulkResult {
+	return bulkAdd(objectives, opts, bulkOps[*QualityObjective]{
+		id: func(objective *QualityObjective) string { return objective.ID },
+		claim: func(id string) error {
+			_, ok := qom.Objectives[id]
+			return claimID(qom.IDs, id, EntityTypeObjective, ok)
+		},
+		validate: checkNewObjective,
+		write: func(batch []*QualityObjective) {
+			for _, objective := range batch {
+				objective.Created = time.Now()
+				objective.Status = ObjectiveStatusPlanned
+				qom.Objectives[objective.ID] = objective
+			}
+		},
+		rollback: func(batch []*QualityObjective) {
+			for _, objective := range batch {
+				delete(qom.Objectives, objective.ID)
+				releaseID(qom.IDs, objective.ID)
+			}
+		},
+	})
+}
+
+// BulkAddAudits creates audits in batches
+func (am *AuditManager) BulkAddAudits(audits []*Audit, opts BulkOptions) BulkResult {
+	return bulkAdd(audits, opts, bulkOps[*Audit]{
+		id:       func(audit *Audit) string { return audit.ID },
+		claim:    func(id string) error { _, ok := am.Audits[id]; return claimID(am.IDs, id, EntityTypeAudit, ok) },
+		validate: checkNewAudit,
+		write: func(batch []*Audit) {
+			for _, audit := range batch {
+				audit.Created = time.Now()
+				audit.Modified = time.Now()
+				audit.Status = AuditStatusPlanned
+				am.Audits[audit.ID] = audit
+			}
+		},
+		rollback: func(batch []*Audit) {
+			for _, audit := range batch {
+				delete(am.Audits, audit.ID)
+				releaseID(am.IDs, audit.ID)
+			}
+		},
+	})
+}
+
+// BulkAddManagementReviews creates management reviews in batches
+func (am *AuditManager) BulkAddManagementReviews(reviews []*ManagementReview, opts BulkOptions) BulkResult {
+	return bulkAdd(reviews, opts, bulkOps[*ManagementReview]{
+		id: func(review *ManagementReview) string { return review.ID },
+		claim: func(id string) error {
+			_, ok := am.ManagementReviews[id]
+			return claimID(am.IDs, id, EntityTypeManagementReview, ok)
+		},
+		validate: checkNewManagementReview,
+		write: func(batch []*ManagementReview) {
+			for _, review := range batch {
+				review.Created = time.Now()
+				review.Status = ReviewStatusPending
+				am.ManagementReviews[review.ID] = review
+			}
+		},
+		rollback: func(batch []*ManagementReview) {
+			for _, review := range batch {
+				delete(am.ManagementReviews, review.ID)
+				releaseID(am.IDs, review.ID)
+			}
+		},
+	})
+}
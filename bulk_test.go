@@ -0,0 +1,67 @@
+package iso9001
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestBulkAddRisks(t *testing.T) {
+	rm := NewRiskManager()
+	if err := rm.IdentifyRisk(&Risk{ID: "RISK-EXISTING", Description: "Existing risk"}); err != nil {
+		t.Fatalf("Failed to identify risk: %v", err)
+	}
+
+	risks := []*Risk{
+		{ID: "RISK-001", Description: "First imported risk"},
+		{ID: "RISK-002", Description: ""},
+		{ID: "RISK-001", Description: "Duplicate imported risk"},
+		{ID: "RISK-EXISTING", Description: "Clashes with existing risk"},
+		{ID: "RISK-003", Description: "Second imported risk"},
+	}
+
+	var batches [][]string
+	result := rm.BulkAddRisks(risks, BulkOptions{
+		BatchSize: 1,
+		OnBatch: func(ids []string) error {
+			batches = append(batches, ids)
+			return nil
+		},
+	})
+
+	if result.Added != 2 {
+		t.Errorf("Expected 2 risks added, got %d", result.Added)
+	}
+	if len(result.Errors) != 3 {
+		t.Fatalf("Expected 3 item errors, got %d", len(result.Errors))
+	}
+	for i, want := range []int{1, 2, 3} {
+		if result.Errors[i].Index != want {
+			t.Errorf("Expected error %d for item %d, got item %d", i, want, result.Errors[i].Index)
+		}
+	}
+	if len(batches) != 2 {
+		t.Errorf("Expected 2 batches, got %d", len(batches))
+	}
+	if len(rm.Register.OrganizationRisks) != 3 {
+		t.Errorf("Expected 3 register entries, got %d", len(rm.Register.OrganizationRisks))
+	}
+}
+
+func TestBulkAddDocumentsRollsBackFailedBatch(t *testing.T) {
+	dm := NewDocumentationManager()
+	docs := []*DocumentedInformation{
+		{ID: "DOC-001", Title: "First", Type: DocumentTypeProcedure},
+		{ID: "DOC-002", Title: "Second", Type: DocumentTypeProcedure},
+	}
+
+	result := dm.BulkAddDocuments(docs, BulkOptions{
+		OnBatch: func(ids []string) error { return fmt.Errorf("storage unavailable") },
+	})
+
+	if result.Added != 0 || len(result.Errors) != 2 {
+		t.Errorf("Expected all items to fail, got added=%d errors=%d", result.Added, len(result.Errors))
+	}
+	if len(dm.Documents) != 0 || len(dm.Index.ByType) != 0 {
+		t.Error("Expected failed batch to be rolled back")
+	}
+}
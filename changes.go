@@ -0,0 +1,134 @@
+package iso9001
+
+import (
+	"sync"
+	"time"
+)
+
+// ChangeOperation describes what happened to an entity
+type ChangeOperation string
+
+const (
+	ChangeOperationCreated ChangeOperation = "created"
+	ChangeOperationUpdated ChangeOperation = "updated"
+	ChangeOperationDeleted ChangeOperation = "deleted"
+	// ChangeOperationErased records that the personal data of a person was scrubbed
+	ChangeOperationErased ChangeOperation = "erased"
+)
+
+// ChangeRecord is a single entry in a change log
+type ChangeRecord struct {
+	Sequence   uint64          `json:"sequence" yaml:"sequence"`
+	EntityType string          `json:"entity_type" yaml:"entity_type"`
+	EntityID   string          `json:"entity_id" yaml:"entity_id"`
+	Operation  ChangeOperation `json:"operation" yaml:"operation"`
+	Entity     interface{}     `json:"entity,omitempty" yaml:"entity,omitempty"`
+	Timestamp  time.Time       `json:"timestamp" yaml:"timestamp"`
+}
+
+// ChangeSet is the result of a delta query against a change log
+type ChangeSet struct {
+	Changes    []ChangeRecord `json:"changes" yaml:"changes"`
+	NextCursor uint64         `json:"next_cursor" yaml:"next_cursor"`
+	HasMore    bool           `json:"has_more" yaml:"has_more"`
+	// Reset is set when the cursor predates the retained history, in which case the
+	// client should discard its local state and resync from the returned changes
+	Reset bool `json:"reset" yaml:"reset"`
+}
+
+// ChangeLog records entity changes with monotonically increasing sequence numbers so
+// clients can fetch only what changed since their last cursor
+type ChangeLog struct {
+	mu       sync.RWMutex
+	records  []ChangeRecord
+	sequence uint64
+	capacity int
+}
+
+// NewChangeLog creates a change log retaining at most capacity records;
+// zero or less retains everything
+func NewChangeLog(capacity int) *ChangeLog {
+	return &ChangeLog{capacity: capacity}
+}
+
+// Record appends a change and returns it with its assigned sequence number
+func (cl *ChangeLog) Record(entityType, entityID string, op ChangeOperation, entity interface{}) ChangeRecord {
+	cl.mu.Lock()
+	defer cl.mu.Unlock()
+
+	cl.sequence++
+	record := ChangeRecord{
+		Sequence:   cl.sequence,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Operation:  op,
+		Entity:     entity,
+		Timestamp:  time.Now(),
+	}
+
+	cl.records = append(cl.records, record)
+	if cl.capacity > 0 && len(cl.records) > cl.capacity {
+		cl.records = append([]ChangeRecord(nil), cl.records[len(cl.records)-cl.capacity:]...)
+	}
+
+	return record
+}
+
+// Cursor returns the sequence number of the latest change
+func (cl *ChangeLog) Cursor() uint64 {
+	cl.mu.RLock()
+	defer cl.mu.RUnlock()
+	return cl.sequence
+}
+
+// Since returns the latest change of every entity modified after cursor, ordered by
+// sequence. At most limit changes are returned when limit is positive; use the
+// returned NextCursor to fetch the remainder.
+func (cl *ChangeLog) Since(cursor uint64, limit int) ChangeSet {
+	cl.mu.RLock()
+	defer cl.mu.RUnlock()
+
+	set := ChangeSet{Changes: []ChangeRecord{}, NextCursor: cursor}
+	if cursor > cl.sequence {
+		// Cursor from a different log instance, e.g. before a server restart
+		set.Reset = true
+		cursor = 0
+	}
+	if len(cl.records) > 0 && cursor > 0 && cursor < cl.records[0].Sequence-1 {
+		set.Reset = true
+		cursor = 0
+	}
+
+	// Keep only the most recent record for each entity
+	latest := make(map[string]int)
+	var pending []ChangeRecord
+	for _, record := range cl.records {
+		if record.Sequence <= cursor {
+			continue
+		}
+		key := record.EntityType + "\x00" + record.EntityID
+		if i, exists := latest[key]; exists {
+			pending[i].Sequence = 0 // superseded
+		}
+		latest[key] = len(pending)
+		pending = append(pending, record)
+	}
+
+	set.NextCursor = cursor
+	for _, record := range pending {
+		if record.Sequence == 0 {
+			continue
+		}
+		if limit > 0 && len(set.Changes) == limit {
+			set.HasMore = true
+			break
+		}
+		set.Changes = append(set.Changes, record)
+		set.NextCursor = record.Sequence
+	}
+	if !set.HasMore {
+		set.NextCursor = cl.sequence
+	}
+
+	return set
+}
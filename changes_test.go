@@ -0,0 +1,49 @@
+package iso9001
+
+import "testing"
+
+func TestChangeLogSinceReturnsLatestPerEntity(t *testing.T) {
+	cl := NewChangeLog(0)
+	cl.Record("risk", "RISK-001", ChangeOperationCreated, nil)
+	cl.Record("process", "PROC-001", ChangeOperationCreated, nil)
+	cl.Record("risk", "RISK-001", ChangeOperationUpdated, nil)
+
+	set := cl.Since(0, 0)
+	if len(set.Changes) != 2 {
+		t.Fatalf("Expected 2 changes, got %d", len(set.Changes))
+	}
+	if set.Changes[1].EntityID != "RISK-001" || set.Changes[1].Operation != ChangeOperationUpdated {
+		t.Errorf("Expected latest risk update last, got %+v", set.Changes[1])
+	}
+	if set.NextCursor != 3 {
+		t.Errorf("Expected next cursor 3, got %d", set.NextCursor)
+	}
+
+	cl.Record("document", "DOC-001", ChangeOperationCreated, nil)
+	set = cl.Since(set.NextCursor, 0)
+	if len(set.Changes) != 1 || set.Changes[0].EntityID != "DOC-001" {
+		t.Errorf("Expected only DOC-001 after cursor, got %+v", set.Changes)
+	}
+}
+
+func TestChangeLogSincePagingAndReset(t *testing.T) {
+	cl := NewChangeLog(2)
+	for _, id := range []string{"A", "B", "C", "D"} {
+		cl.Record("risk", id, ChangeOperationCreated, nil)
+	}
+
+	set := cl.Since(0, 1)
+	if !set.HasMore || len(set.Changes) != 1 || set.NextCursor != 3 {
+		t.Errorf("Expected one change with more pending at cursor 3, got %+v", set)
+	}
+
+	set = cl.Since(1, 0)
+	if !set.Reset || len(set.Changes) != 2 {
+		t.Errorf("Expected reset with retained changes for evicted cursor, got %+v", set)
+	}
+
+	set = cl.Since(99, 0)
+	if !set.Reset {
+		t.Error("Expected reset for cursor ahead of log")
+	}
+}
@@ -0,0 +1,455 @@
+package iso9001
+
+import "strings"
+
+// Clause describes a clause or sub-clause of ISO 9001:2015 with the documented
+// information it requires organizations to retain and the evidence auditors typically
+// look for. Descriptions state the requirement in the imperative.
+type Clause struct {
+	Number          string   `json:"number" yaml:"number"`
+	Title           string   `json:"title" yaml:"title"`
+	Description     string   `json:"description" yaml:"description"`
+	RequiredRecords []string `json:"required_records,omitempty" yaml:"required_records,omitempty"` // documented information to be retained
+	TypicalEvidence []string `json:"typical_evidence,omitempty" yaml:"typical_evidence,omitempty"`
+}
+
+// Parent returns the number of the enclosing clause, or "" for a top-level clause
+func (c Clause) Parent() string {
+	i := strings.LastIndex(c.Number, ".")
+	if i < 0 {
+		return ""
+	}
+	return c.Number[:i]
+}
+
+// Clauses returns the operational clauses 7 to 10 at sub-clause granularity, in the
+// order they appear in the standard
+func Clauses() []Clause {
+	clauses := make([]Clause, len(clauseCatalog))
+	copy(clauses, clauseCatalog)
+	return clauses
+}
+
+// LookupClause returns a clause by number; references such as "Clause 8.5.1" are
+// accepted
+func LookupClause(reference string) (Clause, bool) {
+	number := normalizeClauseReference(reference)
+	for _, clause := range clauseCatalog {
+		if clause.Number == number {
+			return clause, true
+		}
+	}
+	return Clause{}, false
+}
+
+// SubClauses returns the clauses nested below a clause at any depth, in standard order
+func SubClauses(reference string) []Clause {
+	prefix := normalizeClauseReference(reference) + "."
+	var clauses []Clause
+	for _, clause := range clauseCatalog {
+		if strings.HasPrefix(clause.Number, prefix) {
+			clauses = append(clauses, clause)
+		}
+	}
+	return clauses
+}
+
+var clauseCatalog = []Clause{
+	// Clause 7: Support
+	{
+		Number:      "7.1",
+		Title:       "Resources",
+		Description: "Determine and provide the resources needed for the quality management system.",
+	},
+	{
+		Number:          "7.1.1",
+		Title:           "General",
+		Description:     "Consider the capabilities of and constraints on existing internal resources and what needs to be obtained from external providers.",
+		TypicalEvidence: []string{"Resource plans and budgets", "Make-or-buy decisions", "Management review resource outputs"},
+	},
+	{
+		Number:          "7.1.2",
+		Title:           "People",
+		Description:     "Provide the persons necessary for the effective implementation of the quality management system and the operation and control of its processes.",
+		TypicalEvidence: []string{"Organization chart", "Headcount and staffing plans", "Shift rosters"},
+	},
+	{
+		Number:          "7.1.3",
+		Title:           "Infrastructure",
+		Description:     "Determine, provide and maintain the buildings, equipment, software, transport and information technology needed to achieve conformity of products and services.",
+		TypicalEvidence: []string{"Asset register", "Preventive maintenance schedules and records", "IT service and backup records"},
+	},
+	{
+		Number:          "7.1.4",
+		Title:           "Environment for the operation of processes",
+		Description:     "Determine, provide and maintain the social, psychological and physical environment needed for the operation of processes.",
+		TypicalEvidence: []string{"Environmental condition monitoring (temperature, humidity, cleanliness)", "Workplace inspections", "Employee wellbeing surveys"},
+	},
+	{
+		Number:      "7.1.5",
+		Title:       "Monitoring and measuring resources",
+		Description: "Provide the resources needed to ensure valid and reliable results when monitoring or measuring is used to verify conformity.",
+	},
+	{
+		Number:          "7.1.5.1",
+		Title:           "General",
+		Description:     "Ensure monitoring and measuring resources are suitable for the activities undertaken and maintained to stay fit for purpose.",
+		RequiredRecords: []string{"Evidence of fitness for purpose of monitoring and measuring resources"},
+		TypicalEvidence: []string{"Inspection and test equipment list", "Measurement system analysis"},
+	},
+	{
+		Number:          "7.1.5.2",
+		Title:           "Measurement traceability",
+		Description:     "Calibrate or verify measuring equipment at specified intervals against traceable standards, identify its status and safeguard it from adjustments that would invalidate results.",
+		RequiredRecords: []string{"Basis used for calibration or verification where no international or national standards exist", "Calibration and verification records"},
+		TypicalEvidence: []string{"Calibration certificates", "Calibration status labels", "Out-of-tolerance impact assessments"},
+	},
+	{
+		Number:          "7.1.6",
+		Title:           "Organizational knowledge",
+		Description:     "Determine, maintain and make available the knowledge necessary for the operation of processes and to achieve conformity of products and services.",
+		TypicalEvidence: []string{"Lessons learned registers", "Knowledge bases and wikis", "Succession and handover plans"},
+	},
+	{
+		Number:          "7.2",
+		Title:           "Competence",
+		Description:     "Determine the necessary competence of persons doing work that affects quality performance, ensure they are competent and take action to acquire competence where needed.",
+		RequiredRecords: []string{"Evidence of competence"},
+		TypicalEvidence: []string{"Competence matrix", "Training records and certificates", "Training effectiveness evaluations", "Job descriptions"},
+	},
+	{
+		Number:          "7.3",
+		Title:           "Awareness",
+		Description:     "Ensure persons are aware of the quality policy, relevant quality objectives, their contribution to the QMS and the implications of not conforming.",
+		TypicalEvidence: []string{"Induction records", "Team briefings", "Interview responses during audits"},
+	},
+	{
+		Number:          "7.4",
+		Title:           "Communication",
+		Description:     "Determine the internal and external communications relevant to the QMS: what, when, with whom, how and who communicates.",
+		TypicalEvidence: []string{"Communication plan or matrix", "Meeting minutes", "Notice boards and intranet posts"},
+	},
+	{
+		Number:      "7.5",
+		Title:       "Documented information",
+		Description: "Maintain and retain the documented information required by the standard and determined by the organization as necessary for the effectiveness of the QMS.",
+	},
+	{
+		Number:          "7.5.1",
+		Title:           "General",
+		Description:     "Include the documented information required by the standard and that determined to be necessary for the effectiveness of the QMS.",
+		TypicalEvidence: []string{"Document master list", "Quality manual or process map"},
+	},
+	{
+		Number:          "7.5.2",
+		Title:           "Creating and updating",
+		Description:     "Ensure appropriate identification, format and review and approval for suitability and adequacy when creating and updating documented information.",
+		TypicalEvidence: []string{"Document templates", "Approval signatures or workflow records", "Revision history"},
+	},
+	{
+		Number:      "7.5.3",
+		Title:       "Control of documented information",
+		Description: "Control documented information so it is available where needed and adequately protected.",
+	},
+	{
+		Number:          "7.5.3.1",
+		Title:           "General",
+		Description:     "Ensure documented information is available and suitable for use where and when needed and protected from loss of confidentiality, improper use or loss of integrity.",
+		TypicalEvidence: []string{"Access controls", "Backup records"},
+	},
+	{
+		Number:          "7.5.3.2",
+		Title:           "Distribution, access, storage and retention",
+		Description:     "Address distribution, access, retrieval, storage, preservation, control of changes, retention and disposition, including documented information of external origin.",
+		TypicalEvidence: []string{"Distribution lists", "Retention schedule", "Register of external documents", "Obsolete document handling"},
+	},
+
+	// Clause 8: Operation
+	{
+		Number:          "8.1",
+		Title:           "Operational planning and control",
+		Description:     "Plan, implement and control the processes needed to meet requirements for products and services, including criteria, resources and controls.",
+		RequiredRecords: []string{"Evidence that processes have been carried out as planned", "Evidence of conformity of products and services to their requirements"},
+		TypicalEvidence: []string{"Production or service plans", "Control plans", "Work orders"},
+	},
+	{
+		Number:      "8.2",
+		Title:       "Requirements for products and services",
+		Description: "Determine, review and manage changes to the requirements for products and services.",
+	},
+	{
+		Number:          "8.2.1",
+		Title:           "Customer communication",
+		Description:     "Communicate with customers about products and services, enquiries, contracts and orders, feedback and complaints, customer property and contingency actions.",
+		TypicalEvidence: []string{"Customer correspondence", "Complaint logs", "Product information and catalogs"},
+	},
+	{
+		Number:          "8.2.2",
+		Title:           "Determining the requirements for products and services",
+		Description:     "Ensure requirements, including applicable statutory and regulatory requirements, are defined and the organization can meet its claims.",
+		TypicalEvidence: []string{"Product specifications", "Register of legal and regulatory requirements"},
+	},
+	{
+		Number:      "8.2.3",
+		Title:       "Review of the requirements for products and services",
+		Description: "Review requirements before committing to supply products and services to a customer.",
+	},
+	{
+		Number:          "8.2.3.1",
+		Title:           "Review before commitment",
+		Description:     "Review customer-specified, implied, statutory and organization requirements and resolve differences from previously expressed requirements.",
+		TypicalEvidence: []string{"Contract and order review checklists", "Quotation approvals"},
+	},
+	{
+		Number:          "8.2.3.2",
+		Title:           "Results of the review",
+		Description:     "Retain documented information on the results of the review and on any new requirements.",
+		RequiredRecords: []string{"Results of the review of requirements", "New requirements for the products and services"},
+		TypicalEvidence: []string{"Signed order acknowledgements"},
+	},
+	{
+		Number:          "8.2.4",
+		Title:           "Changes to requirements for products and services",
+		Description:     "Amend documented information and inform relevant persons when requirements for products and services change.",
+		TypicalEvidence: []string{"Contract amendments", "Change notifications to production"},
+	},
+	{
+		Number:      "8.3",
+		Title:       "Design and development of products and services",
+		Description: "Establish, implement and maintain a design and development process appropriate to ensure the subsequent provision of products and services.",
+	},
+	{
+		Number:          "8.3.1",
+		Title:           "General",
+		Description:     "Establish a design and development process, or justify its exclusion where no design is performed.",
+		TypicalEvidence: []string{"Design and development procedure", "Scope exclusion justification"},
+	},
+	{
+		Number:          "8.3.2",
+		Title:           "Design and development planning",
+		Description:     "Determine the stages, reviews, verification and validation, responsibilities, resources and interfaces of design and development.",
+		RequiredRecords: []string{"Evidence that design and development requirements have been met"},
+		TypicalEvidence: []string{"Project plans", "Stage-gate records"},
+	},
+	{
+		Number:          "8.3.3",
+		Title:           "Design and development inputs",
+		Description:     "Determine the requirements essential for the specific types of products and services to be designed and developed.",
+		RequiredRecords: []string{"Design and development inputs"},
+		TypicalEvidence: []string{"Requirements specifications", "Applicable standards and codes of practice"},
+	},
+	{
+		Number:          "8.3.4",
+		Title:           "Design and development controls",
+		Description:     "Apply controls so results are defined, reviews are conducted and verification and validation are performed.",
+		RequiredRecords: []string{"Results of design reviews, verification and validation activities"},
+		TypicalEvidence: []string{"Design review minutes", "Test reports", "Validation protocols"},
+	},
+	{
+		Number:          "8.3.5",
+		Title:           "Design and development outputs",
+		Description:     "Ensure outputs meet input requirements, are adequate for subsequent processes and specify monitoring, acceptance criteria and essential characteristics.",
+		RequiredRecords: []string{"Design and development outputs"},
+		TypicalEvidence: []string{"Drawings and bills of material", "Service specifications", "Inspection criteria"},
+	},
+	{
+		Number:          "8.3.6",
+		Title:           "Design and development changes",
+		Description:     "Identify, review and control changes made during or after design and development so they do not adversely affect conformity.",
+		RequiredRecords: []string{"Design and development changes", "Results of reviews of changes", "Authorization of changes", "Actions taken to prevent adverse impacts"},
+		TypicalEvidence: []string{"Engineering change requests and notices"},
+	},
+	{
+		Number:      "8.4",
+		Title:       "Control of externally provided processes, products and services",
+		Description: "Ensure externally provided processes, products and services conform to requirements.",
+	},
+	{
+		Number:          "8.4.1",
+		Title:           "General",
+		Description:     "Determine the controls to apply to externally provided processes, products and services and criteria for evaluating, selecting, monitoring and re-evaluating external providers.",
+		RequiredRecords: []string{"Results of evaluation, selection, performance monitoring and re-evaluation of external providers", "Actions arising from the evaluations"},
+		TypicalEvidence: []string{"Approved supplier list", "Supplier scorecards", "Supplier audit reports"},
+	},
+	{
+		Number:          "8.4.2",
+		Title:           "Type and extent of control",
+		Description:     "Ensure externally provided processes remain within the control of the QMS and define the verification needed.",
+		TypicalEvidence: []string{"Incoming inspection records", "Outsourced process agreements", "Certificates of conformity"},
+	},
+	{
+		Number:          "8.4.3",
+		Title:           "Information for external providers",
+		Description:     "Communicate requirements for products, approvals, competence, interactions, control and verification to external providers.",
+		TypicalEvidence: []string{"Purchase orders with quality requirements", "Supplier quality agreements"},
+	},
+	{
+		Number:      "8.5",
+		Title:       "Production and service provision",
+		Description: "Implement production and service provision under controlled conditions.",
+	},
+	{
+		Number:          "8.5.1",
+		Title:           "Control of production and service provision",
+		Description:     "Provide controlled conditions including documented characteristics and results, suitable infrastructure, monitoring, competent persons, validation of special processes and release activities.",
+		TypicalEvidence: []string{"Work instructions", "In-process inspection records", "Special process validations"},
+	},
+	{
+		Number:          "8.5.2",
+		Title:           "Identification and traceability",
+		Description:     "Identify outputs and their monitoring and measurement status, and control unique identification when traceability is a requirement.",
+		RequiredRecords: []string{"Unique identification of outputs when traceability is a requirement"},
+		TypicalEvidence: []string{"Lot and serial number records", "Status tags and labels"},
+	},
+	{
+		Number:          "8.5.3",
+		Title:           "Property belonging to customers or external providers",
+		Description:     "Identify, verify, protect and safeguard property of customers or external providers, including intellectual property and personal data.",
+		RequiredRecords: []string{"Reports to the owner when their property is lost, damaged or unsuitable"},
+		TypicalEvidence: []string{"Customer property register"},
+	},
+	{
+		Number:          "8.5.4",
+		Title:           "Preservation",
+		Description:     "Preserve outputs during production and service provision to ensure conformity, including identification, handling, packaging, storage and protection.",
+		TypicalEvidence: []string{"Storage condition records", "Packaging specifications", "Shelf-life controls"},
+	},
+	{
+		Number:          "8.5.5",
+		Title:           "Post-delivery activities",
+		Description:     "Meet requirements for post-delivery activities such as warranty, maintenance and recycling, considering risks, customer requirements and feedback.",
+		TypicalEvidence: []string{"Warranty claims", "Service reports", "Field feedback analysis"},
+	},
+	{
+		Number:          "8.5.6",
+		Title:           "Control of changes",
+		Description:     "Review and control changes to production or service provision to ensure continuing conformity with requirements.",
+		RequiredRecords: []string{"Results of the review of changes", "Persons authorizing the change", "Necessary actions arising from the review"},
+		TypicalEvidence: []string{"Process change requests", "First-article inspections after changes"},
+	},
+	{
+		Number:          "8.6",
+		Title:           "Release of products and services",
+		Description:     "Verify at planned stages that requirements have been met before releasing products and services to the customer.",
+		RequiredRecords: []string{"Evidence of conformity with the acceptance criteria", "Traceability to the person(s) authorizing the release"},
+		TypicalEvidence: []string{"Final inspection reports", "Certificates of conformance", "Release authorizations"},
+	},
+	{
+		Number:      "8.7",
+		Title:       "Control of nonconforming outputs",
+		Description: "Identify and control outputs that do not conform to requirements to prevent their unintended use or delivery.",
+	},
+	{
+		Number:          "8.7.1",
+		Title:           "Handling nonconforming outputs",
+		Description:     "Take appropriate action based on the nature of the nonconformity: correction, segregation, containment, return, suspension, informing the customer or concession.",
+		TypicalEvidence: []string{"Quarantine areas and hold tags", "Rework and scrap records"},
+	},
+	{
+		Number:          "8.7.2",
+		Title:           "Documenting nonconforming outputs",
+		Description:     "Retain documented information describing the nonconformity, the actions taken, any concessions and the authority deciding the action.",
+		RequiredRecords: []string{"Description of the nonconformity", "Actions taken", "Concessions obtained", "Authority deciding the action"},
+		TypicalEvidence: []string{"Nonconformance reports", "Concession forms"},
+	},
+
+	// Clause 9: Performance evaluation
+	{
+		Number:      "9.1",
+		Title:       "Monitoring, measurement, analysis and evaluation",
+		Description: "Determine what needs to be monitored and measured, the methods, when and when results are analysed and evaluated.",
+	},
+	{
+		Number:          "9.1.1",
+		Title:           "General",
+		Description:     "Evaluate the performance and effectiveness of the QMS using monitoring and measurement at planned times.",
+		RequiredRecords: []string{"Evidence of the results of monitoring and measurement"},
+		TypicalEvidence: []string{"KPI dashboards", "Process performance reports"},
+	},
+	{
+		Number:          "9.1.2",
+		Title:           "Customer satisfaction",
+		Description:     "Monitor customers' perceptions of the degree to which their needs and expectations have been fulfilled.",
+		TypicalEvidence: []string{"Customer surveys", "Net promoter score results", "Complaint trends", "Customer meeting notes"},
+	},
+	{
+		Number:          "9.1.3",
+		Title:           "Analysis and evaluation",
+		Description:     "Analyse and evaluate data to assess conformity, customer satisfaction, QMS effectiveness, planning, actions on risks, external providers and improvement needs.",
+		TypicalEvidence: []string{"Trend analyses", "Statistical reports", "Management review inputs"},
+	},
+	{
+		Number:      "9.2",
+		Title:       "Internal audit",
+		Description: "Conduct internal audits at planned intervals to determine whether the QMS conforms and is effectively implemented and maintained.",
+	},
+	{
+		Number:          "9.2.1",
+		Title:           "Purpose of internal audits",
+		Description:     "Provide information on whether the QMS conforms to the organization's own requirements and the standard and is effectively implemented.",
+		TypicalEvidence: []string{"Internal audit procedure"},
+	},
+	{
+		Number:          "9.2.2",
+		Title:           "Audit programme",
+		Description:     "Plan an audit programme, define criteria and scope, select objective auditors, report results and take corrections and corrective actions without undue delay.",
+		RequiredRecords: []string{"Evidence of the implementation of the audit programme", "Audit results"},
+		TypicalEvidence: []string{"Audit schedule", "Audit plans and checklists", "Audit reports", "Auditor qualification records"},
+	},
+	{
+		Number:      "9.3",
+		Title:       "Management review",
+		Description: "Review the QMS at planned intervals to ensure its continuing suitability, adequacy, effectiveness and alignment with strategic direction.",
+	},
+	{
+		Number:          "9.3.1",
+		Title:           "General",
+		Description:     "Have top management review the QMS at planned intervals.",
+		TypicalEvidence: []string{"Management review schedule", "Attendance records"},
+	},
+	{
+		Number:          "9.3.2",
+		Title:           "Management review inputs",
+		Description:     "Consider previous actions, changes in issues, performance trends, resource adequacy, effectiveness of actions on risks and opportunities for improvement.",
+		TypicalEvidence: []string{"Management review agenda", "Input data packs"},
+	},
+	{
+		Number:          "9.3.3",
+		Title:           "Management review outputs",
+		Description:     "Include decisions and actions related to improvement opportunities, changes to the QMS and resource needs.",
+		RequiredRecords: []string{"Results of management reviews"},
+		TypicalEvidence: []string{"Management review minutes", "Action item logs"},
+	},
+
+	// Clause 10: Improvement
+	{
+		Number:          "10.1",
+		Title:           "General",
+		Description:     "Determine and select opportunities for improvement and implement necessary actions to meet customer requirements and enhance satisfaction.",
+		TypicalEvidence: []string{"Improvement project register", "Suggestion schemes"},
+	},
+	{
+		Number:      "10.2",
+		Title:       "Nonconformity and corrective action",
+		Description: "React to nonconformities, evaluate the need to eliminate their causes and implement corrective action.",
+	},
+	{
+		Number:          "10.2.1",
+		Title:           "Reacting to nonconformities",
+		Description:     "Control and correct nonconformities, determine their causes, check for similar nonconformities, implement action, review effectiveness and update risks.",
+		TypicalEvidence: []string{"Root cause analyses", "Corrective action plans", "Effectiveness reviews", "Updated risk registers"},
+	},
+	{
+		Number:          "10.2.2",
+		Title:           "Documenting nonconformities",
+		Description:     "Retain documented information as evidence of the nature of nonconformities, subsequent actions and the results of corrective action.",
+		RequiredRecords: []string{"Nature of the nonconformities and subsequent actions taken", "Results of any corrective action"},
+		TypicalEvidence: []string{"Corrective action reports (CAR/8D)"},
+	},
+	{
+		Number:          "10.3",
+		Title:           "Continual improvement",
+		Description:     "Continually improve the suitability, adequacy and effectiveness of the QMS, using analysis, evaluation and management review outputs.",
+		TypicalEvidence: []string{"Improvement trends", "Completed improvement projects", "Objective achievement over time"},
+	},
+}
@@ -0,0 +1,51 @@
+package iso9001
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestClauseCatalog(t *testing.T) {
+	clauses := Clauses()
+	if clauses[0].Number != "7.1" || clauses[len(clauses)-1].Number != "10.3" {
+		t.Fatalf("Expected catalog from 7.1 to 10.3, got %s to %s", clauses[0].Number, clauses[len(clauses)-1].Number)
+	}
+
+	seen := make(map[string]bool)
+	for _, clause := range clauses {
+		if seen[clause.Number] {
+			t.Errorf("Duplicate clause %s", clause.Number)
+		}
+		seen[clause.Number] = true
+		if clause.Title == "" || clause.Description == "" {
+			t.Errorf("Clause %s missing title or description", clause.Number)
+		}
+		if parent := clause.Parent(); strings.Contains(parent, ".") && !seen[parent] {
+			t.Errorf("Clause %s listed before its parent %s", clause.Number, parent)
+		}
+	}
+
+	for _, number := range []string{"7.1.5.2", "7.2", "8.2.3.2", "8.3.6", "8.4.1", "8.5.2", "8.5.6", "8.6", "8.7.2", "9.1.1", "9.2.2", "9.3.3", "10.2.2"} {
+		if clause, ok := LookupClause(number); !ok || len(clause.RequiredRecords) == 0 {
+			t.Errorf("Expected required records for clause %s", number)
+		}
+	}
+}
+
+func TestLookupClause(t *testing.T) {
+	clause, ok := LookupClause("Clause 8.5.1")
+	if !ok || clause.Title != "Control of production and service provision" {
+		t.Errorf("Unexpected lookup result %+v, %v", clause, ok)
+	}
+	if _, ok := LookupClause("8.9"); ok {
+		t.Error("Expected unknown clause to be rejected")
+	}
+
+	subClauses := SubClauses("8.7")
+	if len(subClauses) != 2 || subClauses[0].Number != "8.7.1" || subClauses[1].Number != "8.7.2" {
+		t.Errorf("Unexpected sub-clauses of 8.7: %+v", subClauses)
+	}
+	if got := len(SubClauses("7.1.5")); got != 2 {
+		t.Errorf("Expected 2 sub-clauses of 7.1.5, got %d", got)
+	}
+}
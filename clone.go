@@ -0,0 +1,91 @@
+package iso9001
+
+import "reflect"
+
+// Clone returns a deep copy of the organization that shares no slices, maps or
+// pointers with it, e.g. to try out changes or keep a snapshot
+func (o *Organization) Clone() *Organization {
+	return deepCopy(o)
+}
+
+// Clone returns a deep copy of the quality management system
+func (q *QualityManagementSystem) Clone() *QualityManagementSystem {
+	return deepCopy(q)
+}
+
+// Clone returns a deep copy of the audit, including its findings, corrective actions
+// and report
+func (a *Audit) Clone() *Audit {
+	return deepCopy(a)
+}
+
+// Clone returns a deep copy of the document, including its versions, approvals and
+// attachment records. Attachment content kept in an AttachmentStore is not copied.
+func (d *DocumentedInformation) Clone() *DocumentedInformation {
+	return deepCopy(d)
+}
+
+// deepCopy copies a value of the data model. Unlike a JSON round trip it cannot fail
+// and keeps times, nil and empty slices and unexported fields exactly as they are.
+// Values must not contain cycles.
+func deepCopy[T any](v *T) *T {
+	if v == nil {
+		return nil
+	}
+	return deepCopyValue(reflect.ValueOf(v)).Interface().(*T)
+}
+
+func deepCopyValue(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v
+		}
+		copied := reflect.New(v.Type().Elem())
+		copied.Elem().Set(deepCopyValue(v.Elem()))
+		return copied
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		copied := reflect.New(v.Type()).Elem()
+		copied.Set(deepCopyValue(v.Elem()))
+		return copied
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		copied := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			copied.Index(i).Set(deepCopyValue(v.Index(i)))
+		}
+		return copied
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		copied := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			copied.SetMapIndex(iter.Key(), deepCopyValue(iter.Value()))
+		}
+		return copied
+	case reflect.Struct:
+		// Unexported fields, such as the location of a time.Time, are copied as they are
+		copied := reflect.New(v.Type()).Elem()
+		copied.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if field := copied.Field(i); field.CanSet() {
+				field.Set(deepCopyValue(v.Field(i)))
+			}
+		}
+		return copied
+	case reflect.Array:
+		copied := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			copied.Index(i).Set(deepCopyValue(v.Index(i)))
+		}
+		return copied
+	}
+	return v
+}
@@ -0,0 +1,78 @@
+package iso9001
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestOrganizationClone(t *testing.T) {
+	org := CreateExampleOrganization()
+	baseline := 90.0
+	org.QMS.Objectives[0].Targets[0].Baseline = &baseline
+
+	clone := org.Clone()
+	if !reflect.DeepEqual(org, clone) {
+		t.Fatal("Expected the clone to equal the original")
+	}
+
+	clone.Name = "Changed"
+	clone.Leadership.QualityPolicy.Statement = "Changed"
+	clone.QMS.Processes[0].Risks = append(clone.QMS.Processes[0].Risks, Risk{ID: "RISK-NEW"})
+	clone.QMS.Processes[0].Inputs[0].Name = "Changed"
+	*clone.QMS.Objectives[0].Targets[0].Baseline = 50
+	clone.Context.ExternalIssues = nil
+
+	if org.Name == "Changed" || org.Leadership.QualityPolicy.Statement == "Changed" || org.QMS.Processes[0].Inputs[0].Name == "Changed" {
+		t.Error("Expected changes to the clone not to affect the original")
+	}
+	if len(org.QMS.Processes[0].Risks) == len(clone.QMS.Processes[0].Risks) || len(org.Context.ExternalIssues) == 0 {
+		t.Error("Expected the clone not to share slices with the original")
+	}
+	if *org.QMS.Objectives[0].Targets[0].Baseline != 90 {
+		t.Error("Expected the clone not to share pointers with the original")
+	}
+
+	qms := org.QMS.Clone()
+	qms.Scope.Description = "Changed"
+	if org.QMS.Scope.Description == "Changed" {
+		t.Error("Expected the QMS clone not to share its scope")
+	}
+
+	var missing *Organization
+	if missing.Clone() != nil {
+		t.Error("Expected the clone of nil to be nil")
+	}
+}
+
+func TestAuditAndDocumentClone(t *testing.T) {
+	started := time.Now()
+	audit := &Audit{
+		ID:              "AUD-001",
+		ActualStartDate: &started,
+		Findings:        []AuditFinding{{ID: "F-001", CorrectiveActions: []CorrectiveAction{{ID: "CA-001", Actions: []string{"Retrain"}}}}},
+		Report:          &AuditReport{ID: "REP-001"},
+	}
+	clone := audit.Clone()
+	if !reflect.DeepEqual(audit, clone) || !clone.ActualStartDate.Equal(started) {
+		t.Fatal("Expected the audit clone to equal the original")
+	}
+	clone.Findings[0].CorrectiveActions[0].Actions[0] = "Changed"
+	*clone.ActualStartDate = started.Add(time.Hour)
+	clone.Report.ID = "Changed"
+	if audit.Findings[0].CorrectiveActions[0].Actions[0] != "Retrain" || !audit.ActualStartDate.Equal(started) || audit.Report.ID != "REP-001" {
+		t.Error("Expected changes to the audit clone not to affect the original")
+	}
+
+	doc := &DocumentedInformation{
+		ID:       "DOC-001",
+		Approval: &DocumentApproval{RequiredApprovers: []string{"CEO"}, ActualApprovers: []Approval{{ApproverID: "P-001"}}},
+		Versions: []DocumentVersion{{VersionNumber: "1.0"}},
+	}
+	copied := doc.Clone()
+	copied.Approval.ActualApprovers[0].ApproverID = "P-002"
+	copied.Versions[0].VersionNumber = "2.0"
+	if doc.Approval.ActualApprovers[0].ApproverID != "P-001" || doc.Versions[0].VersionNumber != "1.0" {
+		t.Error("Expected changes to the document clone not to affect the original")
+	}
+}
@@ -0,0 +1,215 @@
+package iso9001
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// complianceSections are the top-level clauses compliance is broken down into
+var complianceSections = []struct{ clause, title string }{
+	{"4", "Context of the organization"},
+	{"5", "Leadership"},
+	{"6", "Planning"},
+	{"7", "Support"},
+	{"8", "Operation"},
+	{"9", "Performance evaluation"},
+	{"10", "Improvement"},
+}
+
+// ClauseCompliance is the compliance of one top-level clause at the time of a snapshot
+type ClauseCompliance struct {
+	Clause       string  `json:"clause" yaml:"clause"`
+	Title        string  `json:"title" yaml:"title"`
+	Score        float64 `json:"score" yaml:"score"`
+	Errors       int     `json:"errors" yaml:"errors"`
+	Warnings     int     `json:"warnings" yaml:"warnings"`
+	OpenFindings int     `json:"open_findings" yaml:"open_findings"`
+}
+
+// ComplianceSnapshot records the compliance of an organization at one point in time
+type ComplianceSnapshot struct {
+	Date         time.Time          `json:"date" yaml:"date"`
+	Score        float64            `json:"score" yaml:"score"`
+	Errors       int                `json:"errors" yaml:"errors"`
+	Warnings     int                `json:"warnings" yaml:"warnings"`
+	OpenFindings int                `json:"open_findings" yaml:"open_findings"`
+	Clauses      []ClauseCompliance `json:"clauses" yaml:"clauses"`
+}
+
+// TakeComplianceSnapshot validates the organization and counts the audit findings not
+// yet closed or accepted, overall and per top-level clause. Clause scores are weighted
+// like GetComplianceScore; audits may be nil.
+func TakeComplianceSnapshot(org *Organization, audits *AuditManager, at time.Time) ComplianceSnapshot {
+	result := ValidateOrganization(org)
+	snapshot := ComplianceSnapshot{
+		Date:     at,
+		Score:    complianceScore(result),
+		Errors:   len(result.Errors),
+		Warnings: len(result.Warnings),
+	}
+
+	openFindings := make(map[string]int)
+	if audits != nil {
+		for _, audit := range audits.Audits {
+			for _, finding := range audit.Findings {
+				if finding.Status == FindingStatusClosed || finding.Status == FindingStatusAccepted {
+					continue
+				}
+				snapshot.OpenFindings++
+				openFindings[topLevelClause(finding.Clause)]++
+			}
+		}
+	}
+
+	for _, section := range complianceSections {
+		partial := &ValidationResult{
+			Errors:   clauseIssues(result.Errors, section.clause),
+			Warnings: clauseIssues(result.Warnings, section.clause),
+			Infos:    clauseIssues(result.Infos, section.clause),
+		}
+		snapshot.Clauses = append(snapshot.Clauses, ClauseCompliance{
+			Clause:       section.clause,
+			Title:        section.title,
+			Score:        complianceScore(partial),
+			Errors:       len(partial.Errors),
+			Warnings:     len(partial.Warnings),
+			OpenFindings: openFindings[section.clause],
+		})
+	}
+	return snapshot
+}
+
+// topLevelClause returns the top-level clause of a reference such as "8.5.1"
+func topLevelClause(reference string) string {
+	major, _, _ := strings.Cut(normalizeClauseReference(reference), ".")
+	return major
+}
+
+// clauseIssues returns the validation issues raised under a top-level clause
+func clauseIssues(issues []ValidationError, clause string) []ValidationError {
+	var matched []ValidationError
+	for _, issue := range issues {
+		if topLevelClause(issue.Clause) == clause {
+			matched = append(matched, issue)
+		}
+	}
+	return matched
+}
+
+// ComplianceHistory keeps one compliance snapshot per period so the evolution of
+// compliance can be shown in management reviews
+type ComplianceHistory struct {
+	Period    TrendPeriod          `json:"period" yaml:"period"`
+	Snapshots []ComplianceSnapshot `json:"snapshots" yaml:"snapshots"`
+}
+
+// NewComplianceHistory creates a history keeping one snapshot per period, a month when
+// period is empty
+func NewComplianceHistory(period TrendPeriod) *ComplianceHistory {
+	if period == "" {
+		period = TrendPeriodMonth
+	}
+	return &ComplianceHistory{Period: period}
+}
+
+// Record adds a snapshot to the history. A snapshot taken in a period that already has
+// one replaces it, so running Record more often than once per period keeps the latest.
+func (h *ComplianceHistory) Record(snapshot ComplianceSnapshot) {
+	key := h.Period.periodKey(snapshot.Date)
+	for i, existing := range h.Snapshots {
+		if h.Period.periodKey(existing.Date) == key {
+			h.Snapshots[i] = snapshot
+			return
+		}
+	}
+	h.Snapshots = append(h.Snapshots, snapshot)
+	sort.SliceStable(h.Snapshots, func(i, j int) bool { return h.Snapshots[i].Date.Before(h.Snapshots[j].Date) })
+}
+
+// RecordComplianceSnapshot takes a compliance snapshot of the tenant and records it in
+// the tenant's compliance history, creating a monthly history if there is none
+func (t *Tenant) RecordComplianceSnapshot(at time.Time) ComplianceSnapshot {
+	snapshot := TakeComplianceSnapshot(t.Organization, t.Audits, at)
+	if t.Compliance == nil {
+		t.Compliance = NewComplianceHistory(TrendPeriodMonth)
+	}
+	t.Compliance.Record(snapshot)
+	return snapshot
+}
+
+// CompliancePoint is the compliance of one period of a timeline
+type CompliancePoint struct {
+	Period string `json:"period" yaml:"period"` // e.g. "2024-05" or "2024-Q2"
+	ComplianceSnapshot
+}
+
+// ClauseSeries is the score of one top-level clause over the periods of a timeline
+type ClauseSeries struct {
+	Clause string    `json:"clause" yaml:"clause"`
+	Title  string    `json:"title" yaml:"title"`
+	Scores []float64 `json:"scores" yaml:"scores"`
+	Trend  string    `json:"trend,omitempty" yaml:"trend,omitempty"` // "improving", "stable" or "declining"
+}
+
+// ComplianceTimeline shows how compliance evolved, ready for plotting: one point per
+// period and one score series per clause, aligned with the points
+type ComplianceTimeline struct {
+	Period  TrendPeriod       `json:"period" yaml:"period"`
+	Points  []CompliancePoint `json:"points" yaml:"points"`
+	Clauses []ClauseSeries    `json:"clauses" yaml:"clauses"`
+	Change  float64           `json:"change" yaml:"change"`                   // score of the last point minus the first
+	Trend   string            `json:"trend,omitempty" yaml:"trend,omitempty"` // trend of the overall score
+}
+
+// Timeline groups the snapshots taken between from and to (either may be zero for no
+// bound) into periods, which may be longer than the history's own, taking the last
+// snapshot of each period. Trends need at least two periods and treat changes of less
+// than one point per period as stable.
+func (h *ComplianceHistory) Timeline(period TrendPeriod, from, to time.Time) ComplianceTimeline {
+	if period == "" {
+		period = h.Period
+	}
+	timeline := ComplianceTimeline{Period: period, Points: []CompliancePoint{}, Clauses: []ClauseSeries{}}
+
+	for _, snapshot := range h.Snapshots {
+		if (!from.IsZero() && snapshot.Date.Before(from)) || (!to.IsZero() && snapshot.Date.After(to)) {
+			continue
+		}
+		point := CompliancePoint{Period: period.periodKey(snapshot.Date), ComplianceSnapshot: snapshot}
+		if n := len(timeline.Points); n > 0 && timeline.Points[n-1].Period == point.Period {
+			timeline.Points[n-1] = point
+			continue
+		}
+		timeline.Points = append(timeline.Points, point)
+	}
+	if len(timeline.Points) == 0 {
+		return timeline
+	}
+
+	scores := make([]float64, len(timeline.Points))
+	for i, point := range timeline.Points {
+		scores[i] = point.Score
+	}
+	timeline.Change = scores[len(scores)-1] - scores[0]
+	if len(scores) >= 2 {
+		timeline.Trend = trendDirection(scores, 1)
+	}
+
+	for _, section := range complianceSections {
+		series := ClauseSeries{Clause: section.clause, Title: section.title, Scores: make([]float64, len(timeline.Points))}
+		for i, point := range timeline.Points {
+			series.Scores[i] = 100
+			for _, clause := range point.Clauses {
+				if clause.Clause == section.clause {
+					series.Scores[i] = clause.Score
+				}
+			}
+		}
+		if len(series.Scores) >= 2 {
+			series.Trend = trendDirection(series.Scores, 1)
+		}
+		timeline.Clauses = append(timeline.Clauses, series)
+	}
+	return timeline
+}
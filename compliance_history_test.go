@@ -0,0 +1,51 @@
+package iso9001
+
+import (
+	"testing"
+	"time"
+)
+
+func TestComplianceHistoryTimeline(t *testing.T) {
+	tenant := NewTenant("ACME")
+	tenant.Organization.Name = "Acme"
+	tenant.Audits.Audits["AUD-001"] = &Audit{ID: "AUD-001", Findings: []AuditFinding{
+		{ID: "F-1", Clause: "8.5.1", Status: FindingStatusOpen},
+		{ID: "F-2", Clause: "7.2", Status: FindingStatusClosed},
+	}}
+
+	jan := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	first := tenant.RecordComplianceSnapshot(jan)
+	if first.OpenFindings != 1 || len(first.Clauses) != 7 {
+		t.Fatalf("Unexpected snapshot %+v", first)
+	}
+	for _, clause := range first.Clauses {
+		if clause.Clause == "8" && clause.OpenFindings != 1 {
+			t.Errorf("Expected open finding under clause 8, got %+v", clause)
+		}
+	}
+	if first.Score != GetComplianceScore(tenant.Organization) {
+		t.Errorf("Snapshot score %.1f differs from compliance score", first.Score)
+	}
+
+	tenant.Organization.Context = &OrganizationalContext{ExternalIssues: []Issue{{ID: "I-1", Description: "Competition"}}}
+	tenant.RecordComplianceSnapshot(jan.AddDate(0, 0, 5))
+	if len(tenant.Compliance.Snapshots) != 1 {
+		t.Fatalf("Expected snapshot in the same month to replace the first, got %d", len(tenant.Compliance.Snapshots))
+	}
+
+	tenant.Compliance.Record(ComplianceSnapshot{Date: jan.AddDate(0, 1, 0), Score: 50, Clauses: []ClauseCompliance{{Clause: "4", Score: 60}}})
+	tenant.Compliance.Record(ComplianceSnapshot{Date: jan.AddDate(0, 4, 0), Score: 80, Clauses: []ClauseCompliance{{Clause: "4", Score: 90}}})
+
+	timeline := tenant.Compliance.Timeline(TrendPeriodMonth, jan.AddDate(0, 1, 0), time.Time{})
+	if len(timeline.Points) != 2 || timeline.Points[0].Period != "2024-02" || timeline.Change != 30 || timeline.Trend != TrendImproving {
+		t.Errorf("Unexpected monthly timeline %+v", timeline)
+	}
+	if clause := timeline.Clauses[0]; clause.Clause != "4" || len(clause.Scores) != 2 || clause.Scores[1] != 90 {
+		t.Errorf("Unexpected clause series %+v", clause)
+	}
+
+	quarterly, ok := tenant.Lookup("compliance_timeline", "quarter")
+	if !ok || len(quarterly.(*ComplianceTimeline).Points) != 2 {
+		t.Errorf("Expected two quarters in the timeline, got %+v", quarterly)
+	}
+}
@@ -0,0 +1,55 @@
+package iso9001
+
+import (
+	"context"
+	"io"
+)
+
+type actorContextKey struct{}
+
+// WithActor returns a context carrying the identity of the caller, such as a user ID
+// or the name of an API key. Tenant.ChangeContext attributes changes to it, so servers
+// set it once per request and pass the context down.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorContextKey{}, actor)
+}
+
+// ActorFromContext returns the identity set with WithActor, or "" when there is none
+func ActorFromContext(ctx context.Context) string {
+	actor, _ := ctx.Value(actorContextKey{}).(string)
+	return actor
+}
+
+// ContextTenantBackend is implemented by tenant backends whose loads and saves can be
+// cancelled, such as database backends. TenantStore passes the context of
+// GetTenantContext, WithTenantContext and FlushContext to them; other backends are
+// only called while the context is still active.
+type ContextTenantBackend interface {
+	TenantBackend
+	LoadTenantContext(ctx context.Context, id string) (*Tenant, error)
+	SaveTenantContext(ctx context.Context, tenant *Tenant) error
+}
+
+// ChangeContext runs fn like Change, attributing the changes to the actor carried by
+// ctx. Nothing is run when ctx is already done. fn should check ctx itself during
+// long operations and return its error, which rolls back the changes fn made so far
+// like any other error.
+func (t *Tenant) ChangeContext(ctx context.Context, action string, fn func(tenant *Tenant) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return t.Change(ActorFromContext(ctx), action, fn)
+}
+
+// contextReader fails reads once its context is done
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (r contextReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return r.r.Read(p)
+}
@@ -0,0 +1,163 @@
+package iso9001
+
+import (
+	"fmt"
+	"time"
+)
+
+// PESTLECategory is one of the six external factor categories of a PESTLE analysis
+type PESTLECategory string
+
+const (
+	PESTLEPolitical     PESTLECategory = "political"
+	PESTLEEconomic      PESTLECategory = "economic"
+	PESTLESocial        PESTLECategory = "social"
+	PESTLETechnological PESTLECategory = "technological"
+	PESTLELegal         PESTLECategory = "legal"
+	PESTLEEnvironmental PESTLECategory = "environmental"
+)
+
+// PESTLECategories lists the PESTLE categories in analysis order
+var PESTLECategories = []PESTLECategory{PESTLEPolitical, PESTLEEconomic, PESTLESocial, PESTLETechnological, PESTLELegal, PESTLEEnvironmental}
+
+// PESTLEAnalysis records the external factors relevant to the organization (clause 4.1)
+type PESTLEAnalysis struct {
+	Factors []PESTLEFactor `json:"factors" yaml:"factors"`
+	Analyst string         `json:"analyst,omitempty" yaml:"analyst,omitempty"`
+	Date    time.Time      `json:"date" yaml:"date"`
+	NextDue time.Time      `json:"next_due,omitempty" yaml:"next_due,omitempty"`
+}
+
+// PESTLEFactor is one external factor found in a PESTLE analysis
+type PESTLEFactor struct {
+	ID          string         `json:"id,omitempty" yaml:"id,omitempty"`
+	Category    PESTLECategory `json:"category" yaml:"category"`
+	Description string         `json:"description" yaml:"description"`
+	Impact      Impact         `json:"impact" yaml:"impact"`
+	Trend       string         `json:"trend,omitempty" yaml:"trend,omitempty"` // e.g., "increasing", "stable", "decreasing"
+}
+
+// SWOTAnalysis records internal strengths and weaknesses and external opportunities
+// and threats (clause 4.1)
+type SWOTAnalysis struct {
+	Strengths     []SWOTItem `json:"strengths" yaml:"strengths"`
+	Weaknesses    []SWOTItem `json:"weaknesses" yaml:"weaknesses"`
+	Opportunities []SWOTItem `json:"opportunities" yaml:"opportunities"`
+	Threats       []SWOTItem `json:"threats" yaml:"threats"`
+	Analyst       string     `json:"analyst,omitempty" yaml:"analyst,omitempty"`
+	Date          time.Time  `json:"date" yaml:"date"`
+}
+
+// SWOTItem is one entry of a SWOT quadrant
+type SWOTItem struct {
+	ID          string `json:"id,omitempty" yaml:"id,omitempty"`
+	Description string `json:"description" yaml:"description"`
+	Impact      Impact `json:"impact" yaml:"impact"`
+}
+
+// Issues returns an external issue for each factor. Factors without an ID get one
+// made from their category and position, such as PESTLE-E-2.
+func (a *PESTLEAnalysis) Issues() []Issue {
+	issues := make([]Issue, 0, len(a.Factors))
+	for i, factor := range a.Factors {
+		id := factor.ID
+		if id == "" {
+			id = fmt.Sprintf("PESTLE-%s-%d", pestleCode(factor.Category), i+1)
+		}
+		description := factor.Description
+		if factor.Category != "" {
+			description = fmt.Sprintf("%s: %s", factor.Category, factor.Description)
+		}
+		issues = append(issues, Issue{
+			ID:          id,
+			Description: description,
+			Type:        IssueTypeExternal,
+			Impact:      factor.Impact,
+			Status:      StatusActive,
+			Created:     a.Date,
+		})
+	}
+	return issues
+}
+
+// Issues returns internal issues for the strengths and weaknesses and external issues
+// for the opportunities and threats. Items without an ID get one made from their
+// quadrant and position, such as SWOT-W-1.
+func (a *SWOTAnalysis) Issues() (internal, external []Issue) {
+	quadrant := func(items []SWOTItem, code, label string, issueType IssueType) []Issue {
+		issues := make([]Issue, 0, len(items))
+		for i, item := range items {
+			id := item.ID
+			if id == "" {
+				id = fmt.Sprintf("SWOT-%s-%d", code, i+1)
+			}
+			issues = append(issues, Issue{
+				ID:          id,
+				Description: fmt.Sprintf("%s: %s", label, item.Description),
+				Type:        issueType,
+				Impact:      item.Impact,
+				Status:      StatusActive,
+				Created:     a.Date,
+			})
+		}
+		return issues
+	}
+
+	internal = append(quadrant(a.Strengths, "S", "Strength", IssueTypeInternal),
+		quadrant(a.Weaknesses, "W", "Weakness", IssueTypeInternal)...)
+	external = append(quadrant(a.Opportunities, "O", "Opportunity", IssueTypeExternal),
+		quadrant(a.Threats, "T", "Threat", IssueTypeExternal)...)
+	return internal, external
+}
+
+// DeriveIssues adds the issues derived from the PESTLE and SWOT analyses to the
+// external and internal issues. Issues whose ID is already listed are left unchanged,
+// so the derivation can be repeated after the analyses are extended. It returns the
+// number of issues added.
+func (c *OrganizationalContext) DeriveIssues() int {
+	known := make(map[string]bool)
+	for _, issue := range append(append([]Issue{}, c.ExternalIssues...), c.InternalIssues...) {
+		known[issue.ID] = true
+	}
+
+	added := 0
+	add := func(list *[]Issue, issues []Issue) {
+		for _, issue := range issues {
+			if known[issue.ID] {
+				continue
+			}
+			known[issue.ID] = true
+			*list = append(*list, issue)
+			added++
+		}
+	}
+
+	if c.PESTLE != nil {
+		add(&c.ExternalIssues, c.PESTLE.Issues())
+	}
+	if c.SWOT != nil {
+		internal, external := c.SWOT.Issues()
+		add(&c.InternalIssues, internal)
+		add(&c.ExternalIssues, external)
+	}
+	return added
+}
+
+// pestleCode returns the letter of a category in PESTLE
+func pestleCode(category PESTLECategory) string {
+	switch category {
+	case PESTLEPolitical:
+		return "P"
+	case PESTLEEconomic:
+		return "E"
+	case PESTLESocial:
+		return "S"
+	case PESTLETechnological:
+		return "T"
+	case PESTLELegal:
+		return "L"
+	case PESTLEEnvironmental:
+		return "EN"
+	}
+	return "X"
+}
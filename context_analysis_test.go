@@ -0,0 +1,40 @@
+package iso9001
+
+import "testing"
+
+func TestDeriveIssuesFromAnalyses(t *testing.T) {
+	context := &OrganizationalContext{
+		ExternalIssues: []Issue{{ID: "EXT-001", Description: "Existing issue", Type: IssueTypeExternal}},
+		PESTLE: &PESTLEAnalysis{Factors: []PESTLEFactor{
+			{Category: PESTLELegal, Description: "New product safety regulation", Impact: ImpactHigh},
+			{ID: "EXT-001", Category: PESTLEEconomic, Description: "Duplicate of an existing issue"},
+		}},
+		SWOT: &SWOTAnalysis{
+			Strengths:  []SWOTItem{{Description: "Experienced workforce", Impact: ImpactMedium}},
+			Weaknesses: []SWOTItem{{Description: "Manual order entry", Impact: ImpactHigh}},
+			Threats:    []SWOTItem{{Description: "Low-cost competitors", Impact: ImpactHigh}},
+		},
+	}
+
+	if added := context.DeriveIssues(); added != 4 {
+		t.Fatalf("Expected 4 derived issues, got %d", added)
+	}
+	if len(context.ExternalIssues) != 3 || len(context.InternalIssues) != 2 {
+		t.Fatalf("Expected 3 external and 2 internal issues, got %d and %d", len(context.ExternalIssues), len(context.InternalIssues))
+	}
+
+	legal := context.ExternalIssues[1]
+	if legal.ID != "PESTLE-L-1" || legal.Description != "legal: New product safety regulation" || legal.Impact != ImpactHigh || legal.Type != IssueTypeExternal {
+		t.Errorf("Unexpected PESTLE issue %+v", legal)
+	}
+	if weakness := context.InternalIssues[1]; weakness.ID != "SWOT-W-1" || weakness.Type != IssueTypeInternal {
+		t.Errorf("Unexpected SWOT issue %+v", weakness)
+	}
+	if context.ExternalIssues[0].Description != "Existing issue" {
+		t.Error("Expected existing issue to be left unchanged")
+	}
+
+	if added := context.DeriveIssues(); added != 0 {
+		t.Errorf("Expected repeated derivation to add nothing, added %d", added)
+	}
+}
@@ -0,0 +1,158 @@
+package iso9001
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+// contextBackend is a ContextTenantBackend recording the actor of the contexts it is
+// called with
+type contextBackend struct {
+	*FileTenantBackend
+	actors []string
+}
+
+func (b *contextBackend) LoadTenantContext(ctx context.Context, id string) (*Tenant, error) {
+	b.actors = append(b.actors, ActorFromContext(ctx))
+	return b.LoadTenant(id)
+}
+
+func (b *contextBackend) SaveTenantContext(ctx context.Context, tenant *Tenant) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	b.actors = append(b.actors, ActorFromContext(ctx))
+	return b.SaveTenant(tenant)
+}
+
+func TestTenantStoreContext(t *testing.T) {
+	files, err := NewFileTenantBackend(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create backend: %v", err)
+	}
+	backend := &contextBackend{FileTenantBackend: files}
+	store := NewTenantStore(backend, 1)
+	if _, err := store.CreateTenant("ORG-001"); err != nil {
+		t.Fatalf("Failed to create tenant: %v", err)
+	}
+
+	ctx := WithActor(context.Background(), "jane")
+	err = store.WithTenantContext(ctx, "ORG-001", func(tenant *Tenant) error {
+		return tenant.ChangeContext(ctx, "identify risk", func(tenant *Tenant) error {
+			return tenant.Risks.IdentifyRisk(&Risk{ID: "RISK-001", Description: "Late delivery"})
+		})
+	})
+	if err != nil {
+		t.Fatalf("Failed to change tenant: %v", err)
+	}
+	tenant, _ := store.GetTenant("ORG-001")
+	if entries := tenant.Trail.Entries; len(entries) != 1 || entries[0].Actor != "jane" {
+		t.Errorf("Expected the change to be attributed to jane, got %+v", entries)
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if err := store.FlushContext(cancelled); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected a cancelled flush, got %v", err)
+	}
+	if err := store.FlushContext(ctx); err != nil {
+		t.Fatalf("Failed to flush: %v", err)
+	}
+	if len(backend.actors) != 1 || backend.actors[0] != "jane" {
+		t.Errorf("Expected one save with the caller's context, got %v", backend.actors)
+	}
+
+	if _, err := store.GetTenantContext(cancelled, "ORG-001"); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected GetTenantContext to fail once cancelled, got %v", err)
+	}
+	ran := false
+	err = tenant.ChangeContext(cancelled, "noop", func(tenant *Tenant) error {
+		ran = true
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) || ran {
+		t.Errorf("Expected a cancelled change not to run, got %v", err)
+	}
+
+	// a change stopped by cancellation part way is rolled back
+	stopping, stop := context.WithCancel(context.Background())
+	err = tenant.ChangeContext(stopping, "identify risks", func(tenant *Tenant) error {
+		if err := tenant.Risks.IdentifyRisk(&Risk{ID: "RISK-002", Description: "Key supplier fails an audit"}); err != nil {
+			return err
+		}
+		stop()
+		return stopping.Err()
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the change to stop, got %v", err)
+	}
+	if _, ok := tenant.Risks.Risks["RISK-002"]; ok {
+		t.Error("Expected the changes made before stopping to be rolled back")
+	}
+}
+
+func TestWithTenantContextDeadline(t *testing.T) {
+	store := NewTenantStore(nil, 1)
+	if _, err := store.CreateTenant("ORG-001"); err != nil {
+		t.Fatalf("Failed to create tenant: %v", err)
+	}
+
+	locked := make(chan struct{})
+	release := make(chan struct{})
+	go store.WithTenant("ORG-001", func(tenant *Tenant) error {
+		close(locked)
+		<-release
+		return nil
+	})
+	<-locked
+	// the holder keeps the tenant until the waiter has given up
+	defer close(release)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	ran := false
+	err := store.WithTenantContext(ctx, "ORG-001", func(tenant *Tenant) error {
+		ran = true
+		return nil
+	})
+	if !errors.Is(err, context.DeadlineExceeded) || ran {
+		t.Errorf("Expected the deadline to pass while waiting for the lock, got %v", err)
+	}
+}
+
+// cancellingReader cancels its context after the first read
+type cancellingReader struct {
+	io.Reader
+	cancel context.CancelFunc
+}
+
+func (r cancellingReader) Read(p []byte) (int, error) {
+	defer r.cancel()
+	return r.Reader.Read(p[:1])
+}
+
+func TestAddAttachmentContext(t *testing.T) {
+	dm := NewDocumentationManager()
+	store := NewMemoryAttachmentStore()
+	dm.Attachments = store
+	if err := dm.AddDocument(&DocumentedInformation{ID: "DOC-001", Title: "Drawing", Status: DocumentStatusDraft}); err != nil {
+		t.Fatalf("Failed to add document: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	content := cancellingReader{Reader: strings.NewReader("gauge,limit\n"), cancel: cancel}
+	_, err := dm.AddAttachmentContext(ctx, "DOC-001", Attachment{FileName: "limits.csv"}, content)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected the upload to be cancelled, got %v", err)
+	}
+	if doc, _ := dm.GetDocument("DOC-001"); len(doc.Attachments) != 0 {
+		t.Error("Expected no attachment after a cancelled upload")
+	}
+	if _, err := store.Open("DOC-001/ATT-001-limits.csv"); !errors.Is(err, ErrAttachmentNotFound) {
+		t.Errorf("Expected partial content to be removed, got %v", err)
+	}
+}
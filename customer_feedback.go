@@ -0,0 +1,331 @@
+package iso9001
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Complaint statuses. A complaint is acknowledged when someone starts investigating it
+// and counts as handled once resolved.
+const (
+	ComplaintStatusOpen          = "open"
+	ComplaintStatusInvestigating = "investigating"
+	ComplaintStatusResolved      = "resolved"
+	ComplaintStatusClosed        = "closed"
+)
+
+// ComplaintSLA sets how many days are allowed to acknowledge a complaint and, per
+// priority, to resolve it
+type ComplaintSLA struct {
+	AcknowledgeDays int              `json:"acknowledge_days" yaml:"acknowledge_days"`
+	Days            map[Priority]int `json:"days" yaml:"days"`
+	DefaultDays     int              `json:"default_days" yaml:"default_days"` // used for priorities missing from Days
+}
+
+// DefaultComplaintSLA returns the SLA used when none is configured
+func DefaultComplaintSLA() ComplaintSLA {
+	return ComplaintSLA{
+		AcknowledgeDays: 2,
+		Days: map[Priority]int{
+			PriorityCritical: 5,
+			PriorityHigh:     10,
+			PriorityMedium:   20,
+			PriorityLow:      30,
+		},
+		DefaultDays: 20,
+	}
+}
+
+// DaysFor returns the number of days allowed to resolve a complaint of the given priority
+func (s ComplaintSLA) DaysFor(priority Priority) int {
+	if days, ok := s.Days[priority]; ok {
+		return days
+	}
+	return s.DefaultDays
+}
+
+// CustomerFeedbackManager records customer complaints and satisfaction surveys and
+// turns them into the customer satisfaction input of management review (clause 9.1.2)
+type CustomerFeedbackManager struct {
+	Complaints map[string]*CustomerComplaint `json:"complaints" yaml:"complaints"`
+	Surveys    *SurveyManager                `json:"surveys" yaml:"surveys"`
+	SLA        ComplaintSLA                  `json:"sla" yaml:"sla"`
+
+	// IDs, when set, is shared with the organization's other managers
+	IDs *IDRegistry `json:"-" yaml:"-"`
+	// DueDates, when set, evaluates SLA deadlines in the organization's time zone
+	DueDates *DueDateCalculator `json:"-" yaml:"-"`
+}
+
+// NewCustomerFeedbackManager creates a feedback manager with the default SLA
+func NewCustomerFeedbackManager() *CustomerFeedbackManager {
+	return &CustomerFeedbackManager{
+		Complaints: make(map[string]*CustomerComplaint),
+		Surveys:    NewSurveyManager(),
+		SLA:        DefaultComplaintSLA(),
+	}
+}
+
+func (fm *CustomerFeedbackManager) dueDates() *DueDateCalculator {
+	if fm.DueDates != nil {
+		return fm.DueDates
+	}
+	return NewDueDateCalculator(time.UTC)
+}
+
+// RecordComplaint records a new complaint. The date defaults to now, the priority to
+// medium and the ID to CC-0001 onwards; the acknowledgement and resolution deadlines
+// follow from the SLA.
+func (fm *CustomerFeedbackManager) RecordComplaint(complaint *CustomerComplaint) error {
+	if complaint.Description == "" {
+		return fmt.Errorf("complaint must have a description")
+	}
+	if complaint.ID == "" {
+		complaint.ID = fm.nextComplaintID()
+	}
+	_, exists := fm.Complaints[complaint.ID]
+	if err := claimID(fm.IDs, complaint.ID, EntityTypeComplaint, exists); err != nil {
+		return err
+	}
+
+	if complaint.Date.IsZero() {
+		complaint.Date = time.Now()
+	}
+	if complaint.Priority == "" {
+		complaint.Priority = PriorityMedium
+	}
+	complaint.Status = ComplaintStatusOpen
+	complaint.AcknowledgeBy = fm.dueDates().DueDate(complaint.Date, fm.SLA.AcknowledgeDays)
+	complaint.DueDate = fm.dueDates().DueDate(complaint.Date, fm.SLA.DaysFor(complaint.Priority))
+
+	fm.Complaints[complaint.ID] = complaint
+	return nil
+}
+
+func (fm *CustomerFeedbackManager) nextComplaintID() string {
+	for n := len(fm.Complaints) + 1; ; n++ {
+		id := fmt.Sprintf("CC-%04d", n)
+		if _, exists := fm.Complaints[id]; !exists {
+			return id
+		}
+	}
+}
+
+// transition moves a complaint to a new status
+func (fm *CustomerFeedbackManager) transition(complaintID, status string) (*CustomerComplaint, error) {
+	complaint, exists := fm.Complaints[complaintID]
+	if !exists {
+		return nil, fmt.Errorf("complaint with ID %s not found", complaintID)
+	}
+	if err := complaintTransitions.check(EntityTypeComplaint, complaintID, complaint.Status, status); err != nil {
+		return nil, err
+	}
+	complaint.Status = status
+	return complaint, nil
+}
+
+// AcknowledgeComplaint starts the investigation of a complaint and assigns it
+func (fm *CustomerFeedbackManager) AcknowledgeComplaint(complaintID, responsible string, at time.Time) error {
+	complaint, err := fm.transition(complaintID, ComplaintStatusInvestigating)
+	if err != nil {
+		return err
+	}
+	complaint.Acknowledged = &at
+	if responsible != "" {
+		complaint.Responsible = responsible
+	}
+	return nil
+}
+
+// ResolveComplaint records the resolution of a complaint. A complaint resolved without
+// being acknowledged first counts as acknowledged at the same time.
+func (fm *CustomerFeedbackManager) ResolveComplaint(complaintID, resolution string, at time.Time) error {
+	if resolution == "" {
+		return fmt.Errorf("complaint %s: resolution must be described", complaintID)
+	}
+	complaint, err := fm.transition(complaintID, ComplaintStatusResolved)
+	if err != nil {
+		return err
+	}
+	if complaint.Acknowledged == nil {
+		complaint.Acknowledged = &at
+	}
+	complaint.Resolution = resolution
+	complaint.Resolved = &at
+	return nil
+}
+
+// CloseComplaint closes a resolved complaint, e.g. once the customer confirmed the
+// resolution
+func (fm *CustomerFeedbackManager) CloseComplaint(complaintID string) error {
+	_, err := fm.transition(complaintID, ComplaintStatusClosed)
+	return err
+}
+
+// ReopenComplaint reopens a resolved or closed complaint the customer is not satisfied
+// with. The resolution deadline stays as it was.
+func (fm *CustomerFeedbackManager) ReopenComplaint(complaintID string) error {
+	complaint, err := fm.transition(complaintID, ComplaintStatusInvestigating)
+	if err != nil {
+		return err
+	}
+	complaint.Resolved = nil
+	return nil
+}
+
+// OpenComplaints returns the complaints not yet resolved, oldest first
+func (fm *CustomerFeedbackManager) OpenComplaints() []*CustomerComplaint {
+	var open []*CustomerComplaint
+	for _, complaint := range fm.Complaints {
+		if !complaint.handled() {
+			open = append(open, complaint)
+		}
+	}
+	sortComplaints(open)
+	return open
+}
+
+// OverdueComplaints returns the unresolved complaints past their resolution deadline
+func (fm *CustomerFeedbackManager) OverdueComplaints(now time.Time) []*CustomerComplaint {
+	var overdue []*CustomerComplaint
+	for _, complaint := range fm.OpenComplaints() {
+		if fm.dueDates().IsOverdue(complaint.DueDate, now) {
+			overdue = append(overdue, complaint)
+		}
+	}
+	return overdue
+}
+
+// ComplaintStatistics summarizes complaint handling against the SLA
+type ComplaintStatistics struct {
+	Received              int              `json:"received" yaml:"received"`
+	Open                  int              `json:"open" yaml:"open"`
+	Resolved              int              `json:"resolved" yaml:"resolved"`
+	Overdue               int              `json:"overdue" yaml:"overdue"`
+	AcknowledgedInTime    int              `json:"acknowledged_in_time" yaml:"acknowledged_in_time"`
+	ResolvedInTime        int              `json:"resolved_in_time" yaml:"resolved_in_time"`
+	SLACompliance         float64          `json:"sla_compliance" yaml:"sla_compliance"` // percentage of resolved complaints resolved in time
+	AverageResolutionDays float64          `json:"average_resolution_days" yaml:"average_resolution_days"`
+	ByPriority            map[Priority]int `json:"by_priority" yaml:"by_priority"`
+}
+
+// ComplaintStatistics summarizes the complaints received between since and until
+// (either may be zero for no bound) as of now
+func (fm *CustomerFeedbackManager) ComplaintStatistics(since, until, now time.Time) ComplaintStatistics {
+	stats := ComplaintStatistics{ByPriority: make(map[Priority]int)}
+	var resolutionDays float64
+	for _, complaint := range fm.complaintsBetween(since, until) {
+		stats.Received++
+		stats.ByPriority[complaint.Priority]++
+		if complaint.Acknowledged != nil && !complaint.Acknowledged.After(complaint.AcknowledgeBy) {
+			stats.AcknowledgedInTime++
+		}
+		if !complaint.handled() {
+			stats.Open++
+			if fm.dueDates().IsOverdue(complaint.DueDate, now) {
+				stats.Overdue++
+			}
+			continue
+		}
+		stats.Resolved++
+		if complaint.Resolved != nil {
+			resolutionDays += complaint.Resolved.Sub(complaint.Date).Hours() / 24
+			if !complaint.Resolved.After(complaint.DueDate) {
+				stats.ResolvedInTime++
+			}
+		}
+	}
+	if stats.Resolved > 0 {
+		stats.SLACompliance = float64(stats.ResolvedInTime) / float64(stats.Resolved) * 100
+		stats.AverageResolutionDays = resolutionDays / float64(stats.Resolved)
+	}
+	return stats
+}
+
+// complaintsBetween returns the complaints received between since and until, oldest first
+func (fm *CustomerFeedbackManager) complaintsBetween(since, until time.Time) []*CustomerComplaint {
+	var complaints []*CustomerComplaint
+	for _, complaint := range fm.Complaints {
+		if (!since.IsZero() && complaint.Date.Before(since)) || (!until.IsZero() && complaint.Date.After(until)) {
+			continue
+		}
+		complaints = append(complaints, complaint)
+	}
+	sortComplaints(complaints)
+	return complaints
+}
+
+// SatisfactionReport produces the customer satisfaction input of a management review:
+// survey satisfaction and NPS with their trends, the complaints still open or received
+// in the last options.Periods periods, complaint statistics against the SLA and a trend
+// of complaints received per period, where fewer complaints count as improving.
+func (fm *CustomerFeedbackManager) SatisfactionReport(options TrendOptions, now time.Time) CustomerSatisfactionReport {
+	if options.Period == "" {
+		options.Period = TrendPeriodMonth
+	}
+	if options.Tolerance == 0 {
+		options.Tolerance = 1
+	}
+	report := fm.Surveys.SatisfactionReport(options)
+
+	counts := make(map[string]int)
+	var periods []string
+	for _, complaint := range fm.complaintsBetween(time.Time{}, time.Time{}) {
+		key := options.Period.periodKey(complaint.Date)
+		if _, ok := counts[key]; !ok {
+			periods = append(periods, key)
+		}
+		counts[key]++
+	}
+	if options.Periods > 0 && len(periods) > options.Periods {
+		periods = periods[len(periods)-options.Periods:]
+	}
+
+	var since time.Time
+	if options.Periods > 0 && len(periods) > 0 {
+		for _, complaint := range fm.complaintsBetween(time.Time{}, time.Time{}) {
+			if options.Period.periodKey(complaint.Date) == periods[0] {
+				since = complaint.Date
+				break
+			}
+		}
+	}
+	for _, complaint := range fm.complaintsBetween(time.Time{}, time.Time{}) {
+		if !complaint.handled() || !complaint.Date.Before(since) {
+			report.Complaints = append(report.Complaints, *complaint)
+		}
+	}
+	stats := fm.ComplaintStatistics(since, time.Time{}, now)
+	report.ComplaintStatistics = &stats
+
+	if len(periods) >= 2 {
+		data := make([]float64, len(periods))
+		negated := make([]float64, len(periods))
+		for i, period := range periods {
+			data[i] = float64(counts[period])
+			negated[i] = -data[i]
+		}
+		report.Trends = append(report.Trends, Trend{
+			Metric:    "customer_complaints",
+			Direction: trendDirection(negated, options.Tolerance),
+			Period:    periods[0] + "/" + periods[len(periods)-1],
+			Data:      data,
+		})
+	}
+	return report
+}
+
+// handled reports whether a complaint has been resolved or closed
+func (c *CustomerComplaint) handled() bool {
+	return c.Status == ComplaintStatusResolved || c.Status == ComplaintStatusClosed
+}
+
+func sortComplaints(complaints []*CustomerComplaint) {
+	sort.Slice(complaints, func(i, j int) bool {
+		if !complaints[i].Date.Equal(complaints[j].Date) {
+			return complaints[i].Date.Before(complaints[j].Date)
+		}
+		return complaints[i].ID < complaints[j].ID
+	})
+}
@@ -0,0 +1,73 @@
+package iso9001
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCustomerFeedbackComplaints(t *testing.T) {
+	fm := NewCustomerFeedbackManager()
+	jan := time.Date(2024, 1, 10, 9, 0, 0, 0, time.UTC)
+
+	complaints := []*CustomerComplaint{
+		{Description: "Late delivery", Customer: "Globex", Date: jan, Priority: PriorityHigh},
+		{Description: "Damaged packaging", Customer: "Initech", Date: jan.AddDate(0, 1, 0)},
+		{Description: "Wrong invoice", Customer: "Globex", Date: jan.AddDate(0, 1, 5)},
+		{Description: "Missing parts", Customer: "Umbrella", Date: jan.AddDate(0, 2, 0), Priority: PriorityCritical},
+	}
+	for _, complaint := range complaints {
+		if err := fm.RecordComplaint(complaint); err != nil {
+			t.Fatalf("Failed to record complaint: %v", err)
+		}
+	}
+	if err := fm.RecordComplaint(&CustomerComplaint{}); err == nil {
+		t.Error("Expected complaint without description to be rejected")
+	}
+
+	late := fm.Complaints["CC-0001"]
+	if late.Status != ComplaintStatusOpen || !late.DueDate.Equal(jan.AddDate(0, 0, 10).Truncate(24*time.Hour).Add(24*time.Hour-1)) {
+		t.Errorf("Expected high priority complaint due in 10 days, got %+v", late)
+	}
+	if complaints[1].Priority != PriorityMedium {
+		t.Errorf("Expected default priority medium, got %s", complaints[1].Priority)
+	}
+
+	if err := fm.AcknowledgeComplaint("CC-0001", "jane", jan.Add(time.Hour)); err != nil {
+		t.Fatalf("Failed to acknowledge: %v", err)
+	}
+	if err := fm.ResolveComplaint("CC-0001", "Expedited replacement", jan.AddDate(0, 0, 4)); err != nil {
+		t.Fatalf("Failed to resolve: %v", err)
+	}
+	if err := fm.ResolveComplaint("CC-0002", "Repacked", jan.AddDate(0, 2, 0)); err != nil {
+		t.Fatalf("Failed to resolve: %v", err)
+	}
+	var transition *InvalidTransitionError
+	if err := fm.CloseComplaint("CC-0003"); !errors.As(err, &transition) {
+		t.Errorf("Expected closing an open complaint to fail, got %v", err)
+	}
+	if err := fm.CloseComplaint("CC-0001"); err != nil {
+		t.Errorf("Failed to close: %v", err)
+	}
+
+	now := jan.AddDate(0, 3, 0)
+	if overdue := fm.OverdueComplaints(now); len(overdue) != 2 || overdue[0].ID != "CC-0003" {
+		t.Errorf("Expected CC-0003 and CC-0004 to be overdue, got %d", len(overdue))
+	}
+
+	stats := fm.ComplaintStatistics(time.Time{}, time.Time{}, now)
+	if stats.Received != 4 || stats.Open != 2 || stats.Resolved != 2 || stats.Overdue != 2 {
+		t.Errorf("Unexpected statistics %+v", stats)
+	}
+	if stats.ResolvedInTime != 1 || stats.SLACompliance != 50 || stats.AcknowledgedInTime != 1 {
+		t.Errorf("Expected one of two complaints handled within the SLA, got %+v", stats)
+	}
+
+	report := fm.SatisfactionReport(TrendOptions{Periods: 2}, now)
+	if len(report.Complaints) != 3 || report.ComplaintStatistics == nil || report.ComplaintStatistics.Received != 3 {
+		t.Errorf("Expected the complaints of the last two months, got %d", len(report.Complaints))
+	}
+	if len(report.Trends) != 1 || report.Trends[0].Metric != "customer_complaints" || report.Trends[0].Direction != TrendImproving {
+		t.Errorf("Expected complaints to trend down, got %+v", report.Trends)
+	}
+}
@@ -0,0 +1,144 @@
+package iso9001
+
+import (
+	_ "embed"
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+	"time"
+)
+
+// dashboardTemplate is the page written by WriteDashboardHTML
+//
+//go:embed templates/dashboard.html
+var dashboardTemplate string
+
+var dashboardPage = template.Must(template.New("dashboard").Funcs(template.FuncMap{
+	"date":    formatReportDate,
+	"percent": func(score float64) string { return fmt.Sprintf("%.1f", score) },
+}).Parse(dashboardTemplate))
+
+// heatMapLevels orders risk levels from highest to lowest
+var heatMapLevels = []RiskLevel{RiskLevelVeryHigh, RiskLevelHigh, RiskLevelMedium, RiskLevelLow, RiskLevelVeryLow}
+
+// Dashboard is a snapshot of the status of a QMS: compliance, risks, audits and
+// overdue items. Sections whose manager was not given are nil.
+type Dashboard struct {
+	OrganizationID   string    `json:"organization_id" yaml:"organization_id"`
+	OrganizationName string    `json:"organization_name" yaml:"organization_name"`
+	Generated        time.Time `json:"generated" yaml:"generated"`
+
+	ComplianceScore float64 `json:"compliance_score" yaml:"compliance_score"`
+	Rating          string  `json:"rating" yaml:"rating"` // "excellent", "good", "satisfactory", "needs_improvement" or "critical_gaps"
+	RatingLabel     string  `json:"rating_label" yaml:"rating_label"`
+	Errors          int     `json:"errors" yaml:"errors"`
+	Warnings        int     `json:"warnings" yaml:"warnings"`
+
+	// HeatMap has a row per impact level, highest first, with a cell per likelihood
+	// level, lowest first
+	HeatMap []HeatMapRow     `json:"heat_map,omitempty" yaml:"heat_map,omitempty"`
+	Risks   *RiskStatistics  `json:"risks,omitempty" yaml:"risks,omitempty"`
+	Audits  *AuditStatistics `json:"audits,omitempty" yaml:"audits,omitempty"`
+
+	// Overdue lists the deadlines that have passed, oldest first
+	Overdue []Deadline `json:"overdue" yaml:"overdue"`
+}
+
+// HeatMapRow is the number of risks of one impact level by likelihood
+type HeatMapRow struct {
+	Impact RiskLevel     `json:"impact" yaml:"impact"`
+	Cells  []HeatMapCell `json:"cells" yaml:"cells"`
+}
+
+// HeatMapCell is the number of risks of a likelihood and impact, with a band of
+// "low", "medium" or "high" for their combined rating
+type HeatMapCell struct {
+	Likelihood RiskLevel `json:"likelihood" yaml:"likelihood"`
+	Count      int       `json:"count" yaml:"count"`
+	Band       string    `json:"band" yaml:"band"`
+}
+
+// BuildDashboard gathers the dashboard of an organization and its managers at the
+// given time. Deadlines count as overdue from the end of their due day in the
+// organization's time zone.
+func BuildDashboard(sources DeadlineSources, now time.Time) *Dashboard {
+	org := sources.Organization
+	if org == nil {
+		org = &Organization{}
+	}
+	score := GetComplianceScore(org)
+	result := ValidateOrganization(org)
+	rating := complianceRating(score)
+	dashboard := &Dashboard{
+		OrganizationID:   org.ID,
+		OrganizationName: org.Name,
+		Generated:        now,
+		ComplianceScore:  score,
+		Rating:           strings.ReplaceAll(strings.ToLower(rating), " ", "_"),
+		RatingLabel:      rating,
+		Errors:           len(result.Errors),
+		Warnings:         len(result.Warnings),
+		Overdue:          []Deadline{},
+	}
+
+	if rm := sources.Risks; rm != nil {
+		stats := rm.GetRiskStatistics()
+		dashboard.Risks = &stats
+		dashboard.HeatMap = heatMapRows(rm.GetRiskHeatMap())
+	}
+	if am := sources.Audits; am != nil {
+		stats := am.GetAuditStatistics()
+		dashboard.Audits = &stats
+	}
+
+	// An invalid time zone leaves the calculator nil, which evaluates deadlines in UTC
+	dueDates, _ := NewDueDateCalculatorForOrganization(org)
+	for _, deadline := range CollectDeadlines(sources) {
+		if dueDates.IsOverdue(deadline.Due, now) {
+			dashboard.Overdue = append(dashboard.Overdue, deadline)
+		}
+	}
+	return dashboard
+}
+
+// Dashboard gathers the dashboard of the tenant at the given time
+func (t *Tenant) Dashboard(now time.Time) *Dashboard {
+	return BuildDashboard(DeadlineSources{
+		Organization: t.Organization,
+		Documents:    t.Documents,
+		Risks:        t.Risks,
+		Objectives:   t.Objectives,
+		Audits:       t.Audits,
+		Outputs:      t.Outputs,
+	}, now)
+}
+
+// WriteDashboardHTML writes the dashboard as a self-contained HTML page with inline
+// styles and no scripts or external resources, so it can be served from or embedded
+// in an intranet as is. The styles only apply inside the page's .qms-dashboard
+// element.
+func WriteDashboardHTML(w io.Writer, dashboard *Dashboard) error {
+	return dashboardPage.Execute(w, dashboard)
+}
+
+// heatMapRows lays out a heat map with impact down and likelihood across
+func heatMapRows(heatMap RiskHeatMap) []HeatMapRow {
+	byImpact := []map[RiskLevel]int{heatMap.VeryHigh, heatMap.High, heatMap.Medium, heatMap.Low, heatMap.VeryLow}
+	rows := make([]HeatMapRow, len(heatMapLevels))
+	for i, impact := range heatMapLevels {
+		rows[i].Impact = impact
+		for j := len(heatMapLevels) - 1; j >= 0; j-- {
+			likelihood := heatMapLevels[j]
+			band := "low"
+			switch severity := (len(heatMapLevels) - i) * (len(heatMapLevels) - j); {
+			case severity >= 15:
+				band = "high"
+			case severity >= 8:
+				band = "medium"
+			}
+			rows[i].Cells = append(rows[i].Cells, HeatMapCell{Likelihood: likelihood, Count: byImpact[i][likelihood], Band: band})
+		}
+	}
+	return rows
+}
@@ -0,0 +1,61 @@
+package iso9001
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDashboard(t *testing.T) {
+	tenant := NewTenant("acme")
+	tenant.Organization = CreateExampleOrganization()
+	tenant.Organization.Name = "Acme <Tools>"
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tenant.Risks.IdentifyRisk(&Risk{ID: "RISK-001", Description: "Supplier fails"})
+	tenant.Risks.AssessRisk("RISK-001", RiskLevelVeryHigh, RiskLevelVeryHigh)
+	tenant.Audits.CreateAudit(&Audit{ID: "AUD-001", Title: "Purchasing", Scope: AuditScope{Description: "Purchasing"}, PlannedStartDate: now.AddDate(0, 1, 0)})
+	tenant.Audits.AddFinding("AUD-001", AuditFinding{ID: "F-001", Description: "No supplier evaluation", Severity: SeverityMajor, DueDate: now.AddDate(0, 0, -3)})
+	tenant.Audits.AddFinding("AUD-001", AuditFinding{ID: "F-002", Description: "Due today", Severity: SeverityMinor, DueDate: now})
+
+	dashboard := tenant.Dashboard(now)
+	if dashboard.OrganizationName != "Acme <Tools>" || dashboard.Rating == "" || strings.Contains(dashboard.Rating, " ") {
+		t.Errorf("Unexpected compliance summary %+v", dashboard)
+	}
+	if dashboard.Audits == nil || dashboard.Audits.Planned != 1 || dashboard.Audits.MajorFindings != 1 {
+		t.Errorf("Expected audit statistics, got %+v", dashboard.Audits)
+	}
+	if len(dashboard.Overdue) != 1 || dashboard.Overdue[0].EntityID != "F-001" {
+		t.Errorf("Expected only the finding due before today to be overdue, got %+v", dashboard.Overdue)
+	}
+	if cell := dashboard.HeatMap[0].Cells[4]; cell.Likelihood != RiskLevelVeryHigh || cell.Count != 1 || cell.Band != "high" {
+		t.Errorf("Expected very high risk in the top right cell, got %+v", cell)
+	}
+	if cell := dashboard.HeatMap[4].Cells[0]; cell.Band != "low" || cell.Count != 0 {
+		t.Errorf("Expected empty low band in the bottom left cell, got %+v", cell)
+	}
+
+	var out bytes.Buffer
+	if err := WriteDashboardHTML(&out, dashboard); err != nil {
+		t.Fatal(err)
+	}
+	page := out.String()
+	for _, text := range []string{"Acme &lt;Tools&gt;", "No supplier evaluation", "Overdue items (1)", `class="high">1</td>`} {
+		if !strings.Contains(page, text) {
+			t.Errorf("Expected dashboard to contain %q", text)
+		}
+	}
+	if strings.Contains(page, "<script") || strings.Contains(page, "http://") || strings.Contains(page, "https://") {
+		t.Error("Expected dashboard to be self-contained")
+	}
+
+	// Without managers only the compliance section and deadlines are shown
+	out.Reset()
+	if err := WriteDashboardHTML(&out, BuildDashboard(DeadlineSources{Organization: tenant.Organization}, now)); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(out.String(), "Risk heat map") || !strings.Contains(out.String(), "Nothing is overdue.") {
+		t.Error("Expected sections without managers to be left out")
+	}
+}
@@ -0,0 +1,216 @@
+package iso9001
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ErrNotDistributed is returned when a person acknowledges a document they were not
+// given a copy of
+var ErrNotDistributed = errors.New("document was not distributed to recipient")
+
+// DistributionRecord records a controlled copy of a document version issued to a
+// person and when they acknowledged receiving it (clause 7.5.3.2)
+type DistributionRecord struct {
+	CopyNumber    int        `json:"copy_number" yaml:"copy_number"` // controlled copy number, unique within the document
+	Recipient     string     `json:"recipient" yaml:"recipient"`
+	Version       string     `json:"version" yaml:"version"`
+	Distributed   time.Time  `json:"distributed" yaml:"distributed"`
+	DistributedBy string     `json:"distributed_by,omitempty" yaml:"distributed_by,omitempty"`
+	Acknowledged  *time.Time `json:"acknowledged,omitempty" yaml:"acknowledged,omitempty"`
+}
+
+// DistributedCopy is a distribution record together with the document it belongs to
+type DistributedCopy struct {
+	DocumentID string `json:"document_id" yaml:"document_id"`
+	Title      string `json:"title" yaml:"title"`
+	DistributionRecord
+}
+
+// ObsoleteCopy is a controlled copy its recipient still works from although the
+// document was revised or withdrawn, with the document in force they should use
+// instead, if any
+type ObsoleteCopy struct {
+	DistributedCopy
+	CurrentDocumentID string `json:"current_document_id,omitempty" yaml:"current_document_id,omitempty"`
+	CurrentVersion    string `json:"current_version,omitempty" yaml:"current_version,omitempty"`
+}
+
+// DistributeDocument issues controlled copies of the current version of a published
+// document. Recipients already holding that version are skipped. With an approver
+// directory, recipients must be known people.
+func (dm *DocumentationManager) DistributeDocument(docID, distributedBy string, recipients ...string) ([]DistributionRecord, error) {
+	doc, exists := dm.Documents[docID]
+	if !exists {
+		return nil, fmt.Errorf("document with ID %s not found", docID)
+	}
+	if doc.Status != DocumentStatusPublished {
+		return nil, fmt.Errorf("only published documents can be distributed, document %s is %s", docID, doc.Status)
+	}
+	if approvers := dm.directory(); approvers != nil {
+		for _, recipient := range recipients {
+			if _, ok := approvers.FindPerson(recipient); !ok {
+				return nil, fmt.Errorf("unknown recipient %s of document %s", recipient, docID)
+			}
+		}
+	}
+
+	version := doc.currentVersion()
+	now := time.Now()
+	var issued []DistributionRecord
+	for _, recipient := range recipients {
+		if doc.distributedTo(recipient, version) {
+			continue
+		}
+		record := DistributionRecord{
+			CopyNumber:    len(doc.Distribution) + 1,
+			Recipient:     recipient,
+			Version:       version,
+			Distributed:   now,
+			DistributedBy: distributedBy,
+		}
+		doc.Distribution = append(doc.Distribution, record)
+		issued = append(issued, record)
+	}
+	if len(issued) == 0 {
+		return nil, nil
+	}
+	doc.Modified = now
+
+	if err := dm.save(doc, fmt.Sprintf("Distribute %s version %s to %d recipients", docID, version, len(issued))); err != nil {
+		return nil, err
+	}
+	return issued, nil
+}
+
+// distributedTo reports whether the recipient was given a copy of the version
+func (doc *DocumentedInformation) distributedTo(recipient, version string) bool {
+	for _, record := range doc.Distribution {
+		if record.Recipient == recipient && record.Version == version {
+			return true
+		}
+	}
+	return false
+}
+
+// AcknowledgeDistribution records that a recipient acknowledged the latest copy of a
+// document they were given. The acknowledgment time defaults to now.
+func (dm *DocumentationManager) AcknowledgeDistribution(docID, recipient string, at time.Time) (*DistributionRecord, error) {
+	doc, exists := dm.Documents[docID]
+	if !exists {
+		return nil, fmt.Errorf("document with ID %s not found", docID)
+	}
+	if at.IsZero() {
+		at = time.Now()
+	}
+	for i := len(doc.Distribution) - 1; i >= 0; i-- {
+		record := &doc.Distribution[i]
+		if record.Recipient != recipient {
+			continue
+		}
+		if record.Acknowledged == nil {
+			record.Acknowledged = &at
+			doc.Modified = at
+			if err := dm.save(doc, fmt.Sprintf("Acknowledge %s version %s by %s", docID, record.Version, recipient)); err != nil {
+				return nil, err
+			}
+		}
+		return record, nil
+	}
+	return nil, fmt.Errorf("%w: %s has no copy of document %s", ErrNotDistributed, recipient, docID)
+}
+
+// OutstandingAcknowledgments lists the copies of current documents that their
+// recipients have not acknowledged, ordered by document and copy number
+func (dm *DocumentationManager) OutstandingAcknowledgments() []DistributedCopy {
+	var outstanding []DistributedCopy
+	for _, doc := range dm.Documents {
+		if doc.Status != DocumentStatusPublished {
+			continue
+		}
+		version := doc.currentVersion()
+		for _, record := range doc.Distribution {
+			if record.Acknowledged == nil && record.Version == version {
+				outstanding = append(outstanding, DistributedCopy{DocumentID: doc.ID, Title: doc.Title, DistributionRecord: record})
+			}
+		}
+	}
+	sort.Slice(outstanding, func(i, j int) bool {
+		if outstanding[i].DocumentID != outstanding[j].DocumentID {
+			return outstanding[i].DocumentID < outstanding[j].DocumentID
+		}
+		return outstanding[i].CopyNumber < outstanding[j].CopyNumber
+	})
+	return outstanding
+}
+
+// ObsoleteCopies lists the people still working from an obsolete revision: they hold
+// a copy of a document that was superseded or withdrawn, or of an earlier version,
+// and have not been given the current version of the document in force. Copies are
+// ordered by recipient and document.
+func (dm *DocumentationManager) ObsoleteCopies() []ObsoleteCopy {
+	var obsolete []ObsoleteCopy
+	for _, doc := range dm.Documents {
+		current := dm.currentRevision(doc)
+		inForce := current.Status == DocumentStatusPublished
+		currentVersion := current.currentVersion()
+
+		latest := make(map[string]DistributionRecord)
+		for _, record := range doc.Distribution {
+			if previous, seen := latest[record.Recipient]; !seen || !record.Distributed.Before(previous.Distributed) {
+				latest[record.Recipient] = record
+			}
+		}
+		for recipient, record := range latest {
+			if inForce && current.distributedTo(recipient, currentVersion) {
+				continue
+			}
+			if dm.laterRevisionHeld(doc, recipient) {
+				continue // reported for the later revision
+			}
+			held := ObsoleteCopy{DistributedCopy: DistributedCopy{DocumentID: doc.ID, Title: doc.Title, DistributionRecord: record}}
+			if inForce {
+				held.CurrentDocumentID, held.CurrentVersion = current.ID, currentVersion
+			}
+			obsolete = append(obsolete, held)
+		}
+	}
+	sort.Slice(obsolete, func(i, j int) bool {
+		if obsolete[i].Recipient != obsolete[j].Recipient {
+			return obsolete[i].Recipient < obsolete[j].Recipient
+		}
+		return obsolete[i].DocumentID < obsolete[j].DocumentID
+	})
+	return obsolete
+}
+
+// laterRevisionHeld reports whether the recipient was given a copy of a revision that
+// superseded the document
+func (dm *DocumentationManager) laterRevisionHeld(doc *DocumentedInformation, recipient string) bool {
+	seen := map[string]bool{doc.ID: true}
+	for later := dm.Documents[doc.SupersededBy]; later != nil && !seen[later.ID]; later = dm.Documents[later.SupersededBy] {
+		seen[later.ID] = true
+		for _, record := range later.Distribution {
+			if record.Recipient == recipient {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// currentRevision follows the superseding revisions of a document to the one in force
+func (dm *DocumentationManager) currentRevision(doc *DocumentedInformation) *DocumentedInformation {
+	seen := map[string]bool{doc.ID: true}
+	for doc.SupersededBy != "" && !seen[doc.SupersededBy] {
+		next, exists := dm.Documents[doc.SupersededBy]
+		if !exists {
+			break
+		}
+		seen[next.ID] = true
+		doc = next
+	}
+	return doc
+}
@@ -0,0 +1,57 @@
+package iso9001
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDocumentDistribution(t *testing.T) {
+	dm := NewDocumentationManager()
+	dm.AddDocument(&DocumentedInformation{ID: "WI-001", Title: "Torque settings", Content: "Rev A"})
+	if _, err := dm.DistributeDocument("WI-001", "qm", "ann"); err == nil {
+		t.Error("Expected draft document not to be distributed")
+	}
+	dm.SetDocumentStatus("WI-001", DocumentStatusApproved)
+	dm.SetDocumentStatus("WI-001", DocumentStatusPublished)
+
+	issued, err := dm.DistributeDocument("WI-001", "qm", "ann", "bob")
+	if err != nil || len(issued) != 2 || issued[1].CopyNumber != 2 || issued[1].Version != "1.0" {
+		t.Fatalf("Expected two controlled copies, got %+v %v", issued, err)
+	}
+	if again, _ := dm.DistributeDocument("WI-001", "qm", "ann"); len(again) != 0 {
+		t.Error("Expected recipient holding the version to be skipped")
+	}
+	if _, err := dm.AcknowledgeDistribution("WI-001", "carl", time.Time{}); !errors.Is(err, ErrNotDistributed) {
+		t.Errorf("Expected acknowledgment without a copy to fail, got %v", err)
+	}
+	if record, err := dm.AcknowledgeDistribution("WI-001", "ann", time.Time{}); err != nil || record.Acknowledged == nil {
+		t.Fatalf("Expected acknowledgment, got %+v %v", record, err)
+	}
+	if outstanding := dm.OutstandingAcknowledgments(); len(outstanding) != 1 || outstanding[0].Recipient != "bob" || outstanding[0].DocumentID != "WI-001" {
+		t.Errorf("Expected bob's acknowledgment to be outstanding, got %+v", outstanding)
+	}
+	if obsolete := dm.ObsoleteCopies(); len(obsolete) != 0 {
+		t.Errorf("Expected no obsolete copies, got %+v", obsolete)
+	}
+
+	// Publishing a revision leaves holders of the old one on an obsolete revision until
+	// they receive the new one
+	dm.CreateRevision("WI-001", "WI-001-B", "qm", "New torque values")
+	dm.SetDocumentStatus("WI-001-B", DocumentStatusApproved)
+	dm.SetDocumentStatus("WI-001-B", DocumentStatusPublished)
+	dm.DistributeDocument("WI-001-B", "qm", "ann")
+
+	obsolete := dm.ObsoleteCopies()
+	if len(obsolete) != 1 || obsolete[0].Recipient != "bob" || obsolete[0].CurrentDocumentID != "WI-001-B" || obsolete[0].CurrentVersion != "2.0" {
+		t.Fatalf("Expected bob to work from the obsolete revision, got %+v", obsolete)
+	}
+	if outstanding := dm.OutstandingAcknowledgments(); len(outstanding) != 1 || outstanding[0].DocumentID != "WI-001-B" {
+		t.Errorf("Expected only the new revision to await acknowledgment, got %+v", outstanding)
+	}
+
+	dm.ArchiveDocument("WI-001-B", "Process retired")
+	if obsolete := dm.ObsoleteCopies(); len(obsolete) != 2 || obsolete[0].CurrentDocumentID != "" {
+		t.Errorf("Expected copies of a withdrawn document to be obsolete, got %+v", obsolete)
+	}
+}
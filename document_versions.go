@@ -0,0 +1,123 @@
+package iso9001
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Errors returned when documents are versioned
+var (
+	ErrChangeSummaryRequired = errors.New("document changes must be summarized")
+	ErrVersionNotFound       = errors.New("document version not found")
+)
+
+// VersionChange describes the change recorded by a new document version
+type VersionChange struct {
+	Summary string // what changed and why; required
+	Major   bool   // a major change, e.g. 1.3 to 2.0, rather than a minor one, e.g. 1.3 to 1.4
+}
+
+// nextVersion returns the version number following current. Numbers that do not
+// have the form major.minor, such as 1.0.1.1 from earlier releases, continue from
+// their first two components.
+func nextVersion(current string, major bool) string {
+	parts := strings.SplitN(current, ".", 3)
+	majorNumber, _ := strconv.Atoi(parts[0])
+	minorNumber := 0
+	if len(parts) > 1 {
+		minorNumber, _ = strconv.Atoi(parts[1])
+	}
+	if major {
+		return fmt.Sprintf("%d.0", majorNumber+1)
+	}
+	return fmt.Sprintf("%d.%d", majorNumber, minorNumber+1)
+}
+
+// GetDocumentVersion returns a version of a document, including the content it had
+func (dm *DocumentationManager) GetDocumentVersion(docID, version string) (*DocumentVersion, error) {
+	doc, exists := dm.Documents[docID]
+	if !exists {
+		return nil, fmt.Errorf("document with ID %s not found", docID)
+	}
+	for i := range doc.Versions {
+		if doc.Versions[i].VersionNumber == version {
+			found := doc.Versions[i]
+			return &found, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s of document %s", ErrVersionNotFound, version, docID)
+}
+
+// RollbackDocument restores the content of an earlier version of a draft document.
+// The rollback is recorded as a new minor version, so the history is kept, and like
+// any new version it returns an approved or reviewed document to draft.
+func (dm *DocumentationManager) RollbackDocument(docID, version, author string) (*DocumentVersion, error) {
+	doc, exists := dm.Documents[docID]
+	if !exists {
+		return nil, fmt.Errorf("document with ID %s not found", docID)
+	}
+	if doc.isReleased() {
+		return nil, fmt.Errorf("%w: document %s is %s", ErrDocumentReleased, docID, doc.Status)
+	}
+	target, err := dm.GetDocumentVersion(docID, version)
+	if err != nil {
+		return nil, err
+	}
+	if target.Content == "" {
+		return nil, fmt.Errorf("version %s of document %s has no recorded content", version, docID)
+	}
+
+	rollback := DocumentVersion{
+		VersionNumber: nextVersion(doc.currentVersion(), false),
+		ChangeSummary: fmt.Sprintf("Rolled back to version %s", version),
+		CreatedBy:     author,
+		CreatedAt:     time.Now(),
+		Content:       target.Content,
+	}
+	doc.Content = target.Content
+	doc.Versions = append(doc.Versions, rollback)
+	doc.Modified = rollback.CreatedAt
+	doc.withdrawApproval()
+
+	dm.updateIndex(doc)
+	if err := dm.save(doc, fmt.Sprintf("Roll back %s to version %s", docID, version)); err != nil {
+		return nil, err
+	}
+	return &rollback, nil
+}
+
+// withdrawApproval returns a document whose content changed to draft, so the new
+// version has to be reviewed and approved before it can be published. Approvals of
+// earlier versions stay in the history but no longer count.
+func (doc *DocumentedInformation) withdrawApproval() {
+	if doc.Status == DocumentStatusApproved || doc.Status == DocumentStatusReview {
+		doc.Status = DocumentStatusDraft
+	}
+	if doc.Approval == nil {
+		return
+	}
+	if doc.Approval.Status == ApprovalStatusApproved {
+		doc.Approval.Status = ApprovalStatusPending
+	}
+	for i := range doc.Approval.Stages {
+		doc.Approval.Stages[i].Started, doc.Approval.Stages[i].Escalated = nil, nil
+	}
+}
+
+// Changelog lists the versions of the document, newest first, one line each with the
+// version number, date, author and change summary
+func (doc *DocumentedInformation) Changelog() string {
+	var b strings.Builder
+	for i := len(doc.Versions) - 1; i >= 0; i-- {
+		version := doc.Versions[i]
+		fmt.Fprintf(&b, "%s (%s", version.VersionNumber, version.CreatedAt.Format("2006-01-02"))
+		if version.CreatedBy != "" {
+			fmt.Fprintf(&b, ", %s", version.CreatedBy)
+		}
+		fmt.Fprintf(&b, "): %s\n", version.ChangeSummary)
+	}
+	return b.String()
+}
@@ -1,10 +1,16 @@
 package iso9001
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 )
 
+// ErrDocumentReleased is returned when an in-place edit is attempted on a published,
+// obsolete or archived document; use CreateRevision to change its content instead
+var ErrDocumentReleased = errors.New("released documents cannot be edited in place")
+
 // DocumentedInformation represents clause 7.5 requirements
 type DocumentedInformation struct {
 	ID          string                 `json:"id" yaml:"id"`
@@ -12,12 +18,17 @@ type DocumentedInformation struct {
 	Type        DocumentType           `json:"type" yaml:"type"`
 	Category    DocumentCategory       `json:"category" yaml:"category"`
 	Content     string                 `json:"content" yaml:"content"`
+	Encrypted   *EncryptedContent      `json:"encrypted,omitempty" yaml:"encrypted,omitempty"` // sealed content of confidential documents at rest
+	Attachments []Attachment           `json:"attachments,omitempty" yaml:"attachments,omitempty"` // files such as PDFs and drawings, kept in the attachment store
+	Distribution []DistributionRecord  `json:"distribution,omitempty" yaml:"distribution,omitempty"` // controlled copies issued and their acknowledgments
 	Metadata    DocumentMetadata       `json:"metadata" yaml:"metadata"`
 	Approval    *DocumentApproval      `json:"approval,omitempty" yaml:"approval,omitempty"`
 	Review      *DocumentReview        `json:"review,omitempty" yaml:"review,omitempty"`
 	Access      DocumentAccess         `json:"access" yaml:"access"`
 	Status      DocumentStatus         `json:"status" yaml:"status"`
 	Versions    []DocumentVersion      `json:"versions" yaml:"versions"`
+	RevisionOf  string                 `json:"revision_of,omitempty" yaml:"revision_of,omitempty"`     // published document this draft revises
+	SupersededBy string                `json:"superseded_by,omitempty" yaml:"superseded_by,omitempty"` // revision that replaced this document
 	Created     time.Time              `json:"created" yaml:"created"`
 	Modified    time.Time              `json:"modified" yaml:"modified"`
 }
@@ -66,11 +77,14 @@ type DocumentMetadata struct {
 	Language       string            `json:"language" yaml:"language"`
 }
 
-// DocumentApproval represents approval information
+// DocumentApproval represents approval information. Stages, when set, define an
+// ordered approval workflow; otherwise every one of RequiredApprovers must approve.
 type DocumentApproval struct {
 	RequiredApprovers []string    `json:"required_approvers" yaml:"required_approvers"`
 	ActualApprovers   []Approval  `json:"actual_approvers" yaml:"actual_approvers"`
 	Status            ApprovalStatus `json:"status" yaml:"status"`
+	Stages            []ApprovalStage     `json:"stages,omitempty" yaml:"stages,omitempty"`
+	Rejections        []ApprovalRejection `json:"rejections,omitempty" yaml:"rejections,omitempty"`
 }
 
 // Approval represents an individual approval
@@ -80,6 +94,11 @@ type Approval struct {
 	Role         string     `json:"role" yaml:"role"`
 	Timestamp    time.Time  `json:"timestamp" yaml:"timestamp"`
 	Comments     string     `json:"comments" yaml:"comments"`
+	Version      string     `json:"version,omitempty" yaml:"version,omitempty"` // document version the approval applies to
+	Stage        string     `json:"stage,omitempty" yaml:"stage,omitempty"` // approval stage and group the approval counts for
+	Group        string     `json:"group,omitempty" yaml:"group,omitempty"`
+	OnBehalfOf   string     `json:"on_behalf_of,omitempty" yaml:"on_behalf_of,omitempty"`   // delegator, when approved under a delegation
+	DelegationID string     `json:"delegation_id,omitempty" yaml:"delegation_id,omitempty"` // delegation the approval was given under
 }
 
 // ApprovalStatus represents the status of document approval
@@ -130,18 +149,33 @@ const (
 	DocumentStatusArchived  DocumentStatus = "archived"
 )
 
-// DocumentVersion represents a version of the document
+// DocumentVersion represents a version of the document. Version numbers have the
+// form major.minor.
 type DocumentVersion struct {
 	VersionNumber string    `json:"version_number" yaml:"version_number"`
 	ChangeSummary string    `json:"change_summary" yaml:"change_summary"`
 	CreatedBy     string    `json:"created_by" yaml:"created_by"`
 	CreatedAt     time.Time `json:"created_at" yaml:"created_at"`
+	// Content is the document content as of this version, kept for retrieval and rollback
+	Content   string            `json:"content,omitempty" yaml:"content,omitempty"`
+	Encrypted *EncryptedContent `json:"encrypted,omitempty" yaml:"encrypted,omitempty"` // sealed Content of confidential documents at rest
 }
 
 // DocumentationManager manages documented information
 type DocumentationManager struct {
 	Documents map[string]*DocumentedInformation `json:"documents" yaml:"documents"`
 	Index     DocumentIndex                     `json:"index" yaml:"index"`
+
+	// IDs, when set, is shared with the organization's other managers
+	IDs *IDRegistry `json:"-" yaml:"-"`
+	// Approvers, when set, is used to verify that approvers exist and hold a required role
+	Approvers ApproverDirectory `json:"-" yaml:"-"`
+	// Repository, when set, receives every change so documents are kept outside the
+	// manager as well, e.g. in a GitDocumentRepository
+	Repository DocumentRepository `json:"-" yaml:"-"`
+	// Attachments holds the content of document attachments; an in-memory store by
+	// default
+	Attachments AttachmentStore `json:"-" yaml:"-"`
 }
 
 // DocumentIndex provides search and indexing capabilities
@@ -164,18 +198,37 @@ func NewDocumentationManager() *DocumentationManager {
 			ByClause:   make(map[string][]string),
 			ByKeyword:  make(map[string][]string),
 		},
+		Attachments: NewMemoryAttachmentStore(),
 	}
 }
 
 // AddDocument adds a new document to the documentation system
 func (dm *DocumentationManager) AddDocument(doc *DocumentedInformation) error {
+	if err := checkNewDocument(doc); err != nil {
+		return err
+	}
+	_, exists := dm.Documents[doc.ID]
+	if err := claimID(dm.IDs, doc.ID, EntityTypeDocument, exists); err != nil {
+		return err
+	}
+
+	dm.insertDocument(doc)
+	return dm.save(doc, fmt.Sprintf("Add %s: %s", doc.ID, doc.Title))
+}
+
+// checkNewDocument verifies the fields required to add a document
+func checkNewDocument(doc *DocumentedInformation) error {
 	if doc.ID == "" {
 		return fmt.Errorf("document must have an ID")
 	}
 	if doc.Title == "" {
 		return fmt.Errorf("document must have a title")
 	}
+	return nil
+}
 
+// insertDocument initializes a new document and stores it in the manager
+func (dm *DocumentationManager) insertDocument(doc *DocumentedInformation) {
 	doc.Created = time.Now()
 	doc.Modified = time.Now()
 	doc.Status = DocumentStatusDraft
@@ -187,40 +240,67 @@ func (dm *DocumentationManager) AddDocument(doc *DocumentedInformation) error {
 			ChangeSummary: "Initial version",
 			CreatedBy:     doc.Metadata.Author,
 			CreatedAt:     time.Now(),
+			Content:       doc.Content,
 		}}
 	}
 
 	dm.Documents[doc.ID] = doc
 	dm.updateIndex(doc)
-
-	return nil
 }
 
-// UpdateDocument updates an existing document
-func (dm *DocumentationManager) UpdateDocument(docID string, updates *DocumentedInformation) error {
+// UpdateDocument updates an existing document as a new version. The change must be
+// summarized; a major change increments the major version number.
+func (dm *DocumentationManager) UpdateDocument(docID string, updates *DocumentedInformation, change VersionChange) error {
 	existing, exists := dm.Documents[docID]
 	if !exists {
 		return fmt.Errorf("document with ID %s not found", docID)
 	}
+	if existing.isReleased() {
+		return fmt.Errorf("%w: document %s is %s", ErrDocumentReleased, docID, existing.Status)
+	}
+	if strings.TrimSpace(change.Summary) == "" {
+		return fmt.Errorf("%w: document %s", ErrChangeSummaryRequired, docID)
+	}
+
+	// Keep the current status unless a valid transition is requested
+	if updates.Status == "" {
+		updates.Status = existing.Status
+	} else if updates.Status != existing.Status {
+		if err := documentTransitions.check(EntityTypeDocument, docID, existing.Status, updates.Status); err != nil {
+			return err
+		}
+	}
 
-	// Preserve creation date and ID
+	// Preserve creation date, ID, revision links, attachments and, unless replaced, the
+	// approval workflow
 	updates.ID = existing.ID
 	updates.Created = existing.Created
+	updates.RevisionOf = existing.RevisionOf
+	updates.SupersededBy = existing.SupersededBy
+	updates.Attachments = existing.Attachments
+	updates.Distribution = existing.Distribution
+	if updates.Approval == nil {
+		updates.Approval = existing.Approval
+	}
 	updates.Modified = time.Now()
 
 	// Add new version
 	newVersion := DocumentVersion{
-		VersionNumber: dm.getNextVersion(existing.Versions[len(existing.Versions)-1].VersionNumber),
-		ChangeSummary: "Updated document",
+		VersionNumber: nextVersion(existing.currentVersion(), change.Major),
+		ChangeSummary: change.Summary,
 		CreatedBy:     updates.Metadata.Author,
 		CreatedAt:     time.Now(),
+		Content:       updates.Content,
 	}
 	updates.Versions = append(existing.Versions, newVersion)
+	if existing.Status == DocumentStatusApproved || existing.Status == DocumentStatusReview || updates.Status == DocumentStatusApproved {
+		updates.withdrawApproval()
+	}
 
 	dm.Documents[docID] = updates
 	dm.updateIndex(updates)
 
-	return nil
+	return dm.save(updates, fmt.Sprintf("Update %s to version %s", docID, newVersion.VersionNumber))
 }
 
 // GetDocument retrieves a document by ID
@@ -263,23 +343,173 @@ func (dm *DocumentationManager) ApproveDocument(docID string, approver Approval)
 		return fmt.Errorf("document with ID %s not found", docID)
 	}
 
+	if doc.Status != DocumentStatusApproved && !doc.Status.CanTransitionTo(DocumentStatusApproved) {
+		return &InvalidTransitionError{EntityType: EntityTypeDocument, EntityID: docID, From: string(doc.Status), To: string(DocumentStatusApproved)}
+	}
+
+	now := time.Now()
+	if approver.Timestamp.IsZero() {
+		approver.Timestamp = now
+	}
+	match, err := dm.checkApprover(doc, approver)
+	if err != nil {
+		return err
+	}
+	if approver.Role == "" && match.entry != "" && match.entry != approver.ApproverID {
+		approver.Role = match.entry
+	}
+	if match.delegation != nil {
+		approver.OnBehalfOf = match.delegation.Delegator
+		approver.DelegationID = match.delegation.ID
+	}
+	approver.Version = doc.currentVersion()
+	approver.Stage = match.stage
+	approver.Group = match.group
+
 	if doc.Approval == nil {
 		doc.Approval = &DocumentApproval{}
 	}
 
 	doc.Approval.ActualApprovers = append(doc.Approval.ActualApprovers, approver)
-	doc.Modified = time.Now()
+	doc.Approval.Status = ApprovalStatusPending
+	doc.Modified = now
 
-	// Check if all required approvals are received
-	if dm.hasAllRequiredApprovals(doc) {
+	// Approve the document once every stage is complete, otherwise start the next one
+	if doc.currentStage() == len(doc.Approval.workflow()) {
 		doc.Approval.Status = ApprovalStatusApproved
 		doc.Status = DocumentStatusApproved
+	} else {
+		doc.startCurrentStage(now)
 	}
 
 	dm.updateIndex(doc)
+	if err := dm.save(doc, fmt.Sprintf("Approve %s version %s by %s", docID, approver.Version, approver.ApproverID)); err != nil {
+		return err
+	}
+	if dm.Repository != nil {
+		return dm.Repository.RecordApproval(doc, approver)
+	}
 	return nil
 }
 
+// SetDocumentStatus moves a document to a new status, e.g. to submit it for review
+// or publish it once approved
+func (dm *DocumentationManager) SetDocumentStatus(docID string, status DocumentStatus) error {
+	doc, exists := dm.Documents[docID]
+	if !exists {
+		return fmt.Errorf("document with ID %s not found", docID)
+	}
+	if err := documentTransitions.check(EntityTypeDocument, docID, doc.Status, status); err != nil {
+		return err
+	}
+
+	// Publishing a revision makes the document it revises obsolete
+	var original *DocumentedInformation
+	if status == DocumentStatusPublished && doc.RevisionOf != "" {
+		original = dm.Documents[doc.RevisionOf]
+		if original != nil && original.Status == DocumentStatusPublished {
+			if err := documentTransitions.check(EntityTypeDocument, original.ID, original.Status, DocumentStatusObsolete); err != nil {
+				return err
+			}
+		} else {
+			original = nil
+		}
+	}
+
+	doc.Status = status
+	doc.Modified = time.Now()
+	if status == DocumentStatusReview {
+		doc.startCurrentStage(doc.Modified)
+	}
+	dm.updateIndex(doc)
+
+	if original != nil {
+		original.Status = DocumentStatusObsolete
+		original.SupersededBy = doc.ID
+		original.Modified = time.Now()
+		dm.updateIndex(original)
+		if err := dm.save(original, fmt.Sprintf("Supersede %s by %s", original.ID, doc.ID)); err != nil {
+			return err
+		}
+	}
+
+	return dm.save(doc, fmt.Sprintf("Set %s status to %s", docID, status))
+}
+
+// CreateRevision starts a new draft revision of a published document under newID,
+// with the next major version number. The published document stays unchanged as
+// evidence of the approved content until the revision is itself published, at which
+// point it becomes obsolete.
+func (dm *DocumentationManager) CreateRevision(docID, newID, author, changeSummary string) (*DocumentedInformation, error) {
+	published, exists := dm.Documents[docID]
+	if !exists {
+		return nil, fmt.Errorf("document with ID %s not found", docID)
+	}
+	if published.Status != DocumentStatusPublished {
+		return nil, fmt.Errorf("only published documents can be revised, document %s is %s", docID, published.Status)
+	}
+	for _, doc := range dm.Documents {
+		if doc.RevisionOf == docID && doc.Status != DocumentStatusArchived {
+			return nil, fmt.Errorf("document %s already has an open revision %s", docID, doc.ID)
+		}
+	}
+	if changeSummary == "" {
+		changeSummary = fmt.Sprintf("Revision of %s", docID)
+	}
+
+	revision := &DocumentedInformation{
+		ID:         newID,
+		Title:      published.Title,
+		Type:       published.Type,
+		Category:   published.Category,
+		Content:    published.Content,
+		Metadata:   published.Metadata,
+		Access:     published.Access,
+		RevisionOf: docID,
+		// the revision refers to the same stored content until its attachments change
+		Attachments: append([]Attachment(nil), published.Attachments...),
+	}
+	revision.Metadata.Author = author
+	revision.Metadata.Keywords = append([]string(nil), published.Metadata.Keywords...)
+	revision.Metadata.RelatedClauses = append([]string(nil), published.Metadata.RelatedClauses...)
+	revision.Metadata.RelatedDocuments = append([]string(nil), published.Metadata.RelatedDocuments...)
+	revision.Access.ReadAccess = append([]string(nil), published.Access.ReadAccess...)
+	revision.Access.WriteAccess = append([]string(nil), published.Access.WriteAccess...)
+	if published.Approval != nil {
+		revision.Approval = &DocumentApproval{
+			RequiredApprovers: append([]string(nil), published.Approval.RequiredApprovers...),
+			Status:            ApprovalStatusPending,
+		}
+		for _, stage := range published.Approval.Stages {
+			stage.Groups = append([]ApproverGroup(nil), stage.Groups...)
+			stage.Started, stage.Escalated = nil, nil
+			revision.Approval.Stages = append(revision.Approval.Stages, stage)
+		}
+	}
+	revision.Versions = append(append([]DocumentVersion(nil), published.Versions...), DocumentVersion{
+		VersionNumber: nextVersion(published.currentVersion(), true),
+		ChangeSummary: changeSummary,
+		CreatedBy:     author,
+		CreatedAt:     time.Now(),
+		Content:       published.Content,
+	})
+
+	if err := dm.AddDocument(revision); err != nil {
+		return nil, err
+	}
+	return revision, nil
+}
+
+// isReleased reports whether the document has been published, after which its
+// content is kept unchanged as evidence
+func (doc *DocumentedInformation) isReleased() bool {
+	switch doc.Status {
+	case DocumentStatusPublished, DocumentStatusObsolete, DocumentStatusArchived:
+		return true
+	}
+	return false
+}
+
 // ReviewDocument performs a review of a document
 func (dm *DocumentationManager) ReviewDocument(docID string, review DocumentReview) error {
 	doc, exists := dm.Documents[docID]
@@ -291,7 +521,7 @@ func (dm *DocumentationManager) ReviewDocument(docID string, review DocumentRevi
 	doc.Modified = time.Now()
 
 	dm.updateIndex(doc)
-	return nil
+	return dm.save(doc, fmt.Sprintf("Review %s", docID))
 }
 
 // GetDocumentsDueForReview returns documents due for review
@@ -329,25 +559,40 @@ func (dm *DocumentationManager) ArchiveDocument(docID string, reason string) err
 	if !exists {
 		return fmt.Errorf("document with ID %s not found", docID)
 	}
+	if err := documentTransitions.check(EntityTypeDocument, docID, doc.Status, DocumentStatusArchived); err != nil {
+		return err
+	}
 
 	doc.Status = DocumentStatusArchived
 	doc.Modified = time.Now()
 
 	// Add archival version
 	newVersion := DocumentVersion{
-		VersionNumber: dm.getNextVersion(doc.Versions[len(doc.Versions)-1].VersionNumber),
+		VersionNumber: nextVersion(doc.currentVersion(), false),
 		ChangeSummary: fmt.Sprintf("Archived: %s", reason),
 		CreatedBy:     "system",
 		CreatedAt:     time.Now(),
+		Content:       doc.Content,
 	}
 	doc.Versions = append(doc.Versions, newVersion)
 
 	dm.updateIndex(doc)
-	return nil
+	return dm.save(doc, fmt.Sprintf("Archive %s: %s", docID, reason))
 }
 
 // Helper methods
 
+// save passes a changed document to the repository, if one is set
+func (dm *DocumentationManager) save(doc *DocumentedInformation, message string) error {
+	if dm.Repository == nil {
+		return nil
+	}
+	if err := dm.Repository.SaveDocument(doc, message); err != nil {
+		return fmt.Errorf("failed to save document %s: %w", doc.ID, err)
+	}
+	return nil
+}
+
 func (dm *DocumentationManager) updateIndex(doc *DocumentedInformation) {
 	// Remove from old index positions
 	dm.removeFromIndex(doc.ID)
@@ -367,8 +612,28 @@ func (dm *DocumentationManager) updateIndex(doc *DocumentedInformation) {
 }
 
 func (dm *DocumentationManager) removeFromIndex(docID string) {
-	// Implementation would remove the document ID from all index maps
-	// This is a simplified version
+	removeID(dm.Index.ByType, docID)
+	removeID(dm.Index.ByCategory, docID)
+	removeID(dm.Index.ByStatus, docID)
+	removeID(dm.Index.ByClause, docID)
+	removeID(dm.Index.ByKeyword, docID)
+}
+
+// removeID removes an ID from every list of an index map, dropping empty lists
+func removeID[K comparable](index map[K][]string, id string) {
+	for key, ids := range index {
+		kept := ids[:0]
+		for _, existing := range ids {
+			if existing != id {
+				kept = append(kept, existing)
+			}
+		}
+		if len(kept) == 0 {
+			delete(index, key)
+		} else {
+			index[key] = kept
+		}
+	}
 }
 
 func (dm *DocumentationManager) matchesCriteria(doc *DocumentedInformation, criteria DocumentSearchCriteria) bool {
@@ -396,30 +661,6 @@ func (dm *DocumentationManager) matchesCriteria(doc *DocumentedInformation, crit
 	return true
 }
 
-func (dm *DocumentationManager) hasAllRequiredApprovals(doc *DocumentedInformation) bool {
-	if doc.Approval == nil {
-		return false
-	}
-
-	approvedBy := make(map[string]bool)
-	for _, approval := range doc.Approval.ActualApprovers {
-		approvedBy[approval.ApproverID] = true
-	}
-
-	for _, required := range doc.Approval.RequiredApprovers {
-		if !approvedBy[required] {
-			return false
-		}
-	}
-
-	return true
-}
-
-func (dm *DocumentationManager) getNextVersion(currentVersion string) string {
-	// Simple version incrementing logic
-	return fmt.Sprintf("%s.1", currentVersion)
-}
-
 func containsString(search string, items ...string) bool {
 	for _, item := range items {
 		if item == search {
@@ -0,0 +1,243 @@
+package iso9001
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPublishedDocumentRevisions(t *testing.T) {
+	dm := NewDocumentationManager()
+	dm.AddDocument(&DocumentedInformation{ID: "DOC-001", Title: "Procedure", Content: "Approved content"})
+	dm.ApproveDocument("DOC-001", Approval{ApproverID: "QM"})
+	if err := dm.SetDocumentStatus("DOC-001", DocumentStatusPublished); err != nil {
+		t.Fatalf("Failed to publish document: %v", err)
+	}
+
+	err := dm.UpdateDocument("DOC-001", &DocumentedInformation{Title: "Procedure", Content: "Edited"}, VersionChange{Summary: "Edit"})
+	if !errors.Is(err, ErrDocumentReleased) {
+		t.Fatalf("Expected ErrDocumentReleased, got %v", err)
+	}
+
+	revision, err := dm.CreateRevision("DOC-001", "DOC-001-R2", "Author", "Clarify scope")
+	if err != nil {
+		t.Fatalf("Failed to create revision: %v", err)
+	}
+	if revision.Status != DocumentStatusDraft || revision.RevisionOf != "DOC-001" {
+		t.Errorf("Expected draft revision linked to DOC-001, got %s / %q", revision.Status, revision.RevisionOf)
+	}
+	if err := dm.UpdateDocument("DOC-001-R2", &DocumentedInformation{Title: "Procedure", Content: "Edited"}, VersionChange{Summary: "Edit"}); err != nil {
+		t.Fatalf("Failed to edit revision: %v", err)
+	}
+
+	dm.ApproveDocument("DOC-001-R2", Approval{ApproverID: "QM"})
+	if err := dm.SetDocumentStatus("DOC-001-R2", DocumentStatusPublished); err != nil {
+		t.Fatalf("Failed to publish revision: %v", err)
+	}
+
+	original, _ := dm.GetDocument("DOC-001")
+	if original.Content != "Approved content" {
+		t.Errorf("Expected published content to be preserved, got %q", original.Content)
+	}
+	if original.Status != DocumentStatusObsolete || original.SupersededBy != "DOC-001-R2" {
+		t.Errorf("Expected original to be obsolete and superseded, got %s / %q", original.Status, original.SupersededBy)
+	}
+}
+
+func TestApproveDocumentValidatesApprovers(t *testing.T) {
+	org := &Organization{
+		Leadership: &Leadership{
+			TopManagement: []Person{{ID: "P-001", Name: "Ada", Role: "CEO"}},
+			Roles: []OrganizationalRole{
+				{ID: "ROLE-QM", Name: "Quality Manager", AssignedTo: "P-002"},
+				{ID: "ROLE-OPS", Name: "Operations Manager", AssignedTo: "P-003"},
+			},
+		},
+	}
+	directory := NewOrganizationDirectory(org)
+
+	dm := NewDocumentationManager()
+	dm.Approvers = directory
+	dm.AddDocument(&DocumentedInformation{
+		ID:       "DOC-001",
+		Title:    "Procedure",
+		Approval: &DocumentApproval{RequiredApprovers: []string{"Quality Manager", "CEO"}},
+	})
+
+	if err := dm.ApproveDocument("DOC-001", Approval{ApproverID: "P-999"}); !errors.Is(err, ErrUnknownApprover) {
+		t.Errorf("Expected ErrUnknownApprover, got %v", err)
+	}
+	if err := dm.ApproveDocument("DOC-001", Approval{ApproverID: "P-003"}); !errors.Is(err, ErrApproverNotAuthorized) {
+		t.Errorf("Expected ErrApproverNotAuthorized, got %v", err)
+	}
+	if err := dm.ApproveDocument("DOC-001", Approval{ApproverID: "P-002"}); err != nil {
+		t.Fatalf("Expected quality manager approval, got %v", err)
+	}
+	if err := dm.ApproveDocument("DOC-001", Approval{ApproverID: "P-002"}); !errors.Is(err, ErrAlreadyApproved) {
+		t.Errorf("Expected ErrAlreadyApproved, got %v", err)
+	}
+
+	doc, _ := dm.GetDocument("DOC-001")
+	if doc.Status == DocumentStatusApproved {
+		t.Fatal("Document should still await CEO approval")
+	}
+
+	directory.Delegate("CEO", "P-003")
+	if err := dm.ApproveDocument("DOC-001", Approval{ApproverID: "P-003"}); err != nil {
+		t.Fatalf("Expected delegated approval, got %v", err)
+	}
+	if doc.Status != DocumentStatusApproved {
+		t.Errorf("Expected document to be approved, got %s", doc.Status)
+	}
+}
+
+func TestApproveDocumentTimeBoundDelegation(t *testing.T) {
+	org := &Organization{
+		Leadership: &Leadership{
+			TopManagement: []Person{{ID: "P-001", Name: "Ada", Role: "CEO"}},
+			Roles:         []OrganizationalRole{{ID: "ROLE-OPS", Name: "Operations Manager", AssignedTo: "P-003"}},
+		},
+	}
+	directory := NewOrganizationDirectory(org)
+	dm := NewDocumentationManager()
+	dm.Approvers = directory
+	dm.AddDocument(&DocumentedInformation{
+		ID:       "DOC-001",
+		Title:    "Training procedure",
+		Category: CategoryTraining,
+		Approval: &DocumentApproval{RequiredApprovers: []string{"CEO"}},
+	})
+
+	from := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	if _, err := directory.AddDelegation(Delegation{Delegator: "P-001", Delegate: "P-003", Roles: []string{"Auditor"}, From: from, Until: from.AddDate(0, 0, 14)}); !errors.Is(err, ErrInvalidDelegation) {
+		t.Errorf("Expected ErrInvalidDelegation for a role the delegator does not hold, got %v", err)
+	}
+	delegation, err := directory.AddDelegation(Delegation{
+		Delegator:  "P-001",
+		Delegate:   "P-003",
+		Roles:      []string{"CEO"},
+		Categories: []DocumentCategory{CategoryTraining},
+		From:       from,
+		Until:      from.AddDate(0, 0, 14),
+		Reason:     "Annual leave",
+	})
+	if err != nil {
+		t.Fatalf("Failed to add delegation: %v", err)
+	}
+	if len(org.Leadership.Delegations) != 1 || org.Leadership.Delegations[0].ID != delegation.ID {
+		t.Errorf("Expected delegation to be kept in the organization, got %+v", org.Leadership.Delegations)
+	}
+
+	if err := dm.ApproveDocument("DOC-001", Approval{ApproverID: "P-003", Timestamp: from.AddDate(0, 0, 20)}); !errors.Is(err, ErrApproverNotAuthorized) {
+		t.Errorf("Expected expired delegation to be rejected, got %v", err)
+	}
+	if err := dm.ApproveDocument("DOC-001", Approval{ApproverID: "P-003", Timestamp: from.AddDate(0, 0, 3)}); err != nil {
+		t.Fatalf("Expected delegated approval, got %v", err)
+	}
+
+	doc, _ := dm.GetDocument("DOC-001")
+	approval := doc.Approval.ActualApprovers[0]
+	if approval.OnBehalfOf != "P-001" || approval.DelegationID != delegation.ID || approval.Role != "CEO" {
+		t.Errorf("Expected delegation in approval history, got %+v", approval)
+	}
+	if doc.Status != DocumentStatusApproved {
+		t.Errorf("Expected document to be approved, got %s", doc.Status)
+	}
+
+	if err := directory.RevokeDelegation(delegation.ID, from.AddDate(0, 0, 5)); err != nil {
+		t.Fatalf("Failed to revoke delegation: %v", err)
+	}
+	if _, ok := directory.DelegationFor("P-003", "CEO", doc, from.AddDate(0, 0, 6)); ok {
+		t.Error("Expected revoked delegation to be inactive")
+	}
+}
+
+func TestDocumentVersions(t *testing.T) {
+	dm := NewDocumentationManager()
+	dm.AddDocument(&DocumentedInformation{ID: "DOC-001", Title: "Procedure", Content: "First draft"})
+
+	if err := dm.UpdateDocument("DOC-001", &DocumentedInformation{Title: "Procedure", Content: "Second"}, VersionChange{}); !errors.Is(err, ErrChangeSummaryRequired) {
+		t.Fatalf("Expected update without a summary to be rejected, got %v", err)
+	}
+	if err := dm.UpdateDocument("DOC-001", &DocumentedInformation{Title: "Procedure", Content: "Second"}, VersionChange{Summary: "Clarify scope"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := dm.UpdateDocument("DOC-001", &DocumentedInformation{Title: "Procedure", Content: "Rewritten"}, VersionChange{Summary: "Restructure", Major: true}); err != nil {
+		t.Fatal(err)
+	}
+	doc, _ := dm.GetDocument("DOC-001")
+	var numbers []string
+	for _, version := range doc.Versions {
+		numbers = append(numbers, version.VersionNumber)
+	}
+	if strings.Join(numbers, " ") != "1.0 1.1 2.0" {
+		t.Errorf("Expected versions 1.0 1.1 2.0, got %v", numbers)
+	}
+
+	version, err := dm.GetDocumentVersion("DOC-001", "1.1")
+	if err != nil || version.Content != "Second" || version.ChangeSummary != "Clarify scope" {
+		t.Errorf("Expected version 1.1 with its content, got %+v %v", version, err)
+	}
+	if _, err := dm.GetDocumentVersion("DOC-001", "3.0"); !errors.Is(err, ErrVersionNotFound) {
+		t.Errorf("Expected unknown version to be reported, got %v", err)
+	}
+
+	rollback, err := dm.RollbackDocument("DOC-001", "1.0", "Ada")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rollback.VersionNumber != "2.1" || doc.Content != "First draft" {
+		t.Errorf("Expected rollback as version 2.1 with the content of 1.0, got %s %q", rollback.VersionNumber, doc.Content)
+	}
+	if log := doc.Changelog(); !strings.HasPrefix(log, "2.1 (") || !strings.Contains(log, "Ada): Rolled back to version 1.0") {
+		t.Errorf("Unexpected changelog:\n%s", log)
+	}
+
+	dm.ApproveDocument("DOC-001", Approval{ApproverID: "QM"})
+	dm.SetDocumentStatus("DOC-001", DocumentStatusPublished)
+	if _, err := dm.RollbackDocument("DOC-001", "1.1", "Ada"); !errors.Is(err, ErrDocumentReleased) {
+		t.Errorf("Expected published document to refuse rollback, got %v", err)
+	}
+	revision, err := dm.CreateRevision("DOC-001", "DOC-001-R3", "Ada", "Annual review")
+	if err != nil || revision.currentVersion() != "3.0" {
+		t.Errorf("Expected revision to start version 3.0, got %v", err)
+	}
+}
+
+func TestDocumentEditWithdrawsApproval(t *testing.T) {
+	dm := NewDocumentationManager()
+	dm.AddDocument(&DocumentedInformation{ID: "DOC-001", Title: "Procedure", Content: "Approved text", Approval: &DocumentApproval{RequiredApprovers: []string{"QM"}}})
+	if err := dm.ApproveDocument("DOC-001", Approval{ApproverID: "QM"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := dm.UpdateDocument("DOC-001", &DocumentedInformation{Title: "Procedure", Content: "Unapproved text"}, VersionChange{Summary: "Late edit"}); err != nil {
+		t.Fatal(err)
+	}
+	doc, _ := dm.GetDocument("DOC-001")
+	if doc.Status != DocumentStatusDraft || doc.Approval.Status != ApprovalStatusPending {
+		t.Errorf("Expected the edit to return the document to draft pending approval, got %s %s", doc.Status, doc.Approval.Status)
+	}
+	var transition *InvalidTransitionError
+	if err := dm.SetDocumentStatus("DOC-001", DocumentStatusPublished); !errors.As(err, &transition) {
+		t.Fatalf("Expected edited content to be refused publication, got %v", err)
+	}
+
+	// approving the new version allows publishing it; a rollback withdraws it again
+	if err := dm.ApproveDocument("DOC-001", Approval{ApproverID: "QM"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := dm.RollbackDocument("DOC-001", "1.0", "Ada"); err != nil {
+		t.Fatal(err)
+	}
+	if err := dm.SetDocumentStatus("DOC-001", DocumentStatusPublished); !errors.As(err, &transition) {
+		t.Errorf("Expected rolled back content to be refused publication, got %v", err)
+	}
+	if err := dm.ApproveDocument("DOC-001", Approval{ApproverID: "QM"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := dm.SetDocumentStatus("DOC-001", DocumentStatusPublished); err != nil {
+		t.Errorf("Expected the approved version to be published, got %v", err)
+	}
+}
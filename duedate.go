@@ -0,0 +1,234 @@
+package iso9001
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// holidayLayout is the date format used to key holiday calendars
+const holidayLayout = "2006-01-02"
+
+// ErrNoBusinessDays is returned for a calendar whose weekend covers the whole week
+var ErrNoBusinessDays = errors.New("calendar has no business days")
+
+// WorkCalendar is the working week and the holidays of an organization, which its due
+// dates are counted in
+type WorkCalendar struct {
+	// BusinessDays counts due date offsets in business days instead of calendar days
+	BusinessDays bool `json:"business_days,omitempty" yaml:"business_days,omitempty"`
+	// Weekend names the non-working weekdays, e.g. "friday"; empty means Saturday and Sunday
+	Weekend []string `json:"weekend,omitempty" yaml:"weekend,omitempty"`
+	// Holidays are the non-working calendar dates
+	Holidays []Holiday `json:"holidays,omitempty" yaml:"holidays,omitempty"`
+}
+
+// Holiday is a non-working calendar date
+type Holiday struct {
+	Date string `json:"date" yaml:"date"` // YYYY-MM-DD
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+}
+
+// Validate checks that the weekdays and dates of the calendar are known and that it
+// leaves at least one business day a week
+func (wc *WorkCalendar) Validate() error {
+	_, err := wc.calculator(time.UTC)
+	return err
+}
+
+// calculator creates a due date calculator for the calendar in a time zone
+func (wc *WorkCalendar) calculator(loc *time.Location) (*DueDateCalculator, error) {
+	calc := NewDueDateCalculator(loc)
+	calc.BusinessDays = wc.BusinessDays
+	for _, name := range wc.Weekend {
+		day, err := parseWeekday(name)
+		if err != nil {
+			return nil, err
+		}
+		calc.Weekend = append(calc.Weekend, day)
+	}
+	for _, holiday := range wc.Holidays {
+		date, err := time.ParseInLocation(holidayLayout, holiday.Date, loc)
+		if err != nil {
+			return nil, fmt.Errorf("invalid holiday date %q, expected YYYY-MM-DD", holiday.Date)
+		}
+		calc.AddHoliday(date, holiday.Name)
+	}
+	return calc, calc.Validate()
+}
+
+// parseWeekday parses an English weekday name, in any case
+func parseWeekday(name string) (time.Weekday, error) {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if strings.EqualFold(strings.TrimSpace(name), day.String()) {
+			return day, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown weekday %q", name)
+}
+
+// DueDateCalculator computes deadlines and overdue status in an organization's time
+// zone. Deadlines fall at the end of the due day, so an item due "today" is not
+// overdue until local midnight, wherever the server runs.
+type DueDateCalculator struct {
+	// Location is the organization's time zone; nil means UTC
+	Location *time.Location
+	// BusinessDays makes DueDate skip weekends and holidays when counting days
+	BusinessDays bool
+	// Weekend lists the non-working weekdays; nil means Saturday and Sunday
+	Weekend []time.Weekday
+
+	holidays map[string]string
+}
+
+// NewDueDateCalculator creates a calculator for the given time zone
+func NewDueDateCalculator(loc *time.Location) *DueDateCalculator {
+	return &DueDateCalculator{Location: loc, holidays: make(map[string]string)}
+}
+
+// NewDueDateCalculatorForOrganization creates a calculator using the organization's
+// TimeZone, falling back to UTC when none is set, and its Calendar
+func NewDueDateCalculatorForOrganization(org *Organization) (*DueDateCalculator, error) {
+	loc := time.UTC
+	if org.TimeZone != "" {
+		var err error
+		if loc, err = time.LoadLocation(org.TimeZone); err != nil {
+			return nil, fmt.Errorf("invalid time zone %q: %w", org.TimeZone, err)
+		}
+	}
+	if org.Calendar == nil {
+		return NewDueDateCalculator(loc), nil
+	}
+	return org.Calendar.calculator(loc)
+}
+
+// Validate checks that the calculator leaves at least one business day a week
+func (c *DueDateCalculator) Validate() error {
+	if !c.hasBusinessDays() {
+		return fmt.Errorf("%w: every weekday is a weekend day", ErrNoBusinessDays)
+	}
+	return nil
+}
+
+// AddHoliday marks a calendar date as a non-working day
+func (c *DueDateCalculator) AddHoliday(date time.Time, name string) {
+	if c.holidays == nil {
+		c.holidays = make(map[string]string)
+	}
+	c.holidays[c.local(date).Format(holidayLayout)] = name
+}
+
+// Holiday returns the name of the holiday falling on date, if any
+func (c *DueDateCalculator) Holiday(date time.Time) (string, bool) {
+	name, ok := c.holidays[c.local(date).Format(holidayLayout)]
+	return name, ok
+}
+
+// IsBusinessDay reports whether date is neither a weekend day nor a holiday in the
+// calculator's time zone
+func (c *DueDateCalculator) IsBusinessDay(date time.Time) bool {
+	date = c.local(date)
+
+	weekend := c.Weekend
+	if weekend == nil {
+		weekend = []time.Weekday{time.Saturday, time.Sunday}
+	}
+	for _, day := range weekend {
+		if date.Weekday() == day {
+			return false
+		}
+	}
+
+	_, holiday := c.Holiday(date)
+	return !holiday
+}
+
+// AddBusinessDays returns the end of the day that lies days business days after from.
+// Zero days returns the end of from's day, or of the next business day if from is
+// not a business day. A calculator failing Validate, having no business days at all,
+// counts calendar days instead.
+func (c *DueDateCalculator) AddBusinessDays(from time.Time, days int) time.Time {
+	if !c.hasBusinessDays() {
+		return c.AddCalendarDays(from, days)
+	}
+	date := c.startOfDay(from)
+	for !c.IsBusinessDay(date) {
+		date = date.AddDate(0, 0, 1)
+	}
+	for days > 0 {
+		date = date.AddDate(0, 0, 1)
+		if c.IsBusinessDay(date) {
+			days--
+		}
+	}
+	return c.EndOfDay(date)
+}
+
+// AddCalendarDays returns the end of the day that lies days calendar days after from,
+// counted in the calculator's time zone so DST changes do not shift the deadline
+func (c *DueDateCalculator) AddCalendarDays(from time.Time, days int) time.Time {
+	return c.EndOfDay(c.startOfDay(from).AddDate(0, 0, days))
+}
+
+// DueDate returns the deadline days after from, counting business days when
+// BusinessDays is set and calendar days otherwise
+func (c *DueDateCalculator) DueDate(from time.Time, days int) time.Time {
+	if c.BusinessDays {
+		return c.AddBusinessDays(from, days)
+	}
+	return c.AddCalendarDays(from, days)
+}
+
+// EndOfDay returns the last instant of t's day in the calculator's time zone
+func (c *DueDateCalculator) EndOfDay(t time.Time) time.Time {
+	return c.startOfDay(t).AddDate(0, 0, 1).Add(-time.Nanosecond)
+}
+
+// IsOverdue reports whether an item due at due is overdue at now. A nil calculator
+// compares the instants directly.
+func (c *DueDateCalculator) IsOverdue(due, now time.Time) bool {
+	if due.IsZero() {
+		return false
+	}
+	if c == nil {
+		return due.Before(now)
+	}
+	return c.EndOfDay(due).Before(now)
+}
+
+// hasBusinessDays reports whether some weekday is not a weekend day. Holidays are
+// finite, so any such weekday is eventually a business day.
+func (c *DueDateCalculator) hasBusinessDays() bool {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if !containsWeekday(c.Weekend, day) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsWeekday(days []time.Weekday, day time.Weekday) bool {
+	for _, d := range days {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *DueDateCalculator) location() *time.Location {
+	if c.Location == nil {
+		return time.UTC
+	}
+	return c.Location
+}
+
+func (c *DueDateCalculator) local(t time.Time) time.Time {
+	return t.In(c.location())
+}
+
+func (c *DueDateCalculator) startOfDay(t time.Time) time.Time {
+	t = c.local(t)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
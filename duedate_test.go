@@ -0,0 +1,141 @@
+package iso9001
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDueDateCalculatorBusinessDays(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("Time zone data unavailable: %v", err)
+	}
+
+	calc := NewDueDateCalculator(berlin)
+	calc.BusinessDays = true
+	calc.AddHoliday(time.Date(2025, 12, 25, 0, 0, 0, 0, berlin), "Christmas Day")
+	calc.AddHoliday(time.Date(2025, 12, 26, 0, 0, 0, 0, berlin), "Boxing Day")
+
+	// Tuesday 23 Dec 2025, 23:30 UTC is already Wednesday 24 Dec in Berlin
+	from := time.Date(2025, 12, 23, 23, 30, 0, 0, time.UTC)
+	due := calc.DueDate(from, 2)
+
+	want := time.Date(2025, 12, 31, 0, 0, 0, 0, berlin).Add(-time.Nanosecond)
+	if !due.Equal(want) {
+		t.Errorf("Expected due date %v, got %v", want, due)
+	}
+}
+
+func TestDueDateCalculatorOverdueAtLocalEndOfDay(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skipf("Time zone data unavailable: %v", err)
+	}
+
+	calc := NewDueDateCalculator(tokyo)
+	due := time.Date(2025, 3, 10, 9, 0, 0, 0, tokyo)
+
+	if calc.IsOverdue(due, time.Date(2025, 3, 10, 23, 0, 0, 0, tokyo)) {
+		t.Error("Item should not be overdue before the end of its due day")
+	}
+	if !calc.IsOverdue(due, time.Date(2025, 3, 11, 0, 0, 1, 0, tokyo)) {
+		t.Error("Item should be overdue after its due day ended")
+	}
+}
+
+func TestDueDateCalculatorWithoutBusinessDays(t *testing.T) {
+	calc := NewDueDateCalculator(time.UTC)
+	calc.BusinessDays = true
+	calc.Weekend = []time.Weekday{time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday}
+
+	if err := calc.Validate(); !errors.Is(err, ErrNoBusinessDays) {
+		t.Errorf("Expected ErrNoBusinessDays, got %v", err)
+	}
+	// counts calendar days instead of searching for a business day forever
+	from := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	if due, want := calc.DueDate(from, 3), calc.AddCalendarDays(from, 3); !due.Equal(want) {
+		t.Errorf("Expected due date %v, got %v", want, due)
+	}
+
+	org := &Organization{ID: "ORG-001", Calendar: &WorkCalendar{Weekend: []string{
+		"sunday", "monday", "tuesday", "wednesday", "thursday", "friday", "saturday",
+	}}}
+	if _, err := NewDueDateCalculatorForOrganization(org); !errors.Is(err, ErrNoBusinessDays) {
+		t.Errorf("Expected an all-weekend calendar to be rejected, got %v", err)
+	}
+}
+
+func TestDueDateCalculatorForOrganizationCalendar(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("Time zone data unavailable: %v", err)
+	}
+
+	org := &Organization{ID: "ORG-001", TimeZone: "Europe/Berlin", Calendar: &WorkCalendar{
+		BusinessDays: true,
+		Weekend:      []string{"Friday", "saturday"},
+		Holidays:     []Holiday{{Date: "2025-12-25", Name: "Christmas Day"}},
+	}}
+	calc, err := NewDueDateCalculatorForOrganization(org)
+	if err != nil {
+		t.Fatalf("Failed to create calculator: %v", err)
+	}
+
+	// Wednesday 24 Dec plus one business day skips the holiday and the Friday-Saturday
+	// weekend to Sunday 28 Dec
+	due := calc.DueDate(time.Date(2025, 12, 24, 9, 0, 0, 0, berlin), 1)
+	want := time.Date(2025, 12, 29, 0, 0, 0, 0, berlin).Add(-time.Nanosecond)
+	if !due.Equal(want) {
+		t.Errorf("Expected due date %v, got %v", want, due)
+	}
+
+	org.Calendar.Holidays = []Holiday{{Date: "25.12.2025"}}
+	if _, err := NewDueDateCalculatorForOrganization(org); err == nil {
+		t.Error("Expected an invalid holiday date to be rejected")
+	}
+	org.Calendar.Holidays = nil
+	org.Calendar.Weekend = []string{"caturday"}
+	if err := org.Calendar.Validate(); err == nil {
+		t.Error("Expected an unknown weekday to be rejected")
+	}
+}
+
+func TestTenantDueDatesFollowOrganization(t *testing.T) {
+	if _, err := time.LoadLocation("Asia/Tokyo"); err != nil {
+		t.Skipf("Time zone data unavailable: %v", err)
+	}
+
+	tenant := NewTenant("ORG-001")
+	err := tenant.Change("admin", "set time zone", func(tenant *Tenant) error {
+		tenant.Organization = &Organization{ID: "ORG-001", Name: "Acme", TimeZone: "Asia/Tokyo"}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Failed to change tenant: %v", err)
+	}
+	for name, calc := range map[string]*DueDateCalculator{
+		"risks": tenant.Risks.DueDates, "objectives": tenant.Objectives.DueDates,
+		"audits": tenant.Audits.DueDates, "feedback": tenant.Feedback.DueDates,
+	} {
+		if calc == nil || calc.location().String() != "Asia/Tokyo" {
+			t.Errorf("Expected the %s due dates in the organization's time zone, got %+v", name, calc)
+		}
+	}
+
+	// an invalid calendar rolls the change back
+	entries := len(tenant.Trail.Entries)
+	err = tenant.Change("admin", "set calendar", func(tenant *Tenant) error {
+		tenant.Organization.TimeZone = "Mars/Olympus_Mons"
+		return nil
+	})
+	if err == nil {
+		t.Fatal("Expected an invalid time zone to be rejected")
+	}
+	if tenant.Organization.TimeZone != "Asia/Tokyo" || len(tenant.Trail.Entries) != entries {
+		t.Errorf("Expected the change to be rolled back, got time zone %q", tenant.Organization.TimeZone)
+	}
+	if tenant.Audits.DueDates == nil || tenant.Audits.DueDates.location().String() != "Asia/Tokyo" {
+		t.Error("Expected the due dates to survive the rollback")
+	}
+}
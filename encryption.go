@@ -0,0 +1,316 @@
+package iso9001
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Errors returned when document content cannot be sealed or opened
+var (
+	ErrKeyNotFound      = errors.New("encryption key not found")
+	ErrDecryptionFailed = errors.New("document content could not be decrypted")
+)
+
+// Document classifications, from least to most sensitive
+const (
+	ClassificationPublic       = "public"
+	ClassificationInternal     = "internal"
+	ClassificationConfidential = "confidential"
+	ClassificationRestricted   = "restricted"
+)
+
+// EncryptionAlgorithm identifies the cipher used for EncryptedContent
+const EncryptionAlgorithm = "AES-256-GCM"
+
+// EncryptedContent is document content sealed for storage. The document ID is bound to
+// the ciphertext, so sealed content cannot be moved to another document unnoticed.
+type EncryptedContent struct {
+	Algorithm  string `json:"algorithm" yaml:"algorithm"`
+	KeyID      string `json:"key_id" yaml:"key_id"`
+	Nonce      []byte `json:"nonce" yaml:"nonce"`
+	Ciphertext []byte `json:"ciphertext" yaml:"ciphertext"`
+}
+
+// KeyProvider supplies the data keys used to seal document content. Implement it to
+// fetch keys from a key management service; keys are identified so they can be
+// rotated while content sealed under older keys stays readable.
+type KeyProvider interface {
+	// CurrentKey returns the key new content is sealed with
+	CurrentKey() (keyID string, key []byte, err error)
+	// Key returns the key with the given ID, or an error wrapping ErrKeyNotFound
+	Key(keyID string) ([]byte, error)
+}
+
+// StaticKeyProvider holds data keys in memory
+type StaticKeyProvider struct {
+	mu      sync.RWMutex
+	keys    map[string][]byte
+	current string
+}
+
+// NewStaticKeyProvider creates a key provider with no keys
+func NewStaticKeyProvider() *StaticKeyProvider {
+	return &StaticKeyProvider{keys: make(map[string][]byte)}
+}
+
+// AddKey registers a 32-byte AES-256 key. The first key added becomes the current key.
+func (p *StaticKeyProvider) AddKey(keyID string, key []byte) error {
+	if keyID == "" {
+		return errors.New("key ID is required")
+	}
+	if len(key) != 32 {
+		return fmt.Errorf("key %s must be 32 bytes, got %d", keyID, len(key))
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.keys[keyID] = append([]byte(nil), key...)
+	if p.current == "" {
+		p.current = keyID
+	}
+	return nil
+}
+
+// SetCurrent selects the key new content is sealed with, e.g. after rotation
+func (p *StaticKeyProvider) SetCurrent(keyID string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, ok := p.keys[keyID]; !ok {
+		return fmt.Errorf("%w: %s", ErrKeyNotFound, keyID)
+	}
+	p.current = keyID
+	return nil
+}
+
+// CurrentKey returns the current key
+func (p *StaticKeyProvider) CurrentKey() (string, []byte, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.current == "" {
+		return "", nil, fmt.Errorf("%w: no current key", ErrKeyNotFound)
+	}
+	return p.current, p.keys[p.current], nil
+}
+
+// Key returns the key with the given ID
+func (p *StaticKeyProvider) Key(keyID string) ([]byte, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	key, ok := p.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrKeyNotFound, keyID)
+	}
+	return key, nil
+}
+
+// RequiresEncryption reports whether content of the classification is encrypted at rest
+func RequiresEncryption(classification string) bool {
+	switch strings.ToLower(classification) {
+	case ClassificationConfidential, ClassificationRestricted:
+		return true
+	}
+	return false
+}
+
+// SealDocument returns the document as it should be stored. Content of confidential
+// and restricted documents, and the content kept with each of their versions, is
+// encrypted with the current key and cleared; other documents, and documents already
+// sealed, are returned unchanged. The original document is never modified.
+func SealDocument(doc *DocumentedInformation, keys KeyProvider) (*DocumentedInformation, error) {
+	if doc == nil || doc.Encrypted != nil || !RequiresEncryption(doc.Access.Classification) {
+		return doc, nil
+	}
+
+	keyID, key, err := keys.CurrentKey()
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newDocumentCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	sealed := *doc
+	sealed.Content = ""
+	if sealed.Encrypted, err = sealContent(aead, keyID, doc.Content, doc.ID); err != nil {
+		return nil, err
+	}
+	sealed.Versions = append([]DocumentVersion(nil), doc.Versions...)
+	for i := range sealed.Versions {
+		version := &sealed.Versions[i]
+		if version.Content == "" || version.Encrypted != nil {
+			continue
+		}
+		if version.Encrypted, err = sealContent(aead, keyID, version.Content, versionBinding(doc.ID, version.VersionNumber)); err != nil {
+			return nil, err
+		}
+		version.Content = ""
+	}
+	return &sealed, nil
+}
+
+// sealContent encrypts plaintext bound to the given additional data
+func sealContent(aead cipher.AEAD, keyID, plaintext, binding string) (*EncryptedContent, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	return &EncryptedContent{
+		Algorithm:  EncryptionAlgorithm,
+		KeyID:      keyID,
+		Nonce:      nonce,
+		Ciphertext: aead.Seal(nil, nonce, []byte(plaintext), []byte(binding)),
+	}, nil
+}
+
+// versionBinding binds the sealed content of a version to its document and number
+func versionBinding(docID, version string) string {
+	return docID + "@" + version
+}
+
+// OpenDocument decrypts sealed content, including the content of its versions, back
+// in place. Documents that are not sealed are left unchanged.
+func OpenDocument(doc *DocumentedInformation, keys KeyProvider) error {
+	if doc == nil {
+		return nil
+	}
+	if doc.Encrypted != nil {
+		plaintext, err := openContent(doc.Encrypted, keys, doc.ID, doc.ID)
+		if err != nil {
+			return err
+		}
+		doc.Content = plaintext
+		doc.Encrypted = nil
+	}
+	copied := false
+	for i := range doc.Versions {
+		if doc.Versions[i].Encrypted == nil {
+			continue
+		}
+		if !copied {
+			// copies of a sealed document share its versions, which must stay sealed
+			doc.Versions = append([]DocumentVersion(nil), doc.Versions...)
+			copied = true
+		}
+		version := &doc.Versions[i]
+		plaintext, err := openContent(version.Encrypted, keys, doc.ID, versionBinding(doc.ID, version.VersionNumber))
+		if err != nil {
+			return err
+		}
+		version.Content = plaintext
+		version.Encrypted = nil
+	}
+	return nil
+}
+
+// openContent decrypts sealed content bound to the given additional data
+func openContent(sealed *EncryptedContent, keys KeyProvider, docID, binding string) (string, error) {
+	if sealed.Algorithm != EncryptionAlgorithm {
+		return "", fmt.Errorf("%w: unsupported algorithm %q for document %s", ErrDecryptionFailed, sealed.Algorithm, docID)
+	}
+
+	key, err := keys.Key(sealed.KeyID)
+	if err != nil {
+		return "", err
+	}
+	aead, err := newDocumentCipher(key)
+	if err != nil {
+		return "", err
+	}
+	plaintext, err := aead.Open(nil, sealed.Nonce, sealed.Ciphertext, []byte(binding))
+	if err != nil {
+		return "", fmt.Errorf("%w: document %s", ErrDecryptionFailed, docID)
+	}
+	return string(plaintext), nil
+}
+
+func newDocumentCipher(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid encryption key: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// EncryptingTenantBackend seals confidential document content before it reaches the
+// wrapped backend and opens it again on load, so tenants held in memory always carry
+// plain content while the stored copies do not
+type EncryptingTenantBackend struct {
+	Backend TenantBackend
+	Keys    KeyProvider
+}
+
+// LoadTenant loads a tenant and decrypts its sealed documents
+func (eb *EncryptingTenantBackend) LoadTenant(id string) (*Tenant, error) {
+	tenant, err := eb.Backend.LoadTenant(id)
+	if err != nil {
+		return nil, err
+	}
+	if tenant.Documents != nil {
+		for _, doc := range tenant.Documents.Documents {
+			if err := OpenDocument(doc, eb.Keys); err != nil {
+				return nil, fmt.Errorf("failed to decrypt tenant %s: %w", id, err)
+			}
+		}
+	}
+	return tenant, nil
+}
+
+// SaveTenant saves a copy of the tenant whose confidential documents are sealed
+func (eb *EncryptingTenantBackend) SaveTenant(tenant *Tenant) error {
+	if tenant.Documents == nil {
+		return eb.Backend.SaveTenant(tenant)
+	}
+
+	documents := *tenant.Documents
+	documents.Documents = make(map[string]*DocumentedInformation, len(tenant.Documents.Documents))
+	for id, doc := range tenant.Documents.Documents {
+		sealed, err := SealDocument(doc, eb.Keys)
+		if err != nil {
+			return fmt.Errorf("failed to encrypt document %s: %w", id, err)
+		}
+		documents.Documents[id] = sealed
+	}
+
+	stored := &Tenant{
+		SchemaVersion: tenant.SchemaVersion,
+		ID:            tenant.ID,
+		Organization:  tenant.Organization,
+		Documents:     &documents,
+		Risks:         tenant.Risks,
+		Objectives:    tenant.Objectives,
+		Audits:        tenant.Audits,
+		Trail:         tenant.Trail,
+	}
+	return eb.Backend.SaveTenant(stored)
+}
+
+// EncryptingDocumentRepository seals confidential document content before passing
+// documents to the wrapped repository, e.g. a shared GitDocumentRepository
+type EncryptingDocumentRepository struct {
+	Repository DocumentRepository
+	Keys       KeyProvider
+}
+
+// SaveDocument stores the document with its confidential content sealed
+func (er *EncryptingDocumentRepository) SaveDocument(doc *DocumentedInformation, message string) error {
+	sealed, err := SealDocument(doc, er.Keys)
+	if err != nil {
+		return err
+	}
+	return er.Repository.SaveDocument(sealed, message)
+}
+
+// RecordApproval records the approval with the document's confidential content sealed
+func (er *EncryptingDocumentRepository) RecordApproval(doc *DocumentedInformation, approval Approval) error {
+	sealed, err := SealDocument(doc, er.Keys)
+	if err != nil {
+		return err
+	}
+	return er.Repository.RecordApproval(sealed, approval)
+}
@@ -0,0 +1,105 @@
+package iso9001
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func testKeys(t *testing.T) *StaticKeyProvider {
+	t.Helper()
+	keys := NewStaticKeyProvider()
+	if err := keys.AddKey("k1", bytes.Repeat([]byte{1}, 32)); err != nil {
+		t.Fatal(err)
+	}
+	return keys
+}
+
+func TestSealAndOpenDocument(t *testing.T) {
+	keys := testKeys(t)
+	doc := &DocumentedInformation{ID: "DOC-001", Content: "Supplier price list", Access: DocumentAccess{Classification: ClassificationConfidential}}
+	doc.Versions = []DocumentVersion{{VersionNumber: "1.0", Content: "Supplier price list"}}
+
+	sealed, err := SealDocument(doc, keys)
+	if err != nil {
+		t.Fatalf("Failed to seal: %v", err)
+	}
+	if sealed == doc || sealed.Content != "" || sealed.Encrypted == nil || sealed.Encrypted.KeyID != "k1" {
+		t.Fatalf("Expected sealed copy, got %+v", sealed)
+	}
+	if doc.Content != "Supplier price list" || doc.Versions[0].Content != "Supplier price list" {
+		t.Error("Original document must not be modified")
+	}
+	if sealed.Versions[0].Content != "" || sealed.Versions[0].Encrypted == nil {
+		t.Errorf("Expected version content to be sealed, got %+v", sealed.Versions[0])
+	}
+
+	// Rotating the key keeps older content readable
+	if err := keys.AddKey("k2", bytes.Repeat([]byte{2}, 32)); err != nil {
+		t.Fatal(err)
+	}
+	if err := keys.SetCurrent("k2"); err != nil {
+		t.Fatal(err)
+	}
+	opened := *sealed
+	if err := OpenDocument(&opened, keys); err != nil {
+		t.Fatalf("Failed to open: %v", err)
+	}
+	if opened.Content != doc.Content || opened.Encrypted != nil || opened.Versions[0].Content != doc.Content {
+		t.Errorf("Unexpected opened document %+v", opened)
+	}
+	if sealed.Versions[0].Encrypted == nil {
+		t.Error("Opening a copy must leave the sealed versions sealed")
+	}
+
+	moved := *sealed
+	moved.ID = "DOC-002"
+	if err := OpenDocument(&moved, keys); !errors.Is(err, ErrDecryptionFailed) {
+		t.Errorf("Expected sealed content to be bound to its document, got %v", err)
+	}
+
+	public := &DocumentedInformation{ID: "DOC-003", Content: "Quality policy", Access: DocumentAccess{Classification: ClassificationPublic}}
+	if same, _ := SealDocument(public, keys); same != public {
+		t.Error("Public documents should be stored as they are")
+	}
+
+	if err := OpenDocument(sealed, NewStaticKeyProvider()); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("Expected missing key error, got %v", err)
+	}
+}
+
+func TestEncryptingTenantBackend(t *testing.T) {
+	dir := t.TempDir()
+	files, err := NewFileTenantBackend(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	backend := &EncryptingTenantBackend{Backend: files, Keys: testKeys(t)}
+
+	tenant := NewTenant("acme")
+	tenant.Documents.Documents["DOC-001"] = &DocumentedInformation{ID: "DOC-001", Content: "Salary bands", Access: DocumentAccess{Classification: ClassificationRestricted}}
+	if err := backend.SaveTenant(tenant); err != nil {
+		t.Fatalf("Failed to save: %v", err)
+	}
+	if tenant.Documents.Documents["DOC-001"].Content != "Salary bands" {
+		t.Error("Saving must not seal the tenant held in memory")
+	}
+
+	raw, err := os.ReadFile(filepath.Join(dir, "acme.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(raw, []byte("Salary bands")) {
+		t.Error("Stored tenant contains confidential content in clear")
+	}
+
+	loaded, err := backend.LoadTenant("acme")
+	if err != nil {
+		t.Fatalf("Failed to load: %v", err)
+	}
+	if got := loaded.Documents.Documents["DOC-001"].Content; got != "Salary bands" {
+		t.Errorf("Expected decrypted content, got %q", got)
+	}
+}
@@ -0,0 +1,102 @@
+package iso9001
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrAuditStarted is returned when an audit that has started is to be removed. Audit
+// results must be retained as evidence of the audit programme (clause 9.2.2 f).
+var ErrAuditStarted = errors.New("audit has started")
+
+// UpdateRisk replaces a risk with updates, keeping its ID, creation date, residual
+// ratings and review dates. The status keeps its value unless updates requests an
+// allowed transition. Changed ratings reprioritize the risk and count as a review.
+func (rm *RiskManager) UpdateRisk(riskID string, updates *Risk) error {
+	existing, exists := rm.Risks[riskID]
+	if !exists {
+		return fmt.Errorf("risk with ID %s not found", riskID)
+	}
+	updates.ID = existing.ID
+	if err := checkNewRisk(updates); err != nil {
+		return err
+	}
+	if updates.Status == "" {
+		updates.Status = existing.Status
+	} else if updates.Status != existing.Status {
+		if err := riskTransitions.check(EntityTypeRisk, riskID, existing.Status, updates.Status); err != nil {
+			return err
+		}
+	}
+
+	updates.Created = existing.Created
+	updates.ResidualLikelihood = existing.ResidualLikelihood
+	updates.ResidualImpact = existing.ResidualImpact
+	updates.ResidualPriority = existing.ResidualPriority
+	updates.LastReviewed = existing.LastReviewed
+	updates.NextReviewDate = existing.NextReviewDate
+	updates.Priority = existing.Priority
+	if updates.Likelihood != existing.Likelihood || updates.Impact != existing.Impact {
+		updates.Priority = rm.calculatePriority(updates.Likelihood, updates.Impact)
+		rm.recordReview(updates, time.Now())
+	}
+
+	rm.Risks[riskID] = updates
+	rm.updateRegister(riskID)
+	return nil
+}
+
+// RemoveRisk deletes a risk from the register, e.g. one identified by mistake. Risks
+// that no longer apply should rather be kept and monitored.
+func (rm *RiskManager) RemoveRisk(riskID string) error {
+	if _, exists := rm.Risks[riskID]; !exists {
+		return fmt.Errorf("risk with ID %s not found", riskID)
+	}
+	delete(rm.Risks, riskID)
+	releaseID(rm.IDs, riskID)
+	rm.updateRegister(riskID)
+	return nil
+}
+
+// UpdateAudit replaces the plan of an audit with updates: its title, type, scope,
+// planned dates, participants and risk assessment. The status, findings,
+// recommendations, report and actual dates are kept; they change as the audit is
+// conducted.
+func (am *AuditManager) UpdateAudit(auditID string, updates *Audit) error {
+	existing, exists := am.Audits[auditID]
+	if !exists {
+		return fmt.Errorf("audit with ID %s not found", auditID)
+	}
+	updates.ID = existing.ID
+	if err := checkNewAudit(updates); err != nil {
+		return err
+	}
+
+	updates.Status = existing.Status
+	updates.Findings = existing.Findings
+	updates.Recommendations = existing.Recommendations
+	updates.Report = existing.Report
+	updates.ActualStartDate = existing.ActualStartDate
+	updates.ActualEndDate = existing.ActualEndDate
+	updates.Created = existing.Created
+	updates.Modified = time.Now()
+
+	am.Audits[auditID] = updates
+	return nil
+}
+
+// RemoveAudit cancels an audit that is still planned. Audits that have started are
+// kept; see ErrAuditStarted.
+func (am *AuditManager) RemoveAudit(auditID string) error {
+	audit, exists := am.Audits[auditID]
+	if !exists {
+		return fmt.Errorf("audit with ID %s not found", auditID)
+	}
+	if audit.Status != AuditStatusPlanned {
+		return fmt.Errorf("%w: audit %s is %s", ErrAuditStarted, auditID, audit.Status)
+	}
+	delete(am.Audits, auditID)
+	releaseID(am.IDs, auditID)
+	return nil
+}
@@ -0,0 +1,80 @@
+package iso9001
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestUpdateAndRemoveRisk(t *testing.T) {
+	rm := NewRiskManager()
+	rm.IdentifyRisk(&Risk{ID: "RISK-001", Description: "Supplier failure"})
+	rm.AssessRisk("RISK-001", RiskLevelLow, RiskLevelLow)
+	created := rm.Risks["RISK-001"].Created
+
+	if err := rm.UpdateRisk("RISK-404", &Risk{Description: "Missing"}); err == nil {
+		t.Error("Expected error for unknown risk")
+	}
+	if err := rm.UpdateRisk("RISK-001", &Risk{}); err == nil {
+		t.Error("Expected error for risk without description")
+	}
+	if err := rm.UpdateRisk("RISK-001", &Risk{Description: "D", Status: RiskStatusIdentified}); err == nil {
+		t.Error("Expected error for invalid status transition")
+	}
+
+	err := rm.UpdateRisk("RISK-001", &Risk{ID: "OTHER", Description: "Sole supplier failure", Likelihood: RiskLevelVeryHigh, Impact: RiskLevelVeryHigh, Owner: "Purchasing"})
+	if err != nil {
+		t.Fatalf("Failed to update risk: %v", err)
+	}
+	risk := rm.Risks["RISK-001"]
+	if risk.ID != "RISK-001" || !risk.Created.Equal(created) || risk.Status != RiskStatusAssessed {
+		t.Errorf("Expected ID, creation date and status kept, got %+v", risk)
+	}
+	if risk.Priority != PriorityCritical || risk.LastReviewed.IsZero() {
+		t.Errorf("Expected reprioritized and reviewed risk, got priority %s reviewed %v", risk.Priority, risk.LastReviewed)
+	}
+	if entries := rm.Register.OrganizationRisks; len(entries) != 1 || entries[0].RiskID != "RISK-001" {
+		t.Errorf("Expected one register entry, got %+v", entries)
+	}
+
+	if err := rm.RemoveRisk("RISK-001"); err != nil {
+		t.Fatalf("Failed to remove risk: %v", err)
+	}
+	if _, exists := rm.Risks["RISK-001"]; exists || len(rm.Register.OrganizationRisks) != 0 {
+		t.Error("Expected risk removed from the register")
+	}
+	if err := rm.RemoveRisk("RISK-001"); err == nil {
+		t.Error("Expected error for removed risk")
+	}
+}
+
+func TestUpdateAndRemoveAudit(t *testing.T) {
+	am := NewAuditManager()
+	am.CreateAudit(&Audit{ID: "AUDIT-001", Title: "Audit", Scope: AuditScope{Description: "Scope"}})
+	am.AddFinding("AUDIT-001", AuditFinding{ID: "F-001", Severity: SeverityMinor, Status: FindingStatusOpen})
+
+	if err := am.UpdateAudit("AUDIT-001", &Audit{Title: "Audit"}); err == nil {
+		t.Error("Expected error for audit without scope")
+	}
+	err := am.UpdateAudit("AUDIT-001", &Audit{Title: "Purchasing audit", Status: AuditStatusClosed, Scope: AuditScope{Description: "Purchasing", Clauses: []string{"8.4"}}})
+	if err != nil {
+		t.Fatalf("Failed to update audit: %v", err)
+	}
+	audit := am.Audits["AUDIT-001"]
+	if audit.Title != "Purchasing audit" || audit.Status != AuditStatusPlanned || len(audit.Findings) != 1 {
+		t.Errorf("Expected new plan with status and findings kept, got %+v", audit)
+	}
+
+	am.StartAudit("AUDIT-001", time.Now())
+	if err := am.RemoveAudit("AUDIT-001"); !errors.Is(err, ErrAuditStarted) {
+		t.Errorf("Expected ErrAuditStarted, got %v", err)
+	}
+
+	am.CreateAudit(&Audit{ID: "AUDIT-002", Title: "Audit", Scope: AuditScope{Description: "Scope"}})
+	if err := am.RemoveAudit("AUDIT-002"); err != nil {
+		t.Fatalf("Failed to remove audit: %v", err)
+	}
+	if err := am.CreateAudit(&Audit{ID: "AUDIT-002", Title: "Audit", Scope: AuditScope{Description: "Scope"}}); err != nil {
+		t.Errorf("Expected the ID of a removed audit to be free, got %v", err)
+	}
+}
@@ -0,0 +1,642 @@
+package iso9001
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EventType names what happened to an entity of an event-sourced tenant
+type EventType string
+
+const (
+	EventOrganizationCreated EventType = "OrganizationCreated"
+	EventOrganizationUpdated EventType = "OrganizationUpdated"
+	EventOrganizationRemoved EventType = "OrganizationRemoved"
+
+	EventDocumentAdded     EventType = "DocumentAdded"
+	EventDocumentUpdated   EventType = "DocumentUpdated"
+	EventDocumentApproved  EventType = "DocumentApproved"
+	EventDocumentPublished EventType = "DocumentPublished"
+	EventDocumentObsoleted EventType = "DocumentObsoleted"
+	EventDocumentArchived  EventType = "DocumentArchived"
+	EventDocumentRemoved   EventType = "DocumentRemoved"
+
+	EventRiskIdentified EventType = "RiskIdentified"
+	EventRiskUpdated    EventType = "RiskUpdated"
+	EventRiskAssessed   EventType = "RiskAssessed"
+	EventRiskMitigated  EventType = "RiskMitigated"
+	EventRiskRemoved    EventType = "RiskRemoved"
+
+	EventOpportunityIdentified EventType = "OpportunityIdentified"
+	EventOpportunityUpdated    EventType = "OpportunityUpdated"
+	EventOpportunityRemoved    EventType = "OpportunityRemoved"
+
+	EventObjectiveSet      EventType = "ObjectiveSet"
+	EventObjectiveUpdated  EventType = "ObjectiveUpdated"
+	EventObjectiveAchieved EventType = "ObjectiveAchieved"
+	EventObjectiveRemoved  EventType = "ObjectiveRemoved"
+
+	EventAuditPlanned   EventType = "AuditPlanned"
+	EventAuditUpdated   EventType = "AuditUpdated"
+	EventAuditStarted   EventType = "AuditStarted"
+	EventAuditCompleted EventType = "AuditCompleted"
+	EventAuditClosed    EventType = "AuditClosed"
+	EventAuditRemoved   EventType = "AuditRemoved"
+
+	EventFindingAdded   EventType = "FindingAdded"
+	EventFindingUpdated EventType = "FindingUpdated"
+	EventFindingClosed  EventType = "FindingClosed"
+	EventFindingRemoved EventType = "FindingRemoved"
+
+	EventManagementReviewScheduled EventType = "ManagementReviewScheduled"
+	EventManagementReviewUpdated   EventType = "ManagementReviewUpdated"
+	EventManagementReviewCompleted EventType = "ManagementReviewCompleted"
+	EventManagementReviewRemoved   EventType = "ManagementReviewRemoved"
+
+	// EventRecordsUpdated carries the rest of the tenant, such as measurements,
+	// complaints, templates and manager settings
+	EventRecordsUpdated EventType = "RecordsUpdated"
+)
+
+// eventNames names the events of each entity type: created, updated and removed,
+// and updates that move the entity to a status
+var eventNames = map[string]struct {
+	created, updated, removed EventType
+	statuses                  map[string]EventType
+}{
+	EntityTypeOrganization: {EventOrganizationCreated, EventOrganizationUpdated, EventOrganizationRemoved, nil},
+	EntityTypeDocument: {EventDocumentAdded, EventDocumentUpdated, EventDocumentRemoved, map[string]EventType{
+		string(DocumentStatusApproved):  EventDocumentApproved,
+		string(DocumentStatusPublished): EventDocumentPublished,
+		string(DocumentStatusObsolete):  EventDocumentObsoleted,
+		string(DocumentStatusArchived):  EventDocumentArchived,
+	}},
+	EntityTypeRisk: {EventRiskIdentified, EventRiskUpdated, EventRiskRemoved, map[string]EventType{
+		string(RiskStatusAssessed):  EventRiskAssessed,
+		string(RiskStatusMitigated): EventRiskMitigated,
+	}},
+	EntityTypeOpportunity: {EventOpportunityIdentified, EventOpportunityUpdated, EventOpportunityRemoved, nil},
+	EntityTypeObjective: {EventObjectiveSet, EventObjectiveUpdated, EventObjectiveRemoved, map[string]EventType{
+		string(ObjectiveStatusAchieved): EventObjectiveAchieved,
+	}},
+	EntityTypeAudit: {EventAuditPlanned, EventAuditUpdated, EventAuditRemoved, map[string]EventType{
+		string(AuditStatusInProgress): EventAuditStarted,
+		string(AuditStatusCompleted):  EventAuditCompleted,
+		string(AuditStatusClosed):     EventAuditClosed,
+	}},
+	EntityTypeFinding: {EventFindingAdded, EventFindingUpdated, EventFindingRemoved, map[string]EventType{
+		string(FindingStatusClosed): EventFindingClosed,
+	}},
+	EntityTypeManagementReview: {EventManagementReviewScheduled, EventManagementReviewUpdated, EventManagementReviewRemoved, map[string]EventType{
+		string(ReviewStatusCompleted): EventManagementReviewCompleted,
+	}},
+}
+
+// DomainEvent is one change to an event-sourced tenant. Data is the entity as JSON
+// after the change, empty when it was removed; replaying the events in order rebuilds
+// the tenant.
+type DomainEvent struct {
+	Sequence   uint64          `json:"sequence" yaml:"sequence"`
+	Type       EventType       `json:"type" yaml:"type"`
+	Timestamp  time.Time       `json:"timestamp" yaml:"timestamp"`
+	Actor      string          `json:"actor,omitempty" yaml:"actor,omitempty"`
+	Action     string          `json:"action,omitempty" yaml:"action,omitempty"`
+	EntityType string          `json:"entity_type,omitempty" yaml:"entity_type,omitempty"`
+	EntityID   string          `json:"entity_id,omitempty" yaml:"entity_id,omitempty"`
+	Operation  ChangeOperation `json:"operation" yaml:"operation"`
+	Data       json.RawMessage `json:"data,omitempty" yaml:"data,omitempty"`
+}
+
+// EventStore keeps the events of each tenant in the order they were appended
+type EventStore interface {
+	// AppendEvents adds events to the end of a tenant's stream
+	AppendEvents(tenantID string, events []DomainEvent) error
+	// LoadEvents returns a tenant's stream or ErrTenantNotFound
+	LoadEvents(tenantID string) ([]DomainEvent, error)
+}
+
+// MemoryEventStore keeps event streams in memory, e.g. for tests
+type MemoryEventStore struct {
+	mu      sync.RWMutex
+	streams map[string][]DomainEvent
+}
+
+// NewMemoryEventStore creates an empty in-memory event store
+func NewMemoryEventStore() *MemoryEventStore {
+	return &MemoryEventStore{streams: make(map[string][]DomainEvent)}
+}
+
+// AppendEvents adds events to the end of a tenant's stream
+func (ms *MemoryEventStore) AppendEvents(tenantID string, events []DomainEvent) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	ms.streams[tenantID] = append(ms.streams[tenantID], events...)
+	return nil
+}
+
+// LoadEvents returns a copy of a tenant's stream
+func (ms *MemoryEventStore) LoadEvents(tenantID string) ([]DomainEvent, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	stream, exists := ms.streams[tenantID]
+	if !exists {
+		return nil, ErrTenantNotFound
+	}
+	return append([]DomainEvent(nil), stream...), nil
+}
+
+// FileEventStore keeps the stream of each tenant as a JSON Lines file in a directory,
+// to which events are only ever appended
+type FileEventStore struct {
+	Dir string
+}
+
+// NewFileEventStore creates a file event store, creating the directory if needed
+func NewFileEventStore(dir string) (*FileEventStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create event directory: %w", err)
+	}
+	return &FileEventStore{Dir: dir}, nil
+}
+
+func (fs *FileEventStore) path(tenantID string) (string, error) {
+	if tenantID == "" || tenantID == "." || tenantID == ".." || strings.ContainsAny(tenantID, `/\`) {
+		return "", fmt.Errorf("invalid tenant ID %q", tenantID)
+	}
+	return filepath.Join(fs.Dir, tenantID+".events.jsonl"), nil
+}
+
+// AppendEvents writes events to the end of a tenant's file, one JSON object per line
+func (fs *FileEventStore) AppendEvents(tenantID string, events []DomainEvent) error {
+	path, err := fs.path(tenantID)
+	if err != nil {
+		return err
+	}
+	var lines []byte
+	for _, event := range events {
+		line, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("failed to encode event %d of tenant %s: %w", event.Sequence, tenantID, err)
+		}
+		lines = append(append(lines, line...), '\n')
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_RDWR, 0o644)
+	if err != nil {
+		return err
+	}
+	if err := truncateTornLine(file); err != nil {
+		file.Close()
+		return err
+	}
+	if _, err := file.Write(lines); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// LoadEvents reads a tenant's file
+func (fs *FileEventStore) LoadEvents(tenantID string) ([]DomainEvent, error) {
+	path, err := fs.path(tenantID)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrTenantNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var events []DomainEvent
+	reader := bufio.NewReader(file)
+	for line := 1; ; line++ {
+		data, err := reader.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+		complete := err == nil
+		if data = bytes.TrimSpace(data); len(data) > 0 {
+			var event DomainEvent
+			if err := json.Unmarshal(data, &event); err != nil {
+				if !complete {
+					// the last append was cut short, e.g. by a crash, and never
+					// acknowledged; the next append removes it
+					break
+				}
+				return nil, fmt.Errorf("events of tenant %s, line %d: %w", tenantID, line, err)
+			}
+			events = append(events, event)
+		}
+		if !complete {
+			break
+		}
+	}
+	return events, nil
+}
+
+// truncateTornLine removes a last line that a crash left without its newline, so
+// appends that never completed do not corrupt the events written after them
+func truncateTornLine(file *os.File) error {
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	size := info.Size()
+	end := size
+	buf := make([]byte, 4096)
+	for end > 0 {
+		n := min(int64(len(buf)), end)
+		if _, err := file.ReadAt(buf[:n], end-n); err != nil {
+			return err
+		}
+		if i := bytes.LastIndexByte(buf[:n], '\n'); i >= 0 {
+			end = end - n + int64(i) + 1
+			break
+		}
+		end -= n
+	}
+	if end == size {
+		return nil
+	}
+	return file.Truncate(end)
+}
+
+// EventSourcedBackend is a TenantBackend that persists each tenant as a stream of
+// events instead of a snapshot. Saving a tenant appends an event for every entity
+// changed since the last save, attributed to the actor of the latest audit trail
+// entry for it; loading replays the stream. TenantAt rebuilds a tenant as it was at
+// any past time, e.g. for a retrospective audit.
+type EventSourcedBackend struct {
+	Store EventStore
+
+	mu       sync.Mutex
+	saved    map[string]*eventCheckpoint
+	trailKey []byte
+}
+
+// eventCheckpoint is what was last persisted of a tenant
+type eventCheckpoint struct {
+	entities map[trailKey]trailState
+	records  []byte
+	sequence uint64 // of the last event
+	trail    uint64 // sequence of the last audit trail entry already attributed
+}
+
+// NewEventSourcedBackend creates a backend keeping tenants in the event store
+func NewEventSourcedBackend(store EventStore) *EventSourcedBackend {
+	return &EventSourcedBackend{Store: store, saved: make(map[string]*eventCheckpoint)}
+}
+
+// LoadTenant replays the events of a tenant
+func (eb *EventSourcedBackend) LoadTenant(id string) (*Tenant, error) {
+	eb.mu.Lock()
+	defer eb.mu.Unlock()
+	tenant, _, err := eb.load(id)
+	return tenant, err
+}
+
+// TenantAt rebuilds a tenant from the events up to and including at
+func (eb *EventSourcedBackend) TenantAt(id string, at time.Time) (*Tenant, error) {
+	events, err := eb.Store.LoadEvents(id)
+	if err != nil {
+		return nil, err
+	}
+	return replayEvents(id, events, at, eb.trailKey)
+}
+
+// setTrailKey keys the audit trails the backend rebuilds; see TenantStore.SetTrailKey
+func (eb *EventSourcedBackend) setTrailKey(key []byte) {
+	eb.mu.Lock()
+	defer eb.mu.Unlock()
+	eb.trailKey = key
+}
+
+// SaveTenant appends the events for the changes made to the tenant since it was last
+// saved or loaded
+func (eb *EventSourcedBackend) SaveTenant(tenant *Tenant) error {
+	eb.mu.Lock()
+	defer eb.mu.Unlock()
+
+	checkpoint, exists := eb.saved[tenant.ID]
+	if !exists {
+		_, loaded, err := eb.load(tenant.ID)
+		if errors.Is(err, ErrTenantNotFound) {
+			loaded = &eventCheckpoint{entities: make(map[trailKey]trailState)}
+		} else if err != nil {
+			return err
+		}
+		checkpoint = loaded
+	}
+
+	entities := tenant.trailSnapshot()
+	records, err := tenantRecords(tenant)
+	if err != nil {
+		return fmt.Errorf("failed to encode tenant %s: %w", tenant.ID, err)
+	}
+
+	// Attribute each change to the latest audit trail entry for its entity
+	type attribution struct {
+		actor, action string
+		at            time.Time
+	}
+	attributions := make(map[trailKey]attribution)
+	trail := checkpoint.trail
+	if tenant.Trail != nil {
+		for _, entry := range tenant.Trail.Entries {
+			if entry.Sequence > checkpoint.trail {
+				attributions[trailKey{entry.EntityType, entry.EntityID}] = attribution{entry.Actor, entry.Action, entry.Timestamp}
+				trail = entry.Sequence
+			}
+		}
+	}
+
+	now := time.Now().UTC()
+	var events []DomainEvent
+	for _, key := range trailKeys(checkpoint.entities, entities) {
+		event, changed := entityEvent(key, checkpoint.entities, entities)
+		if !changed {
+			continue
+		}
+		event.Timestamp = now
+		if by, ok := attributions[key]; ok {
+			event.Actor, event.Action, event.Timestamp = by.actor, by.action, by.at.UTC()
+		}
+		events = append(events, event)
+	}
+	if string(records) != string(checkpoint.records) {
+		events = append(events, DomainEvent{Type: EventRecordsUpdated, Timestamp: now, Operation: ChangeOperationUpdated, Data: records})
+	}
+	if len(events) == 0 {
+		return nil
+	}
+
+	sequence := checkpoint.sequence
+	for i := range events {
+		sequence++
+		events[i].Sequence = sequence
+	}
+	if err := eb.Store.AppendEvents(tenant.ID, events); err != nil {
+		return err
+	}
+	eb.saved[tenant.ID] = &eventCheckpoint{entities: entities, records: records, sequence: sequence, trail: trail}
+	return nil
+}
+
+// load replays a tenant's events and remembers the result as its checkpoint. The
+// caller holds eb.mu.
+func (eb *EventSourcedBackend) load(id string) (*Tenant, *eventCheckpoint, error) {
+	events, err := eb.Store.LoadEvents(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	tenant, err := replayEvents(id, events, time.Time{}, eb.trailKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	records, err := tenantRecords(tenant)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	checkpoint := &eventCheckpoint{entities: tenant.trailSnapshot(), records: records}
+	if n := len(events); n > 0 {
+		checkpoint.sequence = events[n-1].Sequence
+	}
+	if n := len(tenant.Trail.Entries); n > 0 {
+		checkpoint.trail = tenant.Trail.Entries[n-1].Sequence
+	}
+	if eb.saved == nil {
+		eb.saved = make(map[string]*eventCheckpoint)
+	}
+	eb.saved[id] = checkpoint
+	return tenant, checkpoint, nil
+}
+
+// entityEvent returns the event for an entity that differs between two snapshots
+func entityEvent(key trailKey, before, after map[trailKey]trailState) (DomainEvent, bool) {
+	names := eventNames[key.entityType]
+	old, existed := before[key]
+	current, exists := after[key]
+	event := DomainEvent{EntityType: key.entityType, EntityID: key.id}
+	switch {
+	case !existed:
+		event.Type, event.Operation, event.Data = names.created, ChangeOperationCreated, current.data
+	case !exists:
+		event.Type, event.Operation = names.removed, ChangeOperationDeleted
+	case old.digest != current.digest:
+		event.Type, event.Operation, event.Data = names.updated, ChangeOperationUpdated, current.data
+		if status := entityStatus(current.data); status != entityStatus(old.data) {
+			if named, ok := names.statuses[status]; ok {
+				event.Type = named
+			}
+		}
+	default:
+		return event, false
+	}
+	return event, true
+}
+
+// entityStatus returns the status field of an entity's JSON
+func entityStatus(data []byte) string {
+	var entity struct {
+		Status string `json:"status"`
+	}
+	json.Unmarshal(data, &entity)
+	return entity.Status
+}
+
+// eventEntityFields are the parts of a tenant's JSON carried by entity events, rebuilt
+// on replay or, for the audit trail, derived from the events; a field without a
+// manager is at the top level
+var eventEntityFields = []struct{ manager, field string }{
+	{"", "organization"},
+	{"", "trail"},
+	{"documents", "documents"},
+	{"documents", "index"},
+	{"risks", "risks"},
+	{"risks", "opportunities"},
+	{"risks", "register"},
+	{"objectives", "objectives"},
+	{"audits", "audits"},
+	{"audits", "management_reviews"},
+}
+
+// tenantRecords returns the JSON of the tenant without the parts carried by entity
+// events
+func tenantRecords(tenant *Tenant) ([]byte, error) {
+	data, err := json.Marshal(tenant)
+	if err != nil {
+		return nil, err
+	}
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	for _, path := range eventEntityFields {
+		if path.manager == "" {
+			delete(doc, path.field)
+			continue
+		}
+		var manager map[string]json.RawMessage
+		if err := json.Unmarshal(doc[path.manager], &manager); err != nil || manager == nil {
+			continue
+		}
+		delete(manager, path.field)
+		if doc[path.manager], err = json.Marshal(manager); err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(doc)
+}
+
+// ReplayEvents rebuilds a tenant from its events. Events after until are skipped; a
+// zero until replays every event. The audit trail of the tenant is rebuilt from the
+// events, and derived data such as the document index and risk register is
+// recomputed.
+func ReplayEvents(tenantID string, events []DomainEvent, until time.Time) (*Tenant, error) {
+	return replayEvents(tenantID, events, until, nil)
+}
+
+// replayEvents rebuilds a tenant like ReplayEvents, hashing its audit trail with key
+func replayEvents(tenantID string, events []DomainEvent, until time.Time, key []byte) (*Tenant, error) {
+	entities := make(map[trailKey]json.RawMessage)
+	var records json.RawMessage
+	trail := NewAuditTrail()
+	trail.SetKey(key)
+
+	for _, event := range events {
+		if !until.IsZero() && event.Timestamp.After(until) {
+			continue
+		}
+		if event.Type == EventRecordsUpdated {
+			records = event.Data
+			continue
+		}
+
+		key := trailKey{event.EntityType, event.EntityID}
+		entry := TrailEntry{Timestamp: event.Timestamp, Actor: event.Actor, Action: event.Action, EntityType: event.EntityType, EntityID: event.EntityID, Operation: event.Operation}
+		if before, ok := entities[key]; ok {
+			entry.Before = summarizeEventData(event.EntityType, before)
+		}
+		if event.Operation == ChangeOperationDeleted {
+			delete(entities, key)
+		} else {
+			entities[key] = event.Data
+			entry.After = summarizeEventData(event.EntityType, event.Data)
+		}
+		trail.Record(entry)
+	}
+
+	doc := make(map[string]json.RawMessage)
+	if len(records) > 0 {
+		if err := json.Unmarshal(records, &doc); err != nil {
+			return nil, fmt.Errorf("failed to decode records of tenant %s: %w", tenantID, err)
+		}
+	}
+	collection := func(entityType string) map[string]json.RawMessage {
+		entries := make(map[string]json.RawMessage)
+		for key, data := range entities {
+			if key.entityType == entityType {
+				entries[key.id] = data
+			}
+		}
+		return entries
+	}
+	for _, data := range collection(EntityTypeOrganization) {
+		doc["organization"] = data
+	}
+	// Findings travel with their audit; their own events only name the change
+	for _, path := range []struct{ manager, field, entityType string }{
+		{"documents", "documents", EntityTypeDocument},
+		{"risks", "risks", EntityTypeRisk},
+		{"risks", "opportunities", EntityTypeOpportunity},
+		{"objectives", "objectives", EntityTypeObjective},
+		{"audits", "audits", EntityTypeAudit},
+		{"audits", "management_reviews", EntityTypeManagementReview},
+	} {
+		manager := make(map[string]json.RawMessage)
+		if data, ok := doc[path.manager]; ok && string(data) != "null" {
+			if err := json.Unmarshal(data, &manager); err != nil {
+				return nil, fmt.Errorf("failed to decode records of tenant %s: %w", tenantID, err)
+			}
+		}
+		data, err := json.Marshal(collection(path.entityType))
+		if err != nil {
+			return nil, err
+		}
+		manager[path.field] = data
+		if doc[path.manager], err = json.Marshal(manager); err != nil {
+			return nil, err
+		}
+	}
+	if _, ok := doc["id"]; !ok {
+		doc["id"], _ = json.Marshal(tenantID)
+	}
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	tenant, err := decodeTenant(tenantID, data)
+	if err != nil {
+		return nil, err
+	}
+	tenant.Trail = trail
+
+	var ids []string
+	for id := range tenant.Documents.Documents {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		tenant.Documents.updateIndex(tenant.Documents.Documents[id])
+	}
+	tenant.Risks.RebuildRegister()
+	return tenant, nil
+}
+
+// summarizeEventData summarizes the entity carried by an event
+func summarizeEventData(entityType string, data []byte) string {
+	var entity interface{}
+	switch entityType {
+	case EntityTypeOrganization:
+		entity = &Organization{}
+	case EntityTypeDocument:
+		entity = &DocumentedInformation{}
+	case EntityTypeRisk:
+		entity = &Risk{}
+	case EntityTypeObjective:
+		entity = &QualityObjective{}
+	case EntityTypeAudit:
+		entity = &Audit{}
+	case EntityTypeFinding:
+		entity = &AuditFinding{}
+	default:
+		entity = &json.RawMessage{}
+	}
+	if err := json.Unmarshal(data, entity); err != nil {
+		return ""
+	}
+	if raw, ok := entity.(*json.RawMessage); ok {
+		return SummarizeEntity(*raw)
+	}
+	return SummarizeEntity(entity)
+}
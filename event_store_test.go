@@ -0,0 +1,171 @@
+package iso9001
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestEventSourcedBackendReplaysTenant(t *testing.T) {
+	store, err := NewFileEventStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create event store: %v", err)
+	}
+	backend := NewEventSourcedBackend(store)
+
+	tenant := NewTenant("acme")
+	tenant.Change("jane", "identify", func(tenant *Tenant) error {
+		tenant.Organization.Name = "Acme"
+		tenant.Documents.AddDocument(&DocumentedInformation{ID: "DOC-001", Title: "Purchasing procedure", Content: "Evaluate suppliers"})
+		return tenant.Risks.IdentifyRisk(&Risk{ID: "RISK-001", Description: "Supplier fails"})
+	})
+	if err := backend.SaveTenant(tenant); err != nil {
+		t.Fatalf("Failed to save tenant: %v", err)
+	}
+	saved := time.Now()
+	time.Sleep(10 * time.Millisecond)
+
+	tenant.Change("carl", "audit", func(tenant *Tenant) error {
+		tenant.Documents.ApproveDocument("DOC-001", Approval{ApproverID: "carl"})
+		tenant.Audits.CreateAudit(&Audit{ID: "AUDIT-001", Title: "Purchasing", Scope: AuditScope{Description: "Purchasing"}})
+		return tenant.Audits.AddFinding("AUDIT-001", AuditFinding{ID: "F-001", Description: "No evaluation", Severity: SeverityMinor})
+	})
+	if err := backend.SaveTenant(tenant); err != nil {
+		t.Fatalf("Failed to save tenant: %v", err)
+	}
+
+	events, err := store.LoadEvents("acme")
+	if err != nil {
+		t.Fatalf("Failed to load events: %v", err)
+	}
+	types := make(map[EventType]DomainEvent)
+	for i, event := range events {
+		if event.Sequence != uint64(i+1) {
+			t.Errorf("Expected event %d to have sequence %d, got %d", i, i+1, event.Sequence)
+		}
+		types[event.Type] = event
+	}
+	for _, want := range []EventType{EventOrganizationCreated, EventDocumentAdded, EventRiskIdentified, EventDocumentApproved, EventAuditPlanned, EventFindingAdded} {
+		if _, ok := types[want]; !ok {
+			t.Errorf("Expected a %s event, got %+v", want, events)
+		}
+	}
+	if event := types[EventFindingAdded]; event.Actor != "carl" || event.Action != "audit" || event.EntityID != "F-001" {
+		t.Errorf("Expected finding event attributed to carl, got %+v", event)
+	}
+
+	loaded, err := NewEventSourcedBackend(store).LoadTenant("acme")
+	if err != nil {
+		t.Fatalf("Failed to replay tenant: %v", err)
+	}
+	if loaded.Organization.Name != "Acme" || loaded.Documents.Documents["DOC-001"].Status != DocumentStatusApproved {
+		t.Errorf("Expected replayed organization and approved document, got %+v", loaded.Documents.Documents["DOC-001"])
+	}
+	if audit := loaded.Audits.Audits["AUDIT-001"]; audit == nil || len(audit.Findings) != 1 {
+		t.Errorf("Expected replayed audit with its finding, got %+v", audit)
+	}
+	if entries := loaded.Risks.Register.OrganizationRisks; len(entries) != 1 || entries[0].RiskID != "RISK-001" {
+		t.Errorf("Expected risk register to be rebuilt, got %+v", entries)
+	}
+	if ids := loaded.Documents.Index.ByStatus[DocumentStatusApproved]; len(ids) != 1 {
+		t.Errorf("Expected document index to be rebuilt, got %v", loaded.Documents.Index)
+	}
+	if err := loaded.Trail.Verify(); err != nil || len(loaded.Trail.Query(ActivityQuery{Actor: "carl"})) == 0 {
+		t.Errorf("Expected audit trail to be rebuilt from events, got %v", err)
+	}
+
+	past, err := backend.TenantAt("acme", saved)
+	if err != nil {
+		t.Fatalf("Failed to rebuild tenant at %v: %v", saved, err)
+	}
+	if past.Documents.Documents["DOC-001"].Status != DocumentStatusDraft || len(past.Audits.Audits) != 0 {
+		t.Errorf("Expected the draft document and no audits before the audit, got %s and %d audits", past.Documents.Documents["DOC-001"].Status, len(past.Audits.Audits))
+	}
+
+	if err := backend.SaveTenant(tenant); err != nil {
+		t.Fatalf("Failed to save unchanged tenant: %v", err)
+	}
+	if again, _ := store.LoadEvents("acme"); len(again) != len(events) {
+		t.Errorf("Expected no events for an unchanged tenant, got %d more", len(again)-len(events))
+	}
+}
+
+func TestEventSourcedBackendRemovals(t *testing.T) {
+	store := NewMemoryEventStore()
+	backend := NewEventSourcedBackend(store)
+	if _, err := backend.LoadTenant("acme"); !errors.Is(err, ErrTenantNotFound) {
+		t.Errorf("Expected ErrTenantNotFound, got %v", err)
+	}
+
+	tenant := NewTenant("acme")
+	tenant.Risks.IdentifyRisk(&Risk{ID: "RISK-001", Description: "Supplier fails"})
+	tenant.Risks.IdentifyRisk(&Risk{ID: "RISK-002", Description: "Key staff leave"})
+	backend.SaveTenant(tenant)
+
+	delete(tenant.Risks.Risks, "RISK-001")
+	tenant.Risks.AssessRisk("RISK-002", RiskLevelHigh, RiskLevelMedium)
+	if err := backend.SaveTenant(tenant); err != nil {
+		t.Fatalf("Failed to save tenant: %v", err)
+	}
+
+	events, _ := store.LoadEvents("acme")
+	var removed, assessed bool
+	for _, event := range events {
+		removed = removed || event.Type == EventRiskRemoved && event.EntityID == "RISK-001" && len(event.Data) == 0
+		assessed = assessed || event.Type == EventRiskAssessed && event.EntityID == "RISK-002"
+	}
+	if !removed || !assessed {
+		t.Errorf("Expected RiskRemoved and RiskAssessed events, got %+v", events)
+	}
+
+	loaded, err := ReplayEvents("acme", events, time.Time{})
+	if err != nil {
+		t.Fatalf("Failed to replay events: %v", err)
+	}
+	if _, ok := loaded.Risks.Risks["RISK-001"]; ok || loaded.Risks.Risks["RISK-002"].Status != RiskStatusAssessed {
+		t.Errorf("Expected only the assessed risk to remain, got %+v", loaded.Risks.Risks)
+	}
+
+	want, _ := json.Marshal(tenant.Risks.Risks)
+	got, _ := json.Marshal(loaded.Risks.Risks)
+	if string(want) != string(got) {
+		t.Errorf("Expected replayed risks to match\nwant %s\ngot  %s", want, got)
+	}
+}
+
+func TestFileEventStoreTornAppend(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewFileEventStore(dir)
+	if err != nil {
+		t.Fatalf("Failed to create event store: %v", err)
+	}
+	first := DomainEvent{Sequence: 1, Type: EventRiskIdentified, EntityType: EntityTypeRisk, EntityID: "RISK-001"}
+	if err := store.AppendEvents("acme", []DomainEvent{first}); err != nil {
+		t.Fatalf("Failed to append events: %v", err)
+	}
+
+	// a crash cut the next append short
+	path := filepath.Join(dir, "acme.events.jsonl")
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatalf("Failed to open event file: %v", err)
+	}
+	file.WriteString(`{"sequence":2,"type":"RiskAss`)
+	file.Close()
+
+	events, err := store.LoadEvents("acme")
+	if err != nil || len(events) != 1 {
+		t.Fatalf("Expected the torn event to be skipped, got %d events and %v", len(events), err)
+	}
+	second := DomainEvent{Sequence: 2, Type: EventRiskAssessed, EntityType: EntityTypeRisk, EntityID: "RISK-001"}
+	if err := store.AppendEvents("acme", []DomainEvent{second}); err != nil {
+		t.Fatalf("Failed to append events: %v", err)
+	}
+	events, err = store.LoadEvents("acme")
+	if err != nil || len(events) != 2 || events[1].Type != EventRiskAssessed {
+		t.Errorf("Expected the next append to replace the torn event, got %+v and %v", events, err)
+	}
+}
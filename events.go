@@ -0,0 +1,343 @@
+package iso9001
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalidEventSignature is returned when an inbound event is unsigned, signed
+	// with the wrong secret or signed too long ago
+	ErrInvalidEventSignature = errors.New("invalid event signature")
+	// ErrUnmappedEvent is returned when no mapping covers an inbound event
+	ErrUnmappedEvent = errors.New("no mapping for event")
+)
+
+// DefaultEventTolerance is how far the signature timestamp of an inbound event may
+// be from the time it is received
+const DefaultEventTolerance = 5 * time.Minute
+
+// EventTarget is the kind of QMS entity an inbound event becomes
+type EventTarget string
+
+const (
+	EventTargetNonconformance EventTarget = "nonconformance"
+	EventTargetComplaint      EventTarget = "complaint"
+	EventTargetMeasurement    EventTarget = "measurement"
+)
+
+// eventTargetFields lists the fields each target accepts; the first is required
+var eventTargetFields = map[EventTarget][]string{
+	EventTargetNonconformance: {"description", "root_cause", "process", "date"},
+	EventTargetComplaint:      {"description", "customer", "date", "product", "priority"},
+	EventTargetMeasurement:    {"value", "metric", "target", "unit", "date", "process_id", "criteria_id", "objective_id"},
+}
+
+// EventSource is an external system allowed to send events, such as a MES, LIMS or
+// helpdesk. Its events are signed with the shared secret.
+type EventSource struct {
+	Name      string `json:"name" yaml:"name"`
+	Secret    string `json:"secret" yaml:"secret"`
+	TypeField string `json:"type_field,omitempty" yaml:"type_field,omitempty"` // path of the event type in the payload, "type" when empty
+	IDField   string `json:"id_field,omitempty" yaml:"id_field,omitempty"`     // path of the event ID in the payload, "id" when empty
+}
+
+// EventMapping turns events of one type from a source into QMS entities. Fields maps
+// each field of the target to a dot-separated path in the event payload, e.g.
+// "description": "deviation.summary"; Defaults supplies values for fields the payload
+// does not provide.
+type EventMapping struct {
+	Source    string            `json:"source" yaml:"source"`
+	EventType string            `json:"event_type" yaml:"event_type"` // "*" matches every type of the source
+	Target    EventTarget       `json:"target" yaml:"target"`
+	Fields    map[string]string `json:"fields" yaml:"fields"`
+	Defaults  map[string]string `json:"defaults,omitempty" yaml:"defaults,omitempty"`
+}
+
+// EventMappings configures inbound event ingestion: the sources that may send events
+// and how their events map into QMS entities
+type EventMappings struct {
+	Sources  []EventSource  `json:"sources" yaml:"sources"`
+	Mappings []EventMapping `json:"mappings" yaml:"mappings"`
+}
+
+// LoadEventMappings parses and checks an event mapping configuration in JSON
+func LoadEventMappings(data []byte) (*EventMappings, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	var mappings EventMappings
+	if err := decoder.Decode(&mappings); err != nil {
+		return nil, fmt.Errorf("invalid event mappings: %w", err)
+	}
+
+	sources := make(map[string]bool)
+	for _, source := range mappings.Sources {
+		if source.Name == "" || source.Secret == "" {
+			return nil, fmt.Errorf("invalid event mappings: every source needs a name and a secret")
+		}
+		sources[source.Name] = true
+	}
+	for i, mapping := range mappings.Mappings {
+		if !sources[mapping.Source] {
+			return nil, fmt.Errorf("invalid event mappings: mapping %d refers to unknown source %q", i+1, mapping.Source)
+		}
+		if mapping.EventType == "" {
+			return nil, fmt.Errorf("invalid event mappings: mapping %d has no event type", i+1)
+		}
+		fields, ok := eventTargetFields[mapping.Target]
+		if !ok {
+			return nil, fmt.Errorf("invalid event mappings: mapping %d has unknown target %q", i+1, mapping.Target)
+		}
+		for _, set := range []map[string]string{mapping.Fields, mapping.Defaults} {
+			for field := range set {
+				if !containsString(field, fields...) {
+					return nil, fmt.Errorf("invalid event mappings: %s has no field %q (fields: %s)", mapping.Target, field, strings.Join(fields, ", "))
+				}
+			}
+		}
+		if mapping.Fields[fields[0]] == "" && mapping.Defaults[fields[0]] == "" {
+			return nil, fmt.Errorf("invalid event mappings: mapping %d must map the %s field", i+1, fields[0])
+		}
+	}
+	return &mappings, nil
+}
+
+// Source returns the configured source with the given name
+func (m *EventMappings) Source(name string) (*EventSource, bool) {
+	for i := range m.Sources {
+		if m.Sources[i].Name == name {
+			return &m.Sources[i], true
+		}
+	}
+	return nil, false
+}
+
+// mappingFor returns the first mapping covering the event; a mapping for the exact
+// event type takes precedence over a "*" mapping
+func (m *EventMappings) mappingFor(event InboundEvent) (*EventMapping, bool) {
+	var wildcard *EventMapping
+	for i := range m.Mappings {
+		mapping := &m.Mappings[i]
+		if mapping.Source != event.Source {
+			continue
+		}
+		if mapping.EventType == event.Type {
+			return mapping, true
+		}
+		if mapping.EventType == "*" && wildcard == nil {
+			wildcard = mapping
+		}
+	}
+	return wildcard, wildcard != nil
+}
+
+// SignEvent computes the signature a source sends with an event: "sha256=" followed by
+// the hex HMAC-SHA256 of the Unix timestamp, a dot and the body
+func SignEvent(secret string, timestamp time.Time, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%d.", timestamp.Unix())
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the signature of an event body. timestamp is the Unix time the event
+// was signed at, which must lie within DefaultEventTolerance of now.
+func (s *EventSource) Verify(body []byte, timestamp, signature string, now time.Time) error {
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: missing or malformed timestamp", ErrInvalidEventSignature)
+	}
+	signed := time.Unix(seconds, 0)
+	if delta := now.Sub(signed); delta > DefaultEventTolerance || delta < -DefaultEventTolerance {
+		return fmt.Errorf("%w: timestamp %s is outside the tolerance of %s", ErrInvalidEventSignature, signed.UTC().Format(time.RFC3339), DefaultEventTolerance)
+	}
+	if !hmac.Equal([]byte(SignEvent(s.Secret, signed, body)), []byte(signature)) {
+		return fmt.Errorf("%w: signature does not match", ErrInvalidEventSignature)
+	}
+	return nil
+}
+
+// InboundEvent is an event received from an external system
+type InboundEvent struct {
+	Source   string                 `json:"source" yaml:"source"`
+	ID       string                 `json:"id" yaml:"id"`
+	Type     string                 `json:"type" yaml:"type"`
+	Received time.Time              `json:"received" yaml:"received"`
+	Data     map[string]interface{} `json:"data" yaml:"data"`
+}
+
+// Decode parses the JSON body of an event from a source, taking its ID and type from
+// the fields configured for the source
+func (s *EventSource) Decode(body []byte, received time.Time) (InboundEvent, error) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	event := InboundEvent{Source: s.Name, Received: received}
+	if err := decoder.Decode(&event.Data); err != nil {
+		return event, fmt.Errorf("invalid event payload: %w", err)
+	}
+
+	typeField, idField := s.TypeField, s.IDField
+	if typeField == "" {
+		typeField = "type"
+	}
+	if idField == "" {
+		idField = "id"
+	}
+	event.Type, _ = payloadValue(event.Data, typeField)
+	event.ID, _ = payloadValue(event.Data, idField)
+	if event.Type == "" || event.ID == "" {
+		return event, fmt.Errorf("invalid event payload: %q and %q are required", typeField, idField)
+	}
+	return event, nil
+}
+
+// payloadValue looks up a dot-separated path in an event payload and formats the
+// value found there as a string
+func payloadValue(data map[string]interface{}, path string) (string, bool) {
+	var value interface{} = data
+	for _, key := range strings.Split(path, ".") {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return "", false
+		}
+		if value, ok = object[key]; !ok {
+			return "", false
+		}
+	}
+	switch v := value.(type) {
+	case nil:
+		return "", false
+	case string:
+		return v, true
+	case map[string]interface{}, []interface{}:
+		encoded, _ := json.Marshal(v)
+		return string(encoded), true
+	}
+	return fmt.Sprint(value), true
+}
+
+// EventResult reports what an inbound event became
+type EventResult struct {
+	EventID   string      `json:"event_id" yaml:"event_id"`
+	Target    EventTarget `json:"target" yaml:"target"`
+	EntityID  string      `json:"entity_id" yaml:"entity_id"`
+	Duplicate bool        `json:"duplicate,omitempty" yaml:"duplicate,omitempty"` // the event was delivered before and not applied again
+}
+
+// ApplyEvent maps an inbound event into the tenant, e.g. a production deviation into a
+// nonconformance or a support escalation into a customer complaint. Events are applied
+// once: redelivering an event returns the entity it created the first time.
+func (t *Tenant) ApplyEvent(mappings *EventMappings, event InboundEvent) (EventResult, error) {
+	key := event.Source + ":" + event.ID
+	if entityID, ok := t.Events[key]; ok {
+		return EventResult{EventID: event.ID, EntityID: entityID, Duplicate: true}, nil
+	}
+
+	mapping, ok := mappings.mappingFor(event)
+	if !ok {
+		return EventResult{}, fmt.Errorf("%w: %s event %q", ErrUnmappedEvent, event.Source, event.Type)
+	}
+	fields := make(map[string]string)
+	for field, value := range mapping.Defaults {
+		fields[field] = value
+	}
+	for field, path := range mapping.Fields {
+		if value, ok := payloadValue(event.Data, path); ok && value != "" {
+			fields[field] = value
+		}
+	}
+	required := eventTargetFields[mapping.Target][0]
+	if fields[required] == "" {
+		return EventResult{}, fmt.Errorf("%s event %s has no %s at %q", event.Source, event.ID, required, mapping.Fields[required])
+	}
+	date := event.Received
+	if fields["date"] != "" {
+		parsed, err := parseEventDate(fields["date"])
+		if err != nil {
+			return EventResult{}, fmt.Errorf("%s event %s: %w", event.Source, event.ID, err)
+		}
+		date = parsed
+	}
+	origin := event.Source + " event " + event.ID
+
+	result := EventResult{EventID: event.ID, Target: mapping.Target}
+	switch mapping.Target {
+	case EventTargetNonconformance:
+		result.EntityID = fmt.Sprintf("NC-%04d", len(t.Nonconformities)+1)
+		t.Nonconformities = append(t.Nonconformities, NonconformanceReport{
+			ID:          result.EntityID,
+			Description: fields["description"],
+			Status:      NonconformanceStatusOpen,
+			RootCause:   fields["root_cause"],
+			Process:     fields["process"],
+			Date:        date,
+			Source:      origin,
+		})
+	case EventTargetComplaint:
+		if t.Feedback == nil {
+			t.Feedback = NewCustomerFeedbackManager()
+		}
+		complaint := &CustomerComplaint{
+			Description: fields["description"],
+			Date:        date,
+			Customer:    fields["customer"],
+			Product:     fields["product"],
+			Priority:    Priority(fields["priority"]),
+			Source:      origin,
+		}
+		if err := t.Feedback.RecordComplaint(complaint); err != nil {
+			return EventResult{}, fmt.Errorf("%s event %s: %w", event.Source, event.ID, err)
+		}
+		result.EntityID = complaint.ID
+	case EventTargetMeasurement:
+		measurement := MeasurementResult{
+			Metric:      fields["metric"],
+			Date:        date,
+			Unit:        fields["unit"],
+			ProcessID:   fields["process_id"],
+			CriteriaID:  fields["criteria_id"],
+			ObjectiveID: fields["objective_id"],
+			Source:      origin,
+		}
+		var err error
+		if measurement.Value, err = strconv.ParseFloat(fields["value"], 64); err != nil {
+			return EventResult{}, fmt.Errorf("%s event %s: invalid value %q", event.Source, event.ID, fields["value"])
+		}
+		if fields["target"] != "" {
+			if measurement.Target, err = strconv.ParseFloat(fields["target"], 64); err != nil {
+				return EventResult{}, fmt.Errorf("%s event %s: invalid target %q", event.Source, event.ID, fields["target"])
+			}
+		}
+		ingestion := t.IngestMeasurements(measurement)
+		if len(ingestion.Rejected) > 0 {
+			return EventResult{}, fmt.Errorf("%s event %s: %s", event.Source, event.ID, ingestion.Rejected[0].Reason)
+		}
+		result.EntityID = ingestion.Accepted[0]
+	}
+
+	if t.Events == nil {
+		t.Events = make(map[string]string)
+	}
+	t.Events[key] = result.EntityID
+	return result, nil
+}
+
+// parseEventDate accepts RFC 3339 timestamps and plain dates
+func parseEventDate(value string) (time.Time, error) {
+	if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+		return parsed, nil
+	}
+	parsed, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q", value)
+	}
+	return parsed, nil
+}
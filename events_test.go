@@ -0,0 +1,81 @@
+package iso9001
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+)
+
+const testEventMappings = `{
+  "sources": [
+    {"name": "mes", "secret": "mes-secret"},
+    {"name": "helpdesk", "secret": "hd-secret", "type_field": "event", "id_field": "ticket.id"}
+  ],
+  "mappings": [
+    {"source": "mes", "event_type": "production.deviation", "target": "nonconformance",
+     "fields": {"description": "deviation.summary", "process": "line"}},
+    {"source": "helpdesk", "event_type": "*", "target": "complaint",
+     "fields": {"description": "ticket.subject", "customer": "ticket.account", "date": "ticket.opened"}}
+  ]
+}`
+
+func TestInboundEvents(t *testing.T) {
+	mappings, err := LoadEventMappings([]byte(testEventMappings))
+	if err != nil {
+		t.Fatalf("Failed to load mappings: %v", err)
+	}
+	if _, err := LoadEventMappings([]byte(`{"sources": [{"name": "mes", "secret": "s"}], "mappings": [{"source": "mes", "event_type": "x", "target": "complaint", "fields": {"severity": "s"}}]}`)); err == nil {
+		t.Error("Expected error for unknown target field")
+	}
+
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	mes, _ := mappings.Source("mes")
+	body := []byte(`{"id": "EVT-1", "type": "production.deviation", "line": "PROC-002", "deviation": {"summary": "Torque out of tolerance"}}`)
+	signature := SignEvent("mes-secret", now, body)
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	if err := mes.Verify(body, timestamp, signature, now.Add(time.Minute)); err != nil {
+		t.Fatalf("Expected valid signature: %v", err)
+	}
+	if err := mes.Verify(body, timestamp, signature, now.Add(time.Hour)); !errors.Is(err, ErrInvalidEventSignature) {
+		t.Errorf("Expected stale signature to be rejected, got %v", err)
+	}
+	if err := mes.Verify([]byte(`{}`), timestamp, signature, now); !errors.Is(err, ErrInvalidEventSignature) {
+		t.Errorf("Expected tampered body to be rejected, got %v", err)
+	}
+
+	tenant := NewTenant("ACME")
+	event, err := mes.Decode(body, now)
+	if err != nil {
+		t.Fatalf("Failed to decode: %v", err)
+	}
+	result, err := tenant.ApplyEvent(mappings, event)
+	if err != nil {
+		t.Fatalf("Failed to apply event: %v", err)
+	}
+	nc := tenant.Nonconformities[0]
+	if result.EntityID != "NC-0001" || nc.Description != "Torque out of tolerance" || nc.Process != "PROC-002" || nc.Status != NonconformanceStatusOpen || nc.Source != "mes event EVT-1" {
+		t.Errorf("Unexpected nonconformance %+v", nc)
+	}
+	if again, _ := tenant.ApplyEvent(mappings, event); !again.Duplicate || again.EntityID != "NC-0001" || len(tenant.Nonconformities) != 1 {
+		t.Errorf("Expected redelivered event to be ignored, got %+v", again)
+	}
+
+	other, _ := mes.Decode([]byte(`{"id": "EVT-2", "type": "shift.report"}`), now)
+	if _, err := tenant.ApplyEvent(mappings, other); !errors.Is(err, ErrUnmappedEvent) {
+		t.Errorf("Expected unmapped event error, got %v", err)
+	}
+
+	helpdesk, _ := mappings.Source("helpdesk")
+	escalation, err := helpdesk.Decode([]byte(`{"event": "escalated", "ticket": {"id": 4711, "subject": "Late delivery", "account": "Globex", "opened": "2024-05-30"}}`), now)
+	if err != nil {
+		t.Fatalf("Failed to decode: %v", err)
+	}
+	if _, err := tenant.ApplyEvent(mappings, escalation); err != nil {
+		t.Fatalf("Failed to apply escalation: %v", err)
+	}
+	complaint := tenant.Feedback.Complaints["CC-0001"]
+	if complaint == nil || complaint.Customer != "Globex" || complaint.Date.Day() != 30 || tenant.Events["helpdesk:4711"] != "CC-0001" {
+		t.Errorf("Unexpected complaint %+v", complaint)
+	}
+}
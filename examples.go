@@ -306,8 +306,21 @@ type ImprovementArea struct {
 
 // GenerateComplianceReport generates a comprehensive compliance report
 func GenerateComplianceReport(org *Organization) *ComplianceReport {
-	result := ValidateOrganization(org)
-	score := GetComplianceScore(org)
+	return GenerateLocalizedComplianceReport(org, LocaleEnglish)
+}
+
+// GenerateLocalizedComplianceReport generates a compliance report with its findings,
+// recommendations and ratings in the given locale
+func GenerateLocalizedComplianceReport(org *Organization, locale Locale) *ComplianceReport {
+	return GenerateComplianceReportWithOptions(org, locale, ValidationOptions{})
+}
+
+// GenerateComplianceReportWithOptions generates a localized compliance report from a
+// validation run with the given options, e.g. to report its progress
+func GenerateComplianceReportWithOptions(org *Organization, locale Locale, opts ValidationOptions) *ComplianceReport {
+	result := ValidateOrganizationWithOptions(org, opts)
+	score := complianceScore(result)
+	result = result.Localize(locale)
 
 	report := &ComplianceReport{
 		OrganizationID:    org.ID,
@@ -320,18 +333,7 @@ func GenerateComplianceReport(org *Organization) *ComplianceReport {
 	}
 
 	// Determine overall compliance level
-	switch {
-	case score >= 90:
-		report.OverallCompliance = "Excellent"
-	case score >= 80:
-		report.OverallCompliance = "Good"
-	case score >= 70:
-		report.OverallCompliance = "Satisfactory"
-	case score >= 60:
-		report.OverallCompliance = "Needs Improvement"
-	default:
-		report.OverallCompliance = "Critical Gaps"
-	}
+	report.OverallCompliance = Translate(locale, complianceRating(score))
 
 	// Extract critical gaps from validation errors
 	for _, err := range result.Errors {
@@ -339,7 +341,7 @@ func GenerateComplianceReport(org *Organization) *ComplianceReport {
 			gap := ComplianceGap{
 				Clause:      err.Clause,
 				Description: err.Message,
-				Severity:    "Critical",
+				Severity:    Translate(locale, "Critical"),
 				Priority:    PriorityHigh,
 			}
 			report.CriticalGaps = append(report.CriticalGaps, gap)
@@ -359,25 +361,41 @@ func GenerateComplianceReport(org *Organization) *ComplianceReport {
 	// Add default recommendations
 	if len(report.CriticalGaps) > 0 {
 		report.Recommendations = append(report.Recommendations,
-			"Address critical compliance gaps immediately",
-			"Implement corrective actions for identified nonconformities",
-			"Strengthen QMS documentation and procedures")
+			Translate(locale, "Address critical compliance gaps immediately"),
+			Translate(locale, "Implement corrective actions for identified nonconformities"),
+			Translate(locale, "Strengthen QMS documentation and procedures"))
 	}
 
 	if len(report.ImprovementAreas) > 0 {
 		report.Recommendations = append(report.Recommendations,
-			"Develop action plans for improvement areas",
-			"Enhance monitoring and measurement processes",
-			"Provide additional training where needed")
+			Translate(locale, "Develop action plans for improvement areas"),
+			Translate(locale, "Enhance monitoring and measurement processes"),
+			Translate(locale, "Provide additional training where needed"))
 	}
 
 	// Identify strengths
 	if org.QMS != nil && len(org.QMS.Processes) > 0 {
-		report.Strengths = append(report.Strengths, "Processes are defined and documented")
+		report.Strengths = append(report.Strengths, Translate(locale, "Processes are defined and documented"))
 	}
 	if org.Leadership != nil && org.Leadership.QualityPolicy != nil {
-		report.Strengths = append(report.Strengths, "Quality policy is established and communicated")
+		report.Strengths = append(report.Strengths, Translate(locale, "Quality policy is established and communicated"))
 	}
 
 	return report
 }
+
+// complianceRating returns the overall compliance level of a compliance score
+func complianceRating(score float64) string {
+	switch {
+	case score >= 90:
+		return "Excellent"
+	case score >= 80:
+		return "Good"
+	case score >= 70:
+		return "Satisfactory"
+	case score >= 60:
+		return "Needs Improvement"
+	default:
+		return "Critical Gaps"
+	}
+}
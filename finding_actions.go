@@ -0,0 +1,149 @@
+package iso9001
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	// ErrActionsNotVerified is returned when a finding is closed before its corrective
+	// actions have been verified as effective
+	ErrActionsNotVerified = errors.New("corrective actions not verified")
+	// ErrCriticalFindingsOpen is returned when an audit is completed while a critical
+	// finding is neither closed nor accepted
+	ErrCriticalFindingsOpen = errors.New("critical findings open")
+)
+
+// RaiseCorrectiveAction links a corrective action to a finding of an audit (clause
+// 10.2). The ID defaults to the finding ID followed by -CA1, -CA2 and so on, and the
+// due date to the finding's. An open finding moves to in progress.
+func (am *AuditManager) RaiseCorrectiveAction(auditID, findingID string, action CorrectiveAction) (CorrectiveAction, error) {
+	audit, finding, err := am.finding(auditID, findingID)
+	if err != nil {
+		return action, err
+	}
+	if action.Description == "" {
+		return action, fmt.Errorf("corrective action for finding %s must have a description", findingID)
+	}
+	if action.ID == "" {
+		action.ID = fmt.Sprintf("%s-CA%d", findingID, len(finding.CorrectiveActions)+1)
+	}
+	if correctiveAction(finding, action.ID) != nil {
+		return action, fmt.Errorf("finding %s already has corrective action %s", findingID, action.ID)
+	}
+	if action.Status == "" {
+		action.Status = ActionStatusPlanned
+	}
+	if action.DueDate.IsZero() {
+		action.DueDate = finding.DueDate
+	}
+
+	finding.CorrectiveActions = append(finding.CorrectiveActions, action)
+	if finding.Status == FindingStatusOpen {
+		finding.Status = FindingStatusInProgress
+	}
+	audit.Modified = time.Now()
+	return action, nil
+}
+
+// UpdateCorrectiveActionStatus moves a corrective action of a finding to planned, in
+// progress or completed. Completed actions are verified with VerifyCorrectiveAction.
+func (am *AuditManager) UpdateCorrectiveActionStatus(auditID, findingID, actionID string, status ActionStatus) error {
+	audit, finding, err := am.finding(auditID, findingID)
+	if err != nil {
+		return err
+	}
+	action := correctiveAction(finding, actionID)
+	if action == nil {
+		return fmt.Errorf("corrective action with ID %s not found in finding %s", actionID, findingID)
+	}
+	if status == ActionStatusVerified {
+		return fmt.Errorf("corrective action %s must be verified with VerifyCorrectiveAction", actionID)
+	}
+
+	action.Status = status
+	audit.Modified = time.Now()
+	return nil
+}
+
+// VerifyCorrectiveAction records the verification of the effectiveness of a completed
+// corrective action. An effective action becomes verified; one that is not goes back
+// to in progress.
+func (am *AuditManager) VerifyCorrectiveAction(auditID, findingID, actionID string, effective bool, verification string) error {
+	audit, finding, err := am.finding(auditID, findingID)
+	if err != nil {
+		return err
+	}
+	action := correctiveAction(finding, actionID)
+	if action == nil {
+		return fmt.Errorf("corrective action with ID %s not found in finding %s", actionID, findingID)
+	}
+	if action.Status != ActionStatusCompleted {
+		return fmt.Errorf("corrective action %s must be completed before it is verified", actionID)
+	}
+	if verification == "" {
+		return fmt.Errorf("verification of corrective action %s must describe the evidence of effectiveness", actionID)
+	}
+
+	action.Verification = verification
+	action.Status = ActionStatusVerified
+	if !effective {
+		action.Status = ActionStatusInProgress
+	}
+	audit.Modified = time.Now()
+	return nil
+}
+
+// checkFindingClosure returns ErrActionsNotVerified unless a finding can be closed:
+// nonconformities need at least one corrective action and every action verified,
+// observations may be closed as they are
+func checkFindingClosure(finding *AuditFinding) error {
+	if finding.Severity == SeverityObservation {
+		return nil
+	}
+	if len(finding.CorrectiveActions) == 0 {
+		return fmt.Errorf("%w: finding %s has no corrective action", ErrActionsNotVerified, finding.ID)
+	}
+	for _, action := range finding.CorrectiveActions {
+		if action.Status != ActionStatusVerified {
+			return fmt.Errorf("%w: corrective action %s of finding %s is %s", ErrActionsNotVerified, action.ID, finding.ID, action.Status)
+		}
+	}
+	return nil
+}
+
+// checkCriticalFindings returns ErrCriticalFindingsOpen when a critical finding of an
+// audit is neither closed nor accepted
+func checkCriticalFindings(audit *Audit) error {
+	for _, finding := range audit.Findings {
+		if finding.Severity == SeverityCritical && finding.Status != FindingStatusClosed && finding.Status != FindingStatusAccepted {
+			return fmt.Errorf("%w: finding %s of audit %s is %s", ErrCriticalFindingsOpen, finding.ID, audit.ID, finding.Status)
+		}
+	}
+	return nil
+}
+
+// finding returns an audit and one of its findings
+func (am *AuditManager) finding(auditID, findingID string) (*Audit, *AuditFinding, error) {
+	audit, exists := am.Audits[auditID]
+	if !exists {
+		return nil, nil, fmt.Errorf("audit with ID %s not found", auditID)
+	}
+	for i := range audit.Findings {
+		if audit.Findings[i].ID == findingID {
+			return audit, &audit.Findings[i], nil
+		}
+	}
+	return nil, nil, fmt.Errorf("finding with ID %s not found in audit %s", findingID, auditID)
+}
+
+// correctiveAction returns the corrective action of a finding with the ID, or nil
+func correctiveAction(finding *AuditFinding, actionID string) *CorrectiveAction {
+	for i := range finding.CorrectiveActions {
+		if finding.CorrectiveActions[i].ID == actionID {
+			return &finding.CorrectiveActions[i]
+		}
+	}
+	return nil
+}
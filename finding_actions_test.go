@@ -0,0 +1,79 @@
+package iso9001
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestFindingClosureRequiresVerifiedActions(t *testing.T) {
+	am := NewAuditManager()
+	am.CreateAudit(&Audit{ID: "AUDIT-001", Title: "Audit", Scope: AuditScope{Description: "Scope"}})
+	am.AddFinding("AUDIT-001", AuditFinding{ID: "F-001", Severity: SeverityMajor, Status: FindingStatusOpen})
+	am.AddFinding("AUDIT-001", AuditFinding{ID: "F-002", Severity: SeverityObservation, Status: FindingStatusOpen})
+
+	if err := am.UpdateFindingStatus("AUDIT-001", "F-001", FindingStatusClosed); !errors.Is(err, ErrActionsNotVerified) {
+		t.Errorf("Expected finding without corrective action to stay open, got %v", err)
+	}
+	if err := am.UpdateFindingStatus("AUDIT-001", "F-002", FindingStatusClosed); err != nil {
+		t.Errorf("Expected observation to close without corrective action, got %v", err)
+	}
+
+	action, err := am.RaiseCorrectiveAction("AUDIT-001", "F-001", CorrectiveAction{Description: "Retrain buyers", Responsible: "Purchasing"})
+	if err != nil {
+		t.Fatalf("Failed to raise corrective action: %v", err)
+	}
+	finding := am.Audits["AUDIT-001"].Findings[0]
+	if action.ID != "F-001-CA1" || action.Status != ActionStatusPlanned || !action.DueDate.Equal(finding.DueDate) {
+		t.Errorf("Unexpected corrective action %+v", action)
+	}
+	if finding.Status != FindingStatusInProgress {
+		t.Errorf("Expected finding to be in progress, got %s", finding.Status)
+	}
+	if _, err := am.RaiseCorrectiveAction("AUDIT-001", "F-001", CorrectiveAction{ID: "F-001-CA1", Description: "Again"}); err == nil {
+		t.Error("Expected error for duplicate corrective action")
+	}
+
+	if err := am.VerifyCorrectiveAction("AUDIT-001", "F-001", action.ID, true, "Checked"); err == nil {
+		t.Error("Expected error verifying an action that is not completed")
+	}
+	if err := am.UpdateCorrectiveActionStatus("AUDIT-001", "F-001", action.ID, ActionStatusVerified); err == nil {
+		t.Error("Expected verification to go through VerifyCorrectiveAction")
+	}
+	am.UpdateCorrectiveActionStatus("AUDIT-001", "F-001", action.ID, ActionStatusCompleted)
+	if err := am.UpdateFindingStatus("AUDIT-001", "F-001", FindingStatusClosed); !errors.Is(err, ErrActionsNotVerified) {
+		t.Errorf("Expected completed but unverified action to block closure, got %v", err)
+	}
+
+	if err := am.VerifyCorrectiveAction("AUDIT-001", "F-001", action.ID, false, "Errors recurred in May"); err != nil {
+		t.Fatalf("Failed to record ineffective action: %v", err)
+	}
+	if got := am.Audits["AUDIT-001"].Findings[0].CorrectiveActions[0].Status; got != ActionStatusInProgress {
+		t.Errorf("Expected ineffective action back in progress, got %s", got)
+	}
+	am.UpdateCorrectiveActionStatus("AUDIT-001", "F-001", action.ID, ActionStatusCompleted)
+	if err := am.VerifyCorrectiveAction("AUDIT-001", "F-001", action.ID, true, "No recurrence in three months of orders"); err != nil {
+		t.Fatalf("Failed to verify action: %v", err)
+	}
+	if err := am.UpdateFindingStatus("AUDIT-001", "F-001", FindingStatusClosed); err != nil {
+		t.Errorf("Expected verified finding to close, got %v", err)
+	}
+}
+
+func TestCompleteAuditBlockedByCriticalFindings(t *testing.T) {
+	am := NewAuditManager()
+	am.CreateAudit(&Audit{ID: "AUDIT-001", Title: "Audit", Scope: AuditScope{Description: "Scope"}})
+	am.StartAudit("AUDIT-001", time.Now())
+	am.AddFinding("AUDIT-001", AuditFinding{ID: "F-001", Severity: SeverityCritical, Status: FindingStatusOpen})
+	am.AddFinding("AUDIT-001", AuditFinding{ID: "F-002", Severity: SeverityMajor, Status: FindingStatusOpen})
+
+	if err := am.CompleteAudit("AUDIT-001", time.Now(), nil); !errors.Is(err, ErrCriticalFindingsOpen) {
+		t.Fatalf("Expected open critical finding to block completion, got %v", err)
+	}
+	if err := am.UpdateFindingStatus("AUDIT-001", "F-001", FindingStatusAccepted); err != nil {
+		t.Fatalf("Failed to accept finding: %v", err)
+	}
+	if err := am.CompleteAudit("AUDIT-001", time.Now(), nil); err != nil {
+		t.Errorf("Expected audit with only major findings open to complete, got %v", err)
+	}
+}
@@ -0,0 +1,230 @@
+package iso9001
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// FMEARow is one failure mode of a process step in a Failure Mode and Effects
+// Analysis. Severity, occurrence and detection are rated from 1 to 10; their product
+// is the risk priority number (RPN).
+type FMEARow struct {
+	ID                string    `json:"id" yaml:"id"`
+	ProcessStep       string    `json:"process_step,omitempty" yaml:"process_step,omitempty"`
+	FailureMode       string    `json:"failure_mode" yaml:"failure_mode"`
+	Effect            string    `json:"effect,omitempty" yaml:"effect,omitempty"`
+	Cause             string    `json:"cause,omitempty" yaml:"cause,omitempty"`
+	CurrentControls   string    `json:"current_controls,omitempty" yaml:"current_controls,omitempty"`
+	Severity          int       `json:"severity" yaml:"severity"`
+	Occurrence        int       `json:"occurrence" yaml:"occurrence"`
+	Detection         int       `json:"detection" yaml:"detection"`
+	RPN               int       `json:"rpn" yaml:"rpn"`
+	PreviousRPN       int       `json:"previous_rpn,omitempty" yaml:"previous_rpn,omitempty"` // RPN before the last re-rating
+	Level             RPNLevel  `json:"level" yaml:"level"`
+	RecommendedAction string    `json:"recommended_action,omitempty" yaml:"recommended_action,omitempty"`
+	Responsible       string    `json:"responsible,omitempty" yaml:"responsible,omitempty"`
+	DueDate           time.Time `json:"due_date,omitempty" yaml:"due_date,omitempty"`
+	RiskID            string    `json:"risk_id,omitempty" yaml:"risk_id,omitempty"` // related risk in the register
+	Updated           time.Time `json:"updated" yaml:"updated"`
+}
+
+// RPNLevel classifies a failure mode against the RPN thresholds of its worksheet
+type RPNLevel string
+
+const (
+	RPNLevelAcceptable     RPNLevel = "acceptable"
+	RPNLevelActionRequired RPNLevel = "action_required"
+	RPNLevelCritical       RPNLevel = "critical"
+)
+
+// RPNThresholds decide which failure modes need action
+type RPNThresholds struct {
+	Action   int `json:"action" yaml:"action"`     // RPN from which a recommended action is required
+	Critical int `json:"critical" yaml:"critical"` // RPN from which the failure mode is critical
+	Severity int `json:"severity" yaml:"severity"` // severity from which action is required whatever the RPN
+}
+
+// DefaultRPNThresholds require action from an RPN of 100 or a severity of 9, and
+// treat an RPN of 200 or more as critical
+var DefaultRPNThresholds = RPNThresholds{Action: 100, Critical: 200, Severity: 9}
+
+// Classify rates an RPN and severity against the thresholds
+func (t RPNThresholds) Classify(rpn, severity int) RPNLevel {
+	switch {
+	case t.Critical > 0 && rpn >= t.Critical:
+		return RPNLevelCritical
+	case t.Action > 0 && rpn >= t.Action, t.Severity > 0 && severity >= t.Severity:
+		return RPNLevelActionRequired
+	default:
+		return RPNLevelAcceptable
+	}
+}
+
+// FMEAWorksheet holds the failure modes analyzed for one process
+type FMEAWorksheet struct {
+	ProcessID  string        `json:"process_id" yaml:"process_id"`
+	Title      string        `json:"title,omitempty" yaml:"title,omitempty"`
+	Thresholds RPNThresholds `json:"thresholds" yaml:"thresholds"`
+	Rows       []*FMEARow    `json:"rows" yaml:"rows"`
+	Updated    time.Time     `json:"updated" yaml:"updated"`
+}
+
+// NewFMEAWorksheet creates an empty worksheet for a process using the default
+// thresholds
+func NewFMEAWorksheet(processID string) *FMEAWorksheet {
+	return &FMEAWorksheet{ProcessID: processID, Thresholds: DefaultRPNThresholds, Rows: []*FMEARow{}}
+}
+
+// UpsertRow adds a failure mode, or replaces the row with the same ID. New rows get
+// IDs such as FM-001. The RPN and level are computed from the ratings.
+func (w *FMEAWorksheet) UpsertRow(row FMEARow) (*FMEARow, error) {
+	if row.FailureMode == "" {
+		return nil, fmt.Errorf("FMEA row must describe a failure mode")
+	}
+	for name, rating := range map[string]int{"severity": row.Severity, "occurrence": row.Occurrence, "detection": row.Detection} {
+		if rating < 1 || rating > 10 {
+			return nil, fmt.Errorf("FMEA %s must be rated from 1 to 10, got %d", name, rating)
+		}
+	}
+
+	row.RPN = row.Severity * row.Occurrence * row.Detection
+	row.Level = w.Thresholds.Classify(row.RPN, row.Severity)
+	row.Updated = time.Now()
+	w.Updated = row.Updated
+
+	if existing := w.Row(row.ID); existing != nil {
+		row.PreviousRPN = existing.PreviousRPN
+		if existing.RPN != row.RPN {
+			row.PreviousRPN = existing.RPN
+		}
+		*existing = row
+		return existing, nil
+	}
+	if row.ID == "" {
+		row.ID = w.nextRowID()
+	}
+	w.Rows = append(w.Rows, &row)
+	return &row, nil
+}
+
+// nextRowID returns the first free row ID of the form FM-001
+func (w *FMEAWorksheet) nextRowID() string {
+	for n := len(w.Rows) + 1; ; n++ {
+		if id := fmt.Sprintf("FM-%03d", n); w.Row(id) == nil {
+			return id
+		}
+	}
+}
+
+// Row returns a row by ID, or nil
+func (w *FMEAWorksheet) Row(id string) *FMEARow {
+	if id == "" {
+		return nil
+	}
+	for _, row := range w.Rows {
+		if row.ID == id {
+			return row
+		}
+	}
+	return nil
+}
+
+// RemoveRow deletes a row
+func (w *FMEAWorksheet) RemoveRow(id string) error {
+	for i, row := range w.Rows {
+		if row.ID == id {
+			w.Rows = append(w.Rows[:i], w.Rows[i+1:]...)
+			w.Updated = time.Now()
+			return nil
+		}
+	}
+	return fmt.Errorf("FMEA row %s not found in worksheet %s", id, w.ProcessID)
+}
+
+// SetThresholds changes the thresholds and re-classifies every row
+func (w *FMEAWorksheet) SetThresholds(thresholds RPNThresholds) error {
+	if thresholds.Critical > 0 && thresholds.Action > thresholds.Critical {
+		return fmt.Errorf("action threshold %d must not exceed critical threshold %d", thresholds.Action, thresholds.Critical)
+	}
+	w.Thresholds = thresholds
+	for _, row := range w.Rows {
+		row.Level = thresholds.Classify(row.RPN, row.Severity)
+	}
+	w.Updated = time.Now()
+	return nil
+}
+
+// Prioritized returns the rows ordered by RPN, highest first, breaking ties by
+// severity
+func (w *FMEAWorksheet) Prioritized() []*FMEARow {
+	rows := append([]*FMEARow(nil), w.Rows...)
+	sort.SliceStable(rows, func(i, j int) bool {
+		if rows[i].RPN != rows[j].RPN {
+			return rows[i].RPN > rows[j].RPN
+		}
+		return rows[i].Severity > rows[j].Severity
+	})
+	return rows
+}
+
+// ActionRequired returns the rows that need a recommended action, highest RPN first
+func (w *FMEAWorksheet) ActionRequired() []*FMEARow {
+	var rows []*FMEARow
+	for _, row := range w.Prioritized() {
+		if row.Level != RPNLevelAcceptable {
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+// FMEASummary counts the rows of a worksheet by level
+type FMEASummary struct {
+	ProcessID      string  `json:"process_id" yaml:"process_id"`
+	Rows           int     `json:"rows" yaml:"rows"`
+	Critical       int     `json:"critical" yaml:"critical"`
+	ActionRequired int     `json:"action_required" yaml:"action_required"`
+	WithoutAction  int     `json:"without_action" yaml:"without_action"` // rows needing action with no recommended action
+	MaxRPN         int     `json:"max_rpn" yaml:"max_rpn"`
+	AverageRPN     float64 `json:"average_rpn" yaml:"average_rpn"`
+}
+
+// Summary counts the rows of the worksheet by level
+func (w *FMEAWorksheet) Summary() FMEASummary {
+	summary := FMEASummary{ProcessID: w.ProcessID, Rows: len(w.Rows)}
+	total := 0
+	for _, row := range w.Rows {
+		total += row.RPN
+		if row.RPN > summary.MaxRPN {
+			summary.MaxRPN = row.RPN
+		}
+		switch row.Level {
+		case RPNLevelCritical:
+			summary.Critical++
+		case RPNLevelActionRequired:
+			summary.ActionRequired++
+		}
+		if row.Level != RPNLevelAcceptable && row.RecommendedAction == "" {
+			summary.WithoutAction++
+		}
+	}
+	if len(w.Rows) > 0 {
+		summary.AverageRPN = float64(total) / float64(len(w.Rows))
+	}
+	return summary
+}
+
+// Worksheet returns the FMEA worksheet of a process, creating it when the process
+// has none yet
+func (rm *RiskManager) Worksheet(processID string) *FMEAWorksheet {
+	if rm.FMEA == nil {
+		rm.FMEA = make(map[string]*FMEAWorksheet)
+	}
+	worksheet, exists := rm.FMEA[processID]
+	if !exists {
+		worksheet = NewFMEAWorksheet(processID)
+		rm.FMEA[processID] = worksheet
+	}
+	return worksheet
+}
@@ -0,0 +1,60 @@
+package iso9001
+
+import "testing"
+
+func TestFMEAWorksheet(t *testing.T) {
+	rm := NewRiskManager()
+	ws := rm.Worksheet("PROC-001")
+	if rm.Worksheet("PROC-001") != ws {
+		t.Fatal("Expected the same worksheet for a process")
+	}
+
+	leak, err := ws.UpsertRow(FMEARow{FailureMode: "Seal leaks", Effect: "Fluid loss", Severity: 8, Occurrence: 5, Detection: 6})
+	if err != nil {
+		t.Fatalf("Failed to add row: %v", err)
+	}
+	if leak.ID != "FM-001" || leak.RPN != 240 || leak.Level != RPNLevelCritical {
+		t.Errorf("Unexpected row %+v", leak)
+	}
+	burn, _ := ws.UpsertRow(FMEARow{FailureMode: "Operator burn", Severity: 9, Occurrence: 2, Detection: 2})
+	if burn.Level != RPNLevelActionRequired {
+		t.Errorf("Expected severity 9 to require action at RPN %d, got %s", burn.RPN, burn.Level)
+	}
+	scratch, _ := ws.UpsertRow(FMEARow{FailureMode: "Label scratched", Severity: 2, Occurrence: 4, Detection: 3})
+	if scratch.Level != RPNLevelAcceptable {
+		t.Errorf("Expected low RPN to be acceptable, got %s", scratch.Level)
+	}
+	if _, err := ws.UpsertRow(FMEARow{FailureMode: "Bad", Severity: 11, Occurrence: 1, Detection: 1}); err == nil {
+		t.Error("Expected rating above 10 to be rejected")
+	}
+
+	// Re-rating after the recommended action keeps the previous RPN
+	updated, err := ws.UpsertRow(FMEARow{ID: "FM-001", FailureMode: "Seal leaks", Severity: 8, Occurrence: 2, Detection: 3, RecommendedAction: "Add leak test"})
+	if err != nil || updated != leak || leak.RPN != 48 || leak.PreviousRPN != 240 || leak.Level != RPNLevelAcceptable {
+		t.Errorf("Unexpected re-rated row %+v (%v)", leak, err)
+	}
+
+	if rows := ws.ActionRequired(); len(rows) != 1 || rows[0] != burn {
+		t.Errorf("Expected only the burn hazard to need action, got %d rows", len(rows))
+	}
+	if err := ws.SetThresholds(RPNThresholds{Action: 20, Critical: 40}); err != nil {
+		t.Fatal(err)
+	}
+	if prioritized := ws.Prioritized(); prioritized[0] != leak || prioritized[2] != scratch {
+		t.Errorf("Expected rows ordered by RPN, got %s first", prioritized[0].ID)
+	}
+	if leak.Level != RPNLevelCritical || scratch.Level != RPNLevelActionRequired {
+		t.Errorf("Expected rows to be re-classified, got %s and %s", leak.Level, scratch.Level)
+	}
+	if err := ws.SetThresholds(RPNThresholds{Action: 300, Critical: 200}); err == nil {
+		t.Error("Expected action threshold above the critical threshold to be rejected")
+	}
+
+	summary := ws.Summary()
+	if summary.Rows != 3 || summary.Critical != 1 || summary.ActionRequired != 2 || summary.WithoutAction != 2 || summary.MaxRPN != 48 {
+		t.Errorf("Unexpected summary %+v", summary)
+	}
+	if err := ws.RemoveRow("FM-003"); err != nil || len(ws.Rows) != 2 {
+		t.Errorf("Failed to remove row: %v", err)
+	}
+}
@@ -0,0 +1,274 @@
+package iso9001
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Effort in person-days assumed to close a gap, by severity. The weights follow those
+// of the compliance score.
+var gapEffortDays = map[string]float64{
+	SeverityError:   3,
+	SeverityWarning: 1,
+	SeverityInfo:    0.5,
+}
+
+// remediationPhases sequence the remediation plan. Each phase builds on the clauses of
+// the phases before it: planning needs the context, scope and policy, operation needs
+// the plans and resources, and evaluation needs processes that run.
+var remediationPhases = []struct {
+	name, description, role string
+	clauses                 []string
+}{
+	{"Establish the foundation", "Determine the context, scope and processes of the QMS, and have top management commit to it and set the quality policy", "Top management", []string{"4", "5"}},
+	{"Plan and provide support", "Address risks and opportunities, set quality objectives, and provide the resources, competence, awareness and documented information the QMS needs", "Quality manager", []string{"6", "7"}},
+	{"Implement operational controls", "Plan and control the operation, from customer requirements and design to external providers, production, release and nonconforming outputs", "Process owner", []string{"8"}},
+	{"Evaluate and improve", "Monitor and measure, audit and review the QMS, and correct nonconformities to improve it continually", "Quality manager", []string{"9", "10"}},
+}
+
+// GapAnalysisOwner is the owner of remediation actions until one is assigned
+const GapAnalysisOwner = "TBD"
+
+// GapAnalysis groups the gaps validation finds in an organization by clause and plans
+// their remediation in phases
+type GapAnalysis struct {
+	OrganizationID    string             `json:"organization_id" yaml:"organization_id"`
+	Date              time.Time          `json:"date" yaml:"date"`
+	ComplianceScore   float64            `json:"compliance_score" yaml:"compliance_score"`
+	OverallCompliance string             `json:"overall_compliance" yaml:"overall_compliance"`
+	Clauses           []ClauseGaps       `json:"clauses" yaml:"clauses"`
+	Plan              []RemediationPhase `json:"plan" yaml:"plan"`
+	TotalEffortDays   float64            `json:"total_effort_days" yaml:"total_effort_days"`
+}
+
+// ClauseGaps are the gaps found under one clause, such as 4.3 or 7.1
+type ClauseGaps struct {
+	Clause     string   `json:"clause" yaml:"clause"`
+	Title      string   `json:"title" yaml:"title"`
+	Priority   Priority `json:"priority" yaml:"priority"`
+	EffortDays float64  `json:"effort_days" yaml:"effort_days"`
+	Gaps       []Gap    `json:"gaps" yaml:"gaps"`
+}
+
+// Gap is a validation error, warning or note to be resolved
+type Gap struct {
+	ID          string   `json:"id" yaml:"id"`
+	Clause      string   `json:"clause" yaml:"clause"`
+	Field       string   `json:"field,omitempty" yaml:"field,omitempty"`
+	Description string   `json:"description" yaml:"description"`
+	Severity    string   `json:"severity" yaml:"severity"`
+	Priority    Priority `json:"priority" yaml:"priority"`
+	EffortDays  float64  `json:"effort_days" yaml:"effort_days"`
+}
+
+// RemediationPhase is a stage of the remediation plan; it starts when the phases
+// before it are done
+type RemediationPhase struct {
+	Phase       int                 `json:"phase" yaml:"phase"`
+	Name        string              `json:"name" yaml:"name"`
+	Description string              `json:"description" yaml:"description"`
+	Clauses     []string            `json:"clauses" yaml:"clauses"`
+	EffortDays  float64             `json:"effort_days" yaml:"effort_days"`
+	Actions     []RemediationAction `json:"actions" yaml:"actions"`
+}
+
+// RemediationAction closes the gaps of one clause. Actions are numbered in the order
+// they should be started.
+type RemediationAction struct {
+	Sequence      int      `json:"sequence" yaml:"sequence"`
+	Clause        string   `json:"clause" yaml:"clause"`
+	Action        string   `json:"action" yaml:"action"`
+	Resolves      []string `json:"resolves" yaml:"resolves"` // IDs of the gaps
+	Deliverables  []string `json:"deliverables,omitempty" yaml:"deliverables,omitempty"`
+	Owner         string   `json:"owner" yaml:"owner"`
+	SuggestedRole string   `json:"suggested_role" yaml:"suggested_role"`
+	Priority      Priority `json:"priority" yaml:"priority"`
+	EffortDays    float64  `json:"effort_days" yaml:"effort_days"`
+}
+
+// AnalyzeGaps validates the organization and turns the issues found into a gap
+// analysis. Gaps are grouped under the clauses of the quality manual, e.g. a gap
+// raised under 7.1.5 belongs to 7.1. The plan has one action per clause with gaps,
+// ordered by phase, then by priority, then by clause.
+func AnalyzeGaps(org *Organization) *GapAnalysis {
+	return AnalyzeLocalizedGaps(org, LocaleEnglish)
+}
+
+// AnalyzeLocalizedGaps is AnalyzeGaps with the gap descriptions and the compliance
+// rating in the given locale
+func AnalyzeLocalizedGaps(org *Organization, locale Locale) *GapAnalysis {
+	result := ValidateOrganization(org)
+	score := complianceScore(result)
+	result = result.Localize(locale)
+	analysis := &GapAnalysis{
+		OrganizationID:    org.ID,
+		Date:              time.Now(),
+		ComplianceScore:   score,
+		OverallCompliance: Translate(locale, complianceRating(score)),
+		Clauses:           []ClauseGaps{},
+		Plan:              []RemediationPhase{},
+	}
+
+	index := make(map[string]int, len(manualSections))
+	for i, entry := range manualSections {
+		index[entry.clause] = i
+	}
+
+	byClause := make(map[string]*ClauseGaps)
+	var issues []ValidationError
+	issues = append(issues, result.Errors...)
+	issues = append(issues, result.Warnings...)
+	issues = append(issues, result.Infos...)
+	for i, issue := range issues {
+		clause := normalizeClauseReference(issue.Clause)
+		if section, ok := manualSectionFor(index, clause); ok {
+			clause = manualSections[section].clause
+		}
+		gap := Gap{
+			ID:          fmt.Sprintf("GAP-%03d", i+1),
+			Clause:      issue.Clause,
+			Field:       issue.Field,
+			Description: issue.Message,
+			Severity:    issue.Severity,
+			Priority:    gapPriority(issue),
+			EffortDays:  gapEffortDays[issue.Severity],
+		}
+
+		group, ok := byClause[clause]
+		if !ok {
+			group = &ClauseGaps{Clause: clause, Title: gapClauseTitle(index, clause), Priority: PriorityLow}
+			byClause[clause] = group
+		}
+		group.Gaps = append(group.Gaps, gap)
+		group.EffortDays += gap.EffortDays
+		if priorityRank(gap.Priority) > priorityRank(group.Priority) {
+			group.Priority = gap.Priority
+		}
+		analysis.TotalEffortDays += gap.EffortDays
+	}
+
+	for _, group := range byClause {
+		analysis.Clauses = append(analysis.Clauses, *group)
+	}
+	sort.Slice(analysis.Clauses, func(i, j int) bool {
+		return clauseBefore(index, analysis.Clauses[i].Clause, analysis.Clauses[j].Clause)
+	})
+
+	sequence := 0
+	for i, phase := range remediationPhases {
+		planned := RemediationPhase{
+			Phase:       i + 1,
+			Name:        phase.name,
+			Description: phase.description,
+			Clauses:     phase.clauses,
+			Actions:     []RemediationAction{},
+		}
+		var groups []ClauseGaps
+		for _, group := range analysis.Clauses {
+			if containsString(topLevelClause(group.Clause), phase.clauses...) {
+				groups = append(groups, group)
+			}
+		}
+		sort.SliceStable(groups, func(a, b int) bool {
+			return priorityRank(groups[a].Priority) > priorityRank(groups[b].Priority)
+		})
+		for _, group := range groups {
+			sequence++
+			action := RemediationAction{
+				Sequence:      sequence,
+				Clause:        group.Clause,
+				Action:        gapClauseAction(index, group.Clause),
+				Owner:         GapAnalysisOwner,
+				SuggestedRole: phase.role,
+				Priority:      group.Priority,
+				EffortDays:    group.EffortDays,
+			}
+			for _, gap := range group.Gaps {
+				action.Resolves = append(action.Resolves, gap.ID)
+			}
+			if clause, ok := LookupClause(group.Clause); ok {
+				for _, sub := range append([]Clause{clause}, SubClauses(group.Clause)...) {
+					action.Deliverables = append(action.Deliverables, sub.RequiredRecords...)
+				}
+			}
+			planned.Actions = append(planned.Actions, action)
+			planned.EffortDays += action.EffortDays
+		}
+		if len(planned.Actions) > 0 {
+			analysis.Plan = append(analysis.Plan, planned)
+		}
+	}
+	for i := range analysis.Plan {
+		analysis.Plan[i].Phase = i + 1
+	}
+
+	return analysis
+}
+
+// gapPriority ranks a validation issue. Errors in clauses 4 and 5 are critical because
+// the rest of the QMS builds on them.
+func gapPriority(issue ValidationError) Priority {
+	switch issue.Severity {
+	case SeverityError:
+		if clause := topLevelClause(issue.Clause); clause == "4" || clause == "5" {
+			return PriorityCritical
+		}
+		return PriorityHigh
+	case SeverityWarning:
+		return PriorityMedium
+	default:
+		return PriorityLow
+	}
+}
+
+// priorityRank orders priorities from low to critical
+func priorityRank(priority Priority) int {
+	switch priority {
+	case PriorityCritical:
+		return 3
+	case PriorityHigh:
+		return 2
+	case PriorityMedium:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// gapClauseTitle returns the title of a clause of the quality manual
+func gapClauseTitle(index map[string]int, clause string) string {
+	if found, ok := LookupClause(clause); ok {
+		return found.Title
+	}
+	if i, ok := index[clause]; ok {
+		return manualSections[i].title
+	}
+	return ""
+}
+
+// gapClauseAction states what to do to meet a clause
+func gapClauseAction(index map[string]int, clause string) string {
+	if found, ok := LookupClause(clause); ok && found.Description != "" {
+		return found.Description
+	}
+	if i, ok := index[clause]; ok && manualSections[i].todo != "" {
+		return manualSections[i].todo
+	}
+	return fmt.Sprintf("Meet the requirements of clause %s", clause)
+}
+
+// clauseBefore orders clauses as the quality manual does; clauses it does not
+// address come last
+func clauseBefore(index map[string]int, a, b string) bool {
+	i, iok := index[a]
+	j, jok := index[b]
+	switch {
+	case iok && jok:
+		return i < j
+	case iok != jok:
+		return iok
+	default:
+		return a < b
+	}
+}
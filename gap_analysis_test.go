@@ -0,0 +1,81 @@
+package iso9001
+
+import "testing"
+
+func TestAnalyzeGaps(t *testing.T) {
+	org := &Organization{ID: "ORG-001", Name: "Acme"}
+	result := ValidateOrganization(org)
+	issues := len(result.Errors) + len(result.Warnings) + len(result.Infos)
+
+	analysis := AnalyzeGaps(org)
+	if analysis.ComplianceScore != GetComplianceScore(org) {
+		t.Errorf("Expected the compliance score %.1f, got %.1f", GetComplianceScore(org), analysis.ComplianceScore)
+	}
+
+	gaps := 0
+	var effort float64
+	seen := make(map[string]bool)
+	for _, clause := range analysis.Clauses {
+		gaps += len(clause.Gaps)
+		effort += clause.EffortDays
+		if seen[clause.Clause] {
+			t.Errorf("Clause %s listed twice", clause.Clause)
+		}
+		seen[clause.Clause] = true
+	}
+	if gaps != issues || effort != analysis.TotalEffortDays {
+		t.Errorf("Expected %d gaps taking %.1f days, got %d taking %.1f", issues, analysis.TotalEffortDays, gaps, effort)
+	}
+
+	if len(analysis.Plan) == 0 || analysis.Plan[0].Name != remediationPhases[0].name {
+		t.Fatalf("Expected the plan to start with the foundation, got %+v", analysis.Plan)
+	}
+	sequence := 0
+	planned := 0
+	for i, phase := range analysis.Plan {
+		if phase.Phase != i+1 {
+			t.Errorf("Expected phase %d, got %d", i+1, phase.Phase)
+		}
+		for j, action := range phase.Actions {
+			sequence++
+			planned += len(action.Resolves)
+			if action.Sequence != sequence || action.Owner != GapAnalysisOwner || action.Action == "" {
+				t.Errorf("Unexpected action %+v", action)
+			}
+			if j > 0 && priorityRank(action.Priority) > priorityRank(phase.Actions[j-1].Priority) {
+				t.Errorf("Action %d of phase %s is more urgent than the one before it", action.Sequence, phase.Name)
+			}
+		}
+	}
+	if planned != issues {
+		t.Errorf("Expected the plan to resolve all %d gaps, got %d", issues, planned)
+	}
+
+	if first := analysis.Plan[0].Actions[0]; first.Priority != PriorityCritical || topLevelClause(first.Clause) != "4" && topLevelClause(first.Clause) != "5" {
+		t.Errorf("Expected a critical foundation action first, got %+v", first)
+	}
+}
+
+func TestAnalyzeLocalizedGaps(t *testing.T) {
+	org := &Organization{ID: "ORG-001", Name: "Acme"}
+	english := AnalyzeGaps(org)
+	german := AnalyzeLocalizedGaps(org, LocaleGerman)
+
+	if german.ComplianceScore != english.ComplianceScore || len(german.Clauses) != len(english.Clauses) {
+		t.Fatalf("Expected the same analysis in German, got %+v", german)
+	}
+	if german.OverallCompliance != Translate(LocaleGerman, english.OverallCompliance) {
+		t.Errorf("Expected a German rating, got %q", german.OverallCompliance)
+	}
+	found := false
+	for _, group := range german.Clauses {
+		for _, gap := range group.Gaps {
+			if gap.Description == "Die Qualitätspolitik muss festgelegt und aufrechterhalten werden" {
+				found = true
+			}
+		}
+	}
+	if !found {
+		t.Errorf("Expected German gap descriptions, got %+v", german.Clauses)
+	}
+}
@@ -0,0 +1,400 @@
+// Package generator builds synthetic ISO 9001 organizations for demos, load tests and
+// benchmark fixtures. Output is deterministic for a given seed, and the share of
+// requirements met can be tuned to produce partially compliant organizations.
+package generator
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/example/iso9001"
+)
+
+// Options configures a generated organization
+type Options struct {
+	ID   string
+	Name string
+	// Seed makes the output reproducible: the same seed and Now yield the same data
+	Seed int64
+	// Now anchors generated dates; defaults to the current time
+	Now time.Time
+
+	Processes  int
+	Risks      int
+	Documents  int
+	Objectives int
+	Audits     int
+
+	// Compliance is the probability, from 0 to 1, that each individual requirement
+	// is met. 1 produces a fully populated organization, 0 an empty shell.
+	Compliance float64
+}
+
+// DefaultOptions returns options for a mid-sized, mostly compliant organization
+func DefaultOptions() Options {
+	return Options{
+		ID:         "ORG-GEN-001",
+		Name:       "Generated Manufacturing Ltd",
+		Seed:       1,
+		Processes:  12,
+		Risks:      25,
+		Documents:  20,
+		Objectives: 6,
+		Audits:     4,
+		Compliance: 0.8,
+	}
+}
+
+// Vocabulary used to give generated entities plausible names
+var (
+	processNames = []string{
+		"Sales", "Product Design", "Purchasing", "Production Planning", "Manufacturing",
+		"Inspection", "Warehousing", "Delivery", "Customer Service", "Maintenance",
+		"Calibration", "Training", "Supplier Evaluation", "Complaint Handling", "Internal Audit",
+	}
+	riskCauses = []string{
+		"supplier delay", "equipment failure", "staff turnover", "specification change",
+		"inadequate training", "raw material variation", "IT outage", "regulatory change",
+	}
+	riskEffects = []string{
+		"late delivery", "nonconforming product", "customer complaint", "rework cost",
+		"audit nonconformity", "lost order",
+	}
+	objectiveMetrics = []struct{ name, metric, value, unit string }{
+		{"Improve on-time delivery", "on_time_delivery", "95", "percent"},
+		{"Reduce customer complaints", "complaints_per_month", "5", "count"},
+		{"Reduce scrap rate", "scrap_rate", "2", "percent"},
+		{"Increase first-pass yield", "first_pass_yield", "98", "percent"},
+		{"Complete planned training", "training_completion", "100", "percent"},
+		{"Improve supplier quality", "supplier_ppm", "500", "ppm"},
+	}
+	documentKinds = []struct {
+		title    string
+		docType  iso9001.DocumentType
+		category iso9001.DocumentCategory
+		clause   string
+	}{
+		{"Quality Manual", iso9001.DocumentTypeManual, iso9001.CategoryQualityManagement, "4.3"},
+		{"Control of Documented Information", iso9001.DocumentTypeProcedure, iso9001.CategoryQualityManagement, "7.5"},
+		{"Risk Assessment", iso9001.DocumentTypeProcedure, iso9001.CategoryRiskManagement, "6.1"},
+		{"Internal Audit Procedure", iso9001.DocumentTypeProcedure, iso9001.CategoryAudit, "9.2"},
+		{"Training Record", iso9001.DocumentTypeRecord, iso9001.CategoryTraining, "7.2"},
+		{"Supplier Evaluation Form", iso9001.DocumentTypeForm, iso9001.CategorySupplier, "8.4"},
+		{"Calibration Work Instruction", iso9001.DocumentTypeWorkInstruction, iso9001.CategoryCalibration, "7.1.5"},
+		{"Nonconformity Report", iso9001.DocumentTypeRecord, iso9001.CategoryNonconformance, "10.2"},
+		{"Management Review Minutes", iso9001.DocumentTypeRecord, iso9001.CategoryManagementReview, "9.3"},
+	}
+	levels = []iso9001.RiskLevel{
+		iso9001.RiskLevelVeryLow, iso9001.RiskLevelLow, iso9001.RiskLevelMedium,
+		iso9001.RiskLevelHigh, iso9001.RiskLevelVeryHigh,
+	}
+	severities = []iso9001.FindingSeverity{
+		iso9001.SeverityMajor, iso9001.SeverityMinor, iso9001.SeverityMinor, iso9001.SeverityObservation,
+	}
+)
+
+// generator holds the random source of one run
+type generator struct {
+	opts Options
+	rand *rand.Rand
+	now  time.Time
+}
+
+func newGenerator(opts Options) *generator {
+	if opts.ID == "" {
+		opts.ID = DefaultOptions().ID
+	}
+	if opts.Name == "" {
+		opts.Name = DefaultOptions().Name
+	}
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+	return &generator{opts: opts, rand: rand.New(rand.NewSource(opts.Seed)), now: opts.Now}
+}
+
+// meets reports whether a requirement is met, with probability opts.Compliance
+func (g *generator) meets() bool {
+	return g.rand.Float64() < g.opts.Compliance
+}
+
+func (g *generator) pick(items []string) string {
+	return items[g.rand.Intn(len(items))]
+}
+
+// Organization generates an organization with the configured number of processes,
+// risks and objectives
+func Organization(opts Options) *iso9001.Organization {
+	return newGenerator(opts).organization()
+}
+
+// Tenant generates an organization together with populated documentation, risk,
+// objective and audit managers
+func Tenant(opts Options) (*iso9001.Tenant, error) {
+	g := newGenerator(opts)
+	tenant := iso9001.NewTenant(g.opts.ID)
+	org := g.organization()
+	org.SchemaVersion = tenant.Organization.SchemaVersion
+	tenant.Organization = org
+
+	for _, risk := range org.QMS.Risks {
+		risk := risk
+		if err := tenant.Risks.IdentifyRisk(&risk); err != nil {
+			return nil, err
+		}
+		if err := tenant.Risks.AssessRisk(risk.ID, risk.Likelihood, risk.Impact); err != nil {
+			return nil, err
+		}
+		if len(risk.Mitigation) > 0 {
+			if err := tenant.Risks.MitigateRisk(risk.ID, risk.Mitigation); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, objective := range org.QMS.Objectives {
+		objective := objective
+		if !objective.Measurable || len(objective.Targets) == 0 || objective.Responsible == "" {
+			continue // only valid objectives can be tracked by the manager
+		}
+		if err := tenant.Objectives.CreateObjective(&objective); err != nil {
+			return nil, err
+		}
+	}
+	if err := g.documents(tenant.Documents); err != nil {
+		return nil, err
+	}
+	if err := g.audits(tenant.Audits, org); err != nil {
+		return nil, err
+	}
+	return tenant, nil
+}
+
+func (g *generator) organization() *iso9001.Organization {
+	org := &iso9001.Organization{
+		ID:       g.opts.ID,
+		Name:     g.opts.Name,
+		Created:  g.now,
+		Modified: g.now,
+		QMS:      &iso9001.QualityManagementSystem{ID: g.opts.ID + "-QMS"},
+	}
+
+	if g.meets() {
+		org.Context = &iso9001.OrganizationalContext{
+			ExternalIssues: []iso9001.Issue{{ID: "EXT-001", Description: "Price pressure from competitors", Type: iso9001.IssueTypeExternal, Impact: iso9001.ImpactHigh, Created: g.now}},
+			InternalIssues: []iso9001.Issue{{ID: "INT-001", Description: "Ageing production equipment", Type: iso9001.IssueTypeInternal, Impact: iso9001.ImpactMedium, Created: g.now}},
+		}
+		if g.meets() {
+			org.Context.InterestedParties = []iso9001.InterestedParty{
+				{ID: "PARTY-001", Name: "Customers", Type: "customer", Requirements: []string{"Conforming products", "On-time delivery"}},
+				{ID: "PARTY-002", Name: "Suppliers", Type: "supplier", Requirements: []string{"Clear purchase specifications"}},
+			}
+		}
+	}
+
+	if g.meets() {
+		org.Leadership = &iso9001.Leadership{
+			TopManagement: []iso9001.Person{{ID: "PER-001", Name: "Managing Director", Role: "Chief Executive Officer"}},
+			Commitment:    []iso9001.LeadershipCommitment{iso9001.CommitmentQMSEffectiveness, iso9001.CommitmentQualityPolicy, iso9001.CommitmentCustomerFocus},
+		}
+		if g.meets() {
+			org.Leadership.QualityPolicy = &iso9001.QualityPolicy{
+				ID:           "QP-001",
+				Statement:    fmt.Sprintf("%s provides products that consistently meet customer and applicable statutory requirements", g.opts.Name),
+				Objectives:   "Quality objectives are set and reviewed annually",
+				Commitment:   "We are committed to satisfying applicable requirements",
+				Improvement:  "We continually improve the quality management system",
+				Communicated: g.meets(),
+				Available:    g.meets(),
+			}
+		}
+		if g.meets() {
+			org.Leadership.Roles = []iso9001.OrganizationalRole{{
+				ID:               "ROLE-001",
+				Name:             "Quality Manager",
+				Responsibilities: []string{"Maintain the QMS", "Report on QMS performance"},
+				Authorities:      []string{"Approve quality documents"},
+				AssignedTo:       "PER-002",
+			}}
+		}
+	}
+
+	if g.meets() {
+		org.QMS.Scope = &iso9001.QMSScope{
+			Description: fmt.Sprintf("Design, manufacture and delivery of products by %s", g.opts.Name),
+			Products:    []string{"Standard products", "Custom products"},
+		}
+	}
+
+	for i := 0; i < g.opts.Processes; i++ {
+		org.QMS.Processes = append(org.QMS.Processes, g.process(i))
+	}
+	for i := 0; i < g.opts.Risks; i++ {
+		org.QMS.Risks = append(org.QMS.Risks, g.risk(i))
+	}
+	for i := 0; i < g.opts.Objectives; i++ {
+		org.QMS.Objectives = append(org.QMS.Objectives, g.objective(i))
+	}
+	return org
+}
+
+func (g *generator) process(i int) iso9001.Process {
+	name := processNames[i%len(processNames)]
+	if i >= len(processNames) {
+		name = fmt.Sprintf("%s %d", name, i/len(processNames)+1)
+	}
+	process := iso9001.Process{
+		ID:          fmt.Sprintf("PROC-%03d", i+1),
+		Name:        name,
+		Description: fmt.Sprintf("%s process", name),
+		Status:      iso9001.ProcessStatusImplemented,
+		Created:     g.now,
+	}
+	if g.meets() {
+		process.Inputs = []iso9001.ProcessInput{{Name: "Requirements", Type: "information", Source: "upstream process"}}
+	}
+	if g.meets() {
+		process.Outputs = []iso9001.ProcessOutput{{Name: name + " results", Type: "information", Destination: "downstream process"}}
+	}
+	if g.meets() {
+		process.Responsibilities = []string{name + " Lead"}
+	}
+	if g.meets() {
+		process.Criteria = []iso9001.ProcessCriteria{{Name: "Performance", Metric: "kpi", Target: "100%"}}
+	}
+	return process
+}
+
+func (g *generator) risk(i int) iso9001.Risk {
+	cause := g.pick(riskCauses)
+	effect := g.pick(riskEffects)
+	risk := iso9001.Risk{
+		ID:          fmt.Sprintf("RISK-%03d", i+1),
+		Description: fmt.Sprintf("%s leads to %s", cause, effect),
+		Causes:      []string{cause},
+		Effects:     []string{effect},
+		Likelihood:  levels[g.rand.Intn(len(levels))],
+		Impact:      levels[g.rand.Intn(len(levels))],
+		Status:      iso9001.RiskStatusIdentified,
+		Created:     g.now,
+	}
+	if g.meets() {
+		risk.Mitigation = []iso9001.Action{{
+			ID:          fmt.Sprintf("ACT-%03d", i+1),
+			Description: "Mitigate " + cause,
+			Type:        iso9001.ActionTypePreventive,
+			Responsible: "Quality Manager",
+			Timeline:    g.now.AddDate(0, 0, 30+g.rand.Intn(90)),
+			Status:      iso9001.ActionStatusPlanned,
+			Created:     g.now,
+		}}
+	}
+	return risk
+}
+
+func (g *generator) objective(i int) iso9001.QualityObjective {
+	metric := objectiveMetrics[i%len(objectiveMetrics)]
+	objective := iso9001.QualityObjective{
+		ID:          fmt.Sprintf("OBJ-%03d", i+1),
+		Name:        metric.name,
+		Description: fmt.Sprintf("%s to %s %s", metric.name, metric.value, metric.unit),
+		Measurable:  g.meets(),
+		Timeline:    iso9001.ObjectiveTimeline{TargetDate: g.now.AddDate(0, 3+g.rand.Intn(12), 0)},
+		Created:     g.now,
+	}
+	if g.meets() {
+		objective.Targets = []iso9001.ObjectiveTarget{{Metric: metric.metric, Value: metric.value, Unit: metric.unit}}
+	}
+	if g.meets() {
+		objective.Responsible = "Quality Manager"
+	}
+	return objective
+}
+
+// documents adds documents and moves the compliant share of them through approval
+// to publication
+func (g *generator) documents(dm *iso9001.DocumentationManager) error {
+	for i := 0; i < g.opts.Documents; i++ {
+		kind := documentKinds[i%len(documentKinds)]
+		doc := &iso9001.DocumentedInformation{
+			ID:       fmt.Sprintf("DOC-%03d", i+1),
+			Title:    kind.title,
+			Type:     kind.docType,
+			Category: kind.category,
+			Content:  fmt.Sprintf("# %s\n\nThis document describes how %s meets clause %s.\n", kind.title, g.opts.Name, kind.clause),
+			Metadata: iso9001.DocumentMetadata{
+				Author:          "Quality Manager",
+				Owner:           "Quality Manager",
+				Keywords:        []string{string(kind.category)},
+				RelatedClauses:  []string{kind.clause},
+				RetentionPeriod: 3 * 365 * 24 * time.Hour,
+				ReviewFrequency: 365 * 24 * time.Hour,
+				Format:          "electronic",
+				Language:        "en",
+			},
+		}
+		if i >= len(documentKinds) {
+			doc.Title = fmt.Sprintf("%s %d", kind.title, i/len(documentKinds)+1)
+		}
+		if err := dm.AddDocument(doc); err != nil {
+			return err
+		}
+		if !g.meets() {
+			continue // left as a draft
+		}
+		if err := dm.ApproveDocument(doc.ID, iso9001.Approval{ApproverID: "PER-002", ApproverName: "Quality Manager", Timestamp: g.now}); err != nil {
+			return err
+		}
+		if err := dm.SetDocumentStatus(doc.ID, iso9001.DocumentStatusPublished); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// audits plans audits spread over the past and coming year; past audits are
+// completed with findings, fewer of them the more compliant the organization is
+func (g *generator) audits(am *iso9001.AuditManager, org *iso9001.Organization) error {
+	for i := 0; i < g.opts.Audits; i++ {
+		start := g.now.AddDate(0, 3*i-3*(g.opts.Audits/2), 0)
+		audit := &iso9001.Audit{
+			ID:               fmt.Sprintf("AUD-%03d", i+1),
+			Title:            fmt.Sprintf("Internal audit %d", i+1),
+			Type:             iso9001.AuditTypeInternal,
+			Scope:            iso9001.AuditScope{Description: "Quality management system"},
+			PlannedStartDate: start,
+			PlannedEndDate:   start.AddDate(0, 0, 2),
+		}
+		if err := am.CreateAudit(audit); err != nil {
+			return err
+		}
+		if !start.Before(g.now) {
+			continue
+		}
+
+		if err := am.StartAudit(audit.ID, start); err != nil {
+			return err
+		}
+		for j, process := range org.QMS.Processes {
+			if g.meets() {
+				continue
+			}
+			finding := iso9001.AuditFinding{
+				ID:          fmt.Sprintf("%s-F%02d", audit.ID, j+1),
+				Clause:      "4.4",
+				Description: fmt.Sprintf("%s process is not fully defined", process.Name),
+				Severity:    severities[g.rand.Intn(len(severities))],
+				Category:    iso9001.CategoryAuditProcess,
+				Process:     process.ID,
+				Status:      iso9001.FindingStatusOpen,
+			}
+			if err := am.AddFinding(audit.ID, finding); err != nil {
+				return err
+			}
+		}
+		if err := am.CompleteAudit(audit.ID, start.AddDate(0, 0, 2), nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package generator
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/example/iso9001"
+)
+
+func TestOrganizationIsDeterministic(t *testing.T) {
+	opts := DefaultOptions()
+	opts.Now = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	first, _ := json.Marshal(Organization(opts))
+	second, _ := json.Marshal(Organization(opts))
+	if string(first) != string(second) {
+		t.Error("Expected the same organization for the same seed")
+	}
+
+	opts.Seed = 2
+	other, _ := json.Marshal(Organization(opts))
+	if string(first) == string(other) {
+		t.Error("Expected a different organization for a different seed")
+	}
+}
+
+func TestComplianceControlsScore(t *testing.T) {
+	opts := DefaultOptions()
+	opts.Compliance = 1
+	full := iso9001.GetComplianceScore(Organization(opts))
+	opts.Compliance = 0.3
+	partial := iso9001.GetComplianceScore(Organization(opts))
+
+	if full <= partial {
+		t.Errorf("Expected full compliance to score above partial compliance, got %.1f and %.1f", full, partial)
+	}
+	if result := iso9001.ValidateOrganization(Organization(opts)); result.Valid {
+		t.Error("Expected a partially compliant organization to fail validation")
+	}
+}
+
+func TestTenant(t *testing.T) {
+	opts := DefaultOptions()
+	opts.Processes, opts.Risks, opts.Documents, opts.Objectives, opts.Audits = 30, 40, 25, 8, 6
+	opts.Compliance = 1
+
+	tenant, err := Tenant(opts)
+	if err != nil {
+		t.Fatalf("Failed to generate tenant: %v", err)
+	}
+	if got := len(tenant.Organization.QMS.Processes); got != 30 {
+		t.Errorf("Expected 30 processes, got %d", got)
+	}
+	if got := len(tenant.Risks.Risks); got != 40 {
+		t.Errorf("Expected 40 risks, got %d", got)
+	}
+	if got := len(tenant.Documents.Documents); got != 25 {
+		t.Errorf("Expected 25 documents, got %d", got)
+	}
+	for id, doc := range tenant.Documents.Documents {
+		if doc.Status != iso9001.DocumentStatusPublished {
+			t.Errorf("Expected document %s to be published, got %s", id, doc.Status)
+		}
+	}
+	if got := len(tenant.Objectives.Objectives); got != 8 {
+		t.Errorf("Expected 8 objectives, got %d", got)
+	}
+	if got := len(tenant.Audits.Audits); got != 6 {
+		t.Errorf("Expected 6 audits, got %d", got)
+	}
+}
@@ -0,0 +1,318 @@
+package iso9001
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrInvalidRevision is returned for a revision that is empty or looks like an option
+var ErrInvalidRevision = errors.New("invalid revision")
+
+// DocumentRepository persists documents outside the manager. The manager calls it
+// after every change, so the repository always holds the current state of each
+// document.
+type DocumentRepository interface {
+	// SaveDocument stores the current state of a document
+	SaveDocument(doc *DocumentedInformation, message string) error
+	// RecordApproval records an approval of the document's current version
+	RecordApproval(doc *DocumentedInformation, approval Approval) error
+}
+
+// DocumentCommit is a change to a document in a Git repository
+type DocumentCommit struct {
+	Hash    string    `json:"hash" yaml:"hash"`
+	Author  string    `json:"author" yaml:"author"`
+	Date    time.Time `json:"date" yaml:"date"`
+	Message string    `json:"message" yaml:"message"`
+}
+
+// ApprovalTag is an approval recorded as a Git tag
+type ApprovalTag struct {
+	Name    string `json:"name" yaml:"name"`
+	Commit  string `json:"commit" yaml:"commit"`
+	Message string `json:"message" yaml:"message"`
+}
+
+// GitDocumentRepository keeps each document in a Git working tree: the content in
+// <id>.md and the remaining fields in <id>.json. Every change is a commit authored by
+// the person who made it, and every approval is an annotated tag, signed when
+// SignTags is set, on the commit holding the approved version.
+type GitDocumentRepository struct {
+	Dir string
+	// SignTags creates approval tags with "git tag -s", which needs a signing key
+	// configured for Git
+	SignTags bool
+	// Email is used for commit authors and the committer; defaults to qms@localhost
+	Email string
+	// Committer is the name recorded as committer; defaults to "ISO 9001 QMS"
+	Committer string
+}
+
+// NewGitDocumentRepository opens the Git repository in dir, initializing it when dir
+// is not yet a repository
+func NewGitDocumentRepository(dir string) (*GitDocumentRepository, error) {
+	if _, err := exec.LookPath("git"); err != nil {
+		return nil, fmt.Errorf("git document repository requires git: %w", err)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create document repository: %w", err)
+	}
+
+	repo := &GitDocumentRepository{Dir: dir}
+	if _, err := os.Stat(filepath.Join(dir, ".git")); errors.Is(err, os.ErrNotExist) {
+		if _, err := repo.git("init", "--quiet"); err != nil {
+			return nil, err
+		}
+	}
+	return repo, nil
+}
+
+// NewGitDocumentationManager creates a documentation manager backed by the Git
+// repository in dir, loading the documents it already holds
+func NewGitDocumentationManager(dir string) (*DocumentationManager, error) {
+	repo, err := NewGitDocumentRepository(dir)
+	if err != nil {
+		return nil, err
+	}
+	docs, err := repo.LoadDocuments()
+	if err != nil {
+		return nil, err
+	}
+
+	dm := NewDocumentationManager()
+	for _, doc := range docs {
+		dm.Documents[doc.ID] = doc
+		dm.updateIndex(doc)
+	}
+	dm.Repository = repo
+	return dm, nil
+}
+
+// SaveDocument writes the document's files and commits them if they changed
+func (r *GitDocumentRepository) SaveDocument(doc *DocumentedInformation, message string) error {
+	contentPath, metadataPath, err := r.paths(doc.ID)
+	if err != nil {
+		return err
+	}
+
+	stored := *doc
+	stored.Content = ""
+	metadata, err := json.MarshalIndent(&stored, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(r.Dir, contentPath), []byte(doc.Content), 0o644); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(r.Dir, metadataPath), append(metadata, '\n'), 0o644); err != nil {
+		return err
+	}
+
+	if _, err := r.git("add", "--", contentPath, metadataPath); err != nil {
+		return err
+	}
+	if _, err := r.git("diff", "--cached", "--quiet"); err == nil {
+		return nil // nothing changed
+	}
+
+	author := doc.Metadata.Author
+	if len(doc.Versions) > 0 && doc.Versions[len(doc.Versions)-1].CreatedBy != "" {
+		author = doc.Versions[len(doc.Versions)-1].CreatedBy
+	}
+	if author == "" {
+		author = r.committer()
+	}
+	_, err = r.git("commit", "--quiet", "-m", message, "--author", fmt.Sprintf("%s <%s>", author, r.email()))
+	return err
+}
+
+// RecordApproval tags the commit holding the document's current version with an
+// annotated tag named approval/<document>/v<version>/<approver>
+func (r *GitDocumentRepository) RecordApproval(doc *DocumentedInformation, approval Approval) error {
+	if approval.Version == "" {
+		approval.Version = doc.currentVersion()
+	}
+	name := strings.Join([]string{
+		"approval",
+		refComponent(doc.ID),
+		"v" + refComponent(approval.Version),
+		refComponent(approval.ApproverID),
+	}, "/")
+
+	message := fmt.Sprintf("Approved %s version %s by %s", doc.ID, approval.Version, approval.ApproverID)
+	if approval.ApproverName != "" {
+		message = fmt.Sprintf("%s (%s)", message, approval.ApproverName)
+	}
+	if approval.Role != "" {
+		message += " as " + approval.Role
+	}
+	if approval.Comments != "" {
+		message += "\n\n" + approval.Comments
+	}
+
+	flag := "-a"
+	if r.SignTags {
+		flag = "-s"
+	}
+	_, err := r.git("tag", flag, name, "-m", message)
+	return err
+}
+
+// LoadDocuments reads every document in the working tree
+func (r *GitDocumentRepository) LoadDocuments() ([]*DocumentedInformation, error) {
+	entries, err := os.ReadDir(r.Dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var docs []*DocumentedInformation
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(r.Dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		var doc DocumentedInformation
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("invalid document %s: %w", entry.Name(), err)
+		}
+		content, err := os.ReadFile(filepath.Join(r.Dir, strings.TrimSuffix(entry.Name(), ".json")+".md"))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+		doc.Content = string(content)
+		docs = append(docs, &doc)
+	}
+
+	sort.Slice(docs, func(i, j int) bool { return docs[i].ID < docs[j].ID })
+	return docs, nil
+}
+
+// History returns the commits that changed a document, newest first
+func (r *GitDocumentRepository) History(docID string) ([]DocumentCommit, error) {
+	contentPath, metadataPath, err := r.paths(docID)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := r.git("log", "--format=%H%x1f%an%x1f%aI%x1f%s%x1e", "--", contentPath, metadataPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var commits []DocumentCommit
+	for _, record := range strings.Split(out, "\x1e") {
+		fields := strings.Split(strings.TrimSpace(record), "\x1f")
+		if len(fields) != 4 {
+			continue
+		}
+		date, _ := time.Parse(time.RFC3339, fields[2])
+		commits = append(commits, DocumentCommit{Hash: fields[0], Author: fields[1], Date: date, Message: fields[3]})
+	}
+	return commits, nil
+}
+
+// Diff returns the unified diff of a document's content between two revisions, such
+// as commit hashes or approval tags. Revisions starting with "-" are rejected, so a
+// caller cannot pass options to git, such as --output.
+func (r *GitDocumentRepository) Diff(docID, from, to string) (string, error) {
+	contentPath, _, err := r.paths(docID)
+	if err != nil {
+		return "", err
+	}
+	for _, revision := range []string{from, to} {
+		if revision == "" || strings.HasPrefix(revision, "-") {
+			return "", fmt.Errorf("%w: %q", ErrInvalidRevision, revision)
+		}
+	}
+	return r.git("diff", "--end-of-options", from, to, "--", contentPath)
+}
+
+// Approvals lists the approval tags of a document
+func (r *GitDocumentRepository) Approvals(docID string) ([]ApprovalTag, error) {
+	out, err := r.git("for-each-ref", "--format=%(refname:short)%1f%(*objectname)%1f%(contents:subject)",
+		"refs/tags/approval/"+refComponent(docID)+"/")
+	if err != nil {
+		return nil, err
+	}
+
+	var tags []ApprovalTag
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		fields := strings.Split(line, "\x1f")
+		if len(fields) != 3 {
+			continue
+		}
+		tags = append(tags, ApprovalTag{Name: fields[0], Commit: fields[1], Message: fields[2]})
+	}
+	return tags, nil
+}
+
+func (r *GitDocumentRepository) paths(docID string) (content, metadata string, err error) {
+	if docID == "" || docID == "." || docID == ".." || strings.ContainsAny(docID, `/\`) {
+		return "", "", fmt.Errorf("invalid document ID %q", docID)
+	}
+	return docID + ".md", docID + ".json", nil
+}
+
+func (r *GitDocumentRepository) email() string {
+	if r.Email == "" {
+		return "qms@localhost"
+	}
+	return r.Email
+}
+
+func (r *GitDocumentRepository) committer() string {
+	if r.Committer == "" {
+		return "ISO 9001 QMS"
+	}
+	return r.Committer
+}
+
+// git runs a git command in the repository and returns its standard output
+func (r *GitDocumentRepository) git(args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = r.Dir
+	cmd.Env = append(os.Environ(),
+		"GIT_COMMITTER_NAME="+r.committer(),
+		"GIT_COMMITTER_EMAIL="+r.email(),
+	)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("git %s: %s", args[0], msg)
+		}
+		return "", fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return stdout.String(), nil
+}
+
+// refComponent makes a value safe for use as one component of a Git ref name
+func refComponent(value string) string {
+	var b strings.Builder
+	for _, r := range value {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			b.WriteRune(r)
+		default:
+			b.WriteRune('-')
+		}
+	}
+	component := strings.Trim(strings.ReplaceAll(b.String(), "..", "-"), ".")
+	if component == "" {
+		return "-"
+	}
+	return component
+}
@@ -0,0 +1,85 @@
+package iso9001
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGitDocumentRepository(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+
+	dm, err := NewGitDocumentationManager(dir)
+	if err != nil {
+		t.Fatalf("Failed to open repository: %v", err)
+	}
+	repo := dm.Repository.(*GitDocumentRepository)
+
+	doc := &DocumentedInformation{ID: "DOC-001", Title: "Procedure", Content: "First draft\n"}
+	doc.Metadata.Author = "Ada"
+	if err := dm.AddDocument(doc); err != nil {
+		t.Fatalf("Failed to add document: %v", err)
+	}
+	update := &DocumentedInformation{Title: "Procedure", Content: "Second draft\n"}
+	update.Metadata.Author = "Grace"
+	if err := dm.UpdateDocument("DOC-001", update, VersionChange{Summary: "Second draft"}); err != nil {
+		t.Fatalf("Failed to update document: %v", err)
+	}
+	if err := dm.ApproveDocument("DOC-001", Approval{ApproverID: "QM", ApproverName: "Quality Manager"}); err != nil {
+		t.Fatalf("Failed to approve document: %v", err)
+	}
+
+	history, err := repo.History("DOC-001")
+	if err != nil {
+		t.Fatalf("Failed to read history: %v", err)
+	}
+	if len(history) != 3 {
+		t.Fatalf("Expected 3 commits, got %d: %+v", len(history), history)
+	}
+	if history[2].Author != "Ada" || history[1].Author != "Grace" {
+		t.Errorf("Expected commits authored by Ada then Grace, got %s and %s", history[2].Author, history[1].Author)
+	}
+
+	diff, err := repo.Diff("DOC-001", history[2].Hash, history[1].Hash)
+	if err != nil {
+		t.Fatalf("Failed to diff document: %v", err)
+	}
+	if !strings.Contains(diff, "-First draft") || !strings.Contains(diff, "+Second draft") {
+		t.Errorf("Expected content diff, got:\n%s", diff)
+	}
+	output := filepath.Join(t.TempDir(), "written")
+	for _, from := range []string{"--output=" + output, "-R", ""} {
+		if _, err := repo.Diff("DOC-001", from, history[1].Hash); !errors.Is(err, ErrInvalidRevision) {
+			t.Errorf("Expected revision %q to be rejected, got %v", from, err)
+		}
+	}
+	if _, err := os.Stat(output); !os.IsNotExist(err) {
+		t.Errorf("Expected no file written through an option, got %v", err)
+	}
+
+	approvals, err := repo.Approvals("DOC-001")
+	if err != nil {
+		t.Fatalf("Failed to list approvals: %v", err)
+	}
+	if len(approvals) != 1 || approvals[0].Name != "approval/DOC-001/v1.1/QM" || approvals[0].Commit != history[0].Hash {
+		t.Errorf("Expected approval tag on the latest commit, got %+v", approvals)
+	}
+
+	reopened, err := NewGitDocumentationManager(dir)
+	if err != nil {
+		t.Fatalf("Failed to reopen repository: %v", err)
+	}
+	loaded, err := reopened.GetDocument("DOC-001")
+	if err != nil {
+		t.Fatalf("Expected document to be loaded from the repository: %v", err)
+	}
+	if loaded.Content != "Second draft\n" || loaded.Status != DocumentStatusApproved {
+		t.Errorf("Expected approved second draft, got %s %q", loaded.Status, loaded.Content)
+	}
+}
@@ -2,6 +2,4 @@ module github.com/example/iso9001
 
 go 1.21
 
-require (
-	// No external dependencies required for core functionality
-)
+require gopkg.in/yaml.v3 v3.0.1
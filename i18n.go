@@ -0,0 +1,121 @@
+package iso9001
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Locale identifies the language of user-facing messages
+type Locale string
+
+const (
+	LocaleEnglish Locale = "en"
+	LocaleGerman  Locale = "de"
+	LocaleFrench  Locale = "fr"
+	LocaleSpanish Locale = "es"
+)
+
+// messageCatalog holds translations keyed by the English message, which for messages
+// with arguments is the format string
+var messageCatalog = struct {
+	sync.RWMutex
+	messages map[Locale]map[string]string
+}{messages: defaultMessages}
+
+// RegisterMessages adds or replaces translations for a locale, which may be a new one.
+// Keys are the English messages or format strings; translated format strings must
+// use the same verbs in the same order.
+func RegisterMessages(locale Locale, messages map[string]string) {
+	messageCatalog.Lock()
+	defer messageCatalog.Unlock()
+
+	catalog := messageCatalog.messages[locale]
+	if catalog == nil {
+		catalog = make(map[string]string, len(messages))
+		messageCatalog.messages[locale] = catalog
+	}
+	for key, translation := range messages {
+		catalog[key] = translation
+	}
+}
+
+// SupportedLocales returns the locales with a message catalog, English first
+func SupportedLocales() []Locale {
+	messageCatalog.RLock()
+	defer messageCatalog.RUnlock()
+
+	locales := []Locale{LocaleEnglish}
+	for locale := range messageCatalog.messages {
+		if locale != LocaleEnglish {
+			locales = append(locales, locale)
+		}
+	}
+	sort.Slice(locales[1:], func(i, j int) bool { return locales[i+1] < locales[j+1] })
+	return locales
+}
+
+// ParseLocale maps a language tag such as "de-DE" or "fr_CA.UTF-8" to a supported
+// locale, falling back to English
+func ParseLocale(tag string) Locale {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(tag, "-_."); i >= 0 {
+		tag = tag[:i]
+	}
+
+	messageCatalog.RLock()
+	defer messageCatalog.RUnlock()
+	if _, ok := messageCatalog.messages[Locale(tag)]; ok {
+		return Locale(tag)
+	}
+	return LocaleEnglish
+}
+
+// Translate returns a message in the given locale, formatting it with args. Messages
+// without a translation are returned in English.
+func Translate(locale Locale, message string, args ...interface{}) string {
+	format := message
+	if locale != "" && locale != LocaleEnglish {
+		messageCatalog.RLock()
+		if translated, ok := messageCatalog.messages[locale][message]; ok {
+			format = translated
+		}
+		messageCatalog.RUnlock()
+	}
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// Localize returns a copy of the validation error whose message and Error text are in
+// the given locale
+func (e ValidationError) Localize(locale Locale) ValidationError {
+	if e.format == "" {
+		e.format = e.Message
+	}
+	e.Message = Translate(locale, e.format, e.args...)
+	e.locale = locale
+	return e
+}
+
+// Localize returns a copy of the result with all messages in the given locale
+func (r *ValidationResult) Localize(locale Locale) *ValidationResult {
+	localized := &ValidationResult{
+		Valid:    r.Valid,
+		Errors:   make([]ValidationError, len(r.Errors)),
+		Warnings: make([]ValidationError, len(r.Warnings)),
+		Infos:    make([]ValidationError, len(r.Infos)),
+	}
+	for i, e := range r.Errors {
+		localized.Errors[i] = e.Localize(locale)
+	}
+	for i, e := range r.Warnings {
+		localized.Warnings[i] = e.Localize(locale)
+	}
+	for i, e := range r.Infos {
+		localized.Infos[i] = e.Localize(locale)
+	}
+	return localized
+}
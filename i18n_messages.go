@@ -0,0 +1,288 @@
+package iso9001
+
+// defaultMessages holds the built-in translations of validation and report messages
+var defaultMessages = map[Locale]map[string]string{
+	LocaleEnglish: {},
+
+	LocaleGerman: {
+		// Validation output
+		"[%s] Clause %s - %s: %s": "[%s] Abschnitt %s - %s: %s",
+		"error":                   "Fehler",
+		"warning":                 "Warnung",
+		"info":                    "Hinweis",
+
+		// Clause 4.1
+		"Organizational context must be defined": "Der Kontext der Organisation muss bestimmt werden",
+		"No external issues identified - consider reviewing legal, technological, competitive, market, cultural, social and economic environments": "Keine externen Themen ermittelt - rechtliches, technologisches, wettbewerbliches, marktbezogenes, kulturelles, soziales und wirtschaftliches Umfeld prüfen",
+		"No internal issues identified - consider reviewing values, culture, knowledge and performance":                                            "Keine internen Themen ermittelt - Werte, Kultur, Wissen und Leistung prüfen",
+		"Issue must have a description":              "Das Thema muss eine Beschreibung haben",
+		"Issue must have a type (external/internal)": "Das Thema muss einen Typ haben (extern/intern)",
+
+		// Clause 4.2
+		"Interested parties must be identified and their requirements determined": "Interessierte Parteien müssen ermittelt und ihre Anforderungen bestimmt werden",
+		"Interested party must have a name":                                       "Die interessierte Partei muss einen Namen haben",
+		"No requirements specified for interested party":                          "Für die interessierte Partei sind keine Anforderungen angegeben",
+		"No customers identified as interested parties":                           "Keine Kunden als interessierte Parteien ermittelt",
+		"No suppliers/external providers identified as interested parties":        "Keine Lieferanten/externen Anbieter als interessierte Parteien ermittelt",
+		"Consider identifying regulatory authorities as interested parties":       "Behörden als interessierte Parteien in Betracht ziehen",
+
+		// Clause 4.3
+		"QMS scope must be determined and documented":                                                      "Der Anwendungsbereich des QMS muss festgelegt und dokumentiert werden",
+		"Scope must include a description of products and services covered":                                "Der Anwendungsbereich muss die abgedeckten Produkte und Dienstleistungen beschreiben",
+		"Scope must specify the types of products and services covered":                                    "Der Anwendungsbereich muss die Arten der abgedeckten Produkte und Dienstleistungen angeben",
+		"Exclusion must specify which clause is not applicable":                                            "Der Ausschluss muss angeben, welcher Abschnitt nicht zutrifft",
+		"Exclusion must be justified and not affect organization's ability to meet requirements":           "Der Ausschluss muss begründet sein und darf die Fähigkeit der Organisation zur Erfüllung der Anforderungen nicht beeinträchtigen",
+		"Exclusion clause %q is not a valid ISO 9001 clause reference":                                     "Der ausgeschlossene Abschnitt %q ist kein gültiger ISO-9001-Abschnitt",
+		"Clause %s cannot be excluded - only requirements of clause 8 may be determined as not applicable": "Abschnitt %s kann nicht ausgeschlossen werden - nur Anforderungen aus Abschnitt 8 dürfen als nicht zutreffend bestimmt werden",
+		"Excluding clause %s is likely to affect the ability to provide conforming products and services":  "Der Ausschluss von Abschnitt %s beeinträchtigt voraussichtlich die Fähigkeit, konforme Produkte und Dienstleistungen bereitzustellen",
+
+		// Clause 4.4
+		"QMS processes must be established, implemented, maintained and continually improved": "QMS-Prozesse müssen aufgebaut, verwirklicht, aufrechterhalten und fortlaufend verbessert werden",
+		"Process must have a name":                                       "Der Prozess muss einen Namen haben",
+		"Process inputs should be defined":                               "Prozesseingaben sollten festgelegt werden",
+		"Process outputs should be defined":                              "Prozessergebnisse sollten festgelegt werden",
+		"Process responsibilities and authorities must be assigned":      "Verantwortlichkeiten und Befugnisse für den Prozess müssen zugewiesen werden",
+		"Process criteria and methods for monitoring must be determined": "Prozesskriterien und Überwachungsmethoden müssen bestimmt werden",
+		"Consider identifying risks and opportunities for this process":  "Risiken und Chancen für diesen Prozess ermitteln",
+
+		// Clause 5.1
+		"Top management must demonstrate leadership and commitment": "Die oberste Leitung muss Führung und Verpflichtung zeigen",
+		"Top management must be identified":                         "Die oberste Leitung muss benannt werden",
+		"Missing leadership commitment: %s":                         "Fehlende Verpflichtung der Leitung: %s",
+
+		// Clause 5.2
+		"Quality policy must be established and maintained":                          "Die Qualitätspolitik muss festgelegt und aufrechterhalten werden",
+		"Quality policy must include a statement of intent":                          "Die Qualitätspolitik muss eine Absichtserklärung enthalten",
+		"Quality policy must provide a framework for setting quality objectives":     "Die Qualitätspolitik muss einen Rahmen zum Festlegen von Qualitätszielen bieten",
+		"Quality policy must include commitment to satisfy applicable requirements":  "Die Qualitätspolitik muss die Verpflichtung zur Erfüllung zutreffender Anforderungen enthalten",
+		"Quality policy must include commitment to continual improvement":            "Die Qualitätspolitik muss die Verpflichtung zur fortlaufenden Verbesserung enthalten",
+		"Quality policy must be communicated and understood within the organization": "Die Qualitätspolitik muss innerhalb der Organisation vermittelt und verstanden werden",
+		"Quality policy must be available to relevant interested parties":            "Die Qualitätspolitik muss relevanten interessierten Parteien zur Verfügung stehen",
+
+		// Clause 5.3
+		"Organizational roles, responsibilities and authorities must be assigned and communicated": "Rollen, Verantwortlichkeiten und Befugnisse in der Organisation müssen zugewiesen und bekannt gemacht werden",
+		"Role must have a name":                   "Die Rolle muss einen Namen haben",
+		"Role must have defined responsibilities": "Die Rolle muss festgelegte Verantwortlichkeiten haben",
+		"Role must have defined authorities":      "Die Rolle muss festgelegte Befugnisse haben",
+		"Role must be assigned to a person":       "Die Rolle muss einer Person zugewiesen sein",
+
+		// Clause 6.1
+		"QMS must be defined to validate risks and opportunities":                 "Das QMS muss definiert sein, um Risiken und Chancen zu prüfen",
+		"No risks identified - risk-based thinking should be applied to planning": "Keine Risiken ermittelt - risikobasiertes Denken sollte in der Planung angewendet werden",
+		"Consider identifying opportunities for improvement":                      "Verbesserungschancen ermitteln",
+		"Risk should have mitigation actions defined":                             "Für das Risiko sollten Maßnahmen zur Minderung festgelegt werden",
+		"Opportunity should have actions defined for realization":                 "Für die Chance sollten Maßnahmen zur Umsetzung festgelegt werden",
+
+		// Clause 6.2
+		"Quality objectives must be established at relevant functions and levels": "Qualitätsziele müssen für relevante Funktionen und Ebenen festgelegt werden",
+		"Quality objective must have a name":                                      "Das Qualitätsziel muss einen Namen haben",
+		"Quality objectives must be measurable":                                   "Qualitätsziele müssen messbar sein",
+		"Quality objectives must have specific targets":                           "Qualitätsziele müssen konkrete Zielwerte haben",
+		"Quality objectives must have responsible parties assigned":               "Qualitätszielen müssen Verantwortliche zugewiesen sein",
+		"Quality objectives must have target dates":                               "Qualitätsziele müssen Zieltermine haben",
+
+		// Compliance report
+		"Excellent":         "Ausgezeichnet",
+		"Good":              "Gut",
+		"Satisfactory":      "Zufriedenstellend",
+		"Needs Improvement": "Verbesserungsbedürftig",
+		"Critical Gaps":     "Kritische Lücken",
+		"Critical":          "Kritisch",
+		"Address critical compliance gaps immediately":                "Kritische Konformitätslücken sofort schließen",
+		"Implement corrective actions for identified nonconformities": "Korrekturmaßnahmen für festgestellte Nichtkonformitäten umsetzen",
+		"Strengthen QMS documentation and procedures":                 "QMS-Dokumentation und Verfahren stärken",
+		"Develop action plans for improvement areas":                  "Maßnahmenpläne für Verbesserungsbereiche erstellen",
+		"Enhance monitoring and measurement processes":                "Überwachungs- und Messprozesse verbessern",
+		"Provide additional training where needed":                    "Bei Bedarf zusätzliche Schulungen anbieten",
+		"Processes are defined and documented":                        "Prozesse sind festgelegt und dokumentiert",
+		"Quality policy is established and communicated":              "Die Qualitätspolitik ist festgelegt und bekannt gemacht",
+	},
+
+	LocaleFrench: {
+		// Validation output
+		"[%s] Clause %s - %s: %s": "[%s] Article %s - %s : %s",
+		"error":                   "erreur",
+		"warning":                 "avertissement",
+		"info":                    "information",
+
+		// Clause 4.1
+		"Organizational context must be defined": "Le contexte de l'organisme doit être défini",
+		"No external issues identified - consider reviewing legal, technological, competitive, market, cultural, social and economic environments": "Aucun enjeu externe identifié - examiner les environnements légal, technologique, concurrentiel, commercial, culturel, social et économique",
+		"No internal issues identified - consider reviewing values, culture, knowledge and performance":                                            "Aucun enjeu interne identifié - examiner les valeurs, la culture, les connaissances et la performance",
+		"Issue must have a description":              "L'enjeu doit avoir une description",
+		"Issue must have a type (external/internal)": "L'enjeu doit avoir un type (externe/interne)",
+
+		// Clause 4.2
+		"Interested parties must be identified and their requirements determined": "Les parties intéressées doivent être identifiées et leurs exigences déterminées",
+		"Interested party must have a name":                                       "La partie intéressée doit avoir un nom",
+		"No requirements specified for interested party":                          "Aucune exigence précisée pour la partie intéressée",
+		"No customers identified as interested parties":                           "Aucun client identifié comme partie intéressée",
+		"No suppliers/external providers identified as interested parties":        "Aucun fournisseur/prestataire externe identifié comme partie intéressée",
+		"Consider identifying regulatory authorities as interested parties":       "Envisager d'identifier les autorités réglementaires comme parties intéressées",
+
+		// Clause 4.3
+		"QMS scope must be determined and documented":                                                      "Le domaine d'application du SMQ doit être déterminé et documenté",
+		"Scope must include a description of products and services covered":                                "Le domaine d'application doit décrire les produits et services couverts",
+		"Scope must specify the types of products and services covered":                                    "Le domaine d'application doit préciser les types de produits et services couverts",
+		"Exclusion must specify which clause is not applicable":                                            "L'exclusion doit préciser l'article non applicable",
+		"Exclusion must be justified and not affect organization's ability to meet requirements":           "L'exclusion doit être justifiée et ne pas compromettre l'aptitude de l'organisme à satisfaire aux exigences",
+		"Exclusion clause %q is not a valid ISO 9001 clause reference":                                     "L'article exclu %q n'est pas une référence valide de l'ISO 9001",
+		"Clause %s cannot be excluded - only requirements of clause 8 may be determined as not applicable": "L'article %s ne peut pas être exclu - seules les exigences de l'article 8 peuvent être déterminées comme non applicables",
+		"Excluding clause %s is likely to affect the ability to provide conforming products and services":  "L'exclusion de l'article %s risque de compromettre l'aptitude à fournir des produits et services conformes",
+
+		// Clause 4.4
+		"QMS processes must be established, implemented, maintained and continually improved": "Les processus du SMQ doivent être établis, mis en œuvre, tenus à jour et améliorés en continu",
+		"Process must have a name":                                       "Le processus doit avoir un nom",
+		"Process inputs should be defined":                               "Les éléments d'entrée du processus devraient être définis",
+		"Process outputs should be defined":                              "Les éléments de sortie du processus devraient être définis",
+		"Process responsibilities and authorities must be assigned":      "Les responsabilités et autorités du processus doivent être attribuées",
+		"Process criteria and methods for monitoring must be determined": "Les critères et méthodes de surveillance du processus doivent être déterminés",
+		"Consider identifying risks and opportunities for this process":  "Envisager d'identifier les risques et opportunités de ce processus",
+
+		// Clause 5.1
+		"Top management must demonstrate leadership and commitment": "La direction doit démontrer son leadership et son engagement",
+		"Top management must be identified":                         "La direction doit être identifiée",
+		"Missing leadership commitment: %s":                         "Engagement de la direction manquant : %s",
+
+		// Clause 5.2
+		"Quality policy must be established and maintained":                          "La politique qualité doit être établie et tenue à jour",
+		"Quality policy must include a statement of intent":                          "La politique qualité doit comporter une déclaration d'intention",
+		"Quality policy must provide a framework for setting quality objectives":     "La politique qualité doit fournir un cadre pour l'établissement des objectifs qualité",
+		"Quality policy must include commitment to satisfy applicable requirements":  "La politique qualité doit comporter l'engagement de satisfaire aux exigences applicables",
+		"Quality policy must include commitment to continual improvement":            "La politique qualité doit comporter l'engagement d'amélioration continue",
+		"Quality policy must be communicated and understood within the organization": "La politique qualité doit être communiquée et comprise au sein de l'organisme",
+		"Quality policy must be available to relevant interested parties":            "La politique qualité doit être mise à disposition des parties intéressées pertinentes",
+
+		// Clause 5.3
+		"Organizational roles, responsibilities and authorities must be assigned and communicated": "Les rôles, responsabilités et autorités au sein de l'organisme doivent être attribués et communiqués",
+		"Role must have a name":                   "Le rôle doit avoir un nom",
+		"Role must have defined responsibilities": "Le rôle doit avoir des responsabilités définies",
+		"Role must have defined authorities":      "Le rôle doit avoir des autorités définies",
+		"Role must be assigned to a person":       "Le rôle doit être attribué à une personne",
+
+		// Clause 6.1
+		"QMS must be defined to validate risks and opportunities":                 "Le SMQ doit être défini pour valider les risques et opportunités",
+		"No risks identified - risk-based thinking should be applied to planning": "Aucun risque identifié - l'approche par les risques devrait être appliquée à la planification",
+		"Consider identifying opportunities for improvement":                      "Envisager d'identifier des opportunités d'amélioration",
+		"Risk should have mitigation actions defined":                             "Le risque devrait avoir des actions d'atténuation définies",
+		"Opportunity should have actions defined for realization":                 "L'opportunité devrait avoir des actions de réalisation définies",
+
+		// Clause 6.2
+		"Quality objectives must be established at relevant functions and levels": "Les objectifs qualité doivent être établis aux fonctions et niveaux concernés",
+		"Quality objective must have a name":                                      "L'objectif qualité doit avoir un nom",
+		"Quality objectives must be measurable":                                   "Les objectifs qualité doivent être mesurables",
+		"Quality objectives must have specific targets":                           "Les objectifs qualité doivent avoir des cibles précises",
+		"Quality objectives must have responsible parties assigned":               "Les objectifs qualité doivent avoir des responsables désignés",
+		"Quality objectives must have target dates":                               "Les objectifs qualité doivent avoir des échéances",
+
+		// Compliance report
+		"Excellent":         "Excellent",
+		"Good":              "Bon",
+		"Satisfactory":      "Satisfaisant",
+		"Needs Improvement": "À améliorer",
+		"Critical Gaps":     "Écarts critiques",
+		"Critical":          "Critique",
+		"Address critical compliance gaps immediately":                "Traiter immédiatement les écarts de conformité critiques",
+		"Implement corrective actions for identified nonconformities": "Mettre en œuvre des actions correctives pour les non-conformités identifiées",
+		"Strengthen QMS documentation and procedures":                 "Renforcer la documentation et les procédures du SMQ",
+		"Develop action plans for improvement areas":                  "Élaborer des plans d'action pour les axes d'amélioration",
+		"Enhance monitoring and measurement processes":                "Améliorer les processus de surveillance et de mesure",
+		"Provide additional training where needed":                    "Dispenser des formations complémentaires si nécessaire",
+		"Processes are defined and documented":                        "Les processus sont définis et documentés",
+		"Quality policy is established and communicated":              "La politique qualité est établie et communiquée",
+	},
+
+	LocaleSpanish: {
+		// Validation output
+		"[%s] Clause %s - %s: %s": "[%s] Capítulo %s - %s: %s",
+		"error":                   "error",
+		"warning":                 "advertencia",
+		"info":                    "información",
+
+		// Clause 4.1
+		"Organizational context must be defined": "Debe definirse el contexto de la organización",
+		"No external issues identified - consider reviewing legal, technological, competitive, market, cultural, social and economic environments": "No se han identificado cuestiones externas - revise los entornos legal, tecnológico, competitivo, de mercado, cultural, social y económico",
+		"No internal issues identified - consider reviewing values, culture, knowledge and performance":                                            "No se han identificado cuestiones internas - revise los valores, la cultura, el conocimiento y el desempeño",
+		"Issue must have a description":              "La cuestión debe tener una descripción",
+		"Issue must have a type (external/internal)": "La cuestión debe tener un tipo (externa/interna)",
+
+		// Clause 4.2
+		"Interested parties must be identified and their requirements determined": "Deben identificarse las partes interesadas y determinarse sus requisitos",
+		"Interested party must have a name":                                       "La parte interesada debe tener un nombre",
+		"No requirements specified for interested party":                          "No se han especificado requisitos para la parte interesada",
+		"No customers identified as interested parties":                           "No se han identificado clientes como partes interesadas",
+		"No suppliers/external providers identified as interested parties":        "No se han identificado proveedores externos como partes interesadas",
+		"Consider identifying regulatory authorities as interested parties":       "Considere identificar a las autoridades reguladoras como partes interesadas",
+
+		// Clause 4.3
+		"QMS scope must be determined and documented":                                                      "Debe determinarse y documentarse el alcance del SGC",
+		"Scope must include a description of products and services covered":                                "El alcance debe describir los productos y servicios cubiertos",
+		"Scope must specify the types of products and services covered":                                    "El alcance debe especificar los tipos de productos y servicios cubiertos",
+		"Exclusion must specify which clause is not applicable":                                            "La exclusión debe especificar qué capítulo no es aplicable",
+		"Exclusion must be justified and not affect organization's ability to meet requirements":           "La exclusión debe justificarse y no afectar a la capacidad de la organización para cumplir los requisitos",
+		"Exclusion clause %q is not a valid ISO 9001 clause reference":                                     "El capítulo excluido %q no es una referencia válida de la ISO 9001",
+		"Clause %s cannot be excluded - only requirements of clause 8 may be determined as not applicable": "El capítulo %s no puede excluirse - solo los requisitos del capítulo 8 pueden determinarse como no aplicables",
+		"Excluding clause %s is likely to affect the ability to provide conforming products and services":  "Excluir el capítulo %s probablemente afecte a la capacidad de proporcionar productos y servicios conformes",
+
+		// Clause 4.4
+		"QMS processes must be established, implemented, maintained and continually improved": "Los procesos del SGC deben establecerse, implementarse, mantenerse y mejorarse continuamente",
+		"Process must have a name":                                       "El proceso debe tener un nombre",
+		"Process inputs should be defined":                               "Deberían definirse las entradas del proceso",
+		"Process outputs should be defined":                              "Deberían definirse las salidas del proceso",
+		"Process responsibilities and authorities must be assigned":      "Deben asignarse las responsabilidades y autoridades del proceso",
+		"Process criteria and methods for monitoring must be determined": "Deben determinarse los criterios y métodos de seguimiento del proceso",
+		"Consider identifying risks and opportunities for this process":  "Considere identificar riesgos y oportunidades para este proceso",
+
+		// Clause 5.1
+		"Top management must demonstrate leadership and commitment": "La alta dirección debe demostrar liderazgo y compromiso",
+		"Top management must be identified":                         "Debe identificarse la alta dirección",
+		"Missing leadership commitment: %s":                         "Falta el compromiso de la dirección: %s",
+
+		// Clause 5.2
+		"Quality policy must be established and maintained":                          "La política de la calidad debe establecerse y mantenerse",
+		"Quality policy must include a statement of intent":                          "La política de la calidad debe incluir una declaración de intenciones",
+		"Quality policy must provide a framework for setting quality objectives":     "La política de la calidad debe proporcionar un marco para establecer los objetivos de la calidad",
+		"Quality policy must include commitment to satisfy applicable requirements":  "La política de la calidad debe incluir el compromiso de cumplir los requisitos aplicables",
+		"Quality policy must include commitment to continual improvement":            "La política de la calidad debe incluir el compromiso de mejora continua",
+		"Quality policy must be communicated and understood within the organization": "La política de la calidad debe comunicarse y entenderse dentro de la organización",
+		"Quality policy must be available to relevant interested parties":            "La política de la calidad debe estar disponible para las partes interesadas pertinentes",
+
+		// Clause 5.3
+		"Organizational roles, responsibilities and authorities must be assigned and communicated": "Deben asignarse y comunicarse los roles, responsabilidades y autoridades en la organización",
+		"Role must have a name":                   "El rol debe tener un nombre",
+		"Role must have defined responsibilities": "El rol debe tener responsabilidades definidas",
+		"Role must have defined authorities":      "El rol debe tener autoridades definidas",
+		"Role must be assigned to a person":       "El rol debe asignarse a una persona",
+
+		// Clause 6.1
+		"QMS must be defined to validate risks and opportunities":                 "Debe definirse el SGC para validar riesgos y oportunidades",
+		"No risks identified - risk-based thinking should be applied to planning": "No se han identificado riesgos - debería aplicarse el pensamiento basado en riesgos a la planificación",
+		"Consider identifying opportunities for improvement":                      "Considere identificar oportunidades de mejora",
+		"Risk should have mitigation actions defined":                             "El riesgo debería tener acciones de mitigación definidas",
+		"Opportunity should have actions defined for realization":                 "La oportunidad debería tener acciones definidas para su realización",
+
+		// Clause 6.2
+		"Quality objectives must be established at relevant functions and levels": "Deben establecerse objetivos de la calidad en las funciones y niveles pertinentes",
+		"Quality objective must have a name":                                      "El objetivo de la calidad debe tener un nombre",
+		"Quality objectives must be measurable":                                   "Los objetivos de la calidad deben ser medibles",
+		"Quality objectives must have specific targets":                           "Los objetivos de la calidad deben tener metas específicas",
+		"Quality objectives must have responsible parties assigned":               "Los objetivos de la calidad deben tener responsables asignados",
+		"Quality objectives must have target dates":                               "Los objetivos de la calidad deben tener fechas objetivo",
+
+		// Compliance report
+		"Excellent":         "Excelente",
+		"Good":              "Bueno",
+		"Satisfactory":      "Satisfactorio",
+		"Needs Improvement": "Necesita mejorar",
+		"Critical Gaps":     "Brechas críticas",
+		"Critical":          "Crítico",
+		"Address critical compliance gaps immediately":                "Abordar de inmediato las brechas de cumplimiento críticas",
+		"Implement corrective actions for identified nonconformities": "Implementar acciones correctivas para las no conformidades identificadas",
+		"Strengthen QMS documentation and procedures":                 "Reforzar la documentación y los procedimientos del SGC",
+		"Develop action plans for improvement areas":                  "Elaborar planes de acción para las áreas de mejora",
+		"Enhance monitoring and measurement processes":                "Mejorar los procesos de seguimiento y medición",
+		"Provide additional training where needed":                    "Proporcionar formación adicional cuando sea necesario",
+		"Processes are defined and documented":                        "Los procesos están definidos y documentados",
+		"Quality policy is established and communicated":              "La política de la calidad está establecida y comunicada",
+	},
+}
@@ -0,0 +1,104 @@
+package iso9001
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestCatalogsAreComplete(t *testing.T) {
+	verbs := regexp.MustCompile(`%[a-z]`)
+	for _, locale := range []Locale{LocaleGerman, LocaleFrench, LocaleSpanish} {
+		for key := range defaultMessages[LocaleGerman] {
+			translated, ok := defaultMessages[locale][key]
+			if !ok {
+				t.Errorf("%s: missing translation for %q", locale, key)
+				continue
+			}
+			if strings.Join(verbs.FindAllString(key, -1), "") != strings.Join(verbs.FindAllString(translated, -1), "") {
+				t.Errorf("%s: verbs of %q do not match %q", locale, translated, key)
+			}
+		}
+		if len(defaultMessages[locale]) != len(defaultMessages[LocaleGerman]) {
+			t.Errorf("%s: expected %d messages, got %d", locale, len(defaultMessages[LocaleGerman]), len(defaultMessages[locale]))
+		}
+	}
+}
+
+func TestLocalizedValidation(t *testing.T) {
+	org := &Organization{
+		ID:         "ORG-001",
+		Leadership: &Leadership{TopManagement: []Person{{ID: "P-1", Name: "Ada"}}},
+		QMS: &QualityManagementSystem{
+			Scope:     &QMSScope{Description: "All", Products: []string{"Widgets"}, Exclusions: []Exclusion{{Clause: "7.1.5", Justification: "n/a"}}},
+			Processes: []Process{{ID: "P-1", Name: "Sales"}},
+		},
+	}
+	result := ValidateOrganization(org)
+
+	for _, locale := range []Locale{LocaleGerman, LocaleFrench, LocaleSpanish} {
+		localized := result.Localize(locale)
+		if len(localized.Errors) != len(result.Errors) || len(localized.Warnings) != len(result.Warnings) {
+			t.Fatalf("%s: expected the same number of findings", locale)
+		}
+		for i, e := range localized.Errors {
+			if e.Message == result.Errors[i].Message && e.Message != "" {
+				t.Errorf("%s: message %q was not translated", locale, e.Message)
+			}
+		}
+	}
+
+	german := result.Localize(LocaleGerman)
+	var commitment, exclusion string
+	for _, e := range german.Errors {
+		switch {
+		case e.Field == "leadership_commitment" && commitment == "":
+			commitment = e.Error()
+		case strings.HasPrefix(e.Field, "exclusion_"):
+			exclusion = e.Message
+		}
+	}
+	if commitment != "[Fehler] Abschnitt 5.1 - leadership_commitment: Fehlende Verpflichtung der Leitung: qms_effectiveness" {
+		t.Errorf("Unexpected German error text: %q", commitment)
+	}
+	if !strings.Contains(exclusion, "Abschnitt 7.1.5 kann nicht ausgeschlossen werden") {
+		t.Errorf("Expected formatted German exclusion message, got %q", exclusion)
+	}
+
+	// The original result stays in English
+	if !strings.HasPrefix(result.Errors[0].Error(), "[error] Clause") {
+		t.Errorf("Expected English error text, got %q", result.Errors[0].Error())
+	}
+}
+
+func TestLocalizedComplianceReport(t *testing.T) {
+	report := GenerateLocalizedComplianceReport(&Organization{ID: "ORG-001"}, LocaleSpanish)
+	if report.OverallCompliance != "Brechas críticas" {
+		t.Errorf("Expected Spanish rating, got %q", report.OverallCompliance)
+	}
+	if len(report.CriticalGaps) == 0 || report.CriticalGaps[0].Description != "Debe definirse el contexto de la organización" {
+		t.Errorf("Expected Spanish gaps, got %+v", report.CriticalGaps)
+	}
+	if report.Recommendations[0] != "Abordar de inmediato las brechas de cumplimiento críticas" {
+		t.Errorf("Expected Spanish recommendations, got %v", report.Recommendations)
+	}
+}
+
+func TestParseLocale(t *testing.T) {
+	cases := map[string]Locale{"de-DE": LocaleGerman, "fr_CA.UTF-8": LocaleFrench, "ES": LocaleSpanish, "ja": LocaleEnglish, "": LocaleEnglish}
+	for tag, want := range cases {
+		if got := ParseLocale(tag); got != want {
+			t.Errorf("ParseLocale(%q) = %s, want %s", tag, got, want)
+		}
+	}
+
+	RegisterMessages("nl", map[string]string{"Good": "Goed"})
+	defer func() {
+		messageCatalog.Lock()
+		delete(messageCatalog.messages, "nl")
+		messageCatalog.Unlock()
+	}()
+	if ParseLocale("nl-BE") != "nl" || Translate("nl", "Good") != "Goed" || Translate("nl", "Excellent") != "Excellent" {
+		t.Error("Expected registered locale to be used with English fallback")
+	}
+}
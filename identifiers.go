@@ -0,0 +1,170 @@
+package iso9001
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Entity types used to label identifiers in the ID registry and change logs
+const (
+	EntityTypeOrganization        = "organization"
+	EntityTypeProcess             = "process"
+	EntityTypeRisk                = "risk"
+	EntityTypeOpportunity         = "opportunity"
+	EntityTypeObjective           = "objective"
+	EntityTypeIssue               = "issue"
+	EntityTypeInterestedParty     = "interested_party"
+	EntityTypeRole                = "role"
+	EntityTypeDocument            = "document"
+	EntityTypeAudit               = "audit"
+	EntityTypeFinding             = "finding"
+	EntityTypeManagementReview    = "management_review"
+	EntityTypeSurvey              = "survey"
+	EntityTypeComplaint           = "complaint"
+	EntityTypeNonconformingOutput = "nonconforming_output"
+	EntityTypeNonconformance      = "nonconformance"
+	EntityTypeFMEAWorksheet       = "fmea_worksheet"
+	EntityTypeUser                = "user"
+	EntityTypeApproval            = "approval"
+	EntityTypeCorrectiveAction    = "corrective_action"
+	EntityTypeTemplate            = "template"
+	EntityTypeAuditChecklist      = "audit_checklist"
+)
+
+// DuplicateIDError is returned when an ID is already used by another entity of the
+// same organization, whether of the same type or a different one
+type DuplicateIDError struct {
+	ID           string `json:"id" yaml:"id"`
+	EntityType   string `json:"entity_type" yaml:"entity_type"`
+	ExistingType string `json:"existing_type" yaml:"existing_type"`
+}
+
+func (e *DuplicateIDError) Error() string {
+	if e.EntityType == e.ExistingType {
+		return fmt.Sprintf("%s with ID %s already exists", e.EntityType, e.ID)
+	}
+	return fmt.Sprintf("cannot use ID %s for %s: already used by %s", e.ID, e.EntityType, e.ExistingType)
+}
+
+// IDRegistry tracks the IDs claimed within an organization so that no two entities,
+// of any type, share an ID. Managers sharing a registry reject each other's IDs.
+type IDRegistry struct {
+	mu  sync.Mutex
+	ids map[string]string
+}
+
+// NewIDRegistry creates an empty ID registry
+func NewIDRegistry() *IDRegistry {
+	return &IDRegistry{ids: make(map[string]string)}
+}
+
+// Register claims an ID for an entity type, returning a *DuplicateIDError if the ID
+// is already taken
+func (r *IDRegistry) Register(id, entityType string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if existing, taken := r.ids[id]; taken {
+		return &DuplicateIDError{ID: id, EntityType: entityType, ExistingType: existing}
+	}
+	r.ids[id] = entityType
+	return nil
+}
+
+// Release frees an ID so it can be claimed again
+func (r *IDRegistry) Release(id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.ids, id)
+}
+
+// Lookup returns the entity type that claimed an ID
+func (r *IDRegistry) Lookup(id string) (string, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	entityType, taken := r.ids[id]
+	return entityType, taken
+}
+
+// claimID checks an ID against a manager's own entities and, when set, the shared
+// registry. A nil registry only enforces uniqueness within the manager.
+func claimID(registry *IDRegistry, id, entityType string, exists bool) error {
+	if exists {
+		return &DuplicateIDError{ID: id, EntityType: entityType, ExistingType: entityType}
+	}
+	if registry == nil {
+		return nil
+	}
+	return registry.Register(id, entityType)
+}
+
+// releaseID frees an ID previously claimed with claimID
+func releaseID(registry *IDRegistry, id string) {
+	if registry != nil {
+		registry.Release(id)
+	}
+}
+
+// ValidateIdentifiers reports IDs shared by more than one entity of an organization,
+// such as a process and a risk with the same ID. Entities without an ID are ignored.
+func ValidateIdentifiers(org *Organization) *ValidationResult {
+	result := &ValidationResult{
+		Valid:    true,
+		Errors:   []ValidationError{},
+		Warnings: []ValidationError{},
+		Infos:    []ValidationError{},
+	}
+
+	registry := NewIDRegistry()
+	check := func(id, entityType string) {
+		if id == "" {
+			return
+		}
+		if err := registry.Register(id, entityType); err != nil {
+			result.addError("7.5.2", fmt.Sprintf("%s_%s_id", entityType, id), err.Error())
+		}
+	}
+
+	check(org.ID, EntityTypeOrganization)
+
+	if org.Context != nil {
+		for _, issue := range org.Context.ExternalIssues {
+			check(issue.ID, EntityTypeIssue)
+		}
+		for _, issue := range org.Context.InternalIssues {
+			check(issue.ID, EntityTypeIssue)
+		}
+		for _, party := range org.Context.InterestedParties {
+			check(party.ID, EntityTypeInterestedParty)
+		}
+	}
+
+	if org.Leadership != nil {
+		for _, role := range org.Leadership.Roles {
+			check(role.ID, EntityTypeRole)
+		}
+	}
+
+	if org.QMS != nil {
+		for _, process := range org.QMS.Processes {
+			check(process.ID, EntityTypeProcess)
+			for _, risk := range process.Risks {
+				check(risk.ID, EntityTypeRisk)
+			}
+			for _, opportunity := range process.Opportunities {
+				check(opportunity.ID, EntityTypeOpportunity)
+			}
+		}
+		for _, risk := range org.QMS.Risks {
+			check(risk.ID, EntityTypeRisk)
+		}
+		for _, opportunity := range org.QMS.Opportunities {
+			check(opportunity.ID, EntityTypeOpportunity)
+		}
+		for _, objective := range org.QMS.Objectives {
+			check(objective.ID, EntityTypeObjective)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,55 @@
+package iso9001
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSharedIDRegistryRejectsCrossTypeDuplicates(t *testing.T) {
+	tenant := NewTenant("ORG-001")
+
+	if err := tenant.Risks.IdentifyRisk(&Risk{ID: "X-001", Description: "Supplier delay"}); err != nil {
+		t.Fatalf("Failed to identify risk: %v", err)
+	}
+
+	err := tenant.Documents.AddDocument(&DocumentedInformation{ID: "X-001", Title: "Procedure"})
+	var dup *DuplicateIDError
+	if !errors.As(err, &dup) {
+		t.Fatalf("Expected DuplicateIDError, got %v", err)
+	}
+	if dup.EntityType != EntityTypeDocument || dup.ExistingType != EntityTypeRisk {
+		t.Errorf("Unexpected duplicate error %+v", dup)
+	}
+
+	err = tenant.Risks.IdentifyRisk(&Risk{ID: "X-001", Description: "Again"})
+	if !errors.As(err, &dup) || dup.ExistingType != EntityTypeRisk {
+		t.Errorf("Expected same-type duplicate error, got %v", err)
+	}
+
+	result := tenant.Objectives.BulkAddObjectives([]*QualityObjective{
+		{ID: "X-001", Name: "Clash", Measurable: true, Targets: []ObjectiveTarget{{Metric: "m"}}, Responsible: "QM"},
+	}, BulkOptions{})
+	if len(result.Errors) != 1 || !errors.As(result.Errors[0], &dup) {
+		t.Errorf("Expected bulk import to report duplicate ID, got %+v", result.Errors)
+	}
+}
+
+func TestValidateIdentifiers(t *testing.T) {
+	org := &Organization{
+		ID: "ORG-001",
+		QMS: &QualityManagementSystem{
+			Processes: []Process{{ID: "P-001", Name: "Sales"}},
+			Risks:     []Risk{{ID: "P-001", Description: "Shares the process ID"}},
+		},
+	}
+
+	result := ValidateIdentifiers(org)
+	if result.Valid || len(result.Errors) != 1 {
+		t.Fatalf("Expected one duplicate ID error, got %+v", result.Errors)
+	}
+
+	org.QMS.Risks[0].ID = "R-001"
+	if result := ValidateIdentifiers(org); !result.Valid {
+		t.Errorf("Expected unique IDs to validate, got %+v", result.Errors)
+	}
+}
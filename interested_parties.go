@@ -0,0 +1,94 @@
+package iso9001
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Interested party categories walked through when identifying interested parties (clause 4.2)
+const (
+	PartyCustomer  = "customer"
+	PartyRegulator = "regulator"
+	PartyEmployee  = "employee"
+	PartyOwner     = "owner"
+	PartySupplier  = "supplier"
+	PartyCommunity = "community"
+)
+
+// InterestedPartyCategories lists the categories in the order they are reviewed
+var InterestedPartyCategories = []string{PartyCustomer, PartyRegulator, PartyEmployee, PartyOwner, PartySupplier, PartyCommunity}
+
+// IsInterestedPartyCategory reports whether a party type is one of the standard categories
+func IsInterestedPartyCategory(partyType string) bool {
+	for _, category := range InterestedPartyCategories {
+		if category == partyType {
+			return true
+		}
+	}
+	return false
+}
+
+// AddInterestedParties records identified interested parties in the context. A party
+// with the same name and type as one already listed is merged into it, adding only the
+// requirements not yet recorded. Parties without an ID are numbered IP-001, IP-002 and
+// so on. It returns the number of parties added.
+func (c *OrganizationalContext) AddInterestedParties(parties ...InterestedParty) int {
+	added := 0
+	for _, party := range parties {
+		existing := c.findInterestedParty(party.Name, party.Type)
+		if existing == nil {
+			if party.ID == "" {
+				party.ID = c.nextInterestedPartyID()
+			}
+			party.Requirements = appendRequirements(nil, party.Requirements)
+			c.InterestedParties = append(c.InterestedParties, party)
+			added++
+			continue
+		}
+		existing.Requirements = appendRequirements(existing.Requirements, party.Requirements)
+	}
+	return added
+}
+
+func (c *OrganizationalContext) findInterestedParty(name, partyType string) *InterestedParty {
+	for i := range c.InterestedParties {
+		party := &c.InterestedParties[i]
+		if strings.EqualFold(party.Name, name) && party.Type == partyType {
+			return party
+		}
+	}
+	return nil
+}
+
+func (c *OrganizationalContext) nextInterestedPartyID() string {
+	used := make(map[string]bool, len(c.InterestedParties))
+	for _, party := range c.InterestedParties {
+		used[party.ID] = true
+	}
+	for n := len(c.InterestedParties) + 1; ; n++ {
+		if id := fmt.Sprintf("IP-%03d", n); !used[id] {
+			return id
+		}
+	}
+}
+
+// appendRequirements adds the non-empty requirements not already in the list
+func appendRequirements(list, requirements []string) []string {
+	for _, requirement := range requirements {
+		requirement = strings.TrimSpace(requirement)
+		if requirement == "" {
+			continue
+		}
+		duplicate := false
+		for _, existing := range list {
+			if strings.EqualFold(existing, requirement) {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			list = append(list, requirement)
+		}
+	}
+	return list
+}
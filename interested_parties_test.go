@@ -0,0 +1,37 @@
+package iso9001
+
+import "testing"
+
+func TestAddInterestedParties(t *testing.T) {
+	context := &OrganizationalContext{
+		InterestedParties: []InterestedParty{{ID: "IP-001", Name: "Customers", Type: PartyCustomer, Requirements: []string{"On-time delivery"}}},
+	}
+
+	added := context.AddInterestedParties(
+		InterestedParty{Name: "customers", Type: PartyCustomer, Requirements: []string{"on-time delivery", "Product conformity"}},
+		InterestedParty{Name: "Trading standards", Type: PartyRegulator, Requirements: []string{"Product safety", " "}},
+		InterestedParty{Name: "Local residents", Type: PartyCommunity},
+	)
+	if added != 2 {
+		t.Fatalf("Expected 2 parties added, got %d", added)
+	}
+	if len(context.InterestedParties) != 3 {
+		t.Fatalf("Expected 3 parties, got %d", len(context.InterestedParties))
+	}
+
+	customers := context.InterestedParties[0]
+	if len(customers.Requirements) != 2 || customers.Requirements[1] != "Product conformity" {
+		t.Errorf("Expected merged customer requirements, got %v", customers.Requirements)
+	}
+	regulator := context.InterestedParties[1]
+	if regulator.ID != "IP-002" || len(regulator.Requirements) != 1 {
+		t.Errorf("Unexpected regulator %+v", regulator)
+	}
+	if context.InterestedParties[2].ID != "IP-003" {
+		t.Errorf("Expected IP-003, got %s", context.InterestedParties[2].ID)
+	}
+
+	if !IsInterestedPartyCategory(PartyOwner) || IsInterestedPartyCategory("competitor") {
+		t.Error("Unexpected category check result")
+	}
+}
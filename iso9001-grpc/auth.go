@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+
+	"github.com/example/iso9001"
+	iso9001v1 "github.com/example/iso9001-grpc/gen/iso9001/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// anonymousActor names callers without an API key in the audit trail
+const anonymousActor = "grpc"
+
+// writeMethods are the calls that change the store and need a write-scoped key
+var writeMethods = map[string]bool{
+	iso9001v1.QMSService_PutOrganization_FullMethodName:   true,
+	iso9001v1.QMSService_IdentifyRisk_FullMethodName:      true,
+	iso9001v1.QMSService_AssessRisk_FullMethodName:        true,
+	iso9001v1.QMSService_MitigateRisk_FullMethodName:      true,
+	iso9001v1.QMSService_CreateObjective_FullMethodName:   true,
+	iso9001v1.QMSService_AddDocument_FullMethodName:       true,
+	iso9001v1.QMSService_ApproveDocument_FullMethodName:   true,
+	iso9001v1.QMSService_SetDocumentStatus_FullMethodName: true,
+	iso9001v1.QMSService_CreateAudit_FullMethodName:       true,
+	iso9001v1.QMSService_StartAudit_FullMethodName:        true,
+	iso9001v1.QMSService_AddFinding_FullMethodName:        true,
+	iso9001v1.QMSService_CompleteAudit_FullMethodName:     true,
+}
+
+type apiKeyContextKey struct{}
+
+// caller names the client in the audit trail: the name of its API key, if any
+func caller(ctx context.Context) string {
+	if key, ok := ctx.Value(apiKeyContextKey{}).(*iso9001.APIKey); ok {
+		return key.Name
+	}
+	return anonymousActor
+}
+
+// guard returns interceptors that throttle each call by the client's address and,
+// when keys is set, reject calls without a valid key, presented as
+// "authorization: Bearer <key>" or "x-api-key: <key>" metadata, and throttle each
+// key. Calls in writeMethods need a write-scoped key, all others a read-scoped one.
+// Addresses are throttled before keys are checked, so guessing keys is throttled too.
+func guard(keys *iso9001.APIKeyManager, limiter *iso9001.RateLimiter) []grpc.ServerOption {
+	admit := func(ctx context.Context, method string) (context.Context, error) {
+		address := "unknown"
+		if p, ok := peer.FromContext(ctx); ok {
+			address = p.Addr.String()
+			if host, _, err := net.SplitHostPort(address); err == nil {
+				address = host
+			}
+		}
+		if err := throttle(limiter, "addr:"+address); err != nil {
+			return nil, err
+		}
+		if keys == nil {
+			return ctx, nil
+		}
+		ctx, err := authenticate(ctx, keys, method)
+		if err != nil {
+			return nil, err
+		}
+		key := ctx.Value(apiKeyContextKey{}).(*iso9001.APIKey)
+		if key.RateLimit != nil {
+			limiter.SetClientLimit(key.ID, *key.RateLimit)
+		} else {
+			limiter.ClearClientLimit(key.ID)
+		}
+		return ctx, throttle(limiter, key.ID)
+	}
+	return []grpc.ServerOption{
+		grpc.UnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			ctx, err := admit(ctx, info.FullMethod)
+			if err != nil {
+				return nil, err
+			}
+			return handler(ctx, req)
+		}),
+		grpc.StreamInterceptor(func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			ctx, err := admit(stream.Context(), info.FullMethod)
+			if err != nil {
+				return err
+			}
+			return handler(srv, &contextStream{ServerStream: stream, ctx: ctx})
+		}),
+	}
+}
+
+// contextStream is a server stream carrying the context of an admitted call
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context { return s.ctx }
+
+// throttle counts a call by the client, failing with ResourceExhausted when it
+// exceeds its rate or daily quota
+func throttle(limiter *iso9001.RateLimiter, client string) error {
+	if _, err := limiter.Allow(client); err != nil {
+		return status.Error(codes.ResourceExhausted, err.Error())
+	}
+	return nil
+}
+
+// authenticate checks the key presented for a call and adds it to the context
+func authenticate(ctx context.Context, keys *iso9001.APIKeyManager, method string) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	var secret string
+	if values := md.Get("x-api-key"); len(values) > 0 {
+		secret = values[0]
+	}
+	if values := md.Get("authorization"); len(values) > 0 && strings.HasPrefix(values[0], "Bearer ") {
+		secret = strings.TrimPrefix(values[0], "Bearer ")
+	}
+	if secret == "" {
+		return nil, status.Error(codes.Unauthenticated, "API key required")
+	}
+
+	scope := iso9001.APIKeyScopeRead
+	if writeMethods[method] {
+		scope = iso9001.APIKeyScopeWrite
+	}
+	key, err := keys.Authenticate(secret, scope)
+	if errors.Is(err, iso9001.ErrInsufficientScope) {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	} else if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	return context.WithValue(ctx, apiKeyContextKey{}, key), nil
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"encoding/json"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Messages mirror the Go types field for field under their JSON names, so values are
+// converted through JSON. Fields the messages leave out are dropped on the way in.
+var (
+	protoIn  = protojson.UnmarshalOptions{DiscardUnknown: true}
+	protoOut = protojson.MarshalOptions{UseProtoNames: true}
+)
+
+// toProto fills msg with the value of the library it mirrors
+func toProto[M proto.Message](v interface{}, msg M) (M, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return msg, status.Errorf(codes.Internal, "encoding %T: %v", v, err)
+	}
+	if err := protoIn.Unmarshal(data, msg); err != nil {
+		return msg, status.Errorf(codes.Internal, "converting %T: %v", v, err)
+	}
+	return msg, nil
+}
+
+// fromProto fills v with the message sent by a client. A missing message is an
+// invalid argument named by what.
+func fromProto(msg proto.Message, v interface{}, what string) error {
+	if msg == nil || !msg.ProtoReflect().IsValid() {
+		return status.Errorf(codes.InvalidArgument, "%s is required", what)
+	}
+	data, err := protoOut.Marshal(msg)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid %s: %v", what, err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid %s: %v", what, err)
+	}
+	return nil
+}
+
+// eachProto converts the elements of a list of the library, such as a tenant
+// collection, into messages made by newMsg
+func eachProto[M proto.Message](list interface{}, newMsg func() M) ([]M, error) {
+	data, err := json.Marshal(list)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "encoding %T: %v", list, err)
+	}
+	var elements []json.RawMessage
+	if err := json.Unmarshal(data, &elements); err != nil {
+		return nil, status.Errorf(codes.Internal, "encoding %T: %v", list, err)
+	}
+	messages := make([]M, 0, len(elements))
+	for _, element := range elements {
+		msg := newMsg()
+		if err := protoIn.Unmarshal(element, msg); err != nil {
+			return nil, status.Errorf(codes.Internal, "converting %T: %v", list, err)
+		}
+		messages = append(messages, msg)
+	}
+	return messages, nil
+}
@@ -0,0 +1,5005 @@
+// Service definition for the ISO 9001:2015 QMS engine.
+//
+// Messages mirror the Go types of github.com/example/iso9001 field for field, using
+// the same snake_case names as their JSON tags. Status, level and type values are
+// carried as strings holding the Go constant values (e.g. "in_progress") so new
+// values do not require a schema change.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: iso9001/v1/qms.proto
+
+package iso9001v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ValidateOrganizationRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	TenantId string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	// Validates this organization instead of the tenant's stored one when set
+	Organization  *Organization `protobuf:"bytes,2,opt,name=organization,proto3" json:"organization,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateOrganizationRequest) Reset() {
+	*x = ValidateOrganizationRequest{}
+	mi := &file_iso9001_v1_qms_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateOrganizationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateOrganizationRequest) ProtoMessage() {}
+
+func (x *ValidateOrganizationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_iso9001_v1_qms_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateOrganizationRequest.ProtoReflect.Descriptor instead.
+func (*ValidateOrganizationRequest) Descriptor() ([]byte, []int) {
+	return file_iso9001_v1_qms_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ValidateOrganizationRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *ValidateOrganizationRequest) GetOrganization() *Organization {
+	if x != nil {
+		return x.Organization
+	}
+	return nil
+}
+
+type GetComplianceScoreRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Organization  *Organization          `protobuf:"bytes,2,opt,name=organization,proto3" json:"organization,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetComplianceScoreRequest) Reset() {
+	*x = GetComplianceScoreRequest{}
+	mi := &file_iso9001_v1_qms_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetComplianceScoreRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetComplianceScoreRequest) ProtoMessage() {}
+
+func (x *GetComplianceScoreRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_iso9001_v1_qms_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetComplianceScoreRequest.ProtoReflect.Descriptor instead.
+func (*GetComplianceScoreRequest) Descriptor() ([]byte, []int) {
+	return file_iso9001_v1_qms_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetComplianceScoreRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *GetComplianceScoreRequest) GetOrganization() *Organization {
+	if x != nil {
+		return x.Organization
+	}
+	return nil
+}
+
+type GenerateComplianceReportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Organization  *Organization          `protobuf:"bytes,2,opt,name=organization,proto3" json:"organization,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateComplianceReportRequest) Reset() {
+	*x = GenerateComplianceReportRequest{}
+	mi := &file_iso9001_v1_qms_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateComplianceReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateComplianceReportRequest) ProtoMessage() {}
+
+func (x *GenerateComplianceReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_iso9001_v1_qms_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateComplianceReportRequest.ProtoReflect.Descriptor instead.
+func (*GenerateComplianceReportRequest) Descriptor() ([]byte, []int) {
+	return file_iso9001_v1_qms_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GenerateComplianceReportRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *GenerateComplianceReportRequest) GetOrganization() *Organization {
+	if x != nil {
+		return x.Organization
+	}
+	return nil
+}
+
+type GetOrganizationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrganizationRequest) Reset() {
+	*x = GetOrganizationRequest{}
+	mi := &file_iso9001_v1_qms_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrganizationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrganizationRequest) ProtoMessage() {}
+
+func (x *GetOrganizationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_iso9001_v1_qms_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrganizationRequest.ProtoReflect.Descriptor instead.
+func (*GetOrganizationRequest) Descriptor() ([]byte, []int) {
+	return file_iso9001_v1_qms_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetOrganizationRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+type PutOrganizationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Organization  *Organization          `protobuf:"bytes,2,opt,name=organization,proto3" json:"organization,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PutOrganizationRequest) Reset() {
+	*x = PutOrganizationRequest{}
+	mi := &file_iso9001_v1_qms_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PutOrganizationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PutOrganizationRequest) ProtoMessage() {}
+
+func (x *PutOrganizationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_iso9001_v1_qms_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PutOrganizationRequest.ProtoReflect.Descriptor instead.
+func (*PutOrganizationRequest) Descriptor() ([]byte, []int) {
+	return file_iso9001_v1_qms_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *PutOrganizationRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *PutOrganizationRequest) GetOrganization() *Organization {
+	if x != nil {
+		return x.Organization
+	}
+	return nil
+}
+
+type IdentifyRiskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Risk          *Risk                  `protobuf:"bytes,2,opt,name=risk,proto3" json:"risk,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IdentifyRiskRequest) Reset() {
+	*x = IdentifyRiskRequest{}
+	mi := &file_iso9001_v1_qms_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IdentifyRiskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IdentifyRiskRequest) ProtoMessage() {}
+
+func (x *IdentifyRiskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_iso9001_v1_qms_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IdentifyRiskRequest.ProtoReflect.Descriptor instead.
+func (*IdentifyRiskRequest) Descriptor() ([]byte, []int) {
+	return file_iso9001_v1_qms_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *IdentifyRiskRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *IdentifyRiskRequest) GetRisk() *Risk {
+	if x != nil {
+		return x.Risk
+	}
+	return nil
+}
+
+type AssessRiskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	RiskId        string                 `protobuf:"bytes,2,opt,name=risk_id,json=riskId,proto3" json:"risk_id,omitempty"`
+	Likelihood    string                 `protobuf:"bytes,3,opt,name=likelihood,proto3" json:"likelihood,omitempty"`
+	Impact        string                 `protobuf:"bytes,4,opt,name=impact,proto3" json:"impact,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssessRiskRequest) Reset() {
+	*x = AssessRiskRequest{}
+	mi := &file_iso9001_v1_qms_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssessRiskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssessRiskRequest) ProtoMessage() {}
+
+func (x *AssessRiskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_iso9001_v1_qms_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssessRiskRequest.ProtoReflect.Descriptor instead.
+func (*AssessRiskRequest) Descriptor() ([]byte, []int) {
+	return file_iso9001_v1_qms_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *AssessRiskRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *AssessRiskRequest) GetRiskId() string {
+	if x != nil {
+		return x.RiskId
+	}
+	return ""
+}
+
+func (x *AssessRiskRequest) GetLikelihood() string {
+	if x != nil {
+		return x.Likelihood
+	}
+	return ""
+}
+
+func (x *AssessRiskRequest) GetImpact() string {
+	if x != nil {
+		return x.Impact
+	}
+	return ""
+}
+
+type MitigateRiskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	RiskId        string                 `protobuf:"bytes,2,opt,name=risk_id,json=riskId,proto3" json:"risk_id,omitempty"`
+	Actions       []*Action              `protobuf:"bytes,3,rep,name=actions,proto3" json:"actions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MitigateRiskRequest) Reset() {
+	*x = MitigateRiskRequest{}
+	mi := &file_iso9001_v1_qms_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MitigateRiskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MitigateRiskRequest) ProtoMessage() {}
+
+func (x *MitigateRiskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_iso9001_v1_qms_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MitigateRiskRequest.ProtoReflect.Descriptor instead.
+func (*MitigateRiskRequest) Descriptor() ([]byte, []int) {
+	return file_iso9001_v1_qms_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *MitigateRiskRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *MitigateRiskRequest) GetRiskId() string {
+	if x != nil {
+		return x.RiskId
+	}
+	return ""
+}
+
+func (x *MitigateRiskRequest) GetActions() []*Action {
+	if x != nil {
+		return x.Actions
+	}
+	return nil
+}
+
+type ListRisksRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	TenantId string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	// Only return risks of at least this priority when set
+	MinPriority   string `protobuf:"bytes,2,opt,name=min_priority,json=minPriority,proto3" json:"min_priority,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRisksRequest) Reset() {
+	*x = ListRisksRequest{}
+	mi := &file_iso9001_v1_qms_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRisksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRisksRequest) ProtoMessage() {}
+
+func (x *ListRisksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_iso9001_v1_qms_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRisksRequest.ProtoReflect.Descriptor instead.
+func (*ListRisksRequest) Descriptor() ([]byte, []int) {
+	return file_iso9001_v1_qms_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListRisksRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *ListRisksRequest) GetMinPriority() string {
+	if x != nil {
+		return x.MinPriority
+	}
+	return ""
+}
+
+type ListRisksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Risks         []*Risk                `protobuf:"bytes,1,rep,name=risks,proto3" json:"risks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRisksResponse) Reset() {
+	*x = ListRisksResponse{}
+	mi := &file_iso9001_v1_qms_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRisksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRisksResponse) ProtoMessage() {}
+
+func (x *ListRisksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_iso9001_v1_qms_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRisksResponse.ProtoReflect.Descriptor instead.
+func (*ListRisksResponse) Descriptor() ([]byte, []int) {
+	return file_iso9001_v1_qms_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListRisksResponse) GetRisks() []*Risk {
+	if x != nil {
+		return x.Risks
+	}
+	return nil
+}
+
+type CreateObjectiveRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Objective     *QualityObjective      `protobuf:"bytes,2,opt,name=objective,proto3" json:"objective,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateObjectiveRequest) Reset() {
+	*x = CreateObjectiveRequest{}
+	mi := &file_iso9001_v1_qms_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateObjectiveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateObjectiveRequest) ProtoMessage() {}
+
+func (x *CreateObjectiveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_iso9001_v1_qms_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateObjectiveRequest.ProtoReflect.Descriptor instead.
+func (*CreateObjectiveRequest) Descriptor() ([]byte, []int) {
+	return file_iso9001_v1_qms_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CreateObjectiveRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *CreateObjectiveRequest) GetObjective() *QualityObjective {
+	if x != nil {
+		return x.Objective
+	}
+	return nil
+}
+
+type ListObjectivesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	OverdueOnly   bool                   `protobuf:"varint,2,opt,name=overdue_only,json=overdueOnly,proto3" json:"overdue_only,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListObjectivesRequest) Reset() {
+	*x = ListObjectivesRequest{}
+	mi := &file_iso9001_v1_qms_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListObjectivesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListObjectivesRequest) ProtoMessage() {}
+
+func (x *ListObjectivesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_iso9001_v1_qms_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListObjectivesRequest.ProtoReflect.Descriptor instead.
+func (*ListObjectivesRequest) Descriptor() ([]byte, []int) {
+	return file_iso9001_v1_qms_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListObjectivesRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *ListObjectivesRequest) GetOverdueOnly() bool {
+	if x != nil {
+		return x.OverdueOnly
+	}
+	return false
+}
+
+type ListObjectivesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Objectives    []*QualityObjective    `protobuf:"bytes,1,rep,name=objectives,proto3" json:"objectives,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListObjectivesResponse) Reset() {
+	*x = ListObjectivesResponse{}
+	mi := &file_iso9001_v1_qms_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListObjectivesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListObjectivesResponse) ProtoMessage() {}
+
+func (x *ListObjectivesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_iso9001_v1_qms_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListObjectivesResponse.ProtoReflect.Descriptor instead.
+func (*ListObjectivesResponse) Descriptor() ([]byte, []int) {
+	return file_iso9001_v1_qms_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListObjectivesResponse) GetObjectives() []*QualityObjective {
+	if x != nil {
+		return x.Objectives
+	}
+	return nil
+}
+
+type AddDocumentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Document      *DocumentedInformation `protobuf:"bytes,2,opt,name=document,proto3" json:"document,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddDocumentRequest) Reset() {
+	*x = AddDocumentRequest{}
+	mi := &file_iso9001_v1_qms_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddDocumentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddDocumentRequest) ProtoMessage() {}
+
+func (x *AddDocumentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_iso9001_v1_qms_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddDocumentRequest.ProtoReflect.Descriptor instead.
+func (*AddDocumentRequest) Descriptor() ([]byte, []int) {
+	return file_iso9001_v1_qms_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *AddDocumentRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *AddDocumentRequest) GetDocument() *DocumentedInformation {
+	if x != nil {
+		return x.Document
+	}
+	return nil
+}
+
+type GetDocumentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	DocumentId    string                 `protobuf:"bytes,2,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDocumentRequest) Reset() {
+	*x = GetDocumentRequest{}
+	mi := &file_iso9001_v1_qms_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDocumentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDocumentRequest) ProtoMessage() {}
+
+func (x *GetDocumentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_iso9001_v1_qms_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDocumentRequest.ProtoReflect.Descriptor instead.
+func (*GetDocumentRequest) Descriptor() ([]byte, []int) {
+	return file_iso9001_v1_qms_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetDocumentRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *GetDocumentRequest) GetDocumentId() string {
+	if x != nil {
+		return x.DocumentId
+	}
+	return ""
+}
+
+type ApproveDocumentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	DocumentId    string                 `protobuf:"bytes,2,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
+	Approval      *Approval              `protobuf:"bytes,3,opt,name=approval,proto3" json:"approval,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApproveDocumentRequest) Reset() {
+	*x = ApproveDocumentRequest{}
+	mi := &file_iso9001_v1_qms_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproveDocumentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveDocumentRequest) ProtoMessage() {}
+
+func (x *ApproveDocumentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_iso9001_v1_qms_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveDocumentRequest.ProtoReflect.Descriptor instead.
+func (*ApproveDocumentRequest) Descriptor() ([]byte, []int) {
+	return file_iso9001_v1_qms_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ApproveDocumentRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *ApproveDocumentRequest) GetDocumentId() string {
+	if x != nil {
+		return x.DocumentId
+	}
+	return ""
+}
+
+func (x *ApproveDocumentRequest) GetApproval() *Approval {
+	if x != nil {
+		return x.Approval
+	}
+	return nil
+}
+
+type SetDocumentStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	DocumentId    string                 `protobuf:"bytes,2,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetDocumentStatusRequest) Reset() {
+	*x = SetDocumentStatusRequest{}
+	mi := &file_iso9001_v1_qms_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetDocumentStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetDocumentStatusRequest) ProtoMessage() {}
+
+func (x *SetDocumentStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_iso9001_v1_qms_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetDocumentStatusRequest.ProtoReflect.Descriptor instead.
+func (*SetDocumentStatusRequest) Descriptor() ([]byte, []int) {
+	return file_iso9001_v1_qms_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SetDocumentStatusRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *SetDocumentStatusRequest) GetDocumentId() string {
+	if x != nil {
+		return x.DocumentId
+	}
+	return ""
+}
+
+func (x *SetDocumentStatusRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type ListDocumentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDocumentsRequest) Reset() {
+	*x = ListDocumentsRequest{}
+	mi := &file_iso9001_v1_qms_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDocumentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDocumentsRequest) ProtoMessage() {}
+
+func (x *ListDocumentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_iso9001_v1_qms_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDocumentsRequest.ProtoReflect.Descriptor instead.
+func (*ListDocumentsRequest) Descriptor() ([]byte, []int) {
+	return file_iso9001_v1_qms_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListDocumentsRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *ListDocumentsRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ListDocumentsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type ListDocumentsResponse struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Documents     []*DocumentedInformation `protobuf:"bytes,1,rep,name=documents,proto3" json:"documents,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDocumentsResponse) Reset() {
+	*x = ListDocumentsResponse{}
+	mi := &file_iso9001_v1_qms_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDocumentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDocumentsResponse) ProtoMessage() {}
+
+func (x *ListDocumentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_iso9001_v1_qms_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDocumentsResponse.ProtoReflect.Descriptor instead.
+func (*ListDocumentsResponse) Descriptor() ([]byte, []int) {
+	return file_iso9001_v1_qms_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListDocumentsResponse) GetDocuments() []*DocumentedInformation {
+	if x != nil {
+		return x.Documents
+	}
+	return nil
+}
+
+type CreateAuditRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Audit         *Audit                 `protobuf:"bytes,2,opt,name=audit,proto3" json:"audit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAuditRequest) Reset() {
+	*x = CreateAuditRequest{}
+	mi := &file_iso9001_v1_qms_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAuditRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAuditRequest) ProtoMessage() {}
+
+func (x *CreateAuditRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_iso9001_v1_qms_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAuditRequest.ProtoReflect.Descriptor instead.
+func (*CreateAuditRequest) Descriptor() ([]byte, []int) {
+	return file_iso9001_v1_qms_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *CreateAuditRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *CreateAuditRequest) GetAudit() *Audit {
+	if x != nil {
+		return x.Audit
+	}
+	return nil
+}
+
+type StartAuditRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	AuditId       string                 `protobuf:"bytes,2,opt,name=audit_id,json=auditId,proto3" json:"audit_id,omitempty"`
+	StartDate     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartAuditRequest) Reset() {
+	*x = StartAuditRequest{}
+	mi := &file_iso9001_v1_qms_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartAuditRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartAuditRequest) ProtoMessage() {}
+
+func (x *StartAuditRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_iso9001_v1_qms_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartAuditRequest.ProtoReflect.Descriptor instead.
+func (*StartAuditRequest) Descriptor() ([]byte, []int) {
+	return file_iso9001_v1_qms_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *StartAuditRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *StartAuditRequest) GetAuditId() string {
+	if x != nil {
+		return x.AuditId
+	}
+	return ""
+}
+
+func (x *StartAuditRequest) GetStartDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartDate
+	}
+	return nil
+}
+
+type AddFindingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	AuditId       string                 `protobuf:"bytes,2,opt,name=audit_id,json=auditId,proto3" json:"audit_id,omitempty"`
+	Finding       *AuditFinding          `protobuf:"bytes,3,opt,name=finding,proto3" json:"finding,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddFindingRequest) Reset() {
+	*x = AddFindingRequest{}
+	mi := &file_iso9001_v1_qms_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddFindingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddFindingRequest) ProtoMessage() {}
+
+func (x *AddFindingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_iso9001_v1_qms_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddFindingRequest.ProtoReflect.Descriptor instead.
+func (*AddFindingRequest) Descriptor() ([]byte, []int) {
+	return file_iso9001_v1_qms_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *AddFindingRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *AddFindingRequest) GetAuditId() string {
+	if x != nil {
+		return x.AuditId
+	}
+	return ""
+}
+
+func (x *AddFindingRequest) GetFinding() *AuditFinding {
+	if x != nil {
+		return x.Finding
+	}
+	return nil
+}
+
+type CompleteAuditRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	AuditId       string                 `protobuf:"bytes,2,opt,name=audit_id,json=auditId,proto3" json:"audit_id,omitempty"`
+	EndDate       *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	Report        *AuditReport           `protobuf:"bytes,4,opt,name=report,proto3" json:"report,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompleteAuditRequest) Reset() {
+	*x = CompleteAuditRequest{}
+	mi := &file_iso9001_v1_qms_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompleteAuditRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompleteAuditRequest) ProtoMessage() {}
+
+func (x *CompleteAuditRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_iso9001_v1_qms_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompleteAuditRequest.ProtoReflect.Descriptor instead.
+func (*CompleteAuditRequest) Descriptor() ([]byte, []int) {
+	return file_iso9001_v1_qms_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *CompleteAuditRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *CompleteAuditRequest) GetAuditId() string {
+	if x != nil {
+		return x.AuditId
+	}
+	return ""
+}
+
+func (x *CompleteAuditRequest) GetEndDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndDate
+	}
+	return nil
+}
+
+func (x *CompleteAuditRequest) GetReport() *AuditReport {
+	if x != nil {
+		return x.Report
+	}
+	return nil
+}
+
+type ListAuditsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuditsRequest) Reset() {
+	*x = ListAuditsRequest{}
+	mi := &file_iso9001_v1_qms_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditsRequest) ProtoMessage() {}
+
+func (x *ListAuditsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_iso9001_v1_qms_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuditsRequest.ProtoReflect.Descriptor instead.
+func (*ListAuditsRequest) Descriptor() ([]byte, []int) {
+	return file_iso9001_v1_qms_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListAuditsRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *ListAuditsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type ListAuditsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Audits        []*Audit               `protobuf:"bytes,1,rep,name=audits,proto3" json:"audits,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuditsResponse) Reset() {
+	*x = ListAuditsResponse{}
+	mi := &file_iso9001_v1_qms_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditsResponse) ProtoMessage() {}
+
+func (x *ListAuditsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_iso9001_v1_qms_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuditsResponse.ProtoReflect.Descriptor instead.
+func (*ListAuditsResponse) Descriptor() ([]byte, []int) {
+	return file_iso9001_v1_qms_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ListAuditsResponse) GetAudits() []*Audit {
+	if x != nil {
+		return x.Audits
+	}
+	return nil
+}
+
+type ExportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportRequest) Reset() {
+	*x = ExportRequest{}
+	mi := &file_iso9001_v1_qms_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportRequest) ProtoMessage() {}
+
+func (x *ExportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_iso9001_v1_qms_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportRequest.ProtoReflect.Descriptor instead.
+func (*ExportRequest) Descriptor() ([]byte, []int) {
+	return file_iso9001_v1_qms_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ExportRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+type ValidationError struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Clause        string                 `protobuf:"bytes,1,opt,name=clause,proto3" json:"clause,omitempty"`
+	Field         string                 `protobuf:"bytes,2,opt,name=field,proto3" json:"field,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	Severity      string                 `protobuf:"bytes,4,opt,name=severity,proto3" json:"severity,omitempty"` // "error", "warning", "info"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidationError) Reset() {
+	*x = ValidationError{}
+	mi := &file_iso9001_v1_qms_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidationError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidationError) ProtoMessage() {}
+
+func (x *ValidationError) ProtoReflect() protoreflect.Message {
+	mi := &file_iso9001_v1_qms_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidationError.ProtoReflect.Descriptor instead.
+func (*ValidationError) Descriptor() ([]byte, []int) {
+	return file_iso9001_v1_qms_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ValidationError) GetClause() string {
+	if x != nil {
+		return x.Clause
+	}
+	return ""
+}
+
+func (x *ValidationError) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *ValidationError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ValidationError) GetSeverity() string {
+	if x != nil {
+		return x.Severity
+	}
+	return ""
+}
+
+type ValidationResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Valid         bool                   `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
+	Errors        []*ValidationError     `protobuf:"bytes,2,rep,name=errors,proto3" json:"errors,omitempty"`
+	Warnings      []*ValidationError     `protobuf:"bytes,3,rep,name=warnings,proto3" json:"warnings,omitempty"`
+	Infos         []*ValidationError     `protobuf:"bytes,4,rep,name=infos,proto3" json:"infos,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidationResult) Reset() {
+	*x = ValidationResult{}
+	mi := &file_iso9001_v1_qms_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidationResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidationResult) ProtoMessage() {}
+
+func (x *ValidationResult) ProtoReflect() protoreflect.Message {
+	mi := &file_iso9001_v1_qms_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidationResult.ProtoReflect.Descriptor instead.
+func (*ValidationResult) Descriptor() ([]byte, []int) {
+	return file_iso9001_v1_qms_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ValidationResult) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *ValidationResult) GetErrors() []*ValidationError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+func (x *ValidationResult) GetWarnings() []*ValidationError {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
+func (x *ValidationResult) GetInfos() []*ValidationError {
+	if x != nil {
+		return x.Infos
+	}
+	return nil
+}
+
+type ComplianceScore struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	OrganizationId string                 `protobuf:"bytes,1,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"`
+	Score          float64                `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ComplianceScore) Reset() {
+	*x = ComplianceScore{}
+	mi := &file_iso9001_v1_qms_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ComplianceScore) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ComplianceScore) ProtoMessage() {}
+
+func (x *ComplianceScore) ProtoReflect() protoreflect.Message {
+	mi := &file_iso9001_v1_qms_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ComplianceScore.ProtoReflect.Descriptor instead.
+func (*ComplianceScore) Descriptor() ([]byte, []int) {
+	return file_iso9001_v1_qms_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ComplianceScore) GetOrganizationId() string {
+	if x != nil {
+		return x.OrganizationId
+	}
+	return ""
+}
+
+func (x *ComplianceScore) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+type ComplianceGap struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Clause        string                 `protobuf:"bytes,1,opt,name=clause,proto3" json:"clause,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Severity      string                 `protobuf:"bytes,3,opt,name=severity,proto3" json:"severity,omitempty"`
+	Priority      string                 `protobuf:"bytes,4,opt,name=priority,proto3" json:"priority,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ComplianceGap) Reset() {
+	*x = ComplianceGap{}
+	mi := &file_iso9001_v1_qms_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ComplianceGap) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ComplianceGap) ProtoMessage() {}
+
+func (x *ComplianceGap) ProtoReflect() protoreflect.Message {
+	mi := &file_iso9001_v1_qms_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ComplianceGap.ProtoReflect.Descriptor instead.
+func (*ComplianceGap) Descriptor() ([]byte, []int) {
+	return file_iso9001_v1_qms_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ComplianceGap) GetClause() string {
+	if x != nil {
+		return x.Clause
+	}
+	return ""
+}
+
+func (x *ComplianceGap) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *ComplianceGap) GetSeverity() string {
+	if x != nil {
+		return x.Severity
+	}
+	return ""
+}
+
+func (x *ComplianceGap) GetPriority() string {
+	if x != nil {
+		return x.Priority
+	}
+	return ""
+}
+
+type ImprovementArea struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Area          string                 `protobuf:"bytes,1,opt,name=area,proto3" json:"area,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Priority      string                 `protobuf:"bytes,3,opt,name=priority,proto3" json:"priority,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImprovementArea) Reset() {
+	*x = ImprovementArea{}
+	mi := &file_iso9001_v1_qms_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImprovementArea) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImprovementArea) ProtoMessage() {}
+
+func (x *ImprovementArea) ProtoReflect() protoreflect.Message {
+	mi := &file_iso9001_v1_qms_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImprovementArea.ProtoReflect.Descriptor instead.
+func (*ImprovementArea) Descriptor() ([]byte, []int) {
+	return file_iso9001_v1_qms_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ImprovementArea) GetArea() string {
+	if x != nil {
+		return x.Area
+	}
+	return ""
+}
+
+func (x *ImprovementArea) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *ImprovementArea) GetPriority() string {
+	if x != nil {
+		return x.Priority
+	}
+	return ""
+}
+
+type ComplianceReport struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	OrganizationId    string                 `protobuf:"bytes,1,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"`
+	AssessmentDate    *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=assessment_date,json=assessmentDate,proto3" json:"assessment_date,omitempty"`
+	OverallCompliance string                 `protobuf:"bytes,3,opt,name=overall_compliance,json=overallCompliance,proto3" json:"overall_compliance,omitempty"`
+	ComplianceScore   float64                `protobuf:"fixed64,4,opt,name=compliance_score,json=complianceScore,proto3" json:"compliance_score,omitempty"`
+	CriticalGaps      []*ComplianceGap       `protobuf:"bytes,5,rep,name=critical_gaps,json=criticalGaps,proto3" json:"critical_gaps,omitempty"`
+	ImprovementAreas  []*ImprovementArea     `protobuf:"bytes,6,rep,name=improvement_areas,json=improvementAreas,proto3" json:"improvement_areas,omitempty"`
+	Strengths         []string               `protobuf:"bytes,7,rep,name=strengths,proto3" json:"strengths,omitempty"`
+	Recommendations   []string               `protobuf:"bytes,8,rep,name=recommendations,proto3" json:"recommendations,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ComplianceReport) Reset() {
+	*x = ComplianceReport{}
+	mi := &file_iso9001_v1_qms_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ComplianceReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ComplianceReport) ProtoMessage() {}
+
+func (x *ComplianceReport) ProtoReflect() protoreflect.Message {
+	mi := &file_iso9001_v1_qms_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ComplianceReport.ProtoReflect.Descriptor instead.
+func (*ComplianceReport) Descriptor() ([]byte, []int) {
+	return file_iso9001_v1_qms_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ComplianceReport) GetOrganizationId() string {
+	if x != nil {
+		return x.OrganizationId
+	}
+	return ""
+}
+
+func (x *ComplianceReport) GetAssessmentDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AssessmentDate
+	}
+	return nil
+}
+
+func (x *ComplianceReport) GetOverallCompliance() string {
+	if x != nil {
+		return x.OverallCompliance
+	}
+	return ""
+}
+
+func (x *ComplianceReport) GetComplianceScore() float64 {
+	if x != nil {
+		return x.ComplianceScore
+	}
+	return 0
+}
+
+func (x *ComplianceReport) GetCriticalGaps() []*ComplianceGap {
+	if x != nil {
+		return x.CriticalGaps
+	}
+	return nil
+}
+
+func (x *ComplianceReport) GetImprovementAreas() []*ImprovementArea {
+	if x != nil {
+		return x.ImprovementAreas
+	}
+	return nil
+}
+
+func (x *ComplianceReport) GetStrengths() []string {
+	if x != nil {
+		return x.Strengths
+	}
+	return nil
+}
+
+func (x *ComplianceReport) GetRecommendations() []string {
+	if x != nil {
+		return x.Recommendations
+	}
+	return nil
+}
+
+type Organization struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	SchemaVersion int32                    `protobuf:"varint,1,opt,name=schema_version,json=schemaVersion,proto3" json:"schema_version,omitempty"`
+	Id            string                   `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                   `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Context       *OrganizationalContext   `protobuf:"bytes,4,opt,name=context,proto3" json:"context,omitempty"`
+	Leadership    *Leadership              `protobuf:"bytes,5,opt,name=leadership,proto3" json:"leadership,omitempty"`
+	Qms           *QualityManagementSystem `protobuf:"bytes,6,opt,name=qms,proto3" json:"qms,omitempty"`
+	TimeZone      string                   `protobuf:"bytes,7,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+	Created       *timestamppb.Timestamp   `protobuf:"bytes,8,opt,name=created,proto3" json:"created,omitempty"`
+	Modified      *timestamppb.Timestamp   `protobuf:"bytes,9,opt,name=modified,proto3" json:"modified,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Organization) Reset() {
+	*x = Organization{}
+	mi := &file_iso9001_v1_qms_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Organization) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Organization) ProtoMessage() {}
+
+func (x *Organization) ProtoReflect() protoreflect.Message {
+	mi := &file_iso9001_v1_qms_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Organization.ProtoReflect.Descriptor instead.
+func (*Organization) Descriptor() ([]byte, []int) {
+	return file_iso9001_v1_qms_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *Organization) GetSchemaVersion() int32 {
+	if x != nil {
+		return x.SchemaVersion
+	}
+	return 0
+}
+
+func (x *Organization) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Organization) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Organization) GetContext() *OrganizationalContext {
+	if x != nil {
+		return x.Context
+	}
+	return nil
+}
+
+func (x *Organization) GetLeadership() *Leadership {
+	if x != nil {
+		return x.Leadership
+	}
+	return nil
+}
+
+func (x *Organization) GetQms() *QualityManagementSystem {
+	if x != nil {
+		return x.Qms
+	}
+	return nil
+}
+
+func (x *Organization) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+func (x *Organization) GetCreated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Created
+	}
+	return nil
+}
+
+func (x *Organization) GetModified() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Modified
+	}
+	return nil
+}
+
+type OrganizationalContext struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	ExternalIssues    []*Issue               `protobuf:"bytes,1,rep,name=external_issues,json=externalIssues,proto3" json:"external_issues,omitempty"`
+	InternalIssues    []*Issue               `protobuf:"bytes,2,rep,name=internal_issues,json=internalIssues,proto3" json:"internal_issues,omitempty"`
+	InterestedParties []*InterestedParty     `protobuf:"bytes,3,rep,name=interested_parties,json=interestedParties,proto3" json:"interested_parties,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *OrganizationalContext) Reset() {
+	*x = OrganizationalContext{}
+	mi := &file_iso9001_v1_qms_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrganizationalContext) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrganizationalContext) ProtoMessage() {}
+
+func (x *OrganizationalContext) ProtoReflect() protoreflect.Message {
+	mi := &file_iso9001_v1_qms_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrganizationalContext.ProtoReflect.Descriptor instead.
+func (*OrganizationalContext) Descriptor() ([]byte, []int) {
+	return file_iso9001_v1_qms_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *OrganizationalContext) GetExternalIssues() []*Issue {
+	if x != nil {
+		return x.ExternalIssues
+	}
+	return nil
+}
+
+func (x *OrganizationalContext) GetInternalIssues() []*Issue {
+	if x != nil {
+		return x.InternalIssues
+	}
+	return nil
+}
+
+func (x *OrganizationalContext) GetInterestedParties() []*InterestedParty {
+	if x != nil {
+		return x.InterestedParties
+	}
+	return nil
+}
+
+type Issue struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Impact        string                 `protobuf:"bytes,4,opt,name=impact,proto3" json:"impact,omitempty"`
+	Status        string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	Created       *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created,proto3" json:"created,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Issue) Reset() {
+	*x = Issue{}
+	mi := &file_iso9001_v1_qms_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Issue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Issue) ProtoMessage() {}
+
+func (x *Issue) ProtoReflect() protoreflect.Message {
+	mi := &file_iso9001_v1_qms_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Issue.ProtoReflect.Descriptor instead.
+func (*Issue) Descriptor() ([]byte, []int) {
+	return file_iso9001_v1_qms_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *Issue) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Issue) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Issue) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Issue) GetImpact() string {
+	if x != nil {
+		return x.Impact
+	}
+	return ""
+}
+
+func (x *Issue) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Issue) GetCreated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Created
+	}
+	return nil
+}
+
+type InterestedParty struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Requirements  []string               `protobuf:"bytes,4,rep,name=requirements,proto3" json:"requirements,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InterestedParty) Reset() {
+	*x = InterestedParty{}
+	mi := &file_iso9001_v1_qms_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InterestedParty) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InterestedParty) ProtoMessage() {}
+
+func (x *InterestedParty) ProtoReflect() protoreflect.Message {
+	mi := &file_iso9001_v1_qms_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InterestedParty.ProtoReflect.Descriptor instead.
+func (*InterestedParty) Descriptor() ([]byte, []int) {
+	return file_iso9001_v1_qms_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *InterestedParty) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *InterestedParty) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *InterestedParty) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *InterestedParty) GetRequirements() []string {
+	if x != nil {
+		return x.Requirements
+	}
+	return nil
+}
+
+type Leadership struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TopManagement []*Person              `protobuf:"bytes,1,rep,name=top_management,json=topManagement,proto3" json:"top_management,omitempty"`
+	QualityPolicy *QualityPolicy         `protobuf:"bytes,2,opt,name=quality_policy,json=qualityPolicy,proto3" json:"quality_policy,omitempty"`
+	Roles         []*OrganizationalRole  `protobuf:"bytes,3,rep,name=roles,proto3" json:"roles,omitempty"`
+	Commitment    []string               `protobuf:"bytes,4,rep,name=commitment,proto3" json:"commitment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Leadership) Reset() {
+	*x = Leadership{}
+	mi := &file_iso9001_v1_qms_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Leadership) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Leadership) ProtoMessage() {}
+
+func (x *Leadership) ProtoReflect() protoreflect.Message {
+	mi := &file_iso9001_v1_qms_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Leadership.ProtoReflect.Descriptor instead.
+func (*Leadership) Descriptor() ([]byte, []int) {
+	return file_iso9001_v1_qms_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *Leadership) GetTopManagement() []*Person {
+	if x != nil {
+		return x.TopManagement
+	}
+	return nil
+}
+
+func (x *Leadership) GetQualityPolicy() *QualityPolicy {
+	if x != nil {
+		return x.QualityPolicy
+	}
+	return nil
+}
+
+func (x *Leadership) GetRoles() []*OrganizationalRole {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+func (x *Leadership) GetCommitment() []string {
+	if x != nil {
+		return x.Commitment
+	}
+	return nil
+}
+
+type Person struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Role          string                 `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
+	Competence    []string               `protobuf:"bytes,4,rep,name=competence,proto3" json:"competence,omitempty"`
+	Training      []string               `protobuf:"bytes,5,rep,name=training,proto3" json:"training,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Person) Reset() {
+	*x = Person{}
+	mi := &file_iso9001_v1_qms_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Person) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Person) ProtoMessage() {}
+
+func (x *Person) ProtoReflect() protoreflect.Message {
+	mi := &file_iso9001_v1_qms_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Person.ProtoReflect.Descriptor instead.
+func (*Person) Descriptor() ([]byte, []int) {
+	return file_iso9001_v1_qms_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *Person) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Person) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Person) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *Person) GetCompetence() []string {
+	if x != nil {
+		return x.Competence
+	}
+	return nil
+}
+
+func (x *Person) GetTraining() []string {
+	if x != nil {
+		return x.Training
+	}
+	return nil
+}
+
+type OrganizationalRole struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name             string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Responsibilities []string               `protobuf:"bytes,3,rep,name=responsibilities,proto3" json:"responsibilities,omitempty"`
+	Authorities      []string               `protobuf:"bytes,4,rep,name=authorities,proto3" json:"authorities,omitempty"`
+	AssignedTo       string                 `protobuf:"bytes,5,opt,name=assigned_to,json=assignedTo,proto3" json:"assigned_to,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *OrganizationalRole) Reset() {
+	*x = OrganizationalRole{}
+	mi := &file_iso9001_v1_qms_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrganizationalRole) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrganizationalRole) ProtoMessage() {}
+
+func (x *OrganizationalRole) ProtoReflect() protoreflect.Message {
+	mi := &file_iso9001_v1_qms_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrganizationalRole.ProtoReflect.Descriptor instead.
+func (*OrganizationalRole) Descriptor() ([]byte, []int) {
+	return file_iso9001_v1_qms_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *OrganizationalRole) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *OrganizationalRole) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *OrganizationalRole) GetResponsibilities() []string {
+	if x != nil {
+		return x.Responsibilities
+	}
+	return nil
+}
+
+func (x *OrganizationalRole) GetAuthorities() []string {
+	if x != nil {
+		return x.Authorities
+	}
+	return nil
+}
+
+func (x *OrganizationalRole) GetAssignedTo() string {
+	if x != nil {
+		return x.AssignedTo
+	}
+	return ""
+}
+
+type QualityPolicy struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Statement     string                 `protobuf:"bytes,2,opt,name=statement,proto3" json:"statement,omitempty"`
+	Objectives    string                 `protobuf:"bytes,3,opt,name=objectives,proto3" json:"objectives,omitempty"`
+	Commitment    string                 `protobuf:"bytes,4,opt,name=commitment,proto3" json:"commitment,omitempty"`
+	Improvement   string                 `protobuf:"bytes,5,opt,name=improvement,proto3" json:"improvement,omitempty"`
+	Communicated  bool                   `protobuf:"varint,6,opt,name=communicated,proto3" json:"communicated,omitempty"`
+	Available     bool                   `protobuf:"varint,7,opt,name=available,proto3" json:"available,omitempty"`
+	Created       *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created,proto3" json:"created,omitempty"`
+	Updated       *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated,proto3" json:"updated,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QualityPolicy) Reset() {
+	*x = QualityPolicy{}
+	mi := &file_iso9001_v1_qms_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QualityPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QualityPolicy) ProtoMessage() {}
+
+func (x *QualityPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_iso9001_v1_qms_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QualityPolicy.ProtoReflect.Descriptor instead.
+func (*QualityPolicy) Descriptor() ([]byte, []int) {
+	return file_iso9001_v1_qms_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *QualityPolicy) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *QualityPolicy) GetStatement() string {
+	if x != nil {
+		return x.Statement
+	}
+	return ""
+}
+
+func (x *QualityPolicy) GetObjectives() string {
+	if x != nil {
+		return x.Objectives
+	}
+	return ""
+}
+
+func (x *QualityPolicy) GetCommitment() string {
+	if x != nil {
+		return x.Commitment
+	}
+	return ""
+}
+
+func (x *QualityPolicy) GetImprovement() string {
+	if x != nil {
+		return x.Improvement
+	}
+	return ""
+}
+
+func (x *QualityPolicy) GetCommunicated() bool {
+	if x != nil {
+		return x.Communicated
+	}
+	return false
+}
+
+func (x *QualityPolicy) GetAvailable() bool {
+	if x != nil {
+		return x.Available
+	}
+	return false
+}
+
+func (x *QualityPolicy) GetCreated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Created
+	}
+	return nil
+}
+
+func (x *QualityPolicy) GetUpdated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Updated
+	}
+	return nil
+}
+
+type QualityManagementSystem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Scope         *QMSScope              `protobuf:"bytes,2,opt,name=scope,proto3" json:"scope,omitempty"`
+	Processes     []*Process             `protobuf:"bytes,3,rep,name=processes,proto3" json:"processes,omitempty"`
+	Objectives    []*QualityObjective    `protobuf:"bytes,4,rep,name=objectives,proto3" json:"objectives,omitempty"`
+	Risks         []*Risk                `protobuf:"bytes,5,rep,name=risks,proto3" json:"risks,omitempty"`
+	Opportunities []*Opportunity         `protobuf:"bytes,6,rep,name=opportunities,proto3" json:"opportunities,omitempty"`
+	Created       *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created,proto3" json:"created,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QualityManagementSystem) Reset() {
+	*x = QualityManagementSystem{}
+	mi := &file_iso9001_v1_qms_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QualityManagementSystem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QualityManagementSystem) ProtoMessage() {}
+
+func (x *QualityManagementSystem) ProtoReflect() protoreflect.Message {
+	mi := &file_iso9001_v1_qms_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QualityManagementSystem.ProtoReflect.Descriptor instead.
+func (*QualityManagementSystem) Descriptor() ([]byte, []int) {
+	return file_iso9001_v1_qms_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *QualityManagementSystem) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *QualityManagementSystem) GetScope() *QMSScope {
+	if x != nil {
+		return x.Scope
+	}
+	return nil
+}
+
+func (x *QualityManagementSystem) GetProcesses() []*Process {
+	if x != nil {
+		return x.Processes
+	}
+	return nil
+}
+
+func (x *QualityManagementSystem) GetObjectives() []*QualityObjective {
+	if x != nil {
+		return x.Objectives
+	}
+	return nil
+}
+
+func (x *QualityManagementSystem) GetRisks() []*Risk {
+	if x != nil {
+		return x.Risks
+	}
+	return nil
+}
+
+func (x *QualityManagementSystem) GetOpportunities() []*Opportunity {
+	if x != nil {
+		return x.Opportunities
+	}
+	return nil
+}
+
+func (x *QualityManagementSystem) GetCreated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Created
+	}
+	return nil
+}
+
+type QMSScope struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Description   string                 `protobuf:"bytes,1,opt,name=description,proto3" json:"description,omitempty"`
+	Products      []string               `protobuf:"bytes,2,rep,name=products,proto3" json:"products,omitempty"`
+	Services      []string               `protobuf:"bytes,3,rep,name=services,proto3" json:"services,omitempty"`
+	Exclusions    []*Exclusion           `protobuf:"bytes,4,rep,name=exclusions,proto3" json:"exclusions,omitempty"`
+	Justification string                 `protobuf:"bytes,5,opt,name=justification,proto3" json:"justification,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QMSScope) Reset() {
+	*x = QMSScope{}
+	mi := &file_iso9001_v1_qms_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QMSScope) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QMSScope) ProtoMessage() {}
+
+func (x *QMSScope) ProtoReflect() protoreflect.Message {
+	mi := &file_iso9001_v1_qms_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QMSScope.ProtoReflect.Descriptor instead.
+func (*QMSScope) Descriptor() ([]byte, []int) {
+	return file_iso9001_v1_qms_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *QMSScope) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *QMSScope) GetProducts() []string {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+func (x *QMSScope) GetServices() []string {
+	if x != nil {
+		return x.Services
+	}
+	return nil
+}
+
+func (x *QMSScope) GetExclusions() []*Exclusion {
+	if x != nil {
+		return x.Exclusions
+	}
+	return nil
+}
+
+func (x *QMSScope) GetJustification() string {
+	if x != nil {
+		return x.Justification
+	}
+	return ""
+}
+
+type Exclusion struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Clause        string                 `protobuf:"bytes,1,opt,name=clause,proto3" json:"clause,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Justification string                 `protobuf:"bytes,3,opt,name=justification,proto3" json:"justification,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Exclusion) Reset() {
+	*x = Exclusion{}
+	mi := &file_iso9001_v1_qms_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Exclusion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Exclusion) ProtoMessage() {}
+
+func (x *Exclusion) ProtoReflect() protoreflect.Message {
+	mi := &file_iso9001_v1_qms_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Exclusion.ProtoReflect.Descriptor instead.
+func (*Exclusion) Descriptor() ([]byte, []int) {
+	return file_iso9001_v1_qms_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *Exclusion) GetClause() string {
+	if x != nil {
+		return x.Clause
+	}
+	return ""
+}
+
+func (x *Exclusion) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Exclusion) GetJustification() string {
+	if x != nil {
+		return x.Justification
+	}
+	return ""
+}
+
+type Process struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name             string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description      string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Responsibilities []string               `protobuf:"bytes,4,rep,name=responsibilities,proto3" json:"responsibilities,omitempty"`
+	Risks            []*Risk                `protobuf:"bytes,5,rep,name=risks,proto3" json:"risks,omitempty"`
+	Opportunities    []*Opportunity         `protobuf:"bytes,6,rep,name=opportunities,proto3" json:"opportunities,omitempty"`
+	Status           string                 `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
+	Created          *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created,proto3" json:"created,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Process) Reset() {
+	*x = Process{}
+	mi := &file_iso9001_v1_qms_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Process) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Process) ProtoMessage() {}
+
+func (x *Process) ProtoReflect() protoreflect.Message {
+	mi := &file_iso9001_v1_qms_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Process.ProtoReflect.Descriptor instead.
+func (*Process) Descriptor() ([]byte, []int) {
+	return file_iso9001_v1_qms_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *Process) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Process) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Process) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Process) GetResponsibilities() []string {
+	if x != nil {
+		return x.Responsibilities
+	}
+	return nil
+}
+
+func (x *Process) GetRisks() []*Risk {
+	if x != nil {
+		return x.Risks
+	}
+	return nil
+}
+
+func (x *Process) GetOpportunities() []*Opportunity {
+	if x != nil {
+		return x.Opportunities
+	}
+	return nil
+}
+
+func (x *Process) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Process) GetCreated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Created
+	}
+	return nil
+}
+
+type QualityObjective struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Measurable    bool                   `protobuf:"varint,4,opt,name=measurable,proto3" json:"measurable,omitempty"`
+	Targets       []*ObjectiveTarget     `protobuf:"bytes,5,rep,name=targets,proto3" json:"targets,omitempty"`
+	Responsible   string                 `protobuf:"bytes,6,opt,name=responsible,proto3" json:"responsible,omitempty"`
+	Timeline      *ObjectiveTimeline     `protobuf:"bytes,7,opt,name=timeline,proto3" json:"timeline,omitempty"`
+	Status        string                 `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"`
+	Created       *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created,proto3" json:"created,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QualityObjective) Reset() {
+	*x = QualityObjective{}
+	mi := &file_iso9001_v1_qms_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QualityObjective) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QualityObjective) ProtoMessage() {}
+
+func (x *QualityObjective) ProtoReflect() protoreflect.Message {
+	mi := &file_iso9001_v1_qms_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QualityObjective.ProtoReflect.Descriptor instead.
+func (*QualityObjective) Descriptor() ([]byte, []int) {
+	return file_iso9001_v1_qms_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *QualityObjective) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *QualityObjective) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *QualityObjective) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *QualityObjective) GetMeasurable() bool {
+	if x != nil {
+		return x.Measurable
+	}
+	return false
+}
+
+func (x *QualityObjective) GetTargets() []*ObjectiveTarget {
+	if x != nil {
+		return x.Targets
+	}
+	return nil
+}
+
+func (x *QualityObjective) GetResponsible() string {
+	if x != nil {
+		return x.Responsible
+	}
+	return ""
+}
+
+func (x *QualityObjective) GetTimeline() *ObjectiveTimeline {
+	if x != nil {
+		return x.Timeline
+	}
+	return nil
+}
+
+func (x *QualityObjective) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *QualityObjective) GetCreated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Created
+	}
+	return nil
+}
+
+type ObjectiveTarget struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Metric        string                 `protobuf:"bytes,2,opt,name=metric,proto3" json:"metric,omitempty"`
+	Value         string                 `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	Unit          string                 `protobuf:"bytes,4,opt,name=unit,proto3" json:"unit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ObjectiveTarget) Reset() {
+	*x = ObjectiveTarget{}
+	mi := &file_iso9001_v1_qms_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ObjectiveTarget) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ObjectiveTarget) ProtoMessage() {}
+
+func (x *ObjectiveTarget) ProtoReflect() protoreflect.Message {
+	mi := &file_iso9001_v1_qms_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ObjectiveTarget.ProtoReflect.Descriptor instead.
+func (*ObjectiveTarget) Descriptor() ([]byte, []int) {
+	return file_iso9001_v1_qms_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ObjectiveTarget) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ObjectiveTarget) GetMetric() string {
+	if x != nil {
+		return x.Metric
+	}
+	return ""
+}
+
+func (x *ObjectiveTarget) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *ObjectiveTarget) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+type ObjectiveTimeline struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StartDate     *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	TargetDate    *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=target_date,json=targetDate,proto3" json:"target_date,omitempty"`
+	ReviewDate    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=review_date,json=reviewDate,proto3" json:"review_date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ObjectiveTimeline) Reset() {
+	*x = ObjectiveTimeline{}
+	mi := &file_iso9001_v1_qms_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ObjectiveTimeline) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ObjectiveTimeline) ProtoMessage() {}
+
+func (x *ObjectiveTimeline) ProtoReflect() protoreflect.Message {
+	mi := &file_iso9001_v1_qms_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ObjectiveTimeline.ProtoReflect.Descriptor instead.
+func (*ObjectiveTimeline) Descriptor() ([]byte, []int) {
+	return file_iso9001_v1_qms_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *ObjectiveTimeline) GetStartDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartDate
+	}
+	return nil
+}
+
+func (x *ObjectiveTimeline) GetTargetDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.TargetDate
+	}
+	return nil
+}
+
+func (x *ObjectiveTimeline) GetReviewDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReviewDate
+	}
+	return nil
+}
+
+type Risk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Causes        []string               `protobuf:"bytes,3,rep,name=causes,proto3" json:"causes,omitempty"`
+	Effects       []string               `protobuf:"bytes,4,rep,name=effects,proto3" json:"effects,omitempty"`
+	Likelihood    string                 `protobuf:"bytes,5,opt,name=likelihood,proto3" json:"likelihood,omitempty"`
+	Impact        string                 `protobuf:"bytes,6,opt,name=impact,proto3" json:"impact,omitempty"`
+	Priority      string                 `protobuf:"bytes,7,opt,name=priority,proto3" json:"priority,omitempty"`
+	Mitigation    []*Action              `protobuf:"bytes,8,rep,name=mitigation,proto3" json:"mitigation,omitempty"`
+	Status        string                 `protobuf:"bytes,9,opt,name=status,proto3" json:"status,omitempty"`
+	Created       *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created,proto3" json:"created,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Risk) Reset() {
+	*x = Risk{}
+	mi := &file_iso9001_v1_qms_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Risk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Risk) ProtoMessage() {}
+
+func (x *Risk) ProtoReflect() protoreflect.Message {
+	mi := &file_iso9001_v1_qms_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Risk.ProtoReflect.Descriptor instead.
+func (*Risk) Descriptor() ([]byte, []int) {
+	return file_iso9001_v1_qms_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *Risk) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Risk) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Risk) GetCauses() []string {
+	if x != nil {
+		return x.Causes
+	}
+	return nil
+}
+
+func (x *Risk) GetEffects() []string {
+	if x != nil {
+		return x.Effects
+	}
+	return nil
+}
+
+func (x *Risk) GetLikelihood() string {
+	if x != nil {
+		return x.Likelihood
+	}
+	return ""
+}
+
+func (x *Risk) GetImpact() string {
+	if x != nil {
+		return x.Impact
+	}
+	return ""
+}
+
+func (x *Risk) GetPriority() string {
+	if x != nil {
+		return x.Priority
+	}
+	return ""
+}
+
+func (x *Risk) GetMitigation() []*Action {
+	if x != nil {
+		return x.Mitigation
+	}
+	return nil
+}
+
+func (x *Risk) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Risk) GetCreated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Created
+	}
+	return nil
+}
+
+type Opportunity struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Benefits      []string               `protobuf:"bytes,3,rep,name=benefits,proto3" json:"benefits,omitempty"`
+	Likelihood    string                 `protobuf:"bytes,4,opt,name=likelihood,proto3" json:"likelihood,omitempty"`
+	Impact        string                 `protobuf:"bytes,5,opt,name=impact,proto3" json:"impact,omitempty"`
+	Priority      int32                  `protobuf:"varint,6,opt,name=priority,proto3" json:"priority,omitempty"`
+	Actions       []*Action              `protobuf:"bytes,7,rep,name=actions,proto3" json:"actions,omitempty"`
+	Status        string                 `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"`
+	Created       *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created,proto3" json:"created,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Opportunity) Reset() {
+	*x = Opportunity{}
+	mi := &file_iso9001_v1_qms_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Opportunity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Opportunity) ProtoMessage() {}
+
+func (x *Opportunity) ProtoReflect() protoreflect.Message {
+	mi := &file_iso9001_v1_qms_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Opportunity.ProtoReflect.Descriptor instead.
+func (*Opportunity) Descriptor() ([]byte, []int) {
+	return file_iso9001_v1_qms_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *Opportunity) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Opportunity) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Opportunity) GetBenefits() []string {
+	if x != nil {
+		return x.Benefits
+	}
+	return nil
+}
+
+func (x *Opportunity) GetLikelihood() string {
+	if x != nil {
+		return x.Likelihood
+	}
+	return ""
+}
+
+func (x *Opportunity) GetImpact() string {
+	if x != nil {
+		return x.Impact
+	}
+	return ""
+}
+
+func (x *Opportunity) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *Opportunity) GetActions() []*Action {
+	if x != nil {
+		return x.Actions
+	}
+	return nil
+}
+
+func (x *Opportunity) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Opportunity) GetCreated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Created
+	}
+	return nil
+}
+
+type Action struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Responsible   string                 `protobuf:"bytes,4,opt,name=responsible,proto3" json:"responsible,omitempty"`
+	Timeline      *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=timeline,proto3" json:"timeline,omitempty"`
+	Status        string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	Created       *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created,proto3" json:"created,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Action) Reset() {
+	*x = Action{}
+	mi := &file_iso9001_v1_qms_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Action) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Action) ProtoMessage() {}
+
+func (x *Action) ProtoReflect() protoreflect.Message {
+	mi := &file_iso9001_v1_qms_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Action.ProtoReflect.Descriptor instead.
+func (*Action) Descriptor() ([]byte, []int) {
+	return file_iso9001_v1_qms_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *Action) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Action) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Action) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Action) GetResponsible() string {
+	if x != nil {
+		return x.Responsible
+	}
+	return ""
+}
+
+func (x *Action) GetTimeline() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timeline
+	}
+	return nil
+}
+
+func (x *Action) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Action) GetCreated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Created
+	}
+	return nil
+}
+
+type DocumentedInformation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Category      string                 `protobuf:"bytes,4,opt,name=category,proto3" json:"category,omitempty"`
+	Content       string                 `protobuf:"bytes,5,opt,name=content,proto3" json:"content,omitempty"`
+	Metadata      *DocumentMetadata      `protobuf:"bytes,6,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Approval      *DocumentApproval      `protobuf:"bytes,7,opt,name=approval,proto3" json:"approval,omitempty"`
+	Status        string                 `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"`
+	Versions      []*DocumentVersion     `protobuf:"bytes,9,rep,name=versions,proto3" json:"versions,omitempty"`
+	RevisionOf    string                 `protobuf:"bytes,10,opt,name=revision_of,json=revisionOf,proto3" json:"revision_of,omitempty"`
+	SupersededBy  string                 `protobuf:"bytes,11,opt,name=superseded_by,json=supersededBy,proto3" json:"superseded_by,omitempty"`
+	Created       *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=created,proto3" json:"created,omitempty"`
+	Modified      *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=modified,proto3" json:"modified,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DocumentedInformation) Reset() {
+	*x = DocumentedInformation{}
+	mi := &file_iso9001_v1_qms_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DocumentedInformation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DocumentedInformation) ProtoMessage() {}
+
+func (x *DocumentedInformation) ProtoReflect() protoreflect.Message {
+	mi := &file_iso9001_v1_qms_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DocumentedInformation.ProtoReflect.Descriptor instead.
+func (*DocumentedInformation) Descriptor() ([]byte, []int) {
+	return file_iso9001_v1_qms_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *DocumentedInformation) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DocumentedInformation) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *DocumentedInformation) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *DocumentedInformation) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *DocumentedInformation) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *DocumentedInformation) GetMetadata() *DocumentMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *DocumentedInformation) GetApproval() *DocumentApproval {
+	if x != nil {
+		return x.Approval
+	}
+	return nil
+}
+
+func (x *DocumentedInformation) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *DocumentedInformation) GetVersions() []*DocumentVersion {
+	if x != nil {
+		return x.Versions
+	}
+	return nil
+}
+
+func (x *DocumentedInformation) GetRevisionOf() string {
+	if x != nil {
+		return x.RevisionOf
+	}
+	return ""
+}
+
+func (x *DocumentedInformation) GetSupersededBy() string {
+	if x != nil {
+		return x.SupersededBy
+	}
+	return ""
+}
+
+func (x *DocumentedInformation) GetCreated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Created
+	}
+	return nil
+}
+
+func (x *DocumentedInformation) GetModified() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Modified
+	}
+	return nil
+}
+
+type DocumentMetadata struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Author           string                 `protobuf:"bytes,1,opt,name=author,proto3" json:"author,omitempty"`
+	Owner            string                 `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"`
+	Keywords         []string               `protobuf:"bytes,3,rep,name=keywords,proto3" json:"keywords,omitempty"`
+	RelatedClauses   []string               `protobuf:"bytes,4,rep,name=related_clauses,json=relatedClauses,proto3" json:"related_clauses,omitempty"`
+	RelatedDocuments []string               `protobuf:"bytes,5,rep,name=related_documents,json=relatedDocuments,proto3" json:"related_documents,omitempty"`
+	Format           string                 `protobuf:"bytes,6,opt,name=format,proto3" json:"format,omitempty"`
+	Language         string                 `protobuf:"bytes,7,opt,name=language,proto3" json:"language,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *DocumentMetadata) Reset() {
+	*x = DocumentMetadata{}
+	mi := &file_iso9001_v1_qms_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DocumentMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DocumentMetadata) ProtoMessage() {}
+
+func (x *DocumentMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_iso9001_v1_qms_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DocumentMetadata.ProtoReflect.Descriptor instead.
+func (*DocumentMetadata) Descriptor() ([]byte, []int) {
+	return file_iso9001_v1_qms_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *DocumentMetadata) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *DocumentMetadata) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *DocumentMetadata) GetKeywords() []string {
+	if x != nil {
+		return x.Keywords
+	}
+	return nil
+}
+
+func (x *DocumentMetadata) GetRelatedClauses() []string {
+	if x != nil {
+		return x.RelatedClauses
+	}
+	return nil
+}
+
+func (x *DocumentMetadata) GetRelatedDocuments() []string {
+	if x != nil {
+		return x.RelatedDocuments
+	}
+	return nil
+}
+
+func (x *DocumentMetadata) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *DocumentMetadata) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+type DocumentApproval struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	RequiredApprovers []string               `protobuf:"bytes,1,rep,name=required_approvers,json=requiredApprovers,proto3" json:"required_approvers,omitempty"`
+	ActualApprovers   []*Approval            `protobuf:"bytes,2,rep,name=actual_approvers,json=actualApprovers,proto3" json:"actual_approvers,omitempty"`
+	Status            string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *DocumentApproval) Reset() {
+	*x = DocumentApproval{}
+	mi := &file_iso9001_v1_qms_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DocumentApproval) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DocumentApproval) ProtoMessage() {}
+
+func (x *DocumentApproval) ProtoReflect() protoreflect.Message {
+	mi := &file_iso9001_v1_qms_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DocumentApproval.ProtoReflect.Descriptor instead.
+func (*DocumentApproval) Descriptor() ([]byte, []int) {
+	return file_iso9001_v1_qms_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *DocumentApproval) GetRequiredApprovers() []string {
+	if x != nil {
+		return x.RequiredApprovers
+	}
+	return nil
+}
+
+func (x *DocumentApproval) GetActualApprovers() []*Approval {
+	if x != nil {
+		return x.ActualApprovers
+	}
+	return nil
+}
+
+func (x *DocumentApproval) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type Approval struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ApproverId    string                 `protobuf:"bytes,1,opt,name=approver_id,json=approverId,proto3" json:"approver_id,omitempty"`
+	ApproverName  string                 `protobuf:"bytes,2,opt,name=approver_name,json=approverName,proto3" json:"approver_name,omitempty"`
+	Role          string                 `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Comments      string                 `protobuf:"bytes,5,opt,name=comments,proto3" json:"comments,omitempty"`
+	Version       string                 `protobuf:"bytes,6,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Approval) Reset() {
+	*x = Approval{}
+	mi := &file_iso9001_v1_qms_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Approval) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Approval) ProtoMessage() {}
+
+func (x *Approval) ProtoReflect() protoreflect.Message {
+	mi := &file_iso9001_v1_qms_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Approval.ProtoReflect.Descriptor instead.
+func (*Approval) Descriptor() ([]byte, []int) {
+	return file_iso9001_v1_qms_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *Approval) GetApproverId() string {
+	if x != nil {
+		return x.ApproverId
+	}
+	return ""
+}
+
+func (x *Approval) GetApproverName() string {
+	if x != nil {
+		return x.ApproverName
+	}
+	return ""
+}
+
+func (x *Approval) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *Approval) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *Approval) GetComments() string {
+	if x != nil {
+		return x.Comments
+	}
+	return ""
+}
+
+func (x *Approval) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+type DocumentVersion struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VersionNumber string                 `protobuf:"bytes,1,opt,name=version_number,json=versionNumber,proto3" json:"version_number,omitempty"`
+	ChangeSummary string                 `protobuf:"bytes,2,opt,name=change_summary,json=changeSummary,proto3" json:"change_summary,omitempty"`
+	CreatedBy     string                 `protobuf:"bytes,3,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DocumentVersion) Reset() {
+	*x = DocumentVersion{}
+	mi := &file_iso9001_v1_qms_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DocumentVersion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DocumentVersion) ProtoMessage() {}
+
+func (x *DocumentVersion) ProtoReflect() protoreflect.Message {
+	mi := &file_iso9001_v1_qms_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DocumentVersion.ProtoReflect.Descriptor instead.
+func (*DocumentVersion) Descriptor() ([]byte, []int) {
+	return file_iso9001_v1_qms_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *DocumentVersion) GetVersionNumber() string {
+	if x != nil {
+		return x.VersionNumber
+	}
+	return ""
+}
+
+func (x *DocumentVersion) GetChangeSummary() string {
+	if x != nil {
+		return x.ChangeSummary
+	}
+	return ""
+}
+
+func (x *DocumentVersion) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *DocumentVersion) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type Audit struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title            string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Type             string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Scope            *AuditScope            `protobuf:"bytes,4,opt,name=scope,proto3" json:"scope,omitempty"`
+	PlannedStartDate *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=planned_start_date,json=plannedStartDate,proto3" json:"planned_start_date,omitempty"`
+	PlannedEndDate   *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=planned_end_date,json=plannedEndDate,proto3" json:"planned_end_date,omitempty"`
+	ActualStartDate  *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=actual_start_date,json=actualStartDate,proto3" json:"actual_start_date,omitempty"`
+	ActualEndDate    *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=actual_end_date,json=actualEndDate,proto3" json:"actual_end_date,omitempty"`
+	Auditors         []*AuditParticipant    `protobuf:"bytes,9,rep,name=auditors,proto3" json:"auditors,omitempty"`
+	Auditees         []*AuditParticipant    `protobuf:"bytes,10,rep,name=auditees,proto3" json:"auditees,omitempty"`
+	Findings         []*AuditFinding        `protobuf:"bytes,11,rep,name=findings,proto3" json:"findings,omitempty"`
+	Report           *AuditReport           `protobuf:"bytes,12,opt,name=report,proto3" json:"report,omitempty"`
+	Status           string                 `protobuf:"bytes,13,opt,name=status,proto3" json:"status,omitempty"`
+	Created          *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=created,proto3" json:"created,omitempty"`
+	Modified         *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=modified,proto3" json:"modified,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Audit) Reset() {
+	*x = Audit{}
+	mi := &file_iso9001_v1_qms_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Audit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Audit) ProtoMessage() {}
+
+func (x *Audit) ProtoReflect() protoreflect.Message {
+	mi := &file_iso9001_v1_qms_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Audit.ProtoReflect.Descriptor instead.
+func (*Audit) Descriptor() ([]byte, []int) {
+	return file_iso9001_v1_qms_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *Audit) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Audit) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Audit) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Audit) GetScope() *AuditScope {
+	if x != nil {
+		return x.Scope
+	}
+	return nil
+}
+
+func (x *Audit) GetPlannedStartDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PlannedStartDate
+	}
+	return nil
+}
+
+func (x *Audit) GetPlannedEndDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PlannedEndDate
+	}
+	return nil
+}
+
+func (x *Audit) GetActualStartDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ActualStartDate
+	}
+	return nil
+}
+
+func (x *Audit) GetActualEndDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ActualEndDate
+	}
+	return nil
+}
+
+func (x *Audit) GetAuditors() []*AuditParticipant {
+	if x != nil {
+		return x.Auditors
+	}
+	return nil
+}
+
+func (x *Audit) GetAuditees() []*AuditParticipant {
+	if x != nil {
+		return x.Auditees
+	}
+	return nil
+}
+
+func (x *Audit) GetFindings() []*AuditFinding {
+	if x != nil {
+		return x.Findings
+	}
+	return nil
+}
+
+func (x *Audit) GetReport() *AuditReport {
+	if x != nil {
+		return x.Report
+	}
+	return nil
+}
+
+func (x *Audit) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Audit) GetCreated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Created
+	}
+	return nil
+}
+
+func (x *Audit) GetModified() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Modified
+	}
+	return nil
+}
+
+type AuditScope struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Description   string                 `protobuf:"bytes,1,opt,name=description,proto3" json:"description,omitempty"`
+	Processes     []string               `protobuf:"bytes,2,rep,name=processes,proto3" json:"processes,omitempty"`
+	Locations     []string               `protobuf:"bytes,3,rep,name=locations,proto3" json:"locations,omitempty"`
+	Departments   []string               `protobuf:"bytes,4,rep,name=departments,proto3" json:"departments,omitempty"`
+	Clauses       []string               `protobuf:"bytes,5,rep,name=clauses,proto3" json:"clauses,omitempty"`
+	Exclusions    []string               `protobuf:"bytes,6,rep,name=exclusions,proto3" json:"exclusions,omitempty"`
+	Objectives    []string               `protobuf:"bytes,7,rep,name=objectives,proto3" json:"objectives,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditScope) Reset() {
+	*x = AuditScope{}
+	mi := &file_iso9001_v1_qms_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditScope) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditScope) ProtoMessage() {}
+
+func (x *AuditScope) ProtoReflect() protoreflect.Message {
+	mi := &file_iso9001_v1_qms_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditScope.ProtoReflect.Descriptor instead.
+func (*AuditScope) Descriptor() ([]byte, []int) {
+	return file_iso9001_v1_qms_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *AuditScope) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *AuditScope) GetProcesses() []string {
+	if x != nil {
+		return x.Processes
+	}
+	return nil
+}
+
+func (x *AuditScope) GetLocations() []string {
+	if x != nil {
+		return x.Locations
+	}
+	return nil
+}
+
+func (x *AuditScope) GetDepartments() []string {
+	if x != nil {
+		return x.Departments
+	}
+	return nil
+}
+
+func (x *AuditScope) GetClauses() []string {
+	if x != nil {
+		return x.Clauses
+	}
+	return nil
+}
+
+func (x *AuditScope) GetExclusions() []string {
+	if x != nil {
+		return x.Exclusions
+	}
+	return nil
+}
+
+func (x *AuditScope) GetObjectives() []string {
+	if x != nil {
+		return x.Objectives
+	}
+	return nil
+}
+
+type AuditParticipant struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Role          string                 `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
+	Competence    []string               `protobuf:"bytes,4,rep,name=competence,proto3" json:"competence,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditParticipant) Reset() {
+	*x = AuditParticipant{}
+	mi := &file_iso9001_v1_qms_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditParticipant) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditParticipant) ProtoMessage() {}
+
+func (x *AuditParticipant) ProtoReflect() protoreflect.Message {
+	mi := &file_iso9001_v1_qms_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditParticipant.ProtoReflect.Descriptor instead.
+func (*AuditParticipant) Descriptor() ([]byte, []int) {
+	return file_iso9001_v1_qms_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *AuditParticipant) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AuditParticipant) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AuditParticipant) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *AuditParticipant) GetCompetence() []string {
+	if x != nil {
+		return x.Competence
+	}
+	return nil
+}
+
+type AuditFinding struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Clause        string                 `protobuf:"bytes,2,opt,name=clause,proto3" json:"clause,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Evidence      string                 `protobuf:"bytes,4,opt,name=evidence,proto3" json:"evidence,omitempty"`
+	Severity      string                 `protobuf:"bytes,5,opt,name=severity,proto3" json:"severity,omitempty"`
+	Category      string                 `protobuf:"bytes,6,opt,name=category,proto3" json:"category,omitempty"`
+	RootCause     string                 `protobuf:"bytes,7,opt,name=root_cause,json=rootCause,proto3" json:"root_cause,omitempty"`
+	Process       string                 `protobuf:"bytes,8,opt,name=process,proto3" json:"process,omitempty"`
+	Responsible   string                 `protobuf:"bytes,9,opt,name=responsible,proto3" json:"responsible,omitempty"`
+	DueDate       *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=due_date,json=dueDate,proto3" json:"due_date,omitempty"`
+	Status        string                 `protobuf:"bytes,11,opt,name=status,proto3" json:"status,omitempty"`
+	Created       *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=created,proto3" json:"created,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditFinding) Reset() {
+	*x = AuditFinding{}
+	mi := &file_iso9001_v1_qms_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditFinding) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditFinding) ProtoMessage() {}
+
+func (x *AuditFinding) ProtoReflect() protoreflect.Message {
+	mi := &file_iso9001_v1_qms_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditFinding.ProtoReflect.Descriptor instead.
+func (*AuditFinding) Descriptor() ([]byte, []int) {
+	return file_iso9001_v1_qms_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *AuditFinding) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AuditFinding) GetClause() string {
+	if x != nil {
+		return x.Clause
+	}
+	return ""
+}
+
+func (x *AuditFinding) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *AuditFinding) GetEvidence() string {
+	if x != nil {
+		return x.Evidence
+	}
+	return ""
+}
+
+func (x *AuditFinding) GetSeverity() string {
+	if x != nil {
+		return x.Severity
+	}
+	return ""
+}
+
+func (x *AuditFinding) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *AuditFinding) GetRootCause() string {
+	if x != nil {
+		return x.RootCause
+	}
+	return ""
+}
+
+func (x *AuditFinding) GetProcess() string {
+	if x != nil {
+		return x.Process
+	}
+	return ""
+}
+
+func (x *AuditFinding) GetResponsible() string {
+	if x != nil {
+		return x.Responsible
+	}
+	return ""
+}
+
+func (x *AuditFinding) GetDueDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DueDate
+	}
+	return nil
+}
+
+func (x *AuditFinding) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *AuditFinding) GetCreated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Created
+	}
+	return nil
+}
+
+type AuditReport struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Summary       string                 `protobuf:"bytes,2,opt,name=summary,proto3" json:"summary,omitempty"`
+	Conclusions   string                 `protobuf:"bytes,3,opt,name=conclusions,proto3" json:"conclusions,omitempty"`
+	Effectiveness string                 `protobuf:"bytes,4,opt,name=effectiveness,proto3" json:"effectiveness,omitempty"`
+	IssuedDate    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=issued_date,json=issuedDate,proto3" json:"issued_date,omitempty"`
+	ReviewedBy    string                 `protobuf:"bytes,6,opt,name=reviewed_by,json=reviewedBy,proto3" json:"reviewed_by,omitempty"`
+	ApprovedBy    string                 `protobuf:"bytes,7,opt,name=approved_by,json=approvedBy,proto3" json:"approved_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditReport) Reset() {
+	*x = AuditReport{}
+	mi := &file_iso9001_v1_qms_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditReport) ProtoMessage() {}
+
+func (x *AuditReport) ProtoReflect() protoreflect.Message {
+	mi := &file_iso9001_v1_qms_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditReport.ProtoReflect.Descriptor instead.
+func (*AuditReport) Descriptor() ([]byte, []int) {
+	return file_iso9001_v1_qms_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *AuditReport) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AuditReport) GetSummary() string {
+	if x != nil {
+		return x.Summary
+	}
+	return ""
+}
+
+func (x *AuditReport) GetConclusions() string {
+	if x != nil {
+		return x.Conclusions
+	}
+	return ""
+}
+
+func (x *AuditReport) GetEffectiveness() string {
+	if x != nil {
+		return x.Effectiveness
+	}
+	return ""
+}
+
+func (x *AuditReport) GetIssuedDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.IssuedDate
+	}
+	return nil
+}
+
+func (x *AuditReport) GetReviewedBy() string {
+	if x != nil {
+		return x.ReviewedBy
+	}
+	return ""
+}
+
+func (x *AuditReport) GetApprovedBy() string {
+	if x != nil {
+		return x.ApprovedBy
+	}
+	return ""
+}
+
+var File_iso9001_v1_qms_proto protoreflect.FileDescriptor
+
+const file_iso9001_v1_qms_proto_rawDesc = "" +
+	"\n" +
+	"\x14iso9001/v1/qms.proto\x12\n" +
+	"iso9001.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"x\n" +
+	"\x1bValidateOrganizationRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12<\n" +
+	"\forganization\x18\x02 \x01(\v2\x18.iso9001.v1.OrganizationR\forganization\"v\n" +
+	"\x19GetComplianceScoreRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12<\n" +
+	"\forganization\x18\x02 \x01(\v2\x18.iso9001.v1.OrganizationR\forganization\"|\n" +
+	"\x1fGenerateComplianceReportRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12<\n" +
+	"\forganization\x18\x02 \x01(\v2\x18.iso9001.v1.OrganizationR\forganization\"5\n" +
+	"\x16GetOrganizationRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\"s\n" +
+	"\x16PutOrganizationRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12<\n" +
+	"\forganization\x18\x02 \x01(\v2\x18.iso9001.v1.OrganizationR\forganization\"X\n" +
+	"\x13IdentifyRiskRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12$\n" +
+	"\x04risk\x18\x02 \x01(\v2\x10.iso9001.v1.RiskR\x04risk\"\x81\x01\n" +
+	"\x11AssessRiskRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x17\n" +
+	"\arisk_id\x18\x02 \x01(\tR\x06riskId\x12\x1e\n" +
+	"\n" +
+	"likelihood\x18\x03 \x01(\tR\n" +
+	"likelihood\x12\x16\n" +
+	"\x06impact\x18\x04 \x01(\tR\x06impact\"y\n" +
+	"\x13MitigateRiskRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x17\n" +
+	"\arisk_id\x18\x02 \x01(\tR\x06riskId\x12,\n" +
+	"\aactions\x18\x03 \x03(\v2\x12.iso9001.v1.ActionR\aactions\"R\n" +
+	"\x10ListRisksRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12!\n" +
+	"\fmin_priority\x18\x02 \x01(\tR\vminPriority\";\n" +
+	"\x11ListRisksResponse\x12&\n" +
+	"\x05risks\x18\x01 \x03(\v2\x10.iso9001.v1.RiskR\x05risks\"q\n" +
+	"\x16CreateObjectiveRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12:\n" +
+	"\tobjective\x18\x02 \x01(\v2\x1c.iso9001.v1.QualityObjectiveR\tobjective\"W\n" +
+	"\x15ListObjectivesRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12!\n" +
+	"\foverdue_only\x18\x02 \x01(\bR\voverdueOnly\"V\n" +
+	"\x16ListObjectivesResponse\x12<\n" +
+	"\n" +
+	"objectives\x18\x01 \x03(\v2\x1c.iso9001.v1.QualityObjectiveR\n" +
+	"objectives\"p\n" +
+	"\x12AddDocumentRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12=\n" +
+	"\bdocument\x18\x02 \x01(\v2!.iso9001.v1.DocumentedInformationR\bdocument\"R\n" +
+	"\x12GetDocumentRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x1f\n" +
+	"\vdocument_id\x18\x02 \x01(\tR\n" +
+	"documentId\"\x88\x01\n" +
+	"\x16ApproveDocumentRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x1f\n" +
+	"\vdocument_id\x18\x02 \x01(\tR\n" +
+	"documentId\x120\n" +
+	"\bapproval\x18\x03 \x01(\v2\x14.iso9001.v1.ApprovalR\bapproval\"p\n" +
+	"\x18SetDocumentStatusRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x1f\n" +
+	"\vdocument_id\x18\x02 \x01(\tR\n" +
+	"documentId\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\"_\n" +
+	"\x14ListDocumentsRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\"X\n" +
+	"\x15ListDocumentsResponse\x12?\n" +
+	"\tdocuments\x18\x01 \x03(\v2!.iso9001.v1.DocumentedInformationR\tdocuments\"Z\n" +
+	"\x12CreateAuditRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12'\n" +
+	"\x05audit\x18\x02 \x01(\v2\x11.iso9001.v1.AuditR\x05audit\"\x86\x01\n" +
+	"\x11StartAuditRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x19\n" +
+	"\baudit_id\x18\x02 \x01(\tR\aauditId\x129\n" +
+	"\n" +
+	"start_date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tstartDate\"\x7f\n" +
+	"\x11AddFindingRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x19\n" +
+	"\baudit_id\x18\x02 \x01(\tR\aauditId\x122\n" +
+	"\afinding\x18\x03 \x01(\v2\x18.iso9001.v1.AuditFindingR\afinding\"\xb6\x01\n" +
+	"\x14CompleteAuditRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x19\n" +
+	"\baudit_id\x18\x02 \x01(\tR\aauditId\x125\n" +
+	"\bend_date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\aendDate\x12/\n" +
+	"\x06report\x18\x04 \x01(\v2\x17.iso9001.v1.AuditReportR\x06report\"H\n" +
+	"\x11ListAuditsRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\"?\n" +
+	"\x12ListAuditsResponse\x12)\n" +
+	"\x06audits\x18\x01 \x03(\v2\x11.iso9001.v1.AuditR\x06audits\",\n" +
+	"\rExportRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\"u\n" +
+	"\x0fValidationError\x12\x16\n" +
+	"\x06clause\x18\x01 \x01(\tR\x06clause\x12\x14\n" +
+	"\x05field\x18\x02 \x01(\tR\x05field\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x12\x1a\n" +
+	"\bseverity\x18\x04 \x01(\tR\bseverity\"\xc9\x01\n" +
+	"\x10ValidationResult\x12\x14\n" +
+	"\x05valid\x18\x01 \x01(\bR\x05valid\x123\n" +
+	"\x06errors\x18\x02 \x03(\v2\x1b.iso9001.v1.ValidationErrorR\x06errors\x127\n" +
+	"\bwarnings\x18\x03 \x03(\v2\x1b.iso9001.v1.ValidationErrorR\bwarnings\x121\n" +
+	"\x05infos\x18\x04 \x03(\v2\x1b.iso9001.v1.ValidationErrorR\x05infos\"P\n" +
+	"\x0fComplianceScore\x12'\n" +
+	"\x0forganization_id\x18\x01 \x01(\tR\x0eorganizationId\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x01R\x05score\"\x81\x01\n" +
+	"\rComplianceGap\x12\x16\n" +
+	"\x06clause\x18\x01 \x01(\tR\x06clause\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x1a\n" +
+	"\bseverity\x18\x03 \x01(\tR\bseverity\x12\x1a\n" +
+	"\bpriority\x18\x04 \x01(\tR\bpriority\"c\n" +
+	"\x0fImprovementArea\x12\x12\n" +
+	"\x04area\x18\x01 \x01(\tR\x04area\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x1a\n" +
+	"\bpriority\x18\x03 \x01(\tR\bpriority\"\xac\x03\n" +
+	"\x10ComplianceReport\x12'\n" +
+	"\x0forganization_id\x18\x01 \x01(\tR\x0eorganizationId\x12C\n" +
+	"\x0fassessment_date\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x0eassessmentDate\x12-\n" +
+	"\x12overall_compliance\x18\x03 \x01(\tR\x11overallCompliance\x12)\n" +
+	"\x10compliance_score\x18\x04 \x01(\x01R\x0fcomplianceScore\x12>\n" +
+	"\rcritical_gaps\x18\x05 \x03(\v2\x19.iso9001.v1.ComplianceGapR\fcriticalGaps\x12H\n" +
+	"\x11improvement_areas\x18\x06 \x03(\v2\x1b.iso9001.v1.ImprovementAreaR\x10improvementAreas\x12\x1c\n" +
+	"\tstrengths\x18\a \x03(\tR\tstrengths\x12(\n" +
+	"\x0frecommendations\x18\b \x03(\tR\x0frecommendations\"\x90\x03\n" +
+	"\fOrganization\x12%\n" +
+	"\x0eschema_version\x18\x01 \x01(\x05R\rschemaVersion\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12;\n" +
+	"\acontext\x18\x04 \x01(\v2!.iso9001.v1.OrganizationalContextR\acontext\x126\n" +
+	"\n" +
+	"leadership\x18\x05 \x01(\v2\x16.iso9001.v1.LeadershipR\n" +
+	"leadership\x125\n" +
+	"\x03qms\x18\x06 \x01(\v2#.iso9001.v1.QualityManagementSystemR\x03qms\x12\x1b\n" +
+	"\ttime_zone\x18\a \x01(\tR\btimeZone\x124\n" +
+	"\acreated\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\acreated\x126\n" +
+	"\bmodified\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\bmodified\"\xdb\x01\n" +
+	"\x15OrganizationalContext\x12:\n" +
+	"\x0fexternal_issues\x18\x01 \x03(\v2\x11.iso9001.v1.IssueR\x0eexternalIssues\x12:\n" +
+	"\x0finternal_issues\x18\x02 \x03(\v2\x11.iso9001.v1.IssueR\x0einternalIssues\x12J\n" +
+	"\x12interested_parties\x18\x03 \x03(\v2\x1b.iso9001.v1.InterestedPartyR\x11interestedParties\"\xb3\x01\n" +
+	"\x05Issue\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x16\n" +
+	"\x06impact\x18\x04 \x01(\tR\x06impact\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x124\n" +
+	"\acreated\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\acreated\"m\n" +
+	"\x0fInterestedParty\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\"\n" +
+	"\frequirements\x18\x04 \x03(\tR\frequirements\"\xdf\x01\n" +
+	"\n" +
+	"Leadership\x129\n" +
+	"\x0etop_management\x18\x01 \x03(\v2\x12.iso9001.v1.PersonR\rtopManagement\x12@\n" +
+	"\x0equality_policy\x18\x02 \x01(\v2\x19.iso9001.v1.QualityPolicyR\rqualityPolicy\x124\n" +
+	"\x05roles\x18\x03 \x03(\v2\x1e.iso9001.v1.OrganizationalRoleR\x05roles\x12\x1e\n" +
+	"\n" +
+	"commitment\x18\x04 \x03(\tR\n" +
+	"commitment\"|\n" +
+	"\x06Person\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04role\x18\x03 \x01(\tR\x04role\x12\x1e\n" +
+	"\n" +
+	"competence\x18\x04 \x03(\tR\n" +
+	"competence\x12\x1a\n" +
+	"\btraining\x18\x05 \x03(\tR\btraining\"\xa7\x01\n" +
+	"\x12OrganizationalRole\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12*\n" +
+	"\x10responsibilities\x18\x03 \x03(\tR\x10responsibilities\x12 \n" +
+	"\vauthorities\x18\x04 \x03(\tR\vauthorities\x12\x1f\n" +
+	"\vassigned_to\x18\x05 \x01(\tR\n" +
+	"assignedTo\"\xcd\x02\n" +
+	"\rQualityPolicy\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1c\n" +
+	"\tstatement\x18\x02 \x01(\tR\tstatement\x12\x1e\n" +
+	"\n" +
+	"objectives\x18\x03 \x01(\tR\n" +
+	"objectives\x12\x1e\n" +
+	"\n" +
+	"commitment\x18\x04 \x01(\tR\n" +
+	"commitment\x12 \n" +
+	"\vimprovement\x18\x05 \x01(\tR\vimprovement\x12\"\n" +
+	"\fcommunicated\x18\x06 \x01(\bR\fcommunicated\x12\x1c\n" +
+	"\tavailable\x18\a \x01(\bR\tavailable\x124\n" +
+	"\acreated\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\acreated\x124\n" +
+	"\aupdated\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\aupdated\"\xe3\x02\n" +
+	"\x17QualityManagementSystem\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12*\n" +
+	"\x05scope\x18\x02 \x01(\v2\x14.iso9001.v1.QMSScopeR\x05scope\x121\n" +
+	"\tprocesses\x18\x03 \x03(\v2\x13.iso9001.v1.ProcessR\tprocesses\x12<\n" +
+	"\n" +
+	"objectives\x18\x04 \x03(\v2\x1c.iso9001.v1.QualityObjectiveR\n" +
+	"objectives\x12&\n" +
+	"\x05risks\x18\x05 \x03(\v2\x10.iso9001.v1.RiskR\x05risks\x12=\n" +
+	"\ropportunities\x18\x06 \x03(\v2\x17.iso9001.v1.OpportunityR\ropportunities\x124\n" +
+	"\acreated\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\acreated\"\xc1\x01\n" +
+	"\bQMSScope\x12 \n" +
+	"\vdescription\x18\x01 \x01(\tR\vdescription\x12\x1a\n" +
+	"\bproducts\x18\x02 \x03(\tR\bproducts\x12\x1a\n" +
+	"\bservices\x18\x03 \x03(\tR\bservices\x125\n" +
+	"\n" +
+	"exclusions\x18\x04 \x03(\v2\x15.iso9001.v1.ExclusionR\n" +
+	"exclusions\x12$\n" +
+	"\rjustification\x18\x05 \x01(\tR\rjustification\"k\n" +
+	"\tExclusion\x12\x16\n" +
+	"\x06clause\x18\x01 \x01(\tR\x06clause\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12$\n" +
+	"\rjustification\x18\x03 \x01(\tR\rjustification\"\xb0\x02\n" +
+	"\aProcess\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12*\n" +
+	"\x10responsibilities\x18\x04 \x03(\tR\x10responsibilities\x12&\n" +
+	"\x05risks\x18\x05 \x03(\v2\x10.iso9001.v1.RiskR\x05risks\x12=\n" +
+	"\ropportunities\x18\x06 \x03(\v2\x17.iso9001.v1.OpportunityR\ropportunities\x12\x16\n" +
+	"\x06status\x18\a \x01(\tR\x06status\x124\n" +
+	"\acreated\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\acreated\"\xda\x02\n" +
+	"\x10QualityObjective\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1e\n" +
+	"\n" +
+	"measurable\x18\x04 \x01(\bR\n" +
+	"measurable\x125\n" +
+	"\atargets\x18\x05 \x03(\v2\x1b.iso9001.v1.ObjectiveTargetR\atargets\x12 \n" +
+	"\vresponsible\x18\x06 \x01(\tR\vresponsible\x129\n" +
+	"\btimeline\x18\a \x01(\v2\x1d.iso9001.v1.ObjectiveTimelineR\btimeline\x12\x16\n" +
+	"\x06status\x18\b \x01(\tR\x06status\x124\n" +
+	"\acreated\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\acreated\"c\n" +
+	"\x0fObjectiveTarget\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06metric\x18\x02 \x01(\tR\x06metric\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\tR\x05value\x12\x12\n" +
+	"\x04unit\x18\x04 \x01(\tR\x04unit\"\xc8\x01\n" +
+	"\x11ObjectiveTimeline\x129\n" +
+	"\n" +
+	"start_date\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\tstartDate\x12;\n" +
+	"\vtarget_date\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"targetDate\x12;\n" +
+	"\vreview_date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"reviewDate\"\xc0\x02\n" +
+	"\x04Risk\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x16\n" +
+	"\x06causes\x18\x03 \x03(\tR\x06causes\x12\x18\n" +
+	"\aeffects\x18\x04 \x03(\tR\aeffects\x12\x1e\n" +
+	"\n" +
+	"likelihood\x18\x05 \x01(\tR\n" +
+	"likelihood\x12\x16\n" +
+	"\x06impact\x18\x06 \x01(\tR\x06impact\x12\x1a\n" +
+	"\bpriority\x18\a \x01(\tR\bpriority\x122\n" +
+	"\n" +
+	"mitigation\x18\b \x03(\v2\x12.iso9001.v1.ActionR\n" +
+	"mitigation\x12\x16\n" +
+	"\x06status\x18\t \x01(\tR\x06status\x124\n" +
+	"\acreated\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\acreated\"\xab\x02\n" +
+	"\vOpportunity\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x1a\n" +
+	"\bbenefits\x18\x03 \x03(\tR\bbenefits\x12\x1e\n" +
+	"\n" +
+	"likelihood\x18\x04 \x01(\tR\n" +
+	"likelihood\x12\x16\n" +
+	"\x06impact\x18\x05 \x01(\tR\x06impact\x12\x1a\n" +
+	"\bpriority\x18\x06 \x01(\x05R\bpriority\x12,\n" +
+	"\aactions\x18\a \x03(\v2\x12.iso9001.v1.ActionR\aactions\x12\x16\n" +
+	"\x06status\x18\b \x01(\tR\x06status\x124\n" +
+	"\acreated\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\acreated\"\xf6\x01\n" +
+	"\x06Action\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12 \n" +
+	"\vresponsible\x18\x04 \x01(\tR\vresponsible\x126\n" +
+	"\btimeline\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\btimeline\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x124\n" +
+	"\acreated\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\acreated\"\x80\x04\n" +
+	"\x15DocumentedInformation\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x1a\n" +
+	"\bcategory\x18\x04 \x01(\tR\bcategory\x12\x18\n" +
+	"\acontent\x18\x05 \x01(\tR\acontent\x128\n" +
+	"\bmetadata\x18\x06 \x01(\v2\x1c.iso9001.v1.DocumentMetadataR\bmetadata\x128\n" +
+	"\bapproval\x18\a \x01(\v2\x1c.iso9001.v1.DocumentApprovalR\bapproval\x12\x16\n" +
+	"\x06status\x18\b \x01(\tR\x06status\x127\n" +
+	"\bversions\x18\t \x03(\v2\x1b.iso9001.v1.DocumentVersionR\bversions\x12\x1f\n" +
+	"\vrevision_of\x18\n" +
+	" \x01(\tR\n" +
+	"revisionOf\x12#\n" +
+	"\rsuperseded_by\x18\v \x01(\tR\fsupersededBy\x124\n" +
+	"\acreated\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\acreated\x126\n" +
+	"\bmodified\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\bmodified\"\xe6\x01\n" +
+	"\x10DocumentMetadata\x12\x16\n" +
+	"\x06author\x18\x01 \x01(\tR\x06author\x12\x14\n" +
+	"\x05owner\x18\x02 \x01(\tR\x05owner\x12\x1a\n" +
+	"\bkeywords\x18\x03 \x03(\tR\bkeywords\x12'\n" +
+	"\x0frelated_clauses\x18\x04 \x03(\tR\x0erelatedClauses\x12+\n" +
+	"\x11related_documents\x18\x05 \x03(\tR\x10relatedDocuments\x12\x16\n" +
+	"\x06format\x18\x06 \x01(\tR\x06format\x12\x1a\n" +
+	"\blanguage\x18\a \x01(\tR\blanguage\"\x9a\x01\n" +
+	"\x10DocumentApproval\x12-\n" +
+	"\x12required_approvers\x18\x01 \x03(\tR\x11requiredApprovers\x12?\n" +
+	"\x10actual_approvers\x18\x02 \x03(\v2\x14.iso9001.v1.ApprovalR\x0factualApprovers\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\"\xd4\x01\n" +
+	"\bApproval\x12\x1f\n" +
+	"\vapprover_id\x18\x01 \x01(\tR\n" +
+	"approverId\x12#\n" +
+	"\rapprover_name\x18\x02 \x01(\tR\fapproverName\x12\x12\n" +
+	"\x04role\x18\x03 \x01(\tR\x04role\x128\n" +
+	"\ttimestamp\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x1a\n" +
+	"\bcomments\x18\x05 \x01(\tR\bcomments\x12\x18\n" +
+	"\aversion\x18\x06 \x01(\tR\aversion\"\xb9\x01\n" +
+	"\x0fDocumentVersion\x12%\n" +
+	"\x0eversion_number\x18\x01 \x01(\tR\rversionNumber\x12%\n" +
+	"\x0echange_summary\x18\x02 \x01(\tR\rchangeSummary\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x03 \x01(\tR\tcreatedBy\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xec\x05\n" +
+	"\x05Audit\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12,\n" +
+	"\x05scope\x18\x04 \x01(\v2\x16.iso9001.v1.AuditScopeR\x05scope\x12H\n" +
+	"\x12planned_start_date\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x10plannedStartDate\x12D\n" +
+	"\x10planned_end_date\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x0eplannedEndDate\x12F\n" +
+	"\x11actual_start_date\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x0factualStartDate\x12B\n" +
+	"\x0factual_end_date\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\ractualEndDate\x128\n" +
+	"\bauditors\x18\t \x03(\v2\x1c.iso9001.v1.AuditParticipantR\bauditors\x128\n" +
+	"\bauditees\x18\n" +
+	" \x03(\v2\x1c.iso9001.v1.AuditParticipantR\bauditees\x124\n" +
+	"\bfindings\x18\v \x03(\v2\x18.iso9001.v1.AuditFindingR\bfindings\x12/\n" +
+	"\x06report\x18\f \x01(\v2\x17.iso9001.v1.AuditReportR\x06report\x12\x16\n" +
+	"\x06status\x18\r \x01(\tR\x06status\x124\n" +
+	"\acreated\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\acreated\x126\n" +
+	"\bmodified\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\bmodified\"\xe6\x01\n" +
+	"\n" +
+	"AuditScope\x12 \n" +
+	"\vdescription\x18\x01 \x01(\tR\vdescription\x12\x1c\n" +
+	"\tprocesses\x18\x02 \x03(\tR\tprocesses\x12\x1c\n" +
+	"\tlocations\x18\x03 \x03(\tR\tlocations\x12 \n" +
+	"\vdepartments\x18\x04 \x03(\tR\vdepartments\x12\x18\n" +
+	"\aclauses\x18\x05 \x03(\tR\aclauses\x12\x1e\n" +
+	"\n" +
+	"exclusions\x18\x06 \x03(\tR\n" +
+	"exclusions\x12\x1e\n" +
+	"\n" +
+	"objectives\x18\a \x03(\tR\n" +
+	"objectives\"j\n" +
+	"\x10AuditParticipant\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04role\x18\x03 \x01(\tR\x04role\x12\x1e\n" +
+	"\n" +
+	"competence\x18\x04 \x03(\tR\n" +
+	"competence\"\x8c\x03\n" +
+	"\fAuditFinding\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06clause\x18\x02 \x01(\tR\x06clause\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1a\n" +
+	"\bevidence\x18\x04 \x01(\tR\bevidence\x12\x1a\n" +
+	"\bseverity\x18\x05 \x01(\tR\bseverity\x12\x1a\n" +
+	"\bcategory\x18\x06 \x01(\tR\bcategory\x12\x1d\n" +
+	"\n" +
+	"root_cause\x18\a \x01(\tR\trootCause\x12\x18\n" +
+	"\aprocess\x18\b \x01(\tR\aprocess\x12 \n" +
+	"\vresponsible\x18\t \x01(\tR\vresponsible\x125\n" +
+	"\bdue_date\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\adueDate\x12\x16\n" +
+	"\x06status\x18\v \x01(\tR\x06status\x124\n" +
+	"\acreated\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\acreated\"\xfe\x01\n" +
+	"\vAuditReport\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\asummary\x18\x02 \x01(\tR\asummary\x12 \n" +
+	"\vconclusions\x18\x03 \x01(\tR\vconclusions\x12$\n" +
+	"\reffectiveness\x18\x04 \x01(\tR\reffectiveness\x12;\n" +
+	"\vissued_date\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"issuedDate\x12\x1f\n" +
+	"\vreviewed_by\x18\x06 \x01(\tR\n" +
+	"reviewedBy\x12\x1f\n" +
+	"\vapproved_by\x18\a \x01(\tR\n" +
+	"approvedBy2\xb2\x0f\n" +
+	"\n" +
+	"QMSService\x12]\n" +
+	"\x14ValidateOrganization\x12'.iso9001.v1.ValidateOrganizationRequest\x1a\x1c.iso9001.v1.ValidationResult\x12X\n" +
+	"\x12GetComplianceScore\x12%.iso9001.v1.GetComplianceScoreRequest\x1a\x1b.iso9001.v1.ComplianceScore\x12e\n" +
+	"\x18GenerateComplianceReport\x12+.iso9001.v1.GenerateComplianceReportRequest\x1a\x1c.iso9001.v1.ComplianceReport\x12O\n" +
+	"\x0fGetOrganization\x12\".iso9001.v1.GetOrganizationRequest\x1a\x18.iso9001.v1.Organization\x12O\n" +
+	"\x0fPutOrganization\x12\".iso9001.v1.PutOrganizationRequest\x1a\x18.iso9001.v1.Organization\x12A\n" +
+	"\fIdentifyRisk\x12\x1f.iso9001.v1.IdentifyRiskRequest\x1a\x10.iso9001.v1.Risk\x12=\n" +
+	"\n" +
+	"AssessRisk\x12\x1d.iso9001.v1.AssessRiskRequest\x1a\x10.iso9001.v1.Risk\x12A\n" +
+	"\fMitigateRisk\x12\x1f.iso9001.v1.MitigateRiskRequest\x1a\x10.iso9001.v1.Risk\x12H\n" +
+	"\tListRisks\x12\x1c.iso9001.v1.ListRisksRequest\x1a\x1d.iso9001.v1.ListRisksResponse\x12S\n" +
+	"\x0fCreateObjective\x12\".iso9001.v1.CreateObjectiveRequest\x1a\x1c.iso9001.v1.QualityObjective\x12W\n" +
+	"\x0eListObjectives\x12!.iso9001.v1.ListObjectivesRequest\x1a\".iso9001.v1.ListObjectivesResponse\x12P\n" +
+	"\vAddDocument\x12\x1e.iso9001.v1.AddDocumentRequest\x1a!.iso9001.v1.DocumentedInformation\x12P\n" +
+	"\vGetDocument\x12\x1e.iso9001.v1.GetDocumentRequest\x1a!.iso9001.v1.DocumentedInformation\x12X\n" +
+	"\x0fApproveDocument\x12\".iso9001.v1.ApproveDocumentRequest\x1a!.iso9001.v1.DocumentedInformation\x12\\\n" +
+	"\x11SetDocumentStatus\x12$.iso9001.v1.SetDocumentStatusRequest\x1a!.iso9001.v1.DocumentedInformation\x12T\n" +
+	"\rListDocuments\x12 .iso9001.v1.ListDocumentsRequest\x1a!.iso9001.v1.ListDocumentsResponse\x12@\n" +
+	"\vCreateAudit\x12\x1e.iso9001.v1.CreateAuditRequest\x1a\x11.iso9001.v1.Audit\x12>\n" +
+	"\n" +
+	"StartAudit\x12\x1d.iso9001.v1.StartAuditRequest\x1a\x11.iso9001.v1.Audit\x12>\n" +
+	"\n" +
+	"AddFinding\x12\x1d.iso9001.v1.AddFindingRequest\x1a\x11.iso9001.v1.Audit\x12D\n" +
+	"\rCompleteAudit\x12 .iso9001.v1.CompleteAuditRequest\x1a\x11.iso9001.v1.Audit\x12K\n" +
+	"\n" +
+	"ListAudits\x12\x1d.iso9001.v1.ListAuditsRequest\x1a\x1e.iso9001.v1.ListAuditsResponse\x12<\n" +
+	"\vExportRisks\x12\x19.iso9001.v1.ExportRequest\x1a\x10.iso9001.v1.Risk0\x01\x12M\n" +
+	"\x10ExportObjectives\x12\x19.iso9001.v1.ExportRequest\x1a\x1c.iso9001.v1.QualityObjective0\x01\x12Q\n" +
+	"\x0fExportDocuments\x12\x19.iso9001.v1.ExportRequest\x1a!.iso9001.v1.DocumentedInformation0\x01\x12>\n" +
+	"\fExportAudits\x12\x19.iso9001.v1.ExportRequest\x1a\x11.iso9001.v1.Audit0\x01B:Z8github.com/example/iso9001-grpc/gen/iso9001/v1;iso9001v1b\x06proto3"
+
+var (
+	file_iso9001_v1_qms_proto_rawDescOnce sync.Once
+	file_iso9001_v1_qms_proto_rawDescData []byte
+)
+
+func file_iso9001_v1_qms_proto_rawDescGZIP() []byte {
+	file_iso9001_v1_qms_proto_rawDescOnce.Do(func() {
+		file_iso9001_v1_qms_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_iso9001_v1_qms_proto_rawDesc), len(file_iso9001_v1_qms_proto_rawDesc)))
+	})
+	return file_iso9001_v1_qms_proto_rawDescData
+}
+
+var file_iso9001_v1_qms_proto_msgTypes = make([]protoimpl.MessageInfo, 60)
+var file_iso9001_v1_qms_proto_goTypes = []any{
+	(*ValidateOrganizationRequest)(nil),     // 0: iso9001.v1.ValidateOrganizationRequest
+	(*GetComplianceScoreRequest)(nil),       // 1: iso9001.v1.GetComplianceScoreRequest
+	(*GenerateComplianceReportRequest)(nil), // 2: iso9001.v1.GenerateComplianceReportRequest
+	(*GetOrganizationRequest)(nil),          // 3: iso9001.v1.GetOrganizationRequest
+	(*PutOrganizationRequest)(nil),          // 4: iso9001.v1.PutOrganizationRequest
+	(*IdentifyRiskRequest)(nil),             // 5: iso9001.v1.IdentifyRiskRequest
+	(*AssessRiskRequest)(nil),               // 6: iso9001.v1.AssessRiskRequest
+	(*MitigateRiskRequest)(nil),             // 7: iso9001.v1.MitigateRiskRequest
+	(*ListRisksRequest)(nil),                // 8: iso9001.v1.ListRisksRequest
+	(*ListRisksResponse)(nil),               // 9: iso9001.v1.ListRisksResponse
+	(*CreateObjectiveRequest)(nil),          // 10: iso9001.v1.CreateObjectiveRequest
+	(*ListObjectivesRequest)(nil),           // 11: iso9001.v1.ListObjectivesRequest
+	(*ListObjectivesResponse)(nil),          // 12: iso9001.v1.ListObjectivesResponse
+	(*AddDocumentRequest)(nil),              // 13: iso9001.v1.AddDocumentRequest
+	(*GetDocumentRequest)(nil),              // 14: iso9001.v1.GetDocumentRequest
+	(*ApproveDocumentRequest)(nil),          // 15: iso9001.v1.ApproveDocumentRequest
+	(*SetDocumentStatusRequest)(nil),        // 16: iso9001.v1.SetDocumentStatusRequest
+	(*ListDocumentsRequest)(nil),            // 17: iso9001.v1.ListDocumentsRequest
+	(*ListDocumentsResponse)(nil),           // 18: iso9001.v1.ListDocumentsResponse
+	(*CreateAuditRequest)(nil),              // 19: iso9001.v1.CreateAuditRequest
+	(*StartAuditRequest)(nil),               // 20: iso9001.v1.StartAuditRequest
+	(*AddFindingRequest)(nil),               // 21: iso9001.v1.AddFindingRequest
+	(*CompleteAuditRequest)(nil),            // 22: iso9001.v1.CompleteAuditRequest
+	(*ListAuditsRequest)(nil),               // 23: iso9001.v1.ListAuditsRequest
+	(*ListAuditsResponse)(nil),              // 24: iso9001.v1.ListAuditsResponse
+	(*ExportRequest)(nil),                   // 25: iso9001.v1.ExportRequest
+	(*ValidationError)(nil),                 // 26: iso9001.v1.ValidationError
+	(*ValidationResult)(nil),                // 27: iso9001.v1.ValidationResult
+	(*ComplianceScore)(nil),                 // 28: iso9001.v1.ComplianceScore
+	(*ComplianceGap)(nil),                   // 29: iso9001.v1.ComplianceGap
+	(*ImprovementArea)(nil),                 // 30: iso9001.v1.ImprovementArea
+	(*ComplianceReport)(nil),                // 31: iso9001.v1.ComplianceReport
+	(*Organization)(nil),                    // 32: iso9001.v1.Organization
+	(*OrganizationalContext)(nil),           // 33: iso9001.v1.OrganizationalContext
+	(*Issue)(nil),                           // 34: iso9001.v1.Issue
+	(*InterestedParty)(nil),                 // 35: iso9001.v1.InterestedParty
+	(*Leadership)(nil),                      // 36: iso9001.v1.Leadership
+	(*Person)(nil),                          // 37: iso9001.v1.Person
+	(*OrganizationalRole)(nil),              // 38: iso9001.v1.OrganizationalRole
+	(*QualityPolicy)(nil),                   // 39: iso9001.v1.QualityPolicy
+	(*QualityManagementSystem)(nil),         // 40: iso9001.v1.QualityManagementSystem
+	(*QMSScope)(nil),                        // 41: iso9001.v1.QMSScope
+	(*Exclusion)(nil),                       // 42: iso9001.v1.Exclusion
+	(*Process)(nil),                         // 43: iso9001.v1.Process
+	(*QualityObjective)(nil),                // 44: iso9001.v1.QualityObjective
+	(*ObjectiveTarget)(nil),                 // 45: iso9001.v1.ObjectiveTarget
+	(*ObjectiveTimeline)(nil),               // 46: iso9001.v1.ObjectiveTimeline
+	(*Risk)(nil),                            // 47: iso9001.v1.Risk
+	(*Opportunity)(nil),                     // 48: iso9001.v1.Opportunity
+	(*Action)(nil),                          // 49: iso9001.v1.Action
+	(*DocumentedInformation)(nil),           // 50: iso9001.v1.DocumentedInformation
+	(*DocumentMetadata)(nil),                // 51: iso9001.v1.DocumentMetadata
+	(*DocumentApproval)(nil),                // 52: iso9001.v1.DocumentApproval
+	(*Approval)(nil),                        // 53: iso9001.v1.Approval
+	(*DocumentVersion)(nil),                 // 54: iso9001.v1.DocumentVersion
+	(*Audit)(nil),                           // 55: iso9001.v1.Audit
+	(*AuditScope)(nil),                      // 56: iso9001.v1.AuditScope
+	(*AuditParticipant)(nil),                // 57: iso9001.v1.AuditParticipant
+	(*AuditFinding)(nil),                    // 58: iso9001.v1.AuditFinding
+	(*AuditReport)(nil),                     // 59: iso9001.v1.AuditReport
+	(*timestamppb.Timestamp)(nil),           // 60: google.protobuf.Timestamp
+}
+var file_iso9001_v1_qms_proto_depIdxs = []int32{
+	32,  // 0: iso9001.v1.ValidateOrganizationRequest.organization:type_name -> iso9001.v1.Organization
+	32,  // 1: iso9001.v1.GetComplianceScoreRequest.organization:type_name -> iso9001.v1.Organization
+	32,  // 2: iso9001.v1.GenerateComplianceReportRequest.organization:type_name -> iso9001.v1.Organization
+	32,  // 3: iso9001.v1.PutOrganizationRequest.organization:type_name -> iso9001.v1.Organization
+	47,  // 4: iso9001.v1.IdentifyRiskRequest.risk:type_name -> iso9001.v1.Risk
+	49,  // 5: iso9001.v1.MitigateRiskRequest.actions:type_name -> iso9001.v1.Action
+	47,  // 6: iso9001.v1.ListRisksResponse.risks:type_name -> iso9001.v1.Risk
+	44,  // 7: iso9001.v1.CreateObjectiveRequest.objective:type_name -> iso9001.v1.QualityObjective
+	44,  // 8: iso9001.v1.ListObjectivesResponse.objectives:type_name -> iso9001.v1.QualityObjective
+	50,  // 9: iso9001.v1.AddDocumentRequest.document:type_name -> iso9001.v1.DocumentedInformation
+	53,  // 10: iso9001.v1.ApproveDocumentRequest.approval:type_name -> iso9001.v1.Approval
+	50,  // 11: iso9001.v1.ListDocumentsResponse.documents:type_name -> iso9001.v1.DocumentedInformation
+	55,  // 12: iso9001.v1.CreateAuditRequest.audit:type_name -> iso9001.v1.Audit
+	60,  // 13: iso9001.v1.StartAuditRequest.start_date:type_name -> google.protobuf.Timestamp
+	58,  // 14: iso9001.v1.AddFindingRequest.finding:type_name -> iso9001.v1.AuditFinding
+	60,  // 15: iso9001.v1.CompleteAuditRequest.end_date:type_name -> google.protobuf.Timestamp
+	59,  // 16: iso9001.v1.CompleteAuditRequest.report:type_name -> iso9001.v1.AuditReport
+	55,  // 17: iso9001.v1.ListAuditsResponse.audits:type_name -> iso9001.v1.Audit
+	26,  // 18: iso9001.v1.ValidationResult.errors:type_name -> iso9001.v1.ValidationError
+	26,  // 19: iso9001.v1.ValidationResult.warnings:type_name -> iso9001.v1.ValidationError
+	26,  // 20: iso9001.v1.ValidationResult.infos:type_name -> iso9001.v1.ValidationError
+	60,  // 21: iso9001.v1.ComplianceReport.assessment_date:type_name -> google.protobuf.Timestamp
+	29,  // 22: iso9001.v1.ComplianceReport.critical_gaps:type_name -> iso9001.v1.ComplianceGap
+	30,  // 23: iso9001.v1.ComplianceReport.improvement_areas:type_name -> iso9001.v1.ImprovementArea
+	33,  // 24: iso9001.v1.Organization.context:type_name -> iso9001.v1.OrganizationalContext
+	36,  // 25: iso9001.v1.Organization.leadership:type_name -> iso9001.v1.Leadership
+	40,  // 26: iso9001.v1.Organization.qms:type_name -> iso9001.v1.QualityManagementSystem
+	60,  // 27: iso9001.v1.Organization.created:type_name -> google.protobuf.Timestamp
+	60,  // 28: iso9001.v1.Organization.modified:type_name -> google.protobuf.Timestamp
+	34,  // 29: iso9001.v1.OrganizationalContext.external_issues:type_name -> iso9001.v1.Issue
+	34,  // 30: iso9001.v1.OrganizationalContext.internal_issues:type_name -> iso9001.v1.Issue
+	35,  // 31: iso9001.v1.OrganizationalContext.interested_parties:type_name -> iso9001.v1.InterestedParty
+	60,  // 32: iso9001.v1.Issue.created:type_name -> google.protobuf.Timestamp
+	37,  // 33: iso9001.v1.Leadership.top_management:type_name -> iso9001.v1.Person
+	39,  // 34: iso9001.v1.Leadership.quality_policy:type_name -> iso9001.v1.QualityPolicy
+	38,  // 35: iso9001.v1.Leadership.roles:type_name -> iso9001.v1.OrganizationalRole
+	60,  // 36: iso9001.v1.QualityPolicy.created:type_name -> google.protobuf.Timestamp
+	60,  // 37: iso9001.v1.QualityPolicy.updated:type_name -> google.protobuf.Timestamp
+	41,  // 38: iso9001.v1.QualityManagementSystem.scope:type_name -> iso9001.v1.QMSScope
+	43,  // 39: iso9001.v1.QualityManagementSystem.processes:type_name -> iso9001.v1.Process
+	44,  // 40: iso9001.v1.QualityManagementSystem.objectives:type_name -> iso9001.v1.QualityObjective
+	47,  // 41: iso9001.v1.QualityManagementSystem.risks:type_name -> iso9001.v1.Risk
+	48,  // 42: iso9001.v1.QualityManagementSystem.opportunities:type_name -> iso9001.v1.Opportunity
+	60,  // 43: iso9001.v1.QualityManagementSystem.created:type_name -> google.protobuf.Timestamp
+	42,  // 44: iso9001.v1.QMSScope.exclusions:type_name -> iso9001.v1.Exclusion
+	47,  // 45: iso9001.v1.Process.risks:type_name -> iso9001.v1.Risk
+	48,  // 46: iso9001.v1.Process.opportunities:type_name -> iso9001.v1.Opportunity
+	60,  // 47: iso9001.v1.Process.created:type_name -> google.protobuf.Timestamp
+	45,  // 48: iso9001.v1.QualityObjective.targets:type_name -> iso9001.v1.ObjectiveTarget
+	46,  // 49: iso9001.v1.QualityObjective.timeline:type_name -> iso9001.v1.ObjectiveTimeline
+	60,  // 50: iso9001.v1.QualityObjective.created:type_name -> google.protobuf.Timestamp
+	60,  // 51: iso9001.v1.ObjectiveTimeline.start_date:type_name -> google.protobuf.Timestamp
+	60,  // 52: iso9001.v1.ObjectiveTimeline.target_date:type_name -> google.protobuf.Timestamp
+	60,  // 53: iso9001.v1.ObjectiveTimeline.review_date:type_name -> google.protobuf.Timestamp
+	49,  // 54: iso9001.v1.Risk.mitigation:type_name -> iso9001.v1.Action
+	60,  // 55: iso9001.v1.Risk.created:type_name -> google.protobuf.Timestamp
+	49,  // 56: iso9001.v1.Opportunity.actions:type_name -> iso9001.v1.Action
+	60,  // 57: iso9001.v1.Opportunity.created:type_name -> google.protobuf.Timestamp
+	60,  // 58: iso9001.v1.Action.timeline:type_name -> google.protobuf.Timestamp
+	60,  // 59: iso9001.v1.Action.created:type_name -> google.protobuf.Timestamp
+	51,  // 60: iso9001.v1.DocumentedInformation.metadata:type_name -> iso9001.v1.DocumentMetadata
+	52,  // 61: iso9001.v1.DocumentedInformation.approval:type_name -> iso9001.v1.DocumentApproval
+	54,  // 62: iso9001.v1.DocumentedInformation.versions:type_name -> iso9001.v1.DocumentVersion
+	60,  // 63: iso9001.v1.DocumentedInformation.created:type_name -> google.protobuf.Timestamp
+	60,  // 64: iso9001.v1.DocumentedInformation.modified:type_name -> google.protobuf.Timestamp
+	53,  // 65: iso9001.v1.DocumentApproval.actual_approvers:type_name -> iso9001.v1.Approval
+	60,  // 66: iso9001.v1.Approval.timestamp:type_name -> google.protobuf.Timestamp
+	60,  // 67: iso9001.v1.DocumentVersion.created_at:type_name -> google.protobuf.Timestamp
+	56,  // 68: iso9001.v1.Audit.scope:type_name -> iso9001.v1.AuditScope
+	60,  // 69: iso9001.v1.Audit.planned_start_date:type_name -> google.protobuf.Timestamp
+	60,  // 70: iso9001.v1.Audit.planned_end_date:type_name -> google.protobuf.Timestamp
+	60,  // 71: iso9001.v1.Audit.actual_start_date:type_name -> google.protobuf.Timestamp
+	60,  // 72: iso9001.v1.Audit.actual_end_date:type_name -> google.protobuf.Timestamp
+	57,  // 73: iso9001.v1.Audit.auditors:type_name -> iso9001.v1.AuditParticipant
+	57,  // 74: iso9001.v1.Audit.auditees:type_name -> iso9001.v1.AuditParticipant
+	58,  // 75: iso9001.v1.Audit.findings:type_name -> iso9001.v1.AuditFinding
+	59,  // 76: iso9001.v1.Audit.report:type_name -> iso9001.v1.AuditReport
+	60,  // 77: iso9001.v1.Audit.created:type_name -> google.protobuf.Timestamp
+	60,  // 78: iso9001.v1.Audit.modified:type_name -> google.protobuf.Timestamp
+	60,  // 79: iso9001.v1.AuditFinding.due_date:type_name -> google.protobuf.Timestamp
+	60,  // 80: iso9001.v1.AuditFinding.created:type_name -> google.protobuf.Timestamp
+	60,  // 81: iso9001.v1.AuditReport.issued_date:type_name -> google.protobuf.Timestamp
+	0,   // 82: iso9001.v1.QMSService.ValidateOrganization:input_type -> iso9001.v1.ValidateOrganizationRequest
+	1,   // 83: iso9001.v1.QMSService.GetComplianceScore:input_type -> iso9001.v1.GetComplianceScoreRequest
+	2,   // 84: iso9001.v1.QMSService.GenerateComplianceReport:input_type -> iso9001.v1.GenerateComplianceReportRequest
+	3,   // 85: iso9001.v1.QMSService.GetOrganization:input_type -> iso9001.v1.GetOrganizationRequest
+	4,   // 86: iso9001.v1.QMSService.PutOrganization:input_type -> iso9001.v1.PutOrganizationRequest
+	5,   // 87: iso9001.v1.QMSService.IdentifyRisk:input_type -> iso9001.v1.IdentifyRiskRequest
+	6,   // 88: iso9001.v1.QMSService.AssessRisk:input_type -> iso9001.v1.AssessRiskRequest
+	7,   // 89: iso9001.v1.QMSService.MitigateRisk:input_type -> iso9001.v1.MitigateRiskRequest
+	8,   // 90: iso9001.v1.QMSService.ListRisks:input_type -> iso9001.v1.ListRisksRequest
+	10,  // 91: iso9001.v1.QMSService.CreateObjective:input_type -> iso9001.v1.CreateObjectiveRequest
+	11,  // 92: iso9001.v1.QMSService.ListObjectives:input_type -> iso9001.v1.ListObjectivesRequest
+	13,  // 93: iso9001.v1.QMSService.AddDocument:input_type -> iso9001.v1.AddDocumentRequest
+	14,  // 94: iso9001.v1.QMSService.GetDocument:input_type -> iso9001.v1.GetDocumentRequest
+	15,  // 95: iso9001.v1.QMSService.ApproveDocument:input_type -> iso9001.v1.ApproveDocumentRequest
+	16,  // 96: iso9001.v1.QMSService.SetDocumentStatus:input_type -> iso9001.v1.SetDocumentStatusRequest
+	17,  // 97: iso9001.v1.QMSService.ListDocuments:input_type -> iso9001.v1.ListDocumentsRequest
+	19,  // 98: iso9001.v1.QMSService.CreateAudit:input_type -> iso9001.v1.CreateAuditRequest
+	20,  // 99: iso9001.v1.QMSService.StartAudit:input_type -> iso9001.v1.StartAuditRequest
+	21,  // 100: iso9001.v1.QMSService.AddFinding:input_type -> iso9001.v1.AddFindingRequest
+	22,  // 101: iso9001.v1.QMSService.CompleteAudit:input_type -> iso9001.v1.CompleteAuditRequest
+	23,  // 102: iso9001.v1.QMSService.ListAudits:input_type -> iso9001.v1.ListAuditsRequest
+	25,  // 103: iso9001.v1.QMSService.ExportRisks:input_type -> iso9001.v1.ExportRequest
+	25,  // 104: iso9001.v1.QMSService.ExportObjectives:input_type -> iso9001.v1.ExportRequest
+	25,  // 105: iso9001.v1.QMSService.ExportDocuments:input_type -> iso9001.v1.ExportRequest
+	25,  // 106: iso9001.v1.QMSService.ExportAudits:input_type -> iso9001.v1.ExportRequest
+	27,  // 107: iso9001.v1.QMSService.ValidateOrganization:output_type -> iso9001.v1.ValidationResult
+	28,  // 108: iso9001.v1.QMSService.GetComplianceScore:output_type -> iso9001.v1.ComplianceScore
+	31,  // 109: iso9001.v1.QMSService.GenerateComplianceReport:output_type -> iso9001.v1.ComplianceReport
+	32,  // 110: iso9001.v1.QMSService.GetOrganization:output_type -> iso9001.v1.Organization
+	32,  // 111: iso9001.v1.QMSService.PutOrganization:output_type -> iso9001.v1.Organization
+	47,  // 112: iso9001.v1.QMSService.IdentifyRisk:output_type -> iso9001.v1.Risk
+	47,  // 113: iso9001.v1.QMSService.AssessRisk:output_type -> iso9001.v1.Risk
+	47,  // 114: iso9001.v1.QMSService.MitigateRisk:output_type -> iso9001.v1.Risk
+	9,   // 115: iso9001.v1.QMSService.ListRisks:output_type -> iso9001.v1.ListRisksResponse
+	44,  // 116: iso9001.v1.QMSService.CreateObjective:output_type -> iso9001.v1.QualityObjective
+	12,  // 117: iso9001.v1.QMSService.ListObjectives:output_type -> iso9001.v1.ListObjectivesResponse
+	50,  // 118: iso9001.v1.QMSService.AddDocument:output_type -> iso9001.v1.DocumentedInformation
+	50,  // 119: iso9001.v1.QMSService.GetDocument:output_type -> iso9001.v1.DocumentedInformation
+	50,  // 120: iso9001.v1.QMSService.ApproveDocument:output_type -> iso9001.v1.DocumentedInformation
+	50,  // 121: iso9001.v1.QMSService.SetDocumentStatus:output_type -> iso9001.v1.DocumentedInformation
+	18,  // 122: iso9001.v1.QMSService.ListDocuments:output_type -> iso9001.v1.ListDocumentsResponse
+	55,  // 123: iso9001.v1.QMSService.CreateAudit:output_type -> iso9001.v1.Audit
+	55,  // 124: iso9001.v1.QMSService.StartAudit:output_type -> iso9001.v1.Audit
+	55,  // 125: iso9001.v1.QMSService.AddFinding:output_type -> iso9001.v1.Audit
+	55,  // 126: iso9001.v1.QMSService.CompleteAudit:output_type -> iso9001.v1.Audit
+	24,  // 127: iso9001.v1.QMSService.ListAudits:output_type -> iso9001.v1.ListAuditsResponse
+	47,  // 128: iso9001.v1.QMSService.ExportRisks:output_type -> iso9001.v1.Risk
+	44,  // 129: iso9001.v1.QMSService.ExportObjectives:output_type -> iso9001.v1.QualityObjective
+	50,  // 130: iso9001.v1.QMSService.ExportDocuments:output_type -> iso9001.v1.DocumentedInformation
+	55,  // 131: iso9001.v1.QMSService.ExportAudits:output_type -> iso9001.v1.Audit
+	107, // [107:132] is the sub-list for method output_type
+	82,  // [82:107] is the sub-list for method input_type
+	82,  // [82:82] is the sub-list for extension type_name
+	82,  // [82:82] is the sub-list for extension extendee
+	0,   // [0:82] is the sub-list for field type_name
+}
+
+func init() { file_iso9001_v1_qms_proto_init() }
+func file_iso9001_v1_qms_proto_init() {
+	if File_iso9001_v1_qms_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_iso9001_v1_qms_proto_rawDesc), len(file_iso9001_v1_qms_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   60,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_iso9001_v1_qms_proto_goTypes,
+		DependencyIndexes: file_iso9001_v1_qms_proto_depIdxs,
+		MessageInfos:      file_iso9001_v1_qms_proto_msgTypes,
+	}.Build()
+	File_iso9001_v1_qms_proto = out.File
+	file_iso9001_v1_qms_proto_goTypes = nil
+	file_iso9001_v1_qms_proto_depIdxs = nil
+}
@@ -0,0 +1,1075 @@
+// Service definition for the ISO 9001:2015 QMS engine.
+//
+// Messages mirror the Go types of github.com/example/iso9001 field for field, using
+// the same snake_case names as their JSON tags. Status, level and type values are
+// carried as strings holding the Go constant values (e.g. "in_progress") so new
+// values do not require a schema change.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: iso9001/v1/qms.proto
+
+package iso9001v1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	QMSService_ValidateOrganization_FullMethodName     = "/iso9001.v1.QMSService/ValidateOrganization"
+	QMSService_GetComplianceScore_FullMethodName       = "/iso9001.v1.QMSService/GetComplianceScore"
+	QMSService_GenerateComplianceReport_FullMethodName = "/iso9001.v1.QMSService/GenerateComplianceReport"
+	QMSService_GetOrganization_FullMethodName          = "/iso9001.v1.QMSService/GetOrganization"
+	QMSService_PutOrganization_FullMethodName          = "/iso9001.v1.QMSService/PutOrganization"
+	QMSService_IdentifyRisk_FullMethodName             = "/iso9001.v1.QMSService/IdentifyRisk"
+	QMSService_AssessRisk_FullMethodName               = "/iso9001.v1.QMSService/AssessRisk"
+	QMSService_MitigateRisk_FullMethodName             = "/iso9001.v1.QMSService/MitigateRisk"
+	QMSService_ListRisks_FullMethodName                = "/iso9001.v1.QMSService/ListRisks"
+	QMSService_CreateObjective_FullMethodName          = "/iso9001.v1.QMSService/CreateObjective"
+	QMSService_ListObjectives_FullMethodName           = "/iso9001.v1.QMSService/ListObjectives"
+	QMSService_AddDocument_FullMethodName              = "/iso9001.v1.QMSService/AddDocument"
+	QMSService_GetDocument_FullMethodName              = "/iso9001.v1.QMSService/GetDocument"
+	QMSService_ApproveDocument_FullMethodName          = "/iso9001.v1.QMSService/ApproveDocument"
+	QMSService_SetDocumentStatus_FullMethodName        = "/iso9001.v1.QMSService/SetDocumentStatus"
+	QMSService_ListDocuments_FullMethodName            = "/iso9001.v1.QMSService/ListDocuments"
+	QMSService_CreateAudit_FullMethodName              = "/iso9001.v1.QMSService/CreateAudit"
+	QMSService_StartAudit_FullMethodName               = "/iso9001.v1.QMSService/StartAudit"
+	QMSService_AddFinding_FullMethodName               = "/iso9001.v1.QMSService/AddFinding"
+	QMSService_CompleteAudit_FullMethodName            = "/iso9001.v1.QMSService/CompleteAudit"
+	QMSService_ListAudits_FullMethodName               = "/iso9001.v1.QMSService/ListAudits"
+	QMSService_ExportRisks_FullMethodName              = "/iso9001.v1.QMSService/ExportRisks"
+	QMSService_ExportObjectives_FullMethodName         = "/iso9001.v1.QMSService/ExportObjectives"
+	QMSService_ExportDocuments_FullMethodName          = "/iso9001.v1.QMSService/ExportDocuments"
+	QMSService_ExportAudits_FullMethodName             = "/iso9001.v1.QMSService/ExportAudits"
+)
+
+// QMSServiceClient is the client API for QMSService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// QMSService exposes validation, scoring and entity management. Every request names
+// the tenant whose organization and managers it operates on.
+type QMSServiceClient interface {
+	// Compliance
+	ValidateOrganization(ctx context.Context, in *ValidateOrganizationRequest, opts ...grpc.CallOption) (*ValidationResult, error)
+	GetComplianceScore(ctx context.Context, in *GetComplianceScoreRequest, opts ...grpc.CallOption) (*ComplianceScore, error)
+	GenerateComplianceReport(ctx context.Context, in *GenerateComplianceReportRequest, opts ...grpc.CallOption) (*ComplianceReport, error)
+	// Organizations
+	GetOrganization(ctx context.Context, in *GetOrganizationRequest, opts ...grpc.CallOption) (*Organization, error)
+	PutOrganization(ctx context.Context, in *PutOrganizationRequest, opts ...grpc.CallOption) (*Organization, error)
+	// Risks and opportunities (clause 6.1)
+	IdentifyRisk(ctx context.Context, in *IdentifyRiskRequest, opts ...grpc.CallOption) (*Risk, error)
+	AssessRisk(ctx context.Context, in *AssessRiskRequest, opts ...grpc.CallOption) (*Risk, error)
+	MitigateRisk(ctx context.Context, in *MitigateRiskRequest, opts ...grpc.CallOption) (*Risk, error)
+	ListRisks(ctx context.Context, in *ListRisksRequest, opts ...grpc.CallOption) (*ListRisksResponse, error)
+	// Quality objectives (clause 6.2)
+	CreateObjective(ctx context.Context, in *CreateObjectiveRequest, opts ...grpc.CallOption) (*QualityObjective, error)
+	ListObjectives(ctx context.Context, in *ListObjectivesRequest, opts ...grpc.CallOption) (*ListObjectivesResponse, error)
+	// Documented information (clause 7.5)
+	AddDocument(ctx context.Context, in *AddDocumentRequest, opts ...grpc.CallOption) (*DocumentedInformation, error)
+	GetDocument(ctx context.Context, in *GetDocumentRequest, opts ...grpc.CallOption) (*DocumentedInformation, error)
+	ApproveDocument(ctx context.Context, in *ApproveDocumentRequest, opts ...grpc.CallOption) (*DocumentedInformation, error)
+	SetDocumentStatus(ctx context.Context, in *SetDocumentStatusRequest, opts ...grpc.CallOption) (*DocumentedInformation, error)
+	ListDocuments(ctx context.Context, in *ListDocumentsRequest, opts ...grpc.CallOption) (*ListDocumentsResponse, error)
+	// Internal audits (clause 9.2)
+	CreateAudit(ctx context.Context, in *CreateAuditRequest, opts ...grpc.CallOption) (*Audit, error)
+	StartAudit(ctx context.Context, in *StartAuditRequest, opts ...grpc.CallOption) (*Audit, error)
+	AddFinding(ctx context.Context, in *AddFindingRequest, opts ...grpc.CallOption) (*Audit, error)
+	CompleteAudit(ctx context.Context, in *CompleteAuditRequest, opts ...grpc.CallOption) (*Audit, error)
+	ListAudits(ctx context.Context, in *ListAuditsRequest, opts ...grpc.CallOption) (*ListAuditsResponse, error)
+	// Bulk export. Each call streams one collection of the tenant, ordered by ID, one
+	// entity per message, so large registers need not fit into a single response.
+	ExportRisks(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Risk], error)
+	ExportObjectives(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[QualityObjective], error)
+	ExportDocuments(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DocumentedInformation], error)
+	ExportAudits(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Audit], error)
+}
+
+type qMSServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewQMSServiceClient(cc grpc.ClientConnInterface) QMSServiceClient {
+	return &qMSServiceClient{cc}
+}
+
+func (c *qMSServiceClient) ValidateOrganization(ctx context.Context, in *ValidateOrganizationRequest, opts ...grpc.CallOption) (*ValidationResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ValidationResult)
+	err := c.cc.Invoke(ctx, QMSService_ValidateOrganization_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qMSServiceClient) GetComplianceScore(ctx context.Context, in *GetComplianceScoreRequest, opts ...grpc.CallOption) (*ComplianceScore, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ComplianceScore)
+	err := c.cc.Invoke(ctx, QMSService_GetComplianceScore_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qMSServiceClient) GenerateComplianceReport(ctx context.Context, in *GenerateComplianceReportRequest, opts ...grpc.CallOption) (*ComplianceReport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ComplianceReport)
+	err := c.cc.Invoke(ctx, QMSService_GenerateComplianceReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qMSServiceClient) GetOrganization(ctx context.Context, in *GetOrganizationRequest, opts ...grpc.CallOption) (*Organization, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Organization)
+	err := c.cc.Invoke(ctx, QMSService_GetOrganization_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qMSServiceClient) PutOrganization(ctx context.Context, in *PutOrganizationRequest, opts ...grpc.CallOption) (*Organization, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Organization)
+	err := c.cc.Invoke(ctx, QMSService_PutOrganization_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qMSServiceClient) IdentifyRisk(ctx context.Context, in *IdentifyRiskRequest, opts ...grpc.CallOption) (*Risk, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Risk)
+	err := c.cc.Invoke(ctx, QMSService_IdentifyRisk_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qMSServiceClient) AssessRisk(ctx context.Context, in *AssessRiskRequest, opts ...grpc.CallOption) (*Risk, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Risk)
+	err := c.cc.Invoke(ctx, QMSService_AssessRisk_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qMSServiceClient) MitigateRisk(ctx context.Context, in *MitigateRiskRequest, opts ...grpc.CallOption) (*Risk, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Risk)
+	err := c.cc.Invoke(ctx, QMSService_MitigateRisk_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qMSServiceClient) ListRisks(ctx context.Context, in *ListRisksRequest, opts ...grpc.CallOption) (*ListRisksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRisksResponse)
+	err := c.cc.Invoke(ctx, QMSService_ListRisks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qMSServiceClient) CreateObjective(ctx context.Context, in *CreateObjectiveRequest, opts ...grpc.CallOption) (*QualityObjective, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QualityObjective)
+	err := c.cc.Invoke(ctx, QMSService_CreateObjective_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qMSServiceClient) ListObjectives(ctx context.Context, in *ListObjectivesRequest, opts ...grpc.CallOption) (*ListObjectivesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListObjectivesResponse)
+	err := c.cc.Invoke(ctx, QMSService_ListObjectives_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qMSServiceClient) AddDocument(ctx context.Context, in *AddDocumentRequest, opts ...grpc.CallOption) (*DocumentedInformation, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DocumentedInformation)
+	err := c.cc.Invoke(ctx, QMSService_AddDocument_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qMSServiceClient) GetDocument(ctx context.Context, in *GetDocumentRequest, opts ...grpc.CallOption) (*DocumentedInformation, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DocumentedInformation)
+	err := c.cc.Invoke(ctx, QMSService_GetDocument_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qMSServiceClient) ApproveDocument(ctx context.Context, in *ApproveDocumentRequest, opts ...grpc.CallOption) (*DocumentedInformation, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DocumentedInformation)
+	err := c.cc.Invoke(ctx, QMSService_ApproveDocument_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qMSServiceClient) SetDocumentStatus(ctx context.Context, in *SetDocumentStatusRequest, opts ...grpc.CallOption) (*DocumentedInformation, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DocumentedInformation)
+	err := c.cc.Invoke(ctx, QMSService_SetDocumentStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qMSServiceClient) ListDocuments(ctx context.Context, in *ListDocumentsRequest, opts ...grpc.CallOption) (*ListDocumentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDocumentsResponse)
+	err := c.cc.Invoke(ctx, QMSService_ListDocuments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qMSServiceClient) CreateAudit(ctx context.Context, in *CreateAuditRequest, opts ...grpc.CallOption) (*Audit, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Audit)
+	err := c.cc.Invoke(ctx, QMSService_CreateAudit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qMSServiceClient) StartAudit(ctx context.Context, in *StartAuditRequest, opts ...grpc.CallOption) (*Audit, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Audit)
+	err := c.cc.Invoke(ctx, QMSService_StartAudit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qMSServiceClient) AddFinding(ctx context.Context, in *AddFindingRequest, opts ...grpc.CallOption) (*Audit, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Audit)
+	err := c.cc.Invoke(ctx, QMSService_AddFinding_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qMSServiceClient) CompleteAudit(ctx context.Context, in *CompleteAuditRequest, opts ...grpc.CallOption) (*Audit, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Audit)
+	err := c.cc.Invoke(ctx, QMSService_CompleteAudit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qMSServiceClient) ListAudits(ctx context.Context, in *ListAuditsRequest, opts ...grpc.CallOption) (*ListAuditsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAuditsResponse)
+	err := c.cc.Invoke(ctx, QMSService_ListAudits_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qMSServiceClient) ExportRisks(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Risk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &QMSService_ServiceDesc.Streams[0], QMSService_ExportRisks_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportRequest, Risk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type QMSService_ExportRisksClient = grpc.ServerStreamingClient[Risk]
+
+func (c *qMSServiceClient) ExportObjectives(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[QualityObjective], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &QMSService_ServiceDesc.Streams[1], QMSService_ExportObjectives_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportRequest, QualityObjective]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type QMSService_ExportObjectivesClient = grpc.ServerStreamingClient[QualityObjective]
+
+func (c *qMSServiceClient) ExportDocuments(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DocumentedInformation], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &QMSService_ServiceDesc.Streams[2], QMSService_ExportDocuments_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportRequest, DocumentedInformation]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type QMSService_ExportDocumentsClient = grpc.ServerStreamingClient[DocumentedInformation]
+
+func (c *qMSServiceClient) ExportAudits(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Audit], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &QMSService_ServiceDesc.Streams[3], QMSService_ExportAudits_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportRequest, Audit]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type QMSService_ExportAuditsClient = grpc.ServerStreamingClient[Audit]
+
+// QMSServiceServer is the server API for QMSService service.
+// All implementations must embed UnimplementedQMSServiceServer
+// for forward compatibility.
+//
+// QMSService exposes validation, scoring and entity management. Every request names
+// the tenant whose organization and managers it operates on.
+type QMSServiceServer interface {
+	// Compliance
+	ValidateOrganization(context.Context, *ValidateOrganizationRequest) (*ValidationResult, error)
+	GetComplianceScore(context.Context, *GetComplianceScoreRequest) (*ComplianceScore, error)
+	GenerateComplianceReport(context.Context, *GenerateComplianceReportRequest) (*ComplianceReport, error)
+	// Organizations
+	GetOrganization(context.Context, *GetOrganizationRequest) (*Organization, error)
+	PutOrganization(context.Context, *PutOrganizationRequest) (*Organization, error)
+	// Risks and opportunities (clause 6.1)
+	IdentifyRisk(context.Context, *IdentifyRiskRequest) (*Risk, error)
+	AssessRisk(context.Context, *AssessRiskRequest) (*Risk, error)
+	MitigateRisk(context.Context, *MitigateRiskRequest) (*Risk, error)
+	ListRisks(context.Context, *ListRisksRequest) (*ListRisksResponse, error)
+	// Quality objectives (clause 6.2)
+	CreateObjective(context.Context, *CreateObjectiveRequest) (*QualityObjective, error)
+	ListObjectives(context.Context, *ListObjectivesRequest) (*ListObjectivesResponse, error)
+	// Documented information (clause 7.5)
+	AddDocument(context.Context, *AddDocumentRequest) (*DocumentedInformation, error)
+	GetDocument(context.Context, *GetDocumentRequest) (*DocumentedInformation, error)
+	ApproveDocument(context.Context, *ApproveDocumentRequest) (*DocumentedInformation, error)
+	SetDocumentStatus(context.Context, *SetDocumentStatusRequest) (*DocumentedInformation, error)
+	ListDocuments(context.Context, *ListDocumentsRequest) (*ListDocumentsResponse, error)
+	// Internal audits (clause 9.2)
+	CreateAudit(context.Context, *CreateAuditRequest) (*Audit, error)
+	StartAudit(context.Context, *StartAuditRequest) (*Audit, error)
+	AddFinding(context.Context, *AddFindingRequest) (*Audit, error)
+	CompleteAudit(context.Context, *CompleteAuditRequest) (*Audit, error)
+	ListAudits(context.Context, *ListAuditsRequest) (*ListAuditsResponse, error)
+	// Bulk export. Each call streams one collection of the tenant, ordered by ID, one
+	// entity per message, so large registers need not fit into a single response.
+	ExportRisks(*ExportRequest, grpc.ServerStreamingServer[Risk]) error
+	ExportObjectives(*ExportRequest, grpc.ServerStreamingServer[QualityObjective]) error
+	ExportDocuments(*ExportRequest, grpc.ServerStreamingServer[DocumentedInformation]) error
+	ExportAudits(*ExportRequest, grpc.ServerStreamingServer[Audit]) error
+	mustEmbedUnimplementedQMSServiceServer()
+}
+
+// UnimplementedQMSServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedQMSServiceServer struct{}
+
+func (UnimplementedQMSServiceServer) ValidateOrganization(context.Context, *ValidateOrganizationRequest) (*ValidationResult, error) {
+	return nil, status.Error(codes.Unimplemented, "method ValidateOrganization not implemented")
+}
+func (UnimplementedQMSServiceServer) GetComplianceScore(context.Context, *GetComplianceScoreRequest) (*ComplianceScore, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetComplianceScore not implemented")
+}
+func (UnimplementedQMSServiceServer) GenerateComplianceReport(context.Context, *GenerateComplianceReportRequest) (*ComplianceReport, error) {
+	return nil, status.Error(codes.Unimplemented, "method GenerateComplianceReport not implemented")
+}
+func (UnimplementedQMSServiceServer) GetOrganization(context.Context, *GetOrganizationRequest) (*Organization, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetOrganization not implemented")
+}
+func (UnimplementedQMSServiceServer) PutOrganization(context.Context, *PutOrganizationRequest) (*Organization, error) {
+	return nil, status.Error(codes.Unimplemented, "method PutOrganization not implemented")
+}
+func (UnimplementedQMSServiceServer) IdentifyRisk(context.Context, *IdentifyRiskRequest) (*Risk, error) {
+	return nil, status.Error(codes.Unimplemented, "method IdentifyRisk not implemented")
+}
+func (UnimplementedQMSServiceServer) AssessRisk(context.Context, *AssessRiskRequest) (*Risk, error) {
+	return nil, status.Error(codes.Unimplemented, "method AssessRisk not implemented")
+}
+func (UnimplementedQMSServiceServer) MitigateRisk(context.Context, *MitigateRiskRequest) (*Risk, error) {
+	return nil, status.Error(codes.Unimplemented, "method MitigateRisk not implemented")
+}
+func (UnimplementedQMSServiceServer) ListRisks(context.Context, *ListRisksRequest) (*ListRisksResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListRisks not implemented")
+}
+func (UnimplementedQMSServiceServer) CreateObjective(context.Context, *CreateObjectiveRequest) (*QualityObjective, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateObjective not implemented")
+}
+func (UnimplementedQMSServiceServer) ListObjectives(context.Context, *ListObjectivesRequest) (*ListObjectivesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListObjectives not implemented")
+}
+func (UnimplementedQMSServiceServer) AddDocument(context.Context, *AddDocumentRequest) (*DocumentedInformation, error) {
+	return nil, status.Error(codes.Unimplemented, "method AddDocument not implemented")
+}
+func (UnimplementedQMSServiceServer) GetDocument(context.Context, *GetDocumentRequest) (*DocumentedInformation, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetDocument not implemented")
+}
+func (UnimplementedQMSServiceServer) ApproveDocument(context.Context, *ApproveDocumentRequest) (*DocumentedInformation, error) {
+	return nil, status.Error(codes.Unimplemented, "method ApproveDocument not implemented")
+}
+func (UnimplementedQMSServiceServer) SetDocumentStatus(context.Context, *SetDocumentStatusRequest) (*DocumentedInformation, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetDocumentStatus not implemented")
+}
+func (UnimplementedQMSServiceServer) ListDocuments(context.Context, *ListDocumentsRequest) (*ListDocumentsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListDocuments not implemented")
+}
+func (UnimplementedQMSServiceServer) CreateAudit(context.Context, *CreateAuditRequest) (*Audit, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateAudit not implemented")
+}
+func (UnimplementedQMSServiceServer) StartAudit(context.Context, *StartAuditRequest) (*Audit, error) {
+	return nil, status.Error(codes.Unimplemented, "method StartAudit not implemented")
+}
+func (UnimplementedQMSServiceServer) AddFinding(context.Context, *AddFindingRequest) (*Audit, error) {
+	return nil, status.Error(codes.Unimplemented, "method AddFinding not implemented")
+}
+func (UnimplementedQMSServiceServer) CompleteAudit(context.Context, *CompleteAuditRequest) (*Audit, error) {
+	return nil, status.Error(codes.Unimplemented, "method CompleteAudit not implemented")
+}
+func (UnimplementedQMSServiceServer) ListAudits(context.Context, *ListAuditsRequest) (*ListAuditsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListAudits not implemented")
+}
+func (UnimplementedQMSServiceServer) ExportRisks(*ExportRequest, grpc.ServerStreamingServer[Risk]) error {
+	return status.Error(codes.Unimplemented, "method ExportRisks not implemented")
+}
+func (UnimplementedQMSServiceServer) ExportObjectives(*ExportRequest, grpc.ServerStreamingServer[QualityObjective]) error {
+	return status.Error(codes.Unimplemented, "method ExportObjectives not implemented")
+}
+func (UnimplementedQMSServiceServer) ExportDocuments(*ExportRequest, grpc.ServerStreamingServer[DocumentedInformation]) error {
+	return status.Error(codes.Unimplemented, "method ExportDocuments not implemented")
+}
+func (UnimplementedQMSServiceServer) ExportAudits(*ExportRequest, grpc.ServerStreamingServer[Audit]) error {
+	return status.Error(codes.Unimplemented, "method ExportAudits not implemented")
+}
+func (UnimplementedQMSServiceServer) mustEmbedUnimplementedQMSServiceServer() {}
+func (UnimplementedQMSServiceServer) testEmbeddedByValue()                    {}
+
+// UnsafeQMSServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to QMSServiceServer will
+// result in compilation errors.
+type UnsafeQMSServiceServer interface {
+	mustEmbedUnimplementedQMSServiceServer()
+}
+
+func RegisterQMSServiceServer(s grpc.ServiceRegistrar, srv QMSServiceServer) {
+	// If the following call panics, it indicates UnimplementedQMSServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&QMSService_ServiceDesc, srv)
+}
+
+func _QMSService_ValidateOrganization_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateOrganizationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QMSServiceServer).ValidateOrganization(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QMSService_ValidateOrganization_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QMSServiceServer).ValidateOrganization(ctx, req.(*ValidateOrganizationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QMSService_GetComplianceScore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetComplianceScoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QMSServiceServer).GetComplianceScore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QMSService_GetComplianceScore_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QMSServiceServer).GetComplianceScore(ctx, req.(*GetComplianceScoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QMSService_GenerateComplianceReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenerateComplianceReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QMSServiceServer).GenerateComplianceReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QMSService_GenerateComplianceReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QMSServiceServer).GenerateComplianceReport(ctx, req.(*GenerateComplianceReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QMSService_GetOrganization_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrganizationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QMSServiceServer).GetOrganization(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QMSService_GetOrganization_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QMSServiceServer).GetOrganization(ctx, req.(*GetOrganizationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QMSService_PutOrganization_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PutOrganizationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QMSServiceServer).PutOrganization(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QMSService_PutOrganization_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QMSServiceServer).PutOrganization(ctx, req.(*PutOrganizationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QMSService_IdentifyRisk_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdentifyRiskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QMSServiceServer).IdentifyRisk(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QMSService_IdentifyRisk_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QMSServiceServer).IdentifyRisk(ctx, req.(*IdentifyRiskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QMSService_AssessRisk_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AssessRiskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QMSServiceServer).AssessRisk(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QMSService_AssessRisk_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QMSServiceServer).AssessRisk(ctx, req.(*AssessRiskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QMSService_MitigateRisk_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MitigateRiskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QMSServiceServer).MitigateRisk(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QMSService_MitigateRisk_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QMSServiceServer).MitigateRisk(ctx, req.(*MitigateRiskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QMSService_ListRisks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRisksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QMSServiceServer).ListRisks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QMSService_ListRisks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QMSServiceServer).ListRisks(ctx, req.(*ListRisksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QMSService_CreateObjective_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateObjectiveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QMSServiceServer).CreateObjective(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QMSService_CreateObjective_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QMSServiceServer).CreateObjective(ctx, req.(*CreateObjectiveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QMSService_ListObjectives_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListObjectivesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QMSServiceServer).ListObjectives(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QMSService_ListObjectives_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QMSServiceServer).ListObjectives(ctx, req.(*ListObjectivesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QMSService_AddDocument_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddDocumentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QMSServiceServer).AddDocument(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QMSService_AddDocument_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QMSServiceServer).AddDocument(ctx, req.(*AddDocumentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QMSService_GetDocument_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDocumentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QMSServiceServer).GetDocument(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QMSService_GetDocument_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QMSServiceServer).GetDocument(ctx, req.(*GetDocumentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QMSService_ApproveDocument_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApproveDocumentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QMSServiceServer).ApproveDocument(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QMSService_ApproveDocument_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QMSServiceServer).ApproveDocument(ctx, req.(*ApproveDocumentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QMSService_SetDocumentStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetDocumentStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QMSServiceServer).SetDocumentStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QMSService_SetDocumentStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QMSServiceServer).SetDocumentStatus(ctx, req.(*SetDocumentStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QMSService_ListDocuments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDocumentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QMSServiceServer).ListDocuments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QMSService_ListDocuments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QMSServiceServer).ListDocuments(ctx, req.(*ListDocumentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QMSService_CreateAudit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAuditRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QMSServiceServer).CreateAudit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QMSService_CreateAudit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QMSServiceServer).CreateAudit(ctx, req.(*CreateAuditRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QMSService_StartAudit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartAuditRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QMSServiceServer).StartAudit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QMSService_StartAudit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QMSServiceServer).StartAudit(ctx, req.(*StartAuditRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QMSService_AddFinding_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddFindingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QMSServiceServer).AddFinding(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QMSService_AddFinding_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QMSServiceServer).AddFinding(ctx, req.(*AddFindingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QMSService_CompleteAudit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompleteAuditRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QMSServiceServer).CompleteAudit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QMSService_CompleteAudit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QMSServiceServer).CompleteAudit(ctx, req.(*CompleteAuditRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QMSService_ListAudits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAuditsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QMSServiceServer).ListAudits(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QMSService_ListAudits_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QMSServiceServer).ListAudits(ctx, req.(*ListAuditsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QMSService_ExportRisks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(QMSServiceServer).ExportRisks(m, &grpc.GenericServerStream[ExportRequest, Risk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type QMSService_ExportRisksServer = grpc.ServerStreamingServer[Risk]
+
+func _QMSService_ExportObjectives_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(QMSServiceServer).ExportObjectives(m, &grpc.GenericServerStream[ExportRequest, QualityObjective]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type QMSService_ExportObjectivesServer = grpc.ServerStreamingServer[QualityObjective]
+
+func _QMSService_ExportDocuments_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(QMSServiceServer).ExportDocuments(m, &grpc.GenericServerStream[ExportRequest, DocumentedInformation]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type QMSService_ExportDocumentsServer = grpc.ServerStreamingServer[DocumentedInformation]
+
+func _QMSService_ExportAudits_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(QMSServiceServer).ExportAudits(m, &grpc.GenericServerStream[ExportRequest, Audit]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type QMSService_ExportAuditsServer = grpc.ServerStreamingServer[Audit]
+
+// QMSService_ServiceDesc is the grpc.ServiceDesc for QMSService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var QMSService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "iso9001.v1.QMSService",
+	HandlerType: (*QMSServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ValidateOrganization",
+			Handler:    _QMSService_ValidateOrganization_Handler,
+		},
+		{
+			MethodName: "GetComplianceScore",
+			Handler:    _QMSService_GetComplianceScore_Handler,
+		},
+		{
+			MethodName: "GenerateComplianceReport",
+			Handler:    _QMSService_GenerateComplianceReport_Handler,
+		},
+		{
+			MethodName: "GetOrganization",
+			Handler:    _QMSService_GetOrganization_Handler,
+		},
+		{
+			MethodName: "PutOrganization",
+			Handler:    _QMSService_PutOrganization_Handler,
+		},
+		{
+			MethodName: "IdentifyRisk",
+			Handler:    _QMSService_IdentifyRisk_Handler,
+		},
+		{
+			MethodName: "AssessRisk",
+			Handler:    _QMSService_AssessRisk_Handler,
+		},
+		{
+			MethodName: "MitigateRisk",
+			Handler:    _QMSService_MitigateRisk_Handler,
+		},
+		{
+			MethodName: "ListRisks",
+			Handler:    _QMSService_ListRisks_Handler,
+		},
+		{
+			MethodName: "CreateObjective",
+			Handler:    _QMSService_CreateObjective_Handler,
+		},
+		{
+			MethodName: "ListObjectives",
+			Handler:    _QMSService_ListObjectives_Handler,
+		},
+		{
+			MethodName: "AddDocument",
+			Handler:    _QMSService_AddDocument_Handler,
+		},
+		{
+			MethodName: "GetDocument",
+			Handler:    _QMSService_GetDocument_Handler,
+		},
+		{
+			MethodName: "ApproveDocument",
+			Handler:    _QMSService_ApproveDocument_Handler,
+		},
+		{
+			MethodName: "SetDocumentStatus",
+			Handler:    _QMSService_SetDocumentStatus_Handler,
+		},
+		{
+			MethodName: "ListDocuments",
+			Handler:    _QMSService_ListDocuments_Handler,
+		},
+		{
+			MethodName: "CreateAudit",
+			Handler:    _QMSService_CreateAudit_Handler,
+		},
+		{
+			MethodName: "StartAudit",
+			Handler:    _QMSService_StartAudit_Handler,
+		},
+		{
+			MethodName: "AddFinding",
+			Handler:    _QMSService_AddFinding_Handler,
+		},
+		{
+			MethodName: "CompleteAudit",
+			Handler:    _QMSService_CompleteAudit_Handler,
+		},
+		{
+			MethodName: "ListAudits",
+			Handler:    _QMSService_ListAudits_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportRisks",
+			Handler:       _QMSService_ExportRisks_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ExportObjectives",
+			Handler:       _QMSService_ExportObjectives_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ExportDocuments",
+			Handler:       _QMSService_ExportDocuments_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ExportAudits",
+			Handler:       _QMSService_ExportAudits_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "iso9001/v1/qms.proto",
+}
@@ -0,0 +1,126 @@
+// Command iso9001-grpc serves QMSService, the gRPC interface to the QMS engine defined
+// in proto/iso9001/v1/qms.proto, on the organizations of a store directory.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/example/iso9001"
+	iso9001v1 "github.com/example/iso9001-grpc/gen/iso9001/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+func main() {
+	storeDir := flag.String("store", "", "Directory holding the organizations; the same directory as iso9001-mcp -store and iso9001ctl serve -store")
+	eventStore := flag.Bool("event-store", false, "Read and persist -store as events, as written by iso9001-mcp -event-store")
+	addr := flag.String("listen", "localhost:9090", "Address to listen on")
+	maxIdle := flag.Duration("max-idle", time.Minute, "Reload tenants from the store after this long without access")
+	requireKey := flag.Bool("require-api-key", false, "Require an API key (see iso9001ctl apikey) on every call; calls that change the store need a write-scoped key")
+	rate := flag.Float64("rate", 0, "Calls per second allowed per client address and per API key; zero is unlimited")
+	burst := flag.Int("burst", 0, "Calls a client may make at once (default the rate, at least 1)")
+	quota := flag.Int("daily-quota", 0, "Calls per day allowed per client address and per API key; zero is unlimited")
+	shutdownTimeout := flag.Duration("shutdown-timeout", 30*time.Second, "How long open calls may take to finish when the server is stopped")
+	flag.Parse()
+
+	if *storeDir == "" {
+		log.Fatal("-store is required")
+	}
+	store, err := openStore(*storeDir, *eventStore)
+	if err != nil {
+		log.Fatalf("Invalid -store: %v", err)
+	}
+	stop := store.StartEviction(*maxIdle/2, *maxIdle, func(err error) {
+		log.Printf("Eviction error: %v", err)
+	})
+	defer stop()
+
+	var keys *iso9001.APIKeyManager
+	if *requireKey {
+		if keys, err = iso9001.NewAPIKeyManager(iso9001.APIKeyFile(*storeDir)); err != nil {
+			log.Fatalf("Invalid -store: %v", err)
+		}
+		if !keys.HasActiveKeys() {
+			log.Fatal("-require-api-key set but the store has no active API keys; create one with iso9001ctl apikey create")
+		}
+	}
+	limiter := iso9001.NewRateLimiter(iso9001.RateLimit{Rate: *rate, Burst: *burst, DailyQuota: *quota})
+
+	server := grpc.NewServer(guard(keys, limiter)...)
+	iso9001v1.RegisterQMSServiceServer(server, &qmsServer{store: store})
+	reflection.Register(server)
+
+	listener, err := net.Listen("tcp", *addr)
+	if err != nil {
+		log.Fatalf("Invalid -listen: %v", err)
+	}
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	served := make(chan error, 1)
+	go func() {
+		served <- server.Serve(listener)
+	}()
+	log.Printf("Serving organizations from %s over gRPC on %s", *storeDir, listener.Addr())
+
+	select {
+	case err := <-served:
+		log.Fatal(err)
+	case <-ctx.Done():
+	}
+
+	log.Println("Shutting down...")
+	gracefulStop(server, *shutdownTimeout)
+	stop()
+	if err := store.Flush(); err != nil {
+		log.Fatalf("Failed to save the store: %v", err)
+	}
+}
+
+// gracefulStop lets open calls finish, cutting off those still running after timeout
+func gracefulStop(server *grpc.Server, timeout time.Duration) {
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(timeout):
+		log.Printf("Graceful shutdown incomplete after %s", timeout)
+		server.Stop()
+		<-stopped
+	}
+}
+
+// openStore opens the tenants of a store directory, kept as files or as events
+func openStore(dir string, events bool) (*iso9001.TenantStore, error) {
+	var backend iso9001.TenantBackend
+	if events {
+		store, err := iso9001.NewFileEventStore(dir)
+		if err != nil {
+			return nil, err
+		}
+		backend = iso9001.NewEventSourcedBackend(store)
+	} else {
+		files, err := iso9001.NewFileTenantBackend(dir)
+		if err != nil {
+			return nil, err
+		}
+		backend = files
+	}
+	key, err := iso9001.LoadTrailKey(iso9001.TrailKeyFile(dir))
+	if err != nil {
+		return nil, err
+	}
+	store := iso9001.NewTenantStore(backend, 0)
+	store.SetTrailKey(key)
+	return store, nil
+}
@@ -0,0 +1,501 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/example/iso9001"
+	iso9001v1 "github.com/example/iso9001-grpc/gen/iso9001/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// qmsServer implements QMSService on the tenants of a store, which it shares with the
+// MCP server and iso9001ctl serve
+type qmsServer struct {
+	iso9001v1.UnimplementedQMSServiceServer
+	store *iso9001.TenantStore
+}
+
+// view runs fn on the tenant named in a request, unless the call is cancelled or its
+// deadline passes first
+func (s *qmsServer) view(ctx context.Context, tenantID string, fn func(tenant *iso9001.Tenant) error) error {
+	return rpcError(s.store.WithTenantContext(ctx, tenantID, fn))
+}
+
+// change runs fn on the tenant named in a request, records the change in the tenant's
+// audit trail under the caller and saves the store
+func (s *qmsServer) change(ctx context.Context, tenantID, action string, fn func(tenant *iso9001.Tenant) error) error {
+	ctx = iso9001.WithActor(ctx, caller(ctx))
+	err := s.store.WithTenantContext(ctx, tenantID, func(tenant *iso9001.Tenant) error {
+		return tenant.ChangeContext(ctx, action, fn)
+	})
+	if err != nil {
+		return rpcError(err)
+	}
+	if err := s.store.FlushTenantContext(ctx, tenantID); err != nil {
+		return rpcError(err)
+	}
+	return nil
+}
+
+// withOrganization runs fn on the organization a compliance request carries or else on
+// the one stored for the tenant
+func (s *qmsServer) withOrganization(ctx context.Context, tenantID string, msg *iso9001v1.Organization, fn func(org *iso9001.Organization)) error {
+	if msg != nil {
+		var org iso9001.Organization
+		if err := fromProto(msg, &org, "organization"); err != nil {
+			return err
+		}
+		fn(&org)
+		return nil
+	}
+	return s.view(ctx, tenantID, func(tenant *iso9001.Tenant) error {
+		fn(tenant.Organization)
+		return nil
+	})
+}
+
+// Compliance
+
+func (s *qmsServer) ValidateOrganization(ctx context.Context, req *iso9001v1.ValidateOrganizationRequest) (*iso9001v1.ValidationResult, error) {
+	var result *iso9001.ValidationResult
+	if err := s.withOrganization(ctx, req.GetTenantId(), req.GetOrganization(), func(org *iso9001.Organization) {
+		result = iso9001.ValidateOrganization(org)
+	}); err != nil {
+		return nil, err
+	}
+	return toProto(result, &iso9001v1.ValidationResult{})
+}
+
+func (s *qmsServer) GetComplianceScore(ctx context.Context, req *iso9001v1.GetComplianceScoreRequest) (*iso9001v1.ComplianceScore, error) {
+	score := &iso9001v1.ComplianceScore{}
+	if err := s.withOrganization(ctx, req.GetTenantId(), req.GetOrganization(), func(org *iso9001.Organization) {
+		score.OrganizationId = org.ID
+		score.Score = iso9001.GetComplianceScore(org)
+	}); err != nil {
+		return nil, err
+	}
+	return score, nil
+}
+
+func (s *qmsServer) GenerateComplianceReport(ctx context.Context, req *iso9001v1.GenerateComplianceReportRequest) (*iso9001v1.ComplianceReport, error) {
+	var report *iso9001.ComplianceReport
+	if err := s.withOrganization(ctx, req.GetTenantId(), req.GetOrganization(), func(org *iso9001.Organization) {
+		report = iso9001.GenerateComplianceReport(org)
+	}); err != nil {
+		return nil, err
+	}
+	return toProto(report, &iso9001v1.ComplianceReport{})
+}
+
+// Organizations
+
+func (s *qmsServer) GetOrganization(ctx context.Context, req *iso9001v1.GetOrganizationRequest) (*iso9001v1.Organization, error) {
+	var result *iso9001v1.Organization
+	err := s.view(ctx, req.GetTenantId(), func(tenant *iso9001.Tenant) error {
+		var err error
+		result, err = toProto(tenant.Organization, &iso9001v1.Organization{})
+		return err
+	})
+	return result, err
+}
+
+// PutOrganization replaces the organization of a tenant, creating the tenant on first
+// use. The organization keeps the tenant's ID and its creation date.
+func (s *qmsServer) PutOrganization(ctx context.Context, req *iso9001v1.PutOrganizationRequest) (*iso9001v1.Organization, error) {
+	if req.GetTenantId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "tenant_id is required")
+	}
+	var org iso9001.Organization
+	if err := fromProto(req.GetOrganization(), &org, "organization"); err != nil {
+		return nil, err
+	}
+	if _, err := s.store.GetTenantContext(ctx, req.GetTenantId()); errors.Is(err, iso9001.ErrTenantNotFound) {
+		// a concurrent call may have created it meanwhile, which change then finds
+		s.store.CreateTenant(req.GetTenantId())
+	} else if err != nil {
+		return nil, rpcError(err)
+	}
+
+	var result *iso9001v1.Organization
+	err := s.change(ctx, req.GetTenantId(), "update organization", func(tenant *iso9001.Tenant) error {
+		org.ID = tenant.ID
+		org.Created = time.Now()
+		if tenant.Organization != nil && !tenant.Organization.Created.IsZero() {
+			org.Created = tenant.Organization.Created
+		}
+		org.Modified = time.Now()
+		tenant.Organization = &org
+		var err error
+		result, err = toProto(tenant.Organization, &iso9001v1.Organization{})
+		return err
+	})
+	return result, err
+}
+
+// Risks and opportunities
+
+func (s *qmsServer) IdentifyRisk(ctx context.Context, req *iso9001v1.IdentifyRiskRequest) (*iso9001v1.Risk, error) {
+	var risk iso9001.Risk
+	if err := fromProto(req.GetRisk(), &risk, "risk"); err != nil {
+		return nil, err
+	}
+	var result *iso9001v1.Risk
+	err := s.change(ctx, req.GetTenantId(), "identify risk", func(tenant *iso9001.Tenant) error {
+		if err := tenant.Risks.IdentifyRisk(&risk); err != nil {
+			return err
+		}
+		var err error
+		result, err = toProto(&risk, &iso9001v1.Risk{})
+		return err
+	})
+	return result, err
+}
+
+func (s *qmsServer) AssessRisk(ctx context.Context, req *iso9001v1.AssessRiskRequest) (*iso9001v1.Risk, error) {
+	return s.changeRisk(ctx, req.GetTenantId(), req.GetRiskId(), "assess risk", func(risks *iso9001.RiskManager) error {
+		return risks.AssessRisk(req.GetRiskId(), iso9001.RiskLevel(req.GetLikelihood()), iso9001.RiskLevel(req.GetImpact()))
+	})
+}
+
+func (s *qmsServer) MitigateRisk(ctx context.Context, req *iso9001v1.MitigateRiskRequest) (*iso9001v1.Risk, error) {
+	actions := make([]iso9001.Action, len(req.GetActions()))
+	for i, msg := range req.GetActions() {
+		if err := fromProto(msg, &actions[i], "action"); err != nil {
+			return nil, err
+		}
+	}
+	return s.changeRisk(ctx, req.GetTenantId(), req.GetRiskId(), "mitigate risk", func(risks *iso9001.RiskManager) error {
+		return risks.MitigateRisk(req.GetRiskId(), actions)
+	})
+}
+
+// changeRisk applies a change to a risk of the tenant and returns the changed risk
+func (s *qmsServer) changeRisk(ctx context.Context, tenantID, riskID, action string, apply func(risks *iso9001.RiskManager) error) (*iso9001v1.Risk, error) {
+	var result *iso9001v1.Risk
+	err := s.change(ctx, tenantID, action, func(tenant *iso9001.Tenant) error {
+		risk, exists := tenant.Risks.Risks[riskID]
+		if !exists {
+			return notFound(iso9001.EntityTypeRisk, riskID)
+		}
+		if err := apply(tenant.Risks); err != nil {
+			return err
+		}
+		var err error
+		result, err = toProto(risk, &iso9001v1.Risk{})
+		return err
+	})
+	return result, err
+}
+
+func (s *qmsServer) ListRisks(ctx context.Context, req *iso9001v1.ListRisksRequest) (*iso9001v1.ListRisksResponse, error) {
+	response := &iso9001v1.ListRisksResponse{}
+	err := s.view(ctx, req.GetTenantId(), func(tenant *iso9001.Tenant) error {
+		risks, _ := tenant.Collection("risks")
+		if req.GetMinPriority() != "" {
+			high := tenant.Risks.GetHighPriorityRisks(iso9001.Priority(req.GetMinPriority()))
+			sort.Slice(high, func(i, j int) bool { return high[i].ID < high[j].ID })
+			risks = high
+		}
+		var err error
+		response.Risks, err = eachProto(risks, func() *iso9001v1.Risk { return &iso9001v1.Risk{} })
+		return err
+	})
+	return response, err
+}
+
+// Quality objectives
+
+func (s *qmsServer) CreateObjective(ctx context.Context, req *iso9001v1.CreateObjectiveRequest) (*iso9001v1.QualityObjective, error) {
+	var objective iso9001.QualityObjective
+	if err := fromProto(req.GetObjective(), &objective, "objective"); err != nil {
+		return nil, err
+	}
+	var result *iso9001v1.QualityObjective
+	err := s.change(ctx, req.GetTenantId(), "create objective", func(tenant *iso9001.Tenant) error {
+		if err := tenant.Objectives.CreateObjective(&objective); err != nil {
+			return err
+		}
+		var err error
+		result, err = toProto(&objective, &iso9001v1.QualityObjective{})
+		return err
+	})
+	return result, err
+}
+
+func (s *qmsServer) ListObjectives(ctx context.Context, req *iso9001v1.ListObjectivesRequest) (*iso9001v1.ListObjectivesResponse, error) {
+	response := &iso9001v1.ListObjectivesResponse{}
+	err := s.view(ctx, req.GetTenantId(), func(tenant *iso9001.Tenant) error {
+		objectives, _ := tenant.Collection("objectives")
+		if req.GetOverdueOnly() {
+			overdue := tenant.Objectives.GetOverdueObjectives()
+			sort.Slice(overdue, func(i, j int) bool { return overdue[i].ID < overdue[j].ID })
+			objectives = overdue
+		}
+		var err error
+		response.Objectives, err = eachProto(objectives, func() *iso9001v1.QualityObjective { return &iso9001v1.QualityObjective{} })
+		return err
+	})
+	return response, err
+}
+
+// Documented information
+
+func (s *qmsServer) AddDocument(ctx context.Context, req *iso9001v1.AddDocumentRequest) (*iso9001v1.DocumentedInformation, error) {
+	var doc iso9001.DocumentedInformation
+	if err := fromProto(req.GetDocument(), &doc, "document"); err != nil {
+		return nil, err
+	}
+	var result *iso9001v1.DocumentedInformation
+	err := s.change(ctx, req.GetTenantId(), "add document", func(tenant *iso9001.Tenant) error {
+		if err := tenant.Documents.AddDocument(&doc); err != nil {
+			return err
+		}
+		var err error
+		result, err = toProto(&doc, &iso9001v1.DocumentedInformation{})
+		return err
+	})
+	return result, err
+}
+
+func (s *qmsServer) GetDocument(ctx context.Context, req *iso9001v1.GetDocumentRequest) (*iso9001v1.DocumentedInformation, error) {
+	var result *iso9001v1.DocumentedInformation
+	err := s.view(ctx, req.GetTenantId(), func(tenant *iso9001.Tenant) error {
+		doc, exists := tenant.Documents.Documents[req.GetDocumentId()]
+		if !exists {
+			return notFound(iso9001.EntityTypeDocument, req.GetDocumentId())
+		}
+		var err error
+		result, err = toProto(doc, &iso9001v1.DocumentedInformation{})
+		return err
+	})
+	return result, err
+}
+
+func (s *qmsServer) ApproveDocument(ctx context.Context, req *iso9001v1.ApproveDocumentRequest) (*iso9001v1.DocumentedInformation, error) {
+	var approval iso9001.Approval
+	if err := fromProto(req.GetApproval(), &approval, "approval"); err != nil {
+		return nil, err
+	}
+	return s.changeDocument(ctx, req.GetTenantId(), req.GetDocumentId(), "approve document", func(docs *iso9001.DocumentationManager) error {
+		return docs.ApproveDocument(req.GetDocumentId(), approval)
+	})
+}
+
+func (s *qmsServer) SetDocumentStatus(ctx context.Context, req *iso9001v1.SetDocumentStatusRequest) (*iso9001v1.DocumentedInformation, error) {
+	return s.changeDocument(ctx, req.GetTenantId(), req.GetDocumentId(), "set document status", func(docs *iso9001.DocumentationManager) error {
+		return docs.SetDocumentStatus(req.GetDocumentId(), iso9001.DocumentStatus(req.GetStatus()))
+	})
+}
+
+// changeDocument applies a change to a document of the tenant and returns the changed
+// document
+func (s *qmsServer) changeDocument(ctx context.Context, tenantID, docID, action string, apply func(docs *iso9001.DocumentationManager) error) (*iso9001v1.DocumentedInformation, error) {
+	var result *iso9001v1.DocumentedInformation
+	err := s.change(ctx, tenantID, action, func(tenant *iso9001.Tenant) error {
+		doc, exists := tenant.Documents.Documents[docID]
+		if !exists {
+			return notFound(iso9001.EntityTypeDocument, docID)
+		}
+		if err := apply(tenant.Documents); err != nil {
+			return err
+		}
+		var err error
+		result, err = toProto(doc, &iso9001v1.DocumentedInformation{})
+		return err
+	})
+	return result, err
+}
+
+func (s *qmsServer) ListDocuments(ctx context.Context, req *iso9001v1.ListDocumentsRequest) (*iso9001v1.ListDocumentsResponse, error) {
+	var criteria iso9001.DocumentSearchCriteria
+	if req.GetType() != "" {
+		docType := iso9001.DocumentType(req.GetType())
+		criteria.Type = &docType
+	}
+	if req.GetStatus() != "" {
+		docStatus := iso9001.DocumentStatus(req.GetStatus())
+		criteria.Status = &docStatus
+	}
+	response := &iso9001v1.ListDocumentsResponse{}
+	err := s.view(ctx, req.GetTenantId(), func(tenant *iso9001.Tenant) error {
+		docs := tenant.Documents.SearchDocuments(criteria)
+		sort.Slice(docs, func(i, j int) bool { return docs[i].ID < docs[j].ID })
+		var err error
+		response.Documents, err = eachProto(docs, func() *iso9001v1.DocumentedInformation { return &iso9001v1.DocumentedInformation{} })
+		return err
+	})
+	return response, err
+}
+
+// Internal audits
+
+func (s *qmsServer) CreateAudit(ctx context.Context, req *iso9001v1.CreateAuditRequest) (*iso9001v1.Audit, error) {
+	var audit iso9001.Audit
+	if err := fromProto(req.GetAudit(), &audit, "audit"); err != nil {
+		return nil, err
+	}
+	var result *iso9001v1.Audit
+	err := s.change(ctx, req.GetTenantId(), "create audit", func(tenant *iso9001.Tenant) error {
+		if err := tenant.Audits.CreateAudit(&audit); err != nil {
+			return err
+		}
+		var err error
+		result, err = toProto(&audit, &iso9001v1.Audit{})
+		return err
+	})
+	return result, err
+}
+
+// StartAudit starts an audit on the given date, or now when none is given
+func (s *qmsServer) StartAudit(ctx context.Context, req *iso9001v1.StartAuditRequest) (*iso9001v1.Audit, error) {
+	start := time.Now()
+	if req.GetStartDate() != nil {
+		start = req.GetStartDate().AsTime()
+	}
+	return s.changeAudit(ctx, req.GetTenantId(), req.GetAuditId(), "start audit", func(audits *iso9001.AuditManager) error {
+		return audits.StartAudit(req.GetAuditId(), start)
+	})
+}
+
+func (s *qmsServer) AddFinding(ctx context.Context, req *iso9001v1.AddFindingRequest) (*iso9001v1.Audit, error) {
+	var finding iso9001.AuditFinding
+	if err := fromProto(req.GetFinding(), &finding, "finding"); err != nil {
+		return nil, err
+	}
+	return s.changeAudit(ctx, req.GetTenantId(), req.GetAuditId(), "add finding", func(audits *iso9001.AuditManager) error {
+		return audits.AddFinding(req.GetAuditId(), finding)
+	})
+}
+
+// CompleteAudit completes an audit on the given date, or now when none is given, with
+// the report if one is sent
+func (s *qmsServer) CompleteAudit(ctx context.Context, req *iso9001v1.CompleteAuditRequest) (*iso9001v1.Audit, error) {
+	end := time.Now()
+	if req.GetEndDate() != nil {
+		end = req.GetEndDate().AsTime()
+	}
+	var report *iso9001.AuditReport
+	if req.GetReport() != nil {
+		report = &iso9001.AuditReport{}
+		if err := fromProto(req.GetReport(), report, "report"); err != nil {
+			return nil, err
+		}
+	}
+	return s.changeAudit(ctx, req.GetTenantId(), req.GetAuditId(), "complete audit", func(audits *iso9001.AuditManager) error {
+		return audits.CompleteAudit(req.GetAuditId(), end, report)
+	})
+}
+
+// changeAudit applies a change to an audit of the tenant and returns the changed audit
+func (s *qmsServer) changeAudit(ctx context.Context, tenantID, auditID, action string, apply func(audits *iso9001.AuditManager) error) (*iso9001v1.Audit, error) {
+	var result *iso9001v1.Audit
+	err := s.change(ctx, tenantID, action, func(tenant *iso9001.Tenant) error {
+		audit, exists := tenant.Audits.Audits[auditID]
+		if !exists {
+			return notFound(iso9001.EntityTypeAudit, auditID)
+		}
+		if err := apply(tenant.Audits); err != nil {
+			return err
+		}
+		var err error
+		result, err = toProto(audit, &iso9001v1.Audit{})
+		return err
+	})
+	return result, err
+}
+
+func (s *qmsServer) ListAudits(ctx context.Context, req *iso9001v1.ListAuditsRequest) (*iso9001v1.ListAuditsResponse, error) {
+	response := &iso9001v1.ListAuditsResponse{}
+	err := s.view(ctx, req.GetTenantId(), func(tenant *iso9001.Tenant) error {
+		audits := []*iso9001.Audit{}
+		for _, audit := range tenant.Audits.Audits {
+			if req.GetStatus() == "" || string(audit.Status) == req.GetStatus() {
+				audits = append(audits, audit)
+			}
+		}
+		sort.Slice(audits, func(i, j int) bool { return audits[i].ID < audits[j].ID })
+		var err error
+		response.Audits, err = eachProto(audits, func() *iso9001v1.Audit { return &iso9001v1.Audit{} })
+		return err
+	})
+	return response, err
+}
+
+// Bulk export
+
+func (s *qmsServer) ExportRisks(req *iso9001v1.ExportRequest, stream iso9001v1.QMSService_ExportRisksServer) error {
+	return exportCollection(s, stream.Context(), req.GetTenantId(), "risks", stream.Send)
+}
+
+func (s *qmsServer) ExportObjectives(req *iso9001v1.ExportRequest, stream iso9001v1.QMSService_ExportObjectivesServer) error {
+	return exportCollection(s, stream.Context(), req.GetTenantId(), "objectives", stream.Send)
+}
+
+func (s *qmsServer) ExportDocuments(req *iso9001v1.ExportRequest, stream iso9001v1.QMSService_ExportDocumentsServer) error {
+	return exportCollection(s, stream.Context(), req.GetTenantId(), "documents", stream.Send)
+}
+
+func (s *qmsServer) ExportAudits(req *iso9001v1.ExportRequest, stream iso9001v1.QMSService_ExportAuditsServer) error {
+	return exportCollection(s, stream.Context(), req.GetTenantId(), "audits", stream.Send)
+}
+
+// exportCollection streams a collection of the tenant one entity at a time. The
+// collection is converted while the tenant is held and sent after it is released, so a
+// slow client does not block other calls on the tenant.
+func exportCollection[M proto.Message](s *qmsServer, ctx context.Context, tenantID, collection string, send func(M) error) error {
+	var messages []M
+	err := s.view(ctx, tenantID, func(tenant *iso9001.Tenant) error {
+		entities, _ := tenant.Collection(collection)
+		var err error
+		messages, err = eachProto(entities, func() M {
+			var msg M
+			return msg.ProtoReflect().New().Interface().(M)
+		})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	for _, msg := range messages {
+		if err := send(msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func notFound(entityType, id string) error {
+	return status.Errorf(codes.NotFound, "%s with ID %s not found", entityType, id)
+}
+
+// rpcError gives an error of the library its status code. Changes that conflict with
+// the state of an entity, such as a status the entity cannot move to, fail their
+// precondition; other errors are invalid arguments.
+func rpcError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return status.FromContextError(err).Err()
+	}
+	code := codes.InvalidArgument
+	var duplicate *iso9001.DuplicateIDError
+	var transition *iso9001.InvalidTransitionError
+	switch {
+	case errors.Is(err, iso9001.ErrTenantNotFound):
+		code = codes.NotFound
+	case errors.As(err, &duplicate):
+		code = codes.AlreadyExists
+	case errors.As(err, &transition), errors.Is(err, iso9001.ErrAuditStarted), errors.Is(err, iso9001.ErrDocumentReleased):
+		code = codes.FailedPrecondition
+	}
+	return status.Error(code, err.Error())
+}
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/example/iso9001"
+	iso9001v1 "github.com/example/iso9001-grpc/gen/iso9001/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// testServer serves a store in a temporary directory over an in-memory connection
+type testServer struct {
+	client iso9001v1.QMSServiceClient
+	store  *iso9001.TenantStore
+	keys   *iso9001.APIKeyManager
+}
+
+func newTestServer(t *testing.T, requireKey bool) *testServer {
+	t.Helper()
+	dir := t.TempDir()
+	store, err := openStore(dir, false)
+	if err != nil {
+		t.Fatalf("Failed to open store: %v", err)
+	}
+	var keys *iso9001.APIKeyManager
+	if requireKey {
+		if keys, err = iso9001.NewAPIKeyManager(iso9001.APIKeyFile(dir)); err != nil {
+			t.Fatalf("Failed to open API keys: %v", err)
+		}
+	}
+
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer(guard(keys, iso9001.NewRateLimiter(iso9001.RateLimit{}))...)
+	iso9001v1.RegisterQMSServiceServer(server, &qmsServer{store: store})
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return &testServer{client: iso9001v1.NewQMSServiceClient(conn), store: store, keys: keys}
+}
+
+// withKey creates a key of the scope and returns a context presenting it
+func (s *testServer) withKey(t *testing.T, name string, scope iso9001.APIKeyScope) context.Context {
+	t.Helper()
+	secret, _, err := s.keys.Create(name, scope, 0)
+	if err != nil {
+		t.Fatalf("Failed to create key: %v", err)
+	}
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+secret)
+}
+
+func putOrganization(ctx context.Context, client iso9001v1.QMSServiceClient) (*iso9001v1.Organization, error) {
+	return client.PutOrganization(ctx, &iso9001v1.PutOrganizationRequest{
+		TenantId:     "acme",
+		Organization: &iso9001v1.Organization{Name: "Acme Manufacturing", TimeZone: "Europe/Berlin"},
+	})
+}
+
+func TestAuthRejection(t *testing.T) {
+	server := newTestServer(t, true)
+	server.withKey(t, "reader", iso9001.APIKeyScopeRead)
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+	}{
+		{"no key", context.Background()},
+		{"unknown bearer key", metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer qms_unknown")},
+		{"unknown x-api-key", metadata.AppendToOutgoingContext(context.Background(), "x-api-key", "qms_unknown")},
+		{"not a bearer token", metadata.AppendToOutgoingContext(context.Background(), "authorization", "Basic dXNlcjpwYXNz")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := server.client.GetOrganization(tt.ctx, &iso9001v1.GetOrganizationRequest{TenantId: "acme"})
+			if status.Code(err) != codes.Unauthenticated {
+				t.Errorf("Expected Unauthenticated, got %v", err)
+			}
+
+			stream, err := server.client.ExportRisks(tt.ctx, &iso9001v1.ExportRequest{TenantId: "acme"})
+			if err == nil {
+				_, err = stream.Recv()
+			}
+			if status.Code(err) != codes.Unauthenticated {
+				t.Errorf("Expected Unauthenticated on stream, got %v", err)
+			}
+		})
+	}
+}
+
+func TestScopeEnforcement(t *testing.T) {
+	server := newTestServer(t, true)
+	reader := server.withKey(t, "reader", iso9001.APIKeyScopeRead)
+	writer := server.withKey(t, "writer", iso9001.APIKeyScopeWrite)
+
+	if _, err := putOrganization(reader, server.client); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Expected a read-scoped key to be denied a write, got %v", err)
+	}
+	if _, err := putOrganization(writer, server.client); err != nil {
+		t.Fatalf("Expected a write-scoped key to write, got %v", err)
+	}
+	if _, err := server.client.GetOrganization(reader, &iso9001v1.GetOrganizationRequest{TenantId: "acme"}); err != nil {
+		t.Errorf("Expected a read-scoped key to read, got %v", err)
+	}
+	if _, err := server.client.GetOrganization(writer, &iso9001v1.GetOrganizationRequest{TenantId: "acme"}); err != nil {
+		t.Errorf("Expected a write-scoped key to read, got %v", err)
+	}
+
+	// the change is attributed to the key that made it
+	err := server.store.WithTenant("acme", func(tenant *iso9001.Tenant) error {
+		entries := tenant.Trail.Entries
+		if len(entries) == 0 || entries[len(entries)-1].Actor != "writer" {
+			t.Errorf("Expected the change to be recorded under the key name, got %+v", entries)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Failed to read tenant: %v", err)
+	}
+}
+
+func TestMessageConversion(t *testing.T) {
+	server := newTestServer(t, false)
+	ctx := context.Background()
+
+	org, err := putOrganization(ctx, server.client)
+	if err != nil {
+		t.Fatalf("Failed to put organization: %v", err)
+	}
+	if org.GetId() != "acme" || org.GetName() != "Acme Manufacturing" || org.GetTimeZone() != "Europe/Berlin" || org.GetCreated() == nil {
+		t.Errorf("Expected organization with the tenant ID and creation date, got %v", org)
+	}
+
+	risk, err := server.client.IdentifyRisk(ctx, &iso9001v1.IdentifyRiskRequest{
+		TenantId: "acme",
+		Risk: &iso9001v1.Risk{
+			Id:          "RISK-001",
+			Description: "Supplier delivers late",
+			Causes:      []string{"single source"},
+			Likelihood:  string(iso9001.RiskLevelHigh),
+			Impact:      string(iso9001.RiskLevelMedium),
+			Mitigation:  []*iso9001v1.Action{{Id: "ACT-001", Description: "Qualify a second supplier"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to identify risk: %v", err)
+	}
+	if risk.GetId() != "RISK-001" || risk.GetLikelihood() != "high" || len(risk.GetCauses()) != 1 || risk.GetCreated() == nil {
+		t.Errorf("Expected the risk to come back with its fields and creation date, got %v", risk)
+	}
+
+	// the message reached the library as the Go type
+	err = server.store.WithTenant("acme", func(tenant *iso9001.Tenant) error {
+		stored, ok := tenant.Risks.Risks["RISK-001"]
+		if !ok || stored.Likelihood != iso9001.RiskLevelHigh || stored.Causes[0] != "single source" || len(stored.Mitigation) != 1 || stored.Mitigation[0].ID != "ACT-001" {
+			t.Errorf("Expected the risk to be stored as sent, got %+v", stored)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Failed to read tenant: %v", err)
+	}
+
+	stream, err := server.client.ExportRisks(ctx, &iso9001v1.ExportRequest{TenantId: "acme"})
+	if err != nil {
+		t.Fatalf("Failed to export risks: %v", err)
+	}
+	var exported []*iso9001v1.Risk
+	for {
+		risk, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Failed to receive risk: %v", err)
+		}
+		exported = append(exported, risk)
+	}
+	if len(exported) != 1 || exported[0].GetDescription() != "Supplier delivers late" {
+		t.Errorf("Expected the risk in the export, got %v", exported)
+	}
+
+	if _, err := server.client.IdentifyRisk(ctx, &iso9001v1.IdentifyRiskRequest{TenantId: "acme"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected a missing message to be an invalid argument, got %v", err)
+	}
+	if _, err := server.client.GetOrganization(ctx, &iso9001v1.GetOrganizationRequest{TenantId: "missing"}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected an unknown tenant to be not found, got %v", err)
+	}
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/example/iso9001"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// accessPolicy assigns QMS roles to identities, loaded from -access-policy. When nil,
+// access control is disabled and every tool may be called.
+var accessPolicy *iso9001.AccessPolicy
+
+// stdioIdentity identifies the client of the stdio transport, i.e. the local user
+// running the server, set by -identity or ISO9001_IDENTITY
+var stdioIdentity string
+
+// readOnly rejects every tool that modifies data when set by -read-only
+var readOnly bool
+
+// loadAccessPolicy reads the access policy file given to -access-policy
+func loadAccessPolicy(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	policy, err := iso9001.LoadAccessPolicyJSON(data)
+	if err != nil {
+		return err
+	}
+	accessPolicy = policy
+	return nil
+}
+
+// requestIdentity returns the identity of the connection a request arrives over:
+// the API key or client certificate a network client authenticated with, or the
+// -identity of the stdio client. It is "" for unidentified connections, which an
+// access policy denies. Identities asserted by the client, e.g. in _meta, are ignored.
+func requestIdentity(ctx context.Context) string {
+	return iso9001.ActorFromContext(ctx)
+}
+
+// viewOnly reports whether a tool needs no permission beyond viewing, i.e. whether it
+// is safe to call in read-only mode
+func viewOnly(permissions []iso9001.Permission) bool {
+	for _, permission := range permissions {
+		if permission != iso9001.PermissionView {
+			return false
+		}
+	}
+	return true
+}
+
+// requirePermission wraps a tool handler so it only runs when the caller holds the
+// permissions the tool declares and, in read-only mode or for API keys without write
+// scope, when the tool only reads
+func requirePermission(handler server.ToolHandlerFunc, permissions ...iso9001.Permission) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if readOnly && !viewOnly(permissions) {
+			return mcp.NewToolResultError(fmt.Sprintf("Tool %s modifies QMS data and is disabled: the server runs in read-only mode", request.Params.Name)), nil
+		}
+		if key, ok := ctx.Value(apiKeyContextKey{}).(*iso9001.APIKey); ok && !viewOnly(permissions) && !key.Scope.Allows(iso9001.APIKeyScopeWrite) {
+			return mcp.NewToolResultError(fmt.Sprintf("Tool %s not allowed: %v: write scope required", request.Params.Name, iso9001.ErrInsufficientScope)), nil
+		}
+		if accessPolicy != nil {
+			if err := accessPolicy.Authorize(requestIdentity(ctx), permissions...); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Tool %s not allowed: %v", request.Params.Name, err)), nil
+			}
+		}
+		return handler(ctx, request)
+	}
+}
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/example/iso9001"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// activityLogs attribute every write made through the tools to the calling identity,
+// per tenant
+var activityLogs = iso9001.NewTenantScoped(iso9001.NewActivityLog)
+
+// anonymousActor is recorded for calls over a connection without identity
+const anonymousActor = "anonymous"
+
+type toolCallKey struct{}
+
+// toolCall identifies the tool invocation a change is made by
+type toolCall struct {
+	actor   string
+	request mcp.CallToolRequest
+}
+
+// withToolCall is tool handler middleware that makes the caller and the request
+// available to recordChange
+func withToolCall(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		actor := requestIdentity(ctx)
+		if actor == "" {
+			actor = anonymousActor
+		}
+		ctx = context.WithValue(ctx, toolCallKey{}, &toolCall{actor: actor, request: request})
+		return next(ctx, request)
+	}
+}
+
+// recordActivity adds a change to the activity log of the tenant. Updates to the organization are
+// summarized before and after; the state before other changes is not known to the
+// server, which receives only the resulting entity.
+func recordActivity(ctx context.Context, tenantID, entityType, entityID string, op iso9001.ChangeOperation, entity interface{}) {
+	entry := iso9001.ActivityEntry{
+		Actor:      anonymousActor,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Operation:  op,
+		After:      iso9001.SummarizeEntity(entity),
+	}
+	if call, ok := ctx.Value(toolCallKey{}).(*toolCall); ok {
+		entry.Actor = call.actor
+		entry.Action = call.request.Params.Name
+		if op != iso9001.ChangeOperationCreated && entityType == iso9001.EntityTypeOrganization {
+			if orgJSON, given, _ := argumentJSON(call.request, "organization"); given {
+				if before, err := iso9001.LoadOrganizationJSON(orgJSON); err == nil {
+					entry.Before = iso9001.SummarizeEntity(before)
+				}
+			}
+		}
+	}
+	activityLogs.For(tenantID).Record(entry)
+}
+
+// Audit Log Handlers
+
+func handleAuditLog(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	query := iso9001.ActivityQuery{
+		Actor:      request.GetString("actor", ""),
+		Action:     request.GetString("tool", ""),
+		EntityType: request.GetString("entity_type", ""),
+		EntityID:   request.GetString("entity_id", ""),
+		Limit:      request.GetInt("limit", 100),
+	}
+
+	var err error
+	if query.Since, err = parseOptionalTime(request.GetString("since", "")); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid since: %v", err)), nil
+	}
+	if query.Until, err = parseOptionalTime(request.GetString("until", "")); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid until: %v", err)), nil
+	}
+
+	tenantID := requestTenant(ctx, request)
+	if accessPolicy != nil {
+		if err := accessPolicy.AuthorizeTenant(requestIdentity(ctx), tenantID); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+	}
+	entries := activityLogs.For(tenantID).Query(query)
+	result, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal audit log: %v", err)), nil
+	}
+
+	return newToolResult("activity", "", fmt.Sprintf("%d audit log entries", len(entries)), json.RawMessage(result))
+}
+
+func handleAuditTrail(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	query := iso9001.ActivityQuery{
+		Actor:      request.GetString("actor", ""),
+		Action:     request.GetString("tool", ""),
+		EntityType: request.GetString("entity_type", ""),
+		EntityID:   request.GetString("entity_id", ""),
+		Limit:      request.GetInt("limit", 100),
+	}
+
+	var err error
+	if query.Since, err = parseOptionalTime(request.GetString("since", "")); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid since: %v", err)), nil
+	}
+	if query.Until, err = parseOptionalTime(request.GetString("until", "")); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid until: %v", err)), nil
+	}
+
+	var entries []iso9001.TrailEntry
+	integrity := "verified"
+	err = viewTenant(ctx, request, requestTenant(ctx, request), func(tenant *iso9001.Tenant) error {
+		if tenant.Trail == nil {
+			entries = []iso9001.TrailEntry{}
+			return nil
+		}
+		if err := tenant.Trail.Verify(); err != nil {
+			integrity = err.Error()
+		}
+		entries = tenant.Trail.Query(query)
+		return nil
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to query audit trail: %v", err)), nil
+	}
+
+	result, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal audit trail: %v", err)), nil
+	}
+
+	var warnings []string
+	if integrity != "verified" {
+		warnings = append(warnings, fmt.Sprintf("Audit trail integrity check failed: %s", integrity))
+	}
+	return newToolResult("audit_trail", "", fmt.Sprintf("%d audit trail entries (integrity: %s)", len(entries), integrity), json.RawMessage(result), warnings...)
+}
+
+// handleAuditLogResource serves the activity log of the organization in the workspace
+func handleAuditLogResource(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	tenantID := workspaceTenant(ctx)
+	if err := authorizeTenant(ctx, tenantID); err != nil {
+		return nil, err
+	}
+	data, err := json.MarshalIndent(activityLogs.For(tenantID).Query(iso9001.ActivityQuery{}), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal audit log: %v", err)
+	}
+
+	return jsonResource(request.Params.URI, data), nil
+}
+
+// parseOptionalTime accepts an RFC 3339 timestamp or a YYYY-MM-DD date; empty means unset
+func parseOptionalTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.ParseInLocation("2006-01-02", value, defaultTimeZone)
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/example/iso9001"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Approval Workflow Handlers
+
+// approvalStageArgument is an approval stage as supplied in stages, with the
+// time allowed in days rather than as a duration
+type approvalStageArgument struct {
+	Name       string                  `json:"name"`
+	Groups     []iso9001.ApproverGroup `json:"groups"`
+	DueDays    float64                 `json:"due_days,omitempty"`
+	EscalateTo []string                `json:"escalate_to,omitempty"`
+}
+
+func handleSetApprovalWorkflow(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	documentID, err := request.RequireString("document_id")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Missing document_id: %v", err)), nil
+	}
+
+	var arguments []approvalStageArgument
+	if err := requireArgument(request, "stages", &arguments); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	stages := make([]iso9001.ApprovalStage, len(arguments))
+	for i, argument := range arguments {
+		stages[i] = iso9001.ApprovalStage{
+			Name:       argument.Name,
+			Groups:     argument.Groups,
+			Due:        time.Duration(argument.DueDays * float64(24*time.Hour)),
+			EscalateTo: argument.EscalateTo,
+		}
+	}
+
+	var doc *iso9001.DocumentedInformation
+	var result []byte
+	_, err = updateTenant(ctx, request, func(tenant *iso9001.Tenant) error {
+		if err := tenant.Documents.SetApprovalWorkflow(documentID, stages); err != nil {
+			return err
+		}
+		doc = tenant.Documents.Documents[documentID]
+		result, err = json.MarshalIndent(doc.Approval, "", "  ")
+		return err
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to set approval workflow: %v", err)), nil
+	}
+
+	recordChange(ctx, iso9001.EntityTypeDocument, documentID, iso9001.ChangeOperationUpdated, doc)
+
+	return newToolResult(iso9001.EntityTypeDocument, documentID, fmt.Sprintf("Approval workflow of document %s", documentID), json.RawMessage(result))
+}
+
+func handleRejectDocument(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	documentID, err := request.RequireString("document_id")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Missing document_id: %v", err)), nil
+	}
+
+	comments, err := request.RequireString("comments")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Missing comments: %v", err)), nil
+	}
+
+	// the approver is the caller, never someone named in the arguments
+	approverID := requestIdentity(ctx)
+	if approverID == "" {
+		return mcp.NewToolResultError("Failed to reject document: the connection has no identity to reject as"), nil
+	}
+	rejection := iso9001.ApprovalRejection{
+		ApproverID: approverID,
+		Role:       request.GetString("role", ""),
+		Comments:   comments,
+		Timestamp:  time.Now(),
+	}
+
+	var doc *iso9001.DocumentedInformation
+	_, err = updateTenant(ctx, request, func(tenant *iso9001.Tenant) error {
+		if err := tenant.Documents.RejectDocument(documentID, rejection); err != nil {
+			return err
+		}
+		doc = tenant.Documents.Documents[documentID]
+		return nil
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to reject document: %v", err)), nil
+	}
+
+	recordChange(ctx, iso9001.EntityTypeApproval, documentID, iso9001.ChangeOperationUpdated, doc.Approval)
+
+	return newToolResult(iso9001.EntityTypeDocument, documentID, fmt.Sprintf("Document %s returned to draft: %s", documentID, comments), doc.Approval)
+}
+
+func handleEscalateApprovals(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var escalations []iso9001.ApprovalEscalation
+	var docs []*iso9001.DocumentedInformation
+	var result []byte
+	_, err := updateTenant(ctx, request, func(tenant *iso9001.Tenant) error {
+		var err error
+		escalations, err = tenant.Documents.EscalateOverdueApprovals(time.Now())
+		for _, escalation := range escalations {
+			docs = append(docs, tenant.Documents.Documents[escalation.DocumentID])
+		}
+		if err != nil {
+			return err
+		}
+		result, err = json.MarshalIndent(escalations, "", "  ")
+		return err
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to escalate approvals: %v", err)), nil
+	}
+
+	for _, doc := range docs {
+		recordChange(ctx, iso9001.EntityTypeDocument, doc.ID, iso9001.ChangeOperationUpdated, doc)
+	}
+
+	return newToolResult("approval_escalation", "", fmt.Sprintf("%d approval stages escalated", len(escalations)), json.RawMessage(result))
+}
+
+func handleDelegateApproval(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	delegate, err := request.RequireString("delegate")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Missing delegate: %v", err)), nil
+	}
+	until, err := parseOptionalTime(request.GetString("until", ""))
+	if err != nil || until.IsZero() {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid until: %q", request.GetString("until", ""))), nil
+	}
+	from, err := parseOptionalTime(request.GetString("from", ""))
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid from: %v", err)), nil
+	}
+	if from.IsZero() {
+		from = time.Now()
+	}
+
+	// the delegator is the caller, who can only hand on their own authority
+	delegator := requestIdentity(ctx)
+	if delegator == "" {
+		return mcp.NewToolResultError("Failed to delegate approval: the connection has no identity to delegate from"), nil
+	}
+	delegation := iso9001.Delegation{
+		Delegator: delegator,
+		Delegate:  delegate,
+		From:      from,
+		Until:     until,
+		Reason:    request.GetString("reason", ""),
+	}
+	if _, err := decodeArgument(request, "roles", &delegation.Roles); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if _, err := decodeArgument(request, "categories", &delegation.Categories); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	tenantID, err := updateTenant(ctx, request, func(tenant *iso9001.Tenant) error {
+		delegation, err = tenant.AddDelegation(delegation)
+		return err
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to delegate approval: %v", err)), nil
+	}
+
+	return newToolResult(iso9001.EntityTypeOrganization, tenantID, fmt.Sprintf("Delegation %s from %s to %s until %s", delegation.ID, delegator, delegate, until.Format("2006-01-02")), delegation)
+}
+
+func handleRevokeDelegation(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	delegationID, err := request.RequireString("delegation_id")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Missing delegation_id: %v", err)), nil
+	}
+
+	identity := requestIdentity(ctx)
+	tenantID, err := updateTenant(ctx, request, func(tenant *iso9001.Tenant) error {
+		if tenant.Organization.Leadership != nil {
+			for _, delegation := range tenant.Organization.Leadership.Delegations {
+				if delegation.ID == delegationID && delegation.Delegator != identity {
+					return fmt.Errorf("%w: only %s can revoke delegation %s", iso9001.ErrPermissionDenied, delegation.Delegator, delegationID)
+				}
+			}
+		}
+		return tenant.RevokeDelegation(delegationID, time.Now())
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to revoke delegation: %v", err)), nil
+	}
+
+	return newToolResult(iso9001.EntityTypeOrganization, tenantID, fmt.Sprintf("Delegation %s revoked", delegationID), nil)
+}
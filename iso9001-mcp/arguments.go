@@ -0,0 +1,277 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/example/iso9001"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Structured tool arguments are declared as JSON schema objects and arrays. Clients
+// written before the schemas were typed may still send them as JSON text, under the
+// same name or under the old name with a _json suffix, e.g. actions_json.
+
+// argumentJSON returns argument name as JSON, and whether it was given
+func argumentJSON(request mcp.CallToolRequest, name string) ([]byte, bool, error) {
+	args := request.GetArguments()
+	value, ok := args[name]
+	if !ok || value == nil {
+		value, ok = args[name+"_json"]
+	}
+	if !ok || value == nil {
+		return nil, false, nil
+	}
+
+	if text, isText := value.(string); isText {
+		if text == "" {
+			return nil, false, nil
+		}
+		return []byte(text), true, nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, true, fmt.Errorf("invalid %s: %v", name, err)
+	}
+	return data, true, nil
+}
+
+// decodeArgument decodes argument name into v, rejecting keys the schema does not
+// declare so that misspelled fields are reported rather than dropped. It reports
+// whether the argument was given.
+func decodeArgument(request mcp.CallToolRequest, name string, v interface{}) (bool, error) {
+	data, given, err := argumentJSON(request, name)
+	if !given || err != nil {
+		return given, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		return true, fmt.Errorf("invalid %s: %v", name, err)
+	}
+	return true, nil
+}
+
+// requireArgument decodes the required argument name into v
+func requireArgument(request mcp.CallToolRequest, name string, v interface{}) error {
+	given, err := decodeArgument(request, name, v)
+	if err == nil && !given {
+		return fmt.Errorf("required argument %q not found", name)
+	}
+	return err
+}
+
+// withOrganization declares the organization argument of the stateless tools, which
+// work on the organization given rather than on a stored one
+func withOrganization(description string) mcp.ToolOption {
+	return mcp.WithObject("organization",
+		mcp.Description(description),
+		mcp.Properties(map[string]any{
+			"id":         map[string]any{"type": "string"},
+			"name":       map[string]any{"type": "string"},
+			"context":    map[string]any{"type": "object", "description": "External and internal issues and interested parties (clause 4)"},
+			"leadership": map[string]any{"type": "object", "description": "Top management, quality policy, roles and commitment (clause 5)"},
+			"qms":        map[string]any{"type": "object", "description": "Scope and processes of the QMS (clause 4.3 and 4.4)"},
+		}),
+	)
+}
+
+// Schemas of the items of structured arguments
+
+var stringListSchema = map[string]any{
+	"type":  "array",
+	"items": map[string]any{"type": "string"},
+}
+
+var dateTimeSchema = map[string]any{
+	"type":   "string",
+	"format": "date-time",
+}
+
+var actionSchema = map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		"id":          map[string]any{"type": "string"},
+		"description": map[string]any{"type": "string"},
+		"type":        map[string]any{"type": "string", "enum": []string{"preventive", "corrective", "improvement", "mitigation"}},
+		"responsible": map[string]any{"type": "string"},
+		"timeline":    dateTimeSchema,
+		"status":      map[string]any{"type": "string", "enum": []string{"planned", "in_progress", "completed", "verified"}},
+	},
+	"required":             []string{"description"},
+	"additionalProperties": false,
+}
+
+var interestedPartySchema = map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		"id":           map[string]any{"type": "string"},
+		"name":         map[string]any{"type": "string"},
+		"type":         map[string]any{"type": "string", "enum": iso9001.InterestedPartyCategories},
+		"requirements": stringListSchema,
+	},
+	"required":             []string{"name", "type"},
+	"additionalProperties": false,
+}
+
+var approvalStageSchema = map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		"name": map[string]any{"type": "string"},
+		"groups": map[string]any{
+			"type": "array",
+			"items": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"name":          map[string]any{"type": "string"},
+					"approvers":     stringListSchema,
+					"min_approvals": map[string]any{"type": "integer", "minimum": 0},
+				},
+				"required":             []string{"approvers"},
+				"additionalProperties": false,
+			},
+		},
+		"due_days":    map[string]any{"type": "number", "minimum": 0, "description": "Days the stage may take before it can be escalated"},
+		"escalate_to": stringListSchema,
+	},
+	"required":             []string{"name", "groups"},
+	"additionalProperties": false,
+}
+
+var reviewAttendeeSchema = map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		"id":      map[string]any{"type": "string"},
+		"name":    map[string]any{"type": "string"},
+		"role":    map[string]any{"type": "string"},
+		"present": map[string]any{"type": "boolean"},
+	},
+	"required":             []string{"name"},
+	"additionalProperties": false,
+}
+
+var holidaySchema = map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		"date": map[string]any{"type": "string", "format": "date", "description": "YYYY-MM-DD"},
+		"name": map[string]any{"type": "string"},
+	},
+	"required":             []string{"date"},
+	"additionalProperties": false,
+}
+
+var priorityEnum = []string{"low", "medium", "high", "critical"}
+
+var reviewOutputsProperties = map[string]any{
+	"decisions": stringListSchema,
+	"improvement_opportunities": map[string]any{
+		"type": "array",
+		"items": map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"id":          map[string]any{"type": "string"},
+				"description": map[string]any{"type": "string"},
+				"priority":    map[string]any{"type": "string", "enum": priorityEnum},
+				"category":    map[string]any{"type": "string"},
+				"benefits":    stringListSchema,
+			},
+			"additionalProperties": false,
+		},
+	},
+	"qms_changes": map[string]any{
+		"type": "array",
+		"items": map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"id":          map[string]any{"type": "string"},
+				"description": map[string]any{"type": "string"},
+				"type":        map[string]any{"type": "string"},
+				"impact":      map[string]any{"type": "string"},
+				"timeline":    dateTimeSchema,
+			},
+			"additionalProperties": false,
+		},
+	},
+	"resource_needs": map[string]any{
+		"type": "array",
+		"items": map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"resource_type": map[string]any{"type": "string"},
+				"description":   map[string]any{"type": "string"},
+				"priority":      map[string]any{"type": "string", "enum": priorityEnum},
+				"timeline":      dateTimeSchema,
+			},
+			"additionalProperties": false,
+		},
+	},
+	"action_items": map[string]any{
+		"type": "array",
+		"items": map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"id":          map[string]any{"type": "string"},
+				"description": map[string]any{"type": "string"},
+				"responsible": map[string]any{"type": "string"},
+				"due_date":    dateTimeSchema,
+				"priority":    map[string]any{"type": "string", "enum": priorityEnum},
+				"status":      map[string]any{"type": "string", "enum": []string{"planned", "in_progress", "completed", "verified"}},
+			},
+			"required":             []string{"description"},
+			"additionalProperties": false,
+		},
+	},
+	"next_review_date": dateTimeSchema,
+}
+
+var templateProperties = map[string]any{
+	"id":          map[string]any{"type": "string"},
+	"name":        map[string]any{"type": "string"},
+	"description": map[string]any{"type": "string"},
+	"kind":        map[string]any{"type": "string", "enum": []string{"document", "process"}},
+	"variables": map[string]any{
+		"type": "array",
+		"items": map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"name":        map[string]any{"type": "string"},
+				"description": map[string]any{"type": "string"},
+				"default":     map[string]any{"type": "string"},
+			},
+			"required":             []string{"name"},
+			"additionalProperties": false,
+		},
+	},
+	"document": map[string]any{"type": "object", "description": "Document of a document template; strings may contain {{variable}} placeholders"},
+	"process":  map[string]any{"type": "object", "description": "Process of a process template; strings may contain {{variable}} placeholders"},
+}
+
+var causeProperties = map[string]any{
+	"cause":      map[string]any{"type": "string"},
+	"category":   map[string]any{"type": "string", "enum": iso9001.FishboneCategories, "description": "Bone of a fishbone diagram; required on the causes directly under the problem"},
+	"evidence":   map[string]any{"type": "string"},
+	"root_cause": map[string]any{"type": "boolean", "description": "Confirmed as a root cause"},
+	"causes": map[string]any{
+		"type":        "array",
+		"description": "Why this cause happened, as causes of the same shape",
+		"items":       map[string]any{"type": "object"},
+	},
+}
+
+var rootCauseAnalysisProperties = map[string]any{
+	"method":  map[string]any{"type": "string", "enum": []string{"five_whys", "fishbone"}},
+	"problem": map[string]any{"type": "string"},
+	"causes": map[string]any{
+		"type": "array",
+		"items": map[string]any{
+			"type":                 "object",
+			"properties":           causeProperties,
+			"required":             []string{"cause"},
+			"additionalProperties": false,
+		},
+	},
+	"performed_by": map[string]any{"type": "string", "description": "Who performed the analysis; defaults to the caller"},
+	"date":         dateTimeSchema,
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/example/iso9001"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Attachment Handlers
+
+// useAttachmentStore points the tenant's documentation manager at the tenant's
+// attachment directory when -store is set
+func useAttachmentStore(tenant *iso9001.Tenant) error {
+	if attachmentDir == "" {
+		return nil
+	}
+	if _, ok := tenant.Documents.Attachments.(*iso9001.DirAttachmentStore); ok {
+		return nil
+	}
+	store, err := iso9001.NewDirAttachmentStore(filepath.Join(attachmentDir, tenant.ID))
+	if err != nil {
+		return err
+	}
+	tenant.Documents.Attachments = store
+	return nil
+}
+
+func handleAddAttachment(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	documentID, err := request.RequireString("document_id")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Missing document_id: %v", err)), nil
+	}
+
+	fileName, err := request.RequireString("file_name")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Missing file_name: %v", err)), nil
+	}
+
+	contentBase64, err := request.RequireString("content_base64")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Missing content_base64: %v", err)), nil
+	}
+
+	attachment := iso9001.Attachment{
+		FileName:   fileName,
+		MIMEType:   request.GetString("mime_type", ""),
+		UploadedBy: request.GetString("uploaded_by", requestIdentity(ctx)),
+	}
+	content := base64.NewDecoder(base64.StdEncoding, strings.NewReader(contentBase64))
+
+	var doc *iso9001.DocumentedInformation
+	var result []byte
+	_, err = updateTenant(ctx, request, func(tenant *iso9001.Tenant) error {
+		if err := useAttachmentStore(tenant); err != nil {
+			return err
+		}
+		added, err := tenant.Documents.AddAttachment(documentID, attachment, content)
+		if err != nil {
+			return err
+		}
+		doc = tenant.Documents.Documents[documentID]
+		result, err = json.MarshalIndent(added, "", "  ")
+		return err
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to add attachment: %v", err)), nil
+	}
+
+	recordChange(ctx, iso9001.EntityTypeDocument, documentID, iso9001.ChangeOperationUpdated, doc)
+
+	return newToolResult(iso9001.EntityTypeDocument, documentID, fmt.Sprintf("Attachment added to document %s", documentID), json.RawMessage(result))
+}
+
+func handleGetAttachment(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	documentID, err := request.RequireString("document_id")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Missing document_id: %v", err)), nil
+	}
+
+	attachmentID, err := request.RequireString("attachment_id")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Missing attachment_id: %v", err)), nil
+	}
+
+	tenantID := requestTenant(ctx, request)
+	var attachment *iso9001.Attachment
+	var encoded strings.Builder
+	err = viewTenant(ctx, request, tenantID, func(tenant *iso9001.Tenant) error {
+		doc, err := tenant.Documents.GetDocument(documentID)
+		if err != nil {
+			return err
+		}
+		if accessPolicy != nil && !accessPolicy.CanReadDocument(requestIdentity(ctx), doc) {
+			return fmt.Errorf("%q is not on the read list of %s document %s", requestIdentity(ctx), doc.Access.Classification, doc.ID)
+		}
+		if err := useAttachmentStore(tenant); err != nil {
+			return err
+		}
+
+		r, opened, err := tenant.Documents.OpenAttachment(documentID, attachmentID)
+		if err != nil {
+			return err
+		}
+		defer r.Close()
+		attachment = opened
+
+		w := base64.NewEncoder(base64.StdEncoding, &encoded)
+		if _, err := io.Copy(w, r); err != nil {
+			return err
+		}
+		return w.Close()
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get attachment: %v", err)), nil
+	}
+
+	metadata, err := json.MarshalIndent(attachment, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to encode attachment: %v", err)), nil
+	}
+
+	return mcp.NewToolResultResource(string(metadata), mcp.BlobResourceContents{
+		URI:      fmt.Sprintf("qms://%s/documents/%s/attachments/%s", tenantID, documentID, attachment.ID),
+		MIMEType: attachment.MIMEType,
+		Blob:     encoded.String(),
+	}), nil
+}
+
+func handleRemoveAttachment(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	documentID, err := request.RequireString("document_id")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Missing document_id: %v", err)), nil
+	}
+
+	attachmentID, err := request.RequireString("attachment_id")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Missing attachment_id: %v", err)), nil
+	}
+
+	var doc *iso9001.DocumentedInformation
+	_, err = updateTenant(ctx, request, func(tenant *iso9001.Tenant) error {
+		if err := useAttachmentStore(tenant); err != nil {
+			return err
+		}
+		if err := tenant.Documents.RemoveAttachment(documentID, attachmentID); err != nil {
+			return err
+		}
+		doc = tenant.Documents.Documents[documentID]
+		return nil
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to remove attachment: %v", err)), nil
+	}
+
+	recordChange(ctx, iso9001.EntityTypeDocument, documentID, iso9001.ChangeOperationUpdated, doc)
+
+	return newToolResult(iso9001.EntityTypeDocument, documentID, fmt.Sprintf("Attachment %s removed from document %s", attachmentID, documentID), map[string]string{"attachment_id": attachmentID})
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/example/iso9001"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Audit Checklist Handlers
+
+func handleGenerateAuditChecklist(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	auditID := request.GetString("audit_id", "")
+	scope := iso9001.AuditScope{
+		Clauses:    splitList(request.GetString("clauses", "")),
+		Processes:  splitList(request.GetString("processes", "")),
+		Exclusions: splitList(request.GetString("exclusions", "")),
+	}
+	format := request.GetString("format", "markdown")
+	if format != "markdown" && format != "json" {
+		return mcp.NewToolResultError(fmt.Sprintf("Unknown format %q: use markdown or json", format)), nil
+	}
+
+	var checklist *iso9001.AuditChecklist
+	err := viewTenant(ctx, request, requestTenant(ctx, request), func(tenant *iso9001.Tenant) error {
+		if auditID != "" {
+			audit, exists := tenant.Audits.Audits[auditID]
+			if !exists {
+				return fmt.Errorf("audit with ID %s not found", auditID)
+			}
+			scope = audit.Scope
+		}
+		var err error
+		checklist, err = iso9001.GenerateAuditChecklist(tenant.Organization, scope)
+		return err
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to generate audit checklist: %v", err)), nil
+	}
+
+	if format == "json" {
+		result, err := json.MarshalIndent(checklist, "", "  ")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal audit checklist: %v", err)), nil
+		}
+		return newToolResult(iso9001.EntityTypeAuditChecklist, "", "Audit checklist", json.RawMessage(result))
+	}
+
+	var paper strings.Builder
+	if err := checklist.WriteMarkdown(&paper); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to write audit checklist: %v", err)), nil
+	}
+	return newToolResult(iso9001.EntityTypeAuditChecklist, "", "Audit checklist", map[string]string{"markdown": paper.String()})
+}
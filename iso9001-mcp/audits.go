@@ -0,0 +1,197 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/example/iso9001"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// overdueFinding is a finding reported by qms_get_overdue_findings with the audit it
+// was raised in
+type overdueFinding struct {
+	AuditID     string `json:"audit_id"`
+	DaysOverdue int    `json:"days_overdue"`
+	iso9001.AuditFinding
+}
+
+// Audit Lifecycle Handlers
+
+func handleStartAudit(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	auditID, err := request.RequireString("audit_id")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Missing audit_id: %v", err)), nil
+	}
+
+	start, err := parseOptionalTime(request.GetString("start_date", ""))
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid start_date: %v", err)), nil
+	}
+	if start.IsZero() {
+		start = time.Now()
+	}
+
+	var result []byte
+	_, err = updateTenant(ctx, request, func(tenant *iso9001.Tenant) error {
+		if err := tenant.Audits.StartAudit(auditID, start); err != nil {
+			return err
+		}
+		result, err = json.MarshalIndent(tenant.Audits.Audits[auditID], "", "  ")
+		return err
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to start audit: %v", err)), nil
+	}
+
+	recordChange(ctx, iso9001.EntityTypeAudit, auditID, iso9001.ChangeOperationUpdated, json.RawMessage(result))
+
+	return newToolResult(iso9001.EntityTypeAudit, auditID, fmt.Sprintf("Audit %s started", auditID), json.RawMessage(result))
+}
+
+func handleCompleteAudit(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	auditID, err := request.RequireString("audit_id")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Missing audit_id: %v", err)), nil
+	}
+
+	end, err := parseOptionalTime(request.GetString("end_date", ""))
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid end_date: %v", err)), nil
+	}
+	if end.IsZero() {
+		end = time.Now()
+	}
+
+	var report *iso9001.AuditReport
+	if summary := request.GetString("summary", ""); summary != "" {
+		report = &iso9001.AuditReport{
+			ID:              auditID + "-REPORT",
+			Summary:         summary,
+			Conclusions:     request.GetString("conclusions", ""),
+			Effectiveness:   request.GetString("effectiveness", ""),
+			Recommendations: []iso9001.AuditRecommendation{},
+			IssuedDate:      end,
+			ReviewedBy:      request.GetString("reviewed_by", ""),
+			ApprovedBy:      request.GetString("approved_by", ""),
+		}
+	}
+
+	var result []byte
+	_, err = updateTenant(ctx, request, func(tenant *iso9001.Tenant) error {
+		if err := tenant.Audits.CompleteAudit(auditID, end, report); err != nil {
+			return err
+		}
+		result, err = json.MarshalIndent(tenant.Audits.Audits[auditID], "", "  ")
+		return err
+	})
+	if errors.Is(err, iso9001.ErrCriticalFindingsOpen) {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to complete audit: %v; close or accept its critical findings first with qms_close_finding", err)), nil
+	}
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to complete audit: %v", err)), nil
+	}
+
+	recordChange(ctx, iso9001.EntityTypeAudit, auditID, iso9001.ChangeOperationUpdated, json.RawMessage(result))
+
+	return newToolResult(iso9001.EntityTypeAudit, auditID, fmt.Sprintf("Audit %s completed", auditID), json.RawMessage(result))
+}
+
+func handleCloseFinding(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	findingID, err := request.RequireString("finding_id")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Missing finding_id: %v", err)), nil
+	}
+
+	status := iso9001.FindingStatus(request.GetString("status", string(iso9001.FindingStatusClosed)))
+	if status != iso9001.FindingStatusClosed && status != iso9001.FindingStatusAccepted {
+		return mcp.NewToolResultError(fmt.Sprintf("Unknown status %q (use closed or accepted)", status)), nil
+	}
+
+	auditID := request.GetString("audit_id", "")
+	var result []byte
+	_, err = updateTenant(ctx, request, func(tenant *iso9001.Tenant) error {
+		if auditID == "" {
+			auditID = findingAudit(tenant.Audits, findingID)
+			if auditID == "" {
+				return fmt.Errorf("finding with ID %s not found", findingID)
+			}
+		}
+		if err := tenant.Audits.UpdateFindingStatus(auditID, findingID, status); err != nil {
+			return err
+		}
+		for _, finding := range tenant.Audits.Audits[auditID].Findings {
+			if finding.ID == findingID {
+				result, err = json.MarshalIndent(finding, "", "  ")
+			}
+		}
+		return err
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to close finding: %v", err)), nil
+	}
+
+	recordChange(ctx, iso9001.EntityTypeFinding, findingID, iso9001.ChangeOperationUpdated, json.RawMessage(result))
+
+	return newToolResult(iso9001.EntityTypeFinding, findingID, fmt.Sprintf("Finding %s of audit %s is now %s", findingID, auditID, status), json.RawMessage(result))
+}
+
+// findingAudit returns the ID of the audit with the finding, or "" when there is none
+func findingAudit(audits *iso9001.AuditManager, findingID string) string {
+	for id, audit := range audits.Audits {
+		for _, finding := range audit.Findings {
+			if finding.ID == findingID {
+				return id
+			}
+		}
+	}
+	return ""
+}
+
+func handleGetAuditStatistics(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	tenantID := requestTenant(ctx, request)
+	var stats iso9001.AuditStatistics
+	err := viewTenant(ctx, request, tenantID, func(tenant *iso9001.Tenant) error {
+		stats = tenant.Audits.GetAuditStatistics()
+		return nil
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get audit statistics: %v", err)), nil
+	}
+
+	result, err := json.MarshalIndent(stats, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal audit statistics: %v", err)
+	}
+	return newToolResult("audit_statistics", tenantID, fmt.Sprintf("Audit statistics of organization %s", tenantID), json.RawMessage(result))
+}
+
+func handleGetOverdueFindings(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	tenantID := requestTenant(ctx, request)
+	now := time.Now()
+	findings := []overdueFinding{}
+	err := viewTenant(ctx, request, tenantID, func(tenant *iso9001.Tenant) error {
+		for _, finding := range tenant.Audits.GetOverdueFindings() {
+			findings = append(findings, overdueFinding{
+				AuditID:      findingAudit(tenant.Audits, finding.ID),
+				DaysOverdue:  int(now.Sub(finding.DueDate).Hours() / 24),
+				AuditFinding: finding,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get overdue findings: %v", err)), nil
+	}
+	sort.Slice(findings, func(i, j int) bool { return findings[i].DueDate.Before(findings[j].DueDate) })
+
+	result, err := json.MarshalIndent(findings, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal overdue findings: %v", err)
+	}
+	return newToolResult(iso9001.EntityTypeFinding, "", fmt.Sprintf("%d overdue findings in organization %s", len(findings), tenantID), json.RawMessage(result))
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/example/iso9001"
+	bolt "go.etcd.io/bbolt"
+)
+
+// boltTenantFile is the database file -store-backend bolt keeps in the -store directory
+const boltTenantFile = "tenants.db"
+
+// tenantBucket holds one key per tenant ID, valued with the tenant's JSON
+var tenantBucket = []byte("tenants")
+
+// boltTenantBackend stores tenants in an embedded BoltDB database. Each save is a
+// transaction of its own, so a crash leaves either the old or the new tenant. The
+// database is locked by the process that opened it.
+type boltTenantBackend struct {
+	db *bolt.DB
+}
+
+// openBoltTenantBackend opens, or creates, the tenant database at path. It fails
+// when another process holds the database for longer than a few seconds.
+func openBoltTenantBackend(path string) (*boltTenantBackend, error) {
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open tenant database %s: %w", path, err)
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(tenantBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to prepare tenant database %s: %w", path, err)
+	}
+	return &boltTenantBackend{db: db}, nil
+}
+
+// LoadTenant decodes a tenant from the database, migrating it to the current schema
+func (b *boltTenantBackend) LoadTenant(id string) (*iso9001.Tenant, error) {
+	var data []byte
+	err := b.db.View(func(tx *bolt.Tx) error {
+		// the value is only valid during the transaction
+		if value := tx.Bucket(tenantBucket).Get([]byte(id)); value != nil {
+			data = append([]byte(nil), value...)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, fmt.Errorf("%w: %s", iso9001.ErrTenantNotFound, id)
+	}
+
+	tenant, err := iso9001.LoadTenantJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	if tenant.ID != id {
+		return nil, fmt.Errorf("tenant %s is stored under %s", tenant.ID, id)
+	}
+	return tenant, nil
+}
+
+// SaveTenant writes a tenant to the database, replacing the stored one
+func (b *boltTenantBackend) SaveTenant(tenant *iso9001.Tenant) error {
+	if tenant.ID == "" {
+		return errors.New("tenant must have an ID")
+	}
+	tenant.SchemaVersion = iso9001.CurrentSchemaVersion
+	if tenant.Organization != nil {
+		tenant.Organization.SchemaVersion = iso9001.CurrentSchemaVersion
+	}
+	data, err := json.Marshal(tenant)
+	if err != nil {
+		return fmt.Errorf("failed to encode tenant %s: %w", tenant.ID, err)
+	}
+	return b.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(tenantBucket).Put([]byte(tenant.ID), data)
+	})
+}
+
+// ListTenants returns the IDs of the stored tenants, sorted as bolt keeps its keys
+func (b *boltTenantBackend) ListTenants() ([]string, error) {
+	var ids []string
+	err := b.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(tenantBucket).ForEach(func(key, _ []byte) error {
+			ids = append(ids, string(key))
+			return nil
+		})
+	})
+	return ids, err
+}
+
+// Close releases the database and its lock
+func (b *boltTenantBackend) Close() error {
+	return b.db.Close()
+}
+
+// boltTenantPath returns the tenant database of a -store directory
+func boltTenantPath(dir string) string {
+	return filepath.Join(dir, boltTenantFile)
+}
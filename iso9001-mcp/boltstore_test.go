@@ -0,0 +1,89 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/example/iso9001"
+)
+
+func TestBoltTenantBackend(t *testing.T) {
+	path := boltTenantPath(t.TempDir())
+	backend, err := openBoltTenantBackend(path)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+
+	store := iso9001.NewTenantStore(backend, 0)
+	if _, err := store.CreateTenant("ACME"); err != nil {
+		t.Fatalf("Failed to create tenant: %v", err)
+	}
+	err = store.UpdateTenant("ACME", func(tenant *iso9001.Tenant) error {
+		tenant.Organization = &iso9001.Organization{ID: "ACME", Name: "Acme Manufacturing"}
+		return tenant.Risks.IdentifyRisk(&iso9001.Risk{ID: "RISK-001", Description: "Supplier delivers late"})
+	})
+	if err != nil {
+		t.Fatalf("Failed to update tenant: %v", err)
+	}
+	if err := store.Flush(); err != nil {
+		t.Fatalf("Failed to flush store: %v", err)
+	}
+	if err := backend.Close(); err != nil {
+		t.Fatalf("Failed to close database: %v", err)
+	}
+
+	// the tenant survives reopening the database
+	backend, err = openBoltTenantBackend(path)
+	if err != nil {
+		t.Fatalf("Failed to reopen database: %v", err)
+	}
+	defer backend.Close()
+
+	tenant, err := backend.LoadTenant("ACME")
+	if err != nil {
+		t.Fatalf("Failed to load tenant: %v", err)
+	}
+	if tenant.Organization == nil || tenant.Organization.Name != "Acme Manufacturing" {
+		t.Errorf("Expected the organization to be stored, got %+v", tenant.Organization)
+	}
+	if _, ok := tenant.Risks.Risks["RISK-001"]; !ok {
+		t.Error("Expected the risk to be stored")
+	}
+	if tenant.SchemaVersion != iso9001.CurrentSchemaVersion {
+		t.Errorf("Expected schema version %d, got %d", iso9001.CurrentSchemaVersion, tenant.SchemaVersion)
+	}
+
+	if _, err := backend.LoadTenant("MISSING"); !errors.Is(err, iso9001.ErrTenantNotFound) {
+		t.Errorf("Expected ErrTenantNotFound, got %v", err)
+	}
+	if err := backend.SaveTenant(iso9001.NewTenant("BETA")); err != nil {
+		t.Fatalf("Failed to save tenant: %v", err)
+	}
+	ids, err := backend.ListTenants()
+	if err != nil || len(ids) != 2 || ids[0] != "ACME" || ids[1] != "BETA" {
+		t.Errorf("Expected the stored tenants to be listed, got %v (%v)", ids, err)
+	}
+}
+
+func TestOpenTenantStoreBackends(t *testing.T) {
+	saved := tenantStore
+	defer func() { tenantStore = saved }()
+
+	for _, kind := range []string{storeBackendFiles, storeBackendEvents, storeBackendBolt} {
+		t.Run(kind, func(t *testing.T) {
+			if err := openTenantStore(t.TempDir(), kind); err != nil {
+				t.Fatalf("Failed to open %s store: %v", kind, err)
+			}
+			if _, err := tenantStore.CreateTenant("ACME"); err != nil {
+				t.Fatalf("Failed to create tenant: %v", err)
+			}
+			if err := tenantStore.Flush(); err != nil {
+				t.Errorf("Failed to flush store: %v", err)
+			}
+		})
+	}
+
+	if err := openTenantStore(t.TempDir(), "sqlite"); err == nil {
+		t.Error("Expected an unknown backend to be rejected")
+	}
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/example/iso9001"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// defaultTimeZone is the time zone of organizations created without one, and the one
+// dates given to tools without a time are read in, configured by -time-zone
+var defaultTimeZone = time.UTC
+
+// defaultBusinessDays counts due dates of organizations created without a calendar in
+// business days, configured by -business-days
+var defaultBusinessDays bool
+
+// applyCalendarDefaults gives an organization the server's time zone and calendar
+// where it sets none
+func applyCalendarDefaults(org *iso9001.Organization) {
+	if org.TimeZone == "" && defaultTimeZone != time.UTC {
+		org.TimeZone = defaultTimeZone.String()
+	}
+	if org.Calendar == nil && defaultBusinessDays {
+		org.Calendar = &iso9001.WorkCalendar{BusinessDays: true}
+	}
+}
+
+// tenantDueDates returns the due date calculator of a tenant, built from the time zone
+// and calendar of its organization
+func tenantDueDates(tenant *iso9001.Tenant) *iso9001.DueDateCalculator {
+	if tenant.Audits.DueDates != nil {
+		return tenant.Audits.DueDates
+	}
+	return iso9001.NewDueDateCalculator(defaultTimeZone)
+}
+
+// Calendar Handlers
+
+func handleSetCalendar(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var weekend []string
+	weekendGiven, err := decodeArgument(request, "weekend", &weekend)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	var holidays []iso9001.Holiday
+	holidaysGiven, err := decodeArgument(request, "holidays", &holidays)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	_, businessDaysGiven := request.GetArguments()["business_days"]
+	timeZone := request.GetString("time_zone", "")
+
+	var org *iso9001.Organization
+	tenantID, err := updateTenant(ctx, request, func(tenant *iso9001.Tenant) error {
+		org = tenant.Organization
+		if org == nil {
+			return fmt.Errorf("organization %s is not set up", tenant.ID)
+		}
+		if timeZone != "" {
+			org.TimeZone = timeZone
+		}
+		calendar := iso9001.WorkCalendar{}
+		if org.Calendar != nil {
+			calendar = *org.Calendar
+		}
+		if businessDaysGiven {
+			calendar.BusinessDays = request.GetBool("business_days", false)
+		}
+		if weekendGiven {
+			calendar.Weekend = weekend
+		}
+		if holidaysGiven {
+			calendar.Holidays = holidays
+		}
+		org.Calendar = &calendar
+		org.Modified = time.Now()
+		// the change is rolled back when the time zone or calendar is invalid
+		return nil
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to set calendar: %v", err)), nil
+	}
+
+	recordChange(ctx, iso9001.EntityTypeOrganization, tenantID, iso9001.ChangeOperationUpdated, org)
+
+	zone := org.TimeZone
+	if zone == "" {
+		zone = "UTC"
+	}
+	counting := "calendar days"
+	if org.Calendar.BusinessDays {
+		counting = "business days"
+	}
+	return newToolResult(iso9001.EntityTypeOrganization, tenantID,
+		fmt.Sprintf("Due dates of organization %s are counted in %s in %s, with %d holidays", tenantID, counting, zone, len(org.Calendar.Holidays)),
+		map[string]interface{}{"time_zone": org.TimeZone, "calendar": org.Calendar})
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/example/iso9001"
+)
+
+func TestSetCalendar(t *testing.T) {
+	if _, err := time.LoadLocation("Europe/Berlin"); err != nil {
+		t.Skipf("Time zone data unavailable: %v", err)
+	}
+	useTestStore(t)
+	savedZone, savedBusinessDays := defaultTimeZone, defaultBusinessDays
+	defer func() { defaultTimeZone, defaultBusinessDays = savedZone, savedBusinessDays }()
+	defaultTimeZone, _ = time.LoadLocation("Asia/Tokyo")
+	defaultBusinessDays = true
+	ctx := context.Background()
+
+	if text, isError := callTool(t, ctx, handleCreateOrganization, "qms_create_organization", map[string]any{"id": "ACME", "name": "Acme"}); isError {
+		t.Fatalf("Failed to create organization: %s", text)
+	}
+	dueDates := func() *iso9001.DueDateCalculator {
+		tenant, err := tenantStore.GetTenant("ACME")
+		if err != nil {
+			t.Fatalf("Failed to get tenant: %v", err)
+		}
+		return tenantDueDates(tenant)
+	}
+	// a new organization gets the server defaults
+	if calc := dueDates(); calc.Location.String() != "Asia/Tokyo" || !calc.BusinessDays {
+		t.Errorf("Expected the server defaults for a new organization, got %+v", calc)
+	}
+
+	text, isError := callTool(t, ctx, handleSetCalendar, "qms_set_calendar", map[string]any{
+		"organization_id": "ACME",
+		"time_zone":       "Europe/Berlin",
+		"weekend":         []any{"friday", "saturday"},
+		"holidays":        []any{map[string]any{"date": "2026-12-25", "name": "Christmas Day"}},
+	})
+	if isError {
+		t.Fatalf("Failed to set calendar: %s", text)
+	}
+	calc := dueDates()
+	if calc.Location.String() != "Europe/Berlin" || !calc.BusinessDays || len(calc.Weekend) != 2 {
+		t.Errorf("Expected the tenant's due dates to follow its calendar, got %+v", calc)
+	}
+	if calc.IsBusinessDay(time.Date(2026, 12, 25, 12, 0, 0, 0, calc.Location)) {
+		t.Error("Expected the holiday to be stored with the tenant")
+	}
+
+	// a calendar without business days is rejected and leaves the old one in place
+	text, isError = callTool(t, ctx, handleSetCalendar, "qms_set_calendar", map[string]any{
+		"organization_id": "ACME",
+		"weekend":         []any{"sunday", "monday", "tuesday", "wednesday", "thursday", "friday", "saturday"},
+	})
+	if !isError || !strings.Contains(text, "no business days") {
+		t.Errorf("Expected an all-weekend calendar to be rejected, got %s", text)
+	}
+	if calc := dueDates(); len(calc.Weekend) != 2 {
+		t.Errorf("Expected the calendar to be kept, got weekend %v", calc.Weekend)
+	}
+	text, isError = callTool(t, ctx, handleSetCalendar, "qms_set_calendar", map[string]any{
+		"organization_id": "ACME",
+		"time_zone":       "Mars/Olympus_Mons",
+	})
+	if !isError || !strings.Contains(text, "invalid time zone") {
+		t.Errorf("Expected an unknown time zone to be rejected, got %s", text)
+	}
+}
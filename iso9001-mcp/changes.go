@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/example/iso9001"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// changeLogs record every entity produced by the tools, per tenant, so clients can
+// sync the deltas of their organization
+var changeLogs = iso9001.NewTenantScoped(func() *iso9001.ChangeLog { return iso9001.NewChangeLog(10000) })
+
+// recordChange appends an entity change to the change log of its tenant and attributes
+// it to the calling actor in the tenant's activity log
+func recordChange(ctx context.Context, entityType, entityID string, op iso9001.ChangeOperation, entity interface{}) {
+	tenantID := changeTenant(ctx, entityType, entityID)
+	changeLogs.For(tenantID).Record(entityType, entityID, op, entity)
+	recordActivity(ctx, tenantID, entityType, entityID, op, entity)
+}
+
+// changeTenant returns the tenant a change was made in: the organization's own, or the
+// one the tool call works on
+func changeTenant(ctx context.Context, entityType, entityID string) string {
+	if entityType == iso9001.EntityTypeOrganization {
+		return entityID
+	}
+	if call, ok := ctx.Value(toolCallKey{}).(*toolCall); ok {
+		return requestTenant(ctx, call.request)
+	}
+	return workspaceTenant(ctx)
+}
+
+// Delta Sync Handlers
+
+func handleChanges(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	cursor, err := parseCursor(request.GetString("cursor", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	limit := request.GetInt("limit", 100)
+
+	tenantID := requestTenant(ctx, request)
+	if accessPolicy != nil {
+		if err := accessPolicy.AuthorizeTenant(requestIdentity(ctx), tenantID); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+	}
+	changes := changeLogs.For(tenantID).Since(cursor, limit)
+
+	result, err := json.MarshalIndent(changes, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal changes: %v", err)), nil
+	}
+
+	return newToolResult("change", "", fmt.Sprintf("Changes of %s since cursor %d", tenantID, cursor), json.RawMessage(result))
+}
+
+// handleChangesResource serves the change feed of the organization in the workspace
+func handleChangesResource(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	tenantID := workspaceTenant(ctx)
+	if err := authorizeTenant(ctx, tenantID); err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(changeLogs.For(tenantID).Since(0, 0))
+	if err != nil {
+		return nil, err
+	}
+
+	return jsonResource(request.Params.URI, data), nil
+}
+
+// parseCursor converts a client-supplied cursor; an empty cursor starts from the beginning
+func parseCursor(cursor string) (uint64, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+	value, err := strconv.ParseUint(cursor, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("Invalid cursor %q: must be a value returned as next_cursor", cursor)
+	}
+	return value, nil
+}
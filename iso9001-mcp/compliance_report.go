@@ -0,0 +1,37 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/example/iso9001"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Compliance Report Handlers
+
+func handleGetComplianceReportPDF(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	org, err := organizationArgument(ctx, request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	progress := newProgressReporter(ctx, request)
+	report := iso9001.GenerateComplianceReportWithOptions(org, iso9001.ParseLocale(request.GetString("language", "en")),
+		iso9001.ValidationOptions{Progress: progress.validation(1)})
+	var pdf bytes.Buffer
+	if err := iso9001.WriteComplianceReportPDF(&pdf, org, report); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to render compliance report: %v", err)), nil
+	}
+	progress.step("Rendered compliance report")
+
+	summary := fmt.Sprintf("Compliance report for %s: %.1f%% (%s), %d critical gaps, %d improvement areas",
+		org.ID, report.ComplianceScore, report.OverallCompliance, len(report.CriticalGaps), len(report.ImprovementAreas))
+	return mcp.NewToolResultResource(summary, mcp.BlobResourceContents{
+		URI:      fmt.Sprintf("qms://%s/reports/compliance-%s.pdf", org.ID, report.AssessmentDate.Format("2006-01-02")),
+		MIMEType: "application/pdf",
+		Blob:     base64.StdEncoding.EncodeToString(pdf.Bytes()),
+	}), nil
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/example/iso9001"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Distribution Handlers
+
+func handleDistributeDocument(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	documentID, err := request.RequireString("document_id")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Missing document_id: %v", err)), nil
+	}
+
+	recipients, err := request.RequireString("recipients")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Missing recipients: %v", err)), nil
+	}
+
+	var doc *iso9001.DocumentedInformation
+	var result []byte
+	_, err = updateTenant(ctx, request, func(tenant *iso9001.Tenant) error {
+		issued, err := tenant.Documents.DistributeDocument(documentID, requestIdentity(ctx), splitList(recipients)...)
+		if err != nil {
+			return err
+		}
+		doc = tenant.Documents.Documents[documentID]
+		result, err = json.MarshalIndent(issued, "", "  ")
+		return err
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to distribute document: %v", err)), nil
+	}
+
+	recordChange(ctx, iso9001.EntityTypeDocument, documentID, iso9001.ChangeOperationUpdated, doc)
+
+	return newToolResult(iso9001.EntityTypeDocument, documentID, fmt.Sprintf("Controlled copies of document %s issued", documentID), json.RawMessage(result))
+}
+
+func handleAcknowledgeDocument(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	documentID, err := request.RequireString("document_id")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Missing document_id: %v", err)), nil
+	}
+
+	// Acknowledging records a receipt, so it takes the right to edit; acknowledging
+	// for others also takes the right to manage documents
+	identity := requestIdentity(ctx)
+	recipient := request.GetString("recipient", identity)
+	if accessPolicy != nil && recipient != identity {
+		if err := accessPolicy.Authorize(identity, iso9001.PermissionManageDocuments); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Cannot acknowledge for %s: %v", recipient, err)), nil
+		}
+	}
+
+	var doc *iso9001.DocumentedInformation
+	var record *iso9001.DistributionRecord
+	_, err = updateTenant(ctx, request, func(tenant *iso9001.Tenant) error {
+		var err error
+		record, err = tenant.Documents.AcknowledgeDistribution(documentID, recipient, time.Now())
+		doc = tenant.Documents.Documents[documentID]
+		return err
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to acknowledge document: %v", err)), nil
+	}
+
+	recordChange(ctx, iso9001.EntityTypeDocument, documentID, iso9001.ChangeOperationUpdated, doc)
+
+	return newToolResult(iso9001.EntityTypeDocument, documentID, fmt.Sprintf("%s acknowledged copy %d of document %s version %s", recipient, record.CopyNumber, documentID, record.Version), record)
+}
+
+func handleGetDistributionStatus(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	recipient := request.GetString("recipient", "")
+
+	status := struct {
+		OutstandingAcknowledgments []iso9001.DistributedCopy `json:"outstanding_acknowledgments"`
+		ObsoleteCopies             []iso9001.ObsoleteCopy    `json:"obsolete_copies"`
+	}{[]iso9001.DistributedCopy{}, []iso9001.ObsoleteCopy{}}
+	err := viewTenant(ctx, request, requestTenant(ctx, request), func(tenant *iso9001.Tenant) error {
+		for _, held := range tenant.Documents.OutstandingAcknowledgments() {
+			if recipient == "" || held.Recipient == recipient {
+				status.OutstandingAcknowledgments = append(status.OutstandingAcknowledgments, held)
+			}
+		}
+		for _, held := range tenant.Documents.ObsoleteCopies() {
+			if recipient == "" || held.Recipient == recipient {
+				status.ObsoleteCopies = append(status.ObsoleteCopies, held)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get distribution status: %v", err)), nil
+	}
+
+	result, err := json.MarshalIndent(status, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal distribution status: %v", err)), nil
+	}
+
+	return newToolResult("distribution_status", "", fmt.Sprintf("%d acknowledgments outstanding, %d people working from obsolete revisions",
+		len(status.OutstandingAcknowledgments), len(status.ObsoleteCopies)), json.RawMessage(result))
+}
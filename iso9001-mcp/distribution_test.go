@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/example/iso9001"
+)
+
+// withAPIKey returns a context authenticated with a key of the scope, as the HTTP
+// transport passes it to tool handlers
+func withAPIKey(name string, scope iso9001.APIKeyScope) context.Context {
+	key := &iso9001.APIKey{Name: name, Scope: scope}
+	return iso9001.WithActor(context.WithValue(context.Background(), apiKeyContextKey{}, key), name)
+}
+
+func TestAcknowledgeDocumentScope(t *testing.T) {
+	useTestStore(t)
+	if _, err := tenantStore.CreateTenant("ACME"); err != nil {
+		t.Fatalf("Failed to create tenant: %v", err)
+	}
+	err := tenantStore.UpdateTenant("ACME", func(tenant *iso9001.Tenant) error {
+		docs := tenant.Documents
+		docs.AddDocument(&iso9001.DocumentedInformation{ID: "WI-001", Title: "Torque settings", Content: "Rev A"})
+		docs.SetDocumentStatus("WI-001", iso9001.DocumentStatusApproved)
+		docs.SetDocumentStatus("WI-001", iso9001.DocumentStatusPublished)
+		_, err := docs.DistributeDocument("WI-001", "qm", "ann")
+		return err
+	})
+	if err != nil {
+		t.Fatalf("Failed to distribute document: %v", err)
+	}
+
+	acknowledge := registeredTool(t, setupDocumentationTools, "qms_acknowledge_document")
+	args := map[string]any{"organization_id": "ACME", "document_id": "WI-001"}
+
+	text, isError := callTool(t, withAPIKey("ann", iso9001.APIKeyScopeRead), acknowledge, "qms_acknowledge_document", args)
+	if !isError || !strings.Contains(text, "write scope required") {
+		t.Errorf("Expected a read-scoped key to be denied, got %s", text)
+	}
+	tenant, err := tenantStore.GetTenant("ACME")
+	if err != nil {
+		t.Fatalf("Failed to get tenant: %v", err)
+	}
+	if outstanding := tenant.Documents.OutstandingAcknowledgments(); len(outstanding) != 1 {
+		t.Errorf("Expected the copy to stay unacknowledged, got %+v", outstanding)
+	}
+
+	if text, isError := callTool(t, withAPIKey("ann", iso9001.APIKeyScopeWrite), acknowledge, "qms_acknowledge_document", args); isError {
+		t.Errorf("Expected a write-scoped key to acknowledge, got %s", text)
+	}
+}
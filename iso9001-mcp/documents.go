@@ -0,0 +1,300 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/example/iso9001"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// documentListing is what qms_list_documents reports of a document
+type documentListing struct {
+	ID             string                   `json:"id"`
+	Title          string                   `json:"title"`
+	Type           iso9001.DocumentType     `json:"type"`
+	Category       iso9001.DocumentCategory `json:"category"`
+	Status         iso9001.DocumentStatus   `json:"status"`
+	Version        string                   `json:"version,omitempty"`
+	Owner          string                   `json:"owner,omitempty"`
+	Classification string                   `json:"classification,omitempty"`
+	NextReview     *time.Time               `json:"next_review,omitempty"`
+	Modified       time.Time                `json:"modified"`
+}
+
+// Document Lifecycle Handlers
+
+func handleUpdateDocument(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	documentID, err := request.RequireString("document_id")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Missing document_id: %v", err)), nil
+	}
+
+	status := iso9001.DocumentStatus(request.GetString("status", ""))
+	switch status {
+	case "", iso9001.DocumentStatusDraft, iso9001.DocumentStatusReview, iso9001.DocumentStatusPublished:
+	case iso9001.DocumentStatusApproved:
+		return mcp.NewToolResultError("Documents are approved with qms_approve_document"), nil
+	case iso9001.DocumentStatusArchived:
+		return mcp.NewToolResultError("Documents are archived with qms_archive_document"), nil
+	default:
+		return mcp.NewToolResultError(fmt.Sprintf("Unknown status %q (use draft, review or published)", status)), nil
+	}
+
+	arguments := request.GetArguments()
+	edited := false
+	for _, name := range []string{"title", "content", "owner", "keywords", "related_clauses"} {
+		if _, ok := arguments[name]; ok {
+			edited = true
+		}
+	}
+	summary := request.GetString("change_summary", "")
+	if edited && summary == "" {
+		return mcp.NewToolResultError("change_summary is required when the title, content or metadata change"), nil
+	}
+	if !edited && status == "" {
+		return mcp.NewToolResultError("Nothing to update: give new content, metadata or a status"), nil
+	}
+
+	var result []byte
+	_, err = updateTenant(ctx, request, func(tenant *iso9001.Tenant) error {
+		existing, err := tenant.Documents.GetDocument(documentID)
+		if err != nil {
+			return err
+		}
+		if edited {
+			updates := *existing
+			updates.Title = request.GetString("title", existing.Title)
+			updates.Content = request.GetString("content", existing.Content)
+			updates.Metadata.Owner = request.GetString("owner", existing.Metadata.Owner)
+			if _, ok := arguments["keywords"]; ok {
+				updates.Metadata.Keywords = splitList(request.GetString("keywords", ""))
+			}
+			if _, ok := arguments["related_clauses"]; ok {
+				updates.Metadata.RelatedClauses = splitList(request.GetString("related_clauses", ""))
+			}
+			if author := requestIdentity(ctx); author != "" {
+				updates.Metadata.Author = author
+			}
+			change := iso9001.VersionChange{Summary: summary, Major: request.GetBool("major", false)}
+			if err := tenant.Documents.UpdateDocument(documentID, &updates, change); err != nil {
+				return err
+			}
+		}
+		if status != "" {
+			if doc := tenant.Documents.Documents[documentID]; doc.Status != status {
+				if err := tenant.Documents.SetDocumentStatus(documentID, status); err != nil {
+					return err
+				}
+			}
+		}
+		result, err = json.MarshalIndent(tenant.Documents.Documents[documentID], "", "  ")
+		return err
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to update document: %v", err)), nil
+	}
+
+	recordChange(ctx, iso9001.EntityTypeDocument, documentID, iso9001.ChangeOperationUpdated, json.RawMessage(result))
+
+	return newToolResult(iso9001.EntityTypeDocument, documentID, fmt.Sprintf("Document %s updated", documentID), json.RawMessage(result))
+}
+
+func handleReviewDocument(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	documentID, err := request.RequireString("document_id")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Missing document_id: %v", err)), nil
+	}
+
+	comments, err := request.RequireString("comments")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Missing comments: %v", err)), nil
+	}
+
+	reviewerID := request.GetString("reviewer_id", requestIdentity(ctx))
+	if reviewerID == "" {
+		return mcp.NewToolResultError("Missing reviewer_id: no caller identity to default to"), nil
+	}
+
+	now := time.Now()
+	next, err := parseOptionalTime(request.GetString("next_review_date", ""))
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid next_review_date: %v", err)), nil
+	}
+	if next.IsZero() {
+		next = now.AddDate(1, 0, 0)
+	}
+	if !next.After(now) {
+		return mcp.NewToolResultError("next_review_date must lie in the future"), nil
+	}
+
+	review := iso9001.DocumentReview{
+		ReviewDate:     now,
+		ReviewerID:     reviewerID,
+		ReviewerName:   request.GetString("reviewer_name", ""),
+		ReviewComments: comments,
+		NextReviewDate: next,
+		Status:         iso9001.ReviewStatusCompleted,
+	}
+
+	var result []byte
+	_, err = updateTenant(ctx, request, func(tenant *iso9001.Tenant) error {
+		if err := tenant.Documents.ReviewDocument(documentID, review); err != nil {
+			return err
+		}
+		result, err = json.MarshalIndent(tenant.Documents.Documents[documentID].Review, "", "  ")
+		return err
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to review document: %v", err)), nil
+	}
+
+	recordChange(ctx, iso9001.EntityTypeDocument, documentID, iso9001.ChangeOperationUpdated, review)
+
+	return newToolResult(iso9001.EntityTypeDocument, documentID, fmt.Sprintf("Review of document %s recorded; next review due %s", documentID, next.Format("2006-01-02")), json.RawMessage(result))
+}
+
+func handleArchiveDocument(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	documentID, err := request.RequireString("document_id")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Missing document_id: %v", err)), nil
+	}
+
+	reason, err := request.RequireString("reason")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Missing reason: %v", err)), nil
+	}
+
+	var result []byte
+	_, err = updateTenant(ctx, request, func(tenant *iso9001.Tenant) error {
+		if err := tenant.Documents.ArchiveDocument(documentID, reason); err != nil {
+			return err
+		}
+		result, err = json.MarshalIndent(tenant.Documents.Documents[documentID], "", "  ")
+		return err
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to archive document: %v", err)), nil
+	}
+
+	recordChange(ctx, iso9001.EntityTypeDocument, documentID, iso9001.ChangeOperationUpdated, json.RawMessage(result))
+
+	return newToolResult(iso9001.EntityTypeDocument, documentID, fmt.Sprintf("Document %s archived", documentID), json.RawMessage(result))
+}
+
+func handleListDocuments(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var criteria iso9001.DocumentSearchCriteria
+	if value := request.GetString("type", ""); value != "" {
+		docType := parseDocumentType(value)
+		criteria.Type = &docType
+	}
+	if value := request.GetString("category", ""); value != "" {
+		category := parseDocumentCategory(value)
+		criteria.Category = &category
+	}
+	if value := request.GetString("status", ""); value != "" {
+		status := iso9001.DocumentStatus(value)
+		criteria.Status = &status
+	}
+	for name, target := range map[string]**string{"title": &criteria.Title, "keyword": &criteria.Keyword, "clause": &criteria.Clause} {
+		if value := request.GetString(name, ""); value != "" {
+			*target = &value
+		}
+	}
+	dueForReview := request.GetBool("due_for_review", false)
+
+	tenantID := requestTenant(ctx, request)
+	identity := requestIdentity(ctx)
+	listings := []documentListing{}
+	err := viewTenant(ctx, request, tenantID, func(tenant *iso9001.Tenant) error {
+		docs := tenant.Documents.SearchDocuments(criteria)
+		if dueForReview {
+			due := make(map[string]bool)
+			for _, doc := range tenant.Documents.GetDocumentsDueForReview() {
+				due[doc.ID] = true
+			}
+			var filtered []*iso9001.DocumentedInformation
+			for _, doc := range docs {
+				if due[doc.ID] {
+					filtered = append(filtered, doc)
+				}
+			}
+			docs = filtered
+		}
+		for _, doc := range docs {
+			if !accessPolicy.CanReadDocument(identity, doc) {
+				doc = iso9001.RedactDocument(doc)
+			}
+			listing := documentListing{
+				ID:             doc.ID,
+				Title:          doc.Title,
+				Type:           doc.Type,
+				Category:       doc.Category,
+				Status:         doc.Status,
+				Owner:          doc.Metadata.Owner,
+				Classification: doc.Access.Classification,
+				Modified:       doc.Modified,
+			}
+			if n := len(doc.Versions); n > 0 {
+				listing.Version = doc.Versions[n-1].VersionNumber
+			}
+			if doc.Review != nil && !doc.Review.NextReviewDate.IsZero() {
+				next := doc.Review.NextReviewDate
+				listing.NextReview = &next
+			}
+			listings = append(listings, listing)
+		}
+		return nil
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to list documents: %v", err)), nil
+	}
+	sort.Slice(listings, func(i, j int) bool { return listings[i].ID < listings[j].ID })
+
+	result, err := json.MarshalIndent(listings, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal documents: %v", err)
+	}
+	return newToolResult(iso9001.EntityTypeDocument, "", fmt.Sprintf("%d documents in organization %s", len(listings), tenantID), json.RawMessage(result))
+}
+
+func handleGetDocument(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	documentID, err := request.RequireString("document_id")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Missing document_id: %v", err)), nil
+	}
+	version := request.GetString("version", "")
+
+	tenantID := requestTenant(ctx, request)
+	identity := requestIdentity(ctx)
+	var result []byte
+	err = viewTenant(ctx, request, tenantID, func(tenant *iso9001.Tenant) error {
+		doc, err := tenant.Documents.GetDocument(documentID)
+		if err != nil {
+			return err
+		}
+		if !accessPolicy.CanReadDocument(identity, doc) {
+			if version != "" {
+				return fmt.Errorf("%q is not on the read list of %s document %s", identity, doc.Access.Classification, doc.ID)
+			}
+			doc = iso9001.RedactDocument(doc)
+		}
+		if version != "" {
+			found, err := tenant.Documents.GetDocumentVersion(documentID, version)
+			if err != nil {
+				return err
+			}
+			result, err = json.MarshalIndent(found, "", "  ")
+			return err
+		}
+		result, err = json.MarshalIndent(doc, "", "  ")
+		return err
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get document: %v", err)), nil
+	}
+	return newToolResult(iso9001.EntityTypeDocument, documentID, fmt.Sprintf("Document %s", documentID), json.RawMessage(result))
+}
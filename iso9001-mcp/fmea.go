@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/example/iso9001"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// FMEA Handlers
+
+func handleUpdateFMEARow(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	processID, err := request.RequireString("process_id")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Missing process_id: %v", err)), nil
+	}
+
+	failureMode, err := request.RequireString("failure_mode")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Missing failure_mode: %v", err)), nil
+	}
+
+	var ratings [3]int
+	for i, name := range []string{"severity", "occurrence", "detection"} {
+		rating, err := request.RequireInt(name)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Missing %s: %v", name, err)), nil
+		}
+		ratings[i] = rating
+	}
+
+	dueDate, err := parseOptionalTime(request.GetString("due_date", ""))
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid due_date: %v", err)), nil
+	}
+
+	row := iso9001.FMEARow{
+		ID:                request.GetString("row_id", ""),
+		ProcessStep:       request.GetString("process_step", ""),
+		FailureMode:       failureMode,
+		Effect:            request.GetString("effect", ""),
+		Cause:             request.GetString("cause", ""),
+		CurrentControls:   request.GetString("current_controls", ""),
+		Severity:          ratings[0],
+		Occurrence:        ratings[1],
+		Detection:         ratings[2],
+		RecommendedAction: request.GetString("recommended_action", ""),
+		Responsible:       request.GetString("responsible", ""),
+		DueDate:           dueDate,
+		RiskID:            request.GetString("risk_id", ""),
+	}
+
+	var worksheet *iso9001.FMEAWorksheet
+	var result []byte
+	_, err = updateTenant(ctx, request, func(tenant *iso9001.Tenant) error {
+		worksheet = tenant.Risks.Worksheet(processID)
+		if thresholds, ok := thresholdArguments(request, worksheet.Thresholds); ok {
+			if err := worksheet.SetThresholds(thresholds); err != nil {
+				return err
+			}
+		}
+		updated, err := worksheet.UpsertRow(row)
+		if err != nil {
+			return err
+		}
+		result, err = json.MarshalIndent(updated, "", "  ")
+		return err
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to update FMEA row: %v", err)), nil
+	}
+
+	recordChange(ctx, iso9001.EntityTypeFMEAWorksheet, processID, iso9001.ChangeOperationUpdated, worksheet)
+
+	return newToolResult(iso9001.EntityTypeFMEAWorksheet, processID, fmt.Sprintf("FMEA row saved in the worksheet of %s", processID), json.RawMessage(result))
+}
+
+func handleGetFMEAWorksheet(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	processID, err := request.RequireString("process_id")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Missing process_id: %v", err)), nil
+	}
+
+	var result []byte
+	err = viewTenant(ctx, request, requestTenant(ctx, request), func(tenant *iso9001.Tenant) error {
+		stored, exists := tenant.Risks.FMEA[processID]
+		if !exists {
+			return fmt.Errorf("process %s has no FMEA worksheet", processID)
+		}
+
+		// Work on a copy so that what-if thresholds are not saved
+		worksheet := *stored
+		worksheet.Rows = make([]*iso9001.FMEARow, len(stored.Rows))
+		for i, row := range stored.Rows {
+			copied := *row
+			worksheet.Rows[i] = &copied
+		}
+		if thresholds, ok := thresholdArguments(request, worksheet.Thresholds); ok {
+			if err := worksheet.SetThresholds(thresholds); err != nil {
+				return err
+			}
+		}
+
+		var err error
+		result, err = json.MarshalIndent(map[string]interface{}{
+			"process_id": worksheet.ProcessID,
+			"thresholds": worksheet.Thresholds,
+			"summary":    worksheet.Summary(),
+			"rows":       worksheet.Prioritized(),
+		}, "", "  ")
+		return err
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get FMEA worksheet: %v", err)), nil
+	}
+
+	return newToolResult(iso9001.EntityTypeFMEAWorksheet, processID, fmt.Sprintf("FMEA worksheet of %s", processID), json.RawMessage(result))
+}
+
+// thresholdArguments applies the RPN threshold arguments of a request to the current
+// thresholds, reporting whether any was given
+func thresholdArguments(request mcp.CallToolRequest, thresholds iso9001.RPNThresholds) (iso9001.RPNThresholds, bool) {
+	given := false
+	for name, threshold := range map[string]*int{
+		"action_threshold":   &thresholds.Action,
+		"critical_threshold": &thresholds.Critical,
+		"severity_threshold": &thresholds.Severity,
+	} {
+		if value := request.GetInt(name, -1); value >= 0 {
+			*threshold = value
+			given = true
+		}
+	}
+	return thresholds, given
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// GlossaryTerm is a term from the ISO 9000:2015 vocabulary as used by ISO 9001
+type GlossaryTerm struct {
+	Term       string   `json:"term"`
+	Definition string   `json:"definition"`
+	Note       string   `json:"note,omitempty"`
+	SeeAlso    []string `json:"see_also,omitempty"`
+	NotToBe    []string `json:"not_to_be_confused_with,omitempty"`
+}
+
+// glossary holds the fundamental quality management terms, paraphrased from
+// ISO 9000:2015 clause 3, in alphabetical order
+var glossary = []GlossaryTerm{
+	{Term: "audit", Definition: "Systematic, independent and documented process for obtaining objective evidence and evaluating it objectively to determine the extent to which the audit criteria are fulfilled.", Note: "Internal (first-party) audits are conducted by or on behalf of the organization itself; external audits include second-party (customer) and third-party (certification) audits.", SeeAlso: []string{"audit criteria", "audit evidence", "audit finding", "audit programme"}},
+	{Term: "audit criteria", Definition: "Set of requirements used as a reference against which objective evidence is compared.", SeeAlso: []string{"audit", "requirement"}},
+	{Term: "audit evidence", Definition: "Records, statements of fact or other information that are relevant to the audit criteria and verifiable.", SeeAlso: []string{"objective evidence"}},
+	{Term: "audit finding", Definition: "Result of the evaluation of the collected audit evidence against audit criteria.", Note: "Findings indicate conformity or nonconformity and can lead to the identification of opportunities for improvement.", SeeAlso: []string{"nonconformity"}},
+	{Term: "audit programme", Definition: "Arrangements for a set of one or more audits planned for a specific time frame and directed towards a specific purpose.", SeeAlso: []string{"audit scope"}},
+	{Term: "audit scope", Definition: "Extent and boundaries of an audit, such as the physical and virtual locations, functions, organizational units, activities and processes covered, and the time period.", SeeAlso: []string{"audit programme"}},
+	{Term: "competence", Definition: "Ability to apply knowledge and skills to achieve intended results.", Note: "Demonstrated competence is sometimes referred to as qualification. Training records alone do not demonstrate competence.", NotToBe: []string{"awareness", "training"}},
+	{Term: "complaint", Definition: "Expression of dissatisfaction made to an organization, related to its product or service or the complaints-handling process itself, where a response or resolution is explicitly or implicitly expected.", SeeAlso: []string{"customer satisfaction"}},
+	{Term: "concession", Definition: "Permission to use or release a product or service that does not conform to specified requirements.", Note: "A concession is generally limited to the delivery of products and services with nonconforming characteristics within specified limits, for an agreed time or quantity.", NotToBe: []string{"deviation permit"}},
+	{Term: "conformity", Definition: "Fulfilment of a requirement.", NotToBe: []string{"compliance"}},
+	{Term: "context of the organization", Definition: "Combination of internal and external issues that can have an effect on an organization's approach to developing and achieving its objectives.", SeeAlso: []string{"interested party"}},
+	{Term: "continual improvement", Definition: "Recurring activity to enhance performance.", Note: "Continual improvement need not take place in all areas simultaneously; it differs from continuous improvement, which implies uninterrupted change.", SeeAlso: []string{"corrective action"}},
+	{Term: "correction", Definition: "Action to eliminate a detected nonconformity.", Note: "A correction can be made in advance of, in conjunction with or after a corrective action. Examples are rework and regrade.", SeeAlso: []string{"rework", "regrade"}, NotToBe: []string{"corrective action"}},
+	{Term: "corrective action", Definition: "Action to eliminate the cause of a nonconformity and to prevent recurrence.", Note: "There can be more than one cause for a nonconformity. Corrective action is taken to prevent recurrence, whereas preventive action is taken to prevent occurrence.", SeeAlso: []string{"nonconformity"}, NotToBe: []string{"correction", "preventive action"}},
+	{Term: "customer", Definition: "Person or organization that could or does receive a product or a service that is intended for or required by this person or organization.", Note: "A customer can be internal or external to the organization.", SeeAlso: []string{"interested party"}},
+	{Term: "customer satisfaction", Definition: "Customer's perception of the degree to which the customer's expectations have been fulfilled.", Note: "The absence of complaints does not necessarily indicate high customer satisfaction.", SeeAlso: []string{"complaint"}},
+	{Term: "defect", Definition: "Nonconformity related to an intended or specified use.", Note: "The distinction between defect and nonconformity is important because of its legal connotations, particularly those associated with product and service liability.", NotToBe: []string{"nonconformity"}},
+	{Term: "design and development", Definition: "Set of processes that transform requirements for an object into more detailed requirements for that object.", SeeAlso: []string{"requirement", "verification", "validation"}},
+	{Term: "deviation permit", Definition: "Permission to depart from the originally specified requirements of a product or service prior to its realization.", Note: "A deviation permit is generally given for a limited quantity or period and for a specific use.", NotToBe: []string{"concession"}},
+	{Term: "documented information", Definition: "Information required to be controlled and maintained by an organization and the medium on which it is contained.", Note: "ISO 9001:2015 uses \"maintain documented information\" for what earlier editions called documents and procedures, and \"retain documented information\" for what they called records.", SeeAlso: []string{"procedure", "record"}},
+	{Term: "effectiveness", Definition: "Extent to which planned activities are realized and planned results are achieved.", NotToBe: []string{"efficiency"}},
+	{Term: "efficiency", Definition: "Relationship between the result achieved and the resources used.", NotToBe: []string{"effectiveness"}},
+	{Term: "external provider", Definition: "Provider that is not part of the organization, such as a supplier of products or services.", Note: "ISO 9001:2015 uses external provider in place of supplier and covers outsourced processes as well as purchased products and services.", SeeAlso: []string{"outsource"}},
+	{Term: "infrastructure", Definition: "System of facilities, equipment and services needed for the operation of an organization."},
+	{Term: "interested party", Definition: "Person or organization that can affect, be affected by, or perceive itself to be affected by a decision or activity.", Note: "Also called stakeholder. Examples are customers, owners, people in the organization, providers, bankers, regulators, unions, partners and society.", SeeAlso: []string{"context of the organization", "customer"}},
+	{Term: "management system", Definition: "Set of interrelated or interacting elements of an organization to establish policies and objectives, and processes to achieve those objectives.", SeeAlso: []string{"quality management system"}},
+	{Term: "measurement", Definition: "Process to determine a value.", SeeAlso: []string{"monitoring", "measuring equipment"}},
+	{Term: "measuring equipment", Definition: "Measuring instrument, software, measurement standard, reference material or auxiliary apparatus, or a combination of them, necessary to realize a measurement process."},
+	{Term: "monitoring", Definition: "Determining the status of a system, a process, a product, a service or an activity.", Note: "Monitoring is generally a determination of status carried out at different stages or at different times.", NotToBe: []string{"measurement"}},
+	{Term: "nonconformity", Definition: "Non-fulfilment of a requirement.", Note: "Audit findings are usually graded as major or minor nonconformities, or as observations and opportunities for improvement.", SeeAlso: []string{"correction", "corrective action"}, NotToBe: []string{"defect"}},
+	{Term: "objective evidence", Definition: "Data supporting the existence or verity of something.", Note: "Objective evidence can be obtained through observation, measurement, test or other means.", SeeAlso: []string{"audit evidence"}},
+	{Term: "organization", Definition: "Person or group of people that has its own functions with responsibilities, authorities and relationships to achieve its objectives."},
+	{Term: "outsource", Definition: "Make an arrangement where an external organization performs part of an organization's function or process.", Note: "The outsourced function or process remains within the scope of the quality management system.", SeeAlso: []string{"external provider"}},
+	{Term: "preventive action", Definition: "Action to eliminate the cause of a potential nonconformity or other potential undesirable situation.", Note: "ISO 9001:2015 has no separate preventive action requirement; it is addressed through actions on risks and opportunities (clause 6.1).", NotToBe: []string{"corrective action"}},
+	{Term: "procedure", Definition: "Specified way to carry out an activity or a process.", Note: "Procedures can be documented or not.", NotToBe: []string{"process"}},
+	{Term: "process", Definition: "Set of interrelated or interacting activities that use inputs to deliver an intended result.", Note: "Whether the result is called an output, a product or a service depends on the context of the reference.", NotToBe: []string{"procedure"}},
+	{Term: "product", Definition: "Output of an organization that can be produced without any transaction taking place between the organization and the customer.", SeeAlso: []string{"service"}},
+	{Term: "quality", Definition: "Degree to which a set of inherent characteristics of an object fulfils requirements.", Note: "Quality can be qualified by adjectives such as poor, good or excellent."},
+	{Term: "quality management system", Definition: "Part of a management system with regard to quality.", SeeAlso: []string{"management system"}},
+	{Term: "quality objective", Definition: "Objective related to quality.", Note: "Quality objectives are generally based on the quality policy and specified for relevant functions, levels and processes. ISO 9001 requires them to be measurable.", SeeAlso: []string{"quality policy"}},
+	{Term: "quality policy", Definition: "Intentions and direction of an organization related to quality as formally expressed by top management.", SeeAlso: []string{"quality objective", "top management"}},
+	{Term: "record", Definition: "Document stating results achieved or providing evidence of activities performed.", Note: "Records are not usually subject to revision control. In ISO 9001:2015 they are documented information to be retained.", SeeAlso: []string{"documented information"}},
+	{Term: "regrade", Definition: "Alteration of the grade of a nonconforming product or service in order to make it conform to requirements differing from the initial requirements.", SeeAlso: []string{"correction"}},
+	{Term: "release", Definition: "Permission to proceed to the next stage of a process or the next process."},
+	{Term: "repair", Definition: "Action on a nonconforming product or service to make it acceptable for the intended use.", Note: "Unlike rework, repair can affect or change parts of the nonconforming product or service.", NotToBe: []string{"rework"}},
+	{Term: "requirement", Definition: "Need or expectation that is stated, generally implied or obligatory.", Note: "\"Generally implied\" means it is custom or common practice for the organization, its customers and other interested parties that the need or expectation is implied."},
+	{Term: "review", Definition: "Determination of the suitability, adequacy or effectiveness of an object to achieve established objectives.", Note: "Examples are management review, design and development review and review of customer requirements."},
+	{Term: "rework", Definition: "Action on a nonconforming product or service to make it conform to the requirements.", SeeAlso: []string{"correction"}, NotToBe: []string{"repair"}},
+	{Term: "risk", Definition: "Effect of uncertainty.", Note: "An effect is a deviation from the expected, positive or negative. Risk is often expressed as a combination of the consequences of an event and the associated likelihood of occurrence.", SeeAlso: []string{"preventive action"}},
+	{Term: "scrap", Definition: "Action on a nonconforming product or service to preclude its originally intended use, for example recycling or destruction."},
+	{Term: "service", Definition: "Output of an organization with at least one activity necessarily performed between the organization and the customer.", SeeAlso: []string{"product"}},
+	{Term: "top management", Definition: "Person or group of people who directs and controls an organization at the highest level.", Note: "If the scope of the management system covers only part of an organization, top management refers to those who direct and control that part.", SeeAlso: []string{"quality policy"}},
+	{Term: "traceability", Definition: "Ability to trace the history, application or location of an object.", Note: "For a product or service this can relate to the origin of materials and parts, the processing history and the distribution and location after delivery."},
+	{Term: "validation", Definition: "Confirmation, through the provision of objective evidence, that the requirements for a specific intended use or application have been fulfilled.", NotToBe: []string{"verification"}},
+	{Term: "verification", Definition: "Confirmation, through the provision of objective evidence, that specified requirements have been fulfilled.", NotToBe: []string{"validation"}},
+	{Term: "work environment", Definition: "Set of conditions under which work is performed, including physical, social, psychological and environmental factors."},
+}
+
+// lookupGlossary returns the term matching the query exactly, ignoring case, or
+// otherwise every term whose name or definition contains it
+func lookupGlossary(query string) []GlossaryTerm {
+	query = strings.ToLower(strings.TrimSpace(query))
+	for _, term := range glossary {
+		if term.Term == query {
+			return []GlossaryTerm{term}
+		}
+	}
+
+	var inTerm, inDefinition []GlossaryTerm
+	for _, term := range glossary {
+		switch {
+		case strings.Contains(term.Term, query):
+			inTerm = append(inTerm, term)
+		case strings.Contains(strings.ToLower(term.Definition), query):
+			inDefinition = append(inDefinition, term)
+		}
+	}
+	return append(inTerm, inDefinition...)
+}
+
+// Glossary Handlers
+
+func handleGlossaryLookup(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	query, err := request.RequireString("term")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	matches := lookupGlossary(query)
+	if len(matches) == 0 {
+		terms := make([]string, len(glossary))
+		for i, term := range glossary {
+			terms[i] = term.Term
+		}
+		return mcp.NewToolResultError(fmt.Sprintf("No glossary term matches %q. Known terms: %s", query, strings.Join(terms, ", "))), nil
+	}
+
+	result, err := json.MarshalIndent(matches, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal glossary terms: %v", err)), nil
+	}
+
+	return newToolResult("glossary_term", "", fmt.Sprintf("%d glossary terms match %q", len(matches), query), json.RawMessage(result))
+}
+
+func handleGlossaryResource(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	data, err := json.Marshal(glossary)
+	if err != nil {
+		return nil, err
+	}
+
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{
+			URI:      request.Params.URI,
+			MIMEType: "application/json",
+			Text:     string(data),
+		},
+	}, nil
+}
@@ -5,6 +5,7 @@ go 1.23.0
 require (
 	github.com/example/iso9001 v0.0.0-00010101000000-000000000000
 	github.com/mark3labs/mcp-go v0.43.2
+	go.etcd.io/bbolt v1.3.10
 )
 
 require (
@@ -16,6 +17,7 @@ require (
 	github.com/spf13/cast v1.7.1 // indirect
 	github.com/wk8/go-ordered-map/v2 v2.1.8 // indirect
 	github.com/yosida95/uritemplate/v3 v3.0.2 // indirect
+	golang.org/x/sys v0.4.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
 
@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/example/iso9001"
@@ -46,20 +48,31 @@ func handleCreateOrganization(ctx context.Context, request mcp.CallToolRequest)
 		Created: time.Now(),
 		Modified: time.Now(),
 	}
+	applyCalendarDefaults(org)
+
+	err = updateTenantByID(ctx, request, id, func(tenant *iso9001.Tenant) error {
+		if tenant.Organization != nil && tenant.Organization.Name != "" {
+			return fmt.Errorf("organization with ID %s already exists", id)
+		}
+		tenant.Organization = org
+		return nil
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to create organization: %v", err)), nil
+	}
+	openWorkspace(ctx, id)
 
 	result, err := json.MarshalIndent(org, "", "  ")
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal organization: %v", err)), nil
 	}
 
-	return mcp.NewToolResultText(fmt.Sprintf("Organization created successfully:\n%s", string(result))), nil
+	recordChange(ctx, iso9001.EntityTypeOrganization, org.ID, iso9001.ChangeOperationCreated, org)
+
+	return newToolResult(iso9001.EntityTypeOrganization, org.ID, "Organization created", json.RawMessage(result))
 }
 
 func handleAddQualityPolicy(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	orgJSON, err := request.RequireString("organization_json")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Missing organization_json: %v", err)), nil
-	}
 
 	policyStatement, err := request.RequireString("policy_statement")
 	if err != nil {
@@ -76,36 +89,105 @@ func handleAddQualityPolicy(ctx context.Context, request mcp.CallToolRequest) (*
 		return mcp.NewToolResultError(fmt.Sprintf("Missing commitment: %v", err)), nil
 	}
 
-	var org iso9001.Organization
-	if err := json.Unmarshal([]byte(orgJSON), &org); err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Invalid organization JSON: %v", err)), nil
+	org, err := organizationArgument(ctx, request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
+	if org.Leadership == nil {
+		org.Leadership = &iso9001.Leadership{}
+	}
 	org.Leadership.QualityPolicy = &iso9001.QualityPolicy{
 		ID:          org.ID + "_policy",
 		Statement:   policyStatement,
 		Objectives:  objectives,
 		Commitment:  commitment,
-		Improvement: "Continuous improvement of the quality management system",
+		Improvement: request.GetString("improvement", "Continuous improvement of the quality management system"),
 		Communicated: true,
 		Available:   true,
 		Created:     time.Now(),
 		Updated:     time.Now(),
 	}
 
+	if err := storeOrganization(ctx, request, org); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to save organization: %v", err)), nil
+	}
+
 	result, err := json.MarshalIndent(org, "", "  ")
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal organization: %v", err)), nil
 	}
 
-	return mcp.NewToolResultText(fmt.Sprintf("Quality policy added successfully:\n%s", string(result))), nil
+	recordChange(ctx, iso9001.EntityTypeOrganization, org.ID, iso9001.ChangeOperationUpdated, org)
+
+	return newToolResult(iso9001.EntityTypeOrganization, org.ID, "Quality policy added", json.RawMessage(result))
 }
 
-func handleAddProcess(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	orgJSON, err := request.RequireString("organization_json")
+// qualityPolicyDraft is the policy argument of qms_adopt_quality_policy
+type qualityPolicyDraft struct {
+	Statement   string `json:"statement"`
+	Objectives  string `json:"objectives"`
+	Commitment  string `json:"commitment"`
+	Improvement string `json:"improvement"`
+}
+
+// handleAdoptQualityPolicy stores a drafted policy with handleAddQualityPolicy, warning
+// when the organization's context or objectives were missing from the workspace, so
+// that the draft could not be made appropriate to them
+func handleAdoptQualityPolicy(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var draft qualityPolicyDraft
+	if err := requireArgument(request, "policy", &draft); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	for _, element := range []struct{ value, name string }{
+		{draft.Statement, "statement"},
+		{draft.Objectives, "objectives"},
+		{draft.Commitment, "commitment"},
+		{draft.Improvement, "improvement"},
+	} {
+		if strings.TrimSpace(element.value) == "" {
+			return mcp.NewToolResultError(fmt.Sprintf("Policy has no %s; clause 5.2.1 requires it", element.name)), nil
+		}
+	}
+
+	var warnings []string
+	err := viewTenant(ctx, request, requestTenant(ctx, request), func(tenant *iso9001.Tenant) error {
+		org := tenant.Organization
+		if org.Context == nil || len(org.Context.ExternalIssues)+len(org.Context.InternalIssues) == 0 {
+			warnings = append(warnings, "No external or internal issues recorded: check the policy is appropriate to the context of the organization (clause 4.1)")
+		}
+		if org.Context == nil || len(org.Context.InterestedParties) == 0 {
+			warnings = append(warnings, "No interested parties recorded: check the policy covers their relevant requirements (clause 4.2)")
+		}
+		if len(workspaceObjectives(tenant)) == 0 {
+			warnings = append(warnings, "No quality objectives recorded: set objectives consistent with the policy (clause 6.2)")
+		}
+		return nil
+	})
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Missing organization_json: %v", err)), nil
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	arguments := make(map[string]any, len(request.GetArguments())+4)
+	for name, value := range request.GetArguments() {
+		if name != "policy" && name != "policy_json" {
+			arguments[name] = value
+		}
 	}
+	arguments["policy_statement"] = draft.Statement
+	arguments["objectives"] = draft.Objectives
+	arguments["commitment"] = draft.Commitment
+	arguments["improvement"] = draft.Improvement
+	request.Params.Arguments = arguments
+
+	result, err := handleAddQualityPolicy(ctx, request)
+	if err != nil {
+		return result, err
+	}
+	return withWarnings(result, warnings...)
+}
+
+func handleAddProcess(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 
 	processID, err := request.RequireString("process_id")
 	if err != nil {
@@ -122,9 +204,9 @@ func handleAddProcess(ctx context.Context, request mcp.CallToolRequest) (*mcp.Ca
 		return mcp.NewToolResultError(fmt.Sprintf("Missing description: %v", err)), nil
 	}
 
-	var org iso9001.Organization
-	if err := json.Unmarshal([]byte(orgJSON), &org); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal organization: %v", err)
+	org, err := organizationArgument(ctx, request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
 	process := iso9001.Process{
@@ -142,14 +224,23 @@ func handleAddProcess(ctx context.Context, request mcp.CallToolRequest) (*mcp.Ca
 		Created:     time.Now(),
 	}
 
+	if org.QMS == nil {
+		org.QMS = &iso9001.QualityManagementSystem{ID: org.ID + "_qms", Created: time.Now()}
+	}
 	org.QMS.Processes = append(org.QMS.Processes, process)
 
+	if err := storeOrganization(ctx, request, org); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to save organization: %v", err)), nil
+	}
+
 	result, err := json.MarshalIndent(org, "", "  ")
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal organization: %v", err)), nil
 	}
 
-	return mcp.NewToolResultText(fmt.Sprintf("Process added successfully:\n%s", string(result))), nil
+	recordChange(ctx, iso9001.EntityTypeProcess, process.ID, iso9001.ChangeOperationCreated, process)
+
+	return newToolResult(iso9001.EntityTypeOrganization, org.ID, fmt.Sprintf("Process %s added", process.ID), json.RawMessage(result))
 }
 
 // Risk Management Handlers
@@ -160,52 +251,33 @@ func handleIdentifyRisk(ctx context.Context, request mcp.CallToolRequest) (*mcp.
 		return mcp.NewToolResultError(fmt.Sprintf("Missing description: %v", err)), nil
 	}
 
-	causesJSON := ""
-	effectsJSON := ""
-	if args, ok := request.Params.Arguments.(map[string]interface{}); ok {
-		if causes, exists := args["causes"]; exists {
-			if causesStr, ok := causes.(string); ok {
-				causesJSON = causesStr
-			}
-		}
-		if effects, exists := args["effects"]; exists {
-			if effectsStr, ok := effects.(string); ok {
-				effectsJSON = effectsStr
-			}
-		}
-	}
-
-	riskManager := iso9001.NewRiskManager()
-
 	risk := &iso9001.Risk{
-		ID:          fmt.Sprintf("RISK-%d", time.Now().Unix()),
 		Description: description,
 	}
 
-	if causesJSON != "" {
-		var causes []string
-		if err := json.Unmarshal([]byte(causesJSON), &causes); err == nil {
-			risk.Causes = causes
-		}
+	if _, err := decodeArgument(request, "causes", &risk.Causes); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
 	}
-
-	if effectsJSON != "" {
-		var effects []string
-		if err := json.Unmarshal([]byte(effectsJSON), &effects); err == nil {
-			risk.Effects = effects
-		}
-	}
-
-	if err := riskManager.IdentifyRisk(risk); err != nil {
-		return nil, fmt.Errorf("failed to identify risk: %v", err)
+	if _, err := decodeArgument(request, "effects", &risk.Effects); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	result, err := json.Marshal(risk)
+	var result []byte
+	tenantID, err := updateTenant(ctx, request, func(tenant *iso9001.Tenant) error {
+		risk.ID = nextTenantID(tenant, "RISK")
+		if err := tenant.Risks.IdentifyRisk(risk); err != nil {
+			return err
+		}
+		result, err = json.Marshal(risk)
+		return err
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal risk: %v", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to identify risk: %v", err)), nil
 	}
 
-	return mcp.NewToolResultText(fmt.Sprintf("Risk identified successfully:\n%s", string(result))), nil
+	recordChange(ctx, iso9001.EntityTypeRisk, risk.ID, iso9001.ChangeOperationCreated, risk)
+
+	return newToolResult(iso9001.EntityTypeRisk, risk.ID, fmt.Sprintf("Risk identified in organization %s", tenantID), json.RawMessage(result))
 }
 
 func handleAssessRisk(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -224,29 +296,33 @@ func handleAssessRisk(ctx context.Context, request mcp.CallToolRequest) (*mcp.Ca
 		return mcp.NewToolResultError(fmt.Sprintf("Missing impact: %v", err)), nil
 	}
 
-	riskManager := iso9001.NewRiskManager()
-
-	// For demonstration, we'll create a risk first if it doesn't exist
-	risk := &iso9001.Risk{
-		ID:          riskID,
-		Description: "Risk to be assessed",
-	}
-	riskManager.IdentifyRisk(risk)
-
 	likelihood := parseRiskLevel(likelihoodStr)
 	impact := parseRiskLevel(impactStr)
 
-	if err := riskManager.AssessRisk(riskID, likelihood, impact); err != nil {
-		return nil, fmt.Errorf("failed to assess risk: %v", err)
-	}
+	reviewDays := request.GetInt("review_frequency_days", -1)
 
-	risk = riskManager.Risks[riskID]
-	result, err := json.Marshal(risk)
+	var risk *iso9001.Risk
+	var result []byte
+	_, err = updateTenant(ctx, request, func(tenant *iso9001.Tenant) error {
+		if reviewDays >= 0 {
+			if err := tenant.Risks.SetReviewFrequency(riskID, time.Duration(reviewDays)*24*time.Hour); err != nil {
+				return err
+			}
+		}
+		if err := tenant.Risks.AssessRisk(riskID, likelihood, impact); err != nil {
+			return err
+		}
+		risk = tenant.Risks.Risks[riskID]
+		result, err = json.Marshal(risk)
+		return err
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal risk: %v", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to assess risk: %v", err)), nil
 	}
 
-	return mcp.NewToolResultText(fmt.Sprintf("Risk identified successfully:\n%s", string(result))), nil
+	recordChange(ctx, iso9001.EntityTypeRisk, risk.ID, iso9001.ChangeOperationUpdated, risk)
+
+	return newToolResult(iso9001.EntityTypeRisk, risk.ID, "Risk assessed", json.RawMessage(result))
 }
 
 func handleMitigateRisk(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -255,29 +331,28 @@ func handleMitigateRisk(ctx context.Context, request mcp.CallToolRequest) (*mcp.
 		return mcp.NewToolResultError(fmt.Sprintf("Missing risk_id: %v", err)), nil
 	}
 
-	actionsJSON, err := request.RequireString("actions_json")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Missing actions_json: %v", err)), nil
-	}
-
-	riskManager := iso9001.NewRiskManager()
-
 	var actions []iso9001.Action
-	if err := json.Unmarshal([]byte(actionsJSON), &actions); err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Invalid actions JSON: %v", err)), nil
+	if err := requireArgument(request, "actions", &actions); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	if err := riskManager.MitigateRisk(riskID, actions); err != nil {
+	var risk *iso9001.Risk
+	var result []byte
+	_, err = updateTenant(ctx, request, func(tenant *iso9001.Tenant) error {
+		if err := tenant.Risks.MitigateRisk(riskID, actions); err != nil {
+			return err
+		}
+		risk = tenant.Risks.Risks[riskID]
+		result, err = json.MarshalIndent(risk, "", "  ")
+		return err
+	})
+	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to mitigate risk: %v", err)), nil
 	}
 
-	risk := riskManager.Risks[riskID]
-	result, err := json.MarshalIndent(risk, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal risk: %v", err)), nil
-	}
+	recordChange(ctx, iso9001.EntityTypeRisk, risk.ID, iso9001.ChangeOperationUpdated, risk)
 
-	return mcp.NewToolResultText(fmt.Sprintf("Risk mitigation added successfully:\n%s", string(result))), nil
+	return newToolResult(iso9001.EntityTypeRisk, risk.ID, "Risk mitigation added", json.RawMessage(result))
 }
 
 // Audit Handlers
@@ -314,21 +389,27 @@ func handleCreateAudit(ctx context.Context, request mcp.CallToolRequest) (*mcp.C
 		Auditees: []iso9001.AuditParticipant{},
 		Findings: []iso9001.AuditFinding{},
 		Recommendations: []iso9001.AuditRecommendation{},
-		Status:   iso9001.AuditStatusPlanned,
-		Created:  time.Now(),
-		Modified: time.Now(),
 	}
 
-	result, err := json.Marshal(audit)
+	var result []byte
+	tenantID, err := updateTenant(ctx, request, func(tenant *iso9001.Tenant) error {
+		if err := tenant.Audits.CreateAudit(audit); err != nil {
+			return err
+		}
+		result, err = json.Marshal(audit)
+		return err
+	})
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal audit: %v", err)), nil
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to create audit: %v", err)), nil
 	}
 
-	return mcp.NewToolResultText(fmt.Sprintf("Audit created successfully:\n%s", string(result))), nil
+	recordChange(ctx, iso9001.EntityTypeAudit, audit.ID, iso9001.ChangeOperationCreated, audit)
+
+	return newToolResult(iso9001.EntityTypeAudit, audit.ID, fmt.Sprintf("Audit created in organization %s", tenantID), json.RawMessage(result))
 }
 
 func handleAddAuditFinding(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	_, err := request.RequireString("audit_id")
+	auditID, err := request.RequireString("audit_id")
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Missing audit_id: %v", err)), nil
 	}
@@ -353,23 +434,35 @@ func handleAddAuditFinding(ctx context.Context, request mcp.CallToolRequest) (*m
 		return mcp.NewToolResultError(fmt.Sprintf("Missing responsible: %v", err)), nil
 	}
 
+	severity := parseFindingSeverity(severityStr)
+
 	finding := iso9001.AuditFinding{
-		ID:          fmt.Sprintf("FINDING-%d", time.Now().Unix()),
 		Description: findingDescription,
 		Clause:      clause,
-		Severity:    parseFindingSeverity(severityStr),
+		Severity:    severity,
 		Responsible: responsible,
-		DueDate:     time.Now().AddDate(0, 0, 30), // 30 days from now
 		Status:      iso9001.FindingStatusOpen,
-		Created:     time.Now(),
 	}
 
-	result, err := json.Marshal(finding)
+	var result []byte
+	_, err = updateTenant(ctx, request, func(tenant *iso9001.Tenant) error {
+		finding.ID = nextTenantID(tenant, "FINDING")
+		finding.DueDate = tenantDueDates(tenant).DueDate(time.Now(), findingPolicy.DaysFor(severity))
+		if err := tenant.Audits.AddFinding(auditID, finding); err != nil {
+			return err
+		}
+		findings := tenant.Audits.Audits[auditID].Findings
+		finding = findings[len(findings)-1]
+		result, err = json.Marshal(finding)
+		return err
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal finding: %v", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to add finding: %v", err)), nil
 	}
 
-	return mcp.NewToolResultText(fmt.Sprintf("Risk identified successfully:\n%s", string(result))), nil
+	recordChange(ctx, iso9001.EntityTypeFinding, finding.ID, iso9001.ChangeOperationCreated, finding)
+
+	return newToolResult(iso9001.EntityTypeFinding, finding.ID, fmt.Sprintf("Finding added to audit %s", auditID), json.RawMessage(result))
 }
 
 // Documentation Handlers
@@ -418,94 +511,117 @@ func handleCreateDocument(ctx context.Context, request mcp.CallToolRequest) (*mc
 			Keywords: []string{},
 			RelatedClauses: []string{},
 		},
-		Status: iso9001.DocumentStatusDraft,
-		Versions: []iso9001.DocumentVersion{},
-		Created: time.Now(),
-		Modified: time.Now(),
 	}
 
-	result, err := json.MarshalIndent(doc, "", "  ")
+	var result []byte
+	tenantID, err := updateTenant(ctx, request, func(tenant *iso9001.Tenant) error {
+		if err := tenant.Documents.AddDocument(doc); err != nil {
+			return err
+		}
+		result, err = json.MarshalIndent(doc, "", "  ")
+		return err
+	})
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal document: %v", err)), nil
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to create document: %v", err)), nil
 	}
 
-	return mcp.NewToolResultText(fmt.Sprintf("Document created successfully:\n%s", string(result))), nil
+	recordChange(ctx, iso9001.EntityTypeDocument, doc.ID, iso9001.ChangeOperationCreated, doc)
+
+	return newToolResult(iso9001.EntityTypeDocument, doc.ID, fmt.Sprintf("Document created in organization %s", tenantID), json.RawMessage(result))
 }
 
 func handleApproveDocument(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	_, err := request.RequireString("document_id")
+	documentID, err := request.RequireString("document_id")
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Missing document_id: %v", err)), nil
 	}
 
-	approverID, err := request.RequireString("approver_id")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Missing approver_id: %v", err)), nil
-	}
-
-	approverName, err := request.RequireString("approver_name")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Missing approver_name: %v", err)), nil
-	}
-
 	role, err := request.RequireString("role")
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Missing role: %v", err)), nil
 	}
 
+	// the approver is the caller, never someone named in the arguments
+	approverID := requestIdentity(ctx)
+	if approverID == "" {
+		return mcp.NewToolResultError("Failed to approve document: the connection has no identity to approve as"), nil
+	}
 	approval := iso9001.Approval{
 		ApproverID:   approverID,
-		ApproverName: approverName,
+		ApproverName: approverID,
 		Role:         role,
 		Timestamp:    time.Now(),
 		Comments:     "Approved via MCP server",
 	}
 
-	result, err := json.Marshal(approval)
+	var result []byte
+	_, err = updateTenant(ctx, request, func(tenant *iso9001.Tenant) error {
+		if person, ok := tenant.Users.FindPerson(approverID); ok {
+			approval.ApproverName = person.Name
+		}
+		if err := tenant.Documents.ApproveDocument(documentID, approval); err != nil {
+			return err
+		}
+		result, err = json.Marshal(tenant.Documents.Documents[documentID])
+		return err
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal approval: %v", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to approve document: %v", err)), nil
 	}
 
-	return mcp.NewToolResultText(fmt.Sprintf("Risk identified successfully:\n%s", string(result))), nil
+	recordChange(ctx, iso9001.EntityTypeApproval, documentID, iso9001.ChangeOperationCreated, approval)
+
+	return newToolResult(iso9001.EntityTypeDocument, documentID, "Document approved", json.RawMessage(result))
 }
 
 // Validation Handlers
 
 func handleValidateOrganization(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	orgJSON, err := request.RequireString("organization_json")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Missing organization_json: %v", err)), nil
-	}
 
-	var org iso9001.Organization
-	if err := json.Unmarshal([]byte(orgJSON), &org); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal organization: %v", err)
+	org, err := organizationArgument(ctx, request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	result := iso9001.ValidateOrganization(&org)
+	progress := newProgressReporter(ctx, request)
+	result := iso9001.ValidateOrganizationWithOptions(org, iso9001.ValidationOptions{Progress: progress.validation(0)}).Localize(iso9001.ParseLocale(request.GetString("language", "en")))
 
 	validationResult, err := json.Marshal(result)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal validation result: %v", err)
 	}
 
-	return mcp.NewToolResultText(fmt.Sprintf("Validation completed:\n%s", string(validationResult))), nil
+	return newToolResult("validation_result", org.ID, "Validation completed", json.RawMessage(validationResult))
 }
 
 func handleGetComplianceScore(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	orgJSON, err := request.RequireString("organization_json")
+
+	org, err := organizationArgument(ctx, request)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Missing organization_json: %v", err)), nil
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	var org iso9001.Organization
-	if err := json.Unmarshal([]byte(orgJSON), &org); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal organization: %v", err)
+	score := iso9001.GetComplianceScore(org)
+
+	return newToolResult("compliance_score", org.ID, fmt.Sprintf("Compliance Score: %.1f%%", score), map[string]float64{"score": score})
+}
+
+func handleGapAnalysis(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+	org, err := organizationArgument(ctx, request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	score := iso9001.GetComplianceScore(&org)
+	analysis := iso9001.AnalyzeGaps(org)
 
-	return mcp.NewToolResultText(fmt.Sprintf("Compliance Score: %.1f%%", score)), nil
+	var actions int
+	for _, phase := range analysis.Plan {
+		actions += len(phase.Actions)
+	}
+	return newToolResult("gap_analysis", org.ID,
+		fmt.Sprintf("%d clauses with gaps; remediation plan of %d actions in %d phases, about %.1f person-days", len(analysis.Clauses), actions, len(analysis.Plan), analysis.TotalEffortDays),
+		analysis)
 }
 
 // Utility Handlers
@@ -556,23 +672,26 @@ func handleCreateQualityObjective(ctx context.Context, request mcp.CallToolReque
 			TargetDate:  time.Now().AddDate(0, 12, 0), // 1 year from now
 			ReviewDate:  time.Now().AddDate(0, 6, 0),  // 6 months from now
 		},
-		Status:   iso9001.ObjectiveStatusPlanned,
-		Created: time.Now(),
 	}
 
-	result, err := json.Marshal(objective)
+	var result []byte
+	tenantID, err := updateTenant(ctx, request, func(tenant *iso9001.Tenant) error {
+		if err := tenant.Objectives.CreateObjective(objective); err != nil {
+			return err
+		}
+		result, err = json.Marshal(objective)
+		return err
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal objective: %v", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to create objective: %v", err)), nil
 	}
 
-	return mcp.NewToolResultText(fmt.Sprintf("Risk identified successfully:\n%s", string(result))), nil
+	recordChange(ctx, iso9001.EntityTypeObjective, objective.ID, iso9001.ChangeOperationCreated, objective)
+
+	return newToolResult(iso9001.EntityTypeObjective, objective.ID, fmt.Sprintf("Quality objective created in organization %s", tenantID), json.RawMessage(result))
 }
 
 func handleAddContextIssue(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	orgJSON, err := request.RequireString("organization_json")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Missing organization_json: %v", err)), nil
-	}
 
 	description, err := request.RequireString("description")
 	if err != nil {
@@ -589,9 +708,9 @@ func handleAddContextIssue(ctx context.Context, request mcp.CallToolRequest) (*m
 		return mcp.NewToolResultError(fmt.Sprintf("Missing impact: %v", err)), nil
 	}
 
-	var org iso9001.Organization
-	if err := json.Unmarshal([]byte(orgJSON), &org); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal organization: %v", err)
+	org, err := organizationArgument(ctx, request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
 	issue := iso9001.Issue{
@@ -603,22 +722,94 @@ func handleAddContextIssue(ctx context.Context, request mcp.CallToolRequest) (*m
 		Created:     time.Now(),
 	}
 
+	if org.Context == nil {
+		org.Context = &iso9001.OrganizationalContext{}
+	}
 	if issue.Type == iso9001.IssueTypeExternal {
 		org.Context.ExternalIssues = append(org.Context.ExternalIssues, issue)
 	} else {
 		org.Context.InternalIssues = append(org.Context.InternalIssues, issue)
 	}
 
+	if err := storeOrganization(ctx, request, org); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to save organization: %v", err)), nil
+	}
+
 	result, err := json.Marshal(org)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal organization: %v", err)
 	}
 
-	return mcp.NewToolResultText(fmt.Sprintf("Risk identified successfully:\n%s", string(result))), nil
+	recordChange(ctx, iso9001.EntityTypeOrganization, org.ID, iso9001.ChangeOperationUpdated, org)
+
+	return newToolResult(iso9001.EntityTypeOrganization, org.ID, fmt.Sprintf("%s issue %s added", issue.Type, issue.ID), json.RawMessage(result))
+}
+
+func handleAddInterestedParties(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+	var parties []iso9001.InterestedParty
+	if err := requireArgument(request, "parties", &parties); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	org, err := organizationArgument(ctx, request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	for i, party := range parties {
+		if party.Name == "" {
+			return mcp.NewToolResultError(fmt.Sprintf("Interested party %d has no name", i+1)), nil
+		}
+		parties[i].Type = strings.ToLower(strings.TrimSpace(party.Type))
+		if !iso9001.IsInterestedPartyCategory(parties[i].Type) {
+			return mcp.NewToolResultError(fmt.Sprintf("Interested party %q has unknown type %q (expected %s)",
+				party.Name, party.Type, strings.Join(iso9001.InterestedPartyCategories, ", "))), nil
+		}
+	}
+
+	if org.Context == nil {
+		org.Context = &iso9001.OrganizationalContext{}
+	}
+	added := org.Context.AddInterestedParties(parties...)
+
+	if err := storeOrganization(ctx, request, org); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to save organization: %v", err)), nil
+	}
+
+	result, err := json.Marshal(org)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal organization: %v", err)
+	}
+
+	recordChange(ctx, iso9001.EntityTypeOrganization, org.ID, iso9001.ChangeOperationUpdated, org)
+
+	return newToolResult(iso9001.EntityTypeOrganization, org.ID, fmt.Sprintf("Added %d interested parties, updated %d", added, len(parties)-added), json.RawMessage(result))
 }
 
 // Helper functions for parsing
 
+// findingPolicy sets the days allowed per finding severity, configured by -finding-due-days
+var findingPolicy = iso9001.DefaultDueDatePolicy()
+
+// strictJSON rejects unknown keys in a supplied organization when set
+var strictJSON bool
+
+// decodeOrganization parses a supplied organization, reporting unknown or misspelled keys
+// when the server runs with -strict-json
+func decodeOrganization(orgJSON string) (*iso9001.Organization, error) {
+	load := iso9001.LoadOrganizationJSON
+	if strictJSON {
+		load = iso9001.LoadOrganizationJSONStrict
+	}
+
+	org, err := load([]byte(orgJSON))
+	if err != nil {
+		return nil, fmt.Errorf("Invalid organization JSON: %v", err)
+	}
+	return org, nil
+}
+
 func parseRiskLevel(level string) iso9001.RiskLevel {
 	switch level {
 	case "very_low":
@@ -670,6 +861,37 @@ func parseFindingSeverity(severity string) iso9001.FindingSeverity {
 	}
 }
 
+// parseDueDatePolicy overrides the default policy with a list such as
+// "critical=7,major=30,default=45"
+func parseDueDatePolicy(spec string) (iso9001.DueDatePolicy, error) {
+	policy := iso9001.DefaultDueDatePolicy()
+	if spec == "" {
+		return policy, nil
+	}
+
+	for _, entry := range strings.Split(spec, ",") {
+		name, value, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok {
+			return policy, fmt.Errorf("invalid due date entry %q: expected severity=days", entry)
+		}
+		days, err := strconv.Atoi(value)
+		if err != nil || days < 0 {
+			return policy, fmt.Errorf("invalid number of days in %q", entry)
+		}
+
+		switch name {
+		case "default":
+			policy.DefaultDays = days
+		case "critical", "major", "minor", "observation":
+			policy.Days[iso9001.FindingSeverity(name)] = days
+		default:
+			return policy, fmt.Errorf("unknown finding severity %q", name)
+		}
+	}
+
+	return policy, nil
+}
+
 func parseDocumentType(docType string) iso9001.DocumentType {
 	switch docType {
 	case "policy":
@@ -1,13 +1,90 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
 	"log"
+	"net/http"
+	"os"
+	"time"
 
+	"github.com/example/iso9001"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 )
 
 func main() {
+	flag.BoolVar(&strictJSON, "strict-json", false, "Reject unknown or misspelled keys in a supplied organization")
+	timeZone := flag.String("time-zone", "UTC", "IANA time zone of organizations created without one, and of dates given to tools without a time, e.g. Europe/Berlin; see qms_set_calendar")
+	flag.BoolVar(&defaultBusinessDays, "business-days", false, "Count due date offsets in business days, skipping weekends, for organizations created without a calendar")
+	findingDueDays := flag.String("finding-due-days", "", "Days allowed per finding severity, e.g. critical=7,major=30,minor=60,observation=90,default=30")
+	flag.StringVar(&promptDir, "prompt-dir", "", "Directory of prompt templates (<prompt>.<language>.md) that override the built-in ones")
+	accessPolicyFile := flag.String("access-policy", "", "JSON file assigning QMS roles to identities; enables access control on tools")
+	flag.StringVar(&stdioIdentity, "identity", os.Getenv("ISO9001_IDENTITY"), "Identity of the local user served over -transport stdio; network clients are identified by their API key or client certificate")
+	flag.BoolVar(&readOnly, "read-only", false, "Reject tools that modify QMS data; query, report, resource and prompt capabilities stay available")
+	storeDir := flag.String("store", "", "Directory to persist organizations and their risks, audits, documents and objectives in; kept in memory when empty")
+	storeBackend := flag.String("store-backend", storeBackendFiles, "How -store persists organizations: files (a JSON file each), events (see -event-store) or bolt (an embedded BoltDB database, locked by this server)")
+	eventStore := flag.Bool("event-store", false, "Persist every change to -store as an event (RiskIdentified, FindingAdded, DocumentApproved, ...) and rebuild organizations by replaying them; same as -store-backend events")
+	webhookFile := flag.String("webhooks", "", "JSON file of webhooks to POST QMS events to (finding.added, document.approved, risk.critical, objective.overdue)")
+	flag.StringVar(&defaultOrganization, "organization", defaultOrganization, "Organization used by tools called without organization_id before a workspace is loaded")
+	var network httpOptions
+	flag.StringVar(&network.Transport, "transport", "stdio", "How clients connect: stdio, http (Streamable HTTP) or sse (HTTP with Server-Sent Events)")
+	flag.StringVar(&network.Addr, "listen", ":8080", "Address to listen on with -transport http or sse")
+	flag.StringVar(&network.Path, "http-path", "/mcp", "Endpoint of Streamable HTTP, or base path of the SSE endpoints /sse and /message")
+	flag.StringVar(&network.CertFile, "tls-cert", "", "PEM certificate to serve HTTPS with; requires -tls-key")
+	flag.StringVar(&network.KeyFile, "tls-key", "", "PEM private key of -tls-cert")
+	flag.StringVar(&network.ClientCAFile, "tls-client-ca", "", "PEM CA certificates; clients must present a certificate signed by one of them")
+	flag.DurationVar(&network.ShutdownTimeout, "shutdown-timeout", 30*time.Second, "How long open requests may take to finish when the server is stopped")
+	flag.StringVar(&network.APIKeyFile, "api-keys", "", "API key file clients of -transport http or sse authenticate with (default the keys of -store, see iso9001ctl apikey)")
+	flag.Float64Var(&network.RateLimit.Rate, "rate", 0, "Requests per second allowed per client address and per API key or client certificate; zero is unlimited")
+	flag.IntVar(&network.RateLimit.Burst, "burst", 0, "Requests a client may send at once (default the rate, at least 1)")
+	flag.IntVar(&network.RateLimit.DailyQuota, "daily-quota", 0, "Requests per day allowed per client address and per API key or client certificate; zero is unlimited")
+	flag.Parse()
+
+	if *eventStore {
+		*storeBackend = storeBackendEvents
+	}
+	if *storeBackend != storeBackendFiles && *storeDir == "" {
+		log.Fatalf("-store-backend %s requires -store", *storeBackend)
+	}
+	if *storeDir != "" {
+		if err := openTenantStore(*storeDir, *storeBackend); err != nil {
+			log.Fatalf("Invalid -store: %v", err)
+		}
+		if network.APIKeyFile == "" {
+			network.APIKeyFile = iso9001.APIKeyFile(*storeDir)
+		}
+	}
+
+	if *accessPolicyFile != "" {
+		if err := loadAccessPolicy(*accessPolicyFile); err != nil {
+			log.Fatalf("Invalid -access-policy: %v", err)
+		}
+	}
+
+	if *webhookFile != "" {
+		if err := loadWebhooks(*webhookFile); err != nil {
+			log.Fatalf("Invalid -webhooks: %v", err)
+		}
+	}
+
+	policy, err := parseDueDatePolicy(*findingDueDays)
+	if err != nil {
+		log.Fatalf("Invalid -finding-due-days: %v", err)
+	}
+	findingPolicy = policy
+
+	loc, err := time.LoadLocation(*timeZone)
+	if err != nil {
+		log.Fatalf("Invalid time zone: %v", err)
+	}
+	defaultTimeZone = loc
+
+	// Forget the workspace of a conversation when its client disconnects
+	hooks := &server.Hooks{}
+	hooks.AddOnUnregisterSession(closeWorkspace)
+
 	// Create MCP server with full capabilities
 	s := server.NewMCPServer(
 		"ISO 9001:2015 Quality Management System MCP Server",
@@ -17,6 +94,8 @@ func main() {
 		server.WithPromptCapabilities(true),
 		server.WithLogging(),
 		server.WithRecovery(),
+		server.WithToolHandlerMiddleware(withToolCall),
+		server.WithHooks(hooks),
 		server.WithInstructions("A comprehensive MCP server for ISO 9001:2015 Quality Management System operations including organization setup, risk management, audit management, documentation, and compliance validation."),
 	)
 
@@ -29,9 +108,21 @@ func main() {
 	// Initialize QMS prompts
 	setupQMSPrompts(s)
 
-	// Start the server using stdio transport
 	log.Println("Starting ISO 9001:2015 QMS MCP Server...")
-	if err := server.ServeStdio(s); err != nil {
+	if readOnly {
+		log.Println("Read-only mode: tools that modify QMS data are disabled")
+	}
+
+	// Serve one client over stdio, or any number of clients over the network
+	if network.Transport != "stdio" {
+		if err := serveHTTP(s, network); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("Server error: %v", err)
+		}
+		return
+	}
+	if err := server.ServeStdio(s, server.WithStdioContextFunc(func(ctx context.Context) context.Context {
+		return iso9001.WithActor(ctx, stdioIdentity)
+	})); err != nil {
 		log.Fatalf("Server error: %v", err)
 	}
 }
@@ -54,6 +145,249 @@ func setupQMSTools(s *server.MCPServer) {
 
 	// Utility Tools
 	setupUtilityTools(s)
+
+	// Delta Sync Tools
+	setupSyncTools(s)
+
+	// Terminology Tools
+	setupGlossaryTools(s)
+
+	// Workspace Tools
+	setupWorkspaceTools(s)
+
+	// Nonconforming Output Tools
+	setupNonconformingOutputTools(s)
+
+	// Template Tools
+	setupTemplateTools(s)
+}
+
+func setupTemplateTools(s *server.MCPServer) {
+	// List Templates Tool
+	listTemplatesTool := mcp.NewTool("qms_list_templates",
+		mcp.WithDescription("List the document and process templates of the organization, including the built-in ones, with their variables"),
+		mcp.WithString("kind",
+			mcp.Description("Only templates of this kind"),
+			mcp.Enum("document", "process"),
+		),
+		withOrganizationID(),
+	)
+
+	s.AddTool(listTemplatesTool, requirePermission(handleListTemplates, iso9001.PermissionView))
+
+	// Add Template Tool
+	addTemplateTool := mcp.NewTool("qms_add_template",
+		mcp.WithDescription("Store a document or process template for the organization; a template with the ID of a built-in one replaces it"),
+		mcp.WithObject("template",
+			mcp.Required(),
+			mcp.Properties(templateProperties),
+			mcp.AdditionalProperties(false),
+			mcp.Description(`Template, e.g. {"id":"sop","name":"SOP","kind":"document","variables":[{"name":"id"},{"name":"process_name"},{"name":"owner","default":"Quality Manager"}],"document":{"id":"{{id}}","title":"SOP {{process_name}}","type":"procedure","content":"Owner: {{owner}}"}}`),
+		),
+		withOrganizationID(),
+	)
+
+	s.AddTool(addTemplateTool, requirePermission(handleAddTemplate, iso9001.PermissionManageDocuments))
+
+	// Instantiate Template Tool
+	instantiateTemplateTool := mcp.NewTool("qms_instantiate_template",
+		mcp.WithDescription("Create a draft document or a planned process from a template, replacing {{variable}} placeholders with the given values"),
+		mcp.WithString("template_id",
+			mcp.Required(),
+			mcp.Description("ID of the template, e.g. procedure, work_instruction, quality_policy or process"),
+		),
+		mcp.WithObject("variables",
+			mcp.Description(`Variable values, e.g. {"id":"PROC-010","process_name":"Purchasing"}`),
+			mcp.AdditionalProperties(map[string]any{"type": "string"}),
+		),
+		withOrganizationID(),
+	)
+
+	s.AddTool(instantiateTemplateTool, requirePermission(handleInstantiateTemplate, iso9001.PermissionEdit))
+}
+
+func setupNonconformingOutputTools(s *server.MCPServer) {
+	// Record Nonconforming Output Tool
+	recordOutputTool := mcp.NewTool("qms_record_nonconforming_output",
+		mcp.WithDescription("Record a nonconforming product or service so it is identified and controlled (ISO 9001 clause 8.7)"),
+		mcp.WithString("description",
+			mcp.Required(),
+			mcp.Description("What does not conform"),
+		),
+		mcp.WithString("product",
+			mcp.Required(),
+			mcp.Description("Product, batch or service affected"),
+		),
+		mcp.WithNumber("quantity",
+			mcp.Description("Quantity affected"),
+		),
+		mcp.WithString("unit",
+			mcp.Description("Unit of the quantity, e.g. pcs or kg"),
+		),
+		mcp.WithString("process",
+			mcp.Description("ID of the process the nonconformity was found in"),
+		),
+		mcp.WithString("requirement",
+			mcp.Description("Requirement that is not met"),
+		),
+		mcp.WithString("detected_by",
+			mcp.Description("Who detected it; defaults to the caller"),
+		),
+		mcp.WithString("nonconformance_id",
+			mcp.Description("ID of a related nonconformance report"),
+		),
+		withOrganizationID(),
+	)
+
+	s.AddTool(recordOutputTool, requirePermission(handleRecordNonconformingOutput, iso9001.PermissionEdit))
+
+	// Request Concession Tool
+	requestConcessionTool := mcp.NewTool("qms_request_concession",
+		mcp.WithDescription("Request a concession or deviation permit to release or use a nonconforming output as is"),
+		mcp.WithString("output_id",
+			mcp.Required(),
+			mcp.Description("ID of the nonconforming output"),
+		),
+		mcp.WithString("justification",
+			mcp.Required(),
+			mcp.Description("Why the output can be accepted"),
+		),
+		mcp.WithString("required_approvers",
+			mcp.Required(),
+			mcp.Description("Comma-separated approver IDs or roles that must approve, e.g. quality_manager,customer"),
+		),
+		mcp.WithString("kind",
+			mcp.Description("concession (default) or deviation"),
+		),
+		mcp.WithString("requested_by",
+			mcp.Description("Who requests it; defaults to the caller"),
+		),
+		mcp.WithString("valid_until",
+			mcp.Description("Last day the concession may be used (YYYY-MM-DD)"),
+		),
+		withOrganizationID(),
+	)
+
+	s.AddTool(requestConcessionTool, requirePermission(handleRequestConcession, iso9001.PermissionEdit))
+
+	// Decide Concession Tool
+	decideConcessionTool := mcp.NewTool("qms_decide_concession",
+		mcp.WithDescription("Approve or reject a pending concession as one of its required approvers"),
+		mcp.WithString("output_id",
+			mcp.Required(),
+			mcp.Description("ID of the nonconforming output"),
+		),
+		mcp.WithString("approver_id",
+			mcp.Required(),
+			mcp.Description("ID of the approver"),
+		),
+		mcp.WithBoolean("approved",
+			mcp.Required(),
+			mcp.Description("true to approve, false to reject"),
+		),
+		mcp.WithString("approver_name",
+			mcp.Description("Name of the approver"),
+		),
+		mcp.WithString("role",
+			mcp.Description("Role of the approver, e.g. quality_manager or customer"),
+		),
+		mcp.WithString("comments",
+			mcp.Description("Conditions or reasons for the decision"),
+		),
+		withOrganizationID(),
+	)
+
+	s.AddTool(decideConcessionTool, requirePermission(handleDecideConcession, iso9001.PermissionEdit))
+
+	// Disposition Tool
+	dispositionTool := mcp.NewTool("qms_disposition_nonconforming_output",
+		mcp.WithDescription("Decide what happens to a nonconforming output; a concession disposition needs an approved concession"),
+		mcp.WithString("output_id",
+			mcp.Required(),
+			mcp.Description("ID of the nonconforming output"),
+		),
+		mcp.WithString("disposition",
+			mcp.Required(),
+			mcp.Description("Disposition (rework, concession, scrap, return)"),
+		),
+		mcp.WithString("rationale",
+			mcp.Description("Reason for the disposition"),
+		),
+		mcp.WithString("decided_by",
+			mcp.Description("Who decided it; defaults to the caller"),
+		),
+		mcp.WithNumber("quantity",
+			mcp.Description("Quantity the disposition applies to; defaults to the whole output"),
+		),
+		withOrganizationID(),
+	)
+
+	s.AddTool(dispositionTool, requirePermission(handleDispositionNonconformingOutput, iso9001.PermissionEdit))
+
+	// Close Nonconforming Output Tool
+	closeOutputTool := mcp.NewTool("qms_close_nonconforming_output",
+		mcp.WithDescription("Close a dispositioned nonconforming output; reworked output must be verified"),
+		mcp.WithString("output_id",
+			mcp.Required(),
+			mcp.Description("ID of the nonconforming output"),
+		),
+		mcp.WithString("verified_by",
+			mcp.Description("Who verified the reworked output against the requirements"),
+		),
+		withOrganizationID(),
+	)
+
+	s.AddTool(closeOutputTool, requirePermission(handleCloseNonconformingOutput, iso9001.PermissionEdit))
+
+	// Raise Corrective Action Tool
+	raiseActionTool := mcp.NewTool("qms_raise_corrective_action",
+		mcp.WithDescription("Link a corrective action to a nonconforming output to remove the cause of the nonconformity"),
+		mcp.WithString("output_id",
+			mcp.Required(),
+			mcp.Description("ID of the nonconforming output"),
+		),
+		mcp.WithString("description",
+			mcp.Required(),
+			mcp.Description("Corrective action to take"),
+		),
+		mcp.WithString("responsible",
+			mcp.Required(),
+			mcp.Description("Person responsible for the action"),
+		),
+		mcp.WithString("root_cause",
+			mcp.Description("Root cause the action addresses"),
+		),
+		mcp.WithString("due_date",
+			mcp.Description("Due date (YYYY-MM-DD); defaults to the default finding due days"),
+		),
+		withOrganizationID(),
+	)
+
+	s.AddTool(raiseActionTool, requirePermission(handleRaiseCorrectiveAction, iso9001.PermissionEdit))
+}
+
+func setupWorkspaceTools(s *server.MCPServer) {
+	// Load Organization Tool
+	loadOrgTool := mcp.NewTool("qms_load_organization",
+		mcp.WithDescription("Load an organization into the workspace of this conversation, so later tools work on it without passing the organization"),
+		withOrganization("Organization to load, or a workspace exported by qms_export_organization including its risks, audits, documents and objectives"),
+		mcp.WithString("organization_id",
+			mcp.Description("ID of a stored organization to switch to instead"),
+		),
+	)
+
+	s.AddTool(loadOrgTool, requirePermission(handleLoadOrganization, iso9001.PermissionEdit))
+
+	// Export Organization Tool
+	exportOrgTool := mcp.NewTool("qms_export_organization",
+		mcp.WithDescription("Export the workspace organization with its risks, audits, documents and objectives as JSON"),
+		withOrganizationID(),
+		mcp.WithBoolean("organization_only",
+			mcp.Description("Export only the organization, without the risks, audits, documents and objectives"),
+		),
+	)
+
+	s.AddTool(exportOrgTool, requirePermission(handleExportOrganization, iso9001.PermissionView))
 }
 
 func setupOrganizationTools(s *server.MCPServer) {
@@ -70,15 +404,13 @@ func setupOrganizationTools(s *server.MCPServer) {
 		),
 	)
 
-	s.AddTool(createOrgTool, handleCreateOrganization)
+	s.AddTool(createOrgTool, requirePermission(handleCreateOrganization, iso9001.PermissionEdit))
 
 	// Add Quality Policy Tool
 	addPolicyTool := mcp.NewTool("qms_add_quality_policy",
 		mcp.WithDescription("Add quality policy to an organization"),
-		mcp.WithString("organization_json",
-			mcp.Required(),
-			mcp.Description("Organization data as JSON"),
-		),
+		withOrganization("Organization to work on; defaults to the organization loaded in the workspace"),
+		withOrganizationID(),
 		mcp.WithString("policy_statement",
 			mcp.Required(),
 			mcp.Description("Quality policy statement"),
@@ -91,17 +423,37 @@ func setupOrganizationTools(s *server.MCPServer) {
 			mcp.Required(),
 			mcp.Description("Leadership commitment statement"),
 		),
+		mcp.WithString("improvement",
+			mcp.Description("Commitment to continual improvement of the QMS"),
+		),
+	)
+
+	s.AddTool(addPolicyTool, requirePermission(handleAddQualityPolicy, iso9001.PermissionEdit))
+
+	// Adopt Quality Policy Tool
+	adoptPolicyTool := mcp.NewTool("qms_adopt_quality_policy",
+		mcp.WithDescription("Store a quality policy drafted with the qms_quality_policy prompt as the organization's policy (clause 5.2), warning about context the draft could not draw on"),
+		mcp.WithObject("policy",
+			mcp.Required(),
+			mcp.Description("Drafted policy, with one entry for each element clause 5.2.1 requires"),
+			mcp.Properties(map[string]any{
+				"statement":   map[string]any{"type": "string", "description": "Policy statement, appropriate to the purpose and context of the organization (5.2.1 a)"},
+				"objectives":  map[string]any{"type": "string", "description": "Framework for setting quality objectives (5.2.1 b)"},
+				"commitment":  map[string]any{"type": "string", "description": "Commitment to satisfy applicable requirements (5.2.1 c)"},
+				"improvement": map[string]any{"type": "string", "description": "Commitment to continual improvement of the QMS (5.2.1 d)"},
+			}),
+			mcp.AdditionalProperties(false),
+		),
+		withOrganizationID(),
 	)
 
-	s.AddTool(addPolicyTool, handleAddQualityPolicy)
+	s.AddTool(adoptPolicyTool, requirePermission(handleAdoptQualityPolicy, iso9001.PermissionEdit))
 
 	// Add Process Tool
 	addProcessTool := mcp.NewTool("qms_add_process",
 		mcp.WithDescription("Add a process to the organization's QMS"),
-		mcp.WithString("organization_json",
-			mcp.Required(),
-			mcp.Description("Organization data as JSON"),
-		),
+		withOrganization("Organization to work on; defaults to the organization loaded in the workspace"),
+		withOrganizationID(),
 		mcp.WithString("process_id",
 			mcp.Required(),
 			mcp.Description("Unique identifier for the process"),
@@ -116,7 +468,7 @@ func setupOrganizationTools(s *server.MCPServer) {
 		),
 	)
 
-	s.AddTool(addProcessTool, handleAddProcess)
+	s.AddTool(addProcessTool, requirePermission(handleAddProcess, iso9001.PermissionEdit))
 }
 
 func setupRiskManagementTools(s *server.MCPServer) {
@@ -127,15 +479,18 @@ func setupRiskManagementTools(s *server.MCPServer) {
 			mcp.Required(),
 			mcp.Description("Description of the risk"),
 		),
-		mcp.WithString("causes",
-			mcp.Description("JSON array of risk causes"),
+		mcp.WithArray("causes",
+			mcp.Description("Causes of the risk"),
+			mcp.WithStringItems(),
 		),
-		mcp.WithString("effects",
-			mcp.Description("JSON array of risk effects"),
+		mcp.WithArray("effects",
+			mcp.Description("Effects of the risk"),
+			mcp.WithStringItems(),
 		),
+		withOrganizationID(),
 	)
 
-	s.AddTool(identifyRiskTool, handleIdentifyRisk)
+	s.AddTool(identifyRiskTool, requirePermission(handleIdentifyRisk, iso9001.PermissionEdit))
 
 	// Assess Risk Tool
 	assessRiskTool := mcp.NewTool("qms_assess_risk",
@@ -152,9 +507,13 @@ func setupRiskManagementTools(s *server.MCPServer) {
 			mcp.Required(),
 			mcp.Description("Risk impact level (very_low, low, medium, high, very_high)"),
 		),
+		mcp.WithNumber("review_frequency_days",
+			mcp.Description("Days between scheduled reassessments of the risk; 0 removes the schedule"),
+		),
+		withOrganizationID(),
 	)
 
-	s.AddTool(assessRiskTool, handleAssessRisk)
+	s.AddTool(assessRiskTool, requirePermission(handleAssessRisk, iso9001.PermissionEdit))
 
 	// Mitigate Risk Tool
 	mitigateRiskTool := mcp.NewTool("qms_mitigate_risk",
@@ -163,13 +522,177 @@ func setupRiskManagementTools(s *server.MCPServer) {
 			mcp.Required(),
 			mcp.Description("ID of the risk to mitigate"),
 		),
-		mcp.WithString("actions_json",
+		mcp.WithArray("actions",
+			mcp.Required(),
+			mcp.Description("Mitigation actions"),
+			mcp.Items(actionSchema),
+			mcp.MinItems(1),
+		),
+		withOrganizationID(),
+	)
+
+	s.AddTool(mitigateRiskTool, requirePermission(handleMitigateRisk, iso9001.PermissionEdit))
+
+	// Assess Residual Risk Tool
+	assessResidualRiskTool := mcp.NewTool("qms_assess_residual_risk",
+		mcp.WithDescription("Assess the likelihood and impact remaining once a risk's mitigation is in place, and check it against the risk appetite"),
+		mcp.WithString("risk_id",
+			mcp.Required(),
+			mcp.Description("ID of the assessed risk"),
+		),
+		mcp.WithString("likelihood",
+			mcp.Required(),
+			mcp.Description("Residual likelihood level (very_low, low, medium, high, very_high)"),
+		),
+		mcp.WithString("impact",
+			mcp.Required(),
+			mcp.Description("Residual impact level (very_low, low, medium, high, very_high)"),
+		),
+		withOrganizationID(),
+	)
+
+	s.AddTool(assessResidualRiskTool, requirePermission(handleAssessResidualRisk, iso9001.PermissionEdit))
+
+	// Set Risk Appetite Tool
+	setRiskAppetiteTool := mcp.NewTool("qms_set_risk_appetite",
+		mcp.WithDescription("Set the organization's risk appetite; risks whose residual exposure is above it are flagged"),
+		mcp.WithNumber("max_score",
+			mcp.Required(),
+			mcp.Description("Highest accepted residual score, likelihood x impact from 1 to 16 (default 8)"),
+		),
+		mcp.WithString("max_impact",
+			mcp.Description("Highest accepted residual impact whatever the likelihood (very_low, low, medium, high, very_high)"),
+		),
+		mcp.WithString("statement",
+			mcp.Description("Risk appetite statement approved by top management"),
+		),
+		withOrganizationID(),
+	)
+
+	s.AddTool(setRiskAppetiteTool, requirePermission(handleSetRiskAppetite, iso9001.PermissionEdit))
+
+	// Set Calendar Tool
+	setCalendarTool := mcp.NewTool("qms_set_calendar",
+		mcp.WithDescription("Set the time zone, working week and holidays the organization's due dates are counted in; arguments left out keep their current value"),
+		mcp.WithString("time_zone",
+			mcp.Description("IANA time zone, e.g. Europe/Berlin"),
+		),
+		mcp.WithBoolean("business_days",
+			mcp.Description("Count due date offsets in business days, skipping weekends and holidays, instead of calendar days"),
+		),
+		mcp.WithArray("weekend",
+			mcp.Description("Non-working weekdays, e.g. [\"friday\", \"saturday\"]; empty means Saturday and Sunday. At least one weekday must be a working day"),
+			mcp.WithStringItems(),
+		),
+		mcp.WithArray("holidays",
+			mcp.Description(`Non-working dates, replacing the current ones, e.g. [{"date":"2026-12-25","name":"Christmas Day"}]`),
+			mcp.Items(holidaySchema),
+		),
+		withOrganizationID(),
+	)
+
+	s.AddTool(setCalendarTool, requirePermission(handleSetCalendar, iso9001.PermissionEdit))
+
+	// Risk Exposure Tool
+	riskExposureTool := mcp.NewTool("qms_risk_exposure",
+		mcp.WithDescription("Report inherent and residual risk exposure and the risks still above the risk appetite"),
+		withOrganizationID(),
+	)
+
+	s.AddTool(riskExposureTool, requirePermission(handleRiskExposure, iso9001.PermissionView))
+
+	// Risks Due For Review Tool
+	risksDueForReviewTool := mcp.NewTool("qms_get_risks_due_for_review",
+		mcp.WithDescription("List risks whose scheduled review has passed, longest overdue first; they are set to overdue until reassessed with qms_assess_risk"),
+		withOrganizationID(),
+	)
+
+	s.AddTool(risksDueForReviewTool, requirePermission(handleGetRisksDueForReview, iso9001.PermissionEdit))
+
+	// Update FMEA Row Tool
+	updateFMEARowTool := mcp.NewTool("qms_update_fmea_row",
+		mcp.WithDescription("Add or update a failure mode in the FMEA worksheet of a process; the RPN (severity x occurrence x detection) is computed and rated against the worksheet thresholds"),
+		mcp.WithString("process_id",
+			mcp.Required(),
+			mcp.Description("ID of the process the worksheet belongs to"),
+		),
+		mcp.WithString("failure_mode",
+			mcp.Required(),
+			mcp.Description("How the process step can fail"),
+		),
+		mcp.WithNumber("severity",
+			mcp.Required(),
+			mcp.Description("Severity of the effect, 1 (none) to 10 (hazardous)"),
+		),
+		mcp.WithNumber("occurrence",
+			mcp.Required(),
+			mcp.Description("Likelihood of the cause, 1 (remote) to 10 (almost certain)"),
+		),
+		mcp.WithNumber("detection",
+			mcp.Required(),
+			mcp.Description("Chance that controls miss the failure, 1 (certain detection) to 10 (undetectable)"),
+		),
+		mcp.WithString("row_id",
+			mcp.Description("ID of the row to update, e.g. FM-001; omit to add a row"),
+		),
+		mcp.WithString("process_step",
+			mcp.Description("Process step or function analyzed"),
+		),
+		mcp.WithString("effect",
+			mcp.Description("Effect of the failure"),
+		),
+		mcp.WithString("cause",
+			mcp.Description("Cause of the failure"),
+		),
+		mcp.WithString("current_controls",
+			mcp.Description("Controls currently preventing or detecting the failure"),
+		),
+		mcp.WithString("recommended_action",
+			mcp.Description("Action recommended to reduce the RPN"),
+		),
+		mcp.WithString("responsible",
+			mcp.Description("Person responsible for the recommended action"),
+		),
+		mcp.WithString("due_date",
+			mcp.Description("Due date of the recommended action (YYYY-MM-DD)"),
+		),
+		mcp.WithString("risk_id",
+			mcp.Description("ID of a related risk in the risk register"),
+		),
+		mcp.WithNumber("action_threshold",
+			mcp.Description("Set the RPN from which action is required (default 100)"),
+		),
+		mcp.WithNumber("critical_threshold",
+			mcp.Description("Set the RPN from which a failure mode is critical (default 200)"),
+		),
+		mcp.WithNumber("severity_threshold",
+			mcp.Description("Set the severity from which action is required whatever the RPN (default 9)"),
+		),
+		withOrganizationID(),
+	)
+
+	s.AddTool(updateFMEARowTool, requirePermission(handleUpdateFMEARow, iso9001.PermissionEdit))
+
+	// Get FMEA Worksheet Tool
+	getFMEAWorksheetTool := mcp.NewTool("qms_get_fmea_worksheet",
+		mcp.WithDescription("Show the FMEA worksheet of a process with rows ordered by RPN and a summary; threshold arguments rate the rows against other thresholds without saving them"),
+		mcp.WithString("process_id",
 			mcp.Required(),
-			mcp.Description("JSON array of mitigation actions"),
+			mcp.Description("ID of the process"),
+		),
+		mcp.WithNumber("action_threshold",
+			mcp.Description("RPN from which action is required"),
+		),
+		mcp.WithNumber("critical_threshold",
+			mcp.Description("RPN from which a failure mode is critical"),
+		),
+		mcp.WithNumber("severity_threshold",
+			mcp.Description("Severity from which action is required whatever the RPN"),
 		),
+		withOrganizationID(),
 	)
 
-	s.AddTool(mitigateRiskTool, handleMitigateRisk)
+	s.AddTool(getFMEAWorksheetTool, requirePermission(handleGetFMEAWorksheet, iso9001.PermissionView))
 }
 
 func setupAuditTools(s *server.MCPServer) {
@@ -192,9 +715,10 @@ func setupAuditTools(s *server.MCPServer) {
 			mcp.Required(),
 			mcp.Description("Description of audit scope"),
 		),
+		withOrganizationID(),
 	)
 
-	s.AddTool(createAuditTool, handleCreateAudit)
+	s.AddTool(createAuditTool, requirePermission(handleCreateAudit, iso9001.PermissionManageAudits))
 
 	// Add Audit Finding Tool
 	addFindingTool := mcp.NewTool("qms_add_audit_finding",
@@ -219,89 +743,594 @@ func setupAuditTools(s *server.MCPServer) {
 			mcp.Required(),
 			mcp.Description("Person responsible for corrective action"),
 		),
+		withOrganizationID(),
 	)
 
-	s.AddTool(addFindingTool, handleAddAuditFinding)
-}
+	s.AddTool(addFindingTool, requirePermission(handleAddAuditFinding, iso9001.PermissionManageAudits))
 
-func setupDocumentationTools(s *server.MCPServer) {
-	// Create Document Tool
-	createDocTool := mcp.NewTool("qms_create_document",
-		mcp.WithDescription("Create a new documented information record"),
-		mcp.WithString("id",
-			mcp.Required(),
-			mcp.Description("Unique identifier for the document"),
+	// Generate Audit Checklist Tool
+	checklistTool := mcp.NewTool("qms_generate_audit_checklist",
+		mcp.WithDescription("Generate the checklist questions of an audit from the clause library and the organization's processes, as a working paper with evidence to look for and columns for results and notes"),
+		mcp.WithString("audit_id",
+			mcp.Description("ID of an audit whose scope to use; overrides clauses, processes and exclusions"),
 		),
-		mcp.WithString("title",
-			mcp.Required(),
-			mcp.Description("Document title"),
+		mcp.WithString("clauses",
+			mcp.Description("Comma-separated clauses in scope, each with the clauses below it (e.g. 7.1, 8.4); the whole standard when empty"),
 		),
-		mcp.WithString("type",
-			mcp.Required(),
-			mcp.Description("Document type (policy, procedure, work_instruction, record, form, template, plan, report, manual)"),
+		mcp.WithString("processes",
+			mcp.Description("Comma-separated IDs of processes in scope"),
 		),
-		mcp.WithString("category",
-			mcp.Required(),
-			mcp.Description("Document category (quality_management, process_management, risk_management, training, audit, management_review, supplier, customer, calibration, nonconformance)"),
+		mcp.WithString("exclusions",
+			mcp.Description("Comma-separated clauses to leave out"),
 		),
-		mcp.WithString("content",
-			mcp.Required(),
-			mcp.Description("Document content"),
+		mcp.WithString("format",
+			mcp.Description("Output format: markdown (default) or json"),
 		),
-		mcp.WithString("author",
+		withOrganizationID(),
+	)
+
+	s.AddTool(checklistTool, requirePermission(handleGenerateAuditChecklist, iso9001.PermissionView))
+
+	// Start Audit Tool
+	startAuditTool := mcp.NewTool("qms_start_audit",
+		mcp.WithDescription("Start a planned audit"),
+		mcp.WithString("audit_id",
 			mcp.Required(),
-			mcp.Description("Document author"),
+			mcp.Description("ID of the audit"),
 		),
+		mcp.WithString("start_date",
+			mcp.Description("Date the audit started (YYYY-MM-DD or RFC 3339); today when empty"),
+		),
+		withOrganizationID(),
 	)
 
-	s.AddTool(createDocTool, handleCreateDocument)
+	s.AddTool(startAuditTool, requirePermission(handleStartAudit, iso9001.PermissionManageAudits))
 
-	// Approve Document Tool
-	approveDocTool := mcp.NewTool("qms_approve_document",
-		mcp.WithDescription("Approve a document"),
-		mcp.WithString("document_id",
+	// Complete Audit Tool
+	completeAuditTool := mcp.NewTool("qms_complete_audit",
+		mcp.WithDescription("Complete an audit in progress, optionally issuing its report. Fails while critical findings are open."),
+		mcp.WithString("audit_id",
 			mcp.Required(),
-			mcp.Description("ID of the document to approve"),
+			mcp.Description("ID of the audit"),
 		),
-		mcp.WithString("approver_id",
-			mcp.Required(),
-			mcp.Description("ID of the approver"),
+		mcp.WithString("end_date",
+			mcp.Description("Date the audit ended (YYYY-MM-DD or RFC 3339); today when empty"),
 		),
-		mcp.WithString("approver_name",
-			mcp.Required(),
-			mcp.Description("Name of the approver"),
+		mcp.WithString("summary",
+			mcp.Description("Summary of the audit report; no report is issued when empty"),
 		),
-		mcp.WithString("role",
-			mcp.Required(),
-			mcp.Description("Role of the approver"),
+		mcp.WithString("conclusions",
+			mcp.Description("Conclusions of the audit report"),
+		),
+		mcp.WithString("effectiveness",
+			mcp.Description("Assessment of the effectiveness of the audited QMS"),
 		),
+		mcp.WithString("reviewed_by",
+			mcp.Description("Who reviewed the report"),
+		),
+		mcp.WithString("approved_by",
+			mcp.Description("Who approved the report"),
+		),
+		withOrganizationID(),
 	)
 
-	s.AddTool(approveDocTool, handleApproveDocument)
-}
+	s.AddTool(completeAuditTool, requirePermission(handleCompleteAudit, iso9001.PermissionManageAudits))
 
-func setupValidationTools(s *server.MCPServer) {
-	// Validate Organization Tool
-	validateOrgTool := mcp.NewTool("qms_validate_organization",
-		mcp.WithDescription("Validate organization against ISO 9001:2015 requirements"),
-		mcp.WithString("organization_json",
+	// Close Finding Tool
+	closeFindingTool := mcp.NewTool("qms_close_finding",
+		mcp.WithDescription("Close or accept an audit finding. Closing requires its corrective actions to be verified, except for observations."),
+		mcp.WithString("finding_id",
 			mcp.Required(),
-			mcp.Description("Organization data as JSON"),
+			mcp.Description("ID of the finding"),
+		),
+		mcp.WithString("audit_id",
+			mcp.Description("ID of the audit the finding was raised in; looked up when empty"),
+		),
+		mcp.WithString("status",
+			mcp.Description("New status: closed (default) or accepted"),
 		),
+		withOrganizationID(),
 	)
 
-	s.AddTool(validateOrgTool, handleValidateOrganization)
+	s.AddTool(closeFindingTool, requirePermission(handleCloseFinding, iso9001.PermissionCloseFinding))
 
-	// Get Compliance Score Tool
-	complianceScoreTool := mcp.NewTool("qms_get_compliance_score",
-		mcp.WithDescription("Calculate ISO 9001 compliance score for an organization"),
-		mcp.WithString("organization_json",
+	// Record Root Cause Analysis Tool
+	rootCauseTool := mcp.NewTool("qms_record_root_cause_analysis",
+		mcp.WithDescription("Store the 5-Whys or fishbone analysis of an audit finding or a nonconformance as a tree of causes (clause 10.2.1 b); its root causes become the root cause of the finding or nonconformance"),
+		mcp.WithString("finding_id",
+			mcp.Description("ID of the finding; give this or nonconformance_id"),
+		),
+		mcp.WithString("audit_id",
+			mcp.Description("ID of the audit the finding was raised in; looked up when empty"),
+		),
+		mcp.WithString("nonconformance_id",
+			mcp.Description("ID of the nonconformance; give this or finding_id"),
+		),
+		mcp.WithObject("analysis",
+			mcp.Required(),
+			mcp.Properties(rootCauseAnalysisProperties),
+			mcp.AdditionalProperties(false),
+			mcp.Description(`Analysis, e.g. {"method":"five_whys","problem":"Wrong parts shipped","causes":[{"cause":"Parts picked from the wrong bin","causes":[{"cause":"Bin labels swapped","causes":[{"cause":"Labels not checked after stock moves","root_cause":true}]}]}]}`),
+		),
+		withOrganizationID(),
+	)
+
+	s.AddTool(rootCauseTool, requirePermission(handleRecordRootCauseAnalysis, iso9001.PermissionEdit))
+
+	// Audit Statistics Tool
+	auditStatsTool := mcp.NewTool("qms_get_audit_statistics",
+		mcp.WithDescription("Get counts of audits by status and of findings by severity"),
+		withOrganizationID(),
+	)
+
+	s.AddTool(auditStatsTool, requirePermission(handleGetAuditStatistics, iso9001.PermissionView))
+
+	// Overdue Findings Tool
+	overdueFindingsTool := mcp.NewTool("qms_get_overdue_findings",
+		mcp.WithDescription("List open audit findings past their due date, oldest first, with their audit and days overdue"),
+		withOrganizationID(),
+	)
+
+	s.AddTool(overdueFindingsTool, requirePermission(handleGetOverdueFindings, iso9001.PermissionView))
+
+	// Create Management Review Tool
+	createReviewTool := mcp.NewTool("qms_create_management_review",
+		mcp.WithDescription("Plan a management review (clause 9.3), with its inputs collected from the organization's records"),
+		mcp.WithString("title",
+			mcp.Required(),
+			mcp.Description("Title of the review"),
+		),
+		mcp.WithString("id",
+			mcp.Description("ID of the review; the next free MR-nnn when empty"),
+		),
+		mcp.WithString("date",
+			mcp.Description("Date of the review (YYYY-MM-DD or RFC 3339); today when empty"),
+		),
+		mcp.WithArray("attendees",
+			mcp.Description("Attendees of the review"),
+			mcp.Items(reviewAttendeeSchema),
+		),
+		mcp.WithBoolean("collect_inputs",
+			mcp.Description("Collect the review inputs as qms_collect_review_inputs does (default true)"),
+		),
+		mcp.WithString("inputs_since",
+			mcp.Description("Start of the period the inputs cover; the date of the last completed review when empty"),
+		),
+		withOrganizationID(),
+	)
+
+	s.AddTool(createReviewTool, requirePermission(handleCreateManagementReview, iso9001.PermissionManageAudits))
+
+	// Complete Management Review Tool
+	completeReviewTool := mcp.NewTool("qms_complete_management_review",
+		mcp.WithDescription("Complete a management review, recording its outputs (clause 9.3.3). Action items without an ID are numbered after the review."),
+		mcp.WithString("review_id",
 			mcp.Required(),
-			mcp.Description("Organization data as JSON"),
+			mcp.Description("ID of the review"),
+		),
+		mcp.WithObject("outputs",
+			mcp.Required(),
+			mcp.Description("Outputs of the review: decisions, improvement opportunities, QMS changes, resource needs, action items and the next review date"),
+			mcp.Properties(reviewOutputsProperties),
+			mcp.AdditionalProperties(false),
+		),
+		withOrganizationID(),
+	)
+
+	s.AddTool(completeReviewTool, requirePermission(handleCompleteManagementReview, iso9001.PermissionManageAudits))
+
+	// Collect Review Inputs Tool
+	collectInputsTool := mcp.NewTool("qms_collect_review_inputs",
+		mcp.WithDescription("Assemble the inputs of a management review (clause 9.3.2) from the organization's actions, context, feedback, objectives, measurements, findings, nonconformities, resources and opportunities"),
+		mcp.WithString("since",
+			mcp.Description("Start of the period to cover (YYYY-MM-DD or RFC 3339); the date of the last completed review when empty"),
 		),
+		withOrganizationID(),
 	)
 
-	s.AddTool(complianceScoreTool, handleGetComplianceScore)
+	s.AddTool(collectInputsTool, requirePermission(handleCollectReviewInputs, iso9001.PermissionView))
+}
+
+func setupDocumentationTools(s *server.MCPServer) {
+	// Create Document Tool
+	createDocTool := mcp.NewTool("qms_create_document",
+		mcp.WithDescription("Create a new documented information record"),
+		mcp.WithString("id",
+			mcp.Required(),
+			mcp.Description("Unique identifier for the document"),
+		),
+		mcp.WithString("title",
+			mcp.Required(),
+			mcp.Description("Document title"),
+		),
+		mcp.WithString("type",
+			mcp.Required(),
+			mcp.Description("Document type (policy, procedure, work_instruction, record, form, template, plan, report, manual)"),
+		),
+		mcp.WithString("category",
+			mcp.Required(),
+			mcp.Description("Document category (quality_management, process_management, risk_management, training, audit, management_review, supplier, customer, calibration, nonconformance)"),
+		),
+		mcp.WithString("content",
+			mcp.Required(),
+			mcp.Description("Document content"),
+		),
+		mcp.WithString("author",
+			mcp.Required(),
+			mcp.Description("Document author"),
+		),
+		withOrganizationID(),
+	)
+
+	s.AddTool(createDocTool, requirePermission(handleCreateDocument, iso9001.PermissionManageDocuments))
+
+	// Approve Document Tool
+	approveDocTool := mcp.NewTool("qms_approve_document",
+		mcp.WithDescription("Approve a document as the caller; the approver is the identity of the connection, checked against the organization's users once it has any"),
+		mcp.WithString("document_id",
+			mcp.Required(),
+			mcp.Description("ID of the document to approve"),
+		),
+		mcp.WithString("role",
+			mcp.Required(),
+			mcp.Description("Role the caller approves in"),
+		),
+		withOrganizationID(),
+	)
+
+	s.AddTool(approveDocTool, requirePermission(handleApproveDocument, iso9001.PermissionApproveDocument))
+
+	// Set Approval Workflow Tool
+	setApprovalWorkflowTool := mcp.NewTool("qms_set_approval_workflow",
+		mcp.WithDescription("Set the ordered approval stages of a document; the approver groups of a stage approve in parallel and overdue stages can be escalated"),
+		mcp.WithString("document_id",
+			mcp.Required(),
+			mcp.Description("ID of the document"),
+		),
+		mcp.WithArray("stages",
+			mcp.Required(),
+			mcp.Description(`Approval stages in order, e.g. [{"name":"technical","due_days":5,"escalate_to":["Quality Manager"],"groups":[{"name":"engineering","approvers":["Engineer"],"min_approvals":2}]},{"name":"release","groups":[{"approvers":["CEO"]}]}]`),
+			mcp.Items(approvalStageSchema),
+			mcp.MinItems(1),
+		),
+		withOrganizationID(),
+	)
+
+	s.AddTool(setApprovalWorkflowTool, requirePermission(handleSetApprovalWorkflow, iso9001.PermissionManageDocuments))
+
+	// Reject Document Tool
+	rejectDocTool := mcp.NewTool("qms_reject_document",
+		mcp.WithDescription("Reject a document in review as the caller, an approver of the current stage, returning it to draft with comments; approvals given so far no longer count"),
+		mcp.WithString("document_id",
+			mcp.Required(),
+			mcp.Description("ID of the document to reject"),
+		),
+		mcp.WithString("role",
+			mcp.Description("Role the caller rejects in"),
+		),
+		mcp.WithString("comments",
+			mcp.Required(),
+			mcp.Description("What has to change before the document can be approved"),
+		),
+		withOrganizationID(),
+	)
+
+	s.AddTool(rejectDocTool, requirePermission(handleRejectDocument, iso9001.PermissionApproveDocument))
+
+	// Delegate Approval Tool
+	delegateApprovalTool := mcp.NewTool("qms_delegate_approval",
+		mcp.WithDescription("Delegate the caller's approval authority to another person for a limited period, e.g. during leave; the delegation is saved with the organization"),
+		mcp.WithString("delegate",
+			mcp.Required(),
+			mcp.Description("ID of the person who may approve on the caller's behalf"),
+		),
+		mcp.WithString("until",
+			mcp.Required(),
+			mcp.Description("End of the delegation, as YYYY-MM-DD or an RFC 3339 timestamp"),
+		),
+		mcp.WithString("from",
+			mcp.Description("Start of the delegation, as YYYY-MM-DD or an RFC 3339 timestamp; defaults to now"),
+		),
+		mcp.WithArray("roles",
+			mcp.Description("Roles of the caller the delegation is limited to; all of them when omitted"),
+			mcp.WithStringItems(),
+		),
+		mcp.WithArray("categories",
+			mcp.Description("Document categories the delegation is limited to; all of them when omitted"),
+			mcp.WithStringItems(),
+		),
+		mcp.WithString("reason",
+			mcp.Description("Reason for the delegation, e.g. Annual leave"),
+		),
+		withOrganizationID(),
+	)
+
+	s.AddTool(delegateApprovalTool, requirePermission(handleDelegateApproval, iso9001.PermissionApproveDocument))
+
+	// Revoke Delegation Tool
+	revokeDelegationTool := mcp.NewTool("qms_revoke_delegation",
+		mcp.WithDescription("End one of the caller's delegations early; approvals already given under it remain valid"),
+		mcp.WithString("delegation_id",
+			mcp.Required(),
+			mcp.Description("ID of the delegation, e.g. DEL-001"),
+		),
+		withOrganizationID(),
+	)
+
+	s.AddTool(revokeDelegationTool, requirePermission(handleRevokeDelegation, iso9001.PermissionApproveDocument))
+
+	// Escalate Approvals Tool
+	escalateApprovalsTool := mcp.NewTool("qms_escalate_approvals",
+		mcp.WithDescription("Escalate approval stages past their due date, letting their escalation approvers approve; returns the escalations for notification"),
+		withOrganizationID(),
+	)
+
+	s.AddTool(escalateApprovalsTool, requirePermission(handleEscalateApprovals, iso9001.PermissionManageDocuments))
+
+	// Add Attachment Tool
+	addAttachmentTool := mcp.NewTool("qms_add_attachment",
+		mcp.WithDescription("Attach a file, such as a PDF, drawing or spreadsheet, to a draft document; size and SHA-256 checksum are recorded"),
+		mcp.WithString("document_id",
+			mcp.Required(),
+			mcp.Description("ID of the document to attach the file to"),
+		),
+		mcp.WithString("file_name",
+			mcp.Required(),
+			mcp.Description("File name without a directory, e.g. drawing-12.pdf"),
+		),
+		mcp.WithString("content_base64",
+			mcp.Required(),
+			mcp.Description("File content, base64 encoded"),
+		),
+		mcp.WithString("mime_type",
+			mcp.Description("MIME type; detected from the file name or content when omitted"),
+		),
+		mcp.WithString("uploaded_by",
+			mcp.Description("Person attaching the file; defaults to the caller's identity"),
+		),
+		withOrganizationID(),
+	)
+
+	s.AddTool(addAttachmentTool, requirePermission(handleAddAttachment, iso9001.PermissionManageDocuments))
+
+	// Get Attachment Tool
+	getAttachmentTool := mcp.NewTool("qms_get_attachment",
+		mcp.WithDescription("Return a document attachment as an embedded resource, verified against its checksum"),
+		mcp.WithString("document_id",
+			mcp.Required(),
+			mcp.Description("ID of the document"),
+		),
+		mcp.WithString("attachment_id",
+			mcp.Required(),
+			mcp.Description("ID of the attachment, e.g. ATT-001"),
+		),
+		withOrganizationID(),
+	)
+
+	s.AddTool(getAttachmentTool, requirePermission(handleGetAttachment, iso9001.PermissionView))
+
+	// Remove Attachment Tool
+	removeAttachmentTool := mcp.NewTool("qms_remove_attachment",
+		mcp.WithDescription("Remove an attachment from a draft document"),
+		mcp.WithString("document_id",
+			mcp.Required(),
+			mcp.Description("ID of the document"),
+		),
+		mcp.WithString("attachment_id",
+			mcp.Required(),
+			mcp.Description("ID of the attachment to remove"),
+		),
+		withOrganizationID(),
+	)
+
+	s.AddTool(removeAttachmentTool, requirePermission(handleRemoveAttachment, iso9001.PermissionManageDocuments))
+
+	// Distribute Document Tool
+	distributeDocTool := mcp.NewTool("qms_distribute_document",
+		mcp.WithDescription("Issue controlled copies of the current version of a published document to personnel (ISO 9001 clause 7.5.3.2)"),
+		mcp.WithString("document_id",
+			mcp.Required(),
+			mcp.Description("ID of the published document"),
+		),
+		mcp.WithString("recipients",
+			mcp.Required(),
+			mcp.Description("Comma-separated IDs of the people receiving a copy"),
+		),
+		withOrganizationID(),
+	)
+
+	s.AddTool(distributeDocTool, requirePermission(handleDistributeDocument, iso9001.PermissionManageDocuments))
+
+	// Acknowledge Document Tool
+	acknowledgeDocTool := mcp.NewTool("qms_acknowledge_document",
+		mcp.WithDescription("Acknowledge receipt of the latest controlled copy of a document"),
+		mcp.WithString("document_id",
+			mcp.Required(),
+			mcp.Description("ID of the document"),
+		),
+		mcp.WithString("recipient",
+			mcp.Description("Person acknowledging; defaults to the caller identity"),
+		),
+		withOrganizationID(),
+	)
+
+	s.AddTool(acknowledgeDocTool, requirePermission(handleAcknowledgeDocument, iso9001.PermissionEdit))
+
+	// Distribution Status Tool
+	distributionStatusTool := mcp.NewTool("qms_get_distribution_status",
+		mcp.WithDescription("List controlled copies awaiting acknowledgment and personnel still working from obsolete revisions"),
+		mcp.WithString("recipient",
+			mcp.Description("Only copies held by this person"),
+		),
+		withOrganizationID(),
+	)
+
+	s.AddTool(distributionStatusTool, requirePermission(handleGetDistributionStatus, iso9001.PermissionView))
+
+	// Update Document Tool
+	updateDocTool := mcp.NewTool("qms_update_document",
+		mcp.WithDescription("Update the content or metadata of a draft document as a new version, and move it between draft, review and published (ISO 9001 clause 7.5.2)"),
+		mcp.WithString("document_id",
+			mcp.Required(),
+			mcp.Description("ID of the document to update"),
+		),
+		mcp.WithString("title",
+			mcp.Description("New title"),
+		),
+		mcp.WithString("content",
+			mcp.Description("New content"),
+		),
+		mcp.WithString("owner",
+			mcp.Description("New document owner"),
+		),
+		mcp.WithString("keywords",
+			mcp.Description("Comma-separated keywords, replacing the current ones"),
+		),
+		mcp.WithString("related_clauses",
+			mcp.Description("Comma-separated ISO 9001 clauses the document addresses, e.g. 7.5,8.4"),
+		),
+		mcp.WithString("change_summary",
+			mcp.Description("What changed and why; required when the title, content or metadata change"),
+		),
+		mcp.WithBoolean("major",
+			mcp.Description("Increment the major version, e.g. 1.3 to 2.0"),
+		),
+		mcp.WithString("status",
+			mcp.Description("New status: draft, review (submit for approval) or published (release an approved document)"),
+		),
+		withOrganizationID(),
+	)
+
+	s.AddTool(updateDocTool, requirePermission(handleUpdateDocument, iso9001.PermissionManageDocuments))
+
+	// Review Document Tool
+	reviewDocTool := mcp.NewTool("qms_review_document",
+		mcp.WithDescription("Record a periodic review of a document for continuing suitability and adequacy, and schedule the next one"),
+		mcp.WithString("document_id",
+			mcp.Required(),
+			mcp.Description("ID of the reviewed document"),
+		),
+		mcp.WithString("comments",
+			mcp.Required(),
+			mcp.Description("Outcome of the review"),
+		),
+		mcp.WithString("reviewer_id",
+			mcp.Description("ID of the reviewer; defaults to the caller's identity"),
+		),
+		mcp.WithString("reviewer_name",
+			mcp.Description("Name of the reviewer"),
+		),
+		mcp.WithString("next_review_date",
+			mcp.Description("Date of the next review (YYYY-MM-DD or RFC 3339); defaults to one year from now"),
+		),
+		withOrganizationID(),
+	)
+
+	s.AddTool(reviewDocTool, requirePermission(handleReviewDocument, iso9001.PermissionManageDocuments))
+
+	// Archive Document Tool
+	archiveDocTool := mcp.NewTool("qms_archive_document",
+		mcp.WithDescription("Withdraw a document to the archive, where it is retained as a record but no longer in use"),
+		mcp.WithString("document_id",
+			mcp.Required(),
+			mcp.Description("ID of the document to archive"),
+		),
+		mcp.WithString("reason",
+			mcp.Required(),
+			mcp.Description("Why the document is withdrawn"),
+		),
+		withOrganizationID(),
+	)
+
+	s.AddTool(archiveDocTool, requirePermission(handleArchiveDocument, iso9001.PermissionManageDocuments))
+
+	// List Documents Tool
+	listDocsTool := mcp.NewTool("qms_list_documents",
+		mcp.WithDescription("List the documents of an organization with their status, version, owner and next review, optionally filtered"),
+		mcp.WithString("type",
+			mcp.Description("Only documents of this type, e.g. procedure"),
+		),
+		mcp.WithString("category",
+			mcp.Description("Only documents of this category, e.g. quality_management"),
+		),
+		mcp.WithString("status",
+			mcp.Description("Only documents in this status: draft, review, approved, published, obsolete or archived"),
+		),
+		mcp.WithString("title",
+			mcp.Description("Only documents with this title"),
+		),
+		mcp.WithString("keyword",
+			mcp.Description("Only documents with this keyword"),
+		),
+		mcp.WithString("clause",
+			mcp.Description("Only documents addressing this clause, e.g. 7.5"),
+		),
+		mcp.WithBoolean("due_for_review",
+			mcp.Description("Only documents whose periodic review is overdue"),
+		),
+		withOrganizationID(),
+	)
+
+	s.AddTool(listDocsTool, requirePermission(handleListDocuments, iso9001.PermissionView))
+
+	// Get Document Tool
+	getDocTool := mcp.NewTool("qms_get_document",
+		mcp.WithDescription("Return a document with its content, approvals, review and version history, or one earlier version of it"),
+		mcp.WithString("document_id",
+			mcp.Required(),
+			mcp.Description("ID of the document"),
+		),
+		mcp.WithString("version",
+			mcp.Description("Version to return, e.g. 1.2; defaults to the whole current document"),
+		),
+		withOrganizationID(),
+	)
+
+	s.AddTool(getDocTool, requirePermission(handleGetDocument, iso9001.PermissionView))
+}
+
+func setupValidationTools(s *server.MCPServer) {
+	// Validate Organization Tool
+	validateOrgTool := mcp.NewTool("qms_validate_organization",
+		mcp.WithDescription("Validate organization against ISO 9001:2015 requirements"),
+		withOrganization("Organization to work on; defaults to the organization loaded in the workspace"),
+		withOrganizationID(),
+		mcp.WithString("language",
+			mcp.Description("Language of the messages: en, de, fr or es (default en)"),
+		),
+	)
+
+	s.AddTool(validateOrgTool, requirePermission(handleValidateOrganization, iso9001.PermissionView))
+
+	// Get Compliance Score Tool
+	complianceScoreTool := mcp.NewTool("qms_get_compliance_score",
+		mcp.WithDescription("Calculate ISO 9001 compliance score for an organization"),
+		withOrganization("Organization to work on; defaults to the organization loaded in the workspace"),
+		withOrganizationID(),
+	)
+
+	s.AddTool(complianceScoreTool, requirePermission(handleGetComplianceScore, iso9001.PermissionView))
+
+	// Gap Analysis Tool
+	gapAnalysisTool := mcp.NewTool("qms_gap_analysis",
+		mcp.WithDescription("Analyze the gaps between an organization and ISO 9001:2015 by clause, with priority and effort estimates, and plan their remediation in phases: foundation (clauses 4-5), planning and support (6-7), operation (8), evaluation and improvement (9-10). Action owners are TBD until assigned."),
+		withOrganization("Organization to work on; defaults to the organization loaded in the workspace"),
+		withOrganizationID(),
+	)
+
+	s.AddTool(gapAnalysisTool, requirePermission(handleGapAnalysis, iso9001.PermissionView))
+
+	// Compliance Report PDF Tool
+	complianceReportPDFTool := mcp.NewTool("qms_get_compliance_report_pdf",
+		mcp.WithDescription("Render the compliance report as a PDF for management, with a cover page, score gauge, gaps by clause and recommendations. The PDF is returned as a base64 encoded resource."),
+		withOrganization("Organization to work on; defaults to the organization loaded in the workspace"),
+		withOrganizationID(),
+		mcp.WithString("language",
+			mcp.Description("Language of the findings and ratings: en, de, fr or es (default en)"),
+		),
+	)
+
+	s.AddTool(complianceReportPDFTool, requirePermission(handleGetComplianceReportPDF, iso9001.PermissionView))
 }
 
 func setupUtilityTools(s *server.MCPServer) {
@@ -332,17 +1361,16 @@ func setupUtilityTools(s *server.MCPServer) {
 			mcp.Required(),
 			mcp.Description("Target value to achieve"),
 		),
+		withOrganizationID(),
 	)
 
-	s.AddTool(createObjectiveTool, handleCreateQualityObjective)
+	s.AddTool(createObjectiveTool, requirePermission(handleCreateQualityObjective, iso9001.PermissionEdit))
 
 	// Add Context Issue Tool
 	addContextIssueTool := mcp.NewTool("qms_add_context_issue",
 		mcp.WithDescription("Add an external or internal issue to organizational context"),
-		mcp.WithString("organization_json",
-			mcp.Required(),
-			mcp.Description("Organization data as JSON"),
-		),
+		withOrganization("Organization to work on; defaults to the organization loaded in the workspace"),
+		withOrganizationID(),
 		mcp.WithString("description",
 			mcp.Required(),
 			mcp.Description("Description of the issue"),
@@ -357,7 +1385,109 @@ func setupUtilityTools(s *server.MCPServer) {
 		),
 	)
 
-	s.AddTool(addContextIssueTool, handleAddContextIssue)
+	s.AddTool(addContextIssueTool, requirePermission(handleAddContextIssue, iso9001.PermissionEdit))
+
+	// Add Interested Parties Tool
+	addPartiesTool := mcp.NewTool("qms_add_interested_parties",
+		mcp.WithDescription("Record interested parties and their requirements in the organizational context in one pass (clause 4.2)"),
+		withOrganization("Organization to work on; defaults to the organization loaded in the workspace"),
+		withOrganizationID(),
+		mcp.WithArray("parties",
+			mcp.Required(),
+			mcp.Description("Interested parties, each with name, type and requirements; parties already listed under the same name and type gain the new requirements"),
+			mcp.Items(interestedPartySchema),
+			mcp.MinItems(1),
+		),
+	)
+
+	s.AddTool(addPartiesTool, requirePermission(handleAddInterestedParties, iso9001.PermissionEdit))
+}
+
+func setupSyncTools(s *server.MCPServer) {
+	// Changes Tool
+	changesTool := mcp.NewTool("qms_changes",
+		mcp.WithDescription("List entities of the organization changed since a cursor so clients can keep local state fresh without re-reading full registries"),
+		withOrganizationID(),
+		mcp.WithString("cursor",
+			mcp.Description("Cursor returned as next_cursor by a previous call; omit to fetch all retained changes"),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description("Maximum number of changes to return (default 100)"),
+		),
+	)
+
+	s.AddTool(changesTool, requirePermission(handleChanges, iso9001.PermissionView))
+
+	// Audit Log Tool
+	auditLogTool := mcp.NewTool("qms_audit_log",
+		mcp.WithDescription("Query the organization's log of write operations since server start: who changed which entity, when, with which tool, and a summary before and after"),
+		withOrganizationID(),
+		mcp.WithString("actor",
+			mcp.Description("Only entries made by this identity"),
+		),
+		mcp.WithString("tool",
+			mcp.Description("Only entries made by this tool, e.g. qms_approve_document"),
+		),
+		mcp.WithString("entity_type",
+			mcp.Description("Only entries for this entity type, e.g. document, risk, organization"),
+		),
+		mcp.WithString("entity_id",
+			mcp.Description("Only entries for this entity"),
+		),
+		mcp.WithString("since",
+			mcp.Description("Only entries at or after this time (RFC 3339 or YYYY-MM-DD)"),
+		),
+		mcp.WithString("until",
+			mcp.Description("Only entries at or before this time (RFC 3339 or YYYY-MM-DD)"),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description("Maximum number of most recent entries to return (default 100)"),
+		),
+	)
+
+	s.AddTool(auditLogTool, requirePermission(handleAuditLog, iso9001.PermissionView))
+
+	// Audit Trail Tool
+	auditTrailTool := mcp.NewTool("qms_audit_trail",
+		mcp.WithDescription("Query the organization's tamper-evident audit trail, saved with the organization: every entity created, updated or deleted, by whom, when and with which tool. Reports whether the hash chain is intact."),
+		mcp.WithString("actor",
+			mcp.Description("Only entries made by this identity"),
+		),
+		mcp.WithString("tool",
+			mcp.Description("Only entries made by this tool, e.g. qms_add_audit_finding"),
+		),
+		mcp.WithString("entity_type",
+			mcp.Description("Only entries for this entity type, e.g. document, risk, audit, finding, organization"),
+		),
+		mcp.WithString("entity_id",
+			mcp.Description("Only entries for this entity"),
+		),
+		mcp.WithString("since",
+			mcp.Description("Only entries at or after this time (RFC 3339 or YYYY-MM-DD)"),
+		),
+		mcp.WithString("until",
+			mcp.Description("Only entries at or before this time (RFC 3339 or YYYY-MM-DD)"),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description("Maximum number of most recent entries to return (default 100)"),
+		),
+		withOrganizationID(),
+	)
+
+	s.AddTool(auditTrailTool, requirePermission(handleAuditTrail, iso9001.PermissionView))
+}
+
+func setupGlossaryTools(s *server.MCPServer) {
+	// Glossary Lookup Tool
+	glossaryTool := mcp.NewTool("qms_glossary_lookup",
+		mcp.WithDescription("Look up ISO 9000:2015 quality management terms such as nonconformity, correction or corrective action, with definitions and commonly confused terms"),
+		mcp.WithString("term",
+			mcp.Required(),
+			mcp.Description("Term to define, or a word to search for in terms and definitions"),
+		),
+	)
+
+	s.AddTool(glossaryTool, handleGlossaryLookup)
 }
 
 func setupQMSResources(s *server.MCPServer) {
@@ -385,17 +1515,86 @@ func setupQMSResources(s *server.MCPServer) {
 	templatesResource := mcp.NewResource(
 		"qms://templates",
 		"QMS Templates",
-		mcp.WithResourceDescription("Pre-defined templates for QMS documentation and processes"),
+		mcp.WithResourceDescription("Pre-defined templates for QMS documentation and processes; instantiable_templates can be created with qms_instantiate_template"),
 		mcp.WithMIMEType("application/json"),
 	)
 
 	s.AddResource(templatesResource, handleTemplatesResource)
+
+	// QMS Changes Resource
+	changesResource := mcp.NewResource(
+		"qms://changes",
+		"QMS Change Feed",
+		mcp.WithResourceDescription("Latest state of every entity of the organization in the workspace changed since server start, with the current sync cursor"),
+		mcp.WithMIMEType("application/json"),
+	)
+
+	s.AddResource(changesResource, handleChangesResource)
+
+	// ISO 9000 Glossary Resource
+	glossaryResource := mcp.NewResource(
+		"qms://glossary",
+		"ISO 9000:2015 Terms and Definitions",
+		mcp.WithResourceDescription("Quality management vocabulary used by ISO 9001, with definitions, notes and terms not to be confused"),
+		mcp.WithMIMEType("application/json"),
+	)
+
+	s.AddResource(glossaryResource, handleGlossaryResource)
+
+	// Audit Log Resource
+	auditLogResource := mcp.NewResource(
+		"qms://audit-log",
+		"QMS Audit Log",
+		mcp.WithResourceDescription("Every write operation on the organization in the workspace since server start, attributed to the identity that made it"),
+		mcp.WithMIMEType("application/json"),
+	)
+
+	s.AddResource(auditLogResource, handleAuditLogResource)
+
+	// Tenant Entity Resources
+	tenantEntityTemplate := mcp.NewResourceTemplate(
+		"qms://{tenant}/{collection}/{id}",
+		"Tenant Entity",
+		mcp.WithTemplateDescription("An entity of a tenant, e.g. qms://ACME/organizations/ACME or qms://ACME/risks/RISK-001; only tenants assigned to the caller can be read"),
+		mcp.WithTemplateMIMEType("application/json"),
+	)
+
+	s.AddResourceTemplate(tenantEntityTemplate, handleTenantEntityResource)
+
+	// Organization Resources
+	organizationTemplate := mcp.NewResourceTemplate(
+		"qms://organizations/{id}",
+		"Organization",
+		mcp.WithTemplateDescription("The organization of a tenant, with its context, leadership and QMS, read from the store"),
+		mcp.WithTemplateMIMEType("application/json"),
+	)
+
+	s.AddResourceTemplate(organizationTemplate, handleOrganizationResource)
+
+	organizationCollectionTemplate := mcp.NewResourceTemplate(
+		"qms://organizations/{id}/{collection}",
+		"Organization Collection",
+		mcp.WithTemplateDescription("All entities of a collection of an organization, e.g. qms://organizations/ACME/risks, audits, documents, objectives or management_reviews"),
+		mcp.WithTemplateMIMEType("application/json"),
+	)
+
+	s.AddResourceTemplate(organizationCollectionTemplate, handleOrganizationCollectionResource)
+
+	// Audit Findings Resource
+	auditFindingsTemplate := mcp.NewResourceTemplate(
+		"qms://audits/{id}/findings",
+		"Audit Findings",
+		mcp.WithTemplateDescription("The findings of an audit of the organization loaded in the workspace, with their corrective actions"),
+		mcp.WithTemplateMIMEType("application/json"),
+	)
+
+	s.AddResourceTemplate(auditFindingsTemplate, handleAuditFindingsResource)
 }
 
 func setupQMSPrompts(s *server.MCPServer) {
 	// QMS Implementation Prompt
 	implementationPrompt := mcp.NewPrompt("qms_implementation_guide",
-		mcp.WithPromptDescription("Guide through QMS implementation planning and execution"),
+		mcp.WithPromptDescription("Guide through QMS implementation with a roadmap built from the gaps of the workspace organization and its overdue items"),
 		mcp.WithArgument("organization_size",
 			mcp.ArgumentDescription("Size of the organization (small, medium, large)"),
 		),
@@ -405,20 +1604,130 @@ func setupQMSPrompts(s *server.MCPServer) {
 		mcp.WithArgument("timeline",
 			mcp.ArgumentDescription("Available timeline for implementation"),
 		),
+		mcp.WithArgument("organization_id",
+			mcp.ArgumentDescription("ID of the organization; defaults to the organization loaded in the workspace"),
+		),
+		mcp.WithArgument("language",
+			mcp.ArgumentDescription("Language of the guide: en, de, fr or es (default en)"),
+		),
 	)
 
 	s.AddPrompt(implementationPrompt, handleQMSImplementationPrompt)
 
 	// Audit Preparation Prompt
 	auditPrepPrompt := mcp.NewPrompt("qms_audit_preparation",
-		mcp.WithPromptDescription("Guide through audit preparation and readiness assessment"),
+		mcp.WithPromptDescription("Guide through audit preparation with the checklist of the clauses in scope and the known gaps, open findings and overdue items of the workspace organization"),
 		mcp.WithArgument("audit_type",
-			mcp.ArgumentDescription("Type of audit (internal, external, certification)"),
+			mcp.ArgumentDescription("Type of audit (internal, external, certification); taken from the audit when audit_id is given"),
 		),
 		mcp.WithArgument("scope",
-			mcp.ArgumentDescription("Audit scope and focus areas"),
+			mcp.ArgumentDescription("Audit scope and focus areas; taken from the audit when audit_id is given"),
+		),
+		mcp.WithArgument("audit_id",
+			mcp.ArgumentDescription("ID of a planned audit whose scope to prepare"),
+		),
+		mcp.WithArgument("clauses",
+			mcp.ArgumentDescription("Comma-separated clauses in scope when no audit_id is given (e.g. 7.1, 8.4); the whole standard when empty"),
+		),
+		mcp.WithArgument("organization_id",
+			mcp.ArgumentDescription("ID of the organization; defaults to the organization loaded in the workspace"),
+		),
+		mcp.WithArgument("language",
+			mcp.ArgumentDescription("Language of the guide: en, de, fr or es (default en)"),
 		),
 	)
 
 	s.AddPrompt(auditPrepPrompt, handleAuditPreparationPrompt)
+
+	// Context Analysis Prompt
+	contextAnalysisPrompt := mcp.NewPrompt("qms_context_analysis",
+		mcp.WithPromptDescription("Facilitate a PESTLE and SWOT analysis and derive external and internal issues (clause 4.1)"),
+		mcp.WithArgument("industry",
+			mcp.ArgumentDescription("Industry sector used to suggest typical factors"),
+		),
+		mcp.WithArgument("offering",
+			mcp.ArgumentDescription("Products and services the organization provides"),
+		),
+		mcp.WithArgument("language",
+			mcp.ArgumentDescription("Language of the guide: en, de, fr or es (default en)"),
+		),
+	)
+
+	s.AddPrompt(contextAnalysisPrompt, handleContextAnalysisPrompt)
+
+	// Interested Parties Prompt
+	interestedPartiesPrompt := mcp.NewPrompt("qms_interested_parties",
+		mcp.WithPromptDescription("Walk through interested parties by category, capture their requirements and record them with qms_add_interested_parties (clause 4.2)"),
+		mcp.WithArgument("industry",
+			mcp.ArgumentDescription("Industry sector used to suggest typical parties"),
+		),
+		mcp.WithArgument("offering",
+			mcp.ArgumentDescription("Products and services the organization provides"),
+		),
+		mcp.WithArgument("language",
+			mcp.ArgumentDescription("Language of the guide: en, de, fr or es (default en)"),
+		),
+	)
+
+	s.AddPrompt(interestedPartiesPrompt, handleInterestedPartiesPrompt)
+
+	// Management Review Prompt
+	managementReviewPrompt := mcp.NewPrompt("qms_management_review",
+		mcp.WithPromptDescription("Prepare a management review from the review inputs of the organization: a clause 9.3.2 agenda, discussion points per input and a skeleton of the minutes and outputs (clause 9.3.3)"),
+		mcp.WithArgument("review_id",
+			mcp.ArgumentDescription("ID of a planned review whose recorded inputs to use; inputs are collected afresh when empty"),
+		),
+		mcp.WithArgument("since",
+			mcp.ArgumentDescription("Start of the period fresh inputs cover; the date of the last completed review when empty"),
+		),
+		mcp.WithArgument("title",
+			mcp.ArgumentDescription("Title of the review"),
+		),
+		mcp.WithArgument("organization_id",
+			mcp.ArgumentDescription("ID of the organization; defaults to the organization loaded in the workspace"),
+		),
+		mcp.WithArgument("language",
+			mcp.ArgumentDescription("Language of the agenda: en, de, fr or es (default en)"),
+		),
+	)
+
+	s.AddPrompt(managementReviewPrompt, handleManagementReviewPrompt)
+
+	// Root Cause Analysis Prompt
+	rootCausePrompt := mcp.NewPrompt("qms_root_cause_analysis",
+		mcp.WithPromptDescription("Find the root cause of a problem, audit finding or nonconformance with the 5 Whys and a fishbone diagram, and record the result as a tree of causes"),
+		mcp.WithArgument("problem",
+			mcp.ArgumentDescription("Problem to analyze; taken from the finding or nonconformance when empty"),
+		),
+		mcp.WithArgument("finding_id",
+			mcp.ArgumentDescription("ID of the audit finding to analyze"),
+		),
+		mcp.WithArgument("nonconformance_id",
+			mcp.ArgumentDescription("ID of the nonconformance to analyze"),
+		),
+		mcp.WithArgument("method",
+			mcp.ArgumentDescription("five_whys or fishbone; both when empty"),
+		),
+		mcp.WithArgument("organization_id",
+			mcp.ArgumentDescription("ID of the organization; defaults to the organization loaded in the workspace"),
+		),
+		mcp.WithArgument("language",
+			mcp.ArgumentDescription("Language of the analysis: en, de, fr or es (default en)"),
+		),
+	)
+
+	s.AddPrompt(rootCausePrompt, handleRootCauseAnalysisPrompt)
+
+	// Quality Policy Prompt
+	qualityPolicyPrompt := mcp.NewPrompt("qms_quality_policy",
+		mcp.WithPromptDescription("Draft a quality policy meeting clause 5.2 from the context issues, interested parties and quality objectives of the organization, then store it with qms_adopt_quality_policy"),
+		mcp.WithArgument("organization_id",
+			mcp.ArgumentDescription("ID of the organization; defaults to the organization loaded in the workspace"),
+		),
+		mcp.WithArgument("language",
+			mcp.ArgumentDescription("Language of the draft: en, de, fr or es (default en)"),
+		),
+	)
+
+	s.AddPrompt(qualityPolicyPrompt, handleQualityPolicyPrompt)
 }
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// useTestStore points the server at a file store in a temporary directory for the
+// duration of the test
+func useTestStore(t *testing.T) {
+	t.Helper()
+	saved := tenantStore
+	t.Cleanup(func() { tenantStore = saved })
+	if err := openTenantStore(t.TempDir(), storeBackendFiles); err != nil {
+		t.Fatalf("Failed to open store: %v", err)
+	}
+}
+
+// callTool calls a tool handler with the arguments and returns the text of its result
+// and whether it is an error
+func callTool(t *testing.T, ctx context.Context, handler server.ToolHandlerFunc, name string, args map[string]any) (string, bool) {
+	t.Helper()
+	request := mcp.CallToolRequest{}
+	request.Params.Name = name
+	request.Params.Arguments = args
+	result, err := handler(ctx, request)
+	if err != nil {
+		t.Fatalf("%s failed: %v", name, err)
+	}
+	return resultText(result), result.IsError
+}
+
+// resultText returns the text content of a tool result
+func resultText(result *mcp.CallToolResult) string {
+	var text string
+	for _, content := range result.Content {
+		if content, ok := content.(mcp.TextContent); ok {
+			text += content.Text
+		}
+	}
+	return text
+}
+
+// registeredTool returns the handler a setup function registers for a tool, wrapped
+// as the server calls it
+func registeredTool(t *testing.T, setup func(s *server.MCPServer), name string) server.ToolHandlerFunc {
+	t.Helper()
+	s := server.NewMCPServer("iso9001-test", "1.0.0", server.WithToolCapabilities(false))
+	setup(s)
+	tool := s.GetTool(name)
+	if tool == nil {
+		t.Fatalf("Tool %s is not registered", name)
+	}
+	return tool.Handler
+}
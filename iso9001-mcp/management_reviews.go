@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/example/iso9001"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Management Review Handlers
+
+func handleCreateManagementReview(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	title, err := request.RequireString("title")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Missing title: %v", err)), nil
+	}
+
+	date, err := parseOptionalTime(request.GetString("date", ""))
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid date: %v", err)), nil
+	}
+	if date.IsZero() {
+		date = time.Now()
+	}
+	since, err := parseOptionalTime(request.GetString("inputs_since", ""))
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid inputs_since: %v", err)), nil
+	}
+
+	review := &iso9001.ManagementReview{
+		ID:        request.GetString("id", ""),
+		Title:     title,
+		Date:      date,
+		Attendees: []iso9001.ReviewAttendee{},
+	}
+	if _, err := decodeArgument(request, "attendees", &review.Attendees); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	var result []byte
+	tenantID, err := updateTenant(ctx, request, func(tenant *iso9001.Tenant) error {
+		if review.ID == "" {
+			review.ID = nextTenantID(tenant, "MR")
+		}
+		if request.GetBool("collect_inputs", true) {
+			review.Inputs = tenant.CollectReviewInputs(since, time.Now())
+		}
+		if err := tenant.Audits.CreateManagementReview(review); err != nil {
+			return err
+		}
+		result, err = json.MarshalIndent(review, "", "  ")
+		return err
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to create management review: %v", err)), nil
+	}
+
+	recordChange(ctx, iso9001.EntityTypeManagementReview, review.ID, iso9001.ChangeOperationCreated, json.RawMessage(result))
+
+	return newToolResult(iso9001.EntityTypeManagementReview, review.ID, fmt.Sprintf("Management review %s created in organization %s", review.ID, tenantID), json.RawMessage(result))
+}
+
+func handleCompleteManagementReview(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	reviewID, err := request.RequireString("review_id")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Missing review_id: %v", err)), nil
+	}
+
+	var outputs iso9001.ManagementReviewOutputs
+	if err := requireArgument(request, "outputs", &outputs); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	for i := range outputs.ActionItems {
+		item := &outputs.ActionItems[i]
+		if item.ID == "" {
+			item.ID = fmt.Sprintf("%s-A%d", reviewID, i+1)
+		}
+		if item.Status == "" {
+			item.Status = iso9001.ActionStatusPlanned
+		}
+	}
+
+	var result []byte
+	_, err = updateTenant(ctx, request, func(tenant *iso9001.Tenant) error {
+		if err := tenant.Audits.CompleteManagementReview(reviewID, outputs); err != nil {
+			return err
+		}
+		result, err = json.MarshalIndent(tenant.Audits.ManagementReviews[reviewID], "", "  ")
+		return err
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to complete management review: %v", err)), nil
+	}
+
+	recordChange(ctx, iso9001.EntityTypeManagementReview, reviewID, iso9001.ChangeOperationUpdated, json.RawMessage(result))
+
+	return newToolResult(iso9001.EntityTypeManagementReview, reviewID, fmt.Sprintf("Management review %s completed", reviewID), json.RawMessage(result))
+}
+
+func handleCollectReviewInputs(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	since, err := parseOptionalTime(request.GetString("since", ""))
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid since: %v", err)), nil
+	}
+
+	tenantID := requestTenant(ctx, request)
+	var inputs iso9001.ManagementReviewInputs
+	err = viewTenant(ctx, request, tenantID, func(tenant *iso9001.Tenant) error {
+		inputs = tenant.CollectReviewInputs(since, time.Now())
+		return nil
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to collect review inputs: %v", err)), nil
+	}
+
+	result, err := json.MarshalIndent(inputs, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal review inputs: %v", err)
+	}
+	return newToolResult("management_review_inputs", tenantID, fmt.Sprintf("Management review inputs of organization %s", tenantID), json.RawMessage(result))
+}
@@ -0,0 +1,242 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/example/iso9001"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Nonconforming Output Handlers
+
+func handleRecordNonconformingOutput(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	description, err := request.RequireString("description")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Missing description: %v", err)), nil
+	}
+
+	product, err := request.RequireString("product")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Missing product: %v", err)), nil
+	}
+
+	output := &iso9001.NonconformingOutput{
+		Description:      description,
+		Product:          product,
+		Quantity:         request.GetFloat("quantity", 0),
+		Unit:             request.GetString("unit", ""),
+		Process:          request.GetString("process", ""),
+		Requirement:      request.GetString("requirement", ""),
+		DetectedBy:       request.GetString("detected_by", requestIdentity(ctx)),
+		NonconformanceID: request.GetString("nonconformance_id", ""),
+	}
+
+	var result []byte
+	tenantID, err := updateTenant(ctx, request, func(tenant *iso9001.Tenant) error {
+		if err := tenant.Outputs.RecordOutput(output); err != nil {
+			return err
+		}
+		result, err = json.MarshalIndent(output, "", "  ")
+		return err
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to record nonconforming output: %v", err)), nil
+	}
+
+	recordChange(ctx, iso9001.EntityTypeNonconformingOutput, output.ID, iso9001.ChangeOperationCreated, output)
+
+	return newToolResult(iso9001.EntityTypeNonconformingOutput, output.ID, fmt.Sprintf("Nonconforming output recorded in organization %s", tenantID), json.RawMessage(result), "Segregate the output until a disposition is decided")
+}
+
+func handleRequestConcession(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	outputID, err := request.RequireString("output_id")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Missing output_id: %v", err)), nil
+	}
+
+	justification, err := request.RequireString("justification")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Missing justification: %v", err)), nil
+	}
+
+	approvers, err := request.RequireString("required_approvers")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Missing required_approvers: %v", err)), nil
+	}
+
+	validUntil, err := parseOptionalTime(request.GetString("valid_until", ""))
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid valid_until: %v", err)), nil
+	}
+
+	concession := iso9001.ConcessionRequest{
+		Kind:              iso9001.ConcessionKind(request.GetString("kind", string(iso9001.ConcessionKindConcession))),
+		Justification:     justification,
+		RequestedBy:       request.GetString("requested_by", requestIdentity(ctx)),
+		RequiredApprovers: splitList(approvers),
+		ValidUntil:        validUntil,
+	}
+
+	return updateOutput(ctx, request, outputID, "request concession", "Concession requested", func(outputs *iso9001.NonconformingOutputManager) error {
+		return outputs.RequestConcession(outputID, concession)
+	})
+}
+
+func handleDecideConcession(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	outputID, err := request.RequireString("output_id")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Missing output_id: %v", err)), nil
+	}
+
+	approverID, err := request.RequireString("approver_id")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Missing approver_id: %v", err)), nil
+	}
+
+	approved, err := request.RequireBool("approved")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Missing approved: %v", err)), nil
+	}
+
+	approval := iso9001.Approval{
+		ApproverID:   approverID,
+		ApproverName: request.GetString("approver_name", ""),
+		Role:         request.GetString("role", ""),
+		Timestamp:    time.Now(),
+		Comments:     request.GetString("comments", ""),
+	}
+
+	return updateOutput(ctx, request, outputID, "decide concession", "Concession decision recorded", func(outputs *iso9001.NonconformingOutputManager) error {
+		return outputs.DecideConcession(outputID, approval, approved)
+	})
+}
+
+func handleDispositionNonconformingOutput(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	outputID, err := request.RequireString("output_id")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Missing output_id: %v", err)), nil
+	}
+
+	dispositionType, err := request.RequireString("disposition")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Missing disposition: %v", err)), nil
+	}
+
+	disposition := iso9001.Disposition{
+		Type:      iso9001.DispositionType(strings.ToLower(dispositionType)),
+		Quantity:  request.GetFloat("quantity", 0),
+		Rationale: request.GetString("rationale", ""),
+		DecidedBy: request.GetString("decided_by", requestIdentity(ctx)),
+		Date:      time.Now(),
+	}
+
+	return updateOutput(ctx, request, outputID, "set disposition", "Disposition recorded", func(outputs *iso9001.NonconformingOutputManager) error {
+		return outputs.SetDisposition(outputID, disposition)
+	})
+}
+
+func handleCloseNonconformingOutput(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	outputID, err := request.RequireString("output_id")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Missing output_id: %v", err)), nil
+	}
+
+	verifiedBy := request.GetString("verified_by", "")
+	now := time.Now()
+
+	return updateOutput(ctx, request, outputID, "close nonconforming output", "Nonconforming output closed", func(outputs *iso9001.NonconformingOutputManager) error {
+		if verifiedBy != "" {
+			if err := outputs.VerifyRework(outputID, verifiedBy, now); err != nil {
+				return err
+			}
+		}
+		return outputs.CloseOutput(outputID, now)
+	})
+}
+
+func handleRaiseCorrectiveAction(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	outputID, err := request.RequireString("output_id")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Missing output_id: %v", err)), nil
+	}
+
+	description, err := request.RequireString("description")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Missing description: %v", err)), nil
+	}
+
+	responsible, err := request.RequireString("responsible")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Missing responsible: %v", err)), nil
+	}
+
+	dueDate, err := parseOptionalTime(request.GetString("due_date", ""))
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid due_date: %v", err)), nil
+	}
+
+	action := iso9001.CorrectiveAction{
+		Description: description,
+		RootCause:   request.GetString("root_cause", ""),
+		Responsible: responsible,
+		DueDate:     dueDate,
+	}
+
+	var result []byte
+	_, err = updateTenant(ctx, request, func(tenant *iso9001.Tenant) error {
+		var err error
+		if action.DueDate.IsZero() {
+			action.DueDate = tenantDueDates(tenant).DueDate(time.Now(), findingPolicy.DefaultDays)
+		}
+		if action, err = tenant.Outputs.RaiseCorrectiveAction(outputID, action); err != nil {
+			return err
+		}
+		result, err = json.MarshalIndent(action, "", "  ")
+		return err
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to raise corrective action: %v", err)), nil
+	}
+
+	recordChange(ctx, iso9001.EntityTypeCorrectiveAction, action.ID, iso9001.ChangeOperationCreated, action)
+
+	return newToolResult(iso9001.EntityTypeCorrectiveAction, action.ID, fmt.Sprintf("Corrective action raised for %s", outputID), json.RawMessage(result))
+}
+
+// updateOutput applies a change to a nonconforming output of the requested
+// organization and returns the updated output
+func updateOutput(ctx context.Context, request mcp.CallToolRequest, outputID, action, message string, change func(outputs *iso9001.NonconformingOutputManager) error) (*mcp.CallToolResult, error) {
+	var output *iso9001.NonconformingOutput
+	var result []byte
+	_, err := updateTenant(ctx, request, func(tenant *iso9001.Tenant) error {
+		if err := change(tenant.Outputs); err != nil {
+			return err
+		}
+		output = tenant.Outputs.Outputs[outputID]
+		var err error
+		result, err = json.MarshalIndent(output, "", "  ")
+		return err
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to %s: %v", action, err)), nil
+	}
+
+	recordChange(ctx, iso9001.EntityTypeNonconformingOutput, output.ID, iso9001.ChangeOperationUpdated, output)
+
+	return newToolResult(iso9001.EntityTypeNonconformingOutput, outputID, fmt.Sprintf("%s for %s", message, outputID), json.RawMessage(result))
+}
+
+// splitList splits a comma-separated argument, dropping empty entries
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
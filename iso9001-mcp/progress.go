@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/example/iso9001"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Progress Notifications
+
+// progressReporter sends MCP progress notifications for a tool call whose client asked
+// for them with a progress token. A nil reporter sends nothing.
+type progressReporter struct {
+	ctx      context.Context
+	token    mcp.ProgressToken
+	progress int
+	total    int
+}
+
+// newProgressReporter returns the reporter of a tool call, or nil when the client gave
+// no progress token
+func newProgressReporter(ctx context.Context, request mcp.CallToolRequest) *progressReporter {
+	if request.Params.Meta == nil || request.Params.Meta.ProgressToken == nil || server.ServerFromContext(ctx) == nil {
+		return nil
+	}
+	return &progressReporter{ctx: ctx, token: request.Params.Meta.ProgressToken}
+}
+
+// validation reports each clause once its rules have been checked. steps is the number
+// of steps the tool takes after the validation, counted into the total.
+func (p *progressReporter) validation(steps int) iso9001.ValidationProgress {
+	if p == nil {
+		return nil
+	}
+	return func(clause string, checked, total int) {
+		p.total = total + steps
+		p.notify(checked, fmt.Sprintf("Validated clause %s", clause))
+	}
+}
+
+// step reports that a step following the validation is done
+func (p *progressReporter) step(message string) {
+	if p == nil {
+		return
+	}
+	p.notify(p.progress+1, message)
+}
+
+func (p *progressReporter) notify(progress int, message string) {
+	p.progress = progress
+	params := map[string]any{
+		"progressToken": p.token,
+		"progress":      progress,
+		"message":       message,
+	}
+	if p.total > 0 {
+		params["total"] = p.total
+	}
+	// A client that cannot take the notification still gets the result of the call
+	_ = server.ServerFromContext(p.ctx).SendNotificationToClient(p.ctx, "notifications/progress", params)
+}
@@ -1,388 +1,573 @@
 package main
 
 import (
+	"bytes"
 	"context"
+	"embed"
+	"errors"
 	"fmt"
-
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/example/iso9001"
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
-// QMS Prompts
+// embeddedPrompts holds the built-in prompt templates, one file per prompt and
+// language named <prompt>.<language>.md
+//
+//go:embed prompts/*.md
+var embeddedPrompts embed.FS
 
-func handleQMSImplementationPrompt(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
-	orgSize := "medium"
-	industry := "general"
-	timeline := "12 months"
+// promptDir, when set, is searched for prompt templates before the built-in ones so
+// guidance can be customized without rebuilding the server
+var promptDir string
+
+// promptVariant is the description and argument defaults of a prompt in one language
+type promptVariant struct {
+	description string
+	defaults    []string // default argument values, in the order the arguments are named
+}
+
+// implementationGuides describes the QMS implementation guide by language
+var implementationGuides = map[iso9001.Locale]promptVariant{
+	iso9001.LocaleEnglish: {
+		description: "Comprehensive QMS implementation guide tailored to your organization",
+		defaults:    []string{"medium", "general", "12 months"},
+	},
+	iso9001.LocaleGerman: {
+		description: "Umfassender Leitfaden zur QMS-Einführung, zugeschnitten auf Ihre Organisation",
+		defaults:    []string{"mittelgroße", "allgemein", "12 Monate"},
+	},
+	iso9001.LocaleFrench: {
+		description: "Guide complet de mise en œuvre du SMQ adapté à votre organisme",
+		defaults:    []string{"moyenne", "général", "12 mois"},
+	},
+	iso9001.LocaleSpanish: {
+		description: "Guía completa de implantación del SGC adaptada a su organización",
+		defaults:    []string{"mediana", "general", "12 meses"},
+	},
+}
+
+// auditPreparationGuides describes the audit preparation guide by language
+var auditPreparationGuides = map[iso9001.Locale]promptVariant{
+	iso9001.LocaleEnglish: {
+		description: "Comprehensive audit preparation guide tailored to your audit type and scope",
+		defaults:    []string{"internal", "full QMS"},
+	},
+	iso9001.LocaleGerman: {
+		description: "Umfassender Leitfaden zur Auditvorbereitung, zugeschnitten auf Auditart und Umfang",
+		defaults:    []string{"internes Audit", "gesamtes QMS"},
+	},
+	iso9001.LocaleFrench: {
+		description: "Guide complet de préparation d'audit adapté au type et au périmètre de votre audit",
+		defaults:    []string{"audit interne", "SMQ complet"},
+	},
+	iso9001.LocaleSpanish: {
+		description: "Guía completa de preparación de auditorías adaptada al tipo y alcance de su auditoría",
+		defaults:    []string{"auditoría interna", "SGC completo"},
+	},
+}
 
-	if sizeArg, exists := request.Params.Arguments["organization_size"]; exists {
-		orgSize = sizeArg
+// contextAnalyses describes the facilitated PESTLE and SWOT context analysis by language
+var contextAnalyses = map[iso9001.Locale]promptVariant{
+	iso9001.LocaleEnglish: {
+		description: "Facilitated PESTLE and SWOT analysis of the context of the organization",
+		defaults:    []string{"general", "your products and services"},
+	},
+	iso9001.LocaleGerman: {
+		description: "Moderierte PESTLE- und SWOT-Analyse des Kontexts der Organisation",
+		defaults:    []string{"allgemein", "Ihren Produkten und Dienstleistungen"},
+	},
+	iso9001.LocaleFrench: {
+		description: "Analyse PESTLE et SWOT animée du contexte de l'organisme",
+		defaults:    []string{"général", "vos produits et services"},
+	},
+	iso9001.LocaleSpanish: {
+		description: "Análisis PESTLE y DAFO guiado del contexto de la organización",
+		defaults:    []string{"general", "sus productos y servicios"},
+	},
+}
+
+// interestedPartyWizards describes the interested party analysis wizard by language
+var interestedPartyWizards = map[iso9001.Locale]promptVariant{
+	iso9001.LocaleEnglish: {
+		description: "Guided identification of interested parties and their requirements",
+		defaults:    []string{"general", "products and services"},
+	},
+	iso9001.LocaleGerman: {
+		description: "Geführte Ermittlung der interessierten Parteien und ihrer Anforderungen",
+		defaults:    []string{"allgemein", "Produkte und Dienstleistungen"},
+	},
+	iso9001.LocaleFrench: {
+		description: "Identification guidée des parties intéressées et de leurs exigences",
+		defaults:    []string{"général", "produits et services"},
+	},
+	iso9001.LocaleSpanish: {
+		description: "Identificación guiada de las partes interesadas y sus requisitos",
+		defaults:    []string{"general", "productos y servicios"},
+	},
+}
+
+// managementReviewAgendas describes the management review agenda and minutes by language
+var managementReviewAgendas = map[iso9001.Locale]promptVariant{
+	iso9001.LocaleEnglish: {
+		description: "Agenda, discussion points and minutes skeleton of a management review, drawn from the review inputs of the workspace",
+		defaults:    []string{"Management review"},
+	},
+	iso9001.LocaleGerman: {
+		description: "Tagesordnung, Diskussionspunkte und Protokollgerüst einer Managementbewertung aus den Eingaben des Arbeitsbereichs",
+		defaults:    []string{"Managementbewertung"},
+	},
+	iso9001.LocaleFrench: {
+		description: "Ordre du jour, points de discussion et trame de compte rendu d'une revue de direction, établis à partir des éléments d'entrée de l'espace de travail",
+		defaults:    []string{"Revue de direction"},
+	},
+	iso9001.LocaleSpanish: {
+		description: "Orden del día, puntos de discusión y esquema de acta de una revisión por la dirección, a partir de las entradas del espacio de trabajo",
+		defaults:    []string{"Revisión por la dirección"},
+	},
+}
+
+// rootCauseAnalyses describes the root cause analysis guide by language
+var rootCauseAnalyses = map[iso9001.Locale]promptVariant{
+	iso9001.LocaleEnglish: {
+		description: "Root cause analysis of a problem, finding or nonconformance with the 5 Whys and a fishbone diagram",
+		defaults:    []string{""},
+	},
+	iso9001.LocaleGerman: {
+		description: "Ursachenanalyse eines Problems, einer Feststellung oder Nichtkonformität mit 5-Why und Ishikawa-Diagramm",
+		defaults:    []string{""},
+	},
+	iso9001.LocaleFrench: {
+		description: "Analyse des causes d'un problème, d'un constat ou d'une non-conformité par les 5 pourquoi et le diagramme d'Ishikawa",
+		defaults:    []string{""},
+	},
+	iso9001.LocaleSpanish: {
+		description: "Análisis de causa raíz de un problema, hallazgo o no conformidad con los 5 porqués y el diagrama de Ishikawa",
+		defaults:    []string{""},
+	},
+}
+
+// qualityPolicyDrafts describes the quality policy drafting prompt by language
+var qualityPolicyDrafts = map[iso9001.Locale]promptVariant{
+	iso9001.LocaleEnglish: {
+		description: "Draft a clause 5.2 quality policy from the context, interested parties and objectives of the workspace organization",
+	},
+	iso9001.LocaleGerman: {
+		description: "Entwurf einer Qualitätspolitik nach Abschnitt 5.2 aus Kontext, interessierten Parteien und Zielen der Organisation im Arbeitsbereich",
+	},
+	iso9001.LocaleFrench: {
+		description: "Rédiger une politique qualité conforme à l'article 5.2 à partir du contexte, des parties intéressées et des objectifs de l'organisme de l'espace de travail",
+	},
+	iso9001.LocaleSpanish: {
+		description: "Redactar una política de la calidad conforme al capítulo 5.2 a partir del contexto, las partes interesadas y los objetivos de la organización del espacio de trabajo",
+	},
+}
+
+// loadPromptTemplate reads the template for a prompt in the given language, preferring
+// promptDir over the built-in templates
+func loadPromptTemplate(name string, locale iso9001.Locale) (*template.Template, error) {
+	file := fmt.Sprintf("%s.%s.md", name, locale)
+	var text []byte
+	err := fs.ErrNotExist
+	if promptDir != "" {
+		text, err = os.ReadFile(filepath.Join(promptDir, file))
+	}
+	if errors.Is(err, fs.ErrNotExist) {
+		text, err = embeddedPrompts.ReadFile(path.Join("prompts", file))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("prompt template %s: %w", file, err)
 	}
+	return template.New(file).Option("missingkey=zero").Parse(string(text))
+}
 
-	if industryArg, exists := request.Params.Arguments["industry"]; exists {
-		industry = industryArg
+// renderPrompt fills in the template for the requested language, taking each named
+// argument from the request, from data or else from the variant's defaults. Templates
+// refer to the arguments by name, e.g. {{.organization_size}}, and to anything else
+// the handler puts in data, such as the state of the workspace.
+func renderPrompt(name string, variants map[iso9001.Locale]promptVariant, request mcp.GetPromptRequest, data map[string]interface{}, names ...string) (*mcp.GetPromptResult, error) {
+	locale := iso9001.ParseLocale(request.Params.Arguments["language"])
+	variant, ok := variants[locale]
+	if !ok {
+		locale = iso9001.LocaleEnglish
+		variant = variants[locale]
 	}
 
-	if timelineArg, exists := request.Params.Arguments["timeline"]; exists {
-		timeline = timelineArg
+	tmpl, err := loadPromptTemplate(name, locale)
+	if err != nil {
+		return nil, err
 	}
 
-	prompt := fmt.Sprintf(`# ISO 9001:2015 Quality Management System Implementation Guide
-
-## Organization Profile
-- **Size**: %s organization
-- **Industry**: %s
-- **Timeline**: %s
-
-## Implementation Roadmap
-
-### Phase 1: Planning and Preparation (Months 1-2)
-1. **Establish QMS Project Team**
-   - Appoint QMS Manager/Champion
-   - Form implementation team
-   - Define roles and responsibilities
-
-2. **Conduct Gap Analysis**
-   - Assess current management practices
-   - Identify gaps against ISO 9001 requirements
-   - Prioritize implementation activities
-
-3. **Develop Implementation Plan**
-   - Set realistic timelines and milestones
-   - Allocate necessary resources
-   - Define success criteria
-
-### Phase 2: QMS Design and Documentation (Months 3-6)
-1. **Understand Context (Clause 4.1)**
-   - Identify external issues (market, regulatory, competitive)
-   - Identify internal issues (organizational culture, processes)
-   - Document SWOT analysis
-
-2. **Identify Interested Parties (Clause 4.2)**
-   - List all stakeholders (customers, suppliers, employees, regulators)
-   - Determine their requirements and expectations
-   - Establish communication channels
-
-3. **Define QMS Scope (Clause 4.3)**
-   - Determine products/services within scope
-   - Identify geographical boundaries
-   - Justify any exclusions
-
-4. **Establish Quality Policy (Clause 5.2)**
-   - Develop policy statement aligned with organizational objectives
-   - Ensure commitment to compliance and improvement
-   - Communicate policy throughout organization
-
-5. **Identify Processes (Clause 4.4)**
-   - Map core business processes
-   - Identify support and management processes
-   - Define process interactions and interfaces
-
-### Phase 3: Risk Management and Objectives (Months 7-8)
-1. **Risk and Opportunity Assessment (Clause 6.1)**
-   - Identify potential risks to QMS effectiveness
-   - Assess likelihood and impact of each risk
-   - Develop mitigation strategies
-
-2. **Set Quality Objectives (Clause 6.2)**
-   - Establish measurable objectives at all levels
-   - Align objectives with quality policy
-   - Define monitoring and measurement methods
-
-### Phase 4: Resource Allocation and Training (Months 9-10)
-1. **Determine Resource Needs (Clause 7.1)**
-   - Assess personnel requirements
-   - Identify infrastructure and equipment needs
-   - Plan work environment requirements
-
-2. **Develop Competence Matrix**
-   - Identify required competencies for each role
-   - Assess current competence levels
-   - Plan training and development activities
-
-### Phase 5: Implementation and Internal Audit (Months 11-12)
-1. **Implement QMS Processes**
-   - Roll out documented procedures
-   - Train personnel on new processes
-   - Establish monitoring and measurement systems
-
-2. **Conduct Internal Audits (Clause 9.2)**
-   - Plan and schedule internal audits
-   - Train internal auditors
-   - Conduct audits and address findings
-
-### Phase 6: Certification and Continual Improvement
-1. **Management Review (Clause 9.3)**
-   - Conduct regular management reviews
-   - Assess QMS performance and effectiveness
-   - Identify improvement opportunities
-
-2. **Certification Audit**
-   - Select accredited certification body
-   - Prepare for Stage 1 and Stage 2 audits
-   - Address any nonconformities
-
-## Key Success Factors for %s Organizations
-
-### Small Organizations (<50 employees)
-- Focus on simple, practical approaches
-- Leverage existing systems where possible
-- Use external consultants for specialized areas
-- Maintain flexibility in implementation approach
-
-### Medium Organizations (50-250 employees)
-- Balance formalization with operational efficiency
-- Implement integrated management systems
-- Develop internal audit capabilities
-- Focus on measurable improvements
-
-### Large Organizations (>250 employees)
-- Implement phased rollout approach
-- Leverage existing enterprise systems
-- Develop comprehensive training programs
-- Focus on cultural change management
-
-## Industry-Specific Considerations for %s
-
-### Manufacturing
-- Emphasize process control and product quality
-- Implement statistical process control
-- Focus on supply chain quality management
-- Develop robust calibration systems
-
-### Service Industries
-- Define service quality metrics
-- Implement customer feedback systems
-- Focus on service delivery processes
-- Develop competence management systems
-
-### Technology/Software
-- Adapt requirements to agile development
-- Implement code quality and testing standards
-- Focus on documentation and traceability
-- Develop cybersecurity controls
-
-## Common Implementation Challenges
-
-1. **Resource Constraints**
-   - Solution: Prioritize high-impact activities, use consultants strategically
-
-2. **Resistance to Change**
-   - Solution: Communicate benefits, involve employees, provide training
-
-3. **Documentation Overload**
-   - Solution: Focus on value-adding documentation, use simple formats
-
-4. **Maintaining Momentum**
-   - Solution: Set achievable milestones, celebrate successes, regular reviews
-
-## Next Steps
-
-1. **Immediate Actions (Week 1)**
-   - Appoint QMS implementation team
-   - Conduct initial gap analysis
-   - Develop high-level implementation plan
-
-2. **Short-term Goals (Month 1)**
-   - Complete organizational context analysis
-   - Draft quality policy
-   - Identify key processes
-
-3. **Long-term Objectives (%s)**
-   - Achieve ISO 9001 certification
-   - Establish continual improvement culture
-   - Realize quality and efficiency benefits
-
-## Recommended Tools and Resources
-
-- ISO 9001:2015 standard documentation
-- Quality management software systems
-- Internal auditor training courses
-- External consultant support
-- Industry-specific quality guidelines
-
-Remember: ISO 9001 implementation is a journey, not a destination. Focus on adding value to your organization while meeting certification requirements.`, orgSize, industry, timeline, orgSize, industry, timeline)
+	if data == nil {
+		data = make(map[string]interface{}, len(names))
+	}
+	for i, argName := range names {
+		if value, exists := request.Params.Arguments[argName]; exists {
+			data[argName] = value
+		} else if _, exists := data[argName]; !exists {
+			data[argName] = variant.defaults[i]
+		}
+	}
+
+	var text bytes.Buffer
+	if err := tmpl.Execute(&text, data); err != nil {
+		return nil, fmt.Errorf("prompt template %s: %w", tmpl.Name(), err)
+	}
 
 	return &mcp.GetPromptResult{
-		Description: "Comprehensive QMS implementation guide tailored to your organization",
+		Description: variant.description,
 		Messages: []mcp.PromptMessage{
 			{
 				Role:    mcp.RoleUser,
-				Content: mcp.TextContent{Text: prompt},
+				Content: mcp.TextContent{Text: strings.TrimSpace(text.String())},
 			},
 		},
 	}, nil
 }
 
+// QMS Prompts
+
+// promptDeadline is an overdue item listed by a prompt
+type promptDeadline struct {
+	iso9001.Deadline
+	DaysOverdue int
+}
+
+// promptFinding is an open finding of an earlier audit listed by a prompt
+type promptFinding struct {
+	iso9001.AuditFinding
+	AuditID string
+	Overdue bool
+}
+
+// The implementation guide and the audit preparation guide are assembled from the
+// clause library and from the state of the workspace organization: its gaps, open
+// findings and overdue items. Without a workspace organization they cover the whole
+// standard.
+
+func handleQMSImplementationPrompt(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	locale := iso9001.ParseLocale(request.Params.Arguments["language"])
+	now := time.Now()
+	data := map[string]interface{}{}
+	err := workspacePromptTenant(ctx, request, func(tenant *iso9001.Tenant, recorded bool) error {
+		data["recorded"] = recorded
+		if recorded {
+			data["organization"] = tenant.Organization.Name
+			data["overdue"] = overdueDeadlines(tenant, now)
+		}
+		data["analysis"] = iso9001.AnalyzeLocalizedGaps(tenant.Organization, locale)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return renderPrompt("qms_implementation_guide", implementationGuides, request, data, "organization_size", "industry", "timeline")
+}
+
 func handleAuditPreparationPrompt(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
-	auditType := "internal"
-	scope := "full QMS"
+	locale := iso9001.ParseLocale(request.Params.Arguments["language"])
+	now := time.Now()
+	scope := iso9001.AuditScope{Clauses: splitList(request.Params.Arguments["clauses"])}
+	data := map[string]interface{}{}
+	err := workspacePromptTenant(ctx, request, func(tenant *iso9001.Tenant, recorded bool) error {
+		data["recorded"] = recorded
+		if auditID := request.Params.Arguments["audit_id"]; auditID != "" {
+			audit, ok := tenant.Audits.Audits[auditID]
+			if !ok {
+				return fmt.Errorf("audit with ID %s not found", auditID)
+			}
+			scope = audit.Scope
+			data["audit"] = audit
+			data["audit_type"] = string(audit.Type)
+			if audit.Scope.Description != "" {
+				data["scope"] = audit.Scope.Description
+			}
+		}
+
+		checklist, err := iso9001.GenerateAuditChecklist(tenant.Organization, scope)
+		if err != nil {
+			return err
+		}
+		data["clauses"] = scope.Clauses
+		data["checklist"] = checklist.Items
+		if !recorded {
+			return nil
+		}
+
+		data["organization"] = tenant.Organization.Name
+		var gaps []iso9001.ClauseGaps
+		for _, group := range iso9001.AnalyzeLocalizedGaps(tenant.Organization, locale).Clauses {
+			if clauseInScope(group.Clause, scope) {
+				gaps = append(gaps, group)
+			}
+		}
+		data["gaps"] = gaps
+
+		var findings []promptFinding
+		for _, auditID := range sortedAuditIDs(tenant.Audits) {
+			for _, finding := range tenant.Audits.Audits[auditID].Findings {
+				if finding.Status == iso9001.FindingStatusClosed || finding.Status == iso9001.FindingStatusAccepted || !clauseInScope(finding.Clause, scope) {
+					continue
+				}
+				findings = append(findings, promptFinding{
+					AuditFinding: finding,
+					AuditID:      auditID,
+					Overdue:      !finding.DueDate.IsZero() && finding.DueDate.Before(now),
+				})
+			}
+		}
+		data["open_findings"] = findings
+		data["overdue"] = overdueDeadlines(tenant, now)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
 
-	if typeArg, exists := request.Params.Arguments["audit_type"]; exists {
-		auditType = typeArg
+	return renderPrompt("qms_audit_preparation", auditPreparationGuides, request, data, "audit_type", "scope")
+}
+
+// workspacePromptTenant runs fn on the organization a prompt names with organization_id,
+// or else on the session's workspace. Guidance prompts also work before a workspace
+// organization is created, or for callers who may not read it: fn then gets an empty
+// tenant and recorded is false.
+func workspacePromptTenant(ctx context.Context, request mcp.GetPromptRequest, fn func(tenant *iso9001.Tenant, recorded bool) error) error {
+	if request.Params.Arguments["organization_id"] != "" {
+		return viewPromptTenant(ctx, request, func(tenant *iso9001.Tenant) error {
+			return fn(tenant, true)
+		})
 	}
 
-	if scopeArg, exists := request.Params.Arguments["scope"]; exists {
-		scope = scopeArg
+	tenantID := workspaceTenant(ctx)
+	if authorizeTenant(ctx, tenantID) == nil {
+		err := tenantStore.WithTenantContext(ctx, tenantID, func(tenant *iso9001.Tenant) error {
+			return fn(tenant, true)
+		})
+		if !errors.Is(err, iso9001.ErrTenantNotFound) {
+			return err
+		}
 	}
+	return fn(iso9001.NewTenant(tenantID), false)
+}
 
-	prompt := fmt.Sprintf(`# Audit Preparation Guide for %s Audit
-
-## Audit Overview
-- **Audit Type**: %s
-- **Scope**: %s
-
-## Pre-Audit Preparation Checklist
-
-### 1. Audit Planning (2-4 weeks before)
-- [ ] Define audit objectives and scope clearly
-- [ ] Select qualified audit team members
-- [ ] Develop detailed audit plan and schedule
-- [ ] Prepare audit checklist based on ISO 9001 requirements
-- [ ] Notify auditees and schedule interviews
-- [ ] Gather relevant documentation and records
-
-### 2. Documentation Review (1-2 weeks before)
-- [ ] Verify all required documented information is available
-- [ ] Check document control procedures are followed
-- [ ] Review management system procedures and work instructions
-- [ ] Validate record keeping practices
-- [ ] Assess compliance with applicable regulatory requirements
-
-### 3. Process Readiness Assessment
-- [ ] Verify processes are implemented as documented
-- [ ] Check process performance monitoring systems
-- [ ] Review quality objectives achievement
-- [ ] Assess risk management effectiveness
-- [ ] Validate corrective action system functionality
-
-### 4. Interview Preparation
-- [ ] Identify key personnel to interview
-- [ ] Prepare interview questions based on audit scope
-- [ ] Review interviewee roles and responsibilities
-- [ ] Ensure interview logistics are arranged
-
-### 5. Physical Audit Preparation
-- [ ] Confirm access to audit locations
-- [ ] Arrange for escort/guides as needed
-- [ ] Prepare audit working papers and forms
-- [ ] Set up opening and closing meeting logistics
-
-## Audit Day Execution
-
-### Opening Meeting
-- [ ] Introduce audit team and auditees
-- [ ] Review audit objectives, scope, and criteria
-- [ ] Confirm audit plan and schedule
-- [ ] Establish communication protocols
-- [ ] Review confidentiality and conflict resolution
-
-### Audit Execution
-- [ ] Follow systematic audit approach
-- [ ] Use sampling techniques for records review
-- [ ] Conduct interviews professionally
-- [ ] Document objective evidence thoroughly
-- [ ] Maintain audit trail and working papers
-
-### Finding Development
-- [ ] Gather objective evidence for each finding
-- [ ] Classify findings appropriately (major/minor/observation)
-- [ ] Reference specific requirements and evidence
-- [ ] Ensure findings are clear and actionable
-
-### Closing Meeting
-- [ ] Present preliminary findings
-- [ ] Discuss root causes and impacts
-- [ ] Agree on corrective action timelines
-- [ ] Confirm understanding and commitment
-
-## Post-Audit Activities
-
-### 1. Audit Report Preparation
-- [ ] Compile comprehensive audit report
-- [ ] Include executive summary and detailed findings
-- [ ] Provide evidence for each finding
-- [ ] Include positive observations and opportunities
-
-### 2. Corrective Action Planning
-- [ ] Develop detailed corrective action plans
-- [ ] Assign responsibilities and timelines
-- [ ] Identify root causes thoroughly
-- [ ] Plan preventive actions
-
-### 3. Follow-up and Verification
-- [ ] Monitor corrective action implementation
-- [ ] Verify effectiveness of implemented actions
-- [ ] Close out audit findings appropriately
-- [ ] Update audit schedule and plans
-
-## Audit Types and Specific Considerations
-
-### Internal Audits
-- Focus on improvement and compliance verification
-- Use internal auditors familiar with processes
-- Emphasize training and development opportunities
-- Balance audit rigor with operational efficiency
-
-### External Audits
-- Prepare for independent assessment
-- Ensure all documentation is audit-ready
-- Train staff on external audit expectations
-- Focus on objective evidence and traceability
-
-### Certification Audits
-- Understand certification body requirements
-- Prepare for Stage 1 (documentation review) and Stage 2 (implementation verification)
-- Address all major nonconformities before certification
-- Plan for surveillance audit schedule
-
-## Common Audit Findings and Prevention
-
-### Documentation Issues
-- **Prevention**: Implement robust document control procedures
-- **Common Finding**: Outdated or uncontrolled documents
-- **Solution**: Regular document review and approval processes
-
-### Process Nonconformance
-- **Prevention**: Regular process monitoring and measurement
-- **Common Finding**: Processes not followed as documented
-- **Solution**: Training and procedure adherence monitoring
-
-### Record Keeping Issues
-- **Prevention**: Automated record generation and storage
-- **Common Finding**: Incomplete or missing records
-- **Solution**: Clear record requirements and verification procedures
-
-### Management System Weaknesses
-- **Prevention**: Regular management reviews and internal audits
-- **Common Finding**: Lack of management commitment or oversight
-- **Solution**: Active leadership involvement and regular reviews
-
-## Audit Success Factors
-
-1. **Clear Objectives**: Well-defined audit scope and objectives
-2. **Qualified Auditors**: Competent audit team with appropriate training
-3. **Thorough Preparation**: Comprehensive pre-audit activities
-4. **Systematic Approach**: Consistent audit methodology and documentation
-5. **Professional Conduct**: Objective, fair, and collaborative approach
-6. **Follow-through**: Effective corrective action and verification
-
-## Resources and Tools
-
-- ISO 19011:2018 Guidelines for auditing management systems
-- ISO 9001:2015 requirements checklist
-- Audit report templates and working papers
-- Corrective action tracking systems
-- Auditor qualification and training records
-
-Remember: Audits are opportunities for improvement, not just compliance checks. Approach them with a positive mindset focused on organizational excellence.`, auditType, scope)
+// overdueDeadlines returns the deadlines of a tenant that have passed, oldest first
+func overdueDeadlines(tenant *iso9001.Tenant, now time.Time) []promptDeadline {
+	var overdue []promptDeadline
+	for _, deadline := range tenant.Deadlines() {
+		if !deadline.Due.Before(now) {
+			break
+		}
+		overdue = append(overdue, promptDeadline{Deadline: deadline, DaysOverdue: int(now.Sub(deadline.Due).Hours() / 24)})
+	}
+	return overdue
+}
 
-	return &mcp.GetPromptResult{
-		Description: "Comprehensive audit preparation guide tailored to your audit type and scope",
-		Messages: []mcp.PromptMessage{
-			{
-				Role:    mcp.RoleUser,
-				Content: mcp.TextContent{Text: prompt},
-			},
-		},
-	}, nil
+// clauseInScope reports whether a clause falls within the clauses of an audit scope,
+// or contains one of them; a scope without clauses covers the whole standard. Excluded
+// clauses are out of scope.
+func clauseInScope(clause string, scope iso9001.AuditScope) bool {
+	normalize := func(reference string) string {
+		return strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(reference), "Clause"))
+	}
+	within := func(clause, parent string) bool {
+		return clause == parent || strings.HasPrefix(clause, parent+".")
+	}
+	for _, exclusion := range scope.Exclusions {
+		if within(clause, normalize(exclusion)) {
+			return false
+		}
+	}
+	if len(scope.Clauses) == 0 {
+		return true
+	}
+	for _, reference := range scope.Clauses {
+		if reference = normalize(reference); within(clause, reference) || within(reference, clause) {
+			return true
+		}
+	}
+	return false
+}
+
+// sortedAuditIDs returns the IDs of the audits in order
+func sortedAuditIDs(audits *iso9001.AuditManager) []string {
+	ids := make([]string, 0, len(audits.Audits))
+	for id := range audits.Audits {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func handleContextAnalysisPrompt(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	return renderPrompt("qms_context_analysis", contextAnalyses, request, nil, "industry", "offering")
+}
+
+func handleInterestedPartiesPrompt(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	return renderPrompt("qms_interested_parties", interestedPartyWizards, request, nil, "industry", "offering")
+}
+
+func handleManagementReviewPrompt(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	since, err := parseOptionalTime(request.Params.Arguments["since"])
+	if err != nil {
+		return nil, fmt.Errorf("invalid since: %v", err)
+	}
+
+	now := time.Now()
+	data := map[string]interface{}{"date": now.Format("2006-01-02")}
+	err = viewPromptTenant(ctx, request, func(tenant *iso9001.Tenant) error {
+		data["organization"] = tenant.Organization.Name
+		if tenant.Organization.Name == "" {
+			data["organization"] = tenant.ID
+		}
+		if last := tenant.Audits.LatestCompletedReview(); last != nil {
+			data["last_review"] = last.Date.Format("2006-01-02")
+		}
+
+		reviewID := request.Params.Arguments["review_id"]
+		if reviewID == "" {
+			data["inputs"] = tenant.CollectReviewInputs(since, now)
+			return nil
+		}
+		review, ok := tenant.Audits.ManagementReviews[reviewID]
+		if !ok {
+			return fmt.Errorf("management review with ID %s not found", reviewID)
+		}
+		data["review_id"] = review.ID
+		if review.Title != "" {
+			data["title"] = review.Title
+		}
+		data["date"] = review.Date.Format("2006-01-02")
+		data["attendees"] = review.Attendees
+		data["inputs"] = review.Inputs
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return renderPrompt("qms_management_review", managementReviewAgendas, request, data, "title")
+}
+
+func handleRootCauseAnalysisPrompt(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	method := request.Params.Arguments["method"]
+	if method != "" && method != string(iso9001.RCAMethodFiveWhys) && method != string(iso9001.RCAMethodFishbone) {
+		return nil, fmt.Errorf("unknown method %q (use %s or %s)", method, iso9001.RCAMethodFiveWhys, iso9001.RCAMethodFishbone)
+	}
+
+	findingID := request.Params.Arguments["finding_id"]
+	nonconformanceID := request.Params.Arguments["nonconformance_id"]
+	data := map[string]interface{}{"problem": request.Params.Arguments["problem"]}
+	if findingID != "" || nonconformanceID != "" {
+		err := viewPromptTenant(ctx, request, func(tenant *iso9001.Tenant) error {
+			if findingID != "" {
+				auditID := findingAudit(tenant.Audits, findingID)
+				if auditID == "" {
+					return fmt.Errorf("finding with ID %s not found", findingID)
+				}
+				for _, finding := range tenant.Audits.Audits[auditID].Findings {
+					if finding.ID == findingID {
+						data["finding"] = finding
+						data["audit_id"] = auditID
+						if data["problem"] == "" {
+							data["problem"] = finding.Description
+						}
+					}
+				}
+				return nil
+			}
+			for _, report := range tenant.Nonconformities {
+				if report.ID == nonconformanceID {
+					data["nonconformance"] = report
+					if data["problem"] == "" {
+						data["problem"] = report.Description
+					}
+					return nil
+				}
+			}
+			return fmt.Errorf("nonconformance with ID %s not found", nonconformanceID)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	if data["problem"] == "" {
+		return nil, fmt.Errorf("give the problem, a finding_id or a nonconformance_id")
+	}
+
+	return renderPrompt("qms_root_cause_analysis", rootCauseAnalyses, request, data, "method")
+}
+
+func handleQualityPolicyPrompt(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	data := map[string]interface{}{}
+	err := viewPromptTenant(ctx, request, func(tenant *iso9001.Tenant) error {
+		org := tenant.Organization
+		data["organization"] = org.Name
+		if org.Name == "" {
+			data["organization"] = tenant.ID
+		}
+		if org.Context != nil {
+			data["external_issues"] = org.Context.ExternalIssues
+			data["internal_issues"] = org.Context.InternalIssues
+			data["interested_parties"] = org.Context.InterestedParties
+		}
+		if org.QMS != nil && org.QMS.Scope != nil {
+			data["scope"] = org.QMS.Scope
+		}
+		if org.Leadership != nil && org.Leadership.QualityPolicy != nil {
+			data["policy"] = org.Leadership.QualityPolicy
+		}
+		data["objectives"] = workspaceObjectives(tenant)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return renderPrompt("qms_quality_policy", qualityPolicyDrafts, request, data)
+}
+
+// workspaceObjectives returns the quality objectives of a tenant: those of its
+// objectives manager by ID, then any others listed in its QMS
+func workspaceObjectives(tenant *iso9001.Tenant) []iso9001.QualityObjective {
+	var objectives []iso9001.QualityObjective
+	seen := make(map[string]bool)
+	managed, _ := tenant.Collection("objectives")
+	for _, objective := range managed.([]*iso9001.QualityObjective) {
+		objectives = append(objectives, *objective)
+		seen[objective.ID] = true
+	}
+	if qms := tenant.Organization.QMS; qms != nil {
+		for _, objective := range qms.Objectives {
+			if !seen[objective.ID] {
+				objectives = append(objectives, objective)
+			}
+		}
+	}
+	return objectives
+}
+
+// viewPromptTenant runs fn on the organization a prompt names with organization_id or
+// else on the session's workspace, once the connection may read it
+func viewPromptTenant(ctx context.Context, request mcp.GetPromptRequest, fn func(tenant *iso9001.Tenant) error) error {
+	tenantID := request.Params.Arguments["organization_id"]
+	if tenantID == "" {
+		tenantID = workspaceTenant(ctx)
+	}
+	if err := authorizeTenant(ctx, tenantID); err != nil {
+		return err
+	}
+	err := tenantStore.WithTenantContext(ctx, tenantID, fn)
+	if errors.Is(err, iso9001.ErrTenantNotFound) {
+		return fmt.Errorf("no organization %s in the workspace: create one with qms_create_organization or load one with qms_load_organization", tenantID)
+	}
+	return err
 }
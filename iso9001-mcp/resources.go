@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 
+	"github.com/example/iso9001"
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
@@ -47,34 +48,12 @@ func handleClausesResource(ctx context.Context, request mcp.ReadResourceRequest)
 			"title": "Quality objectives and planning to achieve them",
 			"description": "The organization shall establish quality objectives at relevant functions and levels.",
 		},
-		"7.1": map[string]string{
-			"title": "Resources",
-			"description": "The organization shall determine and provide the resources needed for the establishment, implementation, maintenance and continual improvement of the quality management system.",
-		},
-		"7.5": map[string]string{
-			"title": "Documented information",
-			"description": "The organization shall maintain documented information required by the quality management system.",
-		},
-		"8.1": map[string]string{
-			"title": "Operational planning and control",
-			"description": "The organization shall plan, implement and control the processes needed to meet requirements.",
-		},
-		"9.1": map[string]string{
-			"title": "Monitoring, measurement, analysis and evaluation",
-			"description": "The organization shall monitor, measure, analyze and evaluate the performance of the quality management system.",
-		},
-		"9.2": map[string]string{
-			"title": "Internal audit",
-			"description": "The organization shall conduct internal audits at planned intervals to provide information on whether the quality management system conforms to requirements.",
-		},
-		"9.3": map[string]string{
-			"title": "Management review",
-			"description": "Top management shall review the organization's quality management system at planned intervals.",
-		},
-		"10.1": map[string]string{
-			"title": "Improvement",
-			"description": "The organization shall continually improve the suitability, adequacy and effectiveness of the quality management system.",
-		},
+	}
+
+	// Clauses 7 to 10 at sub-clause granularity, with required records and typical
+	// evidence
+	for _, clause := range iso9001.Clauses() {
+		clauses[clause.Number] = clause
 	}
 
 	data, err := json.Marshal(clauses)
@@ -240,6 +219,8 @@ func handleTemplatesResource(ctx context.Context, request mcp.ReadResourceReques
 				"Review Date",
 			},
 		},
+		// Built-in templates that qms_instantiate_template turns into documents and processes
+		"instantiable_templates": iso9001.NewTemplateManager().ListTemplates(""),
 	}
 
 	data, err := json.Marshal(templates)
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// toolResult is the envelope of every successful tool result, so that clients can
+// read what a tool did without parsing prose. EntityType and ID name the entity the
+// tool created, changed or returned; ID is empty when Data holds several entities
+// or a report about them.
+type toolResult struct {
+	EntityType string      `json:"entity_type"`
+	ID         string      `json:"id"`
+	Message    string      `json:"message"`
+	Data       interface{} `json:"data"`
+	Warnings   []string    `json:"warnings"`
+}
+
+// newToolResult returns the envelope as structured content and, for clients that
+// only read text content, as indented JSON text. data may be a json.RawMessage
+// already marshaled by the handler.
+func newToolResult(entityType, id, message string, data interface{}, warnings ...string) (*mcp.CallToolResult, error) {
+	if warnings == nil {
+		warnings = []string{}
+	}
+	envelope := toolResult{
+		EntityType: entityType,
+		ID:         id,
+		Message:    message,
+		Data:       data,
+		Warnings:   warnings,
+	}
+
+	text, err := json.MarshalIndent(envelope, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal tool result: %v", err)
+	}
+	return &mcp.CallToolResult{
+		Content:           []mcp.Content{mcp.NewTextContent(string(text))},
+		StructuredContent: envelope,
+	}, nil
+}
+
+// withWarnings adds warnings to a result made by newToolResult, e.g. when a tool hands
+// its work to another tool's handler. Error results are returned as they are.
+func withWarnings(result *mcp.CallToolResult, warnings ...string) (*mcp.CallToolResult, error) {
+	envelope, ok := result.StructuredContent.(toolResult)
+	if !ok || result.IsError || len(warnings) == 0 {
+		return result, nil
+	}
+	return newToolResult(envelope.EntityType, envelope.ID, envelope.Message, envelope.Data, append(envelope.Warnings, warnings...)...)
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/example/iso9001"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Risk Appetite Handlers
+
+func handleAssessResidualRisk(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	riskID, err := request.RequireString("risk_id")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Missing risk_id: %v", err)), nil
+	}
+
+	likelihoodStr, err := request.RequireString("likelihood")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Missing likelihood: %v", err)), nil
+	}
+
+	impactStr, err := request.RequireString("impact")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Missing impact: %v", err)), nil
+	}
+
+	var risk *iso9001.Risk
+	var result []byte
+	_, err = updateTenant(ctx, request, func(tenant *iso9001.Tenant) error {
+		if err := tenant.Risks.AssessResidualRisk(riskID, parseRiskLevel(likelihoodStr), parseRiskLevel(impactStr)); err != nil {
+			return err
+		}
+		risk = tenant.Risks.Risks[riskID]
+		result, err = json.MarshalIndent(risk, "", "  ")
+		return err
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to assess residual risk: %v", err)), nil
+	}
+
+	recordChange(ctx, iso9001.EntityTypeRisk, risk.ID, iso9001.ChangeOperationUpdated, risk)
+
+	if risk.ExceedsAppetite {
+		return newToolResult(iso9001.EntityTypeRisk, risk.ID, "Residual risk assessed; it still EXCEEDS the risk appetite", json.RawMessage(result),
+			"The residual risk exceeds the risk appetite and needs further treatment or formal acceptance")
+	}
+	return newToolResult(iso9001.EntityTypeRisk, risk.ID, "Residual risk assessed; it is within the risk appetite", json.RawMessage(result))
+}
+
+func handleSetRiskAppetite(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	maxScore, err := request.RequireInt("max_score")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Missing max_score: %v", err)), nil
+	}
+
+	appetite := iso9001.RiskAppetite{
+		MaxScore:  maxScore,
+		Statement: request.GetString("statement", ""),
+	}
+	if maxImpact := request.GetString("max_impact", ""); maxImpact != "" {
+		appetite.MaxImpact = parseRiskLevel(maxImpact)
+	}
+
+	var exceeding []*iso9001.Risk
+	tenantID, err := updateTenant(ctx, request, func(tenant *iso9001.Tenant) error {
+		if err := tenant.Risks.SetAppetite(appetite); err != nil {
+			return err
+		}
+		exceeding = tenant.Risks.RisksExceedingAppetite()
+		return nil
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to set risk appetite: %v", err)), nil
+	}
+
+	var warnings []string
+	for _, risk := range exceeding {
+		warnings = append(warnings, fmt.Sprintf("Risk %s exceeds the risk appetite", risk.ID))
+	}
+	return newToolResult("risk_appetite", tenantID, fmt.Sprintf("Risk appetite of organization %s set to a residual score of %d; %d risks exceed it", tenantID, appetite.MaxScore, len(exceeding)), appetite, warnings...)
+}
+
+func handleRiskExposure(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var result []byte
+	err := viewTenant(ctx, request, requestTenant(ctx, request), func(tenant *iso9001.Tenant) error {
+		var err error
+		result, err = json.MarshalIndent(tenant.Risks.ResidualExposure(), "", "  ")
+		return err
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to report risk exposure: %v", err)), nil
+	}
+
+	return newToolResult("risk_exposure", "", "Residual risk exposure", json.RawMessage(result))
+}
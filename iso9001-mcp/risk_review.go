@@ -0,0 +1,41 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/example/iso9001"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Risk Review Handlers
+
+func handleGetRisksDueForReview(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var due, flagged []*iso9001.Risk
+	var result []byte
+	_, err := updateTenant(ctx, request, func(tenant *iso9001.Tenant) error {
+		wasOverdue := make(map[string]bool)
+		for id, risk := range tenant.Risks.Risks {
+			wasOverdue[id] = risk.Status == iso9001.RiskStatusOverdue
+		}
+		due = tenant.Risks.GetRisksDueForReview()
+		for _, risk := range due {
+			if !wasOverdue[risk.ID] {
+				flagged = append(flagged, risk)
+			}
+		}
+		var err error
+		result, err = json.MarshalIndent(due, "", "  ")
+		return err
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get risks due for review: %v", err)), nil
+	}
+
+	for _, risk := range flagged {
+		recordChange(ctx, iso9001.EntityTypeRisk, risk.ID, iso9001.ChangeOperationUpdated, risk)
+	}
+
+	return newToolResult(iso9001.EntityTypeRisk, "", fmt.Sprintf("%d risks due for review", len(due)), json.RawMessage(result))
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/example/iso9001"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Root Cause Analysis Handlers
+
+func handleRecordRootCauseAnalysis(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	findingID := request.GetString("finding_id", "")
+	nonconformanceID := request.GetString("nonconformance_id", "")
+	if (findingID == "") == (nonconformanceID == "") {
+		return mcp.NewToolResultError("Give either finding_id or nonconformance_id"), nil
+	}
+
+	var rca iso9001.RootCauseAnalysis
+	if err := requireArgument(request, "analysis", &rca); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if rca.PerformedBy == "" {
+		rca.PerformedBy = requestIdentity(ctx)
+	}
+
+	entityType, entityID := iso9001.EntityTypeFinding, findingID
+	if nonconformanceID != "" {
+		entityType, entityID = iso9001.EntityTypeNonconformance, nonconformanceID
+	}
+
+	auditID := request.GetString("audit_id", "")
+	var entity interface{}
+	var result []byte
+	_, err := updateTenant(ctx, request, func(tenant *iso9001.Tenant) error {
+		if nonconformanceID != "" {
+			report, err := tenant.RecordNonconformanceRootCause(nonconformanceID, rca)
+			if err != nil {
+				return err
+			}
+			entity = report
+		} else {
+			if auditID == "" {
+				auditID = findingAudit(tenant.Audits, findingID)
+				if auditID == "" {
+					return fmt.Errorf("finding with ID %s not found", findingID)
+				}
+			}
+			if err := tenant.Audits.RecordRootCauseAnalysis(auditID, findingID, rca); err != nil {
+				return err
+			}
+			for _, finding := range tenant.Audits.Audits[auditID].Findings {
+				if finding.ID == findingID {
+					entity = finding
+				}
+			}
+		}
+		var err error
+		result, err = json.MarshalIndent(entity, "", "  ")
+		return err
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to record root cause analysis: %v", err)), nil
+	}
+
+	recordChange(ctx, entityType, entityID, iso9001.ChangeOperationUpdated, entity)
+
+	return newToolResult(entityType, entityID, fmt.Sprintf("Root cause analysis recorded for %s %s: %s", entityType, entityID, rca.Summary()), json.RawMessage(result))
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/example/iso9001"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Template Handlers
+
+// tenantTemplates returns the tenant's template manager, creating it for tenants
+// stored before templates existed
+func tenantTemplates(tenant *iso9001.Tenant) *iso9001.TemplateManager {
+	if tenant.Templates == nil {
+		tenant.Templates = iso9001.NewTemplateManager()
+	}
+	return tenant.Templates
+}
+
+func handleListTemplates(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	kind := iso9001.TemplateKind(request.GetString("kind", ""))
+
+	var result []byte
+	err := viewTenant(ctx, request, requestTenant(ctx, request), func(tenant *iso9001.Tenant) error {
+		templates := iso9001.NewTemplateManager()
+		if tenant.Templates != nil {
+			templates = tenant.Templates
+		}
+		var err error
+		result, err = json.MarshalIndent(templates.ListTemplates(kind), "", "  ")
+		return err
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to list templates: %v", err)), nil
+	}
+
+	return newToolResult(iso9001.EntityTypeTemplate, "", "Templates", json.RawMessage(result))
+}
+
+func handleAddTemplate(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var template iso9001.Template
+	if err := requireArgument(request, "template", &template); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	_, err := updateTenant(ctx, request, func(tenant *iso9001.Tenant) error {
+		return tenantTemplates(tenant).AddTemplate(&template)
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to add template: %v", err)), nil
+	}
+
+	recordChange(ctx, iso9001.EntityTypeTemplate, template.ID, iso9001.ChangeOperationCreated, template)
+
+	return newToolResult(iso9001.EntityTypeTemplate, template.ID, fmt.Sprintf("Template %s stored with %d variables", template.ID, len(template.Variables)), template)
+}
+
+func handleInstantiateTemplate(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	templateID, err := request.RequireString("template_id")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Missing template_id: %v", err)), nil
+	}
+
+	values := map[string]string{}
+	if _, err := decodeArgument(request, "variables", &values); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	var entityType, entityID string
+	var entity any
+	tenantID, err := updateTenant(ctx, request, func(tenant *iso9001.Tenant) error {
+		templates := tenantTemplates(tenant)
+		template, err := templates.GetTemplate(templateID)
+		if err != nil {
+			return err
+		}
+
+		switch template.Kind {
+		case iso9001.TemplateKindDocument:
+			if accessPolicy != nil {
+				if err := accessPolicy.Authorize(requestIdentity(ctx), iso9001.PermissionManageDocuments); err != nil {
+					return err
+				}
+			}
+			doc, err := templates.InstantiateDocument(templateID, values)
+			if err != nil {
+				return err
+			}
+			if doc.Metadata.Author == "" {
+				doc.Metadata.Author = requestIdentity(ctx)
+			}
+			if err := tenant.Documents.AddDocument(doc); err != nil {
+				return err
+			}
+			entityType, entityID, entity = iso9001.EntityTypeDocument, doc.ID, doc
+		case iso9001.TemplateKindProcess:
+			process, err := templates.InstantiateProcess(templateID, values)
+			if err != nil {
+				return err
+			}
+			org := tenant.Organization
+			if org.QMS == nil {
+				org.QMS = &iso9001.QualityManagementSystem{ID: org.ID + "_qms", Created: time.Now()}
+			}
+			for _, existing := range org.QMS.Processes {
+				if existing.ID == process.ID {
+					return fmt.Errorf("process with ID %s already exists", process.ID)
+				}
+			}
+			org.QMS.Processes = append(org.QMS.Processes, *process)
+			entityType, entityID, entity = iso9001.EntityTypeProcess, process.ID, process
+		}
+		return nil
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to instantiate template: %v", err)), nil
+	}
+
+	result, err := json.MarshalIndent(entity, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal %s: %v", entityType, err)), nil
+	}
+
+	recordChange(ctx, entityType, entityID, iso9001.ChangeOperationCreated, entity)
+
+	return newToolResult(entityType, entityID, fmt.Sprintf("Created %s %s from template %s in organization %s", entityType, entityID, templateID, tenantID), json.RawMessage(result))
+}
@@ -0,0 +1,336 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/example/iso9001"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// tenantStore holds the organizations the tools work on, each with its risks, audits,
+// documents and objectives, so state survives between tool calls. Tenants live in
+// memory unless -store names a directory to persist them in.
+var tenantStore = iso9001.NewTenantStore(nil, 0)
+
+// attachmentDir, when set by -store, keeps the document attachments of each tenant in
+// a subdirectory named after the tenant; otherwise they are kept in memory
+var attachmentDir string
+
+// defaultOrganization is the tenant used by tools called without organization_id, set
+// by -organization
+var defaultOrganization = "default"
+
+// Store backends selected with -store-backend
+const (
+	storeBackendFiles  = "files"  // a JSON file per tenant
+	storeBackendEvents = "events" // an event stream per tenant
+	storeBackendBolt   = "bolt"   // an embedded BoltDB database
+)
+
+// openTenantStore persists tenants in a directory, as tenant files, event streams or
+// a BoltDB database depending on kind. Any other iso9001.TenantBackend can be plugged
+// in the same way.
+func openTenantStore(dir, kind string) error {
+	var backend iso9001.TenantBackend
+	switch kind {
+	case storeBackendFiles, "":
+		files, err := iso9001.NewFileTenantBackend(dir)
+		if err != nil {
+			return err
+		}
+		backend = files
+	case storeBackendEvents:
+		store, err := iso9001.NewFileEventStore(dir)
+		if err != nil {
+			return err
+		}
+		backend = iso9001.NewEventSourcedBackend(store)
+	case storeBackendBolt:
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("failed to create store directory: %w", err)
+		}
+		db, err := openBoltTenantBackend(boltTenantPath(dir))
+		if err != nil {
+			return err
+		}
+		backend = db
+	default:
+		return fmt.Errorf("unknown store backend %q (expected %s, %s or %s)", kind, storeBackendFiles, storeBackendEvents, storeBackendBolt)
+	}
+	key, err := iso9001.LoadTrailKey(iso9001.TrailKeyFile(dir))
+	if err != nil {
+		return err
+	}
+	tenantStore = iso9001.NewTenantStore(backend, 0)
+	tenantStore.SetTrailKey(key)
+	attachmentDir = filepath.Join(dir, "attachments")
+	return nil
+}
+
+// withOrganizationID adds the optional organization_id argument to a tool
+func withOrganizationID() mcp.ToolOption {
+	return mcp.WithString("organization_id",
+		mcp.Description("ID of the organization to work on; defaults to the organization loaded in the workspace"),
+	)
+}
+
+// updateTenant runs fn on the tenant a tool call names with organization_id or else
+// on the session's workspace, creating it on first use, and saves the store
+// afterwards. It returns the tenant ID.
+func updateTenant(ctx context.Context, request mcp.CallToolRequest, fn func(tenant *iso9001.Tenant) error) (string, error) {
+	tenantID := requestTenant(ctx, request)
+	return tenantID, updateTenantByID(ctx, request, tenantID, fn)
+}
+
+// updateTenantByID runs fn on the given tenant, creating it on first use, once the
+// caller is authorized for it, and saves the store afterwards. The changes fn makes
+// are recorded in the tenant's audit trail under the caller's identity and the tool,
+// and the events they trigger are sent to the -webhooks.
+func updateTenantByID(ctx context.Context, request mcp.CallToolRequest, tenantID string, fn func(tenant *iso9001.Tenant) error) error {
+	if tenantID == "" {
+		return fmt.Errorf("organization must have an ID")
+	}
+	if accessPolicy != nil {
+		if err := accessPolicy.AuthorizeTenant(requestIdentity(ctx), tenantID); err != nil {
+			return err
+		}
+	}
+	if _, err := tenantStore.GetTenantContext(ctx, tenantID); errors.Is(err, iso9001.ErrTenantNotFound) {
+		// a concurrent call may have created it meanwhile, which WithTenant then finds
+		tenantStore.CreateTenant(tenantID)
+	} else if err != nil {
+		return err
+	}
+
+	actor := requestIdentity(ctx)
+	if actor == "" {
+		actor = anonymousActor
+	}
+	var payloads []iso9001.WebhookPayload
+	err := tenantStore.WithTenantContext(ctx, tenantID, func(tenant *iso9001.Tenant) error {
+		return tenant.Change(actor, request.Params.Name, func(tenant *iso9001.Tenant) error {
+			if webhooks == nil {
+				return fn(tenant)
+			}
+			var err error
+			payloads, err = webhooks.Observe(tenant, actor, fn)
+			payloads = append(payloads, webhooks.Overdue(tenant, time.Now())...)
+			return err
+		})
+	})
+	if err != nil {
+		return err
+	}
+	if err := tenantStore.FlushTenantContext(ctx, tenantID); err != nil {
+		return err
+	}
+	deliverWebhooks(payloads)
+	return nil
+}
+
+// storeOrganization saves an organization supplied and changed by a tool as the
+// organization of the tenant with its ID. An organization new to the tenant gets the
+// server's time zone and calendar where it sets none.
+func storeOrganization(ctx context.Context, request mcp.CallToolRequest, org *iso9001.Organization) error {
+	return updateTenantByID(ctx, request, org.ID, func(tenant *iso9001.Tenant) error {
+		if tenant.Organization == nil {
+			applyCalendarDefaults(org)
+		}
+		tenant.Organization = org
+		return nil
+	})
+}
+
+// nextTenantID returns the first unused ID of the form PREFIX-001 in a tenant. IDs are
+// unique across all entity types of a tenant.
+func nextTenantID(tenant *iso9001.Tenant, prefix string) string {
+	for n := 1; ; n++ {
+		id := fmt.Sprintf("%s-%03d", prefix, n)
+		if _, taken := tenant.Risks.IDs.Lookup(id); !taken {
+			return id
+		}
+	}
+}
+
+// authorizeTenant checks that the connection may read the tenant's entities. Without
+// an access policy every tenant is accessible.
+func authorizeTenant(ctx context.Context, tenantID string) error {
+	if accessPolicy == nil {
+		return nil
+	}
+	identity := requestIdentity(ctx)
+	if err := accessPolicy.Authorize(identity, iso9001.PermissionView); err != nil {
+		return err
+	}
+	return accessPolicy.AuthorizeTenant(identity, tenantID)
+}
+
+// readableDocument enforces a document's access controls for the connection: readers
+// not on the read list of a restricted document get redacted metadata, and the denial
+// is logged
+func readableDocument(ctx context.Context, tenantID string, doc *iso9001.DocumentedInformation) *iso9001.DocumentedInformation {
+	identity := requestIdentity(ctx)
+	if accessPolicy.CanReadDocument(identity, doc) {
+		return doc
+	}
+	log.Printf("Access denied: %q is not on the read list of %s document %s of tenant %s; serving redacted metadata",
+		identity, doc.Access.Classification, doc.ID, tenantID)
+	return iso9001.RedactDocument(doc)
+}
+
+// Tenant Resource Handlers
+
+func handleTenantEntityResource(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	tenantID := templateArgument(request, "tenant")
+	collection := templateArgument(request, "collection")
+	id := templateArgument(request, "id")
+
+	// qms://organizations/ACME/risks and qms://audits/AUDIT-001/findings match this
+	// template too, and the server tries templates in no particular order
+	switch {
+	case tenantID == "organizations":
+		return readTenantCollection(ctx, request.Params.URI, collection, id)
+	case tenantID == "audits" && id == "findings":
+		return readAuditFindings(ctx, request.Params.URI, collection)
+	}
+
+	if err := authorizeTenant(ctx, tenantID); err != nil {
+		return nil, err
+	}
+
+	var data []byte
+	err := tenantStore.WithTenantContext(ctx, tenantID, func(tenant *iso9001.Tenant) error {
+		entity, ok := tenant.Lookup(collection, id)
+		if !ok {
+			return fmt.Errorf("no entity %q in %s of tenant %s (collections: %s)", id, collection, tenantID,
+				strings.Join(iso9001.TenantCollections, ", "))
+		}
+		if doc, ok := entity.(*iso9001.DocumentedInformation); ok {
+			entity = readableDocument(ctx, tenantID, doc)
+		}
+		var err error
+		data, err = json.MarshalIndent(entity, "", "  ")
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{
+			URI:      request.Params.URI,
+			MIMEType: "application/json",
+			Text:     string(data),
+		},
+	}, nil
+}
+
+func handleOrganizationResource(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	tenantID := templateArgument(request, "id")
+	if err := authorizeTenant(ctx, tenantID); err != nil {
+		return nil, err
+	}
+
+	var data []byte
+	err := tenantStore.WithTenantContext(ctx, tenantID, func(tenant *iso9001.Tenant) error {
+		var err error
+		data, err = json.MarshalIndent(tenant.Organization, "", "  ")
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return jsonResource(request.Params.URI, data), nil
+}
+
+func handleOrganizationCollectionResource(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	return readTenantCollection(ctx, request.Params.URI, templateArgument(request, "id"), templateArgument(request, "collection"))
+}
+
+func handleAuditFindingsResource(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	return readAuditFindings(ctx, request.Params.URI, templateArgument(request, "id"))
+}
+
+// readTenantCollection serves all entities of a collection of a tenant, with the
+// documents the connection may not read redacted
+func readTenantCollection(ctx context.Context, uri, tenantID, collection string) ([]mcp.ResourceContents, error) {
+	if err := authorizeTenant(ctx, tenantID); err != nil {
+		return nil, err
+	}
+
+	var data []byte
+	err := tenantStore.WithTenantContext(ctx, tenantID, func(tenant *iso9001.Tenant) error {
+		entities, ok := tenant.Collection(collection)
+		if !ok {
+			return fmt.Errorf("no collection %q in tenant %s (collections: %s)", collection, tenantID,
+				strings.Join(iso9001.TenantCollections, ", "))
+		}
+		if docs, ok := entities.([]*iso9001.DocumentedInformation); ok {
+			for i, doc := range docs {
+				docs[i] = readableDocument(ctx, tenantID, doc)
+			}
+		}
+		var err error
+		data, err = json.MarshalIndent(entities, "", "  ")
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return jsonResource(uri, data), nil
+}
+
+// readAuditFindings serves the findings of an audit of the connection's workspace
+// organization
+func readAuditFindings(ctx context.Context, uri, auditID string) ([]mcp.ResourceContents, error) {
+	tenantID := workspaceTenant(ctx)
+	if err := authorizeTenant(ctx, tenantID); err != nil {
+		return nil, err
+	}
+
+	var data []byte
+	err := tenantStore.WithTenantContext(ctx, tenantID, func(tenant *iso9001.Tenant) error {
+		audit, ok := tenant.Audits.Audits[auditID]
+		if !ok {
+			return fmt.Errorf("audit with ID %s not found in organization %s", auditID, tenantID)
+		}
+		findings := append([]iso9001.AuditFinding{}, audit.Findings...)
+		var err error
+		data, err = json.MarshalIndent(findings, "", "  ")
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return jsonResource(uri, data), nil
+}
+
+// jsonResource wraps JSON as the contents of a resource
+func jsonResource(uri string, data []byte) []mcp.ResourceContents {
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{
+			URI:      uri,
+			MIMEType: "application/json",
+			Text:     string(data),
+		},
+	}
+}
+
+// templateArgument returns a variable matched from a resource URI template
+func templateArgument(request mcp.ReadResourceRequest, name string) string {
+	switch value := request.Params.Arguments[name].(type) {
+	case string:
+		return value
+	case []string:
+		return strings.Join(value, ",")
+	}
+	return ""
+}
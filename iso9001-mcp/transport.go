@@ -0,0 +1,241 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/example/iso9001"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// httpOptions configures serving the MCP server over the network, set by -transport
+// and the flags that go with it
+type httpOptions struct {
+	Transport string // http for Streamable HTTP, sse for HTTP with Server-Sent Events
+	Addr      string
+	Path      string // endpoint of Streamable HTTP, or base path of the SSE endpoints
+	// CertFile and KeyFile enable TLS; with ClientCAFile, clients must present a
+	// certificate signed by one of its CAs
+	CertFile        string
+	KeyFile         string
+	ClientCAFile    string
+	ShutdownTimeout time.Duration
+	// APIKeyFile holds the API keys clients may authenticate with instead of a client
+	// certificate, managed with iso9001ctl apikey
+	APIKeyFile string
+	RateLimit  iso9001.RateLimit // applied per client address and per API key or client certificate
+}
+
+// httpTransport is a network transport of the MCP server
+type httpTransport interface {
+	http.Handler
+	Shutdown(ctx context.Context) error
+}
+
+// serveHTTP serves the MCP server to any number of clients until SIGINT or SIGTERM.
+// It then stops accepting connections, gives open requests and webhook deliveries
+// up to the shutdown timeout to finish, and saves the tenant store. Every client must
+// present an API key or a client certificate, which identifies it to the tools.
+func serveHTTP(s *server.MCPServer, opts httpOptions) error {
+	if (opts.CertFile == "") != (opts.KeyFile == "") {
+		return errors.New("-tls-cert and -tls-key must be given together")
+	}
+	if opts.ClientCAFile != "" && opts.CertFile == "" {
+		return errors.New("-tls-client-ca requires -tls-cert and -tls-key")
+	}
+	var keys *iso9001.APIKeyManager
+	if opts.APIKeyFile != "" {
+		var err error
+		if keys, err = iso9001.NewAPIKeyManager(opts.APIKeyFile); err != nil {
+			return err
+		}
+		if !keys.HasActiveKeys() && opts.ClientCAFile == "" {
+			return fmt.Errorf("no active API keys in %s; create one with iso9001ctl apikey create", opts.APIKeyFile)
+		}
+	} else if opts.ClientCAFile == "" {
+		return errors.New("clients of -transport http or sse must authenticate: set -api-keys or -store for API keys, or -tls-client-ca for client certificates")
+	}
+	limiter := iso9001.NewRateLimiter(opts.RateLimit)
+
+	httpServer := &http.Server{Addr: opts.Addr, ReadHeaderTimeout: 10 * time.Second}
+	handler, transport, err := newHTTPTransport(s, opts, httpServer)
+	if err != nil {
+		return err
+	}
+	httpServer.Handler = authenticate(keys, limiter, handler)
+
+	if opts.ClientCAFile != "" {
+		pem, err := os.ReadFile(opts.ClientCAFile)
+		if err != nil {
+			return err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("no certificates in %s", opts.ClientCAFile)
+		}
+		clientAuth := tls.RequireAndVerifyClientCert
+		if keys != nil {
+			// clients without a certificate authenticate with an API key instead
+			clientAuth = tls.VerifyClientCertIfGiven
+		}
+		httpServer.TLSConfig = &tls.Config{
+			MinVersion: tls.VersionTLS12,
+			ClientCAs:  pool,
+			ClientAuth: clientAuth,
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	served := make(chan error, 1)
+	go func() {
+		if opts.CertFile != "" {
+			served <- httpServer.ListenAndServeTLS(opts.CertFile, opts.KeyFile)
+		} else {
+			served <- httpServer.ListenAndServe()
+		}
+	}()
+	log.Printf("Listening on %s (%s)", opts.Addr, describeEndpoints(opts))
+
+	select {
+	case err := <-served:
+		return err
+	case <-ctx.Done():
+	}
+
+	log.Println("Shutting down...")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), opts.ShutdownTimeout)
+	defer cancel()
+	if err := transport.Shutdown(shutdownCtx); err != nil {
+		// Streams still open after the timeout are cut off
+		log.Printf("Graceful shutdown incomplete: %v", err)
+		httpServer.Close()
+	}
+	waitForWebhooks(shutdownCtx)
+	return tenantStore.Flush()
+}
+
+// newHTTPTransport creates the -transport of the MCP server, shut down together with
+// httpServer, and the handler serving its endpoints under opts.Path
+func newHTTPTransport(s *server.MCPServer, opts httpOptions, httpServer *http.Server) (http.Handler, httpTransport, error) {
+	switch opts.Transport {
+	case "http":
+		streamable := server.NewStreamableHTTPServer(s,
+			server.WithEndpointPath(opts.Path),
+			server.WithStreamableHTTPServer(httpServer),
+		)
+		mux := http.NewServeMux()
+		mux.Handle(opts.Path, streamable)
+		return mux, streamable, nil
+	case "sse":
+		sse := server.NewSSEServer(s,
+			server.WithStaticBasePath(opts.Path),
+			server.WithHTTPServer(httpServer),
+		)
+		return sse, sse, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown transport %q (use stdio, http or sse)", opts.Transport)
+	}
+}
+
+// describeEndpoints names the URLs clients connect to
+func describeEndpoints(opts httpOptions) string {
+	scheme := "http"
+	if opts.CertFile != "" {
+		scheme = "https"
+	}
+	if opts.Transport == "sse" {
+		return fmt.Sprintf("SSE at %s://%s%s/sse, messages at %s/message", scheme, opts.Addr, opts.Path, opts.Path)
+	}
+	return fmt.Sprintf("Streamable HTTP at %s://%s%s", scheme, opts.Addr, opts.Path)
+}
+
+type apiKeyContextKey struct{}
+
+// authenticate identifies each client by its verified client certificate, whose
+// common name becomes its identity, or else by an API key presented as
+// "Authorization: Bearer <key>" or in the X-API-Key header, whose name becomes its
+// identity. Requests of unidentified clients are rejected; the others are throttled
+// per certificate or key before they reach the MCP server. Every request is first
+// throttled by client address, so guessing keys is throttled too.
+func authenticate(keys *iso9001.APIKeyManager, limiter *iso9001.RateLimiter, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		address := r.RemoteAddr
+		if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+			address = host
+		}
+		if !throttle(w, limiter, "addr:"+address) {
+			return
+		}
+
+		ctx := r.Context()
+		var client string
+		if r.TLS != nil && len(r.TLS.VerifiedChains) > 0 && r.TLS.VerifiedChains[0][0].Subject.CommonName != "" {
+			identity := r.TLS.VerifiedChains[0][0].Subject.CommonName
+			client = "cert:" + identity
+			ctx = iso9001.WithActor(ctx, identity)
+		} else if secret := apiKeySecret(r); keys != nil && secret != "" {
+			key, err := keys.Authenticate(secret, iso9001.APIKeyScopeRead)
+			if err != nil {
+				status := http.StatusUnauthorized
+				if errors.Is(err, iso9001.ErrInsufficientScope) {
+					status = http.StatusForbidden
+				}
+				http.Error(w, err.Error(), status)
+				return
+			}
+			client = key.ID
+			if key.RateLimit != nil {
+				limiter.SetClientLimit(key.ID, *key.RateLimit)
+			} else {
+				limiter.ClearClientLimit(key.ID)
+			}
+			ctx = iso9001.WithActor(context.WithValue(ctx, apiKeyContextKey{}, key), key.Name)
+		} else {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, "API key or client certificate required", http.StatusUnauthorized)
+			return
+		}
+
+		if throttle(w, limiter, client) {
+			next.ServeHTTP(w, r.WithContext(ctx))
+		}
+	})
+}
+
+// throttle counts a request by the client and reports whether it may proceed,
+// answering throttled requests itself
+func throttle(w http.ResponseWriter, limiter *iso9001.RateLimiter, client string) bool {
+	_, err := limiter.Allow(client)
+	if err == nil {
+		return true
+	}
+	var throttled *iso9001.ThrottleError
+	if errors.As(err, &throttled) {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(throttled.RetryAfter.Seconds()))))
+	}
+	http.Error(w, err.Error(), http.StatusTooManyRequests)
+	return false
+}
+
+// apiKeySecret returns the API key a request presents, if any
+func apiKeySecret(r *http.Request) string {
+	if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+		return strings.TrimPrefix(auth, "Bearer ")
+	}
+	return r.Header.Get("X-API-Key")
+}
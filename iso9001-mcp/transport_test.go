@@ -0,0 +1,287 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/example/iso9001"
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/client/transport"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// testHTTPServer serves the QMS tools over Streamable HTTP behind the API key
+// authentication of -transport http
+type testHTTPServer struct {
+	url  string
+	keys *iso9001.APIKeyManager
+}
+
+func newTestHTTPServer(t *testing.T, limit iso9001.RateLimit) *testHTTPServer {
+	t.Helper()
+	useTestStore(t)
+	keys, err := iso9001.NewAPIKeyManager(iso9001.APIKeyFile(t.TempDir()))
+	if err != nil {
+		t.Fatalf("Failed to open API keys: %v", err)
+	}
+
+	s := server.NewMCPServer("iso9001-test", "1.0.0",
+		server.WithToolCapabilities(true),
+		server.WithToolHandlerMiddleware(withToolCall),
+	)
+	setupQMSTools(s)
+	handler, _, err := newHTTPTransport(s, httpOptions{Transport: "http", Path: "/mcp"}, &http.Server{})
+	if err != nil {
+		t.Fatalf("Failed to create transport: %v", err)
+	}
+	ts := httptest.NewServer(authenticate(keys, iso9001.NewRateLimiter(limit), handler))
+	t.Cleanup(ts.Close)
+	return &testHTTPServer{url: ts.URL + "/mcp", keys: keys}
+}
+
+// createKey creates an API key of the scope and returns its secret
+func (s *testHTTPServer) createKey(t *testing.T, name string, scope iso9001.APIKeyScope) string {
+	t.Helper()
+	secret, _, err := s.keys.Create(name, scope, 0)
+	if err != nil {
+		t.Fatalf("Failed to create key: %v", err)
+	}
+	return secret
+}
+
+// connect opens an MCP session presenting the headers
+func (s *testHTTPServer) connect(t *testing.T, headers map[string]string) (*client.Client, error) {
+	t.Helper()
+	c, err := client.NewStreamableHttpClient(s.url, transport.WithHTTPHeaders(headers))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	t.Cleanup(func() { c.Close() })
+	ctx := context.Background()
+	if err := c.Start(ctx); err != nil {
+		return nil, err
+	}
+	request := mcp.InitializeRequest{}
+	request.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	request.Params.ClientInfo = mcp.Implementation{Name: "iso9001-test", Version: "1.0.0"}
+	if _, err := c.Initialize(ctx, request); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// connectWithKey opens an MCP session authenticated with the API key
+func (s *testHTTPServer) connectWithKey(t *testing.T, secret string) *client.Client {
+	t.Helper()
+	c, err := s.connect(t, map[string]string{"Authorization": "Bearer " + secret})
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	return c
+}
+
+// call calls a tool over the session and returns the text of its result and whether
+// it is an error
+func call(t *testing.T, c *client.Client, name string, args map[string]any) (string, bool) {
+	t.Helper()
+	request := mcp.CallToolRequest{}
+	request.Params.Name = name
+	request.Params.Arguments = args
+	result, err := c.CallTool(context.Background(), request)
+	if err != nil {
+		t.Fatalf("%s failed: %v", name, err)
+	}
+	return resultText(result), result.IsError
+}
+
+// initializeBody is a bare JSON-RPC request opening an MCP session
+const initializeBody = `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26","capabilities":{},"clientInfo":{"name":"iso9001-test","version":"1.0.0"}}}`
+
+// post sends initializeBody with the headers and returns the response
+func post(t *testing.T, url string, headers map[string]string) *http.Response {
+	t.Helper()
+	request, err := http.NewRequest(http.MethodPost, url, strings.NewReader(initializeBody))
+	if err != nil {
+		t.Fatal(err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+	for name, value := range headers {
+		request.Header.Set(name, value)
+	}
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	response.Body.Close()
+	return response
+}
+
+func TestHTTPAuthentication(t *testing.T) {
+	s := newTestHTTPServer(t, iso9001.RateLimit{})
+	secret := s.createKey(t, "reader", iso9001.APIKeyScopeRead)
+
+	tests := []struct {
+		name    string
+		headers map[string]string
+		status  int
+	}{
+		{"no key", nil, http.StatusUnauthorized},
+		{"unknown bearer key", map[string]string{"Authorization": "Bearer qms_unknown"}, http.StatusUnauthorized},
+		{"unknown x-api-key", map[string]string{"X-API-Key": "qms_unknown"}, http.StatusUnauthorized},
+		{"not a bearer token", map[string]string{"Authorization": "Basic dXNlcjpwYXNz"}, http.StatusUnauthorized},
+		{"bearer key", map[string]string{"Authorization": "Bearer " + secret}, http.StatusOK},
+		{"x-api-key", map[string]string{"X-API-Key": secret}, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := post(t, s.url, tt.headers)
+			if response.StatusCode != tt.status {
+				t.Errorf("Expected status %d, got %d", tt.status, response.StatusCode)
+			}
+			if tt.status == http.StatusUnauthorized && tt.headers == nil && response.Header.Get("WWW-Authenticate") != "Bearer" {
+				t.Error("Expected a Bearer challenge")
+			}
+		})
+	}
+
+	if _, err := s.connect(t, nil); err == nil {
+		t.Error("Expected a session without a key to be refused")
+	}
+	if err := s.keys.Revoke(s.keys.List()[0].ID); err != nil {
+		t.Fatal(err)
+	}
+	if response := post(t, s.url, map[string]string{"Authorization": "Bearer " + secret}); response.StatusCode != http.StatusUnauthorized {
+		t.Errorf("Expected a revoked key to be refused, got status %d", response.StatusCode)
+	}
+}
+
+func TestHTTPScope(t *testing.T) {
+	s := newTestHTTPServer(t, iso9001.RateLimit{})
+	reader := s.connectWithKey(t, s.createKey(t, "reader", iso9001.APIKeyScopeRead))
+	writer := s.connectWithKey(t, s.createKey(t, "writer", iso9001.APIKeyScopeWrite))
+	create := map[string]any{"id": "ACME", "name": "Acme Manufacturing"}
+
+	if text, isError := call(t, reader, "qms_create_organization", create); !isError || !strings.Contains(text, "write scope required") {
+		t.Errorf("Expected a read-scoped key to be denied a write, got %s", text)
+	}
+	if text, isError := call(t, writer, "qms_create_organization", create); isError {
+		t.Fatalf("Expected a write-scoped key to write, got %s", text)
+	}
+	if text, isError := call(t, reader, "qms_export_organization", map[string]any{"organization_id": "ACME"}); isError || !strings.Contains(text, "Acme Manufacturing") {
+		t.Errorf("Expected a read-scoped key to read, got %s", text)
+	}
+
+	// the change is attributed to the key that made it
+	tenant, err := tenantStore.GetTenant("ACME")
+	if err != nil {
+		t.Fatalf("Failed to get tenant: %v", err)
+	}
+	if entries := tenant.Trail.Entries; len(entries) == 0 || entries[len(entries)-1].Actor != "writer" {
+		t.Errorf("Expected the change to be recorded under the key name, got %+v", entries)
+	}
+}
+
+func TestHTTPThrottling(t *testing.T) {
+	s := newTestHTTPServer(t, iso9001.RateLimit{Rate: 0.01, Burst: 2})
+	headers := map[string]string{"Authorization": "Bearer " + s.createKey(t, "reader", iso9001.APIKeyScopeRead)}
+
+	for i := 0; i < 2; i++ {
+		if response := post(t, s.url, headers); response.StatusCode != http.StatusOK {
+			t.Fatalf("Expected request %d within the burst to pass, got status %d", i+1, response.StatusCode)
+		}
+	}
+	response := post(t, s.url, headers)
+	if response.StatusCode != http.StatusTooManyRequests || response.Header.Get("Retry-After") == "" {
+		t.Errorf("Expected the request beyond the burst to be throttled with Retry-After, got status %d", response.StatusCode)
+	}
+	// requests with unknown keys count against the client address too
+	if response := post(t, s.url, map[string]string{"Authorization": "Bearer qms_unknown"}); response.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("Expected guessing keys to be throttled, got status %d", response.StatusCode)
+	}
+}
+
+func TestHTTPKeyRateLimit(t *testing.T) {
+	s := newTestHTTPServer(t, iso9001.RateLimit{})
+	secret := s.createKey(t, "batch", iso9001.APIKeyScopeRead)
+	if err := s.keys.SetRateLimit(s.keys.List()[0].ID, &iso9001.RateLimit{DailyQuota: 1}); err != nil {
+		t.Fatalf("Failed to set rate limit: %v", err)
+	}
+	headers := map[string]string{"Authorization": "Bearer " + secret}
+
+	if response := post(t, s.url, headers); response.StatusCode != http.StatusOK {
+		t.Fatalf("Expected the first request to pass, got status %d", response.StatusCode)
+	}
+	if response := post(t, s.url, headers); response.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("Expected the key's daily quota to be enforced, got status %d", response.StatusCode)
+	}
+	// other keys keep the default limit
+	other := map[string]string{"Authorization": "Bearer " + s.createKey(t, "reader", iso9001.APIKeyScopeRead)}
+	if response := post(t, s.url, other); response.StatusCode != http.StatusOK {
+		t.Errorf("Expected another key to pass, got status %d", response.StatusCode)
+	}
+}
+
+func TestHTTPReadOnly(t *testing.T) {
+	s := newTestHTTPServer(t, iso9001.RateLimit{})
+	writer := s.connectWithKey(t, s.createKey(t, "writer", iso9001.APIKeyScopeWrite))
+	if text, isError := call(t, writer, "qms_create_organization", map[string]any{"id": "ACME", "name": "Acme"}); isError {
+		t.Fatalf("Failed to create organization: %s", text)
+	}
+
+	readOnly = true
+	defer func() { readOnly = false }()
+	text, isError := call(t, writer, "qms_identify_risk", map[string]any{"organization_id": "ACME", "description": "Supplier delivers late"})
+	if !isError || !strings.Contains(text, "read-only mode") {
+		t.Errorf("Expected writes to be disabled, got %s", text)
+	}
+	if text, isError := call(t, writer, "qms_export_organization", map[string]any{"organization_id": "ACME"}); isError {
+		t.Errorf("Expected reads to work in read-only mode, got %s", text)
+	}
+}
+
+func TestHTTPTenantRouting(t *testing.T) {
+	s := newTestHTTPServer(t, iso9001.RateLimit{})
+	saved := accessPolicy
+	defer func() { accessPolicy = saved }()
+	accessPolicy = iso9001.NewAccessPolicy()
+	accessPolicy.Assign("acme-admin", iso9001.RoleQualityManager)
+	accessPolicy.Assign("beta-admin", iso9001.RoleQualityManager)
+	accessPolicy.Tenants["acme-admin"] = []string{"ACME"}
+	accessPolicy.Tenants["beta-admin"] = []string{"BETA"}
+
+	acme := s.connectWithKey(t, s.createKey(t, "acme-admin", iso9001.APIKeyScopeWrite))
+	beta := s.connectWithKey(t, s.createKey(t, "beta-admin", iso9001.APIKeyScopeWrite))
+	for c, id := range map[*client.Client]string{acme: "ACME", beta: "BETA"} {
+		if text, isError := call(t, c, "qms_create_organization", map[string]any{"id": id, "name": id + " Corp"}); isError {
+			t.Fatalf("Failed to create organization %s: %s", id, text)
+		}
+	}
+
+	// organization_id routes a call to its tenant, and the workspace of each session
+	// is the organization it created
+	if text, isError := call(t, acme, "qms_export_organization", nil); isError || !strings.Contains(text, "ACME Corp") {
+		t.Errorf("Expected the session's workspace to be ACME, got %s", text)
+	}
+	if text, isError := call(t, beta, "qms_export_organization", map[string]any{"organization_id": "BETA"}); isError || !strings.Contains(text, "BETA Corp") {
+		t.Errorf("Expected organization_id to route to BETA, got %s", text)
+	}
+
+	// a key is confined to the tenants the access policy assigns its identity
+	if text, isError := call(t, acme, "qms_export_organization", map[string]any{"organization_id": "BETA"}); !isError || !strings.Contains(text, "no access to tenant BETA") {
+		t.Errorf("Expected ACME's key to be denied BETA, got %s", text)
+	}
+	if text, isError := call(t, beta, "qms_create_organization", map[string]any{"id": "ACME", "name": "Takeover"}); !isError || !strings.Contains(text, "no access to tenant ACME") {
+		t.Errorf("Expected BETA's key to be denied a write to ACME, got %s", text)
+	}
+	tenant, err := tenantStore.GetTenant("ACME")
+	if err != nil {
+		t.Fatalf("Failed to get tenant: %v", err)
+	}
+	if tenant.Organization.Name != "ACME Corp" {
+		t.Errorf("Expected ACME to be unchanged, got %q", tenant.Organization.Name)
+	}
+}
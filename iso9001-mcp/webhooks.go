@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"sync"
+
+	"github.com/example/iso9001"
+)
+
+// webhooks posts QMS events such as added findings or approved documents to the
+// endpoints listed in -webhooks. When nil, no events are sent.
+var webhooks *iso9001.WebhookDispatcher
+
+// pendingDeliveries counts the deliveries still running in the background
+var pendingDeliveries sync.WaitGroup
+
+// loadWebhooks reads the webhooks from a JSON file
+func loadWebhooks(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	hooks, err := iso9001.LoadWebhooks(data)
+	if err != nil {
+		return err
+	}
+	webhooks = iso9001.NewWebhookDispatcher(hooks...)
+	return nil
+}
+
+// deliverWebhooks sends the payloads in the background, so a slow endpoint does not
+// hold up the tool call; failed deliveries are logged
+func deliverWebhooks(payloads []iso9001.WebhookPayload) {
+	if webhooks == nil || len(payloads) == 0 {
+		return
+	}
+	pendingDeliveries.Add(1)
+	go func() {
+		defer pendingDeliveries.Done()
+		if err := webhooks.Deliver(context.Background(), payloads); err != nil {
+			log.Printf("Webhook delivery failed: %v", err)
+		}
+	}()
+}
+
+// waitForWebhooks waits for the deliveries still running, or until ctx is done
+func waitForWebhooks(ctx context.Context) {
+	done := make(chan struct{})
+	go func() {
+		pendingDeliveries.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		log.Println("Gave up waiting for webhook deliveries")
+	}
+}
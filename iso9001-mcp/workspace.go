@@ -0,0 +1,226 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/example/iso9001"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// workspaces maps each client session to the organization it works on, so a
+// conversation can build its QMS step by step without passing the organization to
+// every tool. The organization and its risks, audits and documents live in the tenant
+// store; a session only remembers which tenant it has loaded.
+var workspaces = struct {
+	sync.Mutex
+	tenants map[string]string
+}{tenants: make(map[string]string)}
+
+// sessionID returns the ID of the client session a request arrives on, or "" outside
+// a session
+func sessionID(ctx context.Context) string {
+	if session := server.ClientSessionFromContext(ctx); session != nil {
+		return session.SessionID()
+	}
+	return ""
+}
+
+// workspaceTenant returns the tenant the session has loaded, falling back to
+// -organization
+func workspaceTenant(ctx context.Context) string {
+	workspaces.Lock()
+	defer workspaces.Unlock()
+	if tenantID, ok := workspaces.tenants[sessionID(ctx)]; ok {
+		return tenantID
+	}
+	return defaultOrganization
+}
+
+// openWorkspace makes the tenant the workspace of the session
+func openWorkspace(ctx context.Context, tenantID string) {
+	workspaces.Lock()
+	defer workspaces.Unlock()
+	workspaces.tenants[sessionID(ctx)] = tenantID
+}
+
+// closeWorkspace forgets the workspace of a session once the client disconnects; the
+// tenant itself stays in the store
+func closeWorkspace(ctx context.Context, session server.ClientSession) {
+	workspaces.Lock()
+	defer workspaces.Unlock()
+	delete(workspaces.tenants, session.SessionID())
+}
+
+// requestTenant returns the tenant a tool call works on: the one named by
+// organization_id or else the session's workspace
+func requestTenant(ctx context.Context, request mcp.CallToolRequest) string {
+	if tenantID := request.GetString("organization_id", ""); tenantID != "" {
+		return tenantID
+	}
+	return workspaceTenant(ctx)
+}
+
+// viewTenant runs fn on an existing tenant the caller is authorized for, without
+// creating it
+func viewTenant(ctx context.Context, request mcp.CallToolRequest, tenantID string, fn func(tenant *iso9001.Tenant) error) error {
+	if accessPolicy != nil {
+		if err := accessPolicy.AuthorizeTenant(requestIdentity(ctx), tenantID); err != nil {
+			return err
+		}
+	}
+	err := tenantStore.WithTenantContext(ctx, tenantID, fn)
+	if errors.Is(err, iso9001.ErrTenantNotFound) {
+		return fmt.Errorf("no organization %s in the workspace: create one with qms_create_organization or load one with qms_load_organization", tenantID)
+	}
+	return err
+}
+
+// organizationArgument returns the organization a tool changes or evaluates: the one
+// supplied as the organization argument or else a copy of the workspace organization, which
+// the tool saves back with storeOrganization
+func organizationArgument(ctx context.Context, request mcp.CallToolRequest) (*iso9001.Organization, error) {
+	if orgJSON, given, err := argumentJSON(request, "organization"); given {
+		if err != nil {
+			return nil, err
+		}
+		return decodeOrganization(string(orgJSON))
+	}
+
+	var data []byte
+	err := viewTenant(ctx, request, requestTenant(ctx, request), func(tenant *iso9001.Tenant) error {
+		var err error
+		data, err = json.Marshal(tenant.Organization)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return iso9001.LoadOrganizationJSON(data)
+}
+
+// Workspace Handlers
+
+func handleLoadOrganization(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	orgJSON, given, err := argumentJSON(request, "organization")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	tenantID := request.GetString("organization_id", "")
+	if !given && tenantID == "" {
+		return mcp.NewToolResultError("Provide an organization to load or the organization_id of a stored organization"), nil
+	}
+
+	if given {
+		var probe map[string]json.RawMessage
+		if err := json.Unmarshal(orgJSON, &probe); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid organization JSON: %v", err)), nil
+		}
+		if _, envelope := probe["entity_type"]; envelope && probe["data"] != nil {
+			// the whole result of qms_export_organization rather than its data
+			orgJSON, probe = probe["data"], nil
+			if err := json.Unmarshal(orgJSON, &probe); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Invalid organization JSON: %v", err)), nil
+			}
+		}
+
+		if _, exported := probe["organization"]; exported {
+			// a workspace exported by qms_export_organization
+			tenant, err := iso9001.LoadTenantJSON(orgJSON)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Invalid workspace JSON: %v", err)), nil
+			}
+			// the stored audit trail is kept; the one in the export is not trusted
+			imported := len(tenant.Trail.Entries)
+			if err := updateTenantByID(ctx, request, tenant.ID, func(current *iso9001.Tenant) error {
+				current.Replace(tenant)
+				actor := requestIdentity(ctx)
+				if actor == "" {
+					actor = anonymousActor
+				}
+				current.Trail.Record(iso9001.TrailEntry{
+					Actor:      actor,
+					Action:     request.Params.Name,
+					EntityType: iso9001.EntityTypeOrganization,
+					EntityID:   current.ID,
+					Operation:  iso9001.ChangeOperationUpdated,
+					After:      fmt.Sprintf("workspace imported, its %d audit trail entries discarded", imported),
+				})
+				return nil
+			}); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to load organization: %v", err)), nil
+			}
+			tenantID = tenant.ID
+		} else {
+			org, err := decodeOrganization(string(orgJSON))
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if err := storeOrganization(ctx, request, org); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to save organization: %v", err)), nil
+			}
+			tenantID = org.ID
+			recordChange(ctx, iso9001.EntityTypeOrganization, org.ID, iso9001.ChangeOperationUpdated, org)
+		}
+	}
+
+	var summary string
+	err = viewTenant(ctx, request, tenantID, func(tenant *iso9001.Tenant) error {
+		summary = summarizeWorkspace(tenant)
+		return nil
+	})
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	openWorkspace(ctx, tenantID)
+
+	return newToolResult(iso9001.EntityTypeOrganization, tenantID,
+		"Workspace loaded; tools called without organization or organization_id now work on this organization",
+		map[string]string{"summary": summary})
+}
+
+func handleExportOrganization(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	tenantID := requestTenant(ctx, request)
+
+	organizationOnly := request.GetBool("organization_only", false)
+
+	var data []byte
+	err := viewTenant(ctx, request, tenantID, func(tenant *iso9001.Tenant) error {
+		var err error
+		if organizationOnly {
+			data, err = json.Marshal(tenant.Organization)
+		} else {
+			data, err = json.Marshal(tenant)
+		}
+		return err
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to export organization: %v", err)), nil
+	}
+
+	message := fmt.Sprintf("Workspace of organization %s exported", tenantID)
+	if organizationOnly {
+		message = fmt.Sprintf("Organization %s exported", tenantID)
+	}
+	return newToolResult(iso9001.EntityTypeOrganization, tenantID, message, json.RawMessage(data))
+}
+
+// summarizeWorkspace describes what a tenant holds, e.g. after loading it
+func summarizeWorkspace(tenant *iso9001.Tenant) string {
+	name := tenant.ID
+	if tenant.Organization != nil && tenant.Organization.Name != "" {
+		name = fmt.Sprintf("%s (%s)", tenant.Organization.Name, tenant.ID)
+	}
+	counts := []string{
+		fmt.Sprintf("%d risks", len(tenant.Risks.Risks)),
+		fmt.Sprintf("%d audits", len(tenant.Audits.Audits)),
+		fmt.Sprintf("%d documents", len(tenant.Documents.Documents)),
+		fmt.Sprintf("%d objectives", len(tenant.Objectives.Objectives)),
+	}
+	return fmt.Sprintf("%s with %s", name, strings.Join(counts, ", "))
+}
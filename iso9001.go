@@ -10,11 +10,14 @@ const Version = "ISO 9001:2015"
 
 // Organization represents an organization implementing a QMS
 type Organization struct {
+	SchemaVersion int                  `json:"schema_version,omitempty" yaml:"schema_version,omitempty"`
 	ID          string                 `json:"id" yaml:"id"`
 	Name        string                 `json:"name" yaml:"name"`
 	Context     *OrganizationalContext `json:"context" yaml:"context"`
 	Leadership  *Leadership           `json:"leadership" yaml:"leadership"`
 	QMS         *QualityManagementSystem `json:"qms" yaml:"qms"`
+	TimeZone    string                 `json:"time_zone,omitempty" yaml:"time_zone,omitempty"` // IANA name, e.g. "Europe/Berlin"
+	Calendar    *WorkCalendar          `json:"calendar,omitempty" yaml:"calendar,omitempty"`    // working days due dates are counted in
 	Created     time.Time              `json:"created" yaml:"created"`
 	Modified    time.Time              `json:"modified" yaml:"modified"`
 }
@@ -24,6 +27,8 @@ type OrganizationalContext struct {
 	ExternalIssues []Issue `json:"external_issues" yaml:"external_issues"`
 	InternalIssues []Issue `json:"internal_issues" yaml:"internal_issues"`
 	InterestedParties []InterestedParty `json:"interested_parties" yaml:"interested_parties"`
+	PESTLE         *PESTLEAnalysis `json:"pestle,omitempty" yaml:"pestle,omitempty"`
+	SWOT           *SWOTAnalysis   `json:"swot,omitempty" yaml:"swot,omitempty"`
 }
 
 // Issue represents external or internal issues affecting the organization
@@ -58,6 +63,9 @@ type Leadership struct {
 	QualityPolicy *QualityPolicy        `json:"quality_policy" yaml:"quality_policy"`
 	Roles         []OrganizationalRole  `json:"roles" yaml:"roles"`
 	Commitment    []LeadershipCommitment `json:"commitment" yaml:"commitment"`
+	// Delegations are the time-bound delegations of approval authority, revoked ones
+	// included
+	Delegations []Delegation `json:"delegations,omitempty" yaml:"delegations,omitempty"`
 }
 
 // LeadershipCommitment represents demonstrated leadership commitments
@@ -175,6 +183,8 @@ type ObjectiveTarget struct {
 	Metric      string `json:"metric" yaml:"metric"`
 	Value       string `json:"value" yaml:"value"`
 	Unit        string `json:"unit" yaml:"unit"`
+	Baseline    *float64        `json:"baseline,omitempty" yaml:"baseline,omitempty"`   // value of the metric when the objective was set
+	Direction   TargetDirection `json:"direction,omitempty" yaml:"direction,omitempty"` // whether the metric should rise or fall to the target
 }
 
 // ObjectiveTimeline represents the timeline for achieving objectives
@@ -200,12 +210,25 @@ type Risk struct {
 	Description string     `json:"description" yaml:"description"`
 	Causes      []string   `json:"causes" yaml:"causes"`
 	Effects     []string   `json:"effects" yaml:"effects"`
-	Likelihood  RiskLevel  `json:"likelihood" yaml:"likelihood"`
-	Impact      RiskLevel  `json:"impact" yaml:"impact"`
+	Likelihood  RiskLevel  `json:"likelihood" yaml:"likelihood"` // inherent, before mitigation
+	Impact      RiskLevel  `json:"impact" yaml:"impact"`         // inherent, before mitigation
 	Priority    Priority   `json:"priority" yaml:"priority"`
 	Mitigation  []Action   `json:"mitigation" yaml:"mitigation"`
+	Owner       string     `json:"owner,omitempty" yaml:"owner,omitempty"` // person accountable for the risk
 	Status      RiskStatus `json:"status" yaml:"status"`
 	Created     time.Time  `json:"created" yaml:"created"`
+
+	// Residual ratings remaining after mitigation; empty until assessed
+	ResidualLikelihood RiskLevel `json:"residual_likelihood,omitempty" yaml:"residual_likelihood,omitempty"`
+	ResidualImpact     RiskLevel `json:"residual_impact,omitempty" yaml:"residual_impact,omitempty"`
+	ResidualPriority   Priority  `json:"residual_priority,omitempty" yaml:"residual_priority,omitempty"`
+	// ExceedsAppetite flags a risk whose residual exposure is above the risk appetite
+	ExceedsAppetite bool `json:"exceeds_appetite,omitempty" yaml:"exceeds_appetite,omitempty"`
+
+	// ReviewFrequency is how often the risk is reassessed; zero means no scheduled review
+	ReviewFrequency time.Duration `json:"review_frequency,omitempty" yaml:"review_frequency,omitempty"`
+	LastReviewed    time.Time     `json:"last_reviewed,omitempty" yaml:"last_reviewed,omitempty"`
+	NextReviewDate  time.Time     `json:"next_review_date,omitempty" yaml:"next_review_date,omitempty"`
 }
 
 // Opportunity represents identified opportunities (clause 6.1)
@@ -251,6 +274,7 @@ const (
 	RiskStatusAssessed   RiskStatus = "assessed"
 	RiskStatusMitigated  RiskStatus = "mitigated"
 	RiskStatusMonitored  RiskStatus = "monitored"
+	RiskStatusOverdue    RiskStatus = "overdue" // scheduled review has passed
 )
 
 // OpportunityStatus represents the status of opportunity realization
@@ -302,6 +326,7 @@ type Resource struct {
 	Description string       `json:"description" yaml:"description"`
 	Quantity    string       `json:"quantity" yaml:"quantity"`
 	Available   bool         `json:"available" yaml:"available"`
+	CalibrationDue *time.Time `json:"calibration_due,omitempty" yaml:"calibration_due,omitempty"` // next calibration or verification of monitoring resources (clause 7.1.5)
 }
 
 // ResourceType represents different types of resources
@@ -322,6 +347,14 @@ type Person struct {
 	Role        string   `json:"role" yaml:"role"`
 	Competence  []string `json:"competence" yaml:"competence"`
 	Training    []string `json:"training" yaml:"training"`
+	PlannedTraining []PlannedTraining `json:"planned_training,omitempty" yaml:"planned_training,omitempty"`
+}
+
+// PlannedTraining is training a person has to complete by a due date (clause 7.2)
+type PlannedTraining struct {
+	Course    string     `json:"course" yaml:"course"`
+	Due       time.Time  `json:"due" yaml:"due"`
+	Completed *time.Time `json:"completed,omitempty" yaml:"completed,omitempty"`
 }
 
 // OrganizationalRole represents roles and responsibilities (clause 5.3)
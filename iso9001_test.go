@@ -1,6 +1,8 @@
 package iso9001
 
 import (
+	"fmt"
+	"reflect"
 	"testing"
 	"time"
 )
@@ -97,6 +99,46 @@ func TestOrganizationValidation(t *testing.T) {
 	t.Logf("Warnings: %d, Infos: %d", len(result.Warnings), len(result.Infos))
 }
 
+func TestParallelValidationMatchesSequential(t *testing.T) {
+	org := CreateExampleOrganization()
+
+	sequential := ValidateOrganization(org)
+	for _, workers := range []int{2, 4, 32} {
+		parallel := ValidateOrganizationWithOptions(org, ValidationOptions{Workers: workers})
+		if !reflect.DeepEqual(sequential, parallel) {
+			t.Errorf("Expected parallel validation with %d workers to match sequential result", workers)
+		}
+	}
+}
+
+func TestValidationProgress(t *testing.T) {
+	org := CreateExampleOrganization()
+	clauses := map[string]bool{}
+	for _, rule := range DefaultRules.Rules() {
+		clauses[rule.Clause] = true
+	}
+
+	for _, workers := range []int{0, 4} {
+		var reported []string
+		last := 0
+		ValidateOrganizationWithOptions(org, ValidationOptions{Workers: workers, Progress: func(clause string, checked, total int) {
+			if checked != last+1 || total != len(clauses) {
+				t.Errorf("Expected progress %d of %d, got %d of %d", last+1, len(clauses), checked, total)
+			}
+			last = checked
+			reported = append(reported, clause)
+		}})
+		if len(reported) != len(clauses) {
+			t.Errorf("Expected progress for %d clauses with %d workers, got %d", len(clauses), workers, len(reported))
+		}
+		for _, clause := range reported {
+			if !clauses[clause] {
+				t.Errorf("Unexpected progress for clause %s", clause)
+			}
+		}
+	}
+}
+
 func TestDocumentationManager(t *testing.T) {
 	dm := NewDocumentationManager()
 
@@ -183,6 +225,42 @@ func TestRiskManager(t *testing.T) {
 	}
 }
 
+func TestRiskRegisterIncrementalUpdates(t *testing.T) {
+	rm := NewRiskManager()
+	levels := []RiskLevel{RiskLevelLow, RiskLevelMedium, RiskLevelHigh, RiskLevelVeryHigh}
+
+	for i := 0; i < 12; i++ {
+		id := fmt.Sprintf("RISK-%03d", i)
+		if err := rm.IdentifyRisk(&Risk{ID: id, Description: "Register test risk"}); err != nil {
+			t.Fatalf("Failed to identify risk: %v", err)
+		}
+		if err := rm.AssessRisk(id, levels[i%len(levels)], levels[(i/2)%len(levels)]); err != nil {
+			t.Fatalf("Failed to assess risk: %v", err)
+		}
+	}
+
+	entries := rm.Register.OrganizationRisks
+	if len(entries) != 12 {
+		t.Fatalf("Expected 12 register entries, got %d", len(entries))
+	}
+	for i := 1; i < len(entries); i++ {
+		if entries[i-1].RiskScore < entries[i].RiskScore {
+			t.Fatalf("Register not sorted by score at position %d", i)
+		}
+	}
+	if len(rm.Register.CriticalRisks) != criticalRiskLimit {
+		t.Errorf("Expected %d critical risks, got %d", criticalRiskLimit, len(rm.Register.CriticalRisks))
+	}
+
+	incremental := append([]RiskEntry(nil), entries...)
+	rm.RebuildRegister()
+	for i := range incremental {
+		if incremental[i].RiskScore != rm.Register.OrganizationRisks[i].RiskScore {
+			t.Errorf("Incremental register differs from rebuilt register at position %d", i)
+		}
+	}
+}
+
 func TestQualityObjectivesManager(t *testing.T) {
 	qom := NewQualityObjectivesManager()
 
@@ -330,20 +408,91 @@ func TestComplianceReport(t *testing.T) {
 	t.Logf("  Recommendations: %d", len(report.Recommendations))
 }
 
-func BenchmarkOrganizationValidation(b *testing.B) {
-	org := CreateExampleOrganization()
+func TestFindingDueDatePolicy(t *testing.T) {
+	am := NewAuditManager()
+	am.DueDatePolicy = &DueDatePolicy{Days: map[FindingSeverity]int{SeverityCritical: 3}, DefaultDays: 45}
+
+	audits := []*Audit{
+		{ID: "AUDIT-001", Title: "Manager policy", Scope: AuditScope{Description: "Scope"}},
+		{ID: "AUDIT-002", Title: "Audit override", Scope: AuditScope{Description: "Scope"}, DueDatePolicy: &DueDatePolicy{DefaultDays: 10}},
+	}
+	for _, audit := range audits {
+		if err := am.CreateAudit(audit); err != nil {
+			t.Fatalf("Failed to create audit: %v", err)
+		}
+	}
+
+	if err := am.AddFinding("AUDIT-001", AuditFinding{ID: "F-001", Severity: SeverityCritical}); err != nil {
+		t.Fatalf("Failed to add finding: %v", err)
+	}
+	if err := am.AddFinding("AUDIT-001", AuditFinding{ID: "F-002", Severity: SeverityMinor}); err != nil {
+		t.Fatalf("Failed to add finding: %v", err)
+	}
+	if err := am.AddFinding("AUDIT-002", AuditFinding{ID: "F-003", Severity: SeverityCritical}); err != nil {
+		t.Fatalf("Failed to add finding: %v", err)
+	}
 
-	b.ResetTimer()
-	for i := 0; i < b.N; i++ {
-		ValidateOrganization(org)
+	for _, tc := range []struct {
+		finding AuditFinding
+		days    int
+	}{
+		{audits[0].Findings[0], 3},
+		{audits[0].Findings[1], 45},
+		{audits[1].Findings[0], 10},
+	} {
+		want := tc.finding.Created.AddDate(0, 0, tc.days)
+		if !tc.finding.DueDate.Equal(want) {
+			t.Errorf("Finding %s: expected due date %v, got %v", tc.finding.ID, want, tc.finding.DueDate)
+		}
 	}
 }
 
-func BenchmarkComplianceScore(b *testing.B) {
-	org := CreateExampleOrganization()
+func TestScopeExclusionConformance(t *testing.T) {
+	org := &Organization{
+		QMS: &QualityManagementSystem{
+			Scope: &QMSScope{
+				Description: "Scope",
+				Products:    []string{"Widgets"},
+				Exclusions: []Exclusion{
+					{Clause: "8.3", Justification: "No design activities"},
+					{Clause: "Clause 7.1.5", Justification: "No measuring equipment"},
+					{Clause: "8.7", Justification: "No nonconforming outputs"},
+					{Clause: "12.1", Justification: "Unknown clause"},
+				},
+			},
+		},
+	}
 
-	b.ResetTimer()
-	for i := 0; i < b.N; i++ {
-		GetComplianceScore(org)
+	result := &ValidationResult{Valid: true}
+	for _, rule := range DefaultRules.Rules() {
+		if rule.Clause == "4.3" {
+			rule.check(org, result)
+		}
+	}
+	if len(result.Errors) != 2 {
+		t.Errorf("Expected errors for clause 7.1.5 and 12.1 exclusions, got %+v", result.Errors)
+	}
+	if len(result.Warnings) != 1 || result.Warnings[0].Field != "exclusion_2_clause" {
+		t.Errorf("Expected a warning for excluding clause 8.7, got %+v", result.Warnings)
+	}
+}
+
+func TestGetUpcomingAudits(t *testing.T) {
+	am := NewAuditManager()
+	for i, days := range []int{20, 3, 60} {
+		audit := &Audit{
+			ID:               fmt.Sprintf("AUDIT-%03d", i+1),
+			Title:            "Planned audit",
+			Scope:            AuditScope{Description: "QMS"},
+			PlannedStartDate: time.Now().AddDate(0, 0, days),
+		}
+		if err := am.CreateAudit(audit); err != nil {
+			t.Fatalf("Failed to create audit: %v", err)
+		}
+	}
+
+	upcoming := am.GetUpcomingAudits(30 * 24 * time.Hour)
+	if len(upcoming) != 2 || upcoming[0].ID != "AUDIT-002" || upcoming[1].ID != "AUDIT-001" {
+		t.Errorf("Expected AUDIT-002 then AUDIT-001, got %v", upcoming)
 	}
 }
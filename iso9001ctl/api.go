@@ -0,0 +1,351 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/example/iso9001"
+)
+
+// maxEntityBody limits the size of an entity sent to the API
+const maxEntityBody = 4 << 20
+
+// statusError is an error answered with a particular HTTP status
+type statusError struct {
+	status int
+	err    error
+}
+
+func (e statusError) Error() string { return e.err.Error() }
+func (e statusError) Unwrap() error { return e.err }
+
+func notFound(entityType, id string) error {
+	return statusError{http.StatusNotFound, fmt.Errorf("%s with ID %s not found", entityType, id)}
+}
+
+// addEntityRoutes exposes the entity collections of the organizations in the store,
+// a validation of organizations sent by clients and, when write is set, the changes
+// to organizations, risks, audits and documents
+func addEntityRoutes(mux *http.ServeMux, store *iso9001.TenantStore, write bool) {
+	mux.HandleFunc("GET /organizations/{id}/{collection}", func(w http.ResponseWriter, r *http.Request) {
+		tenantView(store, w, r, func(tenant *iso9001.Tenant) (interface{}, error) {
+			entities, ok := tenant.Collection(r.PathValue("collection"))
+			if !ok {
+				return nil, statusError{http.StatusNotFound, fmt.Errorf("unknown collection %s", r.PathValue("collection"))}
+			}
+			return entities, nil
+		})
+	})
+	mux.HandleFunc("GET /organizations/{id}/{collection}/{entity}", func(w http.ResponseWriter, r *http.Request) {
+		tenantView(store, w, r, func(tenant *iso9001.Tenant) (interface{}, error) {
+			entity, ok := tenant.Lookup(r.PathValue("collection"), r.PathValue("entity"))
+			if !ok {
+				return nil, notFound(r.PathValue("collection"), r.PathValue("entity"))
+			}
+			return entity, nil
+		})
+	})
+	mux.HandleFunc("POST /validation", validationHandler)
+	if !write {
+		return
+	}
+
+	mux.HandleFunc("POST /organizations", createOrganizationHandler(store))
+	mux.HandleFunc("PUT /organizations/{id}", changeHandler(store, "update organization", func(tenant *iso9001.Tenant, r *http.Request) (int, interface{}, error) {
+		var org iso9001.Organization
+		if err := decodeEntity(r, &org); err != nil {
+			return 0, nil, err
+		}
+		org.ID = tenant.ID
+		if tenant.Organization != nil && tenant.Organization.ID != "" {
+			org.ID = tenant.Organization.ID
+			org.Created = tenant.Organization.Created
+		}
+		org.Modified = time.Now()
+		tenant.Organization = &org
+		return http.StatusOK, tenant.Organization, nil
+	}))
+
+	mux.HandleFunc("POST /organizations/{id}/risks", changeHandler(store, "identify risk", func(tenant *iso9001.Tenant, r *http.Request) (int, interface{}, error) {
+		var risk iso9001.Risk
+		if err := decodeEntity(r, &risk); err != nil {
+			return 0, nil, err
+		}
+		likelihood, impact := risk.Likelihood, risk.Impact
+		if err := tenant.Risks.IdentifyRisk(&risk); err != nil {
+			return 0, nil, err
+		}
+		if likelihood != "" && impact != "" {
+			if err := tenant.Risks.AssessRisk(risk.ID, likelihood, impact); err != nil {
+				return 0, nil, err
+			}
+		}
+		return http.StatusCreated, &risk, nil
+	}))
+	mux.HandleFunc("PUT /organizations/{id}/risks/{entity}", changeHandler(store, "update risk", func(tenant *iso9001.Tenant, r *http.Request) (int, interface{}, error) {
+		id := r.PathValue("entity")
+		if _, exists := tenant.Risks.Risks[id]; !exists {
+			return 0, nil, notFound(iso9001.EntityTypeRisk, id)
+		}
+		var risk iso9001.Risk
+		if err := decodeEntity(r, &risk); err != nil {
+			return 0, nil, err
+		}
+		if err := tenant.Risks.UpdateRisk(id, &risk); err != nil {
+			return 0, nil, err
+		}
+		return http.StatusOK, &risk, nil
+	}))
+	mux.HandleFunc("DELETE /organizations/{id}/risks/{entity}", changeHandler(store, "remove risk", func(tenant *iso9001.Tenant, r *http.Request) (int, interface{}, error) {
+		id := r.PathValue("entity")
+		if _, exists := tenant.Risks.Risks[id]; !exists {
+			return 0, nil, notFound(iso9001.EntityTypeRisk, id)
+		}
+		return http.StatusNoContent, nil, tenant.Risks.RemoveRisk(id)
+	}))
+
+	mux.HandleFunc("POST /organizations/{id}/audits", changeHandler(store, "create audit", func(tenant *iso9001.Tenant, r *http.Request) (int, interface{}, error) {
+		var audit iso9001.Audit
+		if err := decodeEntity(r, &audit); err != nil {
+			return 0, nil, err
+		}
+		if err := tenant.Audits.CreateAudit(&audit); err != nil {
+			return 0, nil, err
+		}
+		return http.StatusCreated, &audit, nil
+	}))
+	mux.HandleFunc("PUT /organizations/{id}/audits/{entity}", changeHandler(store, "update audit", func(tenant *iso9001.Tenant, r *http.Request) (int, interface{}, error) {
+		id := r.PathValue("entity")
+		if _, exists := tenant.Audits.Audits[id]; !exists {
+			return 0, nil, notFound(iso9001.EntityTypeAudit, id)
+		}
+		var audit iso9001.Audit
+		if err := decodeEntity(r, &audit); err != nil {
+			return 0, nil, err
+		}
+		if err := tenant.Audits.UpdateAudit(id, &audit); err != nil {
+			return 0, nil, err
+		}
+		return http.StatusOK, &audit, nil
+	}))
+	mux.HandleFunc("DELETE /organizations/{id}/audits/{entity}", changeHandler(store, "cancel audit", func(tenant *iso9001.Tenant, r *http.Request) (int, interface{}, error) {
+		id := r.PathValue("entity")
+		if _, exists := tenant.Audits.Audits[id]; !exists {
+			return 0, nil, notFound(iso9001.EntityTypeAudit, id)
+		}
+		return http.StatusNoContent, nil, tenant.Audits.RemoveAudit(id)
+	}))
+
+	mux.HandleFunc("POST /organizations/{id}/documents", changeHandler(store, "add document", func(tenant *iso9001.Tenant, r *http.Request) (int, interface{}, error) {
+		var doc iso9001.DocumentedInformation
+		if err := decodeEntity(r, &doc); err != nil {
+			return 0, nil, err
+		}
+		if err := tenant.Documents.AddDocument(&doc); err != nil {
+			return 0, nil, err
+		}
+		return http.StatusCreated, &doc, nil
+	}))
+	// A document update is a new version, summarized by ?summary= and, for a major
+	// change, ?major=true
+	mux.HandleFunc("PUT /organizations/{id}/documents/{entity}", changeHandler(store, "update document", func(tenant *iso9001.Tenant, r *http.Request) (int, interface{}, error) {
+		id := r.PathValue("entity")
+		if _, exists := tenant.Documents.Documents[id]; !exists {
+			return 0, nil, notFound(iso9001.EntityTypeDocument, id)
+		}
+		change := iso9001.VersionChange{Summary: r.URL.Query().Get("summary")}
+		if major := r.URL.Query().Get("major"); major != "" {
+			var err error
+			if change.Major, err = strconv.ParseBool(major); err != nil {
+				return 0, nil, fmt.Errorf("invalid major: %w", err)
+			}
+		}
+		var doc iso9001.DocumentedInformation
+		if err := decodeEntity(r, &doc); err != nil {
+			return 0, nil, err
+		}
+		if err := tenant.Documents.UpdateDocument(id, &doc, change); err != nil {
+			return 0, nil, err
+		}
+		return http.StatusOK, &doc, nil
+	}))
+	// Documented information is retained (clause 7.5.3), so deleting a document
+	// archives it, for the reason given as ?reason=
+	mux.HandleFunc("DELETE /organizations/{id}/documents/{entity}", changeHandler(store, "archive document", func(tenant *iso9001.Tenant, r *http.Request) (int, interface{}, error) {
+		id := r.PathValue("entity")
+		doc, exists := tenant.Documents.Documents[id]
+		if !exists {
+			return 0, nil, notFound(iso9001.EntityTypeDocument, id)
+		}
+		reason := r.URL.Query().Get("reason")
+		if reason == "" {
+			reason = "deleted through the API"
+		}
+		if err := tenant.Documents.ArchiveDocument(id, reason); err != nil {
+			return 0, nil, err
+		}
+		return http.StatusOK, doc, nil
+	}))
+}
+
+// validationHandler validates an organization sent in the request body without
+// storing it, with messages in the language given as ?lang=
+func validationHandler(w http.ResponseWriter, r *http.Request) {
+	var org iso9001.Organization
+	if err := decodeEntity(r, &org); err != nil {
+		writeError(w, err)
+		return
+	}
+	result := iso9001.ValidateOrganization(&org).Localize(iso9001.ParseLocale(r.URL.Query().Get("lang")))
+	writeJSON(w, http.StatusOK, result)
+}
+
+// createOrganizationHandler creates a tenant for the organization in the request body
+func createOrganizationHandler(store *iso9001.TenantStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !requireWriteScope(w, r) {
+			return
+		}
+		var org iso9001.Organization
+		if err := decodeEntity(r, &org); err != nil {
+			writeError(w, err)
+			return
+		}
+		if org.ID == "" {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": "organization must have an ID"})
+			return
+		}
+		org.Created = time.Now()
+		org.Modified = org.Created
+		ctx := iso9001.WithActor(r.Context(), apiActor(r))
+		if _, err := store.GetTenantContext(ctx, org.ID); err == nil {
+			writeJSON(w, http.StatusConflict, map[string]string{"error": fmt.Sprintf("organization with ID %s already exists", org.ID)})
+			return
+		} else if !errors.Is(err, iso9001.ErrTenantNotFound) {
+			writeError(w, err)
+			return
+		}
+		if _, err := store.CreateTenant(org.ID); err != nil {
+			writeJSON(w, http.StatusConflict, map[string]string{"error": err.Error()})
+			return
+		}
+
+		err := store.WithTenantContext(ctx, org.ID, func(tenant *iso9001.Tenant) error {
+			return tenant.ChangeContext(ctx, "create organization", func(tenant *iso9001.Tenant) error {
+				tenant.Organization = &org
+				return nil
+			})
+		})
+		if err == nil {
+			err = store.FlushTenantContext(ctx, org.ID)
+		}
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		w.Header().Set("Location", "/organizations/"+org.ID)
+		writeJSON(w, http.StatusCreated, &org)
+	}
+}
+
+// changeHandler runs apply on the tenant named in the request path, records the
+// change in the tenant's audit trail and saves the store. apply returns the status and
+// body of the response.
+func changeHandler(store *iso9001.TenantStore, action string, apply func(tenant *iso9001.Tenant, r *http.Request) (int, interface{}, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !requireWriteScope(w, r) {
+			return
+		}
+		ctx := iso9001.WithActor(r.Context(), apiActor(r))
+		var status int
+		var body interface{}
+		err := store.WithTenantContext(ctx, r.PathValue("id"), func(tenant *iso9001.Tenant) error {
+			return tenant.ChangeContext(ctx, action, func(tenant *iso9001.Tenant) error {
+				var err error
+				status, body, err = apply(tenant, r)
+				return err
+			})
+		})
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		if err := store.FlushTenantContext(ctx, r.PathValue("id")); err != nil {
+			writeJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+			return
+		}
+		if status == http.StatusNoContent {
+			w.WriteHeader(status)
+			return
+		}
+		writeJSON(w, status, body)
+	}
+}
+
+// tenantView renders what view returns for the tenant named in the request path
+func tenantView(store *iso9001.TenantStore, w http.ResponseWriter, r *http.Request, view func(tenant *iso9001.Tenant) (interface{}, error)) {
+	var body interface{}
+	err := store.WithTenantContext(r.Context(), r.PathValue("id"), func(tenant *iso9001.Tenant) error {
+		var err error
+		body, err = view(tenant)
+		return err
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, body)
+}
+
+// requireWriteScope rejects requests made with an API key that may only read
+func requireWriteScope(w http.ResponseWriter, r *http.Request) bool {
+	if key, ok := r.Context().Value(apiKeyContextKey{}).(*iso9001.APIKey); ok && !key.Scope.Allows(iso9001.APIKeyScopeWrite) {
+		writeJSON(w, http.StatusForbidden, map[string]string{"error": fmt.Sprintf("%v: write scope required", iso9001.ErrInsufficientScope)})
+		return false
+	}
+	return true
+}
+
+// apiActor names the client in the audit trail: the name of its API key, if any
+func apiActor(r *http.Request) string {
+	if key, ok := r.Context().Value(apiKeyContextKey{}).(*iso9001.APIKey); ok {
+		return key.Name
+	}
+	return "api"
+}
+
+// decodeEntity reads the JSON entity in the request body
+func decodeEntity(r *http.Request, v interface{}) error {
+	if err := json.NewDecoder(http.MaxBytesReader(nil, r.Body, maxEntityBody)).Decode(v); err != nil {
+		return statusError{http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err)}
+	}
+	return nil
+}
+
+// writeError answers an error with its status. Changes that conflict with the state of
+// an entity, such as a duplicate ID or a status the entity cannot move to, are
+// conflicts, and requests whose context ends before they are done are unavailable;
+// other errors reject the request.
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusBadRequest
+	var statusErr statusError
+	var duplicate *iso9001.DuplicateIDError
+	var transition *iso9001.InvalidTransitionError
+	switch {
+	case errors.As(err, &statusErr):
+		status = statusErr.status
+	case errors.Is(err, iso9001.ErrTenantNotFound):
+		status = http.StatusNotFound
+	case errors.As(err, &duplicate), errors.As(err, &transition),
+		errors.Is(err, iso9001.ErrAuditStarted), errors.Is(err, iso9001.ErrDocumentReleased):
+		status = http.StatusConflict
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		status = http.StatusServiceUnavailable
+	}
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/example/iso9001"
+)
+
+func runAPIKey(args []string) error {
+	if len(args) == 0 {
+		return usageError{"expected a subcommand: create, rotate, revoke, limit or list"}
+	}
+
+	fs := newFlagSet("apikey " + args[0])
+	dir, _ := storeFlags(fs)
+	switch args[0] {
+	case "create":
+		name := fs.String("name", "", "Name describing who or what uses the key")
+		scope := fs.String("scope", string(iso9001.APIKeyScopeRead), "Scope of the key: read, write or admin")
+		ttl := fs.Duration("ttl", 0, "Lifetime of the key, e.g. 2160h; zero never expires")
+		if err := fs.Parse(args[1:]); err != nil {
+			return usageError{err.Error()}
+		}
+		if *name == "" {
+			return usageError{"-name is required"}
+		}
+		keyScope, err := iso9001.ParseAPIKeyScope(*scope)
+		if err != nil {
+			return usageError{err.Error()}
+		}
+		keys, err := iso9001.NewAPIKeyManager(iso9001.APIKeyFile(*dir))
+		if err != nil {
+			return err
+		}
+		secret, key, err := keys.Create(*name, keyScope, *ttl)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "Created %s (%s) with scope %s. Store the key now; it is not shown again.\n", key.ID, key.Name, key.Scope)
+		fmt.Println(secret)
+		return nil
+
+	case "rotate", "revoke":
+		if err := fs.Parse(args[1:]); err != nil {
+			return usageError{err.Error()}
+		}
+		if fs.NArg() != 1 {
+			return usageError{fmt.Sprintf("expected one key ID, got %d arguments", fs.NArg())}
+		}
+		keys, err := iso9001.NewAPIKeyManager(iso9001.APIKeyFile(*dir))
+		if err != nil {
+			return err
+		}
+		if args[0] == "revoke" {
+			if err := keys.Revoke(fs.Arg(0)); err != nil {
+				return err
+			}
+			fmt.Fprintf(os.Stderr, "Revoked %s\n", fs.Arg(0))
+			return nil
+		}
+		secret, err := keys.Rotate(fs.Arg(0))
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "Rotated %s. The previous key no longer works.\n", fs.Arg(0))
+		fmt.Println(secret)
+		return nil
+
+	case "limit":
+		rate := fs.Float64("rate", 0, "Sustained requests per second; zero is unlimited")
+		burst := fs.Int("burst", 0, "Requests allowed at once (default the rate, at least 1)")
+		quota := fs.Int("daily-quota", 0, "Operations per day; zero is unlimited")
+		reset := fs.Bool("default", false, "Remove the key's limits so the serve defaults apply")
+		if err := fs.Parse(args[1:]); err != nil {
+			return usageError{err.Error()}
+		}
+		if fs.NArg() != 1 {
+			return usageError{fmt.Sprintf("expected one key ID, got %d arguments", fs.NArg())}
+		}
+		keys, err := iso9001.NewAPIKeyManager(iso9001.APIKeyFile(*dir))
+		if err != nil {
+			return err
+		}
+		var limit *iso9001.RateLimit
+		if !*reset {
+			limit = &iso9001.RateLimit{Rate: *rate, Burst: *burst, DailyQuota: *quota}
+		}
+		if err := keys.SetRateLimit(fs.Arg(0), limit); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "Updated limits of %s\n", fs.Arg(0))
+		return nil
+
+	case "list":
+		if err := fs.Parse(args[1:]); err != nil {
+			return usageError{err.Error()}
+		}
+		keys, err := iso9001.NewAPIKeyManager(iso9001.APIKeyFile(*dir))
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tNAME\tSCOPE\tHINT\tCREATED\tLIMITS\tSTATUS")
+		now := time.Now()
+		for _, key := range keys.List() {
+			status := "active"
+			switch {
+			case key.Revoked != nil:
+				status = "revoked " + key.Revoked.Format("2006-01-02")
+			case !key.Active(now):
+				status = "expired " + key.Expires.Format("2006-01-02")
+			case key.Expires != nil:
+				status = "expires " + key.Expires.Format("2006-01-02")
+			}
+			limits := "default"
+			if key.RateLimit != nil {
+				limits = fmt.Sprintf("%g/s, %d/day", key.RateLimit.Rate, key.RateLimit.DailyQuota)
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s...\t%s\t%s\t%s\n", key.ID, key.Name, key.Scope, key.Hint, key.Created.Format("2006-01-02"), limits, status)
+		}
+		return w.Flush()
+	}
+	return usageError{fmt.Sprintf("unknown subcommand %q: expected create, rotate, revoke, limit or list", args[0])}
+}
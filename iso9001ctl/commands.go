@@ -0,0 +1,440 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/example/iso9001"
+	"github.com/example/iso9001ctl/lint"
+)
+
+// defaultStoreDir is used when neither -store nor ISO9001_STORE is set
+const defaultStoreDir = "qms-store"
+
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	return fs
+}
+
+// parseArgs parses flags and returns the single file argument, which defaults to
+// stdin when optional
+func parseArgs(fs *flag.FlagSet, args []string) (string, error) {
+	if err := fs.Parse(args); err != nil {
+		return "", usageError{err.Error()}
+	}
+	switch fs.NArg() {
+	case 0:
+		return "", usageError{"missing organization file (use - for stdin)"}
+	case 1:
+		return fs.Arg(0), nil
+	default:
+		return "", usageError{fmt.Sprintf("expected one organization file, got %d arguments", fs.NArg())}
+	}
+}
+
+// storeFlags adds the flags selecting the shared tenant store
+func storeFlags(fs *flag.FlagSet) (dir, tenant *string) {
+	defaultDir := os.Getenv("ISO9001_STORE")
+	if defaultDir == "" {
+		defaultDir = defaultStoreDir
+	}
+	dir = fs.String("store", defaultDir, "Directory of the shared tenant store (env ISO9001_STORE)")
+	tenant = fs.String("tenant", "", "Tenant ID; defaults to the organization ID")
+	return dir, tenant
+}
+
+// langFlag adds the flag selecting the language of validation messages
+func langFlag(fs *flag.FlagSet) *string {
+	defaultLang := os.Getenv("ISO9001_LANG")
+	if defaultLang == "" {
+		defaultLang = string(iso9001.LocaleEnglish)
+	}
+	return fs.String("lang", defaultLang, "Language of messages: en, de, fr or es (env ISO9001_LANG)")
+}
+
+// rulesFlag adds the flag selecting a file of company-specific validation rules
+func rulesFlag(fs *flag.FlagSet) *string {
+	return fs.String("rules", "", "YAML rule set adding custom rules or disabling built-in ones")
+}
+
+// loadRules loads a rule set file into the default rules, if one is given
+func loadRules(path string) error {
+	if path == "" {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := iso9001.DefaultRules.LoadYAML(data); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+func openStore(dir string) (*iso9001.TenantStore, error) {
+	backend, err := iso9001.NewFileTenantBackend(dir)
+	if err != nil {
+		return nil, err
+	}
+	return newStore(dir, backend)
+}
+
+// newStore creates a store over backend whose audit trails are keyed with the trail
+// key of dir
+func newStore(dir string, backend iso9001.TenantBackend) (*iso9001.TenantStore, error) {
+	key, err := iso9001.LoadTrailKey(iso9001.TrailKeyFile(dir))
+	if err != nil {
+		return nil, err
+	}
+	store := iso9001.NewTenantStore(backend, 0)
+	store.SetTrailKey(key)
+	return store, nil
+}
+
+func runValidate(args []string) error {
+	fs := newFlagSet("validate")
+	format := fs.String("format", formatText, "Output format: text, json or yaml")
+	strict := fs.Bool("strict", false, "Reject unknown or misspelled keys")
+	clauses := fs.Bool("clauses", false, "Also print the score of each top-level clause")
+	failOn := fs.String("fail-on", iso9001.SeverityError, "Exit with status 1 on issues of this severity or worse: error, warning, info or none")
+	lang := langFlag(fs)
+	rules := rulesFlag(fs)
+	path, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	switch *failOn {
+	case iso9001.SeverityError, iso9001.SeverityWarning, iso9001.SeverityInfo, "none":
+	default:
+		return usageError{fmt.Sprintf("unknown -fail-on severity %q (use error, warning, info or none)", *failOn)}
+	}
+	if err := loadRules(*rules); err != nil {
+		return err
+	}
+
+	org, err := loadOrganization(path, *strict)
+	if err != nil {
+		return err
+	}
+
+	result := iso9001.ValidateOrganization(org).Localize(iso9001.ParseLocale(*lang))
+	var output interface{} = result
+	var scores []iso9001.ClauseCompliance
+	if *clauses {
+		scores = clauseScores(org)
+		output = struct {
+			*iso9001.ValidationResult `yaml:",inline"`
+			Clauses                   []iso9001.ClauseCompliance `json:"clauses" yaml:"clauses"`
+		}{result, scores}
+	}
+	err = writeOutput("", *format, output, func(w io.Writer) {
+		for _, issues := range [][]iso9001.ValidationError{result.Errors, result.Warnings, result.Infos} {
+			for _, issue := range issues {
+				fmt.Fprintln(w, issue.Error())
+			}
+		}
+		fmt.Fprintf(w, "%d errors, %d warnings, %d infos\n", len(result.Errors), len(result.Warnings), len(result.Infos))
+		writeClauseScores(w, scores)
+	})
+	if err != nil {
+		return err
+	}
+	if failsAt(result, *failOn) {
+		return errFailed
+	}
+	return nil
+}
+
+// failsAt reports whether a validation result has issues of the given severity or
+// worse. Nothing fails at severity none.
+func failsAt(result *iso9001.ValidationResult, severity string) bool {
+	switch severity {
+	case iso9001.SeverityInfo:
+		return !result.Valid || len(result.Warnings) > 0 || len(result.Infos) > 0
+	case iso9001.SeverityWarning:
+		return !result.Valid || len(result.Warnings) > 0
+	case iso9001.SeverityError:
+		return !result.Valid
+	}
+	return false
+}
+
+// clauseScores returns the compliance of each top-level clause of an organization
+func clauseScores(org *iso9001.Organization) []iso9001.ClauseCompliance {
+	return iso9001.TakeComplianceSnapshot(org, nil, time.Now()).Clauses
+}
+
+// writeClauseScores prints one line per top-level clause
+func writeClauseScores(w io.Writer, scores []iso9001.ClauseCompliance) {
+	for _, clause := range scores {
+		fmt.Fprintf(w, "%-3s %5.1f  %s (%d errors, %d warnings)\n", clause.Clause, clause.Score, clause.Title, clause.Errors, clause.Warnings)
+	}
+}
+
+func runScore(args []string) error {
+	fs := newFlagSet("score")
+	format := fs.String("format", formatText, "Output format: text, json or yaml")
+	minScore := fs.Float64("min", 0, "Exit with status 1 when the score is below this value")
+	clauses := fs.Bool("clauses", false, "Also print the score of each top-level clause")
+	rules := rulesFlag(fs)
+	path, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := loadRules(*rules); err != nil {
+		return err
+	}
+
+	org, err := loadOrganization(path, false)
+	if err != nil {
+		return err
+	}
+
+	score := iso9001.GetComplianceScore(org)
+	output := struct {
+		OrganizationID string                     `json:"organization_id" yaml:"organization_id"`
+		Score          float64                    `json:"score" yaml:"score"`
+		Clauses        []iso9001.ClauseCompliance `json:"clauses,omitempty" yaml:"clauses,omitempty"`
+	}{OrganizationID: org.ID, Score: score}
+	if *clauses {
+		output.Clauses = clauseScores(org)
+	}
+	err = writeOutput("", *format, output, func(w io.Writer) {
+		fmt.Fprintf(w, "%.1f\n", score)
+		writeClauseScores(w, output.Clauses)
+	})
+	if err != nil {
+		return err
+	}
+	if score < *minScore {
+		return errFailed
+	}
+	return nil
+}
+
+func runReport(args []string) (err error) {
+	fs := newFlagSet("report")
+	format := fs.String("format", formatText, "Output format: text, json or yaml")
+	out := fs.String("o", "", "Write the report to a file instead of stdout")
+	templateFile := fs.String("template", "", "Render the text report with this text/template file")
+	signKey := fs.String("sign", "", "Sign the report with this Ed25519 private key (PEM), writing <o>.sig")
+	keyID := fs.String("key-id", "", "Key ID recorded in the signature")
+	lang := langFlag(fs)
+	path, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if *signKey != "" && (*out == "" || *out == "-") {
+		return usageError{"-sign requires -o"}
+	}
+	if *signKey != "" {
+		defer func() {
+			if err == nil {
+				err = signFile(*out, *signKey, *keyID)
+			}
+		}()
+	}
+
+	org, err := loadOrganization(path, false)
+	if err != nil {
+		return err
+	}
+
+	report := iso9001.GenerateLocalizedComplianceReport(org, iso9001.ParseLocale(*lang))
+	if *templateFile != "" {
+		text, err := os.ReadFile(*templateFile)
+		if err != nil {
+			return err
+		}
+		templates := iso9001.NewReportTemplates()
+		if err := templates.SetOrganizationTemplate(org.ID, iso9001.ReportCompliance, string(text)); err != nil {
+			return err
+		}
+		var rendered strings.Builder
+		if err := templates.RenderComplianceReport(&rendered, org, report); err != nil {
+			return err
+		}
+		return writeOutput(*out, formatText, nil, func(w io.Writer) {
+			io.WriteString(w, rendered.String())
+		})
+	}
+	return writeOutput(*out, *format, report, func(w io.Writer) {
+		fmt.Fprintf(w, "Compliance report for %s\n", org.Name)
+		fmt.Fprintf(w, "Score: %.1f (%s)\n", report.ComplianceScore, report.OverallCompliance)
+		if len(report.CriticalGaps) > 0 {
+			fmt.Fprintln(w, "\nCritical gaps:")
+			for _, gap := range report.CriticalGaps {
+				fmt.Fprintf(w, "  - [%s] %s\n", gap.Clause, gap.Description)
+			}
+		}
+		if len(report.ImprovementAreas) > 0 {
+			fmt.Fprintln(w, "\nImprovement areas:")
+			for _, area := range report.ImprovementAreas {
+				fmt.Fprintf(w, "  - %s: %s\n", area.Area, area.Description)
+			}
+		}
+		if len(report.Strengths) > 0 {
+			fmt.Fprintln(w, "\nStrengths:")
+			for _, strength := range report.Strengths {
+				fmt.Fprintf(w, "  - %s\n", strength)
+			}
+		}
+		if len(report.Recommendations) > 0 {
+			fmt.Fprintln(w, "\nRecommendations:")
+			for _, recommendation := range report.Recommendations {
+				fmt.Fprintf(w, "  - %s\n", recommendation)
+			}
+		}
+	})
+}
+
+func runExport(args []string) error {
+	fs := newFlagSet("export")
+	dir, tenantID := storeFlags(fs)
+	out := fs.String("o", "", "Output file; the extension selects JSON or YAML (default stdout)")
+	format := fs.String("format", "", "Output format: json or yaml (default from -o, else json)")
+	if err := fs.Parse(args); err != nil {
+		return usageError{err.Error()}
+	}
+	if *tenantID == "" {
+		return usageError{"-tenant is required"}
+	}
+	if *format == "" {
+		*format = formatFor(*out)
+	}
+
+	store, err := openStore(*dir)
+	if err != nil {
+		return err
+	}
+	tenant, err := store.GetTenant(*tenantID)
+	if err != nil {
+		return err
+	}
+	return writeOutput(*out, *format, tenant.Organization, nil)
+}
+
+func runImport(args []string) error {
+	fs := newFlagSet("import")
+	dir, tenantID := storeFlags(fs)
+	strict := fs.Bool("strict", false, "Reject unknown or misspelled keys")
+	dryRun := fs.Bool("plan", false, "Only show the changes the import would make")
+	path, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+
+	org, err := loadOrganization(path, *strict)
+	if err != nil {
+		return err
+	}
+	if *tenantID == "" {
+		*tenantID = org.ID
+	}
+	if *tenantID == "" {
+		return usageError{"organization has no ID; pass -tenant"}
+	}
+
+	store, err := openStore(*dir)
+	if err != nil {
+		return err
+	}
+	if _, err := store.GetTenant(*tenantID); errors.Is(err, iso9001.ErrTenantNotFound) {
+		if _, err := store.CreateTenant(*tenantID); err != nil {
+			return err
+		}
+	} else if err != nil {
+		return err
+	}
+
+	plan, err := store.Plan(*tenantID, func(tenant *iso9001.Tenant) error {
+		tenant.Organization = org
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Fprint(os.Stderr, plan)
+	if *dryRun {
+		return nil
+	}
+
+	if err := store.Apply(plan); err != nil {
+		return err
+	}
+	if err := store.FlushTenant(*tenantID); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "Imported %s into tenant %s\n", path, *tenantID)
+	return nil
+}
+
+func runLint(args []string) error {
+	fs := newFlagSet("lint")
+	format := fs.String("format", formatText, "Output format: text, json or sarif")
+	out := fs.String("o", "", "Write the findings to a file instead of stdout")
+	failOnWarnings := fs.Bool("warnings", false, "Also exit with status 1 when there are warnings")
+	if err := fs.Parse(args); err != nil {
+		return usageError{err.Error()}
+	}
+	if fs.NArg() == 0 {
+		return usageError{"expected organization files or directories"}
+	}
+
+	// Directories stand for the JSON and YAML files they contain
+	var files []string
+	for _, arg := range fs.Args() {
+		if info, err := os.Stat(arg); err == nil && info.IsDir() {
+			found, err := organizationFiles(arg)
+			if err != nil {
+				return err
+			}
+			files = append(files, found...)
+			continue
+		}
+		files = append(files, arg)
+	}
+
+	result, err := lint.Lint(files)
+	if err != nil {
+		return err
+	}
+	if cwd, err := os.Getwd(); err == nil {
+		result.RelativeTo(cwd)
+	}
+
+	if *format == formatSARIF {
+		w := os.Stdout
+		if *out != "" && *out != "-" {
+			if w, err = os.Create(*out); err != nil {
+				return err
+			}
+			defer w.Close()
+		}
+		err = result.WriteSARIF(w)
+	} else {
+		err = writeOutput(*out, *format, result, func(w io.Writer) {
+			for _, finding := range result.Findings {
+				fmt.Fprintln(w, finding)
+			}
+			fmt.Fprintf(w, "%d errors, %d warnings, %d infos\n",
+				result.Count(lint.SeverityError), result.Count(lint.SeverityWarning), result.Count(lint.SeverityInfo))
+		})
+	}
+	if err != nil {
+		return err
+	}
+
+	if result.HasErrors() || (*failOnWarnings && result.Count(lint.SeverityWarning) > 0) {
+		return errFailed
+	}
+	return nil
+}
@@ -0,0 +1,207 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/example/iso9001"
+)
+
+// ANSI escape sequences used by the dashboard
+const (
+	ansiClear = "\033[H\033[2J"
+	ansiBold  = "\033[1m"
+	ansiReset = "\033[0m"
+)
+
+// heatMapLevels orders risk levels from highest to lowest
+var heatMapLevels = []iso9001.RiskLevel{
+	iso9001.RiskLevelVeryHigh,
+	iso9001.RiskLevelHigh,
+	iso9001.RiskLevelMedium,
+	iso9001.RiskLevelLow,
+	iso9001.RiskLevelVeryLow,
+}
+
+func runDashboard(args []string) error {
+	fs := newFlagSet("dashboard")
+	dir, tenantID := storeFlags(fs)
+	refresh := fs.Duration("refresh", 30*time.Second, "Redraw interval")
+	horizon := fs.Duration("upcoming", 30*24*time.Hour, "Show audits planned within this period")
+	once := fs.Bool("once", false, "Print the dashboard once and exit")
+	html := fs.Bool("html", false, "Print the dashboard once as a self-contained HTML page and exit")
+	if err := fs.Parse(args); err != nil {
+		return usageError{err.Error()}
+	}
+
+	backend, err := iso9001.NewFileTenantBackend(*dir)
+	if err != nil {
+		return err
+	}
+	ids, err := backend.ListTenants()
+	if err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return fmt.Errorf("no tenants in %s; use 'iso9001ctl import' first", *dir)
+	}
+
+	current := 0
+	if *tenantID != "" {
+		current = -1
+		for i, id := range ids {
+			if id == *tenantID {
+				current = i
+			}
+		}
+		if current < 0 {
+			return fmt.Errorf("%w: %s", iso9001.ErrTenantNotFound, *tenantID)
+		}
+	}
+
+	draw := func(clear bool) error {
+		// Reload from disk on every draw so changes made by other processes show up
+		tenant, err := backend.LoadTenant(ids[current])
+		if err != nil {
+			return err
+		}
+		if clear {
+			fmt.Fprint(os.Stdout, ansiClear)
+		}
+		renderDashboard(os.Stdout, tenant, *horizon, time.Now())
+		return nil
+	}
+
+	if *html {
+		tenant, err := backend.LoadTenant(ids[current])
+		if err != nil {
+			return err
+		}
+		return iso9001.WriteDashboardHTML(os.Stdout, tenant.Dashboard(time.Now()))
+	}
+	if *once {
+		return draw(false)
+	}
+
+	keys := make(chan string)
+	go func() {
+		scanner := bufio.NewScanner(os.Stdin)
+		for scanner.Scan() {
+			keys <- strings.TrimSpace(scanner.Text())
+		}
+		close(keys)
+	}()
+
+	ticker := time.NewTicker(*refresh)
+	defer ticker.Stop()
+	for {
+		if err := draw(true); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stdout, "\n[n]ext tenant  [p]revious tenant  [r]efresh  [q]uit  (tenant %d of %d) > ", current+1, len(ids))
+
+		select {
+		case key, ok := <-keys:
+			if !ok || key == "q" {
+				fmt.Fprintln(os.Stdout)
+				return nil
+			}
+			switch key {
+			case "n":
+				current = (current + 1) % len(ids)
+			case "p":
+				current = (current + len(ids) - 1) % len(ids)
+			}
+		case <-ticker.C:
+		}
+	}
+}
+
+// renderDashboard writes the compliance score, overdue items, risk heat map and
+// upcoming audits of a tenant
+func renderDashboard(w io.Writer, tenant *iso9001.Tenant, horizon time.Duration, now time.Time) {
+	org := tenant.Organization
+	if org == nil {
+		org = &iso9001.Organization{ID: tenant.ID}
+	}
+
+	fmt.Fprintf(w, "%sISO 9001 dashboard - %s (%s)%s    %s\n\n", ansiBold, org.Name, tenant.ID, ansiReset, now.Format("2006-01-02 15:04"))
+
+	score := iso9001.GetComplianceScore(org)
+	result := iso9001.ValidateOrganization(org)
+	fmt.Fprintf(w, "%sCompliance%s  %5.1f  %s\n", ansiBold, ansiReset, score, scoreBar(score, 40))
+	fmt.Fprintf(w, "            %d errors, %d warnings\n\n", len(result.Errors), len(result.Warnings))
+
+	fmt.Fprintf(w, "%sOverdue%s\n", ansiBold, ansiReset)
+	findings := tenant.Audits.GetOverdueFindings()
+	risks := tenant.Risks.GetOverdueMitigations()
+	objectives := tenant.Objectives.GetOverdueObjectives()
+	if len(findings)+len(risks)+len(objectives) == 0 {
+		fmt.Fprintln(w, "  nothing overdue")
+	}
+	for _, finding := range findings {
+		fmt.Fprintf(w, "  finding    %-12s %-9s due %s  %s\n", finding.ID, finding.Severity, finding.DueDate.Format("2006-01-02"), truncate(finding.Description, 40))
+	}
+	for _, risk := range risks {
+		fmt.Fprintf(w, "  mitigation %-12s %-9s %s\n", risk.ID, risk.Priority, truncate(risk.Description, 50))
+	}
+	for _, objective := range objectives {
+		fmt.Fprintf(w, "  objective  %-12s due %s  %s\n", objective.ID, objective.Timeline.TargetDate.Format("2006-01-02"), truncate(objective.Name, 40))
+	}
+
+	fmt.Fprintf(w, "\n%sRisk heat map%s (impact down, likelihood across)\n", ansiBold, ansiReset)
+	heatMap := tenant.Risks.GetRiskHeatMap()
+	rows := []map[iso9001.RiskLevel]int{heatMap.VeryHigh, heatMap.High, heatMap.Medium, heatMap.Low, heatMap.VeryLow}
+	fmt.Fprintf(w, "  %-10s", "")
+	for i := len(heatMapLevels) - 1; i >= 0; i-- {
+		fmt.Fprintf(w, " %-9s", heatMapLevels[i])
+	}
+	fmt.Fprintln(w)
+	for row, impact := range heatMapLevels {
+		fmt.Fprintf(w, "  %-10s", impact)
+		for col := len(heatMapLevels) - 1; col >= 0; col-- {
+			count := rows[row][heatMapLevels[col]]
+			severity := (len(heatMapLevels) - row) * (len(heatMapLevels) - col)
+			fmt.Fprintf(w, " %s %-7d %s", heatColor(severity), count, ansiReset)
+		}
+		fmt.Fprintln(w)
+	}
+
+	fmt.Fprintf(w, "\n%sUpcoming audits%s (next %s)\n", ansiBold, ansiReset, horizon)
+	upcoming := tenant.Audits.GetUpcomingAudits(horizon)
+	if len(upcoming) == 0 {
+		fmt.Fprintln(w, "  none planned")
+	}
+	for _, audit := range upcoming {
+		fmt.Fprintf(w, "  %s  %-12s %-13s %s\n", audit.PlannedStartDate.Format("2006-01-02"), audit.ID, audit.Type, truncate(audit.Title, 40))
+	}
+}
+
+// scoreBar draws a horizontal bar for a 0-100 score
+func scoreBar(score float64, width int) string {
+	filled := int(score / 100 * float64(width))
+	return "[" + strings.Repeat("#", filled) + strings.Repeat(".", width-filled) + "]"
+}
+
+// heatColor returns a background color for a likelihood x impact product (1-25)
+func heatColor(severity int) string {
+	switch {
+	case severity >= 15:
+		return "\033[41m" // red
+	case severity >= 8:
+		return "\033[43m" // yellow
+	default:
+		return "\033[42m" // green
+	}
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n-3] + "..."
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/example/iso9001"
+)
+
+func runDiff(args []string) error {
+	fs := newFlagSet("diff")
+	format := fs.String("format", formatText, "Output format: text, json or yaml")
+	exitCode := fs.Bool("exit-code", false, "Exit with status 1 when the organizations differ")
+	if err := fs.Parse(args); err != nil {
+		return usageError{err.Error()}
+	}
+	if fs.NArg() != 2 {
+		return usageError{fmt.Sprintf("expected two organization files, got %d arguments", fs.NArg())}
+	}
+
+	before, err := loadOrganization(fs.Arg(0), false)
+	if err != nil {
+		return err
+	}
+	after, err := loadOrganization(fs.Arg(1), false)
+	if err != nil {
+		return err
+	}
+	diff, err := iso9001.DiffOrganizations(before, after)
+	if err != nil {
+		return err
+	}
+
+	err = writeOutput("", *format, diff, func(w io.Writer) {
+		fmt.Fprint(w, diff)
+	})
+	if err != nil {
+		return err
+	}
+	if *exitCode && !diff.Empty() {
+		return errFailed
+	}
+	return nil
+}
+
+// runMerge merges two organization files edited from a common base. Its arguments
+// match those git passes to a merge driver, so it can be configured as one for QMS
+// files; the result replaces our file unless -o is given. Git passes temporary files
+// without extension, so drivers set -format.
+func runMerge(args []string) error {
+	fs := newFlagSet("merge")
+	out := fs.String("o", "", "Output file (default: overwrite ours)")
+	format := fs.String("format", "", "Format of the files: json or yaml (default from the extension of ours)")
+	if err := fs.Parse(args); err != nil {
+		return usageError{err.Error()}
+	}
+	if fs.NArg() != 3 {
+		return usageError{fmt.Sprintf("expected base, ours and theirs organization files, got %d arguments", fs.NArg())}
+	}
+	if *format == "" {
+		*format = formatFor(fs.Arg(1))
+	}
+	if *format != formatJSON && *format != formatYAML {
+		return usageError{fmt.Sprintf("unknown format %q (use json or yaml)", *format)}
+	}
+
+	var orgs [3]*iso9001.Organization
+	for i := range orgs {
+		org, err := loadOrganizationAs(fs.Arg(i), *format, false)
+		if err != nil {
+			return err
+		}
+		orgs[i] = org
+	}
+	merge, err := iso9001.MergeOrganizationChanges(orgs[0], orgs[1], orgs[2])
+	if err != nil {
+		return err
+	}
+
+	path := *out
+	if path == "" {
+		path = fs.Arg(1)
+	}
+	if err := writeOutput(path, *format, merge.Organization, nil); err != nil {
+		return err
+	}
+	for _, conflict := range merge.Conflicts {
+		fmt.Fprintf(os.Stderr, "conflict: %s; kept ours\n", conflict)
+	}
+	if !merge.Clean() {
+		return errFailed
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/example/iso9001"
+)
+
+// maxEventBody limits the size of an inbound event
+const maxEventBody = 1 << 20
+
+// loadEventMappings reads the event mapping configuration given to serve -events
+func loadEventMappings(path string) (*iso9001.EventMappings, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return iso9001.LoadEventMappings(data)
+}
+
+// eventIntakeHandler accepts events from the external systems configured in the
+// mappings. Events are authenticated by their X-QMS-Signature and X-QMS-Timestamp
+// headers instead of an API key, and mapped into entities of the tenant.
+func eventIntakeHandler(store *iso9001.TenantStore, mappings *iso9001.EventMappings) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		source, ok := mappings.Source(r.PathValue("source"))
+		if !ok {
+			writeJSON(w, http.StatusNotFound, map[string]string{"error": "unknown event source " + r.PathValue("source")})
+			return
+		}
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxEventBody))
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+			return
+		}
+		now := time.Now()
+		if err := source.Verify(body, r.Header.Get("X-QMS-Timestamp"), r.Header.Get("X-QMS-Signature"), now); err != nil {
+			writeJSON(w, http.StatusUnauthorized, map[string]string{"error": err.Error()})
+			return
+		}
+		event, err := source.Decode(body, now)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+			return
+		}
+
+		var result iso9001.EventResult
+		err = store.UpdateTenantContext(r.Context(), r.PathValue("id"), func(tenant *iso9001.Tenant) error {
+			result, err = tenant.ApplyEvent(mappings, event)
+			return err
+		})
+		if err == nil && !result.Duplicate {
+			err = store.FlushTenantContext(r.Context(), r.PathValue("id"))
+		}
+		switch {
+		case errors.Is(err, iso9001.ErrTenantNotFound):
+			writeJSON(w, http.StatusNotFound, map[string]string{"error": err.Error()})
+		case errors.Is(err, iso9001.ErrUnmappedEvent):
+			writeJSON(w, http.StatusUnprocessableEntity, map[string]string{"error": err.Error()})
+		case err != nil:
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+		case result.Duplicate:
+			writeJSON(w, http.StatusOK, result)
+		default:
+			writeJSON(w, http.StatusCreated, result)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/example/iso9001"
+	"gopkg.in/yaml.v3"
+)
+
+// Supported file formats
+const (
+	formatJSON  = "json"
+	formatYAML  = "yaml"
+	formatText  = "text"
+	formatSARIF = "sarif"
+)
+
+// formatFor picks a format from a file extension, defaulting to JSON
+func formatFor(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return formatYAML
+	default:
+		return formatJSON
+	}
+}
+
+// loadOrganization reads an organization from a JSON or YAML file, or from stdin when
+// path is "-". Files written by earlier library versions are migrated on load.
+func loadOrganization(path string, strict bool) (*iso9001.Organization, error) {
+	return loadOrganizationAs(path, formatFor(path), strict)
+}
+
+// loadOrganizationAs reads an organization in the given format regardless of the file
+// extension, e.g. from the temporary files of a merge
+func loadOrganizationAs(path, format string, strict bool) (*iso9001.Organization, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var org *iso9001.Organization
+	switch {
+	case format == formatYAML && strict:
+		org, err = iso9001.LoadOrganizationFromYAMLStrict(data)
+	case format == formatYAML:
+		org, err = iso9001.LoadOrganizationFromYAML(data)
+	case strict:
+		org, err = iso9001.LoadOrganizationJSONStrict(data)
+	default:
+		org, err = iso9001.LoadOrganizationJSON(data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid organization in %s: %w", path, err)
+	}
+	return org, nil
+}
+
+// writeOutput encodes v as JSON, YAML or text to path, or to stdout when path is
+// empty or "-"
+func writeOutput(path, format string, v interface{}, text func(w io.Writer)) error {
+	var buf bytes.Buffer
+	switch format {
+	case formatJSON:
+		encoder := json.NewEncoder(&buf)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(v); err != nil {
+			return err
+		}
+	case formatYAML:
+		encoder := yaml.NewEncoder(&buf)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(v); err != nil {
+			return err
+		}
+		encoder.Close()
+	case formatText:
+		if text == nil {
+			return fmt.Errorf("text output is not supported here")
+		}
+		text(&buf)
+	default:
+		return fmt.Errorf("unknown format %q (use json, yaml or text)", format)
+	}
+
+	if path == "" || path == "-" {
+		_, err := os.Stdout.Write(buf.Bytes())
+		return err
+	}
+	return os.WriteFile(path, buf.Bytes(), 0o644)
+}
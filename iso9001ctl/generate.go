@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/example/iso9001"
+	"github.com/example/iso9001/generator"
+)
+
+func runGenerate(args []string) error {
+	defaults := generator.DefaultOptions()
+	fs := newFlagSet("generate")
+	id := fs.String("id", defaults.ID, "Organization ID")
+	name := fs.String("name", defaults.Name, "Organization name")
+	seed := fs.Int64("seed", defaults.Seed, "Random seed; the same seed produces the same organization")
+	processes := fs.Int("processes", defaults.Processes, "Number of processes")
+	risks := fs.Int("risks", defaults.Risks, "Number of risks")
+	documents := fs.Int("documents", defaults.Documents, "Number of documents (with -store only)")
+	objectives := fs.Int("objectives", defaults.Objectives, "Number of quality objectives")
+	audits := fs.Int("audits", defaults.Audits, "Number of audits (with -store only)")
+	compliance := fs.Float64("compliance", defaults.Compliance, "Probability from 0 to 1 that each requirement is met")
+	store := fs.String("store", "", "Save the organization with its documents and audits as a tenant in this store")
+	out := fs.String("o", "", "Output file; the extension selects JSON or YAML (default stdout)")
+	format := fs.String("format", "", "Output format: json or yaml (default from -o, else json)")
+	if err := fs.Parse(args); err != nil {
+		return usageError{err.Error()}
+	}
+	if *compliance < 0 || *compliance > 1 {
+		return usageError{"-compliance must be between 0 and 1"}
+	}
+
+	opts := generator.Options{
+		ID:         *id,
+		Name:       *name,
+		Seed:       *seed,
+		Processes:  *processes,
+		Risks:      *risks,
+		Documents:  *documents,
+		Objectives: *objectives,
+		Audits:     *audits,
+		Compliance: *compliance,
+	}
+
+	if *store == "" {
+		if *format == "" {
+			*format = formatFor(*out)
+		}
+		return writeOutput(*out, *format, generator.Organization(opts), nil)
+	}
+
+	backend, err := iso9001.NewFileTenantBackend(*store)
+	if err != nil {
+		return err
+	}
+	tenant, err := generator.Tenant(opts)
+	if err != nil {
+		return err
+	}
+	if err := backend.SaveTenant(tenant); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Generated tenant %s in %s (score %.1f)\n", tenant.ID, *store, iso9001.GetComplianceScore(tenant.Organization))
+	return nil
+}
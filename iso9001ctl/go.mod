@@ -0,0 +1,10 @@
+module github.com/example/iso9001ctl
+
+go 1.23.0
+
+require (
+	github.com/example/iso9001 v0.0.0-00010101000000-000000000000
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+replace github.com/example/iso9001 => ../
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/example/iso9001"
+)
+
+// runSnapshot records a compliance snapshot for one or all tenants of the store. Run
+// it from cron or a scheduled job; snapshots taken within the same period replace
+// each other, so running it daily keeps the latest state of each month.
+func runSnapshot(args []string) error {
+	fs := newFlagSet("snapshot")
+	dir, tenantID := storeFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return usageError{err.Error()}
+	}
+	if fs.NArg() != 0 {
+		return usageError{fmt.Sprintf("unexpected arguments: %v", fs.Args())}
+	}
+
+	backend, err := iso9001.NewFileTenantBackend(*dir)
+	if err != nil {
+		return err
+	}
+	ids := []string{*tenantID}
+	if *tenantID == "" {
+		if ids, err = backend.ListTenants(); err != nil {
+			return err
+		}
+	}
+
+	store, err := newStore(*dir, backend)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	for _, id := range ids {
+		var snapshot iso9001.ComplianceSnapshot
+		if err := store.UpdateTenant(id, func(tenant *iso9001.Tenant) error {
+			snapshot = tenant.RecordComplianceSnapshot(now)
+			return nil
+		}); err != nil {
+			return err
+		}
+		fmt.Printf("%s: %.1f%% compliant, %d open findings\n", id, snapshot.Score, snapshot.OpenFindings)
+	}
+	return store.Flush()
+}
+
+// complianceHistoryHandler serves the compliance timeline of a tenant, grouped by the
+// period given as ?period=week|month|quarter and limited by ?since and ?until
+func complianceHistoryHandler(store *iso9001.TenantStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		values := r.URL.Query()
+		period := iso9001.TrendPeriod(values.Get("period"))
+		switch period {
+		case "", iso9001.TrendPeriodWeek, iso9001.TrendPeriodMonth, iso9001.TrendPeriodQuarter:
+		default:
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": fmt.Sprintf("unknown period %q", period)})
+			return
+		}
+		var since, until time.Time
+		for name, target := range map[string]*time.Time{"since": &since, "until": &until} {
+			if value := values.Get(name); value != "" {
+				parsed, err := time.Parse(time.RFC3339, value)
+				if err != nil {
+					writeJSON(w, http.StatusBadRequest, map[string]string{"error": fmt.Sprintf("invalid %s: %v", name, err)})
+					return
+				}
+				*target = parsed
+			}
+		}
+
+		tenantHandler(store, func(tenant *iso9001.Tenant) interface{} {
+			history := tenant.Compliance
+			if history == nil {
+				history = iso9001.NewComplianceHistory("")
+			}
+			return history.Timeline(period, since, until)
+		})(w, r)
+	}
+}
@@ -0,0 +1,398 @@
+// Package lint validates organizations kept as JSON or YAML files and reports each
+// finding at the file, line and column it concerns, for use in CI pipelines.
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/example/iso9001"
+	"gopkg.in/yaml.v3"
+)
+
+// Severity levels of findings, matching iso9001.ValidationError
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+	SeverityInfo    = "info"
+)
+
+// RuleParse identifies findings for files that cannot be read as an organization
+const RuleParse = "parse"
+
+// Finding is a validation issue located in one of the linted files
+type Finding struct {
+	Rule     string `json:"rule" yaml:"rule"` // "clause-<n>" or RuleParse
+	Clause   string `json:"clause,omitempty" yaml:"clause,omitempty"`
+	Field    string `json:"field,omitempty" yaml:"field,omitempty"`
+	Message  string `json:"message" yaml:"message"`
+	Severity string `json:"severity" yaml:"severity"`
+	File     string `json:"file" yaml:"file"`
+	Line     int    `json:"line" yaml:"line"`
+	Column   int    `json:"column,omitempty" yaml:"column,omitempty"`
+}
+
+func (f Finding) String() string {
+	return fmt.Sprintf("%s:%d:%d: %s: %s (%s)", f.File, f.Line, f.Column, f.Severity, f.Message, f.Rule)
+}
+
+// Result holds the findings for a set of files
+type Result struct {
+	Files          []string  `json:"files" yaml:"files"`
+	OrganizationID string    `json:"organization_id,omitempty" yaml:"organization_id,omitempty"`
+	Score          float64   `json:"score" yaml:"score"`
+	Findings       []Finding `json:"findings" yaml:"findings"`
+}
+
+// HasErrors reports whether any finding has error severity
+func (r *Result) HasErrors() bool {
+	for _, finding := range r.Findings {
+		if finding.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// Count returns the number of findings with the given severity
+func (r *Result) Count(severity string) int {
+	count := 0
+	for _, finding := range r.Findings {
+		if finding.Severity == severity {
+			count++
+		}
+	}
+	return count
+}
+
+// Lint validates the organization described by files, which are merged in order as
+// described for Load. Files that cannot be parsed are reported as findings; the
+// error is only set when a file cannot be read.
+func Lint(files []string) (*Result, error) {
+	result := &Result{Files: files, Findings: []Finding{}}
+	if len(files) == 0 {
+		return result, nil
+	}
+
+	docs := make([]*yaml.Node, len(files))
+	index := newLocationIndex()
+	for i, path := range files {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var doc yaml.Node
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			result.Findings = append(result.Findings, parseFinding(path, err))
+			continue
+		}
+		docs[i] = &doc
+		index.add(path, &doc)
+	}
+	if len(result.Findings) > 0 {
+		return result, nil
+	}
+
+	org, err := load(files, docs, false)
+	if err != nil {
+		result.Findings = append(result.Findings, Finding{Rule: RuleParse, Message: err.Error(), Severity: SeverityError, File: files[0], Line: 1, Column: 1})
+		return result, nil
+	}
+	result.OrganizationID = org.ID
+	result.Score = iso9001.GetComplianceScore(org)
+
+	validation := iso9001.ValidateOrganization(org)
+	for _, issues := range [][]iso9001.ValidationError{validation.Errors, validation.Warnings, validation.Infos} {
+		for _, issue := range issues {
+			file, line, column := index.locate(fieldPath(org, issue.Field), files[0])
+			result.Findings = append(result.Findings, Finding{
+				Rule:     "clause-" + issue.Clause,
+				Clause:   issue.Clause,
+				Field:    issue.Field,
+				Message:  issue.Message,
+				Severity: issue.Severity,
+				File:     file,
+				Line:     line,
+				Column:   column,
+			})
+		}
+	}
+	return result, nil
+}
+
+// Load merges files into one organization, so that for example the organization, its
+// processes and its risks can be kept in separate files. Objects are merged key by
+// key, lists are concatenated and later files override scalars.
+func Load(files []string, strict bool) (*iso9001.Organization, error) {
+	docs := make([]*yaml.Node, len(files))
+	for i, path := range files {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var doc yaml.Node
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", path, err)
+		}
+		docs[i] = &doc
+	}
+	return load(files, docs, strict)
+}
+
+func load(files []string, docs []*yaml.Node, strict bool) (*iso9001.Organization, error) {
+	merged := map[string]interface{}{}
+	for i, doc := range docs {
+		if len(doc.Content) == 0 {
+			continue // empty file
+		}
+		var values map[string]interface{}
+		if err := doc.Decode(&values); err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", files[i], err)
+		}
+		merge(merged, values)
+	}
+
+	data, err := json.Marshal(merged)
+	if err != nil {
+		return nil, err
+	}
+	if strict {
+		return iso9001.LoadOrganizationJSONStrict(data)
+	}
+	return iso9001.LoadOrganizationJSON(data)
+}
+
+func merge(dst, src map[string]interface{}) {
+	for key, value := range src {
+		switch value := value.(type) {
+		case map[string]interface{}:
+			if existing, ok := dst[key].(map[string]interface{}); ok {
+				merge(existing, value)
+				continue
+			}
+		case []interface{}:
+			if existing, ok := dst[key].([]interface{}); ok {
+				dst[key] = append(existing, value...)
+				continue
+			}
+		}
+		dst[key] = value
+	}
+}
+
+var yamlLine = regexp.MustCompile(`line (\d+)`)
+
+func parseFinding(path string, err error) Finding {
+	line := 1
+	if match := yamlLine.FindStringSubmatch(err.Error()); match != nil {
+		line, _ = strconv.Atoi(match[1])
+	}
+	return Finding{Rule: RuleParse, Message: err.Error(), Severity: SeverityError, File: path, Line: line, Column: 1}
+}
+
+// position is a place in a linted file
+type position struct {
+	file         string
+	line, column int
+}
+
+// locationIndex maps paths such as "qms.processes[2].criteria" to the first place
+// they appear. List indexes count across files, matching the merged organization.
+type locationIndex struct {
+	positions map[string]position
+	counts    map[string]int
+}
+
+func newLocationIndex() *locationIndex {
+	return &locationIndex{positions: make(map[string]position), counts: make(map[string]int)}
+}
+
+func (idx *locationIndex) add(file string, doc *yaml.Node) {
+	if doc.Kind == yaml.DocumentNode && len(doc.Content) > 0 {
+		idx.walk(file, "", doc.Content[0])
+	}
+}
+
+func (idx *locationIndex) walk(file, path string, node *yaml.Node) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			child := key.Value
+			if path != "" {
+				child = path + "." + key.Value
+			}
+			idx.record(child, position{file, key.Line, key.Column})
+			idx.walk(file, child, value)
+		}
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			child := fmt.Sprintf("%s[%d]", path, idx.counts[path])
+			idx.counts[path]++
+			idx.record(child, position{file, item.Line, item.Column})
+			idx.walk(file, child, item)
+		}
+	}
+}
+
+func (idx *locationIndex) record(path string, pos position) {
+	if _, exists := idx.positions[path]; !exists {
+		idx.positions[path] = pos
+	}
+}
+
+// locate finds the closest recorded ancestor of path, falling back to the start of
+// the first file
+func (idx *locationIndex) locate(path, fallback string) (string, int, int) {
+	for path != "" {
+		if pos, ok := idx.positions[path]; ok {
+			return pos.file, pos.line, pos.column
+		}
+		cut := strings.LastIndexAny(path, ".[")
+		if cut < 0 {
+			break
+		}
+		path = path[:cut]
+	}
+	return fallback, 1, 1
+}
+
+// Patterns of the field names used by iso9001.ValidateOrganization
+var (
+	indexedField = regexp.MustCompile(`^(issue|exclusion|process|role|objective|risk|opportunity)_(\d+)_(\w+)$`)
+	namedField   = regexp.MustCompile(`^(party|process|role|objective)_(.+)_(\w+)$`)
+)
+
+// Paths of fields that name a section of the organization
+var sectionPaths = map[string]string{
+	"context":                "context",
+	"external_issues":        "context.external_issues",
+	"internal_issues":        "context.internal_issues",
+	"interested_parties":     "context.interested_parties",
+	"customers":              "context.interested_parties",
+	"suppliers":              "context.interested_parties",
+	"regulators":             "context.interested_parties",
+	"party_name":             "context.interested_parties",
+	"leadership":             "leadership",
+	"leadership_commitment":  "leadership.commitment",
+	"top_management":         "leadership.top_management",
+	"quality_policy":         "leadership.quality_policy",
+	"policy_statement":       "leadership.quality_policy.statement",
+	"policy_objectives":      "leadership.quality_policy.objectives",
+	"policy_commitment":      "leadership.quality_policy.commitment",
+	"policy_improvement":     "leadership.quality_policy.improvement",
+	"policy_communication":   "leadership.quality_policy.communicated",
+	"policy_availability":    "leadership.quality_policy.available",
+	"roles_responsibilities": "leadership.roles",
+	"qms":                    "qms",
+	"scope":                  "qms.scope",
+	"scope_description":      "qms.scope.description",
+	"scope_coverage":         "qms.scope",
+	"processes":              "qms.processes",
+	"quality_objectives":     "qms.objectives",
+	"risks":                  "qms.risks",
+	"opportunities":          "qms.opportunities",
+}
+
+// Attributes named in validation fields that differ from their JSON keys
+var attributeKeys = map[string]string{
+	"assignment": "assigned_to",
+	"timeline":   "timeline.target_date",
+}
+
+// fieldPath translates a validation field name to a path in the organization files
+func fieldPath(org *iso9001.Organization, field string) string {
+	if path, ok := sectionPaths[field]; ok {
+		return path
+	}
+
+	if match := indexedField.FindStringSubmatch(field); match != nil {
+		i, _ := strconv.Atoi(match[2])
+		attribute := attributeKey(match[3])
+		switch match[1] {
+		case "issue":
+			if org.Context != nil && i >= len(org.Context.ExternalIssues) {
+				return fmt.Sprintf("context.internal_issues[%d].%s", i-len(org.Context.ExternalIssues), attribute)
+			}
+			return fmt.Sprintf("context.external_issues[%d].%s", i, attribute)
+		case "exclusion":
+			return fmt.Sprintf("qms.scope.exclusions[%d].%s", i, attribute)
+		case "process":
+			return fmt.Sprintf("qms.processes[%d].%s", i, attribute)
+		case "role":
+			return fmt.Sprintf("leadership.roles[%d].%s", i, attribute)
+		case "objective":
+			return fmt.Sprintf("qms.objectives[%d].%s", i, attribute)
+		case "risk":
+			return fmt.Sprintf("qms.risks[%d].%s", i, attribute)
+		case "opportunity":
+			return fmt.Sprintf("qms.opportunities[%d].%s", i, attribute)
+		}
+	}
+
+	if match := namedField.FindStringSubmatch(field); match != nil {
+		name, attribute := match[2], attributeKey(match[3])
+		switch match[1] {
+		case "party":
+			if org.Context != nil {
+				for i, party := range org.Context.InterestedParties {
+					if party.Name == name {
+						return fmt.Sprintf("context.interested_parties[%d].%s", i, attribute)
+					}
+				}
+			}
+			return "context.interested_parties"
+		case "process":
+			if org.QMS != nil {
+				for i, process := range org.QMS.Processes {
+					if process.Name == name {
+						return fmt.Sprintf("qms.processes[%d].%s", i, attribute)
+					}
+				}
+			}
+			return "qms.processes"
+		case "role":
+			if org.Leadership != nil {
+				for i, role := range org.Leadership.Roles {
+					if role.Name == name {
+						return fmt.Sprintf("leadership.roles[%d].%s", i, attribute)
+					}
+				}
+			}
+			return "leadership.roles"
+		case "objective":
+			if org.QMS != nil {
+				for i, objective := range org.QMS.Objectives {
+					if objective.Name == name {
+						return fmt.Sprintf("qms.objectives[%d].%s", i, attribute)
+					}
+				}
+			}
+			return "qms.objectives"
+		}
+	}
+
+	return ""
+}
+
+func attributeKey(attribute string) string {
+	if key, ok := attributeKeys[attribute]; ok {
+		return key
+	}
+	return attribute
+}
+
+// RelativeTo rewrites finding paths relative to dir, as expected by SARIF consumers
+// that resolve locations against the repository root
+func (r *Result) RelativeTo(dir string) {
+	for i, finding := range r.Findings {
+		if rel, err := filepath.Rel(dir, finding.File); err == nil && !strings.HasPrefix(rel, "..") {
+			r.Findings[i].File = filepath.ToSlash(rel)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLintLocatesFindings(t *testing.T) {
+	dir := t.TempDir()
+	org := writeFile(t, dir, "org.yaml", "id: ORG-1\nname: Acme\nqms:\n  scope:\n    description: Everything\n")
+	processes := writeFile(t, dir, "processes.yaml", `qms:
+  processes:
+    - id: P-1
+      name: Sales
+      responsibilities: [Sales Lead]
+      criteria:
+        - name: Orders
+    - id: P-2
+      name: Production
+`)
+
+	result, err := Lint([]string{org, processes})
+	if err != nil {
+		t.Fatalf("Lint failed: %v", err)
+	}
+	if !result.HasErrors() {
+		t.Fatal("Expected errors for an incomplete organization")
+	}
+
+	found := false
+	for _, finding := range result.Findings {
+		if finding.Field == "process_Production_criteria" {
+			found = true
+			if finding.File != processes || finding.Line != 8 {
+				t.Errorf("Expected finding at %s:8, got %s:%d", processes, finding.File, finding.Line)
+			}
+		}
+		if finding.Field == "scope_description" {
+			t.Errorf("Unexpected finding for the scope description: %v", finding)
+		}
+	}
+	if !found {
+		t.Error("Expected a finding for the missing criteria of the second process")
+	}
+}
+
+func TestLintReportsParseErrors(t *testing.T) {
+	dir := t.TempDir()
+	path := writeFile(t, dir, "broken.yaml", "id: ORG-1\nname: Acme\nqms: [\n")
+
+	result, err := Lint([]string{path})
+	if err != nil {
+		t.Fatalf("Lint failed: %v", err)
+	}
+	if len(result.Findings) != 1 || result.Findings[0].Rule != RuleParse || result.Findings[0].Line != 3 {
+		t.Errorf("Expected one parse finding on line 3, got %+v", result.Findings)
+	}
+}
+
+func TestWriteSARIF(t *testing.T) {
+	result := &Result{Findings: []Finding{
+		{Rule: "clause-4.4", Clause: "4.4", Message: "Process criteria missing", Severity: SeverityError, File: "qms/processes.yaml", Line: 8, Column: 7},
+		{Rule: "clause-6.1", Clause: "6.1", Message: "No opportunities", Severity: SeverityInfo, File: "qms/org.yaml", Line: 1, Column: 1},
+	}}
+
+	var buf bytes.Buffer
+	if err := result.WriteSARIF(&buf); err != nil {
+		t.Fatalf("WriteSARIF failed: %v", err)
+	}
+	var log sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("Invalid SARIF: %v", err)
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 || len(log.Runs[0].Tool.Driver.Rules) != 2 {
+		t.Fatalf("Unexpected SARIF log: %s", buf.String())
+	}
+	results := log.Runs[0].Results
+	if results[0].Level != "error" || results[1].Level != "note" {
+		t.Errorf("Expected levels error and note, got %s and %s", results[0].Level, results[1].Level)
+	}
+	region := results[0].Locations[0].PhysicalLocation.Region
+	if region.StartLine != 8 || region.StartColumn != 7 {
+		t.Errorf("Expected region 8:7, got %d:%d", region.StartLine, region.StartColumn)
+	}
+}
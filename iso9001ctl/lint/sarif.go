@@ -0,0 +1,112 @@
+package lint
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+)
+
+// SARIF 2.1.0 document, limited to the properties written by WriteSARIF
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri,omitempty"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifact `json:"artifactLocation"`
+	Region           sarifRegion   `json:"region"`
+}
+
+type sarifArtifact struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+// sarifLevels maps finding severities to SARIF result levels
+var sarifLevels = map[string]string{
+	SeverityError:   "error",
+	SeverityWarning: "warning",
+	SeverityInfo:    "note",
+}
+
+// WriteSARIF writes the result as a SARIF 2.1.0 log, the format read by code
+// scanning tools such as GitHub code scanning
+func (r *Result) WriteSARIF(w io.Writer) error {
+	rules := make(map[string]sarifRule)
+	run := sarifRun{
+		Tool:    sarifTool{Driver: sarifDriver{Name: "iso9001ctl", InformationURI: "https://www.iso.org/standard/62085.html"}},
+		Results: []sarifResult{},
+	}
+
+	for _, finding := range r.Findings {
+		if _, exists := rules[finding.Rule]; !exists {
+			description := "ISO 9001:2015 clause " + finding.Clause
+			if finding.Rule == RuleParse {
+				description = "Organization file cannot be parsed"
+			}
+			rules[finding.Rule] = sarifRule{ID: finding.Rule, ShortDescription: sarifMessage{Text: description}}
+		}
+		run.Results = append(run.Results, sarifResult{
+			RuleID:  finding.Rule,
+			Level:   sarifLevels[finding.Severity],
+			Message: sarifMessage{Text: finding.Message},
+			Locations: []sarifLocation{{PhysicalLocation: sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifact{URI: finding.File},
+				Region:           sarifRegion{StartLine: finding.Line, StartColumn: finding.Column},
+			}}},
+		})
+	}
+
+	for _, rule := range rules {
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, rule)
+	}
+	sort.Slice(run.Tool.Driver.Rules, func(i, j int) bool { return run.Tool.Driver.Rules[i].ID < run.Tool.Driver.Rules[j].ID })
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(sarifLog{
+		Version: "2.1.0",
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Runs:    []sarifRun{run},
+	})
+}
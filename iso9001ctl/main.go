@@ -0,0 +1,90 @@
+// Command iso9001ctl validates, scores and reports on ISO 9001:2015 organizations
+// kept as JSON or YAML files, and moves them in and out of the shared tenant store.
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+// usageError marks errors caused by invalid command lines
+type usageError struct{ msg string }
+
+func (e usageError) Error() string { return e.msg }
+
+// errFailed is returned by checks that completed but did not pass
+var errFailed = errors.New("check failed")
+
+type command struct {
+	name    string
+	summary string
+	run     func(args []string) error
+}
+
+var commands = []command{
+	{"validate", "Validate an organization file against ISO 9001 requirements", runValidate},
+	{"lint", "Check organization files and report findings with file and line", runLint},
+	{"score", "Print the compliance score of an organization file", runScore},
+	{"diff", "Show the processes, risks and other entities changed between two organization files", runDiff},
+	{"merge", "Merge two organization files edited from a common base", runMerge},
+	{"report", "Generate a compliance report for an organization file", runReport},
+	{"verify", "Verify the detached signature of a report", runVerify},
+	{"export", "Write an organization from the store to a file", runExport},
+	{"import", "Load an organization file into the store", runImport},
+	{"serve", "Serve organizations in the store over HTTP", runServe},
+	{"dashboard", "Show an interactive terminal dashboard of the store", runDashboard},
+	{"watch", "Re-validate a directory of organization files whenever it changes", runWatch},
+	{"generate", "Generate a synthetic organization for demos and load tests", runGenerate},
+	{"apikey", "Create, rotate, revoke or list API keys for serve", runAPIKey},
+	{"ingest", "Add measurement results from a CSV or JSON file to a tenant", runIngest},
+	{"seal", "Key the audit trails of tenants with the store's trail key", runSeal},
+	{"snapshot", "Record the current compliance of tenants in their history", runSnapshot},
+	{"remind", "Send reminders of upcoming and overdue deadlines of tenants", runRemind},
+}
+
+func main() {
+	os.Exit(run(os.Args[1:]))
+}
+
+func run(args []string) int {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "--help" || args[0] == "help" {
+		usage()
+		return 2
+	}
+
+	for _, cmd := range commands {
+		if cmd.name != args[0] {
+			continue
+		}
+		err := cmd.run(args[1:])
+		var usageErr usageError
+		switch {
+		case err == nil:
+			return 0
+		case errors.Is(err, errFailed):
+			return 1
+		case errors.As(err, &usageErr):
+			fmt.Fprintf(os.Stderr, "iso9001ctl %s: %v\n", cmd.name, err)
+			return 2
+		default:
+			fmt.Fprintf(os.Stderr, "iso9001ctl %s: %v\n", cmd.name, err)
+			return 1
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "iso9001ctl: unknown command %q\n\n", args[0])
+	usage()
+	return 2
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage: iso9001ctl <command> [flags] [file]")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "Commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(os.Stderr, "  %-10s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "Run 'iso9001ctl <command> -h' for the flags of a command.")
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// runCommand runs iso9001ctl with args and returns its exit status and what it wrote
+// to stdout and stderr
+func runCommand(t *testing.T, args ...string) (int, string, string) {
+	t.Helper()
+	capture := func(name string) *os.File {
+		f, err := os.Create(filepath.Join(t.TempDir(), name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { f.Close() })
+		return f
+	}
+	stdout, stderr := capture("stdout"), capture("stderr")
+	savedOut, savedErr := os.Stdout, os.Stderr
+	os.Stdout, os.Stderr = stdout, stderr
+	code := run(args)
+	os.Stdout, os.Stderr = savedOut, savedErr
+
+	read := func(f *os.File) string {
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			t.Fatal(err)
+		}
+		data, err := io.ReadAll(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+	return code, read(stdout), read(stderr)
+}
+
+// fixtureDir returns a directory holding only the named fixture organization, as
+// watched by iso9001ctl watch
+func fixtureDir(t *testing.T, fixture string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", fixture))
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, fixture), data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestCommands(t *testing.T) {
+	store := t.TempDir()
+	validDir, invalidDir := fixtureDir(t, "valid.yaml"), fixtureDir(t, "invalid.yaml")
+
+	// testdata/valid.yaml has no errors, 1 warning and 3 infos; testdata/invalid.yaml
+	// has 9 errors
+	tests := []struct {
+		name   string
+		args   []string
+		code   int
+		stdout string
+		stderr string
+	}{
+		{"validate valid", []string{"validate", "testdata/valid.yaml"}, 0, "0 errors, 1 warnings, 3 infos", ""},
+		{"validate invalid", []string{"validate", "testdata/invalid.yaml"}, 1, "9 errors, 3 warnings, 2 infos", ""},
+		{"validate fails on warnings", []string{"validate", "-fail-on", "warning", "testdata/valid.yaml"}, 1, "1 warnings", ""},
+		{"validate fails on infos", []string{"validate", "-fail-on", "info", "testdata/valid.yaml"}, 1, "3 infos", ""},
+		{"validate fails on errors only", []string{"validate", "-fail-on", "error", "testdata/valid.yaml"}, 0, "", ""},
+		{"validate never fails", []string{"validate", "-fail-on", "none", "testdata/invalid.yaml"}, 0, "9 errors", ""},
+		{"validate unknown severity", []string{"validate", "-fail-on", "fatal", "testdata/valid.yaml"}, 2, "", `unknown -fail-on severity "fatal"`},
+		{"validate as JSON", []string{"validate", "-format", "json", "testdata/valid.yaml"}, 0, `"valid": true`, ""},
+		{"validate without file", []string{"validate"}, 2, "", "missing organization file"},
+		{"validate missing file", []string{"validate", "testdata/missing.yaml"}, 1, "", "missing.yaml"},
+		{"score", []string{"score", "testdata/valid.yaml"}, 0, "83.3", ""},
+		{"score below minimum", []string{"score", "-min", "90", "testdata/valid.yaml"}, 1, "83.3", ""},
+		{"score as JSON", []string{"score", "-format", "json", "testdata/invalid.yaml"}, 0, `"organization_id": "ORG-002"`, ""},
+		{"lint", []string{"lint", "testdata/valid.yaml"}, 0, "testdata/valid.yaml:14:3: warning", ""},
+		{"import", []string{"import", "-store", store, "testdata/valid.yaml"}, 0, "", "Imported testdata/valid.yaml into tenant ORG-001"},
+		{"export", []string{"export", "-store", store, "-tenant", "ORG-001", "-format", "yaml"}, 0, "name: Acme Manufacturing", ""},
+		{"export unknown tenant", []string{"export", "-store", store, "-tenant", "ORG-404"}, 1, "", "tenant not found: ORG-404"},
+		{"watch once valid", []string{"watch", "-once", validDir}, 0, "ORG-001: valid, score 83.3", ""},
+		{"watch once invalid", []string{"watch", "-once", invalidDir}, 1, "ORG-002: INVALID", ""},
+		{"watch without directory", []string{"watch", "-once"}, 2, "", "expected one directory"},
+		{"unknown command", []string{"audit"}, 2, "", `unknown command "audit"`},
+		{"no command", nil, 2, "", "Usage: iso9001ctl"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, stdout, stderr := runCommand(t, tt.args...)
+			if code != tt.code {
+				t.Errorf("Expected exit status %d, got %d (stderr %q)", tt.code, code, stderr)
+			}
+			if !strings.Contains(stdout, tt.stdout) {
+				t.Errorf("Expected %q in output, got %q", tt.stdout, stdout)
+			}
+			if !strings.Contains(stderr, tt.stderr) {
+				t.Errorf("Expected %q in errors, got %q", tt.stderr, stderr)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/example/iso9001"
+)
+
+// maxMeasurementBody limits the size of a measurement batch posted to serve
+const maxMeasurementBody = 10 << 20
+
+// readMeasurements decodes measurement results given as CSV or as a JSON array
+func readMeasurements(r io.Reader, csv bool) ([]iso9001.MeasurementResult, error) {
+	if csv {
+		return iso9001.ReadMeasurementsCSV(r)
+	}
+	var results []iso9001.MeasurementResult
+	if err := json.NewDecoder(r).Decode(&results); err != nil {
+		return nil, fmt.Errorf("invalid JSON measurements: %w", err)
+	}
+	return results, nil
+}
+
+func runIngest(args []string) error {
+	fs := newFlagSet("ingest")
+	dir, tenantID := storeFlags(fs)
+	format := fs.String("format", "", "Input format: csv or json (default from the file extension, json for stdin)")
+	if err := fs.Parse(args); err != nil {
+		return usageError{err.Error()}
+	}
+	if fs.NArg() != 1 {
+		return usageError{fmt.Sprintf("expected one measurements file (use - for stdin), got %d arguments", fs.NArg())}
+	}
+	if *tenantID == "" {
+		return usageError{"-tenant is required"}
+	}
+	path := fs.Arg(0)
+	if *format == "" {
+		*format = "json"
+		if strings.EqualFold(filepath.Ext(path), ".csv") {
+			*format = "csv"
+		}
+	}
+	if *format != "csv" && *format != "json" {
+		return usageError{fmt.Sprintf("unknown format %q", *format)}
+	}
+
+	input := os.Stdin
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		input = file
+	}
+	results, err := readMeasurements(input, *format == "csv")
+	if err != nil {
+		return err
+	}
+
+	store, err := openStore(*dir)
+	if err != nil {
+		return err
+	}
+	var ingestion iso9001.MeasurementIngestion
+	if err := store.UpdateTenant(*tenantID, func(tenant *iso9001.Tenant) error {
+		ingestion = tenant.IngestMeasurements(results...)
+		return nil
+	}); err != nil {
+		return err
+	}
+	if err := store.FlushTenant(*tenantID); err != nil {
+		return err
+	}
+
+	fmt.Printf("Ingested %d of %d measurements into %s\n", len(ingestion.Accepted), len(results), *tenantID)
+	for _, rejected := range ingestion.Rejected {
+		fmt.Fprintf(os.Stderr, "  result %d %s: %s\n", rejected.Index+1, rejected.ID, rejected.Reason)
+	}
+	if len(ingestion.Rejected) > 0 {
+		return errFailed
+	}
+	return nil
+}
+
+// measurementIntakeHandler accepts measurement batches posted as JSON or CSV
+// (Content-Type text/csv) and saves them to the tenant. Requests authenticated with an
+// API key need write scope.
+func measurementIntakeHandler(store *iso9001.TenantStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !requireWriteScope(w, r) {
+			return
+		}
+		mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		results, err := readMeasurements(http.MaxBytesReader(w, r.Body, maxMeasurementBody), mediaType == "text/csv")
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+			return
+		}
+
+		var ingestion iso9001.MeasurementIngestion
+		err = store.UpdateTenantContext(r.Context(), r.PathValue("id"), func(tenant *iso9001.Tenant) error {
+			ingestion = tenant.IngestMeasurements(results...)
+			return nil
+		})
+		if err == nil {
+			err = store.FlushTenantContext(r.Context(), r.PathValue("id"))
+		}
+		switch {
+		case errors.Is(err, iso9001.ErrTenantNotFound):
+			writeJSON(w, http.StatusNotFound, map[string]string{"error": err.Error()})
+		case err != nil:
+			writeJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		case len(ingestion.Accepted) == 0 && len(ingestion.Rejected) > 0:
+			writeJSON(w, http.StatusUnprocessableEntity, ingestion)
+		default:
+			writeJSON(w, http.StatusOK, ingestion)
+		}
+	}
+}
+
+// measurementQuery reads a measurement query from URL parameters
+func measurementQuery(r *http.Request) (iso9001.MeasurementQuery, error) {
+	values := r.URL.Query()
+	query := iso9001.MeasurementQuery{
+		Metric:      values.Get("metric"),
+		ProcessID:   values.Get("process"),
+		CriteriaID:  values.Get("criteria"),
+		ObjectiveID: values.Get("objective"),
+	}
+	for name, target := range map[string]*time.Time{"since": &query.Since, "until": &query.Until} {
+		if value := values.Get(name); value != "" {
+			parsed, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return query, fmt.Errorf("invalid %s: %w", name, err)
+			}
+			*target = parsed
+		}
+	}
+	return query, nil
+}
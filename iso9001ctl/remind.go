@@ -0,0 +1,179 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/smtp"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/example/iso9001"
+)
+
+func runRemind(args []string) error {
+	fs := newFlagSet("remind")
+	dir, tenantID := storeFlags(fs)
+	var leads []iso9001.LeadTimeRule
+	var escalations []iso9001.EscalationRule
+	fs.Func("lead", "Remind this long before a deadline, as [kind=]duration, e.g. 7d or calibration=14d; repeatable (default 7d and 1d)", func(value string) error {
+		rule, err := parseLeadTimeRule(value)
+		leads = append(leads, rule)
+		return err
+	})
+	fs.Func("escalate", "Escalate deadlines overdue this long, as [kind:]duration=recipient[,recipient], e.g. 7d=qm@acme.example; repeatable", func(value string) error {
+		rule, err := parseEscalationRule(value)
+		escalations = append(escalations, rule)
+		return err
+	})
+	webhook := fs.String("webhook", "", "POST each reminder as JSON to this URL")
+	webhookSecret := fs.String("webhook-secret", os.Getenv("ISO9001_WEBHOOK_SECRET"), "Secret to sign webhook requests with (env ISO9001_WEBHOOK_SECRET)")
+	smtpAddr := fs.String("smtp", "", "host:port of an SMTP server to mail reminders through; credentials from ISO9001_SMTP_USER and ISO9001_SMTP_PASSWORD")
+	from := fs.String("from", "", "Sender address of reminder e-mails")
+	to := fs.String("to", "", "Comma-separated addresses that receive every reminder e-mail")
+	recipientsFile := fs.String("recipients", "", "JSON file mapping responsible persons, roles and escalation recipients to e-mail addresses")
+	every := fs.Duration("every", time.Hour, "How often to check for due reminders")
+	once := fs.Bool("once", false, "Send the reminders due now and exit")
+	if err := fs.Parse(args); err != nil {
+		return usageError{err.Error()}
+	}
+	if fs.NArg() != 0 {
+		return usageError{fmt.Sprintf("unexpected arguments: %v", fs.Args())}
+	}
+
+	var sinks []iso9001.NotificationSink
+	if *webhook != "" {
+		sinks = append(sinks, iso9001.WebhookSink{URL: *webhook, Secret: *webhookSecret})
+	}
+	if *smtpAddr != "" {
+		if *from == "" {
+			return usageError{"-smtp requires -from"}
+		}
+		email := iso9001.EmailSink{Addr: *smtpAddr, From: *from}
+		if *to != "" {
+			email.To = strings.Split(*to, ",")
+		}
+		if *recipientsFile != "" {
+			data, err := os.ReadFile(*recipientsFile)
+			if err != nil {
+				return err
+			}
+			if err := json.Unmarshal(data, &email.Recipients); err != nil {
+				return fmt.Errorf("invalid -recipients: %w", err)
+			}
+		}
+		if user := os.Getenv("ISO9001_SMTP_USER"); user != "" {
+			host, _, _ := net.SplitHostPort(*smtpAddr)
+			email.Auth = smtp.PlainAuth("", user, os.Getenv("ISO9001_SMTP_PASSWORD"), host)
+		}
+		sinks = append(sinks, email)
+	}
+
+	// Reminders are deduplicated per tenant, since entity IDs repeat across tenants
+	schedulers := make(map[string]*iso9001.Scheduler)
+	remind := func(ctx context.Context, now time.Time) error {
+		store, err := openStore(*dir)
+		if err != nil {
+			return err
+		}
+		ids := []string{*tenantID}
+		if *tenantID == "" {
+			if ids, err = listTenants(*dir); err != nil {
+				return err
+			}
+		}
+		for _, id := range ids {
+			tenant, err := store.GetTenant(id)
+			if err != nil {
+				return err
+			}
+			scheduler, ok := schedulers[id]
+			if !ok {
+				id := id
+				printer := iso9001.NotificationSinkFunc(func(ctx context.Context, reminder iso9001.Reminder) error {
+					fmt.Printf("%s: %s\n", id, reminder)
+					return nil
+				})
+				scheduler = iso9001.NewScheduler(append([]iso9001.NotificationSink{printer}, sinks...)...)
+				scheduler.Rules = leads
+				scheduler.Escalations = escalations
+				schedulers[id] = scheduler
+			}
+			scheduler.DueDates = tenant.Audits.DueDates
+			if _, err := scheduler.Dispatch(ctx, tenant.Deadlines(), now); err != nil {
+				fmt.Fprintf(os.Stderr, "iso9001ctl remind: %s: %v\n", id, err)
+			}
+		}
+		return nil
+	}
+
+	if *once {
+		return remind(context.Background(), time.Now())
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	ticker := time.NewTicker(*every)
+	defer ticker.Stop()
+	for {
+		if err := remind(ctx, time.Now()); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// listTenants returns the IDs of the tenants in the store directory
+func listTenants(dir string) ([]string, error) {
+	backend, err := iso9001.NewFileTenantBackend(dir)
+	if err != nil {
+		return nil, err
+	}
+	return backend.ListTenants()
+}
+
+// parseLeadTimeRule parses [kind=]duration
+func parseLeadTimeRule(value string) (iso9001.LeadTimeRule, error) {
+	var rule iso9001.LeadTimeRule
+	if kind, duration, ok := strings.Cut(value, "="); ok {
+		rule.Kind, value = iso9001.DeadlineKind(kind), duration
+	}
+	before, err := parseDays(value)
+	rule.Before = before
+	return rule, err
+}
+
+// parseEscalationRule parses [kind:]duration=recipient[,recipient]
+func parseEscalationRule(value string) (iso9001.EscalationRule, error) {
+	var rule iso9001.EscalationRule
+	after, to, ok := strings.Cut(value, "=")
+	if !ok || to == "" {
+		return rule, fmt.Errorf("expected [kind:]duration=recipient, got %q", value)
+	}
+	if kind, duration, ok := strings.Cut(after, ":"); ok {
+		rule.Kind, after = iso9001.DeadlineKind(kind), duration
+	}
+	duration, err := parseDays(after)
+	rule.After, rule.To = duration, strings.Split(to, ",")
+	return rule, err
+}
+
+// parseDays parses a Go duration or a number of days such as 7d
+func parseDays(value string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid number of days %q", value)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	return time.ParseDuration(value)
+}
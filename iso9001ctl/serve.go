@@ -0,0 +1,242 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/example/iso9001"
+)
+
+func runServe(args []string) error {
+	fs := newFlagSet("serve")
+	dir, _ := storeFlags(fs)
+	addr := fs.String("addr", "localhost:8080", "Address to listen on")
+	maxIdle := fs.Duration("max-idle", time.Minute, "Reload tenants from the store after this long without access")
+	requireKey := fs.Bool("require-api-key", false, "Require a read-scoped API key (see iso9001ctl apikey) on every request")
+	rate := fs.Float64("rate", 0, "Requests per second allowed per API key or client address; zero is unlimited")
+	burst := fs.Int("burst", 0, "Requests a client may send at once (default the rate, at least 1)")
+	quota := fs.Int("daily-quota", 0, "Operations per day allowed per API key or client address; zero is unlimited")
+	ingest := fs.Bool("ingest", false, "Accept measurement data at POST /organizations/{id}/measurements (write-scoped key when -require-api-key is set)")
+	write := fs.Bool("write", false, "Accept changes to organizations, risks, audits and documents (write-scoped key when -require-api-key is set)")
+	eventsFile := fs.String("events", "", "Event mapping file; accept signed events from external systems at POST /organizations/{id}/events/{source}")
+	if err := fs.Parse(args); err != nil {
+		return usageError{err.Error()}
+	}
+
+	store, err := openStore(*dir)
+	if err != nil {
+		return err
+	}
+	stop := store.StartEviction(*maxIdle/2, *maxIdle, func(err error) {
+		log.Printf("Eviction error: %v", err)
+	})
+	defer stop()
+
+	var keys *iso9001.APIKeyManager
+	if *requireKey {
+		if keys, err = iso9001.NewAPIKeyManager(iso9001.APIKeyFile(*dir)); err != nil {
+			return err
+		}
+		if !keys.HasActiveKeys() {
+			return fmt.Errorf("-require-api-key set but the store has no active API keys; create one with iso9001ctl apikey create")
+		}
+	}
+	var mappings *iso9001.EventMappings
+	if *eventsFile != "" {
+		if mappings, err = loadEventMappings(*eventsFile); err != nil {
+			return err
+		}
+	}
+	limiter := iso9001.NewRateLimiter(iso9001.RateLimit{Rate: *rate, Burst: *burst, DailyQuota: *quota})
+	handler := newServeHandler(store, serveOptions{Keys: keys, Limiter: limiter, Events: mappings, Ingest: *ingest, Write: *write})
+
+	log.Printf("Serving organizations from %s on http://%s", *dir, *addr)
+	return http.ListenAndServe(*addr, handler)
+}
+
+// serveOptions selects what serve exposes and how it protects it
+type serveOptions struct {
+	Keys          *iso9001.APIKeyManager // requires a read-scoped key on every request when set
+	Limiter       *iso9001.RateLimiter
+	Events        *iso9001.EventMappings // accepts signed events when set
+	Ingest, Write bool
+}
+
+// newServeHandler wraps the routes of newServeMux in the API key check and the rate
+// limits. Clients are throttled by address before their key is checked, so guessing
+// keys is throttled too, and then by key.
+func newServeHandler(store *iso9001.TenantStore, opts serveOptions) http.Handler {
+	var handler http.Handler = newServeMux(store, opts.Ingest, opts.Write)
+	if opts.Keys != nil {
+		handler = requireAPIKey(opts.Keys, iso9001.APIKeyScopeRead, rateLimitKey(opts.Limiter, handler))
+	}
+	if opts.Events != nil {
+		// Events carry their own signatures, so they bypass the API key check
+		events := http.NewServeMux()
+		events.Handle("POST /organizations/{id}/events/{source}", eventIntakeHandler(store, opts.Events))
+		events.Handle("/", handler)
+		handler = events
+	}
+	return rateLimitAddress(opts.Limiter, handler)
+}
+
+// requireAPIKey rejects requests without a valid key of the required scope, presented
+// as "Authorization: Bearer <key>" or in the X-API-Key header
+func requireAPIKey(keys *iso9001.APIKeyManager, scope iso9001.APIKeyScope, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		secret := r.Header.Get("X-API-Key")
+		if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+			secret = strings.TrimPrefix(auth, "Bearer ")
+		}
+		if secret == "" {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "API key required"})
+			return
+		}
+		key, err := keys.Authenticate(secret, scope)
+		if err != nil {
+			status := http.StatusUnauthorized
+			if errors.Is(err, iso9001.ErrInsufficientScope) {
+				status = http.StatusForbidden
+			}
+			writeJSON(w, status, map[string]string{"error": err.Error()})
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), apiKeyContextKey{}, key)))
+	})
+}
+
+type apiKeyContextKey struct{}
+
+// rateLimitAddress throttles each client address, before the request is
+// authenticated
+func rateLimitAddress(limiter *iso9001.RateLimiter, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		client := r.RemoteAddr
+		if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+			client = host
+		}
+		if throttle(w, limiter, "addr:"+client) {
+			next.ServeHTTP(w, r)
+		}
+	})
+}
+
+// rateLimitKey throttles each authenticated API key. Keys with their own limits
+// override the limiter's defaults.
+func rateLimitKey(limiter *iso9001.RateLimiter, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key, ok := r.Context().Value(apiKeyContextKey{}).(*iso9001.APIKey)
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if key.RateLimit != nil {
+			limiter.SetClientLimit(key.ID, *key.RateLimit)
+		} else {
+			limiter.ClearClientLimit(key.ID)
+		}
+		if throttle(w, limiter, key.ID) {
+			next.ServeHTTP(w, r)
+		}
+	})
+}
+
+// throttle counts a request by the client and reports whether it may proceed. It
+// answers throttled requests itself and adds the client's quota to the others.
+func throttle(w http.ResponseWriter, limiter *iso9001.RateLimiter, client string) bool {
+	usage, err := limiter.Allow(client)
+	var throttled *iso9001.ThrottleError
+	if errors.As(err, &throttled) {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(throttled.RetryAfter.Seconds()))))
+		writeJSON(w, http.StatusTooManyRequests, map[string]string{"error": err.Error()})
+		return false
+	}
+	if usage.Limit > 0 {
+		w.Header().Set("X-Quota-Limit", strconv.Itoa(usage.Limit))
+		w.Header().Set("X-Quota-Remaining", strconv.Itoa(usage.Limit-usage.Used))
+		w.Header().Set("X-Quota-Reset", usage.Resets.UTC().Format(time.RFC3339))
+	}
+	return true
+}
+
+// newServeMux exposes read-only views of the organizations in the store and, when
+// ingest is set, the measurement intake and, when write is set, changes to the
+// organizations and their risks, audits and documents
+func newServeMux(store *iso9001.TenantStore, ingest, write bool) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /organizations/{id}", organizationHandler(store, func(org *iso9001.Organization) interface{} {
+		return org
+	}))
+	mux.HandleFunc("GET /organizations/{id}/validation", organizationHandler(store, func(org *iso9001.Organization) interface{} {
+		return iso9001.ValidateOrganization(org)
+	}))
+	mux.HandleFunc("GET /organizations/{id}/score", organizationHandler(store, func(org *iso9001.Organization) interface{} {
+		return map[string]interface{}{"organization_id": org.ID, "score": iso9001.GetComplianceScore(org)}
+	}))
+	mux.HandleFunc("GET /organizations/{id}/report", organizationHandler(store, func(org *iso9001.Organization) interface{} {
+		return iso9001.GenerateComplianceReport(org)
+	}))
+	mux.HandleFunc("GET /organizations/{id}/measurements", func(w http.ResponseWriter, r *http.Request) {
+		query, err := measurementQuery(r)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+			return
+		}
+		tenantHandler(store, func(tenant *iso9001.Tenant) interface{} {
+			results := []iso9001.MeasurementResult{}
+			if tenant.Measurements != nil {
+				results = append(results, tenant.Measurements.Query(query)...)
+			}
+			return results
+		})(w, r)
+	})
+	mux.HandleFunc("GET /organizations/{id}/compliance-history", complianceHistoryHandler(store))
+	if ingest {
+		mux.HandleFunc("POST /organizations/{id}/measurements", measurementIntakeHandler(store))
+	}
+	addEntityRoutes(mux, store, write)
+	return mux
+}
+
+// organizationHandler renders a view of the organization held by the tenant named in
+// the request path
+func organizationHandler(store *iso9001.TenantStore, view func(org *iso9001.Organization) interface{}) http.HandlerFunc {
+	return tenantHandler(store, func(tenant *iso9001.Tenant) interface{} {
+		return view(tenant.Organization)
+	})
+}
+
+// tenantHandler renders a view of the tenant named in the request path
+func tenantHandler(store *iso9001.TenantStore, view func(tenant *iso9001.Tenant) interface{}) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var body interface{}
+		err := store.WithTenantContext(r.Context(), r.PathValue("id"), func(tenant *iso9001.Tenant) error {
+			body = view(tenant)
+			return nil
+		})
+		switch {
+		case errors.Is(err, iso9001.ErrTenantNotFound):
+			writeJSON(w, http.StatusNotFound, map[string]string{"error": err.Error()})
+		case err != nil:
+			writeJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		default:
+			writeJSON(w, http.StatusOK, body)
+		}
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/example/iso9001"
+)
+
+func TestServeRevokedKey(t *testing.T) {
+	dir := t.TempDir()
+	store, err := openStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.CreateTenant("ORG-001"); err != nil {
+		t.Fatal(err)
+	}
+	keys, err := iso9001.NewAPIKeyManager(iso9001.APIKeyFile(dir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	secret, key, err := keys.Create("dashboard", iso9001.APIKeyScopeRead, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	limiter := iso9001.NewRateLimiter(iso9001.RateLimit{})
+	server := httptest.NewServer(newServeHandler(store, serveOptions{Keys: keys, Limiter: limiter}))
+	defer server.Close()
+
+	get := func() int {
+		req, _ := http.NewRequest(http.MethodGet, server.URL+"/organizations/ORG-001/risks", nil)
+		req.Header.Set("Authorization", "Bearer "+secret)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+	if status := get(); status != http.StatusOK {
+		t.Fatalf("Expected the key to be accepted, got %d", status)
+	}
+
+	if err := runAPIKey([]string{"revoke", "-store", dir, key.ID}); err != nil {
+		t.Fatalf("Failed to revoke key: %v", err)
+	}
+	if status := get(); status != http.StatusUnauthorized {
+		t.Errorf("Expected the revoked key to be rejected by the running server, got %d", status)
+	}
+}
+
+func TestServeThrottlesKeyGuessing(t *testing.T) {
+	dir := t.TempDir()
+	store, err := openStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys, err := iso9001.NewAPIKeyManager(iso9001.APIKeyFile(dir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	limiter := iso9001.NewRateLimiter(iso9001.RateLimit{Rate: 0.001, Burst: 2})
+	handler := newServeHandler(store, serveOptions{Keys: keys, Limiter: limiter})
+
+	var statuses []int
+	for i := 0; i < 3; i++ {
+		req := httptest.NewRequest(http.MethodGet, "/organizations/ORG-001", nil)
+		req.Header.Set("X-API-Key", "qms_guess")
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		statuses = append(statuses, w.Code)
+	}
+	want := []int{http.StatusUnauthorized, http.StatusUnauthorized, http.StatusTooManyRequests}
+	for i := range want {
+		if statuses[i] != want[i] {
+			t.Fatalf("Expected %v, got %v", want, statuses)
+		}
+	}
+}
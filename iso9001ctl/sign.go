@@ -0,0 +1,89 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/example/iso9001"
+)
+
+// signatureSuffix is appended to a report file name for its detached signature
+const signatureSuffix = ".sig"
+
+// signFile writes a detached signature of the file at path to path.sig
+func signFile(path, keyFile, keyID string) error {
+	pemData, err := os.ReadFile(keyFile)
+	if err != nil {
+		return err
+	}
+	key, err := iso9001.ParseReportSigningKey(pemData)
+	if err != nil {
+		return err
+	}
+	signer, err := iso9001.NewReportSigner(keyID, key)
+	if err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	signature, err := json.MarshalIndent(signer.Sign(data), "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path+signatureSuffix, append(signature, '\n'), 0o644); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Signed %s; signature written to %s\n", path, path+signatureSuffix)
+	return nil
+}
+
+func runVerify(args []string) error {
+	fs := newFlagSet("verify")
+	keyFile := fs.String("key", "", "Ed25519 public key in PEM form")
+	sigFile := fs.String("sig", "", "Signature file (default <report>.sig)")
+	if err := fs.Parse(args); err != nil {
+		return usageError{err.Error()}
+	}
+	if fs.NArg() != 1 {
+		return usageError{fmt.Sprintf("expected one report file, got %d arguments", fs.NArg())}
+	}
+	if *keyFile == "" {
+		return usageError{"-key is required"}
+	}
+	path := fs.Arg(0)
+	if *sigFile == "" {
+		*sigFile = path + signatureSuffix
+	}
+
+	pemData, err := os.ReadFile(*keyFile)
+	if err != nil {
+		return err
+	}
+	key, err := iso9001.ParseReportVerificationKey(pemData)
+	if err != nil {
+		return err
+	}
+	sigData, err := os.ReadFile(*sigFile)
+	if err != nil {
+		return err
+	}
+	var signature iso9001.ReportSignature
+	if err := json.Unmarshal(sigData, &signature); err != nil {
+		return fmt.Errorf("invalid signature file %s: %w", *sigFile, err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	if err := iso9001.VerifyReportSignature(data, &signature, key); err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", path, err)
+		return errFailed
+	}
+	fmt.Printf("%s: valid signature by key %q, signed %s\n", path, signature.KeyID, signature.Signed.Format("2006-01-02 15:04:05 MST"))
+	return nil
+}
@@ -0,0 +1,207 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/example/iso9001"
+	"github.com/example/iso9001ctl/lint"
+)
+
+// watchEvent is the outcome of one validation run in watch mode
+type watchEvent struct {
+	Time           time.Time                 `json:"time" yaml:"time"`
+	Files          []string                  `json:"files" yaml:"files"`
+	OrganizationID string                    `json:"organization_id,omitempty" yaml:"organization_id,omitempty"`
+	Valid          bool                      `json:"valid" yaml:"valid"`
+	Score          float64                   `json:"score" yaml:"score"`
+	Errors         []iso9001.ValidationError `json:"errors,omitempty" yaml:"errors,omitempty"`
+	Warnings       []iso9001.ValidationError `json:"warnings,omitempty" yaml:"warnings,omitempty"`
+	LoadError      string                    `json:"load_error,omitempty" yaml:"load_error,omitempty"`
+}
+
+func runWatch(args []string) error {
+	fs := newFlagSet("watch")
+	interval := fs.Duration("interval", 2*time.Second, "How often to check the directory for changes")
+	format := fs.String("format", formatText, "Output format: text or json (one event per line)")
+	webhook := fs.String("webhook", "", "POST each result as JSON to this URL")
+	strict := fs.Bool("strict", false, "Reject unknown or misspelled keys")
+	once := fs.Bool("once", false, "Validate once and exit with the validation status")
+	if err := fs.Parse(args); err != nil {
+		return usageError{err.Error()}
+	}
+	if fs.NArg() != 1 {
+		return usageError{"expected one directory of organization files"}
+	}
+	if *format != formatText && *format != formatJSON {
+		return usageError{fmt.Sprintf("unknown format %q (use text or json)", *format)}
+	}
+	dir := fs.Arg(0)
+
+	emit := func(event watchEvent) {
+		if *format == formatJSON {
+			json.NewEncoder(os.Stdout).Encode(event)
+		} else {
+			printWatchEvent(os.Stdout, event)
+		}
+		if *webhook != "" {
+			if err := postWebhook(*webhook, event); err != nil {
+				fmt.Fprintf(os.Stderr, "iso9001ctl watch: %v\n", err)
+			}
+		}
+	}
+
+	if *once {
+		event := validateDirectory(dir, *strict)
+		emit(event)
+		if !event.Valid {
+			return errFailed
+		}
+		return nil
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	return watchDirectory(ctx, dir, *interval, *strict, emit)
+}
+
+// watchDirectory validates the organization in dir, then checks the directory every
+// interval and validates it again whenever it changed, until ctx is done
+func watchDirectory(ctx context.Context, dir string, interval time.Duration, strict bool, emit func(watchEvent)) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	var last string
+	for {
+		state, err := directoryState(dir)
+		if err != nil {
+			return err
+		}
+		if state != last {
+			last = state
+			emit(validateDirectory(dir, strict))
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// organizationFiles lists the JSON and YAML files below dir in lexical order
+func organizationFiles(dir string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if path != dir && strings.HasPrefix(entry.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".json", ".yaml", ".yml":
+			files = append(files, path)
+		}
+		return nil
+	})
+	sort.Strings(files)
+	return files, err
+}
+
+// directoryState summarizes the names, sizes and modification times of the files
+// being watched; it changes whenever a file is added, removed or written
+func directoryState(dir string) (string, error) {
+	files, err := organizationFiles(dir)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	for _, path := range files {
+		info, err := os.Stat(path)
+		if err != nil {
+			continue // removed while walking; picked up on the next check
+		}
+		fmt.Fprintf(&b, "%s %d %d\n", path, info.Size(), info.ModTime().UnixNano())
+	}
+	return b.String(), nil
+}
+
+// validateDirectory loads the organization described by the files in dir and
+// validates it
+func validateDirectory(dir string, strict bool) watchEvent {
+	event := watchEvent{Time: time.Now()}
+	files, err := organizationFiles(dir)
+	event.Files = files
+	if err == nil && len(files) == 0 {
+		err = fmt.Errorf("no JSON or YAML files in %s", dir)
+	}
+	var org *iso9001.Organization
+	if err == nil {
+		org, err = lint.Load(files, strict)
+	}
+	if err != nil {
+		event.LoadError = err.Error()
+		return event
+	}
+
+	result := iso9001.ValidateOrganization(org)
+	event.OrganizationID = org.ID
+	event.Valid = result.Valid
+	event.Score = iso9001.GetComplianceScore(org)
+	event.Errors = result.Errors
+	event.Warnings = result.Warnings
+	return event
+}
+
+func printWatchEvent(w io.Writer, event watchEvent) {
+	stamp := event.Time.Format("15:04:05")
+	if event.LoadError != "" {
+		fmt.Fprintf(w, "[%s] cannot load organization: %s\n", stamp, event.LoadError)
+		return
+	}
+	status := "valid"
+	if !event.Valid {
+		status = "INVALID"
+	}
+	fmt.Fprintf(w, "[%s] %s: %s, score %.1f, %d errors, %d warnings\n",
+		stamp, event.OrganizationID, status, event.Score, len(event.Errors), len(event.Warnings))
+	for _, issues := range [][]iso9001.ValidationError{event.Errors, event.Warnings} {
+		for _, issue := range issues {
+			fmt.Fprintf(w, "  %s\n", issue.Error())
+		}
+	}
+}
+
+// postWebhook sends an event to a webhook as JSON
+func postWebhook(url string, event watchEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook: %s returned %s", url, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWatchDirectory(t *testing.T) {
+	dir := fixtureDir(t, "valid.yaml")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events := make(chan watchEvent, 8)
+	done := make(chan error, 1)
+	go func() {
+		done <- watchDirectory(ctx, dir, 10*time.Millisecond, false, func(event watchEvent) { events <- event })
+	}()
+	next := func() watchEvent {
+		t.Helper()
+		select {
+		case event := <-events:
+			return event
+		case <-time.After(5 * time.Second):
+			t.Fatal("Timed out waiting for a validation run")
+			return watchEvent{}
+		}
+	}
+
+	if event := next(); !event.Valid || event.OrganizationID != "ORG-001" {
+		t.Errorf("Expected the fixture to be validated on start, got %+v", event)
+	}
+
+	// unchanged files are not validated again
+	time.Sleep(50 * time.Millisecond)
+	select {
+	case event := <-events:
+		t.Errorf("Expected no validation without changes, got %+v", event)
+	default:
+	}
+
+	invalid, err := os.ReadFile(filepath.Join("testdata", "invalid.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "valid.yaml"), invalid, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if event := next(); event.Valid || event.OrganizationID != "ORG-002" || len(event.Errors) != 9 {
+		t.Errorf("Expected the changed file to be validated, got %+v", event)
+	}
+
+	if err := os.Remove(filepath.Join(dir, "valid.yaml")); err != nil {
+		t.Fatal(err)
+	}
+	if event := next(); event.LoadError == "" {
+		t.Errorf("Expected an empty directory to be reported, got %+v", event)
+	}
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Expected watching to stop cleanly, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected watching to stop when cancelled")
+	}
+}
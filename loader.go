@@ -0,0 +1,246 @@
+package iso9001
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// UnknownField describes a key in the input that does not map to any struct field
+type UnknownField struct {
+	Path       string `json:"path" yaml:"path"`
+	Suggestion string `json:"suggestion,omitempty" yaml:"suggestion,omitempty"`
+}
+
+func (f UnknownField) String() string {
+	if f.Suggestion != "" {
+		return fmt.Sprintf("%s (did you mean %q?)", f.Path, f.Suggestion)
+	}
+	return f.Path
+}
+
+// UnknownFieldsError is returned by strict loaders when the input contains keys
+// that would otherwise be silently ignored
+type UnknownFieldsError struct {
+	Fields []UnknownField `json:"fields" yaml:"fields"`
+}
+
+func (e *UnknownFieldsError) Error() string {
+	names := make([]string, len(e.Fields))
+	for i, field := range e.Fields {
+		names[i] = field.String()
+	}
+	return fmt.Sprintf("unknown fields: %s", strings.Join(names, ", "))
+}
+
+// LoadOrganizationJSON decodes an organization, ignoring unknown fields. Data written
+// by earlier library versions is migrated to the current schema first.
+func LoadOrganizationJSON(data []byte) (*Organization, error) {
+	data, err := DefaultMigrations.MigrateJSON(SchemaKindOrganization, data)
+	if err != nil {
+		return nil, err
+	}
+
+	var org Organization
+	if err := json.Unmarshal(data, &org); err != nil {
+		return nil, err
+	}
+	org.SchemaVersion = CurrentSchemaVersion
+	return &org, nil
+}
+
+// LoadOrganizationJSONStrict decodes an organization and rejects unknown or misspelled
+// keys with an *UnknownFieldsError listing every offending key. Keys renamed by a
+// migration are not reported.
+func LoadOrganizationJSONStrict(data []byte) (*Organization, error) {
+	data, err := DefaultMigrations.MigrateJSON(SchemaKindOrganization, data)
+	if err != nil {
+		return nil, err
+	}
+
+	var org Organization
+	if err := DecodeJSONStrict(data, &org); err != nil {
+		return nil, err
+	}
+	org.SchemaVersion = CurrentSchemaVersion
+	return &org, nil
+}
+
+// DecodeJSONStrict decodes data into v using DisallowUnknownFields. When decoding
+// fails because of unknown keys, all of them are reported rather than just the first.
+func DecodeJSONStrict(data []byte, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	err := decoder.Decode(v)
+	if err == nil {
+		return nil
+	}
+	if !strings.Contains(err.Error(), "unknown field") {
+		return err
+	}
+
+	var raw interface{}
+	if jsonErr := json.Unmarshal(data, &raw); jsonErr != nil {
+		return err
+	}
+
+	var fields []UnknownField
+	collectUnknownFields(raw, reflect.TypeOf(v), "", &fields)
+	if len(fields) == 0 {
+		return err
+	}
+	return &UnknownFieldsError{Fields: fields}
+}
+
+// collectUnknownFields walks a decoded JSON value alongside the Go type it is meant
+// to populate and records every object key without a matching field
+func collectUnknownFields(value interface{}, t reflect.Type, path string, fields *[]UnknownField) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		switch t.Kind() {
+		case reflect.Map:
+			keys := sortedKeys(v)
+			for _, key := range keys {
+				collectUnknownFields(v[key], t.Elem(), joinPath(path, key), fields)
+			}
+		case reflect.Struct:
+			known := jsonFields(t)
+			for _, key := range sortedKeys(v) {
+				field, ok := lookupJSONField(known, key)
+				if !ok {
+					*fields = append(*fields, UnknownField{
+						Path:       joinPath(path, key),
+						Suggestion: suggestFieldName(key, known),
+					})
+					continue
+				}
+				collectUnknownFields(v[key], field.Type, joinPath(path, key), fields)
+			}
+		}
+	case []interface{}:
+		if t.Kind() != reflect.Slice && t.Kind() != reflect.Array {
+			return
+		}
+		for i, item := range v {
+			collectUnknownFields(item, t.Elem(), fmt.Sprintf("%s[%d]", path, i), fields)
+		}
+	}
+}
+
+// jsonFields maps the JSON names of a struct's exported fields to their definitions.
+// Like encoding/json, it promotes the fields of embedded structs without a JSON name
+// unless the outer struct has a field of the same name.
+func jsonFields(t reflect.Type) map[string]reflect.StructField {
+	known := make(map[string]reflect.StructField)
+	var embedded []reflect.Type
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tagName, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if tagName == "-" {
+			continue
+		}
+		if field.Anonymous && tagName == "" {
+			embeddedType := field.Type
+			if embeddedType.Kind() == reflect.Ptr {
+				embeddedType = embeddedType.Elem()
+			}
+			if embeddedType.Kind() == reflect.Struct {
+				embedded = append(embedded, embeddedType)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		name := field.Name
+		if tagName != "" {
+			name = tagName
+		}
+		known[name] = field
+	}
+	for _, embeddedType := range embedded {
+		for name, field := range jsonFields(embeddedType) {
+			if _, ok := known[name]; !ok {
+				known[name] = field
+			}
+		}
+	}
+	return known
+}
+
+// lookupJSONField matches a key like encoding/json does, preferring an exact match
+// and falling back to a case-insensitive one
+func lookupJSONField(known map[string]reflect.StructField, key string) (reflect.StructField, bool) {
+	if field, ok := known[key]; ok {
+		return field, true
+	}
+	for name, field := range known {
+		if strings.EqualFold(name, key) {
+			return field, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// suggestFieldName returns the known field closest to key, if it is close enough to
+// plausibly be a misspelling
+func suggestFieldName(key string, known map[string]reflect.StructField) string {
+	best := ""
+	bestDistance := len(key)/3 + 1
+	for name := range known {
+		d := editDistance(strings.ToLower(key), strings.ToLower(name))
+		if d < bestDistance || (d == bestDistance && best != "" && name < best) {
+			best = name
+			bestDistance = d
+		}
+	}
+	return best
+}
+
+// editDistance computes the Levenshtein distance between two strings
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(b)]
+}
+
+// sortedKeys returns the keys of a map in order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
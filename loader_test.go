@@ -0,0 +1,81 @@
+package iso9001
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestLoadOrganizationJSONStrictReportsUnknownFields(t *testing.T) {
+	data := []byte(`{
+		"id": "ORG-001",
+		"name": "Test Organization",
+		"qms": {
+			"scope": {"descripton": "Typo in scope"},
+			"processes": [{"name": "Sales", "owner": "Sales Manager"}]
+		}
+	}`)
+
+	if _, err := LoadOrganizationJSON(data); err != nil {
+		t.Fatalf("Expected lenient load to succeed, got %v", err)
+	}
+
+	_, err := LoadOrganizationJSONStrict(data)
+	var unknown *UnknownFieldsError
+	if !errors.As(err, &unknown) {
+		t.Fatalf("Expected UnknownFieldsError, got %v", err)
+	}
+	if len(unknown.Fields) != 2 {
+		t.Fatalf("Expected 2 unknown fields, got %v", unknown.Fields)
+	}
+
+	if unknown.Fields[0].Path != "qms.processes[0].owner" {
+		t.Errorf("Unexpected path %s", unknown.Fields[0].Path)
+	}
+	if unknown.Fields[1].Path != "qms.scope.descripton" || unknown.Fields[1].Suggestion != "description" {
+		t.Errorf("Expected suggestion for misspelled description, got %+v", unknown.Fields[1])
+	}
+}
+
+func TestLoadOrganizationJSONStrictAcceptsKnownFields(t *testing.T) {
+	org, err := LoadOrganizationJSONStrict([]byte(`{"id": "ORG-001", "NAME": "Case insensitive"}`))
+	if err != nil {
+		t.Fatalf("Expected strict load to succeed, got %v", err)
+	}
+	if org.Name != "Case insensitive" {
+		t.Errorf("Expected name to be decoded, got %q", org.Name)
+	}
+}
+
+func TestCollectUnknownFieldsPromotesEmbeddedFields(t *testing.T) {
+	type audited struct {
+		CreatedBy string `json:"created_by"`
+		Version   int    `json:"version"`
+	}
+	type Named struct {
+		Name string `json:"name"`
+	}
+	type record struct {
+		audited
+		*Named
+		Tagged  audited `json:"tagged"`
+		Version string  `json:"version"`
+	}
+
+	var value interface{}
+	data := []byte(`{"created_by": "qm", "name": "Record", "version": "2", "tagged": {"created_by": "qm"}, "created_at": "today", "owner": "qm"}`)
+	if err := json.Unmarshal(data, &value); err != nil {
+		t.Fatal(err)
+	}
+	var fields []UnknownField
+	collectUnknownFields(value, reflect.TypeOf(record{}), "", &fields)
+	if len(fields) != 2 || fields[0].Path != "created_at" || fields[1].Path != "owner" {
+		t.Errorf("Expected only created_at and owner to be unknown, got %+v", fields)
+	}
+
+	// the outer field wins over the promoted one, as in encoding/json
+	if field := jsonFields(reflect.TypeOf(record{}))["version"]; field.Type.Kind() != reflect.String {
+		t.Errorf("Expected the outer version field, got %v", field.Type)
+	}
+}
@@ -0,0 +1,366 @@
+package iso9001
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// LatestCompletedReview returns the completed management review with the latest date,
+// or nil when no review has been completed yet
+func (am *AuditManager) LatestCompletedReview() *ManagementReview {
+	var latest *ManagementReview
+	for _, review := range am.ManagementReviews {
+		if review.Status == ReviewStatusCompleted && (latest == nil || review.Date.After(latest.Date)) {
+			latest = review
+		}
+	}
+	return latest
+}
+
+// CollectReviewInputs assembles the inputs of a management review (clause 9.3.2) from
+// the tenant's records between since and now. A zero since starts at the date of the
+// latest completed review, or takes in all records when there is none.
+//
+// Issues, complaints, measurements and audits count when they fall in the period; open
+// actions, nonconformities and opportunities count however old they are. Conformity of
+// products and external provider performance are not recorded by the tenant and are
+// left for the review to fill in.
+func (t *Tenant) CollectReviewInputs(since, now time.Time) ManagementReviewInputs {
+	if since.IsZero() {
+		if latest := t.Audits.LatestCompletedReview(); latest != nil {
+			since = latest.Date
+		}
+	}
+	inPeriod := func(date time.Time) bool {
+		return !date.Before(since) && !date.After(now)
+	}
+
+	inputs := ManagementReviewInputs{
+		StatusOfActions:              []ActionStatusReport{},
+		ChangesInExternalIssues:      []Issue{},
+		ChangesInInternalIssues:      []Issue{},
+		ChangesInInterestedParties:   []InterestedParty{},
+		ProcessPerformance:           []ProcessPerformanceReport{},
+		ConformityOfProducts:         []ProductConformityReport{},
+		StatusOfNonconformities:      []NonconformanceReport{},
+		StatusOfCorrectiveActions:    []CorrectiveActionReport{},
+		MonitoringMeasurementResults: []MeasurementResult{},
+		InternalAuditResults:         []AuditResultSummary{},
+		ExternalProviderPerformance:  []ProviderPerformanceReport{},
+		EffectivenessOfActionsTaken:  []ActionEffectivenessReport{},
+		OpportunitiesForImprovement:  []ImprovementOpportunity{},
+	}
+
+	// Actions from previous management reviews (9.3.2 a)
+	for _, review := range sortedReviews(t.Audits) {
+		for _, item := range review.Outputs.ActionItems {
+			if actionOpen(item.Status) {
+				inputs.StatusOfActions = append(inputs.StatusOfActions, ActionStatusReport{
+					ActionID:    item.ID,
+					Description: item.Description,
+					Status:      item.Status,
+					Comments:    fmt.Sprintf("From management review %s, due %s, responsible %s", review.ID, item.DueDate.Format("2006-01-02"), item.Responsible),
+				})
+			}
+		}
+	}
+
+	// Changes in external and internal issues and interested parties (9.3.2 b)
+	if org := t.Organization; org != nil && org.Context != nil {
+		for _, issue := range org.Context.ExternalIssues {
+			if inPeriod(issue.Created) {
+				inputs.ChangesInExternalIssues = append(inputs.ChangesInExternalIssues, issue)
+			}
+		}
+		for _, issue := range org.Context.InternalIssues {
+			if inPeriod(issue.Created) {
+				inputs.ChangesInInternalIssues = append(inputs.ChangesInInternalIssues, issue)
+			}
+		}
+		// Interested parties carry no dates, so all of them are put up for review
+		inputs.ChangesInInterestedParties = append(inputs.ChangesInInterestedParties, org.Context.InterestedParties...)
+	}
+
+	// Customer satisfaction and complaints (9.3.2 c 1)
+	if t.Feedback != nil {
+		inputs.CustomerSatisfaction = t.Feedback.SatisfactionReport(TrendOptions{}, now)
+	}
+
+	// Performance of the QMS, objectives and processes (9.3.2 c 2 and 3)
+	inputs.QMSPerformance = t.qmsPerformance(now)
+	var results []MeasurementResult
+	if t.Measurements != nil {
+		results = t.Measurements.Query(MeasurementQuery{Since: since, Until: now.Add(time.Nanosecond)})
+		inputs.MonitoringMeasurementResults = append(inputs.MonitoringMeasurementResults, results...)
+	}
+	if org := t.Organization; org != nil && org.QMS != nil {
+		for _, process := range org.QMS.Processes {
+			inputs.ProcessPerformance = append(inputs.ProcessPerformance, t.processPerformance(process, results))
+		}
+	}
+
+	// Nonconformities, corrective actions and audit results (9.3.2 c 4, 5 and 6)
+	for _, audit := range sortedAudits(t.Audits) {
+		for _, finding := range audit.Findings {
+			if finding.Severity != SeverityObservation && finding.Category != CategoryAuditOpportunity && findingOpen(finding.Status) {
+				inputs.StatusOfNonconformities = append(inputs.StatusOfNonconformities, NonconformanceReport{
+					ID:          finding.ID,
+					Description: finding.Description,
+					Status:      findingNonconformanceStatus(finding.Status),
+					RootCause:   finding.RootCause,
+					Process:     finding.Process,
+					Date:        finding.Created,
+					Source:      "audit " + audit.ID,
+				})
+			}
+			if finding.Category == CategoryAuditOpportunity && findingOpen(finding.Status) {
+				inputs.OpportunitiesForImprovement = append(inputs.OpportunitiesForImprovement, ImprovementOpportunity{
+					ID:          finding.ID,
+					Description: finding.Description,
+					Priority:    PriorityMedium,
+					Category:    "audit",
+					Benefits:    []string{},
+				})
+			}
+			inputs.addCorrectiveActions(finding.CorrectiveActions)
+		}
+
+		end := audit.ActualEndDate
+		if audit.Type == AuditTypeInternal && end != nil && inPeriod(*end) {
+			summary := AuditResultSummary{AuditID: audit.ID, OverallResult: string(audit.Status), FindingsCount: len(audit.Findings)}
+			if audit.Report != nil && audit.Report.Conclusions != "" {
+				summary.OverallResult = audit.Report.Conclusions
+			}
+			for _, finding := range audit.Findings {
+				if finding.Severity == SeverityCritical {
+					summary.CriticalFindings++
+				}
+			}
+			inputs.InternalAuditResults = append(inputs.InternalAuditResults, summary)
+		}
+	}
+	for _, nc := range t.Nonconformities {
+		if nc.Status != NonconformanceStatusClosed || inPeriod(nc.Date) {
+			inputs.StatusOfNonconformities = append(inputs.StatusOfNonconformities, nc)
+		}
+	}
+	if t.Outputs != nil {
+		ids := make([]string, 0, len(t.Outputs.Outputs))
+		for id := range t.Outputs.Outputs {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		for _, id := range ids {
+			output := t.Outputs.Outputs[id]
+			if output.Status != OutputStatusClosed || inPeriod(output.Detected) {
+				inputs.StatusOfNonconformities = append(inputs.StatusOfNonconformities, NonconformanceReport{
+					ID:          output.ID,
+					Description: output.Description,
+					Status:      outputNonconformanceStatus(output.Status),
+					Process:     output.Process,
+					Date:        output.Detected,
+					Source:      output.DetectedBy,
+				})
+			}
+			inputs.addCorrectiveActions(output.CorrectiveActions)
+		}
+	}
+
+	// Adequacy of resources (9.3.2 d)
+	inputs.ResourceAdequacy = t.resourceAdequacy(now)
+
+	// Opportunities for improvement (9.3.2 f)
+	ids := make([]string, 0, len(t.Risks.Opportunities))
+	for id := range t.Risks.Opportunities {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		opportunity := t.Risks.Opportunities[id]
+		if opportunity.Status == OpportunityStatusRealized {
+			continue
+		}
+		inputs.OpportunitiesForImprovement = append(inputs.OpportunitiesForImprovement, ImprovementOpportunity{
+			ID:          opportunity.ID,
+			Description: opportunity.Description,
+			Priority:    t.Risks.calculatePriority(RiskLevel(opportunity.Likelihood), RiskLevel(opportunity.Impact)),
+			Category:    "risk register",
+			Benefits:    append([]string{}, opportunity.Benefits...),
+		})
+	}
+
+	return inputs
+}
+
+// addCorrectiveActions reports the status of corrective actions and, for verified
+// ones, the effectiveness of the actions taken (9.3.2 c 5 and e)
+func (inputs *ManagementReviewInputs) addCorrectiveActions(actions []CorrectiveAction) {
+	for _, action := range actions {
+		inputs.StatusOfCorrectiveActions = append(inputs.StatusOfCorrectiveActions, CorrectiveActionReport{
+			ActionID:      action.ID,
+			Description:   action.Description,
+			Status:        action.Status,
+			Effectiveness: action.Verification,
+		})
+		if action.Status == ActionStatusVerified {
+			inputs.EffectivenessOfActionsTaken = append(inputs.EffectivenessOfActionsTaken, ActionEffectivenessReport{
+				ActionID:  action.ID,
+				Effective: true,
+				Evidence:  action.Verification,
+			})
+		}
+	}
+}
+
+// qmsPerformance summarizes the performance of the QMS by its objectives, findings
+// and risks
+func (t *Tenant) qmsPerformance(now time.Time) QMSPerformanceReport {
+	objectives := t.Objectives.CalculateObjectiveProgress()
+	audits := t.Audits.GetAuditStatistics()
+	risks := t.Risks.GetRiskStatistics()
+
+	openFindings := 0
+	for _, audit := range t.Audits.Audits {
+		for _, finding := range audit.Findings {
+			if findingOpen(finding.Status) {
+				openFindings++
+			}
+		}
+	}
+	overdueFindings := len(t.Audits.GetOverdueFindings())
+
+	report := QMSPerformanceReport{
+		OverallPerformance: fmt.Sprintf("%d of %d objectives achieved; %d open audit findings, %d overdue; %d high and %d critical risks",
+			objectives.Achieved, objectives.TotalObjectives, openFindings, overdueFindings, risks.High, risks.Critical),
+		KeyMetrics: []PerformanceMetric{
+			{Name: "Objective achievement rate", Value: objectives.AchievementRate, Target: 100, Unit: "%"},
+			{Name: "Open audit findings", Value: float64(openFindings), Unit: "findings"},
+			{Name: "Overdue audit findings", Value: float64(overdueFindings), Unit: "findings"},
+			{Name: "Critical audit findings", Value: float64(audits.CriticalFindings), Unit: "findings"},
+			{Name: "High and critical risks", Value: float64(risks.High + risks.Critical), Unit: "risks"},
+		},
+		Trends: []Trend{},
+	}
+	for _, trend := range objectives.Trends {
+		report.Trends = append(report.Trends, Trend{Metric: "objective " + trend.ObjectiveID, Direction: trend.Trend, Period: trend.Period, Data: trend.Data})
+	}
+	return report
+}
+
+// processPerformance reports the latest measurement of each monitored metric of a
+// process in the period and the open findings raised against it
+func (t *Tenant) processPerformance(process Process, results []MeasurementResult) ProcessPerformanceReport {
+	report := ProcessPerformanceReport{ProcessID: process.ID, Metrics: []PerformanceMetric{}, Issues: []string{}}
+
+	latest := make(map[string]MeasurementResult)
+	var metrics []string
+	for _, result := range results {
+		if result.ProcessID != process.ID {
+			continue
+		}
+		if _, seen := latest[result.Metric]; !seen {
+			metrics = append(metrics, result.Metric)
+		}
+		latest[result.Metric] = result
+	}
+	onTarget := 0
+	for _, metric := range metrics {
+		result := latest[metric]
+		report.Metrics = append(report.Metrics, PerformanceMetric{Name: metric, Value: result.Value, Target: result.Target, Unit: result.Unit})
+		if result.Value >= result.Target {
+			onTarget++
+		}
+	}
+	// Efficiency is the share of metrics at or above target, as a percentage
+	if len(metrics) > 0 {
+		report.Efficiency = float64(onTarget) / float64(len(metrics)) * 100
+	}
+
+	for _, audit := range sortedAudits(t.Audits) {
+		for _, finding := range audit.Findings {
+			if finding.Process == process.ID && findingOpen(finding.Status) {
+				report.Issues = append(report.Issues, fmt.Sprintf("%s (%s): %s", finding.ID, finding.Severity, finding.Description))
+			}
+		}
+	}
+	return report
+}
+
+// resourceAdequacy reports the process resources that are not available and the
+// monitoring resources whose calibration is overdue
+func (t *Tenant) resourceAdequacy(now time.Time) ResourceAdequacyReport {
+	report := ResourceAdequacyReport{ResourceType: "all", Adequate: true, Gaps: []string{}}
+	if t.Organization == nil || t.Organization.QMS == nil {
+		return report
+	}
+	for _, process := range t.Organization.QMS.Processes {
+		for _, resource := range process.Resources {
+			if !resource.Available {
+				report.Gaps = append(report.Gaps, fmt.Sprintf("%s %s of process %s is not available", resource.Type, resourceName(resource), process.ID))
+			}
+			if resource.CalibrationDue != nil && resource.CalibrationDue.Before(now) {
+				report.Gaps = append(report.Gaps, fmt.Sprintf("Calibration of %s was due on %s", resourceName(resource), resource.CalibrationDue.Format("2006-01-02")))
+			}
+		}
+	}
+	report.Adequate = len(report.Gaps) == 0
+	return report
+}
+
+// resourceName names a resource by its name, or its ID when it has none
+func resourceName(resource Resource) string {
+	if strings.TrimSpace(resource.Name) != "" {
+		return resource.Name
+	}
+	return resource.ID
+}
+
+// findingOpen reports whether a finding is neither closed nor accepted
+func findingOpen(status FindingStatus) bool {
+	return status != FindingStatusClosed && status != FindingStatusAccepted
+}
+
+// findingNonconformanceStatus maps the status of a finding to that of a nonconformity
+func findingNonconformanceStatus(status FindingStatus) NonconformanceStatus {
+	switch status {
+	case FindingStatusInProgress:
+		return NonconformanceStatusInvestigating
+	case FindingStatusClosed, FindingStatusAccepted:
+		return NonconformanceStatusClosed
+	}
+	return NonconformanceStatusOpen
+}
+
+// outputNonconformanceStatus maps the status of a nonconforming output to that of a
+// nonconformity
+func outputNonconformanceStatus(status NonconformingOutputStatus) NonconformanceStatus {
+	switch status {
+	case OutputStatusDispositioned:
+		return NonconformanceStatusCorrected
+	case OutputStatusClosed:
+		return NonconformanceStatusClosed
+	}
+	return NonconformanceStatusOpen
+}
+
+// sortedAudits returns the audits ordered by ID
+func sortedAudits(am *AuditManager) []*Audit {
+	audits := make([]*Audit, 0, len(am.Audits))
+	for _, audit := range am.Audits {
+		audits = append(audits, audit)
+	}
+	sort.Slice(audits, func(i, j int) bool { return audits[i].ID < audits[j].ID })
+	return audits
+}
+
+// sortedReviews returns the management reviews ordered by date
+func sortedReviews(am *AuditManager) []*ManagementReview {
+	reviews := make([]*ManagementReview, 0, len(am.ManagementReviews))
+	for _, review := range am.ManagementReviews {
+		reviews = append(reviews, review)
+	}
+	sort.Slice(reviews, func(i, j int) bool { return reviews[i].Date.Before(reviews[j].Date) })
+	return reviews
+}
@@ -0,0 +1,75 @@
+package iso9001
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCollectReviewInputs(t *testing.T) {
+	now := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+	lastReview := now.AddDate(0, -6, 0)
+
+	tenant := NewTenant("acme")
+	tenant.Organization.Context = &OrganizationalContext{
+		ExternalIssues:    []Issue{{ID: "EXT-001", Description: "New customer regulation", Created: now.AddDate(0, -1, 0)}},
+		InternalIssues:    []Issue{{ID: "INT-001", Description: "Staff turnover", Created: now.AddDate(-1, 0, 0)}},
+		InterestedParties: []InterestedParty{{ID: "IP-001", Name: "Customers"}},
+	}
+	tenant.Organization.QMS = &QualityManagementSystem{Processes: []Process{{
+		ID:        "PROC-001",
+		Name:      "Production",
+		Resources: []Resource{{ID: "RES-001", Name: "Caliper", Type: ResourceTypeMonitoring, Available: true, CalibrationDue: &lastReview}},
+	}}}
+
+	tenant.Audits.CreateManagementReview(&ManagementReview{ID: "MR-001", Title: "Management review H2", Date: lastReview})
+	tenant.Audits.CompleteManagementReview("MR-001", ManagementReviewOutputs{ActionItems: []ActionItem{
+		{ID: "MR-001-A1", Description: "Hire a second inspector", Status: ActionStatusInProgress},
+		{ID: "MR-001-A2", Description: "Update the policy", Status: ActionStatusCompleted},
+	}})
+
+	tenant.Audits.CreateAudit(&Audit{ID: "AUDIT-001", Title: "Production", Type: AuditTypeInternal, Scope: AuditScope{Description: "Production"}})
+	tenant.Audits.AddFinding("AUDIT-001", AuditFinding{ID: "F-001", Description: "Records missing", Severity: SeverityMinor, Process: "PROC-001", Status: FindingStatusOpen})
+	tenant.Audits.AddFinding("AUDIT-001", AuditFinding{ID: "F-002", Description: "Automate inspection", Severity: SeverityObservation, Category: CategoryAuditOpportunity})
+	action, _ := tenant.Audits.RaiseCorrectiveAction("AUDIT-001", "F-001", CorrectiveAction{Description: "Train operators"})
+	tenant.Audits.UpdateCorrectiveActionStatus("AUDIT-001", "F-001", action.ID, ActionStatusCompleted)
+	tenant.Audits.VerifyCorrectiveAction("AUDIT-001", "F-001", action.ID, true, "No missing records since May")
+	tenant.Audits.StartAudit("AUDIT-001", now.AddDate(0, -2, 0))
+	tenant.Audits.CompleteAudit("AUDIT-001", now.AddDate(0, -2, 1), &AuditReport{Conclusions: "QMS effective"})
+
+	tenant.Risks.IdentifyOpportunity(&Opportunity{ID: "OPP-001", Description: "Enter new market", Likelihood: OpportunityLevelHigh, Impact: OpportunityLevelHigh})
+	tenant.IngestMeasurements(
+		MeasurementResult{Metric: "yield", Value: 97, Target: 98, ProcessID: "PROC-001", Date: now.AddDate(0, -3, 0)},
+		MeasurementResult{Metric: "yield", Value: 99, Target: 98, ProcessID: "PROC-001", Date: now.AddDate(0, -1, 0)},
+		MeasurementResult{Metric: "yield", Value: 90, Target: 98, ProcessID: "PROC-001", Date: now.AddDate(-1, 0, 0)},
+	)
+
+	inputs := tenant.CollectReviewInputs(time.Time{}, now)
+
+	if len(inputs.StatusOfActions) != 1 || inputs.StatusOfActions[0].ActionID != "MR-001-A1" {
+		t.Errorf("Expected the open action of the last review, got %+v", inputs.StatusOfActions)
+	}
+	if len(inputs.ChangesInExternalIssues) != 1 || len(inputs.ChangesInInternalIssues) != 0 {
+		t.Errorf("Expected only issues raised since the last review, got %+v and %+v", inputs.ChangesInExternalIssues, inputs.ChangesInInternalIssues)
+	}
+	if len(inputs.MonitoringMeasurementResults) != 2 {
+		t.Errorf("Expected the measurements since the last review, got %+v", inputs.MonitoringMeasurementResults)
+	}
+	if process := inputs.ProcessPerformance; len(process) != 1 || len(process[0].Metrics) != 1 || process[0].Metrics[0].Value != 99 || process[0].Efficiency != 100 || len(process[0].Issues) != 1 {
+		t.Errorf("Expected the latest yield and finding of the process, got %+v", process)
+	}
+	if ncs := inputs.StatusOfNonconformities; len(ncs) != 1 || ncs[0].ID != "F-001" || ncs[0].Status != NonconformanceStatusInvestigating {
+		t.Errorf("Expected finding F-001 as an open nonconformity, got %+v", ncs)
+	}
+	if len(inputs.EffectivenessOfActionsTaken) != 1 || !inputs.EffectivenessOfActionsTaken[0].Effective {
+		t.Errorf("Expected the verified corrective action, got %+v", inputs.EffectivenessOfActionsTaken)
+	}
+	if audits := inputs.InternalAuditResults; len(audits) != 1 || audits[0].OverallResult != "QMS effective" || audits[0].FindingsCount != 2 {
+		t.Errorf("Expected the completed internal audit, got %+v", audits)
+	}
+	if inputs.ResourceAdequacy.Adequate || len(inputs.ResourceAdequacy.Gaps) != 1 {
+		t.Errorf("Expected the overdue calibration as a resource gap, got %+v", inputs.ResourceAdequacy)
+	}
+	if ofis := inputs.OpportunitiesForImprovement; len(ofis) != 2 || ofis[0].ID != "F-002" || ofis[1].Priority != PriorityHigh {
+		t.Errorf("Expected the audit and risk register opportunities, got %+v", ofis)
+	}
+}
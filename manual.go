@@ -0,0 +1,288 @@
+package iso9001
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// QualityManualOutline is a clause-by-clause skeleton of a quality manual. Sections
+// are pre-populated from the organization where it already holds the information,
+// and list what remains to be written otherwise.
+type QualityManualOutline struct {
+	OrganizationID string          `json:"organization_id" yaml:"organization_id"`
+	Title          string          `json:"title" yaml:"title"`
+	Generated      time.Time       `json:"generated" yaml:"generated"`
+	Sections       []ManualSection `json:"sections" yaml:"sections"`
+}
+
+// ManualSection is the part of the manual addressing one clause
+type ManualSection struct {
+	Clause    string              `json:"clause" yaml:"clause"`
+	Title     string              `json:"title" yaml:"title"`
+	Content   []string            `json:"content,omitempty" yaml:"content,omitempty"`     // text taken from the organization
+	ToDo      []string            `json:"todo,omitempty" yaml:"todo,omitempty"`           // what still has to be written
+	Records   []string            `json:"records,omitempty" yaml:"records,omitempty"`     // documented information to be retained
+	Documents []ManualDocumentRef `json:"documents,omitempty" yaml:"documents,omitempty"` // existing documents for the clause
+}
+
+// ManualDocumentRef refers to an existing document from a manual section
+type ManualDocumentRef struct {
+	ID     string         `json:"id" yaml:"id"`
+	Title  string         `json:"title" yaml:"title"`
+	Type   DocumentType   `json:"type" yaml:"type"`
+	Status DocumentStatus `json:"status" yaml:"status"`
+}
+
+// manualSections are the clauses the manual addresses, with titles for clauses 4 to 6;
+// titles of later clauses come from the clause catalog
+var manualSections = []struct {
+	clause, title, todo string
+}{
+	{"4.1", "Understanding the organization and its context", "Describe the external and internal issues relevant to the organization's purpose and strategic direction"},
+	{"4.2", "Understanding the needs and expectations of interested parties", "List the relevant interested parties and their requirements"},
+	{"4.3", "Determining the scope of the quality management system", "State the scope, the products and services covered and any justified exclusions"},
+	{"4.4", "Quality management system and its processes", "Describe the QMS processes, their sequence and interaction"},
+	{"5.1", "Leadership and commitment", "Describe how top management demonstrates leadership and customer focus"},
+	{"5.2", "Quality policy", "Include or reference the approved quality policy"},
+	{"5.3", "Organizational roles, responsibilities and authorities", "Describe who is responsible and has authority for the QMS and its processes"},
+	{"6.1", "Actions to address risks and opportunities", "Describe how risks and opportunities are determined and addressed"},
+	{"6.2", "Quality objectives and planning to achieve them", "List the quality objectives and how they will be achieved"},
+	{"6.3", "Planning of changes", "Describe how changes to the QMS are planned and carried out"},
+	{"7.1", "", ""},
+	{"7.2", "", ""},
+	{"7.3", "", ""},
+	{"7.4", "", ""},
+	{"7.5", "", ""},
+	{"8.1", "", ""},
+	{"8.2", "", ""},
+	{"8.3", "", ""},
+	{"8.4", "", ""},
+	{"8.5", "", ""},
+	{"8.6", "", ""},
+	{"8.7", "", ""},
+	{"9.1", "", ""},
+	{"9.2", "", ""},
+	{"9.3", "", ""},
+	{"10.1", "", ""},
+	{"10.2", "", ""},
+	{"10.3", "", ""},
+}
+
+// manualCategoryClauses places documents without related clauses in the manual by
+// their category
+var manualCategoryClauses = map[DocumentCategory]string{
+	CategoryProcessManagement: "4.4",
+	CategoryRiskManagement:    "6.1",
+	CategoryCalibration:       "7.1",
+	CategoryTraining:          "7.2",
+	CategoryCustomer:          "8.2",
+	CategorySupplier:          "8.4",
+	CategoryNonconformance:    "10.2",
+	CategoryAudit:             "9.2",
+	CategoryManagementReview:  "9.3",
+}
+
+// GenerateQualityManualOutline produces a quality manual skeleton for an organization.
+// The scope, policy, processes, roles, risks and objectives are filled in from the
+// organization; documents are referenced from the sections of their related clauses,
+// or of their category when they name no clause. Obsolete and archived documents are
+// left out.
+func GenerateQualityManualOutline(org *Organization, documents ...*DocumentedInformation) *QualityManualOutline {
+	outline := &QualityManualOutline{
+		OrganizationID: org.ID,
+		Title:          "Quality Manual",
+		Generated:      time.Now(),
+	}
+	if org.Name != "" {
+		outline.Title = org.Name + " Quality Manual"
+	}
+
+	index := make(map[string]int, len(manualSections))
+	for _, entry := range manualSections {
+		section := ManualSection{Clause: entry.clause, Title: entry.title}
+		if clause, ok := LookupClause(entry.clause); ok {
+			section.Title = clause.Title
+			for _, sub := range append([]Clause{clause}, SubClauses(entry.clause)...) {
+				section.Records = append(section.Records, sub.RequiredRecords...)
+			}
+		}
+		section.Content = manualContent(org, entry.clause)
+		if len(section.Content) == 0 {
+			section.ToDo = []string{entry.todo}
+			if entry.todo == "" {
+				section.ToDo = []string{fmt.Sprintf("Describe how the organization meets clause %s", entry.clause)}
+			}
+		}
+		index[entry.clause] = len(outline.Sections)
+		outline.Sections = append(outline.Sections, section)
+	}
+
+	for _, doc := range documents {
+		if doc == nil || doc.Status == DocumentStatusObsolete || doc.Status == DocumentStatusArchived {
+			continue
+		}
+		ref := ManualDocumentRef{ID: doc.ID, Title: doc.Title, Type: doc.Type, Status: doc.Status}
+		placed := make(map[int]bool)
+		for _, related := range doc.Metadata.RelatedClauses {
+			if i, ok := manualSectionFor(index, related); ok && !placed[i] {
+				placed[i] = true
+				outline.Sections[i].Documents = append(outline.Sections[i].Documents, ref)
+			}
+		}
+		if len(placed) == 0 {
+			if clause, ok := manualCategoryClauses[doc.Category]; ok {
+				i := index[clause]
+				outline.Sections[i].Documents = append(outline.Sections[i].Documents, ref)
+			}
+		}
+	}
+	for i := range outline.Sections {
+		docs := outline.Sections[i].Documents
+		sort.Slice(docs, func(a, b int) bool { return docs[a].ID < docs[b].ID })
+	}
+
+	return outline
+}
+
+// manualSectionFor finds the section covering a clause reference such as "7.1.5" or
+// "Clause 8.4"
+func manualSectionFor(index map[string]int, reference string) (int, bool) {
+	number := normalizeClauseReference(reference)
+	for number != "" {
+		if i, ok := index[number]; ok {
+			return i, true
+		}
+		dot := strings.LastIndex(number, ".")
+		if dot < 0 {
+			break
+		}
+		number = number[:dot]
+	}
+	return 0, false
+}
+
+// manualContent returns the text the organization already holds for a clause
+func manualContent(org *Organization, clause string) []string {
+	var content []string
+	switch clause {
+	case "4.1":
+		if org.Context != nil {
+			for _, issue := range org.Context.ExternalIssues {
+				content = append(content, "External issue: "+issue.Description)
+			}
+			for _, issue := range org.Context.InternalIssues {
+				content = append(content, "Internal issue: "+issue.Description)
+			}
+		}
+	case "4.2":
+		if org.Context != nil {
+			for _, party := range org.Context.InterestedParties {
+				line := party.Name
+				if len(party.Requirements) > 0 {
+					line += ": " + strings.Join(party.Requirements, "; ")
+				}
+				content = append(content, line)
+			}
+		}
+	case "4.3":
+		if org.QMS != nil && org.QMS.Scope != nil {
+			scope := org.QMS.Scope
+			if scope.Description != "" {
+				content = append(content, scope.Description)
+			}
+			if len(scope.Products) > 0 {
+				content = append(content, "Products: "+strings.Join(scope.Products, ", "))
+			}
+			if len(scope.Services) > 0 {
+				content = append(content, "Services: "+strings.Join(scope.Services, ", "))
+			}
+			for _, exclusion := range scope.Exclusions {
+				content = append(content, fmt.Sprintf("Exclusion of clause %s: %s", exclusion.Clause, exclusion.Justification))
+			}
+		}
+	case "4.4":
+		if org.QMS != nil {
+			for _, process := range org.QMS.Processes {
+				line := process.Name
+				if process.Description != "" {
+					line += ": " + process.Description
+				}
+				content = append(content, line)
+			}
+		}
+	case "5.1":
+		if org.Leadership != nil {
+			for _, person := range org.Leadership.TopManagement {
+				content = append(content, fmt.Sprintf("%s, %s", person.Name, person.Role))
+			}
+		}
+	case "5.2":
+		if org.Leadership != nil && org.Leadership.QualityPolicy != nil && org.Leadership.QualityPolicy.Statement != "" {
+			content = append(content, org.Leadership.QualityPolicy.Statement)
+		}
+	case "5.3":
+		if org.Leadership != nil {
+			for _, role := range org.Leadership.Roles {
+				line := role.Name
+				if role.AssignedTo != "" {
+					line += " (" + role.AssignedTo + ")"
+				}
+				if len(role.Responsibilities) > 0 {
+					line += ": " + strings.Join(role.Responsibilities, "; ")
+				}
+				content = append(content, line)
+			}
+		}
+	case "6.1":
+		if org.QMS != nil {
+			for _, risk := range org.QMS.Risks {
+				content = append(content, "Risk: "+risk.Description)
+			}
+			for _, opportunity := range org.QMS.Opportunities {
+				content = append(content, "Opportunity: "+opportunity.Description)
+			}
+		}
+	case "6.2":
+		if org.QMS != nil {
+			for _, objective := range org.QMS.Objectives {
+				line := objective.Name
+				if objective.Responsible != "" {
+					line += " (responsible: " + objective.Responsible + ")"
+				}
+				content = append(content, line)
+			}
+		}
+	}
+	return content
+}
+
+// Markdown renders the outline as a Markdown document ready for completion
+func (o *QualityManualOutline) Markdown() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\nGenerated %s for organization %s.\n", o.Title, o.Generated.Format("2006-01-02"), o.OrganizationID)
+
+	for _, section := range o.Sections {
+		fmt.Fprintf(&b, "\n## %s %s\n\n", section.Clause, section.Title)
+		for _, line := range section.Content {
+			fmt.Fprintf(&b, "- %s\n", line)
+		}
+		for _, todo := range section.ToDo {
+			fmt.Fprintf(&b, "TODO: %s.\n", todo)
+		}
+		if len(section.Documents) > 0 {
+			b.WriteString("\nReferenced documents:\n\n")
+			for _, doc := range section.Documents {
+				fmt.Fprintf(&b, "- %s %s (%s, %s)\n", doc.ID, doc.Title, doc.Type, doc.Status)
+			}
+		}
+		if len(section.Records) > 0 {
+			b.WriteString("\nRecords to retain:\n\n")
+			for _, record := range section.Records {
+				fmt.Fprintf(&b, "- %s\n", record)
+			}
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,55 @@
+package iso9001
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateQualityManualOutline(t *testing.T) {
+	org := CreateExampleOrganization()
+	documents := []*DocumentedInformation{
+		{ID: "DOC-002", Title: "Calibration Procedure", Type: DocumentTypeProcedure, Status: DocumentStatusPublished, Metadata: DocumentMetadata{RelatedClauses: []string{"Clause 7.1.5.2"}}},
+		{ID: "DOC-001", Title: "Supplier Evaluation", Type: DocumentTypeProcedure, Status: DocumentStatusApproved, Category: CategorySupplier},
+		{ID: "DOC-003", Title: "Old Procedure", Status: DocumentStatusObsolete, Metadata: DocumentMetadata{RelatedClauses: []string{"7.1"}}},
+	}
+
+	outline := GenerateQualityManualOutline(org, documents...)
+	if outline.Title != org.Name+" Quality Manual" {
+		t.Errorf("Unexpected title %q", outline.Title)
+	}
+
+	sections := make(map[string]ManualSection)
+	for _, section := range outline.Sections {
+		sections[section.Clause] = section
+	}
+	if len(sections) != len(manualSections) || outline.Sections[len(outline.Sections)-1].Clause != "10.3" {
+		t.Fatalf("Expected %d sections ending at 10.3", len(manualSections))
+	}
+
+	if policy := sections["5.2"]; len(policy.Content) != 1 || policy.Content[0] != org.Leadership.QualityPolicy.Statement || len(policy.ToDo) != 0 {
+		t.Errorf("Expected policy statement in 5.2, got %+v", policy)
+	}
+	if processes := sections["4.4"]; len(processes.Content) != len(org.QMS.Processes) {
+		t.Errorf("Expected %d processes in 4.4, got %d", len(org.QMS.Processes), len(processes.Content))
+	}
+	if planning := sections["6.3"]; len(planning.ToDo) == 0 {
+		t.Error("Expected a to-do for clause 6.3")
+	}
+
+	if docs := sections["7.1"].Documents; len(docs) != 1 || docs[0].ID != "DOC-002" {
+		t.Errorf("Expected only the current calibration procedure under 7.1, got %+v", docs)
+	}
+	if docs := sections["8.4"].Documents; len(docs) != 1 || docs[0].ID != "DOC-001" {
+		t.Errorf("Expected supplier procedure under 8.4 by category, got %+v", docs)
+	}
+	if records := sections["9.2"].Records; len(records) == 0 {
+		t.Error("Expected required records for clause 9.2")
+	}
+
+	markdown := outline.Markdown()
+	for _, want := range []string{"## 5.2 Quality policy", "## 7.1 Resources", "- DOC-002 Calibration Procedure (procedure, published)", "TODO: "} {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("Markdown missing %q", want)
+		}
+	}
+}
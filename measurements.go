@@ -0,0 +1,299 @@
+package iso9001
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MeasurementLog holds the time-stamped measurement results of an organization, the
+// data behind objective progress, trends and process monitoring (clause 9.1.1)
+type MeasurementLog struct {
+	Results []MeasurementResult `json:"results" yaml:"results"`
+}
+
+// NewMeasurementLog creates an empty measurement log
+func NewMeasurementLog() *MeasurementLog {
+	return &MeasurementLog{}
+}
+
+// MeasurementIngestion reports the outcome of ingesting a batch of results
+type MeasurementIngestion struct {
+	Accepted []string               `json:"accepted" yaml:"accepted"` // IDs of the results added to the log
+	Rejected []MeasurementRejection `json:"rejected,omitempty" yaml:"rejected,omitempty"`
+}
+
+// MeasurementRejection explains why a result of a batch was not ingested
+type MeasurementRejection struct {
+	Index  int    `json:"index" yaml:"index"` // position of the result in the batch, from 0
+	ID     string `json:"id,omitempty" yaml:"id,omitempty"`
+	Reason string `json:"reason" yaml:"reason"`
+}
+
+// measurementLinks resolves the processes and objectives results refer to
+type measurementLinks struct {
+	processes  map[string]*Process
+	objectives map[string]*QualityObjective
+}
+
+func newMeasurementLinks(org *Organization, objectives *QualityObjectivesManager) *measurementLinks {
+	links := &measurementLinks{processes: map[string]*Process{}, objectives: map[string]*QualityObjective{}}
+	if org != nil && org.QMS != nil {
+		for i := range org.QMS.Processes {
+			links.processes[org.QMS.Processes[i].ID] = &org.QMS.Processes[i]
+		}
+		for i := range org.QMS.Objectives {
+			links.objectives[org.QMS.Objectives[i].ID] = &org.QMS.Objectives[i]
+		}
+	}
+	if objectives != nil {
+		for id, objective := range objectives.Objectives {
+			links.objectives[id] = objective
+		}
+	}
+	return links
+}
+
+// Ingest validates results and adds the valid ones to the log. Results must name a
+// metric, or take it from the criteria or single-target objective they refer to, and
+// carry a date. When org is not nil, process, criteria and objective references must
+// exist in it, and the metric of a result tied to an objective must be one of its
+// targets. Missing targets and units are filled in from the referenced criteria or
+// objective target, and missing IDs are assigned as MEAS-000001 onwards. Invalid
+// results are reported and skipped.
+func (l *MeasurementLog) Ingest(org *Organization, results ...MeasurementResult) MeasurementIngestion {
+	var links *measurementLinks
+	if org != nil {
+		links = newMeasurementLinks(org, nil)
+	}
+	return l.ingest(links, results)
+}
+
+// IngestMeasurements ingests results into the tenant's measurement log, resolving
+// references against its organization and objectives
+func (t *Tenant) IngestMeasurements(results ...MeasurementResult) MeasurementIngestion {
+	if t.Measurements == nil {
+		t.Measurements = NewMeasurementLog()
+	}
+	return t.Measurements.ingest(newMeasurementLinks(t.Organization, t.Objectives), results)
+}
+
+func (l *MeasurementLog) ingest(links *measurementLinks, results []MeasurementResult) MeasurementIngestion {
+	ingestion := MeasurementIngestion{Accepted: []string{}}
+	ids := make(map[string]bool, len(l.Results))
+	for _, result := range l.Results {
+		ids[result.ID] = true
+	}
+
+	for i, result := range results {
+		if err := links.resolve(&result); err != nil {
+			ingestion.Rejected = append(ingestion.Rejected, MeasurementRejection{Index: i, ID: result.ID, Reason: err.Error()})
+			continue
+		}
+		if result.ID == "" {
+			for n := len(l.Results) + 1; result.ID == "" || ids[result.ID]; n++ {
+				result.ID = fmt.Sprintf("MEAS-%06d", n)
+			}
+		} else if ids[result.ID] {
+			ingestion.Rejected = append(ingestion.Rejected, MeasurementRejection{Index: i, ID: result.ID, Reason: "duplicate measurement ID"})
+			continue
+		}
+		ids[result.ID] = true
+		l.Results = append(l.Results, result)
+		ingestion.Accepted = append(ingestion.Accepted, result.ID)
+	}
+	return ingestion
+}
+
+// resolve checks a result and fills in what its references imply. A nil receiver
+// checks the result alone.
+func (links *measurementLinks) resolve(result *MeasurementResult) error {
+	if math.IsNaN(result.Value) || math.IsInf(result.Value, 0) {
+		return errors.New("value must be a finite number")
+	}
+	if result.Date.IsZero() {
+		return errors.New("date is required")
+	}
+	if result.CriteriaID != "" && result.ProcessID == "" {
+		return errors.New("criteria_id requires process_id")
+	}
+
+	if links != nil && result.ProcessID != "" {
+		process, ok := links.processes[result.ProcessID]
+		if !ok {
+			return fmt.Errorf("unknown process %s", result.ProcessID)
+		}
+		if result.CriteriaID != "" {
+			criteria := findCriteria(process, result.CriteriaID)
+			if criteria == nil {
+				return fmt.Errorf("process %s has no criteria %s", result.ProcessID, result.CriteriaID)
+			}
+			if result.Metric == "" {
+				result.Metric = criteria.Metric
+			}
+			if result.Target == 0 {
+				result.Target, _ = ParseTargetValue(criteria.Target)
+			}
+		}
+	}
+
+	if links != nil && result.ObjectiveID != "" {
+		objective, ok := links.objectives[result.ObjectiveID]
+		if !ok {
+			return fmt.Errorf("unknown objective %s", result.ObjectiveID)
+		}
+		if result.Metric == "" && len(objective.Targets) == 1 {
+			result.Metric = objective.Targets[0].Metric
+		}
+		target, ok := findTarget(objective, result.Metric)
+		if !ok {
+			return fmt.Errorf("objective %s has no target for metric %q", result.ObjectiveID, result.Metric)
+		}
+		if result.Target == 0 {
+			result.Target, _ = ParseTargetValue(target.Value)
+		}
+		if result.Unit == "" {
+			result.Unit = target.Unit
+		}
+	}
+
+	if strings.TrimSpace(result.Metric) == "" {
+		return errors.New("metric is required")
+	}
+	return nil
+}
+
+func findCriteria(process *Process, id string) *ProcessCriteria {
+	for i := range process.Criteria {
+		if process.Criteria[i].ID == id {
+			return &process.Criteria[i]
+		}
+	}
+	return nil
+}
+
+func findTarget(objective *QualityObjective, metric string) (ObjectiveTarget, bool) {
+	for _, target := range objective.Targets {
+		if strings.EqualFold(target.Metric, metric) {
+			return target, true
+		}
+	}
+	return ObjectiveTarget{}, false
+}
+
+// MeasurementQuery selects results from a measurement log; empty fields match
+// everything
+type MeasurementQuery struct {
+	Metric      string    `json:"metric,omitempty" yaml:"metric,omitempty"`
+	ProcessID   string    `json:"process_id,omitempty" yaml:"process_id,omitempty"`
+	CriteriaID  string    `json:"criteria_id,omitempty" yaml:"criteria_id,omitempty"`
+	ObjectiveID string    `json:"objective_id,omitempty" yaml:"objective_id,omitempty"`
+	Since       time.Time `json:"since,omitempty" yaml:"since,omitempty"`
+	Until       time.Time `json:"until,omitempty" yaml:"until,omitempty"`
+}
+
+// Query returns the matching results ordered by date. Metrics are matched
+// case-insensitively; Since is inclusive and Until exclusive.
+func (l *MeasurementLog) Query(query MeasurementQuery) []MeasurementResult {
+	var matched []MeasurementResult
+	for _, result := range l.Results {
+		switch {
+		case query.Metric != "" && !strings.EqualFold(result.Metric, query.Metric),
+			query.ProcessID != "" && result.ProcessID != query.ProcessID,
+			query.CriteriaID != "" && result.CriteriaID != query.CriteriaID,
+			query.ObjectiveID != "" && result.ObjectiveID != query.ObjectiveID,
+			!query.Since.IsZero() && result.Date.Before(query.Since),
+			!query.Until.IsZero() && !result.Date.Before(query.Until):
+			continue
+		}
+		matched = append(matched, result)
+	}
+	sort.SliceStable(matched, func(i, j int) bool { return matched[i].Date.Before(matched[j].Date) })
+	return matched
+}
+
+// measurementColumns are the CSV columns ReadMeasurementsCSV understands
+var measurementColumns = []string{"id", "metric", "value", "target", "unit", "date", "process_id", "criteria_id", "objective_id", "source"}
+
+// ReadMeasurementsCSV reads measurement results from CSV with a header row naming the
+// columns: value and date are required; id, metric, target, unit, process_id,
+// criteria_id, objective_id and source are optional. Dates are RFC 3339 timestamps or
+// YYYY-MM-DD. The results still have to be ingested to be validated.
+func ReadMeasurementsCSV(r io.Reader) ([]MeasurementResult, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		known := false
+		for _, column := range measurementColumns {
+			known = known || column == name
+		}
+		if !known {
+			return nil, fmt.Errorf("unknown CSV column %q (expected %s)", name, strings.Join(measurementColumns, ", "))
+		}
+		columns[name] = i
+	}
+	for _, required := range []string{"value", "date"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("CSV header lacks required column %q", required)
+		}
+	}
+
+	var results []MeasurementResult
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return results, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		field := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		result := MeasurementResult{
+			ID:          field("id"),
+			Metric:      field("metric"),
+			Unit:        field("unit"),
+			ProcessID:   field("process_id"),
+			CriteriaID:  field("criteria_id"),
+			ObjectiveID: field("objective_id"),
+			Source:      field("source"),
+		}
+		if result.Value, err = strconv.ParseFloat(field("value"), 64); err != nil {
+			return nil, fmt.Errorf("line %d: invalid value %q", line, field("value"))
+		}
+		if target := field("target"); target != "" {
+			if result.Target, err = strconv.ParseFloat(target, 64); err != nil {
+				return nil, fmt.Errorf("line %d: invalid target %q", line, target)
+			}
+		}
+		if result.Date, err = parseMeasurementDate(field("date")); err != nil {
+			return nil, fmt.Errorf("line %d: invalid date %q", line, field("date"))
+		}
+		results = append(results, result)
+	}
+}
+
+func parseMeasurementDate(value string) (time.Time, error) {
+	if date, err := time.Parse(time.RFC3339, value); err == nil {
+		return date, nil
+	}
+	return time.Parse("2006-01-02", value)
+}
@@ -0,0 +1,74 @@
+package iso9001
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTenantIngestMeasurements(t *testing.T) {
+	tenant := NewTenant("ACME")
+	tenant.Organization.QMS = &QualityManagementSystem{
+		Processes: []Process{{ID: "PROC-001", Name: "Delivery", Criteria: []ProcessCriteria{{ID: "C-1", Metric: "delivery_rate", Target: "95%"}}}},
+	}
+	tenant.Objectives.Objectives["OBJ-001"] = &QualityObjective{
+		ID:      "OBJ-001",
+		Targets: []ObjectiveTarget{{Metric: "complaints", Value: "<= 4", Unit: "per month"}},
+	}
+
+	day := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	ingestion := tenant.IngestMeasurements(
+		MeasurementResult{ProcessID: "PROC-001", CriteriaID: "C-1", Value: 93, Date: day},
+		MeasurementResult{ObjectiveID: "OBJ-001", Value: 6, Date: day.AddDate(0, 0, -1)},
+		MeasurementResult{ID: "M-1", Metric: "scrap_rate", Value: 2, Date: day},
+		MeasurementResult{ID: "M-1", Metric: "scrap_rate", Value: 3, Date: day},
+		MeasurementResult{ProcessID: "PROC-404", Metric: "x", Value: 1, Date: day},
+		MeasurementResult{ObjectiveID: "OBJ-001", Metric: "scrap_rate", Value: 1, Date: day},
+		MeasurementResult{Metric: "scrap_rate", Value: 1},
+	)
+
+	if strings.Join(ingestion.Accepted, ",") != "MEAS-000001,MEAS-000002,M-1" {
+		t.Errorf("Unexpected accepted results %v", ingestion.Accepted)
+	}
+	if len(ingestion.Rejected) != 4 || ingestion.Rejected[0].Index != 3 || ingestion.Rejected[0].Reason != "duplicate measurement ID" {
+		t.Errorf("Unexpected rejections %+v", ingestion.Rejected)
+	}
+
+	delivery := tenant.Measurements.Query(MeasurementQuery{ProcessID: "PROC-001"})
+	if len(delivery) != 1 || delivery[0].Metric != "delivery_rate" || delivery[0].Target != 95 {
+		t.Errorf("Expected metric and target from the process criteria, got %+v", delivery)
+	}
+	complaints := tenant.Measurements.Query(MeasurementQuery{ObjectiveID: "OBJ-001"})
+	if len(complaints) != 1 || complaints[0].Metric != "complaints" || complaints[0].Unit != "per month" {
+		t.Errorf("Expected metric and unit from the objective target, got %+v", complaints)
+	}
+	if all := tenant.Measurements.Query(MeasurementQuery{Since: day}); len(all) != 2 {
+		t.Errorf("Expected two results since %s, got %d", day, len(all))
+	}
+}
+
+func TestReadMeasurementsCSV(t *testing.T) {
+	data := "metric,value,date,objective_id,Target\n" +
+		"delivery_rate,93.5,2024-06-01,OBJ-001,95\n" +
+		"complaints, 6, 2024-06-02T08:00:00Z,,\n"
+	results, err := ReadMeasurementsCSV(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("Failed to read CSV: %v", err)
+	}
+	if len(results) != 2 || results[0].Value != 93.5 || results[0].Target != 95 || results[0].ObjectiveID != "OBJ-001" {
+		t.Errorf("Unexpected results %+v", results)
+	}
+	if results[1].Date.Hour() != 8 {
+		t.Errorf("Expected RFC 3339 timestamp, got %s", results[1].Date)
+	}
+
+	if _, err := ReadMeasurementsCSV(strings.NewReader("metric,value\nx,1\n")); err == nil {
+		t.Error("Expected missing date column to be rejected")
+	}
+	if _, err := ReadMeasurementsCSV(strings.NewReader("metric,value,date,colour\n")); err == nil {
+		t.Error("Expected unknown column to be rejected")
+	}
+	if _, err := ReadMeasurementsCSV(strings.NewReader("value,date\nabc,2024-01-01\n")); err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("Expected line number in error, got %v", err)
+	}
+}
@@ -0,0 +1,101 @@
+package iso9001
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ErrReviewNotCompleted is returned when minutes are requested for a management review
+// that has not been completed
+var ErrReviewNotCompleted = errors.New("management review not completed")
+
+// MinutesIDPrefix is prepended to a management review ID to form the ID of its minutes
+const MinutesIDPrefix = "MIN-"
+
+// MinutesFormat is the file format minutes are written in
+type MinutesFormat string
+
+const (
+	MinutesMarkdown MinutesFormat = "markdown"
+	MinutesDOCX     MinutesFormat = "docx"
+)
+
+// WriteReviewMinutes writes the minutes of a completed management review as Markdown
+// or as a Word document, using the organization's management review template. A nil
+// templates uses the built-in template.
+func WriteReviewMinutes(w io.Writer, org *Organization, review *ManagementReview, format MinutesFormat, templates *ReportTemplates) error {
+	markdown, err := renderReviewMinutes(org, review, templates)
+	if err != nil {
+		return err
+	}
+	switch format {
+	case MinutesMarkdown, "":
+		_, err = io.WriteString(w, markdown)
+		return err
+	case MinutesDOCX:
+		return WriteDOCX(w, markdown)
+	}
+	return fmt.Errorf("unknown minutes format %q (expected %s or %s)", format, MinutesMarkdown, MinutesDOCX)
+}
+
+func renderReviewMinutes(org *Organization, review *ManagementReview, templates *ReportTemplates) (string, error) {
+	if review.Status != ReviewStatusCompleted {
+		return "", fmt.Errorf("%w: %s is %s", ErrReviewNotCompleted, review.ID, review.Status)
+	}
+	if templates == nil {
+		templates = NewReportTemplates()
+	}
+	var b strings.Builder
+	if err := templates.RenderManagementReviewMinutes(&b, org, review); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// ReviewMinutesDocument records the minutes of a completed management review as a
+// draft report, ready for approval like any other document. The Markdown minutes are
+// the document content; attendees are its keywords.
+func ReviewMinutesDocument(org *Organization, review *ManagementReview, templates *ReportTemplates) (*DocumentedInformation, error) {
+	content, err := renderReviewMinutes(org, review, templates)
+	if err != nil {
+		return nil, err
+	}
+	title := review.Title
+	if title == "" {
+		title = review.ID
+	}
+
+	doc := &DocumentedInformation{
+		ID:       MinutesIDPrefix + review.ID,
+		Title:    "Minutes: " + title,
+		Type:     DocumentTypeReport,
+		Category: CategoryManagementReview,
+		Content:  content,
+		Metadata: DocumentMetadata{
+			RelatedClauses: []string{"9.3"},
+			Format:         "electronic",
+		},
+		Status: DocumentStatusDraft,
+	}
+	for _, attendee := range review.Attendees {
+		if attendee.Present && attendee.Name != "" {
+			doc.Metadata.Keywords = append(doc.Metadata.Keywords, attendee.Name)
+		}
+	}
+	return doc, nil
+}
+
+// AddReviewMinutes records the minutes of a completed management review and adds them
+// to the manager as a draft report
+func (dm *DocumentationManager) AddReviewMinutes(org *Organization, review *ManagementReview, templates *ReportTemplates) (*DocumentedInformation, error) {
+	doc, err := ReviewMinutesDocument(org, review, templates)
+	if err != nil {
+		return nil, err
+	}
+	if err := dm.AddDocument(doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
@@ -0,0 +1,68 @@
+package iso9001
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReviewMinutes(t *testing.T) {
+	org := &Organization{ID: "ACME", Name: "Acme"}
+	review := &ManagementReview{
+		ID:        "MR-2024-1",
+		Title:     "Q1 review",
+		Date:      time.Date(2024, 4, 2, 0, 0, 0, 0, time.UTC),
+		Attendees: []ReviewAttendee{{Name: "Jane Doe", Role: "CEO", Present: true}, {Name: "Sam Lee", Present: false}},
+		Status:    ReviewStatusPending,
+	}
+
+	if _, err := ReviewMinutesDocument(org, review, nil); !errors.Is(err, ErrReviewNotCompleted) {
+		t.Errorf("Expected ErrReviewNotCompleted, got %v", err)
+	}
+
+	review.Status = ReviewStatusCompleted
+	review.Outputs = ManagementReviewOutputs{
+		Decisions:   []string{"Extend supplier audits to Tier 2 & Tier 3"},
+		ActionItems: []ActionItem{{ID: "A-1", Description: "Hire auditor", Responsible: "Jane Doe", DueDate: review.Date.AddDate(0, 1, 0)}},
+	}
+
+	dm := NewDocumentationManager()
+	doc, err := dm.AddReviewMinutes(org, review, nil)
+	if err != nil {
+		t.Fatalf("Failed to add minutes: %v", err)
+	}
+	if doc.ID != "MIN-MR-2024-1" || doc.Type != DocumentTypeReport || doc.Category != CategoryManagementReview {
+		t.Errorf("Unexpected minutes document %+v", doc)
+	}
+	if !strings.Contains(doc.Content, "- Decision: Extend supplier audits") || !strings.Contains(doc.Content, "Action A-1: Hire auditor, owner Jane Doe") {
+		t.Errorf("Minutes missing decisions or actions:\n%s", doc.Content)
+	}
+	if len(doc.Metadata.Keywords) != 1 || doc.Metadata.Keywords[0] != "Jane Doe" {
+		t.Errorf("Expected present attendees as keywords, got %v", doc.Metadata.Keywords)
+	}
+
+	var out bytes.Buffer
+	if err := WriteReviewMinutes(&out, org, review, MinutesDOCX, nil); err != nil {
+		t.Fatalf("Failed to write DOCX: %v", err)
+	}
+	archive, err := zip.NewReader(bytes.NewReader(out.Bytes()), int64(out.Len()))
+	if err != nil {
+		t.Fatalf("DOCX is not a zip archive: %v", err)
+	}
+	var document string
+	for _, file := range archive.File {
+		if file.Name == "word/document.xml" {
+			rc, _ := file.Open()
+			data, _ := io.ReadAll(rc)
+			rc.Close()
+			document = string(data)
+		}
+	}
+	if !strings.Contains(document, `<w:pStyle w:val="Title"/>`) || !strings.Contains(document, "Tier 2 &amp; Tier 3") {
+		t.Errorf("Unexpected document.xml:\n%s", document)
+	}
+}
@@ -0,0 +1,374 @@
+package iso9001
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ErrConcessionRequired is returned when an output is released under concession, or
+// closed after such a release, without an approved concession
+var ErrConcessionRequired = errors.New("approved concession required")
+
+// DispositionType is the action taken on a nonconforming output (clause 8.7.1)
+type DispositionType string
+
+const (
+	DispositionRework     DispositionType = "rework"     // corrected, then verified against the requirements
+	DispositionConcession DispositionType = "concession" // used or released as is under an approved concession
+	DispositionScrap      DispositionType = "scrap"
+	DispositionReturn     DispositionType = "return" // returned to the supplier or recalled from the customer
+)
+
+// NonconformingOutputStatus tracks a nonconforming output from detection to closure
+type NonconformingOutputStatus string
+
+const (
+	OutputStatusOpen          NonconformingOutputStatus = "open"
+	OutputStatusDispositioned NonconformingOutputStatus = "dispositioned"
+	OutputStatusClosed        NonconformingOutputStatus = "closed"
+)
+
+// ConcessionKind distinguishes a concession, granted for output already produced, from a
+// deviation permit, granted before production to depart from the requirements
+type ConcessionKind string
+
+const (
+	ConcessionKindConcession ConcessionKind = "concession"
+	ConcessionKindDeviation  ConcessionKind = "deviation"
+)
+
+// ConcessionStatus is the decision on a concession request
+type ConcessionStatus string
+
+const (
+	ConcessionPending  ConcessionStatus = "pending"
+	ConcessionApproved ConcessionStatus = "approved"
+	ConcessionRejected ConcessionStatus = "rejected"
+)
+
+// ConcessionRequest asks the relevant authority and, where applicable, the customer to
+// accept an output that does not meet its requirements
+type ConcessionRequest struct {
+	Kind              ConcessionKind   `json:"kind" yaml:"kind"`
+	Justification     string           `json:"justification" yaml:"justification"`
+	RequestedBy       string           `json:"requested_by" yaml:"requested_by"`
+	Requested         time.Time        `json:"requested" yaml:"requested"`
+	RequiredApprovers []string         `json:"required_approvers" yaml:"required_approvers"` // e.g. quality_manager, customer
+	ValidUntil        time.Time        `json:"valid_until,omitempty" yaml:"valid_until,omitempty"`
+	Approvals         []Approval       `json:"approvals,omitempty" yaml:"approvals,omitempty"`
+	Status            ConcessionStatus `json:"status" yaml:"status"`
+}
+
+// Disposition records how a nonconforming output was dealt with and who decided it
+type Disposition struct {
+	Type       DispositionType `json:"type" yaml:"type"`
+	Quantity   float64         `json:"quantity,omitempty" yaml:"quantity,omitempty"` // defaults to the whole output
+	Rationale  string          `json:"rationale" yaml:"rationale"`
+	DecidedBy  string          `json:"decided_by" yaml:"decided_by"`
+	Date       time.Time       `json:"date" yaml:"date"`
+	VerifiedBy string          `json:"verified_by,omitempty" yaml:"verified_by,omitempty"` // conformity verified after rework
+	Verified   *time.Time      `json:"verified,omitempty" yaml:"verified,omitempty"`
+}
+
+// NonconformingOutput is a product or service found not to conform to its requirements
+// and controlled to prevent its unintended use or delivery (clause 8.7)
+type NonconformingOutput struct {
+	ID                string                    `json:"id" yaml:"id"`
+	Description       string                    `json:"description" yaml:"description"`
+	Product           string                    `json:"product" yaml:"product"` // product, batch or service affected
+	Quantity          float64                   `json:"quantity,omitempty" yaml:"quantity,omitempty"`
+	Unit              string                    `json:"unit,omitempty" yaml:"unit,omitempty"`
+	Process           string                    `json:"process,omitempty" yaml:"process,omitempty"`
+	Requirement       string                    `json:"requirement,omitempty" yaml:"requirement,omitempty"` // requirement not met
+	DetectedBy        string                    `json:"detected_by,omitempty" yaml:"detected_by,omitempty"`
+	Detected          time.Time                 `json:"detected" yaml:"detected"`
+	Status            NonconformingOutputStatus `json:"status" yaml:"status"`
+	Concession        *ConcessionRequest        `json:"concession,omitempty" yaml:"concession,omitempty"`
+	Disposition       *Disposition              `json:"disposition,omitempty" yaml:"disposition,omitempty"`
+	NonconformanceID  string                    `json:"nonconformance_id,omitempty" yaml:"nonconformance_id,omitempty"` // related nonconformity, if any
+	CorrectiveActions []CorrectiveAction        `json:"corrective_actions,omitempty" yaml:"corrective_actions,omitempty"`
+	Closed            *time.Time                `json:"closed,omitempty" yaml:"closed,omitempty"`
+}
+
+// NonconformingOutputManager keeps the records of nonconforming outputs, their
+// dispositions and concessions required by clause 8.7.2
+type NonconformingOutputManager struct {
+	Outputs map[string]*NonconformingOutput `json:"outputs" yaml:"outputs"`
+
+	// IDs, when set, is shared with the organization's other managers
+	IDs *IDRegistry `json:"-" yaml:"-"`
+}
+
+// NewNonconformingOutputManager creates an empty nonconforming output manager
+func NewNonconformingOutputManager() *NonconformingOutputManager {
+	return &NonconformingOutputManager{Outputs: make(map[string]*NonconformingOutput)}
+}
+
+// RecordOutput records a nonconforming output. The detection date defaults to now and
+// the ID to NCO-0001 onwards.
+func (nm *NonconformingOutputManager) RecordOutput(output *NonconformingOutput) error {
+	if output.Description == "" {
+		return fmt.Errorf("nonconforming output must have a description")
+	}
+	if output.Product == "" {
+		return fmt.Errorf("nonconforming output must name the product or service affected")
+	}
+	if output.ID == "" {
+		output.ID = nm.nextID()
+	}
+	_, exists := nm.Outputs[output.ID]
+	if err := claimID(nm.IDs, output.ID, EntityTypeNonconformingOutput, exists); err != nil {
+		return err
+	}
+
+	if output.Detected.IsZero() {
+		output.Detected = time.Now()
+	}
+	output.Status = OutputStatusOpen
+	nm.Outputs[output.ID] = output
+	return nil
+}
+
+func (nm *NonconformingOutputManager) nextID() string {
+	for n := len(nm.Outputs) + 1; ; n++ {
+		id := fmt.Sprintf("NCO-%04d", n)
+		if _, exists := nm.Outputs[id]; !exists {
+			return id
+		}
+	}
+}
+
+func (nm *NonconformingOutputManager) output(outputID string) (*NonconformingOutput, error) {
+	output, exists := nm.Outputs[outputID]
+	if !exists {
+		return nil, fmt.Errorf("nonconforming output with ID %s not found", outputID)
+	}
+	return output, nil
+}
+
+// RequestConcession asks for a concession or deviation permit for an open output,
+// replacing an earlier request that was rejected
+func (nm *NonconformingOutputManager) RequestConcession(outputID string, request ConcessionRequest) error {
+	output, err := nm.output(outputID)
+	if err != nil {
+		return err
+	}
+	if output.Status != OutputStatusOpen {
+		return &InvalidTransitionError{EntityType: EntityTypeNonconformingOutput, EntityID: outputID, From: string(output.Status), To: "concession requested"}
+	}
+	if output.Concession != nil && output.Concession.Status != ConcessionRejected {
+		return fmt.Errorf("nonconforming output %s already has a %s concession", outputID, output.Concession.Status)
+	}
+	if request.Justification == "" {
+		return fmt.Errorf("concession for %s must be justified", outputID)
+	}
+	if len(request.RequiredApprovers) == 0 {
+		return fmt.Errorf("concession for %s must name its required approvers", outputID)
+	}
+	if request.Kind == "" {
+		request.Kind = ConcessionKindConcession
+	}
+	if request.Requested.IsZero() {
+		request.Requested = time.Now()
+	}
+	request.Approvals = nil
+	request.Status = ConcessionPending
+	output.Concession = &request
+	return nil
+}
+
+// DecideConcession records an approver's decision on a pending concession. The
+// concession is approved once every required approver has approved it, identified by
+// approver ID or role, and rejected as soon as one of them rejects it.
+func (nm *NonconformingOutputManager) DecideConcession(outputID string, approver Approval, approved bool) error {
+	output, err := nm.output(outputID)
+	if err != nil {
+		return err
+	}
+	concession := output.Concession
+	if concession == nil || concession.Status != ConcessionPending {
+		return fmt.Errorf("nonconforming output %s has no pending concession", outputID)
+	}
+	if !containsString(approver.ApproverID, concession.RequiredApprovers...) && !containsString(approver.Role, concession.RequiredApprovers...) {
+		return fmt.Errorf("%s is not a required approver of the concession for %s", approver.ApproverID, outputID)
+	}
+	if approver.Timestamp.IsZero() {
+		approver.Timestamp = time.Now()
+	}
+
+	if !approved {
+		concession.Status = ConcessionRejected
+		concession.Approvals = append(concession.Approvals, approver)
+		return nil
+	}
+	concession.Approvals = append(concession.Approvals, approver)
+	for _, required := range concession.RequiredApprovers {
+		if !concessionApprovedBy(concession, required) {
+			return nil
+		}
+	}
+	concession.Status = ConcessionApproved
+	return nil
+}
+
+func concessionApprovedBy(concession *ConcessionRequest, required string) bool {
+	for _, approval := range concession.Approvals {
+		if approval.ApproverID == required || approval.Role == required {
+			return true
+		}
+	}
+	return false
+}
+
+// SetDisposition decides what happens to a nonconforming output. A concession
+// disposition needs an approved concession that has not expired. The disposition can
+// be changed until the output is closed.
+func (nm *NonconformingOutputManager) SetDisposition(outputID string, disposition Disposition) error {
+	output, err := nm.output(outputID)
+	if err != nil {
+		return err
+	}
+	if err := outputTransitions.check(EntityTypeNonconformingOutput, outputID, output.Status, OutputStatusDispositioned); err != nil {
+		return err
+	}
+	switch disposition.Type {
+	case DispositionRework, DispositionScrap, DispositionReturn:
+	case DispositionConcession:
+		if err := output.checkConcession(disposition.Date); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown disposition %q for %s", disposition.Type, outputID)
+	}
+	if disposition.DecidedBy == "" {
+		return fmt.Errorf("disposition of %s must name who decided it", outputID)
+	}
+	if disposition.Date.IsZero() {
+		disposition.Date = time.Now()
+	}
+	if disposition.Quantity == 0 {
+		disposition.Quantity = output.Quantity
+	}
+	disposition.VerifiedBy, disposition.Verified = "", nil
+
+	output.Disposition = &disposition
+	output.Status = OutputStatusDispositioned
+	return nil
+}
+
+// checkConcession returns ErrConcessionRequired unless the output has a concession
+// approved and valid at the given time
+func (o *NonconformingOutput) checkConcession(at time.Time) error {
+	if o.Concession == nil || o.Concession.Status != ConcessionApproved {
+		return fmt.Errorf("%w: nonconforming output %s", ErrConcessionRequired, o.ID)
+	}
+	if !o.Concession.ValidUntil.IsZero() && !at.IsZero() && at.After(o.Concession.ValidUntil) {
+		return fmt.Errorf("%w: the concession for %s expired on %s", ErrConcessionRequired, o.ID, o.Concession.ValidUntil.Format("2006-01-02"))
+	}
+	return nil
+}
+
+// VerifyRework records that reworked output was verified to conform to the requirements
+func (nm *NonconformingOutputManager) VerifyRework(outputID, verifier string, at time.Time) error {
+	output, err := nm.output(outputID)
+	if err != nil {
+		return err
+	}
+	if output.Disposition == nil || output.Disposition.Type != DispositionRework {
+		return fmt.Errorf("nonconforming output %s was not reworked", outputID)
+	}
+	output.Disposition.VerifiedBy = verifier
+	output.Disposition.Verified = &at
+	return nil
+}
+
+// CloseOutput closes a dispositioned output. Reworked output must have been verified.
+func (nm *NonconformingOutputManager) CloseOutput(outputID string, at time.Time) error {
+	output, err := nm.output(outputID)
+	if err != nil {
+		return err
+	}
+	if err := outputTransitions.check(EntityTypeNonconformingOutput, outputID, output.Status, OutputStatusClosed); err != nil {
+		return err
+	}
+	if output.Disposition.Type == DispositionRework && output.Disposition.Verified == nil {
+		return fmt.Errorf("nonconforming output %s: rework must be verified before closing", outputID)
+	}
+	output.Status = OutputStatusClosed
+	output.Closed = &at
+	return nil
+}
+
+// RaiseCorrectiveAction links a corrective action to a nonconforming output, so the
+// cause of the nonconformity is addressed and does not recur (clause 10.2). The ID
+// defaults to the output ID followed by -CA1, -CA2 and so on.
+func (nm *NonconformingOutputManager) RaiseCorrectiveAction(outputID string, action CorrectiveAction) (CorrectiveAction, error) {
+	output, err := nm.output(outputID)
+	if err != nil {
+		return action, err
+	}
+	if action.Description == "" {
+		return action, fmt.Errorf("corrective action for %s must have a description", outputID)
+	}
+	if action.ID == "" {
+		action.ID = fmt.Sprintf("%s-CA%d", outputID, len(output.CorrectiveActions)+1)
+	}
+	for _, existing := range output.CorrectiveActions {
+		if existing.ID == action.ID {
+			return action, fmt.Errorf("nonconforming output %s already has corrective action %s", outputID, action.ID)
+		}
+	}
+	if action.Status == "" {
+		action.Status = ActionStatusPlanned
+	}
+	output.CorrectiveActions = append(output.CorrectiveActions, action)
+	return action, nil
+}
+
+// NonconformingOutputSummary counts nonconforming outputs for reporting, e.g. as
+// input to management review
+type NonconformingOutputSummary struct {
+	Total          int                               `json:"total" yaml:"total"`
+	ByStatus       map[NonconformingOutputStatus]int `json:"by_status" yaml:"by_status"`
+	ByDisposition  map[DispositionType]int           `json:"by_disposition" yaml:"by_disposition"`
+	ByProduct      map[string]int                    `json:"by_product" yaml:"by_product"`
+	Concessions    int                               `json:"concessions" yaml:"concessions"`         // concessions and deviations requested
+	OpenActions    int                               `json:"open_actions" yaml:"open_actions"`       // corrective actions not yet completed
+	AwaitingAction []string                          `json:"awaiting_action" yaml:"awaiting_action"` // open outputs without disposition
+}
+
+// Summary counts the outputs detected between since and until; either may be zero for
+// no bound
+func (nm *NonconformingOutputManager) Summary(since, until time.Time) NonconformingOutputSummary {
+	summary := NonconformingOutputSummary{
+		ByStatus:       make(map[NonconformingOutputStatus]int),
+		ByDisposition:  make(map[DispositionType]int),
+		ByProduct:      make(map[string]int),
+		AwaitingAction: []string{},
+	}
+	for _, output := range nm.Outputs {
+		if (!since.IsZero() && output.Detected.Before(since)) || (!until.IsZero() && output.Detected.After(until)) {
+			continue
+		}
+		summary.Total++
+		summary.ByStatus[output.Status]++
+		summary.ByProduct[output.Product]++
+		if output.Disposition != nil {
+			summary.ByDisposition[output.Disposition.Type]++
+		} else {
+			summary.AwaitingAction = append(summary.AwaitingAction, output.ID)
+		}
+		if output.Concession != nil {
+			summary.Concessions++
+		}
+		for _, action := range output.CorrectiveActions {
+			if actionOpen(action.Status) {
+				summary.OpenActions++
+			}
+		}
+	}
+	sort.Strings(summary.AwaitingAction)
+	return summary
+}
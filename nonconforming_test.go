@@ -0,0 +1,86 @@
+package iso9001
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNonconformingOutputDisposition(t *testing.T) {
+	tenant := NewTenant("ACME")
+	nm := tenant.Outputs
+	day := time.Date(2024, 3, 4, 10, 0, 0, 0, time.UTC)
+
+	batch := &NonconformingOutput{Description: "Bore diameter out of tolerance", Product: "Housing batch 2411", Quantity: 40, Unit: "pcs", Detected: day}
+	if err := nm.RecordOutput(batch); err != nil {
+		t.Fatalf("Failed to record output: %v", err)
+	}
+	if batch.ID != "NCO-0001" || batch.Status != OutputStatusOpen {
+		t.Fatalf("Unexpected output %+v", batch)
+	}
+	if err := nm.RecordOutput(&NonconformingOutput{ID: "ACME", Description: "Clash", Product: "X"}); err == nil {
+		t.Error("Expected an ID used by another entity to be rejected")
+	}
+
+	if err := nm.SetDisposition("NCO-0001", Disposition{Type: DispositionConcession, DecidedBy: "jane"}); !errors.Is(err, ErrConcessionRequired) {
+		t.Errorf("Expected concession disposition without approval to fail, got %v", err)
+	}
+	if err := nm.RequestConcession("NCO-0001", ConcessionRequest{Justification: "Fit unaffected", RequestedBy: "sam", RequiredApprovers: []string{"quality_manager", "customer"}}); err != nil {
+		t.Fatalf("Failed to request concession: %v", err)
+	}
+	if err := nm.DecideConcession("NCO-0001", Approval{ApproverID: "bob", Role: "buyer"}, true); err == nil {
+		t.Error("Expected an approver not required by the concession to be rejected")
+	}
+	if err := nm.DecideConcession("NCO-0001", Approval{ApproverID: "jane", Role: "quality_manager"}, true); err != nil {
+		t.Fatalf("Failed to approve: %v", err)
+	}
+	if batch.Concession.Status != ConcessionPending {
+		t.Errorf("Expected concession to wait for the customer, got %s", batch.Concession.Status)
+	}
+	if err := nm.DecideConcession("NCO-0001", Approval{ApproverID: "customer"}, true); err != nil {
+		t.Fatalf("Failed to approve: %v", err)
+	}
+	if err := nm.SetDisposition("NCO-0001", Disposition{Type: DispositionConcession, DecidedBy: "jane", Rationale: "Accepted by customer"}); err != nil {
+		t.Fatalf("Failed to set disposition: %v", err)
+	}
+	if batch.Disposition.Quantity != 40 || batch.Status != OutputStatusDispositioned {
+		t.Errorf("Expected the whole batch to be dispositioned, got %+v", batch.Disposition)
+	}
+	if err := nm.CloseOutput("NCO-0001", day.AddDate(0, 0, 2)); err != nil {
+		t.Errorf("Failed to close output: %v", err)
+	}
+
+	rework := &NonconformingOutput{Description: "Scratched coating", Product: "Panel", Detected: day}
+	if err := nm.RecordOutput(rework); err != nil {
+		t.Fatal(err)
+	}
+	if err := nm.SetDisposition(rework.ID, Disposition{Type: DispositionRework, DecidedBy: "sam"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := nm.CloseOutput(rework.ID, day); err == nil {
+		t.Error("Expected unverified rework to keep the output open")
+	}
+	action, err := nm.RaiseCorrectiveAction(rework.ID, CorrectiveAction{Description: "Add protective film", Responsible: "sam", DueDate: day.AddDate(0, 0, 14)})
+	if err != nil || action.ID != "NCO-0002-CA1" || action.Status != ActionStatusPlanned {
+		t.Fatalf("Unexpected corrective action %+v (%v)", action, err)
+	}
+	if err := nm.VerifyRework(rework.ID, "jane", day); err != nil {
+		t.Fatal(err)
+	}
+	if err := nm.CloseOutput(rework.ID, day); err != nil {
+		t.Errorf("Failed to close verified rework: %v", err)
+	}
+
+	deadlines := tenant.Deadlines()
+	if len(deadlines) != 1 || deadlines[0].EntityID != "NCO-0002-CA1" || deadlines[0].Kind != DeadlineCorrectiveAction {
+		t.Errorf("Expected the corrective action deadline, got %+v", deadlines)
+	}
+
+	summary := nm.Summary(time.Time{}, time.Time{})
+	if summary.Total != 2 || summary.ByDisposition[DispositionRework] != 1 || summary.Concessions != 1 || summary.OpenActions != 1 {
+		t.Errorf("Unexpected summary %+v", summary)
+	}
+	if entity, ok := tenant.Lookup("nonconforming_outputs", "NCO-0002"); !ok || entity.(*NonconformingOutput) != rework {
+		t.Error("Expected output to be addressable by the tenant")
+	}
+}
@@ -0,0 +1,149 @@
+package iso9001
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// Reminder events named in the X-QMS-Event header of webhook reminders
+const (
+	ReminderEventDue       = "reminder.due"
+	ReminderEventOverdue   = "reminder.overdue"
+	ReminderEventEscalated = "reminder.escalated"
+)
+
+// event returns the webhook event of the reminder
+func (r Reminder) event() string {
+	switch {
+	case len(r.EscalateTo) > 0:
+		return ReminderEventEscalated
+	case r.Overdue:
+		return ReminderEventOverdue
+	}
+	return ReminderEventDue
+}
+
+// WebhookSink posts each reminder as JSON to a URL, e.g. of a chat or ticketing
+// system. With a secret, requests are signed like the payloads of a Webhook.
+type WebhookSink struct {
+	URL    string
+	Secret string
+	// Client sends the requests; a client with a 10 second timeout when nil
+	Client *http.Client
+}
+
+// Notify posts the reminder
+func (s WebhookSink) Notify(ctx context.Context, reminder Reminder) error {
+	body, err := json.Marshal(reminder)
+	if err != nil {
+		return err
+	}
+	client := s.Client
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	d := reminder.Deadline
+	delivery := fmt.Sprintf("%s:%s:%s:%s", reminder.event(), d.Kind, d.EntityID, d.Due.UTC().Format("2006-01-02"))
+	if err := postWebhook(ctx, client, Webhook{URL: s.URL, Secret: s.Secret}, reminder.event(), delivery, body); err != nil {
+		return fmt.Errorf("webhook %s: %w", s.URL, err)
+	}
+	return nil
+}
+
+// EmailSink mails each reminder through an SMTP server to the person responsible for
+// the deadline and, for escalations, to the escalation recipients
+type EmailSink struct {
+	Addr string // host:port of the SMTP server
+	Auth smtp.Auth
+	From string
+	// To receive every reminder
+	To []string
+	// Recipients maps the responsible persons and roles of deadlines, and escalation
+	// recipients that are not addresses, to e-mail addresses
+	Recipients map[string][]string
+
+	send func(addr string, auth smtp.Auth, from string, to []string, msg []byte) error // smtp.SendMail when nil
+}
+
+// Notify mails the reminder. It fails when the reminder has no recipient.
+func (s EmailSink) Notify(ctx context.Context, reminder Reminder) error {
+	to := append([]string(nil), s.To...)
+	add := func(name string) {
+		addresses, ok := s.Recipients[name]
+		if !ok && strings.Contains(name, "@") {
+			addresses = []string{name}
+		}
+		for _, address := range addresses {
+			if !containsString(address, to...) {
+				to = append(to, address)
+			}
+		}
+	}
+	if reminder.Deadline.Responsible != "" {
+		add(reminder.Deadline.Responsible)
+	}
+	for _, name := range reminder.EscalateTo {
+		add(name)
+	}
+	if len(to) == 0 {
+		return fmt.Errorf("no e-mail recipient for %s %s", reminder.Deadline.Kind, reminder.Deadline.EntityID)
+	}
+
+	send := s.send
+	if send == nil {
+		send = smtp.SendMail
+	}
+	if err := send(s.Addr, s.Auth, s.From, to, s.message(reminder, to)); err != nil {
+		return fmt.Errorf("e-mail: %w", err)
+	}
+	return nil
+}
+
+// message composes the plain text e-mail for a reminder
+func (s EmailSink) message(reminder Reminder, to []string) []byte {
+	d := reminder.Deadline
+	subject := "Due"
+	switch {
+	case len(reminder.EscalateTo) > 0:
+		subject = "Escalated"
+	case reminder.Overdue:
+		subject = "Overdue"
+	}
+	sent := reminder.Sent
+	if sent.IsZero() {
+		sent = time.Now()
+	}
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", s.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(&msg, "Subject: [QMS] %s: %s %s %s\r\n", subject, d.Kind, d.EntityID, strings.ReplaceAll(d.Title, "\n", " "))
+	fmt.Fprintf(&msg, "Date: %s\r\n", sent.Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\nContent-Type: text/plain; charset=utf-8\r\n\r\n")
+	fmt.Fprintf(&msg, "%s\r\n", reminder)
+	if d.ParentID != "" {
+		fmt.Fprintf(&msg, "\r\nBelongs to: %s\r\n", d.ParentID)
+	}
+	if reminder.Escalation > 0 {
+		fmt.Fprintf(&msg, "\r\nOverdue for more than %s.\r\n", formatDays(reminder.Escalation))
+	}
+	return msg.Bytes()
+}
+
+// formatDays formats a duration in whole days where it is one
+func formatDays(d time.Duration) string {
+	if d%(24*time.Hour) == 0 {
+		days := int(d / (24 * time.Hour))
+		if days == 1 {
+			return "1 day"
+		}
+		return fmt.Sprintf("%d days", days)
+	}
+	return d.String()
+}
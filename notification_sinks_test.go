@@ -0,0 +1,84 @@
+package iso9001
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEmailSinkRecipients(t *testing.T) {
+	type mail struct {
+		to  []string
+		msg string
+	}
+	var sent []mail
+	sink := EmailSink{
+		Addr:       "smtp.acme.example:587",
+		From:       "qms@acme.example",
+		To:         []string{"log@acme.example"},
+		Recipients: map[string][]string{"Purchasing": {"buyer@acme.example"}, "Quality Manager": {"qm@acme.example"}},
+		send: func(addr string, auth smtp.Auth, from string, to []string, msg []byte) error {
+			sent = append(sent, mail{to, string(msg)})
+			return nil
+		},
+	}
+
+	due := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	reminder := Reminder{
+		Deadline:   Deadline{Kind: DeadlineFinding, EntityID: "F-1", ParentID: "AUDIT-001", Title: "No supplier evaluation", Responsible: "Purchasing", Due: due},
+		Overdue:    true,
+		Escalation: 7 * 24 * time.Hour,
+		EscalateTo: []string{"Quality Manager", "ceo@acme.example"},
+		Sent:       due.AddDate(0, 0, 8),
+	}
+	if err := sink.Notify(context.Background(), reminder); err != nil {
+		t.Fatalf("Notify failed: %v", err)
+	}
+	if len(sent) != 1 || strings.Join(sent[0].to, ",") != "log@acme.example,buyer@acme.example,qm@acme.example,ceo@acme.example" {
+		t.Fatalf("Unexpected recipients %+v", sent)
+	}
+	for _, want := range []string{"Subject: [QMS] Escalated: finding F-1 No supplier evaluation", "Belongs to: AUDIT-001", "Overdue for more than 7 days."} {
+		if !strings.Contains(sent[0].msg, want) {
+			t.Errorf("Expected message to contain %q, got\n%s", want, sent[0].msg)
+		}
+	}
+
+	sink.To = nil
+	reminder.Deadline.Responsible, reminder.EscalateTo = "Nobody", nil
+	if err := sink.Notify(context.Background(), reminder); err == nil {
+		t.Error("Expected a reminder without recipients to fail")
+	}
+}
+
+func TestWebhookSinkPostsReminder(t *testing.T) {
+	var event string
+	var got Reminder
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		source := EventSource{Name: "qms", Secret: "s3cret"}
+		if err := source.Verify(body, r.Header.Get("X-QMS-Timestamp"), r.Header.Get("X-QMS-Signature"), time.Now()); err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		event = r.Header.Get("X-QMS-Event")
+		json.Unmarshal(body, &got)
+	}))
+	defer server.Close()
+
+	reminder := Reminder{Deadline: Deadline{Kind: DeadlineObjective, EntityID: "OBJ-1", Due: time.Now()}, Overdue: true}
+	if err := (WebhookSink{URL: server.URL, Secret: "s3cret"}).Notify(context.Background(), reminder); err != nil {
+		t.Fatalf("Notify failed: %v", err)
+	}
+	if event != ReminderEventOverdue || got.Deadline.EntityID != "OBJ-1" {
+		t.Errorf("Expected overdue reminder for OBJ-1, got %q %+v", event, got)
+	}
+	if err := (WebhookSink{URL: server.URL, Secret: "wrong"}).Notify(context.Background(), reminder); err == nil {
+		t.Error("Expected rejected webhook to fail")
+	}
+}
@@ -0,0 +1,293 @@
+package iso9001
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrNoMeasurements is returned when measured progress is requested but no measurement
+// result matches a numeric target of the objective
+var ErrNoMeasurements = errors.New("no measurements for objective targets")
+
+// TargetDirection states whether a metric has to rise or fall to reach its target
+type TargetDirection string
+
+const (
+	TargetIncrease TargetDirection = "increase"
+	TargetDecrease TargetDirection = "decrease"
+)
+
+// ProgressSource distinguishes progress computed from measurement data from progress
+// reported, or overridden, by hand
+type ProgressSource string
+
+const (
+	ProgressSourceManual   ProgressSource = "manual"
+	ProgressSourceMeasured ProgressSource = "measured"
+)
+
+// TargetProgress is the progress towards one objective target computed from the
+// latest measurement of its metric
+type TargetProgress struct {
+	TargetID   string    `json:"target_id,omitempty" yaml:"target_id,omitempty"`
+	Metric     string    `json:"metric" yaml:"metric"`
+	Target     float64   `json:"target" yaml:"target"`
+	Actual     float64   `json:"actual" yaml:"actual"`
+	MeasuredAt time.Time `json:"measured_at" yaml:"measured_at"`
+	Progress   float64   `json:"progress" yaml:"progress"` // 0-100
+}
+
+// ParseTargetValue reads the number a target value starts with, e.g. 95 from "95%" or
+// 2.5 from "<= 2.5 days". Comparison signs are skipped.
+func ParseTargetValue(value string) (float64, bool) {
+	value = strings.TrimLeft(strings.TrimSpace(value), "<>=≤≥ ")
+	end := 0
+	for end < len(value) && (value[end] >= '0' && value[end] <= '9' || value[end] == '.' || end == 0 && value[end] == '-') {
+		end++
+	}
+	number, err := strconv.ParseFloat(value[:end], 64)
+	return number, err == nil
+}
+
+// direction returns the target's direction: as set, falling when the target lies
+// below the baseline or the value reads "< ..." or "≤ ...", and rising otherwise
+func (t ObjectiveTarget) direction(target float64) TargetDirection {
+	switch {
+	case t.Direction != "":
+		return t.Direction
+	case t.Baseline != nil && target < *t.Baseline:
+		return TargetDecrease
+	case strings.HasPrefix(strings.TrimSpace(t.Value), "<"), strings.HasPrefix(strings.TrimSpace(t.Value), "≤"):
+		return TargetDecrease
+	}
+	return TargetIncrease
+}
+
+// ComputeTargetProgress computes progress towards a target from the latest measurement
+// result of its metric. With a baseline, progress is the share of the distance from
+// baseline to target covered; without one, it is the ratio of actual to target, or of
+// target to actual for falling metrics. It reports false when the target value is not
+// numeric or no result matches the metric.
+func ComputeTargetProgress(target ObjectiveTarget, results []MeasurementResult) (TargetProgress, bool) {
+	goal, ok := ParseTargetValue(target.Value)
+	if !ok {
+		return TargetProgress{}, false
+	}
+	var latest *MeasurementResult
+	for i := range results {
+		if strings.EqualFold(results[i].Metric, target.Metric) && (latest == nil || results[i].Date.After(latest.Date)) {
+			latest = &results[i]
+		}
+	}
+	if latest == nil {
+		return TargetProgress{}, false
+	}
+
+	actual := latest.Value
+	decreasing := target.direction(goal) == TargetDecrease
+	var progress float64
+	switch {
+	case decreasing && actual <= goal, !decreasing && actual >= goal:
+		progress = 100
+	case target.Baseline != nil && *target.Baseline != goal:
+		progress = (actual - *target.Baseline) / (goal - *target.Baseline) * 100
+	case decreasing:
+		progress = goal / actual * 100
+	case goal != 0:
+		progress = actual / goal * 100
+	}
+	progress = clampProgress(progress)
+
+	return TargetProgress{
+		TargetID:   target.ID,
+		Metric:     target.Metric,
+		Target:     goal,
+		Actual:     actual,
+		MeasuredAt: latest.Date,
+		Progress:   progress,
+	}, true
+}
+
+// ComputeObjectiveProgress derives an objective's progress from measurement results as
+// the average progress of the targets that could be measured. It reports false when
+// no target could be measured.
+func ComputeObjectiveProgress(objective *QualityObjective, results []MeasurementResult) (ObjectiveProgress, bool) {
+	progress := ObjectiveProgress{ObjectiveID: objective.ID, Source: ProgressSourceMeasured}
+	var total float64
+	for _, target := range objective.Targets {
+		measured, ok := ComputeTargetProgress(target, results)
+		if !ok {
+			continue
+		}
+		progress.Targets = append(progress.Targets, measured)
+		total += measured.Progress
+		if measured.MeasuredAt.After(progress.Date) {
+			progress.Date = measured.MeasuredAt
+		}
+	}
+	if len(progress.Targets) == 0 {
+		return ObjectiveProgress{}, false
+	}
+	progress.Progress = total / float64(len(progress.Targets))
+	if skipped := len(objective.Targets) - len(progress.Targets); skipped > 0 {
+		progress.Comments = fmt.Sprintf("%d of %d targets not measured", skipped, len(objective.Targets))
+	}
+	return progress, true
+}
+
+// RecordMeasuredProgress computes an objective's progress from measurement results and
+// records it as a measured progress report, updating the objective's status as
+// UpdateObjectiveProgress does
+func (qom *QualityObjectivesManager) RecordMeasuredProgress(objectiveID string, results []MeasurementResult) (ObjectiveProgress, error) {
+	objective, exists := qom.Objectives[objectiveID]
+	if !exists {
+		return ObjectiveProgress{}, fmt.Errorf("objective with ID %s not found", objectiveID)
+	}
+	progress, ok := ComputeObjectiveProgress(objective, results)
+	if !ok {
+		return ObjectiveProgress{}, fmt.Errorf("%w: %s", ErrNoMeasurements, objectiveID)
+	}
+	if err := qom.UpdateObjectiveProgress(objectiveID, progress); err != nil {
+		return ObjectiveProgress{}, err
+	}
+	return progress, nil
+}
+
+// LatestProgress returns the most recent measured and manual progress reports of an
+// objective, either of which may be nil. Manual reports override measured progress
+// without replacing it, so both remain visible.
+func (qom *QualityObjectivesManager) LatestProgress(objectiveID string) (measured, manual *ObjectiveProgress) {
+	for i := range qom.Tracker.ProgressReports {
+		report := &qom.Tracker.ProgressReports[i]
+		if report.ObjectiveID != objectiveID {
+			continue
+		}
+		if report.Source == ProgressSourceMeasured {
+			measured = report
+		} else {
+			manual = report
+		}
+	}
+	return measured, manual
+}
+
+func clampProgress(progress float64) float64 {
+	if progress < 0 {
+		return 0
+	}
+	if progress > 100 {
+		return 100
+	}
+	return progress
+}
+
+// Objective trends
+const (
+	TrendImproving = "improving"
+	TrendStable    = "stable"
+	TrendDeclining = "declining"
+)
+
+// TrendPeriod is the length of the periods progress reports are grouped into
+type TrendPeriod string
+
+const (
+	TrendPeriodWeek    TrendPeriod = "week"
+	TrendPeriodMonth   TrendPeriod = "month"
+	TrendPeriodQuarter TrendPeriod = "quarter"
+)
+
+// TrendOptions configures ComputeTrends
+type TrendOptions struct {
+	Period    TrendPeriod // length of a period, a month when empty
+	Periods   int         // number of most recent periods with reports considered, all when 0
+	Tolerance float64     // change in progress points per period below which the trend is stable, 1 when 0
+}
+
+// periodKey names the period a date falls in, e.g. "2024-W05", "2024-05" or "2024-Q2"
+func (p TrendPeriod) periodKey(date time.Time) string {
+	switch p {
+	case TrendPeriodWeek:
+		year, week := date.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", year, week)
+	case TrendPeriodQuarter:
+		return fmt.Sprintf("%d-Q%d", date.Year(), (int(date.Month())-1)/3+1)
+	}
+	return date.Format("2006-01")
+}
+
+// ComputeTrends derives a trend for each objective from its progress report history.
+// Reports are grouped into periods and the last report of each period is taken as its
+// value; the trend follows the least-squares slope of these values. Objectives with
+// reports in fewer than two periods get no trend. The trends replace those held by the
+// tracker and are returned ordered by objective ID.
+func (qom *QualityObjectivesManager) ComputeTrends(options TrendOptions) []ObjectiveTrend {
+	if options.Period == "" {
+		options.Period = TrendPeriodMonth
+	}
+	if options.Tolerance == 0 {
+		options.Tolerance = 1
+	}
+
+	reports := append([]ObjectiveProgress(nil), qom.Tracker.ProgressReports...)
+	sort.SliceStable(reports, func(i, j int) bool { return reports[i].Date.Before(reports[j].Date) })
+
+	type series struct {
+		keys   []string
+		values []float64
+	}
+	byObjective := make(map[string]*series)
+	for _, report := range reports {
+		s := byObjective[report.ObjectiveID]
+		if s == nil {
+			s = &series{}
+			byObjective[report.ObjectiveID] = s
+		}
+		key := options.Period.periodKey(report.Date)
+		if n := len(s.keys); n > 0 && s.keys[n-1] == key {
+			s.values[n-1] = report.Progress
+			continue
+		}
+		s.keys = append(s.keys, key)
+		s.values = append(s.values, report.Progress)
+	}
+
+	var trends []ObjectiveTrend
+	for id, s := range byObjective {
+		if options.Periods > 0 && len(s.keys) > options.Periods {
+			s.keys = s.keys[len(s.keys)-options.Periods:]
+			s.values = s.values[len(s.values)-options.Periods:]
+		}
+		if len(s.values) < 2 {
+			continue
+		}
+		trends = append(trends, ObjectiveTrend{
+			ObjectiveID: id,
+			Period:      s.keys[0] + "/" + s.keys[len(s.keys)-1],
+			Trend:       trendDirection(s.values, options.Tolerance),
+			Data:        s.values,
+		})
+	}
+	sort.Slice(trends, func(i, j int) bool { return trends[i].ObjectiveID < trends[j].ObjectiveID })
+
+	qom.Tracker.Trends = trends
+	return trends
+}
+
+// trendSlope returns the least-squares slope of values taken at equal intervals
+func trendSlope(values []float64) float64 {
+	n := float64(len(values))
+	var sumX, sumY, sumXY, sumXX float64
+	for i, y := range values {
+		x := float64(i)
+		sumX += x
+		sumY += y
+		sumXY += x * y
+		sumXX += x * x
+	}
+	return (n*sumXY - sumX*sumY) / (n*sumXX - sumX*sumX)
+}
@@ -0,0 +1,136 @@
+package iso9001
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestParseTargetValue(t *testing.T) {
+	for value, want := range map[string]float64{"95%": 95, "<= 2.5 days": 2.5, "≤2": 2, "-3": -3} {
+		if got, ok := ParseTargetValue(value); !ok || got != want {
+			t.Errorf("ParseTargetValue(%q) = %v, %v; want %v", value, got, ok, want)
+		}
+	}
+	if _, ok := ParseTargetValue("20%_reduction"); !ok {
+		t.Error("Expected leading number to be parsed")
+	}
+	if _, ok := ParseTargetValue("best in class"); ok {
+		t.Error("Expected non-numeric target to be rejected")
+	}
+}
+
+func TestComputeTargetProgress(t *testing.T) {
+	day := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	results := []MeasurementResult{
+		{Metric: "delivery_rate", Value: 90, Date: day.AddDate(0, 1, 0)},
+		{Metric: "delivery_rate", Value: 80, Date: day},
+		{Metric: "complaints", Value: 8, Date: day},
+	}
+
+	baseline := 85.0
+	measured, ok := ComputeTargetProgress(ObjectiveTarget{Metric: "Delivery_Rate", Value: "95%", Baseline: &baseline}, results)
+	if !ok || measured.Actual != 90 || measured.Progress != 50 {
+		t.Errorf("Expected half way from baseline using the latest result, got %+v, %v", measured, ok)
+	}
+
+	measured, _ = ComputeTargetProgress(ObjectiveTarget{Metric: "complaints", Value: "<= 4"}, results)
+	if measured.Progress != 50 {
+		t.Errorf("Expected falling metric progress of 50, got %v", measured.Progress)
+	}
+
+	high := 12.0
+	measured, _ = ComputeTargetProgress(ObjectiveTarget{Metric: "complaints", Value: "4", Baseline: &high}, results)
+	if measured.Progress != 50 {
+		t.Errorf("Expected direction inferred from baseline, got %v", measured.Progress)
+	}
+
+	if _, ok := ComputeTargetProgress(ObjectiveTarget{Metric: "scrap", Value: "2%"}, results); ok {
+		t.Error("Expected no progress without matching results")
+	}
+}
+
+func TestRecordMeasuredProgressKeepsManualOverride(t *testing.T) {
+	qom := NewQualityObjectivesManager()
+	objective := &QualityObjective{
+		ID:          "OBJ-001",
+		Name:        "On-time delivery",
+		Measurable:  true,
+		Responsible: "Operations Manager",
+		Targets: []ObjectiveTarget{
+			{ID: "T-1", Metric: "delivery_rate", Value: "95%"},
+			{ID: "T-2", Metric: "customer_rating", Value: "excellent"},
+		},
+	}
+	if err := qom.CreateObjective(objective); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := qom.RecordMeasuredProgress("OBJ-001", nil); !errors.Is(err, ErrNoMeasurements) {
+		t.Errorf("Expected ErrNoMeasurements, got %v", err)
+	}
+
+	results := []MeasurementResult{{Metric: "delivery_rate", Value: 76, Date: time.Now()}}
+	progress, err := qom.RecordMeasuredProgress("OBJ-001", results)
+	if err != nil {
+		t.Fatalf("Failed to record measured progress: %v", err)
+	}
+	if math.Abs(progress.Progress-80) > 1e-9 || len(progress.Targets) != 1 || progress.Comments == "" {
+		t.Errorf("Unexpected measured progress %+v", progress)
+	}
+	if objective.Status != ObjectiveStatusInProgress {
+		t.Errorf("Expected objective in progress, got %s", objective.Status)
+	}
+
+	if err := qom.UpdateObjectiveProgress("OBJ-001", ObjectiveProgress{Progress: 85, Comments: "Includes March backlog"}); err != nil {
+		t.Fatal(err)
+	}
+	measured, manual := qom.LatestProgress("OBJ-001")
+	if measured == nil || measured.Source != ProgressSourceMeasured || math.Abs(measured.Progress-80) > 1e-9 {
+		t.Errorf("Expected measured progress to be kept, got %+v", measured)
+	}
+	if manual == nil || manual.Source != ProgressSourceManual || manual.Progress != 85 {
+		t.Errorf("Expected manual override, got %+v", manual)
+	}
+}
+
+func TestComputeTrends(t *testing.T) {
+	qom := NewQualityObjectivesManager()
+	start := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	report := func(id string, months int, progress float64) {
+		qom.Tracker.ProgressReports = append(qom.Tracker.ProgressReports,
+			ObjectiveProgress{ObjectiveID: id, Date: start.AddDate(0, months, 0), Progress: progress})
+	}
+	report("OBJ-001", 0, 10)
+	report("OBJ-001", 1, 20)
+	report("OBJ-001", 1, 30) // later report in the same month wins
+	report("OBJ-001", 2, 40)
+	report("OBJ-002", 0, 60)
+	report("OBJ-002", 1, 40)
+	report("OBJ-003", 0, 50)
+	report("OBJ-003", 4, 50.5)
+	report("OBJ-004", 0, 5)
+
+	trends := qom.ComputeTrends(TrendOptions{})
+	if len(trends) != 3 {
+		t.Fatalf("Expected trends for three objectives, got %+v", trends)
+	}
+	if trends[0].Trend != TrendImproving || trends[0].Period != "2024-01/2024-03" || len(trends[0].Data) != 3 || trends[0].Data[1] != 30 {
+		t.Errorf("Unexpected trend for OBJ-001: %+v", trends[0])
+	}
+	if trends[1].Trend != TrendDeclining || trends[2].Trend != TrendStable {
+		t.Errorf("Expected declining and stable trends, got %s and %s", trends[1].Trend, trends[2].Trend)
+	}
+
+	quarterly := qom.ComputeTrends(TrendOptions{Period: TrendPeriodQuarter})
+	if len(quarterly) != 1 || quarterly[0].ObjectiveID != "OBJ-003" || quarterly[0].Period != "2024-Q1/2024-Q2" {
+		t.Errorf("Expected only OBJ-003 to span two quarters, got %+v", quarterly)
+	}
+
+	qom.ComputeTrends(TrendOptions{Periods: 2})
+	summary := qom.CalculateObjectiveProgress()
+	if summary.Improving != 1 || summary.Declining != 1 || summary.Stable != 1 || len(summary.Trends) != 3 {
+		t.Errorf("Expected trends in progress summary, got %+v", summary)
+	}
+}
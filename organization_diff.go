@@ -0,0 +1,406 @@
+package iso9001
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// organizationEntityTypes names the entities held in lists of an organization by the
+// JSON key of the list. Other lists of objects with IDs are named by their key.
+var organizationEntityTypes = map[string]string{
+	"external_issues":    EntityTypeIssue,
+	"internal_issues":    EntityTypeIssue,
+	"interested_parties": EntityTypeInterestedParty,
+	"top_management":     "person",
+	"roles":              EntityTypeRole,
+	"processes":          EntityTypeProcess,
+	"risks":              EntityTypeRisk,
+	"opportunities":      EntityTypeOpportunity,
+	"objectives":         EntityTypeObjective,
+	"mitigation":         "action",
+	"actions":            "action",
+}
+
+// OrganizationDiff is the change set between two versions of an organization. Changes
+// of the organization itself, such as a new quality policy, are reported as a modified
+// organization with the changed fields, e.g. leadership.quality_policy.
+type OrganizationDiff struct {
+	OrganizationID string          `json:"organization_id" yaml:"organization_id"`
+	Changes        []PlannedChange `json:"changes" yaml:"changes"`
+}
+
+// Empty reports whether the two versions are the same
+func (d *OrganizationDiff) Empty() bool {
+	return len(d.Changes) == 0
+}
+
+// Added returns the entities of a type added in the newer version, e.g. processes
+func (d *OrganizationDiff) Added(entityType string) []string {
+	return d.ids(ChangeAdd, entityType)
+}
+
+// Modified returns the entities of a type changed in the newer version
+func (d *OrganizationDiff) Modified(entityType string) []string {
+	return d.ids(ChangeModify, entityType)
+}
+
+// Removed returns the entities of a type removed in the newer version
+func (d *OrganizationDiff) Removed(entityType string) []string {
+	return d.ids(ChangeRemove, entityType)
+}
+
+func (d *OrganizationDiff) ids(action ChangeAction, entityType string) []string {
+	var ids []string
+	for _, change := range d.Changes {
+		if change.Action == action && change.EntityType == entityType {
+			ids = append(ids, change.EntityID)
+		}
+	}
+	return ids
+}
+
+// String renders the changes with + for additions, ~ for modifications and - for
+// removals
+func (d *OrganizationDiff) String() string {
+	var b strings.Builder
+	symbols := map[ChangeAction]string{ChangeAdd: "+", ChangeModify: "~", ChangeRemove: "-"}
+	counts := make(map[ChangeAction]int)
+
+	for _, change := range d.Changes {
+		counts[change.Action]++
+		fmt.Fprintf(&b, "  %s %s %s", symbols[change.Action], change.EntityType, change.EntityID)
+		if len(change.Fields) > 0 {
+			fmt.Fprintf(&b, " (%s)", strings.Join(change.Fields, ", "))
+		}
+		b.WriteString("\n")
+	}
+	if d.Empty() {
+		b.WriteString("  No changes.\n")
+	}
+	fmt.Fprintf(&b, "\n%d added, %d changed, %d removed.\n", counts[ChangeAdd], counts[ChangeModify], counts[ChangeRemove])
+	return b.String()
+}
+
+// DiffOrganizations compares two versions of an organization. Entities in lists, such
+// as processes, risks, objectives and roles, are matched by ID, so reordering a list
+// is not a change. Risks and actions within a process are reported as entities of
+// their own. The modification time of the organization is ignored.
+func DiffOrganizations(a, b *Organization) (*OrganizationDiff, error) {
+	before, err := organizationTree(a)
+	if err != nil {
+		return nil, err
+	}
+	after, err := organizationTree(b)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &OrganizationDiff{OrganizationID: b.ID, Changes: []PlannedChange{}}
+	if fields := diffTree(before, after, "", 0, &diff.Changes); len(fields) > 0 {
+		diff.Changes = append(diff.Changes, PlannedChange{Action: ChangeModify, EntityType: EntityTypeOrganization, EntityID: b.ID, Fields: fields})
+	}
+	sort.SliceStable(diff.Changes, func(i, j int) bool {
+		if diff.Changes[i].EntityType != diff.Changes[j].EntityType {
+			return diff.Changes[i].EntityType < diff.Changes[j].EntityType
+		}
+		return diff.Changes[i].EntityID < diff.Changes[j].EntityID
+	})
+	return diff, nil
+}
+
+// diffTree compares two versions of an entity. Changes of the entities it holds are
+// appended to changes; the changed fields of the entity itself are returned, naming
+// nested objects down to the second level, e.g. leadership.quality_policy.
+func diffTree(before, after map[string]interface{}, prefix string, depth int, changes *[]PlannedChange) []string {
+	var fields []string
+	for _, key := range unionKeys(before, after) {
+		old, updated := before[key], after[key]
+		if oldList, newList, ok := entityLists(old, updated); ok {
+			diffEntities(entityTypeFor(key), oldList, newList, changes)
+			continue
+		}
+		if reflect.DeepEqual(old, updated) {
+			continue
+		}
+		oldObject, ok1 := old.(map[string]interface{})
+		newObject, ok2 := updated.(map[string]interface{})
+		if ok1 && ok2 && depth == 0 {
+			fields = append(fields, diffTree(oldObject, newObject, prefix+key+".", depth+1, changes)...)
+			continue
+		}
+		fields = append(fields, prefix+key)
+	}
+	return fields
+}
+
+// diffEntities compares two versions of a list of entities matched by ID
+func diffEntities(entityType string, before, after []interface{}, changes *[]PlannedChange) {
+	old := indexEntities(before)
+	for _, entity := range after {
+		updated := entity.(map[string]interface{})
+		id := updated["id"].(string)
+		previous, exists := old[id]
+		if !exists {
+			*changes = append(*changes, PlannedChange{Action: ChangeAdd, EntityType: entityType, EntityID: id})
+			continue
+		}
+		if fields := diffTree(previous, updated, "", 0, changes); len(fields) > 0 {
+			*changes = append(*changes, PlannedChange{Action: ChangeModify, EntityType: entityType, EntityID: id, Fields: fields})
+		}
+	}
+	current := indexEntities(after)
+	for _, entity := range before {
+		id := entity.(map[string]interface{})["id"].(string)
+		if _, exists := current[id]; !exists {
+			*changes = append(*changes, PlannedChange{Action: ChangeRemove, EntityType: entityType, EntityID: id})
+		}
+	}
+}
+
+// MergeConflict is a field of an entity changed to different values on both sides of
+// a three-way merge. Field is empty when one side removed an entity the other changed.
+type MergeConflict struct {
+	EntityType string      `json:"entity_type" yaml:"entity_type"`
+	EntityID   string      `json:"entity_id" yaml:"entity_id"`
+	Field      string      `json:"field,omitempty" yaml:"field,omitempty"`
+	Ours       interface{} `json:"ours" yaml:"ours"`
+	Theirs     interface{} `json:"theirs" yaml:"theirs"`
+}
+
+func (c MergeConflict) String() string {
+	if c.Field == "" {
+		return fmt.Sprintf("%s %s: removed on one side and changed on the other", c.EntityType, c.EntityID)
+	}
+	return fmt.Sprintf("%s %s: %s changed on both sides", c.EntityType, c.EntityID, c.Field)
+}
+
+// OrganizationMerge is the result of a three-way merge
+type OrganizationMerge struct {
+	Organization *Organization   `json:"organization" yaml:"organization"`
+	Conflicts    []MergeConflict `json:"conflicts" yaml:"conflicts"`
+}
+
+// Clean reports whether the merge had no conflicts
+func (m *OrganizationMerge) Clean() bool {
+	return len(m.Conflicts) == 0
+}
+
+// MergeOrganizationChanges reconciles two versions of an organization edited
+// independently from a common base, e.g. two copies of the same QMS YAML file. A
+// change made on one side only is taken over; entities added on either side are
+// kept and entities in lists are matched by ID. Where both sides changed the same
+// field differently, or one side removed an entity the other changed, our version
+// is kept and a conflict is reported. The merged organization carries the later
+// modification time of the two sides.
+func MergeOrganizationChanges(base, ours, theirs *Organization) (*OrganizationMerge, error) {
+	if ours.ID != theirs.ID {
+		return nil, fmt.Errorf("cannot merge organization %s into %s", theirs.ID, ours.ID)
+	}
+	baseTree := map[string]interface{}{}
+	if base != nil {
+		var err error
+		if baseTree, err = organizationTree(base); err != nil {
+			return nil, err
+		}
+	}
+	ourTree, err := organizationTree(ours)
+	if err != nil {
+		return nil, err
+	}
+	theirTree, err := organizationTree(theirs)
+	if err != nil {
+		return nil, err
+	}
+
+	merger := &treeMerger{conflicts: []MergeConflict{}}
+	merged := merger.mergeObjects(EntityTypeOrganization, ours.ID, "", baseTree, ourTree, theirTree)
+	data, err := json.Marshal(merged)
+	if err != nil {
+		return nil, err
+	}
+	org := &Organization{}
+	if err := json.Unmarshal(data, org); err != nil {
+		return nil, err
+	}
+	org.Modified = ours.Modified
+	if theirs.Modified.After(org.Modified) {
+		org.Modified = theirs.Modified
+	}
+	return &OrganizationMerge{Organization: org, Conflicts: merger.conflicts}, nil
+}
+
+// treeMerger merges JSON trees and collects the conflicts
+type treeMerger struct {
+	conflicts []MergeConflict
+}
+
+// merge merges one value of an entity changed on either side of the base
+func (m *treeMerger) merge(entityType, entityID, path string, base, ours, theirs interface{}) interface{} {
+	switch {
+	case reflect.DeepEqual(ours, theirs), reflect.DeepEqual(base, theirs):
+		return ours
+	case reflect.DeepEqual(base, ours):
+		return theirs
+	}
+
+	ourObject, ok1 := ours.(map[string]interface{})
+	theirObject, ok2 := theirs.(map[string]interface{})
+	baseObject, ok3 := base.(map[string]interface{})
+	if ok1 && ok2 && (ok3 || base == nil) {
+		return m.mergeObjects(entityType, entityID, path+".", baseObject, ourObject, theirObject)
+	}
+	if ourList, theirList, ok := entityLists(ours, theirs); ok {
+		if baseList, ok := entityList(base); ok {
+			key := path[strings.LastIndex(path, ".")+1:]
+			return m.mergeEntities(entityTypeFor(key), baseList, ourList, theirList)
+		}
+	}
+
+	m.conflicts = append(m.conflicts, MergeConflict{EntityType: entityType, EntityID: entityID, Field: path, Ours: ours, Theirs: theirs})
+	return ours
+}
+
+// mergeObjects merges the fields of an object; prefix is the path of the object
+// within its entity
+func (m *treeMerger) mergeObjects(entityType, entityID, prefix string, base, ours, theirs map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{})
+	for _, key := range unionKeys(ours, theirs) {
+		_, inOurs := ours[key]
+		_, inTheirs := theirs[key]
+		value := m.merge(entityType, entityID, prefix+key, base[key], ours[key], theirs[key])
+		if value != nil || (inOurs && inTheirs) {
+			merged[key] = value
+		}
+	}
+	return merged
+}
+
+// mergeEntities merges lists of entities matched by ID, in our order followed by the
+// entities only they added
+func (m *treeMerger) mergeEntities(entityType string, base, ours, theirs []interface{}) []interface{} {
+	baseIndex, theirIndex := indexEntities(base), indexEntities(theirs)
+	ourIndex := indexEntities(ours)
+	merged := []interface{}{}
+
+	for _, entity := range ours {
+		ourEntity := entity.(map[string]interface{})
+		id := ourEntity["id"].(string)
+		baseEntity, inBase := baseIndex[id]
+		theirEntity, inTheirs := theirIndex[id]
+		switch {
+		case inTheirs:
+			merged = append(merged, m.mergeObjects(entityType, id, "", baseEntity, ourEntity, theirEntity))
+		case !inBase:
+			merged = append(merged, ourEntity)
+		case !reflect.DeepEqual(baseEntity, ourEntity):
+			// they removed what we changed
+			m.conflicts = append(m.conflicts, MergeConflict{EntityType: entityType, EntityID: id, Ours: ourEntity})
+			merged = append(merged, ourEntity)
+		}
+	}
+	for _, entity := range theirs {
+		theirEntity := entity.(map[string]interface{})
+		id := theirEntity["id"].(string)
+		if _, inOurs := ourIndex[id]; inOurs {
+			continue
+		}
+		baseEntity, inBase := baseIndex[id]
+		switch {
+		case !inBase:
+			merged = append(merged, theirEntity)
+		case !reflect.DeepEqual(baseEntity, theirEntity):
+			// we removed what they changed
+			m.conflicts = append(m.conflicts, MergeConflict{EntityType: entityType, EntityID: id, Theirs: theirEntity})
+		}
+	}
+	return merged
+}
+
+// organizationTree converts an organization into its JSON tree, without the
+// modification time
+func organizationTree(org *Organization) (map[string]interface{}, error) {
+	data, err := json.Marshal(org)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	tree := map[string]interface{}{}
+	if err := decoder.Decode(&tree); err != nil {
+		return nil, err
+	}
+	delete(tree, "modified")
+	return tree, nil
+}
+
+// entityLists returns two values as lists of entities, which holds when every element
+// is an object with a unique ID. Missing and empty lists count as lists of entities.
+func entityLists(a, b interface{}) ([]interface{}, []interface{}, bool) {
+	listA, ok := entityList(a)
+	if !ok {
+		return nil, nil, false
+	}
+	listB, ok := entityList(b)
+	if !ok {
+		return nil, nil, false
+	}
+	return listA, listB, true
+}
+
+func entityList(v interface{}) ([]interface{}, bool) {
+	if v == nil {
+		return nil, true
+	}
+	list, ok := v.([]interface{})
+	if !ok {
+		return nil, false
+	}
+	seen := make(map[string]bool, len(list))
+	for _, element := range list {
+		object, ok := element.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		id, ok := object["id"].(string)
+		if !ok || id == "" || seen[id] {
+			return nil, false
+		}
+		seen[id] = true
+	}
+	return list, true
+}
+
+func indexEntities(list []interface{}) map[string]map[string]interface{} {
+	index := make(map[string]map[string]interface{}, len(list))
+	for _, element := range list {
+		object := element.(map[string]interface{})
+		index[object["id"].(string)] = object
+	}
+	return index
+}
+
+// entityTypeFor names the entities of a list by its JSON key
+func entityTypeFor(key string) string {
+	if entityType, ok := organizationEntityTypes[key]; ok {
+		return entityType
+	}
+	return key
+}
+
+func unionKeys(a, b map[string]interface{}) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for key := range a {
+		keys = append(keys, key)
+	}
+	for key := range b {
+		if _, ok := a[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
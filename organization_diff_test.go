@@ -0,0 +1,166 @@
+package iso9001
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func newDiffOrganization() *Organization {
+	return &Organization{
+		ID:   "ORG-001",
+		Name: "Acme",
+		Leadership: &Leadership{
+			QualityPolicy: &QualityPolicy{Statement: "Quality first"},
+			Roles:         []OrganizationalRole{{ID: "ROLE-QM", Name: "Quality Manager"}},
+		},
+		QMS: &QualityManagementSystem{
+			Processes: []Process{
+				{ID: "PROC-001", Name: "Sales", Risks: []Risk{{ID: "RISK-001", Description: "Late quotes"}}},
+				{ID: "PROC-002", Name: "Production"},
+			},
+			Risks: []Risk{{ID: "RISK-010", Description: "Supplier failure"}},
+		},
+		Modified: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+// copyOrganization returns an independent copy of an organization
+func copyOrganization(t *testing.T, org *Organization) *Organization {
+	t.Helper()
+	data, err := json.Marshal(org)
+	if err != nil {
+		t.Fatalf("Failed to copy organization: %v", err)
+	}
+	copied := &Organization{}
+	if err := json.Unmarshal(data, copied); err != nil {
+		t.Fatalf("Failed to copy organization: %v", err)
+	}
+	return copied
+}
+
+func TestDiffOrganizations(t *testing.T) {
+	before := newDiffOrganization()
+	after := copyOrganization(t, before)
+	after.Modified = time.Now()
+	after.Leadership.QualityPolicy.Statement = "Customers first"
+	after.QMS.Processes = []Process{
+		{ID: "PROC-002", Name: "Production"},
+		{ID: "PROC-001", Name: "Sales", Description: "Quotes and orders", Risks: []Risk{{ID: "RISK-001", Description: "Late quotes and offers"}}},
+		{ID: "PROC-003", Name: "Purchasing"},
+	}
+	after.QMS.Risks = nil
+
+	diff, err := DiffOrganizations(before, after)
+	if err != nil {
+		t.Fatalf("Failed to diff organizations: %v", err)
+	}
+	if got := diff.Added(EntityTypeProcess); !reflect.DeepEqual(got, []string{"PROC-003"}) {
+		t.Errorf("Expected PROC-003 to be added, got %v", got)
+	}
+	if got := diff.Removed(EntityTypeRisk); !reflect.DeepEqual(got, []string{"RISK-010"}) {
+		t.Errorf("Expected RISK-010 to be removed, got %v", got)
+	}
+	if got := diff.Modified(EntityTypeRisk); !reflect.DeepEqual(got, []string{"RISK-001"}) {
+		t.Errorf("Expected nested RISK-001 to be modified, got %v", got)
+	}
+
+	fields := map[string][]string{}
+	for _, change := range diff.Changes {
+		if change.Action == ChangeModify {
+			fields[change.EntityType+"/"+change.EntityID] = change.Fields
+		}
+	}
+	if got := fields["organization/ORG-001"]; !reflect.DeepEqual(got, []string{"leadership.quality_policy"}) {
+		t.Errorf("Expected the policy to be the only organization change, got %v", got)
+	}
+	if got := fields["process/PROC-001"]; !reflect.DeepEqual(got, []string{"description"}) {
+		t.Errorf("Expected only the description of PROC-001 to change, got %v", got)
+	}
+	if _, ok := fields["process/PROC-002"]; ok {
+		t.Error("Expected reordering not to count as a change")
+	}
+
+	same, err := DiffOrganizations(before, copyOrganization(t, before))
+	if err != nil || !same.Empty() {
+		t.Errorf("Expected no changes between copies, got %v, %v", same, err)
+	}
+}
+
+func TestMergeOrganizationChanges(t *testing.T) {
+	base := newDiffOrganization()
+	ours := copyOrganization(t, base)
+	theirs := copyOrganization(t, base)
+
+	ours.Leadership.QualityPolicy.Statement = "Customers first"
+	ours.QMS.Processes[0].Description = "Quotes and orders"
+	ours.QMS.Processes = append(ours.QMS.Processes, Process{ID: "PROC-003", Name: "Purchasing"})
+	theirs.Modified = base.Modified.Add(time.Hour)
+	theirs.Name = "Acme Ltd"
+	theirs.QMS.Processes[0].Name = "Sales and Marketing"
+	theirs.QMS.Processes = append(theirs.QMS.Processes, Process{ID: "PROC-004", Name: "Shipping"})
+	theirs.QMS.Risks = nil
+
+	merge, err := MergeOrganizationChanges(base, ours, theirs)
+	if err != nil {
+		t.Fatalf("Failed to merge: %v", err)
+	}
+	if !merge.Clean() {
+		t.Fatalf("Expected a clean merge, got %v", merge.Conflicts)
+	}
+	org := merge.Organization
+	if org.Name != "Acme Ltd" || org.Leadership.QualityPolicy.Statement != "Customers first" {
+		t.Errorf("Expected changes of both sides, got %q and %q", org.Name, org.Leadership.QualityPolicy.Statement)
+	}
+	sales := org.QMS.Processes[0]
+	if sales.Name != "Sales and Marketing" || sales.Description != "Quotes and orders" || len(sales.Risks) != 1 {
+		t.Errorf("Expected both changes to PROC-001, got %+v", sales)
+	}
+	var ids []string
+	for _, process := range org.QMS.Processes {
+		ids = append(ids, process.ID)
+	}
+	if !reflect.DeepEqual(ids, []string{"PROC-001", "PROC-002", "PROC-003", "PROC-004"}) {
+		t.Errorf("Expected processes added on both sides, got %v", ids)
+	}
+	if len(org.QMS.Risks) != 0 {
+		t.Errorf("Expected RISK-010 to be removed, got %v", org.QMS.Risks)
+	}
+	if !org.Modified.Equal(theirs.Modified) {
+		t.Errorf("Expected the later modification time, got %v", org.Modified)
+	}
+}
+
+func TestMergeOrganizationChangesConflicts(t *testing.T) {
+	base := newDiffOrganization()
+	ours := copyOrganization(t, base)
+	theirs := copyOrganization(t, base)
+
+	ours.Leadership.QualityPolicy.Statement = "Customers first"
+	theirs.Leadership.QualityPolicy.Statement = "Zero defects"
+	ours.QMS.Risks[0].Description = "Supplier insolvency"
+	theirs.QMS.Risks = nil
+
+	merge, err := MergeOrganizationChanges(base, ours, theirs)
+	if err != nil {
+		t.Fatalf("Failed to merge: %v", err)
+	}
+	if len(merge.Conflicts) != 2 {
+		t.Fatalf("Expected 2 conflicts, got %v", merge.Conflicts)
+	}
+	policy := merge.Conflicts[0]
+	if policy.EntityType != EntityTypeOrganization || policy.Field != "leadership.quality_policy.statement" || policy.Theirs != "Zero defects" {
+		t.Errorf("Expected a policy conflict, got %+v", policy)
+	}
+	if removed := merge.Conflicts[1]; removed.EntityType != EntityTypeRisk || removed.EntityID != "RISK-010" || removed.Field != "" {
+		t.Errorf("Expected a remove/change conflict for RISK-010, got %+v", removed)
+	}
+	if merge.Organization.Leadership.QualityPolicy.Statement != "Customers first" || len(merge.Organization.QMS.Risks) != 1 {
+		t.Error("Expected our version to be kept on conflicts")
+	}
+
+	if _, err := MergeOrganizationChanges(base, ours, &Organization{ID: "ORG-002"}); err == nil {
+		t.Error("Expected merging different organizations to fail")
+	}
+}
@@ -0,0 +1,412 @@
+package iso9001
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// A4 page size and margins of the PDF documents, in points
+const (
+	pdfPageWidth  = 595.0
+	pdfPageHeight = 842.0
+	pdfMargin     = 56.0
+)
+
+// pdfFont selects one of the two standard fonts embedded in every PDF reader
+type pdfFont int
+
+const (
+	pdfRegular pdfFont = iota // Helvetica
+	pdfBold                   // Helvetica-Bold
+)
+
+// WriteComplianceReportPDF writes a compliance report as a PDF document for
+// management. A cover page shows the overall rating with a score gauge; the following
+// pages list the critical gaps by clause, improvement areas, strengths and
+// recommendations. The organization, which may be nil, provides the name on the cover.
+func WriteComplianceReportPDF(w io.Writer, org *Organization, report *ComplianceReport) error {
+	name := report.OrganizationID
+	if org != nil && org.Name != "" {
+		name = org.Name
+	}
+	d := &pdfDocument{}
+
+	// Cover page
+	d.newPage()
+	d.centered(740, pdfBold, 12, "ISO 9001:2015")
+	d.centered(700, pdfBold, 28, "Compliance Report")
+	d.centered(665, pdfRegular, 18, name)
+	d.centered(640, pdfRegular, 11, "Assessed "+formatReportDate(report.AssessmentDate))
+	d.gauge(pdfPageWidth/2, 420, 140, report.ComplianceScore)
+	d.centered(330, pdfBold, 18, report.OverallCompliance)
+	summary := []string{
+		fmt.Sprintf("Critical gaps: %d", len(report.CriticalGaps)),
+		fmt.Sprintf("Improvement areas: %d", len(report.ImprovementAreas)),
+		fmt.Sprintf("Recommendations: %d", len(report.Recommendations)),
+	}
+	for i, line := range summary {
+		d.centered(290-float64(i)*18, pdfRegular, 11, line)
+	}
+
+	d.newPage()
+	d.heading("Gaps by clause")
+	if len(report.CriticalGaps) == 0 {
+		d.paragraph("No critical gaps were found.", pdfRegular, 11, 0, "")
+	}
+	for _, clause := range gapClauses(report.CriticalGaps) {
+		title := "General"
+		if clause != "" {
+			title = "Clause " + clause
+			if known, ok := LookupClause(clause); ok {
+				title += " " + known.Title
+			}
+		}
+		d.subheading(title)
+		for _, gap := range report.CriticalGaps {
+			if gap.Clause == clause {
+				d.paragraph(fmt.Sprintf("[%s] %s", gap.Severity, gap.Description), pdfRegular, 11, 0, "•")
+			}
+		}
+	}
+
+	if len(report.ImprovementAreas) > 0 {
+		d.heading("Improvement areas")
+		for _, area := range report.ImprovementAreas {
+			text := area.Description
+			if area.Area != "" {
+				text = area.Area + ": " + text
+			}
+			d.paragraph(text, pdfRegular, 11, 0, "•")
+		}
+	}
+	if len(report.Strengths) > 0 {
+		d.heading("Strengths")
+		for _, strength := range report.Strengths {
+			d.paragraph(strength, pdfRegular, 11, 0, "•")
+		}
+	}
+	d.heading("Recommendations")
+	if len(report.Recommendations) == 0 {
+		d.paragraph("No recommendations.", pdfRegular, 11, 0, "")
+	}
+	for i, recommendation := range report.Recommendations {
+		d.paragraph(recommendation, pdfRegular, 11, 0, fmt.Sprintf("%d.", i+1))
+	}
+
+	d.footer(name)
+	return d.write(w, name+" compliance report", report.AssessmentDate.UTC().Format("20060102150405"))
+}
+
+// gapClauses returns the distinct clauses of the gaps in the order of the standard;
+// gaps without a clause come last
+func gapClauses(gaps []ComplianceGap) []string {
+	seen := make(map[string]bool)
+	var clauses []string
+	for _, gap := range gaps {
+		if !seen[gap.Clause] {
+			seen[gap.Clause] = true
+			clauses = append(clauses, gap.Clause)
+		}
+	}
+	sort.SliceStable(clauses, func(i, j int) bool {
+		if clauses[i] == "" || clauses[j] == "" {
+			return clauses[j] == "" && clauses[i] != ""
+		}
+		return clauseLess(clauses[i], clauses[j])
+	})
+	return clauses
+}
+
+// clauseLess orders clause numbers numerically, so that 9.1 comes before 10.1
+func clauseLess(a, b string) bool {
+	as := strings.Split(normalizeClauseReference(a), ".")
+	bs := strings.Split(normalizeClauseReference(b), ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		x, errX := strconv.Atoi(as[i])
+		y, errY := strconv.Atoi(bs[i])
+		if errX != nil || errY != nil {
+			if as[i] != bs[i] {
+				return as[i] < bs[i]
+			}
+			continue
+		}
+		if x != y {
+			return x < y
+		}
+	}
+	return len(as) < len(bs)
+}
+
+// pdfDocument lays out text on A4 pages, starting a new page when the current one
+// is full
+type pdfDocument struct {
+	pages []*bytes.Buffer // content stream of each page
+	y     float64         // top of the next line on the current page
+}
+
+func (d *pdfDocument) newPage() {
+	d.pages = append(d.pages, &bytes.Buffer{})
+	d.y = pdfPageHeight - pdfMargin
+}
+
+func (d *pdfDocument) page() *bytes.Buffer {
+	return d.pages[len(d.pages)-1]
+}
+
+// ensure starts a new page unless the given height fits above the footer
+func (d *pdfDocument) ensure(height float64) {
+	if d.y-height < pdfMargin+10 {
+		d.newPage()
+	}
+}
+
+// text writes a line with its baseline at y
+func (d *pdfDocument) text(x, y float64, font pdfFont, size float64, s string) {
+	fmt.Fprintf(d.page(), "BT /F%d %s Tf %s %s Td (%s) Tj ET\n", font+1, pdfNumber(size), pdfNumber(x), pdfNumber(y), pdfString(s))
+}
+
+func (d *pdfDocument) centered(y float64, font pdfFont, size float64, s string) {
+	d.text((pdfPageWidth-textWidth(s, font, size))/2, y, font, size, s)
+}
+
+func (d *pdfDocument) heading(s string) {
+	d.ensure(60) // keep the heading with the first lines below it
+	d.y -= 12
+	d.text(pdfMargin, d.y-16, pdfBold, 16, s)
+	d.y -= 28
+}
+
+func (d *pdfDocument) subheading(s string) {
+	d.ensure(40)
+	d.y -= 6
+	d.text(pdfMargin, d.y-12, pdfBold, 12, s)
+	d.y -= 20
+}
+
+// paragraph writes wrapped text indented from the left margin, with a marker such as
+// a bullet or number hanging before its first line
+func (d *pdfDocument) paragraph(s string, font pdfFont, size, indent float64, marker string) {
+	if marker != "" {
+		indent += 16
+	}
+	leading := size * 1.35
+	for i, line := range wrapText(s, font, size, pdfPageWidth-2*pdfMargin-indent) {
+		d.ensure(leading)
+		baseline := d.y - size
+		if i == 0 && marker != "" {
+			d.text(pdfMargin+indent-16, baseline, font, size, marker)
+		}
+		d.text(pdfMargin+indent, baseline, font, size, line)
+		d.y -= leading
+	}
+	d.y -= size * 0.4
+}
+
+// gauge draws a semicircular gauge of a score from 0 to 100 centered at (cx, cy),
+// banded red below 60, amber below 80 and green above
+func (d *pdfDocument) gauge(cx, cy, radius, score float64) {
+	page := d.page()
+	point := func(value, r float64) string {
+		angle := math.Pi * (1 - value/100)
+		return pdfNumber(cx+r*math.Cos(angle)) + " " + pdfNumber(cy+r*math.Sin(angle))
+	}
+	inner := radius * 0.7
+	bands := []struct {
+		from, to float64
+		color    string
+	}{
+		{0, 60, "0.84 0.19 0.15"},
+		{60, 80, "0.96 0.65 0.14"},
+		{80, 100, "0.18 0.62 0.29"},
+	}
+
+	page.WriteString("q\n")
+	for _, band := range bands {
+		fmt.Fprintf(page, "%s rg\n%s m\n", band.color, point(band.from, radius))
+		const steps = 24
+		for i := 1; i <= steps; i++ {
+			fmt.Fprintf(page, "%s l\n", point(band.from+(band.to-band.from)*float64(i)/steps, radius))
+		}
+		for i := steps; i >= 0; i-- {
+			fmt.Fprintf(page, "%s l\n", point(band.from+(band.to-band.from)*float64(i)/steps, inner))
+		}
+		page.WriteString("h f\n")
+	}
+
+	needle := math.Max(0, math.Min(100, score))
+	fmt.Fprintf(page, "0.2 G 0.2 g 3 w 1 J %s %s m %s l S\n", pdfNumber(cx), pdfNumber(cy), point(needle, radius*0.9))
+	fmt.Fprintf(page, "%s %s m\n", pdfNumber(cx+8), pdfNumber(cy))
+	for i := 1; i < 16; i++ {
+		angle := 2 * math.Pi * float64(i) / 16
+		fmt.Fprintf(page, "%s %s l\n", pdfNumber(cx+8*math.Cos(angle)), pdfNumber(cy+8*math.Sin(angle)))
+	}
+	page.WriteString("h f\nQ\n")
+
+	d.text(cx-radius, cy-16, pdfRegular, 10, "0")
+	d.text(cx+radius-textWidth("100", pdfRegular, 10), cy-16, pdfRegular, 10, "100")
+	d.centered(cy-50, pdfBold, 32, fmt.Sprintf("%.1f%%", score))
+}
+
+// footer numbers the pages below the title
+func (d *pdfDocument) footer(title string) {
+	for i, page := range d.pages {
+		label := fmt.Sprintf("Page %d of %d", i+1, len(d.pages))
+		page.WriteString("q 0.4 g\n")
+		fmt.Fprintf(page, "BT /F1 9 Tf %s 30 Td (%s) Tj ET\n", pdfNumber(pdfMargin), pdfString(title))
+		fmt.Fprintf(page, "BT /F1 9 Tf %s 30 Td (%s) Tj ET\n", pdfNumber(pdfPageWidth-pdfMargin-textWidth(label, pdfRegular, 9)), pdfString(label))
+		page.WriteString("Q\n")
+	}
+}
+
+// write writes the pages as a PDF 1.4 file. Objects 1 to 5 are the catalog, the page
+// tree, the two fonts and the document information; each page follows with its
+// content stream.
+func (d *pdfDocument) write(w io.Writer, title, created string) error {
+	var out bytes.Buffer
+	var offsets []int
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 6+2*i)
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	object(fmt.Sprintf("<< /Title (%s) /Producer (iso9001) /CreationDate (D:%sZ) >>", pdfString(title), created))
+	for i, page := range d.pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			pdfNumber(pdfPageWidth), pdfNumber(pdfPageHeight), 7+2*i))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", page.Len(), page.String()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R /Info 5 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	_, err := out.WriteTo(w)
+	return err
+}
+
+// pdfNumber formats a coordinate with at most two decimals
+func pdfNumber(v float64) string {
+	return strconv.FormatFloat(math.Round(v*100)/100, 'f', -1, 64)
+}
+
+// winAnsiExtras maps the characters WinAnsiEncoding places in 0x80 to 0x9F
+var winAnsiExtras = map[rune]byte{
+	'€': 0x80, '‚': 0x82, '„': 0x84, '…': 0x85, 'Œ': 0x8C, 'Š': 0x8A, 'Ž': 0x8E,
+	'‘': 0x91, '’': 0x92, '“': 0x93, '”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97,
+	'™': 0x99, 'š': 0x9A, 'œ': 0x9C, 'ž': 0x9E, 'Ÿ': 0x9F,
+}
+
+// pdfString encodes text as the contents of a PDF string in WinAnsiEncoding.
+// Characters the encoding lacks are replaced by "?".
+func pdfString(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		var c byte
+		switch extra, ok := winAnsiExtras[r]; {
+		case ok:
+			c = extra
+		case r < 0x80 || (r >= 0xA0 && r <= 0xFF):
+			c = byte(r)
+		default:
+			c = '?'
+		}
+		switch {
+		case c == '(' || c == ')' || c == '\\':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case c < 0x20 || c > 0x7E:
+			fmt.Fprintf(&b, "\\%03o", c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// helveticaWidths and helveticaBoldWidths are the widths of the printable ASCII
+// characters, from space to tilde, in thousandths of the font size
+var (
+	helveticaWidths = [95]int{
+		278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+		1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+		333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+		556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+	}
+	helveticaBoldWidths = [95]int{
+		278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+		975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+		333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+		611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+	}
+)
+
+// textWidth returns the width of text in points; characters outside ASCII are
+// counted as wide as a digit
+func textWidth(s string, font pdfFont, size float64) float64 {
+	widths := &helveticaWidths
+	if font == pdfBold {
+		widths = &helveticaBoldWidths
+	}
+	total := 0
+	for _, r := range s {
+		if r >= ' ' && r <= '~' {
+			total += widths[r-' ']
+		} else {
+			total += 556
+		}
+	}
+	return float64(total) * size / 1000
+}
+
+// wrapText breaks text into lines no wider than width, breaking words that do not
+// fit on a line of their own
+func wrapText(s string, font pdfFont, size, width float64) []string {
+	var lines []string
+	line := ""
+	for _, word := range strings.Fields(s) {
+		candidate := word
+		if line != "" {
+			candidate = line + " " + word
+		}
+		if textWidth(candidate, font, size) <= width {
+			line = candidate
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+		line = ""
+		for _, r := range word {
+			if line != "" && textWidth(line+string(r), font, size) > width {
+				lines = append(lines, line)
+				line = ""
+			}
+			line += string(r)
+		}
+	}
+	if line != "" || len(lines) == 0 {
+		lines = append(lines, line)
+	}
+	return lines
+}
@@ -0,0 +1,84 @@
+package iso9001
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteComplianceReportPDF(t *testing.T) {
+	report := &ComplianceReport{
+		OrganizationID:    "ORG-001",
+		AssessmentDate:    time.Date(2026, 5, 4, 9, 30, 0, 0, time.UTC),
+		OverallCompliance: "Needs Improvement",
+		ComplianceScore:   64.5,
+		Recommendations:   []string{"Address critical compliance gaps immediately"},
+		Strengths:         []string{"Processes are defined and documented"},
+	}
+	for _, clause := range []string{"10.2", "", "9.2", "7.1.5", "9.2"} {
+		report.CriticalGaps = append(report.CriticalGaps, ComplianceGap{Clause: clause, Description: "Gap (" + clause + ") in " + strings.Repeat("controls ", 30), Severity: "Critical"})
+	}
+	org := &Organization{ID: "ORG-001", Name: "Müller Präzision"}
+
+	var out bytes.Buffer
+	if err := WriteComplianceReportPDF(&out, org, report); err != nil {
+		t.Fatalf("Failed to write PDF: %v", err)
+	}
+	pdf := out.String()
+	if !strings.HasPrefix(pdf, "%PDF-1.4\n") || !strings.HasSuffix(pdf, "%%EOF\n") {
+		t.Fatal("Expected a PDF header and trailer")
+	}
+
+	// Every cross-reference entry points at its object
+	xref := regexp.MustCompile(`startxref\n(\d+)\n`).FindStringSubmatch(pdf)
+	offset, _ := strconv.Atoi(xref[1])
+	entries := strings.Split(strings.TrimSpace(pdf[offset:strings.Index(pdf, "trailer")]), "\n")[3:]
+	for i, entry := range entries {
+		at, _ := strconv.Atoi(entry[:10])
+		if !strings.HasPrefix(pdf[at:], fmt.Sprintf("%d 0 obj", i+1)) {
+			t.Errorf("Cross-reference entry %d does not point at its object", i+1)
+		}
+	}
+
+	for _, text := range []string{
+		"(M\\374ller Pr\\344zision)", // WinAnsi encoded
+		"(64.5%)",
+		"(Needs Improvement)",
+		"(Clause 7.1.5 Monitoring and measuring resources)",
+		"([Critical] Gap \\(9.2\\) in controls",
+		"(Recommendations)",
+		"/Count 2",
+	} {
+		if !strings.Contains(pdf, text) {
+			t.Errorf("Expected PDF to contain %s", text)
+		}
+	}
+	order := []string{"(Clause 7.1.5", "(Clause 9.2", "(Clause 10.2", "(General)"}
+	for i := 1; i < len(order); i++ {
+		if strings.Index(pdf, order[i-1]) > strings.Index(pdf, order[i]) {
+			t.Errorf("Expected %s before %s", order[i-1], order[i])
+		}
+	}
+	if strings.Count(pdf, "(Clause 9.2") != 1 {
+		t.Error("Expected the gaps of a clause to be listed under one heading")
+	}
+}
+
+func TestWrapText(t *testing.T) {
+	lines := wrapText("The quick brown fox jumps over the lazy dog", pdfRegular, 10, 100)
+	for _, line := range lines {
+		if textWidth(line, pdfRegular, 10) > 100 {
+			t.Errorf("Line %q is wider than 100 points", line)
+		}
+	}
+	if strings.Join(lines, " ") != "The quick brown fox jumps over the lazy dog" {
+		t.Errorf("Expected words to be kept, got %q", lines)
+	}
+	if long := wrapText(strings.Repeat("x", 100), pdfBold, 10, 100); len(long) < 2 {
+		t.Errorf("Expected an overlong word to be broken, got %q", long)
+	}
+}
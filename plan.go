@@ -0,0 +1,354 @@
+package iso9001
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ErrStalePlan is returned by Apply when the tenant changed after the plan was made
+var ErrStalePlan = errors.New("tenant changed since the plan was made")
+
+// Operation is one step of a batch of changes to a tenant
+type Operation func(tenant *Tenant) error
+
+// ChangeAction describes how a planned change affects an entity
+type ChangeAction string
+
+const (
+	ChangeAdd    ChangeAction = "add"
+	ChangeModify ChangeAction = "modify"
+	ChangeRemove ChangeAction = "remove"
+)
+
+// PlannedChange is an entity that a plan adds, modifies or removes
+type PlannedChange struct {
+	Action     ChangeAction `json:"action" yaml:"action"`
+	EntityType string       `json:"entity_type" yaml:"entity_type"`
+	EntityID   string       `json:"entity_id" yaml:"entity_id"`
+	Fields     []string     `json:"fields,omitempty" yaml:"fields,omitempty"` // changed fields of modified entities
+}
+
+// Plan is the projected outcome of a batch of operations, computed on a copy of the
+// tenant without changing it
+type Plan struct {
+	TenantID    string          `json:"tenant_id" yaml:"tenant_id"`
+	Changes     []PlannedChange `json:"changes" yaml:"changes"`
+	ScoreBefore float64         `json:"score_before" yaml:"score_before"`
+	ScoreAfter  float64         `json:"score_after" yaml:"score_after"`
+
+	operations  []Operation
+	fingerprint [sha256.Size]byte
+}
+
+// ScoreDelta returns the projected change of the compliance score
+func (p *Plan) ScoreDelta() float64 {
+	return p.ScoreAfter - p.ScoreBefore
+}
+
+// Empty reports whether the plan changes nothing
+func (p *Plan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+// String renders the plan with + for additions, ~ for modifications and - for
+// removals, followed by the projected compliance score
+func (p *Plan) String() string {
+	var b strings.Builder
+	symbols := map[ChangeAction]string{ChangeAdd: "+", ChangeModify: "~", ChangeRemove: "-"}
+	counts := make(map[ChangeAction]int)
+
+	for _, change := range p.Changes {
+		counts[change.Action]++
+		fmt.Fprintf(&b, "  %s %s %s", symbols[change.Action], change.EntityType, change.EntityID)
+		if len(change.Fields) > 0 {
+			fmt.Fprintf(&b, " (%s)", strings.Join(change.Fields, ", "))
+		}
+		b.WriteString("\n")
+	}
+	if p.Empty() {
+		b.WriteString("  No changes.\n")
+	}
+
+	fmt.Fprintf(&b, "\nPlan: %d to add, %d to change, %d to remove.\n", counts[ChangeAdd], counts[ChangeModify], counts[ChangeRemove])
+	fmt.Fprintf(&b, "Compliance score: %.1f -> %.1f (%+.1f)\n", p.ScoreBefore, p.ScoreAfter, p.ScoreDelta())
+	return b.String()
+}
+
+// Plan runs the operations on a copy of the tenant and reports the changes they would
+// make. The tenant itself is not modified; pass the plan to Apply to commit it.
+func (t *Tenant) Plan(ops ...Operation) (*Plan, error) {
+	fingerprint, err := t.fingerprint()
+	if err != nil {
+		return nil, err
+	}
+	projected, err := t.project(ops)
+	if err != nil {
+		return nil, err
+	}
+
+	before, err := t.entitySnapshots()
+	if err != nil {
+		return nil, err
+	}
+	after, err := projected.entitySnapshots()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Plan{
+		TenantID:    t.ID,
+		Changes:     diffSnapshots(before, after),
+		ScoreBefore: tenantScore(t),
+		ScoreAfter:  tenantScore(projected),
+		operations:  ops,
+		fingerprint: fingerprint,
+	}, nil
+}
+
+// Apply commits a plan made with Plan. It fails with ErrStalePlan when the tenant
+// changed in the meantime, and leaves the tenant untouched if any operation fails.
+func (t *Tenant) Apply(plan *Plan) error {
+	if plan.TenantID != t.ID {
+		return fmt.Errorf("plan for tenant %s cannot be applied to tenant %s", plan.TenantID, t.ID)
+	}
+	fingerprint, err := t.fingerprint()
+	if err != nil {
+		return err
+	}
+	if fingerprint != plan.fingerprint {
+		return ErrStalePlan
+	}
+
+	projected, err := t.project(plan.operations)
+	if err != nil {
+		return err
+	}
+	// Copy into the existing values so callers holding a manager keep seeing the tenant
+	if t.Organization != nil && projected.Organization != nil {
+		*t.Organization = *projected.Organization
+	} else {
+		t.Organization = projected.Organization
+	}
+	*t.Documents = *projected.Documents
+	*t.Risks = *projected.Risks
+	*t.Objectives = *projected.Objectives
+	*t.Audits = *projected.Audits
+	if t.Measurements != nil && projected.Measurements != nil {
+		*t.Measurements = *projected.Measurements
+	} else {
+		t.Measurements = projected.Measurements
+	}
+	return nil
+}
+
+// Plan computes a plan for a stored tenant while holding its lock
+func (ts *TenantStore) Plan(id string, ops ...Operation) (*Plan, error) {
+	tenant, err := ts.lockTenant(context.Background(), id)
+	if err != nil {
+		return nil, err
+	}
+	defer tenant.mu.Unlock()
+	return tenant.Plan(ops...)
+}
+
+// Apply commits a plan to the stored tenant it was made for
+func (ts *TenantStore) Apply(plan *Plan) error {
+	return ts.UpdateTenant(plan.TenantID, func(tenant *Tenant) error {
+		return tenant.Apply(plan)
+	})
+}
+
+// project applies operations to a deep copy of the tenant
+func (t *Tenant) project(ops []Operation) (*Tenant, error) {
+	projected, err := t.copy()
+	if err != nil {
+		return nil, err
+	}
+	for i, op := range ops {
+		if err := op(projected); err != nil {
+			return nil, fmt.Errorf("operation %d: %w", i+1, err)
+		}
+	}
+	return projected, nil
+}
+
+// copy deep-copies the tenant's data through JSON and carries over the manager
+// settings that are not serialized
+func (t *Tenant) copy() (*Tenant, error) {
+	data, err := json.Marshal(t)
+	if err != nil {
+		return nil, err
+	}
+	clone := NewTenant(t.ID)
+	if err := json.Unmarshal(data, clone); err != nil {
+		return nil, err
+	}
+
+	clone.inheritHooks(t)
+	clone.shareIDRegistry()
+	return clone, nil
+}
+
+func (t *Tenant) fingerprint() ([sha256.Size]byte, error) {
+	data, err := json.Marshal(t)
+	if err != nil {
+		return [sha256.Size]byte{}, err
+	}
+	return sha256.Sum256(data), nil
+}
+
+func tenantScore(t *Tenant) float64 {
+	if t.Organization == nil {
+		return GetComplianceScore(&Organization{})
+	}
+	return GetComplianceScore(t.Organization)
+}
+
+// entitySnapshot holds the JSON fields of one entity
+type entitySnapshot struct {
+	entityType string
+	id         string
+	fields     map[string]json.RawMessage
+}
+
+// entitySnapshots flattens the tenant into its individually identifiable entities
+func (t *Tenant) entitySnapshots() (map[string]entitySnapshot, error) {
+	snapshots := make(map[string]entitySnapshot)
+	var failed error
+	add := func(entityType, id string, v interface{}) {
+		data, err := json.Marshal(v)
+		if err != nil {
+			failed = err
+			return
+		}
+		fields := make(map[string]json.RawMessage)
+		if err := json.Unmarshal(data, &fields); err != nil {
+			failed = err
+			return
+		}
+		snapshots[entityType+"/"+id] = entitySnapshot{entityType: entityType, id: id, fields: fields}
+	}
+
+	if org := t.Organization; org != nil {
+		add(EntityTypeOrganization, org.ID, struct {
+			Name     string      `json:"name"`
+			TimeZone string      `json:"time_zone"`
+			Scope    interface{} `json:"scope"`
+			Policy   interface{} `json:"quality_policy"`
+		}{org.Name, org.TimeZone, qmsScope(org), qualityPolicy(org)})
+
+		if org.Context != nil {
+			for _, issue := range append(append([]Issue{}, org.Context.ExternalIssues...), org.Context.InternalIssues...) {
+				add(EntityTypeIssue, issue.ID, issue)
+			}
+			for _, party := range org.Context.InterestedParties {
+				add(EntityTypeInterestedParty, party.ID, party)
+			}
+		}
+		if org.Leadership != nil {
+			for _, role := range org.Leadership.Roles {
+				add(EntityTypeRole, role.ID, role)
+			}
+		}
+		if org.QMS != nil {
+			for _, process := range org.QMS.Processes {
+				add(EntityTypeProcess, process.ID, process)
+			}
+			for _, objective := range org.QMS.Objectives {
+				add(EntityTypeObjective, objective.ID, objective)
+			}
+			for _, risk := range org.QMS.Risks {
+				add(EntityTypeRisk, risk.ID, risk)
+			}
+			for _, opportunity := range org.QMS.Opportunities {
+				add(EntityTypeOpportunity, opportunity.ID, opportunity)
+			}
+		}
+	}
+
+	for id, doc := range t.Documents.Documents {
+		add(EntityTypeDocument, id, doc)
+	}
+	for id, risk := range t.Risks.Risks {
+		add(EntityTypeRisk, id, risk)
+	}
+	for id, opportunity := range t.Risks.Opportunities {
+		add(EntityTypeOpportunity, id, opportunity)
+	}
+	for id, objective := range t.Objectives.Objectives {
+		add(EntityTypeObjective, id, objective)
+	}
+	for id, audit := range t.Audits.Audits {
+		add(EntityTypeAudit, id, audit)
+	}
+	for id, review := range t.Audits.ManagementReviews {
+		add(EntityTypeManagementReview, id, review)
+	}
+
+	return snapshots, failed
+}
+
+func qmsScope(org *Organization) *QMSScope {
+	if org.QMS == nil {
+		return nil
+	}
+	return org.QMS.Scope
+}
+
+func qualityPolicy(org *Organization) *QualityPolicy {
+	if org.Leadership == nil {
+		return nil
+	}
+	return org.Leadership.QualityPolicy
+}
+
+// diffSnapshots compares two flattened tenants, ordering changes by entity type and ID
+func diffSnapshots(before, after map[string]entitySnapshot) []PlannedChange {
+	changes := []PlannedChange{}
+
+	for key, old := range before {
+		updated, exists := after[key]
+		if !exists {
+			changes = append(changes, PlannedChange{Action: ChangeRemove, EntityType: old.entityType, EntityID: old.id})
+			continue
+		}
+		if fields := changedFields(old.fields, updated.fields); len(fields) > 0 {
+			changes = append(changes, PlannedChange{Action: ChangeModify, EntityType: old.entityType, EntityID: old.id, Fields: fields})
+		}
+	}
+	for key, added := range after {
+		if _, exists := before[key]; !exists {
+			changes = append(changes, PlannedChange{Action: ChangeAdd, EntityType: added.entityType, EntityID: added.id})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].EntityType != changes[j].EntityType {
+			return changes[i].EntityType < changes[j].EntityType
+		}
+		return changes[i].EntityID < changes[j].EntityID
+	})
+	return changes
+}
+
+func changedFields(before, after map[string]json.RawMessage) []string {
+	var fields []string
+	for name, value := range after {
+		if old, ok := before[name]; !ok || !bytes.Equal(old, value) {
+			fields = append(fields, name)
+		}
+	}
+	for name := range before {
+		if _, ok := after[name]; !ok {
+			fields = append(fields, name)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
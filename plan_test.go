@@ -0,0 +1,77 @@
+package iso9001
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestPlanAndApply(t *testing.T) {
+	tenant := NewTenant("ORG-001")
+	if err := tenant.Risks.IdentifyRisk(&Risk{ID: "RISK-001", Description: "Supplier delay"}); err != nil {
+		t.Fatalf("Failed to identify risk: %v", err)
+	}
+
+	plan, err := tenant.Plan(
+		func(t *Tenant) error {
+			return t.Risks.IdentifyRisk(&Risk{ID: "RISK-002", Description: "Key staff leaves"})
+		},
+		func(t *Tenant) error {
+			return t.Risks.AssessRisk("RISK-001", RiskLevelHigh, RiskLevelHigh)
+		},
+		func(t *Tenant) error {
+			t.Organization.QMS = &QualityManagementSystem{Processes: []Process{{ID: "PROC-001", Name: "Sales"}}}
+			return nil
+		},
+	)
+	if err != nil {
+		t.Fatalf("Failed to plan: %v", err)
+	}
+
+	if len(plan.Changes) != 3 {
+		t.Fatalf("Expected 3 changes, got %+v", plan.Changes)
+	}
+	if c := plan.Changes[0]; c.Action != ChangeAdd || c.EntityType != EntityTypeProcess {
+		t.Errorf("Expected process to be added first, got %+v", c)
+	}
+	if c := plan.Changes[1]; c.Action != ChangeModify || c.EntityID != "RISK-001" || !strings.Contains(strings.Join(c.Fields, ","), "likelihood") {
+		t.Errorf("Expected RISK-001 likelihood to change, got %+v", c)
+	}
+	if _, exists := tenant.Risks.Risks["RISK-002"]; exists {
+		t.Fatal("Expected planning to leave the tenant unchanged")
+	}
+	if !strings.Contains(plan.String(), "Plan: 2 to add, 1 to change, 0 to remove.") {
+		t.Errorf("Unexpected plan output:\n%s", plan)
+	}
+
+	risks := tenant.Risks
+	if err := tenant.Apply(plan); err != nil {
+		t.Fatalf("Failed to apply plan: %v", err)
+	}
+	if _, exists := risks.Risks["RISK-002"]; !exists {
+		t.Error("Expected applied plan to be visible through the existing manager")
+	}
+	if err := tenant.Apply(plan); !errors.Is(err, ErrStalePlan) {
+		t.Errorf("Expected ErrStalePlan when reapplying, got %v", err)
+	}
+}
+
+func TestApplyLeavesTenantUntouchedOnFailure(t *testing.T) {
+	tenant := NewTenant("ORG-001")
+	plan, err := tenant.Plan(func(t *Tenant) error {
+		return t.Risks.IdentifyRisk(&Risk{ID: "RISK-001", Description: "Supplier delay"})
+	})
+	if err != nil {
+		t.Fatalf("Failed to plan: %v", err)
+	}
+
+	if _, err := tenant.Plan(func(t *Tenant) error { return t.Risks.AssessRisk("RISK-404", RiskLevelLow, RiskLevelLow) }); err == nil {
+		t.Error("Expected planning a failing operation to fail")
+	}
+	if err := tenant.Apply(plan); err != nil {
+		t.Fatalf("Failed to apply plan: %v", err)
+	}
+	if len(tenant.Risks.Risks) != 1 {
+		t.Errorf("Expected exactly one risk, got %d", len(tenant.Risks.Risks))
+	}
+}
@@ -0,0 +1,521 @@
+package iso9001
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ErrDataSubjectNotFound is returned when no personal data is held for a person
+var ErrDataSubjectNotFound = errors.New("no personal data found for person")
+
+// RedactedValue replaces personal data removed by ScrubRemove
+const RedactedValue = "[redacted]"
+
+// ScrubMode selects how personal data of a departed individual is treated
+type ScrubMode string
+
+const (
+	// ScrubPseudonymize replaces IDs, names and references with a stable pseudonym, so
+	// records by the same person can still be related to each other
+	ScrubPseudonymize ScrubMode = "pseudonymize"
+	// ScrubRemove removes IDs, names, references, competence and training and deletes
+	// the person's user. Records keep their place but no longer point at anyone.
+	ScrubRemove ScrubMode = "remove"
+)
+
+// PersonalDataField locates a field holding personal data
+type PersonalDataField struct {
+	EntityType string `json:"entity_type" yaml:"entity_type"`
+	EntityID   string `json:"entity_id" yaml:"entity_id"`
+	Field      string `json:"field" yaml:"field"`
+	Value      string `json:"value,omitempty" yaml:"value,omitempty"`
+	SubjectID  string `json:"subject_id,omitempty" yaml:"subject_id,omitempty"` // person the value belongs to, when known
+}
+
+// DataSubject summarizes the personal data held about one person
+type DataSubject struct {
+	ID         string   `json:"id" yaml:"id"`
+	Names      []string `json:"names" yaml:"names"`
+	References int      `json:"references" yaml:"references"`
+}
+
+// PersonalDataInventory lists every field of a tenant that holds personal data and
+// the people it belongs to
+type PersonalDataInventory struct {
+	Fields   []PersonalDataField `json:"fields" yaml:"fields"`
+	Subjects []DataSubject       `json:"subjects" yaml:"subjects"`
+}
+
+// FieldsFor returns the inventoried fields attributed to a person
+func (inv *PersonalDataInventory) FieldsFor(personID string) []PersonalDataField {
+	var fields []PersonalDataField
+	for _, field := range inv.Fields {
+		if field.SubjectID == personID {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// ScrubOptions configures ScrubPersonalData
+type ScrubOptions struct {
+	Mode ScrubMode `json:"mode" yaml:"mode"`
+	// Salt keys the pseudonym; the tenant's PrivacySalt is used when empty
+	Salt string `json:"-" yaml:"-"`
+	// Actor is recorded in the audit trail as having ordered the erasure
+	Actor string `json:"actor,omitempty" yaml:"actor,omitempty"`
+}
+
+// ScrubReport records where personal data of a person was replaced. Values are not
+// retained. The pseudonym identifies the erasure in the audit trail in either mode.
+type ScrubReport struct {
+	SubjectID string              `json:"subject_id" yaml:"subject_id"`
+	Pseudonym string              `json:"pseudonym" yaml:"pseudonym"`
+	Mode      ScrubMode           `json:"mode" yaml:"mode"`
+	Fields    []PersonalDataField `json:"fields" yaml:"fields"`
+}
+
+// Pseudonym derives the stable pseudonym used for a person ID. Only holders of the
+// salt can tell which ID a pseudonym stands for.
+func Pseudonym(salt, personID string) string {
+	sum := sha256.Sum256([]byte(salt + "\x00" + personID))
+	return "anon-" + hex.EncodeToString(sum[:6])
+}
+
+// personalDataKind tells how a field refers to a person
+type personalDataKind int
+
+const (
+	personalDataID        personalDataKind = iota // a person ID
+	personalDataName                              // the name of the record's person
+	personalDataReference                         // free text naming a person by ID, name or email
+)
+
+// personalDataRef points at a single string holding personal data
+type personalDataRef struct {
+	PersonalDataField
+	kind  personalDataKind
+	value *string
+}
+
+// PersonalDataInventory reports every field of the tenant that holds personal data.
+// References such as a finding's responsible party are attributed to a person when
+// they match the person's ID or one of the names recorded for them.
+func (t *Tenant) PersonalDataInventory() *PersonalDataInventory {
+	refs := t.personalData()
+	names := subjectNames(refs)
+
+	inventory := &PersonalDataInventory{Fields: []PersonalDataField{}, Subjects: []DataSubject{}}
+	references := make(map[string]int)
+	for _, ref := range refs {
+		field := ref.PersonalDataField
+		field.Value = *ref.value
+		if field.SubjectID == "" {
+			field.SubjectID = matchSubject(field.Value, names)
+		}
+		if field.SubjectID != "" {
+			references[field.SubjectID]++
+		}
+		inventory.Fields = append(inventory.Fields, field)
+	}
+
+	for id, count := range references {
+		inventory.Subjects = append(inventory.Subjects, DataSubject{ID: id, Names: names[id], References: count})
+	}
+	sort.Slice(inventory.Subjects, func(i, j int) bool { return inventory.Subjects[i].ID < inventory.Subjects[j].ID })
+
+	return inventory
+}
+
+// ScrubPersonalData pseudonymizes or removes the personal data of a person across the
+// organization and all managers of the tenant. Records themselves are kept: approvals,
+// findings and role assignments stay in place and, when pseudonymized, keep pointing
+// at the same, now anonymous, person. Actors in the audit trail are replaced as well
+// and its hash chain recomputed, unless the trail already failed verification. The
+// erasure is then appended to the trail under the pseudonym.
+func (t *Tenant) ScrubPersonalData(personID string, opts ScrubOptions) (*ScrubReport, error) {
+	switch opts.Mode {
+	case "":
+		opts.Mode = ScrubPseudonymize
+	case ScrubPseudonymize, ScrubRemove:
+	default:
+		return nil, fmt.Errorf("unknown scrub mode %q", opts.Mode)
+	}
+
+	intact := t.Trail != nil && t.Trail.Verify() == nil
+
+	refs := t.personalData()
+	names := subjectNames(refs)[personID]
+
+	var matched []personalDataRef
+	for _, ref := range refs {
+		if refersTo(ref, personID, names) {
+			matched = append(matched, ref)
+		}
+	}
+	if len(matched) == 0 {
+		return nil, ErrDataSubjectNotFound
+	}
+	salt := opts.Salt
+	if salt == "" {
+		var err error
+		if salt, err = t.privacySalt(); err != nil {
+			return nil, err
+		}
+	}
+
+	report := &ScrubReport{
+		SubjectID: personID,
+		Pseudonym: Pseudonym(salt, personID),
+		Mode:      opts.Mode,
+		Fields:    []PersonalDataField{},
+	}
+	if opts.Mode == ScrubRemove {
+		t.clearProfiles(personID)
+	}
+	for _, ref := range matched {
+		if opts.Mode == ScrubPseudonymize {
+			*ref.value = report.Pseudonym
+		} else {
+			*ref.value = RedactedValue
+		}
+		field := ref.PersonalDataField
+		field.SubjectID = report.Pseudonym
+		report.Fields = append(report.Fields, field)
+	}
+
+	if t.Users != nil {
+		t.Users.reindex()
+	}
+	if intact {
+		t.Trail.rehash(t.Trail.key)
+	}
+	if t.Trail == nil {
+		t.Trail = NewAuditTrail()
+	}
+	t.Trail.Record(TrailEntry{
+		Actor:      opts.Actor,
+		Action:     "scrub personal data",
+		EntityType: EntityTypeUser,
+		EntityID:   report.Pseudonym,
+		Operation:  ChangeOperationErased,
+		After:      fmt.Sprintf("%s: %d fields", opts.Mode, len(report.Fields)),
+	})
+	return report, nil
+}
+
+// privacySalt returns the tenant's PrivacySalt, generating a random one on first use
+func (t *Tenant) privacySalt() (string, error) {
+	if t.PrivacySalt == "" {
+		salt := make([]byte, 32)
+		if _, err := rand.Read(salt); err != nil {
+			return "", fmt.Errorf("failed to generate privacy salt: %w", err)
+		}
+		t.PrivacySalt = hex.EncodeToString(salt)
+	}
+	return t.PrivacySalt, nil
+}
+
+// refersTo reports whether a field holds data of the person
+func refersTo(ref personalDataRef, personID string, names []string) bool {
+	if *ref.value == "" || *ref.value == RedactedValue {
+		return false
+	}
+	switch ref.kind {
+	case personalDataID:
+		return *ref.value == personID
+	case personalDataName:
+		return ref.SubjectID == personID
+	case personalDataReference:
+		return *ref.value == personID || matchSubject(*ref.value, map[string][]string{personID: names}) == personID
+	}
+	return false
+}
+
+// clearProfiles removes competence and training of a person scrubbed in remove mode
+// and deletes their user
+func (t *Tenant) clearProfiles(personID string) {
+	if t.Organization != nil && t.Organization.Leadership != nil {
+		for i := range t.Organization.Leadership.TopManagement {
+			person := &t.Organization.Leadership.TopManagement[i]
+			if person.ID == personID {
+				person.Competence = nil
+				person.Training = nil
+			}
+		}
+	}
+	if t.Users != nil {
+		delete(t.Users.Users, personID)
+	}
+	if t.Audits == nil {
+		return
+	}
+	for _, audit := range t.Audits.Audits {
+		for _, participants := range [][]AuditParticipant{audit.Auditors, audit.Auditees} {
+			for i := range participants {
+				if participants[i].ID == personID {
+					participants[i].Competence = nil
+				}
+			}
+		}
+	}
+}
+
+// subjectNames collects the names recorded for each person ID
+func subjectNames(refs []personalDataRef) map[string][]string {
+	names := make(map[string][]string)
+	for _, ref := range refs {
+		if ref.kind != personalDataName || ref.SubjectID == "" || *ref.value == "" {
+			continue
+		}
+		known := false
+		for _, name := range names[ref.SubjectID] {
+			if strings.EqualFold(name, *ref.value) {
+				known = true
+				break
+			}
+		}
+		if !known {
+			names[ref.SubjectID] = append(names[ref.SubjectID], *ref.value)
+		}
+	}
+	return names
+}
+
+// matchSubject returns the person a free-text reference names, matching IDs exactly
+// and names case-insensitively, including the local part of an email address
+func matchSubject(value string, names map[string][]string) string {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return ""
+	}
+	if _, ok := names[value]; ok {
+		return value
+	}
+	local, _, isEmail := strings.Cut(value, "@")
+	for id, candidates := range names {
+		for _, name := range candidates {
+			if strings.EqualFold(name, value) {
+				return id
+			}
+			if isEmail && strings.EqualFold(strings.ReplaceAll(name, " ", "."), local) {
+				return id
+			}
+		}
+	}
+	return ""
+}
+
+// personalData walks the tenant and returns a reference to every string that holds
+// personal data
+func (t *Tenant) personalData() []personalDataRef {
+	var refs []personalDataRef
+	add := func(entityType, entityID, field, subjectID string, kind personalDataKind, value *string) {
+		if *value == "" {
+			return
+		}
+		refs = append(refs, personalDataRef{
+			PersonalDataField: PersonalDataField{EntityType: entityType, EntityID: entityID, Field: field, SubjectID: subjectID},
+			kind:              kind,
+			value:             value,
+		})
+	}
+	person := func(entityType, entityID, prefix string, id, name *string) {
+		add(entityType, entityID, prefix+".id", *id, personalDataID, id)
+		add(entityType, entityID, prefix+".name", *id, personalDataName, name)
+	}
+	actions := func(entityType, entityID, prefix string, list []Action) {
+		for i := range list {
+			add(entityType, entityID, prefix+"."+list[i].ID+".responsible", "", personalDataReference, &list[i].Responsible)
+		}
+	}
+
+	if org := t.Organization; org != nil {
+		if org.Leadership != nil {
+			for i := range org.Leadership.TopManagement {
+				p := &org.Leadership.TopManagement[i]
+				person(EntityTypeOrganization, org.ID, "top_management", &p.ID, &p.Name)
+			}
+			for i := range org.Leadership.Roles {
+				role := &org.Leadership.Roles[i]
+				add(EntityTypeRole, role.ID, "assigned_to", role.AssignedTo, personalDataID, &role.AssignedTo)
+			}
+			for i := range org.Leadership.Delegations {
+				delegation := &org.Leadership.Delegations[i]
+				add(EntityTypeOrganization, org.ID, "delegations."+delegation.ID+".delegator", delegation.Delegator, personalDataID, &delegation.Delegator)
+				add(EntityTypeOrganization, org.ID, "delegations."+delegation.ID+".delegate", delegation.Delegate, personalDataID, &delegation.Delegate)
+			}
+		}
+		if org.QMS != nil {
+			for i := range org.QMS.Processes {
+				process := &org.QMS.Processes[i]
+				for j := range process.Risks {
+					actions(EntityTypeRisk, process.Risks[j].ID, "mitigation", process.Risks[j].Mitigation)
+				}
+				for j := range process.Opportunities {
+					actions(EntityTypeOpportunity, process.Opportunities[j].ID, "actions", process.Opportunities[j].Actions)
+				}
+			}
+			for i := range org.QMS.Risks {
+				actions(EntityTypeRisk, org.QMS.Risks[i].ID, "mitigation", org.QMS.Risks[i].Mitigation)
+			}
+			for i := range org.QMS.Opportunities {
+				actions(EntityTypeOpportunity, org.QMS.Opportunities[i].ID, "actions", org.QMS.Opportunities[i].Actions)
+			}
+			for i := range org.QMS.Objectives {
+				objective := &org.QMS.Objectives[i]
+				add(EntityTypeObjective, objective.ID, "responsible", "", personalDataReference, &objective.Responsible)
+			}
+		}
+	}
+
+	if t.Users != nil {
+		for _, user := range t.Users.Users {
+			add(EntityTypeUser, user.ID, "id", user.ID, personalDataID, &user.ID)
+			add(EntityTypeUser, user.ID, "name", user.ID, personalDataName, &user.Name)
+			add(EntityTypeUser, user.ID, "email", user.ID, personalDataName, &user.Email)
+		}
+	}
+
+	if t.Documents != nil {
+		for _, doc := range t.Documents.Documents {
+			add(EntityTypeDocument, doc.ID, "metadata.author", "", personalDataReference, &doc.Metadata.Author)
+			add(EntityTypeDocument, doc.ID, "metadata.owner", "", personalDataReference, &doc.Metadata.Owner)
+			if doc.Approval != nil {
+				for i := range doc.Approval.RequiredApprovers {
+					add(EntityTypeDocument, doc.ID, "approval.required_approvers", "", personalDataID, &doc.Approval.RequiredApprovers[i])
+				}
+				for i := range doc.Approval.ActualApprovers {
+					approval := &doc.Approval.ActualApprovers[i]
+					person(EntityTypeDocument, doc.ID, "approval.actual_approvers", &approval.ApproverID, &approval.ApproverName)
+				}
+				for i := range doc.Approval.Rejections {
+					rejection := &doc.Approval.Rejections[i]
+					add(EntityTypeDocument, doc.ID, "approval.rejections.approver_id", rejection.ApproverID, personalDataID, &rejection.ApproverID)
+				}
+			}
+			if doc.Review != nil {
+				person(EntityTypeDocument, doc.ID, "review.reviewer", &doc.Review.ReviewerID, &doc.Review.ReviewerName)
+			}
+			for i := range doc.Versions {
+				add(EntityTypeDocument, doc.ID, "versions."+doc.Versions[i].VersionNumber+".created_by", "", personalDataReference, &doc.Versions[i].CreatedBy)
+			}
+			for i := range doc.Access.ReadAccess {
+				add(EntityTypeDocument, doc.ID, "access.read_access", "", personalDataID, &doc.Access.ReadAccess[i])
+			}
+			for i := range doc.Access.WriteAccess {
+				add(EntityTypeDocument, doc.ID, "access.write_access", "", personalDataID, &doc.Access.WriteAccess[i])
+			}
+			for i := range doc.Distribution {
+				record := &doc.Distribution[i]
+				prefix := fmt.Sprintf("distribution.%d.", record.CopyNumber)
+				add(EntityTypeDocument, doc.ID, prefix+"recipient", "", personalDataReference, &record.Recipient)
+				add(EntityTypeDocument, doc.ID, prefix+"distributed_by", "", personalDataReference, &record.DistributedBy)
+			}
+		}
+	}
+
+	if t.Risks != nil {
+		for _, risk := range t.Risks.Risks {
+			add(EntityTypeRisk, risk.ID, "owner", "", personalDataReference, &risk.Owner)
+			actions(EntityTypeRisk, risk.ID, "mitigation", risk.Mitigation)
+		}
+		for _, opportunity := range t.Risks.Opportunities {
+			actions(EntityTypeOpportunity, opportunity.ID, "actions", opportunity.Actions)
+		}
+	}
+
+	if t.Objectives != nil {
+		for _, objective := range t.Objectives.Objectives {
+			add(EntityTypeObjective, objective.ID, "responsible", "", personalDataReference, &objective.Responsible)
+		}
+	}
+
+	if t.Audits != nil {
+		for _, audit := range t.Audits.Audits {
+			for i := range audit.Auditors {
+				person(EntityTypeAudit, audit.ID, "auditors", &audit.Auditors[i].ID, &audit.Auditors[i].Name)
+			}
+			for i := range audit.Auditees {
+				person(EntityTypeAudit, audit.ID, "auditees", &audit.Auditees[i].ID, &audit.Auditees[i].Name)
+			}
+			for i := range audit.Findings {
+				finding := &audit.Findings[i]
+				add(EntityTypeFinding, finding.ID, "responsible", "", personalDataReference, &finding.Responsible)
+				for j := range finding.CorrectiveActions {
+					action := &finding.CorrectiveActions[j]
+					add(EntityTypeFinding, finding.ID, "corrective_actions."+action.ID+".responsible", "", personalDataReference, &action.Responsible)
+				}
+			}
+			for i := range audit.Recommendations {
+				add(EntityTypeAudit, audit.ID, "recommendations."+audit.Recommendations[i].ID+".responsible", "", personalDataReference, &audit.Recommendations[i].Responsible)
+			}
+			if audit.Report != nil {
+				add(EntityTypeAudit, audit.ID, "report.reviewed_by", "", personalDataReference, &audit.Report.ReviewedBy)
+				add(EntityTypeAudit, audit.ID, "report.approved_by", "", personalDataReference, &audit.Report.ApprovedBy)
+				for i := range audit.Report.Recommendations {
+					recommendation := &audit.Report.Recommendations[i]
+					add(EntityTypeAudit, audit.ID, "report.recommendations."+recommendation.ID+".responsible", "", personalDataReference, &recommendation.Responsible)
+				}
+			}
+		}
+		for _, review := range t.Audits.ManagementReviews {
+			for i := range review.Attendees {
+				person(EntityTypeManagementReview, review.ID, "attendees", &review.Attendees[i].ID, &review.Attendees[i].Name)
+			}
+			for i := range review.Outputs.ActionItems {
+				item := &review.Outputs.ActionItems[i]
+				add(EntityTypeManagementReview, review.ID, "outputs.action_items."+item.ID+".responsible", "", personalDataReference, &item.Responsible)
+			}
+		}
+	}
+
+	if t.Feedback != nil {
+		for _, complaint := range t.Feedback.Complaints {
+			add(EntityTypeComplaint, complaint.ID, "customer", "", personalDataReference, &complaint.Customer)
+			add(EntityTypeComplaint, complaint.ID, "source", "", personalDataReference, &complaint.Source)
+			add(EntityTypeComplaint, complaint.ID, "responsible", "", personalDataReference, &complaint.Responsible)
+		}
+		if t.Feedback.Surveys != nil {
+			for i := range t.Feedback.Surveys.Responses {
+				response := &t.Feedback.Surveys.Responses[i]
+				add(EntityTypeSurvey, response.SurveyID, "responses."+response.ID+".respondent", "", personalDataReference, &response.Respondent)
+			}
+		}
+	}
+
+	if t.Outputs != nil {
+		for _, output := range t.Outputs.Outputs {
+			add(EntityTypeNonconformingOutput, output.ID, "detected_by", "", personalDataReference, &output.DetectedBy)
+			if concession := output.Concession; concession != nil {
+				add(EntityTypeNonconformingOutput, output.ID, "concession.requested_by", "", personalDataReference, &concession.RequestedBy)
+				for i := range concession.Approvals {
+					approval := &concession.Approvals[i]
+					person(EntityTypeNonconformingOutput, output.ID, "concession.approvals", &approval.ApproverID, &approval.ApproverName)
+				}
+			}
+			if disposition := output.Disposition; disposition != nil {
+				add(EntityTypeNonconformingOutput, output.ID, "disposition.decided_by", "", personalDataReference, &disposition.DecidedBy)
+				add(EntityTypeNonconformingOutput, output.ID, "disposition.verified_by", "", personalDataReference, &disposition.VerifiedBy)
+			}
+			for i := range output.CorrectiveActions {
+				action := &output.CorrectiveActions[i]
+				add(EntityTypeNonconformingOutput, output.ID, "corrective_actions."+action.ID+".responsible", "", personalDataReference, &action.Responsible)
+			}
+		}
+	}
+
+	if t.Trail != nil {
+		for i := range t.Trail.Entries {
+			entry := &t.Trail.Entries[i]
+			add(entry.EntityType, entry.EntityID, fmt.Sprintf("trail.%d.actor", entry.Sequence), "", personalDataReference, &entry.Actor)
+		}
+	}
+
+	return refs
+}
@@ -0,0 +1,225 @@
+package iso9001
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func newPrivacyTenant(t *testing.T) *Tenant {
+	t.Helper()
+	tenant := NewTenant("ORG-001")
+	tenant.Organization.Leadership = &Leadership{
+		TopManagement: []Person{{ID: "P-001", Name: "Jane Doe", Role: "Quality Manager", Training: []string{"ISO 9001 lead auditor"}}},
+		Roles:         []OrganizationalRole{{ID: "ROLE-QM", Name: "Quality Manager", AssignedTo: "P-001"}},
+	}
+
+	doc := &DocumentedInformation{
+		ID:       "DOC-001",
+		Title:    "Quality Manual",
+		Metadata: DocumentMetadata{Author: "Jane Doe", Owner: "P-002"},
+		Status:   DocumentStatusDraft,
+		Approval: &DocumentApproval{RequiredApprovers: []string{"P-001"}},
+	}
+	if err := tenant.Documents.AddDocument(doc); err != nil {
+		t.Fatalf("Failed to create document: %v", err)
+	}
+	if err := tenant.Documents.ApproveDocument("DOC-001", Approval{ApproverID: "P-001", ApproverName: "Jane Doe"}); err != nil {
+		t.Fatalf("Failed to approve document: %v", err)
+	}
+
+	audit := &Audit{ID: "AUD-001", Title: "Audit", Scope: AuditScope{Description: "QMS"}, Auditors: []AuditParticipant{{ID: "P-001", Name: "Jane Doe"}}}
+	if err := tenant.Audits.CreateAudit(audit); err != nil {
+		t.Fatalf("Failed to create audit: %v", err)
+	}
+	if err := tenant.Audits.AddFinding("AUD-001", AuditFinding{ID: "F-001", Severity: SeverityMinor, Responsible: "jane.doe@example.com"}); err != nil {
+		t.Fatalf("Failed to add finding: %v", err)
+	}
+	return tenant
+}
+
+func TestPersonalDataInventory(t *testing.T) {
+	tenant := newPrivacyTenant(t)
+
+	inventory := tenant.PersonalDataInventory()
+	fields := inventory.FieldsFor("P-001")
+	if len(fields) != 11 {
+		t.Errorf("Expected 11 fields for P-001, got %d: %+v", len(fields), fields)
+	}
+
+	var subject *DataSubject
+	for i := range inventory.Subjects {
+		if inventory.Subjects[i].ID == "P-001" {
+			subject = &inventory.Subjects[i]
+		}
+	}
+	if subject == nil || len(subject.Names) != 1 || subject.Names[0] != "Jane Doe" {
+		t.Errorf("Expected P-001 to be known as Jane Doe, got %+v", subject)
+	}
+}
+
+func TestScrubPersonalData(t *testing.T) {
+	tenant := newPrivacyTenant(t)
+
+	report, err := tenant.ScrubPersonalData("P-001", ScrubOptions{Mode: ScrubRemove, Actor: "dpo"})
+	if err != nil {
+		t.Fatalf("Failed to scrub personal data: %v", err)
+	}
+	if tenant.PrivacySalt == "" || report.Pseudonym != Pseudonym(tenant.PrivacySalt, "P-001") || report.Pseudonym == Pseudonym("ORG-001", "P-001") {
+		t.Errorf("Expected pseudonym keyed by a generated secret salt, got %s", report.Pseudonym)
+	}
+
+	person := tenant.Organization.Leadership.TopManagement[0]
+	if person.ID != RedactedValue || person.Name != RedactedValue || person.Training != nil {
+		t.Errorf("Expected person to be removed, got %+v", person)
+	}
+	if tenant.Organization.Leadership.Roles[0].AssignedTo != RedactedValue {
+		t.Error("Expected role assignment to be removed")
+	}
+
+	doc, _ := tenant.Documents.GetDocument("DOC-001")
+	if doc.Metadata.Author != RedactedValue || doc.Metadata.Owner != "P-002" {
+		t.Errorf("Expected only the scrubbed author to be removed, got %+v", doc.Metadata)
+	}
+	if doc.Approval.Status != ApprovalStatusApproved || doc.Approval.ActualApprovers[0].ApproverID != RedactedValue || doc.Approval.RequiredApprovers[0] != RedactedValue {
+		t.Error("Expected the approval to be kept without its approver")
+	}
+
+	finding := tenant.Audits.Audits["AUD-001"].Findings[0]
+	if finding.Responsible != RedactedValue {
+		t.Errorf("Expected email reference to be removed, got %s", finding.Responsible)
+	}
+
+	if len(tenant.PersonalDataInventory().FieldsFor("P-001")) != 0 {
+		t.Error("Expected no personal data left for P-001")
+	}
+	if _, err := tenant.ScrubPersonalData("P-001", ScrubOptions{}); !errors.Is(err, ErrDataSubjectNotFound) {
+		t.Errorf("Expected ErrDataSubjectNotFound on second scrub, got %v", err)
+	}
+
+	// the erasure is recorded under the pseudonym, not the person ID
+	entry := tenant.Trail.Entries[len(tenant.Trail.Entries)-1]
+	if entry.Operation != ChangeOperationErased || entry.EntityID != report.Pseudonym || entry.Actor != "dpo" || strings.Contains(entry.After, "P-001") {
+		t.Errorf("Expected the erasure in the audit trail, got %+v", entry)
+	}
+	if err := tenant.Trail.Verify(); err != nil {
+		t.Errorf("Expected the trail to verify, got %v", err)
+	}
+}
+
+func TestScrubPersonalDataPseudonymize(t *testing.T) {
+	tenant := newPrivacyTenant(t)
+
+	report, err := tenant.ScrubPersonalData("P-001", ScrubOptions{})
+	if err != nil {
+		t.Fatalf("Failed to scrub personal data: %v", err)
+	}
+	person := tenant.Organization.Leadership.TopManagement[0]
+	if person.ID != report.Pseudonym || person.Name != report.Pseudonym || len(person.Training) != 1 {
+		t.Errorf("Expected person to be pseudonymized, got %+v", person)
+	}
+	doc, _ := tenant.Documents.GetDocument("DOC-001")
+	if doc.Approval.ActualApprovers[0].ApproverID != doc.Approval.RequiredApprovers[0] {
+		t.Error("Expected approval to stay consistent after pseudonymizing")
+	}
+
+	// the salt is saved with the tenant, so later scrubs give the same pseudonyms
+	data, err := json.Marshal(tenant)
+	if err != nil {
+		t.Fatalf("Failed to encode tenant: %v", err)
+	}
+	loaded, err := LoadTenantJSON(data)
+	if err != nil {
+		t.Fatalf("Failed to load tenant: %v", err)
+	}
+	if loaded.PrivacySalt != tenant.PrivacySalt {
+		t.Error("Expected the privacy salt to be stored with the tenant")
+	}
+	if salted, err := newPrivacyTenant(t).ScrubPersonalData("P-001", ScrubOptions{Salt: "secret"}); err != nil || salted.Pseudonym != Pseudonym("secret", "P-001") {
+		t.Errorf("Expected the given salt to key the pseudonym, got %+v %v", salted, err)
+	}
+}
+
+func TestScrubPersonalDataRecords(t *testing.T) {
+	tenant := newPrivacyTenant(t)
+	tenant.Trail.SetKey([]byte("trail-key"))
+
+	err := tenant.Change("Jane Doe", "record", func(tenant *Tenant) error {
+		tenant.Organization.Leadership.Delegations = []Delegation{{ID: "DEL-001", Delegator: "P-001", Delegate: "P-002"}}
+		doc, _ := tenant.Documents.GetDocument("DOC-001")
+		doc.Approval.Rejections = []ApprovalRejection{{ApproverID: "P-001", Stage: "review"}}
+		doc.Distribution = []DistributionRecord{{CopyNumber: 1, Recipient: "P-001", DistributedBy: "Jane Doe"}}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Failed to change tenant: %v", err)
+	}
+	complaint := &CustomerComplaint{Description: "Late delivery", Customer: "Acme Ltd", Responsible: "Jane Doe"}
+	if err := tenant.Feedback.RecordComplaint(complaint); err != nil {
+		t.Fatalf("Failed to record complaint: %v", err)
+	}
+	output := &NonconformingOutput{Description: "Scratched housing", Product: "Batch 7", DetectedBy: "jane.doe@example.com"}
+	if err := tenant.Outputs.RecordOutput(output); err != nil {
+		t.Fatalf("Failed to record output: %v", err)
+	}
+	output.Disposition = &Disposition{Type: DispositionRework, DecidedBy: "P-001", VerifiedBy: "P-002"}
+	output.Concession = &ConcessionRequest{RequestedBy: "Jane Doe", Approvals: []Approval{{ApproverID: "P-001", ApproverName: "Jane Doe"}}}
+
+	fields := make(map[string]bool)
+	for _, field := range tenant.PersonalDataInventory().FieldsFor("P-001") {
+		fields[field.EntityType+":"+field.Field] = true
+	}
+	for _, want := range []string{
+		"organization:delegations.DEL-001.delegator",
+		"document:approval.rejections.approver_id",
+		"document:distribution.1.recipient",
+		"document:distribution.1.distributed_by",
+		"document:trail.1.actor",
+		"complaint:responsible",
+		"nonconforming_output:detected_by",
+		"nonconforming_output:disposition.decided_by",
+		"nonconforming_output:concession.requested_by",
+		"nonconforming_output:concession.approvals.id",
+		"nonconforming_output:concession.approvals.name",
+	} {
+		if !fields[want] {
+			t.Errorf("Expected %s in the inventory of P-001, got %v", want, fields)
+		}
+	}
+
+	if _, err := tenant.ScrubPersonalData("P-001", ScrubOptions{Mode: ScrubRemove}); err != nil {
+		t.Fatalf("Failed to scrub personal data: %v", err)
+	}
+	if delegation := tenant.Organization.Leadership.Delegations[0]; delegation.Delegator != RedactedValue || delegation.Delegate != "P-002" {
+		t.Errorf("Expected only the delegator to be removed, got %+v", delegation)
+	}
+	doc, _ := tenant.Documents.GetDocument("DOC-001")
+	if doc.Approval.Rejections[0].ApproverID != RedactedValue {
+		t.Errorf("Expected rejection to be anonymized, got %+v", doc.Approval.Rejections[0])
+	}
+	if record := doc.Distribution[0]; record.Recipient != RedactedValue || record.DistributedBy != RedactedValue {
+		t.Errorf("Expected distribution record to be anonymized, got %+v", record)
+	}
+	if complaint.Responsible != RedactedValue || complaint.Customer != "Acme Ltd" {
+		t.Errorf("Expected only the responsible person of the complaint to be removed, got %+v", complaint)
+	}
+	if output.DetectedBy != RedactedValue || output.Disposition.DecidedBy != RedactedValue || output.Disposition.VerifiedBy != "P-002" {
+		t.Errorf("Expected output to be anonymized, got %+v %+v", output, output.Disposition)
+	}
+	if approval := output.Concession.Approvals[0]; approval.ApproverID != RedactedValue || approval.ApproverName != RedactedValue {
+		t.Errorf("Expected concession approval to be anonymized, got %+v", approval)
+	}
+
+	for _, entry := range tenant.Trail.Entries {
+		if entry.Operation != ChangeOperationErased && entry.Actor != RedactedValue {
+			t.Errorf("Expected trail actor to be removed, got %s", entry.Actor)
+		}
+	}
+	if err := tenant.Trail.Verify(); err != nil {
+		t.Errorf("Expected the trail to verify after the scrub, got %v", err)
+	}
+	if fields := tenant.PersonalDataInventory().FieldsFor("P-001"); len(fields) != 0 {
+		t.Errorf("Expected no personal data left for P-001, got %+v", fields)
+	}
+}
@@ -0,0 +1,173 @@
+package iso9001
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ProcedureIDPrefix is prepended to a process ID to form the ID of its drafted procedure
+const ProcedureIDPrefix = "PROC-"
+
+// DraftProcedure converts a process definition into a draft procedure document. The
+// content follows the procedure template: purpose, scope, responsibilities, procedure,
+// records and references. Activities the process definition cannot describe are
+// marked TODO for the process owner to complete.
+func DraftProcedure(process Process) *DocumentedInformation {
+	name := process.Name
+	if name == "" {
+		name = process.ID
+	}
+
+	doc := &DocumentedInformation{
+		ID:       ProcedureIDPrefix + process.ID,
+		Title:    name + " Procedure",
+		Type:     DocumentTypeProcedure,
+		Category: CategoryProcessManagement,
+		Content:  draftProcedureContent(name, process),
+		Metadata: DocumentMetadata{
+			Keywords:       []string{name},
+			RelatedClauses: []string{"4.4", "8.1"},
+			Format:         "electronic",
+		},
+		Status: DocumentStatusDraft,
+	}
+	if len(process.Responsibilities) > 0 {
+		doc.Metadata.Owner = process.Responsibilities[0]
+	}
+	return doc
+}
+
+// AddProcedureDraft drafts the procedure for a process and adds it to the manager as
+// a draft document
+func (dm *DocumentationManager) AddProcedureDraft(process Process) (*DocumentedInformation, error) {
+	doc := DraftProcedure(process)
+	if err := dm.AddDocument(doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+func draftProcedureContent(name string, process Process) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s Procedure\n", name)
+
+	b.WriteString("\n1. Purpose\n\n")
+	fmt.Fprintf(&b, "This procedure describes how the %s process is carried out and controlled.", name)
+	if process.Description != "" {
+		fmt.Fprintf(&b, " %s", strings.TrimSpace(process.Description))
+	}
+	b.WriteString("\n")
+
+	b.WriteString("\n2. Scope\n\n")
+	if len(process.Inputs) > 0 {
+		fmt.Fprintf(&b, "The process starts on receipt of %s.\n", joinNames(process.Inputs, func(input ProcessInput) string { return input.Name }))
+	} else {
+		b.WriteString("TODO: define the inputs that start the process.\n")
+	}
+	if len(process.Outputs) > 0 {
+		fmt.Fprintf(&b, "It ends with the delivery of %s.\n", joinNames(process.Outputs, func(output ProcessOutput) string { return output.Name }))
+	} else {
+		b.WriteString("TODO: define the outputs that end the process.\n")
+	}
+
+	b.WriteString("\n3. Responsibilities\n\n")
+	if len(process.Responsibilities) == 0 {
+		b.WriteString("TODO: assign responsibilities and authorities.\n")
+	}
+	for _, responsibility := range process.Responsibilities {
+		fmt.Fprintf(&b, "- %s\n", responsibility)
+	}
+
+	b.WriteString("\n4. Procedure\n\n4.1 Inputs\n\n")
+	if len(process.Inputs) == 0 {
+		b.WriteString("TODO: list the inputs required to start the process.\n")
+	}
+	for _, input := range process.Inputs {
+		line := "- Receive " + input.Name
+		if input.Source != "" {
+			line += " from " + input.Source
+		}
+		fmt.Fprintf(&b, "%s\n", line)
+	}
+
+	b.WriteString("\n4.2 Activities\n\nTODO: describe the steps that transform the inputs into the outputs.\n")
+
+	if len(process.Resources) > 0 {
+		b.WriteString("\nResources required:\n\n")
+		for _, resource := range process.Resources {
+			fmt.Fprintf(&b, "- %s (%s)\n", resource.Name, resource.Type)
+		}
+	}
+
+	b.WriteString("\n4.3 Outputs\n\n")
+	if len(process.Outputs) == 0 {
+		b.WriteString("TODO: list the outputs of the process and who receives them.\n")
+	}
+	for _, output := range process.Outputs {
+		line := "- Deliver " + output.Name
+		if output.Destination != "" {
+			line += " to " + output.Destination
+		}
+		fmt.Fprintf(&b, "%s\n", line)
+	}
+
+	b.WriteString("\n4.4 Monitoring and control\n\n")
+	if len(process.Criteria) == 0 {
+		b.WriteString("TODO: define criteria and methods for monitoring the process.\n")
+	}
+	for _, criteria := range process.Criteria {
+		line := "- " + criteria.Name
+		if criteria.Description != "" {
+			line += ": " + criteria.Description
+		}
+		if criteria.Metric != "" || criteria.Target != "" {
+			line += fmt.Sprintf(" (metric: %s, target: %s)", criteria.Metric, criteria.Target)
+		}
+		fmt.Fprintf(&b, "%s\n", line)
+	}
+
+	if len(process.Risks) > 0 {
+		b.WriteString("\n4.5 Risks and controls\n\n")
+		for _, risk := range process.Risks {
+			fmt.Fprintf(&b, "- Risk: %s\n", risk.Description)
+			for _, action := range risk.Mitigation {
+				fmt.Fprintf(&b, "  Control: %s\n", action.Description)
+			}
+			if len(risk.Mitigation) == 0 {
+				b.WriteString("  Control: TODO\n")
+			}
+		}
+	}
+
+	b.WriteString("\n5. Records\n\n")
+	for _, criteria := range process.Criteria {
+		fmt.Fprintf(&b, "- Monitoring results for %s\n", criteria.Name)
+	}
+	for _, output := range process.Outputs {
+		if strings.EqualFold(output.Type, "record") {
+			fmt.Fprintf(&b, "- %s\n", output.Name)
+		}
+	}
+	b.WriteString("- TODO: list any further records and their retention periods\n")
+
+	b.WriteString("\n6. References\n\n")
+	b.WriteString("- ISO 9001:2015 clause 4.4 Quality management system and its processes\n")
+	b.WriteString("- ISO 9001:2015 clause 8.1 Operational planning and control\n")
+	if process.ID != "" {
+		fmt.Fprintf(&b, "- Process definition %s\n", process.ID)
+	}
+
+	return b.String()
+}
+
+// joinNames lists the names of items as "a", "a and b" or "a, b and c"
+func joinNames[T any](items []T, name func(T) string) string {
+	names := make([]string, len(items))
+	for i, item := range items {
+		names[i] = name(item)
+	}
+	if len(names) == 1 {
+		return names[0]
+	}
+	return strings.Join(names[:len(names)-1], ", ") + " and " + names[len(names)-1]
+}
@@ -0,0 +1,53 @@
+package iso9001
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDraftProcedure(t *testing.T) {
+	process := Process{
+		ID:               "SALES",
+		Name:             "Order Handling",
+		Description:      "Turns customer orders into confirmed deliveries.",
+		Inputs:           []ProcessInput{{Name: "Customer order", Source: "Customer"}},
+		Outputs:          []ProcessOutput{{Name: "Order confirmation", Destination: "Customer"}, {Name: "Order review record", Type: "record"}},
+		Responsibilities: []string{"Sales Manager", "Order desk"},
+		Criteria:         []ProcessCriteria{{Name: "On-time confirmation", Metric: "% confirmed within 24h", Target: "95%"}},
+		Risks:            []Risk{{Description: "Order details misread", Mitigation: []Action{{Description: "Four-eyes check of large orders"}}}},
+	}
+
+	doc := DraftProcedure(process)
+	if doc.ID != "PROC-SALES" || doc.Title != "Order Handling Procedure" || doc.Type != DocumentTypeProcedure {
+		t.Errorf("Unexpected document header %s %q %s", doc.ID, doc.Title, doc.Type)
+	}
+	if doc.Metadata.Owner != "Sales Manager" {
+		t.Errorf("Expected first responsibility as owner, got %q", doc.Metadata.Owner)
+	}
+
+	for _, want := range []string{
+		"1. Purpose", "2. Scope", "3. Responsibilities", "4. Procedure", "5. Records", "6. References",
+		"The process starts on receipt of Customer order.",
+		"It ends with the delivery of Order confirmation and Order review record.",
+		"- Receive Customer order from Customer",
+		"- On-time confirmation (metric: % confirmed within 24h, target: 95%)",
+		"  Control: Four-eyes check of large orders",
+		"- Order review record\n",
+	} {
+		if !strings.Contains(doc.Content, want) {
+			t.Errorf("Draft missing %q:\n%s", want, doc.Content)
+		}
+	}
+
+	dm := NewDocumentationManager()
+	stored, err := dm.AddProcedureDraft(Process{ID: "P2", Name: "Calibration"})
+	if err != nil {
+		t.Fatalf("Failed to store draft: %v", err)
+	}
+	if stored.Status != DocumentStatusDraft || !strings.Contains(stored.Content, "TODO: assign responsibilities") {
+		t.Errorf("Expected stored draft with to-dos, got status %s:\n%s", stored.Status, stored.Content)
+	}
+	if _, err := dm.AddProcedureDraft(Process{ID: "P2", Name: "Calibration"}); err == nil {
+		t.Error("Expected duplicate draft to be rejected")
+	}
+}
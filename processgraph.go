@@ -0,0 +1,258 @@
+package iso9001
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ProcessEdge records that one process produces something another consumes
+type ProcessEdge struct {
+	From string `json:"from" yaml:"from"` // producing process
+	To   string `json:"to" yaml:"to"`     // consuming process
+	Item string `json:"item,omitempty" yaml:"item,omitempty"`
+}
+
+// ProcessGraph models the interaction of an organization's processes (clause 4.4.1 b)
+// as producer/consumer relationships
+type ProcessGraph struct {
+	Processes []string      `json:"processes" yaml:"processes"` // process IDs in definition order
+	Edges     []ProcessEdge `json:"edges" yaml:"edges"`
+
+	names     map[string]string
+	consumers map[string][]string
+	producers map[string][]string
+}
+
+// BuildProcessGraph derives the process graph of an organization. A process consumes
+// from another when one of its inputs names that process, by ID or name, as its
+// source, when the other process names it as the destination of an output, or when an
+// input has the same name as another process's output. Other sources and destinations,
+// such as customers or suppliers, are left out.
+func BuildProcessGraph(org *Organization) *ProcessGraph {
+	g := &ProcessGraph{
+		names:     make(map[string]string),
+		consumers: make(map[string][]string),
+		producers: make(map[string][]string),
+	}
+	if org == nil || org.QMS == nil {
+		return g
+	}
+	processes := org.QMS.Processes
+
+	byReference := make(map[string]string)
+	producersOf := make(map[string][]string) // output name -> producing process IDs
+	for _, process := range processes {
+		g.Processes = append(g.Processes, process.ID)
+		g.names[process.ID] = process.Name
+		byReference[strings.ToLower(process.ID)] = process.ID
+		if process.Name != "" {
+			byReference[strings.ToLower(process.Name)] = process.ID
+		}
+		for _, output := range process.Outputs {
+			key := strings.ToLower(strings.TrimSpace(output.Name))
+			if key != "" {
+				producersOf[key] = append(producersOf[key], process.ID)
+			}
+		}
+	}
+	resolve := func(reference string) (string, bool) {
+		id, ok := byReference[strings.ToLower(strings.TrimSpace(reference))]
+		return id, ok
+	}
+
+	seen := make(map[[2]string]bool)
+	link := func(from, to, item string) {
+		if seen[[2]string{from, to}] {
+			return
+		}
+		seen[[2]string{from, to}] = true
+		g.Edges = append(g.Edges, ProcessEdge{From: from, To: to, Item: item})
+		g.consumers[from] = append(g.consumers[from], to)
+		g.producers[to] = append(g.producers[to], from)
+	}
+
+	for _, process := range processes {
+		for _, input := range process.Inputs {
+			if from, ok := resolve(input.Source); ok {
+				link(from, process.ID, input.Name)
+				continue
+			}
+			for _, from := range producersOf[strings.ToLower(strings.TrimSpace(input.Name))] {
+				if from != process.ID {
+					link(from, process.ID, input.Name)
+				}
+			}
+		}
+		for _, output := range process.Outputs {
+			if to, ok := resolve(output.Destination); ok {
+				link(process.ID, to, output.Name)
+			}
+		}
+	}
+	return g
+}
+
+// Consumers returns the processes directly consuming the outputs of a process
+func (g *ProcessGraph) Consumers(processID string) []string {
+	return append([]string(nil), g.consumers[processID]...)
+}
+
+// Producers returns the processes a process directly depends on for its inputs
+func (g *ProcessGraph) Producers(processID string) []string {
+	return append([]string(nil), g.producers[processID]...)
+}
+
+// Dependents returns every process affected, directly or through others, when a
+// process fails, ordered by process ID
+func (g *ProcessGraph) Dependents(processID string) []string {
+	visited := map[string]bool{processID: true}
+	queue := []string{processID}
+	var dependents []string
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, next := range g.consumers[current] {
+			if !visited[next] {
+				visited[next] = true
+				dependents = append(dependents, next)
+				queue = append(queue, next)
+			}
+		}
+	}
+	sort.Strings(dependents)
+	return dependents
+}
+
+// Cycles returns the groups of processes that depend on each other in a loop, each
+// ordered by process ID. Loops are not necessarily wrong, e.g. improvement feeding back
+// into operations, but make failures self-reinforcing.
+func (g *ProcessGraph) Cycles() [][]string {
+	// Tarjan's strongly connected components
+	index := make(map[string]int)
+	low := make(map[string]int)
+	onStack := make(map[string]bool)
+	var stack []string
+	var cycles [][]string
+	next := 0
+
+	var visit func(id string)
+	visit = func(id string) {
+		index[id], low[id] = next, next
+		next++
+		stack = append(stack, id)
+		onStack[id] = true
+		for _, to := range g.consumers[id] {
+			if _, seen := index[to]; !seen {
+				visit(to)
+				low[id] = min(low[id], low[to])
+			} else if onStack[to] {
+				low[id] = min(low[id], index[to])
+			}
+		}
+		if low[id] != index[id] {
+			return
+		}
+		var component []string
+		for {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[top] = false
+			component = append(component, top)
+			if top == id {
+				break
+			}
+		}
+		if len(component) > 1 {
+			sort.Strings(component)
+			cycles = append(cycles, component)
+		}
+	}
+	for _, id := range g.Processes {
+		if _, seen := index[id]; !seen {
+			visit(id)
+		}
+	}
+	sort.Slice(cycles, func(i, j int) bool { return cycles[i][0] < cycles[j][0] })
+	return cycles
+}
+
+// ProcessCriticality ranks a process by how many others depend on it
+type ProcessCriticality struct {
+	ProcessID        string   `json:"process_id" yaml:"process_id"`
+	Name             string   `json:"name" yaml:"name"`
+	DirectDependents int      `json:"direct_dependents" yaml:"direct_dependents"`
+	Cascade          int      `json:"cascade" yaml:"cascade"` // processes affected, directly or indirectly, by a failure
+	Affected         []string `json:"affected,omitempty" yaml:"affected,omitempty"`
+}
+
+// RankCriticality returns every process ordered from the widest failure cascade to the
+// narrowest, then by direct dependents and ID
+func (g *ProcessGraph) RankCriticality() []ProcessCriticality {
+	ranking := make([]ProcessCriticality, 0, len(g.Processes))
+	for _, id := range g.Processes {
+		affected := g.Dependents(id)
+		ranking = append(ranking, ProcessCriticality{
+			ProcessID:        id,
+			Name:             g.names[id],
+			DirectDependents: len(g.consumers[id]),
+			Cascade:          len(affected),
+			Affected:         affected,
+		})
+	}
+	sort.SliceStable(ranking, func(i, j int) bool {
+		a, b := ranking[i], ranking[j]
+		if a.Cascade != b.Cascade {
+			return a.Cascade > b.Cascade
+		}
+		if a.DirectDependents != b.DirectDependents {
+			return a.DirectDependents > b.DirectDependents
+		}
+		return a.ProcessID < b.ProcessID
+	})
+	return ranking
+}
+
+// CascadeRisks suggests a risk for each process whose failure affects at least
+// minAffected other processes, for assessment in the risk register. The impact grows
+// with the share of processes affected; likelihood is left to be assessed.
+func (g *ProcessGraph) CascadeRisks(minAffected int) []Risk {
+	if minAffected < 1 {
+		minAffected = 1
+	}
+	var risks []Risk
+	for _, rank := range g.RankCriticality() {
+		if rank.Cascade < minAffected {
+			break
+		}
+		name := rank.Name
+		if name == "" {
+			name = rank.ProcessID
+		}
+		var effects []string
+		for _, id := range rank.Affected {
+			affected := g.names[id]
+			if affected == "" {
+				affected = id
+			}
+			effects = append(effects, "Disruption of "+affected)
+		}
+
+		impact := RiskLevelMedium
+		switch share := float64(rank.Cascade) / float64(len(g.Processes)); {
+		case share >= 0.5:
+			impact = RiskLevelVeryHigh
+		case share >= 0.25:
+			impact = RiskLevelHigh
+		}
+		risks = append(risks, Risk{
+			ID:          "RISK-CASCADE-" + rank.ProcessID,
+			Description: fmt.Sprintf("Failure of %s disrupts %d dependent processes", name, rank.Cascade),
+			Causes:      []string{"Failure or interruption of " + name},
+			Effects:     effects,
+			Impact:      impact,
+			Status:      RiskStatusIdentified,
+		})
+	}
+	return risks
+}
@@ -0,0 +1,41 @@
+package iso9001
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestProcessGraph(t *testing.T) {
+	org := &Organization{QMS: &QualityManagementSystem{Processes: []Process{
+		{ID: "P1", Name: "Sales", Inputs: []ProcessInput{{Name: "Customer enquiry", Source: "Customer"}}, Outputs: []ProcessOutput{{Name: "Sales order"}}},
+		{ID: "P2", Name: "Planning", Inputs: []ProcessInput{{Name: "Sales order"}}, Outputs: []ProcessOutput{{Name: "Work order", Destination: "Production"}}},
+		{ID: "P3", Name: "Production", Outputs: []ProcessOutput{{Name: "Product"}, {Name: "Nonconformity data"}}},
+		{ID: "P4", Name: "Delivery", Inputs: []ProcessInput{{Name: "Product", Source: "p3"}}},
+		{ID: "P5", Name: "Improvement", Inputs: []ProcessInput{{Name: "Nonconformity data"}}, Outputs: []ProcessOutput{{Name: "Process change", Destination: "P2"}}},
+		{ID: "P6", Name: "Calibration"},
+	}}}
+
+	g := BuildProcessGraph(org)
+	if len(g.Edges) != 5 {
+		t.Errorf("Expected five edges, got %+v", g.Edges)
+	}
+	if got := g.Producers("P2"); !reflect.DeepEqual(got, []string{"P1", "P5"}) {
+		t.Errorf("Producers(P2) = %v", got)
+	}
+	if got := g.Dependents("P2"); !reflect.DeepEqual(got, []string{"P3", "P4", "P5"}) {
+		t.Errorf("Dependents(P2) = %v", got)
+	}
+	if got := g.Cycles(); !reflect.DeepEqual(got, [][]string{{"P2", "P3", "P5"}}) {
+		t.Errorf("Cycles() = %v", got)
+	}
+
+	ranking := g.RankCriticality()
+	if ranking[0].ProcessID != "P1" || ranking[0].Cascade != 4 || ranking[len(ranking)-1].ProcessID != "P6" {
+		t.Errorf("Unexpected ranking %+v", ranking)
+	}
+
+	risks := g.CascadeRisks(4)
+	if len(risks) != 1 || risks[0].ID != "RISK-CASCADE-P1" || risks[0].Impact != RiskLevelVeryHigh || len(risks[0].Effects) != 4 {
+		t.Errorf("Unexpected cascade risks %+v", risks)
+	}
+}
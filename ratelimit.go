@@ -0,0 +1,148 @@
+package iso9001
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// ErrThrottled is wrapped by ThrottleError when a client exceeds its rate or quota
+var ErrThrottled = errors.New("request throttled")
+
+// RateLimit configures how often a client may call a shared instance. Rate is the
+// sustained number of requests per second and Burst the number that may arrive at
+// once; DailyQuota caps the operations per calendar day. Zero values disable the
+// respective limit.
+type RateLimit struct {
+	Rate       float64 `json:"rate,omitempty" yaml:"rate,omitempty"`
+	Burst      int     `json:"burst,omitempty" yaml:"burst,omitempty"`
+	DailyQuota int     `json:"daily_quota,omitempty" yaml:"daily_quota,omitempty"`
+}
+
+// ThrottleError explains why a request was refused and when to try again
+type ThrottleError struct {
+	Client     string
+	Quota      bool // true when the daily quota, not the rate, was exceeded
+	Limit      string
+	RetryAfter time.Duration
+}
+
+func (e *ThrottleError) Error() string {
+	if e.Quota {
+		return fmt.Sprintf("%v: %s has used its daily quota of %s operations; quota resets in %s",
+			ErrThrottled, e.Client, e.Limit, e.RetryAfter.Round(time.Second))
+	}
+	return fmt.Sprintf("%v: %s exceeded %s requests per second; retry in %s",
+		ErrThrottled, e.Client, e.Limit, e.RetryAfter.Round(time.Millisecond))
+}
+
+func (e *ThrottleError) Unwrap() error { return ErrThrottled }
+
+// QuotaUsage reports a client's daily quota after a request was allowed
+type QuotaUsage struct {
+	Used   int       `json:"used" yaml:"used"`
+	Limit  int       `json:"limit" yaml:"limit"` // zero when the client has no quota
+	Resets time.Time `json:"resets" yaml:"resets"`
+}
+
+// RateLimiter applies rate limits and daily quotas per client, such as an API key ID
+// or identity. Each client gets a token bucket refilled at the configured rate.
+type RateLimiter struct {
+	// Location decides when a day, and so a daily quota, begins; UTC when nil
+	Location *time.Location
+
+	mu        sync.Mutex
+	limit     RateLimit
+	overrides map[string]RateLimit
+	clients   map[string]*clientUsage
+	now       func() time.Time
+}
+
+type clientUsage struct {
+	tokens float64
+	last   time.Time
+	day    time.Time
+	used   int
+}
+
+// NewRateLimiter creates a limiter applying limit to every client without an override
+func NewRateLimiter(limit RateLimit) *RateLimiter {
+	return &RateLimiter{
+		limit:     limit,
+		overrides: make(map[string]RateLimit),
+		clients:   make(map[string]*clientUsage),
+		now:       time.Now,
+	}
+}
+
+// SetClientLimit overrides the default limit for one client
+func (l *RateLimiter) SetClientLimit(client string, limit RateLimit) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.overrides[client] = limit
+}
+
+// ClearClientLimit makes a client use the default limit again
+func (l *RateLimiter) ClearClientLimit(client string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.overrides, client)
+}
+
+// Allow counts a request by the client. It returns a *ThrottleError when the client
+// has exceeded its rate or daily quota; throttled requests do not use up the quota.
+func (l *RateLimiter) Allow(client string) (QuotaUsage, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	limit, ok := l.overrides[client]
+	if !ok {
+		limit = l.limit
+	}
+	now := l.now()
+	location := l.Location
+	if location == nil {
+		location = time.UTC
+	}
+	local := now.In(location)
+	day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, location)
+
+	usage, ok := l.clients[client]
+	if !ok {
+		usage = &clientUsage{tokens: float64(burstOf(limit)), last: now, day: day}
+		l.clients[client] = usage
+	}
+	if !usage.day.Equal(day) {
+		usage.day = day
+		usage.used = 0
+	}
+	resets := day.AddDate(0, 0, 1)
+
+	if limit.DailyQuota > 0 && usage.used >= limit.DailyQuota {
+		return QuotaUsage{}, &ThrottleError{Client: client, Quota: true, Limit: fmt.Sprint(limit.DailyQuota), RetryAfter: resets.Sub(now)}
+	}
+
+	if limit.Rate > 0 {
+		burst := float64(burstOf(limit))
+		usage.tokens = math.Min(burst, usage.tokens+now.Sub(usage.last).Seconds()*limit.Rate)
+		usage.last = now
+		if usage.tokens < 1 {
+			wait := time.Duration((1 - usage.tokens) / limit.Rate * float64(time.Second))
+			return QuotaUsage{}, &ThrottleError{Client: client, Limit: fmt.Sprint(limit.Rate), RetryAfter: wait}
+		}
+		usage.tokens--
+	}
+
+	usage.used++
+	return QuotaUsage{Used: usage.used, Limit: limit.DailyQuota, Resets: resets}, nil
+}
+
+// burstOf returns the bucket size, at least one request
+func burstOf(limit RateLimit) int {
+	if limit.Burst > 0 {
+		return limit.Burst
+	}
+	return int(math.Max(1, math.Ceil(limit.Rate)))
+}
@@ -0,0 +1,55 @@
+package iso9001
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter(t *testing.T) {
+	now := time.Date(2024, 6, 3, 23, 59, 0, 0, time.UTC)
+	limiter := NewRateLimiter(RateLimit{Rate: 1, Burst: 2, DailyQuota: 3})
+	limiter.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		if _, err := limiter.Allow("agent"); err != nil {
+			t.Fatalf("Request %d within burst refused: %v", i+1, err)
+		}
+	}
+	_, err := limiter.Allow("agent")
+	var throttled *ThrottleError
+	if !errors.As(err, &throttled) || throttled.Quota || throttled.RetryAfter != time.Second {
+		t.Fatalf("Expected rate throttling with 1s retry, got %v", err)
+	}
+	if !errors.Is(err, ErrThrottled) {
+		t.Error("ThrottleError should wrap ErrThrottled")
+	}
+
+	// Other clients have their own buckets
+	if _, err := limiter.Allow("dashboard"); err != nil {
+		t.Errorf("Independent client refused: %v", err)
+	}
+
+	now = now.Add(2 * time.Second)
+	usage, err := limiter.Allow("agent")
+	if err != nil || usage.Used != 3 || usage.Limit != 3 {
+		t.Fatalf("Expected third operation allowed, got %+v, %v", usage, err)
+	}
+	now = now.Add(2 * time.Second)
+	if _, err := limiter.Allow("agent"); !errors.As(err, &throttled) || !throttled.Quota {
+		t.Fatalf("Expected daily quota exceeded, got %v", err)
+	}
+
+	// The quota resets at midnight
+	now = now.Add(time.Minute)
+	if usage, err := limiter.Allow("agent"); err != nil || usage.Used != 1 {
+		t.Errorf("Expected quota reset on new day, got %+v, %v", usage, err)
+	}
+
+	limiter.SetClientLimit("batch", RateLimit{})
+	for i := 0; i < 10; i++ {
+		if _, err := limiter.Allow("batch"); err != nil {
+			t.Fatalf("Unlimited client throttled: %v", err)
+		}
+	}
+}
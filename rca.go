@@ -0,0 +1,199 @@
+package iso9001
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// RCAMethod is the technique used to find the root cause of a nonconformity (clause
+// 10.2.1 b)
+type RCAMethod string
+
+const (
+	// RCAMethodFiveWhys asks why the problem happened, then why that cause happened,
+	// until a cause is reached that can be acted on; each answer is a child of the last
+	RCAMethodFiveWhys RCAMethod = "five_whys"
+	// RCAMethodFishbone sorts the possible causes into the categories of an Ishikawa
+	// diagram and breaks each one down into its own causes
+	RCAMethodFishbone RCAMethod = "fishbone"
+)
+
+// FishboneCategory is a bone of an Ishikawa diagram
+type FishboneCategory string
+
+const (
+	FishboneMan         FishboneCategory = "man" // people, skills and training
+	FishboneMachine     FishboneCategory = "machine"
+	FishboneMethod      FishboneCategory = "method"
+	FishboneMaterial    FishboneCategory = "material"
+	FishboneMeasurement FishboneCategory = "measurement"
+	FishboneEnvironment FishboneCategory = "environment"
+)
+
+// FishboneCategories are the six categories of the 6M diagram
+var FishboneCategories = []FishboneCategory{
+	FishboneMan, FishboneMachine, FishboneMethod, FishboneMaterial, FishboneMeasurement, FishboneEnvironment,
+}
+
+// RootCauseAnalysis records how the root cause of a nonconformity was found, as a tree
+// of causes leading back from the problem
+type RootCauseAnalysis struct {
+	Method      RCAMethod   `json:"method" yaml:"method"`
+	Problem     string      `json:"problem" yaml:"problem"`
+	Causes      []CauseNode `json:"causes" yaml:"causes"`
+	PerformedBy string      `json:"performed_by,omitempty" yaml:"performed_by,omitempty"`
+	Date        time.Time   `json:"date" yaml:"date"`
+}
+
+// CauseNode is a cause in the tree of a root cause analysis. Its Causes are the
+// answers to why it happened. In a fishbone analysis, the causes directly under the
+// problem carry the category of their bone.
+type CauseNode struct {
+	Cause     string           `json:"cause" yaml:"cause"`
+	Category  FishboneCategory `json:"category,omitempty" yaml:"category,omitempty"`
+	Evidence  string           `json:"evidence,omitempty" yaml:"evidence,omitempty"`
+	RootCause bool             `json:"root_cause,omitempty" yaml:"root_cause,omitempty"` // confirmed as a root cause
+	Causes    []CauseNode      `json:"causes,omitempty" yaml:"causes,omitempty"`
+}
+
+// Validate checks that the analysis states the problem and at least one cause, that
+// every cause is described and that fishbone causes are categorized
+func (rca *RootCauseAnalysis) Validate() error {
+	if rca.Method != RCAMethodFiveWhys && rca.Method != RCAMethodFishbone {
+		return fmt.Errorf("unknown root cause analysis method %q (use %s or %s)", rca.Method, RCAMethodFiveWhys, RCAMethodFishbone)
+	}
+	if rca.Problem == "" {
+		return fmt.Errorf("root cause analysis must state the problem")
+	}
+	if len(rca.Causes) == 0 {
+		return fmt.Errorf("root cause analysis must have at least one cause")
+	}
+	for _, node := range rca.Causes {
+		if rca.Method == RCAMethodFishbone && !isFishboneCategory(node.Category) {
+			return fmt.Errorf("cause %q has unknown fishbone category %q", node.Cause, node.Category)
+		}
+		if err := node.validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (node CauseNode) validate() error {
+	if strings.TrimSpace(node.Cause) == "" {
+		return fmt.Errorf("every cause of a root cause analysis must be described")
+	}
+	for _, child := range node.Causes {
+		if err := child.validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RootCauses returns the causes confirmed as root causes, in the order of the tree.
+// When none is confirmed, the deepest causes of the tree are taken as the root
+// causes: the last answer of each chain of whys.
+func (rca *RootCauseAnalysis) RootCauses() []string {
+	var confirmed, leaves []string
+	var walk func(nodes []CauseNode)
+	walk = func(nodes []CauseNode) {
+		for _, node := range nodes {
+			if node.RootCause {
+				confirmed = append(confirmed, node.Cause)
+			}
+			if len(node.Causes) == 0 {
+				leaves = append(leaves, node.Cause)
+			}
+			walk(node.Causes)
+		}
+	}
+	walk(rca.Causes)
+
+	if len(confirmed) > 0 {
+		return confirmed
+	}
+	return leaves
+}
+
+// Depth returns the number of levels of causes, i.e. the number of whys asked
+func (rca *RootCauseAnalysis) Depth() int {
+	var depth func(nodes []CauseNode) int
+	depth = func(nodes []CauseNode) int {
+		deepest := 0
+		for _, node := range nodes {
+			if d := 1 + depth(node.Causes); d > deepest {
+				deepest = d
+			}
+		}
+		return deepest
+	}
+	return depth(rca.Causes)
+}
+
+// Summary states the root causes in one line, for the RootCause text of a finding or
+// nonconformance
+func (rca *RootCauseAnalysis) Summary() string {
+	return strings.Join(rca.RootCauses(), "; ")
+}
+
+// RecordRootCauseAnalysis stores the root cause analysis of a finding. The finding's
+// root cause text is replaced by the summary of the analysis, and the date defaults to
+// now.
+func (am *AuditManager) RecordRootCauseAnalysis(auditID, findingID string, rca RootCauseAnalysis) error {
+	audit, finding, err := am.finding(auditID, findingID)
+	if err != nil {
+		return err
+	}
+	if err := prepareRootCauseAnalysis(&rca); err != nil {
+		return fmt.Errorf("finding %s: %w", findingID, err)
+	}
+
+	finding.RootCauseAnalysis = &rca
+	finding.RootCause = rca.Summary()
+	audit.Modified = time.Now()
+	return nil
+}
+
+// RecordNonconformanceRootCause stores the root cause analysis of a nonconformance
+// reported to the tenant, replacing its root cause text with the summary of the
+// analysis. An open nonconformance moves to investigating.
+func (t *Tenant) RecordNonconformanceRootCause(nonconformanceID string, rca RootCauseAnalysis) (NonconformanceReport, error) {
+	for i := range t.Nonconformities {
+		report := &t.Nonconformities[i]
+		if report.ID != nonconformanceID {
+			continue
+		}
+		if err := prepareRootCauseAnalysis(&rca); err != nil {
+			return *report, fmt.Errorf("nonconformance %s: %w", nonconformanceID, err)
+		}
+		report.RootCauseAnalysis = &rca
+		report.RootCause = rca.Summary()
+		if report.Status == NonconformanceStatusOpen {
+			report.Status = NonconformanceStatusInvestigating
+		}
+		return *report, nil
+	}
+	return NonconformanceReport{}, fmt.Errorf("nonconformance with ID %s not found", nonconformanceID)
+}
+
+// prepareRootCauseAnalysis validates an analysis and fills in its date
+func prepareRootCauseAnalysis(rca *RootCauseAnalysis) error {
+	if err := rca.Validate(); err != nil {
+		return err
+	}
+	if rca.Date.IsZero() {
+		rca.Date = time.Now()
+	}
+	return nil
+}
+
+func isFishboneCategory(category FishboneCategory) bool {
+	for _, known := range FishboneCategories {
+		if category == known {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,114 @@
+package iso9001
+
+import (
+	"reflect"
+	"testing"
+)
+
+func fiveWhys() RootCauseAnalysis {
+	return RootCauseAnalysis{
+		Method:  RCAMethodFiveWhys,
+		Problem: "Wrong parts shipped to customer",
+		Causes: []CauseNode{{
+			Cause: "Picker took parts from the wrong bin",
+			Causes: []CauseNode{{
+				Cause: "Bin labels were swapped",
+				Causes: []CauseNode{{
+					Cause:    "Labels are not checked after a stock move",
+					Evidence: "Stock move procedure SOP-12 has no label check",
+				}},
+			}},
+		}},
+	}
+}
+
+func TestRootCauseAnalysisFiveWhys(t *testing.T) {
+	rca := fiveWhys()
+	if err := rca.Validate(); err != nil {
+		t.Fatalf("Expected valid analysis, got %v", err)
+	}
+	if rca.Depth() != 3 {
+		t.Errorf("Expected depth 3, got %d", rca.Depth())
+	}
+	if got := rca.RootCauses(); !reflect.DeepEqual(got, []string{"Labels are not checked after a stock move"}) {
+		t.Errorf("Expected the last why as root cause, got %+v", got)
+	}
+
+	rca.Causes[0].Causes[0].RootCause = true
+	if got := rca.Summary(); got != "Bin labels were swapped" {
+		t.Errorf("Expected confirmed root cause, got %q", got)
+	}
+}
+
+func TestRootCauseAnalysisFishbone(t *testing.T) {
+	rca := RootCauseAnalysis{
+		Method:  RCAMethodFishbone,
+		Problem: "Scratched housings",
+		Causes: []CauseNode{
+			{Cause: "Operators not trained on the new fixture", Category: FishboneMan, RootCause: true},
+			{Cause: "Fixture worn", Category: FishboneMachine, Causes: []CauseNode{{Cause: "No preventive maintenance", RootCause: true}}},
+		},
+	}
+	if err := rca.Validate(); err != nil {
+		t.Fatalf("Expected valid analysis, got %v", err)
+	}
+	if got := rca.Summary(); got != "Operators not trained on the new fixture; No preventive maintenance" {
+		t.Errorf("Unexpected summary %q", got)
+	}
+
+	rca.Causes[1].Category = "money"
+	if err := rca.Validate(); err == nil {
+		t.Error("Expected error for unknown fishbone category")
+	}
+	rca.Causes[1].Category = ""
+	if err := rca.Validate(); err == nil {
+		t.Error("Expected error for uncategorized fishbone cause")
+	}
+
+	for _, invalid := range []RootCauseAnalysis{
+		{Method: "pareto", Problem: "P", Causes: []CauseNode{{Cause: "C"}}},
+		{Method: RCAMethodFiveWhys, Causes: []CauseNode{{Cause: "C"}}},
+		{Method: RCAMethodFiveWhys, Problem: "P"},
+		{Method: RCAMethodFiveWhys, Problem: "P", Causes: []CauseNode{{Cause: "C", Causes: []CauseNode{{Cause: " "}}}}},
+	} {
+		if err := invalid.Validate(); err == nil {
+			t.Errorf("Expected error for %+v", invalid)
+		}
+	}
+}
+
+func TestRecordRootCauseAnalysis(t *testing.T) {
+	am := NewAuditManager()
+	am.CreateAudit(&Audit{ID: "AUDIT-001", Title: "Audit", Scope: AuditScope{Description: "Scope"}})
+	am.AddFinding("AUDIT-001", AuditFinding{ID: "F-001", Severity: SeverityMajor, Status: FindingStatusOpen, RootCause: "Unknown"})
+
+	if err := am.RecordRootCauseAnalysis("AUDIT-001", "F-001", RootCauseAnalysis{Method: RCAMethodFiveWhys}); err == nil {
+		t.Error("Expected error for invalid analysis")
+	}
+	if err := am.RecordRootCauseAnalysis("AUDIT-001", "F-404", fiveWhys()); err == nil {
+		t.Error("Expected error for unknown finding")
+	}
+	if err := am.RecordRootCauseAnalysis("AUDIT-001", "F-001", fiveWhys()); err != nil {
+		t.Fatalf("Failed to record analysis: %v", err)
+	}
+	finding := am.Audits["AUDIT-001"].Findings[0]
+	if finding.RootCauseAnalysis == nil || finding.RootCauseAnalysis.Date.IsZero() {
+		t.Fatalf("Expected dated analysis on the finding, got %+v", finding.RootCauseAnalysis)
+	}
+	if finding.RootCause != "Labels are not checked after a stock move" {
+		t.Errorf("Expected root cause from the analysis, got %q", finding.RootCause)
+	}
+
+	tenant := NewTenant("ORG-001")
+	tenant.Nonconformities = []NonconformanceReport{{ID: "NC-0001", Description: "Wrong parts", Status: NonconformanceStatusOpen}}
+	report, err := tenant.RecordNonconformanceRootCause("NC-0001", fiveWhys())
+	if err != nil {
+		t.Fatalf("Failed to record analysis: %v", err)
+	}
+	if report.Status != NonconformanceStatusInvestigating || tenant.Nonconformities[0].RootCauseAnalysis == nil {
+		t.Errorf("Expected nonconformance under investigation with its analysis, got %+v", tenant.Nonconformities[0])
+	}
+	if _, err := tenant.RecordNonconformanceRootCause("NC-0404", fiveWhys()); err == nil {
+		t.Error("Expected error for unknown nonconformance")
+	}
+}
@@ -0,0 +1,211 @@
+package iso9001
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+)
+
+// ReportKind identifies a report that can be rendered from a template
+type ReportKind string
+
+const (
+	ReportCompliance       ReportKind = "compliance"
+	ReportAudit            ReportKind = "audit"
+	ReportManagementReview ReportKind = "management_review"
+)
+
+// ReportKinds lists the kinds of report with a built-in template
+var ReportKinds = []ReportKind{ReportCompliance, ReportAudit, ReportManagementReview}
+
+// defaultReportTemplates holds the built-in templates, one <kind>.tmpl file per kind
+//
+//go:embed templates/*.tmpl
+var defaultReportTemplates embed.FS
+
+// ComplianceReportData is the data passed to compliance report templates
+type ComplianceReportData struct {
+	Organization *Organization
+	Report       *ComplianceReport
+}
+
+// AuditReportData is the data passed to audit report templates
+type AuditReportData struct {
+	Organization *Organization
+	Audit        *Audit
+}
+
+// ManagementReviewData is the data passed to management review minutes templates
+type ManagementReviewData struct {
+	Organization *Organization
+	Review       *ManagementReview
+}
+
+// reportFuncs are available to all report templates in addition to the text/template
+// built-ins
+var reportFuncs = template.FuncMap{
+	"date":  formatReportDate,
+	"join":  strings.Join,
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+}
+
+// formatReportDate formats a time.Time or *time.Time as YYYY-MM-DD; zero and nil
+// times render as an empty string
+func formatReportDate(value interface{}) string {
+	var t time.Time
+	switch v := value.(type) {
+	case time.Time:
+		t = v
+	case *time.Time:
+		if v != nil {
+			t = *v
+		}
+	}
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
+
+// ReportTemplates renders reports from text/template templates. Each kind of report
+// has a default template, which can be replaced globally or for one organization so
+// output matches that organization's document formats.
+type ReportTemplates struct {
+	mu        sync.RWMutex
+	defaults  map[ReportKind]*template.Template
+	overrides map[string]map[ReportKind]*template.Template // by organization ID
+}
+
+// NewReportTemplates creates a template set with the built-in templates
+func NewReportTemplates() *ReportTemplates {
+	rt := &ReportTemplates{
+		defaults:  make(map[ReportKind]*template.Template),
+		overrides: make(map[string]map[ReportKind]*template.Template),
+	}
+	for _, kind := range ReportKinds {
+		text, err := defaultReportTemplates.ReadFile("templates/" + string(kind) + ".tmpl")
+		if err != nil {
+			panic(err)
+		}
+		rt.defaults[kind] = template.Must(parseReportTemplate(kind, string(text)))
+	}
+	return rt
+}
+
+func parseReportTemplate(kind ReportKind, text string) (*template.Template, error) {
+	tmpl, err := template.New(string(kind)).Funcs(reportFuncs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("%s report template: %w", kind, err)
+	}
+	return tmpl, nil
+}
+
+// SetDefault replaces the template used for a kind of report by organizations without
+// their own
+func (rt *ReportTemplates) SetDefault(kind ReportKind, text string) error {
+	tmpl, err := parseReportTemplate(kind, text)
+	if err != nil {
+		return err
+	}
+
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+	rt.defaults[kind] = tmpl
+	return nil
+}
+
+// SetOrganizationTemplate sets the template used for a kind of report for one
+// organization
+func (rt *ReportTemplates) SetOrganizationTemplate(orgID string, kind ReportKind, text string) error {
+	tmpl, err := parseReportTemplate(kind, text)
+	if err != nil {
+		return err
+	}
+
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+	if rt.overrides[orgID] == nil {
+		rt.overrides[orgID] = make(map[ReportKind]*template.Template)
+	}
+	rt.overrides[orgID][kind] = tmpl
+	return nil
+}
+
+// RemoveOrganizationTemplate reverts an organization to the default template for a
+// kind of report
+func (rt *ReportTemplates) RemoveOrganizationTemplate(orgID string, kind ReportKind) {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+	delete(rt.overrides[orgID], kind)
+	if len(rt.overrides[orgID]) == 0 {
+		delete(rt.overrides, orgID)
+	}
+}
+
+// LoadOrganizationTemplates sets an organization's templates from the <kind>.tmpl
+// files in dir, such as audit.tmpl. Kinds without a file keep their current template.
+func (rt *ReportTemplates) LoadOrganizationTemplates(orgID, dir string) error {
+	for _, kind := range ReportKinds {
+		text, err := os.ReadFile(filepath.Join(dir, string(kind)+".tmpl"))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if err := rt.SetOrganizationTemplate(orgID, kind, string(text)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// template returns the organization's template for a kind of report, or the default
+func (rt *ReportTemplates) template(orgID string, kind ReportKind) (*template.Template, error) {
+	rt.mu.RLock()
+	defer rt.mu.RUnlock()
+	if tmpl, ok := rt.overrides[orgID][kind]; ok {
+		return tmpl, nil
+	}
+	if tmpl, ok := rt.defaults[kind]; ok {
+		return tmpl, nil
+	}
+	return nil, fmt.Errorf("no template for %s reports", kind)
+}
+
+// Render writes a report for an organization using its template for the kind
+func (rt *ReportTemplates) Render(w io.Writer, orgID string, kind ReportKind, data interface{}) error {
+	tmpl, err := rt.template(orgID, kind)
+	if err != nil {
+		return err
+	}
+	if err := tmpl.Execute(w, data); err != nil {
+		return fmt.Errorf("%s report: %w", kind, err)
+	}
+	return nil
+}
+
+// RenderComplianceReport writes a compliance report for an organization
+func (rt *ReportTemplates) RenderComplianceReport(w io.Writer, org *Organization, report *ComplianceReport) error {
+	return rt.Render(w, org.ID, ReportCompliance, ComplianceReportData{Organization: org, Report: report})
+}
+
+// RenderAuditReport writes the report of an audit for an organization
+func (rt *ReportTemplates) RenderAuditReport(w io.Writer, org *Organization, audit *Audit) error {
+	return rt.Render(w, org.ID, ReportAudit, AuditReportData{Organization: org, Audit: audit})
+}
+
+// RenderManagementReviewMinutes writes the minutes of a management review for an
+// organization
+func (rt *ReportTemplates) RenderManagementReviewMinutes(w io.Writer, org *Organization, review *ManagementReview) error {
+	return rt.Render(w, org.ID, ReportManagementReview, ManagementReviewData{Organization: org, Review: review})
+}
@@ -0,0 +1,99 @@
+package iso9001
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReportTemplatesDefaults(t *testing.T) {
+	org := CreateExampleOrganization()
+	templates := NewReportTemplates()
+
+	var compliance strings.Builder
+	if err := templates.RenderComplianceReport(&compliance, org, GenerateComplianceReport(org)); err != nil {
+		t.Fatalf("Failed to render compliance report: %v", err)
+	}
+	if !strings.Contains(compliance.String(), "# Compliance Report: "+org.Name) {
+		t.Errorf("Compliance report missing heading:\n%s", compliance.String())
+	}
+
+	start := time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+	audit := &Audit{
+		ID:               "AUD-001",
+		Title:            "Production audit",
+		Type:             AuditTypeInternal,
+		PlannedStartDate: start,
+		PlannedEndDate:   start.AddDate(0, 0, 1),
+		ActualStartDate:  &start,
+		Findings:         []AuditFinding{{ID: "F-001", Clause: "8.5.1", Severity: SeverityMinor, Description: "Work instruction out of date"}},
+	}
+	var auditReport strings.Builder
+	if err := templates.RenderAuditReport(&auditReport, org, audit); err != nil {
+		t.Fatalf("Failed to render audit report: %v", err)
+	}
+	for _, want := range []string{"Conducted: 2024-03-04", "F-001 [8.5.1] MINOR: Work instruction out of date"} {
+		if !strings.Contains(auditReport.String(), want) {
+			t.Errorf("Audit report missing %q:\n%s", want, auditReport.String())
+		}
+	}
+
+	review := &ManagementReview{
+		Title:     "Q1 review",
+		Date:      start,
+		Attendees: []ReviewAttendee{{Name: "Jane Doe", Role: "CEO", Present: true}},
+		Outputs:   ManagementReviewOutputs{ActionItems: []ActionItem{{ID: "A-1", Description: "Hire auditor", DueDate: start.AddDate(0, 1, 0)}}},
+	}
+	var minutes strings.Builder
+	if err := templates.RenderManagementReviewMinutes(&minutes, org, review); err != nil {
+		t.Fatalf("Failed to render minutes: %v", err)
+	}
+	if !strings.Contains(minutes.String(), "Action A-1: Hire auditor, owner unassigned, due 2024-04-04") {
+		t.Errorf("Minutes missing action item:\n%s", minutes.String())
+	}
+}
+
+func TestReportTemplatesOrganizationOverride(t *testing.T) {
+	templates := NewReportTemplates()
+	acme := &Organization{ID: "ACME", Name: "Acme"}
+	other := &Organization{ID: "OTHER", Name: "Other"}
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "compliance.tmpl"), []byte("ACME-QR-01 {{.Organization.Name}} {{printf \"%.0f\" .Report.ComplianceScore}}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := templates.LoadOrganizationTemplates("ACME", dir); err != nil {
+		t.Fatalf("Failed to load templates: %v", err)
+	}
+
+	var out strings.Builder
+	if err := templates.RenderComplianceReport(&out, acme, &ComplianceReport{ComplianceScore: 75}); err != nil {
+		t.Fatalf("Failed to render: %v", err)
+	}
+	if out.String() != "ACME-QR-01 Acme 75" {
+		t.Errorf("Expected organization template, got %q", out.String())
+	}
+
+	out.Reset()
+	if err := templates.RenderComplianceReport(&out, other, &ComplianceReport{}); err != nil {
+		t.Fatalf("Failed to render: %v", err)
+	}
+	if !strings.HasPrefix(out.String(), "# Compliance Report: Other") {
+		t.Errorf("Expected default template for other organization, got %q", out.String())
+	}
+
+	templates.RemoveOrganizationTemplate("ACME", ReportCompliance)
+	out.Reset()
+	if err := templates.RenderComplianceReport(&out, acme, &ComplianceReport{}); err != nil {
+		t.Fatalf("Failed to render: %v", err)
+	}
+	if !strings.HasPrefix(out.String(), "# Compliance Report: Acme") {
+		t.Errorf("Expected default template after removal, got %q", out.String())
+	}
+
+	if err := templates.SetOrganizationTemplate("ACME", ReportAudit, "{{.Audit.Title"); err == nil {
+		t.Error("Expected parse error for malformed template")
+	}
+}
@@ -0,0 +1,564 @@
+package iso9001
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrRestructureConflict is returned when organizations being merged hold entities with
+// the same ID and the conflict resolution does not allow it
+var ErrRestructureConflict = errors.New("restructuring conflict")
+
+// RestructuringKind distinguishes mergers from splits
+type RestructuringKind string
+
+const (
+	RestructuringMerge RestructuringKind = "merge"
+	RestructuringSplit RestructuringKind = "split"
+)
+
+// ConflictResolution decides what happens when organizations being merged use the
+// same ID for different entities
+type ConflictResolution string
+
+const (
+	// ConflictFail aborts the merger on the first clashing ID
+	ConflictFail ConflictResolution = "fail"
+	// ConflictKeepFirst keeps the entity of the organization listed first and drops
+	// the clashing entities of later organizations
+	ConflictKeepFirst ConflictResolution = "keep_first"
+	// ConflictRename keeps every entity, prefixing clashing IDs of later organizations
+	// with their organization ID, e.g. "ACME-RISK-001"
+	ConflictRename ConflictResolution = "rename"
+)
+
+// RestructuringEntry traces one entity through a merger or split. TargetID is empty
+// when the entity was dropped.
+type RestructuringEntry struct {
+	EntityType         string `json:"entity_type" yaml:"entity_type"`
+	SourceOrganization string `json:"source_organization" yaml:"source_organization"`
+	SourceID           string `json:"source_id" yaml:"source_id"`
+	TargetOrganization string `json:"target_organization" yaml:"target_organization"`
+	TargetID           string `json:"target_id,omitempty" yaml:"target_id,omitempty"`
+	Resolution         string `json:"resolution,omitempty" yaml:"resolution,omitempty"` // "renamed" or "dropped" after a conflict
+}
+
+// RestructuringRecord documents a merger or split of organizations so every process,
+// risk, document and objective can be traced back to where it came from
+type RestructuringRecord struct {
+	Kind    RestructuringKind    `json:"kind" yaml:"kind"`
+	Date    time.Time            `json:"date" yaml:"date"`
+	Reason  string               `json:"reason,omitempty" yaml:"reason,omitempty"`
+	Sources []string             `json:"sources" yaml:"sources"`
+	Targets []string             `json:"targets" yaml:"targets"`
+	Entries []RestructuringEntry `json:"entries" yaml:"entries"`
+}
+
+// Lookup returns the entry tracing an entity of a source organization
+func (r *RestructuringRecord) Lookup(sourceOrganization, sourceID string) (RestructuringEntry, bool) {
+	for _, entry := range r.Entries {
+		if entry.SourceOrganization == sourceOrganization && entry.SourceID == sourceID {
+			return entry, true
+		}
+	}
+	return RestructuringEntry{}, false
+}
+
+// MergeOptions describes the organization resulting from a merger
+type MergeOptions struct {
+	ID        string
+	Name      string
+	Conflicts ConflictResolution // defaults to ConflictFail
+	Reason    string
+	Date      time.Time // defaults to now
+}
+
+// MergeOrganizations combines the processes, risks, opportunities, documents and
+// objectives of several tenants into a new tenant, e.g. after an acquisition. The
+// first tenant is the acquirer: its context, leadership and time zone are carried
+// over, and the scopes of all tenants are combined. Clashing IDs are handled as
+// options.Conflicts says, and references between entities follow renamed IDs.
+// Audits, management reviews and measurements remain records of the source
+// organizations and are not merged.
+//
+// The source tenants are not modified. The returned record is also appended to the
+// merged tenant's restructuring history, after the histories of the sources.
+func MergeOrganizations(options MergeOptions, tenants ...*Tenant) (*Tenant, *RestructuringRecord, error) {
+	if options.ID == "" {
+		return nil, nil, fmt.Errorf("merged organization must have an ID")
+	}
+	if len(tenants) < 2 {
+		return nil, nil, fmt.Errorf("merging requires at least two organizations")
+	}
+	if options.Conflicts == "" {
+		options.Conflicts = ConflictFail
+	}
+	if options.Date.IsZero() {
+		options.Date = time.Now()
+	}
+
+	record := &RestructuringRecord{Kind: RestructuringMerge, Date: options.Date, Reason: options.Reason, Targets: []string{options.ID}}
+	m := &merger{target: options.ID, conflicts: options.Conflicts, owners: make(map[string]string), record: record}
+
+	merged := NewTenant(options.ID)
+	org := merged.Organization
+	org.Name = options.Name
+	org.QMS = &QualityManagementSystem{Created: options.Date}
+
+	for i, source := range tenants {
+		if source == nil || source.Organization == nil {
+			return nil, nil, fmt.Errorf("organization %d is empty", i+1)
+		}
+		clone, err := source.copy()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to copy organization %s: %w", source.ID, err)
+		}
+		record.Sources = append(record.Sources, source.ID)
+		merged.Restructurings = append(merged.Restructurings, clone.Restructurings...)
+
+		if i == 0 {
+			org.Context = clone.Organization.Context
+			org.Leadership = clone.Organization.Leadership
+			org.TimeZone = clone.Organization.TimeZone
+			org.Calendar = clone.Organization.Calendar
+			if clone.Organization.QMS != nil {
+				org.QMS.ID = clone.Organization.QMS.ID
+			}
+		}
+		if err := m.add(merged, clone); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	merged.Risks.RebuildRegister()
+	merged.shareIDRegistry()
+	merged.Restructurings = append(merged.Restructurings, *record)
+	return merged, record, nil
+}
+
+// merger assigns the IDs of merged entities and records how each was placed
+type merger struct {
+	target    string
+	conflicts ConflictResolution
+	owners    map[string]string // target ID -> source organization holding it
+	record    *RestructuringRecord
+}
+
+// place decides the ID an entity of a source organization gets in the merged
+// organization; an empty ID means it was dropped. ids remembers the decisions for the
+// source so an entity listed in both the organization and a manager is placed once.
+func (m *merger) place(source string, ids map[string]string, entityType, id string) (string, error) {
+	if target, done := ids[id]; done {
+		return target, nil
+	}
+
+	target, resolution := id, ""
+	if owner, taken := m.owners[id]; taken {
+		switch m.conflicts {
+		case ConflictKeepFirst:
+			target, resolution = "", "dropped"
+		case ConflictRename:
+			target, resolution = source+"-"+id, "renamed"
+			if owner, taken := m.owners[target]; taken {
+				return "", fmt.Errorf("%w: %s %s of %s clashes with %s of %s after renaming", ErrRestructureConflict, entityType, id, source, target, owner)
+			}
+		default:
+			return "", fmt.Errorf("%w: %s %s exists in both %s and %s", ErrRestructureConflict, entityType, id, owner, source)
+		}
+	}
+
+	if target != "" {
+		m.owners[target] = source
+	}
+	ids[id] = target
+	m.record.Entries = append(m.record.Entries, RestructuringEntry{
+		EntityType:         entityType,
+		SourceOrganization: source,
+		SourceID:           id,
+		TargetOrganization: m.target,
+		TargetID:           target,
+		Resolution:         resolution,
+	})
+	return target, nil
+}
+
+// add places the entities of one source tenant into the merged tenant
+func (m *merger) add(merged, source *Tenant) error {
+	ids := make(map[string]string)
+	org, qms := merged.Organization, merged.Organization.QMS
+	processes := len(qms.Processes)
+
+	if sourceQMS := source.Organization.QMS; sourceQMS != nil {
+		if sourceQMS.Scope != nil {
+			qms.Scope = mergeScopes(qms.Scope, sourceQMS.Scope)
+		}
+		for _, process := range sourceQMS.Processes {
+			id, err := m.place(source.ID, ids, EntityTypeProcess, process.ID)
+			if err != nil {
+				return err
+			}
+			if id != "" {
+				process.ID = id
+				qms.Processes = append(qms.Processes, process)
+			}
+		}
+		for _, risk := range sourceQMS.Risks {
+			id, err := m.place(source.ID, ids, EntityTypeRisk, risk.ID)
+			if err != nil {
+				return err
+			}
+			if id != "" {
+				risk.ID = id
+				qms.Risks = append(qms.Risks, risk)
+			}
+		}
+		for _, opportunity := range sourceQMS.Opportunities {
+			id, err := m.place(source.ID, ids, EntityTypeOpportunity, opportunity.ID)
+			if err != nil {
+				return err
+			}
+			if id != "" {
+				opportunity.ID = id
+				qms.Opportunities = append(qms.Opportunities, opportunity)
+			}
+		}
+		for _, objective := range sourceQMS.Objectives {
+			id, err := m.place(source.ID, ids, EntityTypeObjective, objective.ID)
+			if err != nil {
+				return err
+			}
+			if id != "" {
+				objective.ID = id
+				qms.Objectives = append(qms.Objectives, objective)
+			}
+		}
+	}
+
+	for _, id := range sortedKeys(source.Risks.Risks) {
+		target, err := m.place(source.ID, ids, EntityTypeRisk, id)
+		if err != nil {
+			return err
+		}
+		if target != "" {
+			risk := source.Risks.Risks[id]
+			risk.ID = target
+			merged.Risks.Risks[target] = risk
+		}
+	}
+	for _, id := range sortedKeys(source.Risks.Opportunities) {
+		target, err := m.place(source.ID, ids, EntityTypeOpportunity, id)
+		if err != nil {
+			return err
+		}
+		if target != "" {
+			opportunity := source.Risks.Opportunities[id]
+			opportunity.ID = target
+			merged.Risks.Opportunities[target] = opportunity
+		}
+	}
+	for _, id := range sortedKeys(source.Objectives.Objectives) {
+		target, err := m.place(source.ID, ids, EntityTypeObjective, id)
+		if err != nil {
+			return err
+		}
+		if target != "" {
+			objective := source.Objectives.Objectives[id]
+			objective.ID = target
+			merged.Objectives.Objectives[target] = objective
+		}
+	}
+	for _, id := range sortedKeys(source.Documents.Documents) {
+		target, err := m.place(source.ID, ids, EntityTypeDocument, id)
+		if err != nil {
+			return err
+		}
+		if target != "" {
+			doc := source.Documents.Documents[id]
+			doc.ID = target
+			merged.Documents.Documents[target] = doc
+		}
+	}
+
+	// Follow renamed IDs in references between the placed entities
+	renamed := func(id string) string {
+		if target, ok := ids[id]; ok && target != "" {
+			return target
+		}
+		return id
+	}
+	for i := processes; i < len(qms.Processes); i++ {
+		process := &qms.Processes[i]
+		for j := range process.Inputs {
+			process.Inputs[j].Source = renamed(process.Inputs[j].Source)
+		}
+		for j := range process.Outputs {
+			process.Outputs[j].Destination = renamed(process.Outputs[j].Destination)
+		}
+	}
+	for _, id := range sortedKeys(source.Documents.Documents) {
+		if doc, ok := merged.Documents.Documents[ids[id]]; ok && ids[id] != "" {
+			doc.RevisionOf = renamed(doc.RevisionOf)
+			doc.SupersededBy = renamed(doc.SupersededBy)
+			merged.Documents.updateIndex(doc)
+		}
+	}
+	tracker := merged.Objectives.Tracker
+	for _, progress := range source.Objectives.Tracker.ProgressReports {
+		if target := ids[progress.ObjectiveID]; target != "" {
+			progress.ObjectiveID = target
+			tracker.ProgressReports = append(tracker.ProgressReports, progress)
+		}
+	}
+	for _, achievement := range source.Objectives.Tracker.Achievements {
+		if target := ids[achievement.ObjectiveID]; target != "" {
+			achievement.ObjectiveID = target
+			tracker.Achievements = append(tracker.Achievements, achievement)
+		}
+	}
+
+	org.Modified = time.Now()
+	return nil
+}
+
+// mergeScopes combines the products, services and exclusions of two scopes. An
+// exclusion is kept only if both organizations excluded the clause.
+func mergeScopes(into, scope *QMSScope) *QMSScope {
+	if into == nil {
+		copied := *scope
+		return &copied
+	}
+	if into.Description == "" {
+		into.Description = scope.Description
+	} else if scope.Description != "" && scope.Description != into.Description {
+		into.Description += "\n\n" + scope.Description
+	}
+	into.Products = appendMissing(into.Products, scope.Products)
+	into.Services = appendMissing(into.Services, scope.Services)
+
+	var exclusions []Exclusion
+	for _, exclusion := range into.Exclusions {
+		for _, other := range scope.Exclusions {
+			if exclusion.Clause == other.Clause {
+				exclusions = append(exclusions, exclusion)
+				break
+			}
+		}
+	}
+	into.Exclusions = exclusions
+	return into
+}
+
+// appendMissing appends the values not yet in list
+func appendMissing(list, values []string) []string {
+	for _, value := range values {
+		found := false
+		for _, existing := range list {
+			found = found || existing == value
+		}
+		if !found {
+			list = append(list, value)
+		}
+	}
+	return list
+}
+
+// SplitSpec selects the entities that move to the organization split off, e.g. in a
+// divestiture
+type SplitSpec struct {
+	ID            string
+	Name          string
+	Processes     []string
+	Risks         []string
+	Opportunities []string
+	Documents     []string
+	Objectives    []string
+	Reason        string
+	Date          time.Time // defaults to now
+}
+
+// SplitOrganization moves the selected processes, risks, opportunities, documents and
+// objectives out of a tenant into a new tenant. The new organization starts from a
+// copy of the source's context, leadership, time zone and scope, to be adjusted
+// afterwards. Objective progress moves with its objective. Nothing is moved unless
+// every selected ID exists in the source.
+//
+// The returned record is appended to the restructuring history of both tenants.
+func SplitOrganization(source *Tenant, spec SplitSpec) (*Tenant, *RestructuringRecord, error) {
+	if spec.ID == "" {
+		return nil, nil, fmt.Errorf("split organization must have an ID")
+	}
+	if spec.ID == source.ID {
+		return nil, nil, fmt.Errorf("split organization must have an ID other than %s", source.ID)
+	}
+	if spec.Date.IsZero() {
+		spec.Date = time.Now()
+	}
+
+	sourceQMS := source.Organization.QMS
+	if sourceQMS == nil {
+		sourceQMS = &QualityManagementSystem{}
+	}
+	selected := make(map[string]bool)
+	for _, selection := range []struct {
+		entityType string
+		ids        []string
+		exists     func(id string) bool
+	}{
+		{EntityTypeProcess, spec.Processes, func(id string) bool { return indexOf(sourceQMS.Processes, id) >= 0 }},
+		{EntityTypeRisk, spec.Risks, func(id string) bool { return source.Risks.Risks[id] != nil || indexOf(sourceQMS.Risks, id) >= 0 }},
+		{EntityTypeOpportunity, spec.Opportunities, func(id string) bool {
+			return source.Risks.Opportunities[id] != nil || indexOf(sourceQMS.Opportunities, id) >= 0
+		}},
+		{EntityTypeDocument, spec.Documents, func(id string) bool { return source.Documents.Documents[id] != nil }},
+		{EntityTypeObjective, spec.Objectives, func(id string) bool {
+			return source.Objectives.Objectives[id] != nil || indexOf(sourceQMS.Objectives, id) >= 0
+		}},
+	} {
+		for _, id := range selection.ids {
+			if !selection.exists(id) {
+				return nil, nil, fmt.Errorf("%s %s not found in organization %s", selection.entityType, id, source.ID)
+			}
+			selected[id] = true
+		}
+	}
+
+	clone, err := source.copy()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to copy organization %s: %w", source.ID, err)
+	}
+	split := NewTenant(spec.ID)
+	org := split.Organization
+	org.Name = spec.Name
+	org.Context = clone.Organization.Context
+	org.Leadership = clone.Organization.Leadership
+	org.TimeZone = clone.Organization.TimeZone
+	org.Calendar = clone.Organization.Calendar
+	org.QMS = &QualityManagementSystem{Created: spec.Date}
+	if clone.Organization.QMS != nil {
+		org.QMS.Scope = clone.Organization.QMS.Scope
+	}
+
+	record := &RestructuringRecord{Kind: RestructuringSplit, Date: spec.Date, Reason: spec.Reason, Sources: []string{source.ID}, Targets: []string{spec.ID}}
+	trace := func(entityType, id string) {
+		record.Entries = append(record.Entries, RestructuringEntry{
+			EntityType:         entityType,
+			SourceOrganization: source.ID,
+			SourceID:           id,
+			TargetOrganization: spec.ID,
+			TargetID:           id,
+		})
+	}
+
+	org.QMS.Processes, sourceQMS.Processes = partition(sourceQMS.Processes, selected)
+	org.QMS.Risks, sourceQMS.Risks = partition(sourceQMS.Risks, selected)
+	org.QMS.Opportunities, sourceQMS.Opportunities = partition(sourceQMS.Opportunities, selected)
+	org.QMS.Objectives, sourceQMS.Objectives = partition(sourceQMS.Objectives, selected)
+
+	for _, id := range spec.Processes {
+		trace(EntityTypeProcess, id)
+	}
+	for _, id := range spec.Risks {
+		if risk, ok := source.Risks.Risks[id]; ok {
+			split.Risks.Risks[id] = risk
+			delete(source.Risks.Risks, id)
+		}
+		trace(EntityTypeRisk, id)
+	}
+	for _, id := range spec.Opportunities {
+		if opportunity, ok := source.Risks.Opportunities[id]; ok {
+			split.Risks.Opportunities[id] = opportunity
+			delete(source.Risks.Opportunities, id)
+		}
+		trace(EntityTypeOpportunity, id)
+	}
+	for _, id := range spec.Documents {
+		doc := source.Documents.Documents[id]
+		delete(source.Documents.Documents, id)
+		source.Documents.removeFromIndex(id)
+		split.Documents.Documents[id] = doc
+		split.Documents.updateIndex(doc)
+		trace(EntityTypeDocument, id)
+	}
+	for _, id := range spec.Objectives {
+		if objective, ok := source.Objectives.Objectives[id]; ok {
+			split.Objectives.Objectives[id] = objective
+			delete(source.Objectives.Objectives, id)
+		}
+		trace(EntityTypeObjective, id)
+	}
+
+	from, to := source.Objectives.Tracker, split.Objectives.Tracker
+	var kept []ObjectiveProgress
+	for _, progress := range from.ProgressReports {
+		if selected[progress.ObjectiveID] {
+			to.ProgressReports = append(to.ProgressReports, progress)
+		} else {
+			kept = append(kept, progress)
+		}
+	}
+	from.ProgressReports = kept
+	var keptAchievements []ObjectiveAchievement
+	for _, achievement := range from.Achievements {
+		if selected[achievement.ObjectiveID] {
+			to.Achievements = append(to.Achievements, achievement)
+		} else {
+			keptAchievements = append(keptAchievements, achievement)
+		}
+	}
+	from.Achievements = keptAchievements
+
+	for id := range selected {
+		releaseID(source.Documents.IDs, id)
+	}
+	source.Risks.RebuildRegister()
+	split.Risks.RebuildRegister()
+	split.shareIDRegistry()
+
+	source.Organization.Modified = time.Now()
+	source.Restructurings = append(source.Restructurings, *record)
+	split.Restructurings = append(split.Restructurings, *record)
+	return split, record, nil
+}
+
+// identified is implemented by the entity types moved in restructurings
+type identified interface {
+	Process | Risk | Opportunity | QualityObjective
+}
+
+// entityID returns the ID of a process, risk, opportunity or objective
+func entityID[T identified](entity T) string {
+	switch e := any(entity).(type) {
+	case Process:
+		return e.ID
+	case Risk:
+		return e.ID
+	case Opportunity:
+		return e.ID
+	case QualityObjective:
+		return e.ID
+	}
+	return ""
+}
+
+// indexOf returns the position of the entity with the given ID, or -1
+func indexOf[T identified](entities []T, id string) int {
+	for i, entity := range entities {
+		if entityID(entity) == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// partition separates the selected entities from the rest
+func partition[T identified](entities []T, selected map[string]bool) (moved, kept []T) {
+	for _, entity := range entities {
+		if selected[entityID(entity)] {
+			moved = append(moved, entity)
+		} else {
+			kept = append(kept, entity)
+		}
+	}
+	return moved, kept
+}
@@ -0,0 +1,114 @@
+package iso9001
+
+import (
+	"errors"
+	"testing"
+)
+
+func restructureTenant(t *testing.T, id string) *Tenant {
+	t.Helper()
+	tenant := NewTenant(id)
+	tenant.Organization.Name = id
+	tenant.Organization.QMS = &QualityManagementSystem{
+		Scope: &QMSScope{Products: []string{id + " widgets"}},
+		Processes: []Process{
+			{ID: "PROC-001", Name: "Sales", Outputs: []ProcessOutput{{Name: "Order", Destination: "PROC-002"}}},
+			{ID: "PROC-002", Name: id + " production"},
+		},
+	}
+	if err := tenant.Risks.IdentifyRisk(&Risk{ID: "RISK-001", Description: id + " supplier delays"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := tenant.Documents.AddDocument(&DocumentedInformation{ID: id + "-DOC-001", Title: id + " manual"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := tenant.Objectives.CreateObjective(&QualityObjective{ID: id + "-OBJ-001", Name: "On-time delivery", Measurable: true, Targets: []ObjectiveTarget{{Metric: "OTD", Value: "95%"}}, Responsible: "COO"}); err != nil {
+		t.Fatal(err)
+	}
+	return tenant
+}
+
+func TestMergeOrganizations(t *testing.T) {
+	acme, widgets := restructureTenant(t, "ACME"), restructureTenant(t, "WIDGETS")
+
+	if _, _, err := MergeOrganizations(MergeOptions{ID: "GROUP"}, acme, widgets); !errors.Is(err, ErrRestructureConflict) {
+		t.Fatalf("Expected conflict error, got %v", err)
+	}
+
+	merged, record, err := MergeOrganizations(MergeOptions{ID: "GROUP", Name: "Acme Group", Conflicts: ConflictRename}, acme, widgets)
+	if err != nil {
+		t.Fatalf("Failed to merge: %v", err)
+	}
+	if len(merged.Organization.QMS.Processes) != 4 || len(merged.Risks.Risks) != 2 || len(merged.Documents.Documents) != 2 || len(merged.Objectives.Objectives) != 2 {
+		t.Errorf("Unexpected merged contents: %+v", merged.Organization.QMS.Processes)
+	}
+	if merged.Risks.Risks["WIDGETS-RISK-001"] == nil || merged.Risks.Risks["RISK-001"].Description != "ACME supplier delays" {
+		t.Errorf("Expected clashing risk to be renamed, got %v", sortedKeys(merged.Risks.Risks))
+	}
+	if got := merged.Organization.QMS.Processes[2].Outputs[0].Destination; got != "WIDGETS-PROC-002" {
+		t.Errorf("Expected reference to follow renamed process, got %s", got)
+	}
+	if len(merged.Organization.QMS.Scope.Products) != 2 {
+		t.Errorf("Expected scopes to be combined, got %v", merged.Organization.QMS.Scope.Products)
+	}
+	entry, ok := record.Lookup("WIDGETS", "RISK-001")
+	if !ok || entry.TargetID != "WIDGETS-RISK-001" || entry.Resolution != "renamed" {
+		t.Errorf("Unexpected trace %+v", entry)
+	}
+	if len(merged.Restructurings) != 1 || len(acme.Restructurings) != 0 {
+		t.Errorf("Expected the merger to be recorded on the merged organization only")
+	}
+
+	kept, record, err := MergeOrganizations(MergeOptions{ID: "GROUP", Conflicts: ConflictKeepFirst}, acme, widgets)
+	if err != nil {
+		t.Fatalf("Failed to merge: %v", err)
+	}
+	if len(kept.Organization.QMS.Processes) != 2 || len(kept.Risks.Risks) != 1 {
+		t.Errorf("Expected clashing entities to be dropped")
+	}
+	if entry, _ := record.Lookup("WIDGETS", "PROC-002"); entry.Resolution != "dropped" || entry.TargetID != "" {
+		t.Errorf("Unexpected trace %+v", entry)
+	}
+}
+
+func TestSplitOrganization(t *testing.T) {
+	acme := restructureTenant(t, "ACME")
+	if err := acme.Objectives.UpdateObjectiveProgress("ACME-OBJ-001", ObjectiveProgress{Progress: 90}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := SplitOrganization(acme, SplitSpec{ID: "SPIN", Risks: []string{"RISK-404"}}); err == nil {
+		t.Fatal("Expected error for unknown risk")
+	}
+	if len(acme.Risks.Risks) != 1 {
+		t.Fatal("Failed split must not move anything")
+	}
+
+	spin, record, err := SplitOrganization(acme, SplitSpec{
+		ID:         "SPIN",
+		Name:       "Spin-off",
+		Processes:  []string{"PROC-002"},
+		Risks:      []string{"RISK-001"},
+		Documents:  []string{"ACME-DOC-001"},
+		Objectives: []string{"ACME-OBJ-001"},
+		Reason:     "Divestiture of production",
+	})
+	if err != nil {
+		t.Fatalf("Failed to split: %v", err)
+	}
+	if len(acme.Organization.QMS.Processes) != 1 || len(acme.Risks.Risks) != 0 || len(acme.Documents.Documents) != 0 || len(acme.Objectives.Tracker.ProgressReports) != 0 {
+		t.Error("Expected selected entities to leave the source")
+	}
+	if len(spin.Organization.QMS.Processes) != 1 || spin.Risks.Risks["RISK-001"] == nil || len(spin.Objectives.Tracker.ProgressReports) != 1 {
+		t.Error("Expected selected entities in the split organization")
+	}
+	if len(spin.Documents.Index.ByStatus[DocumentStatusDraft]) != 1 || len(acme.Documents.Index.ByStatus) != 0 {
+		t.Error("Expected document indexes to follow the move")
+	}
+	if len(record.Entries) != 4 || len(acme.Restructurings) != 1 || len(spin.Restructurings) != 1 {
+		t.Errorf("Unexpected record %+v", record)
+	}
+	if err := acme.Risks.IdentifyRisk(&Risk{ID: "RISK-001", Description: "Reused ID"}); err != nil {
+		t.Errorf("Expected moved ID to be released: %v", err)
+	}
+}
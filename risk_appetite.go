@@ -0,0 +1,175 @@
+package iso9001
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// RiskAppetite is the amount of residual risk the organization accepts. Scores are
+// likelihood x impact on the 1 to 16 scale of the risk register.
+type RiskAppetite struct {
+	MaxScore  int       `json:"max_score" yaml:"max_score"`                       // highest accepted residual score
+	MaxImpact RiskLevel `json:"max_impact,omitempty" yaml:"max_impact,omitempty"` // highest accepted residual impact, whatever the likelihood
+	Statement string    `json:"statement,omitempty" yaml:"statement,omitempty"`   // appetite statement approved by top management
+}
+
+// DefaultRiskAppetite accepts residual risks up to medium priority
+var DefaultRiskAppetite = RiskAppetite{MaxScore: 8}
+
+// riskLevelRank orders risk levels from very low to very high
+var riskLevelRank = map[RiskLevel]int{
+	RiskLevelVeryLow:  1,
+	RiskLevelLow:      2,
+	RiskLevelMedium:   3,
+	RiskLevelHigh:     4,
+	RiskLevelVeryHigh: 5,
+}
+
+// SetAppetite changes the risk appetite and re-flags every risk against it
+func (rm *RiskManager) SetAppetite(appetite RiskAppetite) error {
+	if appetite.MaxScore < 1 || appetite.MaxScore > 16 {
+		return fmt.Errorf("risk appetite score must be between 1 and 16, got %d", appetite.MaxScore)
+	}
+	if _, ok := riskLevelRank[appetite.MaxImpact]; appetite.MaxImpact != "" && !ok {
+		return fmt.Errorf("unknown risk level %q", appetite.MaxImpact)
+	}
+	rm.Appetite = &appetite
+	rm.RebuildRegister()
+	return nil
+}
+
+// appetite returns the risk appetite in force
+func (rm *RiskManager) appetite() RiskAppetite {
+	if rm.Appetite == nil {
+		return DefaultRiskAppetite
+	}
+	return *rm.Appetite
+}
+
+// AssessResidualRisk records the likelihood and impact that remain once a risk's
+// mitigation is in place. Mitigation cannot make a risk worse than its inherent
+// rating.
+func (rm *RiskManager) AssessResidualRisk(riskID string, likelihood, impact RiskLevel) error {
+	risk, exists := rm.Risks[riskID]
+	if !exists {
+		return fmt.Errorf("risk with ID %s not found", riskID)
+	}
+	if risk.Status == RiskStatusIdentified {
+		return fmt.Errorf("risk %s must be assessed before its residual risk", riskID)
+	}
+	if rm.getRiskScore(likelihood)*rm.getRiskScore(impact) > rm.getRiskScore(risk.Likelihood)*rm.getRiskScore(risk.Impact) {
+		return fmt.Errorf("residual risk of %s cannot exceed its inherent risk", riskID)
+	}
+
+	risk.ResidualLikelihood = likelihood
+	risk.ResidualImpact = impact
+	risk.ResidualPriority = rm.calculatePriority(likelihood, impact)
+	rm.recordReview(risk, time.Now())
+
+	rm.updateRegister(riskID)
+	return nil
+}
+
+// residualScore returns the residual score of a risk, or its inherent score while the
+// residual risk has not been assessed
+func (rm *RiskManager) residualScore(risk *Risk) int {
+	likelihood, impact := residualRatings(risk)
+	return rm.getRiskScore(likelihood) * rm.getRiskScore(impact)
+}
+
+// residualRatings returns the residual likelihood and impact of a risk, falling back
+// to the inherent ratings
+func residualRatings(risk *Risk) (likelihood, impact RiskLevel) {
+	if risk.ResidualLikelihood == "" || risk.ResidualImpact == "" {
+		return risk.Likelihood, risk.Impact
+	}
+	return risk.ResidualLikelihood, risk.ResidualImpact
+}
+
+// ExceedsAppetite reports whether the residual exposure of a risk is above the risk
+// appetite. Risks not yet assessed are not flagged.
+func (rm *RiskManager) ExceedsAppetite(risk *Risk) bool {
+	if risk.Status == RiskStatusIdentified {
+		return false
+	}
+	appetite := rm.appetite()
+	_, impact := residualRatings(risk)
+	if appetite.MaxImpact != "" && riskLevelRank[impact] > riskLevelRank[appetite.MaxImpact] {
+		return true
+	}
+	return rm.residualScore(risk) > appetite.MaxScore
+}
+
+// RiskExposure is the inherent and residual exposure of one risk
+type RiskExposure struct {
+	RiskID            string   `json:"risk_id" yaml:"risk_id"`
+	Description       string   `json:"description" yaml:"description"`
+	InherentScore     int      `json:"inherent_score" yaml:"inherent_score"`
+	ResidualScore     int      `json:"residual_score" yaml:"residual_score"`
+	ResidualPriority  Priority `json:"residual_priority,omitempty" yaml:"residual_priority,omitempty"`
+	MitigationActions int      `json:"mitigation_actions" yaml:"mitigation_actions"`
+}
+
+// RiskExposureReport summarizes how far mitigation brings the risks within appetite
+type RiskExposureReport struct {
+	Appetite          RiskAppetite   `json:"appetite" yaml:"appetite"`
+	Risks             int            `json:"risks" yaml:"risks"`
+	InherentExposure  int            `json:"inherent_exposure" yaml:"inherent_exposure"`
+	ResidualExposure  int            `json:"residual_exposure" yaml:"residual_exposure"`
+	Reduction         float64        `json:"reduction" yaml:"reduction"` // percent of inherent exposure removed by mitigation
+	ResidualAssessed  int            `json:"residual_assessed" yaml:"residual_assessed"`
+	ExceedingAppetite []RiskExposure `json:"exceeding_appetite" yaml:"exceeding_appetite"`
+}
+
+// RisksExceedingAppetite returns the risks whose residual exposure is above the
+// appetite, highest residual score first
+func (rm *RiskManager) RisksExceedingAppetite() []*Risk {
+	var risks []*Risk
+	for _, risk := range rm.Risks {
+		if rm.ExceedsAppetite(risk) {
+			risks = append(risks, risk)
+		}
+	}
+	sort.Slice(risks, func(i, j int) bool {
+		si, sj := rm.residualScore(risks[i]), rm.residualScore(risks[j])
+		if si != sj {
+			return si > sj
+		}
+		return risks[i].ID < risks[j].ID
+	})
+	return risks
+}
+
+// ResidualExposure reports the inherent and residual exposure of the assessed risks
+// and the risks still above the appetite
+func (rm *RiskManager) ResidualExposure() RiskExposureReport {
+	report := RiskExposureReport{Appetite: rm.appetite(), ExceedingAppetite: []RiskExposure{}}
+
+	for _, risk := range rm.Risks {
+		if risk.Status == RiskStatusIdentified {
+			continue
+		}
+		report.Risks++
+		report.InherentExposure += rm.getRiskScore(risk.Likelihood) * rm.getRiskScore(risk.Impact)
+		report.ResidualExposure += rm.residualScore(risk)
+		if risk.ResidualLikelihood != "" && risk.ResidualImpact != "" {
+			report.ResidualAssessed++
+		}
+	}
+	if report.InherentExposure > 0 {
+		report.Reduction = 100 * float64(report.InherentExposure-report.ResidualExposure) / float64(report.InherentExposure)
+	}
+
+	for _, risk := range rm.RisksExceedingAppetite() {
+		report.ExceedingAppetite = append(report.ExceedingAppetite, RiskExposure{
+			RiskID:            risk.ID,
+			Description:       risk.Description,
+			InherentScore:     rm.getRiskScore(risk.Likelihood) * rm.getRiskScore(risk.Impact),
+			ResidualScore:     rm.residualScore(risk),
+			ResidualPriority:  risk.ResidualPriority,
+			MitigationActions: len(risk.Mitigation),
+		})
+	}
+	return report
+}
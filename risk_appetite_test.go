@@ -0,0 +1,59 @@
+package iso9001
+
+import "testing"
+
+func TestRiskAppetite(t *testing.T) {
+	rm := NewRiskManager()
+	for _, risk := range []*Risk{
+		{ID: "RISK-001", Description: "Key supplier fails"},
+		{ID: "RISK-002", Description: "Calibration lapses"},
+		{ID: "RISK-003", Description: "New regulation"},
+	} {
+		if err := rm.IdentifyRisk(risk); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := rm.AssessResidualRisk("RISK-001", RiskLevelLow, RiskLevelLow); err == nil {
+		t.Error("Expected residual assessment of an unassessed risk to fail")
+	}
+
+	rm.AssessRisk("RISK-001", RiskLevelVeryHigh, RiskLevelVeryHigh) // 16
+	rm.AssessRisk("RISK-002", RiskLevelHigh, RiskLevelHigh)         // 9
+	rm.AssessRisk("RISK-003", RiskLevelMedium, RiskLevelLow)        // 2
+	if !rm.Risks["RISK-001"].ExceedsAppetite || !rm.Risks["RISK-002"].ExceedsAppetite || rm.Risks["RISK-003"].ExceedsAppetite {
+		t.Error("Expected unmitigated high risks to exceed the default appetite")
+	}
+
+	if err := rm.AssessResidualRisk("RISK-003", RiskLevelHigh, RiskLevelHigh); err == nil {
+		t.Error("Expected residual risk above the inherent risk to be rejected")
+	}
+	if err := rm.AssessResidualRisk("RISK-002", RiskLevelMedium, RiskLevelMedium); err != nil {
+		t.Fatal(err)
+	}
+	if err := rm.AssessResidualRisk("RISK-001", RiskLevelMedium, RiskLevelVeryHigh); err != nil {
+		t.Fatal(err)
+	}
+	risk := rm.Risks["RISK-002"]
+	if risk.ExceedsAppetite || risk.ResidualPriority != PriorityMedium {
+		t.Errorf("Expected mitigated risk within appetite, got %+v", risk)
+	}
+	if entry := rm.Register.OrganizationRisks[0]; entry.RiskID != "RISK-001" || entry.ResidualScore != 8 || entry.ExceedsAppetite {
+		t.Errorf("Expected register entries to carry residual scores, got %+v", rm.Register.OrganizationRisks[0])
+	}
+
+	report := rm.ResidualExposure()
+	if report.Risks != 3 || report.InherentExposure != 27 || report.ResidualExposure != 14 || report.ResidualAssessed != 2 || len(report.ExceedingAppetite) != 0 {
+		t.Errorf("Unexpected exposure report %+v", report)
+	}
+
+	if err := rm.SetAppetite(RiskAppetite{MaxScore: 6, MaxImpact: RiskLevelHigh}); err != nil {
+		t.Fatal(err)
+	}
+	exceeding := rm.RisksExceedingAppetite()
+	if len(exceeding) != 1 || exceeding[0].ID != "RISK-001" || !exceeding[0].ExceedsAppetite {
+		t.Errorf("Expected the very high residual impact to exceed the appetite, got %d risks", len(exceeding))
+	}
+	if err := rm.SetAppetite(RiskAppetite{MaxScore: 20}); err == nil {
+		t.Error("Expected appetite above the scale to be rejected")
+	}
+}
@@ -0,0 +1,367 @@
+package iso9001
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// RiskRegisterColumns are the columns of a risk register spreadsheet, as written by
+// RiskRegister.ExportCSV
+var RiskRegisterColumns = []string{
+	"Risk ID",
+	"Description",
+	"Causes",
+	"Effects",
+	"Likelihood",
+	"Impact",
+	"Risk Score",
+	"Mitigation Actions",
+	"Owner",
+	"Status",
+	"Review Date",
+}
+
+// riskListSeparator separates causes, effects and mitigation actions within a cell
+const riskListSeparator = "; "
+
+// riskListEscaper escapes the separator, and the escape character, within an item
+var riskListEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`)
+
+// formulaPrefixes are the characters that make a spreadsheet application evaluate a
+// cell as a formula
+const formulaPrefixes = "=+-@"
+
+// ExportCSV writes the register's risks, highest score first, as CSV with a header
+// row of RiskRegisterColumns. Causes, effects, mitigation actions and owner are read
+// from the risk each entry was built from, and are empty for entries decoded from
+// JSON or YAML; RiskManager.ExportRegisterCSV reads them from the manager's risks.
+// Causes, effects and mitigation actions are separated by semicolons, with semicolons
+// within an item escaped as "\;", and review dates are written as YYYY-MM-DD. Text
+// starting with =, +, - or @ is prefixed with an apostrophe, so spreadsheet
+// applications show it instead of evaluating it as a formula.
+func (rr *RiskRegister) ExportCSV(w io.Writer) error {
+	return writeRiskRegisterCSV(w, rr.OrganizationRisks, func(entry *RiskEntry) *Risk { return entry.risk })
+}
+
+// ExportRegisterCSV writes the risk register like RiskRegister.ExportCSV, reading
+// causes, effects, mitigation actions and owner from the manager's risks
+func (rm *RiskManager) ExportRegisterCSV(w io.Writer) error {
+	return writeRiskRegisterCSV(w, rm.Register.OrganizationRisks, func(entry *RiskEntry) *Risk { return rm.Risks[entry.RiskID] })
+}
+
+// writeRiskRegisterCSV writes register entries as CSV. The columns not kept in the
+// register are built from the risk returned by source, which may be nil.
+func writeRiskRegisterCSV(w io.Writer, entries []RiskEntry, source func(entry *RiskEntry) *Risk) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(RiskRegisterColumns); err != nil {
+		return err
+	}
+	for i := range entries {
+		entry := &entries[i]
+		reviewDate := ""
+		if !entry.NextReviewDate.IsZero() {
+			reviewDate = entry.NextReviewDate.Format("2006-01-02")
+		}
+		var causes, effects, mitigation, owner string
+		if risk := source(entry); risk != nil {
+			causes = joinRiskList(risk.Causes)
+			effects = joinRiskList(risk.Effects)
+			mitigation = joinRiskList(mitigationDescriptions(risk.Mitigation))
+			owner = risk.Owner
+		}
+		record := []string{
+			spreadsheetCell(entry.RiskID),
+			spreadsheetCell(entry.Description),
+			spreadsheetCell(causes),
+			spreadsheetCell(effects),
+			string(entry.Probability),
+			string(entry.Impact),
+			fmt.Sprint(entry.RiskScore),
+			spreadsheetCell(mitigation),
+			spreadsheetCell(owner),
+			string(entry.Status),
+			reviewDate,
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// ImportCSV reads risks from a risk register spreadsheet, as described by
+// ReadRiskRegisterCSV, into the register. Entries replace those with the same risk
+// ID. The register is not backed by risks; use RiskManager.ImportRegisterCSV to
+// migrate a spreadsheet into a risk manager.
+func (rr *RiskRegister) ImportCSV(r io.Reader) error {
+	scratch := NewRiskManager()
+	result, err := scratch.ImportRegisterCSV(r)
+	if err != nil {
+		return err
+	}
+	if result.HasErrors() {
+		errs := make([]error, len(result.Errors))
+		for i := range result.Errors {
+			errs[i] = result.Errors[i]
+		}
+		return errors.Join(errs...)
+	}
+
+	imported := scratch.Register.OrganizationRisks
+	var entries []RiskEntry
+	for _, entry := range rr.OrganizationRisks {
+		if _, replaced := scratch.Risks[entry.RiskID]; !replaced {
+			entries = append(entries, entry)
+		}
+	}
+	entries = append(entries, imported...)
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].RiskScore > entries[j].RiskScore
+	})
+
+	rr.OrganizationRisks = entries
+	rr.refreshCriticalRisks()
+	rr.LastUpdated = time.Now()
+	return nil
+}
+
+// ImportRegisterCSV identifies the risks of a risk register spreadsheet, as described
+// by ReadRiskRegisterCSV, and rebuilds the register. Nothing is imported when the
+// spreadsheet cannot be read; rows whose risk ID is already in use are reported in the
+// result.
+func (rm *RiskManager) ImportRegisterCSV(r io.Reader) (BulkResult, error) {
+	risks, err := ReadRiskRegisterCSV(r)
+	if err != nil {
+		return BulkResult{}, err
+	}
+
+	result := BulkResult{}
+	now := time.Now()
+	for i, risk := range risks {
+		_, exists := rm.Risks[risk.ID]
+		if err := claimID(rm.IDs, risk.ID, EntityTypeRisk, exists); err != nil {
+			result.Errors = append(result.Errors, BulkItemError{Index: i, ID: risk.ID, Err: err})
+			continue
+		}
+		if risk.Likelihood != "" {
+			risk.Priority = rm.calculatePriority(risk.Likelihood, risk.Impact)
+		}
+		risk.Created = now
+		for j := range risk.Mitigation {
+			risk.Mitigation[j].Created = now
+		}
+		rm.Risks[risk.ID] = risk
+		result.Added++
+	}
+	rm.RebuildRegister()
+	return result, nil
+}
+
+// ReadRiskRegisterCSV reads risks from CSV with a header row naming the columns of
+// RiskRegisterColumns, in any order and case. Risk ID and Description are required.
+// Likelihood and impact are given together as risk levels such as "Very High";
+// causes, effects and mitigation actions are separated by semicolons, "\;" being a
+// semicolon within an item, and the review date is YYYY-MM-DD or an RFC 3339
+// timestamp. The apostrophe ExportCSV puts before text that looks like a formula is
+// removed. Risk Score is ignored, since it is
+// recomputed from likelihood and impact. Without a status, a risk is mitigated,
+// assessed or identified depending on the columns given. Mitigation actions are
+// planned and assigned to the owner.
+func ReadRiskRegisterCSV(r io.Reader) ([]*Risk, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = riskColumnName(name)
+		known := false
+		for _, column := range RiskRegisterColumns {
+			known = known || riskColumnName(column) == name
+		}
+		if !known {
+			return nil, fmt.Errorf("unknown CSV column %q (expected %s)", name, strings.Join(RiskRegisterColumns, ", "))
+		}
+		columns[name] = i
+	}
+	for _, required := range []string{"Risk ID", "Description"} {
+		if _, ok := columns[riskColumnName(required)]; !ok {
+			return nil, fmt.Errorf("CSV header lacks required column %q", required)
+		}
+	}
+
+	var risks []*Risk
+	seen := make(map[string]int)
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return risks, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		field := func(name string) string {
+			if i, ok := columns[riskColumnName(name)]; ok && i < len(record) {
+				return spreadsheetText(strings.TrimSpace(record[i]))
+			}
+			return ""
+		}
+
+		risk := &Risk{
+			ID:          field("Risk ID"),
+			Description: field("Description"),
+			Causes:      splitRiskList(field("Causes")),
+			Effects:     splitRiskList(field("Effects")),
+			Owner:       field("Owner"),
+		}
+		if err := checkNewRisk(risk); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if previous, duplicate := seen[risk.ID]; duplicate {
+			return nil, fmt.Errorf("line %d: risk %s is already listed on line %d", line, risk.ID, previous)
+		}
+		seen[risk.ID] = line
+
+		if risk.Likelihood, err = parseRiskLevel(field("Likelihood")); err != nil {
+			return nil, fmt.Errorf("line %d: invalid likelihood: %w", line, err)
+		}
+		if risk.Impact, err = parseRiskLevel(field("Impact")); err != nil {
+			return nil, fmt.Errorf("line %d: invalid impact: %w", line, err)
+		}
+		if (risk.Likelihood == "") != (risk.Impact == "") {
+			return nil, fmt.Errorf("line %d: likelihood and impact of risk %s must be given together", line, risk.ID)
+		}
+		for i, description := range splitRiskList(field("Mitigation Actions")) {
+			risk.Mitigation = append(risk.Mitigation, Action{
+				ID:          fmt.Sprintf("%s-M%d", risk.ID, i+1),
+				Description: description,
+				Type:        ActionTypeMitigation,
+				Responsible: risk.Owner,
+				Status:      ActionStatusPlanned,
+			})
+		}
+		if date := field("Review Date"); date != "" {
+			if risk.NextReviewDate, err = parseMeasurementDate(date); err != nil {
+				return nil, fmt.Errorf("line %d: invalid review date %q", line, date)
+			}
+		}
+		if risk.Status, err = parseRiskStatus(field("Status")); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if risk.Status == "" {
+			switch {
+			case len(risk.Mitigation) > 0:
+				risk.Status = RiskStatusMitigated
+			case risk.Likelihood != "":
+				risk.Status = RiskStatusAssessed
+			default:
+				risk.Status = RiskStatusIdentified
+			}
+		}
+		risks = append(risks, risk)
+	}
+}
+
+// riskColumnName normalizes a column name, so that "Risk ID", "risk_id" and "RISK ID"
+// name the same column
+func riskColumnName(name string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), "_", " ")
+}
+
+// joinRiskList joins items into a semicolon separated cell
+func joinRiskList(items []string) string {
+	escaped := make([]string, len(items))
+	for i, item := range items {
+		escaped[i] = riskListEscaper.Replace(item)
+	}
+	return strings.Join(escaped, riskListSeparator)
+}
+
+// splitRiskList splits a semicolon separated cell, dropping empty items. A backslash
+// escapes a following semicolon or backslash; other backslashes are kept.
+func splitRiskList(value string) []string {
+	var items []string
+	var item strings.Builder
+	add := func() {
+		if text := strings.TrimSpace(item.String()); text != "" {
+			items = append(items, text)
+		}
+		item.Reset()
+	}
+	for i := 0; i < len(value); i++ {
+		switch c := value[i]; {
+		case c == '\\' && i+1 < len(value) && (value[i+1] == ';' || value[i+1] == '\\'):
+			i++
+			item.WriteByte(value[i])
+		case c == ';':
+			add()
+		default:
+			item.WriteByte(c)
+		}
+	}
+	add()
+	return items
+}
+
+// spreadsheetCell prefixes text that a spreadsheet application would evaluate as a
+// formula with an apostrophe, as is text that would otherwise lose its own apostrophe
+// to spreadsheetText
+func spreadsheetCell(value string) string {
+	if value != "" && (strings.ContainsRune(formulaPrefixes, rune(value[0])) || spreadsheetText(value) != value) {
+		return "'" + value
+	}
+	return value
+}
+
+// spreadsheetText removes the apostrophe spreadsheetCell put before a cell
+func spreadsheetText(value string) string {
+	if len(value) > 1 && value[0] == '\'' && (strings.ContainsRune(formulaPrefixes, rune(value[1])) || value[1] == '\'') {
+		return value[1:]
+	}
+	return value
+}
+
+// spreadsheetValue normalizes a level or status as written in a spreadsheet, such as
+// "Very High", to its identifier
+func spreadsheetValue(value string) string {
+	value = strings.ToLower(strings.TrimSpace(value))
+	return strings.NewReplacer(" ", "_", "-", "_").Replace(value)
+}
+
+// parseRiskLevel parses a risk level; an empty value is no level
+func parseRiskLevel(value string) (RiskLevel, error) {
+	level := RiskLevel(spreadsheetValue(value))
+	switch level {
+	case "", RiskLevelVeryLow, RiskLevelLow, RiskLevelMedium, RiskLevelHigh, RiskLevelVeryHigh:
+		return level, nil
+	}
+	return "", fmt.Errorf("unknown risk level %q", value)
+}
+
+// parseRiskStatus parses a risk status; an empty value is no status
+func parseRiskStatus(value string) (RiskStatus, error) {
+	status := RiskStatus(spreadsheetValue(value))
+	switch status {
+	case "", RiskStatusIdentified, RiskStatusAssessed, RiskStatusMitigated, RiskStatusMonitored, RiskStatusOverdue:
+		return status, nil
+	}
+	return "", fmt.Errorf("unknown risk status %q", value)
+}
+
+// mitigationDescriptions lists the descriptions of mitigation actions
+func mitigationDescriptions(actions []Action) []string {
+	var descriptions []string
+	for _, action := range actions {
+		descriptions = append(descriptions, action.Description)
+	}
+	return descriptions
+}
@@ -0,0 +1,149 @@
+package iso9001
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRiskRegisterCSV(t *testing.T) {
+	sheet := `risk_id,Description,Causes,Effects,Likelihood,Impact,Mitigation Actions,Owner,Status,Review Date
+RISK-001,Supplier delivers late,Single source; Long lead time,Line stoppage,High,Very High,Qualify second supplier; Keep safety stock,alice,,2026-12-01
+RISK-002,Calibration lapses,,,low,medium,,bob,monitored,
+RISK-003,New regulation,,,,,,,,
+`
+	rm := NewRiskManager()
+	result, err := rm.ImportRegisterCSV(strings.NewReader(sheet))
+	if err != nil || result.Added != 3 || result.HasErrors() {
+		t.Fatalf("Expected three risks to be imported, got %+v %v", result, err)
+	}
+
+	supplier := rm.Risks["RISK-001"]
+	if supplier.Status != RiskStatusMitigated || supplier.Likelihood != RiskLevelHigh || supplier.Impact != RiskLevelVeryHigh || supplier.Priority != PriorityHigh {
+		t.Errorf("Unexpected assessment of imported risk %+v", supplier)
+	}
+	if len(supplier.Causes) != 2 || len(supplier.Mitigation) != 2 || supplier.Mitigation[1].Responsible != "alice" || supplier.Mitigation[1].ID != "RISK-001-M2" {
+		t.Errorf("Expected lists to be split and actions assigned to the owner, got %+v", supplier)
+	}
+	if !supplier.NextReviewDate.Equal(time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected review date to be imported, got %v", supplier.NextReviewDate)
+	}
+	if rm.Risks["RISK-002"].Status != RiskStatusMonitored || rm.Risks["RISK-003"].Status != RiskStatusIdentified {
+		t.Error("Expected given status to be kept and unassessed risks to be identified")
+	}
+	if rm.Register.OrganizationRisks[0].RiskID != "RISK-001" || rm.Register.OrganizationRisks[0].RiskScore != 12 {
+		t.Errorf("Expected register to be rebuilt by score, got %+v", rm.Register.OrganizationRisks[0])
+	}
+
+	// Rows whose ID is in use are reported; unreadable sheets import nothing
+	result, err = rm.ImportRegisterCSV(strings.NewReader("Risk ID,Description\nRISK-001,Again\nRISK-004,Power outage\n"))
+	if err != nil || result.Added != 1 || len(result.Errors) != 1 || result.Errors[0].ID != "RISK-001" {
+		t.Errorf("Expected duplicate risk to be reported, got %+v %v", result, err)
+	}
+	for _, bad := range []string{
+		"Risk ID,Description,Severity\nRISK-009,x,3\n",
+		"Risk ID,Description,Likelihood,Impact\nRISK-009,x,sometimes,high\n",
+		"Risk ID,Description,Likelihood\nRISK-009,x,high\n",
+		"Description\nx\n",
+	} {
+		if _, err := rm.ImportRegisterCSV(strings.NewReader(bad)); err == nil {
+			t.Errorf("Expected %q to be rejected", bad)
+		}
+	}
+	if _, exists := rm.Risks["RISK-009"]; exists {
+		t.Error("Expected nothing to be imported from a rejected sheet")
+	}
+
+	// The export reads back into a register with the same entries
+	var exported bytes.Buffer
+	if err := rm.Register.ExportCSV(&exported); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(exported.String(), strings.Join(RiskRegisterColumns, ",")+"\n") {
+		t.Errorf("Expected header row of the register columns, got %q", exported.String())
+	}
+	if !strings.Contains(exported.String(), "RISK-001,Supplier delivers late,Single source; Long lead time,Line stoppage,high,very_high,12,Qualify second supplier; Keep safety stock,alice,mitigated,2026-12-01") {
+		t.Errorf("Unexpected export %q", exported.String())
+	}
+
+	// A manager decoded from JSON exports the columns its register entries do not keep
+	data, err := json.Marshal(rm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded RiskManager
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	var reexported bytes.Buffer
+	if err := decoded.ExportRegisterCSV(&reexported); err != nil {
+		t.Fatal(err)
+	}
+	if reexported.String() != exported.String() {
+		t.Errorf("Expected the decoded manager to export the same register, got %q", reexported.String())
+	}
+
+	register := &RiskRegister{OrganizationRisks: []RiskEntry{{RiskID: "RISK-001", Description: "Outdated"}, {RiskID: "RISK-100", RiskScore: 20}}}
+	if err := register.ImportCSV(&exported); err != nil {
+		t.Fatal(err)
+	}
+	if len(register.OrganizationRisks) != 5 || register.OrganizationRisks[0].RiskID != "RISK-100" || register.OrganizationRisks[1].RiskID != "RISK-001" {
+		t.Fatalf("Expected imported entries to replace and merge by score, got %+v", register.OrganizationRisks)
+	}
+	if entry := register.OrganizationRisks[1]; entry.Description != "Supplier delivers late" || entry.risk == nil || entry.risk.Owner != "alice" || len(entry.risk.Mitigation) != 2 {
+		t.Errorf("Expected entry to survive the round trip, got %+v", entry)
+	}
+	if len(register.CriticalRisks) != 5 {
+		t.Errorf("Expected critical risks to be refreshed, got %d", len(register.CriticalRisks))
+	}
+}
+
+func TestRiskRegisterCSVRoundTrip(t *testing.T) {
+	entry := &Risk{
+		ID:          "RISK-001",
+		Description: `=HYPERLINK("http://example.com","Open")`,
+		Causes:      []string{"+1 shift missing", "Supplier A; Supplier B", `C:\specs\bom.xlsx`},
+		Effects:     []string{"-5% yield", `trailing \`},
+		Likelihood:  RiskLevelHigh,
+		Impact:      RiskLevelMedium,
+		Mitigation:  []Action{{ID: "RISK-001-M1", Description: "@SUM(A1:A9)"}, {ID: "RISK-001-M2", Description: "'quoted' plan"}},
+		Owner:       "'=alice",
+		Status:      RiskStatusMitigated,
+	}
+	rm := NewRiskManager()
+	rm.Risks[entry.ID] = entry
+	rm.RebuildRegister()
+
+	var exported bytes.Buffer
+	if err := rm.Register.ExportCSV(&exported); err != nil {
+		t.Fatal(err)
+	}
+	for _, cell := range []string{`"'=HYPERLINK(""http://example.com"",""Open"")"`, `'+1 shift missing; Supplier A\; Supplier B; C:\\specs\\bom.xlsx`, `'-5% yield; trailing \\`, `'@SUM(A1:A9); 'quoted' plan`, `''=alice`} {
+		if !strings.Contains(exported.String(), cell) {
+			t.Errorf("Expected cell %s in export %q", cell, exported.String())
+		}
+	}
+
+	risks, err := ReadRiskRegisterCSV(&exported)
+	if err != nil {
+		t.Fatalf("Failed to read export: %v", err)
+	}
+	if len(risks) != 1 {
+		t.Fatalf("Expected one risk, got %d", len(risks))
+	}
+	risk := risks[0]
+	if risk.ID != entry.ID || risk.Description != entry.Description || risk.Owner != entry.Owner {
+		t.Errorf("Expected text cells to survive the round trip, got %q %q %q", risk.ID, risk.Description, risk.Owner)
+	}
+	if strings.Join(risk.Causes, "|") != strings.Join(entry.Causes, "|") || strings.Join(risk.Effects, "|") != strings.Join(entry.Effects, "|") {
+		t.Errorf("Expected causes and effects to survive the round trip, got %q %q", risk.Causes, risk.Effects)
+	}
+	if descriptions := mitigationDescriptions(risk.Mitigation); strings.Join(descriptions, "|") != strings.Join(mitigationDescriptions(entry.Mitigation), "|") {
+		t.Errorf("Expected mitigation actions to survive the round trip, got %q", descriptions)
+	}
+	if risk.Likelihood != entry.Likelihood || risk.Impact != entry.Impact || risk.Status != entry.Status {
+		t.Errorf("Expected assessment to survive the round trip, got %+v", risk)
+	}
+}
@@ -11,6 +11,16 @@ type RiskManager struct {
 	Risks        map[string]*Risk        `json:"risks" yaml:"risks"`
 	Opportunities map[string]*Opportunity `json:"opportunities" yaml:"opportunities"`
 	Register     *RiskRegister           `json:"register" yaml:"register"`
+	// Appetite is the organization's risk appetite; DefaultRiskAppetite when nil
+	Appetite *RiskAppetite `json:"appetite,omitempty" yaml:"appetite,omitempty"`
+	// FMEA holds the failure mode and effects analysis of each process, by process ID
+	FMEA map[string]*FMEAWorksheet `json:"fmea,omitempty" yaml:"fmea,omitempty"`
+
+	// IDs, when set, is shared with the organization's other managers so that risk and
+	// opportunity IDs cannot collide with IDs of other entity types
+	IDs *IDRegistry `json:"-" yaml:"-"`
+	// DueDates, when set, evaluates mitigation deadlines in the organization's time zone
+	DueDates *DueDateCalculator `json:"-" yaml:"-"`
 }
 
 // RiskRegister maintains a comprehensive register of all risks and opportunities
@@ -31,9 +41,14 @@ type RiskEntry struct {
 	Probability  RiskLevel  `json:"probability" yaml:"probability"`
 	Impact       RiskLevel  `json:"impact" yaml:"impact"`
 	RiskScore    int        `json:"risk_score" yaml:"risk_score"`
+	ResidualScore int       `json:"residual_score" yaml:"residual_score"`
+	ExceedsAppetite bool    `json:"exceeds_appetite,omitempty" yaml:"exceeds_appetite,omitempty"`
 	Priority     string     `json:"priority" yaml:"priority"`
 	Status       RiskStatus `json:"status" yaml:"status"`
 	LastAssessed time.Time  `json:"last_assessed" yaml:"last_assessed"`
+	NextReviewDate time.Time `json:"next_review_date,omitempty" yaml:"next_review_date,omitempty"`
+
+	risk *Risk // the risk the entry was built from, read when the register is exported
 }
 
 // RiskType represents the type of risk
@@ -53,6 +68,7 @@ func NewRiskManager() *RiskManager {
 	return &RiskManager{
 		Risks:         make(map[string]*Risk),
 		Opportunities: make(map[string]*Opportunity),
+		FMEA:          make(map[string]*FMEAWorksheet),
 		Register: &RiskRegister{
 			ProcessRisks: make(map[string][]RiskEntry),
 		},
@@ -61,35 +77,51 @@ func NewRiskManager() *RiskManager {
 
 // IdentifyRisk identifies a new risk
 func (rm *RiskManager) IdentifyRisk(risk *Risk) error {
-	if risk.ID == "" {
-		return fmt.Errorf("risk must have an ID")
+	if err := checkNewRisk(risk); err != nil {
+		return err
 	}
-	if risk.Description == "" {
-		return fmt.Errorf("risk must have a description")
+	_, exists := rm.Risks[risk.ID]
+	if err := claimID(rm.IDs, risk.ID, EntityTypeRisk, exists); err != nil {
+		return err
 	}
 
 	risk.Created = time.Now()
 	risk.Status = RiskStatusIdentified
 
 	rm.Risks[risk.ID] = risk
-	rm.updateRegister()
+	rm.updateRegister(risk.ID)
 
 	return nil
 }
 
+// checkNewRisk verifies the fields required to identify a risk
+func checkNewRisk(risk *Risk) error {
+	if risk.ID == "" {
+		return fmt.Errorf("risk must have an ID")
+	}
+	if risk.Description == "" {
+		return fmt.Errorf("risk must have a description")
+	}
+	return nil
+}
+
 // AssessRisk performs risk assessment
 func (rm *RiskManager) AssessRisk(riskID string, likelihood, impact RiskLevel) error {
 	risk, exists := rm.Risks[riskID]
 	if !exists {
 		return fmt.Errorf("risk with ID %s not found", riskID)
 	}
+	if err := riskTransitions.check(EntityTypeRisk, riskID, risk.Status, RiskStatusAssessed); err != nil {
+		return err
+	}
 
 	risk.Likelihood = likelihood
 	risk.Impact = impact
 	risk.Priority = rm.calculatePriority(likelihood, impact)
 	risk.Status = RiskStatusAssessed
+	rm.recordReview(risk, time.Now())
 
-	rm.updateRegister()
+	rm.updateRegister(riskID)
 	return nil
 }
 
@@ -99,11 +131,14 @@ func (rm *RiskManager) MitigateRisk(riskID string, actions []Action) error {
 	if !exists {
 		return fmt.Errorf("risk with ID %s not found", riskID)
 	}
+	if err := riskTransitions.check(EntityTypeRisk, riskID, risk.Status, RiskStatusMitigated); err != nil {
+		return err
+	}
 
 	risk.Mitigation = append(risk.Mitigation, actions...)
 	risk.Status = RiskStatusMitigated
 
-	rm.updateRegister()
+	rm.updateRegister(riskID)
 	return nil
 }
 
@@ -113,30 +148,45 @@ func (rm *RiskManager) MonitorRisk(riskID string, status RiskStatus) error {
 	if !exists {
 		return fmt.Errorf("risk with ID %s not found", riskID)
 	}
+	if err := riskTransitions.check(EntityTypeRisk, riskID, risk.Status, status); err != nil {
+		return err
+	}
 
 	risk.Status = status
-	rm.updateRegister()
+	rm.updateRegister(riskID)
 	return nil
 }
 
 // IdentifyOpportunity identifies a new opportunity
 func (rm *RiskManager) IdentifyOpportunity(opportunity *Opportunity) error {
-	if opportunity.ID == "" {
-		return fmt.Errorf("opportunity must have an ID")
+	if err := checkNewOpportunity(opportunity); err != nil {
+		return err
 	}
-	if opportunity.Description == "" {
-		return fmt.Errorf("opportunity must have a description")
+	_, exists := rm.Opportunities[opportunity.ID]
+	if err := claimID(rm.IDs, opportunity.ID, EntityTypeOpportunity, exists); err != nil {
+		return err
 	}
 
 	opportunity.Created = time.Now()
 	opportunity.Status = OpportunityStatusIdentified
 
 	rm.Opportunities[opportunity.ID] = opportunity
-	rm.updateRegister()
+	rm.Register.LastUpdated = time.Now()
 
 	return nil
 }
 
+// checkNewOpportunity verifies the fields required to identify an opportunity
+func checkNewOpportunity(opportunity *Opportunity) error {
+	if opportunity.ID == "" {
+		return fmt.Errorf("opportunity must have an ID")
+	}
+	if opportunity.Description == "" {
+		return fmt.Errorf("opportunity must have a description")
+	}
+	return nil
+}
+
 // RealizeOpportunity plans the realization of an opportunity
 func (rm *RiskManager) RealizeOpportunity(opportunityID string, actions []Action) error {
 	opportunity, exists := rm.Opportunities[opportunityID]
@@ -147,7 +197,7 @@ func (rm *RiskManager) RealizeOpportunity(opportunityID string, actions []Action
 	opportunity.Actions = actions
 	opportunity.Status = OpportunityStatusPlanned
 
-	rm.updateRegister()
+	rm.Register.LastUpdated = time.Now()
 	return nil
 }
 
@@ -184,7 +234,7 @@ func (rm *RiskManager) GetOverdueMitigations() []*Risk {
 
 	for _, risk := range rm.Risks {
 		for _, action := range risk.Mitigation {
-			if action.Status != ActionStatusCompleted && action.Timeline.Before(now) {
+			if action.Status != ActionStatusCompleted && rm.DueDates.IsOverdue(action.Timeline, now) {
 				overdue = append(overdue, risk)
 				break
 			}
@@ -245,6 +295,8 @@ func (rm *RiskManager) GetRiskStatistics() RiskStatistics {
 			stats.Mitigated++
 		case RiskStatusMonitored:
 			stats.Monitored++
+		case RiskStatusOverdue:
+			stats.Overdue++
 		}
 
 		switch risk.Priority {
@@ -281,6 +333,7 @@ type RiskStatistics struct {
 	Assessed     int `json:"assessed" yaml:"assessed"`
 	Mitigated    int `json:"mitigated" yaml:"mitigated"`
 	Monitored    int `json:"monitored" yaml:"monitored"`
+	Overdue      int `json:"overdue" yaml:"overdue"` // risks whose scheduled review has passed
 	Critical     int `json:"critical" yaml:"critical"`
 	High         int `json:"high" yaml:"high"`
 	Medium       int `json:"medium" yaml:"medium"`
@@ -295,6 +348,11 @@ type RiskStatistics struct {
 type QualityObjectivesManager struct {
 	Objectives map[string]*QualityObjective `json:"objectives" yaml:"objectives"`
 	Tracker    *ObjectivesTracker           `json:"tracker" yaml:"tracker"`
+
+	// IDs, when set, is shared with the organization's other managers
+	IDs *IDRegistry `json:"-" yaml:"-"`
+	// DueDates, when set, evaluates target dates in the organization's time zone
+	DueDates *DueDateCalculator `json:"-" yaml:"-"`
 }
 
 // ObjectivesTracker tracks progress against quality objectives
@@ -311,6 +369,8 @@ type ObjectiveProgress struct {
 	Progress    float64   `json:"progress" yaml:"progress"` // 0-100
 	Status      string    `json:"status" yaml:"status"`
 	Comments    string    `json:"comments" yaml:"comments"`
+	Source      ProgressSource   `json:"source,omitempty" yaml:"source,omitempty"`     // manual report or computed from measurements
+	Targets     []TargetProgress `json:"targets,omitempty" yaml:"targets,omitempty"`   // per-target results of measured progress
 }
 
 // ObjectiveAchievement represents the achievement of a quality objective
@@ -339,6 +399,23 @@ func NewQualityObjectivesManager() *QualityObjectivesManager {
 
 // CreateObjective creates a new quality objective
 func (qom *QualityObjectivesManager) CreateObjective(objective *QualityObjective) error {
+	if err := checkNewObjective(objective); err != nil {
+		return err
+	}
+	_, exists := qom.Objectives[objective.ID]
+	if err := claimID(qom.IDs, objective.ID, EntityTypeObjective, exists); err != nil {
+		return err
+	}
+
+	objective.Created = time.Now()
+	objective.Status = ObjectiveStatusPlanned
+
+	qom.Objectives[objective.ID] = objective
+	return nil
+}
+
+// checkNewObjective verifies the fields required to create a quality objective
+func checkNewObjective(objective *QualityObjective) error {
 	if objective.ID == "" {
 		return fmt.Errorf("objective must have an ID")
 	}
@@ -354,11 +431,6 @@ func (qom *QualityObjectivesManager) CreateObjective(objective *QualityObjective
 	if objective.Responsible == "" {
 		return fmt.Errorf("objective must have a responsible party")
 	}
-
-	objective.Created = time.Now()
-	objective.Status = ObjectiveStatusPlanned
-
-	qom.Objectives[objective.ID] = objective
 	return nil
 }
 
@@ -369,7 +441,23 @@ func (qom *QualityObjectivesManager) UpdateObjectiveProgress(objectiveID string,
 		return fmt.Errorf("objective with ID %s not found", objectiveID)
 	}
 
+	// Determine the status implied by the reported progress
+	status := objective.Status
+	if progress.Progress >= 100 {
+		status = ObjectiveStatusAchieved
+	} else if progress.Progress > 0 {
+		status = ObjectiveStatusInProgress
+	}
+	if status != objective.Status {
+		if err := objectiveTransitions.check(EntityTypeObjective, objectiveID, objective.Status, status); err != nil {
+			return err
+		}
+	}
+
 	progress.ObjectiveID = objectiveID
+	if progress.Source == "" {
+		progress.Source = ProgressSourceManual
+	}
 	qom.Tracker.ProgressReports = append(qom.Tracker.ProgressReports, progress)
 
 	// Update objective status based on progress
@@ -388,6 +476,21 @@ func (qom *QualityObjectivesManager) UpdateObjectiveProgress(objectiveID string,
 	return nil
 }
 
+// SetObjectiveStatus moves an objective to a new status, e.g. to record that it was
+// not achieved by its target date
+func (qom *QualityObjectivesManager) SetObjectiveStatus(objectiveID string, status ObjectiveStatus) error {
+	objective, exists := qom.Objectives[objectiveID]
+	if !exists {
+		return fmt.Errorf("objective with ID %s not found", objectiveID)
+	}
+	if err := objectiveTransitions.check(EntityTypeObjective, objectiveID, objective.Status, status); err != nil {
+		return err
+	}
+
+	objective.Status = status
+	return nil
+}
+
 // GetAchievedObjectives returns objectives that have been achieved
 func (qom *QualityObjectivesManager) GetAchievedObjectives() []*QualityObjective {
 	var achieved []*QualityObjective
@@ -407,7 +510,7 @@ func (qom *QualityObjectivesManager) GetOverdueObjectives() []*QualityObjective
 	now := time.Now()
 
 	for _, objective := range qom.Objectives {
-		if objective.Status != ObjectiveStatusAchieved && objective.Timeline.TargetDate.Before(now) {
+		if objective.Status != ObjectiveStatusAchieved && qom.DueDates.IsOverdue(objective.Timeline.TargetDate, now) {
 			overdue = append(overdue, objective)
 		}
 	}
@@ -449,6 +552,19 @@ func (qom *QualityObjectivesManager) CalculateObjectiveProgress() ObjectiveProgr
 		summary.AchievementRate = float64(summary.Achieved) / float64(summary.TotalObjectives) * 100
 	}
 
+	// Trends are those last computed with ComputeTrends
+	for _, trend := range qom.Tracker.Trends {
+		switch trend.Trend {
+		case TrendImproving:
+			summary.Improving++
+		case TrendStable:
+			summary.Stable++
+		case TrendDeclining:
+			summary.Declining++
+		}
+	}
+	summary.Trends = qom.Tracker.Trends
+
 	return summary
 }
 
@@ -460,6 +576,10 @@ type ObjectiveProgressSummary struct {
 	Achieved        int     `json:"achieved" yaml:"achieved"`
 	NotAchieved     int     `json:"not_achieved" yaml:"not_achieved"`
 	AchievementRate float64 `json:"achievement_rate" yaml:"achievement_rate"`
+	Improving       int              `json:"improving" yaml:"improving"`
+	Stable          int              `json:"stable" yaml:"stable"`
+	Declining       int              `json:"declining" yaml:"declining"`
+	Trends          []ObjectiveTrend `json:"trends,omitempty" yaml:"trends,omitempty"`
 }
 
 // Helper methods
@@ -509,37 +629,106 @@ func (rm *RiskManager) comparePriority(a, b Priority) int {
 	return priorityOrder[a] - priorityOrder[b]
 }
 
-func (rm *RiskManager) updateRegister() {
+// criticalRiskLimit is the number of highest scoring risks kept in the critical risks list
+const criticalRiskLimit = 10
+
+// RebuildRegister rebuilds the risk register from scratch. Use it after modifying
+// the Risks map directly; the manager methods maintain the register incrementally.
+func (rm *RiskManager) RebuildRegister() {
 	rm.Register.LastUpdated = time.Now()
 
 	// Update organization risks
 	var orgRisks []RiskEntry
-	for id, risk := range rm.Risks {
-		entry := RiskEntry{
-			RiskID:       id,
-			Description:  risk.Description,
-			Type:         RiskTypeOperational, // Default, could be enhanced
-			Probability:  risk.Likelihood,
-			Impact:       risk.Impact,
-			RiskScore:    rm.getRiskScore(risk.Likelihood) * rm.getRiskScore(risk.Impact),
-			Priority:     string(risk.Priority),
-			Status:       risk.Status,
-			LastAssessed: time.Now(),
-		}
-		orgRisks = append(orgRisks, entry)
+	for _, risk := range rm.Risks {
+		risk.ExceedsAppetite = rm.ExceedsAppetite(risk)
+		orgRisks = append(orgRisks, rm.newRiskEntry(risk))
 	}
 
 	// Sort by risk score descending
-	sort.Slice(orgRisks, func(i, j int) bool {
+	sort.SliceStable(orgRisks, func(i, j int) bool {
 		return orgRisks[i].RiskScore > orgRisks[j].RiskScore
 	})
 
 	rm.Register.OrganizationRisks = orgRisks
+	rm.refreshCriticalRisks()
+}
+
+// updateRegister inserts, updates or removes the register entry of a single risk
+// while keeping the register ordered by risk score descending. An updated entry is
+// moved to its new position, shifting only the entries in between.
+func (rm *RiskManager) updateRegister(riskID string) {
+	rm.Register.LastUpdated = time.Now()
+
+	entries := rm.Register.OrganizationRisks
+	old := -1
+	for i := range entries {
+		if entries[i].RiskID == riskID {
+			old = i
+			break
+		}
+	}
+
+	risk, exists := rm.Risks[riskID]
+	switch {
+	case exists:
+		risk.ExceedsAppetite = rm.ExceedsAppetite(risk)
+		entry := rm.newRiskEntry(risk)
+		if old < 0 {
+			entries = append(entries, RiskEntry{})
+			old = len(entries) - 1
+		}
+
+		// Insert after any other entries with an equal or higher score
+		pos := sort.Search(len(entries)-1, func(i int) bool {
+			if i >= old {
+				i++
+			}
+			return entries[i].RiskScore < entry.RiskScore
+		})
+		if pos < old {
+			copy(entries[pos+1:old+1], entries[pos:old])
+		} else {
+			copy(entries[old:pos], entries[old+1:pos+1])
+		}
+		entries[pos] = entry
+	case old >= 0:
+		entries = append(entries[:old], entries[old+1:]...)
+	}
+
+	rm.Register.OrganizationRisks = entries
+	rm.refreshCriticalRisks()
+}
+
+// refreshCriticalRisks copies the top scoring entries of the sorted register
+func (rm *RiskManager) refreshCriticalRisks() {
+	rm.Register.refreshCriticalRisks()
+}
+
+func (rr *RiskRegister) refreshCriticalRisks() {
+	n := len(rr.OrganizationRisks)
+	if n > criticalRiskLimit {
+		n = criticalRiskLimit
+	}
+
+	critical := make([]RiskEntry, n)
+	copy(critical, rr.OrganizationRisks[:n])
+	rr.CriticalRisks = critical
+}
 
-	// Update critical risks (top 10 highest scoring)
-	if len(orgRisks) > 10 {
-		rm.Register.CriticalRisks = orgRisks[:10]
-	} else {
-		rm.Register.CriticalRisks = orgRisks
+func (rm *RiskManager) newRiskEntry(risk *Risk) RiskEntry {
+	return RiskEntry{
+		RiskID:       risk.ID,
+		Description:  risk.Description,
+		Type:         RiskTypeOperational, // Default, could be enhanced
+		Probability:  risk.Likelihood,
+		Impact:       risk.Impact,
+		RiskScore:    rm.getRiskScore(risk.Likelihood) * rm.getRiskScore(risk.Impact),
+		ResidualScore: rm.residualScore(risk),
+		ExceedsAppetite: risk.ExceedsAppetite,
+		Priority:     string(risk.Priority),
+		Status:       risk.Status,
+		LastAssessed: time.Now(),
+		NextReviewDate: risk.NextReviewDate,
+		risk:         risk,
 	}
 }
@@ -0,0 +1,68 @@
+package iso9001
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// SetReviewFrequency schedules the periodic reassessment of a risk. The next review
+// falls one frequency after the last review, or after now when the risk has not been
+// reviewed yet. A zero frequency removes the schedule.
+func (rm *RiskManager) SetReviewFrequency(riskID string, frequency time.Duration) error {
+	risk, exists := rm.Risks[riskID]
+	if !exists {
+		return fmt.Errorf("risk with ID %s not found", riskID)
+	}
+	if frequency < 0 {
+		return fmt.Errorf("review frequency of risk %s must not be negative", riskID)
+	}
+
+	risk.ReviewFrequency = frequency
+	risk.NextReviewDate = time.Time{}
+	if frequency > 0 {
+		from := risk.LastReviewed
+		if from.IsZero() {
+			from = time.Now()
+		}
+		risk.NextReviewDate = from.Add(frequency)
+	}
+
+	rm.updateRegister(riskID)
+	return nil
+}
+
+// recordReview notes that a risk was reassessed and schedules its next review
+func (rm *RiskManager) recordReview(risk *Risk, now time.Time) {
+	risk.LastReviewed = now
+	if risk.ReviewFrequency > 0 {
+		risk.NextReviewDate = now.Add(risk.ReviewFrequency)
+	}
+}
+
+// GetRisksDueForReview returns the risks whose scheduled review has passed, longest
+// overdue first. Risks found due are set to overdue in the risk register until they
+// are reassessed.
+func (rm *RiskManager) GetRisksDueForReview() []*Risk {
+	var due []*Risk
+	now := time.Now()
+
+	for _, risk := range rm.Risks {
+		if risk.Status == RiskStatusIdentified || !rm.DueDates.IsOverdue(risk.NextReviewDate, now) {
+			continue
+		}
+		due = append(due, risk)
+		if risk.Status != RiskStatusOverdue {
+			risk.Status = RiskStatusOverdue
+			rm.updateRegister(risk.ID)
+		}
+	}
+
+	sort.Slice(due, func(i, j int) bool {
+		if !due[i].NextReviewDate.Equal(due[j].NextReviewDate) {
+			return due[i].NextReviewDate.Before(due[j].NextReviewDate)
+		}
+		return due[i].ID < due[j].ID
+	})
+	return due
+}
@@ -0,0 +1,68 @@
+package iso9001
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRiskReviewSchedule(t *testing.T) {
+	rm := NewRiskManager()
+	for _, risk := range []*Risk{
+		{ID: "RISK-001", Description: "Key supplier fails", ReviewFrequency: 90 * 24 * time.Hour},
+		{ID: "RISK-002", Description: "Calibration lapses"},
+		{ID: "RISK-003", Description: "New regulation", ReviewFrequency: 30 * 24 * time.Hour},
+	} {
+		if err := rm.IdentifyRisk(risk); err != nil {
+			t.Fatal(err)
+		}
+	}
+	rm.AssessRisk("RISK-001", RiskLevelHigh, RiskLevelHigh)
+	rm.AssessRisk("RISK-002", RiskLevelMedium, RiskLevelMedium)
+
+	risk := rm.Risks["RISK-001"]
+	if risk.LastReviewed.IsZero() || !risk.NextReviewDate.Equal(risk.LastReviewed.Add(risk.ReviewFrequency)) {
+		t.Errorf("Expected assessment to schedule the next review, got %v", risk.NextReviewDate)
+	}
+	if !rm.Risks["RISK-002"].NextReviewDate.IsZero() {
+		t.Error("Expected risk without a review frequency to have no scheduled review")
+	}
+	if err := rm.SetReviewFrequency("RISK-002", 7*24*time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if err := rm.SetReviewFrequency("RISK-002", -time.Hour); err == nil {
+		t.Error("Expected negative review frequency to be rejected")
+	}
+	if len(rm.GetRisksDueForReview()) != 0 {
+		t.Error("Expected no risk to be due for review")
+	}
+
+	// Let the reviews lapse; the unassessed risk is never due
+	rm.Risks["RISK-001"].NextReviewDate = time.Now().Add(-48 * time.Hour)
+	rm.Risks["RISK-002"].NextReviewDate = time.Now().Add(-24 * time.Hour)
+	rm.Risks["RISK-003"].NextReviewDate = time.Now().Add(-24 * time.Hour)
+	due := rm.GetRisksDueForReview()
+	if len(due) != 2 || due[0].ID != "RISK-001" || due[1].ID != "RISK-002" {
+		t.Fatalf("Expected two risks due for review, longest overdue first, got %v", due)
+	}
+	for _, entry := range rm.Register.OrganizationRisks {
+		if overdue := entry.RiskID != "RISK-003"; (entry.Status == RiskStatusOverdue) != overdue {
+			t.Errorf("Expected register entry %s overdue=%v, got %s", entry.RiskID, overdue, entry.Status)
+		}
+	}
+	if stats := rm.GetRiskStatistics(); stats.Overdue != 2 {
+		t.Errorf("Expected 2 overdue risks, got %d", stats.Overdue)
+	}
+	if err := rm.MonitorRisk("RISK-001", RiskStatusMonitored); err == nil {
+		t.Error("Expected overdue risk to need reassessment before monitoring")
+	}
+
+	if err := rm.AssessRisk("RISK-001", RiskLevelMedium, RiskLevelHigh); err != nil {
+		t.Fatal(err)
+	}
+	if risk.Status != RiskStatusAssessed || !risk.NextReviewDate.After(time.Now()) {
+		t.Errorf("Expected reassessment to clear the overdue review, got %s %v", risk.Status, risk.NextReviewDate)
+	}
+	if due := rm.GetRisksDueForReview(); len(due) != 1 || due[0].ID != "RISK-002" {
+		t.Errorf("Expected only RISK-002 to remain due, got %v", due)
+	}
+}
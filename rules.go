@@ -0,0 +1,477 @@
+package iso9001
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Validation rule errors
+var (
+	ErrInvalidRule = errors.New("invalid validation rule")
+	ErrUnknownRule = errors.New("validation rule not found")
+)
+
+// Severities of validation rules and the issues they raise
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+	SeverityInfo    = "info"
+)
+
+// ValidationRule declares one requirement an organization is validated against. A
+// rule either has a Predicate, raising Message under Field when it is not satisfied,
+// or a Check reporting each item that violates it.
+type ValidationRule struct {
+	ID       string `json:"id" yaml:"id"`
+	Clause   string `json:"clause" yaml:"clause"`
+	Severity string `json:"severity" yaml:"severity"` // "error", "warning", "info"
+	Field    string `json:"field,omitempty" yaml:"field,omitempty"`
+	// Message is the issue text, or a format string filled from the Args of violations
+	Message string `json:"message" yaml:"message"`
+
+	// Predicate reports whether the organization satisfies the rule
+	Predicate func(org *Organization) bool `json:"-" yaml:"-"`
+	// Check returns every violation of the rule, e.g. one per process without a description
+	Check func(org *Organization) []RuleViolation `json:"-" yaml:"-"`
+}
+
+// RuleViolation is one violation reported by the Check of a rule
+type RuleViolation struct {
+	Field string        // field the issue is raised under; the rule's Field when empty
+	Args  []interface{} // arguments of the rule's Message
+}
+
+// validate checks that the rule can be registered
+func (rule ValidationRule) validate() error {
+	switch {
+	case rule.ID == "":
+		return fmt.Errorf("%w: rule must have an ID", ErrInvalidRule)
+	case rule.Clause == "":
+		return fmt.Errorf("%w: rule %s must name a clause", ErrInvalidRule, rule.ID)
+	case !containsString(rule.Severity, SeverityError, SeverityWarning, SeverityInfo):
+		return fmt.Errorf("%w: rule %s has unknown severity %q", ErrInvalidRule, rule.ID, rule.Severity)
+	case rule.Message == "":
+		return fmt.Errorf("%w: rule %s must have a message", ErrInvalidRule, rule.ID)
+	case rule.Predicate == nil && rule.Check == nil:
+		return fmt.Errorf("%w: rule %s needs a predicate or a check", ErrInvalidRule, rule.ID)
+	}
+	return nil
+}
+
+// check applies the rule to an organization, adding its issues to result
+func (rule *ValidationRule) check(org *Organization, result *ValidationResult) {
+	if rule.Predicate != nil && !rule.Predicate(org) {
+		result.addIssue(rule.Severity, rule.Clause, rule.Field, rule.Message)
+	}
+	if rule.Check != nil {
+		violations := rule.Check(org)
+		result.reserve(rule.Severity, len(violations))
+		for _, violation := range violations {
+			field := violation.Field
+			if field == "" {
+				field = rule.Field
+			}
+			result.addIssue(rule.Severity, rule.Clause, field, rule.Message, violation.Args...)
+		}
+	}
+}
+
+// RuleRegistry holds the validation rules, in the order their issues are reported.
+// Rules can be disabled by ID without removing them.
+type RuleRegistry struct {
+	mu       sync.RWMutex
+	rules    []ValidationRule
+	disabled map[string]bool
+	enabled  []ValidationRule // the enabled rules, rebuilt whenever rules or disabled change
+}
+
+// DefaultRules is the registry used by ValidateOrganization and GetComplianceScore.
+// Rules registered or disabled here apply to every validation that does not pass
+// its own registry.
+var DefaultRules = NewRuleRegistry()
+
+// NewRuleRegistry creates a registry holding the built-in ISO 9001 rules
+func NewRuleRegistry() *RuleRegistry {
+	r := &RuleRegistry{rules: builtinRules(), disabled: make(map[string]bool)}
+	r.refresh()
+	return r
+}
+
+// Register adds rules after the existing ones. IDs must be unique.
+func (r *RuleRegistry) Register(rules ...ValidationRule) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, rule := range rules {
+		if err := rule.validate(); err != nil {
+			return err
+		}
+		if r.find(rule.ID) >= 0 || containsRule(rules[:i], rule.ID) {
+			return fmt.Errorf("%w: rule %s is already registered", ErrInvalidRule, rule.ID)
+		}
+	}
+	r.rules = append(r.rules, rules...)
+	r.refresh()
+	return nil
+}
+
+// Disable turns rules off so they no longer raise issues
+func (r *RuleRegistry) Disable(ids ...string) error {
+	return r.setDisabled(ids, true)
+}
+
+// Enable turns disabled rules back on
+func (r *RuleRegistry) Enable(ids ...string) error {
+	return r.setDisabled(ids, false)
+}
+
+func (r *RuleRegistry) setDisabled(ids []string, disabled bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, id := range ids {
+		if r.find(id) < 0 {
+			return fmt.Errorf("%w: %s", ErrUnknownRule, id)
+		}
+	}
+	for _, id := range ids {
+		if disabled {
+			r.disabled[id] = true
+		} else {
+			delete(r.disabled, id)
+		}
+	}
+	r.refresh()
+	return nil
+}
+
+// Rules returns the enabled rules in order
+func (r *RuleRegistry) Rules() []ValidationRule {
+	return append([]ValidationRule(nil), r.enabledRules()...)
+}
+
+// enabledRules returns the enabled rules without copying them. The slice is replaced,
+// never modified, when the registry changes, so callers must not modify it either.
+func (r *RuleRegistry) enabledRules() []ValidationRule {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.enabled
+}
+
+// refresh rebuilds the enabled rules; the caller holds the write lock
+func (r *RuleRegistry) refresh() {
+	enabled := make([]ValidationRule, 0, len(r.rules))
+	for _, rule := range r.rules {
+		if !r.disabled[rule.ID] {
+			enabled = append(enabled, rule)
+		}
+	}
+	r.enabled = enabled
+}
+
+// Rule returns a registered rule, enabled or not
+func (r *RuleRegistry) Rule(id string) (ValidationRule, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if i := r.find(id); i >= 0 {
+		return r.rules[i], true
+	}
+	return ValidationRule{}, false
+}
+
+// Validate validates an organization against the enabled rules of the registry
+func (r *RuleRegistry) Validate(org *Organization) *ValidationResult {
+	return ValidateOrganizationWithOptions(org, ValidationOptions{Rules: r})
+}
+
+// find returns the index of a rule, or -1
+func (r *RuleRegistry) find(id string) int {
+	for i, rule := range r.rules {
+		if rule.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func containsRule(rules []ValidationRule, id string) bool {
+	for _, rule := range rules {
+		if rule.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// RuleSet is a file of company-specific rules and built-in rules to disable
+//
+//	disable: [4.2-regulators]
+//	rules:
+//	  - id: acme-process-description
+//	    clause: "4.4"
+//	    severity: error
+//	    for_each: qms.processes
+//	    label: name
+//	    field: process_%v_description
+//	    message: Process %v must be described
+//	    path: description
+//	    required: true
+type RuleSet struct {
+	Disable []string         `json:"disable,omitempty" yaml:"disable,omitempty"`
+	Rules   []RuleDefinition `json:"rules,omitempty" yaml:"rules,omitempty"`
+}
+
+// RuleDefinition declares a rule as data. Path names a value of the organization by
+// its JSON keys, e.g. "leadership.quality_policy.statement". With ForEach, the rule
+// is applied to every item of that list and Path is relative to the item; Label
+// names the item value, or its index when empty, that fills %v in Field and Message.
+//
+// The value at Path must be present when Required, hold at least MinCount items,
+// and, when set, match Pattern and be one of OneOf.
+type RuleDefinition struct {
+	ID       string   `json:"id" yaml:"id"`
+	Clause   string   `json:"clause" yaml:"clause"`
+	Severity string   `json:"severity" yaml:"severity"`
+	Field    string   `json:"field,omitempty" yaml:"field,omitempty"`
+	Message  string   `json:"message" yaml:"message"`
+	ForEach  string   `json:"for_each,omitempty" yaml:"for_each,omitempty"`
+	Label    string   `json:"label,omitempty" yaml:"label,omitempty"`
+	Path     string   `json:"path" yaml:"path"`
+	Required bool     `json:"required,omitempty" yaml:"required,omitempty"`
+	MinCount int      `json:"min_count,omitempty" yaml:"min_count,omitempty"`
+	Pattern  string   `json:"pattern,omitempty" yaml:"pattern,omitempty"`
+	OneOf    []string `json:"one_of,omitempty" yaml:"one_of,omitempty"`
+}
+
+// ParseRuleSetYAML decodes a rule set, rejecting unknown keys
+func ParseRuleSetYAML(data []byte) (*RuleSet, error) {
+	var set RuleSet
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&set); err != nil {
+		return nil, fmt.Errorf("invalid rule set: %w", err)
+	}
+	return &set, nil
+}
+
+// Load registers the rules of a rule set and disables the rules it lists. Nothing
+// changes when any rule is invalid.
+func (r *RuleRegistry) Load(set *RuleSet) error {
+	rules := make([]ValidationRule, 0, len(set.Rules))
+	for _, definition := range set.Rules {
+		rule, err := definition.Compile()
+		if err != nil {
+			return err
+		}
+		rules = append(rules, rule)
+	}
+
+	r.mu.RLock()
+	for _, id := range set.Disable {
+		if r.find(id) < 0 && !containsRule(rules, id) {
+			r.mu.RUnlock()
+			return fmt.Errorf("%w: %s", ErrUnknownRule, id)
+		}
+	}
+	r.mu.RUnlock()
+
+	if err := r.Register(rules...); err != nil {
+		return err
+	}
+	return r.Disable(set.Disable...)
+}
+
+// LoadYAML parses a YAML rule set and loads it like Load
+func (r *RuleRegistry) LoadYAML(data []byte) error {
+	set, err := ParseRuleSetYAML(data)
+	if err != nil {
+		return err
+	}
+	return r.Load(set)
+}
+
+// Compile turns the definition into a rule, checking its paths against the
+// organization model
+func (d RuleDefinition) Compile() (ValidationRule, error) {
+	rule := ValidationRule{ID: d.ID, Clause: d.Clause, Severity: d.Severity, Field: d.Field, Message: d.Message}
+	if d.Path == "" {
+		return rule, fmt.Errorf("%w: rule %s must have a path", ErrInvalidRule, d.ID)
+	}
+	if !d.Required && d.MinCount == 0 && d.Pattern == "" && len(d.OneOf) == 0 {
+		return rule, fmt.Errorf("%w: rule %s needs required, min_count, pattern or one_of", ErrInvalidRule, d.ID)
+	}
+
+	var pattern *regexp.Regexp
+	if d.Pattern != "" {
+		var err error
+		if pattern, err = regexp.Compile(d.Pattern); err != nil {
+			return rule, fmt.Errorf("%w: rule %s: %v", ErrInvalidRule, d.ID, err)
+		}
+	}
+
+	itemType := reflect.TypeOf(Organization{})
+	var items fieldPath
+	if d.ForEach != "" {
+		var err error
+		var listType reflect.Type
+		if items, listType, err = compileFieldPath(itemType, d.ForEach); err != nil {
+			return rule, fmt.Errorf("%w: rule %s: %v", ErrInvalidRule, d.ID, err)
+		}
+		if listType.Kind() != reflect.Slice && listType.Kind() != reflect.Array {
+			return rule, fmt.Errorf("%w: rule %s: %s is not a list", ErrInvalidRule, d.ID, d.ForEach)
+		}
+		itemType = listType.Elem()
+	}
+
+	path, valueType, err := compileFieldPath(itemType, d.Path)
+	if err != nil {
+		return rule, fmt.Errorf("%w: rule %s: %v", ErrInvalidRule, d.ID, err)
+	}
+	if d.MinCount > 0 && !containsKind(valueType.Kind(), reflect.Slice, reflect.Array, reflect.Map, reflect.String) {
+		return rule, fmt.Errorf("%w: rule %s: min_count needs a list at %s", ErrInvalidRule, d.ID, d.Path)
+	}
+
+	var label fieldPath
+	if d.Label != "" {
+		if label, _, err = compileFieldPath(itemType, d.Label); err != nil {
+			return rule, fmt.Errorf("%w: rule %s: %v", ErrInvalidRule, d.ID, err)
+		}
+	}
+
+	satisfied := func(item reflect.Value) bool {
+		value, ok := path.resolve(item)
+		present := ok && !value.IsZero()
+		if d.Required && !present {
+			return false
+		}
+		if d.MinCount > 0 && (!ok || value.Len() < d.MinCount) {
+			return false
+		}
+		if present && pattern != nil && !pattern.MatchString(valueString(value)) {
+			return false
+		}
+		if present && len(d.OneOf) > 0 && !containsString(valueString(value), d.OneOf...) {
+			return false
+		}
+		return true
+	}
+
+	if d.ForEach == "" {
+		rule.Predicate = func(org *Organization) bool {
+			return satisfied(reflect.ValueOf(org).Elem())
+		}
+		return rule, rule.validate()
+	}
+
+	rule.Check = func(org *Organization) []RuleViolation {
+		list, ok := items.resolve(reflect.ValueOf(org).Elem())
+		if !ok {
+			return nil
+		}
+		var violations []RuleViolation
+		for i := 0; i < list.Len(); i++ {
+			item := list.Index(i)
+			if satisfied(item) {
+				continue
+			}
+			var name interface{} = i
+			if label != nil {
+				if value, ok := label.resolve(item); ok {
+					name = valueString(value)
+				}
+			}
+			violations = append(violations, RuleViolation{Field: formatLabel(d.Field, name), Args: labelArgs(d.Message, name)})
+		}
+		return violations
+	}
+	return rule, rule.validate()
+}
+
+// fieldPath is a compiled path of struct field indexes
+type fieldPath [][]int
+
+// compileFieldPath resolves a dotted path of JSON keys against a type, returning the
+// field indexes and the type of the value at the end of the path
+func compileFieldPath(t reflect.Type, path string) (fieldPath, reflect.Type, error) {
+	var compiled fieldPath
+	for _, key := range strings.Split(path, ".") {
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if t.Kind() != reflect.Struct {
+			return nil, nil, fmt.Errorf("%s: %s has no fields", path, key)
+		}
+		field, ok := lookupJSONField(jsonFields(t), key)
+		if !ok {
+			return nil, nil, fmt.Errorf("%s: unknown field %s", path, key)
+		}
+		compiled = append(compiled, field.Index)
+		t = field.Type
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return compiled, t, nil
+}
+
+// resolve follows the path from a value, reporting false when a pointer on the way
+// is nil
+func (p fieldPath) resolve(v reflect.Value) (reflect.Value, bool) {
+	for _, index := range p {
+		for v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.FieldByIndex(index)
+	}
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return reflect.Value{}, false
+		}
+		v = v.Elem()
+	}
+	return v, true
+}
+
+// valueString formats a resolved value for patterns, lists and labels
+func valueString(v reflect.Value) string {
+	if v.Kind() == reflect.String {
+		return v.String()
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+func containsKind(kind reflect.Kind, kinds ...reflect.Kind) bool {
+	for _, k := range kinds {
+		if kind == k {
+			return true
+		}
+	}
+	return false
+}
+
+// formatLabel fills the item label into a field name containing a verb
+func formatLabel(format string, label interface{}) string {
+	if !strings.Contains(format, "%") {
+		return format
+	}
+	return fmt.Sprintf(format, label)
+}
+
+// labelArgs returns the message arguments of an item, none when the message has no verb
+func labelArgs(message string, label interface{}) []interface{} {
+	if !strings.Contains(message, "%") {
+		return nil
+	}
+	return []interface{}{label}
+}
@@ -0,0 +1,117 @@
+package iso9001
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRuleRegistry(t *testing.T) {
+	org := CreateExampleOrganization()
+	registry := NewRuleRegistry()
+	baseline := registry.Validate(org)
+
+	err := registry.Register(ValidationRule{
+		ID: "acme-iso-id", Clause: "4.3", Severity: SeverityError, Field: "id",
+		Message:   "Organization IDs must start with ACME",
+		Predicate: func(org *Organization) bool { return len(org.ID) > 4 && org.ID[:4] == "ACME" },
+	})
+	if err != nil {
+		t.Fatalf("Failed to register rule: %v", err)
+	}
+	if err := registry.Register(ValidationRule{ID: "4.1-context", Clause: "4.1", Severity: SeverityError, Message: "x", Predicate: func(*Organization) bool { return true }}); !errors.Is(err, ErrInvalidRule) {
+		t.Errorf("Expected duplicate rule ID to be rejected, got %v", err)
+	}
+	if err := registry.Register(ValidationRule{ID: "no-check", Clause: "4.1", Severity: SeverityError, Message: "x"}); !errors.Is(err, ErrInvalidRule) {
+		t.Errorf("Expected rule without predicate to be rejected, got %v", err)
+	}
+
+	result := registry.Validate(org)
+	if result.Valid || len(result.Errors) != len(baseline.Errors)+1 || result.Errors[len(result.Errors)-1].Field != "id" {
+		t.Errorf("Expected the custom rule to raise an error, got %+v", result.Errors)
+	}
+
+	if err := registry.Disable("acme-iso-id", "4.2-regulators"); err != nil {
+		t.Fatalf("Failed to disable rules: %v", err)
+	}
+	if err := registry.Disable("missing"); !errors.Is(err, ErrUnknownRule) {
+		t.Errorf("Expected unknown rule to be reported, got %v", err)
+	}
+	if result := registry.Validate(org); len(result.Errors) != len(baseline.Errors) {
+		t.Errorf("Expected disabled rule to be skipped, got %d errors", len(result.Errors))
+	}
+	if _, ok := registry.Rule("acme-iso-id"); !ok {
+		t.Error("Expected disabled rule to stay registered")
+	}
+
+	// DefaultRules is untouched by other registries
+	if len(DefaultRules.Rules()) != len(builtinRules()) {
+		t.Errorf("Expected default rules to be unchanged")
+	}
+}
+
+func TestRuleSetYAML(t *testing.T) {
+	org := CreateExampleOrganization()
+	org.QMS.Processes[0].Description = ""
+
+	registry := NewRuleRegistry()
+	err := registry.LoadYAML([]byte(`
+disable: [6.1-opportunities]
+rules:
+  - id: acme-process-description
+    clause: "4.4"
+    severity: error
+    for_each: qms.processes
+    label: name
+    field: process_%v_description
+    message: Process %v must be described
+    path: description
+    required: true
+  - id: acme-objective-count
+    clause: "6.2"
+    severity: warning
+    field: quality_objectives
+    message: At least five quality objectives should be set
+    path: qms.objectives
+    min_count: 5
+  - id: acme-organization-id
+    clause: "5.2"
+    severity: info
+    message: Organization ID should follow the ORG-nnn scheme
+    path: id
+    pattern: ^ORG-[0-9]{3}$
+`))
+	if err != nil {
+		t.Fatalf("Failed to load rule set: %v", err)
+	}
+
+	result := registry.Validate(org)
+	var described, count bool
+	for _, e := range result.Errors {
+		if e.Field == "process_"+org.QMS.Processes[0].Name+"_description" && e.Message == "Process "+org.QMS.Processes[0].Name+" must be described" {
+			described = true
+		}
+	}
+	for _, e := range result.Warnings {
+		count = count || e.Field == "quality_objectives"
+	}
+	if !described || !count {
+		t.Errorf("Expected the rule set to raise description and objective issues, got %+v %+v", result.Errors, result.Warnings)
+	}
+	for _, e := range result.Infos {
+		if e.Field == "opportunities" {
+			t.Error("Expected disabled rule to be skipped")
+		}
+	}
+
+	for name, data := range map[string]string{
+		"unknown field": "rules:\n  - {id: x, clause: '4.4', severity: error, message: m, path: qms.proceses, required: true}\n",
+		"unknown key":   "rules:\n  - {id: x, clause: '4.4', severity: error, message: m, path: id, requird: true}\n",
+		"no condition":  "rules:\n  - {id: x, clause: '4.4', severity: error, message: m, path: id}\n",
+		"not a list":    "rules:\n  - {id: x, clause: '4.4', severity: error, message: m, for_each: name, path: id, required: true}\n",
+		"unknown rule":  "disable: [9.9-nothing]\n",
+	} {
+		if err := NewRuleRegistry().LoadYAML([]byte(data)); err == nil {
+			t.Errorf("%s: expected rule set to be rejected", name)
+		}
+	}
+}
@@ -0,0 +1,437 @@
+package iso9001
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DeadlineKind identifies the kind of item a deadline belongs to
+type DeadlineKind string
+
+const (
+	DeadlineFinding          DeadlineKind = "finding"
+	DeadlineCorrectiveAction DeadlineKind = "corrective_action"
+	DeadlineMitigation       DeadlineKind = "mitigation"
+	DeadlineRiskReview       DeadlineKind = "risk_review"
+	DeadlineObjective        DeadlineKind = "objective"
+	DeadlineDocumentReview   DeadlineKind = "document_review"
+	DeadlineDocumentApproval DeadlineKind = "document_approval"
+	DeadlineAudit            DeadlineKind = "audit"
+	DeadlineManagementReview DeadlineKind = "management_review"
+	DeadlineReviewAction     DeadlineKind = "review_action"
+	DeadlineCalibration      DeadlineKind = "calibration"
+	DeadlineTraining         DeadlineKind = "training"
+)
+
+// Deadline is an open item that has to be done by a due date
+type Deadline struct {
+	Kind        DeadlineKind `json:"kind" yaml:"kind"`
+	EntityID    string       `json:"entity_id" yaml:"entity_id"`
+	ParentID    string       `json:"parent_id,omitempty" yaml:"parent_id,omitempty"` // audit, risk, review, document or person the item belongs to
+	Title       string       `json:"title" yaml:"title"`
+	Responsible string       `json:"responsible,omitempty" yaml:"responsible,omitempty"`
+	Due         time.Time    `json:"due" yaml:"due"`
+}
+
+// DeadlineSources are the organization and managers deadlines are collected from; nil
+// sources are skipped
+type DeadlineSources struct {
+	Organization *Organization
+	Documents    *DocumentationManager
+	Risks        *RiskManager
+	Objectives   *QualityObjectivesManager
+	Audits       *AuditManager
+	Outputs      *NonconformingOutputManager
+}
+
+// Deadlines collects the open deadlines of the tenant
+func (t *Tenant) Deadlines() []Deadline {
+	return CollectDeadlines(DeadlineSources{
+		Organization: t.Organization,
+		Documents:    t.Documents,
+		Risks:        t.Risks,
+		Objectives:   t.Objectives,
+		Audits:       t.Audits,
+		Outputs:      t.Outputs,
+	})
+}
+
+// CollectDeadlines gathers the due dates of open items across all modules: open
+// findings and their corrective actions, risk mitigations and reviews, objectives not yet achieved,
+// document reviews and approval stages, planned audits, pending management reviews and their action items,
+// the next review set by the latest completed one, calibrations and planned training.
+// Items without a due date are skipped. Deadlines are ordered by due date.
+func CollectDeadlines(sources DeadlineSources) []Deadline {
+	var deadlines []Deadline
+	add := func(d Deadline) {
+		if !d.Due.IsZero() {
+			deadlines = append(deadlines, d)
+		}
+	}
+
+	if am := sources.Audits; am != nil {
+		for _, audit := range am.Audits {
+			if audit.Status == AuditStatusPlanned {
+				add(Deadline{Kind: DeadlineAudit, EntityID: audit.ID, Title: audit.Title, Due: audit.PlannedStartDate})
+			}
+			for _, finding := range audit.Findings {
+				if finding.Status == FindingStatusClosed || finding.Status == FindingStatusAccepted {
+					continue
+				}
+				add(Deadline{Kind: DeadlineFinding, EntityID: finding.ID, ParentID: audit.ID, Title: finding.Description, Responsible: finding.Responsible, Due: finding.DueDate})
+				for _, action := range finding.CorrectiveActions {
+					if actionOpen(action.Status) {
+						add(Deadline{Kind: DeadlineCorrectiveAction, EntityID: action.ID, ParentID: finding.ID, Title: action.Description, Responsible: action.Responsible, Due: action.DueDate})
+					}
+				}
+			}
+		}
+
+		var latest *ManagementReview
+		for _, review := range am.ManagementReviews {
+			if review.Status != ReviewStatusCompleted {
+				add(Deadline{Kind: DeadlineManagementReview, EntityID: review.ID, Title: review.Title, Due: review.Date})
+				continue
+			}
+			if latest == nil || review.Date.After(latest.Date) {
+				latest = review
+			}
+			for _, item := range review.Outputs.ActionItems {
+				if actionOpen(item.Status) {
+					add(Deadline{Kind: DeadlineReviewAction, EntityID: item.ID, ParentID: review.ID, Title: item.Description, Responsible: item.Responsible, Due: item.DueDate})
+				}
+			}
+		}
+		if latest != nil && !latest.Outputs.NextReviewDate.IsZero() && !reviewScheduledAfter(am, latest) {
+			add(Deadline{Kind: DeadlineManagementReview, ParentID: latest.ID, Title: "Next management review", Due: latest.Outputs.NextReviewDate})
+		}
+	}
+
+	if nm := sources.Outputs; nm != nil {
+		for _, output := range nm.Outputs {
+			for _, action := range output.CorrectiveActions {
+				if actionOpen(action.Status) {
+					add(Deadline{Kind: DeadlineCorrectiveAction, EntityID: action.ID, ParentID: output.ID, Title: action.Description, Responsible: action.Responsible, Due: action.DueDate})
+				}
+			}
+		}
+	}
+
+	if rm := sources.Risks; rm != nil {
+		for _, risk := range rm.Risks {
+			for _, action := range risk.Mitigation {
+				if actionOpen(action.Status) {
+					add(Deadline{Kind: DeadlineMitigation, EntityID: action.ID, ParentID: risk.ID, Title: action.Description, Responsible: action.Responsible, Due: action.Timeline})
+				}
+			}
+			if risk.Status != RiskStatusIdentified {
+				add(Deadline{Kind: DeadlineRiskReview, EntityID: risk.ID, Title: risk.Description, Due: risk.NextReviewDate})
+			}
+		}
+	}
+
+	if qom := sources.Objectives; qom != nil {
+		for _, objective := range qom.Objectives {
+			if objective.Status != ObjectiveStatusAchieved {
+				add(Deadline{Kind: DeadlineObjective, EntityID: objective.ID, Title: objective.Name, Responsible: objective.Responsible, Due: objective.Timeline.TargetDate})
+			}
+		}
+	}
+
+	if dm := sources.Documents; dm != nil {
+		for _, doc := range dm.Documents {
+			if doc.Review == nil || doc.Status == DocumentStatusObsolete || doc.Status == DocumentStatusArchived {
+				continue
+			}
+			add(Deadline{Kind: DeadlineDocumentReview, EntityID: doc.ID, Title: doc.Title, Responsible: doc.Metadata.Owner, Due: doc.Review.NextReviewDate})
+		}
+		for _, doc := range dm.Documents {
+			if doc.Status != DocumentStatusDraft && doc.Status != DocumentStatusReview {
+				continue
+			}
+			if stage, ok := doc.CurrentApprovalStage(); ok {
+				add(Deadline{Kind: DeadlineDocumentApproval, EntityID: stage.Name, ParentID: doc.ID, Title: doc.Title, Responsible: strings.Join(doc.PendingApprovers(), ", "), Due: stage.DueDate()})
+			}
+		}
+	}
+
+	if org := sources.Organization; org != nil {
+		if org.QMS != nil {
+			seen := make(map[string]bool)
+			for _, process := range org.QMS.Processes {
+				for _, resource := range process.Resources {
+					if resource.CalibrationDue == nil || seen[resource.ID] {
+						continue
+					}
+					seen[resource.ID] = true
+					add(Deadline{Kind: DeadlineCalibration, EntityID: resource.ID, ParentID: process.ID, Title: resource.Name, Due: *resource.CalibrationDue})
+				}
+			}
+		}
+		if org.Leadership != nil {
+			for _, person := range org.Leadership.TopManagement {
+				for _, training := range person.PlannedTraining {
+					if training.Completed == nil {
+						add(Deadline{Kind: DeadlineTraining, EntityID: training.Course, ParentID: person.ID, Title: training.Course, Responsible: person.Name, Due: training.Due})
+					}
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(deadlines, func(i, j int) bool {
+		if !deadlines[i].Due.Equal(deadlines[j].Due) {
+			return deadlines[i].Due.Before(deadlines[j].Due)
+		}
+		return deadlines[i].Kind+DeadlineKind(deadlines[i].EntityID) < deadlines[j].Kind+DeadlineKind(deadlines[j].EntityID)
+	})
+	return deadlines
+}
+
+// actionOpen reports whether an action still has to be done
+func actionOpen(status ActionStatus) bool {
+	return status != ActionStatusCompleted && status != ActionStatusVerified
+}
+
+// reviewScheduledAfter reports whether a management review has been created for a date
+// after the completed review
+func reviewScheduledAfter(am *AuditManager, completed *ManagementReview) bool {
+	for _, review := range am.ManagementReviews {
+		if review.Status != ReviewStatusCompleted && review.Date.After(completed.Date) {
+			return true
+		}
+	}
+	return false
+}
+
+// LeadTimeRule sends a reminder the given time before a deadline of the kind, or of
+// any kind when Kind is empty
+type LeadTimeRule struct {
+	Kind   DeadlineKind  `json:"kind,omitempty" yaml:"kind,omitempty"`
+	Before time.Duration `json:"before" yaml:"before"`
+}
+
+// DefaultLeadTimeRules remind of every deadline a week and a day ahead
+var DefaultLeadTimeRules = []LeadTimeRule{{Before: 7 * 24 * time.Hour}, {Before: 24 * time.Hour}}
+
+// EscalationRule escalates a deadline of the kind, or of any kind when Kind is empty,
+// to further recipients, e.g. the quality manager or top management, once it has been
+// overdue for the given time
+type EscalationRule struct {
+	Kind  DeadlineKind  `json:"kind,omitempty" yaml:"kind,omitempty"`
+	After time.Duration `json:"after" yaml:"after"`
+	To    []string      `json:"to" yaml:"to"`
+}
+
+// Reminder is a notification about an upcoming or overdue deadline
+type Reminder struct {
+	Deadline Deadline      `json:"deadline" yaml:"deadline"`
+	LeadTime time.Duration `json:"lead_time,omitempty" yaml:"lead_time,omitempty"` // rule that triggered an upcoming reminder
+	Overdue  bool          `json:"overdue" yaml:"overdue"`
+	// Escalation is the overdue time of the escalation rules that triggered the
+	// reminder, and EscalateTo the recipients they name
+	Escalation time.Duration `json:"escalation,omitempty" yaml:"escalation,omitempty"`
+	EscalateTo []string      `json:"escalate_to,omitempty" yaml:"escalate_to,omitempty"`
+	Sent       time.Time     `json:"sent" yaml:"sent"`
+}
+
+// String describes the reminder in one line
+func (r Reminder) String() string {
+	d := r.Deadline
+	state := "due " + d.Due.Format("2006-01-02")
+	if r.Overdue {
+		state = "overdue since " + d.Due.Format("2006-01-02")
+	}
+	line := fmt.Sprintf("%s %s %s: %s", d.Kind, d.EntityID, state, d.Title)
+	if d.Responsible != "" {
+		line += " (" + d.Responsible + ")"
+	}
+	if len(r.EscalateTo) > 0 {
+		line += ", escalated to " + strings.Join(r.EscalateTo, ", ")
+	}
+	return line
+}
+
+// NotificationSink delivers reminders, e.g. by e-mail, chat or ticketing system
+type NotificationSink interface {
+	Notify(ctx context.Context, reminder Reminder) error
+}
+
+// NotificationSinkFunc adapts a function to a NotificationSink
+type NotificationSinkFunc func(ctx context.Context, reminder Reminder) error
+
+// Notify calls f
+func (f NotificationSinkFunc) Notify(ctx context.Context, reminder Reminder) error {
+	return f(ctx, reminder)
+}
+
+// WriterSink writes one line per reminder to an io.Writer such as a log file
+type WriterSink struct {
+	W io.Writer
+}
+
+// Notify writes the reminder
+func (s WriterSink) Notify(ctx context.Context, reminder Reminder) error {
+	_, err := fmt.Fprintln(s.W, reminder)
+	return err
+}
+
+// Scheduler turns deadlines into reminders following lead-time rules and dispatches
+// them to notification sinks. Each reminder is sent once: one for the closest lead
+// time whose window has opened, one when the deadline is overdue and one for each
+// escalation it reaches while it stays overdue.
+type Scheduler struct {
+	// Rules are the lead times reminders are sent at; DefaultLeadTimeRules when empty
+	Rules []LeadTimeRule
+	// Escalations escalate deadlines that stay overdue
+	Escalations []EscalationRule
+	// Sinks receive every reminder
+	Sinks []NotificationSink
+	// DueDates, when set, evaluates deadlines in the organization's time zone
+	DueDates *DueDateCalculator
+
+	mu   sync.Mutex
+	sent map[string]bool
+}
+
+// NewScheduler creates a scheduler dispatching to the given sinks
+func NewScheduler(sinks ...NotificationSink) *Scheduler {
+	return &Scheduler{Sinks: sinks, sent: make(map[string]bool)}
+}
+
+// Upcoming returns the deadlines falling due within the given time from now,
+// including those already overdue
+func (s *Scheduler) Upcoming(deadlines []Deadline, now time.Time, within time.Duration) []Deadline {
+	var upcoming []Deadline
+	for _, deadline := range deadlines {
+		if deadline.Due.Before(now.Add(within)) || s.DueDates.IsOverdue(deadline.Due, now) {
+			upcoming = append(upcoming, deadline)
+		}
+	}
+	return upcoming
+}
+
+// Pending returns the reminders due at now that have not been dispatched yet
+func (s *Scheduler) Pending(deadlines []Deadline, now time.Time) []Reminder {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var reminders []Reminder
+	for _, deadline := range deadlines {
+		reminder, key, ok := s.reminderFor(deadline, now)
+		if ok && !s.sent[key] {
+			reminders = append(reminders, reminder)
+		}
+	}
+	return reminders
+}
+
+// reminderFor returns the reminder a deadline calls for at now and the key it is
+// deduplicated by
+func (s *Scheduler) reminderFor(deadline Deadline, now time.Time) (Reminder, string, bool) {
+	base := fmt.Sprintf("%s/%s/%s/%s", deadline.Kind, deadline.ParentID, deadline.EntityID, deadline.Due.UTC().Format(time.RFC3339))
+	if s.DueDates.IsOverdue(deadline.Due, now) {
+		reminder := Reminder{Deadline: deadline, Overdue: true}
+		for _, rule := range s.Escalations {
+			if rule.Kind != "" && rule.Kind != deadline.Kind || !s.DueDates.IsOverdue(deadline.Due.Add(rule.After), now) {
+				continue
+			}
+			// The longest escalation reached applies, with the recipients of all its rules
+			if rule.After > reminder.Escalation {
+				reminder.Escalation, reminder.EscalateTo = rule.After, nil
+			}
+			if rule.After == reminder.Escalation {
+				for _, to := range rule.To {
+					if !containsString(to, reminder.EscalateTo...) {
+						reminder.EscalateTo = append(reminder.EscalateTo, to)
+					}
+				}
+			}
+		}
+		if reminder.Escalation > 0 {
+			return reminder, fmt.Sprintf("%s/escalated/%s", base, reminder.Escalation), true
+		}
+		return reminder, base + "/overdue", true
+	}
+
+	rules := s.Rules
+	if len(rules) == 0 {
+		rules = DefaultLeadTimeRules
+	}
+	var closest time.Duration = -1
+	for _, rule := range rules {
+		if rule.Kind != "" && rule.Kind != deadline.Kind {
+			continue
+		}
+		if !now.Before(deadline.Due.Add(-rule.Before)) && (closest < 0 || rule.Before < closest) {
+			closest = rule.Before
+		}
+	}
+	if closest < 0 {
+		return Reminder{}, "", false
+	}
+	return Reminder{Deadline: deadline, LeadTime: closest}, fmt.Sprintf("%s/%s", base, closest), true
+}
+
+// Dispatch sends the pending reminders to every sink. A reminder counts as sent when
+// all sinks accepted it, so reminders a sink failed on are retried by the next call.
+// It returns the reminders sent and the sink errors.
+func (s *Scheduler) Dispatch(ctx context.Context, deadlines []Deadline, now time.Time) ([]Reminder, error) {
+	var sent []Reminder
+	var errs []error
+	for _, reminder := range s.Pending(deadlines, now) {
+		reminder.Sent = now
+		failed := false
+		for _, sink := range s.Sinks {
+			if err := sink.Notify(ctx, reminder); err != nil {
+				errs = append(errs, fmt.Errorf("%s %s: %w", reminder.Deadline.Kind, reminder.Deadline.EntityID, err))
+				failed = true
+			}
+		}
+		if failed {
+			continue
+		}
+		_, key, _ := s.reminderFor(reminder.Deadline, now)
+		s.mu.Lock()
+		if s.sent == nil {
+			s.sent = make(map[string]bool)
+		}
+		s.sent[key] = true
+		s.mu.Unlock()
+		sent = append(sent, reminder)
+	}
+	return sent, errors.Join(errs...)
+}
+
+// Start dispatches the reminders for the deadlines returned by source at every interval
+// until stop is called. Errors from the source or the sinks are passed to onError.
+func (s *Scheduler) Start(interval time.Duration, source func() ([]Deadline, error), onError func(error)) (stop func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	ticker := time.NewTicker(interval)
+
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				deadlines, err := source()
+				if err == nil {
+					_, err = s.Dispatch(ctx, deadlines, now)
+				}
+				if err != nil && onError != nil {
+					onError(err)
+				}
+			}
+		}
+	}()
+
+	return cancel
+}
@@ -0,0 +1,126 @@
+package iso9001
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCollectDeadlines(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	calibration := now.AddDate(0, 0, 20)
+	tenant := NewTenant("ACME")
+	tenant.Organization.QMS = &QualityManagementSystem{Processes: []Process{
+		{ID: "PROC-001", Resources: []Resource{{ID: "RES-1", Name: "Caliper", CalibrationDue: &calibration}}},
+		{ID: "PROC-002", Resources: []Resource{{ID: "RES-1", Name: "Caliper", CalibrationDue: &calibration}}},
+	}}
+	tenant.Organization.Leadership = &Leadership{TopManagement: []Person{{ID: "P-1", Name: "Ada", PlannedTraining: []PlannedTraining{
+		{Course: "Internal auditor", Due: now.AddDate(0, 1, 0)},
+		{Course: "ISO awareness", Due: now, Completed: &now},
+	}}}}
+	tenant.Audits.Audits["AUD-1"] = &Audit{ID: "AUD-1", Status: AuditStatusCompleted, Findings: []AuditFinding{
+		{ID: "F-1", Status: FindingStatusOpen, DueDate: now.AddDate(0, 0, -2), CorrectiveActions: []CorrectiveAction{
+			{ID: "CA-1", DueDate: now.AddDate(0, 0, 3)},
+			{ID: "CA-2", DueDate: now, Status: ActionStatusCompleted},
+		}},
+		{ID: "F-2", Status: FindingStatusClosed, DueDate: now},
+	}}
+	tenant.Audits.ManagementReviews["MR-1"] = &ManagementReview{ID: "MR-1", Status: ReviewStatusCompleted, Date: now.AddDate(0, -6, 0),
+		Outputs: ManagementReviewOutputs{NextReviewDate: now.AddDate(0, 0, 10), ActionItems: []ActionItem{{ID: "AI-1", DueDate: now.AddDate(0, 0, 5)}}}}
+	tenant.Objectives.Objectives["OBJ-1"] = &QualityObjective{ID: "OBJ-1", Status: ObjectiveStatusInProgress, Timeline: ObjectiveTimeline{TargetDate: now.AddDate(0, 2, 0)}}
+
+	var got []string
+	for _, deadline := range tenant.Deadlines() {
+		got = append(got, string(deadline.Kind)+":"+deadline.EntityID)
+	}
+	want := "finding:F-1,corrective_action:CA-1,review_action:AI-1,management_review:,calibration:RES-1,training:Internal auditor,objective:OBJ-1"
+	if strings.Join(got, ",") != want {
+		t.Errorf("Deadlines = %s\nwant        %s", strings.Join(got, ","), want)
+	}
+}
+
+func TestSchedulerDispatch(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	deadlines := []Deadline{
+		{Kind: DeadlineFinding, EntityID: "F-1", Due: now.AddDate(0, 0, -1)},
+		{Kind: DeadlineCalibration, EntityID: "RES-1", Due: now.AddDate(0, 0, 3)},
+		{Kind: DeadlineObjective, EntityID: "OBJ-1", Due: now.AddDate(0, 0, 20)},
+	}
+
+	var log strings.Builder
+	failing := true
+	scheduler := NewScheduler(WriterSink{W: &log}, NotificationSinkFunc(func(ctx context.Context, r Reminder) error {
+		if failing && r.Deadline.EntityID == "RES-1" {
+			return errors.New("mail server down")
+		}
+		return nil
+	}))
+	scheduler.Rules = []LeadTimeRule{{Before: 24 * time.Hour}, {Kind: DeadlineCalibration, Before: 14 * 24 * time.Hour}}
+
+	sent, err := scheduler.Dispatch(context.Background(), deadlines, now)
+	if err == nil || len(sent) != 1 || !sent[0].Overdue {
+		t.Fatalf("Expected overdue finding sent and calibration failed, got %+v, %v", sent, err)
+	}
+	if !strings.Contains(log.String(), "finding F-1 overdue since 2024-05-31") {
+		t.Errorf("Unexpected log %q", log.String())
+	}
+
+	failing = false
+	sent, err = scheduler.Dispatch(context.Background(), deadlines, now)
+	if err != nil || len(sent) != 1 || sent[0].Deadline.EntityID != "RES-1" || sent[0].LeadTime != 14*24*time.Hour {
+		t.Errorf("Expected failed calibration reminder to be retried, got %+v, %v", sent, err)
+	}
+	if pending := scheduler.Pending(deadlines, now); len(pending) != 0 {
+		t.Errorf("Expected no pending reminders, got %+v", pending)
+	}
+
+	later := now.AddDate(0, 0, 19).Add(time.Hour)
+	pending := scheduler.Pending(deadlines, later)
+	if len(pending) != 2 || pending[0].Deadline.EntityID != "RES-1" || !pending[0].Overdue || pending[1].LeadTime != 24*time.Hour {
+		t.Errorf("Expected overdue calibration and objective reminders, got %+v", pending)
+	}
+	if upcoming := scheduler.Upcoming(deadlines, now, 7*24*time.Hour); len(upcoming) != 2 {
+		t.Errorf("Expected two deadlines within a week, got %+v", upcoming)
+	}
+}
+
+func TestSchedulerEscalation(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	deadlines := []Deadline{
+		{Kind: DeadlineFinding, EntityID: "F-1", Responsible: "Auditee", Due: now.AddDate(0, 0, -1)},
+		{Kind: DeadlineMitigation, EntityID: "RISK-1", Due: now.AddDate(0, 0, -1)},
+	}
+	scheduler := NewScheduler()
+	scheduler.Escalations = []EscalationRule{
+		{Kind: DeadlineFinding, After: 7 * 24 * time.Hour, To: []string{"Quality Manager"}},
+		{After: 7 * 24 * time.Hour, To: []string{"qm@acme.example"}},
+		{After: 30 * 24 * time.Hour, To: []string{"CEO"}},
+	}
+
+	sent, _ := scheduler.Dispatch(context.Background(), deadlines, now)
+	if len(sent) != 2 || !sent[0].Overdue || sent[0].Escalation != 0 {
+		t.Fatalf("Expected plain overdue reminders first, got %+v", sent)
+	}
+
+	week := now.AddDate(0, 0, 7)
+	sent, _ = scheduler.Dispatch(context.Background(), deadlines, week)
+	if len(sent) != 2 || sent[0].Escalation != 7*24*time.Hour || strings.Join(sent[0].EscalateTo, ",") != "Quality Manager,qm@acme.example" {
+		t.Fatalf("Expected finding escalated to both rules of a week, got %+v", sent)
+	}
+	if strings.Join(sent[1].EscalateTo, ",") != "qm@acme.example" {
+		t.Errorf("Expected finding-only rule to skip mitigations, got %+v", sent[1])
+	}
+	if !strings.Contains(sent[0].String(), "escalated to Quality Manager, qm@acme.example") {
+		t.Errorf("Unexpected reminder line %q", sent[0])
+	}
+	if pending := scheduler.Pending(deadlines, week.Add(time.Hour)); len(pending) != 0 {
+		t.Errorf("Expected each escalation to be sent once, got %+v", pending)
+	}
+
+	pending := scheduler.Pending(deadlines, now.AddDate(0, 1, 0))
+	if len(pending) != 2 || strings.Join(pending[0].EscalateTo, ",") != "CEO" {
+		t.Errorf("Expected escalation to the CEO after a month, got %+v", pending)
+	}
+}
@@ -0,0 +1,195 @@
+package iso9001
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// CurrentSchemaVersion is the schema version written by this version of the library.
+// Data without a schema_version predates versioning and is treated as version 1.
+const CurrentSchemaVersion = 1
+
+// SchemaKind names a persisted aggregate with its own schema history
+type SchemaKind string
+
+const (
+	SchemaKindOrganization SchemaKind = "organization"
+	SchemaKindTenant       SchemaKind = "tenant"
+)
+
+// SchemaVersionError is returned when stored data was written by a newer library
+// version, or when no migration path leads to the current version. Loading such data
+// would silently drop the fields this version does not know.
+type SchemaVersionError struct {
+	Kind      SchemaKind `json:"kind" yaml:"kind"`
+	Version   int        `json:"version" yaml:"version"`
+	Supported int        `json:"supported" yaml:"supported"`
+}
+
+func (e *SchemaVersionError) Error() string {
+	if e.Version > e.Supported {
+		return fmt.Sprintf("%s schema version %d is newer than supported version %d", e.Kind, e.Version, e.Supported)
+	}
+	return fmt.Sprintf("no migration for %s schema version %d to version %d", e.Kind, e.Version, e.Supported)
+}
+
+// Migration upgrades a decoded document of one kind from version From to From+1.
+// Documents are generic JSON or YAML objects, so migrations can rename, move or
+// convert keys that no longer map to a struct field.
+type Migration struct {
+	Kind        SchemaKind                             `json:"kind" yaml:"kind"`
+	From        int                                    `json:"from" yaml:"from"`
+	Description string                                 `json:"description" yaml:"description"`
+	Apply       func(doc map[string]interface{}) error `json:"-" yaml:"-"`
+}
+
+// MigrationRegistry holds the migrations that bring stored data up to a target version
+type MigrationRegistry struct {
+	// Current is the version documents are migrated to
+	Current int
+
+	mu         sync.RWMutex
+	migrations map[SchemaKind]map[int]Migration
+}
+
+// NewMigrationRegistry creates an empty registry migrating to version current
+func NewMigrationRegistry(current int) *MigrationRegistry {
+	return &MigrationRegistry{Current: current, migrations: make(map[SchemaKind]map[int]Migration)}
+}
+
+// DefaultMigrations is used by the loaders and the file tenant backend
+var DefaultMigrations = NewMigrationRegistry(CurrentSchemaVersion)
+
+// RegisterMigration adds a migration to DefaultMigrations
+func RegisterMigration(m Migration) error {
+	return DefaultMigrations.Register(m)
+}
+
+// Register adds a migration, rejecting a second migration for the same step
+func (r *MigrationRegistry) Register(m Migration) error {
+	if m.Apply == nil {
+		return fmt.Errorf("migration for %s from version %d has no Apply function", m.Kind, m.From)
+	}
+	if m.From < 1 {
+		return fmt.Errorf("invalid migration source version %d", m.From)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	steps := r.migrations[m.Kind]
+	if steps == nil {
+		steps = make(map[int]Migration)
+		r.migrations[m.Kind] = steps
+	}
+	if _, exists := steps[m.From]; exists {
+		return fmt.Errorf("migration for %s from version %d already registered", m.Kind, m.From)
+	}
+	steps[m.From] = m
+	return nil
+}
+
+// Migrations lists the registered migrations of a kind in order
+func (r *MigrationRegistry) Migrations(kind SchemaKind) []Migration {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	list := make([]Migration, 0, len(r.migrations[kind]))
+	for _, m := range r.migrations[kind] {
+		list = append(list, m)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].From < list[j].From })
+	return list
+}
+
+// Migrate upgrades a decoded document in place and reports whether it changed.
+// Tenants also migrate their embedded organization.
+func (r *MigrationRegistry) Migrate(kind SchemaKind, doc map[string]interface{}) (bool, error) {
+	version, err := schemaVersionOf(doc)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s schema version: %w", kind, err)
+	}
+	if version > r.Current {
+		return false, &SchemaVersionError{Kind: kind, Version: version, Supported: r.Current}
+	}
+
+	changed := false
+	for ; version < r.Current; version++ {
+		r.mu.RLock()
+		m, ok := r.migrations[kind][version]
+		r.mu.RUnlock()
+		if !ok {
+			return false, &SchemaVersionError{Kind: kind, Version: version, Supported: r.Current}
+		}
+		if err := m.Apply(doc); err != nil {
+			return false, fmt.Errorf("migrating %s from schema version %d: %w", kind, version, err)
+		}
+		changed = true
+	}
+	if changed {
+		doc["schema_version"] = r.Current
+	}
+
+	if kind == SchemaKindTenant {
+		if org, ok := doc["organization"].(map[string]interface{}); ok {
+			orgChanged, err := r.Migrate(SchemaKindOrganization, org)
+			if err != nil {
+				return false, err
+			}
+			changed = changed || orgChanged
+		}
+	}
+
+	return changed, nil
+}
+
+// MigrateJSON upgrades JSON data to the current version, returning data unchanged
+// when no migration applies. Numbers are preserved exactly.
+func (r *MigrationRegistry) MigrateJSON(kind SchemaKind, data []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var doc map[string]interface{}
+	if err := decoder.Decode(&doc); err != nil {
+		// Leave reporting malformed input to the caller's decoder
+		return data, nil
+	}
+
+	changed, err := r.Migrate(kind, doc)
+	if err != nil || !changed {
+		return data, err
+	}
+	return json.Marshal(doc)
+}
+
+// schemaVersionOf reads schema_version from a decoded JSON or YAML document. A
+// missing or zero version means the data predates versioning.
+func schemaVersionOf(doc map[string]interface{}) (int, error) {
+	version := 0
+	switch v := doc["schema_version"].(type) {
+	case nil:
+	case json.Number:
+		n, err := v.Int64()
+		if err != nil {
+			return 0, err
+		}
+		version = int(n)
+	case float64:
+		version = int(v)
+	case int:
+		version = v
+	case int64:
+		version = int(v)
+	case uint64:
+		version = int(v)
+	default:
+		return 0, fmt.Errorf("unexpected type %T", v)
+	}
+	if version < 1 {
+		version = 1
+	}
+	return version, nil
+}
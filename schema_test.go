@@ -0,0 +1,73 @@
+package iso9001
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestMigrationRegistryUpgradesOldData(t *testing.T) {
+	registry := NewMigrationRegistry(3)
+	err := registry.Register(Migration{Kind: SchemaKindOrganization, From: 1, Description: "rename org_name", Apply: func(doc map[string]interface{}) error {
+		if name, ok := doc["org_name"]; ok {
+			doc["name"] = name
+			delete(doc, "org_name")
+		}
+		return nil
+	}})
+	if err != nil {
+		t.Fatalf("Failed to register migration: %v", err)
+	}
+	err = registry.Register(Migration{Kind: SchemaKindOrganization, From: 2, Description: "default time zone", Apply: func(doc map[string]interface{}) error {
+		doc["time_zone"] = "UTC"
+		return nil
+	}})
+	if err != nil {
+		t.Fatalf("Failed to register migration: %v", err)
+	}
+	if err := registry.Register(Migration{Kind: SchemaKindOrganization, From: 2, Apply: func(map[string]interface{}) error { return nil }}); err == nil {
+		t.Error("Expected duplicate migration to be rejected")
+	}
+
+	data := []byte(`{"id": "ORG-001", "org_name": "Legacy Org", "qms": {"objectives": [{"id": "OBJ-001"}]}}`)
+	migrated, err := registry.MigrateJSON(SchemaKindOrganization, data)
+	if err != nil {
+		t.Fatalf("Failed to migrate: %v", err)
+	}
+
+	var org Organization
+	if err := DecodeJSONStrict(migrated, &org); err != nil {
+		t.Fatalf("Expected migrated data to decode strictly, got %v", err)
+	}
+	if org.Name != "Legacy Org" || org.TimeZone != "UTC" || org.SchemaVersion != 3 {
+		t.Errorf("Expected migrated organization, got name=%q tz=%q version=%d", org.Name, org.TimeZone, org.SchemaVersion)
+	}
+
+	tenant := []byte(`{"id": "ORG-001", "schema_version": 3, "organization": {"org_name": "Nested"}}`)
+	migrated, err = registry.MigrateJSON(SchemaKindTenant, tenant)
+	if err != nil {
+		t.Fatalf("Failed to migrate tenant: %v", err)
+	}
+	var doc struct {
+		Organization Organization `json:"organization"`
+	}
+	if err := json.Unmarshal(migrated, &doc); err != nil || doc.Organization.Name != "Nested" {
+		t.Errorf("Expected embedded organization to be migrated, got %+v (%v)", doc.Organization, err)
+	}
+}
+
+func TestLoadRejectsNewerSchema(t *testing.T) {
+	_, err := LoadOrganizationJSON([]byte(`{"schema_version": 99, "id": "ORG-001"}`))
+	var versionErr *SchemaVersionError
+	if !errors.As(err, &versionErr) || versionErr.Version != 99 {
+		t.Fatalf("Expected SchemaVersionError, got %v", err)
+	}
+
+	org, err := LoadOrganizationJSON([]byte(`{"id": "ORG-001"}`))
+	if err != nil {
+		t.Fatalf("Expected unversioned data to load, got %v", err)
+	}
+	if org.SchemaVersion != CurrentSchemaVersion {
+		t.Errorf("Expected loaded organization to be stamped with version %d, got %d", CurrentSchemaVersion, org.SchemaVersion)
+	}
+}
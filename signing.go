@@ -0,0 +1,145 @@
+package iso9001
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrSignatureInvalid is returned when a report does not match its signature
+var ErrSignatureInvalid = errors.New("report signature invalid")
+
+// SignatureAlgorithm identifies the algorithm of a ReportSignature
+const SignatureAlgorithm = "Ed25519"
+
+// ReportSignature is a detached signature over the bytes of a report, such as its
+// canonical JSON or a rendered PDF. It is stored next to the report, e.g. as
+// report.json.sig, so the report itself is unchanged.
+type ReportSignature struct {
+	Algorithm string    `json:"algorithm" yaml:"algorithm"`
+	KeyID     string    `json:"key_id,omitempty" yaml:"key_id,omitempty"`
+	Signed    time.Time `json:"signed" yaml:"signed"`
+	Digest    string    `json:"digest" yaml:"digest"` // hex SHA-256 of the signed bytes
+	Signature []byte    `json:"signature" yaml:"signature"`
+}
+
+// ReportSigner signs reports with an Ed25519 private key
+type ReportSigner struct {
+	KeyID string
+	key   ed25519.PrivateKey
+}
+
+// NewReportSigner creates a signer for a private key. The key ID is recorded in each
+// signature so verifiers can pick the matching public key.
+func NewReportSigner(keyID string, key ed25519.PrivateKey) (*ReportSigner, error) {
+	if len(key) != ed25519.PrivateKeySize {
+		return nil, fmt.Errorf("invalid Ed25519 private key length %d", len(key))
+	}
+	return &ReportSigner{KeyID: keyID, key: key}, nil
+}
+
+// ParseReportSigningKey reads an Ed25519 private key in PKCS #8 PEM form, as written by
+// "openssl genpkey -algorithm ed25519"
+func ParseReportSigningKey(pemData []byte) (ed25519.PrivateKey, error) {
+	block, _ := pem.Decode(pemData)
+	if block == nil {
+		return nil, errors.New("no PEM data found in signing key")
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse signing key: %w", err)
+	}
+	private, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("signing key is %T, not Ed25519", key)
+	}
+	return private, nil
+}
+
+// ParseReportVerificationKey reads an Ed25519 public key in PKIX PEM form, as written
+// by "openssl pkey -pubout"
+func ParseReportVerificationKey(pemData []byte) (ed25519.PublicKey, error) {
+	block, _ := pem.Decode(pemData)
+	if block == nil {
+		return nil, errors.New("no PEM data found in verification key")
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse verification key: %w", err)
+	}
+	public, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("verification key is %T, not Ed25519", key)
+	}
+	return public, nil
+}
+
+// CanonicalReportJSON encodes a report as compact JSON without HTML escaping. Struct
+// fields keep their declaration order and map keys are sorted, so the same report
+// always yields the same bytes.
+func CanonicalReportJSON(report interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(report); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// Sign returns a detached signature over data
+func (s *ReportSigner) Sign(data []byte) *ReportSignature {
+	digest := sha256.Sum256(data)
+	return &ReportSignature{
+		Algorithm: SignatureAlgorithm,
+		KeyID:     s.KeyID,
+		Signed:    time.Now().UTC(),
+		Digest:    hex.EncodeToString(digest[:]),
+		Signature: ed25519.Sign(s.key, data),
+	}
+}
+
+// SignReport encodes a report, such as a ComplianceReport or an Audit, as canonical
+// JSON and signs it. Share the returned JSON together with the signature.
+func (s *ReportSigner) SignReport(report interface{}) ([]byte, *ReportSignature, error) {
+	data, err := CanonicalReportJSON(report)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode report: %w", err)
+	}
+	return data, s.Sign(data), nil
+}
+
+// VerifyReportSignature checks that data is unchanged since it was signed with the
+// private key belonging to key
+func VerifyReportSignature(data []byte, signature *ReportSignature, key ed25519.PublicKey) error {
+	if signature == nil {
+		return fmt.Errorf("%w: no signature", ErrSignatureInvalid)
+	}
+	if signature.Algorithm != SignatureAlgorithm {
+		return fmt.Errorf("%w: unsupported algorithm %q", ErrSignatureInvalid, signature.Algorithm)
+	}
+	digest := sha256.Sum256(data)
+	if hex.EncodeToString(digest[:]) != signature.Digest {
+		return fmt.Errorf("%w: report has been modified", ErrSignatureInvalid)
+	}
+	if !ed25519.Verify(key, data, signature.Signature) {
+		return fmt.Errorf("%w: signature does not match key", ErrSignatureInvalid)
+	}
+	return nil
+}
+
+// VerifyReport checks a signature made by SignReport against the report value
+func VerifyReport(report interface{}, signature *ReportSignature, key ed25519.PublicKey) error {
+	data, err := CanonicalReportJSON(report)
+	if err != nil {
+		return fmt.Errorf("failed to encode report: %w", err)
+	}
+	return VerifyReportSignature(data, signature, key)
+}
@@ -0,0 +1,59 @@
+package iso9001
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"testing"
+)
+
+func TestSignAndVerifyReport(t *testing.T) {
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := ParseReportSigningKey(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+	if err != nil {
+		t.Fatalf("Failed to parse signing key: %v", err)
+	}
+	signer, err := NewReportSigner("qa-2024", parsed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	report := GenerateComplianceReport(CreateExampleOrganization())
+	data, signature, err := signer.SignReport(report)
+	if err != nil {
+		t.Fatalf("Failed to sign: %v", err)
+	}
+	if signature.KeyID != "qa-2024" || signature.Algorithm != SignatureAlgorithm {
+		t.Errorf("Unexpected signature %+v", signature)
+	}
+	if err := VerifyReportSignature(data, signature, public); err != nil {
+		t.Errorf("Expected valid signature: %v", err)
+	}
+	if err := VerifyReport(report, signature, public); err != nil {
+		t.Errorf("Expected report value to verify: %v", err)
+	}
+
+	report.ComplianceScore += 10
+	if err := VerifyReport(report, signature, public); !errors.Is(err, ErrSignatureInvalid) {
+		t.Errorf("Expected tampered report to fail, got %v", err)
+	}
+
+	otherPublic, _, _ := ed25519.GenerateKey(rand.Reader)
+	if err := VerifyReportSignature(data, signature, otherPublic); !errors.Is(err, ErrSignatureInvalid) {
+		t.Errorf("Expected wrong key to fail, got %v", err)
+	}
+
+	pkix, _ := x509.MarshalPKIXPublicKey(public)
+	if _, err := ParseReportVerificationKey(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pkix})); err != nil {
+		t.Errorf("Failed to parse verification key: %v", err)
+	}
+}
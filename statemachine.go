@@ -0,0 +1,123 @@
+package iso9001
+
+import "fmt"
+
+// InvalidTransitionError is returned when an entity is asked to move to a status that
+// cannot be reached from its current status
+type InvalidTransitionError struct {
+	EntityType string `json:"entity_type" yaml:"entity_type"`
+	EntityID   string `json:"entity_id" yaml:"entity_id"`
+	From       string `json:"from" yaml:"from"`
+	To         string `json:"to" yaml:"to"`
+}
+
+func (e *InvalidTransitionError) Error() string {
+	return fmt.Sprintf("%s %s cannot move from %s to %s", e.EntityType, e.EntityID, e.From, e.To)
+}
+
+// statusMachine lists the statuses that can be reached from each status
+type statusMachine[S ~string] map[S][]S
+
+func (m statusMachine[S]) allows(from, to S) bool {
+	for _, next := range m[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// check returns an *InvalidTransitionError when from cannot move to to
+func (m statusMachine[S]) check(entityType, entityID string, from, to S) error {
+	if m.allows(from, to) {
+		return nil
+	}
+	return &InvalidTransitionError{EntityType: entityType, EntityID: entityID, From: string(from), To: string(to)}
+}
+
+// auditTransitions follows an audit from planning through reporting to closure
+var auditTransitions = statusMachine[AuditStatus]{
+	AuditStatusPlanned:    {AuditStatusInProgress},
+	AuditStatusInProgress: {AuditStatusCompleted},
+	AuditStatusCompleted:  {AuditStatusReported, AuditStatusClosed},
+	AuditStatusReported:   {AuditStatusClosed},
+}
+
+// findingTransitions allows a closed finding to be reopened when verification of
+// its corrective actions fails. An open finding is closed through in progress, which
+// its first corrective action moves it to; UpdateFindingStatus lets an open finding
+// without corrective actions, such as an observation, close directly.
+var findingTransitions = statusMachine[FindingStatus]{
+	FindingStatusOpen:       {FindingStatusInProgress, FindingStatusAccepted},
+	FindingStatusInProgress: {FindingStatusOpen, FindingStatusAccepted, FindingStatusClosed},
+	FindingStatusAccepted:   {FindingStatusInProgress, FindingStatusClosed},
+	FindingStatusClosed:     {FindingStatusOpen},
+}
+
+// documentTransitions allows documents to be withdrawn to the archive at any stage
+var documentTransitions = statusMachine[DocumentStatus]{
+	DocumentStatusDraft:     {DocumentStatusReview, DocumentStatusApproved, DocumentStatusArchived},
+	DocumentStatusReview:    {DocumentStatusDraft, DocumentStatusApproved, DocumentStatusArchived},
+	DocumentStatusApproved:  {DocumentStatusDraft, DocumentStatusPublished, DocumentStatusArchived},
+	DocumentStatusPublished: {DocumentStatusObsolete, DocumentStatusArchived},
+	DocumentStatusObsolete:  {DocumentStatusArchived},
+}
+
+// riskTransitions permits reassessment and further mitigation at any point after a
+// risk has been identified. Assessed risks become overdue when their scheduled review
+// passes, until they are reassessed.
+var riskTransitions = statusMachine[RiskStatus]{
+	RiskStatusIdentified: {RiskStatusAssessed, RiskStatusMitigated},
+	RiskStatusAssessed:   {RiskStatusAssessed, RiskStatusMitigated, RiskStatusMonitored, RiskStatusOverdue},
+	RiskStatusMitigated:  {RiskStatusAssessed, RiskStatusMitigated, RiskStatusMonitored, RiskStatusOverdue},
+	RiskStatusMonitored:  {RiskStatusAssessed, RiskStatusMitigated, RiskStatusMonitored, RiskStatusOverdue},
+	RiskStatusOverdue:    {RiskStatusAssessed, RiskStatusMitigated},
+}
+
+// objectiveTransitions treats achievement as final while allowing objectives that
+// were not achieved to be resumed
+var objectiveTransitions = statusMachine[ObjectiveStatus]{
+	ObjectiveStatusPlanned:     {ObjectiveStatusInProgress, ObjectiveStatusAchieved, ObjectiveStatusNotAchieved},
+	ObjectiveStatusInProgress:  {ObjectiveStatusInProgress, ObjectiveStatusAchieved, ObjectiveStatusNotAchieved},
+	ObjectiveStatusNotAchieved: {ObjectiveStatusInProgress, ObjectiveStatusAchieved},
+}
+
+// CanTransitionTo reports whether an audit in status s may move to next
+func (s AuditStatus) CanTransitionTo(next AuditStatus) bool {
+	return auditTransitions.allows(s, next)
+}
+
+// CanTransitionTo reports whether a finding in status s may move to next
+func (s FindingStatus) CanTransitionTo(next FindingStatus) bool {
+	return findingTransitions.allows(s, next)
+}
+
+// CanTransitionTo reports whether a document in status s may move to next
+func (s DocumentStatus) CanTransitionTo(next DocumentStatus) bool {
+	return documentTransitions.allows(s, next)
+}
+
+// CanTransitionTo reports whether a risk in status s may move to next
+func (s RiskStatus) CanTransitionTo(next RiskStatus) bool {
+	return riskTransitions.allows(s, next)
+}
+
+// CanTransitionTo reports whether an objective in status s may move to next
+func (s ObjectiveStatus) CanTransitionTo(next ObjectiveStatus) bool {
+	return objectiveTransitions.allows(s, next)
+}
+
+// complaintTransitions lets a customer reopen a complaint that was resolved or closed
+var complaintTransitions = statusMachine[string]{
+	ComplaintStatusOpen:          {ComplaintStatusInvestigating, ComplaintStatusResolved},
+	ComplaintStatusInvestigating: {ComplaintStatusResolved},
+	ComplaintStatusResolved:      {ComplaintStatusClosed, ComplaintStatusInvestigating},
+	ComplaintStatusClosed:        {ComplaintStatusInvestigating},
+}
+
+// outputTransitions lets the disposition of a nonconforming output be revised until
+// the output is closed
+var outputTransitions = statusMachine[NonconformingOutputStatus]{
+	OutputStatusOpen:          {OutputStatusDispositioned},
+	OutputStatusDispositioned: {OutputStatusDispositioned, OutputStatusClosed},
+}
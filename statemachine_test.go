@@ -0,0 +1,102 @@
+package iso9001
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestAuditStatusTransitions(t *testing.T) {
+	am := NewAuditManager()
+	if err := am.CreateAudit(&Audit{ID: "AUDIT-001", Title: "Audit", Scope: AuditScope{Description: "Scope"}}); err != nil {
+		t.Fatalf("Failed to create audit: %v", err)
+	}
+
+	err := am.CompleteAudit("AUDIT-001", time.Now(), nil)
+	var invalid *InvalidTransitionError
+	if !errors.As(err, &invalid) {
+		t.Fatalf("Expected InvalidTransitionError completing a planned audit, got %v", err)
+	}
+	if invalid.From != string(AuditStatusPlanned) || invalid.To != string(AuditStatusCompleted) {
+		t.Errorf("Unexpected transition error %+v", invalid)
+	}
+
+	if err := am.StartAudit("AUDIT-001", time.Now()); err != nil {
+		t.Fatalf("Failed to start audit: %v", err)
+	}
+	if err := am.CompleteAudit("AUDIT-001", time.Now(), nil); err != nil {
+		t.Fatalf("Failed to complete audit: %v", err)
+	}
+	if err := am.SetAuditStatus("AUDIT-001", AuditStatusClosed); err != nil {
+		t.Fatalf("Failed to close audit: %v", err)
+	}
+	if err := am.StartAudit("AUDIT-001", time.Now()); !errors.As(err, &invalid) {
+		t.Errorf("Expected closed audit to reject restart, got %v", err)
+	}
+}
+
+func TestFindingAndDocumentStatusTransitions(t *testing.T) {
+	am := NewAuditManager()
+	am.CreateAudit(&Audit{ID: "AUDIT-001", Title: "Audit", Scope: AuditScope{Description: "Scope"}})
+	am.AddFinding("AUDIT-001", AuditFinding{ID: "F-001", Status: FindingStatusClosed})
+
+	var invalid *InvalidTransitionError
+	if err := am.UpdateFindingStatus("AUDIT-001", "F-001", FindingStatusAccepted); !errors.As(err, &invalid) {
+		t.Errorf("Expected closed finding to reject acceptance, got %v", err)
+	}
+	if err := am.UpdateFindingStatus("AUDIT-001", "F-001", FindingStatusOpen); err != nil {
+		t.Errorf("Expected closed finding to be reopened, got %v", err)
+	}
+
+	// an open finding with corrective actions is closed through in progress, however
+	// its actions stand
+	am.AddFinding("AUDIT-001", AuditFinding{ID: "F-002", Severity: SeverityMinor, Status: FindingStatusOpen})
+	am.RaiseCorrectiveAction("AUDIT-001", "F-002", CorrectiveAction{Description: "Retrain operators", Status: ActionStatusVerified})
+	am.UpdateFindingStatus("AUDIT-001", "F-002", FindingStatusOpen)
+	if err := am.UpdateFindingStatus("AUDIT-001", "F-002", FindingStatusClosed); !errors.As(err, &invalid) {
+		t.Errorf("Expected open finding with corrective actions to reject closure, got %v", err)
+	}
+	if err := am.UpdateFindingStatus("AUDIT-001", "F-002", FindingStatusInProgress); err != nil {
+		t.Fatalf("Failed to resume finding: %v", err)
+	}
+	if err := am.UpdateFindingStatus("AUDIT-001", "F-002", FindingStatusClosed); err != nil {
+		t.Errorf("Expected finding in progress with verified actions to close, got %v", err)
+	}
+	if FindingStatusOpen.CanTransitionTo(FindingStatusClosed) {
+		t.Error("Expected open findings not to close in general")
+	}
+	// an open observation without corrective actions closes directly
+	am.AddFinding("AUDIT-001", AuditFinding{ID: "F-003", Severity: SeverityObservation, Status: FindingStatusOpen})
+	if err := am.UpdateFindingStatus("AUDIT-001", "F-003", FindingStatusClosed); err != nil {
+		t.Errorf("Expected open observation to close, got %v", err)
+	}
+
+	dm := NewDocumentationManager()
+	dm.AddDocument(&DocumentedInformation{ID: "DOC-001", Title: "Procedure"})
+	if err := dm.SetDocumentStatus("DOC-001", DocumentStatusPublished); !errors.As(err, &invalid) {
+		t.Errorf("Expected draft document to reject publishing, got %v", err)
+	}
+	if err := dm.ApproveDocument("DOC-001", Approval{ApproverID: "QM"}); err != nil {
+		t.Fatalf("Failed to approve document: %v", err)
+	}
+	if err := dm.SetDocumentStatus("DOC-001", DocumentStatusPublished); err != nil {
+		t.Errorf("Expected approved document to be published, got %v", err)
+	}
+}
+
+func TestObjectiveAchievementIsFinal(t *testing.T) {
+	qom := NewQualityObjectivesManager()
+	qom.CreateObjective(&QualityObjective{ID: "OBJ-001", Name: "Objective", Measurable: true, Targets: []ObjectiveTarget{{Metric: "m"}}, Responsible: "QM"})
+
+	if err := qom.UpdateObjectiveProgress("OBJ-001", ObjectiveProgress{Progress: 100}); err != nil {
+		t.Fatalf("Failed to update progress: %v", err)
+	}
+
+	var invalid *InvalidTransitionError
+	if err := qom.UpdateObjectiveProgress("OBJ-001", ObjectiveProgress{Progress: 40}); !errors.As(err, &invalid) {
+		t.Errorf("Expected achieved objective to reject regression, got %v", err)
+	}
+	if len(qom.Tracker.ProgressReports) != 1 {
+		t.Errorf("Expected rejected progress report not to be recorded, got %d reports", len(qom.Tracker.ProgressReports))
+	}
+}
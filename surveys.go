@@ -0,0 +1,382 @@
+package iso9001
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// ErrInvalidSurveyResponse is returned when a response does not fit its survey
+var ErrInvalidSurveyResponse = errors.New("invalid survey response")
+
+// QuestionType determines how answers to a survey question are given and scored
+type QuestionType string
+
+const (
+	QuestionRating QuestionType = "rating" // score on the question's scale, 1 to 5 by default
+	QuestionNPS    QuestionType = "nps"    // likelihood to recommend, 0 to 10
+	QuestionText   QuestionType = "text"   // free text, not scored
+)
+
+// SurveyQuestion is one question of a customer survey
+type SurveyQuestion struct {
+	ID   string       `json:"id" yaml:"id"`
+	Text string       `json:"text" yaml:"text"`
+	Type QuestionType `json:"type" yaml:"type"`
+	Min  int          `json:"min,omitempty" yaml:"min,omitempty"` // lowest rating, 1 when both bounds are 0
+	Max  int          `json:"max,omitempty" yaml:"max,omitempty"` // highest rating, 5 when both bounds are 0
+}
+
+// scale returns the range of scores the question accepts
+func (q SurveyQuestion) scale() (low, high float64) {
+	switch {
+	case q.Type == QuestionNPS:
+		return 0, 10
+	case q.Min == 0 && q.Max == 0:
+		return 1, 5
+	}
+	return float64(q.Min), float64(q.Max)
+}
+
+// Survey defines a customer satisfaction survey (clause 9.1.2)
+type Survey struct {
+	ID          string           `json:"id" yaml:"id"`
+	Title       string           `json:"title" yaml:"title"`
+	Description string           `json:"description,omitempty" yaml:"description,omitempty"`
+	Questions   []SurveyQuestion `json:"questions" yaml:"questions"`
+	Opens       time.Time        `json:"opens,omitempty" yaml:"opens,omitempty"`   // responses before this are rejected when set
+	Closes      time.Time        `json:"closes,omitempty" yaml:"closes,omitempty"` // responses from this time on are rejected when set
+	Created     time.Time        `json:"created" yaml:"created"`
+}
+
+func (s *Survey) question(id string) (SurveyQuestion, bool) {
+	for _, question := range s.Questions {
+		if question.ID == id {
+			return question, true
+		}
+	}
+	return SurveyQuestion{}, false
+}
+
+// SurveyResponse is one customer's answers to a survey
+type SurveyResponse struct {
+	ID         string         `json:"id" yaml:"id"`
+	SurveyID   string         `json:"survey_id" yaml:"survey_id"`
+	Respondent string         `json:"respondent,omitempty" yaml:"respondent,omitempty"` // customer or contact, if not anonymous
+	Submitted  time.Time      `json:"submitted" yaml:"submitted"`
+	Answers    []SurveyAnswer `json:"answers" yaml:"answers"`
+}
+
+// SurveyAnswer answers one question; Score is used by rating and NPS questions, Text
+// by text questions
+type SurveyAnswer struct {
+	QuestionID string  `json:"question_id" yaml:"question_id"`
+	Score      float64 `json:"score,omitempty" yaml:"score,omitempty"`
+	Text       string  `json:"text,omitempty" yaml:"text,omitempty"`
+}
+
+// SurveyManager holds survey definitions and the responses collected for them
+type SurveyManager struct {
+	Surveys   map[string]*Survey `json:"surveys" yaml:"surveys"`
+	Responses []SurveyResponse   `json:"responses" yaml:"responses"`
+
+	// IDs, when set, is shared with the organization's other managers
+	IDs *IDRegistry `json:"-" yaml:"-"`
+}
+
+// NewSurveyManager creates an empty survey manager
+func NewSurveyManager() *SurveyManager {
+	return &SurveyManager{Surveys: make(map[string]*Survey)}
+}
+
+// CreateSurvey adds a survey definition. Questions need unique IDs, a known type and,
+// for rating questions with a custom scale, a minimum below the maximum.
+func (sm *SurveyManager) CreateSurvey(survey *Survey) error {
+	if survey.ID == "" {
+		return fmt.Errorf("survey must have an ID")
+	}
+	if survey.Title == "" {
+		return fmt.Errorf("survey must have a title")
+	}
+	if len(survey.Questions) == 0 {
+		return fmt.Errorf("survey must have questions")
+	}
+	seen := make(map[string]bool, len(survey.Questions))
+	for _, question := range survey.Questions {
+		if question.ID == "" || seen[question.ID] {
+			return fmt.Errorf("survey %s: question IDs must be unique and not empty", survey.ID)
+		}
+		seen[question.ID] = true
+		switch question.Type {
+		case QuestionRating:
+			if low, high := question.scale(); low >= high {
+				return fmt.Errorf("survey %s: question %s has an empty scale", survey.ID, question.ID)
+			}
+		case QuestionNPS, QuestionText:
+		default:
+			return fmt.Errorf("survey %s: question %s has unknown type %q", survey.ID, question.ID, question.Type)
+		}
+	}
+	_, exists := sm.Surveys[survey.ID]
+	if err := claimID(sm.IDs, survey.ID, EntityTypeSurvey, exists); err != nil {
+		return err
+	}
+
+	survey.Created = time.Now()
+	sm.Surveys[survey.ID] = survey
+	return nil
+}
+
+// SubmitResponse records a response after checking it against its survey: it must be
+// submitted while the survey is open, answer only the survey's questions and each at
+// most once, and keep scores on the question's scale. NPS scores are whole numbers.
+// The submission time defaults to now and IDs are assigned as RESP-00001 onwards.
+func (sm *SurveyManager) SubmitResponse(response SurveyResponse) (SurveyResponse, error) {
+	survey, ok := sm.Surveys[response.SurveyID]
+	if !ok {
+		return SurveyResponse{}, fmt.Errorf("survey with ID %s not found", response.SurveyID)
+	}
+	if response.Submitted.IsZero() {
+		response.Submitted = time.Now()
+	}
+	if !survey.Opens.IsZero() && response.Submitted.Before(survey.Opens) ||
+		!survey.Closes.IsZero() && !response.Submitted.Before(survey.Closes) {
+		return SurveyResponse{}, fmt.Errorf("%w: survey %s is not open at %s", ErrInvalidSurveyResponse, survey.ID, response.Submitted.Format(time.RFC3339))
+	}
+	if len(response.Answers) == 0 {
+		return SurveyResponse{}, fmt.Errorf("%w: no answers", ErrInvalidSurveyResponse)
+	}
+
+	answered := make(map[string]bool, len(response.Answers))
+	for _, answer := range response.Answers {
+		question, ok := survey.question(answer.QuestionID)
+		if !ok {
+			return SurveyResponse{}, fmt.Errorf("%w: survey %s has no question %s", ErrInvalidSurveyResponse, survey.ID, answer.QuestionID)
+		}
+		if answered[answer.QuestionID] {
+			return SurveyResponse{}, fmt.Errorf("%w: question %s answered twice", ErrInvalidSurveyResponse, answer.QuestionID)
+		}
+		answered[answer.QuestionID] = true
+		if question.Type == QuestionText {
+			continue
+		}
+		low, high := question.scale()
+		if answer.Score < low || answer.Score > high || question.Type == QuestionNPS && answer.Score != math.Trunc(answer.Score) {
+			return SurveyResponse{}, fmt.Errorf("%w: score %g for question %s is outside %g-%g", ErrInvalidSurveyResponse, answer.Score, answer.QuestionID, low, high)
+		}
+	}
+
+	if response.ID == "" {
+		response.ID = fmt.Sprintf("RESP-%05d", len(sm.Responses)+1)
+	}
+	sm.Responses = append(sm.Responses, response)
+	return response, nil
+}
+
+// QuestionScore summarizes the answers to one scored question
+type QuestionScore struct {
+	QuestionID string       `json:"question_id" yaml:"question_id"`
+	Question   string       `json:"question" yaml:"question"`
+	Type       QuestionType `json:"type" yaml:"type"`
+	Average    float64      `json:"average" yaml:"average"`
+	Count      int          `json:"count" yaml:"count"`
+	// Satisfaction is the average as a percentage of the question's scale
+	Satisfaction float64 `json:"satisfaction" yaml:"satisfaction"`
+}
+
+// NPSResult breaks down the answers to NPS questions
+type NPSResult struct {
+	Score      float64 `json:"score" yaml:"score"`           // share of promoters minus share of detractors, -100 to 100
+	Promoters  int     `json:"promoters" yaml:"promoters"`   // scores of 9 and 10
+	Passives   int     `json:"passives" yaml:"passives"`     // scores of 7 and 8
+	Detractors int     `json:"detractors" yaml:"detractors"` // scores of 0 to 6
+}
+
+// SurveyScore summarizes the responses to a survey
+type SurveyScore struct {
+	SurveyID     string          `json:"survey_id" yaml:"survey_id"`
+	Responses    int             `json:"responses" yaml:"responses"`
+	Questions    []QuestionScore `json:"questions" yaml:"questions"`
+	NPS          *NPSResult      `json:"nps,omitempty" yaml:"nps,omitempty"`
+	Satisfaction float64         `json:"satisfaction" yaml:"satisfaction"` // average satisfaction of rating questions, 0-100
+}
+
+// ScoreSurvey scores the responses to a survey submitted in [since, until); zero
+// times leave the range open
+func (sm *SurveyManager) ScoreSurvey(surveyID string, since, until time.Time) (*SurveyScore, error) {
+	survey, ok := sm.Surveys[surveyID]
+	if !ok {
+		return nil, fmt.Errorf("survey with ID %s not found", surveyID)
+	}
+	score := scoreResponses(map[string]*Survey{surveyID: survey}, sm.responses(surveyID, since, until))
+	score.SurveyID = surveyID
+	return score, nil
+}
+
+// responses returns the responses to a survey, or to all surveys when surveyID is
+// empty, submitted in [since, until)
+func (sm *SurveyManager) responses(surveyID string, since, until time.Time) []SurveyResponse {
+	var matched []SurveyResponse
+	for _, response := range sm.Responses {
+		if surveyID != "" && response.SurveyID != surveyID ||
+			!since.IsZero() && response.Submitted.Before(since) ||
+			!until.IsZero() && !response.Submitted.Before(until) {
+			continue
+		}
+		matched = append(matched, response)
+	}
+	return matched
+}
+
+// scoreResponses computes per-question averages, NPS and overall satisfaction
+func scoreResponses(surveys map[string]*Survey, responses []SurveyResponse) *SurveyScore {
+	score := &SurveyScore{Responses: len(responses), Questions: []QuestionScore{}}
+	type tally struct {
+		question SurveyQuestion
+		sum      float64
+		count    int
+	}
+	tallies := make(map[string]*tally)
+	var order []string
+	nps := &NPSResult{}
+	npsAnswers := 0
+
+	for _, response := range responses {
+		survey := surveys[response.SurveyID]
+		if survey == nil {
+			continue
+		}
+		for _, answer := range response.Answers {
+			question, ok := survey.question(answer.QuestionID)
+			if !ok || question.Type == QuestionText {
+				continue
+			}
+			key := response.SurveyID + "/" + question.ID
+			t := tallies[key]
+			if t == nil {
+				t = &tally{question: question}
+				tallies[key] = t
+				order = append(order, key)
+			}
+			t.sum += answer.Score
+			t.count++
+
+			if question.Type == QuestionNPS {
+				npsAnswers++
+				switch {
+				case answer.Score >= 9:
+					nps.Promoters++
+				case answer.Score >= 7:
+					nps.Passives++
+				default:
+					nps.Detractors++
+				}
+			}
+		}
+	}
+	sort.Strings(order)
+
+	var satisfactionSum float64
+	rated := 0
+	for _, key := range order {
+		t := tallies[key]
+		low, high := t.question.scale()
+		average := t.sum / float64(t.count)
+		question := QuestionScore{
+			QuestionID:   t.question.ID,
+			Question:     t.question.Text,
+			Type:         t.question.Type,
+			Average:      average,
+			Count:        t.count,
+			Satisfaction: (average - low) / (high - low) * 100,
+		}
+		score.Questions = append(score.Questions, question)
+		if t.question.Type == QuestionRating {
+			satisfactionSum += question.Satisfaction
+			rated++
+		}
+	}
+	if rated > 0 {
+		score.Satisfaction = satisfactionSum / float64(rated)
+	}
+	if npsAnswers > 0 {
+		nps.Score = float64(nps.Promoters-nps.Detractors) / float64(npsAnswers) * 100
+		score.NPS = nps
+	}
+	return score
+}
+
+// SatisfactionReport turns all survey responses into the customer satisfaction input
+// of a management review. Overall satisfaction and NPS cover every response; trends of
+// both are computed per period as for objective trends, over the most recent periods
+// when options.Periods is set.
+func (sm *SurveyManager) SatisfactionReport(options TrendOptions) CustomerSatisfactionReport {
+	if options.Period == "" {
+		options.Period = TrendPeriodMonth
+	}
+	if options.Tolerance == 0 {
+		options.Tolerance = 1
+	}
+
+	overall := scoreResponses(sm.Surveys, sm.Responses)
+	report := CustomerSatisfactionReport{
+		OverallSatisfaction: overall.Satisfaction,
+		Responses:           overall.Responses,
+		SurveyResults:       []SurveyResult{},
+		Complaints:          []CustomerComplaint{},
+		Trends:              []Trend{},
+	}
+	if overall.NPS != nil {
+		nps := overall.NPS.Score
+		report.NetPromoterScore = &nps
+	}
+	for _, question := range overall.Questions {
+		report.SurveyResults = append(report.SurveyResults, SurveyResult{Question: question.Question, Score: question.Average, Count: question.Count})
+	}
+
+	// Score each period separately for the trends
+	byPeriod := make(map[string][]SurveyResponse)
+	var periods []string
+	for _, response := range sm.Responses {
+		key := options.Period.periodKey(response.Submitted)
+		if _, ok := byPeriod[key]; !ok {
+			periods = append(periods, key)
+		}
+		byPeriod[key] = append(byPeriod[key], response)
+	}
+	sort.Strings(periods)
+	if options.Periods > 0 && len(periods) > options.Periods {
+		periods = periods[len(periods)-options.Periods:]
+	}
+	if len(periods) < 2 {
+		return report
+	}
+
+	var satisfaction, nps []float64
+	for _, period := range periods {
+		score := scoreResponses(sm.Surveys, byPeriod[period])
+		satisfaction = append(satisfaction, score.Satisfaction)
+		if score.NPS != nil {
+			nps = append(nps, score.NPS.Score)
+		}
+	}
+	span := periods[0] + "/" + periods[len(periods)-1]
+	report.Trends = append(report.Trends, Trend{Metric: "customer_satisfaction", Direction: trendDirection(satisfaction, options.Tolerance), Period: span, Data: satisfaction})
+	if len(nps) == len(periods) {
+		report.Trends = append(report.Trends, Trend{Metric: "net_promoter_score", Direction: trendDirection(nps, options.Tolerance), Period: span, Data: nps})
+	}
+	return report
+}
+
+// trendDirection classifies a series by its least-squares slope
+func trendDirection(values []float64, tolerance float64) string {
+	switch slope := trendSlope(values); {
+	case slope >= tolerance:
+		return TrendImproving
+	case slope <= -tolerance:
+		return TrendDeclining
+	}
+	return TrendStable
+}
@@ -0,0 +1,67 @@
+package iso9001
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSurveyScoring(t *testing.T) {
+	sm := NewSurveyManager()
+	survey := &Survey{
+		ID:    "SURV-001",
+		Title: "Customer satisfaction 2024",
+		Questions: []SurveyQuestion{
+			{ID: "Q1", Text: "How satisfied are you with delivery?", Type: QuestionRating},
+			{ID: "Q2", Text: "How likely are you to recommend us?", Type: QuestionNPS},
+			{ID: "Q3", Text: "What should we improve?", Type: QuestionText},
+		},
+	}
+	if err := sm.CreateSurvey(survey); err != nil {
+		t.Fatalf("Failed to create survey: %v", err)
+	}
+	if err := sm.CreateSurvey(&Survey{ID: "SURV-002", Title: "Bad", Questions: []SurveyQuestion{{ID: "Q1", Type: "stars"}}}); err == nil {
+		t.Error("Expected unknown question type to be rejected")
+	}
+
+	if _, err := sm.SubmitResponse(SurveyResponse{SurveyID: "SURV-001", Answers: []SurveyAnswer{{QuestionID: "Q2", Score: 11}}}); !errors.Is(err, ErrInvalidSurveyResponse) {
+		t.Errorf("Expected out-of-scale NPS score to be rejected, got %v", err)
+	}
+	if _, err := sm.SubmitResponse(SurveyResponse{SurveyID: "SURV-001", Answers: []SurveyAnswer{{QuestionID: "Q9", Score: 3}}}); !errors.Is(err, ErrInvalidSurveyResponse) {
+		t.Errorf("Expected unknown question to be rejected, got %v", err)
+	}
+
+	january := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	for i, answers := range [][2]float64{{5, 10}, {4, 9}, {3, 7}, {1, 3}} {
+		month := january.AddDate(0, i/2, 0)
+		if _, err := sm.SubmitResponse(SurveyResponse{SurveyID: "SURV-001", Submitted: month, Answers: []SurveyAnswer{
+			{QuestionID: "Q1", Score: answers[0]},
+			{QuestionID: "Q2", Score: answers[1]},
+			{QuestionID: "Q3", Text: "Faster quotes"},
+		}}); err != nil {
+			t.Fatalf("Failed to submit response %d: %v", i, err)
+		}
+	}
+
+	score, err := sm.ScoreSurvey("SURV-001", time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if score.Responses != 4 || len(score.Questions) != 2 || score.Questions[0].Average != 3.25 {
+		t.Errorf("Unexpected survey score %+v", score)
+	}
+	if score.NPS == nil || score.NPS.Score != 25 || score.NPS.Promoters != 2 || score.NPS.Passives != 1 || score.NPS.Detractors != 1 {
+		t.Errorf("Unexpected NPS %+v", score.NPS)
+	}
+	if score.Satisfaction != 56.25 {
+		t.Errorf("Expected satisfaction of 56.25%%, got %v", score.Satisfaction)
+	}
+
+	report := sm.SatisfactionReport(TrendOptions{})
+	if report.Responses != 4 || report.NetPromoterScore == nil || *report.NetPromoterScore != 25 || len(report.SurveyResults) != 2 {
+		t.Errorf("Unexpected satisfaction report %+v", report)
+	}
+	if len(report.Trends) != 2 || report.Trends[0].Direction != TrendDeclining || report.Trends[1].Metric != "net_promoter_score" || report.Trends[1].Period != "2024-01/2024-02" {
+		t.Errorf("Expected declining satisfaction and NPS trends, got %+v", report.Trends)
+	}
+}
@@ -0,0 +1,341 @@
+package iso9001
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Errors returned when templates are stored or instantiated
+var (
+	ErrTemplateNotFound     = errors.New("template not found")
+	ErrTemplateVariable     = errors.New("invalid template variable")
+	ErrTemplateKindMismatch = errors.New("template is of another kind")
+)
+
+// TemplateKind is the kind of entity a template instantiates
+type TemplateKind string
+
+const (
+	TemplateKindDocument TemplateKind = "document"
+	TemplateKindProcess  TemplateKind = "process"
+)
+
+// templatePlaceholder matches variable placeholders such as {{process_name}}
+var templatePlaceholder = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+
+// Template is a parameterized document or process. Text fields of the body may contain
+// placeholders such as {{owner}}, which are replaced by variable values when the
+// template is instantiated.
+type Template struct {
+	ID          string                 `json:"id" yaml:"id"`
+	Name        string                 `json:"name" yaml:"name"`
+	Description string                 `json:"description,omitempty" yaml:"description,omitempty"`
+	Kind        TemplateKind           `json:"kind" yaml:"kind"`
+	Variables   []TemplateVariable     `json:"variables,omitempty" yaml:"variables,omitempty"`
+	Document    *DocumentedInformation `json:"document,omitempty" yaml:"document,omitempty"`
+	Process     *Process               `json:"process,omitempty" yaml:"process,omitempty"`
+}
+
+// TemplateVariable declares a variable of a template. Variables without a default
+// must be given a value when the template is instantiated.
+type TemplateVariable struct {
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	Default     string `json:"default,omitempty" yaml:"default,omitempty"`
+}
+
+// TemplateManager stores the document and process templates of an organization. The
+// built-in templates are always available; an organization's template with the same
+// ID replaces the built-in one.
+type TemplateManager struct {
+	Templates map[string]*Template `json:"templates" yaml:"templates"`
+}
+
+// NewTemplateManager creates a template manager holding only the built-in templates
+func NewTemplateManager() *TemplateManager {
+	return &TemplateManager{Templates: make(map[string]*Template)}
+}
+
+// AddTemplate stores a template, replacing any template with the same ID. Every
+// placeholder in the body must be a declared variable.
+func (tm *TemplateManager) AddTemplate(template *Template) error {
+	if template.ID == "" {
+		return fmt.Errorf("template must have an ID")
+	}
+	var body any
+	switch template.Kind {
+	case TemplateKindDocument:
+		body = template.Document
+		if template.Document == nil {
+			return fmt.Errorf("document template %s has no document", template.ID)
+		}
+	case TemplateKindProcess:
+		body = template.Process
+		if template.Process == nil {
+			return fmt.Errorf("process template %s has no process", template.ID)
+		}
+	default:
+		return fmt.Errorf("template %s has unknown kind %q", template.ID, template.Kind)
+	}
+
+	declared := make(map[string]bool)
+	for _, variable := range template.Variables {
+		if !templatePlaceholder.MatchString("{{" + variable.Name + "}}") {
+			return fmt.Errorf("%w: template %s declares %q", ErrTemplateVariable, template.ID, variable.Name)
+		}
+		declared[variable.Name] = true
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	for _, match := range templatePlaceholder.FindAllStringSubmatch(string(data), -1) {
+		if !declared[match[1]] {
+			return fmt.Errorf("%w: template %s uses undeclared {{%s}}", ErrTemplateVariable, template.ID, match[1])
+		}
+	}
+
+	if tm.Templates == nil {
+		tm.Templates = make(map[string]*Template)
+	}
+	tm.Templates[template.ID] = template
+	return nil
+}
+
+// RemoveTemplate removes an organization's template. A built-in template it replaced
+// becomes available again; built-in templates themselves cannot be removed.
+func (tm *TemplateManager) RemoveTemplate(templateID string) error {
+	if _, exists := tm.Templates[templateID]; !exists {
+		return fmt.Errorf("%w: %s", ErrTemplateNotFound, templateID)
+	}
+	delete(tm.Templates, templateID)
+	return nil
+}
+
+// GetTemplate returns the organization's template with the ID, or else the built-in one
+func (tm *TemplateManager) GetTemplate(templateID string) (*Template, error) {
+	if template, exists := tm.Templates[templateID]; exists {
+		return template, nil
+	}
+	if template, exists := builtinTemplates()[templateID]; exists {
+		return template, nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrTemplateNotFound, templateID)
+}
+
+// ListTemplates returns the available templates of a kind, or of all kinds when kind
+// is empty, ordered by ID
+func (tm *TemplateManager) ListTemplates(kind TemplateKind) []*Template {
+	available := builtinTemplates()
+	for id, template := range tm.Templates {
+		available[id] = template
+	}
+	var templates []*Template
+	for _, template := range available {
+		if kind == "" || template.Kind == kind {
+			templates = append(templates, template)
+		}
+	}
+	sort.Slice(templates, func(i, j int) bool { return templates[i].ID < templates[j].ID })
+	return templates
+}
+
+// InstantiateDocument creates a draft document from a document template. The document
+// is not added to a documentation manager.
+func (tm *TemplateManager) InstantiateDocument(templateID string, values map[string]string) (*DocumentedInformation, error) {
+	template, err := tm.GetTemplate(templateID)
+	if err != nil {
+		return nil, err
+	}
+	if template.Kind != TemplateKindDocument {
+		return nil, fmt.Errorf("%w: %s is a %s template", ErrTemplateKindMismatch, templateID, template.Kind)
+	}
+	doc := &DocumentedInformation{}
+	if err := template.instantiate(template.Document, doc, values); err != nil {
+		return nil, err
+	}
+	doc.Status = DocumentStatusDraft
+	doc.Versions = nil
+	doc.Approval = nil
+	return doc, nil
+}
+
+// InstantiateProcess creates a planned process from a process template
+func (tm *TemplateManager) InstantiateProcess(templateID string, values map[string]string) (*Process, error) {
+	template, err := tm.GetTemplate(templateID)
+	if err != nil {
+		return nil, err
+	}
+	if template.Kind != TemplateKindProcess {
+		return nil, fmt.Errorf("%w: %s is a %s template", ErrTemplateKindMismatch, templateID, template.Kind)
+	}
+	process := &Process{}
+	if err := template.instantiate(template.Process, process, values); err != nil {
+		return nil, err
+	}
+	if process.Status == "" {
+		process.Status = ProcessStatusPlanned
+	}
+	process.Created = time.Now()
+	return process, nil
+}
+
+// instantiate copies the template body into target, replacing the placeholders in
+// every text field
+func (t *Template) instantiate(body, target any, values map[string]string) error {
+	resolved := make(map[string]string, len(t.Variables))
+	for _, variable := range t.Variables {
+		resolved[variable.Name] = variable.Default
+	}
+	for name, value := range values {
+		if _, declared := resolved[name]; !declared {
+			return fmt.Errorf("%w: template %s has no variable %q", ErrTemplateVariable, t.ID, name)
+		}
+		resolved[name] = value
+	}
+	var missing []string
+	for _, variable := range t.Variables {
+		if resolved[variable.Name] == "" {
+			missing = append(missing, variable.Name)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: template %s needs values for %s", ErrTemplateVariable, t.ID, strings.Join(missing, ", "))
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	var tree any
+	if err := json.Unmarshal(data, &tree); err != nil {
+		return err
+	}
+	if data, err = json.Marshal(substitutePlaceholders(tree, resolved)); err != nil {
+		return err
+	}
+	return json.Unmarshal(data, target)
+}
+
+// substitutePlaceholders replaces placeholders in the strings of a decoded JSON value
+func substitutePlaceholders(value any, values map[string]string) any {
+	switch v := value.(type) {
+	case string:
+		return templatePlaceholder.ReplaceAllStringFunc(v, func(placeholder string) string {
+			return values[templatePlaceholder.FindStringSubmatch(placeholder)[1]]
+		})
+	case []any:
+		for i := range v {
+			v[i] = substitutePlaceholders(v[i], values)
+		}
+	case map[string]any:
+		for key := range v {
+			v[key] = substitutePlaceholders(v[key], values)
+		}
+	}
+	return value
+}
+
+// builtinTemplates returns fresh copies of the built-in templates, so callers cannot
+// change them
+func builtinTemplates() map[string]*Template {
+	owner := TemplateVariable{Name: "owner", Description: "Role responsible for the document or process", Default: "Quality Manager"}
+	templates := []*Template{
+		{
+			ID:          "quality_policy",
+			Name:        "Quality Policy",
+			Description: "Quality policy statement of the organization (clause 5.2)",
+			Kind:        TemplateKindDocument,
+			Variables: []TemplateVariable{
+				{Name: "id", Description: "Document ID", Default: "QP-001"},
+				{Name: "organization", Description: "Name of the organization"},
+				owner,
+			},
+			Document: &DocumentedInformation{
+				ID:       "{{id}}",
+				Title:    "{{organization}} Quality Policy",
+				Type:     DocumentTypePolicy,
+				Category: CategoryQualityManagement,
+				Content: "{{organization}} Quality Policy\n\n1. Purpose\n2. Scope\n3. Policy Statement\n" +
+					"4. Objectives\n5. Commitment\n6. Communication\n7. Review\n\nOwner: {{owner}}",
+				Metadata: DocumentMetadata{Owner: "{{owner}}", RelatedClauses: []string{"5.2"}, Format: "electronic"},
+			},
+		},
+		{
+			ID:          "procedure",
+			Name:        "Procedure",
+			Description: "Documented procedure for a process",
+			Kind:        TemplateKindDocument,
+			Variables: []TemplateVariable{
+				{Name: "id", Description: "Document ID"},
+				{Name: "process_name", Description: "Name of the process the procedure describes"},
+				owner,
+			},
+			Document: &DocumentedInformation{
+				ID:       "{{id}}",
+				Title:    "{{process_name}} Procedure",
+				Type:     DocumentTypeProcedure,
+				Category: CategoryProcessManagement,
+				Content: "{{process_name}} Procedure\n\n1. Purpose\n2. Scope\n3. Responsibilities\n\n{{owner}} owns this procedure.\n\n" +
+					"4. Procedure\n5. Records\n6. References",
+				Metadata: DocumentMetadata{Owner: "{{owner}}", Keywords: []string{"{{process_name}}"}, RelatedClauses: []string{"4.4", "8.1"}, Format: "electronic"},
+			},
+		},
+		{
+			ID:          "work_instruction",
+			Name:        "Work Instruction",
+			Description: "Step-by-step instruction for a task",
+			Kind:        TemplateKindDocument,
+			Variables: []TemplateVariable{
+				{Name: "id", Description: "Document ID"},
+				{Name: "task", Description: "Task the instruction describes"},
+				owner,
+			},
+			Document: &DocumentedInformation{
+				ID:       "{{id}}",
+				Title:    "{{task}} Work Instruction",
+				Type:     DocumentTypeWorkInstruction,
+				Category: CategoryProcessManagement,
+				Content: "{{task}} Work Instruction\n\n1. Purpose\n2. Scope\n3. Safety Considerations\n4. Equipment/Materials\n" +
+					"5. Procedure Steps\n6. Quality Checks\n7. Records",
+				Metadata: DocumentMetadata{Owner: "{{owner}}", Keywords: []string{"{{task}}"}, RelatedClauses: []string{"7.5", "8.5.1"}, Format: "electronic"},
+			},
+		},
+		{
+			ID:          "process",
+			Name:        "QMS Process",
+			Description: "Process of the QMS with an owner and a performance criterion (clause 4.4)",
+			Kind:        TemplateKindProcess,
+			Variables: []TemplateVariable{
+				{Name: "id", Description: "Process ID"},
+				{Name: "name", Description: "Name of the process"},
+				{Name: "description", Description: "What the process does", Default: "To be described by the process owner"},
+				owner,
+			},
+			Process: &Process{
+				ID:               "{{id}}",
+				Name:             "{{name}}",
+				Description:      "{{description}}",
+				Inputs:           []ProcessInput{},
+				Outputs:          []ProcessOutput{},
+				Resources:        []Resource{},
+				Responsibilities: []string{"{{owner}}"},
+				Criteria:         []ProcessCriteria{{ID: "{{id}}-C1", Name: "{{name}} effectiveness", Metric: "To be defined", Target: "To be defined"}},
+				Risks:            []Risk{},
+				Opportunities:    []Opportunity{},
+				Status:           ProcessStatusPlanned,
+			},
+		},
+	}
+
+	byID := make(map[string]*Template, len(templates))
+	for _, template := range templates {
+		byID[template.ID] = template
+	}
+	return byID
+}
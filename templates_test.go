@@ -0,0 +1,74 @@
+package iso9001
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestTemplateManager(t *testing.T) {
+	tm := NewTemplateManager()
+
+	doc, err := tm.InstantiateDocument("procedure", map[string]string{"id": "PROC-010", "process_name": "Purchasing"})
+	if err != nil {
+		t.Fatalf("Failed to instantiate procedure: %v", err)
+	}
+	if doc.ID != "PROC-010" || doc.Title != "Purchasing Procedure" || doc.Metadata.Owner != "Quality Manager" || doc.Metadata.Keywords[0] != "Purchasing" {
+		t.Errorf("Unexpected document %+v", doc)
+	}
+	if !strings.Contains(doc.Content, "Quality Manager owns this procedure") || doc.Status != DocumentStatusDraft {
+		t.Errorf("Expected placeholders in the content to be replaced, got %q", doc.Content)
+	}
+	if _, err := tm.InstantiateDocument("procedure", map[string]string{"id": "PROC-011"}); !errors.Is(err, ErrTemplateVariable) {
+		t.Errorf("Expected missing variable to be reported, got %v", err)
+	}
+	if _, err := tm.InstantiateDocument("procedure", map[string]string{"id": "PROC-011", "process_name": "X", "typo": "y"}); !errors.Is(err, ErrTemplateVariable) {
+		t.Errorf("Expected unknown variable to be reported, got %v", err)
+	}
+	if _, err := tm.InstantiateDocument("process", map[string]string{"id": "P-1", "name": "X"}); !errors.Is(err, ErrTemplateKindMismatch) {
+		t.Errorf("Expected process template to be refused as a document, got %v", err)
+	}
+
+	process, err := tm.InstantiateProcess("process", map[string]string{"id": "P-PUR", "name": "Purchasing", "owner": "Purchasing Manager"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if process.Name != "Purchasing" || process.Responsibilities[0] != "Purchasing Manager" || process.Criteria[0].ID != "P-PUR-C1" || process.Status != ProcessStatusPlanned || process.Created.IsZero() {
+		t.Errorf("Unexpected process %+v", process)
+	}
+
+	// Organization templates replace built-in ones and must declare their variables
+	custom := &Template{
+		ID:        "procedure",
+		Name:      "Procedure",
+		Kind:      TemplateKindDocument,
+		Variables: []TemplateVariable{{Name: "id"}, {Name: "process_name"}},
+		Document:  &DocumentedInformation{ID: "{{id}}", Title: "SOP {{process_name}}", Content: "Approved by {{approver}}"},
+	}
+	if err := tm.AddTemplate(custom); !errors.Is(err, ErrTemplateVariable) {
+		t.Errorf("Expected undeclared placeholder to be rejected, got %v", err)
+	}
+	custom.Document.Content = "Steps for {{ process_name }}"
+	if err := tm.AddTemplate(custom); err != nil {
+		t.Fatal(err)
+	}
+	doc, _ = tm.InstantiateDocument("procedure", map[string]string{"id": "SOP-1", "process_name": "Receiving"})
+	if doc.Title != "SOP Receiving" || doc.Content != "Steps for Receiving" {
+		t.Errorf("Expected organization template to be used, got %+v", doc)
+	}
+	if custom.Document.Title != "SOP {{process_name}}" {
+		t.Error("Expected instantiation to leave the template unchanged")
+	}
+	if len(tm.ListTemplates(TemplateKindDocument)) != 3 || len(tm.ListTemplates("")) != 4 {
+		t.Errorf("Expected 3 document templates and 4 in all, got %d and %d", len(tm.ListTemplates(TemplateKindDocument)), len(tm.ListTemplates("")))
+	}
+	if err := tm.RemoveTemplate("procedure"); err != nil {
+		t.Fatal(err)
+	}
+	if template, _ := tm.GetTemplate("procedure"); template.Document.Title != "{{process_name}} Procedure" {
+		t.Error("Expected built-in template to be restored")
+	}
+	if err := tm.RemoveTemplate("procedure"); !errors.Is(err, ErrTemplateNotFound) {
+		t.Errorf("Expected built-in template not to be removable, got %v", err)
+	}
+}
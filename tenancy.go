@@ -0,0 +1,976 @@
+package iso9001
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrTenantNotFound is returned when a tenant is neither cached nor known to the backend
+var ErrTenantNotFound = errors.New("tenant not found")
+
+// DefaultTenantShards is the number of shards used when NewTenantStore is given zero
+const DefaultTenantShards = 32
+
+// Tenant holds the isolated QMS state of a single organization
+type Tenant struct {
+	SchemaVersion int                       `json:"schema_version,omitempty" yaml:"schema_version,omitempty"`
+	ID            string                    `json:"id" yaml:"id"`
+	Organization  *Organization             `json:"organization" yaml:"organization"`
+	Documents     *DocumentationManager     `json:"documents" yaml:"documents"`
+	Risks         *RiskManager              `json:"risks" yaml:"risks"`
+	Objectives    *QualityObjectivesManager `json:"objectives" yaml:"objectives"`
+	Audits        *AuditManager             `json:"audits" yaml:"audits"`
+	Measurements  *MeasurementLog           `json:"measurements,omitempty" yaml:"measurements,omitempty"`
+	// Restructurings traces the mergers and splits the organization took part in
+	Restructurings []RestructuringRecord `json:"restructurings,omitempty" yaml:"restructurings,omitempty"`
+	// Compliance holds the periodic compliance snapshots of the organization
+	Compliance *ComplianceHistory `json:"compliance,omitempty" yaml:"compliance,omitempty"`
+	// Nonconformities are reported by external systems through events
+	Nonconformities []NonconformanceReport `json:"nonconformities,omitempty" yaml:"nonconformities,omitempty"`
+	// Feedback holds customer complaints, whether recorded directly or reported through
+	// events, and satisfaction surveys
+	Feedback *CustomerFeedbackManager `json:"feedback,omitempty" yaml:"feedback,omitempty"`
+	// Outputs records nonconforming products and services and their dispositions
+	Outputs *NonconformingOutputManager `json:"nonconforming_outputs,omitempty" yaml:"nonconforming_outputs,omitempty"`
+	// Templates holds the organization's document and process templates
+	Templates *TemplateManager `json:"templates,omitempty" yaml:"templates,omitempty"`
+	// Events maps each applied inbound event, as "source:id", to the entity it created
+	Events map[string]string `json:"events,omitempty" yaml:"events,omitempty"`
+	// Users are the people of the organization that references such as
+	// Responsible and Approval.ApproverID resolve to
+	Users *UserManager `json:"users,omitempty" yaml:"users,omitempty"`
+	// Trail records the changes made through Change
+	Trail *AuditTrail `json:"trail,omitempty" yaml:"trail,omitempty"`
+	// PrivacySalt keys the pseudonyms ScrubPersonalData gives people. It is generated
+	// on the first scrub and must stay secret, so pseudonyms cannot be traced to IDs.
+	PrivacySalt string `json:"privacy_salt,omitempty" yaml:"privacy_salt,omitempty"`
+
+	mu         tenantLock
+	lastAccess time.Time
+	dirty      bool
+}
+
+// tenantLock is a mutex whose callers can give up waiting once a context is done. The
+// zero value is unlocked.
+type tenantLock struct {
+	once sync.Once
+	held chan struct{}
+}
+
+func (l *tenantLock) init() {
+	l.once.Do(func() { l.held = make(chan struct{}, 1) })
+}
+
+// Lock waits until the lock is free and takes it
+func (l *tenantLock) Lock() {
+	l.init()
+	l.held <- struct{}{}
+}
+
+// LockContext takes the lock like Lock, unless ctx is done first
+func (l *tenantLock) LockContext(ctx context.Context) error {
+	l.init()
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	select {
+	case l.held <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// TryLock takes the lock if it is free and reports whether it did
+func (l *tenantLock) TryLock() bool {
+	l.init()
+	select {
+	case l.held <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+// Unlock releases the lock
+func (l *tenantLock) Unlock() {
+	l.init()
+	select {
+	case <-l.held:
+	default:
+		panic("iso9001: unlock of unlocked tenant")
+	}
+}
+
+// NewTenant creates an empty tenant with initialized managers. Document approvers are
+// verified against the tenant's Users once it has any.
+func NewTenant(id string) *Tenant {
+	tenant := &Tenant{
+		SchemaVersion: CurrentSchemaVersion,
+		ID:            id,
+		Organization:  &Organization{SchemaVersion: CurrentSchemaVersion, ID: id, Created: time.Now(), Modified: time.Now()},
+		Documents:     NewDocumentationManager(),
+		Risks:         NewRiskManager(),
+		Objectives:    NewQualityObjectivesManager(),
+		Audits:        NewAuditManager(),
+		Measurements:  NewMeasurementLog(),
+		Feedback:      NewCustomerFeedbackManager(),
+		Outputs:       NewNonconformingOutputManager(),
+		Templates:     NewTemplateManager(),
+		Users:         NewUserManager(),
+		Trail:         NewAuditTrail(),
+	}
+	tenant.Documents.Approvers = tenantDirectory{tenant}
+	tenant.shareIDRegistry()
+	tenant.ConfigureDueDates()
+	return tenant
+}
+
+// Replace makes the tenant hold the organization and records of from, e.g. one
+// restored from an export, keeping its own ID and audit trail. The approver
+// directory, document repository and due date calculators of the tenant stay in
+// place. from must not be used afterwards.
+func (t *Tenant) Replace(from *Tenant) {
+	hooks := &Tenant{Documents: t.Documents, Risks: t.Risks, Objectives: t.Objectives, Audits: t.Audits, Feedback: t.Feedback}
+
+	t.Organization = from.Organization
+	t.Documents = from.Documents
+	t.Risks = from.Risks
+	t.Objectives = from.Objectives
+	t.Audits = from.Audits
+	t.Measurements = from.Measurements
+	t.Restructurings = from.Restructurings
+	t.Compliance = from.Compliance
+	t.Nonconformities = from.Nonconformities
+	t.Feedback = from.Feedback
+	t.Outputs = from.Outputs
+	t.Templates = from.Templates
+	t.Events = from.Events
+	t.Users = from.Users
+	t.PrivacySalt = from.PrivacySalt
+	if t.Organization != nil {
+		t.Organization.ID = t.ID
+	}
+
+	t.inheritHooks(hooks)
+	t.shareIDRegistry()
+	t.ConfigureDueDates() // keeps the inherited calculators when the calendar is invalid
+}
+
+// inheritHooks gives the managers of the tenant the approver directory, document
+// repository and due date calculators of from, which are not saved with a tenant
+func (t *Tenant) inheritHooks(from *Tenant) {
+	if t.Documents != nil && from.Documents != nil {
+		t.Documents.Approvers = from.Documents.Approvers
+		if _, users := from.Documents.Approvers.(tenantDirectory); users {
+			t.Documents.Approvers = tenantDirectory{t}
+		}
+		t.Documents.Repository = from.Documents.Repository
+		t.Documents.Attachments = from.Documents.Attachments
+	}
+	if t.Risks != nil && from.Risks != nil {
+		t.Risks.DueDates = from.Risks.DueDates
+	}
+	if t.Objectives != nil && from.Objectives != nil {
+		t.Objectives.DueDates = from.Objectives.DueDates
+	}
+	if t.Audits != nil && from.Audits != nil {
+		t.Audits.DueDates = from.Audits.DueDates
+	}
+	if t.Feedback != nil && from.Feedback != nil {
+		t.Feedback.DueDates = from.Feedback.DueDates
+	}
+}
+
+// ConfigureDueDates gives the risk, objective, audit and feedback managers a due date
+// calculator built from the time zone and calendar of the tenant's organization. The
+// managers keep their calculators when the organization's settings are invalid.
+func (t *Tenant) ConfigureDueDates() error {
+	if t.Organization == nil {
+		return nil
+	}
+	dueDates, err := NewDueDateCalculatorForOrganization(t.Organization)
+	if err != nil {
+		return fmt.Errorf("organization %s: %w", t.ID, err)
+	}
+	if t.Risks != nil {
+		t.Risks.DueDates = dueDates
+	}
+	if t.Objectives != nil {
+		t.Objectives.DueDates = dueDates
+	}
+	if t.Audits != nil {
+		t.Audits.DueDates = dueDates
+	}
+	if t.Feedback != nil {
+		t.Feedback.DueDates = dueDates
+	}
+	return nil
+}
+
+// shareIDRegistry gives all managers of the tenant one ID registry, seeded with the
+// IDs they already hold, so IDs stay unique across entity types
+func (t *Tenant) shareIDRegistry() {
+	registry := NewIDRegistry()
+	registry.Register(t.ID, EntityTypeOrganization)
+
+	for id := range t.Documents.Documents {
+		registry.Register(id, EntityTypeDocument)
+	}
+	for id := range t.Risks.Risks {
+		registry.Register(id, EntityTypeRisk)
+	}
+	for id := range t.Risks.Opportunities {
+		registry.Register(id, EntityTypeOpportunity)
+	}
+	for id := range t.Objectives.Objectives {
+		registry.Register(id, EntityTypeObjective)
+	}
+	for id, audit := range t.Audits.Audits {
+		registry.Register(id, EntityTypeAudit)
+		for _, finding := range audit.Findings {
+			if finding.ID != "" {
+				registry.Register(finding.ID, EntityTypeFinding)
+			}
+		}
+	}
+	for id := range t.Audits.ManagementReviews {
+		registry.Register(id, EntityTypeManagementReview)
+	}
+	if t.Feedback != nil {
+		for id := range t.Feedback.Complaints {
+			registry.Register(id, EntityTypeComplaint)
+		}
+		for id := range t.Feedback.Surveys.Surveys {
+			registry.Register(id, EntityTypeSurvey)
+		}
+		t.Feedback.IDs = registry
+		t.Feedback.Surveys.IDs = registry
+	}
+	if t.Outputs != nil {
+		for id := range t.Outputs.Outputs {
+			registry.Register(id, EntityTypeNonconformingOutput)
+		}
+		t.Outputs.IDs = registry
+	}
+	if t.Users != nil {
+		for id := range t.Users.Users {
+			registry.Register(id, EntityTypeUser)
+		}
+		t.Users.IDs = registry
+	}
+
+	t.Documents.IDs = registry
+	t.Risks.IDs = registry
+	t.Objectives.IDs = registry
+	t.Audits.IDs = registry
+}
+
+// TenantCollections names the entity collections of a tenant addressable by
+// Tenant.Lookup, e.g. in resource URIs such as qms://ACME/risks/RISK-001
+var TenantCollections = []string{"organizations", "documents", "risks", "opportunities", "objectives", "audits", "management_reviews", "nonconformities", "complaints", "nonconforming_outputs", "users", "compliance_timeline"}
+
+// Lookup returns an entity of the tenant by collection and ID. The organizations
+// collection holds only the tenant's own organization. The compliance_timeline
+// collection holds the compliance history grouped by week, month or quarter, e.g.
+// qms://ACME/compliance_timeline/quarter.
+func (t *Tenant) Lookup(collection, id string) (interface{}, bool) {
+	switch collection {
+	case "organizations":
+		if t.Organization != nil && t.Organization.ID == id {
+			return t.Organization, true
+		}
+	case "documents":
+		if doc, ok := t.Documents.Documents[id]; ok {
+			return doc, true
+		}
+	case "risks":
+		if risk, ok := t.Risks.Risks[id]; ok {
+			return risk, true
+		}
+	case "opportunities":
+		if opportunity, ok := t.Risks.Opportunities[id]; ok {
+			return opportunity, true
+		}
+	case "objectives":
+		if objective, ok := t.Objectives.Objectives[id]; ok {
+			return objective, true
+		}
+	case "audits":
+		if audit, ok := t.Audits.Audits[id]; ok {
+			return audit, true
+		}
+	case "management_reviews":
+		if review, ok := t.Audits.ManagementReviews[id]; ok {
+			return review, true
+		}
+	case "nonconformities":
+		for i := range t.Nonconformities {
+			if t.Nonconformities[i].ID == id {
+				return &t.Nonconformities[i], true
+			}
+		}
+	case "complaints":
+		if t.Feedback != nil {
+			if complaint, ok := t.Feedback.Complaints[id]; ok {
+				return complaint, true
+			}
+		}
+	case "nonconforming_outputs":
+		if t.Outputs != nil {
+			if output, ok := t.Outputs.Outputs[id]; ok {
+				return output, true
+			}
+		}
+	case "users":
+		if t.Users != nil {
+			if user, ok := t.Users.Users[id]; ok {
+				return user, true
+			}
+		}
+	case "compliance_timeline":
+		period := TrendPeriod(id)
+		if t.Compliance != nil && (period == TrendPeriodWeek || period == TrendPeriodMonth || period == TrendPeriodQuarter) {
+			timeline := t.Compliance.Timeline(period, time.Time{}, time.Time{})
+			return &timeline, true
+		}
+	}
+	return nil, false
+}
+
+// Collection returns all entities of a collection of the tenant named in
+// TenantCollections, ordered by ID, e.g. for resource URIs such as
+// qms://organizations/ACME/risks. The compliance_timeline collection cannot be listed.
+func (t *Tenant) Collection(collection string) (interface{}, bool) {
+	switch collection {
+	case "organizations":
+		organizations := []*Organization{}
+		if t.Organization != nil {
+			organizations = append(organizations, t.Organization)
+		}
+		return organizations, true
+	case "documents":
+		return sortedValues(t.Documents.Documents), true
+	case "risks":
+		return sortedValues(t.Risks.Risks), true
+	case "opportunities":
+		return sortedValues(t.Risks.Opportunities), true
+	case "objectives":
+		return sortedValues(t.Objectives.Objectives), true
+	case "audits":
+		return sortedValues(t.Audits.Audits), true
+	case "management_reviews":
+		return sortedValues(t.Audits.ManagementReviews), true
+	case "nonconformities":
+		return append([]NonconformanceReport{}, t.Nonconformities...), true
+	case "complaints":
+		if t.Feedback == nil {
+			return []*CustomerComplaint{}, true
+		}
+		return sortedValues(t.Feedback.Complaints), true
+	case "nonconforming_outputs":
+		if t.Outputs == nil {
+			return []*NonconformingOutput{}, true
+		}
+		return sortedValues(t.Outputs.Outputs), true
+	case "users":
+		if t.Users == nil {
+			return []*User{}, true
+		}
+		return sortedValues(t.Users.Users), true
+	}
+	return nil, false
+}
+
+// sortedValues returns the values of a map ordered by key
+func sortedValues[V any](m map[string]V) []V {
+	values := make([]V, 0, len(m))
+	for _, key := range sortedKeys(m) {
+		values = append(values, m[key])
+	}
+	return values
+}
+
+// TenantScoped keeps one value per tenant, keyed by the organization ID, for state a
+// server holds beside its TenantStore, such as change feeds. Values are created on
+// first use, so the state of one tenant never shows up in another.
+type TenantScoped[T any] struct {
+	mu     sync.Mutex
+	values map[string]T
+	create func() T
+}
+
+// NewTenantScoped creates an empty TenantScoped whose values are made by create
+func NewTenantScoped[T any](create func() T) *TenantScoped[T] {
+	return &TenantScoped[T]{values: make(map[string]T), create: create}
+}
+
+// For returns the value of a tenant, creating it on first use
+func (s *TenantScoped[T]) For(tenantID string) T {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	value, ok := s.values[tenantID]
+	if !ok {
+		value = s.create()
+		s.values[tenantID] = value
+	}
+	return value
+}
+
+// Tenants returns the IDs of the tenants holding a value, sorted
+func (s *TenantScoped[T]) Tenants() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return sortedKeys(s.values)
+}
+
+// TenantBackend loads and persists tenants for a TenantStore
+type TenantBackend interface {
+	// LoadTenant returns the stored tenant or ErrTenantNotFound
+	LoadTenant(id string) (*Tenant, error)
+	// SaveTenant persists the tenant
+	SaveTenant(tenant *Tenant) error
+}
+
+// TenantStore hosts many tenants in memory, spread over independently locked shards.
+// Tenants are loaded lazily from the backend on first access and can be evicted
+// again once they have been idle for a while.
+type TenantStore struct {
+	shards   []*tenantShard
+	backend  TenantBackend
+	now      func() time.Time
+	trailKey []byte
+}
+
+type tenantShard struct {
+	mu      sync.Mutex
+	tenants map[string]*Tenant
+}
+
+// NewTenantStore creates a tenant store. A nil backend keeps tenants in memory only,
+// in which case evicted tenants are lost.
+func NewTenantStore(backend TenantBackend, shards int) *TenantStore {
+	if shards <= 0 {
+		shards = DefaultTenantShards
+	}
+
+	store := &TenantStore{
+		shards:  make([]*tenantShard, shards),
+		backend: backend,
+		now:     time.Now,
+	}
+	for i := range store.shards {
+		store.shards[i] = &tenantShard{tenants: make(map[string]*Tenant)}
+	}
+	return store
+}
+
+func (ts *TenantStore) shardFor(id string) *tenantShard {
+	h := fnv.New32a()
+	h.Write([]byte(id))
+	return ts.shards[h.Sum32()%uint32(len(ts.shards))]
+}
+
+// SetTrailKey keys the audit trails of the tenants the store creates, loads or is
+// given with key, e.g. one read with LoadTrailKey. It is passed on to a backend that
+// rebuilds trails itself, such as an EventSourcedBackend. Call it before the store is
+// used; trails saved without a key must be sealed with AuditTrail.Seal to verify.
+func (ts *TenantStore) SetTrailKey(key []byte) {
+	ts.trailKey = key
+	if backend, ok := ts.backend.(interface{ setTrailKey([]byte) }); ok {
+		backend.setTrailKey(key)
+	}
+}
+
+// keyTrail applies the store's audit trail key to a tenant entering the store
+func (ts *TenantStore) keyTrail(tenant *Tenant) {
+	if tenant.Trail == nil {
+		tenant.Trail = NewAuditTrail()
+	}
+	tenant.Trail.SetKey(ts.trailKey)
+}
+
+// CreateTenant registers a new empty tenant
+func (ts *TenantStore) CreateTenant(id string) (*Tenant, error) {
+	if id == "" {
+		return nil, fmt.Errorf("tenant must have an ID")
+	}
+
+	shard := ts.shardFor(id)
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+
+	if _, exists := shard.tenants[id]; exists {
+		return nil, fmt.Errorf("tenant with ID %s already exists", id)
+	}
+	if ts.backend != nil {
+		if _, err := ts.backend.LoadTenant(id); err == nil {
+			return nil, fmt.Errorf("tenant with ID %s already exists", id)
+		} else if !errors.Is(err, ErrTenantNotFound) {
+			return nil, fmt.Errorf("failed to check tenant %s: %w", id, err)
+		}
+	}
+
+	tenant := NewTenant(id)
+	ts.keyTrail(tenant)
+	tenant.lastAccess = ts.now()
+	tenant.dirty = true
+	shard.tenants[id] = tenant
+	return tenant, nil
+}
+
+// GetTenant returns a tenant, loading it from the backend if it is not in memory
+func (ts *TenantStore) GetTenant(id string) (*Tenant, error) {
+	return ts.GetTenantContext(context.Background(), id)
+}
+
+// GetTenantContext returns a tenant like GetTenant. It fails with the context's error
+// once ctx is done, and passes ctx to a ContextTenantBackend loading the tenant.
+func (ts *TenantStore) GetTenantContext(ctx context.Context, id string) (*Tenant, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	shard := ts.shardFor(id)
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+
+	if tenant, exists := shard.tenants[id]; exists {
+		return tenant, nil
+	}
+	if ts.backend == nil {
+		return nil, fmt.Errorf("%w: %s", ErrTenantNotFound, id)
+	}
+
+	tenant, err := ts.load(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	ts.keyTrail(tenant)
+	tenant.lastAccess = ts.now()
+	shard.tenants[id] = tenant
+	return tenant, nil
+}
+
+// PutTenant adds a tenant to the store, replacing the tenant with the same ID, e.g. to
+// restore one from an export. The tenant is saved on the next flush or eviction.
+func (ts *TenantStore) PutTenant(tenant *Tenant) error {
+	if tenant.ID == "" {
+		return fmt.Errorf("tenant must have an ID")
+	}
+
+	shard := ts.shardFor(tenant.ID)
+	for {
+		shard.mu.Lock()
+		existing, exists := shard.tenants[tenant.ID]
+		if !exists {
+			ts.put(shard, tenant)
+			shard.mu.Unlock()
+			return nil
+		}
+		shard.mu.Unlock()
+
+		// wait for callers still working on the replaced tenant; they find it replaced
+		// once they get its lock
+		existing.mu.Lock()
+		shard.mu.Lock()
+		replaced := shard.tenants[tenant.ID] == existing
+		if replaced {
+			ts.put(shard, tenant)
+		}
+		shard.mu.Unlock()
+		existing.mu.Unlock()
+		if replaced {
+			return nil
+		}
+	}
+}
+
+// put adds a tenant to a shard whose lock is held by the caller
+func (ts *TenantStore) put(shard *tenantShard, tenant *Tenant) {
+	ts.keyTrail(tenant)
+	tenant.lastAccess = ts.now()
+	tenant.dirty = true
+	shard.tenants[tenant.ID] = tenant
+}
+
+// WithTenant runs fn while holding the tenant's lock, so concurrent callers working
+// on the same organization never observe each other's partial changes. fn may read
+// the tenant or change it through Tenant.Change; other changes are only saved when
+// made through UpdateTenant.
+func (ts *TenantStore) WithTenant(id string, fn func(tenant *Tenant) error) error {
+	return ts.WithTenantContext(context.Background(), id, fn)
+}
+
+// WithTenantContext runs fn like WithTenant. fn is not run when ctx is done before the
+// tenant is loaded or while waiting for its lock, e.g. once a request's deadline has
+// passed behind a long change by another caller.
+func (ts *TenantStore) WithTenantContext(ctx context.Context, id string, fn func(tenant *Tenant) error) error {
+	tenant, err := ts.lockTenant(ctx, id)
+	if err != nil {
+		return err
+	}
+	defer tenant.mu.Unlock()
+	return fn(tenant)
+}
+
+// UpdateTenant runs fn like WithTenant and marks the tenant as modified, so the next
+// flush or eviction saves whatever fn changed
+func (ts *TenantStore) UpdateTenant(id string, fn func(tenant *Tenant) error) error {
+	return ts.UpdateTenantContext(context.Background(), id, fn)
+}
+
+// UpdateTenantContext runs fn like UpdateTenant, unless ctx is done first
+func (ts *TenantStore) UpdateTenantContext(ctx context.Context, id string, fn func(tenant *Tenant) error) error {
+	tenant, err := ts.lockTenant(ctx, id)
+	if err != nil {
+		return err
+	}
+	defer tenant.mu.Unlock()
+	tenant.dirty = true
+	return fn(tenant)
+}
+
+// lockTenant returns the tenant with its lock held. A tenant evicted or replaced
+// while the caller waited for its lock is looked up again, so changes never go to a
+// tenant the store no longer holds.
+func (ts *TenantStore) lockTenant(ctx context.Context, id string) (*Tenant, error) {
+	for {
+		tenant, err := ts.GetTenantContext(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if err := tenant.mu.LockContext(ctx); err != nil {
+			return nil, err
+		}
+		if !ts.holds(tenant) {
+			tenant.mu.Unlock()
+			continue
+		}
+		tenant.lastAccess = ts.now()
+		return tenant, nil
+	}
+}
+
+// holds reports whether the tenant is still the one the store holds under its ID
+func (ts *TenantStore) holds(tenant *Tenant) bool {
+	shard := ts.shardFor(tenant.ID)
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+	return shard.tenants[tenant.ID] == tenant
+}
+
+// loadedTenants returns the tenants of a shard held in memory
+func (shard *tenantShard) loadedTenants() []*Tenant {
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+	tenants := make([]*Tenant, 0, len(shard.tenants))
+	for _, tenant := range shard.tenants {
+		tenants = append(tenants, tenant)
+	}
+	return tenants
+}
+
+// Loaded returns the number of tenants currently held in memory
+func (ts *TenantStore) Loaded() int {
+	count := 0
+	for _, shard := range ts.shards {
+		shard.mu.Lock()
+		count += len(shard.tenants)
+		shard.mu.Unlock()
+	}
+	return count
+}
+
+// EvictIdle saves and removes tenants that have not been accessed within maxIdle.
+// Tenants that fail to save stay in memory and their errors are joined in the result.
+// Tenants are saved without holding their shard, so other tenants stay available.
+func (ts *TenantStore) EvictIdle(maxIdle time.Duration) (int, error) {
+	cutoff := ts.now().Add(-maxIdle)
+	evicted := 0
+	var errs []error
+
+	for _, shard := range ts.shards {
+		for _, tenant := range shard.loadedTenants() {
+			if !tenant.mu.TryLock() {
+				continue // in use
+			}
+			if tenant.lastAccess.After(cutoff) || !ts.holds(tenant) {
+				tenant.mu.Unlock()
+				continue
+			}
+			if err := ts.saveLocked(context.Background(), tenant); err != nil {
+				errs = append(errs, err)
+				tenant.mu.Unlock()
+				continue
+			}
+			shard.mu.Lock()
+			if shard.tenants[tenant.ID] == tenant {
+				delete(shard.tenants, tenant.ID)
+				evicted++
+			}
+			shard.mu.Unlock()
+			tenant.mu.Unlock()
+		}
+	}
+
+	return evicted, errors.Join(errs...)
+}
+
+// StartEviction periodically evicts tenants idle for longer than maxIdle until the
+// returned stop function is called
+func (ts *TenantStore) StartEviction(interval, maxIdle time.Duration, onError func(error)) (stop func()) {
+	done := make(chan struct{})
+	ticker := time.NewTicker(interval)
+
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if _, err := ts.EvictIdle(maxIdle); err != nil && onError != nil {
+					onError(err)
+				}
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() { once.Do(func() { close(done) }) }
+}
+
+// Flush saves every modified tenant held in memory
+func (ts *TenantStore) Flush() error {
+	return ts.FlushContext(context.Background())
+}
+
+// FlushContext saves every modified tenant like Flush, stopping with the context's
+// error once ctx is done. Tenants not saved yet stay modified for the next flush.
+func (ts *TenantStore) FlushContext(ctx context.Context) error {
+	var errs []error
+	for _, shard := range ts.shards {
+		for _, tenant := range shard.loadedTenants() {
+			if err := ctx.Err(); err != nil {
+				return errors.Join(append(errs, err)...)
+			}
+			if err := ts.flush(ctx, tenant); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// FlushTenant saves one tenant if it was modified, e.g. after a change to it, without
+// waiting for other tenants
+func (ts *TenantStore) FlushTenant(id string) error {
+	return ts.FlushTenantContext(context.Background(), id)
+}
+
+// FlushTenantContext saves one tenant like FlushTenant, unless ctx is done first
+func (ts *TenantStore) FlushTenantContext(ctx context.Context, id string) error {
+	shard := ts.shardFor(id)
+	shard.mu.Lock()
+	tenant, exists := shard.tenants[id]
+	shard.mu.Unlock()
+	if !exists {
+		return nil
+	}
+	return ts.flush(ctx, tenant)
+}
+
+// flush saves a tenant unless it was evicted, and so saved, or replaced meanwhile
+func (ts *TenantStore) flush(ctx context.Context, tenant *Tenant) error {
+	if err := tenant.mu.LockContext(ctx); err != nil {
+		return err
+	}
+	defer tenant.mu.Unlock()
+	if !ts.holds(tenant) {
+		return nil
+	}
+	return ts.saveLocked(ctx, tenant)
+}
+
+// load reads a tenant from the backend, passing ctx to a ContextTenantBackend
+func (ts *TenantStore) load(ctx context.Context, id string) (*Tenant, error) {
+	if backend, ok := ts.backend.(ContextTenantBackend); ok {
+		return backend.LoadTenantContext(ctx, id)
+	}
+	return ts.backend.LoadTenant(id)
+}
+
+// saveLocked persists a tenant whose lock is held by the caller
+func (ts *TenantStore) saveLocked(ctx context.Context, tenant *Tenant) error {
+	if ts.backend == nil || !tenant.dirty {
+		return nil
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	save := ts.backend.SaveTenant
+	if backend, ok := ts.backend.(ContextTenantBackend); ok {
+		save = func(tenant *Tenant) error { return backend.SaveTenantContext(ctx, tenant) }
+	}
+	if err := save(tenant); err != nil {
+		return fmt.Errorf("failed to save tenant %s: %w", tenant.ID, err)
+	}
+	tenant.dirty = false
+	return nil
+}
+
+// FileTenantBackend stores each tenant as a JSON file in a directory
+type FileTenantBackend struct {
+	Dir string
+}
+
+// NewFileTenantBackend creates a file backend, creating the directory if needed
+func NewFileTenantBackend(dir string) (*FileTenantBackend, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create tenant directory: %w", err)
+	}
+	return &FileTenantBackend{Dir: dir}, nil
+}
+
+func (fb *FileTenantBackend) path(id string) (string, error) {
+	if id == "" || id == "." || id == ".." || strings.ContainsAny(id, `/\`) {
+		return "", fmt.Errorf("invalid tenant ID %q", id)
+	}
+	return filepath.Join(fb.Dir, id+".json"), nil
+}
+
+// ListTenants returns the IDs of the tenants stored in the directory, sorted
+func (fb *FileTenantBackend) ListTenants() ([]string, error) {
+	entries, err := os.ReadDir(fb.Dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || filepath.Ext(name) != ".json" {
+			continue
+		}
+		ids = append(ids, strings.TrimSuffix(name, ".json"))
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// LoadTenant reads a tenant from its JSON file
+func (fb *FileTenantBackend) LoadTenant(id string) (*Tenant, error) {
+	path, err := fb.path(id)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrTenantNotFound, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeTenant(id, data)
+}
+
+// LoadTenantJSON decodes a tenant exported as JSON, migrating it to the current schema
+func LoadTenantJSON(data []byte) (*Tenant, error) {
+	var header struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, fmt.Errorf("failed to decode tenant: %w", err)
+	}
+	if header.ID == "" {
+		return nil, fmt.Errorf("tenant must have an ID")
+	}
+	return decodeTenant(header.ID, data)
+}
+
+// decodeTenant migrates and decodes the JSON of the tenant with the given ID
+func decodeTenant(id string, data []byte) (*Tenant, error) {
+	data, err := DefaultMigrations.MigrateJSON(SchemaKindTenant, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to migrate tenant %s: %w", id, err)
+	}
+
+	tenant := NewTenant(id)
+	if err := json.Unmarshal(data, tenant); err != nil {
+		return nil, fmt.Errorf("failed to decode tenant %s: %w", id, err)
+	}
+	tenant.ID = id
+	tenant.SchemaVersion = CurrentSchemaVersion
+	if tenant.Organization != nil {
+		tenant.Organization.SchemaVersion = CurrentSchemaVersion
+	}
+	tenant.shareIDRegistry()
+	tenant.ConfigureDueDates() // a tenant with an invalid calendar still loads, counting in UTC
+	return tenant, nil
+}
+
+// SaveTenant writes a tenant to its JSON file, replacing it atomically
+func (fb *FileTenantBackend) SaveTenant(tenant *Tenant) error {
+	path, err := fb.path(tenant.ID)
+	if err != nil {
+		return err
+	}
+
+	tenant.SchemaVersion = CurrentSchemaVersion
+	if tenant.Organization != nil {
+		tenant.Organization.SchemaVersion = CurrentSchemaVersion
+	}
+	data, err := json.Marshal(tenant)
+	if err != nil {
+		return fmt.Errorf("failed to encode tenant %s: %w", tenant.ID, err)
+	}
+
+	return writeFileAtomic(path, data, 0o644)
+}
+
+// writeFileAtomic replaces the file at path with data. The data is written to a
+// temporary file of its own in the same directory, synced and renamed over path, so
+// a crash or a concurrent writer in another process never leaves a partial file.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // fails harmlessly once renamed
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), perm); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return err
+	}
+	// make the rename itself durable; not every platform can sync a directory
+	if d, err := os.Open(dir); err == nil {
+		d.Sync()
+		d.Close()
+	}
+	return nil
+}
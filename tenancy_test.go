@@ -0,0 +1,302 @@
+package iso9001
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestTenantStoreLazyLoadAndEviction(t *testing.T) {
+	backend, err := NewFileTenantBackend(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create backend: %v", err)
+	}
+
+	store := NewTenantStore(backend, 4)
+	now := time.Now()
+	store.now = func() time.Time { return now }
+
+	if _, err := store.CreateTenant("ORG-001"); err != nil {
+		t.Fatalf("Failed to create tenant: %v", err)
+	}
+
+	err = store.UpdateTenant("ORG-001", func(tenant *Tenant) error {
+		return tenant.Risks.IdentifyRisk(&Risk{ID: "RISK-001", Description: "Tenant risk"})
+	})
+	if err != nil {
+		t.Fatalf("Failed to update tenant: %v", err)
+	}
+
+	now = now.Add(time.Hour)
+	evicted, err := store.EvictIdle(30 * time.Minute)
+	if err != nil {
+		t.Fatalf("Failed to evict tenants: %v", err)
+	}
+	if evicted != 1 || store.Loaded() != 0 {
+		t.Fatalf("Expected tenant to be evicted, evicted=%d loaded=%d", evicted, store.Loaded())
+	}
+
+	tenant, err := store.GetTenant("ORG-001")
+	if err != nil {
+		t.Fatalf("Failed to reload tenant: %v", err)
+	}
+	if _, exists := tenant.Risks.Risks["RISK-001"]; !exists {
+		t.Error("Expected reloaded tenant to contain its risk")
+	}
+
+	if _, err := store.GetTenant("ORG-404"); !errors.Is(err, ErrTenantNotFound) {
+		t.Errorf("Expected ErrTenantNotFound, got %v", err)
+	}
+
+	ids, err := backend.ListTenants()
+	if err != nil || len(ids) != 1 || ids[0] != "ORG-001" {
+		t.Errorf("Expected stored tenant ORG-001 to be listed, got %v (%v)", ids, err)
+	}
+}
+
+// countingBackend counts the tenants saved to a file backend
+type countingBackend struct {
+	*FileTenantBackend
+	saves int
+}
+
+func (b *countingBackend) SaveTenant(tenant *Tenant) error {
+	b.saves++
+	return b.FileTenantBackend.SaveTenant(tenant)
+}
+
+func TestTenantStoreSavesOnlyChanges(t *testing.T) {
+	files, err := NewFileTenantBackend(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create backend: %v", err)
+	}
+	backend := &countingBackend{FileTenantBackend: files}
+	store := NewTenantStore(backend, 1)
+	for _, id := range []string{"ORG-001", "ORG-002"} {
+		if _, err := store.CreateTenant(id); err != nil {
+			t.Fatalf("Failed to create tenant: %v", err)
+		}
+	}
+	if err := store.FlushTenant("ORG-001"); err != nil || backend.saves != 1 {
+		t.Fatalf("Expected FlushTenant to save one tenant, got %d saves (%v)", backend.saves, err)
+	}
+	if err := store.Flush(); err != nil || backend.saves != 2 {
+		t.Fatalf("Expected Flush to save the other tenant, got %d saves (%v)", backend.saves, err)
+	}
+
+	store.WithTenant("ORG-001", func(tenant *Tenant) error {
+		_ = tenant.Risks.Risks["RISK-001"]
+		return nil
+	})
+	if err := store.Flush(); err != nil || backend.saves != 2 {
+		t.Errorf("Expected a read not to save the tenant, got %d saves (%v)", backend.saves, err)
+	}
+
+	store.WithTenant("ORG-001", func(tenant *Tenant) error {
+		return tenant.Change("jane", "identify risk", func(tenant *Tenant) error {
+			return tenant.Risks.IdentifyRisk(&Risk{ID: "RISK-001", Description: "Late delivery"})
+		})
+	})
+	if err := store.Flush(); err != nil || backend.saves != 3 {
+		t.Errorf("Expected a change to save the tenant, got %d saves (%v)", backend.saves, err)
+	}
+}
+
+func TestTenantStoreRetriesEvictedTenant(t *testing.T) {
+	backend, err := NewFileTenantBackend(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create backend: %v", err)
+	}
+	store := NewTenantStore(backend, 1)
+	evicted, err := store.CreateTenant("ORG-001")
+	if err != nil {
+		t.Fatalf("Failed to create tenant: %v", err)
+	}
+
+	// a caller waits for the tenant while it is evicted
+	evicted.mu.Lock()
+	done := make(chan error)
+	go func() {
+		done <- store.UpdateTenant("ORG-001", func(tenant *Tenant) error {
+			return tenant.Risks.IdentifyRisk(&Risk{ID: "RISK-001", Description: "Late delivery"})
+		})
+	}()
+	time.Sleep(10 * time.Millisecond)
+	if err := store.saveLocked(context.Background(), evicted); err != nil {
+		t.Fatalf("Failed to save tenant: %v", err)
+	}
+	store.shards[0].mu.Lock()
+	delete(store.shards[0].tenants, "ORG-001")
+	store.shards[0].mu.Unlock()
+	evicted.mu.Unlock()
+
+	if err := <-done; err != nil {
+		t.Fatalf("Failed to update tenant: %v", err)
+	}
+	if _, exists := evicted.Risks.Risks["RISK-001"]; exists {
+		t.Error("Expected the change not to go to the evicted tenant")
+	}
+	if err := store.Flush(); err != nil {
+		t.Fatalf("Failed to flush: %v", err)
+	}
+	stored, err := backend.LoadTenant("ORG-001")
+	if err != nil {
+		t.Fatalf("Failed to load tenant: %v", err)
+	}
+	if _, exists := stored.Risks.Risks["RISK-001"]; !exists {
+		t.Error("Expected the change to be saved")
+	}
+}
+
+func TestTenantLookup(t *testing.T) {
+	tenant := NewTenant("ACME")
+	tenant.Risks.Risks["RISK-001"] = &Risk{ID: "RISK-001", Description: "Supplier failure"}
+
+	if entity, ok := tenant.Lookup("organizations", "ACME"); !ok || entity.(*Organization).ID != "ACME" {
+		t.Errorf("Expected tenant organization, got %v", entity)
+	}
+	if entity, ok := tenant.Lookup("risks", "RISK-001"); !ok || entity.(*Risk).Description != "Supplier failure" {
+		t.Errorf("Expected risk, got %v", entity)
+	}
+	if _, ok := tenant.Lookup("organizations", "GLOBEX"); ok {
+		t.Error("Lookup must not return another organization")
+	}
+	if _, ok := tenant.Lookup("secrets", "RISK-001"); ok {
+		t.Error("Unknown collection should not match")
+	}
+}
+
+func TestTenantCollection(t *testing.T) {
+	tenant := NewTenant("ACME")
+	tenant.Risks.Risks["RISK-002"] = &Risk{ID: "RISK-002"}
+	tenant.Risks.Risks["RISK-001"] = &Risk{ID: "RISK-001"}
+
+	entities, ok := tenant.Collection("risks")
+	if risks := entities.([]*Risk); !ok || len(risks) != 2 || risks[0].ID != "RISK-001" {
+		t.Errorf("Expected both risks ordered by ID, got %v", entities)
+	}
+	if entities, ok := tenant.Collection("audits"); !ok || len(entities.([]*Audit)) != 0 {
+		t.Errorf("Expected an empty audit list, got %v", entities)
+	}
+	if _, ok := tenant.Collection("compliance_timeline"); ok {
+		t.Error("The compliance timeline should not be listable")
+	}
+}
+
+func TestTenantScoped(t *testing.T) {
+	logs := NewTenantScoped(func() *ChangeLog { return NewChangeLog(0) })
+	logs.For("ACME").Record(EntityTypeRisk, "RISK-001", ChangeOperationCreated, &Risk{ID: "RISK-001", Description: "Supplier failure"})
+	logs.For("GLOBEX").Record(EntityTypeRisk, "RISK-001", ChangeOperationCreated, &Risk{ID: "RISK-001", Description: "Plant fire"})
+
+	acme := logs.For("ACME").Since(0, 0).Changes
+	if len(acme) != 1 || acme[0].Entity.(*Risk).Description != "Supplier failure" {
+		t.Errorf("Expected only the ACME risk, got %+v", acme)
+	}
+	if logs.For("ACME") != logs.For("ACME") {
+		t.Error("Expected the same log on every call")
+	}
+	if got := logs.Tenants(); len(got) != 2 || got[0] != "ACME" || got[1] != "GLOBEX" {
+		t.Errorf("Expected both tenants, got %v", got)
+	}
+}
+
+func TestLoadTenantJSONAndPutTenant(t *testing.T) {
+	exported := NewTenant("ACME")
+	if err := exported.Risks.IdentifyRisk(&Risk{ID: "RISK-001", Description: "Supplier failure"}); err != nil {
+		t.Fatalf("Failed to identify risk: %v", err)
+	}
+	data, err := json.Marshal(exported)
+	if err != nil {
+		t.Fatalf("Failed to export tenant: %v", err)
+	}
+
+	tenant, err := LoadTenantJSON(data)
+	if err != nil {
+		t.Fatalf("Failed to load tenant: %v", err)
+	}
+	if tenant.ID != "ACME" || tenant.Risks.Risks["RISK-001"] == nil {
+		t.Fatalf("Expected ACME with RISK-001, got %s with %d risks", tenant.ID, len(tenant.Risks.Risks))
+	}
+	if err := tenant.Audits.CreateAudit(&Audit{ID: "RISK-001", Title: "Clash", Scope: AuditScope{Description: "All"}}); err == nil {
+		t.Error("Expected the loaded tenant to share one ID registry")
+	}
+	if _, err := LoadTenantJSON([]byte(`{"organization": {}}`)); err == nil {
+		t.Error("Expected a tenant without ID to be rejected")
+	}
+
+	store := NewTenantStore(nil, 1)
+	if _, err := store.CreateTenant("ACME"); err != nil {
+		t.Fatalf("Failed to create tenant: %v", err)
+	}
+	if err := store.PutTenant(tenant); err != nil {
+		t.Fatalf("Failed to put tenant: %v", err)
+	}
+	stored, err := store.GetTenant("ACME")
+	if err != nil || stored != tenant {
+		t.Errorf("Expected the put tenant to replace the created one, got %v", err)
+	}
+}
+
+func TestTenantStoreTrailKey(t *testing.T) {
+	key := []byte("secret")
+	for name, backend := range map[string]TenantBackend{
+		"file":   mustFileBackend(t),
+		"events": NewEventSourcedBackend(NewMemoryEventStore()),
+	} {
+		store := NewTenantStore(backend, 0)
+		store.SetTrailKey(key)
+		if _, err := store.CreateTenant("acme"); err != nil {
+			t.Fatalf("%s: failed to create tenant: %v", name, err)
+		}
+		store.WithTenant("acme", func(tenant *Tenant) error {
+			return tenant.Change("jane", "identify", func(tenant *Tenant) error {
+				return tenant.Risks.IdentifyRisk(&Risk{ID: "RISK-001", Description: "Supplier fails"})
+			})
+		})
+		if err := store.Flush(); err != nil {
+			t.Fatalf("%s: failed to flush: %v", name, err)
+		}
+
+		reloaded := NewTenantStore(backend, 0)
+		reloaded.SetTrailKey(key)
+		tenant, err := reloaded.GetTenant("acme")
+		if err != nil {
+			t.Fatalf("%s: failed to reload tenant: %v", name, err)
+		}
+		if err := tenant.Trail.Verify(); err != nil || len(tenant.Trail.Entries) == 0 {
+			t.Errorf("%s: expected keyed trail to verify after reload, got %d entries and %v", name, len(tenant.Trail.Entries), err)
+		}
+		tenant.Trail.SetKey(nil)
+		if err := tenant.Trail.Verify(); !errors.Is(err, ErrTrailTampered) {
+			t.Errorf("%s: expected trail to be keyed, got %v", name, err)
+		}
+	}
+}
+
+func mustFileBackend(t *testing.T) *FileTenantBackend {
+	backend, err := NewFileTenantBackend(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create backend: %v", err)
+	}
+	return backend
+}
+
+func TestFileTenantBackendAtomicSave(t *testing.T) {
+	backend := mustFileBackend(t)
+	tenant := NewTenant("acme")
+	for i := 0; i < 2; i++ {
+		if err := backend.SaveTenant(tenant); err != nil {
+			t.Fatalf("Failed to save tenant: %v", err)
+		}
+	}
+	entries, err := os.ReadDir(backend.Dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Name() != "acme.json" {
+		t.Errorf("Expected only the tenant file, got %v", entries)
+	}
+}
@@ -0,0 +1,439 @@
+package iso9001
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Errors returned when a reference to a person cannot be resolved to a user
+var (
+	ErrUnknownUser        = errors.New("user not found")
+	ErrAmbiguousReference = errors.New("reference matches more than one user")
+)
+
+// User is a person with an identity in the QMS. The user ID is the ID the person is
+// known by everywhere else: as a Person of top management, as an AuditParticipant,
+// as the ApproverID of approvals and as the assignee of organizational roles.
+type User struct {
+	ID    string `json:"id" yaml:"id"`
+	Name  string `json:"name" yaml:"name"`
+	Email string `json:"email,omitempty" yaml:"email,omitempty"`
+	// Roles are the organizational roles the user holds, by role ID or name
+	Roles      []string  `json:"roles,omitempty" yaml:"roles,omitempty"`
+	Competence []string  `json:"competence,omitempty" yaml:"competence,omitempty"`
+	Training   []string  `json:"training,omitempty" yaml:"training,omitempty"`
+	Created    time.Time `json:"created" yaml:"created"`
+	// Deactivated is set when the person leaves; deactivated users keep their records
+	// but no longer resolve references or approve documents
+	Deactivated *time.Time `json:"deactivated,omitempty" yaml:"deactivated,omitempty"`
+}
+
+// Active reports whether the user has not been deactivated
+func (u *User) Active() bool {
+	return u.Deactivated == nil
+}
+
+// HoldsRole reports whether the user is assigned the role, matched case-insensitively
+func (u *User) HoldsRole(role string) bool {
+	for _, held := range u.Roles {
+		if strings.EqualFold(held, role) {
+			return true
+		}
+	}
+	return false
+}
+
+// Person returns the user as a person of the organization, e.g. for top management.
+// The role is the first role the user holds.
+func (u *User) Person() Person {
+	person := Person{
+		ID:         u.ID,
+		Name:       u.Name,
+		Competence: append([]string(nil), u.Competence...),
+		Training:   append([]string(nil), u.Training...),
+	}
+	if len(u.Roles) > 0 {
+		person.Role = u.Roles[0]
+	}
+	return person
+}
+
+// Participant returns the user as an audit participant in the given role, e.g.
+// "Lead Auditor"
+func (u *User) Participant(role string) AuditParticipant {
+	return AuditParticipant{ID: u.ID, Name: u.Name, Role: role, Competence: append([]string(nil), u.Competence...)}
+}
+
+// Approval returns an approval given by the user in the given role, for
+// DocumentationManager.ApproveDocument
+func (u *User) Approval(role, comments string) Approval {
+	return Approval{ApproverID: u.ID, ApproverName: u.Name, Role: role, Timestamp: time.Now(), Comments: comments}
+}
+
+// UserManager keeps the users of an organization and their role assignments. It is an
+// ApproverDirectory, so it can verify document approvals when set as
+// DocumentationManager.Approvers.
+type UserManager struct {
+	Users map[string]*User `json:"users" yaml:"users"`
+
+	// IDs, when set, is shared with the organization's other managers
+	IDs *IDRegistry `json:"-" yaml:"-"`
+}
+
+// NewUserManager creates a new user manager
+func NewUserManager() *UserManager {
+	return &UserManager{Users: make(map[string]*User)}
+}
+
+// AddUser registers a user. A name is required and email addresses must be unique. An
+// ID of the form USR-001 is assigned when none is given.
+func (um *UserManager) AddUser(user *User) error {
+	if user.Name == "" {
+		return fmt.Errorf("user must have a name")
+	}
+	if user.Email != "" {
+		for _, existing := range um.Users {
+			if strings.EqualFold(existing.Email, user.Email) {
+				return fmt.Errorf("email %s is already used by user %s", user.Email, existing.ID)
+			}
+		}
+	}
+	if user.ID == "" {
+		user.ID = um.nextUserID()
+	}
+	_, exists := um.Users[user.ID]
+	if err := claimID(um.IDs, user.ID, EntityTypeUser, exists); err != nil {
+		return err
+	}
+
+	if user.Created.IsZero() {
+		user.Created = time.Now()
+	}
+	um.Users[user.ID] = user
+	return nil
+}
+
+func (um *UserManager) nextUserID() string {
+	for n := len(um.Users) + 1; ; n++ {
+		id := fmt.Sprintf("USR-%03d", n)
+		if _, exists := um.Users[id]; !exists {
+			return id
+		}
+	}
+}
+
+// GetUser returns a user by ID, deactivated users included
+func (um *UserManager) GetUser(userID string) (*User, error) {
+	user, exists := um.Users[userID]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownUser, userID)
+	}
+	return user, nil
+}
+
+// AssignRole assigns an organizational role to a user
+func (um *UserManager) AssignRole(userID, role string) error {
+	user, err := um.GetUser(userID)
+	if err != nil {
+		return err
+	}
+	if role == "" {
+		return fmt.Errorf("role is required")
+	}
+	if !user.HoldsRole(role) {
+		user.Roles = append(user.Roles, role)
+	}
+	return nil
+}
+
+// UnassignRole removes an organizational role from a user
+func (um *UserManager) UnassignRole(userID, role string) error {
+	user, err := um.GetUser(userID)
+	if err != nil {
+		return err
+	}
+	roles := user.Roles[:0]
+	for _, held := range user.Roles {
+		if !strings.EqualFold(held, role) {
+			roles = append(roles, held)
+		}
+	}
+	user.Roles = roles
+	return nil
+}
+
+// DeactivateUser marks a user as having left the organization
+func (um *UserManager) DeactivateUser(userID string, at time.Time) error {
+	user, err := um.GetUser(userID)
+	if err != nil {
+		return err
+	}
+	if !user.Active() {
+		return fmt.Errorf("user %s is already deactivated", userID)
+	}
+	user.Deactivated = &at
+	return nil
+}
+
+// HoldersOf returns the active users holding a role, ordered by ID
+func (um *UserManager) HoldersOf(role string) []*User {
+	var holders []*User
+	for _, user := range sortedValues(um.Users) {
+		if user.Active() && user.HoldsRole(role) {
+			holders = append(holders, user)
+		}
+	}
+	return holders
+}
+
+// Resolve returns the active user a reference names, such as the Responsible of an
+// objective or the Owner of a risk. A reference is matched against user IDs, then
+// email addresses, then names and finally roles, so Responsible: "Quality Manager"
+// resolves to the one user holding that role. Matches other than by ID are
+// case-insensitive. ErrAmbiguousReference is returned when a name or role matches
+// more than one user.
+func (um *UserManager) Resolve(reference string) (*User, error) {
+	reference = strings.TrimSpace(reference)
+	if user, ok := um.Users[reference]; ok && user.Active() {
+		return user, nil
+	}
+
+	var byEmail, byName []*User
+	for _, user := range sortedValues(um.Users) {
+		if !user.Active() {
+			continue
+		}
+		if user.Email != "" && strings.EqualFold(user.Email, reference) {
+			byEmail = append(byEmail, user)
+		}
+		if strings.EqualFold(user.Name, reference) {
+			byName = append(byName, user)
+		}
+	}
+	for _, matches := range [][]*User{byEmail, byName, um.HoldersOf(reference)} {
+		switch len(matches) {
+		case 0:
+			continue
+		case 1:
+			return matches[0], nil
+		default:
+			return nil, fmt.Errorf("%w: %q matches %s", ErrAmbiguousReference, reference, userIDs(matches))
+		}
+	}
+	return nil, fmt.Errorf("%w: %q", ErrUnknownUser, reference)
+}
+
+// userIDs lists the IDs of users for messages
+func userIDs(users []*User) string {
+	ids := make([]string, len(users))
+	for i, user := range users {
+		ids[i] = user.ID
+	}
+	return strings.Join(ids, ", ")
+}
+
+// ImportOrganization creates users for the people of an organization's top management
+// and the assignees of its roles, and assigns their roles by ID and name. People that
+// are already users only gain missing roles. It returns the users created.
+func (um *UserManager) ImportOrganization(org *Organization) ([]*User, error) {
+	var created []*User
+	if org.Leadership == nil {
+		return created, nil
+	}
+	ensure := func(id, name string) (*User, error) {
+		if user, ok := um.Users[id]; ok {
+			return user, nil
+		}
+		if name == "" {
+			name = id
+		}
+		user := &User{ID: id, Name: name}
+		if err := um.AddUser(user); err != nil {
+			return nil, err
+		}
+		created = append(created, user)
+		return user, nil
+	}
+
+	for _, person := range org.Leadership.TopManagement {
+		if person.ID == "" {
+			continue
+		}
+		user, err := ensure(person.ID, person.Name)
+		if err != nil {
+			return created, err
+		}
+		if len(user.Competence) == 0 && len(user.Training) == 0 {
+			user.Competence = append([]string(nil), person.Competence...)
+			user.Training = append([]string(nil), person.Training...)
+		}
+		if person.Role != "" {
+			if err := um.AssignRole(user.ID, person.Role); err != nil {
+				return created, err
+			}
+		}
+	}
+	for _, role := range org.Leadership.Roles {
+		if role.AssignedTo == "" {
+			continue
+		}
+		user, err := ensure(role.AssignedTo, "")
+		if err != nil {
+			return created, err
+		}
+		for _, name := range []string{role.Name, role.ID} {
+			if name != "" {
+				if err := um.AssignRole(user.ID, name); err != nil {
+					return created, err
+				}
+			}
+		}
+	}
+	return created, nil
+}
+
+// FindPerson returns the active user with the given ID as a person
+func (um *UserManager) FindPerson(personID string) (Person, bool) {
+	user, ok := um.Users[personID]
+	if !ok || !user.Active() {
+		return Person{}, false
+	}
+	return user.Person(), true
+}
+
+// HoldsRole reports whether the user with the given ID is active and holds the role
+func (um *UserManager) HoldsRole(personID, role string) bool {
+	user, ok := um.Users[personID]
+	return ok && user.Active() && user.HoldsRole(role)
+}
+
+// tenantDirectory is the approver directory of a tenant's documents. It resolves
+// approvers among the tenant's users once the tenant has any; until then approvers are
+// matched by ID and role only.
+type tenantDirectory struct {
+	tenant *Tenant
+}
+
+// FindPerson returns the active user with the given ID as a person
+func (d tenantDirectory) FindPerson(personID string) (Person, bool) {
+	return d.tenant.Users.FindPerson(personID)
+}
+
+// HoldsRole reports whether the user with the given ID is active and holds the role
+func (d tenantDirectory) HoldsRole(personID, role string) bool {
+	return d.tenant.Users.HoldsRole(personID, role)
+}
+
+// DelegationFor returns the delegation of the tenant's organization in force at the
+// given time that allows the delegate to approve the document for the role
+func (d tenantDirectory) DelegationFor(delegateID, role string, doc *DocumentedInformation, at time.Time) (*Delegation, bool) {
+	if d.tenant.Organization == nil {
+		return nil, false
+	}
+	return delegationFor(d.tenant.Organization.Leadership, d.HoldsRole, delegateID, role, doc, at)
+}
+
+// verifies reports whether the tenant has users to verify approvers against
+func (d tenantDirectory) verifies() bool {
+	return d.tenant.Users != nil && len(d.tenant.Users.Users) > 0
+}
+
+// AddDelegation records a time-bound delegation in the tenant's organization, see
+// OrganizationDirectory.AddDelegation. Once the tenant has users, the delegator and
+// delegate must be active users and the delegator must hold the delegated roles;
+// until then they are checked against the organization's leadership.
+func (t *Tenant) AddDelegation(delegation Delegation) (Delegation, error) {
+	if t.Organization == nil {
+		return Delegation{}, fmt.Errorf("%w: tenant %s has no organization", ErrInvalidDelegation, t.ID)
+	}
+	directory := tenantDirectory{t}
+	if !directory.verifies() {
+		return NewOrganizationDirectory(t.Organization).AddDelegation(delegation)
+	}
+	if _, ok := directory.FindPerson(delegation.Delegator); !ok {
+		return Delegation{}, fmt.Errorf("%w: unknown delegator %s", ErrInvalidDelegation, delegation.Delegator)
+	}
+	if _, ok := directory.FindPerson(delegation.Delegate); !ok && delegation.Delegate != "" {
+		return Delegation{}, fmt.Errorf("%w: unknown delegate %s", ErrInvalidDelegation, delegation.Delegate)
+	}
+	if t.Organization.Leadership == nil {
+		t.Organization.Leadership = &Leadership{}
+	}
+	return addDelegation(t.Organization.Leadership, delegation, directory.HoldsRole)
+}
+
+// RevokeDelegation ends a delegation of the tenant's organization early; approvals
+// already given remain valid
+func (t *Tenant) RevokeDelegation(id string, at time.Time) error {
+	if t.Organization == nil {
+		return fmt.Errorf("%w: delegation %s not found", ErrInvalidDelegation, id)
+	}
+	return revokeDelegation(t.Organization.Leadership, id, at)
+}
+
+// reindex keys the users by their current ID, after IDs were replaced in place
+func (um *UserManager) reindex() {
+	users := make(map[string]*User, len(um.Users))
+	for _, user := range um.Users {
+		users[user.ID] = user
+	}
+	um.Users = users
+}
+
+// ValidateUserReferences checks that the people referenced across the tenant are
+// users. Recorded approvals must name a user by ID. Responsible parties, owners,
+// authors, auditors, role assignees and required approvers are resolved with
+// UserManager.Resolve; references that name no active user, or more than one, are
+// warnings. A required approver or access entry naming a role held by several users
+// is not ambiguous.
+func (t *Tenant) ValidateUserReferences() *ValidationResult {
+	result := &ValidationResult{
+		Valid:    true,
+		Errors:   []ValidationError{},
+		Warnings: []ValidationError{},
+		Infos:    []ValidationError{},
+	}
+	users := t.Users
+	if users == nil {
+		users = NewUserManager()
+	}
+
+	refs := t.personalData()
+	sort.SliceStable(refs, func(i, j int) bool {
+		a, b := refs[i].PersonalDataField, refs[j].PersonalDataField
+		if a.EntityType != b.EntityType {
+			return a.EntityType < b.EntityType
+		}
+		if a.EntityID != b.EntityID {
+			return a.EntityID < b.EntityID
+		}
+		return a.Field < b.Field
+	})
+
+	for _, ref := range refs {
+		value := *ref.value
+		if ref.EntityType == EntityTypeUser || ref.kind == personalDataName || value == RedactedValue {
+			continue
+		}
+		field := fmt.Sprintf("%s_%s_%s", ref.EntityType, ref.EntityID, ref.Field)
+
+		if ref.Field == "approval.actual_approvers.id" {
+			if _, err := users.GetUser(value); err != nil {
+				result.addError("7.5.2", field, fmt.Sprintf("%s %s was approved by %s, who is not a user", ref.EntityType, ref.EntityID, value))
+			}
+			continue
+		}
+		if ref.kind == personalDataID && len(users.HoldersOf(value)) > 0 {
+			continue
+		}
+		if _, err := users.Resolve(value); errors.Is(err, ErrAmbiguousReference) {
+			result.addWarning("5.3", field, fmt.Sprintf("%s %s: %v", ref.EntityType, ref.EntityID, err))
+		} else if err != nil {
+			result.addWarning("5.3", field, fmt.Sprintf("%s %s: %q does not name an active user", ref.EntityType, ref.EntityID, value))
+		}
+	}
+	return result
+}
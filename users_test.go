@@ -0,0 +1,207 @@
+package iso9001
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func newUserTenant(t *testing.T) *Tenant {
+	t.Helper()
+	tenant := NewTenant("ORG-001")
+	tenant.Organization.Leadership = &Leadership{
+		TopManagement: []Person{{ID: "P-001", Name: "Jane Doe", Role: "CEO", Competence: []string{"Leadership"}}},
+		Roles:         []OrganizationalRole{{ID: "ROLE-QM", Name: "Quality Manager", AssignedTo: "P-002"}},
+	}
+	created, err := tenant.Users.ImportOrganization(tenant.Organization)
+	if err != nil {
+		t.Fatalf("Failed to import organization: %v", err)
+	}
+	if len(created) != 2 {
+		t.Fatalf("Expected 2 users to be created, got %d", len(created))
+	}
+	if err := tenant.Users.AddUser(&User{Name: "John Smith", Email: "john.smith@example.com", Roles: []string{"Internal Auditor"}}); err != nil {
+		t.Fatalf("Failed to add user: %v", err)
+	}
+	return tenant
+}
+
+func TestUserManager(t *testing.T) {
+	tenant := newUserTenant(t)
+	users := tenant.Users
+
+	if _, ok := users.Users["USR-003"]; !ok {
+		t.Fatal("Expected generated ID USR-003")
+	}
+	if err := users.AddUser(&User{Name: "Other", Email: "JOHN.SMITH@example.com"}); err == nil {
+		t.Error("Expected duplicate email to be rejected")
+	}
+	var dupErr *DuplicateIDError
+	if err := users.AddUser(&User{ID: "ORG-001", Name: "Clash"}); !errors.As(err, &dupErr) {
+		t.Errorf("Expected DuplicateIDError for an ID used by the organization, got %v", err)
+	}
+
+	if !users.HoldsRole("P-002", "quality manager") || !users.HoldsRole("P-002", "ROLE-QM") {
+		t.Error("Expected imported role assignment by name and ID")
+	}
+	if person, ok := users.FindPerson("P-001"); !ok || person.Role != "CEO" || person.Competence[0] != "Leadership" {
+		t.Errorf("Expected P-001 as CEO, got %+v", person)
+	}
+
+	cases := map[string]string{
+		"P-001":                  "P-001",
+		"jane doe":               "P-001",
+		"Quality Manager":        "P-002",
+		"john.smith@example.com": "USR-003",
+	}
+	for reference, want := range cases {
+		user, err := users.Resolve(reference)
+		if err != nil || user.ID != want {
+			t.Errorf("Resolve(%q): expected %s, got %v, %v", reference, want, user, err)
+		}
+	}
+	if _, err := users.Resolve("Nobody"); !errors.Is(err, ErrUnknownUser) {
+		t.Errorf("Expected ErrUnknownUser, got %v", err)
+	}
+
+	if err := users.AssignRole("USR-003", "Quality Manager"); err != nil {
+		t.Fatalf("Failed to assign role: %v", err)
+	}
+	if _, err := users.Resolve("Quality Manager"); !errors.Is(err, ErrAmbiguousReference) {
+		t.Errorf("Expected ErrAmbiguousReference for a shared role, got %v", err)
+	}
+	if err := users.DeactivateUser("P-002", time.Now()); err != nil {
+		t.Fatalf("Failed to deactivate user: %v", err)
+	}
+	if user, err := users.Resolve("Quality Manager"); err != nil || user.ID != "USR-003" {
+		t.Errorf("Expected deactivated holder to be skipped, got %v, %v", user, err)
+	}
+	if users.HoldsRole("P-002", "Quality Manager") {
+		t.Error("Expected deactivated user to hold no roles")
+	}
+}
+
+func TestUserManagerApprovals(t *testing.T) {
+	tenant := NewTenant("ORG-002")
+	tenant.Documents.AddDocument(&DocumentedInformation{ID: "DOC-009", Title: "Procedure"})
+	if err := tenant.Documents.ApproveDocument("DOC-009", Approval{ApproverID: "anyone", Role: "Quality Manager"}); err != nil {
+		t.Errorf("Expected approvals to be matched by ID and role in a tenant without users, got %v", err)
+	}
+
+	tenant = newUserTenant(t)
+	data, err := json.Marshal(tenant)
+	if err != nil {
+		t.Fatalf("Failed to encode tenant: %v", err)
+	}
+	if tenant, err = LoadTenantJSON(data); err != nil {
+		t.Fatalf("Failed to load tenant: %v", err)
+	}
+
+	doc := &DocumentedInformation{ID: "DOC-001", Title: "Quality Manual", Status: DocumentStatusDraft, Approval: &DocumentApproval{RequiredApprovers: []string{"Quality Manager"}}}
+	if err := tenant.Documents.AddDocument(doc); err != nil {
+		t.Fatalf("Failed to add document: %v", err)
+	}
+	if err := tenant.Documents.ApproveDocument("DOC-001", Approval{ApproverID: "mallory", Role: "Quality Manager"}); !errors.Is(err, ErrUnknownApprover) {
+		t.Errorf("Expected ErrUnknownApprover for someone who is not a user, got %v", err)
+	}
+	if err := tenant.Documents.ApproveDocument("DOC-001", tenant.Users.Users["USR-003"].Approval("Quality Manager", "")); !errors.Is(err, ErrApproverNotAuthorized) {
+		t.Errorf("Expected ErrApproverNotAuthorized, got %v", err)
+	}
+	if err := tenant.Documents.ApproveDocument("DOC-001", tenant.Users.Users["P-002"].Approval("Quality Manager", "OK")); err != nil {
+		t.Fatalf("Failed to approve document: %v", err)
+	}
+	if doc.Approval.Status != ApprovalStatusApproved {
+		t.Errorf("Expected document to be approved, got %s", doc.Approval.Status)
+	}
+}
+
+func TestValidateUserReferences(t *testing.T) {
+	tenant := newUserTenant(t)
+	objectives := []*QualityObjective{
+		{ID: "OBJ-001", Name: "Resolved by role", Responsible: "Quality Manager"},
+		{ID: "OBJ-002", Name: "Unknown", Responsible: "Head of Sales"},
+	}
+	for _, objective := range objectives {
+		objective.Measurable = true
+		objective.Targets = []ObjectiveTarget{{Metric: "On-time delivery", Value: "95%"}}
+		if err := tenant.Objectives.CreateObjective(objective); err != nil {
+			t.Fatalf("Failed to create objective: %v", err)
+		}
+	}
+	doc := &DocumentedInformation{ID: "DOC-001", Title: "Quality Manual", Metadata: DocumentMetadata{Author: "john.smith@example.com", Owner: "P-001"}, Status: DocumentStatusDraft,
+		Approval: &DocumentApproval{RequiredApprovers: []string{"Quality Manager"}}}
+	if err := tenant.Documents.AddDocument(doc); err != nil {
+		t.Fatalf("Failed to add document: %v", err)
+	}
+	doc.Approval.ActualApprovers = append(doc.Approval.ActualApprovers, Approval{ApproverID: "P-099", Role: "Quality Manager"})
+
+	result := tenant.ValidateUserReferences()
+	if result.Valid || len(result.Errors) != 1 || result.Errors[0].Field != "document_DOC-001_approval.actual_approvers.id" {
+		t.Errorf("Expected an error for the unknown approver, got %+v", result.Errors)
+	}
+	if len(result.Warnings) != 1 || result.Warnings[0].Field != "objective_OBJ-002_responsible" {
+		t.Errorf("Expected a warning for the unknown responsible, got %+v", result.Warnings)
+	}
+}
+
+func TestScrubPersonalDataUsers(t *testing.T) {
+	tenant := newUserTenant(t)
+
+	report, err := tenant.ScrubPersonalData("USR-003", ScrubOptions{Mode: ScrubRemove})
+	if err != nil {
+		t.Fatalf("Failed to scrub personal data: %v", err)
+	}
+	if _, ok := tenant.Users.Users["USR-003"]; ok {
+		t.Error("Expected the user to be deleted")
+	}
+	if _, ok := tenant.Users.Users[report.Pseudonym]; ok {
+		t.Error("Expected no user under the pseudonym")
+	}
+
+	// pseudonymized users stay under their pseudonym
+	tenant = newUserTenant(t)
+	if report, err = tenant.ScrubPersonalData("USR-003", ScrubOptions{}); err != nil {
+		t.Fatalf("Failed to scrub personal data: %v", err)
+	}
+	if user, ok := tenant.Users.Users[report.Pseudonym]; !ok || user.Name != report.Pseudonym {
+		t.Errorf("Expected user to be anonymized under its pseudonym, got %+v", user)
+	}
+}
+
+func TestTenantDelegations(t *testing.T) {
+	tenant := newUserTenant(t)
+	tenant.Documents.AddDocument(&DocumentedInformation{ID: "DOC-001", Title: "Quality Manual", Approval: &DocumentApproval{RequiredApprovers: []string{"Quality Manager"}}})
+
+	from := time.Now().Add(-time.Hour)
+	if _, err := tenant.AddDelegation(Delegation{Delegator: "P-002", Delegate: "mallory", From: from, Until: from.Add(24 * time.Hour)}); !errors.Is(err, ErrInvalidDelegation) {
+		t.Errorf("Expected a delegate who is not a user to be rejected, got %v", err)
+	}
+	delegation, err := tenant.AddDelegation(Delegation{Delegator: "P-002", Delegate: "USR-003", Roles: []string{"Quality Manager"}, From: from, Until: from.Add(24 * time.Hour)})
+	if err != nil {
+		t.Fatalf("Failed to add delegation: %v", err)
+	}
+
+	// the delegation is saved with the tenant and resolved after loading it again
+	data, err := json.Marshal(tenant)
+	if err != nil {
+		t.Fatalf("Failed to encode tenant: %v", err)
+	}
+	if tenant, err = LoadTenantJSON(data); err != nil {
+		t.Fatalf("Failed to load tenant: %v", err)
+	}
+	if err := tenant.Documents.ApproveDocument("DOC-001", Approval{ApproverID: "USR-003"}); err != nil {
+		t.Fatalf("Expected delegated approval, got %v", err)
+	}
+	doc, _ := tenant.Documents.GetDocument("DOC-001")
+	if approval := doc.Approval.ActualApprovers[0]; approval.OnBehalfOf != "P-002" || approval.DelegationID != delegation.ID {
+		t.Errorf("Expected delegation in approval history, got %+v", approval)
+	}
+
+	if err := tenant.RevokeDelegation(delegation.ID, time.Now()); err != nil {
+		t.Fatalf("Failed to revoke delegation: %v", err)
+	}
+	if tenant.Organization.Leadership.Delegations[0].Revoked == nil {
+		t.Error("Expected delegation to be revoked")
+	}
+}
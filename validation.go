@@ -3,6 +3,9 @@ package iso9001
 import (
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
+	"sync"
 )
 
 // ValidationError represents a validation error with context
@@ -11,10 +14,14 @@ type ValidationError struct {
 	Field    string
 	Message  string
 	Severity string // "error", "warning", "info"
+
+	format string        // untranslated message or format string
+	args   []interface{} // arguments of format
+	locale Locale        // set by Localize
 }
 
 func (e ValidationError) Error() string {
-	return fmt.Sprintf("[%s] Clause %s - %s: %s", e.Severity, e.Clause, e.Field, e.Message)
+	return Translate(e.locale, "[%s] Clause %s - %s: %s", Translate(e.locale, e.Severity), e.Clause, e.Field, e.Message)
 }
 
 // ValidationResult contains the results of validation
@@ -27,6 +34,31 @@ type ValidationResult struct {
 
 // ValidateOrganization performs comprehensive validation of an organization against ISO 9001 requirements
 func ValidateOrganization(org *Organization) *ValidationResult {
+	return ValidateOrganizationWithOptions(org, ValidationOptions{})
+}
+
+// ValidationOptions configures how the validation rules are executed
+type ValidationOptions struct {
+	// Workers is the number of goroutines used to run the validation rules.
+	// Values below 2 run the rules sequentially.
+	Workers int
+	// Rules is the registry whose enabled rules are checked; DefaultRules when nil
+	Rules *RuleRegistry
+	// Progress, when set, is called each time all the rules of a clause have been
+	// checked
+	Progress ValidationProgress
+}
+
+// ValidationProgress reports that the rules of a clause have been checked, and how
+// many of the clauses with rules have been checked so far. With several workers it is
+// called from the worker goroutines, one call at a time, and clauses may complete out
+// of order.
+type ValidationProgress func(clause string, checked, total int)
+
+// ValidateOrganizationWithOptions validates an organization, optionally running the
+// rules concurrently. Results are always merged in rule order so the output is
+// identical to a sequential run.
+func ValidateOrganizationWithOptions(org *Organization, opts ValidationOptions) *ValidationResult {
 	result := &ValidationResult{
 		Valid: true,
 		Errors: []ValidationError{},
@@ -34,347 +66,519 @@ func ValidateOrganization(org *Organization) *ValidationResult {
 		Infos: []ValidationError{},
 	}
 
-	// Clause 4.1: Understanding the organization and its context
-	result.merge(validateContext(org))
-
-	// Clause 4.2: Understanding the needs and expectations of interested parties
-	result.merge(validateInterestedParties(org))
-
-	// Clause 4.3: Determining the scope of the quality management system
-	result.merge(validateQMSScope(org))
-
-	// Clause 4.4: Quality management system and its processes
-	result.merge(validateQMSProcesses(org))
-
-	// Clause 5.1: Leadership and commitment
-	result.merge(validateLeadership(org))
-
-	// Clause 5.2: Quality policy
-	result.merge(validateQualityPolicy(org))
-
-	// Clause 5.3: Organizational roles, responsibilities and authorities
-	result.merge(validateRolesResponsibilities(org))
-
-	// Clause 6.1: Actions to address risks and opportunities
-	result.merge(validateRisksOpportunities(org))
+	registry := opts.Rules
+	if registry == nil {
+		registry = DefaultRules
+	}
 
-	// Clause 6.2: Quality objectives and planning to achieve them
-	result.merge(validateQualityObjectives(org))
+	runRules(org, registry.enabledRules(), opts.Workers, opts.Progress, result)
 
 	return result
 }
 
-// validateContext validates clause 4.1 requirements
-func validateContext(org *Organization) *ValidationResult {
-	result := &ValidationResult{Valid: true}
+// runRules checks the rules and adds their issues to result in the order of the rules
+// slice. Run sequentially, each rule reports straight into result; workers report into
+// a result per rule, merged in order once all rules have been checked.
+func runRules(org *Organization, rules []ValidationRule, workers int, progress ValidationProgress, result *ValidationResult) {
+	tracker := newClauseTracker(rules, progress)
 
-	if org.Context == nil {
-		result.addError("4.1", "context", "Organizational context must be defined")
-		return result
+	if workers < 2 {
+		for i := range rules {
+			rules[i].check(org, result)
+			tracker.checked(&rules[i])
+		}
+		return
 	}
 
-	if len(org.Context.ExternalIssues) == 0 {
-		result.addWarning("4.1", "external_issues", "No external issues identified - consider reviewing legal, technological, competitive, market, cultural, social and economic environments")
+	results := make([]ValidationResult, len(rules))
+	if workers > len(rules) {
+		workers = len(rules)
 	}
 
-	if len(org.Context.InternalIssues) == 0 {
-		result.addWarning("4.1", "internal_issues", "No internal issues identified - consider reviewing values, culture, knowledge and performance")
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i].Valid = true
+				rules[i].check(org, &results[i])
+				tracker.checked(&rules[i])
+			}
+		}()
 	}
 
-	// Validate issues have proper descriptions
-	for i, issue := range append(org.Context.ExternalIssues, org.Context.InternalIssues...) {
-		if issue.Description == "" {
-			result.addError("4.1", fmt.Sprintf("issue_%d_description", i), "Issue must have a description")
-		}
-		if issue.Type == "" {
-			result.addError("4.1", fmt.Sprintf("issue_%d_type", i), "Issue must have a type (external/internal)")
-		}
+	for i := range rules {
+		jobs <- i
 	}
+	close(jobs)
+	wg.Wait()
 
-	return result
-}
-
-// validateInterestedParties validates clause 4.2 requirements
-func validateInterestedParties(org *Organization) *ValidationResult {
-	result := &ValidationResult{Valid: true}
-
-	if org.Context == nil || len(org.Context.InterestedParties) == 0 {
-		result.addError("4.2", "interested_parties", "Interested parties must be identified and their requirements determined")
-		return result
+	for i := range results {
+		result.merge(&results[i])
 	}
+}
 
-	// Check for key interested parties
-	hasCustomers := false
-	hasSuppliers := false
-	hasRegulators := false
-
-	for _, party := range org.Context.InterestedParties {
-		if party.Name == "" {
-			result.addError("4.2", "party_name", "Interested party must have a name")
-		}
-		if len(party.Requirements) == 0 {
-			result.addWarning("4.2", fmt.Sprintf("party_%s_requirements", party.Name), "No requirements specified for interested party")
-		}
-
-		switch party.Type {
-		case "customer":
-			hasCustomers = true
-		case "supplier", "external_provider":
-			hasSuppliers = true
-		case "regulator", "authority":
-			hasRegulators = true
-		}
-	}
+// clauseTracker counts the rules left to check per clause and reports each clause to
+// a ValidationProgress once its last rule has been checked. Without a ValidationProgress
+// there is nothing to track and the tracker is nil.
+type clauseTracker struct {
+	mu        sync.Mutex
+	progress  ValidationProgress
+	remaining map[string]int
+	done      int
+}
 
-	if !hasCustomers {
-		result.addWarning("4.2", "customers", "No customers identified as interested parties")
+func newClauseTracker(rules []ValidationRule, progress ValidationProgress) *clauseTracker {
+	if progress == nil {
+		return nil
 	}
-	if !hasSuppliers {
-		result.addWarning("4.2", "suppliers", "No suppliers/external providers identified as interested parties")
+	tracker := &clauseTracker{progress: progress, remaining: map[string]int{}}
+	for _, rule := range rules {
+		tracker.remaining[rule.Clause]++
 	}
-	if !hasRegulators {
-		result.addInfo("4.2", "regulators", "Consider identifying regulatory authorities as interested parties")
-	}
-
-	return result
+	return tracker
 }
 
-// validateQMSScope validates clause 4.3 requirements
-func validateQMSScope(org *Organization) *ValidationResult {
-	result := &ValidationResult{Valid: true}
-
-	if org.QMS == nil || org.QMS.Scope == nil {
-		result.addError("4.3", "scope", "QMS scope must be determined and documented")
-		return result
+func (t *clauseTracker) checked(rule *ValidationRule) {
+	if t == nil {
+		return
 	}
-
-	scope := org.QMS.Scope
-	if scope.Description == "" {
-		result.addError("4.3", "scope_description", "Scope must include a description of products and services covered")
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.remaining[rule.Clause]--
+	if t.remaining[rule.Clause] == 0 {
+		t.done++
+		t.progress(rule.Clause, t.done, len(t.remaining))
 	}
+}
 
-	if len(scope.Products) == 0 && len(scope.Services) == 0 {
-		result.addError("4.3", "scope_coverage", "Scope must specify the types of products and services covered")
+// builtinRules returns the ISO 9001 requirements checked by default, in clause order
+func builtinRules() []ValidationRule {
+	return []ValidationRule{
+		// 4.1 Understanding the organization and its context
+		{ID: "4.1-context", Clause: "4.1", Severity: SeverityError, Field: "context",
+			Message:   "Organizational context must be defined",
+			Predicate: func(org *Organization) bool { return org.Context != nil }},
+		{ID: "4.1-external-issues", Clause: "4.1", Severity: SeverityWarning, Field: "external_issues",
+			Message:   "No external issues identified - consider reviewing legal, technological, competitive, market, cultural, social and economic environments",
+			Predicate: func(org *Organization) bool { return org.Context == nil || len(org.Context.ExternalIssues) > 0 }},
+		{ID: "4.1-internal-issues", Clause: "4.1", Severity: SeverityWarning, Field: "internal_issues",
+			Message:   "No internal issues identified - consider reviewing values, culture, knowledge and performance",
+			Predicate: func(org *Organization) bool { return org.Context == nil || len(org.Context.InternalIssues) > 0 }},
+		{ID: "4.1-issue-description", Clause: "4.1", Severity: SeverityError,
+			Message: "Issue must have a description",
+			Check:   forEach(contextIssues, indexedField[Issue]("issue_%d_description"), func(issue *Issue) bool { return issue.Description != "" })},
+		{ID: "4.1-issue-type", Clause: "4.1", Severity: SeverityError,
+			Message: "Issue must have a type (external/internal)",
+			Check:   forEach(contextIssues, indexedField[Issue]("issue_%d_type"), func(issue *Issue) bool { return issue.Type != "" })},
+
+		// 4.2 Understanding the needs and expectations of interested parties
+		{ID: "4.2-interested-parties", Clause: "4.2", Severity: SeverityError, Field: "interested_parties",
+			Message:   "Interested parties must be identified and their requirements determined",
+			Predicate: func(org *Organization) bool { return len(interestedParties(org)) > 0 }},
+		{ID: "4.2-party-name", Clause: "4.2", Severity: SeverityError,
+			Message: "Interested party must have a name",
+			Check: forEach(interestedParties, func(int, *InterestedParty) string { return "party_name" },
+				func(party *InterestedParty) bool { return party.Name != "" })},
+		{ID: "4.2-party-requirements", Clause: "4.2", Severity: SeverityWarning,
+			Message: "No requirements specified for interested party",
+			Check: forEach(interestedParties, func(_ int, party *InterestedParty) string { return fmt.Sprintf("party_%s_requirements", party.Name) },
+				func(party *InterestedParty) bool { return len(party.Requirements) > 0 })},
+		{ID: "4.2-customers", Clause: "4.2", Severity: SeverityWarning, Field: "customers",
+			Message:   "No customers identified as interested parties",
+			Predicate: hasPartyType("customer")},
+		{ID: "4.2-suppliers", Clause: "4.2", Severity: SeverityWarning, Field: "suppliers",
+			Message:   "No suppliers/external providers identified as interested parties",
+			Predicate: hasPartyType("supplier", "external_provider")},
+		{ID: "4.2-regulators", Clause: "4.2", Severity: SeverityInfo, Field: "regulators",
+			Message:   "Consider identifying regulatory authorities as interested parties",
+			Predicate: hasPartyType("regulator", "authority")},
+
+		// 4.3 Determining the scope of the quality management system
+		{ID: "4.3-scope", Clause: "4.3", Severity: SeverityError, Field: "scope",
+			Message:   "QMS scope must be determined and documented",
+			Predicate: func(org *Organization) bool { return qmsScope(org) != nil }},
+		{ID: "4.3-scope-description", Clause: "4.3", Severity: SeverityError, Field: "scope_description",
+			Message:   "Scope must include a description of products and services covered",
+			Predicate: func(org *Organization) bool { s := qmsScope(org); return s == nil || s.Description != "" }},
+		{ID: "4.3-scope-coverage", Clause: "4.3", Severity: SeverityError, Field: "scope_coverage",
+			Message:   "Scope must specify the types of products and services covered",
+			Predicate: func(org *Organization) bool { s := qmsScope(org); return s == nil || len(s.Products) > 0 || len(s.Services) > 0 }},
+		{ID: "4.3-exclusion-clause", Clause: "4.3", Severity: SeverityError,
+			Message: "Exclusion must specify which clause is not applicable",
+			Check:   forEach(scopeExclusions, indexedField[Exclusion]("exclusion_%d_clause"), func(exclusion *Exclusion) bool { return exclusion.Clause != "" })},
+		{ID: "4.3-exclusion-justification", Clause: "4.3", Severity: SeverityError,
+			Message: "Exclusion must be justified and not affect organization's ability to meet requirements",
+			Check:   forEach(scopeExclusions, indexedField[Exclusion]("exclusion_%d_justification"), func(exclusion *Exclusion) bool { return exclusion.Justification != "" })},
+		{ID: "4.3-exclusion-reference", Clause: "4.3", Severity: SeverityError,
+			Message: "Exclusion clause %q is not a valid ISO 9001 clause reference",
+			Check: checkExclusions(func(exclusion Exclusion, clause int, ok bool) bool {
+				return exclusion.Clause != "" && !ok
+			})},
+		{ID: "4.3-exclusion-not-clause-8", Clause: "4.3", Severity: SeverityError,
+			Message: "Clause %s cannot be excluded - only requirements of clause 8 may be determined as not applicable",
+			Check: checkExclusions(func(exclusion Exclusion, clause int, ok bool) bool {
+				return ok && clause != 8
+			})},
+		{ID: "4.3-exclusion-conformity", Clause: "4.3", Severity: SeverityWarning,
+			Message: "Excluding clause %s is likely to affect the ability to provide conforming products and services",
+			Check: checkExclusions(func(exclusion Exclusion, clause int, ok bool) bool {
+				return ok && clause == 8 && conformityCriticalClauses[normalizeClauseReference(exclusion.Clause)]
+			})},
+
+		// 4.4 Quality management system and its processes
+		{ID: "4.4-processes", Clause: "4.4", Severity: SeverityError, Field: "processes",
+			Message:   "QMS processes must be established, implemented, maintained and continually improved",
+			Predicate: func(org *Organization) bool { return len(qmsProcesses(org)) > 0 }},
+		{ID: "4.4-process-name", Clause: "4.4", Severity: SeverityError,
+			Message: "Process must have a name",
+			Check:   forEach(qmsProcesses, indexedField[Process]("process_%d_name"), func(process *Process) bool { return process.Name != "" })},
+		{ID: "4.4-process-inputs", Clause: "4.4", Severity: SeverityWarning,
+			Message: "Process inputs should be defined",
+			Check:   forEach(qmsProcesses, processField("inputs"), func(process *Process) bool { return len(process.Inputs) > 0 })},
+		{ID: "4.4-process-outputs", Clause: "4.4", Severity: SeverityWarning,
+			Message: "Process outputs should be defined",
+			Check:   forEach(qmsProcesses, processField("outputs"), func(process *Process) bool { return len(process.Outputs) > 0 })},
+		{ID: "4.4-process-responsibilities", Clause: "4.4", Severity: SeverityError,
+			Message: "Process responsibilities and authorities must be assigned",
+			Check:   forEach(qmsProcesses, processField("responsibilities"), func(process *Process) bool { return len(process.Responsibilities) > 0 })},
+		{ID: "4.4-process-criteria", Clause: "4.4", Severity: SeverityError,
+			Message: "Process criteria and methods for monitoring must be determined",
+			Check:   forEach(qmsProcesses, processField("criteria"), func(process *Process) bool { return len(process.Criteria) > 0 })},
+		{ID: "4.4-process-risks", Clause: "4.4", Severity: SeverityInfo,
+			Message: "Consider identifying risks and opportunities for this process",
+			Check:   forEach(qmsProcesses, processField("risks"), func(process *Process) bool { return len(process.Risks) > 0 })},
+
+		// 5.1 Leadership and commitment
+		{ID: "5.1-leadership", Clause: "5.1", Severity: SeverityError, Field: "leadership",
+			Message:   "Top management must demonstrate leadership and commitment",
+			Predicate: func(org *Organization) bool { return org.Leadership != nil }},
+		{ID: "5.1-top-management", Clause: "5.1", Severity: SeverityError, Field: "top_management",
+			Message:   "Top management must be identified",
+			Predicate: func(org *Organization) bool { return org.Leadership == nil || len(org.Leadership.TopManagement) > 0 }},
+		{ID: "5.1-leadership-commitment", Clause: "5.1", Severity: SeverityError, Field: "leadership_commitment",
+			Message: "Missing leadership commitment: %s",
+			Check:   missingCommitments},
+
+		// 5.2 Quality policy
+		{ID: "5.2-quality-policy", Clause: "5.2", Severity: SeverityError, Field: "quality_policy",
+			Message:   "Quality policy must be established and maintained",
+			Predicate: func(org *Organization) bool { return qualityPolicy(org) != nil }},
+		{ID: "5.2-policy-statement", Clause: "5.2", Severity: SeverityError, Field: "policy_statement",
+			Message:   "Quality policy must include a statement of intent",
+			Predicate: policyRequires(func(policy *QualityPolicy) bool { return policy.Statement != "" })},
+		{ID: "5.2-policy-objectives", Clause: "5.2", Severity: SeverityError, Field: "policy_objectives",
+			Message:   "Quality policy must provide a framework for setting quality objectives",
+			Predicate: policyRequires(func(policy *QualityPolicy) bool { return policy.Objectives != "" })},
+		{ID: "5.2-policy-commitment", Clause: "5.2", Severity: SeverityError, Field: "policy_commitment",
+			Message:   "Quality policy must include commitment to satisfy applicable requirements",
+			Predicate: policyRequires(func(policy *QualityPolicy) bool { return policy.Commitment != "" })},
+		{ID: "5.2-policy-improvement", Clause: "5.2", Severity: SeverityError, Field: "policy_improvement",
+			Message:   "Quality policy must include commitment to continual improvement",
+			Predicate: policyRequires(func(policy *QualityPolicy) bool { return policy.Improvement != "" })},
+		{ID: "5.2-policy-communication", Clause: "5.2", Severity: SeverityError, Field: "policy_communication",
+			Message:   "Quality policy must be communicated and understood within the organization",
+			Predicate: policyRequires(func(policy *QualityPolicy) bool { return policy.Communicated })},
+		{ID: "5.2-policy-availability", Clause: "5.2", Severity: SeverityError, Field: "policy_availability",
+			Message:   "Quality policy must be available to relevant interested parties",
+			Predicate: policyRequires(func(policy *QualityPolicy) bool { return policy.Available })},
+
+		// 5.3 Organizational roles, responsibilities and authorities
+		{ID: "5.3-roles", Clause: "5.3", Severity: SeverityError, Field: "roles_responsibilities",
+			Message:   "Organizational roles, responsibilities and authorities must be assigned and communicated",
+			Predicate: func(org *Organization) bool { return len(leadershipRoles(org)) > 0 }},
+		{ID: "5.3-role-name", Clause: "5.3", Severity: SeverityError,
+			Message: "Role must have a name",
+			Check:   forEach(leadershipRoles, indexedField[OrganizationalRole]("role_%d_name"), func(role *OrganizationalRole) bool { return role.Name != "" })},
+		{ID: "5.3-role-responsibilities", Clause: "5.3", Severity: SeverityError,
+			Message: "Role must have defined responsibilities",
+			Check:   forEach(leadershipRoles, roleField("responsibilities"), func(role *OrganizationalRole) bool { return len(role.Responsibilities) > 0 })},
+		{ID: "5.3-role-authorities", Clause: "5.3", Severity: SeverityError,
+			Message: "Role must have defined authorities",
+			Check:   forEach(leadershipRoles, roleField("authorities"), func(role *OrganizationalRole) bool { return len(role.Authorities) > 0 })},
+		{ID: "5.3-role-assignment", Clause: "5.3", Severity: SeverityError,
+			Message: "Role must be assigned to a person",
+			Check:   forEach(leadershipRoles, roleField("assignment"), func(role *OrganizationalRole) bool { return role.AssignedTo != "" })},
+
+		// 6.1 Actions to address risks and opportunities
+		{ID: "6.1-qms", Clause: "6.1", Severity: SeverityError, Field: "qms",
+			Message:   "QMS must be defined to validate risks and opportunities",
+			Predicate: func(org *Organization) bool { return org.QMS != nil }},
+		{ID: "6.1-risks", Clause: "6.1", Severity: SeverityWarning, Field: "risks",
+			Message: "No risks identified - risk-based thinking should be applied to planning",
+			Predicate: func(org *Organization) bool {
+				risks, _ := countRisksOpportunities(org)
+				return org.QMS == nil || risks > 0
+			}},
+		{ID: "6.1-opportunities", Clause: "6.1", Severity: SeverityInfo, Field: "opportunities",
+			Message: "Consider identifying opportunities for improvement",
+			Predicate: func(org *Organization) bool {
+				_, opportunities := countRisksOpportunities(org)
+				return org.QMS == nil || opportunities > 0
+			}},
+		{ID: "6.1-risk-mitigation", Clause: "6.1", Severity: SeverityWarning,
+			Message: "Risk should have mitigation actions defined",
+			Check: forEach(qmsRisks, indexedField[Risk]("risk_%d_mitigation"), func(risk *Risk) bool {
+				return len(risk.Mitigation) > 0 || risk.Status == RiskStatusMitigated
+			})},
+		{ID: "6.1-opportunity-actions", Clause: "6.1", Severity: SeverityInfo,
+			Message: "Opportunity should have actions defined for realization",
+			Check: forEach(qmsOpportunities, indexedField[Opportunity]("opportunity_%d_actions"), func(opportunity *Opportunity) bool {
+				return len(opportunity.Actions) > 0 || opportunity.Status == OpportunityStatusRealized
+			})},
+
+		// 6.2 Quality objectives and planning to achieve them
+		{ID: "6.2-objectives", Clause: "6.2", Severity: SeverityError, Field: "quality_objectives",
+			Message:   "Quality objectives must be established at relevant functions and levels",
+			Predicate: func(org *Organization) bool { return len(qmsObjectives(org)) > 0 }},
+		{ID: "6.2-objective-name", Clause: "6.2", Severity: SeverityError,
+			Message: "Quality objective must have a name",
+			Check:   forEach(qmsObjectives, indexedField[QualityObjective]("objective_%d_name"), func(objective *QualityObjective) bool { return objective.Name != "" })},
+		{ID: "6.2-objective-measurable", Clause: "6.2", Severity: SeverityError,
+			Message: "Quality objectives must be measurable",
+			Check:   forEach(qmsObjectives, objectiveField("measurable"), func(objective *QualityObjective) bool { return objective.Measurable })},
+		{ID: "6.2-objective-targets", Clause: "6.2", Severity: SeverityError,
+			Message: "Quality objectives must have specific targets",
+			Check:   forEach(qmsObjectives, objectiveField("targets"), func(objective *QualityObjective) bool { return len(objective.Targets) > 0 })},
+		{ID: "6.2-objective-responsible", Clause: "6.2", Severity: SeverityError,
+			Message: "Quality objectives must have responsible parties assigned",
+			Check:   forEach(qmsObjectives, objectiveField("responsible"), func(objective *QualityObjective) bool { return objective.Responsible != "" })},
+		{ID: "6.2-objective-timeline", Clause: "6.2", Severity: SeverityError,
+			Message: "Quality objectives must have target dates",
+			Check:   forEach(qmsObjectives, objectiveField("timeline"), func(objective *QualityObjective) bool { return !objective.Timeline.TargetDate.IsZero() })},
 	}
+}
 
-	// Validate exclusions
-	for i, exclusion := range scope.Exclusions {
-		if exclusion.Clause == "" {
-			result.addError("4.3", fmt.Sprintf("exclusion_%d_clause", i), "Exclusion must specify which clause is not applicable")
-		}
-		if exclusion.Justification == "" {
-			result.addError("4.3", fmt.Sprintf("exclusion_%d_justification", i), "Exclusion must be justified and not affect organization's ability to meet requirements")
+// forEach builds the check of a rule applied to every item returned by items. Each
+// item that does not satisfy ok is reported under the field returned by field.
+// Items are passed by pointer so the large structs of the model are not copied for
+// every rule.
+func forEach[T any](items func(org *Organization) []T, field func(i int, item *T) string, ok func(item *T) bool) func(org *Organization) []RuleViolation {
+	return func(org *Organization) []RuleViolation {
+		var violations []RuleViolation
+		list := items(org)
+		for i := range list {
+			if !ok(&list[i]) {
+				violations = append(violations, RuleViolation{Field: field(i, &list[i])})
+			}
 		}
+		return violations
 	}
-
-	return result
 }
 
-// validateQMSProcesses validates clause 4.4 requirements
-func validateQMSProcesses(org *Organization) *ValidationResult {
-	result := &ValidationResult{Valid: true}
+// indexedField reports items under a field numbered by their position, e.g. "issue_%d_type".
+// The field names are built without fmt since large organizations report thousands.
+func indexedField[T any](format string) func(int, *T) string {
+	prefix, suffix, _ := strings.Cut(format, "%d")
+	return func(i int, _ *T) string { return prefix + strconv.Itoa(i) + suffix }
+}
 
-	if org.QMS == nil || len(org.QMS.Processes) == 0 {
-		result.addError("4.4", "processes", "QMS processes must be established, implemented, maintained and continually improved")
-		return result
-	}
+func processField(suffix string) func(int, *Process) string {
+	return func(_ int, process *Process) string { return "process_" + process.Name + "_" + suffix }
+}
 
-	for i, process := range org.QMS.Processes {
-		if process.Name == "" {
-			result.addError("4.4", fmt.Sprintf("process_%d_name", i), "Process must have a name")
-		}
-		if len(process.Inputs) == 0 {
-			result.addWarning("4.4", fmt.Sprintf("process_%s_inputs", process.Name), "Process inputs should be defined")
-		}
-		if len(process.Outputs) == 0 {
-			result.addWarning("4.4", fmt.Sprintf("process_%s_outputs", process.Name), "Process outputs should be defined")
-		}
-		if len(process.Responsibilities) == 0 {
-			result.addError("4.4", fmt.Sprintf("process_%s_responsibilities", process.Name), "Process responsibilities and authorities must be assigned")
-		}
-		if len(process.Criteria) == 0 {
-			result.addError("4.4", fmt.Sprintf("process_%s_criteria", process.Name), "Process criteria and methods for monitoring must be determined")
-		}
-		if len(process.Risks) == 0 {
-			result.addInfo("4.4", fmt.Sprintf("process_%s_risks", process.Name), "Consider identifying risks and opportunities for this process")
-		}
-	}
+func roleField(suffix string) func(int, *OrganizationalRole) string {
+	return func(_ int, role *OrganizationalRole) string { return "role_" + role.Name + "_" + suffix }
+}
 
-	return result
+func objectiveField(suffix string) func(int, *QualityObjective) string {
+	return func(_ int, objective *QualityObjective) string { return "objective_" + objective.Name + "_" + suffix }
 }
 
-// validateLeadership validates clause 5.1 requirements
-func validateLeadership(org *Organization) *ValidationResult {
-	result := &ValidationResult{Valid: true}
+// The accessors below return the parts of an organization the built-in rules check,
+// or nil when the enclosing section is missing
 
-	if org.Leadership == nil {
-		result.addError("5.1", "leadership", "Top management must demonstrate leadership and commitment")
-		return result
+func contextIssues(org *Organization) []Issue {
+	switch {
+	case org.Context == nil:
+		return nil
+	case len(org.Context.InternalIssues) == 0:
+		return org.Context.ExternalIssues
+	case len(org.Context.ExternalIssues) == 0:
+		return org.Context.InternalIssues
 	}
+	return append(append([]Issue{}, org.Context.ExternalIssues...), org.Context.InternalIssues...)
+}
 
-	if len(org.Leadership.TopManagement) == 0 {
-		result.addError("5.1", "top_management", "Top management must be identified")
+func interestedParties(org *Organization) []InterestedParty {
+	if org.Context == nil {
+		return nil
 	}
+	return org.Context.InterestedParties
+}
 
-	// Check required leadership commitments
-	requiredCommitments := []LeadershipCommitment{
-		CommitmentQMSEffectiveness,
-		CommitmentQualityPolicy,
-		CommitmentQMSIntegration,
-		CommitmentProcessApproach,
-		CommitmentRiskThinking,
-		CommitmentResources,
-		CommitmentImportanceQMS,
-		CommitmentConformity,
-		CommitmentQMSResults,
-		CommitmentEngagement,
-		CommitmentImprovement,
-		CommitmentCustomerFocus,
+func scopeExclusions(org *Organization) []Exclusion {
+	if s := qmsScope(org); s != nil {
+		return s.Exclusions
 	}
+	return nil
+}
 
-	commitmentMap := make(map[LeadershipCommitment]bool)
-	for _, commitment := range org.Leadership.Commitment {
-		commitmentMap[commitment] = true
+func qmsProcesses(org *Organization) []Process {
+	if org.QMS == nil {
+		return nil
 	}
+	return org.QMS.Processes
+}
 
-	for _, required := range requiredCommitments {
-		if !commitmentMap[required] {
-			result.addError("5.1", "leadership_commitment", fmt.Sprintf("Missing leadership commitment: %s", required))
-		}
+func leadershipRoles(org *Organization) []OrganizationalRole {
+	if org.Leadership == nil {
+		return nil
 	}
-
-	return result
+	return org.Leadership.Roles
 }
 
-// validateQualityPolicy validates clause 5.2 requirements
-func validateQualityPolicy(org *Organization) *ValidationResult {
-	result := &ValidationResult{Valid: true}
-
-	if org.Leadership == nil || org.Leadership.QualityPolicy == nil {
-		result.addError("5.2", "quality_policy", "Quality policy must be established and maintained")
-		return result
+func qmsRisks(org *Organization) []Risk {
+	if org.QMS == nil {
+		return nil
 	}
+	return org.QMS.Risks
+}
 
-	policy := org.Leadership.QualityPolicy
-	if policy.Statement == "" {
-		result.addError("5.2", "policy_statement", "Quality policy must include a statement of intent")
-	}
-	if policy.Objectives == "" {
-		result.addError("5.2", "policy_objectives", "Quality policy must provide a framework for setting quality objectives")
-	}
-	if policy.Commitment == "" {
-		result.addError("5.2", "policy_commitment", "Quality policy must include commitment to satisfy applicable requirements")
-	}
-	if policy.Improvement == "" {
-		result.addError("5.2", "policy_improvement", "Quality policy must include commitment to continual improvement")
-	}
-	if !policy.Communicated {
-		result.addError("5.2", "policy_communication", "Quality policy must be communicated and understood within the organization")
-	}
-	if !policy.Available {
-		result.addError("5.2", "policy_availability", "Quality policy must be available to relevant interested parties")
+func qmsOpportunities(org *Organization) []Opportunity {
+	if org.QMS == nil {
+		return nil
 	}
-
-	return result
+	return org.QMS.Opportunities
 }
 
-// validateRolesResponsibilities validates clause 5.3 requirements
-func validateRolesResponsibilities(org *Organization) *ValidationResult {
-	result := &ValidationResult{Valid: true}
-
-	if org.Leadership == nil || len(org.Leadership.Roles) == 0 {
-		result.addError("5.3", "roles_responsibilities", "Organizational roles, responsibilities and authorities must be assigned and communicated")
-		return result
+func qmsObjectives(org *Organization) []QualityObjective {
+	if org.QMS == nil {
+		return nil
 	}
+	return org.QMS.Objectives
+}
 
-	for i, role := range org.Leadership.Roles {
-		if role.Name == "" {
-			result.addError("5.3", fmt.Sprintf("role_%d_name", i), "Role must have a name")
+// hasPartyType builds a predicate satisfied when an interested party has one of the
+// types, or when no parties are identified at all (reported by 4.2-interested-parties)
+func hasPartyType(types ...string) func(org *Organization) bool {
+	return func(org *Organization) bool {
+		parties := interestedParties(org)
+		if len(parties) == 0 {
+			return true
 		}
-		if len(role.Responsibilities) == 0 {
-			result.addError("5.3", fmt.Sprintf("role_%s_responsibilities", role.Name), "Role must have defined responsibilities")
-		}
-		if len(role.Authorities) == 0 {
-			result.addError("5.3", fmt.Sprintf("role_%s_authorities", role.Name), "Role must have defined authorities")
-		}
-		if role.AssignedTo == "" {
-			result.addError("5.3", fmt.Sprintf("role_%s_assignment", role.Name), "Role must be assigned to a person")
+		for _, party := range parties {
+			if containsString(party.Type, types...) {
+				return true
+			}
 		}
+		return false
 	}
-
-	return result
 }
 
-// validateRisksOpportunities validates clause 6.1 requirements
-func validateRisksOpportunities(org *Organization) *ValidationResult {
-	result := &ValidationResult{Valid: true}
-
-	if org.QMS == nil {
-		result.addError("6.1", "qms", "QMS must be defined to validate risks and opportunities")
-		return result
+// checkExclusions reports the clause of every exclusion matching violated, given the
+// top-level clause it refers to
+func checkExclusions(violated func(exclusion Exclusion, clause int, ok bool) bool) func(org *Organization) []RuleViolation {
+	return func(org *Organization) []RuleViolation {
+		var violations []RuleViolation
+		for i, exclusion := range scopeExclusions(org) {
+			clause, ok := exclusionClause(exclusion.Clause)
+			if violated(exclusion, clause, ok) {
+				violations = append(violations, RuleViolation{Field: fmt.Sprintf("exclusion_%d_clause", i), Args: []interface{}{exclusion.Clause}})
+			}
+		}
+		return violations
 	}
+}
 
-	totalRisks := len(org.QMS.Risks)
-	totalOpportunities := len(org.QMS.Opportunities)
+// requiredCommitments are the leadership commitments clause 5.1 requires top
+// management to demonstrate
+var requiredCommitments = []LeadershipCommitment{
+	CommitmentQMSEffectiveness,
+	CommitmentQualityPolicy,
+	CommitmentQMSIntegration,
+	CommitmentProcessApproach,
+	CommitmentRiskThinking,
+	CommitmentResources,
+	CommitmentImportanceQMS,
+	CommitmentConformity,
+	CommitmentQMSResults,
+	CommitmentEngagement,
+	CommitmentImprovement,
+	CommitmentCustomerFocus,
+}
 
-	// Add risks and opportunities from processes
-	for _, process := range org.QMS.Processes {
-		totalRisks += len(process.Risks)
-		totalOpportunities += len(process.Opportunities)
+// missingCommitments reports every required leadership commitment not demonstrated
+func missingCommitments(org *Organization) []RuleViolation {
+	if org.Leadership == nil {
+		return nil
 	}
 
-	if totalRisks == 0 {
-		result.addWarning("6.1", "risks", "No risks identified - risk-based thinking should be applied to planning")
-	}
-	if totalOpportunities == 0 {
-		result.addInfo("6.1", "opportunities", "Consider identifying opportunities for improvement")
+	var violations []RuleViolation
+	for _, required := range requiredCommitments {
+		if !hasCommitment(org.Leadership.Commitment, required) {
+			violations = append(violations, RuleViolation{Args: []interface{}{required}})
+		}
 	}
+	return violations
+}
 
-	// Validate risk mitigation actions
-	for i, risk := range org.QMS.Risks {
-		if len(risk.Mitigation) == 0 && risk.Status != RiskStatusMitigated {
-			result.addWarning("6.1", fmt.Sprintf("risk_%d_mitigation", i), "Risk should have mitigation actions defined")
+// hasCommitment reports whether a commitment is demonstrated. The lists are short
+// enough that scanning them is cheaper than building a set on every validation.
+func hasCommitment(commitments []LeadershipCommitment, commitment LeadershipCommitment) bool {
+	for _, c := range commitments {
+		if c == commitment {
+			return true
 		}
 	}
+	return false
+}
 
-	// Validate opportunity actions
-	for i, opportunity := range org.QMS.Opportunities {
-		if len(opportunity.Actions) == 0 && opportunity.Status != OpportunityStatusRealized {
-			result.addInfo("6.1", fmt.Sprintf("opportunity_%d_actions", i), "Opportunity should have actions defined for realization")
-		}
+// policyRequires builds a predicate checking the quality policy, satisfied when there
+// is no policy (reported by 5.2-quality-policy)
+func policyRequires(ok func(policy *QualityPolicy) bool) func(org *Organization) bool {
+	return func(org *Organization) bool {
+		policy := qualityPolicy(org)
+		return policy == nil || ok(policy)
 	}
+}
 
-	return result
+// countRisksOpportunities counts the risks and opportunities of the QMS and its processes
+func countRisksOpportunities(org *Organization) (risks, opportunities int) {
+	if org.QMS == nil {
+		return 0, 0
+	}
+	risks = len(org.QMS.Risks)
+	opportunities = len(org.QMS.Opportunities)
+	for _, process := range org.QMS.Processes {
+		risks += len(process.Risks)
+		opportunities += len(process.Opportunities)
+	}
+	return risks, opportunities
 }
 
-// validateQualityObjectives validates clause 6.2 requirements
-func validateQualityObjectives(org *Organization) *ValidationResult {
-	result := &ValidationResult{Valid: true}
+// conformityCriticalClauses are clause 8 requirements that can rarely be excluded
+// without affecting the conformity of products and services
+var conformityCriticalClauses = map[string]bool{
+	"8.1":   true, // Operational planning and control
+	"8.2":   true, // Requirements for products and services
+	"8.5":   true, // Production and service provision
+	"8.5.1": true, // Control of production and service provision
+	"8.6":   true, // Release of products and services
+	"8.7":   true, // Control of nonconforming outputs
+}
 
-	if org.QMS == nil || len(org.QMS.Objectives) == 0 {
-		result.addError("6.2", "quality_objectives", "Quality objectives must be established at relevant functions and levels")
-		return result
+// normalizeClauseReference strips a leading "Clause" from references such as
+// "Clause 7.1.5", leaving the clause number
+func normalizeClauseReference(reference string) string {
+	reference = strings.TrimSpace(reference)
+	if len(reference) >= 6 && strings.EqualFold(reference[:6], "clause") {
+		reference = strings.TrimSpace(reference[6:])
 	}
+	return reference
+}
 
-	for i, objective := range org.QMS.Objectives {
-		if objective.Name == "" {
-			result.addError("6.2", fmt.Sprintf("objective_%d_name", i), "Quality objective must have a name")
-		}
-		if !objective.Measurable {
-			result.addError("6.2", fmt.Sprintf("objective_%s_measurable", objective.Name), "Quality objectives must be measurable")
-		}
-		if len(objective.Targets) == 0 {
-			result.addError("6.2", fmt.Sprintf("objective_%s_targets", objective.Name), "Quality objectives must have specific targets")
-		}
-		if objective.Responsible == "" {
-			result.addError("6.2", fmt.Sprintf("objective_%s_responsible", objective.Name), "Quality objectives must have responsible parties assigned")
-		}
-		if objective.Timeline.TargetDate.IsZero() {
-			result.addError("6.2", fmt.Sprintf("objective_%s_timeline", objective.Name), "Quality objectives must have target dates")
-		}
+// exclusionClause extracts the top-level clause number from references such as
+// "8.3", "8.3.2" or "Clause 7.1.5"
+func exclusionClause(reference string) (int, bool) {
+	major, _, _ := strings.Cut(normalizeClauseReference(reference), ".")
+	clause, err := strconv.Atoi(major)
+	if err != nil || clause < 4 || clause > 10 {
+		return 0, false
 	}
-
-	return result
+	return clause, true
 }
 
 // Helper methods for ValidationResult
@@ -392,7 +596,7 @@ func (r *ValidationResult) addError(clause, field, message string) {
 		Clause:   clause,
 		Field:    field,
 		Message:  message,
-		Severity: "error",
+		Severity: SeverityError,
 	})
 	r.Valid = false
 }
@@ -402,7 +606,7 @@ func (r *ValidationResult) addWarning(clause, field, message string) {
 		Clause:   clause,
 		Field:    field,
 		Message:  message,
-		Severity: "warning",
+		Severity: SeverityWarning,
 	})
 }
 
@@ -411,10 +615,54 @@ func (r *ValidationResult) addInfo(clause, field, message string) {
 		Clause:   clause,
 		Field:    field,
 		Message:  message,
-		Severity: "info",
+		Severity: SeverityInfo,
 	})
 }
 
+// reserve makes room for n more issues of the given severity, so the violations of a
+// rule are added without growing the slice once per violation
+func (r *ValidationResult) reserve(severity string, n int) {
+	issues := &r.Infos
+	switch severity {
+	case SeverityError:
+		issues = &r.Errors
+	case SeverityWarning:
+		issues = &r.Warnings
+	}
+	if cap(*issues)-len(*issues) < n {
+		grown := make([]ValidationError, len(*issues), 2*len(*issues)+n)
+		copy(grown, *issues)
+		*issues = grown
+	}
+}
+
+// addIssue adds an issue of the given severity. When there are arguments the message
+// is formatted from a catalog format string, keeping the format and arguments so the
+// message can be localized.
+func (r *ValidationResult) addIssue(severity, clause, field, format string, args ...interface{}) {
+	message := format
+	if len(args) > 0 {
+		message = fmt.Sprintf(format, args...)
+	}
+
+	var issues []ValidationError
+	switch severity {
+	case SeverityError:
+		r.addError(clause, field, message)
+		issues = r.Errors
+	case SeverityWarning:
+		r.addWarning(clause, field, message)
+		issues = r.Warnings
+	default:
+		r.addInfo(clause, field, message)
+		issues = r.Infos
+	}
+	if len(args) > 0 {
+		issues[len(issues)-1].format = format
+		issues[len(issues)-1].args = args
+	}
+}
+
 // ValidateQMSCompliance provides a high-level compliance check
 func ValidateQMSCompliance(org *Organization) error {
 	result := ValidateOrganization(org)
@@ -426,8 +674,11 @@ func ValidateQMSCompliance(org *Organization) error {
 
 // GetComplianceScore returns a compliance score (0-100) based on validation results
 func GetComplianceScore(org *Organization) float64 {
-	result := ValidateOrganization(org)
+	return complianceScore(ValidateOrganization(org))
+}
 
+// complianceScore weights the issues of a validation result into a score (0-100)
+func complianceScore(result *ValidationResult) float64 {
 	totalChecks := len(result.Errors) + len(result.Warnings) + len(result.Infos)
 	if totalChecks == 0 {
 		return 100.0
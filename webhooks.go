@@ -0,0 +1,260 @@
+package iso9001
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// WebhookEvent names a QMS event webhooks can subscribe to
+type WebhookEvent string
+
+const (
+	WebhookFindingAdded     WebhookEvent = "finding.added"
+	WebhookDocumentApproved WebhookEvent = "document.approved"
+	WebhookRiskCritical     WebhookEvent = "risk.critical"     // a risk was identified or reassessed as critical
+	WebhookObjectiveOverdue WebhookEvent = "objective.overdue" // an open objective passed its target date
+)
+
+// webhookEvents are the events webhooks can subscribe to
+var webhookEvents = []WebhookEvent{WebhookFindingAdded, WebhookDocumentApproved, WebhookRiskCritical, WebhookObjectiveOverdue}
+
+// Webhook is an external endpoint, such as a ticketing tool, that is sent a JSON
+// payload by POST whenever one of its events occurs. With a secret, each request is
+// signed like inbound events: the X-QMS-Signature header carries SignEvent of the
+// X-QMS-Timestamp header and the body.
+type Webhook struct {
+	URL    string         `json:"url" yaml:"url"`
+	Events []WebhookEvent `json:"events,omitempty" yaml:"events,omitempty"` // every event when empty
+	Secret string         `json:"secret,omitempty" yaml:"secret,omitempty"`
+}
+
+// subscribes reports whether the webhook is sent the event
+func (w Webhook) subscribes(event WebhookEvent) bool {
+	if len(w.Events) == 0 {
+		return true
+	}
+	for _, subscribed := range w.Events {
+		if subscribed == event {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookPayload is the JSON body sent to a webhook. ID identifies the occurrence, so
+// receivers can discard redeliveries; Data is the entity after the change.
+type WebhookPayload struct {
+	ID         string          `json:"id" yaml:"id"`
+	Event      WebhookEvent    `json:"event" yaml:"event"`
+	TenantID   string          `json:"tenant_id,omitempty" yaml:"tenant_id,omitempty"`
+	EntityType string          `json:"entity_type" yaml:"entity_type"`
+	EntityID   string          `json:"entity_id" yaml:"entity_id"`
+	Summary    string          `json:"summary,omitempty" yaml:"summary,omitempty"`
+	Actor      string          `json:"actor,omitempty" yaml:"actor,omitempty"`
+	Timestamp  time.Time       `json:"timestamp" yaml:"timestamp"`
+	Data       json.RawMessage `json:"data,omitempty" yaml:"data,omitempty"`
+}
+
+// LoadWebhooks parses and checks a JSON list of webhooks
+func LoadWebhooks(data []byte) ([]Webhook, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	var hooks []Webhook
+	if err := decoder.Decode(&hooks); err != nil {
+		return nil, fmt.Errorf("invalid webhooks: %w", err)
+	}
+	for i, hook := range hooks {
+		target, err := url.Parse(hook.URL)
+		if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+			return nil, fmt.Errorf("invalid webhooks: webhook %d has no http(s) URL", i+1)
+		}
+		for _, event := range hook.Events {
+			if !containsString(string(event), webhookEventNames()...) {
+				return nil, fmt.Errorf("invalid webhooks: webhook %d has unknown event %q (events: %s)", i+1, event, strings.Join(webhookEventNames(), ", "))
+			}
+		}
+	}
+	return hooks, nil
+}
+
+// webhookEventNames lists the events webhooks can subscribe to by name
+func webhookEventNames() []string {
+	names := make([]string, len(webhookEvents))
+	for i, event := range webhookEvents {
+		names[i] = string(event)
+	}
+	return names
+}
+
+// WebhookDispatcher detects the QMS events webhooks subscribe to and delivers them.
+// Observe and Overdue only collect payloads, so they can run while a tenant is locked;
+// Deliver sends them afterwards.
+type WebhookDispatcher struct {
+	Hooks []Webhook
+	// Client sends the requests; a client with a 10 second timeout when nil
+	Client *http.Client
+
+	mu      sync.Mutex
+	overdue map[string]bool
+}
+
+// NewWebhookDispatcher creates a dispatcher for the given webhooks
+func NewWebhookDispatcher(hooks ...Webhook) *WebhookDispatcher {
+	return &WebhookDispatcher{Hooks: hooks, overdue: make(map[string]bool)}
+}
+
+// Observe runs fn on the tenant and returns the payloads for the findings it added,
+// the documents it approved and the risks it raised to critical priority, attributed
+// to actor. The payloads are returned even when fn fails part way, with its error.
+func (d *WebhookDispatcher) Observe(tenant *Tenant, actor string, fn func(tenant *Tenant) error) ([]WebhookPayload, error) {
+	before := tenant.trailSnapshot()
+	err := fn(tenant)
+	after := tenant.trailSnapshot()
+
+	now := time.Now().UTC()
+	var payloads []WebhookPayload
+	for _, key := range trailKeys(before, after) {
+		change, changed := entityEvent(key, before, after)
+		if !changed {
+			continue
+		}
+		var event WebhookEvent
+		switch change.Type {
+		case EventFindingAdded:
+			event = WebhookFindingAdded
+		case EventDocumentApproved:
+			event = WebhookDocumentApproved
+		case EventRiskIdentified, EventRiskUpdated, EventRiskAssessed, EventRiskMitigated:
+			if riskPriority(change.Data) == PriorityCritical && riskPriority(before[key].data) != PriorityCritical {
+				event = WebhookRiskCritical
+			}
+		}
+		if event == "" {
+			continue
+		}
+		payloads = append(payloads, WebhookPayload{
+			ID:         fmt.Sprintf("%s:%s:%s:%d", tenant.ID, event, key.id, now.UnixNano()),
+			Event:      event,
+			TenantID:   tenant.ID,
+			EntityType: key.entityType,
+			EntityID:   key.id,
+			Summary:    after[key].summary,
+			Actor:      actor,
+			Timestamp:  now,
+			Data:       change.Data,
+		})
+	}
+	return payloads, err
+}
+
+// riskPriority returns the priority field of a risk's JSON
+func riskPriority(data []byte) Priority {
+	var risk struct {
+		Priority Priority `json:"priority"`
+	}
+	json.Unmarshal(data, &risk)
+	return risk.Priority
+}
+
+// Overdue returns a payload for each objective of the tenant that is overdue at now
+// and has not been reported by this dispatcher before
+func (d *WebhookDispatcher) Overdue(tenant *Tenant, now time.Time) []WebhookPayload {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.overdue == nil {
+		d.overdue = make(map[string]bool)
+	}
+
+	var payloads []WebhookPayload
+	for _, deadline := range tenant.Deadlines() {
+		if deadline.Kind != DeadlineObjective || !tenant.Objectives.DueDates.IsOverdue(deadline.Due, now) {
+			continue
+		}
+		id := fmt.Sprintf("%s:%s:%s:%s", tenant.ID, WebhookObjectiveOverdue, deadline.EntityID, deadline.Due.UTC().Format("2006-01-02"))
+		if d.overdue[id] {
+			continue
+		}
+		d.overdue[id] = true
+
+		payload := WebhookPayload{
+			ID:         id,
+			Event:      WebhookObjectiveOverdue,
+			TenantID:   tenant.ID,
+			EntityType: EntityTypeObjective,
+			EntityID:   deadline.EntityID,
+			Summary:    Reminder{Deadline: deadline, Overdue: true}.String(),
+			Timestamp:  now.UTC(),
+		}
+		if objective, ok := tenant.Objectives.Objectives[deadline.EntityID]; ok {
+			payload.Data, _ = json.Marshal(objective)
+		}
+		payloads = append(payloads, payload)
+	}
+	return payloads
+}
+
+// Deliver posts each payload to every webhook subscribed to its event. A webhook
+// that answers with anything but a 2xx status counts as failed; the failures are
+// returned together.
+func (d *WebhookDispatcher) Deliver(ctx context.Context, payloads []WebhookPayload) error {
+	client := d.Client
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	var errs []error
+	for _, payload := range payloads {
+		body, err := json.Marshal(payload)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("webhook payload %s: %w", payload.ID, err))
+			continue
+		}
+		for _, hook := range d.Hooks {
+			if !hook.subscribes(payload.Event) {
+				continue
+			}
+			if err := postWebhook(ctx, client, hook, string(payload.Event), payload.ID, body); err != nil {
+				errs = append(errs, fmt.Errorf("webhook %s, %s %s: %w", hook.URL, payload.Event, payload.EntityID, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// postWebhook posts a JSON body to a webhook, naming the event and the delivery ID in
+// headers and signing it when the webhook has a secret
+func postWebhook(ctx context.Context, client *http.Client, hook Webhook, event, delivery string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-QMS-Event", event)
+	req.Header.Set("X-QMS-Delivery", delivery)
+	if hook.Secret != "" {
+		now := time.Now()
+		req.Header.Set("X-QMS-Timestamp", strconv.FormatInt(now.Unix(), 10))
+		req.Header.Set("X-QMS-Signature", SignEvent(hook.Secret, now, body))
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("returned %s", resp.Status)
+	}
+	return nil
+}
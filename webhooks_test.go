@@ -0,0 +1,130 @@
+package iso9001
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWebhookDispatcherDeliversSubscribedEvents(t *testing.T) {
+	var mu sync.Mutex
+	received := make(map[string][]WebhookPayload)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var payload WebhookPayload
+		json.Unmarshal(body, &payload)
+		if r.URL.Path == "/signed" {
+			source := EventSource{Name: "qms", Secret: "s3cret"}
+			if err := source.Verify(body, r.Header.Get("X-QMS-Timestamp"), r.Header.Get("X-QMS-Signature"), time.Now()); err != nil {
+				http.Error(w, err.Error(), http.StatusUnauthorized)
+				return
+			}
+		}
+		if r.Header.Get("X-QMS-Event") != string(payload.Event) {
+			http.Error(w, "event header mismatch", http.StatusBadRequest)
+			return
+		}
+		mu.Lock()
+		received[r.URL.Path] = append(received[r.URL.Path], payload)
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	hooks, err := LoadWebhooks([]byte(`[
+		{"url": "` + server.URL + `/tickets", "events": ["finding.added", "risk.critical"]},
+		{"url": "` + server.URL + `/signed", "secret": "s3cret"}
+	]`))
+	if err != nil {
+		t.Fatalf("Failed to load webhooks: %v", err)
+	}
+	dispatcher := NewWebhookDispatcher(hooks...)
+
+	tenant := NewTenant("acme")
+	tenant.Risks.IdentifyRisk(&Risk{ID: "RISK-001", Description: "Supplier fails"})
+	tenant.Documents.AddDocument(&DocumentedInformation{ID: "DOC-001", Title: "Purchasing procedure"})
+	tenant.Audits.CreateAudit(&Audit{ID: "AUDIT-001", Title: "Purchasing", Scope: AuditScope{Description: "Purchasing"}})
+
+	payloads, err := dispatcher.Observe(tenant, "jane", func(tenant *Tenant) error {
+		tenant.Risks.AssessRisk("RISK-001", RiskLevelVeryHigh, RiskLevelVeryHigh)
+		tenant.Documents.ApproveDocument("DOC-001", Approval{ApproverID: "jane"})
+		return tenant.Audits.AddFinding("AUDIT-001", AuditFinding{ID: "F-001", Description: "No evaluation", Severity: SeverityMajor})
+	})
+	if err != nil {
+		t.Fatalf("Observe failed: %v", err)
+	}
+	events := make(map[WebhookEvent]WebhookPayload)
+	for _, payload := range payloads {
+		events[payload.Event] = payload
+	}
+	if len(payloads) != 3 || events[WebhookRiskCritical].EntityID != "RISK-001" || events[WebhookDocumentApproved].EntityID != "DOC-001" || events[WebhookFindingAdded].Actor != "jane" {
+		t.Fatalf("Expected critical risk, approved document and finding payloads, got %+v", payloads)
+	}
+
+	// Reassessing a risk that is already critical is not an escalation
+	again, _ := dispatcher.Observe(tenant, "jane", func(tenant *Tenant) error {
+		return tenant.Risks.AssessRisk("RISK-001", RiskLevelVeryHigh, RiskLevelVeryHigh)
+	})
+	if len(again) != 0 {
+		t.Errorf("Expected no payloads for an unchanged priority, got %+v", again)
+	}
+
+	if err := dispatcher.Deliver(context.Background(), payloads); err != nil {
+		t.Fatalf("Deliver failed: %v", err)
+	}
+	if got := received["/tickets"]; len(got) != 2 {
+		t.Errorf("Expected the subscribed finding and risk events only, got %+v", got)
+	}
+	if got := received["/signed"]; len(got) != 3 {
+		t.Errorf("Expected every event at the signed webhook, got %+v", got)
+	}
+
+	failing := NewWebhookDispatcher(Webhook{URL: server.URL + "/signed", Secret: "wrong"})
+	if err := failing.Deliver(context.Background(), payloads[:1]); err == nil || !strings.Contains(err.Error(), "401") {
+		t.Errorf("Expected a rejected delivery to fail, got %v", err)
+	}
+}
+
+func TestWebhookDispatcherOverdueObjectives(t *testing.T) {
+	tenant := NewTenant("acme")
+	now := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+	for id, target := range map[string]time.Time{"OBJ-001": now.AddDate(0, 0, -2), "OBJ-002": now.AddDate(0, 1, 0)} {
+		err := tenant.Objectives.CreateObjective(&QualityObjective{
+			ID:          id,
+			Name:        "On-time delivery",
+			Measurable:  true,
+			Targets:     []ObjectiveTarget{{Metric: "on-time rate", Value: "98", Unit: "%"}},
+			Responsible: "Logistics",
+			Timeline:    ObjectiveTimeline{TargetDate: target},
+		})
+		if err != nil {
+			t.Fatalf("Failed to create objective %s: %v", id, err)
+		}
+	}
+
+	dispatcher := NewWebhookDispatcher()
+	payloads := dispatcher.Overdue(tenant, now)
+	if len(payloads) != 1 || payloads[0].EntityID != "OBJ-001" || payloads[0].Event != WebhookObjectiveOverdue || len(payloads[0].Data) == 0 {
+		t.Fatalf("Expected one overdue objective payload, got %+v", payloads)
+	}
+	if again := dispatcher.Overdue(tenant, now.Add(time.Hour)); len(again) != 0 {
+		t.Errorf("Expected an overdue objective to be reported once, got %+v", again)
+	}
+}
+
+func TestLoadWebhooksRejectsInvalidConfiguration(t *testing.T) {
+	for _, config := range []string{
+		`[{"url": "ftp://example.com"}]`,
+		`[{"url": "https://example.com", "events": ["risk.added"]}]`,
+		`[{"url": "https://example.com", "topic": "qms"}]`,
+	} {
+		if _, err := LoadWebhooks([]byte(config)); err == nil {
+			t.Errorf("Expected %s to be rejected", config)
+		}
+	}
+}
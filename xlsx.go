@@ -0,0 +1,296 @@
+package iso9001
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// xlsxStyles are the cell formats of the workbooks written by WriteAuditXLSX: style 1
+// is a bold header and style 2 a date
+const xlsxStyles = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<numFmts count="1"><numFmt numFmtId="164" formatCode="yyyy-mm-dd"/></numFmts>
+<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>
+<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>
+<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>
+<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>
+<cellXfs count="3"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/><xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/><xf numFmtId="164" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/></cellXfs>
+</styleSheet>`
+
+// xlsxSheet is a worksheet whose first row is a header
+type xlsxSheet struct {
+	name string
+	rows [][]interface{} // cells are strings, ints or times; zero times are left empty
+}
+
+// WriteAuditXLSX writes an audit as an Excel (.xlsx) workbook with the sheets Audit,
+// Findings, Corrective Actions and Statistics. The statistics are usually those of
+// the audit manager, from GetAuditStatistics.
+func WriteAuditXLSX(w io.Writer, audit *Audit, stats AuditStatistics) error {
+	return writeXLSX(w, []xlsxSheet{
+		auditSummarySheet(audit),
+		auditFindingsSheet(audit),
+		auditCorrectiveActionsSheet(audit),
+		{name: "Statistics", rows: [][]interface{}{
+			{"Metric", "Value"},
+			{"Planned audits", stats.Planned},
+			{"Audits in progress", stats.InProgress},
+			{"Completed audits", stats.Completed},
+			{"Closed audits", stats.Closed},
+			{"Critical findings", stats.CriticalFindings},
+			{"Major findings", stats.MajorFindings},
+			{"Minor findings", stats.MinorFindings},
+			{"Observations", stats.Observations},
+		}},
+	})
+}
+
+// ExportAuditXLSX writes an audit with the manager's audit statistics as an Excel
+// workbook; see WriteAuditXLSX
+func (am *AuditManager) ExportAuditXLSX(w io.Writer, auditID string) error {
+	audit, exists := am.Audits[auditID]
+	if !exists {
+		return fmt.Errorf("audit with ID %s not found", auditID)
+	}
+	return WriteAuditXLSX(w, audit, am.GetAuditStatistics())
+}
+
+func auditSummarySheet(audit *Audit) xlsxSheet {
+	counts := make(map[FindingSeverity]int)
+	for _, finding := range audit.Findings {
+		counts[finding.Severity]++
+	}
+	var actualStart, actualEnd time.Time
+	if audit.ActualStartDate != nil {
+		actualStart = *audit.ActualStartDate
+	}
+	if audit.ActualEndDate != nil {
+		actualEnd = *audit.ActualEndDate
+	}
+	return xlsxSheet{name: "Audit", rows: [][]interface{}{
+		{"Field", "Value"},
+		{"ID", audit.ID},
+		{"Title", audit.Title},
+		{"Type", string(audit.Type)},
+		{"Status", string(audit.Status)},
+		{"Scope", audit.Scope.Description},
+		{"Processes", strings.Join(audit.Scope.Processes, ", ")},
+		{"Clauses", strings.Join(audit.Scope.Clauses, ", ")},
+		{"Planned start", audit.PlannedStartDate},
+		{"Planned end", audit.PlannedEndDate},
+		{"Actual start", actualStart},
+		{"Actual end", actualEnd},
+		{"Auditors", participantNames(audit.Auditors)},
+		{"Auditees", participantNames(audit.Auditees)},
+		{"Findings", len(audit.Findings)},
+		{"Critical findings", counts[SeverityCritical]},
+		{"Major findings", counts[SeverityMajor]},
+		{"Minor findings", counts[SeverityMinor]},
+		{"Observations", counts[SeverityObservation]},
+	}}
+}
+
+func auditFindingsSheet(audit *Audit) xlsxSheet {
+	rows := [][]interface{}{{"Finding ID", "Clause", "Severity", "Category", "Description", "Evidence", "Process", "Root Cause", "Responsible", "Due Date", "Status", "Corrective Actions"}}
+	for _, finding := range audit.Findings {
+		var actions []string
+		for _, action := range finding.CorrectiveActions {
+			actions = append(actions, action.ID)
+		}
+		rows = append(rows, []interface{}{
+			finding.ID,
+			finding.Clause,
+			string(finding.Severity),
+			string(finding.Category),
+			finding.Description,
+			finding.Evidence,
+			finding.Process,
+			finding.RootCause,
+			finding.Responsible,
+			finding.DueDate,
+			string(finding.Status),
+			strings.Join(actions, ", "),
+		})
+	}
+	return xlsxSheet{name: "Findings", rows: rows}
+}
+
+func auditCorrectiveActionsSheet(audit *Audit) xlsxSheet {
+	rows := [][]interface{}{{"Finding ID", "Action ID", "Description", "Root Cause", "Actions", "Responsible", "Due Date", "Status", "Verification"}}
+	for _, finding := range audit.Findings {
+		for _, action := range finding.CorrectiveActions {
+			rows = append(rows, []interface{}{
+				finding.ID,
+				action.ID,
+				action.Description,
+				action.RootCause,
+				strings.Join(action.Actions, "; "),
+				action.Responsible,
+				action.DueDate,
+				string(action.Status),
+				action.Verification,
+			})
+		}
+	}
+	return xlsxSheet{name: "Corrective Actions", rows: rows}
+}
+
+func participantNames(participants []AuditParticipant) string {
+	names := make([]string, len(participants))
+	for i, participant := range participants {
+		names[i] = participant.Name
+		if names[i] == "" {
+			names[i] = participant.ID
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// writeXLSX writes the sheets as a workbook. Text is written as inline strings and
+// times as date serials, so that dates sort and filter as dates in Excel.
+func writeXLSX(w io.Writer, sheets []xlsxSheet) error {
+	var contentTypes, workbook, workbookRels bytes.Buffer
+	contentTypes.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>
+<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>
+`)
+	workbook.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>`)
+	workbookRels.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+`)
+	for i, sheet := range sheets {
+		fmt.Fprintf(&contentTypes, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`+"\n", i+1)
+		fmt.Fprintf(&workbook, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, sheet.name, i+1, i+1)
+		fmt.Fprintf(&workbookRels, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`+"\n", i+1, i+1)
+	}
+	contentTypes.WriteString(`</Types>`)
+	workbook.WriteString(`</sheets></workbook>`)
+	fmt.Fprintf(&workbookRels, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>`+"\n</Relationships>", len(sheets)+1)
+
+	archive := zip.NewWriter(w)
+	add := func(name string, content []byte) error {
+		file, err := archive.Create(name)
+		if err != nil {
+			return err
+		}
+		_, err = file.Write(content)
+		return err
+	}
+	if err := add("[Content_Types].xml", contentTypes.Bytes()); err != nil {
+		return err
+	}
+	if err := add("_rels/.rels", []byte(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>
+</Relationships>`)); err != nil {
+		return err
+	}
+	if err := add("xl/workbook.xml", workbook.Bytes()); err != nil {
+		return err
+	}
+	if err := add("xl/_rels/workbook.xml.rels", workbookRels.Bytes()); err != nil {
+		return err
+	}
+	if err := add("xl/styles.xml", []byte(xlsxStyles)); err != nil {
+		return err
+	}
+	for i, sheet := range sheets {
+		content, err := sheet.xml()
+		if err != nil {
+			return err
+		}
+		if err := add(fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1), content); err != nil {
+			return err
+		}
+	}
+	return archive.Close()
+}
+
+// xml renders the worksheet with a frozen header row and columns sized to their
+// content
+func (s xlsxSheet) xml() ([]byte, error) {
+	var body bytes.Buffer
+	body.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n")
+	body.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+	body.WriteString(`<sheetViews><sheetView workbookViewId="0"><pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/></sheetView></sheetViews>`)
+
+	var widths []int
+	for _, row := range s.rows {
+		for i, cell := range row {
+			width := 12
+			if text, ok := cell.(string); ok {
+				width = len(text) + 2
+			}
+			if width > 60 {
+				width = 60
+			}
+			if i == len(widths) {
+				widths = append(widths, 0)
+			}
+			if width > widths[i] {
+				widths[i] = width
+			}
+		}
+	}
+	body.WriteString("<cols>")
+	for i, width := range widths {
+		fmt.Fprintf(&body, `<col min="%d" max="%d" width="%d" customWidth="1"/>`, i+1, i+1, width)
+	}
+	body.WriteString("</cols><sheetData>")
+
+	for r, row := range s.rows {
+		fmt.Fprintf(&body, `<row r="%d">`, r+1)
+		for c, cell := range row {
+			ref := fmt.Sprintf("%s%d", xlsxColumn(c), r+1)
+			switch value := cell.(type) {
+			case string:
+				style := ""
+				if r == 0 {
+					style = ` s="1"`
+				}
+				fmt.Fprintf(&body, `<c r="%s" t="inlineStr"%s><is><t xml:space="preserve">`, ref, style)
+				if err := xml.EscapeText(&body, []byte(value)); err != nil {
+					return nil, err
+				}
+				body.WriteString("</t></is></c>")
+			case int:
+				fmt.Fprintf(&body, `<c r="%s"><v>%d</v></c>`, ref, value)
+			case time.Time:
+				if !value.IsZero() {
+					fmt.Fprintf(&body, `<c r="%s" s="2"><v>%s</v></c>`, ref, xlsxDate(value))
+				}
+			default:
+				return nil, fmt.Errorf("unsupported cell value %T in sheet %s", cell, s.name)
+			}
+		}
+		body.WriteString("</row>")
+	}
+	body.WriteString("</sheetData></worksheet>")
+	return body.Bytes(), nil
+}
+
+// xlsxColumn returns the letters of a zero-based column index: A, B, ..., Z, AA
+func xlsxColumn(index int) string {
+	name := ""
+	for index++; index > 0; index = (index - 1) / 26 {
+		name = string(rune('A'+(index-1)%26)) + name
+	}
+	return name
+}
+
+// xlsxDate returns the Excel date serial of a time: days since 30 December 1899,
+// counting the time of day as a fraction
+func xlsxDate(t time.Time) string {
+	epoch := time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+	wall := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC)
+	return fmt.Sprintf("%g", wall.Sub(epoch).Hours()/24)
+}
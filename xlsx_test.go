@@ -0,0 +1,87 @@
+package iso9001
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteAuditXLSX(t *testing.T) {
+	am := NewAuditManager()
+	audit := &Audit{
+		ID:               "AUD-001",
+		Title:            "Purchasing audit",
+		PlannedStartDate: time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC),
+		PlannedEndDate:   time.Date(2026, 3, 3, 0, 0, 0, 0, time.UTC),
+		Scope:            AuditScope{Description: "Supplier selection", Processes: []string{"PROC-004"}},
+		Auditors:         []AuditParticipant{{ID: "A1", Name: "Jane Auditor"}},
+	}
+	if err := am.CreateAudit(audit); err != nil {
+		t.Fatal(err)
+	}
+	finding := AuditFinding{
+		ID:          "F-001",
+		Clause:      "8.4",
+		Description: "Supplier <evaluation> missing",
+		Severity:    SeverityMajor,
+		Responsible: "bob",
+		DueDate:     time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC),
+		CorrectiveActions: []CorrectiveAction{{
+			ID:          "CA-001",
+			Description: "Evaluate suppliers",
+			Actions:     []string{"Define criteria", "Evaluate top 10"},
+			Status:      ActionStatusPlanned,
+		}},
+	}
+	if err := am.AddFinding("AUD-001", finding); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := am.ExportAuditXLSX(&out, "AUD-001"); err != nil {
+		t.Fatalf("Failed to write XLSX: %v", err)
+	}
+	archive, err := zip.NewReader(bytes.NewReader(out.Bytes()), int64(out.Len()))
+	if err != nil {
+		t.Fatalf("XLSX is not a zip archive: %v", err)
+	}
+	parts := make(map[string]string)
+	for _, file := range archive.File {
+		r, _ := file.Open()
+		content, _ := io.ReadAll(r)
+		r.Close()
+		parts[file.Name] = string(content)
+		if strings.HasSuffix(file.Name, ".xml") || strings.HasSuffix(file.Name, ".rels") {
+			if err := xml.Unmarshal(content, new(interface{})); err != nil {
+				t.Errorf("Part %s is not well-formed XML: %v", file.Name, err)
+			}
+		}
+	}
+
+	for _, sheet := range []string{`name="Audit"`, `name="Findings"`, `name="Corrective Actions"`, `name="Statistics"`} {
+		if !strings.Contains(parts["xl/workbook.xml"], sheet) {
+			t.Errorf("Expected workbook to have sheet %s", sheet)
+		}
+	}
+	findings := parts["xl/worksheets/sheet2.xml"]
+	if !strings.Contains(findings, "Supplier &lt;evaluation&gt; missing") || !strings.Contains(findings, `<c r="J2" s="2"><v>46113</v></c>`) {
+		t.Errorf("Expected finding with escaped text and due date serial, got %s", findings)
+	}
+	if actions := parts["xl/worksheets/sheet3.xml"]; !strings.Contains(actions, "Define criteria; Evaluate top 10") {
+		t.Errorf("Expected corrective actions sheet, got %s", actions)
+	}
+	if stats := parts["xl/worksheets/sheet4.xml"]; !strings.Contains(stats, `<c r="B7"><v>1</v></c>`) {
+		t.Errorf("Expected one major finding in the statistics, got %s", stats)
+	}
+
+	if err := am.ExportAuditXLSX(&out, "AUD-404"); err == nil {
+		t.Error("Expected unknown audit to be rejected")
+	}
+	if xlsxColumn(0) != "A" || xlsxColumn(25) != "Z" || xlsxColumn(26) != "AA" || xlsxColumn(701) != "ZZ" {
+		t.Error("Unexpected column letters")
+	}
+}
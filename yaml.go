@@ -0,0 +1,157 @@
+package iso9001
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LoadOrganizationFromYAML decodes an organization maintained as YAML, ignoring
+// unknown keys. Data written by earlier library versions is migrated first, and
+// durations may be written as "720h" or in nanoseconds.
+func LoadOrganizationFromYAML(data []byte) (*Organization, error) {
+	var org Organization
+	if err := decodeYAML(SchemaKindOrganization, data, &org, false); err != nil {
+		return nil, err
+	}
+	org.SchemaVersion = CurrentSchemaVersion
+	return &org, nil
+}
+
+// LoadOrganizationFromYAMLStrict decodes an organization like LoadOrganizationFromYAML
+// but rejects unknown or misspelled keys with an *UnknownFieldsError listing every
+// offending key
+func LoadOrganizationFromYAMLStrict(data []byte) (*Organization, error) {
+	var org Organization
+	if err := decodeYAML(SchemaKindOrganization, data, &org, true); err != nil {
+		return nil, err
+	}
+	org.SchemaVersion = CurrentSchemaVersion
+	return &org, nil
+}
+
+// SaveOrganizationToYAML writes an organization as YAML at the current schema version
+func SaveOrganizationToYAML(w io.Writer, org *Organization) error {
+	org.SchemaVersion = CurrentSchemaVersion
+	return SaveYAML(w, org)
+}
+
+// LoadTenantFromYAML decodes a tenant with all its managers, migrating it to the
+// current schema
+func LoadTenantFromYAML(data []byte) (*Tenant, error) {
+	var header struct {
+		ID string `yaml:"id"`
+	}
+	if err := yaml.Unmarshal(data, &header); err != nil {
+		return nil, fmt.Errorf("failed to decode tenant: %w", err)
+	}
+	if header.ID == "" {
+		return nil, fmt.Errorf("tenant must have an ID")
+	}
+
+	tenant := NewTenant(header.ID)
+	if err := decodeYAML(SchemaKindTenant, data, tenant, false); err != nil {
+		return nil, fmt.Errorf("failed to decode tenant %s: %w", header.ID, err)
+	}
+	tenant.SchemaVersion = CurrentSchemaVersion
+	if tenant.Organization != nil {
+		tenant.Organization.SchemaVersion = CurrentSchemaVersion
+	}
+	tenant.shareIDRegistry()
+	return tenant, nil
+}
+
+// SaveTenantToYAML writes a tenant with all its managers as YAML
+func SaveTenantToYAML(w io.Writer, tenant *Tenant) error {
+	tenant.SchemaVersion = CurrentSchemaVersion
+	if tenant.Organization != nil {
+		tenant.Organization.SchemaVersion = CurrentSchemaVersion
+	}
+	return SaveYAML(w, tenant)
+}
+
+// LoadDocumentationManagerFromYAML decodes a documentation manager saved with
+// SaveYAML. Attachments are kept in a new in-memory store.
+func LoadDocumentationManagerFromYAML(data []byte) (*DocumentationManager, error) {
+	dm := NewDocumentationManager()
+	if err := decodeYAML("", data, dm, false); err != nil {
+		return nil, err
+	}
+	return dm, nil
+}
+
+// LoadRiskManagerFromYAML decodes a risk manager saved with SaveYAML
+func LoadRiskManagerFromYAML(data []byte) (*RiskManager, error) {
+	rm := NewRiskManager()
+	if err := decodeYAML("", data, rm, false); err != nil {
+		return nil, err
+	}
+	return rm, nil
+}
+
+// LoadObjectivesManagerFromYAML decodes a quality objectives manager saved with
+// SaveYAML
+func LoadObjectivesManagerFromYAML(data []byte) (*QualityObjectivesManager, error) {
+	qom := NewQualityObjectivesManager()
+	if err := decodeYAML("", data, qom, false); err != nil {
+		return nil, err
+	}
+	return qom, nil
+}
+
+// LoadAuditManagerFromYAML decodes an audit manager saved with SaveYAML
+func LoadAuditManagerFromYAML(data []byte) (*AuditManager, error) {
+	am := NewAuditManager()
+	if err := decodeYAML("", data, am, false); err != nil {
+		return nil, err
+	}
+	return am, nil
+}
+
+// SaveYAML writes any part of the QMS model, such as a manager, as YAML with
+// two-space indentation. Durations are written in the form "720h0m0s".
+func SaveYAML(w io.Writer, v interface{}) error {
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(v); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+// decodeYAML migrates YAML data of a schema kind, if any, and decodes it into v. In
+// strict mode every unknown key is reported in an *UnknownFieldsError, as by the
+// strict JSON loaders.
+func decodeYAML(kind SchemaKind, data []byte, v interface{}, strict bool) error {
+	if kind != "" || strict {
+		var doc map[string]interface{}
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return err
+		}
+		if kind != "" {
+			changed, err := DefaultMigrations.Migrate(kind, doc)
+			if err != nil {
+				return err
+			}
+			if changed {
+				if data, err = yaml.Marshal(doc); err != nil {
+					return err
+				}
+			}
+		}
+		if strict {
+			var fields []UnknownField
+			collectUnknownFields(doc, reflect.TypeOf(v), "", &fields)
+			if len(fields) > 0 {
+				return &UnknownFieldsError{Fields: fields}
+			}
+		}
+	}
+
+	if err := yaml.Unmarshal(data, v); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package iso9001
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestOrganizationYAMLRoundTrip(t *testing.T) {
+	org := CreateExampleOrganization()
+	var buf bytes.Buffer
+	if err := SaveOrganizationToYAML(&buf, org); err != nil {
+		t.Fatalf("Failed to save organization: %v", err)
+	}
+	if !strings.HasPrefix(buf.String(), "schema_version: 1\n") {
+		t.Errorf("Expected schema version in YAML, got:\n%s", buf.String())
+	}
+
+	loaded, err := LoadOrganizationFromYAMLStrict(buf.Bytes())
+	if err != nil {
+		t.Fatalf("Failed to load organization: %v", err)
+	}
+	var again bytes.Buffer
+	SaveOrganizationToYAML(&again, loaded)
+	if again.String() != buf.String() {
+		t.Errorf("Organization changed in the YAML round trip:\nwant %s\ngot  %s", buf.String(), again.String())
+	}
+	if !loaded.QMS.Objectives[0].Timeline.TargetDate.Equal(org.QMS.Objectives[0].Timeline.TargetDate) {
+		t.Error("Expected timestamps to survive the round trip")
+	}
+}
+
+func TestLoadOrganizationFromYAMLHandWritten(t *testing.T) {
+	data := []byte(`
+id: ACME
+name: Acme Ltd
+leadership:
+  quality_policy:
+    statement: We deliver on time
+    last_reviewed: 2024-03-01T00:00:00Z
+`)
+	org, err := LoadOrganizationFromYAML(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if org.Name != "Acme Ltd" || org.SchemaVersion != CurrentSchemaVersion {
+		t.Errorf("Unexpected organization %+v", org)
+	}
+
+	_, err = LoadOrganizationFromYAMLStrict([]byte("id: ACME\nnmae: Acme Ltd\nleadership:\n  quality_polcy: {}\n"))
+	var unknown *UnknownFieldsError
+	if !errors.As(err, &unknown) || len(unknown.Fields) != 2 || unknown.Fields[0].Suggestion != "quality_policy" || unknown.Fields[1].Path != "nmae" {
+		t.Errorf("Expected misspelled keys to be reported, got %v", err)
+	}
+}
+
+func TestTenantYAMLRoundTrip(t *testing.T) {
+	tenant := NewTenant("acme")
+	tenant.Organization = CreateExampleOrganization()
+	tenant.Documents.AddDocument(&DocumentedInformation{ID: "QP-001", Title: "Quality Policy", Content: "Policy", Metadata: DocumentMetadata{ReviewFrequency: 365 * 24 * time.Hour, Keywords: []string{"policy"}}})
+	tenant.Risks.IdentifyRisk(&Risk{ID: "R-001", Description: "Supplier delay", Likelihood: RiskLevelMedium, Impact: RiskLevelHigh})
+	tenant.Risks.SetReviewFrequency("R-001", 90*24*time.Hour)
+	tenant.Objectives.CreateObjective(&QualityObjective{ID: "OBJ-001", Name: "On-time delivery", Description: "Deliver 95% of orders on time"})
+	tenant.Audits.CreateAudit(&Audit{ID: "AUD-001", Title: "Purchasing audit"})
+
+	var buf bytes.Buffer
+	if err := SaveTenantToYAML(&buf, tenant); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "review_frequency: 2160h0m0s") {
+		t.Errorf("Expected durations to be written readably")
+	}
+
+	loaded, err := LoadTenantFromYAML(buf.Bytes())
+	if err != nil {
+		t.Fatalf("Failed to load tenant: %v", err)
+	}
+	var again bytes.Buffer
+	SaveTenantToYAML(&again, loaded)
+	if again.String() != buf.String() {
+		t.Errorf("Tenant changed in the YAML round trip:\nwant %s\ngot  %s", buf.String(), again.String())
+	}
+	if err := loaded.Documents.AddDocument(&DocumentedInformation{ID: "R-001", Title: "Clash"}); err == nil {
+		t.Error("Expected loaded tenant to share its ID registry")
+	}
+
+	var risks bytes.Buffer
+	if err := SaveYAML(&risks, tenant.Risks); err != nil {
+		t.Fatal(err)
+	}
+	rm, err := LoadRiskManagerFromYAML(risks.Bytes())
+	if err != nil || rm.Risks["R-001"].ReviewFrequency != 90*24*time.Hour {
+		t.Errorf("Expected risk manager to load from YAML, got %v", err)
+	}
+	if dm, err := LoadDocumentationManagerFromYAML([]byte("documents:\n  QP-001:\n    id: QP-001\n    title: Quality Policy\n")); err != nil || dm.Documents["QP-001"] == nil || dm.Attachments == nil {
+		t.Errorf("Expected documentation manager to load from YAML, got %v", err)
+	}
+}